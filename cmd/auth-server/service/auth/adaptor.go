@@ -67,6 +67,28 @@ func AdaptAuthOptions(a *meta.ResourceAttribute) (client.ActionID, []client.Reso
 		return genNetworkInterfaceResource(a)
 	case meta.Eip:
 		return genEipResource(a)
+	case meta.LoadBalancer:
+		return genLoadBalancerResource(a)
+	case meta.NatGateway:
+		return genNatGatewayResource(a)
+	case meta.Snapshot:
+		return genSnapshotResource(a)
+	case meta.SnapshotPolicy:
+		return genSnapshotPolicyResource(a)
+	case meta.KeyPair:
+		return genKeyPairResource(a)
+	case meta.CloudKeyPair:
+		return genCloudKeyPairResource(a)
+	case meta.Bucket:
+		return genBucketResource(a)
+	case meta.VpcPeering:
+		return genVpcPeeringResource(a)
+	case meta.CidrPool:
+		return genCidrPoolResource(a)
+	case meta.SecurityGroupTemplate:
+		return genSGTemplateResource(a)
+	case meta.Image:
+		return genImageResource(a)
 	case meta.CloudResource:
 		return genCloudResResource(a)
 	case meta.Quota:
@@ -404,6 +404,95 @@ func genEipResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resour
 	}
 }
 
+// genLoadBalancerResource generate load balancer's related iam resource.
+func genLoadBalancerResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
+// genNatGatewayResource generate nat gateway's related iam resource.
+func genNatGatewayResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
+// genSnapshotResource generate disk snapshot's related iam resource.
+func genSnapshotResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
+// genSnapshotPolicyResource generate snapshot policy's related iam resource, snapshot policy belongs to biz and is
+// not related to any account, so it is only operated in biz.
+func genSnapshotPolicyResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	if a.BizID <= 0 {
+		return "", nil, errf.New(errf.InvalidParameter, "biz id is required")
+	}
+
+	return genBizIaaSResResource(a)
+}
+
+// genKeyPairResource generate hcm managed ssh key pair's related iam resource, key pair is imported into biz and
+// pushed to cloud on demand, so it is only operated in biz.
+func genKeyPairResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	if a.BizID <= 0 {
+		return "", nil, errf.New(errf.InvalidParameter, "biz id is required")
+	}
+
+	return genBizIaaSResResource(a)
+}
+
+// genCloudKeyPairResource generate cloud key pair's related iam resource.
+func genCloudKeyPairResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
+// genBucketResource generate object storage bucket's related iam resource.
+func genBucketResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
+// genVpcPeeringResource generate vpc peering's related iam resource.
+func genVpcPeeringResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
+// genImageResource generate private image's related iam resource.
+func genImageResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
+// genCidrPoolResource generate ipam cidr pool's related iam resource, cidr pool is not related to any account,
+// so find action uses resource find permission and other actions use iaas resource permission of any account.
+func genCidrPoolResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find:
+		return sys.ResourceFind, make([]client.Resource, 0), nil
+	case meta.Create:
+		return sys.IaaSResourceCreate, make([]client.Resource, 0), nil
+	case meta.Update:
+		return sys.IaaSResourceOperate, make([]client.Resource, 0), nil
+	case meta.Delete:
+		return sys.IaaSResourceDelete, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genSGTemplateResource generate security group template's related iam resource, template is not related to any
+// account, applying template to security groups is authorized by security group separately.
+func genSGTemplateResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find:
+		return sys.ResourceFind, make([]client.Resource, 0), nil
+	case meta.Create:
+		return sys.IaaSResourceCreate, make([]client.Resource, 0), nil
+	case meta.Update:
+		return sys.IaaSResourceOperate, make([]client.Resource, 0), nil
+	case meta.Delete:
+		return sys.IaaSResourceDelete, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
 // genCloudResResource generate all cloud resource related iam resource.
 func genCloudResResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
 	res := client.Resource{
@@ -452,7 +541,7 @@ func genProxyResourceFind(a *meta.ResourceAttribute) (client.ActionID, []client.
 // genCostManageResource generate cost manage related iam resource.
 func genCostManageResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
 	switch a.Basic.Action {
-	case meta.Find:
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
 		return sys.CostManage, make([]client.Resource, 0), nil
 	default:
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
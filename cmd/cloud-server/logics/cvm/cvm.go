@@ -23,6 +23,7 @@ package cvm
 import (
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/logics/eip"
+	cscvm "hcm/pkg/api/cloud-server/cvm"
 	"hcm/pkg/api/core"
 	"hcm/pkg/client"
 	"hcm/pkg/dal/dao/types"
@@ -34,6 +35,7 @@ type Interface interface {
 	BatchStopCvm(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (*core.BatchOperateResult, error)
 	BatchDeleteCvm(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (*core.BatchOperateResult, error)
 	DeleteRecycledCvm(kt *kit.Kit, infoMap map[string]types.CloudResourceBasicInfo) (*core.BatchOperateResult, error)
+	ModifyCvm(kt *kit.Kit, info types.CloudResourceBasicInfo, req *cscvm.ModifyCvmReq) error
 }
 
 type cvm struct {
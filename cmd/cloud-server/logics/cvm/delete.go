@@ -20,11 +20,11 @@
 package cvm
 
 import (
+	"hcm/cmd/cloud-server/logics/cvm/lifecycle"
 	"hcm/pkg/api/core"
 	networkinterface "hcm/pkg/api/core/cloud/network-interface"
 	"hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/api/data-service/cloud/eip"
-	hcprotocvm "hcm/pkg/api/hc-service/cvm"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
@@ -33,7 +33,6 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
-	"hcm/pkg/tools/classifier"
 	"hcm/pkg/tools/converter"
 	"hcm/pkg/tools/slice"
 )
@@ -52,129 +51,14 @@ func (c *cvm) BatchDeleteCvm(kt *kit.Kit, basicInfoMap map[string]types.CloudRes
 		return nil, err
 	}
 
-	cvmVendorMap := classifier.ClassifyBasicInfoByVendor(basicInfoMap)
-	successIDs := make([]string, 0)
-	for vendor, infos := range cvmVendorMap {
-		switch vendor {
-		case enumor.TCloud, enumor.Aws, enumor.HuaWei:
-			ids, err := c.batchDeleteCvm(kt, vendor, infos)
-			successIDs = append(successIDs, ids...)
-			if err != nil {
-				return &core.BatchOperateResult{
-					Succeeded: successIDs,
-					Failed: &core.FailedInfo{
-						Error: err,
-					},
-				}, errf.NewFromErr(errf.PartialFailed, err)
-			}
-
-		case enumor.Azure, enumor.Gcp:
-			ids, failedID, err := c.deleteCvm(kt, vendor, infos)
-			successIDs = append(successIDs, ids...)
-			if err != nil {
-				return &core.BatchOperateResult{
-					Succeeded: successIDs,
-					Failed: &core.FailedInfo{
-						ID:    failedID,
-						Error: err,
-					},
-				}, errf.NewFromErr(errf.PartialFailed, err)
-			}
-
-		default:
-			return &core.BatchOperateResult{
-				Succeeded: successIDs,
-				Failed: &core.FailedInfo{
-					ID:    infos[0].ID,
-					Error: errf.Newf(errf.Unknown, "vendor: %s not support", vendor),
-				},
-			}, errf.Newf(errf.Unknown, "vendor: %s not support", vendor)
-		}
-
+	res, err := lifecycle.BatchOperate(kt, c.client.HCService(), lifecycle.Delete, basicInfoMap)
+	if err != nil {
+		return res, err
 	}
 
 	return nil, nil
 }
 
-func (c *cvm) deleteCvm(kt *kit.Kit, vendor enumor.Vendor, infoMap []types.CloudResourceBasicInfo) (
-	[]string, string, error) {
-
-	successIDs := make([]string, 0)
-	for _, one := range infoMap {
-		switch vendor {
-		case enumor.Gcp:
-			if err := c.client.HCService().Gcp.Cvm.DeleteCvm(kt.Ctx, kt.Header(), one.ID); err != nil {
-				return successIDs, one.ID, err
-			}
-
-		case enumor.Azure:
-			req := &hcprotocvm.AzureDeleteReq{
-				Force: true,
-			}
-			if err := c.client.HCService().Azure.Cvm.DeleteCvm(kt.Ctx, kt.Header(), one.ID, req); err != nil {
-				return successIDs, one.ID, err
-			}
-
-		default:
-			return successIDs, one.ID, errf.Newf(errf.Unknown, "vendor: %s not support", vendor)
-		}
-	}
-
-	return successIDs, "", nil
-}
-
-// batchDeleteCvm delete cvm.
-func (c *cvm) batchDeleteCvm(kt *kit.Kit, vendor enumor.Vendor, infoMap []types.CloudResourceBasicInfo) (
-	[]string, error) {
-
-	cvmMap := classifier.ClassifyBasicInfoByAccount(infoMap)
-	successIDs := make([]string, 0)
-	for accountID, reginMap := range cvmMap {
-		for region, ids := range reginMap {
-			switch vendor {
-			case enumor.TCloud:
-				req := &hcprotocvm.TCloudBatchDeleteReq{
-					AccountID: accountID,
-					Region:    region,
-					IDs:       ids,
-				}
-				if err := c.client.HCService().TCloud.Cvm.BatchDeleteCvm(kt.Ctx, kt.Header(), req); err != nil {
-					return successIDs, err
-				}
-
-			case enumor.Aws:
-				req := &hcprotocvm.AwsBatchDeleteReq{
-					AccountID: accountID,
-					Region:    region,
-					IDs:       ids,
-				}
-				if err := c.client.HCService().Aws.Cvm.BatchDeleteCvm(kt.Ctx, kt.Header(), req); err != nil {
-					return successIDs, err
-				}
-
-			case enumor.HuaWei:
-				req := &hcprotocvm.HuaWeiBatchDeleteReq{
-					AccountID:      accountID,
-					Region:         region,
-					IDs:            ids,
-					DeletePublicIP: true,
-					DeleteDisk:     true,
-				}
-				if err := c.client.HCService().HuaWei.Cvm.BatchDeleteCvm(kt.Ctx, kt.Header(), req); err != nil {
-					return successIDs, err
-				}
-
-			default:
-				return successIDs, errf.Newf(errf.Unknown, "vendor: %s not support", vendor)
-			}
-
-			successIDs = append(successIDs, ids...)
-		}
-	}
-
-	return successIDs, nil
-}
-
 // DeleteRecycledCvm batch delete recycled cvm.
 func (c *cvm) DeleteRecycledCvm(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (
 	*core.BatchOperateResult, error) {
@@ -0,0 +1,189 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package lifecycle 主机生命周期操作的厂商注册表，各厂商在 init 中注册自身的开机、关机、重启和删除方法，
+// 主机批量操作通过注册表查找厂商，新增厂商不需要修改这些流程。
+package lifecycle
+
+import (
+	"fmt"
+	"sync"
+
+	"hcm/pkg/api/core"
+	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/classifier"
+)
+
+// Operation 主机生命周期操作类型。
+type Operation string
+
+const (
+	// Start 开机
+	Start Operation = "start"
+	// Stop 关机
+	Stop Operation = "stop"
+	// Reboot 重启
+	Reboot Operation = "reboot"
+	// Delete 删除
+	Delete Operation = "delete"
+)
+
+// OperateOption 主机操作参数，按地域批量操作的厂商 IDs 为同一账号同一地域下的主机，否则只有一台主机。
+type OperateOption struct {
+	AccountID string
+	Region    string
+	IDs       []string
+}
+
+// OperateFunc 调用 hc-service 操作主机。
+type OperateFunc func(kt *kit.Kit, cli *hcservice.Client, opt *OperateOption) error
+
+// Handler 厂商的主机生命周期操作方法，均为必填。
+type Handler struct {
+	// BatchByRegion 是否按账号和地域批量操作，为 false 时逐台操作
+	BatchByRegion bool
+	Start         OperateFunc
+	Stop          OperateFunc
+	Reboot        OperateFunc
+	Delete        OperateFunc
+}
+
+func (h Handler) get(op Operation) OperateFunc {
+	switch op {
+	case Start:
+		return h.Start
+	case Stop:
+		return h.Stop
+	case Reboot:
+		return h.Reboot
+	case Delete:
+		return h.Delete
+	default:
+		return nil
+	}
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = make(map[enumor.Vendor]Handler)
+)
+
+// Register register vendor cvm lifecycle handler, it is called in init function of the vendor file,
+// register the same vendor twice will panic.
+func Register(vendor enumor.Vendor, handler Handler) {
+	if len(vendor) == 0 || handler.Start == nil || handler.Stop == nil || handler.Reboot == nil ||
+		handler.Delete == nil {
+		panic("register cvm lifecycle vendor and all operate funcs are required")
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if _, exist := registry[vendor]; exist {
+		panic(fmt.Sprintf("vendor: %s cvm lifecycle handler is already registered", vendor))
+	}
+	registry[vendor] = handler
+}
+
+// Get returns cvm lifecycle handler of the vendor.
+func Get(vendor enumor.Vendor) (Handler, error) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	handler, exist := registry[vendor]
+	if !exist {
+		return Handler{}, errf.Newf(errf.Unknown, "vendor: %s not support", vendor)
+	}
+
+	return handler, nil
+}
+
+// BatchOperate 按厂商注册的方法批量操作主机，部分失败时返回操作结果。
+func BatchOperate(kt *kit.Kit, cli *hcservice.Client, op Operation,
+	basicInfoMap map[string]types.CloudResourceBasicInfo) (*core.BatchOperateResult, error) {
+
+	cvmVendorMap := classifier.ClassifyBasicInfoByVendor(basicInfoMap)
+	successIDs := make([]string, 0)
+	for vendor, infos := range cvmVendorMap {
+		handler, err := Get(vendor)
+		if err != nil {
+			return &core.BatchOperateResult{
+				Succeeded: successIDs,
+				Failed: &core.FailedInfo{
+					ID:    infos[0].ID,
+					Error: err,
+				},
+			}, err
+		}
+
+		ids, failedID, err := operate(kt, cli, handler, op, infos)
+		successIDs = append(successIDs, ids...)
+		if err != nil {
+			return &core.BatchOperateResult{
+				Succeeded: successIDs,
+				Failed: &core.FailedInfo{
+					ID:    failedID,
+					Error: err,
+				},
+			}, errf.NewFromErr(errf.PartialFailed, err)
+		}
+	}
+
+	return nil, nil
+}
+
+// operate 操作同一厂商的主机，返回操作成功的主机 ID，逐台操作失败时同时返回失败的主机 ID。
+func operate(kt *kit.Kit, cli *hcservice.Client, handler Handler, op Operation,
+	infos []types.CloudResourceBasicInfo) ([]string, string, error) {
+
+	operateFunc := handler.get(op)
+	if operateFunc == nil {
+		return nil, "", errf.Newf(errf.InvalidParameter, "cvm operation: %s not support", op)
+	}
+
+	successIDs := make([]string, 0, len(infos))
+	if !handler.BatchByRegion {
+		for _, one := range infos {
+			opt := &OperateOption{AccountID: one.AccountID, Region: one.Region, IDs: []string{one.ID}}
+			if err := operateFunc(kt, cli, opt); err != nil {
+				return successIDs, one.ID, err
+			}
+			successIDs = append(successIDs, one.ID)
+		}
+
+		return successIDs, "", nil
+	}
+
+	cvmMap := classifier.ClassifyBasicInfoByAccount(infos)
+	for accountID, regionMap := range cvmMap {
+		for region, ids := range regionMap {
+			opt := &OperateOption{AccountID: accountID, Region: region, IDs: ids}
+			if err := operateFunc(kt, cli, opt); err != nil {
+				return successIDs, "", err
+			}
+			successIDs = append(successIDs, ids...)
+		}
+	}
+
+	return successIDs, "", nil
+}
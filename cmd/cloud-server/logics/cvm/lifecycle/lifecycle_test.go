@@ -0,0 +1,118 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package lifecycle
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+)
+
+func TestVendorsRegistered(t *testing.T) {
+	vendors := []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei, enumor.Gcp, enumor.Azure, enumor.OpenStack}
+	for _, vendor := range vendors {
+		if _, err := Get(vendor); err != nil {
+			t.Errorf("vendor %s cvm lifecycle handler expect registered, but not", vendor)
+		}
+	}
+}
+
+func TestOperateBatchByRegion(t *testing.T) {
+	calls := make([]OperateOption, 0)
+	handler := Handler{
+		BatchByRegion: true,
+		Stop: func(kt *kit.Kit, cli *hcservice.Client, opt *OperateOption) error {
+			sort.Strings(opt.IDs)
+			calls = append(calls, *opt)
+			return nil
+		},
+	}
+
+	infos := []types.CloudResourceBasicInfo{
+		{ID: "1", AccountID: "a", Region: "r1"},
+		{ID: "2", AccountID: "a", Region: "r1"},
+		{ID: "3", AccountID: "a", Region: "r2"},
+	}
+	ids, failedID, err := operate(kit.New(), nil, handler, Stop, infos)
+	if err != nil || failedID != "" {
+		t.Fatalf("operate expect no error, but got: %v, failed id: %s", err, failedID)
+	}
+
+	if len(ids) != 3 {
+		t.Errorf("operate expect 3 success ids, but got: %v", ids)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("operate expect called once per region, but got: %v", calls)
+	}
+
+	for _, call := range calls {
+		if call.Region == "r1" && len(call.IDs) != 2 {
+			t.Errorf("region r1 expect 2 cvms in one call, but got: %v", call.IDs)
+		}
+	}
+}
+
+func TestOperateOneByOne(t *testing.T) {
+	handler := Handler{
+		Reboot: func(kt *kit.Kit, cli *hcservice.Client, opt *OperateOption) error {
+			if len(opt.IDs) != 1 {
+				t.Errorf("operate one by one expect 1 cvm in one call, but got: %v", opt.IDs)
+			}
+
+			if opt.IDs[0] == "2" {
+				return errors.New("reboot failed")
+			}
+			return nil
+		},
+	}
+
+	infos := []types.CloudResourceBasicInfo{{ID: "1", AccountID: "a"}, {ID: "2", AccountID: "a"},
+		{ID: "3", AccountID: "a"}}
+	ids, failedID, err := operate(kit.New(), nil, handler, Reboot, infos)
+	if err == nil {
+		t.Fatalf("operate expect error, but not")
+	}
+
+	if failedID != "2" {
+		t.Errorf("operate expect failed id 2, but got: %s", failedID)
+	}
+
+	if len(ids) != 1 || ids[0] != "1" {
+		t.Errorf("operate expect success ids [1], but got: %v", ids)
+	}
+}
+
+func TestBatchOperateUnknownVendor(t *testing.T) {
+	infoMap := map[string]types.CloudResourceBasicInfo{"1": {ID: "1", Vendor: "unknown", AccountID: "a"}}
+	res, err := BatchOperate(kit.New(), nil, Start, infoMap)
+	if err == nil {
+		t.Fatalf("batch operate unknown vendor expect error, but not")
+	}
+
+	if res == nil || res.Failed == nil || res.Failed.ID != "1" {
+		t.Errorf("batch operate unknown vendor expect failed id 1, but got: %+v", res)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package lifecycle
+
+import (
+	hcprotocvm "hcm/pkg/api/hc-service/cvm"
+	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+func init() {
+	Register(enumor.OpenStack, Handler{
+		BatchByRegion: true,
+		Start: func(kt *kit.Kit, cli *hcservice.Client, opt *OperateOption) error {
+			req := &hcprotocvm.OpenStackBatchOperateReq{
+				AccountID: opt.AccountID,
+				Region:    opt.Region,
+				IDs:       opt.IDs,
+			}
+			return cli.OpenStack.Cvm.BatchStartCvm(kt.Ctx, kt.Header(), req)
+		},
+		Stop: func(kt *kit.Kit, cli *hcservice.Client, opt *OperateOption) error {
+			req := &hcprotocvm.OpenStackBatchOperateReq{
+				AccountID: opt.AccountID,
+				Region:    opt.Region,
+				IDs:       opt.IDs,
+			}
+			return cli.OpenStack.Cvm.BatchStopCvm(kt.Ctx, kt.Header(), req)
+		},
+		Reboot: func(kt *kit.Kit, cli *hcservice.Client, opt *OperateOption) error {
+			req := &hcprotocvm.OpenStackBatchRebootReq{
+				AccountID: opt.AccountID,
+				Region:    opt.Region,
+				IDs:       opt.IDs,
+				Force:     true,
+			}
+			return cli.OpenStack.Cvm.BatchRebootCvm(kt.Ctx, kt.Header(), req)
+		},
+		Delete: func(kt *kit.Kit, cli *hcservice.Client, opt *OperateOption) error {
+			req := &hcprotocvm.OpenStackBatchOperateReq{
+				AccountID: opt.AccountID,
+				Region:    opt.Region,
+				IDs:       opt.IDs,
+			}
+			return cli.OpenStack.Cvm.BatchDeleteCvm(kt.Ctx, kt.Header(), req)
+		},
+	})
+}
@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	hcprotocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ModifyCvm 对主机进行原地变更（变更机型、扩容云盘、重命名、变更安全组），并记录更新审计。
+func (c *cvm) ModifyCvm(kt *kit.Kit, info types.CloudResourceBasicInfo, req *cscvm.ModifyCvmReq) error {
+	if err := req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch info.Vendor {
+	case enumor.TCloud, enumor.Aws, enumor.HuaWei:
+	default:
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support modify cvm", info.Vendor)
+	}
+
+	if err := c.audit.ResUpdateAudit(kt, enumor.CvmAuditResType, info.ID, req.UpdateFields()); err != nil {
+		logs.Errorf("create update audit failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	var err error
+	switch req.Action {
+	case cscvm.ChangeInstanceType:
+		err = c.changeInstanceType(kt, info, req)
+	case cscvm.ResizeDisk:
+		err = c.resizeDisk(kt, info, req)
+	case cscvm.Rename:
+		err = c.rename(kt, info, req)
+	case cscvm.ChangeSecurityGroup:
+		err = c.changeSecurityGroup(kt, info, req)
+	default:
+		err = errf.Newf(errf.InvalidParameter, "unsupported modify cvm action: %s", req.Action)
+	}
+	if err != nil {
+		logs.Errorf("modify %s cvm failed, err: %v, id: %s, action: %s, rid: %s", info.Vendor, err, info.ID,
+			req.Action, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (c *cvm) changeInstanceType(kt *kit.Kit, info types.CloudResourceBasicInfo, req *cscvm.ModifyCvmReq) error {
+	hcReq := &hcprotocvm.ChangeInstanceTypeReq{InstanceType: req.InstanceType}
+
+	switch info.Vendor {
+	case enumor.TCloud:
+		return c.client.HCService().TCloud.Cvm.ChangeInstanceType(kt.Ctx, kt.Header(), info.ID, hcReq)
+	case enumor.Aws:
+		return c.client.HCService().Aws.Cvm.ChangeInstanceType(kt.Ctx, kt.Header(), info.ID, hcReq)
+	case enumor.HuaWei:
+		return c.client.HCService().HuaWei.Cvm.ChangeInstanceType(kt.Ctx, kt.Header(), info.ID, hcReq)
+	default:
+		return errf.Newf(errf.Unknown, "vendor: %s not support", info.Vendor)
+	}
+}
+
+func (c *cvm) resizeDisk(kt *kit.Kit, info types.CloudResourceBasicInfo, req *cscvm.ModifyCvmReq) error {
+	hcReq := &hcprotocvm.ResizeDiskReq{DiskID: req.DiskID, DiskSize: req.DiskSize}
+
+	switch info.Vendor {
+	case enumor.TCloud:
+		return c.client.HCService().TCloud.Cvm.ResizeDisk(kt.Ctx, kt.Header(), info.ID, hcReq)
+	case enumor.Aws:
+		return c.client.HCService().Aws.Cvm.ResizeDisk(kt.Ctx, kt.Header(), info.ID, hcReq)
+	case enumor.HuaWei:
+		return c.client.HCService().HuaWei.Cvm.ResizeDisk(kt.Ctx, kt.Header(), info.ID, hcReq)
+	default:
+		return errf.Newf(errf.Unknown, "vendor: %s not support", info.Vendor)
+	}
+}
+
+func (c *cvm) rename(kt *kit.Kit, info types.CloudResourceBasicInfo, req *cscvm.ModifyCvmReq) error {
+	hcReq := &hcprotocvm.RenameReq{Name: req.Name}
+
+	switch info.Vendor {
+	case enumor.TCloud:
+		return c.client.HCService().TCloud.Cvm.Rename(kt.Ctx, kt.Header(), info.ID, hcReq)
+	case enumor.Aws:
+		return c.client.HCService().Aws.Cvm.Rename(kt.Ctx, kt.Header(), info.ID, hcReq)
+	case enumor.HuaWei:
+		return c.client.HCService().HuaWei.Cvm.Rename(kt.Ctx, kt.Header(), info.ID, hcReq)
+	default:
+		return errf.Newf(errf.Unknown, "vendor: %s not support", info.Vendor)
+	}
+}
+
+func (c *cvm) changeSecurityGroup(kt *kit.Kit, info types.CloudResourceBasicInfo, req *cscvm.ModifyCvmReq) error {
+	hcReq := &hcprotocvm.ChangeSecurityGroupReq{SecurityGroupIDs: req.SecurityGroupIDs}
+
+	switch info.Vendor {
+	case enumor.TCloud:
+		return c.client.HCService().TCloud.Cvm.ChangeSecurityGroup(kt.Ctx, kt.Header(), info.ID, hcReq)
+	case enumor.Aws:
+		return c.client.HCService().Aws.Cvm.ChangeSecurityGroup(kt.Ctx, kt.Header(), info.ID, hcReq)
+	case enumor.HuaWei:
+		return c.client.HCService().HuaWei.Cvm.ChangeSecurityGroup(kt.Ctx, kt.Header(), info.ID, hcReq)
+	default:
+		return errf.Newf(errf.Unknown, "vendor: %s not support", info.Vendor)
+	}
+}
@@ -20,16 +20,13 @@
 package cvm
 
 import (
-	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/cmd/cloud-server/logics/cvm/lifecycle"
 	"hcm/pkg/api/core"
 	protoaudit "hcm/pkg/api/data-service/audit"
-	hcprotocvm "hcm/pkg/api/hc-service/cvm"
 	"hcm/pkg/criteria/enumor"
-	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
-	"hcm/pkg/tools/classifier"
 )
 
 // BatchStopCvm batch stop cvm.
@@ -50,128 +47,10 @@ func (c *cvm) BatchStopCvm(kt *kit.Kit, basicInfoMap map[string]types.CloudResou
 		return nil, err
 	}
 
-	cvmVendorMap := classifier.ClassifyBasicInfoByVendor(basicInfoMap)
-	successIDs := make([]string, 0)
-	for vendor, infos := range cvmVendorMap {
-		switch vendor {
-		case enumor.TCloud, enumor.Aws, enumor.HuaWei:
-			ids, err := c.batchStopCvm(kt, vendor, infos)
-			successIDs = append(successIDs, ids...)
-			if err != nil {
-				return &core.BatchOperateResult{
-					Succeeded: successIDs,
-					Failed: &core.FailedInfo{
-						Error: err,
-					},
-				}, errf.NewFromErr(errf.PartialFailed, err)
-			}
-
-		case enumor.Azure, enumor.Gcp:
-			ids, failedID, err := c.stopCvm(kt, vendor, infos)
-			successIDs = append(successIDs, ids...)
-			if err != nil {
-				return &core.BatchOperateResult{
-					Succeeded: successIDs,
-					Failed: &core.FailedInfo{
-						ID:    failedID,
-						Error: err,
-					},
-				}, errf.NewFromErr(errf.PartialFailed, err)
-			}
-
-		default:
-			return &core.BatchOperateResult{
-				Succeeded: successIDs,
-				Failed: &core.FailedInfo{
-					ID:    infos[0].ID,
-					Error: errf.Newf(errf.Unknown, "vendor: %s not support", vendor),
-				},
-			}, errf.Newf(errf.Unknown, "vendor: %s not support", vendor)
-		}
-
+	res, err := lifecycle.BatchOperate(kt, c.client.HCService(), lifecycle.Stop, basicInfoMap)
+	if err != nil {
+		return res, err
 	}
 
 	return nil, nil
 }
-
-func (c *cvm) stopCvm(kt *kit.Kit, vendor enumor.Vendor, infoMap []types.CloudResourceBasicInfo) (
-	[]string, string, error) {
-
-	successIDs := make([]string, 0)
-	for _, one := range infoMap {
-		switch vendor {
-		case enumor.Gcp:
-			if err := c.client.HCService().Gcp.Cvm.StopCvm(kt.Ctx, kt.Header(), one.ID); err != nil {
-				return successIDs, one.ID, err
-			}
-
-		case enumor.Azure:
-			req := &hcprotocvm.AzureStopReq{
-				SkipShutdown: false,
-			}
-			if err := c.client.HCService().Azure.Cvm.StopCvm(kt.Ctx, kt.Header(), one.ID, req); err != nil {
-				return successIDs, one.ID, err
-			}
-
-		default:
-			return successIDs, one.ID, errf.Newf(errf.Unknown, "vendor: %s not support", vendor)
-		}
-	}
-
-	return successIDs, "", nil
-}
-
-// batchStopCvm stop cvm.
-func (c *cvm) batchStopCvm(kt *kit.Kit, vendor enumor.Vendor, infoMap []types.CloudResourceBasicInfo) (
-	[]string, error) {
-
-	cvmMap := classifier.ClassifyBasicInfoByAccount(infoMap)
-	successIDs := make([]string, 0)
-	for accountID, reginMap := range cvmMap {
-		for region, ids := range reginMap {
-			switch vendor {
-			case enumor.TCloud:
-				req := &hcprotocvm.TCloudBatchStopReq{
-					AccountID:   accountID,
-					Region:      region,
-					IDs:         ids,
-					StopType:    typecvm.SoftFirst,
-					StoppedMode: typecvm.KeepCharging,
-				}
-				if err := c.client.HCService().TCloud.Cvm.BatchStopCvm(kt.Ctx, kt.Header(), req); err != nil {
-					return successIDs, err
-				}
-
-			case enumor.Aws:
-				req := &hcprotocvm.AwsBatchStopReq{
-					AccountID: accountID,
-					Region:    region,
-					IDs:       ids,
-					Force:     true,
-					Hibernate: false,
-				}
-				if err := c.client.HCService().Aws.Cvm.BatchStopCvm(kt.Ctx, kt.Header(), req); err != nil {
-					return successIDs, err
-				}
-
-			case enumor.HuaWei:
-				req := &hcprotocvm.HuaWeiBatchStopReq{
-					AccountID: accountID,
-					Region:    region,
-					IDs:       ids,
-					Force:     true,
-				}
-				if err := c.client.HCService().HuaWei.Cvm.BatchStopCvm(kt.Ctx, kt.Header(), req); err != nil {
-					return successIDs, err
-				}
-
-			default:
-				return successIDs, errf.Newf(errf.Unknown, "vendor: %s not support", vendor)
-			}
-
-			successIDs = append(successIDs, ids...)
-		}
-	}
-
-	return successIDs, nil
-}
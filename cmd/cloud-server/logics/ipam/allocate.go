@@ -0,0 +1,245 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	"net"
+
+	"hcm/pkg/api/core"
+	coreipam "hcm/pkg/api/core/cloud/ipam"
+	dataservice "hcm/pkg/api/data-service"
+	protoipam "hcm/pkg/api/data-service/cloud/ipam"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/cidr"
+	"hcm/pkg/tools/slice"
+)
+
+// allocateRetryTimes data-service在分配时锁定网段池并校验与已分配网段是否重叠，并发分配时后分配的重叠网段
+// 会被拒绝，此时重新计算可用网段后重试
+const allocateRetryTimes = 3
+
+// AllocateCidr reserve a cidr from cidr pool, the cidr is kept until released.
+func (i *ipam) AllocateCidr(kt *kit.Kit, opt *AllocateOption) (*coreipam.CidrAllocation, error) {
+	return i.allocate(kt, opt, enumor.CidrReserved)
+}
+
+// CreateVpc allocate a cidr from cidr pool and create vpc with it.
+func (i *ipam) CreateVpc(kt *kit.Kit, opt *AllocateOption, create CreateVpcFunc) error {
+	if opt == nil {
+		_, err := create("")
+		return err
+	}
+
+	allocation, err := i.allocate(kt, opt, enumor.CidrReserved)
+	if err != nil {
+		return err
+	}
+
+	vpcID, err := create(allocation.Cidr)
+	if err != nil {
+		if releaseErr := i.ReleaseCidr(kt, []string{allocation.ID}); releaseErr != nil {
+			logs.Errorf("release cidr %s of failed vpc failed, err: %v, allocation: %s, rid: %s", allocation.Cidr,
+				releaseErr, allocation.ID, kt.Rid)
+		}
+		return err
+	}
+
+	updateReq := &protoipam.CidrAllocationBatchUpdateReq{
+		Allocations: []protoipam.CidrAllocationUpdateReq{{
+			ID:     allocation.ID,
+			Status: enumor.CidrAllocated,
+			VpcID:  vpcID,
+		}},
+	}
+	if err = i.client.DataService().Global.Ipam.BatchUpdateCidrAllocation(kt.Ctx, kt.Header(), updateReq); err != nil {
+		// vpc已经创建成功，网段保持预留状态，不影响vpc创建结果
+		logs.Errorf("bind cidr allocation %s to vpc %s failed, err: %v, rid: %s", allocation.ID, vpcID, err,
+			kt.Rid)
+	}
+
+	return nil
+}
+
+// allocate the first available cidr with mask length from cidr pool.
+func (i *ipam) allocate(kt *kit.Kit, opt *AllocateOption, status enumor.CidrAllocationStatus) (
+	*coreipam.CidrAllocation, error) {
+
+	pool, err := i.getCidrPool(kt, opt.PoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkPoolScope(pool, opt); err != nil {
+		return nil, err
+	}
+
+	_, poolNet, err := net.ParseCIDR(pool.IPv4Cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	for retry := 0; ; retry++ {
+		used, err := i.poolUsedNets(kt, pool)
+		if err != nil {
+			return nil, err
+		}
+
+		next, err := cidr.FirstAvailableNet(*poolNet, used, opt.MaskLen)
+		if err != nil {
+			logs.Errorf("cidr pool %s has no available cidr, err: %v, mask len: %d, rid: %s", pool.ID, err,
+				opt.MaskLen, kt.Rid)
+			return nil, errf.Newf(errf.InvalidParameter, "cidr pool %s(%s) has no available cidr with mask length %d",
+				pool.Name, pool.IPv4Cidr, opt.MaskLen)
+		}
+
+		createReq := &protoipam.CidrAllocationBatchCreateReq{
+			Allocations: []protoipam.CidrAllocationCreateReq{{
+				PoolID:    pool.ID,
+				Cidr:      next.String(),
+				Status:    status,
+				Vendor:    opt.Vendor,
+				AccountID: opt.AccountID,
+				Region:    opt.Region,
+				Memo:      opt.Memo,
+			}},
+		}
+		result, err := i.client.DataService().Global.Ipam.BatchCreateCidrAllocation(kt.Ctx, kt.Header(), createReq)
+		if err != nil {
+			if retry+1 < allocateRetryTimes {
+				logs.Warnf("create cidr allocation failed, retry, err: %v, cidr: %s, rid: %s", err, next.String(),
+					kt.Rid)
+				continue
+			}
+
+			logs.Errorf("create cidr allocation failed, err: %v, cidr: %s, rid: %s", err, next.String(), kt.Rid)
+			return nil, err
+		}
+
+		if len(result.IDs) == 0 {
+			return nil, errf.New(errf.Unknown, "create cidr allocation but return no id")
+		}
+
+		return &coreipam.CidrAllocation{
+			ID:        result.IDs[0],
+			PoolID:    pool.ID,
+			Cidr:      next.String(),
+			Status:    status,
+			Vendor:    opt.Vendor,
+			AccountID: opt.AccountID,
+			Region:    opt.Region,
+			Memo:      opt.Memo,
+		}, nil
+	}
+}
+
+// poolUsedNets returns the nets used in cidr pool, which include cidrs allocated from the pool and cidrs of vpcs
+// created without the pool but overlapped with it.
+func (i *ipam) poolUsedNets(kt *kit.Kit, pool *coreipam.CidrPool) ([]net.IPNet, error) {
+	allocations, err := i.listValidAllocation(kt, []string{pool.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	vpcCidrs, err := i.ListVpcCidr(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	cidrs := make([]string, 0, len(allocations)+len(vpcCidrs))
+	for _, one := range allocations {
+		cidrs = append(cidrs, one.Cidr)
+	}
+	for _, one := range vpcCidrs {
+		cidrs = append(cidrs, one.Cidr)
+	}
+
+	return parseNets(cidrs), nil
+}
+
+// listValidAllocation list cidr allocations of pools, allocations whose vpc has been deleted are released.
+func (i *ipam) listValidAllocation(kt *kit.Kit, poolIDs []string) ([]coreipam.CidrAllocation, error) {
+	allocations, err := i.listPoolAllocation(kt, poolIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcIDs := make([]string, 0)
+	for _, one := range allocations {
+		if one.Status == enumor.CidrAllocated && len(one.VpcID) != 0 {
+			vpcIDs = append(vpcIDs, one.VpcID)
+		}
+	}
+
+	existVpc := make(map[string]bool)
+	for _, ids := range slice.Split(slice.Unique(vpcIDs), int(core.DefaultMaxPageLimit)) {
+		listReq := &core.ListReq{
+			Filter: tools.ContainersExpression("id", ids),
+			Page:   core.NewDefaultBasePage(),
+			Fields: []string{"id"},
+		}
+		result, err := i.client.DataService().Global.Vpc.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list vpc of cidr allocation failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+			return nil, err
+		}
+
+		for _, vpc := range result.Details {
+			existVpc[vpc.ID] = true
+		}
+	}
+
+	valid := make([]coreipam.CidrAllocation, 0, len(allocations))
+	staleIDs := make([]string, 0)
+	for _, one := range allocations {
+		if one.Status == enumor.CidrAllocated && !existVpc[one.VpcID] {
+			staleIDs = append(staleIDs, one.ID)
+			continue
+		}
+		valid = append(valid, one)
+	}
+
+	if len(staleIDs) != 0 {
+		logs.Infof("release cidr allocations whose vpc has been deleted, ids: %v, rid: %s", staleIDs, kt.Rid)
+		if err = i.ReleaseCidr(kt, staleIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return valid, nil
+}
+
+// ReleaseCidr release cidr allocations.
+func (i *ipam) ReleaseCidr(kt *kit.Kit, ids []string) error {
+	for _, batch := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+		delReq := &dataservice.BatchDeleteReq{Filter: tools.ContainersExpression("id", batch)}
+		err := i.client.DataService().Global.Ipam.BatchDeleteCidrAllocation(kt.Ctx, kt.Header(), delReq)
+		if err != nil {
+			logs.Errorf("release cidr allocation failed, err: %v, ids: %v, rid: %s", err, batch, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ipam ...
+package ipam
+
+import (
+	"fmt"
+	"net"
+
+	csipam "hcm/pkg/api/cloud-server/ipam"
+	csvpc "hcm/pkg/api/cloud-server/vpc"
+	"hcm/pkg/api/core"
+	coreipam "hcm/pkg/api/core/cloud/ipam"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Interface define ipam interface.
+type Interface interface {
+	// ListVpcCidr list ipv4 cidrs of synced vpcs of all vendors.
+	ListVpcCidr(kt *kit.Kit) ([]csipam.VpcCidr, error)
+	// AllocateCidr reserve a cidr from cidr pool, the cidr is kept until released.
+	AllocateCidr(kt *kit.Kit, opt *AllocateOption) (*coreipam.CidrAllocation, error)
+	// CreateVpc allocate a cidr from cidr pool and create vpc with it, the cidr is released if create failed,
+	// and bound to the created vpc if create succeeded. vpc is created with empty cidr if opt is nil.
+	CreateVpc(kt *kit.Kit, opt *AllocateOption, create CreateVpcFunc) error
+	// ReleaseCidr release cidr allocations.
+	ReleaseCidr(kt *kit.Kit, ids []string) error
+	// Utilization calculate utilization of cidr pools.
+	Utilization(kt *kit.Kit, pools []coreipam.CidrPool) ([]csipam.CidrPoolUtilization, error)
+}
+
+// CreateVpcFunc create vpc with allocated cidr, returns the id of created vpc.
+type CreateVpcFunc func(cidr string) (string, error)
+
+// VpcAllocateOption returns the option to allocate vpc cidr from cidr pool, returns nil if cidr pool is not used.
+func VpcAllocateOption(opt csvpc.CidrPoolOption, bkBizID int64, vendor enumor.Vendor, accountID,
+	region string) *AllocateOption {
+
+	if !opt.UseCidrPool() {
+		return nil
+	}
+
+	return &AllocateOption{
+		PoolID:    opt.CidrPoolID,
+		MaskLen:   opt.VpcMaskLen,
+		BkBizID:   bkBizID,
+		Vendor:    vendor,
+		AccountID: accountID,
+		Region:    region,
+	}
+}
+
+// AllocateOption define allocate cidr from cidr pool option.
+type AllocateOption struct {
+	PoolID    string
+	MaskLen   int
+	BkBizID   int64
+	Vendor    enumor.Vendor
+	AccountID string
+	Region    string
+	Memo      *string
+}
+
+type ipam struct {
+	client *client.ClientSet
+}
+
+// NewIpam new ipam.
+func NewIpam(client *client.ClientSet) Interface {
+	return &ipam{
+		client: client,
+	}
+}
+
+// getCidrPool get cidr pool by id.
+func (i *ipam) getCidrPool(kt *kit.Kit, id string) (*coreipam.CidrPool, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := i.client.DataService().Global.Ipam.ListCidrPool(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list cidr pool failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "cidr pool %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
+
+// listPoolAllocation list all cidr allocations of cidr pools.
+func (i *ipam) listPoolAllocation(kt *kit.Kit, poolIDs []string) ([]coreipam.CidrAllocation, error) {
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("pool_id", poolIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	allocations := make([]coreipam.CidrAllocation, 0)
+	for {
+		result, err := i.client.DataService().Global.Ipam.ListCidrAllocation(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list cidr allocation failed, err: %v, pool ids: %v, rid: %s", err, poolIDs, kt.Rid)
+			return nil, err
+		}
+
+		allocations = append(allocations, result.Details...)
+
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return allocations, nil
+}
+
+// checkPoolScope check whether the cidr pool can be used by the business and region of allocate option.
+func checkPoolScope(pool *coreipam.CidrPool, opt *AllocateOption) error {
+	if pool.BkBizID != constant.UnassignedBiz && opt.BkBizID != 0 && pool.BkBizID != opt.BkBizID {
+		return errf.Newf(errf.InvalidParameter, "cidr pool %s belongs to biz %d, can not be used by biz %d",
+			pool.ID, pool.BkBizID, opt.BkBizID)
+	}
+
+	if len(pool.Region) != 0 && len(opt.Region) != 0 && pool.Region != opt.Region {
+		return errf.Newf(errf.InvalidParameter, "cidr pool %s belongs to region %s, can not be used in region %s",
+			pool.ID, pool.Region, opt.Region)
+	}
+
+	return nil
+}
+
+// parseNets parse cidrs to ipv4 nets, invalid and ipv6 cidrs are ignored.
+func parseNets(cidrs []string) []net.IPNet {
+	nets := make([]net.IPNet, 0, len(cidrs))
+	for _, one := range cidrs {
+		_, ipNet, err := net.ParseCIDR(one)
+		if err != nil || ipNet.IP.To4() == nil {
+			continue
+		}
+		nets = append(nets, *ipNet)
+	}
+
+	return nets
+}
+
+// FirstSubnetCidr returns the first cidr with mask length in vpc cidr, which is used as the subnet cidr of vpc
+// created by cidr pool.
+func FirstSubnetCidr(vpcCidr string, maskLen int) (string, error) {
+	_, vpcNet, err := net.ParseCIDR(vpcCidr)
+	if err != nil {
+		return "", err
+	}
+
+	ones, bits := vpcNet.Mask.Size()
+	if maskLen < ones || maskLen > bits {
+		return "", fmt.Errorf("subnet mask length %d should be in [%d, %d]", maskLen, ones, bits)
+	}
+
+	subnet := net.IPNet{IP: vpcNet.IP, Mask: net.CIDRMask(maskLen, bits)}
+	return subnet.String(), nil
+}
+
+// GatewayIP returns the first host ip of cidr, which is used as the gateway ip of subnet.
+func GatewayIP(subnetCidr string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(subnetCidr)
+	if err != nil {
+		return "", err
+	}
+
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		return "", fmt.Errorf("cidr %s is not ipv4", subnetCidr)
+	}
+
+	gateway := make(net.IP, len(ip))
+	copy(gateway, ip)
+	gateway[3]++
+	return gateway.String(), nil
+}
@@ -0,0 +1,117 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	"math"
+	"net"
+
+	csipam "hcm/pkg/api/cloud-server/ipam"
+	coreipam "hcm/pkg/api/core/cloud/ipam"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/cidr"
+)
+
+// Utilization calculate utilization of cidr pools.
+func (i *ipam) Utilization(kt *kit.Kit, pools []coreipam.CidrPool) ([]csipam.CidrPoolUtilization, error) {
+	if len(pools) == 0 {
+		return make([]csipam.CidrPoolUtilization, 0), nil
+	}
+
+	poolIDs := make([]string, 0, len(pools))
+	for _, pool := range pools {
+		poolIDs = append(poolIDs, pool.ID)
+	}
+
+	allocations, err := i.listValidAllocation(kt, poolIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	poolAllocations := make(map[string][]coreipam.CidrAllocation)
+	for _, one := range allocations {
+		poolAllocations[one.PoolID] = append(poolAllocations[one.PoolID], one)
+	}
+
+	vpcCidrs, err := i.ListVpcCidr(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]csipam.CidrPoolUtilization, 0, len(pools))
+	for _, pool := range pools {
+		_, poolNet, err := net.ParseCIDR(pool.IPv4Cidr)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, poolUtilization(pool, *poolNet, poolAllocations[pool.ID], vpcCidrs))
+	}
+
+	return result, nil
+}
+
+func poolUtilization(pool coreipam.CidrPool, poolNet net.IPNet, allocations []coreipam.CidrAllocation,
+	vpcCidrs []csipam.VpcCidr) csipam.CidrPoolUtilization {
+
+	allocatedCidrs := make([]string, 0, len(allocations))
+	for _, one := range allocations {
+		allocatedCidrs = append(allocatedCidrs, one.Cidr)
+	}
+	allocatedNets := parseNets(allocatedCidrs)
+
+	vpcIDs := make(map[string]struct{})
+	usedNets := append(make([]net.IPNet, 0, len(allocatedNets)), allocatedNets...)
+	for _, one := range vpcCidrs {
+		_, vpcNet, err := net.ParseCIDR(one.Cidr)
+		if err != nil || vpcNet.IP.To4() == nil || !cidr.NetOverlap(poolNet, *vpcNet) {
+			continue
+		}
+
+		vpcIDs[one.VpcID] = struct{}{}
+		usedNets = append(usedNets, *vpcNet)
+	}
+
+	ones, bits := poolNet.Mask.Size()
+	total := uint64(1) << uint(bits-ones)
+	used := cidr.CoveredIPCount(poolNet, usedNets)
+
+	maxFreeMaskLen := 0
+	for maskLen := ones; maskLen <= bits; maskLen++ {
+		if _, err := cidr.FirstAvailableNet(poolNet, usedNets, maskLen); err == nil {
+			maxFreeMaskLen = maskLen
+			break
+		}
+	}
+
+	return csipam.CidrPoolUtilization{
+		PoolID:           pool.ID,
+		Name:             pool.Name,
+		IPv4Cidr:         pool.IPv4Cidr,
+		TotalIPCount:     total,
+		AllocatedIPCount: cidr.CoveredIPCount(poolNet, allocatedNets),
+		UsedIPCount:      used,
+		FreeIPCount:      total - used,
+		UsageRate:        math.Round(float64(used)/float64(total)*10000) / 10000,
+		AllocationCount:  len(allocations),
+		VpcCount:         len(vpcIDs),
+		MaxFreeMaskLen:   maxFreeMaskLen,
+	}
+}
@@ -0,0 +1,227 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	"context"
+	"net/http"
+
+	csipam "hcm/pkg/api/cloud-server/ipam"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListVpcCidr list ipv4 cidrs of synced vpcs of all vendors, gcp vpc has no cidr, so the cidrs of its subnets
+// are used instead.
+func (i *ipam) ListVpcCidr(kt *kit.Kit) ([]csipam.VpcCidr, error) {
+	ds := i.client.DataService()
+
+	result := make([]csipam.VpcCidr, 0)
+
+	tcloud, err := listVendorVpcCidr(kt, ds.TCloud.Vpc.ListVpcExt, func(ext *corecloud.TCloudVpcExtension) []string {
+		cidrs := make([]string, 0, len(ext.Cidr))
+		for _, one := range ext.Cidr {
+			if one.Type == enumor.Ipv4 {
+				cidrs = append(cidrs, one.Cidr)
+			}
+		}
+		return cidrs
+	})
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, tcloud...)
+
+	aws, err := listVendorVpcCidr(kt, ds.Aws.Vpc.ListVpcExt, func(ext *corecloud.AwsVpcExtension) []string {
+		cidrs := make([]string, 0, len(ext.Cidr))
+		for _, one := range ext.Cidr {
+			if one.Type == enumor.Ipv4 {
+				cidrs = append(cidrs, one.Cidr)
+			}
+		}
+		return cidrs
+	})
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, aws...)
+
+	huawei, err := listVendorVpcCidr(kt, ds.HuaWei.Vpc.ListVpcExt, func(ext *corecloud.HuaWeiVpcExtension) []string {
+		cidrs := make([]string, 0, len(ext.Cidr))
+		for _, one := range ext.Cidr {
+			if one.Type == enumor.Ipv4 {
+				cidrs = append(cidrs, one.Cidr)
+			}
+		}
+		return cidrs
+	})
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, huawei...)
+
+	azure, err := listVendorVpcCidr(kt, ds.Azure.Vpc.ListVpcExt, func(ext *corecloud.AzureVpcExtension) []string {
+		cidrs := make([]string, 0, len(ext.Cidr))
+		for _, one := range ext.Cidr {
+			if one.Type == enumor.Ipv4 {
+				cidrs = append(cidrs, one.Cidr)
+			}
+		}
+		return cidrs
+	})
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, azure...)
+
+	openstack, err := listVendorVpcCidr(kt, ds.OpenStack.Vpc.ListVpcExt,
+		func(ext *corecloud.OpenStackVpcExtension) []string {
+			cidrs := make([]string, 0, len(ext.Cidr))
+			for _, one := range ext.Cidr {
+				if one.Type == enumor.Ipv4 {
+					cidrs = append(cidrs, one.Cidr)
+				}
+			}
+			return cidrs
+		})
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, openstack...)
+
+	gcp, err := i.listGcpVpcCidr(kt)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, gcp...)
+
+	return result, nil
+}
+
+type listVpcExtFunc[T corecloud.VpcExtension] func(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protocloud.VpcExtListResult[T], error)
+
+// listVendorVpcCidr list all vpcs of vendor and convert them to vpc cidrs by cidrs func.
+func listVendorVpcCidr[T corecloud.VpcExtension](kt *kit.Kit, list listVpcExtFunc[T], cidrs func(ext *T) []string) (
+	[]csipam.VpcCidr, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.AllExpression(),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	result := make([]csipam.VpcCidr, 0)
+	for {
+		vpcs, err := list(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list vpc ext failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, vpc := range vpcs.Details {
+			if vpc.Extension == nil {
+				continue
+			}
+
+			for _, cidr := range cidrs(vpc.Extension) {
+				result = append(result, csipam.VpcCidr{
+					VpcID:      vpc.ID,
+					CloudVpcID: vpc.CloudID,
+					Name:       vpc.Name,
+					Vendor:     vpc.Vendor,
+					AccountID:  vpc.AccountID,
+					Region:     vpc.Region,
+					BkBizID:    vpc.BkBizID,
+					Cidr:       cidr,
+				})
+			}
+		}
+
+		if len(vpcs.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return result, nil
+}
+
+// listGcpVpcCidr list cidrs of gcp subnets as the cidrs of gcp vpcs.
+func (i *ipam) listGcpVpcCidr(kt *kit.Kit) ([]csipam.VpcCidr, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("vendor", enumor.Gcp),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	vpcNames := make(map[string]string)
+	for {
+		vpcs, err := i.client.DataService().Global.Vpc.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list gcp vpc failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, vpc := range vpcs.Details {
+			vpcNames[vpc.ID] = vpc.Name
+		}
+
+		if len(vpcs.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	listReq.Page = core.NewDefaultBasePage()
+	result := make([]csipam.VpcCidr, 0)
+	for {
+		subnets, err := i.client.DataService().Global.Subnet.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list gcp subnet failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, subnet := range subnets.Details {
+			for _, cidr := range subnet.Ipv4Cidr {
+				result = append(result, csipam.VpcCidr{
+					VpcID:      subnet.VpcID,
+					CloudVpcID: subnet.CloudVpcID,
+					Name:       vpcNames[subnet.VpcID],
+					Vendor:     subnet.Vendor,
+					AccountID:  subnet.AccountID,
+					Region:     subnet.Region,
+					BkBizID:    subnet.BkBizID,
+					Cidr:       cidr,
+				})
+			}
+		}
+
+		if len(subnets.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair ...
+package keypair
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+)
+
+// CheckKeyPairUsable 校验创建主机时指定的密钥对存在，且与主机所属业务一致（业务ID为0时不校验业务）。
+func CheckKeyPairUsable(kt *kit.Kit, cli *dataservice.Client, bizID int64, keyPairID string) error {
+	if len(keyPairID) == 0 {
+		return nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", keyPairID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := cli.Global.KeyPair.ListKeyPair(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Details) == 0 {
+		return errf.Newf(errf.RecordNotFound, "key pair: %s not found", keyPairID)
+	}
+
+	if bizID != 0 && result.Details[0].BkBizID != bizID {
+		return fmt.Errorf("key pair: %s not belong to biz: %d", keyPairID, bizID)
+	}
+
+	return nil
+}
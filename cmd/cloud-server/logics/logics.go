@@ -25,15 +25,19 @@ import (
 	"hcm/cmd/cloud-server/logics/cvm"
 	"hcm/cmd/cloud-server/logics/disk"
 	"hcm/cmd/cloud-server/logics/eip"
+	"hcm/cmd/cloud-server/logics/ipam"
+	securitygroup "hcm/cmd/cloud-server/logics/security-group"
 	"hcm/pkg/client"
 )
 
 // Logics defines cloud-server common logics.
 type Logics struct {
-	Audit audit.Interface
-	Disk  disk.Interface
-	Cvm   cvm.Interface
-	Eip   eip.Interface
+	Audit         audit.Interface
+	Disk          disk.Interface
+	Cvm           cvm.Interface
+	Eip           eip.Interface
+	Ipam          ipam.Interface
+	SecurityGroup securitygroup.Interface
 }
 
 // NewLogics create a new cloud server logics.
@@ -41,9 +45,11 @@ func NewLogics(c *client.ClientSet) *Logics {
 	auditLogics := audit.NewAudit(c.DataService())
 	eipLogics := eip.NewEip(c, auditLogics)
 	return &Logics{
-		Audit: auditLogics,
-		Disk:  disk.NewDisk(c, auditLogics),
-		Cvm:   cvm.NewCvm(c, auditLogics, eipLogics),
-		Eip:   eip.NewEip(c, auditLogics),
+		Audit:         auditLogics,
+		Disk:          disk.NewDisk(c, auditLogics),
+		Cvm:           cvm.NewCvm(c, auditLogics, eipLogics),
+		Eip:           eip.NewEip(c, auditLogics),
+		Ipam:          ipam.NewIpam(c),
+		SecurityGroup: securitygroup.NewSecurityGroup(c),
 	}
 }
@@ -0,0 +1,473 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/sgrule"
+)
+
+// privateNets are the address ranges which are not routed on the internet.
+var privateNets = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7"}
+
+// EvaluateCvmAccess evaluate the effective network access of cvm, which merges the security groups, network
+// security groups of subnet or firewall rules of vpc, and the public ip exposure of cvm.
+func (s *securityGroup) EvaluateCvmAccess(kt *kit.Kit, cvmID string, queries []cloudserver.AccessQuery) (
+	*cloudserver.CvmNetworkAccess, error) {
+
+	cvmReq := &dataproto.CvmListReq{
+		Filter: tools.EqualExpression("id", cvmID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	cvmResp, err := s.client.DataService().Global.Cvm.ListCvm(kt.Ctx, kt.Header(), cvmReq)
+	if err != nil {
+		logs.Errorf("list cvm failed, err: %v, id: %s, rid: %s", err, cvmID, kt.Rid)
+		return nil, err
+	}
+
+	if len(cvmResp.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "cvm: %s not found", cvmID)
+	}
+	cvm := cvmResp.Details[0]
+
+	access := &cloudserver.CvmNetworkAccess{
+		CvmID:      cvm.ID,
+		Vendor:     cvm.Vendor,
+		PublicIPs:  append(append(make([]string, 0), cvm.PublicIPv4Addresses...), cvm.PublicIPv6Addresses...),
+		PrivateIPs: append(append(make([]string, 0), cvm.PrivateIPv4Addresses...), cvm.PrivateIPv6Addresses...),
+		Notes:      make([]string, 0),
+	}
+
+	if access.Layers, err = s.listCvmAccessLayer(kt, cvm, access); err != nil {
+		return nil, err
+	}
+
+	if len(access.PublicIPs) != 0 {
+		access.InternetRoutable = true
+		if cvm.Vendor == enumor.Aws {
+			if access.InternetRoutable, err = s.awsInternetRoutable(kt, cvm, access); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	access.Ingress = sgrule.Exposure(access.Layers, enumor.Ingress)
+	access.Egress = sgrule.Exposure(access.Layers, enumor.Egress)
+	access.Results = make([]cloudserver.AccessQueryResult, 0, len(queries))
+	for _, one := range queries {
+		query := one.Query()
+		verdict, err := sgrule.Evaluate(access.Layers, query)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		result := cloudserver.AccessQueryResult{Query: query, Verdict: verdict, Reachable: verdict.Allowed}
+		if verdict.Allowed && !isPrivateCidr(query.Cidr) {
+			result.Reachable = access.InternetRoutable
+		}
+		access.Results = append(access.Results, result)
+	}
+
+	if len(access.PublicIPs) == 0 {
+		access.Notes = append(access.Notes, "cvm has no public ip, outbound traffic to the internet through nat "+
+			"gateway is not evaluated")
+	}
+
+	return access, nil
+}
+
+// listCvmAccessLayer returns the filtering layers of cvm traffic by vendor:
+// tcloud: security groups of cvm are matched one by one in binding order, the first matched rule decides.
+// aws, huawei: traffic is allowed when any security group of cvm allows it.
+// azure: traffic should be allowed by the network security groups of both network interface and subnet.
+// gcp: firewall rules of vpc, ingress is denied and egress is allowed if no rule is matched.
+func (s *securityGroup) listCvmAccessLayer(kt *kit.Kit, cvm corecvm.BaseCvm, access *cloudserver.CvmNetworkAccess) (
+	[]sgrule.Layer, error) {
+
+	switch cvm.Vendor {
+	case enumor.TCloud, enumor.Aws, enumor.HuaWei:
+		sgIDMap, err := s.listCvmSecurityGroupID(kt, []string{cvm.ID})
+		if err != nil {
+			return nil, err
+		}
+
+		sgIDs := sgIDMap[cvm.ID]
+		if cvm.Vendor == enumor.TCloud {
+			if sgIDs, err = s.sortTCloudSGIDByBindingOrder(kt, cvm.ID, sgIDs, access); err != nil {
+				return nil, err
+			}
+		}
+
+		groups, err := s.listAccessGroup(kt, cvm.Vendor, sgIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		if cvm.Vendor == enumor.TCloud {
+			groups = mergeOrderedGroup(groups)
+			if err = s.noteTCloudNetworkAcl(kt, cvm, access); err != nil {
+				return nil, err
+			}
+		} else {
+			access.Notes = append(access.Notes, "network acls of subnet are not evaluated, their rules are not "+
+				"synced")
+		}
+
+		return []sgrule.Layer{{Name: string(enumor.SecurityGroupCloudResType), Groups: groups}}, nil
+
+	case enumor.Azure:
+		subnetSGIDs, nicSGIDs, err := s.listAzureCvmSecurityGroupID(kt, cvm.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		layers := make([]sgrule.Layer, 0, 2)
+		for _, one := range []struct {
+			name  string
+			sgIDs []string
+		}{
+			{name: string(enumor.NetworkInterfaceCloudResType), sgIDs: nicSGIDs},
+			{name: string(enumor.SubnetCloudResType), sgIDs: subnetSGIDs},
+		} {
+			// 没有绑定网络安全组的网卡或子网不过滤流量
+			if len(one.sgIDs) == 0 {
+				continue
+			}
+
+			groups, err := s.listAccessGroup(kt, cvm.Vendor, one.sgIDs)
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, sgrule.Layer{Name: one.name, Groups: groups})
+		}
+		access.Notes = append(access.Notes, "default rules of azure network security group are simplified as "+
+			"deny all inbound and allow all outbound traffic")
+
+		return layers, nil
+
+	case enumor.Gcp:
+		return s.listGcpAccessLayer(kt, cvm, access)
+
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s does not support network access evaluation",
+			cvm.Vendor)
+	}
+}
+
+func (s *securityGroup) listAccessGroup(kt *kit.Kit, vendor enumor.Vendor, sgIDs []string) ([]sgrule.Group,
+	error) {
+
+	groups := make([]sgrule.Group, 0, len(sgIDs))
+	for _, sgID := range sgIDs {
+		rules, err := s.listRule(kt, vendor, sgID)
+		if err != nil {
+			return nil, err
+		}
+
+		group := sgrule.Group{ID: sgID, Rules: rules}
+		if vendor == enumor.Azure {
+			group.DefaultEgress = sgrule.Allow
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// sortTCloudSGIDByBindingOrder sort security groups of tcloud cvm in binding order, tcloud returns security groups
+// of instance in binding order, which is saved in the extension of cvm when syncing.
+func (s *securityGroup) sortTCloudSGIDByBindingOrder(kt *kit.Kit, cvmID string, sgIDs []string,
+	access *cloudserver.CvmNetworkAccess) ([]string, error) {
+
+	if len(sgIDs) <= 1 {
+		return sgIDs, nil
+	}
+
+	cvm, err := s.client.DataService().TCloud.Cvm.GetCvm(kt.Ctx, kt.Header(), cvmID)
+	if err != nil {
+		logs.Errorf("get tcloud cvm failed, err: %v, id: %s, rid: %s", err, cvmID, kt.Rid)
+		return nil, err
+	}
+
+	listReq := &dataproto.SecurityGroupListReq{
+		Field:  []string{"id", "cloud_id"},
+		Filter: tools.ContainersExpression("id", sgIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	sgResp, err := s.client.DataService().Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list security group failed, err: %v, ids: %v, rid: %s", err, sgIDs, kt.Rid)
+		return nil, err
+	}
+
+	cloudIDMap := make(map[string]string, len(sgResp.Details))
+	for _, one := range sgResp.Details {
+		cloudIDMap[one.ID] = one.CloudID
+	}
+
+	var bindingOrder []string
+	if cvm.Extension != nil {
+		bindingOrder = cvm.Extension.CloudSecurityGroupIDs
+	}
+
+	sorted, unordered := sortByBindingOrder(sgIDs, cloudIDMap, bindingOrder)
+	if len(unordered) != 0 {
+		access.Notes = append(access.Notes, fmt.Sprintf("binding order of security groups %v is unknown, they "+
+			"are matched after other security groups and ordered by id, the result may be approximate",
+			unordered))
+	}
+
+	return sorted, nil
+}
+
+// sortByBindingOrder sort security group ids by the binding order of their cloud ids, security groups whose
+// binding order is unknown are put at last and sorted by id, so the result is stable. returns the sorted ids and
+// the ids whose binding order is unknown.
+func sortByBindingOrder(sgIDs []string, cloudIDMap map[string]string, bindingOrder []string) ([]string,
+	[]string) {
+
+	orderMap := make(map[string]int, len(bindingOrder))
+	for idx, cloudID := range bindingOrder {
+		orderMap[cloudID] = idx
+	}
+
+	indexOf := func(id string) int {
+		if idx, exists := orderMap[cloudIDMap[id]]; exists {
+			return idx
+		}
+		return len(bindingOrder)
+	}
+
+	sorted := append(make([]string, 0, len(sgIDs)), sgIDs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if indexOf(sorted[i]) != indexOf(sorted[j]) {
+			return indexOf(sorted[i]) < indexOf(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	unordered := make([]string, 0)
+	for _, id := range sorted {
+		if indexOf(id) == len(bindingOrder) {
+			unordered = append(unordered, id)
+		}
+	}
+
+	return sorted, unordered
+}
+
+// mergeOrderedGroup merge security groups which are matched in order to one group, priorities of rules are
+// rebased so that rules of the former group are matched first.
+func mergeOrderedGroup(groups []sgrule.Group) []sgrule.Group {
+	if len(groups) <= 1 {
+		return groups
+	}
+
+	ids := make([]string, 0, len(groups))
+	merged := sgrule.Group{Rules: make([]sgrule.Rule, 0)}
+	var offset int64
+	for _, group := range groups {
+		ids = append(ids, group.ID)
+
+		next := offset
+		for _, rule := range group.Rules {
+			rule.Priority += offset
+			if rule.Priority >= next {
+				next = rule.Priority + 1
+			}
+			merged.Rules = append(merged.Rules, rule)
+		}
+		offset = next
+	}
+	merged.ID = strings.Join(ids, ",")
+
+	return []sgrule.Group{merged}
+}
+
+// noteTCloudNetworkAcl tcloud subnet may be bound with a network acl, whose rules are not synced.
+func (s *securityGroup) noteTCloudNetworkAcl(kt *kit.Kit, cvm corecvm.BaseCvm,
+	access *cloudserver.CvmNetworkAccess) error {
+
+	if len(cvm.SubnetIDs) == 0 {
+		return nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", cvm.SubnetIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := s.client.DataService().TCloud.Subnet.ListSubnetExt(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list tcloud subnet failed, err: %v, ids: %v, rid: %s", err, cvm.SubnetIDs, kt.Rid)
+		return err
+	}
+
+	for _, one := range result.Details {
+		if one.Extension != nil && len(converter.PtrToVal(one.Extension.CloudNetworkAclId)) != 0 {
+			access.Notes = append(access.Notes, fmt.Sprintf("network acl %s of subnet %s is not evaluated, its "+
+				"rules are not synced", *one.Extension.CloudNetworkAclId, one.ID))
+		}
+	}
+
+	return nil
+}
+
+// listGcpAccessLayer returns the firewall rules of the vpcs of gcp cvm, network tags and service accounts of cvm
+// are not synced, so only the firewall rules without target are evaluated.
+func (s *securityGroup) listGcpAccessLayer(kt *kit.Kit, cvm corecvm.BaseCvm, access *cloudserver.CvmNetworkAccess) (
+	[]sgrule.Layer, error) {
+
+	group := sgrule.Group{ID: strings.Join(cvm.VpcIDs, ","), Rules: make([]sgrule.Rule, 0),
+		DefaultEgress: sgrule.Allow}
+	if len(cvm.VpcIDs) != 0 {
+		fwRules, err := s.listGcpFirewallRule(kt, tools.ContainersExpression("vpc_id", cvm.VpcIDs))
+		if err != nil {
+			return nil, err
+		}
+
+		targeted := 0
+		for _, one := range fwRules {
+			if len(one.TargetTags) != 0 || len(one.TargetServiceAccounts) != 0 {
+				targeted++
+				continue
+			}
+			group.Rules = append(group.Rules, normalizeGcpRule(one)...)
+		}
+
+		if targeted != 0 {
+			access.Notes = append(access.Notes, fmt.Sprintf("%d firewall rules with target tags or service "+
+				"accounts are not evaluated, network tags of cvm are not synced", targeted))
+		}
+	}
+
+	return []sgrule.Layer{{Name: string(enumor.GcpFirewallRuleCloudResType), Groups: []sgrule.Group{group}}}, nil
+}
+
+// awsInternetRoutable returns whether any subnet of aws cvm has a default route to internet gateway, subnet
+// without explicit route table uses the main route table of vpc.
+func (s *securityGroup) awsInternetRoutable(kt *kit.Kit, cvm corecvm.BaseCvm,
+	access *cloudserver.CvmNetworkAccess) (bool, error) {
+
+	if len(cvm.SubnetIDs) == 0 {
+		return false, nil
+	}
+
+	subnetReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", cvm.SubnetIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	subnets, err := s.client.DataService().Global.Subnet.List(kt.Ctx, kt.Header(), subnetReq)
+	if err != nil {
+		logs.Errorf("list subnet failed, err: %v, ids: %v, rid: %s", err, cvm.SubnetIDs, kt.Rid)
+		return false, err
+	}
+
+	for _, subnet := range subnets.Details {
+		tableID := subnet.RouteTableID
+		if len(tableID) == 0 {
+			if tableID, err = s.getAwsMainRouteTableID(kt, subnet); err != nil {
+				return false, err
+			}
+		}
+
+		if len(tableID) == 0 {
+			access.Notes = append(access.Notes, fmt.Sprintf("route table of subnet %s is not found", subnet.ID))
+			continue
+		}
+
+		routeReq := &core.ListReq{
+			Filter: tools.AllExpression(),
+			Page:   core.NewDefaultBasePage(),
+		}
+		routes, err := s.client.DataService().Aws.RouteTable.ListRoute(kt.Ctx, kt.Header(), tableID, routeReq)
+		if err != nil {
+			logs.Errorf("list aws route failed, err: %v, route table: %s, rid: %s", err, tableID, kt.Rid)
+			return false, err
+		}
+
+		for _, route := range routes.Details {
+			dest := converter.PtrToVal(route.DestinationCidrBlock)
+			if len(dest) == 0 {
+				dest = converter.PtrToVal(route.DestinationIpv6CidrBlock)
+			}
+
+			if sgrule.IsPublicCidr(dest) && strings.HasPrefix(converter.PtrToVal(route.CloudGatewayID), "igw-") {
+				return true, nil
+			}
+		}
+	}
+
+	access.Notes = append(access.Notes, "no subnet of cvm has a default route to internet gateway")
+	return false, nil
+}
+
+func (s *securityGroup) getAwsMainRouteTableID(kt *kit.Kit, subnet corecloud.BaseSubnet) (string, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("vpc_id", subnet.VpcID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	tables, err := s.client.DataService().Aws.RouteTable.ListRouteTableWithExt(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list aws route table failed, err: %v, vpc: %s, rid: %s", err, subnet.VpcID, kt.Rid)
+		return "", err
+	}
+
+	for _, one := range tables {
+		if one.Extension != nil && one.Extension.Main {
+			return one.ID, nil
+		}
+	}
+
+	return "", nil
+}
+
+// isPrivateCidr returns whether the whole cidr is in private address ranges.
+func isPrivateCidr(cidr string) bool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+
+	for _, one := range privateNets {
+		_, privateNet, _ := net.ParseCIDR(one)
+		ones, bits := privateNet.Mask.Size()
+		queryOnes, queryBits := ipNet.Mask.Size()
+		if bits == queryBits && ones <= queryOnes && privateNet.Contains(ipNet.IP) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortByBindingOrder(t *testing.T) {
+	cloudIDMap := map[string]string{"1": "sg-a", "2": "sg-b", "3": "sg-c", "4": "sg-d"}
+	bindingOrder := []string{"sg-c", "sg-a", "sg-b"}
+
+	// the result should be the same whatever the order of input is.
+	for _, sgIDs := range [][]string{{"1", "2", "3", "4"}, {"4", "3", "2", "1"}, {"2", "4", "1", "3"}} {
+		sorted, unordered := sortByBindingOrder(sgIDs, cloudIDMap, bindingOrder)
+
+		if expect := []string{"3", "1", "2", "4"}; !reflect.DeepEqual(sorted, expect) {
+			t.Errorf("sorted ids %v is not as expected %v", sorted, expect)
+		}
+
+		if expect := []string{"4"}; !reflect.DeepEqual(unordered, expect) {
+			t.Errorf("unordered ids %v is not as expected %v", unordered, expect)
+		}
+	}
+
+	sorted, unordered := sortByBindingOrder([]string{"3", "1"}, cloudIDMap, nil)
+	if expect := []string{"1", "3"}; !reflect.DeepEqual(sorted, expect) {
+		t.Errorf("ids without binding order should be sorted by id, got %v", sorted)
+	}
+
+	if len(unordered) != 2 {
+		t.Errorf("ids without binding order should all be unordered, got %v", unordered)
+	}
+}
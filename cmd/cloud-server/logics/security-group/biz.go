@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"sort"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/sgrule"
+)
+
+// AnalyzeBiz analyze rules of security groups and gcp firewall rules assigned to business.
+func (s *securityGroup) AnalyzeBiz(kt *kit.Kit, bkBizID int64) (*cloudserver.BizSecurityGroupAnalysis, error) {
+	sgs, err := s.listSecurityGroup(kt, tools.EqualExpression("bk_biz_id", bkBizID))
+	if err != nil {
+		return nil, err
+	}
+
+	sgAnalyses, err := s.analyzeSecurityGroups(kt, sgs)
+	if err != nil {
+		return nil, err
+	}
+
+	fwRules, err := s.listGcpFirewallRule(kt, tools.EqualExpression("bk_biz_id", bkBizID))
+	if err != nil {
+		return nil, err
+	}
+
+	gcpAnalyses, err := s.analyzeGcpFirewall(kt, fwRules)
+	if err != nil {
+		return nil, err
+	}
+
+	analyses := append(sgAnalyses, gcpAnalyses...)
+	sort.SliceStable(analyses, func(i, j int) bool {
+		return analyses[i].Score > analyses[j].Score
+	})
+
+	result := &cloudserver.BizSecurityGroupAnalysis{
+		BkBizID:        bkBizID,
+		SeverityCount:  make(map[sgrule.Severity]int),
+		SecurityGroups: analyses,
+	}
+	findings := make([]sgrule.Finding, 0)
+	for _, one := range analyses {
+		if one.Score > result.Score {
+			result.Score = one.Score
+		}
+		if len(one.Findings) != 0 {
+			result.RiskySecurityGroupCount++
+		}
+		for _, finding := range one.Findings {
+			result.SeverityCount[finding.Severity]++
+		}
+		findings = append(findings, one.Findings...)
+	}
+	result.MaxSeverity = sgrule.MaxSeverity(findings)
+
+	return result, nil
+}
@@ -0,0 +1,238 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/sgrule"
+	"hcm/pkg/tools/slice"
+)
+
+// AnalyzeCvm analyze rules of security groups bound to cvms, for gcp cvm, the firewall rules of its vpcs
+// which apply to all instances are analyzed.
+func (s *securityGroup) AnalyzeCvm(kt *kit.Kit, cvmIDs []string) ([]cloudserver.CvmSecurityGroupAnalysis, error) {
+	cvmIDs = slice.Unique(cvmIDs)
+	cvmReq := &dataproto.CvmListReq{
+		Field:  []string{"id", "vendor", "vpc_ids"},
+		Filter: tools.ContainersExpression("id", cvmIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	cvmResp, err := s.client.DataService().Global.Cvm.ListCvm(kt.Ctx, kt.Header(), cvmReq)
+	if err != nil {
+		logs.Errorf("list cvm failed, err: %v, ids: %v, rid: %s", err, cvmIDs, kt.Rid)
+		return nil, err
+	}
+
+	cvmMap := make(map[string]corecvm.BaseCvm, len(cvmResp.Details))
+	for _, one := range cvmResp.Details {
+		cvmMap[one.ID] = one
+	}
+	for _, id := range cvmIDs {
+		if _, exists := cvmMap[id]; !exists {
+			return nil, errf.Newf(errf.RecordNotFound, "cvm: %s not found", id)
+		}
+	}
+
+	cvmSGIDs, err := s.listCvmSecurityGroupID(kt, cvmIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// azure的安全组绑定在网络接口和子网上面，需要单独查询
+	for _, one := range cvmResp.Details {
+		if one.Vendor != enumor.Azure {
+			continue
+		}
+
+		subnetSGIDs, nicSGIDs, err := s.listAzureCvmSecurityGroupID(kt, one.ID)
+		if err != nil {
+			return nil, err
+		}
+		cvmSGIDs[one.ID] = slice.Unique(append(subnetSGIDs, nicSGIDs...))
+	}
+
+	sgIDs := make([]string, 0)
+	for _, ids := range cvmSGIDs {
+		sgIDs = append(sgIDs, ids...)
+	}
+	sgAnalyses := make([]cloudserver.SecurityGroupAnalysis, 0)
+	if len(sgIDs) != 0 {
+		if sgAnalyses, err = s.AnalyzeSecurityGroup(kt, slice.Unique(sgIDs)); err != nil {
+			return nil, err
+		}
+	}
+
+	gcpAnalyses, err := s.analyzeCvmGcpFirewall(kt, cvmResp.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	analysisMap := make(map[string]cloudserver.SecurityGroupAnalysis, len(sgAnalyses)+len(gcpAnalyses))
+	for _, one := range append(sgAnalyses, gcpAnalyses...) {
+		analysisMap[one.ID] = one
+	}
+
+	result := make([]cloudserver.CvmSecurityGroupAnalysis, 0, len(cvmIDs))
+	for _, cvmID := range cvmIDs {
+		resIDs := cvmSGIDs[cvmID]
+		if cvmMap[cvmID].Vendor == enumor.Gcp {
+			resIDs = cvmMap[cvmID].VpcIDs
+		}
+
+		cvmAnalysis := cloudserver.CvmSecurityGroupAnalysis{
+			CvmID:          cvmID,
+			SecurityGroups: make([]cloudserver.SecurityGroupAnalysis, 0, len(resIDs)),
+		}
+		findings := make([]sgrule.Finding, 0)
+		for _, id := range resIDs {
+			analysis, exists := analysisMap[id]
+			if !exists {
+				continue
+			}
+			cvmAnalysis.SecurityGroups = append(cvmAnalysis.SecurityGroups, analysis)
+			findings = append(findings, analysis.Findings...)
+		}
+		cvmAnalysis.Score = sgrule.Score(findings)
+		cvmAnalysis.MaxSeverity = sgrule.MaxSeverity(findings)
+		result = append(result, cvmAnalysis)
+	}
+
+	return result, nil
+}
+
+// listCvmSecurityGroupID returns the map of cvm id to the ids of security groups bound to it.
+func (s *securityGroup) listCvmSecurityGroupID(kt *kit.Kit, cvmIDs []string) (map[string][]string, error) {
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("cvm_id", cvmIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result := make(map[string][]string, len(cvmIDs))
+	for {
+		resp, err := s.client.DataService().Global.SGCvmRel.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list security group cvm rel failed, err: %v, cvm ids: %v, rid: %s", err, cvmIDs, kt.Rid)
+			return nil, err
+		}
+
+		for _, rel := range resp.Details {
+			result[rel.CvmID] = append(result[rel.CvmID], rel.SecurityGroupID)
+		}
+
+		if len(resp.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return result, nil
+}
+
+// listAzureCvmSecurityGroupID returns the ids of security groups bound to subnets and network interfaces of cvm.
+func (s *securityGroup) listAzureCvmSecurityGroupID(kt *kit.Kit, cvmID string) (subnetSGIDs []string,
+	nicSGIDs []string, err error) {
+
+	cvm, err := s.client.DataService().Azure.Cvm.GetCvm(kt.Ctx, kt.Header(), cvmID)
+	if err != nil {
+		logs.Errorf("get cvm failed, err: %v, cvmID: %s, rid: %s", err, cvmID, kt.Rid)
+		return nil, nil, err
+	}
+
+	subnetSGIDs, nicSGIDs = make([]string, 0), make([]string, 0)
+	if len(cvm.SubnetIDs) != 0 {
+		listSubnetReq := &core.ListReq{
+			Filter: tools.ContainersExpression("id", cvm.SubnetIDs),
+			Page:   core.NewDefaultBasePage(),
+		}
+		subnetResult, err := s.client.DataService().Azure.Subnet.ListSubnetExt(kt.Ctx, kt.Header(), listSubnetReq)
+		if err != nil {
+			logs.Errorf("list subnet failed, err: %v, subnetIDs: %v, rid: %s", err, cvm.SubnetIDs, kt.Rid)
+			return nil, nil, err
+		}
+
+		for _, one := range subnetResult.Details {
+			if len(one.Extension.SecurityGroupID) != 0 {
+				subnetSGIDs = append(subnetSGIDs, one.Extension.SecurityGroupID)
+			}
+		}
+	}
+
+	if cvm.Extension != nil && len(cvm.Extension.CloudNetworkInterfaceIDs) != 0 {
+		listNIReq := &core.ListReq{
+			Filter: tools.ContainersExpression("cloud_id", cvm.Extension.CloudNetworkInterfaceIDs),
+			Page:   core.NewDefaultBasePage(),
+		}
+		niResult, err := s.client.DataService().Azure.NetworkInterface.ListNetworkInterfaceExt(kt.Ctx, kt.Header(),
+			listNIReq)
+		if err != nil {
+			logs.Errorf("list network interface failed, err: %v, niIDs: %v, rid: %s", err,
+				cvm.Extension.CloudNetworkInterfaceIDs, kt.Rid)
+			return nil, nil, err
+		}
+
+		for _, one := range niResult.Details {
+			if len(converter.PtrToVal(one.Extension.SecurityGroupID)) != 0 {
+				nicSGIDs = append(nicSGIDs, *one.Extension.SecurityGroupID)
+			}
+		}
+	}
+
+	return slice.Unique(subnetSGIDs), slice.Unique(nicSGIDs), nil
+}
+
+// analyzeCvmGcpFirewall analyze firewall rules of vpcs of gcp cvms, network tags and service accounts of cvm
+// are not synced, so only the firewall rules without target are analyzed.
+func (s *securityGroup) analyzeCvmGcpFirewall(kt *kit.Kit, cvms []corecvm.BaseCvm) (
+	[]cloudserver.SecurityGroupAnalysis, error) {
+
+	vpcIDs := make([]string, 0)
+	for _, one := range cvms {
+		if one.Vendor == enumor.Gcp {
+			vpcIDs = append(vpcIDs, one.VpcIDs...)
+		}
+	}
+
+	if len(vpcIDs) == 0 {
+		return make([]cloudserver.SecurityGroupAnalysis, 0), nil
+	}
+
+	fwRules, err := s.listGcpFirewallRule(kt, tools.ContainersExpression("vpc_id", slice.Unique(vpcIDs)))
+	if err != nil {
+		return nil, err
+	}
+
+	untargeted := make([]corecloud.GcpFirewallRule, 0, len(fwRules))
+	for _, one := range fwRules {
+		if len(one.TargetTags) == 0 && len(one.TargetServiceAccounts) == 0 {
+			untargeted = append(untargeted, one)
+		}
+	}
+
+	return s.analyzeGcpFirewall(kt, untargeted)
+}
@@ -0,0 +1,254 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"net"
+	"strings"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/sgrule"
+)
+
+var anyCidrs = []string{"0.0.0.0/0", "::/0"}
+
+// normalizeProtocol convert protocol name or iana protocol number to lower case protocol name.
+func normalizeProtocol(protocol string) string {
+	switch strings.ToLower(strings.TrimSpace(protocol)) {
+	case "", "-1", "*", "all", "any":
+		return sgrule.ProtocolAll
+	case "6", "tcp":
+		return sgrule.ProtocolTCP
+	case "17", "udp":
+		return sgrule.ProtocolUDP
+	case "1", "icmp":
+		return sgrule.ProtocolICMP
+	default:
+		return strings.ToLower(strings.TrimSpace(protocol))
+	}
+}
+
+// normalizeAddress convert address to cidrs, address which is not ip or cidr is returned as reference.
+func normalizeAddress(addr string) (cidrs []string, ref string) {
+	addr = strings.TrimSpace(addr)
+	switch strings.ToLower(addr) {
+	case "":
+		return nil, ""
+	case "*", "any", "internet":
+		return anyCidrs, ""
+	}
+
+	if _, _, err := net.ParseCIDR(addr); err == nil {
+		return []string{addr}, ""
+	}
+
+	if ip := net.ParseIP(addr); ip != nil {
+		if ip.To4() != nil {
+			return []string{addr + "/32"}, ""
+		}
+		return []string{addr + "/128"}, ""
+	}
+
+	return nil, addr
+}
+
+func appendAddress(rule *sgrule.Rule, addrs ...*string) {
+	for _, one := range addrs {
+		cidrs, ref := normalizeAddress(converter.PtrToVal(one))
+		rule.Cidrs = append(rule.Cidrs, cidrs...)
+		if len(ref) != 0 {
+			rule.Refs = append(rule.Refs, ref)
+		}
+	}
+}
+
+func appendRef(rule *sgrule.Rule, refs ...*string) {
+	for _, one := range refs {
+		if len(converter.PtrToVal(one)) != 0 {
+			rule.Refs = append(rule.Refs, *one)
+		}
+	}
+}
+
+// normalizeTCloudRule convert tcloud rule, rules using service template can not be analyzed because the protocol
+// and ports are defined in template, they are skipped.
+func normalizeTCloudRule(one corecloud.TCloudSecurityGroupRule) (sgrule.Rule, bool) {
+	if len(converter.PtrToVal(one.CloudServiceID)) != 0 || len(converter.PtrToVal(one.CloudServiceGroupID)) != 0 {
+		return sgrule.Rule{}, false
+	}
+
+	ports, err := sgrule.ParsePorts(converter.PtrToVal(one.Port))
+	if err != nil {
+		return sgrule.Rule{}, false
+	}
+
+	rule := sgrule.Rule{
+		ID:        one.ID,
+		Direction: one.Type,
+		Action:    sgrule.Allow,
+		Priority:  one.CloudPolicyIndex,
+		Protocol:  normalizeProtocol(converter.PtrToVal(one.Protocol)),
+		Ports:     ports,
+	}
+	if strings.ToUpper(one.Action) == "DROP" {
+		rule.Action = sgrule.Deny
+	}
+	appendAddress(&rule, one.IPv4Cidr, one.IPv6Cidr)
+	appendRef(&rule, one.CloudTargetSecurityGroupID, one.CloudAddressID, one.CloudAddressGroupID)
+
+	return rule, true
+}
+
+// normalizeAwsRule convert aws rule, aws only supports allow rule and has no priority.
+func normalizeAwsRule(one corecloud.AwsSecurityGroupRule) (sgrule.Rule, bool) {
+	rule := sgrule.Rule{
+		ID:        one.ID,
+		Direction: one.Type,
+		Action:    sgrule.Allow,
+		Protocol:  normalizeProtocol(converter.PtrToVal(one.Protocol)),
+	}
+
+	if (rule.Protocol == sgrule.ProtocolTCP || rule.Protocol == sgrule.ProtocolUDP) && one.FromPort != nil &&
+		one.ToPort != nil && *one.FromPort >= 0 {
+
+		if *one.FromPort != 0 || *one.ToPort != 65535 {
+			rule.Ports = []sgrule.PortRange{{From: int(*one.FromPort), To: int(*one.ToPort)}}
+		}
+	}
+	appendAddress(&rule, one.IPv4Cidr, one.IPv6Cidr)
+	appendRef(&rule, one.CloudPrefixListID, one.CloudTargetSecurityGroupID)
+
+	return rule, true
+}
+
+// normalizeHuaWeiRule convert huawei rule, remote is any address of the ether type if neither remote ip prefix
+// nor remote group is set.
+func normalizeHuaWeiRule(one corecloud.HuaWeiSecurityGroupRule) (sgrule.Rule, bool) {
+	ports, err := sgrule.ParsePorts(one.Port)
+	if err != nil {
+		return sgrule.Rule{}, false
+	}
+
+	rule := sgrule.Rule{
+		ID:        one.ID,
+		Direction: one.Type,
+		Action:    sgrule.Action(strings.ToLower(one.Action)),
+		Priority:  one.Priority,
+		Protocol:  normalizeProtocol(one.Protocol),
+		Ports:     ports,
+	}
+	if rule.Action != sgrule.Deny {
+		rule.Action = sgrule.Allow
+	}
+
+	appendAddress(&rule, &one.RemoteIPPrefix)
+	appendRef(&rule, &one.CloudRemoteGroupID, &one.CloudRemoteAddressGroupID)
+	if len(rule.Cidrs) == 0 && len(rule.Refs) == 0 {
+		if strings.EqualFold(one.Ethertype, "IPv6") {
+			rule.Cidrs = []string{"::/0"}
+		} else {
+			rule.Cidrs = []string{"0.0.0.0/0"}
+		}
+	}
+
+	return rule, true
+}
+
+// normalizeAzureRule convert azure rule, the peer of ingress rule is source, and the peer of egress rule is
+// destination, service tags except Internet are treated as references.
+func normalizeAzureRule(one corecloud.AzureSecurityGroupRule) (sgrule.Rule, bool) {
+	portExprs := make([]string, 0, len(one.DestinationPortRanges)+1)
+	for _, port := range append([]*string{one.DestinationPortRange}, one.DestinationPortRanges...) {
+		if len(converter.PtrToVal(port)) != 0 {
+			portExprs = append(portExprs, *port)
+		}
+	}
+
+	ports, err := sgrule.ParsePorts(strings.Join(portExprs, ","))
+	if err != nil {
+		return sgrule.Rule{}, false
+	}
+
+	rule := sgrule.Rule{
+		ID:        one.ID,
+		Direction: one.Type,
+		Action:    sgrule.Allow,
+		Priority:  int64(one.Priority),
+		Protocol:  normalizeProtocol(one.Protocol),
+		Ports:     ports,
+	}
+	if strings.EqualFold(one.Access, "Deny") {
+		rule.Action = sgrule.Deny
+	}
+
+	if one.Type == enumor.Egress {
+		appendAddress(&rule, append([]*string{one.DestinationAddressPrefix}, one.DestinationAddressPrefixes...)...)
+		appendRef(&rule, one.CloudDestinationAppSecurityGroupIDs...)
+	} else {
+		appendAddress(&rule, append([]*string{one.SourceAddressPrefix}, one.SourceAddressPrefixes...)...)
+		appendRef(&rule, one.CloudSourceAppSecurityGroupIDs...)
+	}
+
+	return rule, true
+}
+
+// normalizeGcpRule convert gcp firewall rule, each protocol set of the rule is converted to a rule, disabled
+// rule is skipped.
+func normalizeGcpRule(one corecloud.GcpFirewallRule) []sgrule.Rule {
+	if one.Disabled {
+		return nil
+	}
+
+	base := sgrule.Rule{
+		ID:        one.ID,
+		Direction: enumor.Ingress,
+		Priority:  one.Priority,
+	}
+	if strings.EqualFold(one.Type, "EGRESS") {
+		base.Direction = enumor.Egress
+		base.Cidrs = append(base.Cidrs, one.DestinationRanges...)
+	} else {
+		base.Cidrs = append(base.Cidrs, one.SourceRanges...)
+		base.Refs = append(base.Refs, one.SourceTags...)
+		base.Refs = append(base.Refs, one.SourceServiceAccounts...)
+	}
+
+	rules := make([]sgrule.Rule, 0, len(one.Allowed)+len(one.Denied))
+	convert := func(action sgrule.Action, sets []corecloud.GcpProtocolSet) {
+		for _, set := range sets {
+			ports, err := sgrule.ParsePorts(strings.Join(set.Port, ","))
+			if err != nil {
+				continue
+			}
+
+			rule := base
+			rule.Action = action
+			rule.Protocol = normalizeProtocol(set.Protocol)
+			rule.Ports = ports
+			rules = append(rules, rule)
+		}
+	}
+	convert(sgrule.Allow, one.Allowed)
+	convert(sgrule.Deny, one.Denied)
+
+	return rules
+}
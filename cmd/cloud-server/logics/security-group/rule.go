@@ -0,0 +1,194 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/sgrule"
+)
+
+// listRule list rules of security group and convert them to vendor neutral rules, vendor which has no security
+// group rule model returns empty rules.
+func (s *securityGroup) listRule(kt *kit.Kit, vendor enumor.Vendor, sgID string) ([]sgrule.Rule, error) {
+	var rules []sgrule.Rule
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		rules, err = s.listTCloudRule(kt, sgID)
+	case enumor.Aws:
+		rules, err = s.listAwsRule(kt, sgID)
+	case enumor.HuaWei:
+		rules, err = s.listHuaWeiRule(kt, sgID)
+	case enumor.Azure:
+		rules, err = s.listAzureRule(kt, sgID)
+	default:
+		return make([]sgrule.Rule, 0), nil
+	}
+	if err != nil {
+		logs.Errorf("list %s security group rule failed, err: %v, sg: %s, rid: %s", vendor, err, sgID, kt.Rid)
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (s *securityGroup) listTCloudRule(kt *kit.Kit, sgID string) ([]sgrule.Rule, error) {
+	listReq := &dataproto.TCloudSGRuleListReq{
+		Filter: tools.EqualExpression("security_group_id", sgID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	rules := make([]sgrule.Rule, 0)
+	for {
+		resp, err := s.client.DataService().TCloud.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+			listReq, sgID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			if rule, ok := normalizeTCloudRule(one); ok {
+				rules = append(rules, rule)
+			}
+		}
+
+		if len(resp.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return rules, nil
+}
+
+func (s *securityGroup) listAwsRule(kt *kit.Kit, sgID string) ([]sgrule.Rule, error) {
+	listReq := &dataproto.AwsSGRuleListReq{
+		Filter: tools.EqualExpression("security_group_id", sgID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	rules := make([]sgrule.Rule, 0)
+	for {
+		resp, err := s.client.DataService().Aws.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+			listReq, sgID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			if rule, ok := normalizeAwsRule(one); ok {
+				rules = append(rules, rule)
+			}
+		}
+
+		if len(resp.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return rules, nil
+}
+
+func (s *securityGroup) listHuaWeiRule(kt *kit.Kit, sgID string) ([]sgrule.Rule, error) {
+	listReq := &dataproto.HuaWeiSGRuleListReq{
+		Filter: tools.EqualExpression("security_group_id", sgID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	rules := make([]sgrule.Rule, 0)
+	for {
+		resp, err := s.client.DataService().HuaWei.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+			listReq, sgID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			if rule, ok := normalizeHuaWeiRule(one); ok {
+				rules = append(rules, rule)
+			}
+		}
+
+		if len(resp.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return rules, nil
+}
+
+func (s *securityGroup) listAzureRule(kt *kit.Kit, sgID string) ([]sgrule.Rule, error) {
+	listReq := &dataproto.AzureSGRuleListReq{
+		Filter: tools.EqualExpression("security_group_id", sgID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	rules := make([]sgrule.Rule, 0)
+	for {
+		resp, err := s.client.DataService().Azure.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+			listReq, sgID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			if rule, ok := normalizeAzureRule(one); ok {
+				rules = append(rules, rule)
+			}
+		}
+
+		if len(resp.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return rules, nil
+}
+
+func (s *securityGroup) listGcpFirewallRule(kt *kit.Kit, expr *filter.Expression) ([]corecloud.GcpFirewallRule,
+	error) {
+
+	listReq := &dataproto.GcpFirewallRuleListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+	}
+	result := make([]corecloud.GcpFirewallRule, 0)
+	for {
+		resp, err := s.client.DataService().Gcp.Firewall.ListFirewallRule(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list gcp firewall rule failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, resp.Details...)
+		if len(resp.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return result, nil
+}
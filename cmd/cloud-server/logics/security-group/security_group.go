@@ -0,0 +1,209 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package securitygroup ...
+package securitygroup
+
+import (
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	coresgt "hcm/pkg/api/core/cloud/sg-template"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/sgrule"
+)
+
+// Interface define security group logics interface.
+type Interface interface {
+	// AnalyzeSecurityGroup analyze rules of security groups.
+	AnalyzeSecurityGroup(kt *kit.Kit, sgIDs []string) ([]cloudserver.SecurityGroupAnalysis, error)
+	// AnalyzeCvm analyze rules of security groups bound to cvms, for gcp cvm, the firewall rules of its vpcs
+	// which apply to all instances are analyzed.
+	AnalyzeCvm(kt *kit.Kit, cvmIDs []string) ([]cloudserver.CvmSecurityGroupAnalysis, error)
+	// AnalyzeBiz analyze rules of security groups and gcp firewall rules assigned to business.
+	AnalyzeBiz(kt *kit.Kit, bkBizID int64) (*cloudserver.BizSecurityGroupAnalysis, error)
+	// PreviewTemplate returns the rules to be added and removed on each linked resource if the template is pushed.
+	PreviewTemplate(kt *kit.Kit, tpl *coresgt.SecurityGroupTemplate,
+		rels []coresgt.SecurityGroupTemplateRel) []cloudserver.SGTemplateRelDiff
+	// PushTemplate reconcile rules of each linked resource to the template.
+	PushTemplate(kt *kit.Kit, tpl *coresgt.SecurityGroupTemplate, rels []coresgt.SecurityGroupTemplateRel) (
+		*cloudserver.SGTemplatePushResult, error)
+	// EvaluateCvmAccess evaluate the effective inbound and outbound network access of cvm, and answer queries
+	// such as whether the cvm is reachable from the internet on a port.
+	EvaluateCvmAccess(kt *kit.Kit, cvmID string, queries []cloudserver.AccessQuery) (*cloudserver.CvmNetworkAccess,
+		error)
+}
+
+// NewSecurityGroup new security group logics.
+func NewSecurityGroup(client *client.ClientSet) Interface {
+	return &securityGroup{
+		client: client,
+	}
+}
+
+type securityGroup struct {
+	client *client.ClientSet
+}
+
+// AnalyzeSecurityGroup analyze rules of security groups.
+func (s *securityGroup) AnalyzeSecurityGroup(kt *kit.Kit, sgIDs []string) ([]cloudserver.SecurityGroupAnalysis,
+	error) {
+
+	sgs, err := s.listSecurityGroup(kt, tools.ContainersExpression("id", sgIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.analyzeSecurityGroups(kt, sgs)
+}
+
+func (s *securityGroup) analyzeSecurityGroups(kt *kit.Kit, sgs []corecloud.BaseSecurityGroup) (
+	[]cloudserver.SecurityGroupAnalysis, error) {
+
+	result := make([]cloudserver.SecurityGroupAnalysis, 0, len(sgs))
+	for _, sg := range sgs {
+		rules, err := s.listRule(kt, sg.Vendor, sg.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		analysis := newAnalysis(rules)
+		analysis.ResType = enumor.SecurityGroupCloudResType
+		analysis.ID = sg.ID
+		analysis.CloudID = sg.CloudID
+		analysis.Name = sg.Name
+		analysis.Vendor = sg.Vendor
+		analysis.AccountID = sg.AccountID
+		analysis.Region = sg.Region
+		analysis.BkBizID = sg.BkBizID
+		result = append(result, analysis)
+	}
+
+	return result, nil
+}
+
+// analyzeGcpFirewall analyze gcp firewall rules by vpc.
+func (s *securityGroup) analyzeGcpFirewall(kt *kit.Kit, fwRules []corecloud.GcpFirewallRule) (
+	[]cloudserver.SecurityGroupAnalysis, error) {
+
+	if len(fwRules) == 0 {
+		return make([]cloudserver.SecurityGroupAnalysis, 0), nil
+	}
+
+	vpcRules := make(map[string][]sgrule.Rule)
+	vpcIDs := make([]string, 0)
+	for _, one := range fwRules {
+		if _, exists := vpcRules[one.VpcId]; !exists {
+			vpcIDs = append(vpcIDs, one.VpcId)
+		}
+		vpcRules[one.VpcId] = append(vpcRules[one.VpcId], normalizeGcpRule(one)...)
+	}
+
+	vpcs, err := s.listVpc(kt, vpcIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcMap := make(map[string]corecloud.BaseVpc, len(vpcs))
+	for _, vpc := range vpcs {
+		vpcMap[vpc.ID] = vpc
+	}
+
+	result := make([]cloudserver.SecurityGroupAnalysis, 0, len(vpcIDs))
+	for _, vpcID := range vpcIDs {
+		analysis := newAnalysis(vpcRules[vpcID])
+		analysis.ResType = enumor.GcpFirewallRuleCloudResType
+		analysis.ID = vpcID
+		analysis.Vendor = enumor.Gcp
+		if vpc, exists := vpcMap[vpcID]; exists {
+			analysis.CloudID = vpc.CloudID
+			analysis.Name = vpc.Name
+			analysis.AccountID = vpc.AccountID
+			analysis.Region = vpc.Region
+			analysis.BkBizID = vpc.BkBizID
+		}
+		result = append(result, analysis)
+	}
+
+	return result, nil
+}
+
+func newAnalysis(rules []sgrule.Rule) cloudserver.SecurityGroupAnalysis {
+	findings := sgrule.Analyze(rules)
+	return cloudserver.SecurityGroupAnalysis{
+		RuleCount:   len(rules),
+		Score:       sgrule.Score(findings),
+		MaxSeverity: sgrule.MaxSeverity(findings),
+		Findings:    findings,
+	}
+}
+
+func (s *securityGroup) listSecurityGroup(kt *kit.Kit, expr *filter.Expression) ([]corecloud.BaseSecurityGroup,
+	error) {
+
+	listReq := &dataproto.SecurityGroupListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+	}
+	result := make([]corecloud.BaseSecurityGroup, 0)
+	for {
+		resp, err := s.client.DataService().Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list security group failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, resp.Details...)
+		if len(resp.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return result, nil
+}
+
+func (s *securityGroup) listVpc(kt *kit.Kit, ids []string) ([]corecloud.BaseVpc, error) {
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result := make([]corecloud.BaseVpc, 0, len(ids))
+	for {
+		resp, err := s.client.DataService().Global.Vpc.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list vpc failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, resp.Details...)
+		if len(resp.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return result, nil
+}
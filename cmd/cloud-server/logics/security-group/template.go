@@ -0,0 +1,367 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"fmt"
+	"strings"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	coresgt "hcm/pkg/api/core/cloud/sg-template"
+	protosgt "hcm/pkg/api/data-service/cloud/sg-template"
+	hcproto "hcm/pkg/api/hc-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/sgrule"
+	"hcm/pkg/tools/slice"
+)
+
+// PreviewTemplate returns the rules to be added and removed on each linked resource if the template is pushed.
+func (s *securityGroup) PreviewTemplate(kt *kit.Kit, tpl *coresgt.SecurityGroupTemplate,
+	rels []coresgt.SecurityGroupTemplateRel) []cloudserver.SGTemplateRelDiff {
+
+	result := make([]cloudserver.SGTemplateRelDiff, 0, len(rels))
+	for _, rel := range rels {
+		detail := cloudserver.SGTemplateRelDiff{
+			RelID:   rel.ID,
+			Vendor:  rel.Vendor,
+			ResType: rel.ResType,
+			ResID:   rel.ResID,
+			Version: rel.Version,
+		}
+
+		diff, err := s.diffTemplate(kt, tpl, rel)
+		if err != nil {
+			detail.Error = err.Error()
+			result = append(result, detail)
+			continue
+		}
+
+		detail.Added = diff.Added
+		detail.Removed = diff.Removed
+		detail.Unchanged = diff.Unchanged
+		result = append(result, detail)
+	}
+
+	return result
+}
+
+// PushTemplate reconcile rules of each linked resource to the template, and record the synced template version.
+// push of a resource failed does not stop the others, the error is returned in its result.
+func (s *securityGroup) PushTemplate(kt *kit.Kit, tpl *coresgt.SecurityGroupTemplate,
+	rels []coresgt.SecurityGroupTemplateRel) (*cloudserver.SGTemplatePushResult, error) {
+
+	result := &cloudserver.SGTemplatePushResult{
+		TemplateVersion: tpl.Version,
+		Details:         make([]cloudserver.SGTemplateRelPushResult, 0, len(rels)),
+	}
+	synced := make([]protosgt.SGTemplateRelUpdateReq, 0, len(rels))
+	for _, rel := range rels {
+		detail := cloudserver.SGTemplateRelPushResult{
+			RelID:   rel.ID,
+			ResType: rel.ResType,
+			ResID:   rel.ResID,
+		}
+
+		diff, err := s.diffTemplate(kt, tpl, rel)
+		if err == nil {
+			err = s.applyTemplateDiff(kt, rel, diff)
+		}
+		if err != nil {
+			logs.Errorf("push security group template failed, err: %v, template: %s, rel: %+v, rid: %s", err,
+				tpl.ID, rel, kt.Rid)
+			detail.Error = err.Error()
+			result.Details = append(result.Details, detail)
+			continue
+		}
+
+		detail.Added = len(diff.Added)
+		detail.Removed = len(diff.Removed)
+		result.Details = append(result.Details, detail)
+		if rel.Version != tpl.Version {
+			synced = append(synced, protosgt.SGTemplateRelUpdateReq{ID: rel.ID, Version: tpl.Version})
+		}
+	}
+
+	for _, batch := range slice.Split(synced, constant.BatchOperationMaxLimit) {
+		updateReq := &protosgt.SGTemplateRelBatchUpdateReq{Rels: batch}
+		if err := s.client.DataService().Global.SGTemplate.BatchUpdateSGTemplateRel(kt.Ctx, kt.Header(),
+			updateReq); err != nil {
+			logs.Errorf("update security group template rel version failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (s *securityGroup) diffTemplate(kt *kit.Kit, tpl *coresgt.SecurityGroupTemplate,
+	rel coresgt.SecurityGroupTemplateRel) (sgrule.DiffResult, error) {
+
+	desired, withPriority, err := translateTemplate(rel.Vendor, tpl.Rules)
+	if err != nil {
+		return sgrule.DiffResult{}, err
+	}
+
+	var actual []sgrule.Rule
+	if rel.Vendor == enumor.Gcp {
+		actual, err = s.listGcpTemplateRule(kt, rel)
+	} else {
+		actual, err = s.listRule(kt, rel.Vendor, rel.ResID)
+	}
+	if err != nil {
+		return sgrule.DiffResult{}, err
+	}
+
+	return sgrule.Diff(desired, actual, withPriority), nil
+}
+
+// listGcpTemplateRule list firewall rules of the vpc created by the template rel, other firewall rules of the
+// vpc are not managed by the template.
+func (s *securityGroup) listGcpTemplateRule(kt *kit.Kit, rel coresgt.SecurityGroupTemplateRel) ([]sgrule.Rule,
+	error) {
+
+	fwRules, err := s.listGcpFirewallRule(kt, tools.EqualExpression("vpc_id", rel.ResID))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := gcpTemplateRulePrefix(rel.ID)
+	rules := make([]sgrule.Rule, 0)
+	for _, one := range fwRules {
+		if strings.HasPrefix(one.Name, prefix) {
+			rules = append(rules, normalizeGcpRule(one)...)
+		}
+	}
+
+	return rules, nil
+}
+
+// applyTemplateDiff delete the removed rules before creating the added rules, so that the priority and name of
+// removed rules can be reused.
+func (s *securityGroup) applyTemplateDiff(kt *kit.Kit, rel coresgt.SecurityGroupTemplateRel,
+	diff sgrule.DiffResult) error {
+
+	removedIDs := make([]string, 0, len(diff.Removed))
+	for _, one := range diff.Removed {
+		removedIDs = append(removedIDs, one.ID)
+	}
+
+	for _, id := range slice.Unique(removedIDs) {
+		if err := s.deleteTemplateRule(kt, rel, id); err != nil {
+			return err
+		}
+	}
+
+	if len(diff.Added) == 0 {
+		return nil
+	}
+
+	switch rel.Vendor {
+	case enumor.TCloud:
+		return s.createTCloudTemplateRule(kt, rel, diff.Added)
+	case enumor.Aws:
+		return s.createAwsTemplateRule(kt, rel, diff.Added)
+	case enumor.HuaWei:
+		return s.createHuaWeiTemplateRule(kt, rel, diff.Added)
+	case enumor.Azure:
+		return s.createAzureTemplateRule(kt, rel, diff.Added)
+	case enumor.Gcp:
+		return s.createGcpTemplateRule(kt, rel, diff.Added)
+	default:
+		return fmt.Errorf("vendor: %s does not support security group template", rel.Vendor)
+	}
+}
+
+func (s *securityGroup) deleteTemplateRule(kt *kit.Kit, rel coresgt.SecurityGroupTemplateRel, id string) error {
+	var err error
+	switch rel.Vendor {
+	case enumor.TCloud:
+		err = s.client.HCService().TCloud.SecurityGroup.DeleteSecurityGroupRule(kt.Ctx, kt.Header(), rel.ResID, id)
+	case enumor.Aws:
+		err = s.client.HCService().Aws.SecurityGroup.DeleteSecurityGroupRule(kt.Ctx, kt.Header(), rel.ResID, id)
+	case enumor.HuaWei:
+		err = s.client.HCService().HuaWei.SecurityGroup.DeleteSecurityGroupRule(kt.Ctx, kt.Header(), rel.ResID, id)
+	case enumor.Azure:
+		err = s.client.HCService().Azure.SecurityGroup.DeleteSecurityGroupRule(kt.Ctx, kt.Header(), rel.ResID, id)
+	case enumor.Gcp:
+		err = s.client.HCService().Gcp.Firewall.DeleteFirewallRule(kt.Ctx, kt.Header(), id)
+	default:
+		return fmt.Errorf("vendor: %s does not support security group template", rel.Vendor)
+	}
+	if err != nil {
+		logs.Errorf("delete %s template rule failed, err: %v, res: %s, rule: %s, rid: %s", rel.Vendor, err,
+			rel.ResID, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// splitDirection split rules by direction, because batch create api of security group rule only accepts rules
+// of one direction.
+func splitDirection(rules []sgrule.Rule) (ingress []sgrule.Rule, egress []sgrule.Rule) {
+	for _, one := range rules {
+		if one.Direction == enumor.Egress {
+			egress = append(egress, one)
+			continue
+		}
+		ingress = append(ingress, one)
+	}
+
+	return ingress, egress
+}
+
+func (s *securityGroup) createTCloudTemplateRule(kt *kit.Kit, rel coresgt.SecurityGroupTemplateRel,
+	rules []sgrule.Rule) error {
+
+	ingress, egress := splitDirection(rules)
+	for _, set := range [][]sgrule.Rule{ingress, egress} {
+		if len(set) == 0 {
+			continue
+		}
+
+		req := &hcproto.TCloudSGRuleCreateReq{AccountID: rel.AccountID}
+		for _, one := range set {
+			if one.Direction == enumor.Egress {
+				req.EgressRuleSet = append(req.EgressRuleSet, toTCloudRuleCreate(one))
+			} else {
+				req.IngressRuleSet = append(req.IngressRuleSet, toTCloudRuleCreate(one))
+			}
+		}
+
+		_, err := s.client.HCService().TCloud.SecurityGroup.BatchCreateSecurityGroupRule(kt.Ctx, kt.Header(),
+			rel.ResID, req)
+		if err != nil {
+			logs.Errorf("create tcloud template rule failed, err: %v, sg: %s, rid: %s", err, rel.ResID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *securityGroup) createAwsTemplateRule(kt *kit.Kit, rel coresgt.SecurityGroupTemplateRel,
+	rules []sgrule.Rule) error {
+
+	ingress, egress := splitDirection(rules)
+	for _, set := range [][]sgrule.Rule{ingress, egress} {
+		if len(set) == 0 {
+			continue
+		}
+
+		req := &hcproto.AwsSGRuleCreateReq{AccountID: rel.AccountID}
+		for _, one := range set {
+			if one.Direction == enumor.Egress {
+				req.EgressRuleSet = append(req.EgressRuleSet, toAwsRuleCreate(one))
+			} else {
+				req.IngressRuleSet = append(req.IngressRuleSet, toAwsRuleCreate(one))
+			}
+		}
+
+		_, err := s.client.HCService().Aws.SecurityGroup.BatchCreateSecurityGroupRule(kt.Ctx, kt.Header(),
+			rel.ResID, req)
+		if err != nil {
+			logs.Errorf("create aws template rule failed, err: %v, sg: %s, rid: %s", err, rel.ResID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *securityGroup) createHuaWeiTemplateRule(kt *kit.Kit, rel coresgt.SecurityGroupTemplateRel,
+	rules []sgrule.Rule) error {
+
+	for _, one := range rules {
+		req := &hcproto.HuaWeiSGRuleCreateReq{AccountID: rel.AccountID}
+		if one.Direction == enumor.Egress {
+			req.EgressRule = toHuaWeiRuleCreate(one)
+		} else {
+			req.IngressRule = toHuaWeiRuleCreate(one)
+		}
+
+		_, err := s.client.HCService().HuaWei.SecurityGroup.CreateSecurityGroupRule(kt.Ctx, kt.Header(),
+			rel.ResID, req)
+		if err != nil {
+			logs.Errorf("create huawei template rule failed, err: %v, sg: %s, rid: %s", err, rel.ResID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *securityGroup) createAzureTemplateRule(kt *kit.Kit, rel coresgt.SecurityGroupTemplateRel,
+	rules []sgrule.Rule) error {
+
+	ingress, egress := splitDirection(rules)
+	for _, set := range [][]sgrule.Rule{ingress, egress} {
+		if len(set) == 0 {
+			continue
+		}
+
+		req := &hcproto.AzureSGRuleCreateReq{AccountID: rel.AccountID}
+		for _, one := range set {
+			if one.Direction == enumor.Egress {
+				req.EgressRuleSet = append(req.EgressRuleSet, toAzureRuleCreate(one))
+			} else {
+				req.IngressRuleSet = append(req.IngressRuleSet, toAzureRuleCreate(one))
+			}
+		}
+
+		_, err := s.client.HCService().Azure.SecurityGroup.BatchCreateSecurityGroupRule(kt.Ctx, kt.Header(),
+			rel.ResID, req)
+		if err != nil {
+			logs.Errorf("create azure template rule failed, err: %v, sg: %s, rid: %s", err, rel.ResID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *securityGroup) createGcpTemplateRule(kt *kit.Kit, rel coresgt.SecurityGroupTemplateRel,
+	rules []sgrule.Rule) error {
+
+	vpcs, err := s.listVpc(kt, []string{rel.ResID})
+	if err != nil {
+		return err
+	}
+
+	if len(vpcs) == 0 {
+		return errf.Newf(errf.RecordNotFound, "vpc: %s not found", rel.ResID)
+	}
+
+	for _, one := range rules {
+		req := toGcpFirewallRuleCreate(rel, vpcs[0], one)
+		if _, err = s.client.HCService().Gcp.Firewall.CreateFirewallRule(kt.Ctx, kt.Header(), req); err != nil {
+			logs.Errorf("create gcp template firewall rule failed, err: %v, name: %s, rid: %s", err, req.Name,
+				kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
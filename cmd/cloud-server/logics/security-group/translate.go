@@ -0,0 +1,300 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	coresgt "hcm/pkg/api/core/cloud/sg-template"
+	hcproto "hcm/pkg/api/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/sgrule"
+)
+
+const (
+	// azureTemplateBasePriority azure rule priority ranges from 100 to 4096, template rules take 100-1099.
+	azureTemplateBasePriority = 100
+	// gcpTemplateBasePriority gcp default firewall rule priority is 1000, template rules take 1000-1999.
+	gcpTemplateBasePriority = 1000
+	// rulesPerTemplatePriority is the max count of rules with the same direction and template priority, because
+	// priority of azure and gcp rules must be unique, each template priority is expanded to this many priorities.
+	rulesPerTemplatePriority = 10
+)
+
+// translateTemplate translate template rules to the rules which should exist on the vendor, the returned bool
+// reports whether the priority of rules is configurable and should be compared.
+func translateTemplate(vendor enumor.Vendor, templateRules []coresgt.TemplateRule) ([]sgrule.Rule, bool, error) {
+	sorted := append(make([]coresgt.TemplateRule, 0, len(templateRules)), templateRules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+
+	rules := make([]sgrule.Rule, 0, len(sorted))
+	for _, one := range sorted {
+		rules = append(rules, one.ToRule())
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return splitPorts(rules), false, nil
+	case enumor.Aws:
+		for _, one := range rules {
+			if one.Action == sgrule.Deny {
+				return nil, false, fmt.Errorf("aws security group does not support deny rule, cidr: %v, "+
+					"priority: %d", one.Cidrs, one.Priority)
+			}
+		}
+		return splitPorts(rules), false, nil
+	case enumor.HuaWei:
+		return splitPorts(rules), true, nil
+	case enumor.Azure:
+		if err := rankPriority(rules, azureTemplateBasePriority); err != nil {
+			return nil, false, err
+		}
+		return rules, true, nil
+	case enumor.Gcp:
+		if err := rankPriority(rules, gcpTemplateBasePriority); err != nil {
+			return nil, false, err
+		}
+		return rules, true, nil
+	default:
+		return nil, false, fmt.Errorf("vendor: %s does not support security group template", vendor)
+	}
+}
+
+// splitPorts split rule with multiple port ranges to one rule per port range, because port expression with
+// multiple ranges is not supported by all vendors.
+func splitPorts(rules []sgrule.Rule) []sgrule.Rule {
+	result := make([]sgrule.Rule, 0, len(rules))
+	for _, one := range rules {
+		if len(one.Ports) <= 1 {
+			result = append(result, one)
+			continue
+		}
+
+		for _, port := range one.Ports {
+			rule := one
+			rule.Ports = []sgrule.PortRange{port}
+			result = append(result, rule)
+		}
+	}
+
+	return result
+}
+
+// rankPriority convert template priority to unique vendor priority, rules are sorted by template priority.
+func rankPriority(rules []sgrule.Rule, base int64) error {
+	counts := make(map[string]int64)
+	for index := range rules {
+		key := fmt.Sprintf("%s-%d", rules[index].Direction, rules[index].Priority)
+		rank := counts[key]
+		if rank >= rulesPerTemplatePriority {
+			return fmt.Errorf("%s rules with priority %d should <= %d", rules[index].Direction,
+				rules[index].Priority, rulesPerTemplatePriority)
+		}
+		counts[key] = rank + 1
+
+		rules[index].Priority = base + (rules[index].Priority-1)*rulesPerTemplatePriority + rank
+	}
+
+	return nil
+}
+
+func isIPv6Cidr(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
+
+func templateCidr(rule sgrule.Rule) string {
+	if len(rule.Cidrs) == 0 {
+		return ""
+	}
+	return rule.Cidrs[0]
+}
+
+func toTCloudRuleCreate(rule sgrule.Rule) hcproto.TCloudSGRuleCreate {
+	port := "ALL"
+	if len(rule.Ports) != 0 {
+		port = sgrule.FormatPorts(rule.Ports)
+	}
+
+	create := hcproto.TCloudSGRuleCreate{
+		Protocol: converter.ValToPtr(strings.ToUpper(rule.Protocol)),
+		Port:     converter.ValToPtr(port),
+		Action:   "ACCEPT",
+	}
+	if rule.Action == sgrule.Deny {
+		create.Action = "DROP"
+	}
+
+	cidr := templateCidr(rule)
+	if isIPv6Cidr(cidr) {
+		create.IPv6Cidr = converter.ValToPtr(cidr)
+	} else {
+		create.IPv4Cidr = converter.ValToPtr(cidr)
+	}
+
+	return create
+}
+
+func toAwsRuleCreate(rule sgrule.Rule) hcproto.AwsSGRuleCreate {
+	create := hcproto.AwsSGRuleCreate{
+		Protocol: converter.ValToPtr(rule.Protocol),
+	}
+
+	switch rule.Protocol {
+	case sgrule.ProtocolAll:
+		create.Protocol = converter.ValToPtr("-1")
+	case sgrule.ProtocolICMP:
+		create.FromPort = converter.ValToPtr(int64(-1))
+		create.ToPort = converter.ValToPtr(int64(-1))
+	default:
+		create.FromPort = converter.ValToPtr(int64(0))
+		create.ToPort = converter.ValToPtr(int64(65535))
+		if len(rule.Ports) != 0 {
+			create.FromPort = converter.ValToPtr(int64(rule.Ports[0].From))
+			create.ToPort = converter.ValToPtr(int64(rule.Ports[0].To))
+		}
+	}
+
+	cidr := templateCidr(rule)
+	if isIPv6Cidr(cidr) {
+		create.IPv6Cidr = converter.ValToPtr(cidr)
+	} else {
+		create.IPv4Cidr = converter.ValToPtr(cidr)
+	}
+
+	return create
+}
+
+func toHuaWeiRuleCreate(rule sgrule.Rule) *hcproto.HuaWeiSGRuleCreate {
+	cidr := templateCidr(rule)
+	create := &hcproto.HuaWeiSGRuleCreate{
+		Ethertype:      converter.ValToPtr("IPv4"),
+		RemoteIPPrefix: converter.ValToPtr(cidr),
+		Action:         converter.ValToPtr(string(rule.Action)),
+		Priority:       rule.Priority,
+	}
+	if isIPv6Cidr(cidr) {
+		create.Ethertype = converter.ValToPtr("IPv6")
+	}
+
+	if rule.Protocol != sgrule.ProtocolAll {
+		create.Protocol = converter.ValToPtr(rule.Protocol)
+	}
+
+	if len(rule.Ports) != 0 {
+		create.Port = converter.ValToPtr(sgrule.FormatPorts(rule.Ports))
+	}
+
+	return create
+}
+
+func toAzureRuleCreate(rule sgrule.Rule) hcproto.AzureSGRuleCreate {
+	create := hcproto.AzureSGRuleCreate{
+		// azure rule name must be unique in security group, priority of template rules is unique too.
+		Name:                     fmt.Sprintf("hcm-%s-%d", rule.Direction, rule.Priority),
+		DestinationAddressPrefix: converter.ValToPtr("*"),
+		DestinationPortRange:     converter.ValToPtr("*"),
+		SourceAddressPrefix:      converter.ValToPtr("*"),
+		SourcePortRange:          converter.ValToPtr("*"),
+		Priority:                 int32(rule.Priority),
+		Type:                     rule.Direction,
+		Access:                   "Allow",
+	}
+	if rule.Action == sgrule.Deny {
+		create.Access = "Deny"
+	}
+
+	switch rule.Protocol {
+	case sgrule.ProtocolAll:
+		create.Protocol = "*"
+	default:
+		create.Protocol = strings.ToUpper(rule.Protocol[:1]) + rule.Protocol[1:]
+	}
+
+	if len(rule.Ports) == 1 {
+		create.DestinationPortRange = converter.ValToPtr(sgrule.FormatPorts(rule.Ports))
+	}
+
+	if len(rule.Ports) > 1 {
+		create.DestinationPortRange = nil
+		for _, port := range strings.Split(sgrule.FormatPorts(rule.Ports), ",") {
+			create.DestinationPortRanges = append(create.DestinationPortRanges, converter.ValToPtr(port))
+		}
+	}
+
+	if rule.Direction == enumor.Egress {
+		create.DestinationAddressPrefix = converter.ValToPtr(templateCidr(rule))
+	} else {
+		create.SourceAddressPrefix = converter.ValToPtr(templateCidr(rule))
+	}
+
+	return create
+}
+
+// gcpTemplateRulePrefix returns the name prefix of gcp firewall rules created by the template rel, firewall rules
+// of the vpc with this prefix are managed by the template.
+func gcpTemplateRulePrefix(relID string) string {
+	return fmt.Sprintf("hcm-sgt-%s-", strings.ToLower(relID))
+}
+
+func toGcpFirewallRuleCreate(rel coresgt.SecurityGroupTemplateRel, vpc corecloud.BaseVpc,
+	rule sgrule.Rule) *hcproto.GcpFirewallRuleCreateReq {
+
+	create := &hcproto.GcpFirewallRuleCreateReq{
+		BkBizID:    vpc.BkBizID,
+		AccountID:  rel.AccountID,
+		CloudVpcID: vpc.CloudID,
+		Type:       "INGRESS",
+		// priority of template rules is unique in each direction.
+		Name:     fmt.Sprintf("%sin-%d", gcpTemplateRulePrefix(rel.ID), rule.Priority),
+		Priority: rule.Priority,
+	}
+	if rule.Direction == enumor.Egress {
+		create.Type = "EGRESS"
+		create.Name = fmt.Sprintf("%seg-%d", gcpTemplateRulePrefix(rel.ID), rule.Priority)
+		create.DestinationRanges = rule.Cidrs
+	} else {
+		create.SourceRanges = rule.Cidrs
+	}
+
+	if len(rel.TargetTag) != 0 {
+		create.TargetTags = []string{rel.TargetTag}
+	}
+
+	set := corecloud.GcpProtocolSet{Protocol: rule.Protocol}
+	if len(rule.Ports) != 0 {
+		set.Port = strings.Split(sgrule.FormatPorts(rule.Ports), ",")
+	}
+
+	if rule.Action == sgrule.Deny {
+		create.Denied = []corecloud.GcpProtocolSet{set}
+	} else {
+		create.Allowed = []corecloud.GcpProtocolSet{set}
+	}
+
+	return create
+}
@@ -0,0 +1,259 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"reflect"
+	"testing"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	coresgt "hcm/pkg/api/core/cloud/sg-template"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/sgrule"
+)
+
+func TestTranslateTemplatePriority(t *testing.T) {
+	templateRules := []coresgt.TemplateRule{
+		{Type: enumor.Ingress, Action: sgrule.Allow, Priority: 2, Protocol: "tcp", Port: "22", Cidr: "10.0.0.0/8"},
+		{Type: enumor.Ingress, Action: sgrule.Deny, Priority: 1, Protocol: "all", Cidr: "0.0.0.0/0"},
+		{Type: enumor.Ingress, Action: sgrule.Allow, Priority: 2, Protocol: "udp", Port: "53", Cidr: "10.0.0.0/8"},
+		{Type: enumor.Egress, Action: sgrule.Allow, Priority: 2, Protocol: "all", Cidr: "0.0.0.0/0"},
+	}
+
+	cases := []struct {
+		vendor enumor.Vendor
+		expect []int64
+	}{
+		// 按模板优先级排序，同方向同优先级的规则依次占用展开后的优先级
+		{vendor: enumor.Azure, expect: []int64{100, 110, 111, 110}},
+		{vendor: enumor.Gcp, expect: []int64{1000, 1010, 1011, 1010}},
+	}
+
+	for _, c := range cases {
+		rules, withPriority, err := translateTemplate(c.vendor, templateRules)
+		if err != nil {
+			t.Errorf("translate %s template failed, err: %v", c.vendor, err)
+			continue
+		}
+
+		if !withPriority {
+			t.Errorf("priority of %s rules should be compared", c.vendor)
+		}
+
+		priorities := make([]int64, 0, len(rules))
+		for _, one := range rules {
+			priorities = append(priorities, one.Priority)
+		}
+
+		if !reflect.DeepEqual(priorities, c.expect) {
+			t.Errorf("%s priorities %v are not as expected %v", c.vendor, priorities, c.expect)
+		}
+	}
+}
+
+func TestTranslateTemplateVendor(t *testing.T) {
+	templateRules := []coresgt.TemplateRule{
+		{Type: enumor.Ingress, Action: sgrule.Allow, Priority: 1, Protocol: "tcp", Port: "22,80-90",
+			Cidr: "10.0.0.0/8"},
+	}
+
+	for _, vendor := range []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei} {
+		rules, _, err := translateTemplate(vendor, templateRules)
+		if err != nil {
+			t.Errorf("translate %s template failed, err: %v", vendor, err)
+			continue
+		}
+
+		if len(rules) != 2 {
+			t.Errorf("rule with 2 port ranges of %s should be split to 2 rules, got %+v", vendor, rules)
+		}
+	}
+
+	denyRules := []coresgt.TemplateRule{
+		{Type: enumor.Ingress, Action: sgrule.Deny, Priority: 1, Protocol: "all", Cidr: "0.0.0.0/0"},
+	}
+	if _, _, err := translateTemplate(enumor.Aws, denyRules); err == nil {
+		t.Errorf("aws template with deny rule should fail")
+	}
+
+	if _, _, err := translateTemplate(enumor.Vendor("unknown"), templateRules); err == nil {
+		t.Errorf("template of unsupported vendor should fail")
+	}
+}
+
+func TestSplitPorts(t *testing.T) {
+	rules := splitPorts([]sgrule.Rule{
+		{ID: "1", Protocol: sgrule.ProtocolTCP, Ports: []sgrule.PortRange{{From: 22, To: 22}, {From: 80, To: 90}}},
+		{ID: "2", Protocol: sgrule.ProtocolUDP, Ports: []sgrule.PortRange{{From: 53, To: 53}}},
+		{ID: "3", Protocol: sgrule.ProtocolAll},
+	})
+
+	expect := []sgrule.Rule{
+		{ID: "1", Protocol: sgrule.ProtocolTCP, Ports: []sgrule.PortRange{{From: 22, To: 22}}},
+		{ID: "1", Protocol: sgrule.ProtocolTCP, Ports: []sgrule.PortRange{{From: 80, To: 90}}},
+		{ID: "2", Protocol: sgrule.ProtocolUDP, Ports: []sgrule.PortRange{{From: 53, To: 53}}},
+		{ID: "3", Protocol: sgrule.ProtocolAll},
+	}
+	if !reflect.DeepEqual(rules, expect) {
+		t.Errorf("split rules %+v are not as expected %+v", rules, expect)
+	}
+}
+
+func TestRankPriority(t *testing.T) {
+	rules := make([]sgrule.Rule, 0)
+	for i := 0; i < rulesPerTemplatePriority; i++ {
+		rules = append(rules, sgrule.Rule{Direction: enumor.Ingress, Priority: 3})
+	}
+
+	if err := rankPriority(rules, azureTemplateBasePriority); err != nil {
+		t.Fatalf("rank priority failed, err: %v", err)
+	}
+
+	if rules[0].Priority != 120 || rules[rulesPerTemplatePriority-1].Priority != 129 {
+		t.Errorf("priorities should be 120-129, got %d-%d", rules[0].Priority,
+			rules[rulesPerTemplatePriority-1].Priority)
+	}
+
+	rules = append(rules, sgrule.Rule{Direction: enumor.Egress, Priority: 3})
+	for idx := range rules {
+		rules[idx].Priority = 3
+	}
+	if err := rankPriority(rules, azureTemplateBasePriority); err != nil {
+		t.Errorf("rules with different direction should not exceed the limit, err: %v", err)
+	}
+
+	rules = append(rules, sgrule.Rule{Direction: enumor.Ingress, Priority: 3})
+	for idx := range rules {
+		rules[idx].Priority = 3
+	}
+	if err := rankPriority(rules, azureTemplateBasePriority); err == nil {
+		t.Errorf("rules with the same direction and priority exceeding the limit should fail")
+	}
+}
+
+func TestToTCloudRuleCreate(t *testing.T) {
+	create := toTCloudRuleCreate(sgrule.Rule{Action: sgrule.Deny, Protocol: sgrule.ProtocolTCP,
+		Ports: []sgrule.PortRange{{From: 80, To: 90}}, Cidrs: []string{"fd00::/64"}})
+
+	if *create.Protocol != "TCP" || *create.Port != "80-90" || create.Action != "DROP" {
+		t.Errorf("tcloud rule %+v is not as expected", create)
+	}
+
+	if create.IPv4Cidr != nil || converter.PtrToVal(create.IPv6Cidr) != "fd00::/64" {
+		t.Errorf("ipv6 cidr of tcloud rule is not set correctly")
+	}
+
+	create = toTCloudRuleCreate(sgrule.Rule{Action: sgrule.Allow, Protocol: sgrule.ProtocolAll,
+		Cidrs: []string{"10.0.0.0/8"}})
+	if *create.Port != "ALL" || create.Action != "ACCEPT" || converter.PtrToVal(create.IPv4Cidr) != "10.0.0.0/8" {
+		t.Errorf("tcloud rule %+v is not as expected", create)
+	}
+}
+
+func TestToAwsRuleCreate(t *testing.T) {
+	create := toAwsRuleCreate(sgrule.Rule{Protocol: sgrule.ProtocolTCP,
+		Ports: []sgrule.PortRange{{From: 80, To: 90}}, Cidrs: []string{"10.0.0.0/8"}})
+	if *create.FromPort != 80 || *create.ToPort != 90 || converter.PtrToVal(create.IPv4Cidr) != "10.0.0.0/8" {
+		t.Errorf("aws tcp rule %+v is not as expected", create)
+	}
+
+	create = toAwsRuleCreate(sgrule.Rule{Protocol: sgrule.ProtocolAll, Cidrs: []string{"fd00::/64"}})
+	if *create.Protocol != "-1" || create.FromPort != nil || converter.PtrToVal(create.IPv6Cidr) != "fd00::/64" {
+		t.Errorf("aws all protocol rule %+v is not as expected", create)
+	}
+
+	create = toAwsRuleCreate(sgrule.Rule{Protocol: sgrule.ProtocolICMP, Cidrs: []string{"10.0.0.0/8"}})
+	if *create.FromPort != -1 || *create.ToPort != -1 {
+		t.Errorf("aws icmp rule %+v is not as expected", create)
+	}
+}
+
+func TestToHuaWeiRuleCreate(t *testing.T) {
+	create := toHuaWeiRuleCreate(sgrule.Rule{Action: sgrule.Allow, Priority: 5, Protocol: sgrule.ProtocolTCP,
+		Ports: []sgrule.PortRange{{From: 22, To: 22}, {From: 80, To: 90}}, Cidrs: []string{"fd00::/64"}})
+
+	if *create.Ethertype != "IPv6" || *create.Protocol != "tcp" || *create.Port != "22,80-90" ||
+		*create.Action != "allow" || create.Priority != 5 {
+		t.Errorf("huawei rule %+v is not as expected", create)
+	}
+
+	create = toHuaWeiRuleCreate(sgrule.Rule{Action: sgrule.Deny, Protocol: sgrule.ProtocolAll,
+		Cidrs: []string{"10.0.0.0/8"}})
+	if *create.Ethertype != "IPv4" || create.Protocol != nil || create.Port != nil {
+		t.Errorf("huawei all protocol rule %+v is not as expected", create)
+	}
+}
+
+func TestToAzureRuleCreate(t *testing.T) {
+	create := toAzureRuleCreate(sgrule.Rule{Direction: enumor.Ingress, Action: sgrule.Deny, Priority: 110,
+		Protocol: sgrule.ProtocolTCP, Ports: []sgrule.PortRange{{From: 22, To: 22}, {From: 80, To: 90}},
+		Cidrs: []string{"10.0.0.0/8"}})
+
+	if create.Name != "hcm-ingress-110" || create.Priority != 110 || create.Access != "Deny" ||
+		create.Protocol != "Tcp" || *create.SourceAddressPrefix != "10.0.0.0/8" ||
+		*create.DestinationAddressPrefix != "*" {
+		t.Errorf("azure rule %+v is not as expected", create)
+	}
+
+	// 多个端口范围使用 DestinationPortRanges
+	ports := make([]string, 0, len(create.DestinationPortRanges))
+	for _, one := range create.DestinationPortRanges {
+		ports = append(ports, *one)
+	}
+	if create.DestinationPortRange != nil || !reflect.DeepEqual(ports, []string{"22", "80-90"}) {
+		t.Errorf("azure rule ports %v is not as expected", ports)
+	}
+
+	create = toAzureRuleCreate(sgrule.Rule{Direction: enumor.Egress, Action: sgrule.Allow, Priority: 100,
+		Protocol: sgrule.ProtocolAll, Cidrs: []string{"0.0.0.0/0"}})
+	if create.Protocol != "*" || create.Access != "Allow" || *create.DestinationPortRange != "*" ||
+		*create.DestinationAddressPrefix != "0.0.0.0/0" || *create.SourceAddressPrefix != "*" {
+		t.Errorf("azure egress rule %+v is not as expected", create)
+	}
+}
+
+func TestToGcpFirewallRuleCreate(t *testing.T) {
+	rel := coresgt.SecurityGroupTemplateRel{ID: "ABC", AccountID: "account", TargetTag: "web"}
+	vpc := corecloud.BaseVpc{CloudID: "vpc-1", BkBizID: 1}
+
+	create := toGcpFirewallRuleCreate(rel, vpc, sgrule.Rule{Direction: enumor.Ingress, Action: sgrule.Allow,
+		Priority: 1010, Protocol: sgrule.ProtocolTCP, Ports: []sgrule.PortRange{{From: 22, To: 22},
+			{From: 80, To: 90}}, Cidrs: []string{"10.0.0.0/8"}})
+
+	if create.Name != "hcm-sgt-abc-in-1010" || create.Type != "INGRESS" || create.Priority != 1010 ||
+		!reflect.DeepEqual(create.SourceRanges, []string{"10.0.0.0/8"}) ||
+		!reflect.DeepEqual(create.TargetTags, []string{"web"}) {
+		t.Errorf("gcp rule %+v is not as expected", create)
+	}
+
+	expect := []corecloud.GcpProtocolSet{{Protocol: "tcp", Port: []string{"22", "80-90"}}}
+	if !reflect.DeepEqual(create.Allowed, expect) || create.Denied != nil {
+		t.Errorf("gcp rule allowed %+v is not as expected %+v", create.Allowed, expect)
+	}
+
+	create = toGcpFirewallRuleCreate(rel, vpc, sgrule.Rule{Direction: enumor.Egress, Action: sgrule.Deny,
+		Priority: 1000, Protocol: sgrule.ProtocolAll, Cidrs: []string{"0.0.0.0/0"}})
+	if create.Name != "hcm-sgt-abc-eg-1000" || create.Type != "EGRESS" ||
+		!reflect.DeepEqual(create.DestinationRanges, []string{"0.0.0.0/0"}) ||
+		!reflect.DeepEqual(create.Denied, []corecloud.GcpProtocolSet{{Protocol: "all"}}) {
+		t.Errorf("gcp egress rule %+v is not as expected", create)
+	}
+}
@@ -21,7 +21,6 @@ package account
 
 import (
 	"encoding/json"
-	"fmt"
 
 	"hcm/cmd/cloud-server/service/common"
 	proto "hcm/pkg/api/cloud-server/account"
@@ -43,21 +42,7 @@ func (a *accountSvc) Check(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	var err error
-	switch req.Vendor {
-	case enumor.TCloud:
-		_, err = ParseAndCheckTCloudExtension(cts, a.client, req.Type, req.Extension)
-	case enumor.Aws:
-		_, err = ParseAndCheckAwsExtension(cts, a.client, req.Type, req.Extension)
-	case enumor.HuaWei:
-		_, err = ParseAndCheckHuaWeiExtension(cts, a.client, req.Type, req.Extension)
-	case enumor.Gcp:
-		_, err = ParseAndCheckGcpExtension(cts, a.client, req.Type, req.Extension)
-	case enumor.Azure:
-		_, err = ParseAndCheckAzureExtension(cts, a.client, req.Type, req.Extension)
-	default:
-		err = fmt.Errorf("no support vendor: %s", req.Vendor)
-	}
+	err := ParseAndCheckExtension(cts, a.client, req.Vendor, req.Type, req.Extension)
 
 	return nil, errf.NewFromErr(errf.InvalidParameter, err)
 }
@@ -259,21 +244,13 @@ func (a *accountSvc) CheckByID(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	switch baseInfo.Vendor {
-	case enumor.TCloud:
-		_, err = a.parseAndCheckTCloudExtensionByID(cts, accountID, req.Extension)
-	case enumor.Aws:
-		_, err = a.parseAndCheckAwsExtensionByID(cts, accountID, req.Extension)
-	case enumor.HuaWei:
-		_, err = a.parseAndCheckHuaWeiExtensionByID(cts, accountID, req.Extension)
-	case enumor.Gcp:
-		_, err = a.parseAndCheckGcpExtensionByID(cts, accountID, req.Extension)
-	case enumor.Azure:
-		_, err = a.parseAndCheckAzureExtensionByID(cts, accountID, req.Extension)
-	default:
-		err = fmt.Errorf("no support vendor: %s", baseInfo.Vendor)
+	handler, err := getVendorHandler(baseInfo.Vendor)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	err = handler.parseAndCheckByID(a, cts, accountID, req.Extension)
+
 	return nil, errf.NewFromErr(errf.InvalidParameter, err)
 }
 
@@ -482,3 +459,78 @@ func (a *accountSvc) parseAndCheckAzureExtensionByID(
 
 	return extension, nil
 }
+
+// ParseAndCheckOpenStackExtension ...
+func ParseAndCheckOpenStackExtension(
+	cts *rest.Contexts, client *client.ClientSet, accountType enumor.AccountType, reqExtension json.RawMessage,
+) (*proto.OpenStackAccountExtensionCreateReq, error) {
+	// 解析Extension
+	extension := new(proto.OpenStackAccountExtensionCreateReq)
+	if err := common.DecodeExtension(cts.Kit, reqExtension, extension); err != nil {
+		return nil, err
+	}
+	// 校验Extension
+	if err := extension.Validate(accountType); err != nil {
+		return nil, err
+	}
+
+	// 检查联通性，应用凭据是否有效且属于该项目
+	if accountType != enumor.RegistrationAccount || extension.IsFull() {
+		err := client.HCService().OpenStack.Account.Check(
+			cts.Kit.Ctx,
+			cts.Kit.Header(),
+			&hcproto.OpenStackAccountCheckReq{
+				CloudAuthUrl:   extension.CloudAuthUrl,
+				CloudProjectID: extension.CloudProjectID,
+				CloudSecretID:  extension.CloudSecretID,
+				CloudSecretKey: extension.CloudSecretKey,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return extension, nil
+}
+
+func (a *accountSvc) parseAndCheckOpenStackExtensionByID(
+	cts *rest.Contexts, accountID string, reqExtension json.RawMessage,
+) (*proto.OpenStackAccountExtensionUpdateReq, error) {
+	// 解析Extension
+	extension := new(proto.OpenStackAccountExtensionUpdateReq)
+	if err := common.DecodeExtension(cts.Kit, reqExtension, extension); err != nil {
+		return nil, err
+	}
+
+	// 查询账号其他信息
+	account, err := a.client.DataService().OpenStack.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 校验Extension
+	err = extension.Validate(account.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查联通性，账号是否正确
+	if account.Type != enumor.RegistrationAccount || extension.IsFull() {
+		err = a.client.HCService().OpenStack.Account.Check(
+			cts.Kit.Ctx,
+			cts.Kit.Header(),
+			&hcproto.OpenStackAccountCheckReq{
+				CloudAuthUrl:   account.Extension.CloudAuthUrl,
+				CloudProjectID: account.Extension.CloudProjectID,
+				CloudSecretID:  extension.CloudSecretID,
+				CloudSecretKey: extension.CloudSecretKey,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return extension, nil
+}
@@ -20,8 +20,6 @@
 package account
 
 import (
-	"fmt"
-
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/meta"
@@ -48,43 +46,64 @@ func (a *accountSvc) Get(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	switch baseInfo.Vendor {
-	case enumor.TCloud:
-		account, err := a.client.DataService().TCloud.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
-		// 敏感信息不显示，置空
-		if account != nil {
-			account.Extension.CloudSecretKey = ""
-		}
-		return account, err
-	case enumor.Aws:
-		account, err := a.client.DataService().Aws.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
-		// 敏感信息不显示，置空
-		if account != nil {
-			account.Extension.CloudSecretKey = ""
-		}
-		return account, err
-	case enumor.HuaWei:
-		account, err := a.client.DataService().HuaWei.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
-		// 敏感信息不显示，置空
-		if account != nil {
-			account.Extension.CloudSecretKey = ""
-		}
-		return account, err
-	case enumor.Gcp:
-		account, err := a.client.DataService().Gcp.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
-		// 敏感信息不显示，置空
-		if account != nil {
-			account.Extension.CloudServiceSecretKey = ""
-		}
-		return account, err
-	case enumor.Azure:
-		account, err := a.client.DataService().Azure.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
-		// 敏感信息不显示，置空
-		if account != nil {
-			account.Extension.CloudClientSecretKey = ""
-		}
-		return account, err
-	default:
-		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", baseInfo.Vendor))
+	handler, err := getVendorHandler(baseInfo.Vendor)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return handler.get(a, cts, accountID)
+}
+
+func (a *accountSvc) getForTCloud(cts *rest.Contexts, accountID string) (interface{}, error) {
+	account, err := a.client.DataService().TCloud.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+	// 敏感信息不显示，置空
+	if account != nil {
+		account.Extension.CloudSecretKey = ""
+	}
+	return account, err
+}
+
+func (a *accountSvc) getForAws(cts *rest.Contexts, accountID string) (interface{}, error) {
+	account, err := a.client.DataService().Aws.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+	// 敏感信息不显示，置空
+	if account != nil {
+		account.Extension.CloudSecretKey = ""
+	}
+	return account, err
+}
+
+func (a *accountSvc) getForHuaWei(cts *rest.Contexts, accountID string) (interface{}, error) {
+	account, err := a.client.DataService().HuaWei.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+	// 敏感信息不显示，置空
+	if account != nil {
+		account.Extension.CloudSecretKey = ""
+	}
+	return account, err
+}
+
+func (a *accountSvc) getForGcp(cts *rest.Contexts, accountID string) (interface{}, error) {
+	account, err := a.client.DataService().Gcp.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+	// 敏感信息不显示，置空
+	if account != nil {
+		account.Extension.CloudServiceSecretKey = ""
+	}
+	return account, err
+}
+
+func (a *accountSvc) getForAzure(cts *rest.Contexts, accountID string) (interface{}, error) {
+	account, err := a.client.DataService().Azure.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+	// 敏感信息不显示，置空
+	if account != nil {
+		account.Extension.CloudClientSecretKey = ""
+	}
+	return account, err
+}
+
+func (a *accountSvc) getForOpenStack(cts *rest.Contexts, accountID string) (interface{}, error) {
+	account, err := a.client.DataService().OpenStack.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+	// 敏感信息不显示，置空
+	if account != nil {
+		account.Extension.CloudSecretKey = ""
 	}
+	return account, err
 }
@@ -24,6 +24,7 @@ import (
 
 	proto "hcm/pkg/api/cloud-server/account"
 	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
@@ -34,13 +35,7 @@ import (
 	"github.com/TencentBlueKing/gopkg/conv"
 )
 
-var VendorSecretKeyFieldMap = map[enumor.Vendor]string{
-	enumor.TCloud: "cloud_secret_key",
-	enumor.Aws:    "cloud_secret_key",
-	enumor.HuaWei: "cloud_secret_key",
-	enumor.Gcp:    "cloud_service_secret_key",
-	enumor.Azure:  "cloud_client_secret_key",
-}
+var VendorSecretKeyFieldMap = corecloud.AccountSecretKeyFieldMap
 
 func canListAccountExtension(appCode string) error {
 	// TODO: 校验App来源, 这里只校验了非Web来请求，需要改造从配置文件读取，允许访问该接口的AppCode白名单（目前暂时可以借助APIGateway的应用认证白名单）
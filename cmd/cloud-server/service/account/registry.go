@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+
+	proto "hcm/pkg/api/cloud-server/account"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/rest"
+)
+
+// vendorHandler 云厂商账号的校验、查询和更新方法，均为必填，各厂商在 init 中通过 registerVendor 注册。
+type vendorHandler struct {
+	// parseAndCheck 解析并校验新建账号的 Extension，检查账号联通性
+	parseAndCheck func(cts *rest.Contexts, cli *client.ClientSet, accountType enumor.AccountType,
+		reqExtension json.RawMessage) error
+	// parseAndCheckByID 解析并校验已有账号更新的 Extension，检查账号联通性
+	parseAndCheckByID func(a *accountSvc, cts *rest.Contexts, accountID string, reqExtension json.RawMessage) error
+	// get 查询账号详情，敏感信息置空
+	get func(a *accountSvc, cts *rest.Contexts, accountID string) (interface{}, error)
+	// update 更新账号
+	update func(a *accountSvc, cts *rest.Contexts, req *proto.AccountUpdateReq, accountID string) (interface{}, error)
+}
+
+// vendorHandlers 只在 init 中写入，之后只读，无需加锁。
+var vendorHandlers = make(map[enumor.Vendor]vendorHandler)
+
+// registerVendor register vendor account handler, register the same vendor twice will panic.
+func registerVendor(vendor enumor.Vendor, handler vendorHandler) {
+	if len(vendor) == 0 || handler.parseAndCheck == nil || handler.parseAndCheckByID == nil ||
+		handler.get == nil || handler.update == nil {
+		panic("register account vendor and all handler funcs are required")
+	}
+
+	if _, exist := vendorHandlers[vendor]; exist {
+		panic(fmt.Sprintf("vendor: %s account handler is already registered", vendor))
+	}
+	vendorHandlers[vendor] = handler
+}
+
+func getVendorHandler(vendor enumor.Vendor) (vendorHandler, error) {
+	handler, exist := vendorHandlers[vendor]
+	if !exist {
+		return vendorHandler{}, fmt.Errorf("no support vendor: %s", vendor)
+	}
+
+	return handler, nil
+}
+
+// ParseAndCheckExtension 按厂商解析并校验新建账号的 Extension，检查账号联通性，与申请新增账号复用。
+func ParseAndCheckExtension(cts *rest.Contexts, cli *client.ClientSet, vendor enumor.Vendor,
+	accountType enumor.AccountType, reqExtension json.RawMessage) error {
+
+	handler, err := getVendorHandler(vendor)
+	if err != nil {
+		return err
+	}
+
+	return handler.parseAndCheck(cts, cli, accountType, reqExtension)
+}
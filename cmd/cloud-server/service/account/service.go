@@ -48,6 +48,10 @@ func InitAccountService(c *capability.Capability) {
 	h.Add("Get", http.MethodGet, "/accounts/{account_id}", svc.Get)
 	h.Add("Update", http.MethodPatch, "/accounts/{account_id}", svc.Update)
 	h.Add("SyncCloudResource", http.MethodPost, "/accounts/{account_id}/sync", svc.SyncCloudResource)
+	h.Add("DryRunSyncCloudResource", http.MethodPost, "/accounts/{account_id}/sync/dry_run",
+		svc.DryRunSyncCloudResource)
+	h.Add("ListSyncTask", http.MethodPost, "/accounts/{account_id}/sync_tasks/list", svc.ListSyncTask)
+	h.Add("GetSyncTask", http.MethodGet, "/accounts/{account_id}/sync_tasks/{id}", svc.GetSyncTask)
 	h.Add("DeleteAccount", http.MethodDelete, "/accounts/{account_id}", svc.DeleteAccount)
 	h.Add("DeleteValidate", http.MethodPost, "/accounts/{account_id}/delete/validate", svc.DeleteValidate)
 
@@ -24,18 +24,15 @@ import (
 	"fmt"
 	"strings"
 
-	"hcm/cmd/cloud-server/service/sync/aws"
-	"hcm/cmd/cloud-server/service/sync/azure"
-	"hcm/cmd/cloud-server/service/sync/gcp"
-	"hcm/cmd/cloud-server/service/sync/huawei"
 	"hcm/cmd/cloud-server/service/sync/lock"
-	"hcm/cmd/cloud-server/service/sync/tcloud"
+	"hcm/cmd/cloud-server/service/sync/registry"
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/core"
 	imagecloud "hcm/pkg/api/data-service/cloud/image"
 	protoregion "hcm/pkg/api/data-service/cloud/region"
 	protocloud "hcm/pkg/api/data-service/cloud/zone"
 	dataservice "hcm/pkg/client/data-service"
-	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
@@ -45,8 +42,6 @@ import (
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/tools/converter"
-
-	etcd3 "go.etcd.io/etcd/client/v3"
 )
 
 // SyncCloudResource ...
@@ -76,81 +71,107 @@ func (a *accountSvc) SyncCloudResource(cts *rest.Contexts) (interface{}, error)
 		return nil, err
 	}
 
-	leaseID, err := lock.Manager.TryLock(lock.Key(accountID))
-	if err != nil {
-		if err == lock.ErrLockFailed {
+	// 手动同步交由同步调度器执行，替换排队中的定时同步，调度器在任务执行时获取账号同步锁，
+	// 任务在请求返回后执行，不能使用随请求结束而取消的上下文
+	taskKt := cts.Kit.Detach()
+	task := &scheduler.Task{
+		Vendor:    baseInfo.Vendor,
+		AccountID: accountID,
+		Priority:  scheduler.ManualPriority,
+		Run: func() error {
+			return a.syncAllResourceByVendor(taskKt, baseInfo, accountID, isNeedSyncPublicResFlag, nil)
+		},
+	}
+	if err = scheduler.Manager.Submit(task); err != nil {
+		if errors.Is(err, scheduler.ErrTaskExists) {
 			return nil, errors.New("synchronization is in progress")
 		}
 
 		return nil, err
 	}
 
-	go func(leaseID etcd3.LeaseID) {
-		defer func() {
-			if err := lock.Manager.UnLock(leaseID); err != nil {
-				// 锁已经超时释放了
-				if strings.Contains(err.Error(), "requested lease not found") {
-					return
-				}
-
-				logs.Errorf("%s: unlock account sync lock failed, err: %v, accountID: %s, leaseID: %d, rid: %s",
-					constant.AccountSyncFailed, err, accountID, leaseID, cts.Kit.Rid)
-			}
-		}()
-
-		a.syncAllResourceByVendor(cts, baseInfo, accountID, isNeedSyncPublicResFlag)
-	}(leaseID)
-
 	return nil, nil
 }
 
-func (a *accountSvc) syncAllResourceByVendor(cts *rest.Contexts, baseInfo *types.CloudResourceBasicInfo,
-	accountID string, isNeedSyncPublicResFlag bool) {
+// DryRunSyncCloudResource 演练同步账号下的资源，只对比云上和db中资源的差异，不写入db，同步返回演练同步结果。
+func (a *accountSvc) DryRunSyncCloudResource(cts *rest.Contexts) (interface{}, error) {
+	accountID := cts.PathParameter("account_id").String()
 
-	switch baseInfo.Vendor {
-	case enumor.TCloud:
-		opt := &tcloud.SyncAllResourceOption{
-			AccountID:          accountID,
-			SyncPublicResource: isNeedSyncPublicResFlag,
-		}
-		tcloud.SyncAllResource(cts.Kit, a.client, opt)
+	// 演练同步不修改资源，校验用户有该账号的查看权限
+	if err := a.checkPermission(cts, meta.Find, accountID); err != nil {
+		return nil, err
+	}
 
-	case enumor.Aws:
-		opt := &aws.SyncAllResourceOption{
-			AccountID:          accountID,
-			SyncPublicResource: isNeedSyncPublicResFlag,
-		}
-		aws.SyncAllResource(cts.Kit, a.client, opt)
+	baseInfo, err := a.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.AccountCloudResType, accountID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
 
-	case enumor.HuaWei:
-		opt := &huawei.SyncAllResourceOption{
-			AccountID:          accountID,
-			SyncPublicResource: isNeedSyncPublicResFlag,
+	// 与正式同步共用同步锁，避免同步过程中的中间数据影响演练结果
+	leaseID, err := lock.Manager.TryLock(lock.Key(accountID))
+	if err != nil {
+		if err == lock.ErrLockFailed {
+			return nil, errors.New("synchronization is in progress")
 		}
-		huawei.SyncAllResource(cts.Kit, a.client, opt)
 
-	case enumor.Gcp:
-		opt := &gcp.SyncAllResourceOption{
-			AccountID:          accountID,
-			SyncPublicResource: isNeedSyncPublicResFlag,
-		}
-		gcp.SyncAllResource(cts.Kit, a.client, opt)
+		return nil, err
+	}
 
-	case enumor.Azure:
-		opt := &azure.SyncAllResourceOption{
-			AccountID:          accountID,
-			SyncPublicResource: isNeedSyncPublicResFlag,
+	defer func() {
+		if err := lock.Manager.UnLock(leaseID); err != nil {
+			// 锁已经超时释放了
+			if strings.Contains(err.Error(), "requested lease not found") {
+				return
+			}
+
+			logs.Errorf("unlock account dry run sync lock failed, err: %v, accountID: %s, leaseID: %d, rid: %s",
+				err, accountID, leaseID, cts.Kit.Rid)
 		}
-		azure.SyncAllResource(cts.Kit, a.client, opt)
+	}()
 
-	default:
+	report := syncreport.NewReport(true)
+	if err = a.syncAllResourceByVendor(cts.Kit, baseInfo, accountID, false, report); err != nil {
+		logs.Errorf("dry run sync account resource failed, err: %v, accountID: %s, rid: %s", err, accountID,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return report.Result(), nil
+}
+
+// syncAllResourceByVendor 手动同步账号下的所有资源，report 为演练同步报告时进行演练同步。
+func (a *accountSvc) syncAllResourceByVendor(kt *kit.Kit, baseInfo *types.CloudResourceBasicInfo,
+	accountID string, isNeedSyncPublicResFlag bool, report *syncreport.Report) error {
+
+	syncer, err := registry.Get(baseInfo.Vendor)
+	if err != nil {
 		logs.Errorf("account: %s's vendor not support, vendor: %s", accountID, baseInfo.Vendor)
+		return err
 	}
+
+	opt := &registry.SyncAllResourceOption{
+		AccountID:          accountID,
+		SyncPublicResource: isNeedSyncPublicResFlag,
+		Trigger:            enumor.ManualSyncTaskTrigger,
+		DryRunReport:       report,
+	}
+	return syncer.SyncAllResource(kt, a.client, opt)
 }
 
 func isNeedSyncPublicResource(kt *kit.Kit, dataCli *dataservice.Client, vendor enumor.Vendor) (
 	bool, error) {
 
+	syncer, err := registry.Get(vendor)
+	if err != nil {
+		return false, err
+	}
+
+	// 没有公共资源的厂商无需同步公共资源
+	if syncer.WithoutPublicResource {
+		return false, nil
+	}
+
 	need, err := isNeedSyncRegion(kt, dataCli, vendor)
 	if err != nil {
 		return false, err
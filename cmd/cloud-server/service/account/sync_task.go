@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	proto "hcm/pkg/api/cloud-server"
+	protoaccount "hcm/pkg/api/cloud-server/account"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// ListSyncTask list sync tasks of account.
+func (a *accountSvc) ListSyncTask(cts *rest.Contexts) (interface{}, error) {
+	accountID := cts.PathParameter("account_id").String()
+
+	// 校验用户有该账号的查看权限
+	if err := a.checkPermission(cts, meta.Find, accountID); err != nil {
+		return nil, err
+	}
+
+	req := new(proto.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, err := tools.And(req.Filter, &filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(),
+		Value: accountID})
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   req.Page,
+	}
+	return a.client.DataService().Global.SyncTask.ListSyncTask(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// GetSyncTask get sync task of account with its sync details of each resource type.
+func (a *accountSvc) GetSyncTask(cts *rest.Contexts) (interface{}, error) {
+	accountID := cts.PathParameter("account_id").String()
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "sync task id is required")
+	}
+
+	// 校验用户有该账号的查看权限
+	if err := a.checkPermission(cts, meta.Find, accountID); err != nil {
+		return nil, err
+	}
+
+	taskReq := &core.ListReq{
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{"id": id, "account_id": accountID}),
+		Page:   core.NewDefaultBasePage(),
+	}
+	taskResult, err := a.client.DataService().Global.SyncTask.ListSyncTask(cts.Kit.Ctx, cts.Kit.Header(), taskReq)
+	if err != nil {
+		logs.Errorf("list sync task failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(taskResult.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "sync task: %s not found", id)
+	}
+
+	details := make([]corecloud.SyncDetail, 0)
+	detailReq := &core.ListReq{
+		Filter: tools.EqualExpression("task_id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		detailResult, err := a.client.DataService().Global.SyncTask.ListSyncDetail(cts.Kit.Ctx, cts.Kit.Header(),
+			detailReq)
+		if err != nil {
+			logs.Errorf("list sync detail failed, err: %v, task: %s, rid: %s", err, id, cts.Kit.Rid)
+			return nil, err
+		}
+
+		details = append(details, detailResult.Details...)
+
+		if len(detailResult.Details) < int(detailReq.Page.Limit) {
+			break
+		}
+
+		detailReq.Page.Start += uint32(detailReq.Page.Limit)
+	}
+
+	return &protoaccount.SyncTaskResult{SyncTask: &taskResult.Details[0], SyncDetails: details}, nil
+}
@@ -20,8 +20,6 @@
 package account
 
 import (
-	"fmt"
-
 	proto "hcm/pkg/api/cloud-server/account"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/enumor"
@@ -58,6 +56,11 @@ func (a *accountSvc) Update(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	handler, err := getVendorHandler(baseInfo.Vendor)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
 	// create update audit.
 	updateFields, err := converter.StructToMap(req)
 	if err != nil {
@@ -84,20 +87,7 @@ func (a *accountSvc) Update(cts *rest.Contexts) (interface{}, error) {
 		}
 	}
 
-	switch baseInfo.Vendor {
-	case enumor.TCloud:
-		return a.updateForTCloud(cts, req, accountID)
-	case enumor.Aws:
-		return a.updateForAws(cts, req, accountID)
-	case enumor.HuaWei:
-		return a.updateForHuaWei(cts, req, accountID)
-	case enumor.Gcp:
-		return a.updateForGcp(cts, req, accountID)
-	case enumor.Azure:
-		return a.updateForAzure(cts, req, accountID)
-	default:
-		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", baseInfo.Vendor))
-	}
+	return handler.update(a, cts, req, accountID)
 }
 
 func (a *accountSvc) updateForTCloud(
@@ -332,3 +322,48 @@ func (a *accountSvc) updateForAzure(
 	return nil, nil
 
 }
+
+func (a *accountSvc) updateForOpenStack(
+	cts *rest.Contexts, req *proto.AccountUpdateReq, accountID string,
+) (
+	interface{}, error,
+) {
+	// 解析Extension
+	var (
+		extension *proto.OpenStackAccountExtensionUpdateReq
+		err       error
+	)
+	if req.Extension != nil {
+		extension, err = a.parseAndCheckOpenStackExtensionByID(cts, accountID, req.Extension)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	var shouldUpdatedExtension *dataproto.OpenStackAccountExtensionUpdateReq = nil
+	if req.Extension != nil {
+		shouldUpdatedExtension = &dataproto.OpenStackAccountExtensionUpdateReq{
+			CloudProjectName: extension.CloudProjectName,
+			CloudSecretID:    &extension.CloudSecretID,
+			CloudSecretKey:   &extension.CloudSecretKey,
+		}
+	}
+
+	// 更新
+	_, err = a.client.DataService().OpenStack.Account.Update(
+		cts.Kit.Ctx,
+		cts.Kit.Header(),
+		accountID,
+		&dataproto.AccountUpdateReq[dataproto.OpenStackAccountExtensionUpdateReq]{
+			Name:      req.Name,
+			Managers:  req.Managers,
+			Memo:      req.Memo,
+			Extension: shouldUpdatedExtension,
+		},
+	)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil, nil
+}
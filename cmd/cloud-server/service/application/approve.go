@@ -29,6 +29,7 @@ import (
 	azurecvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/azure"
 	gcpcvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/gcp"
 	huaweicvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/huawei"
+	modifycvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/modify"
 	tcloudcvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/tcloud"
 	awsdiskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/aws"
 	azurediskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/azure"
@@ -285,6 +286,12 @@ func (a *applicationSvc) getHandlerByApplication(
 		return a.getHandlerOfCreateVpc(opt, vendor, application)
 	case enumor.CreateDisk:
 		return a.getHandlerOfCreateDisk(opt, vendor, application)
+	case enumor.ModifyCvm:
+		req, err := parseReqFromApplicationContent[cscvm.ModifyCvmApplicationReq](application.Content)
+		if err != nil {
+			return nil, err
+		}
+		return modifycvmhandler.NewApplicationOfModifyCvm(opt, vendor, req), nil
 	}
 	return nil, errors.New("not handler to support")
 }
@@ -28,6 +28,7 @@ import (
 	azurecvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/azure"
 	gcpcvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/gcp"
 	huaweicvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/huawei"
+	modifycvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/modify"
 	tcloudcvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/tcloud"
 	awsdiskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/aws"
 	azurediskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/azure"
@@ -322,3 +323,28 @@ func (a *applicationSvc) CreateForCreateDisk(cts *rest.Contexts) (interface{}, e
 
 	return nil, nil
 }
+
+// CreateForModifyCvm ...
+func (a *applicationSvc) CreateForModifyCvm(cts *rest.Contexts) (interface{}, error) {
+	req, err := parseReqFromRequestBody[cscvm.ModifyCvmApplicationReq](cts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err = a.checkApplyResPermission(cts, meta.Cvm); err != nil {
+		return nil, err
+	}
+
+	basicInfo, err := a.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.CvmCloudResType, req.CvmID)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := modifycvmhandler.NewApplicationOfModifyCvm(a.getHandlerOption(cts), basicInfo.Vendor, req)
+	return a.create(cts, handler)
+}
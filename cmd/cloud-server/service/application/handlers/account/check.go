@@ -50,26 +50,17 @@ func (a *ApplicationOfAddAccount) CheckReq() error {
 	if err != nil {
 		return fmt.Errorf("json marshal extension failed, err: %w", err)
 	}
-	switch a.req.Vendor {
-	case enumor.TCloud:
-		_, err = accountsvc.ParseAndCheckTCloudExtension(a.Cts, a.Client, a.req.Type, extensionJson)
-	case enumor.Aws:
-		_, err = accountsvc.ParseAndCheckAwsExtension(a.Cts, a.Client, a.req.Type, extensionJson)
-	case enumor.HuaWei:
-		_, err = accountsvc.ParseAndCheckHuaWeiExtension(a.Cts, a.Client, a.req.Type, extensionJson)
-	case enumor.Gcp:
-		_, err = accountsvc.ParseAndCheckGcpExtension(a.Cts, a.Client, a.req.Type, extensionJson)
-	case enumor.Azure:
-		_, err = accountsvc.ParseAndCheckAzureExtension(a.Cts, a.Client, a.req.Type, extensionJson)
-	default:
-		err = fmt.Errorf("no support vendor: %s", a.req.Vendor)
-	}
+	err = accountsvc.ParseAndCheckExtension(a.Cts, a.Client, a.req.Vendor, a.req.Type, extensionJson)
 	if err != nil {
 		return err
 	}
 
 	// 检查资源账号的主账号是否重复
-	mainAccountIDField := vendorMainAccountIDFieldMap[a.req.Vendor]
+	vendor, err := getVendor(a.req.Vendor)
+	if err != nil {
+		return err
+	}
+	mainAccountIDField := vendor.mainAccountIDField
 	err = isDuplicateMainAccount(
 		a.Cts, a.Client, a.req.Vendor, a.req.Type, mainAccountIDField, conv.ToString(a.req.Extension[mainAccountIDField]),
 	)
@@ -24,14 +24,6 @@ import (
 )
 
 var (
-	vendorMainAccountIDFieldMap = map[enumor.Vendor]string{
-		enumor.TCloud: "cloud_main_account_id",
-		enumor.Aws:    "cloud_account_id",
-		enumor.HuaWei: "cloud_sub_account_id",
-		enumor.Gcp:    "cloud_project_id",
-		enumor.Azure:  "cloud_tenant_id",
-	}
-
 	accountTypNameMap = map[enumor.AccountType]string{
 		enumor.RegistrationAccount:  "登记账号",
 		enumor.ResourceAccount:      "资源账号",
@@ -25,7 +25,6 @@ import (
 
 	"hcm/cmd/cloud-server/service/application/handlers"
 	"hcm/pkg/criteria/constant"
-	"hcm/pkg/criteria/enumor"
 )
 
 type formItem struct {
@@ -41,6 +40,10 @@ func (a *ApplicationOfAddAccount) RenderItsmTitle() (string, error) {
 // RenderItsmForm 渲染ITSM表单
 func (a *ApplicationOfAddAccount) RenderItsmForm() (string, error) {
 	req := a.req
+	vendor, err := getVendor(req.Vendor)
+	if err != nil {
+		return "", err
+	}
 
 	// 公共基础信息
 	formItems := []formItem{
@@ -51,45 +54,8 @@ func (a *ApplicationOfAddAccount) RenderItsmForm() (string, error) {
 	}
 
 	// 云特性信息
-	switch req.Vendor {
-	case enumor.TCloud:
-		formItems = append(formItems, []formItem{
-			{Label: "主账号ID", Value: req.Extension["cloud_main_account_id"]},
-			{Label: "子账号ID", Value: req.Extension["cloud_sub_account_id"]},
-			{Label: "SecretId", Value: req.Extension["cloud_secret_id"]},
-		}...)
-	case enumor.Aws:
-		formItems = append(formItems, []formItem{
-			{Label: "账号ID", Value: req.Extension["cloud_account_id"]},
-			{Label: "IAM用户名称", Value: req.Extension["cloud_iam_username"]},
-			{Label: "SecretId/密钥ID", Value: req.Extension["cloud_secret_id"]},
-		}...)
-	case enumor.HuaWei:
-		formItems = append(formItems, []formItem{
-			{Label: "主账号名", Value: req.Extension["cloud_main_account_name"]},
-			{Label: "账号ID", Value: req.Extension["cloud_sub_account_id"]},
-			{Label: "账号名称", Value: req.Extension["cloud_sub_account_name"]},
-			{Label: "IAM用户ID", Value: req.Extension["cloud_iam_user_id"]},
-			{Label: "IAM用户名称", Value: req.Extension["cloud_iam_username"]},
-			{Label: "SecretId/密钥ID", Value: req.Extension["cloud_secret_id"]},
-		}...)
-	case enumor.Gcp:
-		formItems = append(formItems, []formItem{
-			{Label: "项目 ID", Value: req.Extension["cloud_project_id"]},
-			{Label: "项目名称", Value: req.Extension["cloud_project_name"]},
-			{Label: "服务账号ID", Value: req.Extension["cloud_service_account_id"]},
-			{Label: "服务账号名称", Value: req.Extension["cloud_service_account_name"]},
-			{Label: "服务账号密钥ID", Value: req.Extension["cloud_service_secret_id"]},
-		}...)
-	case enumor.Azure:
-		formItems = append(formItems, []formItem{
-			{Label: "租户 ID", Value: req.Extension["cloud_tenant_id"]},
-			{Label: "订阅 ID", Value: req.Extension["cloud_subscription_id"]},
-			{Label: "订阅名称", Value: req.Extension["cloud_subscription_name"]},
-			{Label: "应用程序(客户端) ID", Value: req.Extension["cloud_application_id"]},
-			{Label: "应用程序名称", Value: req.Extension["cloud_application_name"]},
-			{Label: "客户端密钥ID", Value: req.Extension["cloud_client_secret_id"]},
-		}...)
+	for _, field := range vendor.formFields {
+		formItems = append(formItems, formItem{Label: field.Label, Value: req.Extension[field.Field]})
 	}
 
 	// 负责人
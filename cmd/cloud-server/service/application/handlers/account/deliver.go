@@ -31,22 +31,11 @@ import (
 // Deliver 执行资源交付
 func (a *ApplicationOfAddAccount) Deliver() (enumor.ApplicationStatus, map[string]interface{}, error) {
 	// 执行创建账号
-	var (
-		err       error
-		accountID string
-	)
-	switch a.req.Vendor {
-	case enumor.TCloud:
-		accountID, err = a.createForTCloud()
-	case enumor.Aws:
-		accountID, err = a.createForAws()
-	case enumor.HuaWei:
-		accountID, err = a.createForHuaWei()
-	case enumor.Gcp:
-		accountID, err = a.createForGcp()
-	case enumor.Azure:
-		accountID, err = a.createForAzure()
+	vendor, err := getVendor(a.req.Vendor)
+	if err != nil {
+		return enumor.DeliverError, map[string]interface{}{"error": err.Error()}, err
 	}
+	accountID, err := vendor.create(a)
 	// 交付失败
 	if err != nil {
 		return enumor.DeliverError, map[string]interface{}{"error": err.Error()}, err
@@ -201,3 +190,29 @@ func (a *ApplicationOfAddAccount) createForAzure() (string, error) {
 	}
 	return result.ID, err
 }
+
+func (a *ApplicationOfAddAccount) createForOpenStack() (string, error) {
+	result, err := a.Client.DataService().OpenStack.Account.Create(
+		a.Cts.Kit.Ctx,
+		a.Cts.Kit.Header(),
+		&dataprotocloud.AccountCreateReq[dataprotocloud.OpenStackAccountExtensionCreateReq]{
+			Name:     a.req.Name,
+			Managers: a.req.Managers,
+			Type:     a.req.Type,
+			Site:     a.req.Site,
+			Memo:     a.req.Memo,
+			BkBizIDs: a.req.BkBizIDs,
+			Extension: &dataprotocloud.OpenStackAccountExtensionCreateReq{
+				CloudAuthUrl:     a.req.Extension["cloud_auth_url"],
+				CloudProjectID:   a.req.Extension["cloud_project_id"],
+				CloudProjectName: a.req.Extension["cloud_project_name"],
+				CloudSecretID:    a.req.Extension["cloud_secret_id"],
+				CloudSecretKey:   a.req.Extension["cloud_secret_key"],
+			},
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	return result.ID, err
+}
@@ -0,0 +1,39 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"hcm/pkg/criteria/enumor"
+)
+
+func init() {
+	registerVendor(enumor.HuaWei, vendorAccount{
+		mainAccountIDField: "cloud_sub_account_id",
+		formFields: []formField{
+			{Label: "主账号名", Field: "cloud_main_account_name"},
+			{Label: "账号ID", Field: "cloud_sub_account_id"},
+			{Label: "账号名称", Field: "cloud_sub_account_name"},
+			{Label: "IAM用户ID", Field: "cloud_iam_user_id"},
+			{Label: "IAM用户名称", Field: "cloud_iam_username"},
+			{Label: "SecretId/密钥ID", Field: "cloud_secret_id"},
+		},
+		create: (*ApplicationOfAddAccount).createForHuaWei,
+	})
+}
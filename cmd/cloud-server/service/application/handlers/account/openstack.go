@@ -0,0 +1,38 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"hcm/pkg/criteria/enumor"
+)
+
+func init() {
+	registerVendor(enumor.OpenStack, vendorAccount{
+		// openstack 项目ID由部署生成，不同部署之间不会重复
+		mainAccountIDField: "cloud_project_id",
+		formFields: []formField{
+			{Label: "认证地址", Field: "cloud_auth_url"},
+			{Label: "项目 ID", Field: "cloud_project_id"},
+			{Label: "项目名称", Field: "cloud_project_name"},
+			{Label: "应用凭据ID", Field: "cloud_secret_id"},
+		},
+		create: (*ApplicationOfAddAccount).createForOpenStack,
+	})
+}
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// formField ITSM 表单展示的账号 Extension 字段。
+type formField struct {
+	Label string
+	Field string
+}
+
+// vendorAccount 云厂商新增账号申请的处理方法，各厂商在 init 中通过 registerVendor 注册。
+type vendorAccount struct {
+	// mainAccountIDField 主账号ID在 Extension 中的字段名，用于检查主账号是否重复
+	mainAccountIDField string
+	// formFields ITSM 表单展示的云特性信息
+	formFields []formField
+	// create 创建账号，返回账号ID
+	create func(a *ApplicationOfAddAccount) (string, error)
+}
+
+// vendorAccounts 只在 init 中写入，之后只读，无需加锁。
+var vendorAccounts = make(map[enumor.Vendor]vendorAccount)
+
+// registerVendor register vendor add account handler, register the same vendor twice will panic.
+func registerVendor(vendor enumor.Vendor, account vendorAccount) {
+	if len(vendor) == 0 || len(account.mainAccountIDField) == 0 || account.create == nil {
+		panic("register add account vendor, main account id field and create func are required")
+	}
+
+	if _, exist := vendorAccounts[vendor]; exist {
+		panic(fmt.Sprintf("vendor: %s add account handler is already registered", vendor))
+	}
+	vendorAccounts[vendor] = account
+}
+
+func getVendor(vendor enumor.Vendor) (vendorAccount, error) {
+	account, exist := vendorAccounts[vendor]
+	if !exist {
+		return vendorAccount{}, fmt.Errorf("no support vendor: %s", vendor)
+	}
+
+	return account, nil
+}
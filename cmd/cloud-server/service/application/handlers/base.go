@@ -23,6 +23,8 @@ import (
 	"fmt"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/logics/cvm"
+	"hcm/cmd/cloud-server/logics/ipam"
 	"hcm/pkg/api/core"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/enumor"
@@ -40,6 +42,8 @@ type HandlerOption struct {
 	Cipher    cryptography.Crypto
 	Audit     audit.Interface
 	ItsmCli   itsm.Client
+	CvmLgc    cvm.Interface
+	IpamLgc   ipam.Interface
 }
 
 // BaseApplicationHandler 基础的Handler 一些公共函数和属性处理，可以给到其他具体Handler组合
@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package handlers
+
+import (
+	"fmt"
+
+	csvpc "hcm/pkg/api/cloud-server/vpc"
+	"hcm/pkg/api/core"
+	coreipam "hcm/pkg/api/core/cloud/ipam"
+	"hcm/pkg/dal/dao/tools"
+)
+
+// GetCidrPool 查询IP地址池
+func (a *BaseApplicationHandler) GetCidrPool(poolID string) (*coreipam.CidrPool, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", poolID),
+		Page:   a.getPageOfOneLimit(),
+	}
+	resp, err := a.Client.DataService().Global.Ipam.ListCidrPool(a.Cts.Kit.Ctx, a.Cts.Kit.Header(), listReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || len(resp.Details) == 0 {
+		return nil, fmt.Errorf("not found cidr pool by id(%s)", poolID)
+	}
+
+	return &resp.Details[0], nil
+}
+
+// RenderCidr 渲染申请单中的网段，使用IP地址池时网段在交付时才分配，只展示地址池和掩码长度
+func (a *BaseApplicationHandler) RenderCidr(cidr string, opt csvpc.CidrPoolOption, maskLen int) (string, error) {
+	if !opt.UseCidrPool() {
+		return cidr, nil
+	}
+
+	pool, err := a.GetCidrPool(opt.CidrPoolID)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("从IP地址池%s(%s)自动分配 /%d", pool.Name, pool.IPv4Cidr, maskLen), nil
+}
@@ -20,6 +20,8 @@
 package handlers
 
 import (
+	"fmt"
+
 	"hcm/pkg/api/core"
 	corecvm "hcm/pkg/api/core/cloud/cvm"
 	dataproto "hcm/pkg/api/data-service/cloud"
@@ -54,3 +56,30 @@ func (a *BaseApplicationHandler) ListCvm(
 
 	return resp.Details, nil
 }
+
+// GetCvm 查询主机信息
+func (a *BaseApplicationHandler) GetCvm(cvmID string) (*corecvm.BaseCvm, error) {
+	reqFilter := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			filter.AtomRule{Field: "id", Op: filter.Equal.Factory(), Value: cvmID},
+		},
+	}
+	// 查询
+	resp, err := a.Client.DataService().Global.Cvm.ListCvm(
+		a.Cts.Kit.Ctx,
+		a.Cts.Kit.Header(),
+		&dataproto.CvmListReq{
+			Filter: reqFilter,
+			Page:   a.getPageOfOneLimit(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || len(resp.Details) == 0 {
+		return nil, fmt.Errorf("not found cvm by id(%s)", cvmID)
+	}
+
+	return &resp.Details[0], nil
+}
@@ -27,15 +27,22 @@ import (
 	"hcm/pkg/runtime/filter"
 )
 
-// GetImage 查询镜像
+// GetImage 查询镜像，自定义镜像和共享镜像只能被所属或被共享的账号使用，其他账号仅能查询到公共镜像
 func (a *BaseApplicationHandler) GetImage(
-	vendor enumor.Vendor, cloudImageID string,
+	vendor enumor.Vendor, accountID, cloudImageID string,
 ) (*dataprotoimage.ImageResult, error) {
 	reqFilter := &filter.Expression{
 		Op: filter.And,
 		Rules: []filter.RuleFactory{
 			filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
 			filter.AtomRule{Field: "cloud_id", Op: filter.Equal.Factory(), Value: cloudImageID},
+			&filter.Expression{
+				Op: filter.Or,
+				Rules: []filter.RuleFactory{
+					filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: ""},
+					filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				},
+			},
 		},
 	}
 	// 查询
@@ -25,10 +25,11 @@ import (
 
 var (
 	VendorNameMap = map[enumor.Vendor]string{
-		enumor.TCloud: "腾讯云",
-		enumor.Aws:    "亚马逊云",
-		enumor.HuaWei: "华为云",
-		enumor.Gcp:    "谷歌云",
-		enumor.Azure:  "微软云",
+		enumor.TCloud:    "腾讯云",
+		enumor.Aws:       "亚马逊云",
+		enumor.HuaWei:    "华为云",
+		enumor.Gcp:       "谷歌云",
+		enumor.Azure:     "微软云",
+		enumor.OpenStack: "OpenStack",
 	}
 )
@@ -29,6 +29,11 @@ import (
 
 // PrepareReq 预处理请求参数，比如敏感数据加密
 func (a *ApplicationOfCreateAzureCvm) PrepareReq() error {
+	// 使用密钥对登录时没有密码，无需加密
+	if len(a.req.Password) == 0 {
+		return nil
+	}
+
 	// 密码加密
 	encryptedPassword := a.Cipher.EncryptToBase64(a.req.Password)
 	a.req.Password = encryptedPassword
@@ -51,6 +56,10 @@ func (a *ApplicationOfCreateAzureCvm) GenerateApplicationContent() interface{} {
 
 // PrepareReqFromContent 预处理请求参数，对于申请内容来着DB，其实入库前是加密了的
 func (a *ApplicationOfCreateAzureCvm) PrepareReqFromContent() error {
+	if len(a.req.Password) == 0 {
+		return nil
+	}
+
 	// 解密密码
 	password, err := a.Cipher.DecryptFromBase64(a.req.Password)
 	if err != nil {
@@ -19,7 +19,10 @@
 
 package gcp
 
-import logicsaccount "hcm/cmd/cloud-server/logics/account"
+import (
+	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	logicskp "hcm/cmd/cloud-server/logics/key-pair"
+)
 
 // CheckReq 检查申请单的数据是否正确
 func (a *ApplicationOfCreateGcpCvm) CheckReq() error {
@@ -31,5 +34,10 @@ func (a *ApplicationOfCreateGcpCvm) CheckReq() error {
 		return err
 	}
 
+	err := logicskp.CheckKeyPairUsable(a.Cts.Kit, a.Client.DataService(), a.req.BkBizID, a.req.KeyPairID)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
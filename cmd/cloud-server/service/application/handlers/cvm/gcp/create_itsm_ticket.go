@@ -126,7 +126,7 @@ func (a *ApplicationOfCreateGcpCvm) renderBaseInfo() ([]formItem, error) {
 	})
 
 	// 镜像
-	imageInfo, err := a.GetImage(a.Vendor(), req.CloudImageID)
+	imageInfo, err := a.GetImage(a.Vendor(), req.AccountID, req.CloudImageID)
 	if err != nil {
 		return formItems, err
 	}
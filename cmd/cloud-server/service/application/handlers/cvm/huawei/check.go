@@ -23,6 +23,7 @@ import (
 	"errors"
 
 	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	logicskp "hcm/cmd/cloud-server/logics/key-pair"
 )
 
 // CheckReq 检查申请单的数据是否正确
@@ -35,6 +36,11 @@ func (a *ApplicationOfCreateHuaWeiCvm) CheckReq() error {
 		return err
 	}
 
+	err := logicskp.CheckKeyPairUsable(a.Cts.Kit, a.Client.DataService(), a.req.BkBizID, a.req.KeyPairID)
+	if err != nil {
+		return err
+	}
+
 	// TCloud 支持 DryRun，可预校验
 	result, err := a.Client.HCService().HuaWei.Cvm.BatchCreateCvm(
 		a.Cts.Kit.Ctx,
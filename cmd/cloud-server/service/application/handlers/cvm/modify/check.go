@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package modify
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// CheckReq ...
+func (a *ApplicationOfModifyCvm) CheckReq() error {
+	if err := a.req.Validate(); err != nil {
+		return err
+	}
+
+	cvm, err := a.GetCvm(a.req.CvmID)
+	if err != nil {
+		return err
+	}
+
+	if cvm.BkBizID != a.req.BkBizID {
+		return fmt.Errorf("cvm(%s) not belongs to biz(%d)", cvm.ID, a.req.BkBizID)
+	}
+
+	if cvm.Vendor != a.Vendor() {
+		return fmt.Errorf("cvm(%s) vendor is %s, not %s", cvm.ID, cvm.Vendor, a.Vendor())
+	}
+
+	switch cvm.Vendor {
+	case enumor.TCloud, enumor.Aws, enumor.HuaWei:
+	default:
+		return fmt.Errorf("vendor(%s) not support modify cvm", cvm.Vendor)
+	}
+
+	a.cvm = cvm
+
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package modify
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hcm/cmd/cloud-server/service/application/handlers"
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+)
+
+type formItem struct {
+	Label string
+	Value string
+}
+
+// ActionNameMap 主机变更操作的展示名称
+var ActionNameMap = map[cscvm.ModifyCvmAction]string{
+	cscvm.ChangeInstanceType:  "变更机型",
+	cscvm.ResizeDisk:          "扩容云盘",
+	cscvm.Rename:              "修改名称",
+	cscvm.ChangeSecurityGroup: "变更安全组",
+}
+
+// RenderItsmTitle 渲染ITSM单据标题
+func (a *ApplicationOfModifyCvm) RenderItsmTitle() (string, error) {
+	return fmt.Sprintf("申请[%s]主机(%s)%s", handlers.VendorNameMap[a.Vendor()], a.cvm.Name,
+		ActionNameMap[a.req.Action]), nil
+}
+
+// RenderItsmForm 渲染ITSM表单
+func (a *ApplicationOfModifyCvm) RenderItsmForm() (string, error) {
+	req := a.req
+	formItems := make([]formItem, 0)
+
+	// 业务
+	bizName, err := a.GetBizName(req.BkBizID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "业务", Value: bizName})
+
+	// 云账号
+	accountInfo, err := a.GetAccount(a.cvm.AccountID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "云账号", Value: accountInfo.Name})
+
+	// 云厂商
+	formItems = append(formItems, formItem{Label: "云厂商", Value: handlers.VendorNameMap[a.Vendor()]})
+
+	// 云地域
+	formItems = append(formItems, formItem{Label: "云地域", Value: a.cvm.Region})
+
+	// 主机
+	formItems = append(formItems, formItem{Label: "主机", Value: fmt.Sprintf("%s(%s)", a.cvm.Name, a.cvm.CloudID)})
+
+	// 变更内容
+	formItems = append(formItems, formItem{Label: "变更类型", Value: ActionNameMap[req.Action]})
+	switch req.Action {
+	case cscvm.ChangeInstanceType:
+		formItems = append(formItems, formItem{Label: "目标机型", Value: req.InstanceType})
+
+	case cscvm.ResizeDisk:
+		formItems = append(formItems, formItem{Label: "云盘", Value: req.DiskID})
+		formItems = append(formItems, formItem{Label: "扩容后大小(GB)", Value: strconv.FormatInt(req.DiskSize, 10)})
+
+	case cscvm.Rename:
+		formItems = append(formItems, formItem{Label: "新名称", Value: req.Name})
+
+	case cscvm.ChangeSecurityGroup:
+		formItems = append(formItems, formItem{Label: "安全组", Value: strings.Join(req.SecurityGroupIDs, ",")})
+	}
+
+	// 转换为ITSM表单内容数据
+	content := make([]string, 0, len(formItems))
+	for _, i := range formItems {
+		content = append(content, fmt.Sprintf("%s: %s", i.Label, i.Value))
+	}
+	return strings.Join(content, "\n"), nil
+}
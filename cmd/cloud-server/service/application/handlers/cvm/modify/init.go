@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package modify 主机变更申请单，所有厂商共用同一个Handler
+package modify
+
+import (
+	logicscvm "hcm/cmd/cloud-server/logics/cvm"
+	"hcm/cmd/cloud-server/service/application/handlers"
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	"hcm/pkg/criteria/enumor"
+)
+
+// ApplicationOfModifyCvm ...
+type ApplicationOfModifyCvm struct {
+	handlers.BaseApplicationHandler
+	cvmLgc logicscvm.Interface
+	req    *cscvm.ModifyCvmApplicationReq
+
+	// cvm 在CheckReq时查询得到的主机信息
+	cvm *corecvm.BaseCvm
+}
+
+// NewApplicationOfModifyCvm ...
+func NewApplicationOfModifyCvm(
+	opt *handlers.HandlerOption,
+	vendor enumor.Vendor,
+	req *cscvm.ModifyCvmApplicationReq,
+) *ApplicationOfModifyCvm {
+	return &ApplicationOfModifyCvm{
+		BaseApplicationHandler: handlers.NewBaseApplicationHandler(opt, enumor.ModifyCvm, vendor),
+		cvmLgc:                 opt.CvmLgc,
+		req:                    req,
+	}
+}
@@ -108,7 +108,11 @@ func (a *ApplicationOfCreateAwsVpc) renderVpc() ([]formItem, error) {
 	formItems = append(formItems, formItem{Label: "名称", Value: req.Name})
 
 	// IPv4 CIDR
-	formItems = append(formItems, formItem{Label: "IPv4 CIDR", Value: req.IPv4Cidr})
+	vpcCidr, err := a.RenderCidr(req.IPv4Cidr, req.CidrPoolOption, req.VpcMaskLen)
+	if err != nil {
+		return formItems, err
+	}
+	formItems = append(formItems, formItem{Label: "IPv4 CIDR", Value: vpcCidr})
 
 	// 所属的蓝鲸云区域
 	bkCloudAreaName, err := a.GetCloudAreaName(req.BkCloudID)
@@ -20,6 +20,7 @@
 package aws
 
 import (
+	"hcm/cmd/cloud-server/logics/ipam"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	csvpc "hcm/pkg/api/cloud-server/vpc"
 	"hcm/pkg/criteria/enumor"
@@ -29,7 +30,8 @@ import (
 type ApplicationOfCreateAwsVpc struct {
 	handlers.BaseApplicationHandler
 
-	req *csvpc.AwsVpcCreateReq
+	req     *csvpc.AwsVpcCreateReq
+	ipamLgc ipam.Interface
 }
 
 // NewApplicationOfCreateAwsVpc ...
@@ -39,5 +41,6 @@ func NewApplicationOfCreateAwsVpc(
 	return &ApplicationOfCreateAwsVpc{
 		BaseApplicationHandler: handlers.NewBaseApplicationHandler(opt, enumor.CreateVpc, enumor.Aws),
 		req:                    req,
+		ipamLgc:                opt.IpamLgc,
 	}
 }
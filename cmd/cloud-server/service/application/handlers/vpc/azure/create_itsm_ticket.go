@@ -118,7 +118,11 @@ func (a *ApplicationOfCreateAzureVpc) renderVpc() ([]formItem, error) {
 	formItems = append(formItems, formItem{Label: "名称", Value: req.Name})
 
 	// IPv4 CIDR
-	formItems = append(formItems, formItem{Label: "IPv4 CIDR", Value: req.IPv4Cidr})
+	vpcCidr, err := a.RenderCidr(req.IPv4Cidr, req.CidrPoolOption, req.VpcMaskLen)
+	if err != nil {
+		return formItems, err
+	}
+	formItems = append(formItems, formItem{Label: "IPv4 CIDR", Value: vpcCidr})
 
 	// 所属的蓝鲸云区域
 	bkCloudAreaName, err := a.GetCloudAreaName(req.BkCloudID)
@@ -138,7 +142,11 @@ func (a *ApplicationOfCreateAzureVpc) renderSubnet() ([]formItem, error) {
 	formItems = append(formItems, formItem{Label: "子网名称", Value: req.Subnet.Name})
 
 	// IPv4 CIDR
-	formItems = append(formItems, formItem{Label: "子网IPv4 CIDR", Value: req.Subnet.IPv4Cidr})
+	subnetCidr, err := a.RenderCidr(req.Subnet.IPv4Cidr, req.CidrPoolOption, req.SubnetMaskLen)
+	if err != nil {
+		return formItems, err
+	}
+	formItems = append(formItems, formItem{Label: "子网IPv4 CIDR", Value: subnetCidr})
 
 	return formItems, nil
 }
@@ -20,6 +20,7 @@
 package azure
 
 import (
+	"hcm/cmd/cloud-server/logics/ipam"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	csvpc "hcm/pkg/api/cloud-server/vpc"
 	"hcm/pkg/criteria/enumor"
@@ -29,7 +30,8 @@ import (
 type ApplicationOfCreateAzureVpc struct {
 	handlers.BaseApplicationHandler
 
-	req *csvpc.AzureVpcCreateReq
+	req     *csvpc.AzureVpcCreateReq
+	ipamLgc ipam.Interface
 }
 
 // NewApplicationOfCreateAzureVpc ...
@@ -39,5 +41,6 @@ func NewApplicationOfCreateAzureVpc(
 	return &ApplicationOfCreateAzureVpc{
 		BaseApplicationHandler: handlers.NewBaseApplicationHandler(opt, enumor.CreateVpc, enumor.Azure),
 		req:                    req,
+		ipamLgc:                opt.IpamLgc,
 	}
 }
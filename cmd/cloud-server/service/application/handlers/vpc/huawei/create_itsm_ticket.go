@@ -115,7 +115,11 @@ func (a *ApplicationOfCreateHuaWeiVpc) renderVpc() ([]formItem, error) {
 	formItems = append(formItems, formItem{Label: "名称", Value: req.Name})
 
 	// IPv4 CIDR
-	formItems = append(formItems, formItem{Label: "IPv4 CIDR", Value: req.IPv4Cidr})
+	vpcCidr, err := a.RenderCidr(req.IPv4Cidr, req.CidrPoolOption, req.VpcMaskLen)
+	if err != nil {
+		return formItems, err
+	}
+	formItems = append(formItems, formItem{Label: "IPv4 CIDR", Value: vpcCidr})
 
 	// 所属的蓝鲸云区域
 	bkCloudAreaName, err := a.GetCloudAreaName(req.BkCloudID)
@@ -135,10 +139,16 @@ func (a *ApplicationOfCreateHuaWeiVpc) renderSubnet() ([]formItem, error) {
 	formItems = append(formItems, formItem{Label: "子网名称", Value: req.Subnet.Name})
 
 	// IPv4 CIDR
-	formItems = append(formItems, formItem{Label: "子网IPv4 CIDR", Value: req.Subnet.IPv4Cidr})
+	subnetCidr, err := a.RenderCidr(req.Subnet.IPv4Cidr, req.CidrPoolOption, req.SubnetMaskLen)
+	if err != nil {
+		return formItems, err
+	}
+	formItems = append(formItems, formItem{Label: "子网IPv4 CIDR", Value: subnetCidr})
 
-	// 子网网关
-	formItems = append(formItems, formItem{Label: "子网网关", Value: req.Subnet.GatewayIP})
+	// 子网网关，使用IP地址池时为子网网段的第一个地址
+	if !req.UseCidrPool() {
+		formItems = append(formItems, formItem{Label: "子网网关", Value: req.Subnet.GatewayIP})
+	}
 
 	// 是否开启IPv6
 	ipv6EnableNameMap := map[bool]string{true: "是", false: "否"}
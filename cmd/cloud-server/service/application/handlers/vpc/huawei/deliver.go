@@ -20,19 +20,44 @@
 package huawei
 
 import (
+	"hcm/cmd/cloud-server/logics/ipam"
 	"hcm/cmd/cloud-server/service/application/handlers/vpc/logics"
 	"hcm/cmd/cloud-server/service/common"
+	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/enumor"
 )
 
 // Deliver 执行资源交付
 func (a *ApplicationOfCreateHuaWeiVpc) Deliver() (enumor.ApplicationStatus, map[string]interface{}, error) {
-	// 创建vpc
-	result, err := a.Client.HCService().HuaWei.Vpc.Create(
-		a.Cts.Kit.Ctx,
-		a.Cts.Kit.Header(),
-		common.ConvHuaWeiVpcCreateReq(a.req),
-	)
+	// 创建vpc，使用IP地址池时先从地址池分配网段
+	var result *core.CreateResult
+	allocOpt := ipam.VpcAllocateOption(a.req.CidrPoolOption, a.req.BkBizID, a.Vendor(), a.req.AccountID,
+		a.req.Region)
+	err := a.ipamLgc.CreateVpc(a.Cts.Kit, allocOpt, func(cidr string) (string, error) {
+		if len(cidr) != 0 {
+			subnetCidr, err := ipam.FirstSubnetCidr(cidr, a.req.SubnetMaskLen)
+			if err != nil {
+				return "", err
+			}
+
+			gatewayIP, err := ipam.GatewayIP(subnetCidr)
+			if err != nil {
+				return "", err
+			}
+			a.req.IPv4Cidr, a.req.Subnet.IPv4Cidr, a.req.Subnet.GatewayIP = cidr, subnetCidr, gatewayIP
+		}
+
+		var err error
+		result, err = a.Client.HCService().HuaWei.Vpc.Create(
+			a.Cts.Kit.Ctx,
+			a.Cts.Kit.Header(),
+			common.ConvHuaWeiVpcCreateReq(a.req),
+		)
+		if err != nil {
+			return "", err
+		}
+		return result.ID, nil
+	})
 	if err != nil || result == nil {
 		return enumor.DeliverError, map[string]interface{}{"error": err.Error()}, err
 	}
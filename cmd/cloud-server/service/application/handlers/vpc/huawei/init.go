@@ -20,6 +20,7 @@
 package huawei
 
 import (
+	"hcm/cmd/cloud-server/logics/ipam"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	csvpc "hcm/pkg/api/cloud-server/vpc"
 	"hcm/pkg/criteria/enumor"
@@ -29,7 +30,8 @@ import (
 type ApplicationOfCreateHuaWeiVpc struct {
 	handlers.BaseApplicationHandler
 
-	req *csvpc.HuaWeiVpcCreateReq
+	req     *csvpc.HuaWeiVpcCreateReq
+	ipamLgc ipam.Interface
 }
 
 // NewApplicationOfCreateHuaWeiVpc ...
@@ -39,5 +41,6 @@ func NewApplicationOfCreateHuaWeiVpc(
 	return &ApplicationOfCreateHuaWeiVpc{
 		BaseApplicationHandler: handlers.NewBaseApplicationHandler(opt, enumor.CreateVpc, enumor.HuaWei),
 		req:                    req,
+		ipamLgc:                opt.IpamLgc,
 	}
 }
@@ -115,7 +115,11 @@ func (a *ApplicationOfCreateTCloudVpc) renderVpc() ([]formItem, error) {
 	formItems = append(formItems, formItem{Label: "名称", Value: req.Name})
 
 	// IPv4 CIDR
-	formItems = append(formItems, formItem{Label: "IPv4 CIDR", Value: req.IPv4Cidr})
+	vpcCidr, err := a.RenderCidr(req.IPv4Cidr, req.CidrPoolOption, req.VpcMaskLen)
+	if err != nil {
+		return formItems, err
+	}
+	formItems = append(formItems, formItem{Label: "IPv4 CIDR", Value: vpcCidr})
 
 	// 所属的蓝鲸云区域
 	bkCloudAreaName, err := a.GetCloudAreaName(req.BkCloudID)
@@ -135,7 +139,11 @@ func (a *ApplicationOfCreateTCloudVpc) renderSubnet() ([]formItem, error) {
 	formItems = append(formItems, formItem{Label: "子网名称", Value: req.Subnet.Name})
 
 	// IPv4 CIDR
-	formItems = append(formItems, formItem{Label: "子网IPv4 CIDR", Value: req.Subnet.IPv4Cidr})
+	subnetCidr, err := a.RenderCidr(req.Subnet.IPv4Cidr, req.CidrPoolOption, req.SubnetMaskLen)
+	if err != nil {
+		return formItems, err
+	}
+	formItems = append(formItems, formItem{Label: "子网IPv4 CIDR", Value: subnetCidr})
 
 	// 可用区
 	zoneInfo, err := a.GetZone(a.Vendor(), req.Region, req.Subnet.Zone)
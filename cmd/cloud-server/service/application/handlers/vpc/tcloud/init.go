@@ -20,6 +20,7 @@
 package tcloud
 
 import (
+	"hcm/cmd/cloud-server/logics/ipam"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	csvpc "hcm/pkg/api/cloud-server/vpc"
 	"hcm/pkg/criteria/enumor"
@@ -29,7 +30,8 @@ import (
 type ApplicationOfCreateTCloudVpc struct {
 	handlers.BaseApplicationHandler
 
-	req *csvpc.TCloudVpcCreateReq
+	req     *csvpc.TCloudVpcCreateReq
+	ipamLgc ipam.Interface
 }
 
 // NewApplicationOfCreateTCloudVpc ...
@@ -39,5 +41,6 @@ func NewApplicationOfCreateTCloudVpc(
 	return &ApplicationOfCreateTCloudVpc{
 		BaseApplicationHandler: handlers.NewBaseApplicationHandler(opt, enumor.CreateVpc, enumor.TCloud),
 		req:                    req,
+		ipamLgc:                opt.IpamLgc,
 	}
 }
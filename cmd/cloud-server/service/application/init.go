@@ -25,6 +25,8 @@ import (
 	"strings"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/logics/cvm"
+	"hcm/cmd/cloud-server/logics/ipam"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/api/core"
@@ -53,6 +55,8 @@ func InitApplicationService(c *capability.Capability, bkHcmUrl string) {
 		itsmCli:    c.ItsmCli,
 		esbCli:     c.EsbClient,
 		bkHcmUrl:   bkHcmUrl,
+		cvmLgc:     c.Logics.Cvm,
+		ipamLgc:    c.Logics.Ipam,
 	}
 	h := rest.NewHandler()
 	h.Add("List", "POST", "/applications/list", svc.List)
@@ -64,6 +68,7 @@ func InitApplicationService(c *capability.Capability, bkHcmUrl string) {
 	h.Add("CreateForCreateCvm", "POST", "/vendors/{vendor}/applications/types/create_cvm", svc.CreateForCreateCvm)
 	h.Add("CreateForCreateVpc", "POST", "/vendors/{vendor}/applications/types/create_vpc", svc.CreateForCreateVpc)
 	h.Add("CreateForCreateDisk", "POST", "/vendors/{vendor}/applications/types/create_disk", svc.CreateForCreateDisk)
+	h.Add("CreateForModifyCvm", "POST", "/applications/types/modify_cvm", svc.CreateForModifyCvm)
 
 	h.Load(c.WebService)
 }
@@ -76,6 +81,8 @@ type applicationSvc struct {
 	itsmCli    itsm.Client
 	esbCli     esb.Client
 	bkHcmUrl   string
+	cvmLgc     cvm.Interface
+	ipamLgc    ipam.Interface
 }
 
 func (a *applicationSvc) getCallbackUrl() string {
@@ -90,6 +97,8 @@ func (a *applicationSvc) getHandlerOption(cts *rest.Contexts) *handlers.HandlerO
 		EsbClient: a.esbCli,
 		Cipher:    a.cipher,
 		Audit:     a.audit,
+		CvmLgc:    a.cvmLgc,
+		IpamLgc:   a.ipamLgc,
 	}
 }
 
@@ -98,6 +107,7 @@ func (a *applicationSvc) getApprovalProcessInfo(
 ) (int64, []string, error) {
 	// DB中添加4条记录，分别对应add_account、create_cvm、create_vpc、create_disk
 	// Note：目前4条记录对应一个itsm流程id，后续如果要使用其它流程可直接修改数据库适配
+	// modify_cvm 为可选审批流程，只有在DB中添加了对应记录时，业务下的主机变更才需要走申请单审批
 	// 新增类型只需要增加对应的tye和DB记录
 	result, err := a.client.DataService().Global.ApprovalProcess.List(
 		cts.Kit.Ctx,
@@ -49,6 +49,7 @@ func InitService(c *capability.Capability) {
 
 	h.Add("GetAudit", http.MethodGet, "/audits/{id}", svc.GetAudit)
 	h.Add("ListAudit", http.MethodPost, "/audits/list", svc.ListAudit)
+	h.Add("ListResDriftEvent", http.MethodPost, "/drift_events/list", svc.ListResDriftEvent)
 
 	// biz audit apis
 	h.Add("GetBizAudit", http.MethodGet, "/bizs/{bk_biz_id}/audits/{id}", svc.GetBizAudit)
@@ -22,15 +22,54 @@ package bill
 import (
 	"time"
 
+	csbill "hcm/pkg/api/cloud-server/bill"
 	hcbillservice "hcm/pkg/api/hc-service/bill"
 	"hcm/pkg/client"
 	hcservice "hcm/pkg/client/hc-service"
 	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
+	"hcm/pkg/rest"
 )
 
+func init() {
+	registerVendor(enumor.Aws, billVendor{
+		list: func(cts *rest.Contexts, hcCli *hcservice.Client) (interface{}, error) {
+			billReq, err := getAwsListReq(cts)
+			if err != nil {
+				return nil, err
+			}
+			return hcCli.Aws.Bill.List(cts.Kit.Ctx, cts.Kit.Header(), billReq)
+		},
+		syncItem: func(kt *kit.Kit, hcCli *hcservice.Client, req *hcbillservice.BillItemSyncReq) (
+			*hcbillservice.BillItemSyncResult, error) {
+
+			return hcCli.Aws.Bill.SyncBillItem(kt.Ctx, kt.Header(), req)
+		},
+	})
+}
+
+func getAwsListReq(cts *rest.Contexts) (*hcbillservice.AwsBillListReq, error) {
+	req := new(csbill.AwsBillListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	billReq := &hcbillservice.AwsBillListReq{
+		AccountID: req.AccountID,
+		BeginDate: req.BeginDate,
+		EndDate:   req.EndDate,
+		Page:      (*hcbillservice.AwsBillListPage)(req.Page),
+	}
+	return billReq, nil
+}
+
 // BillConfigOption bill config option.
 type BillConfigOption struct {
 	AccountID string `json:"account_id" validate:"required"`
@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	csbill "hcm/pkg/api/cloud-server/bill"
+	hcbill "hcm/pkg/api/hc-service/bill"
+	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+func init() {
+	registerVendor(enumor.Azure, billVendor{
+		list: func(cts *rest.Contexts, hcCli *hcservice.Client) (interface{}, error) {
+			billReq, err := getAzureListReq(cts)
+			if err != nil {
+				return nil, err
+			}
+			return hcCli.Azure.Bill.List(cts.Kit.Ctx, cts.Kit.Header(), billReq)
+		},
+		syncItem: func(kt *kit.Kit, hcCli *hcservice.Client, req *hcbill.BillItemSyncReq) (
+			*hcbill.BillItemSyncResult, error) {
+
+			return hcCli.Azure.Bill.SyncBillItem(kt.Ctx, kt.Header(), req)
+		},
+	})
+}
+
+func getAzureListReq(cts *rest.Contexts) (*hcbill.AzureBillListReq, error) {
+	req := new(csbill.AzureBillListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	billReq := &hcbill.AzureBillListReq{
+		AccountID: req.AccountID,
+		BeginDate: req.BeginDate,
+		EndDate:   req.EndDate,
+		Page:      req.Page,
+	}
+	return billReq, nil
+}
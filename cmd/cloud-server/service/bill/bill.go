@@ -26,9 +26,8 @@ import (
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
 	cloudserver "hcm/pkg/api/cloud-server"
-	csbill "hcm/pkg/api/cloud-server/bill"
 	"hcm/pkg/api/core"
-	hcbill "hcm/pkg/api/hc-service/bill"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
@@ -49,6 +48,19 @@ func InitBillService(c *capability.Capability) {
 
 	h.Add("ListBills", "POST", "/vendors/{vendor}/bills/list", svc.ListBills)
 	h.Add("ListBillsConfig", "POST", "/bills/config/list", svc.ListBillsConfig)
+	h.Add("ListBillItems", "POST", "/bills/items/list", svc.ListBillItems)
+	h.Add("ListBillCostAllocations", "POST", "/bills/allocations/list", svc.ListBillCostAllocations)
+
+	h.Add("ListExchangeRates", "POST", "/bills/exchange_rates/list", svc.ListExchangeRates)
+	h.Add("BatchCreateExchangeRates", "POST", "/bills/exchange_rates/batch/create", svc.BatchCreateExchangeRates)
+	h.Add("BatchUpdateExchangeRates", "PATCH", "/bills/exchange_rates/batch", svc.BatchUpdateExchangeRates)
+	h.Add("BatchDeleteExchangeRates", "DELETE", "/bills/exchange_rates/batch", svc.BatchDeleteExchangeRates)
+
+	h.Add("CreateBudget", "POST", "/bills/budgets/create", svc.CreateBudget)
+	h.Add("UpdateBudget", "PATCH", "/bills/budgets/{id}", svc.UpdateBudget)
+	h.Add("ListBudgets", "POST", "/bills/budgets/list", svc.ListBudgets)
+	h.Add("BatchDeleteBudgets", "DELETE", "/bills/budgets/batch", svc.BatchDeleteBudgets)
+	h.Add("ListBudgetAlerts", "POST", "/bills/budgets/alerts/list", svc.ListBudgetAlerts)
 
 	h.Load(c.WebService)
 }
@@ -71,138 +83,12 @@ func (b *billSvc) ListBills(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	switch vendor {
-	case enumor.Aws:
-		billReq, err := b.getAwsListReq(cts)
-		if err != nil {
-			return nil, err
-		}
-		return b.client.HCService().Aws.Bill.List(cts.Kit.Ctx, cts.Kit.Header(), billReq)
-	case enumor.TCloud:
-		billReq, err := b.getTCloudListReq(cts)
-		if err != nil {
-			return nil, err
-		}
-		return b.client.HCService().TCloud.Bill.List(cts.Kit.Ctx, cts.Kit.Header(), billReq)
-	case enumor.HuaWei:
-		billReq, err := b.getHuaWeiListReq(cts)
-		if err != nil {
-			return nil, err
-		}
-		return b.client.HCService().HuaWei.Bill.List(cts.Kit.Ctx, cts.Kit.Header(), billReq)
-	case enumor.Azure:
-		billReq, err := b.getAzureListReq(cts)
-		if err != nil {
-			return nil, err
-		}
-		return b.client.HCService().Azure.Bill.List(cts.Kit.Ctx, cts.Kit.Header(), billReq)
-	case enumor.Gcp:
-		billReq, err := b.getGcpListReq(cts)
-		if err != nil {
-			return nil, err
-		}
-		return b.client.HCService().Gcp.Bill.List(cts.Kit.Ctx, cts.Kit.Header(), billReq)
-	default:
-		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", vendor))
-	}
-}
-
-func (b *billSvc) getAwsListReq(cts *rest.Contexts) (*hcbill.AwsBillListReq, error) {
-	req := new(csbill.AwsBillListReq)
-	if err := cts.DecodeInto(req); err != nil {
-		return nil, err
-	}
-
-	if err := req.Validate(); err != nil {
-		return nil, err
-	}
-
-	billReq := &hcbill.AwsBillListReq{
-		AccountID: req.AccountID,
-		BeginDate: req.BeginDate,
-		EndDate:   req.EndDate,
-		Page:      (*hcbill.AwsBillListPage)(req.Page),
-	}
-	return billReq, nil
-}
-
-func (b *billSvc) getTCloudListReq(cts *rest.Contexts) (*hcbill.TCloudBillListReq, error) {
-	req := new(csbill.TCloudBillListReq)
-	if err := cts.DecodeInto(req); err != nil {
-		return nil, err
-	}
-
-	if err := req.Validate(); err != nil {
-		return nil, err
-	}
-
-	billReq := &hcbill.TCloudBillListReq{
-		AccountID: req.AccountID,
-		Month:     req.Month,
-		BeginDate: req.BeginDate,
-		EndDate:   req.EndDate,
-		Page:      req.Page,
-		Context:   req.Context,
-	}
-	return billReq, nil
-}
-
-func (b *billSvc) getHuaWeiListReq(cts *rest.Contexts) (*hcbill.HuaWeiBillListReq, error) {
-	req := new(csbill.HuaWeiBillListReq)
-	if err := cts.DecodeInto(req); err != nil {
-		return nil, err
-	}
-
-	if err := req.Validate(); err != nil {
-		return nil, err
-	}
-
-	billReq := &hcbill.HuaWeiBillListReq{
-		AccountID: req.AccountID,
-		Month:     req.Month,
-		Page:      req.Page,
-	}
-	return billReq, nil
-}
-
-func (b *billSvc) getAzureListReq(cts *rest.Contexts) (*hcbill.AzureBillListReq, error) {
-	req := new(csbill.AzureBillListReq)
-	if err := cts.DecodeInto(req); err != nil {
-		return nil, err
-	}
-
-	if err := req.Validate(); err != nil {
-		return nil, err
-	}
-
-	billReq := &hcbill.AzureBillListReq{
-		AccountID: req.AccountID,
-		BeginDate: req.BeginDate,
-		EndDate:   req.EndDate,
-		Page:      req.Page,
-	}
-	return billReq, nil
-}
-
-func (b *billSvc) getGcpListReq(cts *rest.Contexts) (*hcbill.GcpBillListReq, error) {
-	req := new(csbill.GcpBillListReq)
-	if err := cts.DecodeInto(req); err != nil {
-		return nil, err
-	}
-
-	if err := req.Validate(); err != nil {
-		return nil, err
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	billReq := &hcbill.GcpBillListReq{
-		BillAccountID: req.BillAccountID,
-		AccountID:     req.AccountID,
-		Month:         req.Month,
-		BeginDate:     req.BeginDate,
-		EndDate:       req.EndDate,
-		Page:          req.Page,
-	}
-	return billReq, nil
+	return handler.list(cts, b.client.HCService())
 }
 
 func (b *billSvc) checkPermission(cts *rest.Contexts, resType meta.ResourceType, action meta.Action) error {
@@ -256,3 +142,45 @@ func (b *billSvc) ListBillsConfig(cts *rest.Contexts) (interface{}, error) {
 	}
 	return b.client.DataService().Global.Bill.List(cts.Kit.Ctx, cts.Kit.Header(), listReq)
 }
+
+// ListBillItems list bill items.
+func (b *billSvc) ListBillItems(cts *rest.Contexts) (interface{}, error) {
+	// 校验用户是否有拉取权限
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	req := new(cloudserver.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	return b.client.DataService().Global.Bill.ListBillItem(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// ListBillCostAllocations list bill cost allocations by biz, vendor and resource type.
+func (b *billSvc) ListBillCostAllocations(cts *rest.Contexts) (interface{}, error) {
+	// 校验用户是否有拉取权限
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	req := new(dsbill.BillCostAllocationReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return b.client.DataService().Global.Bill.ListBillCostAllocation(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
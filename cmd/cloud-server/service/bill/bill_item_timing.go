@@ -0,0 +1,155 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	hcbill "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+)
+
+// CloudBillItemSync 定时同步云账单明细到本地
+func CloudBillItemSync(intervalMin time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("cloud bill item sync pipeline enable && start, syncIntervalMin: %v", intervalMin)
+
+	for {
+		time.Sleep(intervalMin)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := kit.New()
+		kt.User = constant.BillTimingUserKey
+		kt.AppCode = constant.BillTimingAppCodeKey
+
+		start := time.Now()
+		logs.Infof("cloud bill item sync pipeline start, time: %v, rid: %s", start, kt.Rid)
+
+		// 上月账单在月初仍可能被云厂商调整，因此同时同步上月和本月账单
+		months := []string{
+			start.AddDate(0, -1, -start.Day()+1).Format(constant.MonthLayout),
+			start.Format(constant.MonthLayout),
+		}
+
+		waitGroup := new(sync.WaitGroup)
+
+		vendors := billVendors()
+		waitGroup.Add(len(vendors))
+		for _, vendor := range vendors {
+			go func(vendor enumor.Vendor) {
+				allAccountBillItemSync(kt, cliSet, vendor, months)
+				waitGroup.Done()
+			}(vendor)
+		}
+
+		waitGroup.Wait()
+
+		logs.Infof("cloud bill item sync pipeline end, cost: %v, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+// allAccountBillItemSync sync bill items of all resource accounts of the vendor.
+func allAccountBillItemSync(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor, months []string) {
+	startTime := time.Now()
+	logs.Infof("%s all account bill item sync start, time: %v, rid: %s", vendor, startTime, kt.Rid)
+
+	defer func() {
+		logs.Infof("%s all account bill item sync end, cost: %v, rid: %s", vendor, time.Since(startTime), kt.Rid)
+	}()
+
+	listReq := &protocloud.AccountListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{
+					Field: "vendor",
+					Op:    filter.Equal.Factory(),
+					Value: vendor,
+				},
+				&filter.AtomRule{
+					Field: "type",
+					Op:    filter.Equal.Factory(),
+					Value: enumor.ResourceAccount,
+				},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: core.DefaultMaxPageLimit,
+		},
+	}
+
+	start := uint32(0)
+	for {
+		listReq.Page.Start = start
+		accounts, err := listAccountWithRetry(kt, cliSet.DataService(), listReq)
+		if err != nil {
+			logs.Errorf("%s bill item sync list account failed, err: %v, rid: %s", vendor, err, kt.Rid)
+			break
+		}
+
+		for _, one := range accounts {
+			for _, month := range months {
+				req := &hcbill.BillItemSyncReq{
+					AccountID: one.ID,
+					BillMonth: month,
+				}
+				result, err := syncBillItem(kt, cliSet, vendor, req)
+				if err != nil {
+					logs.Errorf("%s account bill item sync failed, accountID: %s, month: %s, err: %v, rid: %s",
+						vendor, one.ID, month, err, kt.Rid)
+					continue
+				}
+
+				logs.Infof("%s account bill item sync success, accountID: %s, month: %s, count: %d, rid: %s",
+					vendor, one.ID, month, result.Count, kt.Rid)
+			}
+		}
+
+		if len(accounts) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		start += uint32(core.DefaultMaxPageLimit)
+	}
+}
+
+// syncBillItem call hc-service to sync bill items of the account.
+func syncBillItem(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor, req *hcbill.BillItemSyncReq) (
+	*hcbill.BillItemSyncResult, error) {
+
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.syncItem(kt, cliSet.HCService(), req)
+}
@@ -0,0 +1,149 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// CreateBudget create budget.
+func (b *billSvc) CreateBudget(cts *rest.Contexts) (interface{}, error) {
+	if err := b.checkPermission(cts, meta.CostManage, meta.Create); err != nil {
+		return nil, err
+	}
+
+	req := new(dsbill.BudgetCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	createReq := &dsbill.BudgetBatchCreateReq{Budgets: []dsbill.BudgetCreateReq{*req}}
+	if err := createReq.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := b.client.DataService().Global.Bill.BatchCreateBudget(cts.Kit.Ctx, cts.Kit.Header(), createReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, errf.Newf(errf.Aborted, "create budget but return ids count %d is invalid", len(result.IDs))
+	}
+
+	return &core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// UpdateBudget update budget.
+func (b *billSvc) UpdateBudget(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := b.checkPermission(cts, meta.CostManage, meta.Update); err != nil {
+		return nil, err
+	}
+
+	req := new(dsbill.BudgetUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	req.ID = id
+
+	updateReq := &dsbill.BudgetBatchUpdateReq{Budgets: []dsbill.BudgetUpdateReq{*req}}
+	if err := updateReq.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil, b.client.DataService().Global.Bill.BatchUpdateBudget(cts.Kit.Ctx, cts.Kit.Header(), updateReq)
+}
+
+// ListBudgets list budgets.
+func (b *billSvc) ListBudgets(cts *rest.Contexts) (interface{}, error) {
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	req := new(cloudserver.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	return b.client.DataService().Global.Bill.ListBudget(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// BatchDeleteBudgets batch delete budgets, the alerts of budgets are deleted too.
+func (b *billSvc) BatchDeleteBudgets(cts *rest.Contexts) (interface{}, error) {
+	if err := b.checkPermission(cts, meta.CostManage, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	req := new(cloudserver.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	delReq := &dataservice.BatchDeleteReq{
+		Filter: tools.ContainersExpression("id", req.IDs),
+	}
+	return nil, b.client.DataService().Global.Bill.BatchDeleteBudget(cts.Kit.Ctx, cts.Kit.Header(), delReq)
+}
+
+// ListBudgetAlerts list budget alerts.
+func (b *billSvc) ListBudgetAlerts(cts *rest.Contexts) (interface{}, error) {
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	req := new(cloudserver.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	return b.client.DataService().Global.Bill.ListBudgetAlert(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
@@ -0,0 +1,334 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+	"hcm/pkg/thirdparty/notifier"
+	"hcm/pkg/tools/math"
+)
+
+// budgetAlertMsgType budget alert notify message type.
+const budgetAlertMsgType = "budget_alert"
+
+// maxNotifyMsgLength max length of budget alert notify msg.
+const maxNotifyMsgLength = 1024
+
+// BudgetCheckTiming 定时检查预算，当月累计费用达到预算告警阈值时记录告警事件并发送通知
+func BudgetCheckTiming(cfg cc.Budget, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	interval := time.Duration(cfg.CheckIntervalMin) * time.Minute
+	logs.Infof("budget check pipeline enable && start, checkIntervalMin: %v", interval)
+
+	checker := &budgetChecker{
+		cliSet:    cliSet,
+		notifiers: notifier.NewNotifiers(cfg.Webhook),
+	}
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := kit.New()
+		kt.User = constant.BillTimingUserKey
+		kt.AppCode = constant.BillTimingAppCodeKey
+
+		start := time.Now()
+		logs.Infof("budget check pipeline start, time: %v, rid: %s", start, kt.Rid)
+
+		checker.checkAllBudgets(kt, start.Format(constant.MonthLayout))
+
+		logs.Infof("budget check pipeline end, cost: %v, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+type budgetChecker struct {
+	cliSet    *client.ClientSet
+	notifiers []notifier.Notifier
+}
+
+// checkAllBudgets check all budgets of the month.
+func (c *budgetChecker) checkAllBudgets(kt *kit.Kit, month string) {
+	listReq := &core.ListReq{
+		Filter: tools.AllExpression(),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	for {
+		result, err := c.cliSet.DataService().Global.Bill.ListBudget(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list budget failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		for _, budget := range result.Details {
+			if err = c.checkBudget(kt, budget, month); err != nil {
+				logs.Errorf("check budget failed, id: %s, month: %s, err: %v, rid: %s", budget.ID, month, err, kt.Rid)
+				continue
+			}
+		}
+
+		if len(result.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+}
+
+// checkBudget compare month-to-date spend with the budget thresholds, record and notify new alerts.
+func (c *budgetChecker) checkBudget(kt *kit.Kit, budget cloud.Budget, month string) error {
+	spend, err := c.getBudgetSpend(kt, budget, month)
+	if err != nil {
+		return err
+	}
+
+	amount, err := math.NewDecimalFromString(budget.Amount)
+	if err != nil {
+		return fmt.Errorf("parse budget amount %s failed, err: %v", budget.Amount, err)
+	}
+
+	alerted, err := c.listAlertedThresholds(kt, budget.ID, month)
+	if err != nil {
+		return err
+	}
+
+	thresholds := append([]int64{}, budget.Thresholds...)
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i] < thresholds[j] })
+
+	hundred, _ := math.NewDecimalFromString("100")
+	for _, threshold := range thresholds {
+		alert, exist := alerted[threshold]
+		if exist && alert.NotifyStatus != enumor.FailedBudgetNotifyStatus {
+			continue
+		}
+
+		percent, err := math.NewDecimalFromString(strconv.FormatInt(threshold, 10))
+		if err != nil {
+			return err
+		}
+
+		// spend / amount >= threshold / 100
+		if spend.Mul(hundred).Cmp(amount.Mul(percent)) < 0 {
+			break
+		}
+
+		// 通知失败的告警在之后的检查中重新通知，并更新通知结果
+		if exist {
+			if err = c.retryAlert(kt, budget, alert, spend); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err = c.createAlert(kt, budget, month, uint64(threshold), spend); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getBudgetSpend get the month-to-date spend of the budget scope in the budget currency.
+func (c *budgetChecker) getBudgetSpend(kt *kit.Kit, budget cloud.Budget, month string) (math.Decimal, error) {
+	req := &dsbill.BillCostAllocationReq{
+		BillMonth:      month,
+		ReportCurrency: budget.Currency,
+	}
+	switch budget.ScopeType {
+	case enumor.AccountBudgetScope:
+		req.AccountIDs = []string{budget.ScopeValue}
+	case enumor.VendorBudgetScope:
+		req.Vendors = []enumor.Vendor{enumor.Vendor(budget.ScopeValue)}
+	case enumor.BizBudgetScope:
+		bizID, err := strconv.ParseInt(budget.ScopeValue, 10, 64)
+		if err != nil {
+			return math.Decimal{}, fmt.Errorf("parse budget bk_biz_id %s failed, err: %v", budget.ScopeValue, err)
+		}
+		req.BkBizIDs = []int64{bizID}
+	default:
+		return math.Decimal{}, fmt.Errorf("unsupported budget scope type: %s", budget.ScopeType)
+	}
+
+	result, err := c.cliSet.DataService().Global.Bill.ListBillCostAllocation(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list budget bill cost allocation failed, err: %v, budget: %s, rid: %s", err, budget.ID, kt.Rid)
+		return math.Decimal{}, err
+	}
+
+	spend := math.Decimal{}
+	for _, one := range result.Details {
+		cost, err := math.NewDecimalFromString(one.Cost)
+		if err != nil {
+			return math.Decimal{}, fmt.Errorf("parse cost %s failed, err: %v", one.Cost, err)
+		}
+		spend = spend.Add(cost)
+	}
+
+	return spend, nil
+}
+
+// listAlertedThresholds list the alerts of the month, returns map of threshold and its alert.
+func (c *budgetChecker) listAlertedThresholds(kt *kit.Kit, budgetID, month string) (map[int64]cloud.BudgetAlert,
+	error) {
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "budget_id", Op: filter.Equal.Factory(), Value: budgetID},
+				filter.AtomRule{Field: "bill_month", Op: filter.Equal.Factory(), Value: month},
+			},
+		},
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "bill_month", "threshold", "notify_status"},
+	}
+	result, err := c.cliSet.DataService().Global.Bill.ListBudgetAlert(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list budget alert failed, err: %v, budget: %s, rid: %s", err, budgetID, kt.Rid)
+		return nil, err
+	}
+
+	alerted := make(map[int64]cloud.BudgetAlert, len(result.Details))
+	for _, one := range result.Details {
+		alerted[int64(one.Threshold)] = one
+	}
+
+	return alerted, nil
+}
+
+// createAlert notify the budget alert and record it.
+func (c *budgetChecker) createAlert(kt *kit.Kit, budget cloud.Budget, month string, threshold uint64,
+	spend math.Decimal) error {
+
+	alert := dsbill.BudgetAlertCreateReq{
+		BudgetID:   budget.ID,
+		ScopeType:  budget.ScopeType,
+		ScopeValue: budget.ScopeValue,
+		BillMonth:  month,
+		Threshold:  threshold,
+		Amount:     budget.Amount,
+		Spend:      spend.ToString(),
+		Currency:   budget.Currency,
+	}
+	alert.NotifyStatus, alert.NotifyMsg = c.notify(kt, budget, &alert)
+
+	createReq := &dsbill.BudgetAlertBatchCreateReq{Alerts: []dsbill.BudgetAlertCreateReq{alert}}
+	if _, err := c.cliSet.DataService().Global.Bill.BatchCreateBudgetAlert(kt.Ctx, kt.Header(),
+		createReq); err != nil {
+		logs.Errorf("create budget alert failed, err: %v, alert: %+v, rid: %s", err, alert, kt.Rid)
+		return err
+	}
+
+	logs.Infof("budget alert created, budget: %s, month: %s, threshold: %d, spend: %s, rid: %s", budget.ID, month,
+		threshold, alert.Spend, kt.Rid)
+
+	return nil
+}
+
+// retryAlert notify the budget alert which is failed to notify before, and update its notify result.
+func (c *budgetChecker) retryAlert(kt *kit.Kit, budget cloud.Budget, alert cloud.BudgetAlert,
+	spend math.Decimal) error {
+
+	msg := dsbill.BudgetAlertCreateReq{
+		BudgetID:   budget.ID,
+		ScopeType:  budget.ScopeType,
+		ScopeValue: budget.ScopeValue,
+		BillMonth:  alert.BillMonth,
+		Threshold:  alert.Threshold,
+		Amount:     budget.Amount,
+		Spend:      spend.ToString(),
+		Currency:   budget.Currency,
+	}
+	status, notifyMsg := c.notify(kt, budget, &msg)
+
+	updateReq := &dsbill.BudgetAlertBatchUpdateReq{Alerts: []dsbill.BudgetAlertUpdateReq{{
+		ID:           alert.ID,
+		Spend:        msg.Spend,
+		NotifyStatus: status,
+		NotifyMsg:    notifyMsg,
+	}}}
+	if err := c.cliSet.DataService().Global.Bill.BatchUpdateBudgetAlert(kt.Ctx, kt.Header(), updateReq); err != nil {
+		logs.Errorf("update budget alert failed, err: %v, alert: %s, rid: %s", err, alert.ID, kt.Rid)
+		return err
+	}
+
+	logs.Infof("budget alert renotified, budget: %s, month: %s, threshold: %d, status: %s, rid: %s", budget.ID,
+		alert.BillMonth, alert.Threshold, status, kt.Rid)
+
+	return nil
+}
+
+// notify send budget alert by all notifiers, returns notify status and message.
+func (c *budgetChecker) notify(kt *kit.Kit, budget cloud.Budget, alert *dsbill.BudgetAlertCreateReq) (
+	enumor.BudgetNotifyStatus, string) {
+
+	if len(c.notifiers) == 0 {
+		return enumor.SkippedBudgetNotifyStatus, ""
+	}
+
+	msg := &notifier.Message{
+		Type:  budgetAlertMsgType,
+		Title: fmt.Sprintf("预算[%s]告警", budget.Name),
+		Content: fmt.Sprintf("预算[%s](%s: %s) %s月累计费用 %s %s，已达到预算 %s %s 的%d%%", budget.Name,
+			budget.ScopeType, budget.ScopeValue, alert.BillMonth, alert.Spend, alert.Currency, alert.Amount,
+			alert.Currency, alert.Threshold),
+		Data: alert,
+	}
+
+	errMsgs := make([]string, 0)
+	for _, one := range c.notifiers {
+		if err := one.Notify(kt, msg); err != nil {
+			logs.Errorf("%s notify budget alert failed, err: %v, budget: %s, rid: %s", one.Name(), err, budget.ID,
+				kt.Rid)
+			errMsgs = append(errMsgs, fmt.Sprintf("%s: %v", one.Name(), err))
+		}
+	}
+
+	if len(errMsgs) == 0 {
+		return enumor.SuccessBudgetNotifyStatus, ""
+	}
+
+	errMsg := []rune(strings.Join(errMsgs, "; "))
+	if len(errMsg) > maxNotifyMsgLength {
+		errMsg = errMsg[:maxNotifyMsgLength]
+	}
+
+	return enumor.FailedBudgetNotifyStatus, string(errMsg)
+}
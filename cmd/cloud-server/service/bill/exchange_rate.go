@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// ListExchangeRates list exchange rates.
+func (b *billSvc) ListExchangeRates(cts *rest.Contexts) (interface{}, error) {
+	if err := b.checkPermission(cts, meta.CostManage, meta.Find); err != nil {
+		return nil, err
+	}
+
+	req := new(cloudserver.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	return b.client.DataService().Global.Bill.ListExchangeRate(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// BatchCreateExchangeRates batch create exchange rates.
+func (b *billSvc) BatchCreateExchangeRates(cts *rest.Contexts) (interface{}, error) {
+	if err := b.checkPermission(cts, meta.CostManage, meta.Create); err != nil {
+		return nil, err
+	}
+
+	req := new(dsbill.ExchangeRateBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return b.client.DataService().Global.Bill.BatchCreateExchangeRate(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// BatchUpdateExchangeRates batch update exchange rates.
+func (b *billSvc) BatchUpdateExchangeRates(cts *rest.Contexts) (interface{}, error) {
+	if err := b.checkPermission(cts, meta.CostManage, meta.Update); err != nil {
+		return nil, err
+	}
+
+	req := new(dsbill.ExchangeRateBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil, b.client.DataService().Global.Bill.BatchUpdateExchangeRate(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// BatchDeleteExchangeRates batch delete exchange rates.
+func (b *billSvc) BatchDeleteExchangeRates(cts *rest.Contexts) (interface{}, error) {
+	if err := b.checkPermission(cts, meta.CostManage, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	req := new(cloudserver.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	delReq := &dataservice.BatchDeleteReq{
+		Filter: tools.ContainersExpression("id", req.IDs),
+	}
+	return nil, b.client.DataService().Global.Bill.BatchDeleteExchangeRate(cts.Kit.Ctx, cts.Kit.Header(), delReq)
+}
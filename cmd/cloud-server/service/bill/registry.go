@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"sort"
+
+	hcbill "hcm/pkg/api/hc-service/bill"
+	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// billVendor 云厂商账单的拉取和明细同步方法，均为必填，各厂商在 init 中通过 registerVendor 注册，
+// 不支持云账单的厂商不注册。
+type billVendor struct {
+	// list 拉取云账单
+	list func(cts *rest.Contexts, hcCli *hcservice.Client) (interface{}, error)
+	// syncItem 同步账号的云账单明细到本地
+	syncItem func(kt *kit.Kit, hcCli *hcservice.Client, req *hcbill.BillItemSyncReq) (
+		*hcbill.BillItemSyncResult, error)
+}
+
+// vendorBills 只在 init 中写入，之后只读，无需加锁。
+var vendorBills = make(map[enumor.Vendor]billVendor)
+
+// registerVendor register vendor bill handler, register the same vendor twice will panic.
+func registerVendor(vendor enumor.Vendor, handler billVendor) {
+	if len(vendor) == 0 || handler.list == nil || handler.syncItem == nil {
+		panic("register bill vendor and all handler funcs are required")
+	}
+
+	if _, exist := vendorBills[vendor]; exist {
+		panic(fmt.Sprintf("vendor: %s bill handler is already registered", vendor))
+	}
+	vendorBills[vendor] = handler
+}
+
+func getVendor(vendor enumor.Vendor) (billVendor, error) {
+	handler, exist := vendorBills[vendor]
+	if !exist {
+		return billVendor{}, fmt.Errorf("no support vendor: %s", vendor)
+	}
+
+	return handler, nil
+}
+
+// billVendors returns sorted vendors that support cloud bill.
+func billVendors() []enumor.Vendor {
+	vendors := make([]enumor.Vendor, 0, len(vendorBills))
+	for vendor := range vendorBills {
+		vendors = append(vendors, vendor)
+	}
+	sort.Slice(vendors, func(i, j int) bool { return vendors[i] < vendors[j] })
+
+	return vendors
+}
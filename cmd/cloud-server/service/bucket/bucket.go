@@ -0,0 +1,213 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bucket ...
+package bucket
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	csbucket "hcm/pkg/api/cloud-server/bucket"
+	"hcm/pkg/api/core"
+	corebucket "hcm/pkg/api/core/cloud/bucket"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	protobucket "hcm/pkg/api/data-service/cloud/bucket"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitBucketService initialize the bucket service.
+func InitBucketService(c *capability.Capability) {
+	svc := &bucketSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListBucket", http.MethodPost, "/buckets/list", svc.ListBucket)
+	h.Add("ListPublicBucket", http.MethodPost, "/buckets/public/list", svc.ListPublicBucket)
+	h.Add("AssignBucketToBiz", http.MethodPost, "/buckets/assign/bizs", svc.AssignBucketToBiz)
+
+	// bucket apis in biz
+	h.Add("ListBizBucket", http.MethodPost, "/bizs/{bk_biz_id}/buckets/list", svc.ListBizBucket)
+	h.Add("ListBizPublicBucket", http.MethodPost, "/bizs/{bk_biz_id}/buckets/public/list", svc.ListBizPublicBucket)
+
+	h.Load(c.WebService)
+}
+
+type bucketSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
+
+// ListBucket list bucket.
+func (svc *bucketSvc) ListBucket(cts *rest.Contexts) (interface{}, error) {
+	return svc.listBucket(cts, handler.ListResourceAuthRes, false)
+}
+
+// ListBizBucket list biz bucket.
+func (svc *bucketSvc) ListBizBucket(cts *rest.Contexts) (interface{}, error) {
+	return svc.listBucket(cts, handler.ListBizAuthRes, false)
+}
+
+// ListPublicBucket list publicly readable buckets of all accounts the user has permission of.
+func (svc *bucketSvc) ListPublicBucket(cts *rest.Contexts) (interface{}, error) {
+	return svc.listBucket(cts, handler.ListResourceAuthRes, true)
+}
+
+// ListBizPublicBucket list publicly readable buckets of biz.
+func (svc *bucketSvc) ListBizPublicBucket(cts *rest.Contexts) (interface{}, error) {
+	return svc.listBucket(cts, handler.ListBizAuthRes, true)
+}
+
+func (svc *bucketSvc) listBucket(cts *rest.Contexts, authHandler handler.ListAuthResHandler, onlyPublic bool) (
+	interface{}, error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Bucket, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &protobucket.BucketListResult{Details: make([]corebucket.Bucket, 0)}, nil
+	}
+	req.Filter = expr
+
+	if onlyPublic {
+		req.Filter, err = tools.And(expr, &filter.AtomRule{Field: "public_read", Op: filter.Equal.Factory(),
+			Value: true})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return svc.client.DataService().Global.Bucket.ListBucket(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// AssignBucketToBiz assign bucket to biz.
+func (svc *bucketSvc) AssignBucketToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(csbucket.AssignBucketToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeBucketAssignOp(cts.Kit, req.BucketIDs, req.BkBizID); err != nil {
+		return nil, err
+	}
+
+	// check if all buckets are not assigned to biz, right now assigning resource twice is not allowed
+	listReq := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: req.BucketIDs},
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.NotEqual.Factory(), Value: constant.UnassignedBiz},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.Bucket.ListBucket(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list bucket failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != 0 {
+		ids := make([]string, len(result.Details))
+		for index, one := range result.Details {
+			ids[index] = one.ID
+		}
+		return nil, fmt.Errorf("bucket(ids=%v) already assigned", ids)
+	}
+
+	// create assign audit.
+	if err = svc.audit.ResBizAssignAudit(cts.Kit, enumor.BucketAuditResType, req.BucketIDs, req.BkBizID); err != nil {
+		logs.Errorf("create bucket assign audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	update := &protobucket.BucketCommonInfoBatchUpdateReq{
+		IDs:     req.BucketIDs,
+		BkBizID: req.BkBizID,
+	}
+	err = svc.client.DataService().Global.Bucket.BatchUpdateBucketCommonInfo(cts.Kit.Ctx, cts.Kit.Header(), update)
+	if err != nil {
+		logs.Errorf("batch update bucket common info failed, req: %+v, err: %v, rid: %s", req, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *bucketSvc) authorizeBucketAssignOp(kt *kit.Kit, ids []string, bizID int64) error {
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.BucketCloudResType,
+		IDs:          ids,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(kt.Ctx, kt.Header(), basicInfoReq)
+	if err != nil {
+		return err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		authRes = append(authRes, meta.ResourceAttribute{
+			Basic: &meta.Basic{
+				Type:       meta.Bucket,
+				Action:     meta.Assign,
+				ResourceID: info.AccountID,
+			},
+			BizID: bizID,
+		})
+	}
+
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes...)
+}
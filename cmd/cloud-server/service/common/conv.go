@@ -65,6 +65,7 @@ func ConvTCloudCvmCreateReq(req *cscvm.TCloudCvmCreateReq) *hcproto.TCloudBatchC
 		InstanceType:          req.InstanceType,
 		CloudImageID:          req.CloudImageID,
 		Password:              req.Password,
+		KeyPairID:             req.KeyPairID,
 		RequiredCount:         req.RequiredCount,
 		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
 		CloudVpcID:            req.CloudVpcID,
@@ -121,6 +122,7 @@ func ConvAwsCvmCreateReq(req *cscvm.AwsCvmCreateReq) *hcproto.AwsBatchCreateReq
 		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
 		BlockDeviceMapping:    blockDeviceMapping,
 		Password:              req.Password,
+		KeyPairID:             req.KeyPairID,
 		RequiredCount:         req.RequiredCount,
 	}
 
@@ -155,6 +157,7 @@ func ConvGcpCvmCreateReq(req *cscvm.GcpCvmCreateReq) *hcproto.GcpBatchCreateReq
 		InstanceType:  req.InstanceType,
 		CloudImageID:  req.CloudImageID,
 		Password:      req.Password,
+		KeyPairID:     req.KeyPairID,
 		RequiredCount: req.RequiredCount,
 		CloudVpcID:    req.CloudVpcID,
 		CloudSubnetID: req.CloudSubnetID,
@@ -200,6 +203,7 @@ func ConvAzureCvmCreateReq(req *cscvm.AzureCvmCreateReq) *hcproto.AzureBatchCrea
 		CloudImageID:         req.CloudImageID,
 		Username:             req.Username,
 		Password:             req.Password,
+		KeyPairID:            req.KeyPairID,
 		CloudSubnetID:        req.CloudSubnetID,
 		CloudSecurityGroupID: req.CloudSecurityGroupIDs[0],
 		OSDisk: &typecvm.AzureOSDisk{
@@ -242,6 +246,7 @@ func ConvHuaWeiCvmCreateReq(req *cscvm.HuaWeiCvmCreateReq) *hcproto.HuaWeiBatchC
 		InstanceType:          req.InstanceType,
 		CloudImageID:          req.CloudImageID,
 		Password:              req.Password,
+		KeyPairID:             req.KeyPairID,
 		RequiredCount:         int32(req.RequiredCount),
 		CloudSecurityGroupIDs: req.CloudSecurityGroupIDs,
 		CloudVpcID:            req.CloudVpcID,
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	logicskp "hcm/cmd/cloud-server/logics/key-pair"
 	"hcm/cmd/cloud-server/service/common"
 	cloudserver "hcm/pkg/api/cloud-server"
 	cscvm "hcm/pkg/api/cloud-server/cvm"
@@ -84,6 +85,10 @@ func (svc *cvmSvc) createAzureCvm(kt *kit.Kit, body json.RawMessage) (interface{
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := logicskp.CheckKeyPairUsable(kt, svc.client.DataService(), req.BkBizID, req.KeyPairID); err != nil {
+		return nil, err
+	}
+
 	result, err := svc.client.HCService().Azure.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(),
 		common.ConvAzureCvmCreateReq(req))
 	if err != nil {
@@ -105,6 +110,10 @@ func (svc *cvmSvc) createHuaWeiCvm(kt *kit.Kit, body json.RawMessage) (interface
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := logicskp.CheckKeyPairUsable(kt, svc.client.DataService(), req.BkBizID, req.KeyPairID); err != nil {
+		return nil, err
+	}
+
 	result, err := svc.client.HCService().HuaWei.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(),
 		common.ConvHuaWeiCvmCreateReq(req))
 	if err != nil {
@@ -126,6 +135,10 @@ func (svc *cvmSvc) createGcpCvm(kt *kit.Kit, body json.RawMessage) (interface{},
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := logicskp.CheckKeyPairUsable(kt, svc.client.DataService(), req.BkBizID, req.KeyPairID); err != nil {
+		return nil, err
+	}
+
 	result, err := svc.client.HCService().Gcp.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), common.ConvGcpCvmCreateReq(req))
 	if err != nil {
 		logs.Errorf("batch create gcp cvm failed, err: %v, result: %v, rid: %s", err, result, kt.Rid)
@@ -146,6 +159,10 @@ func (svc *cvmSvc) createAwsCvm(kt *kit.Kit, body json.RawMessage) (interface{},
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := logicskp.CheckKeyPairUsable(kt, svc.client.DataService(), req.BkBizID, req.KeyPairID); err != nil {
+		return nil, err
+	}
+
 	result, err := svc.client.HCService().Aws.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), common.ConvAwsCvmCreateReq(req))
 	if err != nil {
 		logs.Errorf("batch create aws cvm failed, err: %v, result: %v, rid: %s", err, result, kt.Rid)
@@ -166,6 +183,10 @@ func (svc *cvmSvc) createTCloudCvm(kt *kit.Kit, body json.RawMessage) (interface
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := logicskp.CheckKeyPairUsable(kt, svc.client.DataService(), req.BkBizID, req.KeyPairID); err != nil {
+		return nil, err
+	}
+
 	result, err := svc.client.HCService().TCloud.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(),
 		common.ConvTCloudCvmCreateReq(req))
 	if err != nil {
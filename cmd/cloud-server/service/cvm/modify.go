@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	proto "hcm/pkg/api/cloud-server/cvm"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ModifyCvm modify cvm in place.
+func (svc *cvmSvc) ModifyCvm(cts *rest.Contexts) (interface{}, error) {
+	return svc.modifyCvmSvc(cts, handler.ResValidWithAuth, false)
+}
+
+// ModifyBizCvm modify biz cvm in place.
+func (svc *cvmSvc) ModifyBizCvm(cts *rest.Contexts) (interface{}, error) {
+	return svc.modifyCvmSvc(cts, handler.BizValidWithAuth, true)
+}
+
+func (svc *cvmSvc) modifyCvmSvc(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	checkApproval bool) (interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.ModifyCvmReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.CvmCloudResType, id, append(types.CommonBasicInfoFields, "region", "recycle_status")...)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Cvm,
+		Action: meta.Update, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	// 业务下的主机变更配置了审批流程时，需要通过申请单审批后再执行变更
+	if checkApproval {
+		needApproval, err := svc.isApprovalProcessInit(cts.Kit, enumor.ModifyCvm)
+		if err != nil {
+			return nil, err
+		}
+
+		if needApproval {
+			return nil, errf.Newf(errf.InvalidParameter, "modify cvm needs approval, please submit an application "+
+				"of %s", enumor.ModifyCvm)
+		}
+	}
+
+	if err = svc.cvmLgc.ModifyCvm(cts.Kit, *basicInfo, req); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// isApprovalProcessInit 判断指定类型的申请单是否配置了审批流程
+func (svc *cvmSvc) isApprovalProcessInit(kt *kit.Kit, applicationType enumor.ApplicationType) (bool, error) {
+	listReq := &dataproto.ApprovalProcessListReq{
+		Filter: tools.EqualExpression("application_type", applicationType),
+		Page:   &core.BasePage{Count: true},
+	}
+	result, err := svc.client.DataService().Global.ApprovalProcess.List(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list approval process failed, err: %v, type: %s, rid: %s", err, applicationType, kt.Rid)
+		return false, err
+	}
+
+	return result.Count > 0, nil
+}
@@ -22,6 +22,7 @@ package image
 import (
 	"net/http"
 
+	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -33,6 +34,7 @@ func InitImageService(c *capability.Capability) {
 	svc := &imageSvc{
 		client:     c.ApiClient,
 		authorizer: c.Authorizer,
+		audit:      c.Audit,
 	}
 
 	h := rest.NewHandler()
@@ -40,10 +42,17 @@ func InitImageService(c *capability.Capability) {
 	h.Add("RetrieveImage", http.MethodGet, "/vendors/{vendor}/images/{id}", svc.RetrieveImage)
 	h.Add("ListImage", http.MethodPost, "/images/list", svc.ListImage)
 
+	// private image apis, private image belongs to account and is not assigned to biz
+	h.Add("CreateImage", http.MethodPost, "/images/create", svc.CreateImage)
+	h.Add("CopyImage", http.MethodPost, "/images/{id}/copy", svc.CopyImage)
+	h.Add("ShareImage", http.MethodPost, "/images/{id}/share", svc.ShareImage)
+	h.Add("DeleteImage", http.MethodDelete, "/images/{id}", svc.DeleteImage)
+
 	h.Load(c.WebService)
 }
 
 type imageSvc struct {
 	client     *client.ClientSet
 	authorizer auth.Authorizer
+	audit      audit.Interface
 }
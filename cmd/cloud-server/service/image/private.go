@@ -0,0 +1,204 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package image
+
+import (
+	protoaudit "hcm/pkg/api/data-service/audit"
+	hcimage "hcm/pkg/api/hc-service/image"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// imageBasicInfoFields 镜像表没有业务字段，只能按账号鉴权
+var imageBasicInfoFields = []string{"id", "vendor", "account_id"}
+
+// CreateImage create private image from stopped cvm.
+func (svc *imageSvc) CreateImage(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcimage.ImageCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvmInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.CvmCloudResType, req.CvmID)
+	if err != nil {
+		return nil, err
+	}
+
+	// private image belongs to account, so authorize image create action of the cvm's account.
+	err = handler.ResValidWithAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Image,
+		Action: meta.Create, BasicInfo: &types.CloudResourceBasicInfo{ID: cvmInfo.ID, AccountID: cvmInfo.AccountID}})
+	if err != nil {
+		return nil, err
+	}
+
+	switch cvmInfo.Vendor {
+	case enumor.TCloud:
+		return svc.client.HCService().TCloud.Image.CreateImage(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.Aws:
+		return svc.client.HCService().Aws.Image.CreateImage(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.HuaWei:
+		return svc.client.HCService().HuaWei.Image.CreateImage(cts.Kit.Ctx, cts.Kit.Header(), req)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support create image", cvmInfo.Vendor)
+	}
+}
+
+// CopyImage copy private image to other regions.
+func (svc *imageSvc) CopyImage(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcimage.ImageCopyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	id := cts.PathParameter("id").String()
+	basicInfo, err := svc.validPrivateImage(cts, id, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.audit.ResBaseOperationAudit(cts.Kit, enumor.ImageAuditResType, protoaudit.Copy, []string{id})
+	if err != nil {
+		logs.Errorf("create image copy audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return svc.client.HCService().TCloud.Image.CopyImage(cts.Kit.Ctx, cts.Kit.Header(), id, req)
+	case enumor.Aws:
+		return svc.client.HCService().Aws.Image.CopyImage(cts.Kit.Ctx, cts.Kit.Header(), id, req)
+	case enumor.HuaWei:
+		return svc.client.HCService().HuaWei.Image.CopyImage(cts.Kit.Ctx, cts.Kit.Header(), id, req)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support copy image", basicInfo.Vendor)
+	}
+}
+
+// ShareImage share private image with other accounts of the same vendor.
+func (svc *imageSvc) ShareImage(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcimage.ImageShareReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	id := cts.PathParameter("id").String()
+	basicInfo, err := svc.validPrivateImage(cts, id, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.audit.ResBaseOperationAudit(cts.Kit, enumor.ImageAuditResType, protoaudit.Share, []string{id})
+	if err != nil {
+		logs.Errorf("create image share audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return nil, svc.client.HCService().TCloud.Image.ShareImage(cts.Kit.Ctx, cts.Kit.Header(), id, req)
+	case enumor.Aws:
+		return nil, svc.client.HCService().Aws.Image.ShareImage(cts.Kit.Ctx, cts.Kit.Header(), id, req)
+	case enumor.HuaWei:
+		return nil, svc.client.HCService().HuaWei.Image.ShareImage(cts.Kit.Ctx, cts.Kit.Header(), id, req)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support share image", basicInfo.Vendor)
+	}
+}
+
+// DeleteImage delete private image.
+func (svc *imageSvc) DeleteImage(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	basicInfo, err := svc.validPrivateImage(cts, id, meta.Delete)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.audit.ResDeleteAudit(cts.Kit, enumor.ImageAuditResType, []string{id}); err != nil {
+		logs.Errorf("create image delete audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return nil, svc.client.HCService().TCloud.Image.DeleteImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.Aws:
+		return nil, svc.client.HCService().Aws.Image.DeleteImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.HuaWei:
+		return nil, svc.client.HCService().HuaWei.Image.DeleteImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support delete image", basicInfo.Vendor)
+	}
+}
+
+// validPrivateImage get image basic info and authorize action of its account, public image can not be operated.
+func (svc *imageSvc) validPrivateImage(cts *rest.Contexts, id string, action meta.Action) (
+	*types.CloudResourceBasicInfo, error) {
+
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.getImageBasicInfo(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(basicInfo.AccountID) == 0 {
+		return nil, errf.Newf(errf.InvalidParameter, "image: %s is public image, can not be operated", id)
+	}
+
+	err = handler.ResValidWithAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Image,
+		Action: action, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	return basicInfo, nil
+}
+
+func (svc *imageSvc) getImageBasicInfo(kt *kit.Kit, id string) (*types.CloudResourceBasicInfo, error) {
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(kt.Ctx, kt.Header(),
+		enumor.ImageCloudResType, id, imageBasicInfoFields...)
+	if err != nil {
+		logs.Errorf("get image basic info failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	return basicInfo, nil
+}
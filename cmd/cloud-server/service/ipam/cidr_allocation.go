@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	"hcm/cmd/cloud-server/logics/ipam"
+	cloudserver "hcm/pkg/api/cloud-server"
+	csipam "hcm/pkg/api/cloud-server/ipam"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// ListCidrAllocation list cidr allocations of cidr pool.
+func (svc *ipamSvc) ListCidrAllocation(cts *rest.Contexts) (interface{}, error) {
+	poolID := cts.PathParameter("id").String()
+	if len(poolID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(cloudserver.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	rules := []filter.RuleFactory{tools.EqualExpression("pool_id", poolID)}
+	if req.Filter != nil && !req.Filter.IsEmpty() {
+		rules = append(rules, req.Filter)
+	}
+	listFilter, err := tools.And(rules...)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: listFilter,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.Ipam.ListCidrAllocation(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// AllocateCidr reserve a cidr from cidr pool, the reserved cidr is kept until released.
+func (svc *ipamSvc) AllocateCidr(cts *rest.Contexts) (interface{}, error) {
+	poolID := cts.PathParameter("id").String()
+	if len(poolID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csipam.AllocateCidrReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, meta.Update); err != nil {
+		return nil, err
+	}
+
+	opt := &ipam.AllocateOption{
+		PoolID:    poolID,
+		MaskLen:   req.MaskLen,
+		Vendor:    req.Vendor,
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		Memo:      req.Memo,
+	}
+	allocation, err := svc.ipamLgc.AllocateCidr(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("allocate cidr failed, err: %v, pool: %s, rid: %s", err, poolID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &csipam.AllocateCidrResult{ID: allocation.ID, Cidr: allocation.Cidr}, nil
+}
+
+// ReleaseCidr release cidr allocations.
+func (svc *ipamSvc) ReleaseCidr(cts *rest.Contexts) (interface{}, error) {
+	req := new(cloudserver.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, meta.Update); err != nil {
+		return nil, err
+	}
+
+	return nil, svc.ipamLgc.ReleaseCidr(cts.Kit, req.IDs)
+}
@@ -0,0 +1,233 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	cloudserver "hcm/pkg/api/cloud-server"
+	csipam "hcm/pkg/api/cloud-server/ipam"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	protoipam "hcm/pkg/api/data-service/cloud/ipam"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/cidr"
+)
+
+// CreateCidrPool create cidr pool, cidr of pool can not overlap with other pools.
+func (svc *ipamSvc) CreateCidrPool(cts *rest.Contexts) (interface{}, error) {
+	req := new(csipam.CreateCidrPoolReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, meta.Create); err != nil {
+		return nil, err
+	}
+
+	pools, err := svc.listAllCidrPool(cts.Kit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pool := range pools {
+		overlap, err := cidr.CidrOverlap(pool.IPv4Cidr, req.IPv4Cidr)
+		if err != nil {
+			return nil, err
+		}
+
+		if overlap {
+			return nil, errf.Newf(errf.InvalidParameter, "cidr %s overlaps with cidr pool %s(%s)", req.IPv4Cidr,
+				pool.Name, pool.IPv4Cidr)
+		}
+	}
+
+	createReq := &protoipam.CidrPoolBatchCreateReq{
+		CidrPools: []protoipam.CidrPoolCreateReq{{
+			Name:        req.Name,
+			BkBizID:     req.BkBizID,
+			Region:      req.Region,
+			Environment: req.Environment,
+			IPv4Cidr:    req.IPv4Cidr,
+			Memo:        req.Memo,
+		}},
+	}
+	result, err := svc.client.DataService().Global.Ipam.BatchCreateCidrPool(cts.Kit.Ctx, cts.Kit.Header(), createReq)
+	if err != nil {
+		logs.Errorf("create cidr pool failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, errf.Newf(errf.Aborted, "create cidr pool but return ids count %d is invalid", len(result.IDs))
+	}
+
+	return &core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// UpdateCidrPool update cidr pool.
+func (svc *ipamSvc) UpdateCidrPool(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csipam.UpdateCidrPoolReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &protoipam.CidrPoolBatchUpdateReq{
+		CidrPools: []protoipam.CidrPoolUpdateReq{{
+			ID:          id,
+			Name:        req.Name,
+			BkBizID:     req.BkBizID,
+			Region:      req.Region,
+			Environment: req.Environment,
+			Memo:        req.Memo,
+		}},
+	}
+	return nil, svc.client.DataService().Global.Ipam.BatchUpdateCidrPool(cts.Kit.Ctx, cts.Kit.Header(), updateReq)
+}
+
+// ListCidrPool list cidr pool.
+func (svc *ipamSvc) ListCidrPool(cts *rest.Contexts) (interface{}, error) {
+	req := new(cloudserver.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.Ipam.ListCidrPool(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// BatchDeleteCidrPool batch delete cidr pool, pool with allocations can not be deleted.
+func (svc *ipamSvc) BatchDeleteCidrPool(cts *rest.Contexts) (interface{}, error) {
+	req := new(cloudserver.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	delReq := &dataservice.BatchDeleteReq{
+		Filter: tools.ContainersExpression("id", req.IDs),
+	}
+	return nil, svc.client.DataService().Global.Ipam.BatchDeleteCidrPool(cts.Kit.Ctx, cts.Kit.Header(), delReq)
+}
+
+// ListCidrPoolUtilization list utilization of cidr pools matched by filter.
+func (svc *ipamSvc) ListCidrPoolUtilization(cts *rest.Contexts) (interface{}, error) {
+	req := new(cloudserver.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	pools, err := svc.client.DataService().Global.Ipam.ListCidrPool(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list cidr pool failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details, err := svc.ipamLgc.Utilization(cts.Kit, pools.Details)
+	if err != nil {
+		logs.Errorf("calculate cidr pool utilization failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &csipam.CidrPoolUtilizationResult{Details: details}, nil
+}
+
+// GetCidrPoolUtilization get utilization of cidr pool.
+func (svc *ipamSvc) GetCidrPoolUtilization(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.authorize(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	pools, err := svc.client.DataService().Global.Ipam.ListCidrPool(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list cidr pool failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(pools.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "cidr pool %s not found", id)
+	}
+
+	details, err := svc.ipamLgc.Utilization(cts.Kit, pools.Details)
+	if err != nil {
+		logs.Errorf("calculate cidr pool utilization failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return details[0], nil
+}
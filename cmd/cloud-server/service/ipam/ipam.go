@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ipam ...
+package ipam
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/ipam"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/api/core"
+	coreipam "hcm/pkg/api/core/cloud/ipam"
+	"hcm/pkg/client"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitIpamService initialize the ipam service.
+func InitIpamService(c *capability.Capability) {
+	svc := &ipamSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		ipamLgc:    c.Logics.Ipam,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateCidrPool", http.MethodPost, "/cidr_pools/create", svc.CreateCidrPool)
+	h.Add("UpdateCidrPool", http.MethodPatch, "/cidr_pools/{id}", svc.UpdateCidrPool)
+	h.Add("ListCidrPool", http.MethodPost, "/cidr_pools/list", svc.ListCidrPool)
+	h.Add("BatchDeleteCidrPool", http.MethodDelete, "/cidr_pools/batch", svc.BatchDeleteCidrPool)
+	h.Add("ListCidrPoolUtilization", http.MethodPost, "/cidr_pools/utilization/list", svc.ListCidrPoolUtilization)
+	h.Add("GetCidrPoolUtilization", http.MethodGet, "/cidr_pools/{id}/utilization", svc.GetCidrPoolUtilization)
+
+	h.Add("ListCidrAllocation", http.MethodPost, "/cidr_pools/{id}/allocations/list", svc.ListCidrAllocation)
+	h.Add("AllocateCidr", http.MethodPost, "/cidr_pools/{id}/allocations/allocate", svc.AllocateCidr)
+	h.Add("ReleaseCidr", http.MethodDelete, "/cidr_allocations/batch", svc.ReleaseCidr)
+
+	h.Add("ListVpcCidrOverlap", http.MethodPost, "/vpcs/cidr_overlaps/list", svc.ListVpcCidrOverlap)
+	h.Add("CheckCidrConflict", http.MethodPost, "/cidrs/conflict/check", svc.CheckCidrConflict)
+
+	h.Load(c.WebService)
+}
+
+type ipamSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	ipamLgc    ipam.Interface
+}
+
+func (svc *ipamSvc) authorize(kt *kit.Kit, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CidrPool, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes)
+}
+
+// listAllCidrPool list all cidr pools.
+func (svc *ipamSvc) listAllCidrPool(kt *kit.Kit) ([]coreipam.CidrPool, error) {
+	listReq := &core.ListReq{
+		Filter: tools.AllExpression(),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	pools := make([]coreipam.CidrPool, 0)
+	for {
+		result, err := svc.client.DataService().Global.Ipam.ListCidrPool(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list cidr pool failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		pools = append(pools, result.Details...)
+
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return pools, nil
+}
@@ -0,0 +1,154 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	"net"
+
+	csipam "hcm/pkg/api/cloud-server/ipam"
+	coreipam "hcm/pkg/api/core/cloud/ipam"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/cidr"
+	"hcm/pkg/tools/slice"
+)
+
+// ListVpcCidrOverlap list overlapped cidrs of different vpcs across all vendors and accounts.
+func (svc *ipamSvc) ListVpcCidrOverlap(cts *rest.Contexts) (interface{}, error) {
+	req := new(csipam.ListVpcCidrOverlapReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	vpcCidrs, err := svc.ipamLgc.ListVpcCidr(cts.Kit)
+	if err != nil {
+		logs.Errorf("list vpc cidr failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	parsed := make([]csipam.VpcCidr, 0, len(vpcCidrs))
+	nets := make([]net.IPNet, 0, len(vpcCidrs))
+	for _, one := range vpcCidrs {
+		_, ipNet, err := net.ParseCIDR(one.Cidr)
+		if err != nil {
+			logs.Warnf("vpc %s has invalid cidr %s, skip it, rid: %s", one.VpcID, one.Cidr, cts.Kit.Rid)
+			continue
+		}
+		parsed = append(parsed, one)
+		nets = append(nets, *ipNet)
+	}
+
+	details := make([]csipam.VpcCidrOverlap, 0)
+	for _, pair := range cidr.FindOverlapPairs(nets) {
+		vpc, peer := parsed[pair[0]], parsed[pair[1]]
+		// 同一个VPC的多个网段之间的重叠由云上保证，不需要关注，如gcp的子网网段
+		if vpc.VpcID == peer.VpcID {
+			continue
+		}
+
+		if !inOverlapScope(req, vpc) && !inOverlapScope(req, peer) {
+			continue
+		}
+
+		details = append(details, csipam.VpcCidrOverlap{Vpc: vpc, PeerVpc: peer})
+	}
+
+	return &csipam.VpcCidrOverlapResult{Details: details}, nil
+}
+
+// inOverlapScope check whether the vpc cidr matches all set scopes of request.
+func inOverlapScope(req *csipam.ListVpcCidrOverlapReq, vpc csipam.VpcCidr) bool {
+	if len(req.Vendors) != 0 && !slice.IsItemInSlice(req.Vendors, vpc.Vendor) {
+		return false
+	}
+
+	if len(req.AccountIDs) != 0 && !slice.IsItemInSlice(req.AccountIDs, vpc.AccountID) {
+		return false
+	}
+
+	if len(req.BkBizIDs) != 0 && !slice.IsItemInSlice(req.BkBizIDs, vpc.BkBizID) {
+		return false
+	}
+
+	return true
+}
+
+// CheckCidrConflict check whether cidrs conflict with synced vpcs and cidr pools, it is used to check cidr
+// before creating vpc or cidr pool.
+func (svc *ipamSvc) CheckCidrConflict(cts *rest.Contexts) (interface{}, error) {
+	req := new(csipam.CheckCidrConflictReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorize(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	vpcCidrs, err := svc.ipamLgc.ListVpcCidr(cts.Kit)
+	if err != nil {
+		logs.Errorf("list vpc cidr failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	pools, err := svc.listAllCidrPool(cts.Kit)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]csipam.CidrConflict, 0, len(req.Cidrs))
+	for _, one := range req.Cidrs {
+		conflict := csipam.CidrConflict{
+			Cidr:      one,
+			Vpcs:      make([]csipam.VpcCidr, 0),
+			CidrPools: make([]coreipam.CidrPool, 0),
+		}
+
+		for _, vpc := range vpcCidrs {
+			if overlap, err := cidr.CidrOverlap(one, vpc.Cidr); err == nil && overlap {
+				conflict.Vpcs = append(conflict.Vpcs, vpc)
+			}
+		}
+
+		for _, pool := range pools {
+			if overlap, err := cidr.CidrOverlap(one, pool.IPv4Cidr); err == nil && overlap {
+				conflict.CidrPools = append(conflict.CidrPools, pool)
+			}
+		}
+
+		details = append(details, conflict)
+	}
+
+	return &csipam.CheckCidrConflictResult{Details: details}, nil
+}
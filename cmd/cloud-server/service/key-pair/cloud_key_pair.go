@@ -0,0 +1,242 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"fmt"
+
+	cskp "hcm/pkg/api/cloud-server/key-pair"
+	"hcm/pkg/api/core"
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	protokp "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ListCloudKeyPair list cloud key pair.
+func (svc *kpSvc) ListCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.listCloudKeyPair(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizCloudKeyPair list biz cloud key pair.
+func (svc *kpSvc) ListBizCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.listCloudKeyPair(cts, handler.ListBizAuthRes)
+}
+
+func (svc *kpSvc) listCloudKeyPair(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{},
+	error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.CloudKeyPair, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &protokp.CloudKeyPairListResult{Details: make([]corekp.CloudKeyPair, 0)}, nil
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.KeyPair.ListCloudKeyPair(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// AssignCloudKeyPairToBiz assign cloud key pair to biz.
+func (svc *kpSvc) AssignCloudKeyPairToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(cskp.AssignCloudKeyPairToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeCloudKeyPairAssignOp(cts.Kit, req.CloudKeyPairIDs, req.BkBizID); err != nil {
+		return nil, err
+	}
+
+	// check if all cloud key pairs are not assigned to biz, right now assigning resource twice is not allowed
+	listReq := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: req.CloudKeyPairIDs},
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.NotEqual.Factory(), Value: constant.UnassignedBiz},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.KeyPair.ListCloudKeyPair(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list cloud key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != 0 {
+		ids := make([]string, len(result.Details))
+		for index, one := range result.Details {
+			ids[index] = one.ID
+		}
+		return nil, fmt.Errorf("cloud key pair(ids=%v) already assigned", ids)
+	}
+
+	// create assign audit.
+	err = svc.audit.ResBizAssignAudit(cts.Kit, enumor.CloudKeyPairAuditResType, req.CloudKeyPairIDs, req.BkBizID)
+	if err != nil {
+		logs.Errorf("create cloud key pair assign audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	update := &protokp.CloudKeyPairCommonInfoBatchUpdateReq{
+		IDs:     req.CloudKeyPairIDs,
+		BkBizID: req.BkBizID,
+	}
+	if err = svc.client.DataService().Global.KeyPair.BatchUpdateCloudKeyPairCommonInfo(cts.Kit.Ctx,
+		cts.Kit.Header(), update); err != nil {
+		logs.Errorf("batch update cloud key pair common info failed, req: %+v, err: %v, rid: %s", req, err,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *kpSvc) authorizeCloudKeyPairAssignOp(kt *kit.Kit, ids []string, bizID int64) error {
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.KeyPairCloudResType,
+		IDs:          ids,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(kt.Ctx, kt.Header(), basicInfoReq)
+	if err != nil {
+		return err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		authRes = append(authRes, meta.ResourceAttribute{
+			Basic: &meta.Basic{
+				Type:       meta.CloudKeyPair,
+				Action:     meta.Assign,
+				ResourceID: info.AccountID,
+			},
+			BizID: bizID,
+		})
+	}
+
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes...)
+}
+
+// BatchDeleteCloudKeyPair batch delete cloud key pair.
+func (svc *kpSvc) BatchDeleteCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteCloudKeyPair(cts, handler.ResValidWithAuth)
+}
+
+// BatchDeleteBizCloudKeyPair batch delete biz cloud key pair.
+func (svc *kpSvc) BatchDeleteBizCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteCloudKeyPair(cts, handler.BizValidWithAuth)
+}
+
+func (svc *kpSvc) batchDeleteCloudKeyPair(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.KeyPairCloudResType,
+		IDs:          req.IDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.CloudKeyPair,
+		Action: meta.Delete, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	// create delete audit.
+	if err = svc.audit.ResDeleteAudit(cts.Kit, enumor.CloudKeyPairAuditResType, req.IDs); err != nil {
+		logs.Errorf("create delete audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	succeeded := make([]string, 0)
+	for _, id := range req.IDs {
+		basicInfo, exists := basicInfoMap[id]
+		if !exists {
+			return nil, errf.Newf(errf.InvalidParameter, "id %s has no corresponding vendor", id)
+		}
+
+		switch basicInfo.Vendor {
+		case enumor.TCloud:
+			err = svc.client.HCService().TCloud.KeyPair.DeleteCloudKeyPair(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.Aws:
+			err = svc.client.HCService().Aws.KeyPair.DeleteCloudKeyPair(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.HuaWei:
+			err = svc.client.HCService().HuaWei.KeyPair.DeleteCloudKeyPair(cts.Kit.Ctx, cts.Kit.Header(), id)
+		default:
+			err = errf.Newf(errf.InvalidParameter, "no support vendor: %s", basicInfo.Vendor)
+		}
+
+		if err != nil {
+			return core.BatchOperateResult{
+				Succeeded: succeeded,
+				Failed: &core.FailedInfo{
+					ID:    id,
+					Error: err,
+				},
+			}, errf.NewFromErr(errf.PartialFailed, err)
+		}
+
+		succeeded = append(succeeded, id)
+	}
+
+	return core.BatchOperateResult{Succeeded: succeeded}, nil
+}
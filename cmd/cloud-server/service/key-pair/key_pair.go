@@ -0,0 +1,361 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair defines ssh key pair service.
+package keypair
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/cmd/cloud-server/service/common"
+	cskp "hcm/pkg/api/cloud-server/key-pair"
+	"hcm/pkg/api/core"
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	protokp "hcm/pkg/api/data-service/cloud/key-pair"
+	hckp "hcm/pkg/api/hc-service/key-pair"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/sshkey"
+)
+
+// InitKeyPairService initialize the key pair service.
+func InitKeyPairService(c *capability.Capability) {
+	svc := &kpSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	// key pair apis in biz, key pair is hcm side resource which only belongs to biz
+	h.Add("CreateBizKeyPair", http.MethodPost, "/bizs/{bk_biz_id}/key_pairs/create", svc.CreateBizKeyPair)
+	h.Add("UpdateBizKeyPair", http.MethodPatch, "/bizs/{bk_biz_id}/key_pairs/{id}", svc.UpdateBizKeyPair)
+	h.Add("ListBizKeyPair", http.MethodPost, "/bizs/{bk_biz_id}/key_pairs/list", svc.ListBizKeyPair)
+	h.Add("BatchDeleteBizKeyPair", http.MethodDelete, "/bizs/{bk_biz_id}/key_pairs/batch", svc.BatchDeleteBizKeyPair)
+	h.Add("PushBizKeyPair", http.MethodPost, "/bizs/{bk_biz_id}/key_pairs/{id}/push", svc.PushBizKeyPair)
+
+	// cloud key pair apis
+	h.Add("ListCloudKeyPair", http.MethodPost, "/cloud_key_pairs/list", svc.ListCloudKeyPair)
+	h.Add("AssignCloudKeyPairToBiz", http.MethodPost, "/cloud_key_pairs/assign/bizs", svc.AssignCloudKeyPairToBiz)
+	h.Add("BatchDeleteCloudKeyPair", http.MethodDelete, "/cloud_key_pairs/batch", svc.BatchDeleteCloudKeyPair)
+
+	// cloud key pair apis in biz
+	h.Add("ListBizCloudKeyPair", http.MethodPost, "/bizs/{bk_biz_id}/cloud_key_pairs/list", svc.ListBizCloudKeyPair)
+	h.Add("BatchDeleteBizCloudKeyPair", http.MethodDelete, "/bizs/{bk_biz_id}/cloud_key_pairs/batch",
+		svc.BatchDeleteBizCloudKeyPair)
+
+	h.Load(c.WebService)
+}
+
+type kpSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
+
+// CreateBizKeyPair import public key as biz key pair.
+func (svc *kpSvc) CreateBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBizKeyPair(cts, meta.Create)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(cskp.KeyPairCreateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	publicKey, err := sshkey.Parse(req.PublicKey)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	createReq := &protokp.KeyPairBatchCreateReq{
+		KeyPairs: []protokp.KeyPairBatchCreate{{
+			Name:        req.Name,
+			BkBizID:     bizID,
+			PublicKey:   publicKey.Normalized,
+			Fingerprint: publicKey.Fingerprint,
+			Memo:        req.Memo,
+		}},
+	}
+	result, err := svc.client.DataService().Global.KeyPair.BatchCreateKeyPair(cts.Kit.Ctx, cts.Kit.Header(),
+		createReq)
+	if err != nil {
+		logs.Errorf("create key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, errf.New(errf.Aborted, "create result is invalid")
+	}
+
+	return core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// UpdateBizKeyPair update biz key pair name or memo.
+func (svc *kpSvc) UpdateBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	bizID, err := svc.authorizeBizKeyPair(cts, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(cskp.KeyPairUpdateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err = svc.getBizKeyPair(cts.Kit, bizID, id); err != nil {
+		return nil, err
+	}
+
+	updateReq := &protokp.KeyPairBatchUpdateReq{
+		KeyPairs: []protokp.KeyPairBatchUpdate{{ID: id, Name: req.Name, Memo: req.Memo}},
+	}
+	if err = svc.client.DataService().Global.KeyPair.BatchUpdateKeyPair(cts.Kit.Ctx, cts.Kit.Header(),
+		updateReq); err != nil {
+		logs.Errorf("update key pair failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBizKeyPair list biz key pair.
+func (svc *kpSvc) ListBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBizKeyPair(cts, meta.Find)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(core.ListReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	bizRule := &filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: bizID}
+	if req.Filter == nil {
+		req.Filter = &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{bizRule}}
+	} else {
+		req.Filter = &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{bizRule, req.Filter}}
+	}
+
+	return svc.client.DataService().Global.KeyPair.ListKeyPair(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// BatchDeleteBizKeyPair batch delete biz key pair, key pair which has been pushed to cloud can not be deleted
+// until all of its cloud key pairs are deleted, otherwise hosts created by it lose the trace of their login key.
+func (svc *kpSvc) BatchDeleteBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBizKeyPair(cts, meta.Delete)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(core.BatchDeleteReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := slice.Unique(req.IDs)
+	listReq := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: bizID},
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: ids},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	keyPairs, err := svc.client.DataService().Global.KeyPair.ListKeyPair(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(keyPairs.Details) != len(ids) {
+		return nil, errf.Newf(errf.InvalidParameter, "some of key pair(ids=%v) not found in biz %d", ids, bizID)
+	}
+
+	cloudListReq := &core.ListReq{
+		Fields: []string{"id", "key_pair_id"},
+		Filter: tools.ContainersExpression("key_pair_id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	cloudKeyPairs, err := svc.client.DataService().Global.KeyPair.ListCloudKeyPair(cts.Kit.Ctx, cts.Kit.Header(),
+		cloudListReq)
+	if err != nil {
+		logs.Errorf("list cloud key pair failed, err: %v, key pair ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(cloudKeyPairs.Details) != 0 {
+		pushed := make([]string, 0, len(cloudKeyPairs.Details))
+		for _, one := range cloudKeyPairs.Details {
+			pushed = append(pushed, one.KeyPairID)
+		}
+		return nil, errf.Newf(errf.InvalidParameter, "key pair(ids=%v) has been pushed to cloud, "+
+			"please delete the cloud key pairs first", slice.Unique(pushed))
+	}
+
+	// create delete audit.
+	if err = svc.audit.ResDeleteAudit(cts.Kit, enumor.KeyPairAuditResType, ids); err != nil {
+		logs.Errorf("create key pair delete audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	deleteReq := &protokp.KeyPairBatchDeleteReq{Filter: tools.ContainersExpression("id", ids)}
+	if err = svc.client.DataService().Global.KeyPair.BatchDeleteKeyPair(cts.Kit.Ctx, cts.Kit.Header(),
+		deleteReq); err != nil {
+		logs.Errorf("delete key pair failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// PushBizKeyPair push biz key pair to the region of cloud account, returns the cloud key pair.
+func (svc *kpSvc) PushBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	bizID, err := svc.authorizeBizKeyPair(cts, meta.Find)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(cskp.KeyPairPushReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// pushing key pair creates cloud key pair in the account, so cloud key pair create permission is required.
+	err = handler.BizValidWithAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: meta.CloudKeyPair, Action: meta.Create,
+		BasicInfo: common.GetCloudResourceBasicInfo(req.AccountID, bizID)})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = svc.getBizKeyPair(cts.Kit, bizID, id); err != nil {
+		return nil, err
+	}
+
+	baseInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.AccountCloudResType, req.AccountID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	pushReq := &hckp.KeyPairPushReq{AccountID: req.AccountID, Region: req.Region, KeyPairID: id}
+	switch baseInfo.Vendor {
+	case enumor.TCloud:
+		return svc.client.HCService().TCloud.KeyPair.PushKeyPair(cts.Kit.Ctx, cts.Kit.Header(), pushReq)
+	case enumor.Aws:
+		return svc.client.HCService().Aws.KeyPair.PushKeyPair(cts.Kit.Ctx, cts.Kit.Header(), pushReq)
+	case enumor.HuaWei:
+		return svc.client.HCService().HuaWei.KeyPair.PushKeyPair(cts.Kit.Ctx, cts.Kit.Header(), pushReq)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support key pair", baseInfo.Vendor)
+	}
+}
+
+// authorizeBizKeyPair parse biz id from url and authorize key pair action in the biz.
+func (svc *kpSvc) authorizeBizKeyPair(cts *rest.Contexts, action meta.Action) (int64, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return 0, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if bizID <= 0 {
+		return 0, errf.New(errf.InvalidParameter, "bk_biz_id should > 0")
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.KeyPair, Action: action}, BizID: bizID}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return 0, err
+	}
+
+	return bizID, nil
+}
+
+func (svc *kpSvc) getBizKeyPair(kt *kit.Kit, bizID int64, id string) (*corekp.KeyPair, error) {
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: bizID},
+				&filter.AtomRule{Field: "id", Op: filter.Equal.Factory(), Value: id},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.KeyPair.ListKeyPair(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "key pair %s not found in biz %d", id, bizID)
+	}
+
+	return &result.Details[0], nil
+}
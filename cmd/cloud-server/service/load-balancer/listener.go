@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ListListener list load balancer listener.
+func (svc *lbSvc) ListListener(cts *rest.Contexts) (interface{}, error) {
+	return svc.listListener(cts, handler.ResValidWithAuth)
+}
+
+// ListBizListener list biz load balancer listener.
+func (svc *lbSvc) ListBizListener(cts *rest.Contexts) (interface{}, error) {
+	return svc.listListener(cts, handler.BizValidWithAuth)
+}
+
+func (svc *lbSvc) listListener(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req, err := svc.decodeLBRelListReq(cts, validHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.LoadBalancer.ListListener(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// ListTarget list load balancer target.
+func (svc *lbSvc) ListTarget(cts *rest.Contexts) (interface{}, error) {
+	return svc.listTarget(cts, handler.ResValidWithAuth)
+}
+
+// ListBizTarget list biz load balancer target.
+func (svc *lbSvc) ListBizTarget(cts *rest.Contexts) (interface{}, error) {
+	return svc.listTarget(cts, handler.BizValidWithAuth)
+}
+
+func (svc *lbSvc) listTarget(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req, err := svc.decodeLBRelListReq(cts, validHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.LoadBalancer.ListTarget(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// decodeLBRelListReq 解析监听器、后端目标查询请求，校验负载均衡的查看权限，并限定查询范围为该负载均衡
+func (svc *lbSvc) decodeLBRelListReq(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	*core.ListReq, error) {
+
+	lbID := cts.PathParameter("id").String()
+	if len(lbID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeLoadBalancerFind(cts, lbID, validHandler); err != nil {
+		return nil, err
+	}
+
+	rules := []filter.RuleFactory{&filter.AtomRule{Field: "lb_id", Op: filter.Equal.Factory(), Value: lbID}}
+	if req.Filter != nil {
+		rules = append(rules, req.Filter)
+	}
+	req.Filter = &filter.Expression{Op: filter.And, Rules: rules}
+
+	return req, nil
+}
+
+func (svc *lbSvc) authorizeLoadBalancerFind(cts *rest.Contexts, id string,
+	validHandler handler.ValidWithAuthHandler) error {
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.LoadBalancerCloudResType, id)
+	if err != nil {
+		return err
+	}
+
+	return validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Find, BasicInfo: basicInfo})
+}
@@ -0,0 +1,434 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer defines load balancer service.
+package loadbalancer
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/cmd/cloud-server/service/common"
+	cslb "hcm/pkg/api/cloud-server/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	protolb "hcm/pkg/api/data-service/cloud/load-balancer"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitLoadBalancerService initialize the load balancer service.
+func InitLoadBalancerService(c *capability.Capability) {
+	svc := &lbSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListLoadBalancer", http.MethodPost, "/load_balancers/list", svc.ListLoadBalancer)
+	h.Add("GetLoadBalancer", http.MethodGet, "/load_balancers/{id}", svc.GetLoadBalancer)
+	h.Add("AssignLoadBalancerToBiz", http.MethodPost, "/load_balancers/assign/bizs", svc.AssignLoadBalancerToBiz)
+	h.Add("BatchDeleteLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteLoadBalancer)
+	h.Add("CreateLoadBalancer", http.MethodPost, "/load_balancers/create", svc.CreateLoadBalancer)
+	h.Add("ListListener", http.MethodPost, "/load_balancers/{id}/listeners/list", svc.ListListener)
+	h.Add("ListTarget", http.MethodPost, "/load_balancers/{id}/targets/list", svc.ListTarget)
+
+	// load balancer apis in biz
+	h.Add("ListBizLoadBalancer", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/list", svc.ListBizLoadBalancer)
+	h.Add("GetBizLoadBalancer", http.MethodGet, "/bizs/{bk_biz_id}/load_balancers/{id}", svc.GetBizLoadBalancer)
+	h.Add("BatchDeleteBizLoadBalancer", http.MethodDelete, "/bizs/{bk_biz_id}/load_balancers/batch",
+		svc.BatchDeleteBizLoadBalancer)
+	h.Add("CreateBizLoadBalancer", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/create",
+		svc.CreateBizLoadBalancer)
+	h.Add("ListBizListener", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/{id}/listeners/list",
+		svc.ListBizListener)
+	h.Add("ListBizTarget", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/{id}/targets/list",
+		svc.ListBizTarget)
+
+	h.Load(c.WebService)
+}
+
+type lbSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
+
+// ListLoadBalancer list load balancer.
+func (svc *lbSvc) ListLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.listLoadBalancer(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizLoadBalancer list biz load balancer.
+func (svc *lbSvc) ListBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.listLoadBalancer(cts, handler.ListBizAuthRes)
+}
+
+func (svc *lbSvc) listLoadBalancer(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{},
+	error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.LoadBalancer, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &protolb.LoadBalancerListResult{Details: make([]corelb.BaseLoadBalancer, 0)}, nil
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.LoadBalancer.ListLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// GetLoadBalancer get load balancer.
+func (svc *lbSvc) GetLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.getLoadBalancer(cts, handler.ResValidWithAuth)
+}
+
+// GetBizLoadBalancer get biz load balancer.
+func (svc *lbSvc) GetBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.getLoadBalancer(cts, handler.BizValidWithAuth)
+}
+
+func (svc *lbSvc) getLoadBalancer(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.LoadBalancerCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return svc.client.DataService().TCloud.LoadBalancer.GetLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.Aws:
+		return svc.client.DataService().Aws.LoadBalancer.GetLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.HuaWei:
+		return svc.client.DataService().HuaWei.LoadBalancer.GetLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.Azure:
+		return svc.client.DataService().Azure.LoadBalancer.GetLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.Gcp:
+		return svc.client.DataService().Gcp.LoadBalancer.GetLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", basicInfo.Vendor)
+	}
+}
+
+// AssignLoadBalancerToBiz assign load balancer to biz.
+func (svc *lbSvc) AssignLoadBalancerToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(cslb.AssignLoadBalancerToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeLoadBalancerAssignOp(cts.Kit, req.LoadBalancerIDs, req.BkBizID); err != nil {
+		return nil, err
+	}
+
+	// check if all load balancers are not assigned to biz, right now assigning resource twice is not allowed
+	listReq := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: req.LoadBalancerIDs},
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.NotEqual.Factory(), Value: constant.UnassignedBiz},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.LoadBalancer.ListLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(),
+		listReq)
+	if err != nil {
+		logs.Errorf("list load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != 0 {
+		ids := make([]string, len(result.Details))
+		for index, one := range result.Details {
+			ids[index] = one.ID
+		}
+		return nil, fmt.Errorf("load balancer(ids=%v) already assigned", ids)
+	}
+
+	// create assign audit.
+	err = svc.audit.ResBizAssignAudit(cts.Kit, enumor.LoadBalancerAuditResType, req.LoadBalancerIDs, req.BkBizID)
+	if err != nil {
+		logs.Errorf("create load balancer assign audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	update := &protolb.LoadBalancerCommonInfoBatchUpdateReq{
+		IDs:     req.LoadBalancerIDs,
+		BkBizID: req.BkBizID,
+	}
+	if err = svc.client.DataService().Global.LoadBalancer.BatchUpdateLoadBalancerCommonInfo(cts.Kit.Ctx,
+		cts.Kit.Header(), update); err != nil {
+		logs.Errorf("batch update load balancer common info failed, req: %+v, err: %v, rid: %s", req, err,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *lbSvc) authorizeLoadBalancerAssignOp(kt *kit.Kit, ids []string, bizID int64) error {
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.LoadBalancerCloudResType,
+		IDs:          ids,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(kt.Ctx, kt.Header(), basicInfoReq)
+	if err != nil {
+		return err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		authRes = append(authRes, meta.ResourceAttribute{
+			Basic: &meta.Basic{
+				Type:       meta.LoadBalancer,
+				Action:     meta.Assign,
+				ResourceID: info.AccountID,
+			},
+			BizID: bizID,
+		})
+	}
+
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes...)
+}
+
+// BatchDeleteLoadBalancer batch delete load balancer.
+func (svc *lbSvc) BatchDeleteLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteLoadBalancer(cts, handler.ResValidWithAuth)
+}
+
+// BatchDeleteBizLoadBalancer batch delete biz load balancer.
+func (svc *lbSvc) BatchDeleteBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteLoadBalancer(cts, handler.BizValidWithAuth)
+}
+
+func (svc *lbSvc) batchDeleteLoadBalancer(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.LoadBalancerCloudResType,
+		IDs:          req.IDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Delete, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	// create delete audit.
+	if err = svc.audit.ResDeleteAudit(cts.Kit, enumor.LoadBalancerAuditResType, req.IDs); err != nil {
+		logs.Errorf("create delete audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	succeeded := make([]string, 0)
+	for _, id := range req.IDs {
+		basicInfo, exists := basicInfoMap[id]
+		if !exists {
+			return nil, errf.Newf(errf.InvalidParameter, "id %s has no corresponding vendor", id)
+		}
+
+		switch basicInfo.Vendor {
+		case enumor.TCloud:
+			err = svc.client.HCService().TCloud.LoadBalancer.DeleteLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.Aws:
+			err = svc.client.HCService().Aws.LoadBalancer.DeleteLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.HuaWei:
+			err = svc.client.HCService().HuaWei.LoadBalancer.DeleteLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.Azure:
+			err = svc.client.HCService().Azure.LoadBalancer.DeleteLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.Gcp:
+			err = svc.client.HCService().Gcp.LoadBalancer.DeleteLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+		default:
+			err = errf.Newf(errf.InvalidParameter, "no support vendor: %s", basicInfo.Vendor)
+		}
+
+		if err != nil {
+			return core.BatchOperateResult{
+				Succeeded: succeeded,
+				Failed: &core.FailedInfo{
+					ID:    id,
+					Error: err,
+				},
+			}, errf.NewFromErr(errf.PartialFailed, err)
+		}
+
+		succeeded = append(succeeded, id)
+	}
+
+	return core.BatchOperateResult{Succeeded: succeeded}, nil
+}
+
+// CreateLoadBalancer create load balancer.
+func (svc *lbSvc) CreateLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.createLoadBalancer(cts, constant.UnassignedBiz, handler.ResValidWithAuth)
+}
+
+// CreateBizLoadBalancer create biz load balancer.
+func (svc *lbSvc) CreateBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	bkBizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	return svc.createLoadBalancer(cts, bkBizID, handler.BizValidWithAuth)
+}
+
+func (svc *lbSvc) createLoadBalancer(cts *rest.Contexts, bizID int64, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	accountID, err := common.ExtractAccountID(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// validate authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Create, BasicInfo: common.GetCloudResourceBasicInfo(accountID, bizID)})
+	if err != nil {
+		return nil, err
+	}
+
+	baseInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.AccountCloudResType, accountID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch baseInfo.Vendor {
+	case enumor.TCloud:
+		req := new(hclb.TCloudLoadBalancerCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		return svc.client.HCService().TCloud.LoadBalancer.CreateLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.Aws:
+		req := new(hclb.AwsLoadBalancerCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		return svc.client.HCService().Aws.LoadBalancer.CreateLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.HuaWei:
+		req := new(hclb.HuaWeiLoadBalancerCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		return svc.client.HCService().HuaWei.LoadBalancer.CreateLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.Azure:
+		req := new(hclb.AzureLoadBalancerCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		return svc.client.HCService().Azure.LoadBalancer.CreateLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.Gcp:
+		req := new(hclb.GcpLoadBalancerCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		return svc.client.HCService().Gcp.LoadBalancer.CreateLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), req)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "no support vendor: %s", baseInfo.Vendor)
+	}
+}
+
+type createReq interface {
+	Validate() error
+}
+
+func decodeCreateReq(cts *rest.Contexts, req createReq) error {
+	if err := cts.DecodeInto(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,428 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway defines nat gateway service.
+package natgateway
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/cmd/cloud-server/service/common"
+	csnat "hcm/pkg/api/cloud-server/nat-gateway"
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	protonat "hcm/pkg/api/data-service/cloud/nat-gateway"
+	hcnat "hcm/pkg/api/hc-service/nat-gateway"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitNatGatewayService initialize the nat gateway service.
+func InitNatGatewayService(c *capability.Capability) {
+	svc := &natSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListNatGateway", http.MethodPost, "/nat_gateways/list", svc.ListNatGateway)
+	h.Add("GetNatGateway", http.MethodGet, "/nat_gateways/{id}", svc.GetNatGateway)
+	h.Add("AssignNatGatewayToBiz", http.MethodPost, "/nat_gateways/assign/bizs", svc.AssignNatGatewayToBiz)
+	h.Add("BatchDeleteNatGateway", http.MethodDelete, "/nat_gateways/batch", svc.BatchDeleteNatGateway)
+	h.Add("CreateNatGateway", http.MethodPost, "/nat_gateways/create", svc.CreateNatGateway)
+
+	// nat gateway apis in biz
+	h.Add("ListBizNatGateway", http.MethodPost, "/bizs/{bk_biz_id}/nat_gateways/list", svc.ListBizNatGateway)
+	h.Add("GetBizNatGateway", http.MethodGet, "/bizs/{bk_biz_id}/nat_gateways/{id}", svc.GetBizNatGateway)
+	h.Add("BatchDeleteBizNatGateway", http.MethodDelete, "/bizs/{bk_biz_id}/nat_gateways/batch",
+		svc.BatchDeleteBizNatGateway)
+	h.Add("CreateBizNatGateway", http.MethodPost, "/bizs/{bk_biz_id}/nat_gateways/create",
+		svc.CreateBizNatGateway)
+
+	h.Load(c.WebService)
+}
+
+type natSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
+
+// ListNatGateway list nat gateway.
+func (svc *natSvc) ListNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return svc.listNatGateway(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizNatGateway list biz nat gateway.
+func (svc *natSvc) ListBizNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return svc.listNatGateway(cts, handler.ListBizAuthRes)
+}
+
+func (svc *natSvc) listNatGateway(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{},
+	error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.NatGateway, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &protonat.NatGatewayListResult{Details: make([]corenat.BaseNatGateway, 0)}, nil
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.NatGateway.ListNatGateway(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// GetNatGateway get nat gateway.
+func (svc *natSvc) GetNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return svc.getNatGateway(cts, handler.ResValidWithAuth)
+}
+
+// GetBizNatGateway get biz nat gateway.
+func (svc *natSvc) GetBizNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return svc.getNatGateway(cts, handler.BizValidWithAuth)
+}
+
+func (svc *natSvc) getNatGateway(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.NatGatewayCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.NatGateway,
+		Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return svc.client.DataService().TCloud.NatGateway.GetNatGateway(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.Aws:
+		return svc.client.DataService().Aws.NatGateway.GetNatGateway(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.HuaWei:
+		return svc.client.DataService().HuaWei.NatGateway.GetNatGateway(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.Azure:
+		return svc.client.DataService().Azure.NatGateway.GetNatGateway(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.Gcp:
+		return svc.client.DataService().Gcp.NatGateway.GetNatGateway(cts.Kit.Ctx, cts.Kit.Header(), id)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", basicInfo.Vendor)
+	}
+}
+
+// AssignNatGatewayToBiz assign nat gateway to biz.
+func (svc *natSvc) AssignNatGatewayToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(csnat.AssignNatGatewayToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeNatGatewayAssignOp(cts.Kit, req.NatGatewayIDs, req.BkBizID); err != nil {
+		return nil, err
+	}
+
+	// check if all nat gateways are not assigned to biz, right now assigning resource twice is not allowed
+	listReq := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: req.NatGatewayIDs},
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.NotEqual.Factory(), Value: constant.UnassignedBiz},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.NatGateway.ListNatGateway(cts.Kit.Ctx, cts.Kit.Header(),
+		listReq)
+	if err != nil {
+		logs.Errorf("list nat gateway failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != 0 {
+		ids := make([]string, len(result.Details))
+		for index, one := range result.Details {
+			ids[index] = one.ID
+		}
+		return nil, fmt.Errorf("nat gateway(ids=%v) already assigned", ids)
+	}
+
+	// create assign audit.
+	err = svc.audit.ResBizAssignAudit(cts.Kit, enumor.NatGatewayAuditResType, req.NatGatewayIDs, req.BkBizID)
+	if err != nil {
+		logs.Errorf("create nat gateway assign audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	update := &protonat.NatGatewayCommonInfoBatchUpdateReq{
+		IDs:     req.NatGatewayIDs,
+		BkBizID: req.BkBizID,
+	}
+	if err = svc.client.DataService().Global.NatGateway.BatchUpdateNatGatewayCommonInfo(cts.Kit.Ctx,
+		cts.Kit.Header(), update); err != nil {
+		logs.Errorf("batch update nat gateway common info failed, req: %+v, err: %v, rid: %s", req, err,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *natSvc) authorizeNatGatewayAssignOp(kt *kit.Kit, ids []string, bizID int64) error {
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.NatGatewayCloudResType,
+		IDs:          ids,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(kt.Ctx, kt.Header(), basicInfoReq)
+	if err != nil {
+		return err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		authRes = append(authRes, meta.ResourceAttribute{
+			Basic: &meta.Basic{
+				Type:       meta.NatGateway,
+				Action:     meta.Assign,
+				ResourceID: info.AccountID,
+			},
+			BizID: bizID,
+		})
+	}
+
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes...)
+}
+
+// BatchDeleteNatGateway batch delete nat gateway.
+func (svc *natSvc) BatchDeleteNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteNatGateway(cts, handler.ResValidWithAuth)
+}
+
+// BatchDeleteBizNatGateway batch delete biz nat gateway.
+func (svc *natSvc) BatchDeleteBizNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteNatGateway(cts, handler.BizValidWithAuth)
+}
+
+func (svc *natSvc) batchDeleteNatGateway(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.NatGatewayCloudResType,
+		IDs:          req.IDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.NatGateway,
+		Action: meta.Delete, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	// create delete audit.
+	if err = svc.audit.ResDeleteAudit(cts.Kit, enumor.NatGatewayAuditResType, req.IDs); err != nil {
+		logs.Errorf("create delete audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	succeeded := make([]string, 0)
+	for _, id := range req.IDs {
+		basicInfo, exists := basicInfoMap[id]
+		if !exists {
+			return nil, errf.Newf(errf.InvalidParameter, "id %s has no corresponding vendor", id)
+		}
+
+		switch basicInfo.Vendor {
+		case enumor.TCloud:
+			err = svc.client.HCService().TCloud.NatGateway.DeleteNatGateway(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.Aws:
+			err = svc.client.HCService().Aws.NatGateway.DeleteNatGateway(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.HuaWei:
+			err = svc.client.HCService().HuaWei.NatGateway.DeleteNatGateway(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.Azure:
+			err = svc.client.HCService().Azure.NatGateway.DeleteNatGateway(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.Gcp:
+			err = svc.client.HCService().Gcp.NatGateway.DeleteNatGateway(cts.Kit.Ctx, cts.Kit.Header(), id)
+		default:
+			err = errf.Newf(errf.InvalidParameter, "no support vendor: %s", basicInfo.Vendor)
+		}
+
+		if err != nil {
+			return core.BatchOperateResult{
+				Succeeded: succeeded,
+				Failed: &core.FailedInfo{
+					ID:    id,
+					Error: err,
+				},
+			}, errf.NewFromErr(errf.PartialFailed, err)
+		}
+
+		succeeded = append(succeeded, id)
+	}
+
+	return core.BatchOperateResult{Succeeded: succeeded}, nil
+}
+
+// CreateNatGateway create nat gateway.
+func (svc *natSvc) CreateNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return svc.createNatGateway(cts, constant.UnassignedBiz, handler.ResValidWithAuth)
+}
+
+// CreateBizNatGateway create biz nat gateway.
+func (svc *natSvc) CreateBizNatGateway(cts *rest.Contexts) (interface{}, error) {
+	bkBizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	return svc.createNatGateway(cts, bkBizID, handler.BizValidWithAuth)
+}
+
+func (svc *natSvc) createNatGateway(cts *rest.Contexts, bizID int64, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	accountID, err := common.ExtractAccountID(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// validate authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.NatGateway,
+		Action: meta.Create, BasicInfo: common.GetCloudResourceBasicInfo(accountID, bizID)})
+	if err != nil {
+		return nil, err
+	}
+
+	baseInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.AccountCloudResType, accountID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch baseInfo.Vendor {
+	case enumor.TCloud:
+		req := new(hcnat.TCloudNatGatewayCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		return svc.client.HCService().TCloud.NatGateway.CreateNatGateway(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.Aws:
+		req := new(hcnat.AwsNatGatewayCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		return svc.client.HCService().Aws.NatGateway.CreateNatGateway(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.HuaWei:
+		req := new(hcnat.HuaWeiNatGatewayCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		return svc.client.HCService().HuaWei.NatGateway.CreateNatGateway(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.Azure:
+		req := new(hcnat.AzureNatGatewayCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		return svc.client.HCService().Azure.NatGateway.CreateNatGateway(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.Gcp:
+		req := new(hcnat.GcpNatGatewayCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		return svc.client.HCService().Gcp.NatGateway.CreateNatGateway(cts.Kit.Ctx, cts.Kit.Header(), req)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "no support vendor: %s", baseInfo.Vendor)
+	}
+}
+
+type createReq interface {
+	Validate() error
+}
+
+func decodeCreateReq(cts *rest.Contexts, req createReq) error {
+	if err := cts.DecodeInto(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil
+}
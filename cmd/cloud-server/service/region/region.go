@@ -112,6 +112,13 @@ func (svc *RegionSvc) ListRegion(cts *rest.Contexts) (interface{}, error) {
 		}
 		return svc.client.DataService().Gcp.Region.ListRegion(cts.Kit.Ctx, cts.Kit.Header(), listReq)
 
+	case enumor.OpenStack:
+		listReq := &dataregion.OpenStackRegionListReq{
+			Filter: req.Filter,
+			Page:   reqPage,
+		}
+		return svc.client.DataService().OpenStack.Region.ListRegion(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+
 	default:
 		return nil, errf.Newf(errf.Unknown, "vendor: %s not support", vendor)
 	}
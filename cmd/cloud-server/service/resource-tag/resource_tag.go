@@ -0,0 +1,313 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines resource tag service.
+package resourcetag
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	csrtag "hcm/pkg/api/cloud-server/resource-tag"
+	"hcm/pkg/api/core"
+	corertag "hcm/pkg/api/core/cloud/resource-tag"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	hcrtag "hcm/pkg/api/hc-service/resource-tag"
+	"hcm/pkg/client"
+	hcrtagcli "hcm/pkg/client/hc-service/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitResourceTagService initialize the resource tag service.
+func InitResourceTagService(c *capability.Capability) {
+	svc := &resourceTagSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListResourceTag", http.MethodPost, "/resource_tags/list", svc.ListResourceTag)
+	h.Add("TagResource", http.MethodPost, "/resource_tags/tag", svc.TagResource)
+	h.Add("UntagResource", http.MethodPost, "/resource_tags/untag", svc.UntagResource)
+
+	// resource tag apis in biz
+	h.Add("ListBizResourceTag", http.MethodPost, "/bizs/{bk_biz_id}/resource_tags/list", svc.ListBizResourceTag)
+	h.Add("TagBizResource", http.MethodPost, "/bizs/{bk_biz_id}/resource_tags/tag", svc.TagBizResource)
+	h.Add("UntagBizResource", http.MethodPost, "/bizs/{bk_biz_id}/resource_tags/untag", svc.UntagBizResource)
+
+	h.Load(c.WebService)
+}
+
+type resourceTagSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// tagAuthResType defines the auth resource type of the tagged resource, tags are authorized as part of resource.
+var tagAuthResType = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.CvmCloudResType:           meta.Cvm,
+	enumor.DiskCloudResType:          meta.Disk,
+	enumor.VpcCloudResType:           meta.Vpc,
+	enumor.SubnetCloudResType:        meta.Subnet,
+	enumor.EipCloudResType:           meta.Eip,
+	enumor.SecurityGroupCloudResType: meta.SecurityGroup,
+}
+
+// ListResourceTag list tags of resources.
+func (svc *resourceTagSvc) ListResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResourceTag(cts, handler.ResValidWithAuth)
+}
+
+// ListBizResourceTag list tags of biz resources.
+func (svc *resourceTagSvc) ListBizResourceTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResourceTag(cts, handler.BizValidWithAuth)
+}
+
+func (svc *resourceTagSvc) listResourceTag(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csrtag.ResourceTagListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err := svc.authorizeResource(cts, validHandler, req.ResType, req.IDs, meta.Find); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: req.ResType},
+				&filter.AtomRule{Field: "res_id", Op: filter.In.Factory(), Value: req.IDs},
+			},
+		},
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"res_id", "tag_key", "tag_value"},
+	}
+
+	tagMap := make(map[string][]corertag.Tag, len(req.IDs))
+	for {
+		result, err := svc.client.DataService().Global.ResourceTag.ListResourceTag(cts.Kit.Ctx, cts.Kit.Header(),
+			listReq)
+		if err != nil {
+			logs.Errorf("list resource tag failed, err: %v, req: %v, rid: %s", err, req, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			tagMap[one.ResID] = append(tagMap[one.ResID], corertag.Tag{Key: one.Key, Value: one.Value})
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	details := make([]csrtag.ResourceTags, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		tags, exists := tagMap[id]
+		if !exists {
+			tags = make([]corertag.Tag, 0)
+		}
+		details = append(details, csrtag.ResourceTags{ResID: id, Tags: tags})
+	}
+
+	return &csrtag.ResourceTagListResult{Details: details}, nil
+}
+
+// TagResource add or overwrite tags of resources.
+func (svc *resourceTagSvc) TagResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.tagResource(cts, handler.ResValidWithAuth)
+}
+
+// TagBizResource add or overwrite tags of biz resources.
+func (svc *resourceTagSvc) TagBizResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.tagResource(cts, handler.BizValidWithAuth)
+}
+
+func (svc *resourceTagSvc) tagResource(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csrtag.TagResourceReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoMap, err := svc.authorizeResource(cts, validHandler, req.ResType, req.IDs, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.operateByAccount(cts.Kit, basicInfoMap, func(cli *hcrtagcli.Client, accountID string,
+		ids []string) error {
+
+		tagReq := &hcrtag.TagReq{
+			AccountID: accountID,
+			ResType:   req.ResType,
+			IDs:       ids,
+			Tags:      req.Tags,
+		}
+		return cli.TagResource(cts.Kit.Ctx, cts.Kit.Header(), tagReq)
+	})
+}
+
+// UntagResource remove tags of resources by keys.
+func (svc *resourceTagSvc) UntagResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.untagResource(cts, handler.ResValidWithAuth)
+}
+
+// UntagBizResource remove tags of biz resources by keys.
+func (svc *resourceTagSvc) UntagBizResource(cts *rest.Contexts) (interface{}, error) {
+	return svc.untagResource(cts, handler.BizValidWithAuth)
+}
+
+func (svc *resourceTagSvc) untagResource(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csrtag.UntagResourceReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoMap, err := svc.authorizeResource(cts, validHandler, req.ResType, req.IDs, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.operateByAccount(cts.Kit, basicInfoMap, func(cli *hcrtagcli.Client, accountID string,
+		ids []string) error {
+
+		untagReq := &hcrtag.UntagReq{
+			AccountID: accountID,
+			ResType:   req.ResType,
+			IDs:       ids,
+			Keys:      req.Keys,
+		}
+		return cli.UntagResource(cts.Kit.Ctx, cts.Kit.Header(), untagReq)
+	})
+}
+
+// authorizeResource validate biz and authorize the tagged resources, returns basic info of the resources.
+func (svc *resourceTagSvc) authorizeResource(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	resType enumor.CloudResourceType, ids []string, action meta.Action) (map[string]types.CloudResourceBasicInfo,
+	error) {
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: resType,
+		IDs:          ids,
+		Fields:       types.CommonBasicInfoFields,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: tagAuthResType[resType],
+		Action: action, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	return basicInfoMap, nil
+}
+
+// operateByAccount call hc-service by vendor and account of the resources, partial failed result is returned when
+// any of the calls failed.
+func (svc *resourceTagSvc) operateByAccount(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo,
+	operate func(cli *hcrtagcli.Client, accountID string, ids []string) error) (interface{}, error) {
+
+	accountMap := make(map[string][]types.CloudResourceBasicInfo)
+	for _, one := range basicInfoMap {
+		accountMap[one.AccountID] = append(accountMap[one.AccountID], one)
+	}
+
+	successIDs := make([]string, 0, len(basicInfoMap))
+	for accountID, infos := range accountMap {
+		ids := make([]string, 0, len(infos))
+		for _, one := range infos {
+			ids = append(ids, one.ID)
+		}
+
+		cli, err := svc.hcResourceTagClient(infos[0].Vendor)
+		if err == nil {
+			err = operate(cli, accountID, ids)
+		}
+
+		if err != nil {
+			logs.Errorf("operate resource tag failed, err: %v, account: %s, ids: %v, rid: %s", err, accountID, ids,
+				kt.Rid)
+			return core.BatchOperateResult{
+				Succeeded: successIDs,
+				Failed: &core.FailedInfo{
+					ID:    ids[0],
+					Error: err,
+				},
+			}, errf.NewFromErr(errf.PartialFailed, err)
+		}
+
+		successIDs = append(successIDs, ids...)
+	}
+
+	return nil, nil
+}
+
+func (svc *resourceTagSvc) hcResourceTagClient(vendor enumor.Vendor) (*hcrtagcli.Client, error) {
+	switch vendor {
+	case enumor.TCloud:
+		return svc.client.HCService().TCloud.ResourceTag, nil
+	case enumor.Aws:
+		return svc.client.HCService().Aws.ResourceTag, nil
+	case enumor.HuaWei:
+		return svc.client.HCService().HuaWei.ResourceTag, nil
+	case enumor.Gcp:
+		return svc.client.HCService().Gcp.ResourceTag, nil
+	case enumor.Azure:
+		return svc.client.HCService().Azure.ResourceTag, nil
+	case enumor.OpenStack:
+		return svc.client.HCService().OpenStack.ResourceTag, nil
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", vendor)
+	}
+}
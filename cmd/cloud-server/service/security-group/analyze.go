@@ -0,0 +1,203 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	cloudserver "hcm/pkg/api/cloud-server"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// AnalyzeSecurityGroup analyze rules of security groups.
+func (svc *securityGroupSvc) AnalyzeSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.analyzeSecurityGroup(cts, handler.ResValidWithAuth)
+}
+
+// AnalyzeBizSecurityGroup analyze rules of biz security groups.
+func (svc *securityGroupSvc) AnalyzeBizSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.analyzeSecurityGroup(cts, handler.BizValidWithAuth)
+}
+
+func (svc *securityGroupSvc) analyzeSecurityGroup(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(cloudserver.SecurityGroupAnalyzeReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.SecurityGroupCloudResType,
+		IDs:          req.SecurityGroupIDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.SecurityGroup,
+		Action: meta.Find, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := svc.sgLogic.AnalyzeSecurityGroup(cts.Kit, req.SecurityGroupIDs)
+	if err != nil {
+		logs.Errorf("analyze security group failed, err: %v, ids: %v, rid: %s", err, req.SecurityGroupIDs,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &cloudserver.SecurityGroupAnalyzeResult{Details: details}, nil
+}
+
+// AnalyzeCvmSecurityGroup analyze rules of security groups bound to cvms.
+func (svc *securityGroupSvc) AnalyzeCvmSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.analyzeCvmSecurityGroup(cts, handler.ResValidWithAuth)
+}
+
+// AnalyzeBizCvmSecurityGroup analyze rules of security groups bound to biz cvms.
+func (svc *securityGroupSvc) AnalyzeBizCvmSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.analyzeCvmSecurityGroup(cts, handler.BizValidWithAuth)
+}
+
+func (svc *securityGroupSvc) analyzeCvmSecurityGroup(cts *rest.Contexts,
+	validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+
+	req := new(cloudserver.CvmSecurityGroupAnalyzeReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.CvmCloudResType,
+		IDs:          req.CvmIDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.SecurityGroup,
+		Action: meta.Find, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := svc.sgLogic.AnalyzeCvm(cts.Kit, req.CvmIDs)
+	if err != nil {
+		logs.Errorf("analyze cvm security group failed, err: %v, cvm ids: %v, rid: %s", err, req.CvmIDs,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &cloudserver.CvmSecurityGroupAnalyzeResult{Details: details}, nil
+}
+
+// GetBizSecurityGroupAnalysis analyze rules of all security groups and gcp firewall rules in biz.
+func (svc *securityGroupSvc) GetBizSecurityGroupAnalysis(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if bizID <= 0 {
+		return nil, errf.New(errf.InvalidParameter, "biz id is invalid")
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.SecurityGroup, Action: meta.Find}, BizID: bizID}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.sgLogic.AnalyzeBiz(cts.Kit, bizID)
+	if err != nil {
+		logs.Errorf("analyze biz security group failed, err: %v, biz: %d, rid: %s", err, bizID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// EvaluateCvmNetworkAccess evaluate effective network access of cvm.
+func (svc *securityGroupSvc) EvaluateCvmNetworkAccess(cts *rest.Contexts) (interface{}, error) {
+	return svc.evaluateCvmNetworkAccess(cts, handler.ResValidWithAuth)
+}
+
+// EvaluateBizCvmNetworkAccess evaluate effective network access of biz cvm.
+func (svc *securityGroupSvc) EvaluateBizCvmNetworkAccess(cts *rest.Contexts) (interface{}, error) {
+	return svc.evaluateCvmNetworkAccess(cts, handler.BizValidWithAuth)
+}
+
+func (svc *securityGroupSvc) evaluateCvmNetworkAccess(cts *rest.Contexts,
+	validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+
+	cvmID := cts.PathParameter("cvm_id").String()
+	if len(cvmID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "cvm_id is required")
+	}
+
+	req := new(cloudserver.CvmNetworkAccessReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.CvmCloudResType, cvmID)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.SecurityGroup,
+		Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := svc.sgLogic.EvaluateCvmAccess(cts.Kit, cvmID, req.Queries)
+	if err != nil {
+		logs.Errorf("evaluate cvm network access failed, err: %v, cvm: %s, rid: %s", err, cvmID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -67,6 +67,13 @@ func (svc *securityGroupSvc) createSecurityGroup(cts *rest.Contexts, bizID int64
 		return nil, err
 	}
 
+	return svc.createVendorSecurityGroup(cts, bizID, req)
+}
+
+// createVendorSecurityGroup create security group by vendor, the result is *core.CreateResult.
+func (svc *securityGroupSvc) createVendorSecurityGroup(cts *rest.Contexts, bizID int64,
+	req *proto.SecurityGroupCreateReq) (interface{}, error) {
+
 	switch req.Vendor {
 	case enumor.TCloud:
 		return svc.createTCloudSecurityGroup(cts, bizID, req)
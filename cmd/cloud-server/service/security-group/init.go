@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	sglgc "hcm/cmd/cloud-server/logics/security-group"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -35,6 +36,7 @@ func InitSecurityGroupService(c *capability.Capability) {
 		client:     c.ApiClient,
 		authorizer: c.Authorizer,
 		audit:      c.Audit,
+		sgLogic:    c.Logics.SecurityGroup,
 	}
 
 	h := rest.NewHandler()
@@ -55,6 +57,10 @@ func InitSecurityGroupService(c *capability.Capability) {
 		svc.AssociateNetworkInterface)
 	h.Add("DisAssociateNetworkInterface", http.MethodPost, "/security_groups/disassociate/network_interfaces",
 		svc.DisAssociateNetworkInterface)
+	h.Add("AnalyzeSecurityGroup", http.MethodPost, "/security_groups/analyze", svc.AnalyzeSecurityGroup)
+	h.Add("AnalyzeCvmSecurityGroup", http.MethodPost, "/security_groups/cvms/analyze", svc.AnalyzeCvmSecurityGroup)
+	h.Add("EvaluateCvmNetworkAccess", http.MethodPost, "/security_groups/cvms/{cvm_id}/network_access/evaluate",
+		svc.EvaluateCvmNetworkAccess)
 
 	h.Add("CreateSecurityGroupRule", http.MethodPost,
 		"/vendors/{vendor}/security_groups/{security_group_id}/rules/create", svc.CreateSecurityGroupRule)
@@ -67,6 +73,21 @@ func InitSecurityGroupService(c *capability.Capability) {
 	h.Add("GetAzureDefaultSGRule", http.MethodGet, "/vendors/azure/default/security_groups/rules/{type}",
 		svc.GetAzureDefaultSGRule)
 
+	// 安全组模版相关接口
+	h.Add("CreateSGTemplate", http.MethodPost, "/security_group_templates/create", svc.CreateSGTemplate)
+	h.Add("GetSGTemplate", http.MethodGet, "/security_group_templates/{id}", svc.GetSGTemplate)
+	h.Add("UpdateSGTemplate", http.MethodPatch, "/security_group_templates/{id}", svc.UpdateSGTemplate)
+	h.Add("ListSGTemplate", http.MethodPost, "/security_group_templates/list", svc.ListSGTemplate)
+	h.Add("BatchDeleteSGTemplate", http.MethodDelete, "/security_group_templates/batch", svc.BatchDeleteSGTemplate)
+	h.Add("InstantiateSGTemplate", http.MethodPost, "/security_group_templates/{id}/instantiate",
+		svc.InstantiateSGTemplate)
+	h.Add("BindSGTemplate", http.MethodPost, "/security_group_templates/{id}/rels/create", svc.BindSGTemplate)
+	h.Add("ListSGTemplateRel", http.MethodPost, "/security_group_templates/{id}/rels/list", svc.ListSGTemplateRel)
+	h.Add("UnbindSGTemplate", http.MethodDelete, "/security_group_templates/{id}/rels/batch", svc.UnbindSGTemplate)
+	h.Add("PreviewPushSGTemplate", http.MethodPost, "/security_group_templates/{id}/push/preview",
+		svc.PreviewPushSGTemplate)
+	h.Add("PushSGTemplate", http.MethodPost, "/security_group_templates/{id}/push", svc.PushSGTemplate)
+
 	// 业务下安全组相关接口
 	h.Add("CreateBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/create",
 		svc.CreateBizSecurityGroup)
@@ -89,6 +110,14 @@ func InitSecurityGroupService(c *capability.Capability) {
 		svc.AssociateBizNIC)
 	h.Add("DisAssociateBizNIC", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/disassociate/network_interfaces",
 		svc.DisAssociateBizNIC)
+	h.Add("AnalyzeBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/analyze",
+		svc.AnalyzeBizSecurityGroup)
+	h.Add("AnalyzeBizCvmSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/cvms/analyze",
+		svc.AnalyzeBizCvmSecurityGroup)
+	h.Add("GetBizSecurityGroupAnalysis", http.MethodGet, "/bizs/{bk_biz_id}/security_groups/analysis",
+		svc.GetBizSecurityGroupAnalysis)
+	h.Add("EvaluateBizCvmNetworkAccess", http.MethodPost,
+		"/bizs/{bk_biz_id}/security_groups/cvms/{cvm_id}/network_access/evaluate", svc.EvaluateBizCvmNetworkAccess)
 
 	h.Add("CreateBizSGRule", http.MethodPost,
 		"/bizs/{bk_biz_id}/vendors/{vendor}/security_groups/{security_group_id}/rules/create", svc.CreateBizSGRule)
@@ -106,4 +135,5 @@ type securityGroupSvc struct {
 	client     *client.ClientSet
 	authorizer auth.Authorizer
 	audit      audit.Interface
+	sgLogic    sglgc.Interface
 }
@@ -108,6 +108,14 @@ func (svc *securityGroupSvc) listSGRule(cts *rest.Contexts, validHandler handler
 		return svc.client.DataService().Azure.SecurityGroup.ListSecurityGroupRule(cts.Kit.Ctx, cts.Kit.Header(),
 			listReq, sgID)
 
+	case enumor.OpenStack:
+		listReq := &dataproto.OpenStackSGRuleListReq{
+			Filter: req.Filter,
+			Page:   req.Page,
+		}
+		return svc.client.DataService().OpenStack.SecurityGroup.ListSecurityGroupRule(cts.Kit.Ctx,
+			cts.Kit.Header(), listReq, sgID)
+
 	default:
 		return nil, errf.Newf(errf.Unknown, "vendor: %s not support", vendor)
 	}
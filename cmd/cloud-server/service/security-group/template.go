@@ -0,0 +1,566 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"hcm/cmd/cloud-server/service/common"
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	coresgt "hcm/pkg/api/core/cloud/sg-template"
+	dataservice "hcm/pkg/api/data-service"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	protosgt "hcm/pkg/api/data-service/cloud/sg-template"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+func (svc *securityGroupSvc) authorizeTemplate(kt *kit.Kit, action meta.Action) error {
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.SecurityGroupTemplate, Action: action}}
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes)
+}
+
+// CreateSGTemplate create security group template.
+func (svc *securityGroupSvc) CreateSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.SGTemplateCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeTemplate(cts.Kit, meta.Create); err != nil {
+		return nil, err
+	}
+
+	createReq := &protosgt.SGTemplateBatchCreateReq{
+		Templates: []protosgt.SGTemplateCreateReq{{
+			Name:  req.Name,
+			Rules: req.Rules,
+			Memo:  req.Memo,
+		}},
+	}
+	result, err := svc.client.DataService().Global.SGTemplate.BatchCreateSGTemplate(cts.Kit.Ctx, cts.Kit.Header(),
+		createReq)
+	if err != nil {
+		logs.Errorf("create security group template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, errf.Newf(errf.Aborted, "create security group template but return ids count %d is invalid",
+			len(result.IDs))
+	}
+
+	return &core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// GetSGTemplate get security group template.
+func (svc *securityGroupSvc) GetSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.authorizeTemplate(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.getSGTemplate(cts.Kit, id)
+}
+
+// UpdateSGTemplate update security group template, version of template is increased if rules are updated, and
+// linked security groups are changed only when the template is pushed.
+func (svc *securityGroupSvc) UpdateSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.SGTemplateUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeTemplate(cts.Kit, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &protosgt.SGTemplateBatchUpdateReq{
+		Templates: []protosgt.SGTemplateUpdateReq{{
+			ID:    id,
+			Name:  req.Name,
+			Rules: req.Rules,
+			Memo:  req.Memo,
+		}},
+	}
+	return nil, svc.client.DataService().Global.SGTemplate.BatchUpdateSGTemplate(cts.Kit.Ctx, cts.Kit.Header(),
+		updateReq)
+}
+
+// ListSGTemplate list security group template.
+func (svc *securityGroupSvc) ListSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeTemplate(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.SGTemplate.ListSGTemplate(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// BatchDeleteSGTemplate batch delete security group template, template linked to resources can not be deleted.
+func (svc *securityGroupSvc) BatchDeleteSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeTemplate(cts.Kit, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	delReq := &dataservice.BatchDeleteReq{
+		Filter: tools.ContainersExpression("id", req.IDs),
+	}
+	return nil, svc.client.DataService().Global.SGTemplate.BatchDeleteSGTemplate(cts.Kit.Ctx, cts.Kit.Header(),
+		delReq)
+}
+
+// InstantiateSGTemplate create a security group on the account and region, link it to the template and push
+// the template rules to it.
+func (svc *securityGroupSvc) InstantiateSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.SGTemplateInstantiateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeTemplate(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	bizID := int64(constant.UnassignedBiz)
+	err := handler.ResValidWithAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: meta.SecurityGroup, Action: meta.Create,
+		BasicInfo: common.GetCloudResourceBasicInfo(req.AccountID, bizID)})
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := svc.getSGTemplate(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := svc.createVendorSecurityGroup(cts, bizID, &req.SecurityGroupCreateReq)
+	if err != nil {
+		return nil, err
+	}
+
+	sgID := created.(*core.CreateResult).ID
+	rel := coresgt.SecurityGroupTemplateRel{
+		TemplateID: tpl.ID,
+		Vendor:     req.Vendor,
+		AccountID:  req.AccountID,
+		Region:     req.Region,
+		ResType:    enumor.SecurityGroupCloudResType,
+		ResID:      sgID,
+	}
+	relIDs, err := svc.createSGTemplateRel(cts.Kit, []coresgt.SecurityGroupTemplateRel{rel})
+	if err != nil {
+		return nil, err
+	}
+	rel.ID = relIDs[0]
+
+	pushResult, err := svc.sgLogic.PushTemplate(cts.Kit, tpl, []coresgt.SecurityGroupTemplateRel{rel})
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.SGTemplateInstantiateResult{SecurityGroupID: sgID, RelID: rel.ID, Push: *pushResult}, nil
+}
+
+// BindSGTemplate link existing security groups or gcp vpcs to the template.
+func (svc *securityGroupSvc) BindSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.SGTemplateBindReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeTemplate(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	if _, err := svc.getSGTemplate(cts.Kit, id); err != nil {
+		return nil, err
+	}
+
+	rels := make([]coresgt.SecurityGroupTemplateRel, 0, len(req.SecurityGroupIDs)+len(req.GcpVpcs))
+	for _, sgID := range req.SecurityGroupIDs {
+		rels = append(rels, coresgt.SecurityGroupTemplateRel{ResType: enumor.SecurityGroupCloudResType,
+			ResID: sgID})
+	}
+	for _, vpc := range req.GcpVpcs {
+		rels = append(rels, coresgt.SecurityGroupTemplateRel{ResType: enumor.VpcCloudResType, ResID: vpc.VpcID,
+			TargetTag: vpc.TargetTag})
+	}
+
+	basicInfos, err := svc.authorizeSGTemplateRel(cts, rels)
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range rels {
+		info, exists := basicInfos[rels[index].ResType][rels[index].ResID]
+		if !exists {
+			return nil, errf.Newf(errf.RecordNotFound, "%s: %s not found", rels[index].ResType, rels[index].ResID)
+		}
+
+		if rels[index].ResType == enumor.VpcCloudResType && info.Vendor != enumor.Gcp {
+			return nil, errf.Newf(errf.InvalidParameter, "vpc: %s is not gcp vpc", rels[index].ResID)
+		}
+
+		rels[index].TemplateID = id
+		rels[index].Vendor = info.Vendor
+		rels[index].AccountID = info.AccountID
+		rels[index].Region = info.Region
+	}
+
+	ids, err := svc.createSGTemplateRel(cts.Kit, rels)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// UnbindSGTemplate unlink security groups or gcp vpcs from the template, rules created by the template are kept.
+func (svc *securityGroupSvc) UnbindSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.SGTemplateUnbindReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rels, err := svc.listSGTemplateRel(cts.Kit, id, req.RelIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = svc.authorizeSGTemplateRel(cts, rels); err != nil {
+		return nil, err
+	}
+
+	delReq := &dataservice.BatchDeleteReq{
+		Filter: tools.ContainersExpression("id", req.RelIDs),
+	}
+	return nil, svc.client.DataService().Global.SGTemplate.BatchDeleteSGTemplateRel(cts.Kit.Ctx, cts.Kit.Header(),
+		delReq)
+}
+
+// ListSGTemplateRel list resources linked to the template, resource whose version is less than the template
+// version has not been pushed the latest rules.
+func (svc *securityGroupSvc) ListSGTemplateRel(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeTemplate(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	expr, err := tools.And(req.Filter, tools.EqualExpression("template_id", id))
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.SGTemplate.ListSGTemplateRel(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// PreviewPushSGTemplate preview the rules to be added and removed on linked resources if the template is pushed.
+func (svc *securityGroupSvc) PreviewPushSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	tpl, rels, err := svc.decodeSGTemplatePush(cts, meta.Find)
+	if err != nil {
+		return nil, err
+	}
+
+	details := svc.sgLogic.PreviewTemplate(cts.Kit, tpl, rels)
+	return &proto.SGTemplatePreviewResult{TemplateVersion: tpl.Version, Details: details}, nil
+}
+
+// PushSGTemplate reconcile rules of linked resources to the template.
+func (svc *securityGroupSvc) PushSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	tpl, rels, err := svc.decodeSGTemplatePush(cts, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := svc.sgLogic.PushTemplate(cts.Kit, tpl, rels)
+	if err != nil {
+		logs.Errorf("push security group template failed, err: %v, id: %s, rid: %s", err, tpl.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (svc *securityGroupSvc) decodeSGTemplatePush(cts *rest.Contexts, action meta.Action) (
+	*coresgt.SecurityGroupTemplate, []coresgt.SecurityGroupTemplateRel, error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.SGTemplatePushReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeTemplate(cts.Kit, meta.Find); err != nil {
+		return nil, nil, err
+	}
+
+	tpl, err := svc.getSGTemplate(cts.Kit, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rels, err := svc.listSGTemplateRel(cts.Kit, id, req.RelIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if action != meta.Find {
+		if _, err = svc.authorizeSGTemplateRel(cts, rels); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return tpl, rels, nil
+}
+
+// authorizeSGTemplateRel authorize operating rules of the linked resources, and returns their basic info grouped
+// by resource type.
+func (svc *securityGroupSvc) authorizeSGTemplateRel(cts *rest.Contexts, rels []coresgt.SecurityGroupTemplateRel) (
+	map[enumor.CloudResourceType]map[string]types.CloudResourceBasicInfo, error) {
+
+	resIDs := make(map[enumor.CloudResourceType][]string)
+	for _, rel := range rels {
+		resIDs[rel.ResType] = append(resIDs[rel.ResType], rel.ResID)
+	}
+
+	result := make(map[enumor.CloudResourceType]map[string]types.CloudResourceBasicInfo)
+	for resType, ids := range resIDs {
+		var authType meta.ResourceType
+		switch resType {
+		case enumor.SecurityGroupCloudResType:
+			authType = meta.SecurityGroupRule
+		case enumor.VpcCloudResType:
+			authType = meta.GcpFirewallRule
+		default:
+			return nil, errf.Newf(errf.InvalidParameter, "resource type: %s is not supported", resType)
+		}
+
+		basicInfoReq := dataproto.ListResourceBasicInfoReq{
+			ResourceType: resType,
+			IDs:          ids,
+			Fields:       append(types.CommonBasicInfoFields, "region"),
+		}
+		basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(cts.Kit.Ctx,
+			cts.Kit.Header(), basicInfoReq)
+		if err != nil {
+			return nil, err
+		}
+
+		err = handler.ResValidWithAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+			ResType: authType, Action: meta.Update, BasicInfos: basicInfoMap})
+		if err != nil {
+			return nil, err
+		}
+		result[resType] = basicInfoMap
+	}
+
+	return result, nil
+}
+
+func (svc *securityGroupSvc) getSGTemplate(kt *kit.Kit, id string) (*coresgt.SecurityGroupTemplate, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.SGTemplate.ListSGTemplate(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list security group template failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "security group template: %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
+
+// listSGTemplateRel list rels of the template, empty rel ids means all rels of the template.
+func (svc *securityGroupSvc) listSGTemplateRel(kt *kit.Kit, templateID string, relIDs []string) (
+	[]coresgt.SecurityGroupTemplateRel, error) {
+
+	rules := []filter.RuleFactory{tools.EqualExpression("template_id", templateID)}
+	if len(relIDs) != 0 {
+		rules = append(rules, tools.ContainersExpression("id", relIDs))
+	}
+	expr, err := tools.And(rules...)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+	}
+	rels := make([]coresgt.SecurityGroupTemplateRel, 0)
+	for {
+		result, err := svc.client.DataService().Global.SGTemplate.ListSGTemplateRel(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list security group template rel failed, err: %v, template: %s, rid: %s", err,
+				templateID, kt.Rid)
+			return nil, err
+		}
+
+		rels = append(rels, result.Details...)
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	if len(relIDs) != 0 && len(rels) != len(relIDs) {
+		return nil, errf.Newf(errf.InvalidParameter, "some rels are not linked to template: %s", templateID)
+	}
+
+	return rels, nil
+}
+
+func (svc *securityGroupSvc) createSGTemplateRel(kt *kit.Kit, rels []coresgt.SecurityGroupTemplateRel) ([]string,
+	error) {
+
+	createReq := &protosgt.SGTemplateRelBatchCreateReq{
+		Rels: make([]protosgt.SGTemplateRelCreateReq, 0, len(rels)),
+	}
+	for _, rel := range rels {
+		createReq.Rels = append(createReq.Rels, protosgt.SGTemplateRelCreateReq{
+			TemplateID: rel.TemplateID,
+			Vendor:     rel.Vendor,
+			AccountID:  rel.AccountID,
+			Region:     rel.Region,
+			ResType:    rel.ResType,
+			ResID:      rel.ResID,
+			TargetTag:  rel.TargetTag,
+		})
+	}
+
+	result, err := svc.client.DataService().Global.SGTemplate.BatchCreateSGTemplateRel(kt.Ctx, kt.Header(),
+		createReq)
+	if err != nil {
+		logs.Errorf("create security group template rel failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != len(rels) {
+		return nil, errf.Newf(errf.Aborted, "create security group template rel but return ids count %d is invalid",
+			len(result.IDs))
+	}
+
+	return result.IDs, nil
+}
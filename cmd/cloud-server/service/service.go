@@ -35,6 +35,7 @@ import (
 	"hcm/cmd/cloud-server/service/assign"
 	"hcm/cmd/cloud-server/service/audit"
 	"hcm/cmd/cloud-server/service/bill"
+	"hcm/cmd/cloud-server/service/bucket"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/cmd/cloud-server/service/cvm"
 	"hcm/cmd/cloud-server/service/disk"
@@ -42,16 +43,24 @@ import (
 	"hcm/cmd/cloud-server/service/firewall"
 	"hcm/cmd/cloud-server/service/image"
 	instancetype "hcm/cmd/cloud-server/service/instance-type"
+	"hcm/cmd/cloud-server/service/ipam"
+	keypair "hcm/cmd/cloud-server/service/key-pair"
+	loadbalancer "hcm/cmd/cloud-server/service/load-balancer"
+	natgateway "hcm/cmd/cloud-server/service/nat-gateway"
 	networkinterface "hcm/cmd/cloud-server/service/network-interface"
 	"hcm/cmd/cloud-server/service/recycle"
 	"hcm/cmd/cloud-server/service/region"
 	resourcegroup "hcm/cmd/cloud-server/service/resource-group"
+	resourcetag "hcm/cmd/cloud-server/service/resource-tag"
 	routetable "hcm/cmd/cloud-server/service/route-table"
 	securitygroup "hcm/cmd/cloud-server/service/security-group"
+	"hcm/cmd/cloud-server/service/snapshot"
 	"hcm/cmd/cloud-server/service/subnet"
 	"hcm/cmd/cloud-server/service/sync"
 	"hcm/cmd/cloud-server/service/sync/lock"
+	"hcm/cmd/cloud-server/service/sync/scheduler"
 	"hcm/cmd/cloud-server/service/vpc"
+	vpcpeering "hcm/cmd/cloud-server/service/vpc-peering"
 	"hcm/cmd/cloud-server/service/zone"
 	"hcm/pkg/cc"
 	"hcm/pkg/client"
@@ -150,17 +159,29 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 		return nil, err
 	}
 
+	scheduler.InitScheduler(cc.CloudServer().CloudResource.Sync.Scheduler)
 	if cc.CloudServer().CloudResource.Sync.Enable {
 		interval := time.Duration(cc.CloudServer().CloudResource.Sync.SyncIntervalMin) * time.Minute
 		go sync.CloudResourceSync(interval, sd, apiClientSet)
+
+		if cc.CloudServer().CloudResource.Sync.IncrSyncEnable {
+			incrInterval := time.Duration(cc.CloudServer().CloudResource.Sync.IncrSyncIntervalMin) * time.Minute
+			go sync.CloudResourceIncrSync(incrInterval, sd, apiClientSet)
+		}
 	}
 
 	if cc.CloudServer().BillConfig.Enable {
 		interval := time.Duration(cc.CloudServer().BillConfig.SyncIntervalMin) * time.Minute
 		go bill.CloudBillConfigCreate(interval, sd, apiClientSet)
+		go bill.CloudBillItemSync(interval, sd, apiClientSet)
+	}
+
+	if cc.CloudServer().Budget.Enable {
+		go bill.BudgetCheckTiming(cc.CloudServer().Budget, sd, apiClientSet)
 	}
 
 	recycle.RecycleTiming(apiClientSet, sd, cc.CloudServer().Recycle)
+	snapshot.SnapshotPolicyTiming(apiClientSet, sd)
 
 	return svr, nil
 }
@@ -169,7 +190,18 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 func newCipherFromConfig(cryptoConfig cc.Crypto) (cryptography.Crypto, error) {
 	// TODO: 目前只支持国际加密，还未支持中国国家商业加密，待后续支持再调整
 	cfg := cryptoConfig.AesGcm
-	return cryptography.NewAESGcm([]byte(cfg.Key), []byte(cfg.Nonce))
+
+	var legacy *cryptography.AESGcm
+	if len(cfg.Key) != 0 {
+		var err error
+		legacy, err = cryptography.NewAESGcm([]byte(cfg.Key), []byte(cfg.Nonce))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	primaryKeyID, keys := cfg.KeyringKeys()
+	return cryptography.NewAESGcmKeyring(primaryKeyID, keys, legacy)
 }
 
 // ListenAndServeRest listen and serve the restful server
@@ -260,6 +292,14 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	eip.InitEipService(c)
 	instancetype.InitInstanceTypeService(c)
 	networkinterface.InitNetworkInterfaceService(c)
+	loadbalancer.InitLoadBalancerService(c)
+	natgateway.InitNatGatewayService(c)
+	snapshot.InitSnapshotService(c)
+	keypair.InitKeyPairService(c)
+	bucket.InitBucketService(c)
+	vpcpeering.InitVpcPeeringService(c)
+	resourcetag.InitResourceTagService(c)
+	ipam.InitIpamService(c)
 
 	application.InitApplicationService(c, bkHcmUrl)
 	audit.InitService(c)
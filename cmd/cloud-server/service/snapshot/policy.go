@@ -0,0 +1,317 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	cssnap "hcm/pkg/api/cloud-server/snapshot"
+	"hcm/pkg/api/core"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	protodisk "hcm/pkg/api/data-service/cloud/disk"
+	protosnap "hcm/pkg/api/data-service/cloud/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// CreateBizSnapshotPolicy create biz snapshot policy.
+func (svc *snapSvc) CreateBizSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBizSnapshotPolicy(cts, meta.Create)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(cssnap.SnapshotPolicyCreateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	diskIDs := slice.Unique(req.DiskIDs)
+	if err = svc.validateBizDisks(cts.Kit, bizID, diskIDs); err != nil {
+		return nil, err
+	}
+
+	createReq := &protosnap.SnapshotPolicyBatchCreateReq{
+		Policies: []protosnap.SnapshotPolicyBatchCreate{{
+			Name:           req.Name,
+			BkBizID:        bizID,
+			Scope:          req.Scope,
+			DiskIDs:        diskIDs,
+			Schedule:       req.Schedule,
+			RetentionCount: req.RetentionCount,
+			Enabled:        req.Enabled,
+			Memo:           req.Memo,
+		}},
+	}
+	result, err := svc.client.DataService().Global.Snapshot.BatchCreateSnapshotPolicy(cts.Kit.Ctx,
+		cts.Kit.Header(), createReq)
+	if err != nil {
+		logs.Errorf("create snapshot policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, errf.New(errf.Aborted, "create result is invalid")
+	}
+
+	return core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// UpdateBizSnapshotPolicy update biz snapshot policy.
+func (svc *snapSvc) UpdateBizSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	bizID, err := svc.authorizeBizSnapshotPolicy(cts, meta.Update)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(cssnap.SnapshotPolicyUpdateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	policies, err := svc.listBizSnapshotPolicy(cts.Kit, bizID, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(policies) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "snapshot policy %s not found in biz %d", id, bizID)
+	}
+	policy := policies[0]
+
+	// 作用范围与云盘需要结合原策略校验，切换为业务范围时需要清空已指定的云盘
+	scope, diskIDs := policy.Scope, policy.DiskIDs
+	if len(req.Scope) != 0 {
+		scope = req.Scope
+	}
+	if req.DiskIDs != nil {
+		diskIDs = slice.Unique(req.DiskIDs)
+	}
+	if scope == enumor.BizSnapshotPolicyScope && len(req.Scope) != 0 && req.DiskIDs == nil {
+		diskIDs = make([]string, 0)
+	}
+
+	if err = cssnap.ValidateSnapshotPolicyScope(scope, diskIDs); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if req.DiskIDs != nil {
+		if err = svc.validateBizDisks(cts.Kit, bizID, diskIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	update := protosnap.SnapshotPolicyBatchUpdate{
+		ID:             id,
+		Name:           req.Name,
+		Scope:          req.Scope,
+		Schedule:       req.Schedule,
+		RetentionCount: req.RetentionCount,
+		Enabled:        req.Enabled,
+		Memo:           req.Memo,
+	}
+	if req.DiskIDs != nil || len(req.Scope) != 0 {
+		update.DiskIDs = diskIDs
+	}
+
+	updateReq := &protosnap.SnapshotPolicyBatchUpdateReq{Policies: []protosnap.SnapshotPolicyBatchUpdate{update}}
+	if err = svc.client.DataService().Global.Snapshot.BatchUpdateSnapshotPolicy(cts.Kit.Ctx, cts.Kit.Header(),
+		updateReq); err != nil {
+		logs.Errorf("update snapshot policy failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBizSnapshotPolicy list biz snapshot policy.
+func (svc *snapSvc) ListBizSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBizSnapshotPolicy(cts, meta.Find)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(core.ListReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	bizRule := &filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: bizID}
+	if req.Filter == nil {
+		req.Filter = &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{bizRule}}
+	} else {
+		req.Filter = &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{bizRule, req.Filter}}
+	}
+
+	return svc.client.DataService().Global.Snapshot.ListSnapshotPolicy(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// BatchDeleteBizSnapshotPolicy batch delete biz snapshot policy, snapshots created by the policy are kept.
+func (svc *snapSvc) BatchDeleteBizSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := svc.authorizeBizSnapshotPolicy(cts, meta.Delete)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(core.BatchDeleteReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := slice.Unique(req.IDs)
+	policies, err := svc.listBizSnapshotPolicy(cts.Kit, bizID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(policies) != len(ids) {
+		existMap := make(map[string]struct{}, len(policies))
+		for _, one := range policies {
+			existMap[one.ID] = struct{}{}
+		}
+
+		notExists := make([]string, 0)
+		for _, id := range ids {
+			if _, exist := existMap[id]; !exist {
+				notExists = append(notExists, id)
+			}
+		}
+		return nil, errf.Newf(errf.InvalidParameter, "snapshot policy(ids=%v) not found in biz %d", notExists, bizID)
+	}
+
+	// create delete audit.
+	if err = svc.audit.ResDeleteAudit(cts.Kit, enumor.SnapshotPolicyAuditResType, ids); err != nil {
+		logs.Errorf("create snapshot policy delete audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	deleteReq := &protosnap.SnapshotPolicyBatchDeleteReq{Filter: tools.ContainersExpression("id", ids)}
+	if err = svc.client.DataService().Global.Snapshot.BatchDeleteSnapshotPolicy(cts.Kit.Ctx, cts.Kit.Header(),
+		deleteReq); err != nil {
+		logs.Errorf("delete snapshot policy failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// authorizeBizSnapshotPolicy parse biz id from url and authorize snapshot policy action in the biz.
+func (svc *snapSvc) authorizeBizSnapshotPolicy(cts *rest.Contexts, action meta.Action) (int64, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return 0, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if bizID <= 0 {
+		return 0, errf.New(errf.InvalidParameter, "bk_biz_id should > 0")
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.SnapshotPolicy, Action: action}, BizID: bizID}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		return 0, err
+	}
+
+	return bizID, nil
+}
+
+func (svc *snapSvc) listBizSnapshotPolicy(kt *kit.Kit, bizID int64, ids []string) ([]coresnap.SnapshotPolicy,
+	error) {
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: bizID},
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: ids},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.Snapshot.ListSnapshotPolicy(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list snapshot policy failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// validateBizDisks validate disks specified by snapshot policy all exist and belong to the biz.
+func (svc *snapSvc) validateBizDisks(kt *kit.Kit, bizID int64, diskIDs []string) error {
+	if len(diskIDs) == 0 {
+		return nil
+	}
+
+	req := &protodisk.DiskListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: bizID},
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: diskIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.ListDisk(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list disk failed, err: %v, ids: %v, rid: %s", err, diskIDs, kt.Rid)
+		return err
+	}
+
+	if len(result.Details) == len(diskIDs) {
+		return nil
+	}
+
+	idMap := converter.StringSliceToMap(diskIDs)
+	for _, one := range result.Details {
+		delete(idMap, one.ID)
+	}
+
+	return errf.Newf(errf.InvalidParameter, "disk(ids=%v) not found in biz %d", converter.MapKeyToStringSlice(idMap),
+		bizID)
+}
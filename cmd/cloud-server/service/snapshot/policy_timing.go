@@ -0,0 +1,328 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"fmt"
+	"time"
+
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	"hcm/pkg/api/core"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	protodisk "hcm/pkg/api/data-service/cloud/disk"
+	protosnap "hcm/pkg/api/data-service/cloud/snapshot"
+	hcsnap "hcm/pkg/api/hc-service/snapshot"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/cron"
+)
+
+type policyTiming struct {
+	client *client.ClientSet
+	state  serviced.State
+}
+
+// SnapshotPolicyTiming timing execute enabled snapshot policies whose schedule is due.
+func SnapshotPolicyTiming(c *client.ClientSet, state serviced.State) {
+	p := &policyTiming{
+		client: c,
+		state:  state,
+	}
+
+	go p.policyTiming()
+}
+
+func (p *policyTiming) policyTiming() {
+	for {
+		kt := kit.New()
+		kt.User = constant.SnapshotPolicyTimingUserKey
+		kt.AppCode = constant.SnapshotPolicyTimingAppCodeKey
+
+		if !p.state.IsMaster() {
+			logs.Infof("execute snapshot policy, but is not master, skip")
+			time.Sleep(time.Minute)
+			continue
+		}
+
+		p.execDuePolicies(kt, time.Now())
+		time.Sleep(time.Minute)
+	}
+}
+
+// execDuePolicies list all enabled snapshot policies, and execute those whose next schedule time has arrived.
+func (p *policyTiming) execDuePolicies(kt *kit.Kit, now time.Time) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("enabled", true),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := p.client.DataService().Global.Snapshot.ListSnapshotPolicy(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list enabled snapshot policy failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		for _, policy := range result.Details {
+			if !p.state.IsMaster() {
+				logs.Infof("execute snapshot policy(id: %s), but is not master, skip, rid: %s", policy.ID, kt.Rid)
+				return
+			}
+
+			due, err := isPolicyDue(policy, now)
+			if err != nil {
+				logs.Errorf("check snapshot policy(id: %s) due failed, err: %v, rid: %s", policy.ID, err, kt.Rid)
+				continue
+			}
+
+			if !due {
+				continue
+			}
+
+			p.execPolicy(kt, policy, now)
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			return
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+}
+
+// isPolicyDue 以策略上次执行时间（从未执行过时为创建时间）为起点，计算下一次调度时间是否已到达
+func isPolicyDue(policy coresnap.SnapshotPolicy, now time.Time) (bool, error) {
+	schedule, err := cron.Parse(policy.Schedule)
+	if err != nil {
+		return false, err
+	}
+
+	base := policy.LastRunAt
+	if len(base) == 0 && policy.Revision != nil {
+		base = policy.CreatedAt
+	}
+
+	last, err := time.Parse(constant.TimeStdFormat, base)
+	if err != nil {
+		return false, fmt.Errorf("parse policy base time %s failed, err: %v", base, err)
+	}
+
+	next := schedule.Next(last.In(time.Local))
+	return !next.IsZero() && !next.After(now), nil
+}
+
+// execPolicy create snapshot for all disks of the policy, then delete snapshots beyond the retention count.
+// 单块云盘失败不影响其它云盘，策略执行时间在处理完成后统一更新，避免失败时每分钟重复触发。
+func (p *policyTiming) execPolicy(kt *kit.Kit, policy coresnap.SnapshotPolicy, now time.Time) {
+	logs.Infof("start execute snapshot policy(id: %s), rid: %s", policy.ID, kt.Rid)
+
+	disks, err := p.listPolicyDisks(kt, policy)
+	if err != nil {
+		logs.Errorf("list snapshot policy(id: %s) disks failed, err: %v, rid: %s", policy.ID, err, kt.Rid)
+		return
+	}
+
+	for _, disk := range disks {
+		if err = p.createPolicySnapshot(kt, policy, disk, now); err != nil {
+			logs.Errorf("snapshot policy(id: %s) create disk(id: %s) snapshot failed, err: %v, rid: %s", policy.ID,
+				disk.ID, err, kt.Rid)
+			continue
+		}
+
+		if err = p.cleanExpiredSnapshot(kt, policy, disk); err != nil {
+			logs.Errorf("snapshot policy(id: %s) clean disk(id: %s) expired snapshot failed, err: %v, rid: %s",
+				policy.ID, disk.ID, err, kt.Rid)
+		}
+	}
+
+	updateReq := &protosnap.SnapshotPolicyBatchUpdateReq{
+		Policies: []protosnap.SnapshotPolicyBatchUpdate{{
+			ID:        policy.ID,
+			LastRunAt: now.Format(constant.TimeStdFormat),
+		}},
+	}
+	if err = p.client.DataService().Global.Snapshot.BatchUpdateSnapshotPolicy(kt.Ctx, kt.Header(),
+		updateReq); err != nil {
+		logs.Errorf("update snapshot policy(id: %s) last run time failed, err: %v, rid: %s", policy.ID, err,
+			kt.Rid)
+		return
+	}
+
+	logs.Infof("finished execute snapshot policy(id: %s), disk count: %d, rid: %s", policy.ID, len(disks), kt.Rid)
+}
+
+// listPolicyDisks list disks of the policy, disks in recycle bin are excluded.
+func (p *policyTiming) listPolicyDisks(kt *kit.Kit, policy coresnap.SnapshotPolicy) ([]*protodisk.DiskResult,
+	error) {
+
+	rules := []filter.RuleFactory{
+		&filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: policy.BkBizID},
+		&filter.AtomRule{Field: "recycle_status", Op: filter.NotEqual.Factory(), Value: enumor.RecycleStatus},
+	}
+	if policy.Scope == enumor.DiskSnapshotPolicyScope {
+		if len(policy.DiskIDs) == 0 {
+			return make([]*protodisk.DiskResult, 0), nil
+		}
+		rules = append(rules, &filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: policy.DiskIDs})
+	}
+
+	listReq := &protodisk.DiskListReq{
+		Filter: &filter.Expression{Op: filter.And, Rules: rules},
+		Page:   core.NewDefaultBasePage(),
+	}
+	disks := make([]*protodisk.DiskResult, 0)
+	for {
+		result, err := p.client.DataService().Global.ListDisk(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		disks = append(disks, result.Details...)
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return disks, nil
+}
+
+// createPolicySnapshot create snapshot of the disk, snapshot is named by policy id, disk id and execute time,
+// which satisfies the naming rules of all vendors.
+func (p *policyTiming) createPolicySnapshot(kt *kit.Kit, policy coresnap.SnapshotPolicy,
+	disk *protodisk.DiskResult, now time.Time) error {
+
+	name := fmt.Sprintf("hcm-%s-%s-%s", policy.ID, disk.ID, now.Format("20060102150405"))
+
+	var err error
+	switch enumor.Vendor(disk.Vendor) {
+	case enumor.TCloud:
+		req := &hcsnap.TCloudSnapshotCreateReq{
+			AccountID:          disk.AccountID,
+			BkBizID:            policy.BkBizID,
+			SnapshotPolicyID:   policy.ID,
+			TCloudCreateOption: &typesnap.TCloudCreateOption{Region: disk.Region, CloudDiskID: disk.CloudID, Name: name},
+		}
+		_, err = p.client.HCService().TCloud.Snapshot.CreateSnapshot(kt.Ctx, kt.Header(), req)
+
+	case enumor.Aws:
+		req := &hcsnap.AwsSnapshotCreateReq{
+			AccountID:        disk.AccountID,
+			BkBizID:          policy.BkBizID,
+			SnapshotPolicyID: policy.ID,
+			AwsCreateOption:  &typesnap.AwsCreateOption{Region: disk.Region, CloudDiskID: disk.CloudID, Name: name},
+		}
+		_, err = p.client.HCService().Aws.Snapshot.CreateSnapshot(kt.Ctx, kt.Header(), req)
+
+	case enumor.HuaWei:
+		req := &hcsnap.HuaWeiSnapshotCreateReq{
+			AccountID:          disk.AccountID,
+			BkBizID:            policy.BkBizID,
+			SnapshotPolicyID:   policy.ID,
+			HuaWeiCreateOption: &typesnap.HuaWeiCreateOption{Region: disk.Region, CloudDiskID: disk.CloudID, Name: name},
+		}
+		_, err = p.client.HCService().HuaWei.Snapshot.CreateSnapshot(kt.Ctx, kt.Header(), req)
+
+	case enumor.Azure:
+		// azure 快照需要指定资源组，通过云盘扩展信息获取
+		azureDisk, getErr := p.client.DataService().Azure.RetrieveDisk(kt.Ctx, kt.Header(), disk.ID)
+		if getErr != nil {
+			return getErr
+		}
+
+		req := &hcsnap.AzureSnapshotCreateReq{
+			AccountID:        disk.AccountID,
+			BkBizID:          policy.BkBizID,
+			SnapshotPolicyID: policy.ID,
+			AzureCreateOption: &typesnap.AzureCreateOption{
+				ResourceGroupName: azureDisk.Extension.ResourceGroupName,
+				Name:              name,
+				Region:            disk.Region,
+				CloudDiskID:       disk.CloudID,
+			},
+		}
+		_, err = p.client.HCService().Azure.Snapshot.CreateSnapshot(kt.Ctx, kt.Header(), req)
+
+	case enumor.Gcp:
+		req := &hcsnap.GcpSnapshotCreateReq{
+			AccountID:        disk.AccountID,
+			BkBizID:          policy.BkBizID,
+			SnapshotPolicyID: policy.ID,
+			GcpCreateOption:  &typesnap.GcpCreateOption{Zone: disk.Zone, CloudDiskName: disk.Name, Name: name},
+		}
+		_, err = p.client.HCService().Gcp.Snapshot.CreateSnapshot(kt.Ctx, kt.Header(), req)
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "no support vendor: %s", disk.Vendor)
+	}
+
+	return err
+}
+
+// cleanExpiredSnapshot delete snapshots of the disk created by the policy beyond retention count, the oldest first.
+func (p *policyTiming) cleanExpiredSnapshot(kt *kit.Kit, policy coresnap.SnapshotPolicy,
+	disk *protodisk.DiskResult) error {
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{
+			"snapshot_policy_id": policy.ID,
+			"disk_id":            disk.ID,
+		}),
+		Page: &core.BasePage{
+			Start: uint32(policy.RetentionCount),
+			Limit: core.DefaultMaxPageLimit,
+			Sort:  "created_at",
+			Order: core.Descending,
+		},
+		Fields: []string{"id"},
+	}
+	result, err := p.client.DataService().Global.Snapshot.ListSnapshot(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		return err
+	}
+
+	for _, one := range result.Details {
+		switch enumor.Vendor(disk.Vendor) {
+		case enumor.TCloud:
+			err = p.client.HCService().TCloud.Snapshot.DeleteSnapshot(kt.Ctx, kt.Header(), one.ID)
+		case enumor.Aws:
+			err = p.client.HCService().Aws.Snapshot.DeleteSnapshot(kt.Ctx, kt.Header(), one.ID)
+		case enumor.HuaWei:
+			err = p.client.HCService().HuaWei.Snapshot.DeleteSnapshot(kt.Ctx, kt.Header(), one.ID)
+		case enumor.Azure:
+			err = p.client.HCService().Azure.Snapshot.DeleteSnapshot(kt.Ctx, kt.Header(), one.ID)
+		case enumor.Gcp:
+			err = p.client.HCService().Gcp.Snapshot.DeleteSnapshot(kt.Ctx, kt.Header(), one.ID)
+		default:
+			err = errf.Newf(errf.InvalidParameter, "no support vendor: %s", disk.Vendor)
+		}
+		if err != nil {
+			return fmt.Errorf("delete snapshot(id: %s) failed, err: %v", one.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	protoaudit "hcm/pkg/api/data-service/audit"
+	hcsnap "hcm/pkg/api/hc-service/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// RollbackSnapshot rollback snapshot to its source disk.
+func (svc *snapSvc) RollbackSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.rollbackSnapshot(cts, handler.ResValidWithAuth)
+}
+
+// RollbackBizSnapshot rollback biz snapshot to its source disk.
+func (svc *snapSvc) RollbackBizSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.rollbackSnapshot(cts, handler.BizValidWithAuth)
+}
+
+func (svc *snapSvc) rollbackSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.SnapshotCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Snapshot,
+		Action: meta.Update, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	// aws、azure、gcp 不支持将快照原地回滚到源云盘，只能通过快照创建新的云盘
+	if basicInfo.Vendor != enumor.TCloud && basicInfo.Vendor != enumor.HuaWei {
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support snapshot rollback, please create "+
+			"a new disk from the snapshot instead", basicInfo.Vendor)
+	}
+
+	req := new(hcsnap.TCloudSnapshotRollbackReq)
+	if basicInfo.Vendor == enumor.TCloud {
+		if err = cts.DecodeInto(req); err != nil {
+			return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+		}
+
+		if err = req.Validate(); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	// create rollback audit.
+	err = svc.audit.ResBaseOperationAudit(cts.Kit, enumor.SnapshotAuditResType, protoaudit.Rollback, []string{id})
+	if err != nil {
+		logs.Errorf("create snapshot rollback audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return nil, svc.client.HCService().TCloud.Snapshot.RollbackSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id, req)
+	case enumor.HuaWei:
+		return nil, svc.client.HCService().HuaWei.Snapshot.RollbackSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", basicInfo.Vendor)
+	}
+}
@@ -0,0 +1,446 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot defines snapshot service.
+package snapshot
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/cmd/cloud-server/service/common"
+	cssnap "hcm/pkg/api/cloud-server/snapshot"
+	"hcm/pkg/api/core"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	protosnap "hcm/pkg/api/data-service/cloud/snapshot"
+	hcsnap "hcm/pkg/api/hc-service/snapshot"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitSnapshotService initialize the snapshot service.
+func InitSnapshotService(c *capability.Capability) {
+	svc := &snapSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListSnapshot", http.MethodPost, "/snapshots/list", svc.ListSnapshot)
+	h.Add("GetSnapshot", http.MethodGet, "/snapshots/{id}", svc.GetSnapshot)
+	h.Add("AssignSnapshotToBiz", http.MethodPost, "/snapshots/assign/bizs", svc.AssignSnapshotToBiz)
+	h.Add("BatchDeleteSnapshot", http.MethodDelete, "/snapshots/batch", svc.BatchDeleteSnapshot)
+	h.Add("CreateSnapshot", http.MethodPost, "/snapshots/create", svc.CreateSnapshot)
+	h.Add("RollbackSnapshot", http.MethodPost, "/snapshots/{id}/rollback", svc.RollbackSnapshot)
+
+	// snapshot apis in biz
+	h.Add("ListBizSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/snapshots/list", svc.ListBizSnapshot)
+	h.Add("GetBizSnapshot", http.MethodGet, "/bizs/{bk_biz_id}/snapshots/{id}", svc.GetBizSnapshot)
+	h.Add("BatchDeleteBizSnapshot", http.MethodDelete, "/bizs/{bk_biz_id}/snapshots/batch", svc.BatchDeleteBizSnapshot)
+	h.Add("CreateBizSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/snapshots/create", svc.CreateBizSnapshot)
+	h.Add("RollbackBizSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/snapshots/{id}/rollback",
+		svc.RollbackBizSnapshot)
+
+	// snapshot policy apis in biz, snapshot policy is hcm side resource which only belongs to biz
+	h.Add("CreateBizSnapshotPolicy", http.MethodPost, "/bizs/{bk_biz_id}/snapshot_policies/create",
+		svc.CreateBizSnapshotPolicy)
+	h.Add("UpdateBizSnapshotPolicy", http.MethodPatch, "/bizs/{bk_biz_id}/snapshot_policies/{id}",
+		svc.UpdateBizSnapshotPolicy)
+	h.Add("ListBizSnapshotPolicy", http.MethodPost, "/bizs/{bk_biz_id}/snapshot_policies/list",
+		svc.ListBizSnapshotPolicy)
+	h.Add("BatchDeleteBizSnapshotPolicy", http.MethodDelete, "/bizs/{bk_biz_id}/snapshot_policies/batch",
+		svc.BatchDeleteBizSnapshotPolicy)
+
+	h.Load(c.WebService)
+}
+
+type snapSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
+
+// ListSnapshot list snapshot.
+func (svc *snapSvc) ListSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.listSnapshot(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizSnapshot list biz snapshot.
+func (svc *snapSvc) ListBizSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.listSnapshot(cts, handler.ListBizAuthRes)
+}
+
+func (svc *snapSvc) listSnapshot(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{},
+	error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Snapshot, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &protosnap.SnapshotListResult{Details: make([]coresnap.BaseSnapshot, 0)}, nil
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.Snapshot.ListSnapshot(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// GetSnapshot get snapshot.
+func (svc *snapSvc) GetSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.getSnapshot(cts, handler.ResValidWithAuth)
+}
+
+// GetBizSnapshot get biz snapshot.
+func (svc *snapSvc) GetBizSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.getSnapshot(cts, handler.BizValidWithAuth)
+}
+
+func (svc *snapSvc) getSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.SnapshotCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Snapshot,
+		Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return svc.client.DataService().TCloud.Snapshot.GetSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.Aws:
+		return svc.client.DataService().Aws.Snapshot.GetSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.HuaWei:
+		return svc.client.DataService().HuaWei.Snapshot.GetSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.Azure:
+		return svc.client.DataService().Azure.Snapshot.GetSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	case enumor.Gcp:
+		return svc.client.DataService().Gcp.Snapshot.GetSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", basicInfo.Vendor)
+	}
+}
+
+// AssignSnapshotToBiz assign snapshot to biz.
+func (svc *snapSvc) AssignSnapshotToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(cssnap.AssignSnapshotToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeSnapshotAssignOp(cts.Kit, req.SnapshotIDs, req.BkBizID); err != nil {
+		return nil, err
+	}
+
+	// check if all snapshots are not assigned to biz, right now assigning resource twice is not allowed
+	listReq := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: req.SnapshotIDs},
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.NotEqual.Factory(), Value: constant.UnassignedBiz},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.Snapshot.ListSnapshot(cts.Kit.Ctx, cts.Kit.Header(),
+		listReq)
+	if err != nil {
+		logs.Errorf("list snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != 0 {
+		ids := make([]string, len(result.Details))
+		for index, one := range result.Details {
+			ids[index] = one.ID
+		}
+		return nil, fmt.Errorf("snapshot(ids=%v) already assigned", ids)
+	}
+
+	// create assign audit.
+	err = svc.audit.ResBizAssignAudit(cts.Kit, enumor.SnapshotAuditResType, req.SnapshotIDs, req.BkBizID)
+	if err != nil {
+		logs.Errorf("create snapshot assign audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	update := &protosnap.SnapshotCommonInfoBatchUpdateReq{
+		IDs:     req.SnapshotIDs,
+		BkBizID: req.BkBizID,
+	}
+	if err = svc.client.DataService().Global.Snapshot.BatchUpdateSnapshotCommonInfo(cts.Kit.Ctx,
+		cts.Kit.Header(), update); err != nil {
+		logs.Errorf("batch update snapshot common info failed, req: %+v, err: %v, rid: %s", req, err,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *snapSvc) authorizeSnapshotAssignOp(kt *kit.Kit, ids []string, bizID int64) error {
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.SnapshotCloudResType,
+		IDs:          ids,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(kt.Ctx, kt.Header(), basicInfoReq)
+	if err != nil {
+		return err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		authRes = append(authRes, meta.ResourceAttribute{
+			Basic: &meta.Basic{
+				Type:       meta.Snapshot,
+				Action:     meta.Assign,
+				ResourceID: info.AccountID,
+			},
+			BizID: bizID,
+		})
+	}
+
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes...)
+}
+
+// BatchDeleteSnapshot batch delete snapshot.
+func (svc *snapSvc) BatchDeleteSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteSnapshot(cts, handler.ResValidWithAuth)
+}
+
+// BatchDeleteBizSnapshot batch delete biz snapshot.
+func (svc *snapSvc) BatchDeleteBizSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteSnapshot(cts, handler.BizValidWithAuth)
+}
+
+func (svc *snapSvc) batchDeleteSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.SnapshotCloudResType,
+		IDs:          req.IDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Snapshot,
+		Action: meta.Delete, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	// create delete audit.
+	if err = svc.audit.ResDeleteAudit(cts.Kit, enumor.SnapshotAuditResType, req.IDs); err != nil {
+		logs.Errorf("create delete audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	succeeded := make([]string, 0)
+	for _, id := range req.IDs {
+		basicInfo, exists := basicInfoMap[id]
+		if !exists {
+			return nil, errf.Newf(errf.InvalidParameter, "id %s has no corresponding vendor", id)
+		}
+
+		switch basicInfo.Vendor {
+		case enumor.TCloud:
+			err = svc.client.HCService().TCloud.Snapshot.DeleteSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.Aws:
+			err = svc.client.HCService().Aws.Snapshot.DeleteSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.HuaWei:
+			err = svc.client.HCService().HuaWei.Snapshot.DeleteSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.Azure:
+			err = svc.client.HCService().Azure.Snapshot.DeleteSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+		case enumor.Gcp:
+			err = svc.client.HCService().Gcp.Snapshot.DeleteSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+		default:
+			err = errf.Newf(errf.InvalidParameter, "no support vendor: %s", basicInfo.Vendor)
+		}
+
+		if err != nil {
+			return core.BatchOperateResult{
+				Succeeded: succeeded,
+				Failed: &core.FailedInfo{
+					ID:    id,
+					Error: err,
+				},
+			}, errf.NewFromErr(errf.PartialFailed, err)
+		}
+
+		succeeded = append(succeeded, id)
+	}
+
+	return core.BatchOperateResult{Succeeded: succeeded}, nil
+}
+
+// CreateSnapshot create snapshot.
+func (svc *snapSvc) CreateSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.createSnapshot(cts, constant.UnassignedBiz, handler.ResValidWithAuth)
+}
+
+// CreateBizSnapshot create biz snapshot.
+func (svc *snapSvc) CreateBizSnapshot(cts *rest.Contexts) (interface{}, error) {
+	bkBizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	return svc.createSnapshot(cts, bkBizID, handler.BizValidWithAuth)
+}
+
+// createSnapshot create snapshot manually, snapshot policy id is only set by snapshot policy timing job, so it is
+// cleared here to avoid manual snapshot being cleaned up by retention of snapshot policy.
+func (svc *snapSvc) createSnapshot(cts *rest.Contexts, bizID int64, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	accountID, err := common.ExtractAccountID(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// validate authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Snapshot,
+		Action: meta.Create, BasicInfo: common.GetCloudResourceBasicInfo(accountID, bizID)})
+	if err != nil {
+		return nil, err
+	}
+
+	baseInfo, err := svc.client.DataService().Global.Cloud.GetResourceBasicInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		enumor.AccountCloudResType, accountID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch baseInfo.Vendor {
+	case enumor.TCloud:
+		req := new(hcsnap.TCloudSnapshotCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		req.SnapshotPolicyID = ""
+		return svc.client.HCService().TCloud.Snapshot.CreateSnapshot(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.Aws:
+		req := new(hcsnap.AwsSnapshotCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		req.SnapshotPolicyID = ""
+		return svc.client.HCService().Aws.Snapshot.CreateSnapshot(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.HuaWei:
+		req := new(hcsnap.HuaWeiSnapshotCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		req.SnapshotPolicyID = ""
+		return svc.client.HCService().HuaWei.Snapshot.CreateSnapshot(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.Azure:
+		req := new(hcsnap.AzureSnapshotCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		req.SnapshotPolicyID = ""
+		return svc.client.HCService().Azure.Snapshot.CreateSnapshot(cts.Kit.Ctx, cts.Kit.Header(), req)
+	case enumor.Gcp:
+		req := new(hcsnap.GcpSnapshotCreateReq)
+		if err = decodeCreateReq(cts, req); err != nil {
+			return nil, err
+		}
+		req.BkBizID = bizID
+		req.SnapshotPolicyID = ""
+		return svc.client.HCService().Gcp.Snapshot.CreateSnapshot(cts.Kit.Ctx, cts.Kit.Header(), req)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "no support vendor: %s", baseInfo.Vendor)
+	}
+}
+
+type createReq interface {
+	Validate() error
+}
+
+func decodeCreateReq(cts *rest.Contexts, req createReq) error {
+	if err := cts.DecodeInto(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil
+}
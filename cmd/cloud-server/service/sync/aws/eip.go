@@ -22,14 +22,17 @@ package aws
 import (
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
 
 // SyncEip ...
-func SyncEip(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string) error {
+func SyncEip(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string, report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("aws account[%s] sync eip start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -39,14 +42,21 @@ func SyncEip(kt *kit.Kit, service *hcservice.Client, accountID string, regions [
 	}()
 
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.Aws, region); err != nil {
+			return err
+		}
+
 		req := &sync.AwsSyncReq{
 			AccountID: accountID,
 			Region:    region,
+			DryRun:    report.IsDryRun(),
 		}
-		if err := service.Aws.Eip.SyncEip(kt.Ctx, kt.Header(), req); err != nil {
+		result, err := service.Aws.Eip.SyncEip(kt.Ctx, kt.Header(), req)
+		if err != nil {
 			logs.Errorf("sync aws eip failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 			return err
 		}
+		report.Merge(result)
 	}
 
 	return nil
@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
+	"hcm/pkg/api/hc-service/sync"
+	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// SyncPrivateImage sync private images of the account.
+func SyncPrivateImage(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string,
+	report *syncreport.Report) error {
+
+	start := time.Now()
+	logs.V(3).Infof("aws account[%s] sync private image start, time: %v, rid: %s", accountID, start, kt.Rid)
+
+	defer func() {
+		logs.V(3).Infof("aws account[%s] sync private image end, cost: %v, rid: %s", accountID,
+			time.Since(start), kt.Rid)
+	}()
+
+	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.Aws, region); err != nil {
+			return err
+		}
+
+		req := &sync.AwsSyncReq{
+			AccountID: accountID,
+			Region:    region,
+			DryRun:    report.IsDryRun(),
+		}
+		result, err := service.Aws.Image.SyncPrivateImage(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("sync aws private image failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+			return err
+		}
+		report.Merge(result)
+	}
+
+	return nil
+}
@@ -23,8 +23,10 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
 	protoimage "hcm/pkg/api/hc-service/image"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
@@ -44,6 +46,11 @@ func SyncAwsImage(kt *kit.Kit, hcCli *hcservice.Client, accountID string, region
 	var firstErr error
 	var wg gosync.WaitGroup
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.Aws, region); err != nil {
+			firstErr = err
+			break
+		}
+
 		pipeline <- true
 		wg.Add(1)
 
@@ -22,14 +22,18 @@ package aws
 import (
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
 
 // SyncSubnet ...
-func SyncSubnet(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string) error {
+func SyncSubnet(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string,
+	report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("aws account[%s] sync subnet start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -39,14 +43,21 @@ func SyncSubnet(kt *kit.Kit, service *hcservice.Client, accountID string, region
 	}()
 
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.Aws, region); err != nil {
+			return err
+		}
+
 		req := &sync.AwsSyncReq{
 			AccountID: accountID,
 			Region:    region,
+			DryRun:    report.IsDryRun(),
 		}
-		if err := service.Aws.Subnet.SyncSubnet(kt.Ctx, kt.Header(), req); err != nil {
+		result, err := service.Aws.Subnet.SyncSubnet(kt.Ctx, kt.Header(), req)
+		if err != nil {
 			logs.Errorf("sync aws subnet failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 			return err
 		}
+		report.Merge(result)
 	}
 
 	return nil
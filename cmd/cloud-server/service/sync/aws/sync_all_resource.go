@@ -22,8 +22,12 @@ package aws
 import (
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/registry"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
+	"hcm/cmd/cloud-server/service/sync/synctask"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -36,6 +40,10 @@ type SyncAllResourceOption struct {
 	AccountID string `json:"account_id" validate:"required"`
 	// SyncPublicResource 是否同步公共资源
 	SyncPublicResource bool `json:"sync_public_resource" validate:"omitempty"`
+	// Trigger 同步任务触发方式，为空时默认为定时同步
+	Trigger enumor.SyncTaskTrigger `json:"trigger" validate:"omitempty"`
+	// DryRunReport 演练同步报告，为演练同步时只对比资源差异不写入db，也不同步公共资源、不记录同步任务
+	DryRunReport *syncreport.Report `json:"-" validate:"-"`
 }
 
 // Validate SyncAllResourceOption
@@ -43,6 +51,20 @@ func (opt *SyncAllResourceOption) Validate() error {
 	return validator.Validate.Struct(opt)
 }
 
+func init() {
+	registry.Register(enumor.Aws, registry.Syncer{
+		SyncAllResource: func(kt *kit.Kit, cliSet *client.ClientSet, opt *registry.SyncAllResourceOption) error {
+			return SyncAllResource(kt, cliSet, &SyncAllResourceOption{
+				AccountID:          opt.AccountID,
+				SyncPublicResource: opt.SyncPublicResource,
+				Trigger:            opt.Trigger,
+				DryRunReport:       opt.DryRunReport,
+			})
+		},
+		SyncChangeEvent: SyncChangeEvent,
+	})
+}
+
 // SyncAllResource sync resource.
 func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet, opt *SyncAllResourceOption) error {
 
@@ -54,8 +76,13 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet, opt *SyncAllResource
 	logs.V(3).Infof("aws account[%s] sync all resource start, time: %v, opt: %v, rid: %s", opt.AccountID,
 		start, opt, kt.Rid)
 
+	tracker := synctask.NewTracker(kt, cliSet.DataService(), enumor.Aws, opt.AccountID, opt.Trigger,
+		opt.DryRunReport)
+
 	var hitErr error
 	defer func() {
+		tracker.Finish(kt, hitErr)
+
 		if hitErr != nil {
 			logs.Errorf("%s: sync all resource failed, err: %v, account: %s, rid: %s", constant.AccountSyncFailed,
 				hitErr, opt.AccountID, kt.Rid)
@@ -67,7 +94,7 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet, opt *SyncAllResource
 			time.Since(start), opt, kt.Rid)
 	}()
 
-	if opt.SyncPublicResource {
+	if opt.SyncPublicResource && !opt.DryRunReport.IsDryRun() {
 		syncOpt := &SyncPublicResourceOption{
 			AccountID: opt.AccountID,
 		}
@@ -82,31 +109,108 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet, opt *SyncAllResource
 		return hitErr
 	}
 
-	if hitErr = SyncDisk(kt, cliSet.HCService(), opt.AccountID, regions); hitErr != nil {
+	hitErr = tracker.Run(kt, enumor.DiskCloudResType, func(report *syncreport.Report) error {
+		return SyncDisk(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.SnapshotCloudResType, func(report *syncreport.Report) error {
+		return SyncSnapshot(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.ImageCloudResType, func(report *syncreport.Report) error {
+		return SyncPrivateImage(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
 		return hitErr
 	}
 
-	if hitErr = SyncVpc(kt, cliSet.HCService(), opt.AccountID, regions); hitErr != nil {
+	hitErr = tracker.Run(kt, enumor.KeyPairCloudResType, func(report *syncreport.Report) error {
+		return SyncKeyPair(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
 		return hitErr
 	}
 
-	if hitErr = SyncSubnet(kt, cliSet.HCService(), opt.AccountID, regions); hitErr != nil {
+	hitErr = tracker.Run(kt, enumor.BucketCloudResType, func(report *syncreport.Report) error {
+		return SyncBucket(kt, cliSet.HCService(), opt.AccountID, report)
+	})
+	if hitErr != nil {
 		return hitErr
 	}
 
-	if hitErr = SyncEip(kt, cliSet.HCService(), opt.AccountID, regions); hitErr != nil {
+	hitErr = tracker.Run(kt, enumor.VpcCloudResType, func(report *syncreport.Report) error {
+		return SyncVpc(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
 		return hitErr
 	}
 
-	if hitErr = SyncSG(kt, cliSet.HCService(), opt.AccountID, regions); hitErr != nil {
+	hitErr = tracker.Run(kt, enumor.SubnetCloudResType, func(report *syncreport.Report) error {
+		return SyncSubnet(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
 		return hitErr
 	}
 
-	if hitErr = SyncCvm(kt, cliSet.HCService(), opt.AccountID, regions); hitErr != nil {
+	hitErr = tracker.Run(kt, enumor.EipCloudResType, func(report *syncreport.Report) error {
+		return SyncEip(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.LoadBalancerCloudResType, func(report *syncreport.Report) error {
+		return SyncLoadBalancer(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
 		return hitErr
 	}
 
-	if hitErr = SyncRouteTable(kt, cliSet.HCService(), opt.AccountID, regions); hitErr != nil {
+	hitErr = tracker.Run(kt, enumor.NatGatewayCloudResType, func(report *syncreport.Report) error {
+		return SyncNatGateway(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.SecurityGroupCloudResType, func(report *syncreport.Report) error {
+		return SyncSG(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.CvmCloudResType, func(report *syncreport.Report) error {
+		return SyncCvm(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.RouteTableCloudResType, func(report *syncreport.Report) error {
+		return SyncRouteTable(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.VpcPeeringCloudResType, func(report *syncreport.Report) error {
+		return SyncVpcPeering(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.ResourceTagCloudResType, func(report *syncreport.Report) error {
+		return SyncResourceTag(kt, cliSet.HCService(), opt.AccountID, report)
+	})
+	if hitErr != nil {
 		return hitErr
 	}
 
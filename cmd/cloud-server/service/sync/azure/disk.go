@@ -23,14 +23,18 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
 
 // SyncDisk ...
-func SyncDisk(kt *kit.Kit, service *hcservice.Client, accountID string, resourceGroupNames []string) error {
+func SyncDisk(kt *kit.Kit, service *hcservice.Client, accountID string, resourceGroupNames []string,
+	report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("azure account[%s] sync disk start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -43,6 +47,11 @@ func SyncDisk(kt *kit.Kit, service *hcservice.Client, accountID string, resource
 	var firstErr error
 	var wg gosync.WaitGroup
 	for _, name := range resourceGroupNames {
+		if err := scheduler.Wait(kt, enumor.Azure, ""); err != nil {
+			firstErr = err
+			break
+		}
+
 		pipeline <- true
 		wg.Add(1)
 
@@ -55,13 +64,15 @@ func SyncDisk(kt *kit.Kit, service *hcservice.Client, accountID string, resource
 			req := &sync.AzureSyncReq{
 				AccountID:         accountID,
 				ResourceGroupName: name,
+				DryRun:            report.IsDryRun(),
 			}
-			err := service.Azure.Disk.SyncDisk(kt.Ctx, kt.Header(), req)
+			result, err := service.Azure.Disk.SyncDisk(kt.Ctx, kt.Header(), req)
 			if firstErr == nil && err != nil {
 				logs.Errorf("sync azure disk failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 				firstErr = err
 				return
 			}
+			report.Merge(result)
 		}(name)
 	}
 
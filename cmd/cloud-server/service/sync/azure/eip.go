@@ -23,14 +23,18 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
 
 // SyncEip ...
-func SyncEip(kt *kit.Kit, service *hcservice.Client, accountID string, resourceGroupNames []string) error {
+func SyncEip(kt *kit.Kit, service *hcservice.Client, accountID string, resourceGroupNames []string,
+	report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("azure account[%s] sync eip start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -43,6 +47,11 @@ func SyncEip(kt *kit.Kit, service *hcservice.Client, accountID string, resourceG
 	var firstErr error
 	var wg gosync.WaitGroup
 	for _, name := range resourceGroupNames {
+		if err := scheduler.Wait(kt, enumor.Azure, ""); err != nil {
+			firstErr = err
+			break
+		}
+
 		pipeline <- true
 		wg.Add(1)
 
@@ -55,13 +64,15 @@ func SyncEip(kt *kit.Kit, service *hcservice.Client, accountID string, resourceG
 			req := &sync.AzureSyncReq{
 				AccountID:         accountID,
 				ResourceGroupName: name,
+				DryRun:            report.IsDryRun(),
 			}
-			err := service.Azure.Eip.SyncEip(kt.Ctx, kt.Header(), req)
+			result, err := service.Azure.Eip.SyncEip(kt.Ctx, kt.Header(), req)
 			if firstErr == nil && err != nil {
 				logs.Errorf("sync azure eip failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 				firstErr = err
 				return
 			}
+			report.Merge(result)
 		}(name)
 	}
 
@@ -23,6 +23,8 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
 	"hcm/pkg/criteria/enumor"
@@ -31,7 +33,8 @@ import (
 )
 
 // SyncNetworkInterface 网络接口同步
-func SyncNetworkInterface(kt *kit.Kit, service *hcservice.Client, accountID string, resourceGroupNames []string) error {
+func SyncNetworkInterface(kt *kit.Kit, service *hcservice.Client, accountID string, resourceGroupNames []string,
+	report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("[%s] account[%s] sync network interface start, time: %v, rid: %s",
@@ -46,6 +49,11 @@ func SyncNetworkInterface(kt *kit.Kit, service *hcservice.Client, accountID stri
 	var firstErr error
 	var wg gosync.WaitGroup
 	for _, name := range resourceGroupNames {
+		if err := scheduler.Wait(kt, enumor.Azure, ""); err != nil {
+			firstErr = err
+			break
+		}
+
 		pipeline <- true
 		wg.Add(1)
 
@@ -58,14 +66,16 @@ func SyncNetworkInterface(kt *kit.Kit, service *hcservice.Client, accountID stri
 			req := &sync.AzureSyncReq{
 				AccountID:         accountID,
 				ResourceGroupName: name,
+				DryRun:            report.IsDryRun(),
 			}
-			err := service.Azure.NetworkInterface.SyncNetworkInterface(kt.Ctx, kt.Header(), req)
+			result, err := service.Azure.NetworkInterface.SyncNetworkInterface(kt.Ctx, kt.Header(), req)
 			if firstErr == nil && err != nil {
 				logs.Errorf("[%s] sync network interface failed, req: %v, err: %v, rid: %s",
 					enumor.Azure, req, err, kt.Rid)
 				firstErr = err
 				return
 			}
+			report.Merge(result)
 		}(name)
 	}
 
@@ -22,8 +22,10 @@ package azure
 import (
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
 	protoimage "hcm/pkg/api/hc-service/image"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
@@ -40,6 +42,10 @@ func SyncAzureImage(kt *kit.Kit, hcCli *hcservice.Client, accountID string, regi
 	}()
 
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.Azure, ""); err != nil {
+			return err
+		}
+
 		for _, name := range resGroupNames {
 			req := &protoimage.AzureImageSyncReq{
 				AccountID:         accountID,
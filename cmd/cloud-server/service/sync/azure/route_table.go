@@ -23,6 +23,8 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
 	"hcm/pkg/criteria/enumor"
@@ -31,7 +33,8 @@ import (
 )
 
 // SyncRouteTable 同步路由表
-func SyncRouteTable(kt *kit.Kit, service *hcservice.Client, accountID string, resourceGroupNames []string) error {
+func SyncRouteTable(kt *kit.Kit, service *hcservice.Client, accountID string, resourceGroupNames []string,
+	report *syncreport.Report) error {
 	start := time.Now()
 	logs.V(3).Infof("[%s] account[%s] sync route table start, time: %v, rid: %s",
 		enumor.Azure, accountID, start, kt.Rid)
@@ -45,6 +48,11 @@ func SyncRouteTable(kt *kit.Kit, service *hcservice.Client, accountID string, re
 	var firstErr error
 	var wg gosync.WaitGroup
 	for _, name := range resourceGroupNames {
+		if err := scheduler.Wait(kt, enumor.Azure, ""); err != nil {
+			firstErr = err
+			break
+		}
+
 		pipeline <- true
 		wg.Add(1)
 
@@ -57,13 +65,15 @@ func SyncRouteTable(kt *kit.Kit, service *hcservice.Client, accountID string, re
 			req := &sync.AzureSyncReq{
 				AccountID:         accountID,
 				ResourceGroupName: name,
+				DryRun:            report.IsDryRun(),
 			}
-			err := service.Azure.RouteTable.SyncRouteTable(kt.Ctx, kt.Header(), req)
+			result, err := service.Azure.RouteTable.SyncRouteTable(kt.Ctx, kt.Header(), req)
 			if firstErr == nil && err != nil {
 				logs.Errorf("sync azure route table failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 				firstErr = err
 				return
 			}
+			report.Merge(result)
 		}(name)
 	}
 
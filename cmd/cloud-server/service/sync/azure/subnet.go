@@ -23,10 +23,13 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/hc-service/sync"
 	dataservice "hcm/pkg/client/data-service"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
@@ -34,7 +37,8 @@ import (
 
 // SyncSubnet ...
 func SyncSubnet(kt *kit.Kit, service *hcservice.Client, dataCli *dataservice.Client, accountID string,
-	resourceGroupNames []string) error {
+	resourceGroupNames []string,
+	report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("azure account[%s] sync subnet start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -80,6 +84,10 @@ func SyncSubnet(kt *kit.Kit, service *hcservice.Client, dataCli *dataservice.Cli
 			}
 
 			for _, vpc := range vpcResult.Details {
+				if err := scheduler.Wait(kt, enumor.Azure, ""); err != nil {
+					return err
+				}
+
 				pipeline <- true
 				wg.Add(1)
 
@@ -93,13 +101,15 @@ func SyncSubnet(kt *kit.Kit, service *hcservice.Client, dataCli *dataservice.Cli
 						AccountID:         accountID,
 						ResourceGroupName: name,
 						CloudVpcID:        vpcID,
+						DryRun:            report.IsDryRun(),
 					}
-					err := service.Azure.Subnet.SyncSubnet(kt.Ctx, kt.Header(), req)
+					result, err := service.Azure.Subnet.SyncSubnet(kt.Ctx, kt.Header(), req)
 					if firstErr == nil && err != nil {
 						logs.Errorf("sync azure subnet failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 						firstErr = err
 						return
 					}
+					report.Merge(result)
 				}(vpc.CloudID, name)
 			}
 
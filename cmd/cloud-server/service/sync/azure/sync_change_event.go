@@ -0,0 +1,115 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/changeevent"
+	"hcm/cmd/cloud-server/service/sync/synctask"
+	typeschangeevent "hcm/pkg/adaptor/types/change-event"
+	hcchangeevent "hcm/pkg/api/hc-service/change-event"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// SyncChangeEvent 增量同步，查询时间范围内的云资源变更事件，只同步变更事件涉及的资源。
+func SyncChangeEvent(kt *kit.Kit, cliSet *client.ClientSet, opt *changeevent.SyncOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	logs.V(3).Infof("azure account[%s] sync change event start, time: %v, opt: %v, rid: %s", opt.AccountID,
+		start, opt, kt.Rid)
+
+	defer func() {
+		logs.V(3).Infof("azure account[%s] sync change event end, cost: %v, opt: %v, rid: %s", opt.AccountID,
+			time.Since(start), opt, kt.Rid)
+	}()
+
+	req := &hcchangeevent.ListReq{
+		AccountID: opt.AccountID,
+		StartTime: opt.StartTime,
+		EndTime:   opt.EndTime,
+	}
+	events, err := cliSet.HCService().Azure.ChangeEvent.List(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list azure change event failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return err
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	tracker := synctask.NewTracker(kt, cliSet.DataService(), enumor.Azure, opt.AccountID,
+		enumor.IncrementalSyncTaskTrigger, nil)
+
+	hc := cliSet.HCService().Azure
+	groupScope := func(event typeschangeevent.ChangeEvent) string { return event.ResourceGroupName }
+	newReq := func(resGroupName string, cloudIDs []string) *sync.AzureSyncReq {
+		return &sync.AzureSyncReq{AccountID: opt.AccountID, ResourceGroupName: resGroupName, CloudIDs: cloudIDs}
+	}
+	// azure 子网同步需要指定所属vpc，子网的变更由全量同步兜底
+	rules := []changeevent.SyncRule{
+		{ResType: enumor.DiskCloudResType, Scope: groupScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.Disk.SyncDisk(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.SecurityGroupCloudResType, Scope: groupScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.SecurityGroup.SyncSecurityGroup(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.VpcCloudResType, Scope: groupScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.Vpc.SyncVpc(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.EipCloudResType, Scope: groupScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.Eip.SyncEip(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.CvmCloudResType, Scope: groupScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.Cvm.SyncCvmWithRelResource(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.RouteTableCloudResType, Scope: groupScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.RouteTable.SyncRouteTable(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.NetworkInterfaceCloudResType, Scope: groupScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.NetworkInterface.SyncNetworkInterface(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+	}
+
+	err = changeevent.Sync(kt, tracker, events, rules)
+	tracker.Finish(kt, err)
+	if err != nil {
+		logs.Errorf("azure account[%s] sync change event failed, err: %v, opt: %v, rid: %s", opt.AccountID, err,
+			opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package changeevent 云资源变更事件增量同步的公共逻辑
+package changeevent
+
+import (
+	"errors"
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/syncreport"
+	"hcm/cmd/cloud-server/service/sync/synctask"
+	typeschangeevent "hcm/pkg/adaptor/types/change-event"
+	hcsync "hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncOption 增量同步参数
+type SyncOption struct {
+	AccountID string    `json:"account_id" validate:"required"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required"`
+}
+
+// Validate SyncOption
+func (opt *SyncOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if !opt.EndTime.After(opt.StartTime) {
+		return errors.New("end_time should be after start_time")
+	}
+
+	return nil
+}
+
+// SyncFunc 同步同一同步范围（地域、可用区、资源组等）下指定云ID的资源
+type SyncFunc func(scope string, cloudIDs []string) (*hcsync.SyncResult, error)
+
+// SyncRule 资源类型的增量同步规则
+type SyncRule struct {
+	ResType enumor.CloudResourceType
+	// Scope 返回变更事件所属的同步范围，返回空时忽略该事件，由全量同步兜底
+	Scope func(event typeschangeevent.ChangeEvent) string
+	Sync  SyncFunc
+}
+
+// Sync 按同步规则的顺序同步变更事件涉及的资源，每种有变更的资源类型记录一条同步详情。
+func Sync(kt *kit.Kit, tracker *synctask.Tracker, events []typeschangeevent.ChangeEvent, rules []SyncRule) error {
+	for _, rule := range rules {
+		scopeCloudIDs := make(map[string][]string)
+		for _, event := range events {
+			if event.ResType != rule.ResType {
+				continue
+			}
+
+			scope := rule.Scope(event)
+			if len(scope) == 0 {
+				continue
+			}
+
+			scopeCloudIDs[scope] = append(scopeCloudIDs[scope], event.CloudID)
+		}
+
+		if len(scopeCloudIDs) == 0 {
+			continue
+		}
+
+		err := tracker.Run(kt, rule.ResType, func(report *syncreport.Report) error {
+			for scope, cloudIDs := range scopeCloudIDs {
+				for _, partCloudIDs := range slice.Split(slice.Unique(cloudIDs), hcsync.SyncCloudIDsMaxLimit) {
+					result, err := rule.Sync(scope, partCloudIDs)
+					if err != nil {
+						logs.Errorf("incremental sync %s failed, err: %v, scope: %s, cloudIDs: %v, rid: %s",
+							rule.ResType, err, scope, partCloudIDs, kt.Rid)
+						return err
+					}
+					report.Merge(result)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RegionScope 以变更事件的地域作为同步范围
+func RegionScope(event typeschangeevent.ChangeEvent) string {
+	return event.Region
+}
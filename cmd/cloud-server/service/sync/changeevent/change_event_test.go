@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package changeevent
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/synctask"
+	typeschangeevent "hcm/pkg/adaptor/types/change-event"
+	hcsync "hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+func TestSync(t *testing.T) {
+	events := []typeschangeevent.ChangeEvent{
+		{ResType: enumor.CvmCloudResType, CloudID: "ins-1", Region: "ap-guangzhou"},
+		{ResType: enumor.CvmCloudResType, CloudID: "ins-1", Region: "ap-guangzhou"},
+		{ResType: enumor.CvmCloudResType, CloudID: "ins-2", Region: "ap-shanghai"},
+		{ResType: enumor.VpcCloudResType, CloudID: "vpc-1", Region: "ap-guangzhou"},
+		// event without scope is ignored and left to full sync.
+		{ResType: enumor.VpcCloudResType, CloudID: "vpc-2"},
+		// resource type without rule is ignored.
+		{ResType: enumor.EipCloudResType, CloudID: "eip-1", Region: "ap-guangzhou"},
+	}
+
+	synced := make([]string, 0)
+	newRule := func(resType enumor.CloudResourceType) SyncRule {
+		return SyncRule{
+			ResType: resType,
+			Scope:   RegionScope,
+			Sync: func(scope string, cloudIDs []string) (*hcsync.SyncResult, error) {
+				sort.Strings(cloudIDs)
+				synced = append(synced, string(resType)+"/"+scope+"/"+strings.Join(cloudIDs, ","))
+				return nil, nil
+			},
+		}
+	}
+	rules := []SyncRule{newRule(enumor.VpcCloudResType), newRule(enumor.CvmCloudResType)}
+
+	if err := Sync(kit.New(), new(synctask.Tracker), events, rules); err != nil {
+		t.Fatalf("sync change events failed, err: %v", err)
+	}
+
+	// vpc is synced before cvm by rule order, cvm of different regions are synced separately.
+	if len(synced) != 3 || synced[0] != "vpc/ap-guangzhou/vpc-1" {
+		t.Fatalf("unexpected synced resources: %v", synced)
+	}
+	cvmSynced := synced[1:]
+	sort.Strings(cvmSynced)
+	if cvmSynced[0] != "cvm/ap-guangzhou/ins-1" || cvmSynced[1] != "cvm/ap-shanghai/ins-2" {
+		t.Errorf("unexpected synced cvm: %v", cvmSynced)
+	}
+}
+
+func TestSyncFailed(t *testing.T) {
+	events := []typeschangeevent.ChangeEvent{
+		{ResType: enumor.VpcCloudResType, CloudID: "vpc-1", Region: "ap-guangzhou"},
+		{ResType: enumor.CvmCloudResType, CloudID: "ins-1", Region: "ap-guangzhou"},
+	}
+
+	syncErr := errors.New("sync failed")
+	cvmSynced := false
+	rules := []SyncRule{
+		{
+			ResType: enumor.VpcCloudResType,
+			Scope:   RegionScope,
+			Sync: func(scope string, cloudIDs []string) (*hcsync.SyncResult, error) {
+				return nil, syncErr
+			},
+		},
+		{
+			ResType: enumor.CvmCloudResType,
+			Scope:   RegionScope,
+			Sync: func(scope string, cloudIDs []string) (*hcsync.SyncResult, error) {
+				cvmSynced = true
+				return nil, nil
+			},
+		},
+	}
+
+	if err := Sync(kit.New(), new(synctask.Tracker), events, rules); err != syncErr {
+		t.Errorf("sync should return the failed rule error, got: %v", err)
+	}
+	if cvmSynced {
+		t.Errorf("rules after the failed rule should not be synced")
+	}
+}
+
+func TestSyncOptionValidate(t *testing.T) {
+	now := time.Now()
+	valid := &SyncOption{AccountID: "account-1", StartTime: now.Add(-time.Hour), EndTime: now}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("valid sync option should pass, err: %v", err)
+	}
+
+	invalid := &SyncOption{AccountID: "account-1", StartTime: now, EndTime: now}
+	if err := invalid.Validate(); err == nil {
+		t.Errorf("sync option whose end time is not after start time should fail")
+	}
+}
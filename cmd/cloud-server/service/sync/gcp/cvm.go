@@ -23,14 +23,18 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
 
 // SyncCvm ...
-func SyncCvm(kt *kit.Kit, service *hcservice.Client, accountID string, regionZoneMap map[string][]string) error {
+func SyncCvm(kt *kit.Kit, service *hcservice.Client, accountID string, regionZoneMap map[string][]string,
+	report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("gcp account[%s] sync cvm start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -44,6 +48,10 @@ func SyncCvm(kt *kit.Kit, service *hcservice.Client, accountID string, regionZon
 	var wg gosync.WaitGroup
 	for region, zones := range regionZoneMap {
 		for _, zone := range zones {
+			if err := scheduler.Wait(kt, enumor.Gcp, region); err != nil {
+				return err
+			}
+
 			pipeline <- true
 			wg.Add(1)
 
@@ -57,13 +65,15 @@ func SyncCvm(kt *kit.Kit, service *hcservice.Client, accountID string, regionZon
 					AccountID: accountID,
 					Region:    region,
 					Zone:      zone,
+					DryRun:    report.IsDryRun(),
 				}
-				err := service.Gcp.Cvm.SyncCvmWithRelResource(kt.Ctx, kt.Header(), req)
+				result, err := service.Gcp.Cvm.SyncCvmWithRelResource(kt.Ctx, kt.Header(), req)
 				if firstErr == nil && err != nil {
 					logs.Errorf("sync gcp cvm failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 					firstErr = err
 					return
 				}
+				report.Merge(result)
 			}(region, zone)
 		}
 	}
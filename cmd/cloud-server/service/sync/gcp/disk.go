@@ -23,14 +23,18 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
 
 // SyncDisk ...
-func SyncDisk(kt *kit.Kit, service *hcservice.Client, accountID string, regionZoneMap map[string][]string) error {
+func SyncDisk(kt *kit.Kit, service *hcservice.Client, accountID string, regionZoneMap map[string][]string,
+	report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("gcp account[%s] sync disk start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -42,8 +46,12 @@ func SyncDisk(kt *kit.Kit, service *hcservice.Client, accountID string, regionZo
 	pipeline := make(chan bool, syncConcurrencyCount)
 	var firstErr error
 	var wg gosync.WaitGroup
-	for _, zones := range regionZoneMap {
+	for region, zones := range regionZoneMap {
 		for _, zone := range zones {
+			if err := scheduler.Wait(kt, enumor.Gcp, region); err != nil {
+				return err
+			}
+
 			pipeline <- true
 			wg.Add(1)
 
@@ -56,13 +64,15 @@ func SyncDisk(kt *kit.Kit, service *hcservice.Client, accountID string, regionZo
 				req := &sync.GcpDiskSyncReq{
 					AccountID: accountID,
 					Zone:      zone,
+					DryRun:    report.IsDryRun(),
 				}
-				err := service.Gcp.Disk.SyncDisk(kt.Ctx, kt.Header(), req)
+				result, err := service.Gcp.Disk.SyncDisk(kt.Ctx, kt.Header(), req)
 				if firstErr == nil && err != nil {
 					logs.Errorf("sync gcp disk failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 					firstErr = err
 					return
 				}
+				report.Merge(result)
 			}(zone)
 		}
 	}
@@ -23,14 +23,17 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
 
 // SyncEip ...
-func SyncEip(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string) error {
+func SyncEip(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string, report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("gcp account[%s] sync eip start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -43,6 +46,11 @@ func SyncEip(kt *kit.Kit, service *hcservice.Client, accountID string, regions [
 	var firstErr error
 	var wg gosync.WaitGroup
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.Gcp, region); err != nil {
+			firstErr = err
+			break
+		}
+
 		pipeline <- true
 		wg.Add(1)
 
@@ -55,13 +63,15 @@ func SyncEip(kt *kit.Kit, service *hcservice.Client, accountID string, regions [
 			req := &sync.GcpSyncReq{
 				AccountID: accountID,
 				Region:    region,
+				DryRun:    report.IsDryRun(),
 			}
-			err := service.Gcp.Eip.SyncEip(kt.Ctx, kt.Header(), req)
+			result, err := service.Gcp.Eip.SyncEip(kt.Ctx, kt.Header(), req)
 			if firstErr == nil && err != nil {
 				logs.Errorf("sync gcp eip failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 				firstErr = err
 				return
 			}
+			report.Merge(result)
 		}(region)
 	}
 
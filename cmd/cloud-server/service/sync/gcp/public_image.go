@@ -22,8 +22,10 @@ package gcp
 import (
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
 	protoimage "hcm/pkg/api/hc-service/image"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
@@ -39,6 +41,10 @@ func SyncGcpImage(kt *kit.Kit, hcCli *hcservice.Client, accountID string, region
 	}()
 
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.Gcp, region); err != nil {
+			return err
+		}
+
 		req := &protoimage.GcpImageSyncReq{
 			AccountID: accountID,
 			Region:    region,
@@ -23,6 +23,8 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
 	"hcm/pkg/criteria/enumor"
@@ -31,7 +33,8 @@ import (
 )
 
 // SyncRoute 同步路由表
-func SyncRoute(kt *kit.Kit, service *hcservice.Client, accountID string, regionZoneMap map[string][]string) error {
+func SyncRoute(kt *kit.Kit, service *hcservice.Client, accountID string, regionZoneMap map[string][]string,
+	report *syncreport.Report) error {
 	start := time.Now()
 	logs.V(3).Infof("[%s] account[%s] sync route table start, time: %v, rid: %s",
 		enumor.Gcp, accountID, start, kt.Rid)
@@ -44,8 +47,12 @@ func SyncRoute(kt *kit.Kit, service *hcservice.Client, accountID string, regionZ
 	pipeline := make(chan bool, syncConcurrencyCount)
 	var firstErr error
 	var wg gosync.WaitGroup
-	for _, zones := range regionZoneMap {
+	for region, zones := range regionZoneMap {
 		for _, zone := range zones {
+			if err := scheduler.Wait(kt, enumor.Gcp, region); err != nil {
+				return err
+			}
+
 			pipeline <- true
 			wg.Add(1)
 
@@ -58,14 +65,16 @@ func SyncRoute(kt *kit.Kit, service *hcservice.Client, accountID string, regionZ
 				req := &sync.GcpRouteSyncReq{
 					AccountID: accountID,
 					Zone:      zone,
+					DryRun:    report.IsDryRun(),
 				}
-				err := service.Gcp.RouteTable.SyncRoute(kt.Ctx, kt.Header(), req)
+				result, err := service.Gcp.RouteTable.SyncRoute(kt.Ctx, kt.Header(), req)
 				if firstErr == nil && err != nil {
 					logs.Errorf("[%s] account[%s] sync route failed, req: %v, err: %v, rid: %s",
 						enumor.Gcp, accountID, req, err, kt.Rid)
 					firstErr = err
 					return
 				}
+				report.Merge(result)
 			}(zone)
 		}
 	}
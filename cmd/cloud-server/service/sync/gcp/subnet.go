@@ -23,14 +23,18 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
 
 // SyncSubnet ...
-func SyncSubnet(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string) error {
+func SyncSubnet(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string,
+	report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("gcp account[%s] sync subnet start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -43,6 +47,11 @@ func SyncSubnet(kt *kit.Kit, service *hcservice.Client, accountID string, region
 	var firstErr error
 	var wg gosync.WaitGroup
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.Gcp, region); err != nil {
+			firstErr = err
+			break
+		}
+
 		pipeline <- true
 		wg.Add(1)
 
@@ -55,13 +64,15 @@ func SyncSubnet(kt *kit.Kit, service *hcservice.Client, accountID string, region
 			req := &sync.GcpSyncReq{
 				AccountID: accountID,
 				Region:    region,
+				DryRun:    report.IsDryRun(),
 			}
-			err := service.Gcp.Subnet.SyncSubnet(kt.Ctx, kt.Header(), req)
+			result, err := service.Gcp.Subnet.SyncSubnet(kt.Ctx, kt.Header(), req)
 			if firstErr == nil && err != nil {
 				logs.Errorf("sync gcp subnet failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 				firstErr = err
 				return
 			}
+			report.Merge(result)
 		}(region)
 	}
 
@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"strings"
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/changeevent"
+	"hcm/cmd/cloud-server/service/sync/synctask"
+	typeschangeevent "hcm/pkg/adaptor/types/change-event"
+	hcchangeevent "hcm/pkg/api/hc-service/change-event"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// globalScope gcp 的 vpc、防火墙规则为全局资源，不区分同步范围
+const globalScope = "global"
+
+// SyncChangeEvent 增量同步，查询时间范围内的云资源变更事件，只同步变更事件涉及的资源。
+func SyncChangeEvent(kt *kit.Kit, cliSet *client.ClientSet, opt *changeevent.SyncOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	logs.V(3).Infof("gcp account[%s] sync change event start, time: %v, opt: %v, rid: %s", opt.AccountID,
+		start, opt, kt.Rid)
+
+	defer func() {
+		logs.V(3).Infof("gcp account[%s] sync change event end, cost: %v, opt: %v, rid: %s", opt.AccountID,
+			time.Since(start), opt, kt.Rid)
+	}()
+
+	req := &hcchangeevent.ListReq{
+		AccountID: opt.AccountID,
+		StartTime: opt.StartTime,
+		EndTime:   opt.EndTime,
+	}
+	events, err := cliSet.HCService().Gcp.ChangeEvent.List(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list gcp change event failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return err
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	tracker := synctask.NewTracker(kt, cliSet.DataService(), enumor.Gcp, opt.AccountID,
+		enumor.IncrementalSyncTaskTrigger, nil)
+
+	hc := cliSet.HCService().Gcp
+	// 可用区资源以可用区作为同步范围，可用区不合法（解析不出地域）的事件由全量同步兜底
+	zoneScope := func(event typeschangeevent.ChangeEvent) string {
+		if len(event.Region) == 0 {
+			return ""
+		}
+		return event.Zone
+	}
+	globalScopeFunc := func(typeschangeevent.ChangeEvent) string { return globalScope }
+	globalReq := func(cloudIDs []string) *sync.GcpGlobalRegionResSyncReq {
+		return &sync.GcpGlobalRegionResSyncReq{AccountID: opt.AccountID, CloudIDs: cloudIDs}
+	}
+	rules := []changeevent.SyncRule{
+		{ResType: enumor.DiskCloudResType, Scope: zoneScope,
+			Sync: func(zone string, cloudIDs []string) (*sync.SyncResult, error) {
+				req := &sync.GcpDiskSyncReq{AccountID: opt.AccountID, Zone: zone, CloudIDs: cloudIDs}
+				return hc.Disk.SyncDisk(kt.Ctx, kt.Header(), req)
+			}},
+		{ResType: enumor.VpcCloudResType, Scope: globalScopeFunc,
+			Sync: func(_ string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.Vpc.SyncVpc(kt.Ctx, kt.Header(), globalReq(cloudIDs))
+			}},
+		{ResType: enumor.SubnetCloudResType, Scope: changeevent.RegionScope,
+			Sync: func(region string, cloudIDs []string) (*sync.SyncResult, error) {
+				req := &sync.GcpSyncReq{AccountID: opt.AccountID, Region: region, CloudIDs: cloudIDs}
+				return hc.Subnet.SyncSubnet(kt.Ctx, kt.Header(), req)
+			}},
+		{ResType: enumor.GcpFirewallRuleCloudResType, Scope: globalScopeFunc,
+			Sync: func(_ string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.Firewall.SyncFirewall(kt.Ctx, kt.Header(), globalReq(cloudIDs))
+			}},
+		{ResType: enumor.CvmCloudResType, Scope: zoneScope,
+			Sync: func(zone string, cloudIDs []string) (*sync.SyncResult, error) {
+				req := &sync.GcpCvmSyncReq{AccountID: opt.AccountID, Region: zone[:strings.LastIndex(zone, "-")],
+					Zone: zone, CloudIDs: cloudIDs}
+				return hc.Cvm.SyncCvmWithRelResource(kt.Ctx, kt.Header(), req)
+			}},
+	}
+
+	err = changeevent.Sync(kt, tracker, events, rules)
+	tracker.Finish(kt, err)
+	if err != nil {
+		logs.Errorf("gcp account[%s] sync change event failed, err: %v, opt: %v, rid: %s", opt.AccountID, err,
+			opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -22,6 +22,7 @@ package gcp
 import (
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
 	"hcm/pkg/kit"
@@ -29,7 +30,7 @@ import (
 )
 
 // SyncVpc ...
-func SyncVpc(kt *kit.Kit, service *hcservice.Client, accountID string) error {
+func SyncVpc(kt *kit.Kit, service *hcservice.Client, accountID string, report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("gcp account[%s] sync vpc start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -40,11 +41,14 @@ func SyncVpc(kt *kit.Kit, service *hcservice.Client, accountID string) error {
 
 	req := &sync.GcpGlobalRegionResSyncReq{
 		AccountID: accountID,
+		DryRun:    report.IsDryRun(),
 	}
-	if err := service.Gcp.Vpc.SyncVpc(kt.Ctx, kt.Header(), req); err != nil {
+	result, err := service.Gcp.Vpc.SyncVpc(kt.Ctx, kt.Header(), req)
+	if err != nil {
 		logs.Errorf("sync gcp vpc failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 		return err
 	}
+	report.Merge(result)
 
 	return nil
 }
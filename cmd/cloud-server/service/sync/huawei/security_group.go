@@ -23,16 +23,20 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/adaptor/huawei"
 	"hcm/pkg/api/hc-service/sync"
 	dataservice "hcm/pkg/client/data-service"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
 
 // SyncSG ...
-func SyncSG(kt *kit.Kit, service *hcservice.Client, dataCli *dataservice.Client, accountID string) error {
+func SyncSG(kt *kit.Kit, service *hcservice.Client, dataCli *dataservice.Client, accountID string,
+	report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("huawei account[%s] sync sg start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -51,6 +55,11 @@ func SyncSG(kt *kit.Kit, service *hcservice.Client, dataCli *dataservice.Client,
 	var firstErr error
 	var wg gosync.WaitGroup
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.HuaWei, region); err != nil {
+			firstErr = err
+			break
+		}
+
 		pipeline <- true
 		wg.Add(1)
 
@@ -63,13 +72,15 @@ func SyncSG(kt *kit.Kit, service *hcservice.Client, dataCli *dataservice.Client,
 			req := &sync.HuaWeiSyncReq{
 				AccountID: accountID,
 				Region:    region,
+				DryRun:    report.IsDryRun(),
 			}
-			err = service.HuaWei.SecurityGroup.SyncSecurityGroup(kt.Ctx, kt.Header(), req)
+			result, err := service.HuaWei.SecurityGroup.SyncSecurityGroup(kt.Ctx, kt.Header(), req)
 			if firstErr == nil && Error(err) != nil {
 				logs.Errorf("sync huawei security group failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 				firstErr = err
 				return
 			}
+			report.Merge(result)
 		}(region)
 	}
 
@@ -23,18 +23,22 @@ import (
 	gosync "sync"
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/adaptor/huawei"
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/hc-service/sync"
 	dataservice "hcm/pkg/client/data-service"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
 )
 
 // SyncSubnet ...
-func SyncSubnet(kt *kit.Kit, hcCli *hcservice.Client, dataCli *dataservice.Client, accountID string) error {
+func SyncSubnet(kt *kit.Kit, hcCli *hcservice.Client, dataCli *dataservice.Client, accountID string,
+	report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("huawei account[%s] sync subnet start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -87,6 +91,10 @@ func SyncSubnet(kt *kit.Kit, hcCli *hcservice.Client, dataCli *dataservice.Clien
 			}
 
 			for _, vpc := range vpcResult.Details {
+				if err := scheduler.Wait(kt, enumor.HuaWei, region); err != nil {
+					return err
+				}
+
 				pipeline <- true
 				wg.Add(1)
 
@@ -100,13 +108,15 @@ func SyncSubnet(kt *kit.Kit, hcCli *hcservice.Client, dataCli *dataservice.Clien
 						AccountID:  accountID,
 						Region:     region,
 						CloudVpcID: cloudVpcID,
+						DryRun:     report.IsDryRun(),
 					}
-					err = hcCli.HuaWei.Subnet.SyncSubnet(kt.Ctx, kt.Header(), req)
+					result, err := hcCli.HuaWei.Subnet.SyncSubnet(kt.Ctx, kt.Header(), req)
 					if firstErr == nil && Error(err) != nil {
 						logs.Errorf("sync huawei subnet failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 						firstErr = err
 						return
 					}
+					report.Merge(result)
 				}(region, vpc.CloudID)
 
 			}
@@ -0,0 +1,225 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sync
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/changeevent"
+	"hcm/cmd/cloud-server/service/sync/lock"
+	"hcm/cmd/cloud-server/service/sync/registry"
+	typeschangeevent "hcm/pkg/adaptor/types/change-event"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+)
+
+// changeEventDelay 云审计事件从发生到可查询存在延迟，增量同步只处理该延迟之前的事件，避免遗漏
+const changeEventDelay = 15 * time.Minute
+
+// CloudResourceIncrSync 定时增量同步云资源，根据云审计中的资源变更事件只同步有变更的资源，全量同步作为兜底。
+func CloudResourceIncrSync(intervalMin time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("cloud resource incremental sync enable, syncIntervalMin: %v", intervalMin)
+
+	for {
+		time.Sleep(intervalMin)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := kit.New()
+		kt.User = constant.SyncTimingUserKey
+		kt.AppCode = constant.SyncTimingAppCodeKey
+
+		start := time.Now()
+		logs.Infof("cloud resource incremental sync start, time: %v, rid: %s", start, kt.Rid)
+
+		waitGroup := new(sync.WaitGroup)
+
+		// 只有支持增量同步的厂商进行增量同步
+		vendors := make([]enumor.Vendor, 0)
+		for _, vendor := range registry.Vendors() {
+			if syncer, err := registry.Get(vendor); err == nil && syncer.SyncChangeEvent != nil {
+				vendors = append(vendors, vendor)
+			}
+		}
+		waitGroup.Add(len(vendors))
+		for _, vendor := range vendors {
+			go func(vendor enumor.Vendor) {
+				allAccountIncrSync(kt, cliSet, vendor)
+				waitGroup.Done()
+			}(vendor)
+		}
+
+		waitGroup.Wait()
+
+		logs.Infof("cloud resource incremental sync end, cost: %v, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+// allAccountIncrSync vendor all account incremental sync.
+func allAccountIncrSync(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor) {
+	listReq := &protocloud.AccountListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "type", Op: filter.Equal.Factory(), Value: enumor.ResourceAccount},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: core.DefaultMaxPageLimit,
+		},
+	}
+	start := uint32(0)
+	for {
+		listReq.Page.Start = start
+		accounts, err := listAccountWithRetry(kt, cliSet.DataService(), listReq)
+		if err != nil {
+			logs.Errorf("list account failed, err: %v, rid: %s", err, kt.Rid)
+			break
+		}
+
+		for _, one := range accounts {
+			if err = accountIncrSync(kt, cliSet, one); err != nil {
+				logs.Errorf("%s incremental sync account resource failed, err: %v, accountID: %s, rid: %s", vendor,
+					err, one.ID, kt.Rid)
+			}
+		}
+
+		if len(accounts) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		start += uint32(core.DefaultMaxPageLimit)
+	}
+}
+
+// accountIncrSync 增量同步账号从水位到当前（减去云审计延迟）的资源变更，单次最多同步 MaxTimeRange 的变更事件，
+// 同步成功后推进水位，账号首次增量同步时只初始化水位，之前的变更由全量同步处理。
+func accountIncrSync(kt *kit.Kit, cliSet *client.ClientSet, account *corecloud.BaseAccount) error {
+	syncer, err := registry.Get(account.Vendor)
+	if err != nil || syncer.SyncChangeEvent == nil {
+		logs.Errorf("%s vendor not support incremental sync, rid: %s", account.Vendor, kt.Rid)
+		return nil
+	}
+
+	end := time.Now().Add(-changeEventDelay).Truncate(time.Second)
+
+	watermark, exist, err := getSyncWatermark(kt, cliSet, account.ID)
+	if err != nil {
+		return err
+	}
+
+	if !exist {
+		return setSyncWatermark(kt, cliSet, account, end)
+	}
+
+	if !end.After(watermark) {
+		return nil
+	}
+
+	if end.Sub(watermark) > typeschangeevent.MaxTimeRange {
+		end = watermark.Add(typeschangeevent.MaxTimeRange)
+	}
+
+	// 与全量同步、手动同步共用同步锁，账号正在同步时跳过，水位不推进，下次增量同步时处理
+	leaseID, err := lock.Manager.TryLock(lock.Key(account.ID))
+	if err != nil {
+		if err == lock.ErrLockFailed {
+			logs.V(3).Infof("account %s is syncing, skip incremental sync, rid: %s", account.ID, kt.Rid)
+			return nil
+		}
+
+		return err
+	}
+
+	defer func() {
+		if err := lock.Manager.UnLock(leaseID); err != nil {
+			// 锁已经超时释放了
+			if strings.Contains(err.Error(), "requested lease not found") {
+				return
+			}
+
+			logs.Errorf("unlock account incremental sync lock failed, err: %v, accountID: %s, leaseID: %d, rid: %s",
+				err, account.ID, leaseID, kt.Rid)
+		}
+	}()
+
+	opt := &changeevent.SyncOption{AccountID: account.ID, StartTime: watermark, EndTime: end}
+	if err = syncer.SyncChangeEvent(kt, cliSet, opt); err != nil {
+		return err
+	}
+
+	return setSyncWatermark(kt, cliSet, account, end)
+}
+
+func getSyncWatermark(kt *kit.Kit, cliSet *client.ClientSet, accountID string) (time.Time, bool, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("account_id", accountID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := cliSet.DataService().Global.SyncTask.ListSyncWatermark(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list sync watermark failed, err: %v, accountID: %s, rid: %s", err, accountID, kt.Rid)
+		return time.Time{}, false, err
+	}
+
+	if len(result.Details) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	watermark, err := time.Parse(constant.TimeStdFormat, result.Details[0].Watermark)
+	if err != nil {
+		logs.Errorf("parse sync watermark failed, err: %v, watermark: %s, rid: %s", err,
+			result.Details[0].Watermark, kt.Rid)
+		return time.Time{}, false, err
+	}
+
+	return watermark, true, nil
+}
+
+func setSyncWatermark(kt *kit.Kit, cliSet *client.ClientSet, account *corecloud.BaseAccount,
+	watermark time.Time) error {
+
+	req := &protocloud.SyncWatermarkSetReq{
+		Vendor:    account.Vendor,
+		AccountID: account.ID,
+		Watermark: watermark.Format(constant.TimeStdFormat),
+	}
+	if err := cliSet.DataService().Global.SyncTask.SetSyncWatermark(kt.Ctx, kt.Header(), req); err != nil {
+		logs.Errorf("set sync watermark failed, err: %v, req: %+v, rid: %s", err, req, kt.Rid)
+		return err
+	}
+
+	return nil
+}
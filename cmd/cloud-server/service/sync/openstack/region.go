@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	gosync "sync"
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
+	protoaccount "hcm/pkg/api/hc-service/account"
+	"hcm/pkg/api/hc-service/region"
+	"hcm/pkg/api/hc-service/sync"
+	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListRegion list regions of account from keystone, openstack regions are different between deployments.
+func ListRegion(kt *kit.Kit, service *hcservice.Client, accountID string) ([]string, error) {
+	req := &protoaccount.ListOpenStackAccountRegionReq{AccountID: accountID}
+	result, err := service.OpenStack.Account.ListRegion(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("sync openstack list region failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+		return nil, err
+	}
+
+	regions := make([]string, 0, len(result))
+	for _, one := range result {
+		regions = append(regions, one.RegionID)
+	}
+
+	return regions, nil
+}
+
+// SyncRegion sync regions of account to db.
+func SyncRegion(kt *kit.Kit, service *hcservice.Client, accountID string) error {
+	start := time.Now()
+	logs.V(3).Infof("openstack account[%s] sync region start, time: %v, rid: %s", accountID, start, kt.Rid)
+
+	defer func() {
+		logs.V(3).Infof("openstack account[%s] sync region end, cost: %v, rid: %s", accountID, time.Since(start),
+			kt.Rid)
+	}()
+
+	req := &region.OpenStackRegionSyncReq{AccountID: accountID}
+	if err := service.OpenStack.Region.SyncRegion(kt.Ctx, kt.Header(), req); err != nil {
+		logs.Errorf("sync openstack region failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// syncRegionResource sync resource of all regions concurrently.
+func syncRegionResource(kt *kit.Kit, accountID string, regions []string, resType enumor.CloudResourceType,
+	report *syncreport.Report, syncFunc func(req *sync.OpenStackSyncReq) (*sync.SyncResult, error)) error {
+
+	start := time.Now()
+	logs.V(3).Infof("openstack account[%s] sync %s start, time: %v, rid: %s", accountID, resType, start, kt.Rid)
+
+	defer func() {
+		logs.V(3).Infof("openstack account[%s] sync %s end, cost: %v, rid: %s", accountID, resType,
+			time.Since(start), kt.Rid)
+	}()
+
+	pipeline := make(chan bool, syncConcurrencyCount)
+	var firstErr error
+	var errLock gosync.Mutex
+	var wg gosync.WaitGroup
+	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.OpenStack, region); err != nil {
+			firstErr = err
+			break
+		}
+
+		pipeline <- true
+		wg.Add(1)
+
+		go func(region string) {
+			defer func() {
+				wg.Done()
+				<-pipeline
+			}()
+
+			req := &sync.OpenStackSyncReq{
+				AccountID: accountID,
+				Region:    region,
+				DryRun:    report.IsDryRun(),
+			}
+			result, err := syncFunc(req)
+			if err != nil {
+				logs.Errorf("sync openstack %s failed, err: %v, req: %v, rid: %s", resType, err, req, kt.Rid)
+				errLock.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				errLock.Unlock()
+				return
+			}
+			report.Merge(result)
+		}(region)
+	}
+
+	wg.Wait()
+
+	return firstErr
+}
@@ -0,0 +1,154 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package openstack openstack 云资源同步，openstack 地域来自账号的 keystone 服务目录，地域和可用区按账号入库，没有公共资源。
+package openstack
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/registry"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
+	"hcm/cmd/cloud-server/service/sync/synctask"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+var syncConcurrencyCount = 10
+
+// SyncAllResourceOption ...
+type SyncAllResourceOption struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// Trigger 同步任务触发方式，为空时默认为定时同步
+	Trigger enumor.SyncTaskTrigger `json:"trigger" validate:"omitempty"`
+	// DryRunReport 演练同步报告，为演练同步时只对比资源差异不写入db，也不记录同步任务
+	DryRunReport *syncreport.Report `json:"-" validate:"-"`
+}
+
+// Validate SyncAllResourceOption
+func (opt *SyncAllResourceOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+func init() {
+	registry.Register(enumor.OpenStack, registry.Syncer{
+		SyncAllResource: func(kt *kit.Kit, cliSet *client.ClientSet, opt *registry.SyncAllResourceOption) error {
+			return SyncAllResource(kt, cliSet, &SyncAllResourceOption{
+				AccountID:    opt.AccountID,
+				Trigger:      opt.Trigger,
+				DryRunReport: opt.DryRunReport,
+			})
+		},
+		// openstack 没有统一的云审计服务，不支持增量同步
+		SyncChangeEvent:       nil,
+		WithoutPublicResource: true,
+	})
+}
+
+// SyncAllResource sync resource.
+func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet, opt *SyncAllResourceOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	logs.V(3).Infof("openstack account[%s] sync all resource start, time: %v, opt: %v, rid: %s", opt.AccountID,
+		start, opt, kt.Rid)
+
+	tracker := synctask.NewTracker(kt, cliSet.DataService(), enumor.OpenStack, opt.AccountID, opt.Trigger,
+		opt.DryRunReport)
+
+	var hitErr error
+	defer func() {
+		tracker.Finish(kt, hitErr)
+
+		if hitErr != nil {
+			logs.Errorf("%s: sync all resource failed, err: %v, account: %s, rid: %s", constant.AccountSyncFailed,
+				hitErr, opt.AccountID, kt.Rid)
+			return
+		}
+
+		logs.V(3).Infof("openstack account[%s] sync all resource end, cost: %v, opt: %v, rid: %s", opt.AccountID,
+			time.Since(start), opt, kt.Rid)
+	}()
+
+	regions, hitErr := ListRegion(kt, cliSet.HCService(), opt.AccountID)
+	if hitErr != nil {
+		return hitErr
+	}
+
+	// 地域和可用区不参与演练对比，只在正式同步时入库
+	if !opt.DryRunReport.IsDryRun() {
+		if hitErr = SyncRegion(kt, cliSet.HCService(), opt.AccountID); hitErr != nil {
+			return hitErr
+		}
+
+		if hitErr = SyncZone(kt, cliSet.HCService(), opt.AccountID, regions); hitErr != nil {
+			return hitErr
+		}
+	}
+
+	hitErr = tracker.Run(kt, enumor.VpcCloudResType, func(report *syncreport.Report) error {
+		return SyncVpc(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.SubnetCloudResType, func(report *syncreport.Report) error {
+		return SyncSubnet(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.SecurityGroupCloudResType, func(report *syncreport.Report) error {
+		return SyncSG(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.DiskCloudResType, func(report *syncreport.Report) error {
+		return SyncDisk(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.CvmCloudResType, func(report *syncreport.Report) error {
+		return SyncCvm(kt, cliSet.HCService(), opt.AccountID, regions, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	hitErr = tracker.Run(kt, enumor.ResourceTagCloudResType, func(report *syncreport.Report) error {
+		return SyncResourceTag(kt, cliSet.HCService(), opt.AccountID, report)
+	})
+	if hitErr != nil {
+		return hitErr
+	}
+
+	return nil
+}
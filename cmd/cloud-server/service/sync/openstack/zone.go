@@ -0,0 +1,40 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/api/hc-service/zone"
+	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+// SyncZone sync zones of the account regions, zones are not compared in dry run.
+func SyncZone(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string) error {
+	return syncRegionResource(kt, accountID, regions, enumor.ZoneCloudResType, nil,
+		func(req *sync.OpenStackSyncReq) (*sync.SyncResult, error) {
+			zoneReq := &zone.OpenStackZoneSyncReq{
+				AccountID: req.AccountID,
+				Region:    req.Region,
+			}
+			return nil, service.OpenStack.Zone.SyncZone(kt.Ctx, kt.Header(), zoneReq)
+		})
+}
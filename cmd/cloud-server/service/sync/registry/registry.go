@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package registry 云资源同步的厂商注册表，各厂商的同步包在 init 中注册自身的同步方法，
+// 定时同步、增量同步和手动同步通过注册表查找厂商，新增厂商不需要修改这些流程。
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"hcm/cmd/cloud-server/service/sync/changeevent"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+// SyncAllResourceOption 同步账号下所有资源的参数，由厂商转换为自身的同步参数。
+type SyncAllResourceOption struct {
+	AccountID string
+	// SyncPublicResource 是否同步公共资源
+	SyncPublicResource bool
+	// Trigger 同步任务触发方式
+	Trigger enumor.SyncTaskTrigger
+	// DryRunReport 演练同步报告，不为空时进行演练同步
+	DryRunReport *syncreport.Report
+}
+
+// Syncer 厂商的同步方法。
+type Syncer struct {
+	// SyncAllResource 全量同步账号下的所有资源，必填。
+	SyncAllResource func(kt *kit.Kit, cliSet *client.ClientSet, opt *SyncAllResourceOption) error
+	// SyncChangeEvent 根据云上资源变更事件增量同步，厂商不支持增量同步时为空。
+	SyncChangeEvent func(kt *kit.Kit, cliSet *client.ClientSet, opt *changeevent.SyncOption) error
+	// WithoutPublicResource 厂商没有需要入库的地域、可用区、公共镜像等公共资源。
+	WithoutPublicResource bool
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = make(map[enumor.Vendor]Syncer)
+)
+
+// Register register vendor syncer, it is called in init function of the vendor sync package,
+// register the same vendor twice will panic.
+func Register(vendor enumor.Vendor, syncer Syncer) {
+	if len(vendor) == 0 || syncer.SyncAllResource == nil {
+		panic("register syncer vendor and sync all resource func is required")
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if _, exist := registry[vendor]; exist {
+		panic(fmt.Sprintf("vendor: %s syncer is already registered", vendor))
+	}
+	registry[vendor] = syncer
+}
+
+// Get returns syncer of the vendor.
+func Get(vendor enumor.Vendor) (Syncer, error) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	syncer, exist := registry[vendor]
+	if !exist {
+		return Syncer{}, fmt.Errorf("vendor: %s not support", vendor)
+	}
+
+	return syncer, nil
+}
+
+// Vendors returns all registered vendors in order.
+func Vendors() []enumor.Vendor {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	vendors := make([]enumor.Vendor, 0, len(registry))
+	for vendor := range registry {
+		vendors = append(vendors, vendor)
+	}
+	sort.Slice(vendors, func(i, j int) bool { return vendors[i] < vendors[j] })
+
+	return vendors
+}
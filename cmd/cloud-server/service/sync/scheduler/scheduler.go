@@ -0,0 +1,289 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package scheduler 账号资源同步调度，每个云厂商一个按优先级执行的协程池，并按云厂商、地域限制同步接口调用频率
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/lock"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/concurrence"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// TimerPriority 定时同步优先级
+	TimerPriority = 0
+	// ManualPriority 手动同步优先级，手动同步先于排队中的定时同步执行
+	ManualPriority = 10
+)
+
+var (
+	// ErrTaskExists 账号已有排队或执行中的同步任务
+	ErrTaskExists = errors.New("account sync task already exists")
+	// ErrInBackoff 账号连续同步失败，处于退避期内，暂停定时同步
+	ErrInBackoff = errors.New("account sync is in failure backoff")
+)
+
+// Manager sync scheduler manager.
+var Manager *Scheduler
+
+// InitScheduler init sync scheduler manager.
+func InitScheduler(opt cc.SyncScheduler) {
+	Manager = NewScheduler(opt)
+}
+
+// Task 账号同步任务
+type Task struct {
+	Vendor    enumor.Vendor
+	AccountID string
+	Priority  int
+	// Run 执行同步，执行前调度器会获取账号同步锁，返回错误时记录账号失败次数，用于失败退避
+	Run func() error
+	// Cancel 任务不再执行时调用，如排队中的任务被更高优先级的任务替换、执行前获取账号同步锁失败，用于释放任务提交方的资源，
+	// 可以为空
+	Cancel func()
+}
+
+// Scheduler 账号同步调度器，同一账号同时只会有一个排队或执行中的任务，定时同步任务会跳过处于失败退避期的账号。
+type Scheduler struct {
+	opt cc.SyncScheduler
+
+	lock     sync.Mutex
+	pools    map[enumor.Vendor]*concurrence.PriorityPool
+	limiters map[string]*rate.Limiter
+	accounts map[string]*entry
+	failures map[string]*failure
+}
+
+// entry 账号排队或执行中的同步任务，排队中的任务被替换后，协程池中可能有多个指向同一 entry 的任务，只有先出队的会执行。
+type entry struct {
+	task    *Task
+	started bool
+}
+
+// failure 账号连续同步失败记录
+type failure struct {
+	count   uint
+	retryAt time.Time
+}
+
+// NewScheduler new sync scheduler.
+func NewScheduler(opt cc.SyncScheduler) *Scheduler {
+	return &Scheduler{
+		opt:      opt,
+		pools:    make(map[enumor.Vendor]*concurrence.PriorityPool),
+		limiters: make(map[string]*rate.Limiter),
+		accounts: make(map[string]*entry),
+		failures: make(map[string]*failure),
+	}
+}
+
+// Submit 提交账号同步任务，账号已有执行中或优先级不低于当前任务的排队任务时返回 ErrTaskExists，排队中的低优先级任务
+// 会被替换为当前任务，定时同步的账号处于退避期时返回 ErrInBackoff。
+func (s *Scheduler) Submit(task *Task) error {
+	if task == nil || task.Run == nil {
+		return errors.New("sync task is invalid")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if one, exist := s.accounts[task.AccountID]; exist {
+		if one.started || task.Priority <= one.task.Priority {
+			return ErrTaskExists
+		}
+
+		// 按新任务的优先级重新排队，原任务在协程池中的排队项出队时发现任务已执行，直接跳过
+		if err := s.getPool(task.Vendor).Submit(task.Priority, func() { s.run(one) }); err != nil {
+			return err
+		}
+
+		replaced := one.task
+		one.task = task
+		if replaced.Cancel != nil {
+			replaced.Cancel()
+		}
+
+		return nil
+	}
+
+	if task.Priority <= TimerPriority {
+		if one, exist := s.failures[task.AccountID]; exist && time.Now().Before(one.retryAt) {
+			return fmt.Errorf("%w, retry at: %s", ErrInBackoff, one.retryAt.Format(time.RFC3339))
+		}
+	}
+
+	one := &entry{task: task}
+	if err := s.getPool(task.Vendor).Submit(task.Priority, func() { s.run(one) }); err != nil {
+		return err
+	}
+	s.accounts[task.AccountID] = one
+
+	return nil
+}
+
+// getPool get priority pool of vendor, need to be called with lock held.
+func (s *Scheduler) getPool(vendor enumor.Vendor) *concurrence.PriorityPool {
+	pool, exist := s.pools[vendor]
+	if !exist {
+		pool = concurrence.NewPriorityPool(int(s.opt.WorkerNum))
+		s.pools[vendor] = pool
+	}
+
+	return pool
+}
+
+func (s *Scheduler) run(one *entry) {
+	s.lock.Lock()
+	if one.started {
+		s.lock.Unlock()
+		return
+	}
+	one.started = true
+	task := one.task
+	s.lock.Unlock()
+
+	// 账号同步锁在任务执行时获取，避免排队期间占用锁，导致增量同步、演练同步及其他实例的同步长时间无法执行
+	unlock, err := lockAccount(task.AccountID)
+	if err != nil {
+		if task.Cancel != nil {
+			task.Cancel()
+		}
+
+		if errors.Is(err, lock.ErrLockFailed) {
+			logs.Infof("%s account %s is syncing, skip sync task, priority: %d", task.Vendor, task.AccountID,
+				task.Priority)
+			s.release(task.AccountID)
+			return
+		}
+
+		logs.Errorf("%s account %s lock sync failed, err: %v", task.Vendor, task.AccountID, err)
+		s.finish(task.AccountID, err)
+		return
+	}
+	defer unlock()
+
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("sync task panic: %v", p)
+			logs.Errorf("%s account %s sync task panic, err: %v", task.Vendor, task.AccountID, p)
+		}
+
+		s.finish(task.AccountID, err)
+	}()
+
+	err = task.Run()
+}
+
+// release 任务未执行同步就结束，只清除账号的任务记录，不影响失败退避。
+func (s *Scheduler) release(accountID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.accounts, accountID)
+}
+
+// finish 任务结束，成功时清除账号失败记录，失败时按连续失败次数翻倍退避时间。
+func (s *Scheduler) finish(accountID string, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.accounts, accountID)
+
+	if err == nil {
+		delete(s.failures, accountID)
+		return
+	}
+
+	one, exist := s.failures[accountID]
+	if !exist {
+		one = new(failure)
+		s.failures[accountID] = one
+	}
+	one.count++
+
+	backoff := time.Duration(s.opt.FailureBackoffMin) * time.Minute
+	maxBackoff := time.Duration(s.opt.MaxFailureBackoffMin) * time.Minute
+	for i := uint(1); i < one.count && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	one.retryAt = time.Now().Add(backoff)
+}
+
+// lockAccount 获取账号同步锁，与增量同步、演练同步及其他 cloud-server 实例的同步互斥，锁管理器未初始化时不加锁。
+func lockAccount(accountID string) (func(), error) {
+	if lock.Manager == nil {
+		return func() {}, nil
+	}
+
+	leaseID, err := lock.Manager.TryLock(lock.Key(accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		if err := lock.Manager.UnLock(leaseID); err != nil {
+			// 锁已经超时释放了
+			if strings.Contains(err.Error(), "requested lease not found") {
+				return
+			}
+
+			logs.Errorf("unlock account sync lock failed, err: %v, accountID: %s, leaseID: %d", err, accountID,
+				leaseID)
+		}
+	}, nil
+}
+
+// Wait 等待云厂商地域的同步接口调用配额，地域为空时使用云厂商维度的配额。
+func (s *Scheduler) Wait(kt *kit.Kit, vendor enumor.Vendor, region string) error {
+	key := string(vendor) + "/" + region
+
+	s.lock.Lock()
+	limiter, exist := s.limiters[key]
+	if !exist {
+		limiter = rate.NewLimiter(rate.Limit(s.opt.RegionLimiter.QPS), int(s.opt.RegionLimiter.Burst))
+		s.limiters[key] = limiter
+	}
+	s.lock.Unlock()
+
+	return limiter.Wait(kt.Ctx)
+}
+
+// Wait 等待云厂商地域的同步接口调用配额，调度器未初始化时不限流。
+func Wait(kt *kit.Kit, vendor enumor.Vendor, region string) error {
+	if Manager == nil {
+		return nil
+	}
+
+	return Manager.Wait(kt, vendor, region)
+}
@@ -0,0 +1,201 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+func newTestScheduler() *Scheduler {
+	return NewScheduler(cc.SyncScheduler{
+		WorkerNum:            1,
+		RegionLimiter:        cc.Limiter{QPS: 1, Burst: 1},
+		FailureBackoffMin:    1,
+		MaxFailureBackoffMin: 10,
+	})
+}
+
+// canceledRequestKit returns a kit whose context is canceled, like the request kit after the request is returned.
+func canceledRequestKit() *kit.Kit {
+	kt := kit.New()
+	kt.User = "admin"
+	kt.AppCode = "hcm"
+	ctx, cancel := context.WithCancel(kt.Ctx)
+	cancel()
+	kt.Ctx = ctx
+	return kt
+}
+
+// submitAndWait submit a manual sync task that waits the region quota, returns the error of the task.
+func submitAndWait(t *testing.T, s *Scheduler, accountID string, kt *kit.Kit) error {
+	result := make(chan error, 1)
+	task := &Task{
+		Vendor:    enumor.TCloud,
+		AccountID: accountID,
+		Priority:  ManualPriority,
+		Run: func() error {
+			err := s.Wait(kt, enumor.TCloud, "ap-guangzhou")
+			result <- err
+			return err
+		},
+	}
+	if err := s.Submit(task); err != nil {
+		t.Fatalf("submit sync task failed, err: %v", err)
+	}
+
+	var err error
+	select {
+	case err = <-result:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("sync task is not executed")
+	}
+
+	// wait the task to be finished.
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		s.lock.Lock()
+		_, running := s.accounts[accountID]
+		s.lock.Unlock()
+		if !running {
+			return err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("sync task is not finished")
+	return err
+}
+
+func TestSubmitWithCanceledRequest(t *testing.T) {
+	s := newTestScheduler()
+	reqKt := canceledRequestKit()
+
+	// the request context is canceled once the request is returned, the task can not use it to wait quota.
+	if err := submitAndWait(t, s, "account-1", reqKt); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait with canceled request context should fail, err: %v", err)
+	}
+
+	taskKt := reqKt.Detach()
+	if taskKt.Rid != reqKt.Rid || taskKt.User != reqKt.User || taskKt.AppCode != reqKt.AppCode {
+		t.Errorf("detached kit should keep the request info, got: %+v", taskKt)
+	}
+	if err := submitAndWait(t, s, "account-2", taskKt); err != nil {
+		t.Errorf("task with detached kit should still run, err: %v", err)
+	}
+
+	// the succeeded account is not in backoff, while the failed account is.
+	timerTask := func(accountID string) *Task {
+		return &Task{Vendor: enumor.TCloud, AccountID: accountID, Priority: TimerPriority,
+			Run: func() error { return nil }}
+	}
+	if err := s.Submit(timerTask("account-2")); err != nil {
+		t.Errorf("succeeded account should accept timer sync, err: %v", err)
+	}
+	if err := s.Submit(timerTask("account-1")); !errors.Is(err, ErrInBackoff) {
+		t.Errorf("failed account should be in backoff, err: %v", err)
+	}
+}
+
+func TestSubmitTaskExists(t *testing.T) {
+	s := newTestScheduler()
+	release := make(chan struct{})
+	defer close(release)
+
+	task := &Task{Vendor: enumor.Aws, AccountID: "account-1", Priority: TimerPriority,
+		Run: func() error {
+			<-release
+			return nil
+		}}
+	if err := s.Submit(task); err != nil {
+		t.Fatalf("submit sync task failed, err: %v", err)
+	}
+	if err := s.Submit(task); !errors.Is(err, ErrTaskExists) {
+		t.Errorf("submit the same account twice should return task exists, err: %v", err)
+	}
+}
+
+func TestSubmitReplaceQueuedTask(t *testing.T) {
+	s := newTestScheduler()
+
+	// occupy the only worker, so that tasks submitted later are queued.
+	started, release := make(chan struct{}), make(chan struct{})
+	blocker := &Task{Vendor: enumor.Aws, AccountID: "account-0", Priority: TimerPriority,
+		Run: func() error {
+			close(started)
+			<-release
+			return nil
+		}}
+	if err := s.Submit(blocker); err != nil {
+		t.Fatalf("submit blocker task failed, err: %v", err)
+	}
+	<-started
+
+	timerRun, canceled := make(chan struct{}, 1), make(chan struct{}, 1)
+	timerTask := &Task{Vendor: enumor.Aws, AccountID: "account-1", Priority: TimerPriority,
+		Run: func() error {
+			timerRun <- struct{}{}
+			return nil
+		},
+		Cancel: func() { canceled <- struct{}{} },
+	}
+	if err := s.Submit(timerTask); err != nil {
+		t.Fatalf("submit timer task failed, err: %v", err)
+	}
+
+	manualRun := make(chan struct{}, 1)
+	manualTask := &Task{Vendor: enumor.Aws, AccountID: "account-1", Priority: ManualPriority,
+		Run: func() error {
+			manualRun <- struct{}{}
+			return nil
+		}}
+	if err := s.Submit(manualTask); err != nil {
+		t.Fatalf("manual task should replace the queued timer task, err: %v", err)
+	}
+
+	select {
+	case <-canceled:
+	default:
+		t.Errorf("replaced timer task should be canceled")
+	}
+
+	if err := s.Submit(manualTask); !errors.Is(err, ErrTaskExists) {
+		t.Errorf("task with the same priority should not replace the queued task, err: %v", err)
+	}
+
+	close(release)
+
+	select {
+	case <-manualRun:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("manual task is not executed")
+	}
+
+	select {
+	case <-timerRun:
+		t.Errorf("replaced timer task should not be executed")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package syncreport ...
+package syncreport
+
+import (
+	"sync"
+
+	hcsync "hcm/pkg/api/hc-service/sync"
+)
+
+// Report 同步报告，汇总各资源同步请求返回的同步结果，并发安全。nil 表示不需要汇总同步结果。
+type Report struct {
+	dryRun bool
+	lock   sync.Mutex
+	result *hcsync.SyncResult
+}
+
+// NewReport new sync report, dryRun 为 true 时进行演练同步，只对比资源差异不写入db。
+func NewReport(dryRun bool) *Report {
+	return &Report{
+		dryRun: dryRun,
+		result: &hcsync.SyncResult{Details: make([]hcsync.SyncResultDetail, 0)},
+	}
+}
+
+// IsDryRun 是否为演练同步。
+func (r *Report) IsDryRun() bool {
+	return r != nil && r.dryRun
+}
+
+// Merge 合并资源同步请求返回的同步结果。
+func (r *Report) Merge(result *hcsync.SyncResult) {
+	if r == nil || result == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.result.Merge(result)
+}
+
+// Result 获取同步结果。
+func (r *Report) Result() *hcsync.SyncResult {
+	if r == nil {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.result
+}
@@ -0,0 +1,162 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package synctask ...
+package synctask
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/syncreport"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// maxReasonLength 同步失败原因最大长度
+const maxReasonLength = 1024
+
+// Tracker 账号资源同步任务记录器，记录同步任务及各资源类型的同步状态、变更数量、失败原因。
+// 记录同步任务失败不影响资源同步，演练同步不记录同步任务。
+type Tracker struct {
+	dataCli      *dataservice.Client
+	vendor       enumor.Vendor
+	accountID    string
+	taskID       string
+	dryRunReport *syncreport.Report
+	changeCount  int64
+}
+
+// NewTracker 创建同步任务记录器，并记录同步任务开始。
+func NewTracker(kt *kit.Kit, dataCli *dataservice.Client, vendor enumor.Vendor, accountID string,
+	trigger enumor.SyncTaskTrigger, dryRunReport *syncreport.Report) *Tracker {
+
+	tracker := &Tracker{
+		dataCli:      dataCli,
+		vendor:       vendor,
+		accountID:    accountID,
+		dryRunReport: dryRunReport,
+	}
+
+	if dryRunReport.IsDryRun() {
+		return tracker
+	}
+
+	if len(trigger) == 0 {
+		trigger = enumor.TimerSyncTaskTrigger
+	}
+
+	req := &protocloud.SyncTaskCreateReq{
+		Vendor:      vendor,
+		AccountID:   accountID,
+		TriggerType: trigger,
+		StartAt:     time.Now().Format(constant.TimeStdFormat),
+	}
+	result, err := dataCli.Global.SyncTask.CreateSyncTask(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("create sync task failed, err: %v, vendor: %s, account: %s, rid: %s", err, vendor,
+			accountID, kt.Rid)
+		return tracker
+	}
+	tracker.taskID = result.ID
+
+	return tracker
+}
+
+// Run 执行一种资源类型的同步，并记录该资源类型的同步状态及变更数量。
+func (t *Tracker) Run(kt *kit.Kit, resType enumor.CloudResourceType,
+	syncFunc func(report *syncreport.Report) error) error {
+
+	if len(t.taskID) == 0 {
+		return syncFunc(t.dryRunReport)
+	}
+
+	detailID := t.createDetail(kt, resType)
+
+	report := syncreport.NewReport(false)
+	err := syncFunc(report)
+	changeCount := int64(report.Result().ChangeCount())
+	t.changeCount += changeCount
+
+	if len(detailID) != 0 {
+		req := newUpdateReq(err, changeCount)
+		if updateErr := t.dataCli.Global.SyncTask.UpdateSyncDetail(kt.Ctx, kt.Header(), detailID,
+			req); updateErr != nil {
+			logs.Errorf("update sync detail failed, err: %v, id: %s, rid: %s", updateErr, detailID, kt.Rid)
+		}
+	}
+
+	return err
+}
+
+// Finish 记录同步任务结束，err 为同步失败原因。
+func (t *Tracker) Finish(kt *kit.Kit, err error) {
+	if len(t.taskID) == 0 {
+		return
+	}
+
+	req := newUpdateReq(err, t.changeCount)
+	if updateErr := t.dataCli.Global.SyncTask.UpdateSyncTask(kt.Ctx, kt.Header(), t.taskID, req); updateErr != nil {
+		logs.Errorf("update sync task failed, err: %v, id: %s, rid: %s", updateErr, t.taskID, kt.Rid)
+	}
+}
+
+func (t *Tracker) createDetail(kt *kit.Kit, resType enumor.CloudResourceType) string {
+	req := &protocloud.SyncDetailBatchCreateReq{
+		Details: []protocloud.SyncDetailCreate{
+			{
+				TaskID:    t.taskID,
+				Vendor:    t.vendor,
+				AccountID: t.accountID,
+				ResType:   resType,
+				StartAt:   time.Now().Format(constant.TimeStdFormat),
+			},
+		},
+	}
+	result, err := t.dataCli.Global.SyncTask.BatchCreateSyncDetail(kt.Ctx, kt.Header(), req)
+	if err != nil || len(result.IDs) != 1 {
+		logs.Errorf("create sync detail failed, err: %v, task: %s, res type: %s, rid: %s", err, t.taskID,
+			resType, kt.Rid)
+		return ""
+	}
+
+	return result.IDs[0]
+}
+
+func newUpdateReq(err error, changeCount int64) *protocloud.SyncTaskUpdateReq {
+	req := &protocloud.SyncTaskUpdateReq{
+		Status:      enumor.SuccessSyncTaskStatus,
+		ChangeCount: changeCount,
+		EndAt:       time.Now().Format(constant.TimeStdFormat),
+	}
+
+	if err != nil {
+		req.Status = enumor.FailedSyncTaskStatus
+		reason := []rune(err.Error())
+		if len(reason) > maxReasonLength {
+			reason = reason[:maxReasonLength]
+		}
+		req.Reason = string(reason)
+	}
+
+	return req
+}
@@ -0,0 +1,203 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package synctask
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"hcm/cmd/cloud-server/service/sync/syncreport"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	hcsync "hcm/pkg/api/hc-service/sync"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest/client"
+)
+
+type fakeDiscovery struct {
+	server string
+}
+
+func (d fakeDiscovery) GetServers() ([]string, error) {
+	return []string{d.server}, nil
+}
+
+// fakeDataService records sync task and sync detail requests sent to data-service.
+type fakeDataService struct {
+	lock          sync.Mutex
+	createFailed  bool
+	createTasks   []protocloud.SyncTaskCreateReq
+	createDetails []protocloud.SyncDetailBatchCreateReq
+	updateTasks   map[string]protocloud.SyncTaskUpdateReq
+	updateDetails map[string]protocloud.SyncTaskUpdateReq
+}
+
+func (f *fakeDataService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	path := r.URL.Path
+	decoder := json.NewDecoder(r.Body)
+	switch {
+	case strings.HasSuffix(path, "/sync_tasks/create"):
+		if f.createFailed {
+			_, _ = w.Write([]byte(`{"code":2000000,"message":"internal error"}`))
+			return
+		}
+		req := protocloud.SyncTaskCreateReq{}
+		_ = decoder.Decode(&req)
+		f.createTasks = append(f.createTasks, req)
+		_, _ = w.Write([]byte(`{"code":0,"data":{"id":"task-1"}}`))
+
+	case strings.HasSuffix(path, "/sync_details/batch/create"):
+		req := protocloud.SyncDetailBatchCreateReq{}
+		_ = decoder.Decode(&req)
+		f.createDetails = append(f.createDetails, req)
+		id := string(req.Details[0].ResType)
+		_, _ = w.Write([]byte(`{"code":0,"data":{"ids":["` + id + `"]}}`))
+
+	case strings.Contains(path, "/sync_details/"):
+		req := protocloud.SyncTaskUpdateReq{}
+		_ = decoder.Decode(&req)
+		f.updateDetails[path[strings.LastIndex(path, "/")+1:]] = req
+		_, _ = w.Write([]byte(`{"code":0}`))
+
+	case strings.Contains(path, "/sync_tasks/"):
+		req := protocloud.SyncTaskUpdateReq{}
+		_ = decoder.Decode(&req)
+		f.updateTasks[path[strings.LastIndex(path, "/")+1:]] = req
+		_, _ = w.Write([]byte(`{"code":0}`))
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestTracker(t *testing.T, fake *fakeDataService, trigger enumor.SyncTaskTrigger,
+	report *syncreport.Report) *Tracker {
+
+	fake.updateTasks = make(map[string]protocloud.SyncTaskUpdateReq)
+	fake.updateDetails = make(map[string]protocloud.SyncTaskUpdateReq)
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	dataCli := dataservice.NewClient(&client.Capability{Client: server.Client(),
+		Discover: fakeDiscovery{server: server.URL}}, "v1")
+	return NewTracker(kit.New(), dataCli, enumor.TCloud, "account-1", trigger, report)
+}
+
+func syncChanges(cloudIDs ...string) func(report *syncreport.Report) error {
+	return func(report *syncreport.Report) error {
+		report.Merge(&hcsync.SyncResult{Details: []hcsync.SyncResultDetail{{ResType: enumor.VpcCloudResType,
+			AddCloudIDs: cloudIDs}}})
+		return nil
+	}
+}
+
+func TestTrackerStatus(t *testing.T) {
+	fake := new(fakeDataService)
+	tracker := newTestTracker(t, fake, "", nil)
+	kt := kit.New()
+
+	if err := tracker.Run(kt, enumor.VpcCloudResType, syncChanges("vpc-1", "vpc-2")); err != nil {
+		t.Fatalf("run vpc sync failed, err: %v", err)
+	}
+
+	syncErr := errors.New(strings.Repeat("失败", maxReasonLength))
+	err := tracker.Run(kt, enumor.SubnetCloudResType, func(report *syncreport.Report) error {
+		return syncErr
+	})
+	if err != syncErr {
+		t.Errorf("run should return sync error, got: %v", err)
+	}
+	tracker.Finish(kt, err)
+
+	if len(fake.createTasks) != 1 || fake.createTasks[0].TriggerType != enumor.TimerSyncTaskTrigger {
+		t.Errorf("sync task should be created with timer trigger, got: %+v", fake.createTasks)
+	}
+	if len(fake.createDetails) != 2 {
+		t.Errorf("expect 2 sync details created, got: %+v", fake.createDetails)
+	}
+
+	vpc := fake.updateDetails[string(enumor.VpcCloudResType)]
+	if vpc.Status != enumor.SuccessSyncTaskStatus || vpc.ChangeCount != 2 || vpc.Reason != "" {
+		t.Errorf("vpc sync detail should be success with 2 changes, got: %+v", vpc)
+	}
+
+	subnet := fake.updateDetails[string(enumor.SubnetCloudResType)]
+	if subnet.Status != enumor.FailedSyncTaskStatus || subnet.ChangeCount != 0 {
+		t.Errorf("subnet sync detail should be failed, got: %+v", subnet)
+	}
+	if utf8.RuneCountInString(subnet.Reason) != maxReasonLength || !utf8.ValidString(subnet.Reason) {
+		t.Errorf("failed reason should be truncated to %d runes, got: %d", maxReasonLength,
+			utf8.RuneCountInString(subnet.Reason))
+	}
+
+	task := fake.updateTasks["task-1"]
+	if task.Status != enumor.FailedSyncTaskStatus || task.ChangeCount != 2 {
+		t.Errorf("sync task should be failed with 2 changes, got: %+v", task)
+	}
+}
+
+func TestTrackerDryRun(t *testing.T) {
+	fake := new(fakeDataService)
+	report := syncreport.NewReport(true)
+	tracker := newTestTracker(t, fake, enumor.ManualSyncTaskTrigger, report)
+	kt := kit.New()
+
+	if err := tracker.Run(kt, enumor.VpcCloudResType, syncChanges("vpc-1")); err != nil {
+		t.Fatalf("run vpc sync failed, err: %v", err)
+	}
+	tracker.Finish(kt, nil)
+
+	if len(fake.createTasks) != 0 || len(fake.createDetails) != 0 || len(fake.updateTasks) != 0 {
+		t.Errorf("dry run should not record sync task")
+	}
+	if report.Result().ChangeCount() != 1 {
+		t.Errorf("dry run changes should be merged into dry run report, got: %+v", report.Result())
+	}
+}
+
+func TestTrackerCreateTaskFailed(t *testing.T) {
+	fake := &fakeDataService{createFailed: true}
+	tracker := newTestTracker(t, fake, enumor.ManualSyncTaskTrigger, nil)
+	kt := kit.New()
+
+	executed := false
+	err := tracker.Run(kt, enumor.VpcCloudResType, func(report *syncreport.Report) error {
+		executed = true
+		return nil
+	})
+	tracker.Finish(kt, err)
+
+	if !executed || err != nil {
+		t.Errorf("sync should still run when create sync task failed, executed: %v, err: %v", executed, err)
+	}
+	if len(fake.createDetails) != 0 || len(fake.updateTasks) != 0 {
+		t.Errorf("sync detail should not be recorded without sync task")
+	}
+}
@@ -22,14 +22,17 @@ package tcloud
 import (
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
 
 // SyncDisk ...
-func SyncDisk(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string) error {
+func SyncDisk(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string, report *syncreport.Report) error {
 
 	start := time.Now()
 	logs.V(3).Infof("tcloud account[%s] sync disk start, time: %v, rid: %s", accountID, start, kt.Rid)
@@ -39,14 +42,21 @@ func SyncDisk(kt *kit.Kit, service *hcservice.Client, accountID string, regions
 	}()
 
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.TCloud, region); err != nil {
+			return err
+		}
+
 		req := &sync.TCloudSyncReq{
 			AccountID: accountID,
 			Region:    region,
+			DryRun:    report.IsDryRun(),
 		}
-		if err := service.TCloud.Disk.SyncDisk(kt.Ctx, kt.Header(), req); err != nil {
+		result, err := service.TCloud.Disk.SyncDisk(kt.Ctx, kt.Header(), req)
+		if err != nil {
 			logs.Errorf("sync tcloud disk failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
 			return err
 		}
+		report.Merge(result)
 	}
 
 	return nil
@@ -22,8 +22,10 @@ package tcloud
 import (
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
 	protoimage "hcm/pkg/api/hc-service/image"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
@@ -39,6 +41,10 @@ func SyncTCloudImage(kt *kit.Kit, hcCli *hcservice.Client, accountID string, reg
 	}()
 
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.TCloud, region); err != nil {
+			return err
+		}
+
 		req := &protoimage.TCloudImageSyncReq{
 			AccountID: accountID,
 			Region:    region,
@@ -22,6 +22,8 @@ package tcloud
 import (
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/syncreport"
 	"hcm/pkg/api/hc-service/sync"
 	hcservice "hcm/pkg/client/hc-service"
 	"hcm/pkg/criteria/enumor"
@@ -30,7 +32,8 @@ import (
 )
 
 // SyncRouteTable 同步路由表
-func SyncRouteTable(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string) error {
+func SyncRouteTable(kt *kit.Kit, service *hcservice.Client, accountID string, regions []string,
+	report *syncreport.Report) error {
 	start := time.Now()
 	logs.V(3).Infof("[%s] account[%s] sync route table start, time: %v, rid: %s",
 		enumor.TCloud, accountID, start, kt.Rid)
@@ -41,15 +44,22 @@ func SyncRouteTable(kt *kit.Kit, service *hcservice.Client, accountID string, re
 	}()
 
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.TCloud, region); err != nil {
+			return err
+		}
+
 		req := &sync.TCloudSyncReq{
 			AccountID: accountID,
 			Region:    region,
+			DryRun:    report.IsDryRun(),
 		}
-		if err := service.TCloud.RouteTable.SyncRouteTable(kt.Ctx, kt.Header(), req); err != nil {
+		result, err := service.TCloud.RouteTable.SyncRouteTable(kt.Ctx, kt.Header(), req)
+		if err != nil {
 			logs.Errorf("[%s] account[%s] sync route table failed, req: %v, err: %v, rid: %s",
 				enumor.TCloud, accountID, req, err, kt.Rid)
 			return err
 		}
+		report.Merge(result)
 	}
 
 	return nil
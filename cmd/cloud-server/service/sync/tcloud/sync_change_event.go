@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/changeevent"
+	"hcm/cmd/cloud-server/service/sync/scheduler"
+	"hcm/cmd/cloud-server/service/sync/synctask"
+	typeschangeevent "hcm/pkg/adaptor/types/change-event"
+	hcchangeevent "hcm/pkg/api/hc-service/change-event"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// SyncChangeEvent 增量同步，查询时间范围内的云资源变更事件，只同步变更事件涉及的资源。
+func SyncChangeEvent(kt *kit.Kit, cliSet *client.ClientSet, opt *changeevent.SyncOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	logs.V(3).Infof("tcloud account[%s] sync change event start, time: %v, opt: %v, rid: %s", opt.AccountID,
+		start, opt, kt.Rid)
+
+	defer func() {
+		logs.V(3).Infof("tcloud account[%s] sync change event end, cost: %v, opt: %v, rid: %s", opt.AccountID,
+			time.Since(start), opt, kt.Rid)
+	}()
+
+	regions, err := ListRegion(kt, cliSet.DataService())
+	if err != nil {
+		return err
+	}
+
+	events := make([]typeschangeevent.ChangeEvent, 0)
+	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.TCloud, region); err != nil {
+			return err
+		}
+
+		req := &hcchangeevent.ListReq{
+			AccountID: opt.AccountID,
+			Region:    region,
+			StartTime: opt.StartTime,
+			EndTime:   opt.EndTime,
+		}
+		list, err := cliSet.HCService().TCloud.ChangeEvent.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list tcloud change event failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+			return err
+		}
+		events = append(events, list...)
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	tracker := synctask.NewTracker(kt, cliSet.DataService(), enumor.TCloud, opt.AccountID,
+		enumor.IncrementalSyncTaskTrigger, nil)
+
+	hc := cliSet.HCService().TCloud
+	newReq := func(scope string, cloudIDs []string) *sync.TCloudSyncReq {
+		return &sync.TCloudSyncReq{AccountID: opt.AccountID, Region: scope, CloudIDs: cloudIDs}
+	}
+	rules := []changeevent.SyncRule{
+		{ResType: enumor.DiskCloudResType, Scope: changeevent.RegionScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.Disk.SyncDisk(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.VpcCloudResType, Scope: changeevent.RegionScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.Vpc.SyncVpc(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.SubnetCloudResType, Scope: changeevent.RegionScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.Subnet.SyncSubnet(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.EipCloudResType, Scope: changeevent.RegionScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.Eip.SyncEip(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.SecurityGroupCloudResType, Scope: changeevent.RegionScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.SecurityGroup.SyncSecurityGroup(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.CvmCloudResType, Scope: changeevent.RegionScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.Cvm.SyncCvmWithRelResource(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+		{ResType: enumor.RouteTableCloudResType, Scope: changeevent.RegionScope,
+			Sync: func(scope string, cloudIDs []string) (*sync.SyncResult, error) {
+				return hc.RouteTable.SyncRouteTable(kt.Ctx, kt.Header(), newReq(scope, cloudIDs))
+			}},
+	}
+
+	err = changeevent.Sync(kt, tracker, events, rules)
+	tracker.Finish(kt, err)
+	if err != nil {
+		logs.Errorf("tcloud account[%s] sync change event failed, err: %v, opt: %v, rid: %s", opt.AccountID, err,
+			opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -22,8 +22,10 @@ package tcloud
 import (
 	"time"
 
+	"hcm/cmd/cloud-server/service/sync/scheduler"
 	"hcm/pkg/api/hc-service/zone"
 	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 )
@@ -39,6 +41,10 @@ func SyncZone(kt *kit.Kit, hcCli *hcservice.Client, accountID string, regions []
 	}()
 
 	for _, region := range regions {
+		if err := scheduler.Wait(kt, enumor.TCloud, region); err != nil {
+			return err
+		}
+
 		syncReq := &zone.TCloudZoneSyncReq{
 			AccountID: accountID,
 			Region:    region,
@@ -24,11 +24,8 @@ import (
 	"sync"
 	"time"
 
-	"hcm/cmd/cloud-server/service/sync/aws"
-	"hcm/cmd/cloud-server/service/sync/azure"
-	"hcm/cmd/cloud-server/service/sync/gcp"
-	"hcm/cmd/cloud-server/service/sync/huawei"
-	"hcm/cmd/cloud-server/service/sync/tcloud"
+	"hcm/cmd/cloud-server/service/sync/registry"
+	"hcm/cmd/cloud-server/service/sync/scheduler"
 	"hcm/pkg/api/core"
 	corecloud "hcm/pkg/api/core/cloud"
 	protocloud "hcm/pkg/api/data-service/cloud"
@@ -63,7 +60,7 @@ func CloudResourceSync(intervalMin time.Duration, sd serviced.ServiceDiscover, c
 
 		waitGroup := new(sync.WaitGroup)
 
-		vendors := []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei, enumor.Azure, enumor.Gcp}
+		vendors := registry.Vendors()
 		waitGroup.Add(len(vendors))
 		for _, vendor := range vendors {
 			go func(vendor enumor.Vendor) {
@@ -78,7 +75,7 @@ func CloudResourceSync(intervalMin time.Duration, sd serviced.ServiceDiscover, c
 	}
 }
 
-// allAccountSync tcloud all account sync.
+// allAccountSync vendor all account sync, accounts are synced concurrently by sync scheduler.
 func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor) {
 
 	startTime := time.Now()
@@ -110,7 +107,8 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor)
 		},
 	}
 	start := uint32(0)
-	syncPublicResource := true
+	publicGuard := new(publicResourceGuard)
+	waitGroup := new(sync.WaitGroup)
 	for {
 		listReq.Page.Start = start
 		accounts, err := listAccountWithRetry(kt, cliSet.DataService(), listReq)
@@ -120,38 +118,24 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor)
 		}
 
 		for _, one := range accounts {
-			switch one.Vendor {
-			case enumor.TCloud:
-				opt := &tcloud.SyncAllResourceOption{AccountID: one.ID, SyncPublicResource: syncPublicResource}
-				err = tcloud.SyncAllResource(kt, cliSet, opt)
-
-			case enumor.Aws:
-				opt := &aws.SyncAllResourceOption{AccountID: one.ID, SyncPublicResource: syncPublicResource}
-				err = aws.SyncAllResource(kt, cliSet, opt)
-
-			case enumor.HuaWei:
-				opt := &huawei.SyncAllResourceOption{AccountID: one.ID, SyncPublicResource: syncPublicResource}
-				err = huawei.SyncAllResource(kt, cliSet, opt)
-
-			case enumor.Azure:
-				opt := &azure.SyncAllResourceOption{AccountID: one.ID, SyncPublicResource: syncPublicResource}
-				err = azure.SyncAllResource(kt, cliSet, opt)
-
-			case enumor.Gcp:
-				opt := &gcp.SyncAllResourceOption{AccountID: one.ID, SyncPublicResource: syncPublicResource}
-				err = gcp.SyncAllResource(kt, cliSet, opt)
-
-			default:
-				logs.Errorf("unknown %s vendor type", one.Vendor)
-				continue
-			}
-			if err != nil {
-				logs.Errorf("sync %s all resource failed, err: %v, accountID: %s, rid: %s", vendor, err, one.ID, kt.Rid)
-				continue
+			accountID := one.ID
+			task := &scheduler.Task{
+				Vendor:    vendor,
+				AccountID: accountID,
+				Priority:  scheduler.TimerPriority,
+				Run: func() error {
+					defer waitGroup.Done()
+					return timerSyncAccount(kt, cliSet, vendor, accountID, publicGuard)
+				},
+				// 被手动同步替换或账号正在同步时任务不会执行
+				Cancel: waitGroup.Done,
 			}
 
-			// 公共资源仅需要同步一次即可
-			syncPublicResource = false
+			waitGroup.Add(1)
+			if err = scheduler.Manager.Submit(task); err != nil {
+				waitGroup.Done()
+				logs.Infof("%s account %s skip timing sync, reason: %v, rid: %s", vendor, accountID, err, kt.Rid)
+			}
 		}
 
 		if len(accounts) < int(core.DefaultMaxPageLimit) {
@@ -160,6 +144,67 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor)
 
 		start += uint32(core.DefaultMaxPageLimit)
 	}
+
+	waitGroup.Wait()
+}
+
+// timerSyncAccount 定时同步账号资源，公共资源每轮同步中只需要由一个账号同步成功一次。
+func timerSyncAccount(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor, accountID string,
+	publicGuard *publicResourceGuard) error {
+
+	syncer, err := registry.Get(vendor)
+	if err != nil {
+		logs.Errorf("get %s syncer failed, err: %v, accountID: %s, rid: %s", vendor, err, accountID, kt.Rid)
+		return err
+	}
+
+	syncPublicResource := !syncer.WithoutPublicResource && publicGuard.acquire()
+
+	opt := &registry.SyncAllResourceOption{AccountID: accountID, SyncPublicResource: syncPublicResource,
+		Trigger: enumor.TimerSyncTaskTrigger}
+	err = syncer.SyncAllResource(kt, cliSet, opt)
+
+	if syncPublicResource {
+		publicGuard.release(err == nil)
+	}
+
+	if err != nil {
+		logs.Errorf("sync %s all resource failed, err: %v, accountID: %s, rid: %s", vendor, err, accountID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// publicResourceGuard 保证一轮定时同步中公共资源只由一个账号同步，同步失败时交由后续账号重试。
+type publicResourceGuard struct {
+	lock    sync.Mutex
+	running bool
+	done    bool
+}
+
+// acquire 返回当前账号是否需要同步公共资源。
+func (g *publicResourceGuard) acquire() bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.done || g.running {
+		return false
+	}
+
+	g.running = true
+	return true
+}
+
+// release 公共资源同步结束，成功后本轮不再同步公共资源。
+func (g *publicResourceGuard) release(success bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.running = false
+	if success {
+		g.done = true
+	}
 }
 
 const maxRetryCount = 3
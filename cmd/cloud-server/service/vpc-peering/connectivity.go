@@ -0,0 +1,568 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package vpcpeering
+
+import (
+	"fmt"
+	"sort"
+
+	csvpcpeering "hcm/pkg/api/cloud-server/vpc-peering"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	corert "hcm/pkg/api/core/cloud/route-table"
+	corevpcpeering "hcm/pkg/api/core/cloud/vpc-peering"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/cidr"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// CheckSubnetConnectivity check whether source subnet can reach destination subnet.
+func (svc *vpcPeeringSvc) CheckSubnetConnectivity(cts *rest.Contexts) (interface{}, error) {
+	return svc.checkSubnetConnectivity(cts, handler.ResValidWithAuth)
+}
+
+// CheckBizSubnetConnectivity check whether source subnet can reach destination subnet in biz.
+func (svc *vpcPeeringSvc) CheckBizSubnetConnectivity(cts *rest.Contexts) (interface{}, error) {
+	return svc.checkSubnetConnectivity(cts, handler.BizValidWithAuth)
+}
+
+func (svc *vpcPeeringSvc) checkSubnetConnectivity(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csvpcpeering.SubnetConnectivityCheckReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := slice.Unique([]string{req.SrcSubnetID, req.DstSubnetID})
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.SubnetCloudResType,
+		IDs:          ids,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(cts.Kit.Ctx,
+		cts.Kit.Header(), basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Subnet,
+		Action: meta.Find, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	subnets, err := svc.client.DataService().Global.Subnet.List(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list subnet failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+
+	subnetMap := make(map[string]cloud.BaseSubnet, len(subnets.Details))
+	for _, one := range subnets.Details {
+		subnetMap[one.ID] = one
+	}
+
+	src, exist := subnetMap[req.SrcSubnetID]
+	if !exist {
+		return nil, errf.Newf(errf.RecordNotFound, "subnet %s is not found", req.SrcSubnetID)
+	}
+
+	dst, exist := subnetMap[req.DstSubnetID]
+	if !exist {
+		return nil, errf.Newf(errf.RecordNotFound, "subnet %s is not found", req.DstSubnetID)
+	}
+
+	return svc.checkConnectivity(cts.Kit, src, dst)
+}
+
+// checkConnectivity check connectivity of subnets by synced vpc peerings and routes. a path is reachable only when
+// the connection is active and route tables of both subnets route the peer subnet to the connection.
+func (svc *vpcPeeringSvc) checkConnectivity(kt *kit.Kit, src, dst cloud.BaseSubnet) (
+	*csvpcpeering.SubnetConnectivityResult, error) {
+
+	result := &csvpcpeering.SubnetConnectivityResult{Paths: make([]csvpcpeering.ConnectivityPath, 0)}
+
+	if src.Vendor != dst.Vendor {
+		result.Reason = fmt.Sprintf("subnets belong to different vendors(%s, %s), connectivity across vendors "+
+			"is not supported", src.Vendor, dst.Vendor)
+		return result, nil
+	}
+
+	if src.CloudVpcID == dst.CloudVpcID {
+		result.Reachable = true
+		result.Reason = fmt.Sprintf("subnets are in the same vpc(%s), they are connected by local route",
+			src.CloudVpcID)
+		return result, nil
+	}
+
+	peerings, err := svc.listActiveVpcPeering(kt, src.Vendor, []string{src.CloudVpcID, dst.CloudVpcID})
+	if err != nil {
+		return nil, err
+	}
+
+	paths := buildConnectivityPaths(src.Vendor, src.CloudVpcID, dst.CloudVpcID, peerings)
+	if len(paths) == 0 {
+		result.Reason = fmt.Sprintf("no active vpc peering, transit gateway or ccn connects vpc %s and vpc %s",
+			src.CloudVpcID, dst.CloudVpcID)
+		return result, nil
+	}
+
+	routeCache := make(map[string][]connectivityRoute)
+	for _, path := range paths {
+		path.Reachable = true
+		for _, pair := range [][2]cloud.BaseSubnet{{src, dst}, {dst, src}} {
+			ok, reasons, err := svc.checkRoute(kt, routeCache, pair[0], pair[1], path)
+			if err != nil {
+				return nil, err
+			}
+			path.Reachable = path.Reachable && ok
+			path.Reasons = append(path.Reasons, reasons...)
+		}
+
+		if path.Type.IsHubAttachment() {
+			path.Reasons = append(path.Reasons, fmt.Sprintf("route table of %s %s is not synced, it's assumed "+
+				"to route traffic between attached vpcs", path.Type, path.CloudGatewayID))
+		}
+
+		result.Reachable = result.Reachable || path.Reachable
+		result.Paths = append(result.Paths, *path)
+	}
+
+	return result, nil
+}
+
+// listActiveVpcPeering list active vpc peerings whose local or peer vpc is one of the cloud vpc ids.
+func (svc *vpcPeeringSvc) listActiveVpcPeering(kt *kit.Kit, vendor enumor.Vendor, cloudVpcIDs []string) (
+	[]corevpcpeering.VpcPeering, error) {
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "status", Op: filter.Equal.Factory(), Value: enumor.VpcPeeringActive},
+				&filter.Expression{Op: filter.Or, Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "local_cloud_vpc_id", Op: filter.In.Factory(), Value: cloudVpcIDs},
+					&filter.AtomRule{Field: "peer_cloud_vpc_id", Op: filter.In.Factory(), Value: cloudVpcIDs},
+				}},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+
+	peerings := make([]corevpcpeering.VpcPeering, 0)
+	for {
+		result, err := svc.client.DataService().Global.VpcPeering.ListVpcPeering(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list vpc peering failed, err: %v, cloud vpc ids: %v, rid: %s", err, cloudVpcIDs, kt.Rid)
+			return nil, err
+		}
+
+		peerings = append(peerings, result.Details...)
+
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return peerings, nil
+}
+
+// buildConnectivityPaths build paths connecting two vpcs, a vpc peering connecting them directly is a path, and
+// two attachments of them to the same transit gateway or ccn is a path.
+func buildConnectivityPaths(vendor enumor.Vendor, srcCloudVpcID, dstCloudVpcID string,
+	peerings []corevpcpeering.VpcPeering) []*csvpcpeering.ConnectivityPath {
+
+	pathMap := make(map[string]*csvpcpeering.ConnectivityPath)
+	keys := make([]string, 0)
+	addPath := func(key string, peering corevpcpeering.VpcPeering) {
+		path, exist := pathMap[key]
+		if !exist {
+			path = &csvpcpeering.ConnectivityPath{
+				Type:           peering.Type,
+				CloudGatewayID: peering.CloudGatewayID,
+				VpcPeeringIDs:  make([]string, 0),
+				Reasons:        make([]string, 0),
+			}
+			pathMap[key] = path
+			keys = append(keys, key)
+		}
+		path.VpcPeeringIDs = append(path.VpcPeeringIDs, peering.ID)
+	}
+
+	srcHubs := make(map[string]corevpcpeering.VpcPeering)
+	dstHubs := make(map[string]corevpcpeering.VpcPeering)
+	for _, one := range peerings {
+		if one.Type.IsHubAttachment() {
+			hubKey := string(one.Type) + "/" + one.CloudGatewayID
+			switch one.LocalCloudVpcID {
+			case srcCloudVpcID:
+				srcHubs[hubKey] = one
+			case dstCloudVpcID:
+				dstHubs[hubKey] = one
+			}
+			continue
+		}
+
+		direct := (one.LocalCloudVpcID == srcCloudVpcID && one.PeerCloudVpcID == dstCloudVpcID) ||
+			(one.LocalCloudVpcID == dstCloudVpcID && one.PeerCloudVpcID == srcCloudVpcID)
+		if !direct {
+			continue
+		}
+
+		switch vendor {
+		case enumor.Azure, enumor.Gcp:
+			// 两端VPC各自配置对等连接，云上ID不同，按VPC对合并为一条路径
+			addPath(string(one.Type), one)
+		default:
+			addPath(string(one.Type)+"/"+one.CloudGatewayID, one)
+		}
+	}
+
+	for hubKey, srcAttach := range srcHubs {
+		dstAttach, exist := dstHubs[hubKey]
+		if !exist {
+			continue
+		}
+		addPath(hubKey, srcAttach)
+		addPath(hubKey, dstAttach)
+	}
+
+	sort.Strings(keys)
+	paths := make([]*csvpcpeering.ConnectivityPath, 0, len(keys))
+	for _, key := range keys {
+		paths = append(paths, pathMap[key])
+	}
+
+	return paths
+}
+
+// checkRoute check whether route table of subnet routes the peer subnet to the connection of path by longest
+// prefix match.
+func (svc *vpcPeeringSvc) checkRoute(kt *kit.Kit, routeCache map[string][]connectivityRoute, from,
+	to cloud.BaseSubnet, path *csvpcpeering.ConnectivityPath) (bool, []string, error) {
+
+	switch from.Vendor {
+	case enumor.Azure, enumor.Gcp:
+		return true, []string{fmt.Sprintf("routes of %s peering are exchanged by system, route check of subnet "+
+			"%s is skipped", from.Vendor, from.CloudID)}, nil
+	}
+
+	routeTableID, err := svc.getSubnetRouteTableID(kt, from)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if len(routeTableID) == 0 {
+		return false, []string{fmt.Sprintf("subnet %s has no synced route table", from.CloudID)}, nil
+	}
+
+	routes, exist := routeCache[routeTableID]
+	if !exist {
+		routes, err = svc.listConnectivityRoute(kt, from.Vendor, routeTableID)
+		if err != nil {
+			return false, nil, err
+		}
+		routeCache[routeTableID] = routes
+	}
+
+	dstCidrs := to.Ipv4Cidr
+	if len(dstCidrs) == 0 {
+		dstCidrs = to.Ipv6Cidr
+	}
+
+	reachable := true
+	reasons := make([]string, 0, len(dstCidrs))
+	for _, dstCidr := range dstCidrs {
+		route, err := longestPrefixMatch(routes, dstCidr)
+		if err != nil {
+			return false, nil, err
+		}
+
+		switch {
+		case route == nil:
+			reachable = false
+			reasons = append(reasons, fmt.Sprintf("route table of subnet %s has no route to %s", from.CloudID,
+				dstCidr))
+		case route.GatewayType != path.Type || route.CloudGatewayID != path.CloudGatewayID:
+			reachable = false
+			reasons = append(reasons, fmt.Sprintf("route table of subnet %s routes %s by %s to next hop %s, not %s",
+				from.CloudID, dstCidr, route.Destination, route.NextHop, path.CloudGatewayID))
+		default:
+			reasons = append(reasons, fmt.Sprintf("route table of subnet %s routes %s by %s to %s", from.CloudID,
+				dstCidr, route.Destination, path.CloudGatewayID))
+		}
+	}
+
+	return reachable, reasons, nil
+}
+
+// longestPrefixMatch find the most specific route whose destination contains the cidr, nil is returned when no
+// route matches.
+func longestPrefixMatch(routes []connectivityRoute, dstCidr string) (*connectivityRoute, error) {
+	var matched *connectivityRoute
+	matchedLen := -1
+	for idx := range routes {
+		route := &routes[idx]
+		contains, err := cidr.CidrContains(route.Destination, dstCidr)
+		if err != nil {
+			// 目的地址为前缀列表等非网段形式的路由不参与匹配
+			continue
+		}
+
+		if !contains {
+			continue
+		}
+
+		maskLen, err := cidr.CidrMaskLen(route.Destination)
+		if err != nil {
+			return nil, err
+		}
+
+		if maskLen > matchedLen {
+			matched, matchedLen = route, maskLen
+		}
+	}
+
+	return matched, nil
+}
+
+// connectivityRoute is route normalized for connectivity check, GatewayType is set only when next hop is a vpc
+// peering, transit gateway or ccn.
+type connectivityRoute struct {
+	Destination    string
+	NextHop        string
+	GatewayType    enumor.VpcPeeringType
+	CloudGatewayID string
+}
+
+// getSubnetRouteTableID get route table of subnet, subnet without explicitly associated route table uses the main
+// route table of its vpc.
+func (svc *vpcPeeringSvc) getSubnetRouteTableID(kt *kit.Kit, subnet cloud.BaseSubnet) (string, error) {
+	if len(subnet.RouteTableID) != 0 {
+		return subnet.RouteTableID, nil
+	}
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("vpc_id", subnet.VpcID),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	ds := svc.client.DataService()
+	switch subnet.Vendor {
+	case enumor.TCloud:
+		tables, err := ds.TCloud.RouteTable.ListRouteTableWithExt(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list tcloud route table failed, err: %v, vpc: %s, rid: %s", err, subnet.VpcID, kt.Rid)
+			return "", err
+		}
+		for _, one := range tables {
+			if one.Extension != nil && one.Extension.Main {
+				return one.ID, nil
+			}
+		}
+	case enumor.Aws:
+		tables, err := ds.Aws.RouteTable.ListRouteTableWithExt(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list aws route table failed, err: %v, vpc: %s, rid: %s", err, subnet.VpcID, kt.Rid)
+			return "", err
+		}
+		for _, one := range tables {
+			if one.Extension != nil && one.Extension.Main {
+				return one.ID, nil
+			}
+		}
+	case enumor.HuaWei:
+		tables, err := ds.HuaWei.RouteTable.ListRouteTableWithExt(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list huawei route table failed, err: %v, vpc: %s, rid: %s", err, subnet.VpcID, kt.Rid)
+			return "", err
+		}
+		for _, one := range tables {
+			if one.Extension != nil && one.Extension.Default {
+				return one.ID, nil
+			}
+		}
+	default:
+		return "", errf.Newf(errf.InvalidParameter, "vendor %s does not support route check", subnet.Vendor)
+	}
+
+	return "", nil
+}
+
+// listConnectivityRoute list all routes of route table and normalize them for connectivity check.
+func (svc *vpcPeeringSvc) listConnectivityRoute(kt *kit.Kit, vendor enumor.Vendor, routeTableID string) (
+	[]connectivityRoute, error) {
+
+	ds := svc.client.DataService()
+	req := &core.ListReq{Filter: tools.AllExpression(), Page: core.NewDefaultBasePage()}
+	routes := make([]connectivityRoute, 0)
+	for {
+		var count int
+		switch vendor {
+		case enumor.TCloud:
+			result, err := ds.TCloud.RouteTable.ListRoute(kt.Ctx, kt.Header(), routeTableID, req)
+			if err != nil {
+				logs.Errorf("list tcloud route failed, err: %v, route table: %s, rid: %s", err, routeTableID, kt.Rid)
+				return nil, err
+			}
+			count = len(result.Details)
+			routes = append(routes, convTCloudRoutes(result.Details)...)
+		case enumor.Aws:
+			result, err := ds.Aws.RouteTable.ListRoute(kt.Ctx, kt.Header(), routeTableID, req)
+			if err != nil {
+				logs.Errorf("list aws route failed, err: %v, route table: %s, rid: %s", err, routeTableID, kt.Rid)
+				return nil, err
+			}
+			count = len(result.Details)
+			routes = append(routes, convAwsRoutes(result.Details)...)
+		case enumor.HuaWei:
+			result, err := ds.HuaWei.RouteTable.ListRoute(kt.Ctx, kt.Header(), routeTableID, req)
+			if err != nil {
+				logs.Errorf("list huawei route failed, err: %v, route table: %s, rid: %s", err, routeTableID, kt.Rid)
+				return nil, err
+			}
+			count = len(result.Details)
+			routes = append(routes, convHuaWeiRoutes(result.Details)...)
+		default:
+			return nil, errf.Newf(errf.InvalidParameter, "vendor %s does not support route check", vendor)
+		}
+
+		if uint(count) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return routes, nil
+}
+
+func convTCloudRoutes(details []corert.TCloudRoute) []connectivityRoute {
+	routes := make([]connectivityRoute, 0, len(details))
+	for _, one := range details {
+		if !one.Enabled {
+			continue
+		}
+
+		route := connectivityRoute{NextHop: one.CloudGatewayID}
+		switch one.GatewayType {
+		case "CCN":
+			route.GatewayType, route.CloudGatewayID = enumor.CcnAttachment, one.CloudGatewayID
+		case "PEERCONNECTION":
+			route.GatewayType, route.CloudGatewayID = enumor.PeeringConnection, one.CloudGatewayID
+		}
+
+		for _, destination := range []string{one.DestinationCidrBlock,
+			converter.PtrToVal(one.DestinationIpv6CidrBlock)} {
+
+			if len(destination) == 0 {
+				continue
+			}
+			route.Destination = destination
+			routes = append(routes, route)
+		}
+	}
+
+	return routes
+}
+
+func convAwsRoutes(details []corert.AwsRoute) []connectivityRoute {
+	routes := make([]connectivityRoute, 0, len(details))
+	for _, one := range details {
+		// blackhole 状态的路由目标已不可用，不参与匹配
+		if one.State != "active" {
+			continue
+		}
+
+		route := connectivityRoute{}
+		switch {
+		case len(converter.PtrToVal(one.CloudVpcPeeringConnectionID)) != 0:
+			route.GatewayType = enumor.PeeringConnection
+			route.CloudGatewayID = converter.PtrToVal(one.CloudVpcPeeringConnectionID)
+			route.NextHop = route.CloudGatewayID
+		case len(converter.PtrToVal(one.CloudTransitGatewayID)) != 0:
+			route.GatewayType = enumor.TransitGatewayAttachment
+			route.CloudGatewayID = converter.PtrToVal(one.CloudTransitGatewayID)
+			route.NextHop = route.CloudGatewayID
+		default:
+			route.NextHop = awsRouteNextHop(one)
+		}
+
+		for _, destination := range []string{converter.PtrToVal(one.DestinationCidrBlock),
+			converter.PtrToVal(one.DestinationIpv6CidrBlock)} {
+
+			if len(destination) == 0 {
+				continue
+			}
+			route.Destination = destination
+			routes = append(routes, route)
+		}
+	}
+
+	return routes
+}
+
+func awsRouteNextHop(route corert.AwsRoute) string {
+	for _, hop := range []*string{route.CloudGatewayID, route.CloudNatGatewayID, route.CloudInstanceID,
+		route.CloudNetworkInterfaceID, route.CloudLocalGatewayID, route.CloudCarrierGatewayID,
+		route.CloudEgressOnlyInternetGatewayID} {
+
+		if len(converter.PtrToVal(hop)) != 0 {
+			return *hop
+		}
+	}
+
+	return ""
+}
+
+func convHuaWeiRoutes(details []corert.HuaWeiRoute) []connectivityRoute {
+	routes := make([]connectivityRoute, 0, len(details))
+	for _, one := range details {
+		route := connectivityRoute{Destination: one.Destination, NextHop: one.NextHop}
+		if one.Type == "peering" {
+			route.GatewayType, route.CloudGatewayID = enumor.PeeringConnection, one.NextHop
+		}
+		routes = append(routes, route)
+	}
+
+	return routes
+}
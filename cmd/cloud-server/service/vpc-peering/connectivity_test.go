@@ -0,0 +1,210 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package vpcpeering
+
+import (
+	"reflect"
+	"testing"
+
+	csvpcpeering "hcm/pkg/api/cloud-server/vpc-peering"
+	corert "hcm/pkg/api/core/cloud/route-table"
+	corevpcpeering "hcm/pkg/api/core/cloud/vpc-peering"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+func TestLongestPrefixMatch(t *testing.T) {
+	cases := []struct {
+		name    string
+		routes  []connectivityRoute
+		dstCidr string
+		expect  string
+	}{
+		{
+			name: "most specific route wins",
+			routes: []connectivityRoute{
+				{Destination: "0.0.0.0/0", NextHop: "igw"},
+				{Destination: "10.0.0.0/8", NextHop: "pcx-1"},
+				{Destination: "10.1.0.0/16", NextHop: "pcx-2"},
+			},
+			dstCidr: "10.1.1.0/24",
+			expect:  "pcx-2",
+		},
+		{
+			name: "first route wins when prefix length ties",
+			routes: []connectivityRoute{
+				{Destination: "10.1.0.0/16", NextHop: "pcx-1"},
+				{Destination: "10.1.0.0/16", NextHop: "pcx-2"},
+			},
+			dstCidr: "10.1.1.0/24",
+			expect:  "pcx-1",
+		},
+		{
+			name: "route not covering the whole destination does not match",
+			routes: []connectivityRoute{
+				{Destination: "10.1.1.0/25", NextHop: "pcx-1"},
+				{Destination: "10.0.0.0/8", NextHop: "pcx-2"},
+			},
+			dstCidr: "10.1.1.0/24",
+			expect:  "pcx-2",
+		},
+		{
+			name: "non cidr destination is skipped",
+			routes: []connectivityRoute{
+				{Destination: "pl-12345", NextHop: "pcx-1"},
+			},
+			dstCidr: "10.1.1.0/24",
+			expect:  "",
+		},
+		{
+			name:    "no route matches",
+			routes:  []connectivityRoute{{Destination: "172.16.0.0/12", NextHop: "pcx-1"}},
+			dstCidr: "10.1.1.0/24",
+			expect:  "",
+		},
+	}
+
+	for _, c := range cases {
+		route, err := longestPrefixMatch(c.routes, c.dstCidr)
+		if err != nil {
+			t.Errorf("%s: longest prefix match failed, err: %v", c.name, err)
+			continue
+		}
+
+		nextHop := ""
+		if route != nil {
+			nextHop = route.NextHop
+		}
+
+		if nextHop != c.expect {
+			t.Errorf("%s: matched next hop %s is not as expected %s", c.name, nextHop, c.expect)
+		}
+	}
+}
+
+func TestBuildConnectivityPaths(t *testing.T) {
+	peerings := []corevpcpeering.VpcPeering{
+		{ID: "1", Type: enumor.PeeringConnection, CloudGatewayID: "pcx-1", LocalCloudVpcID: "vpc-a",
+			PeerCloudVpcID: "vpc-b"},
+		{ID: "2", Type: enumor.PeeringConnection, CloudGatewayID: "pcx-2", LocalCloudVpcID: "vpc-a",
+			PeerCloudVpcID: "vpc-c"},
+		{ID: "3", Type: enumor.TransitGatewayAttachment, CloudGatewayID: "tgw-1", LocalCloudVpcID: "vpc-a"},
+		{ID: "4", Type: enumor.TransitGatewayAttachment, CloudGatewayID: "tgw-1", LocalCloudVpcID: "vpc-b"},
+		// 只有一端关联的中转网关不能连通两个VPC
+		{ID: "5", Type: enumor.TransitGatewayAttachment, CloudGatewayID: "tgw-2", LocalCloudVpcID: "vpc-a"},
+		{ID: "6", Type: enumor.TransitGatewayAttachment, CloudGatewayID: "tgw-3", LocalCloudVpcID: "vpc-b"},
+	}
+
+	paths := buildConnectivityPaths(enumor.Aws, "vpc-a", "vpc-b", peerings)
+	expect := []*csvpcpeering.ConnectivityPath{
+		{Type: enumor.PeeringConnection, CloudGatewayID: "pcx-1", VpcPeeringIDs: []string{"1"},
+			Reasons: []string{}},
+		{Type: enumor.TransitGatewayAttachment, CloudGatewayID: "tgw-1", VpcPeeringIDs: []string{"3", "4"},
+			Reasons: []string{}},
+	}
+	if !reflect.DeepEqual(paths, expect) {
+		t.Errorf("aws paths %+v are not as expected %+v", paths, expect)
+	}
+
+	// 两端VPC各自配置的对等连接合并为一条路径
+	gcpPeerings := []corevpcpeering.VpcPeering{
+		{ID: "1", Type: enumor.PeeringConnection, CloudGatewayID: "peer-ab", LocalCloudVpcID: "vpc-a",
+			PeerCloudVpcID: "vpc-b"},
+		{ID: "2", Type: enumor.PeeringConnection, CloudGatewayID: "peer-ba", LocalCloudVpcID: "vpc-b",
+			PeerCloudVpcID: "vpc-a"},
+	}
+	paths = buildConnectivityPaths(enumor.Gcp, "vpc-a", "vpc-b", gcpPeerings)
+	if len(paths) != 1 || !reflect.DeepEqual(paths[0].VpcPeeringIDs, []string{"1", "2"}) {
+		t.Errorf("gcp peerings of both vpcs should be merged into one path, got %+v", paths)
+	}
+}
+
+func TestConvTCloudRoutes(t *testing.T) {
+	routes := convTCloudRoutes([]corert.TCloudRoute{
+		{DestinationCidrBlock: "10.1.0.0/16", GatewayType: "PEERCONNECTION", CloudGatewayID: "pcx-1", Enabled: true},
+		{DestinationCidrBlock: "10.2.0.0/16", GatewayType: "CCN", CloudGatewayID: "ccn-1", Enabled: true,
+			DestinationIpv6CidrBlock: converter.ValToPtr("fd00::/64")},
+		{DestinationCidrBlock: "10.3.0.0/16", GatewayType: "NAT", CloudGatewayID: "nat-1", Enabled: true},
+		// 未启用的路由不参与匹配
+		{DestinationCidrBlock: "10.4.0.0/16", GatewayType: "PEERCONNECTION", CloudGatewayID: "pcx-2"},
+	})
+
+	expect := []connectivityRoute{
+		{Destination: "10.1.0.0/16", NextHop: "pcx-1", GatewayType: enumor.PeeringConnection,
+			CloudGatewayID: "pcx-1"},
+		{Destination: "10.2.0.0/16", NextHop: "ccn-1", GatewayType: enumor.CcnAttachment, CloudGatewayID: "ccn-1"},
+		{Destination: "fd00::/64", NextHop: "ccn-1", GatewayType: enumor.CcnAttachment, CloudGatewayID: "ccn-1"},
+		{Destination: "10.3.0.0/16", NextHop: "nat-1"},
+	}
+	if !reflect.DeepEqual(routes, expect) {
+		t.Errorf("tcloud routes %+v are not as expected %+v", routes, expect)
+	}
+}
+
+func TestConvAwsRoutes(t *testing.T) {
+	routes := convAwsRoutes([]corert.AwsRoute{
+		{DestinationCidrBlock: converter.ValToPtr("10.1.0.0/16"), State: "active",
+			CloudVpcPeeringConnectionID: converter.ValToPtr("pcx-1")},
+		{DestinationCidrBlock: converter.ValToPtr("10.2.0.0/16"), State: "active",
+			CloudTransitGatewayID: converter.ValToPtr("tgw-1")},
+		{DestinationCidrBlock: converter.ValToPtr("0.0.0.0/0"), State: "active",
+			CloudNatGatewayID: converter.ValToPtr("nat-1")},
+		// blackhole 状态的路由不参与匹配
+		{DestinationCidrBlock: converter.ValToPtr("10.3.0.0/16"), State: "blackhole",
+			CloudVpcPeeringConnectionID: converter.ValToPtr("pcx-2")},
+	})
+
+	expect := []connectivityRoute{
+		{Destination: "10.1.0.0/16", NextHop: "pcx-1", GatewayType: enumor.PeeringConnection,
+			CloudGatewayID: "pcx-1"},
+		{Destination: "10.2.0.0/16", NextHop: "tgw-1", GatewayType: enumor.TransitGatewayAttachment,
+			CloudGatewayID: "tgw-1"},
+		{Destination: "0.0.0.0/0", NextHop: "nat-1"},
+	}
+	if !reflect.DeepEqual(routes, expect) {
+		t.Errorf("aws routes %+v are not as expected %+v", routes, expect)
+	}
+
+	// blackhole 路由被忽略后，目的网段由范围更大的非对等连接路由匹配
+	route, err := longestPrefixMatch(routes, "10.3.1.0/24")
+	if err != nil {
+		t.Fatalf("longest prefix match failed, err: %v", err)
+	}
+
+	if route == nil || route.GatewayType != "" || route.NextHop != "nat-1" {
+		t.Errorf("blackhole route should not be matched, got %+v", route)
+	}
+}
+
+func TestConvHuaWeiRoutes(t *testing.T) {
+	routes := convHuaWeiRoutes([]corert.HuaWeiRoute{
+		{Type: "peering", Destination: "10.1.0.0/16", NextHop: "peer-1"},
+		{Type: "ecs", Destination: "10.2.0.0/16", NextHop: "ecs-1"},
+	})
+
+	expect := []connectivityRoute{
+		{Destination: "10.1.0.0/16", NextHop: "peer-1", GatewayType: enumor.PeeringConnection,
+			CloudGatewayID: "peer-1"},
+		{Destination: "10.2.0.0/16", NextHop: "ecs-1"},
+	}
+	if !reflect.DeepEqual(routes, expect) {
+		t.Errorf("huawei routes %+v are not as expected %+v", routes, expect)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package vpcpeering ...
+package vpcpeering
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	csvpcpeering "hcm/pkg/api/cloud-server/vpc-peering"
+	"hcm/pkg/api/core"
+	corevpcpeering "hcm/pkg/api/core/cloud/vpc-peering"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	protovpcpeering "hcm/pkg/api/data-service/cloud/vpc-peering"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitVpcPeeringService initialize the vpc peering service.
+func InitVpcPeeringService(c *capability.Capability) {
+	svc := &vpcPeeringSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListVpcPeering", http.MethodPost, "/vpc_peerings/list", svc.ListVpcPeering)
+	h.Add("AssignVpcPeeringToBiz", http.MethodPost, "/vpc_peerings/assign/bizs", svc.AssignVpcPeeringToBiz)
+	h.Add("CheckSubnetConnectivity", http.MethodPost, "/subnets/connectivity/check", svc.CheckSubnetConnectivity)
+
+	// vpc peering apis in biz
+	h.Add("ListBizVpcPeering", http.MethodPost, "/bizs/{bk_biz_id}/vpc_peerings/list", svc.ListBizVpcPeering)
+	h.Add("CheckBizSubnetConnectivity", http.MethodPost, "/bizs/{bk_biz_id}/subnets/connectivity/check",
+		svc.CheckBizSubnetConnectivity)
+
+	h.Load(c.WebService)
+}
+
+type vpcPeeringSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
+
+// ListVpcPeering list vpc peering.
+func (svc *vpcPeeringSvc) ListVpcPeering(cts *rest.Contexts) (interface{}, error) {
+	return svc.listVpcPeering(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizVpcPeering list biz vpc peering.
+func (svc *vpcPeeringSvc) ListBizVpcPeering(cts *rest.Contexts) (interface{}, error) {
+	return svc.listVpcPeering(cts, handler.ListBizAuthRes)
+}
+
+func (svc *vpcPeeringSvc) listVpcPeering(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.VpcPeering, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &protovpcpeering.VpcPeeringListResult{Details: make([]corevpcpeering.VpcPeering, 0)}, nil
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.VpcPeering.ListVpcPeering(cts.Kit.Ctx, cts.Kit.Header(), req)
+}
+
+// AssignVpcPeeringToBiz assign vpc peering to biz.
+func (svc *vpcPeeringSvc) AssignVpcPeeringToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(csvpcpeering.AssignVpcPeeringToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizeVpcPeeringAssignOp(cts.Kit, req.VpcPeeringIDs, req.BkBizID); err != nil {
+		return nil, err
+	}
+
+	// check if all vpc peerings are not assigned to biz, right now assigning resource twice is not allowed
+	listReq := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: req.VpcPeeringIDs},
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.NotEqual.Factory(), Value: constant.UnassignedBiz},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.VpcPeering.ListVpcPeering(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list vpc peering failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != 0 {
+		ids := make([]string, len(result.Details))
+		for index, one := range result.Details {
+			ids[index] = one.ID
+		}
+		return nil, fmt.Errorf("vpc peering(ids=%v) already assigned", ids)
+	}
+
+	// create assign audit.
+	err = svc.audit.ResBizAssignAudit(cts.Kit, enumor.VpcPeeringAuditResType, req.VpcPeeringIDs, req.BkBizID)
+	if err != nil {
+		logs.Errorf("create vpc peering assign audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	update := &protovpcpeering.VpcPeeringCommonInfoBatchUpdateReq{
+		IDs:     req.VpcPeeringIDs,
+		BkBizID: req.BkBizID,
+	}
+	err = svc.client.DataService().Global.VpcPeering.BatchUpdateVpcPeeringCommonInfo(cts.Kit.Ctx, cts.Kit.Header(),
+		update)
+	if err != nil {
+		logs.Errorf("batch update vpc peering common info failed, req: %+v, err: %v, rid: %s", req, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *vpcPeeringSvc) authorizeVpcPeeringAssignOp(kt *kit.Kit, ids []string, bizID int64) error {
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.VpcPeeringCloudResType,
+		IDs:          ids,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResourceBasicInfo(kt.Ctx, kt.Header(), basicInfoReq)
+	if err != nil {
+		return err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		authRes = append(authRes, meta.ResourceAttribute{
+			Basic: &meta.Basic{
+				Type:       meta.VpcPeering,
+				Action:     meta.Assign,
+				ResourceID: info.AccountID,
+			},
+			BizID: bizID,
+		})
+	}
+
+	return svc.authorizer.AuthorizeWithPerm(kt, authRes...)
+}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/logics/ipam"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/cmd/cloud-server/service/common"
 	cloudserver "hcm/pkg/api/cloud-server"
@@ -53,6 +54,7 @@ func InitVpcService(c *capability.Capability) {
 		client:     c.ApiClient,
 		authorizer: c.Authorizer,
 		audit:      c.Audit,
+		ipamLgc:    c.Logics.Ipam,
 	}
 
 	h := rest.NewHandler()
@@ -80,6 +82,7 @@ type vpcSvc struct {
 	client     *client.ClientSet
 	authorizer auth.Authorizer
 	audit      audit.Interface
+	ipamLgc    ipam.Interface
 }
 
 // CreateVpc create vpc.
@@ -134,8 +137,25 @@ func (svc *vpcSvc) createTCloudVpc(kt *kit.Kit, body json.RawMessage) (interface
 	if err := req.Validate(false); err != nil {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
-	// 转换参数并调用HCService进行创建流程
-	result, err := svc.client.HCService().TCloud.Vpc.Create(kt.Ctx, kt.Header(), common.ConvTCloudVpcCreateReq(req))
+	// 转换参数并调用HCService进行创建流程，使用地址池时先从地址池分配网段
+	var result *core.CreateResult
+	allocOpt := ipam.VpcAllocateOption(req.CidrPoolOption, req.BkBizID, enumor.TCloud, req.AccountID, req.Region)
+	err := svc.ipamLgc.CreateVpc(kt, allocOpt, func(cidr string) (string, error) {
+		if len(cidr) != 0 {
+			subnetCidr, err := ipam.FirstSubnetCidr(cidr, req.SubnetMaskLen)
+			if err != nil {
+				return "", err
+			}
+			req.IPv4Cidr, req.Subnet.IPv4Cidr = cidr, subnetCidr
+		}
+
+		var err error
+		result, err = svc.client.HCService().TCloud.Vpc.Create(kt.Ctx, kt.Header(), common.ConvTCloudVpcCreateReq(req))
+		if err != nil {
+			return "", err
+		}
+		return result.ID, nil
+	})
 	if err != nil {
 		logs.Errorf("batch create tcloud vpc failed, err: %v, result: %v, rid: %s", err, result, kt.Rid)
 		return result, err
@@ -155,7 +175,24 @@ func (svc *vpcSvc) createAzureVpc(kt *kit.Kit, body json.RawMessage) (interface{
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	result, err := svc.client.HCService().Azure.Vpc.Create(kt.Ctx, kt.Header(), common.ConvAzureVpcCreateReq(req))
+	var result *core.CreateResult
+	allocOpt := ipam.VpcAllocateOption(req.CidrPoolOption, req.BkBizID, enumor.Azure, req.AccountID, req.Region)
+	err := svc.ipamLgc.CreateVpc(kt, allocOpt, func(cidr string) (string, error) {
+		if len(cidr) != 0 {
+			subnetCidr, err := ipam.FirstSubnetCidr(cidr, req.SubnetMaskLen)
+			if err != nil {
+				return "", err
+			}
+			req.IPv4Cidr, req.Subnet.IPv4Cidr = cidr, subnetCidr
+		}
+
+		var err error
+		result, err = svc.client.HCService().Azure.Vpc.Create(kt.Ctx, kt.Header(), common.ConvAzureVpcCreateReq(req))
+		if err != nil {
+			return "", err
+		}
+		return result.ID, nil
+	})
 	if err != nil {
 		logs.Errorf("batch create azure vpc failed, err: %v, result: %v, rid: %s", err, result, kt.Rid)
 		return result, err
@@ -175,8 +212,30 @@ func (svc *vpcSvc) createHuaWeiVpc(kt *kit.Kit, body json.RawMessage) (interface
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	result, err := svc.client.HCService().HuaWei.Vpc.Create(kt.Ctx, kt.Header(),
-		common.ConvHuaWeiVpcCreateReq(req))
+	var result *core.CreateResult
+	allocOpt := ipam.VpcAllocateOption(req.CidrPoolOption, req.BkBizID, enumor.HuaWei, req.AccountID, req.Region)
+	err := svc.ipamLgc.CreateVpc(kt, allocOpt, func(cidr string) (string, error) {
+		if len(cidr) != 0 {
+			subnetCidr, err := ipam.FirstSubnetCidr(cidr, req.SubnetMaskLen)
+			if err != nil {
+				return "", err
+			}
+
+			gatewayIP, err := ipam.GatewayIP(subnetCidr)
+			if err != nil {
+				return "", err
+			}
+			req.IPv4Cidr, req.Subnet.IPv4Cidr, req.Subnet.GatewayIP = cidr, subnetCidr, gatewayIP
+		}
+
+		var err error
+		result, err = svc.client.HCService().HuaWei.Vpc.Create(kt.Ctx, kt.Header(),
+			common.ConvHuaWeiVpcCreateReq(req))
+		if err != nil {
+			return "", err
+		}
+		return result.ID, nil
+	})
 	if err != nil {
 		logs.Errorf("batch create huawei vpc failed, err: %v, result: %v, rid: %s", err, result, kt.Rid)
 		return result, err
@@ -216,7 +275,20 @@ func (svc *vpcSvc) createAwsVpc(kt *kit.Kit, body json.RawMessage) (interface{},
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	result, err := svc.client.HCService().Aws.Vpc.Create(kt.Ctx, kt.Header(), common.ConvAwsVpcCreateReq(req))
+	var result *core.CreateResult
+	allocOpt := ipam.VpcAllocateOption(req.CidrPoolOption, req.BkBizID, enumor.Aws, req.AccountID, req.Region)
+	err := svc.ipamLgc.CreateVpc(kt, allocOpt, func(cidr string) (string, error) {
+		if len(cidr) != 0 {
+			req.IPv4Cidr = cidr
+		}
+
+		var err error
+		result, err = svc.client.HCService().Aws.Vpc.Create(kt.Ctx, kt.Header(), common.ConvAwsVpcCreateReq(req))
+		if err != nil {
+			return "", err
+		}
+		return result.ID, nil
+	})
 	if err != nil {
 		logs.Errorf("batch create aws vpc failed, err: %v, result: %v, rid: %s", err, result, kt.Rid)
 		return result, err
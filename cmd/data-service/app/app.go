@@ -42,6 +42,10 @@ func Run(opt *options.Option) error {
 		return err
 	}
 
+	if opt.ReEncryptAccountSecret {
+		return ds.svc.ReEncryptAccountSecret()
+	}
+
 	if err := ds.svc.ListenAndServeRest(); err != nil {
 		return err
 	}
@@ -30,6 +30,8 @@ import (
 // Option defines the app's runtime flag options.
 type Option struct {
 	Sys *cc.SysOption
+	// ReEncryptAccountSecret 离线使用当前主密钥重新加密全部账号密钥，执行完成后退出，不启动服务
+	ReEncryptAccountSecret bool
 }
 
 // InitOptions init data service's options from command flags.
@@ -38,6 +40,9 @@ func InitOptions() *Option {
 	sysOpt := flags.SysFlags(fs)
 	opt := &Option{Sys: sysOpt}
 
+	fs.BoolVarP(&opt.ReEncryptAccountSecret, "re-encrypt-account-secret", "", false, "re-encrypt all "+
+		"account secrets with the primary key of crypto keyring offline, then exit without serving.")
+
 	// parses the command-line flags from os.Args[1:]. must be called after all flags are defined
 	// and before flags are accessed by the program.
 	pflag.Parse()
@@ -112,6 +112,18 @@ func (ad Audit) buildAssignAuditInfo(kt *kit.Kit, resType enumor.AuditResourceTy
 		audits, err = ad.networkInterface.NetworkInterfaceAssignAuditBuild(kt, assigns)
 	case enumor.RouteTableAuditResType:
 		audits, err = ad.routeTable.RouteTableAssignAuditBuild(kt, assigns)
+	case enumor.LoadBalancerAuditResType:
+		audits, err = ad.loadBalancerAssignAuditBuild(kt, assigns)
+	case enumor.NatGatewayAuditResType:
+		audits, err = ad.natGatewayAssignAuditBuild(kt, assigns)
+	case enumor.SnapshotAuditResType:
+		audits, err = ad.snapshotAssignAuditBuild(kt, assigns)
+	case enumor.CloudKeyPairAuditResType:
+		audits, err = ad.cloudKeyPairAssignAuditBuild(kt, assigns)
+	case enumor.BucketAuditResType:
+		audits, err = ad.bucketAssignAuditBuild(kt, assigns)
+	case enumor.VpcPeeringAuditResType:
+		audits, err = ad.vpcPeeringAssignAuditBuild(kt, assigns)
 	default:
 		return nil, fmt.Errorf("cloud resource type: %s not support", resType)
 	}
@@ -95,6 +95,20 @@ func (ad Audit) buildDeleteAuditInfo(kt *kit.Kit, resType enumor.AuditResourceTy
 		audits, err = ad.eipDeleteAuditBuild(kt, deletes)
 	case enumor.DiskAuditResType:
 		audits, err = ad.diskDeleteAuditBuild(kt, deletes)
+	case enumor.LoadBalancerAuditResType:
+		audits, err = ad.loadBalancerDeleteAuditBuild(kt, deletes)
+	case enumor.NatGatewayAuditResType:
+		audits, err = ad.natGatewayDeleteAuditBuild(kt, deletes)
+	case enumor.SnapshotAuditResType:
+		audits, err = ad.snapshotDeleteAuditBuild(kt, deletes)
+	case enumor.SnapshotPolicyAuditResType:
+		audits, err = ad.snapshotPolicyDeleteAuditBuild(kt, deletes)
+	case enumor.KeyPairAuditResType:
+		audits, err = ad.keyPairDeleteAuditBuild(kt, deletes)
+	case enumor.CloudKeyPairAuditResType:
+		audits, err = ad.cloudKeyPairDeleteAuditBuild(kt, deletes)
+	case enumor.ImageAuditResType:
+		audits, err = ad.imageDeleteAuditBuild(kt, deletes)
 
 	default:
 		return nil, fmt.Errorf("cloud resource type: %s not support", resType)
@@ -87,6 +87,10 @@ func (ad Audit) buildOperationAuditInfo(kt *kit.Kit, resType enumor.AuditResourc
 		audits, err = ad.eipOperationAuditBuild(kt, operations)
 	case enumor.DiskAuditResType:
 		audits, err = ad.diskOperationAuditBuild(kt, operations)
+	case enumor.SnapshotAuditResType:
+		audits, err = ad.snapshotOperationAuditBuild(kt, operations)
+	case enumor.ImageAuditResType:
+		audits, err = ad.imageOperationAuditBuild(kt, operations)
 	default:
 		return nil, fmt.Errorf("cloud resource type: %s not support", resType)
 	}
@@ -0,0 +1,141 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaudit "hcm/pkg/dal/table/audit"
+	tableimage "hcm/pkg/dal/table/cloud/image"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+func (ad Audit) imageOperationAuditBuild(kt *kit.Kit, ops []protoaudit.CloudResourceOperationInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(ops))
+	for _, one := range ops {
+		if one.Action != protoaudit.Copy && one.Action != protoaudit.Share {
+			return nil, fmt.Errorf("audit action: %s not support", one.Action)
+		}
+		ids = append(ids, one.ResID)
+	}
+	idImageMap, err := ad.listImage(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(ops))
+	for _, one := range ops {
+		img, exist := idImageMap[one.ResID]
+		if !exist {
+			continue
+		}
+
+		action, err := one.Action.ConvAuditAction()
+		if err != nil {
+			return nil, err
+		}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: img.CloudID,
+			ResName:    img.Name,
+			ResType:    enumor.ImageAuditResType,
+			Action:     action,
+			Vendor:     enumor.Vendor(img.Vendor),
+			AccountID:  img.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data: map[string]interface{}{"platform": img.Platform, "state": img.State},
+			},
+		})
+	}
+
+	return audits, nil
+}
+
+func (ad Audit) imageDeleteAuditBuild(kt *kit.Kit, deletes []protoaudit.CloudResourceDeleteInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(deletes))
+	for _, one := range deletes {
+		ids = append(ids, one.ResID)
+	}
+	idImageMap, err := ad.listImage(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(deletes))
+	for _, one := range deletes {
+		img, exist := idImageMap[one.ResID]
+		if !exist {
+			continue
+		}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: img.CloudID,
+			ResName:    img.Name,
+			ResType:    enumor.ImageAuditResType,
+			Action:     enumor.Delete,
+			Vendor:     enumor.Vendor(img.Vendor),
+			AccountID:  img.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data: img,
+			},
+		})
+	}
+
+	return audits, nil
+}
+
+func (ad Audit) listImage(kt *kit.Kit, ids []string) (map[string]*tableimage.ImageModel, error) {
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := ad.dao.Image().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list image failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	result := make(map[string]*tableimage.ImageModel, len(list.Details))
+	for _, one := range list.Details {
+		result[one.ID] = one
+	}
+
+	return result, nil
+}
@@ -0,0 +1,178 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"hcm/pkg/api/core"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaudit "hcm/pkg/dal/table/audit"
+	tablekp "hcm/pkg/dal/table/cloud/key-pair"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+func (ad Audit) cloudKeyPairAssignAuditBuild(kt *kit.Kit, assigns []protoaudit.CloudResourceAssignInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(assigns))
+	for _, one := range assigns {
+		ids = append(ids, one.ResID)
+	}
+	idKeyPairMap, err := ad.listCloudKeyPair(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(assigns))
+	for _, one := range assigns {
+		keyPair, exist := idKeyPairMap[one.ResID]
+		if !exist {
+			continue
+		}
+
+		if one.AssignedResType != enumor.BizAuditAssignedResType {
+			return nil, errf.New(errf.InvalidParameter, "assigned resource type is invalid")
+		}
+		changed := map[string]interface{}{"bk_biz_id": one.AssignedResID}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: keyPair.CloudID,
+			ResName:    keyPair.Name,
+			ResType:    enumor.CloudKeyPairAuditResType,
+			Action:     enumor.Assign,
+			BkBizID:    keyPair.BkBizID,
+			Vendor:     keyPair.Vendor,
+			AccountID:  keyPair.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Changed: changed,
+			},
+		})
+	}
+
+	return audits, nil
+}
+
+func (ad Audit) cloudKeyPairDeleteAuditBuild(kt *kit.Kit, deletes []protoaudit.CloudResourceDeleteInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(deletes))
+	for _, one := range deletes {
+		ids = append(ids, one.ResID)
+	}
+	idKeyPairMap, err := ad.listCloudKeyPair(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(deletes))
+	for _, one := range deletes {
+		keyPair, exist := idKeyPairMap[one.ResID]
+		if !exist {
+			continue
+		}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: keyPair.CloudID,
+			ResName:    keyPair.Name,
+			ResType:    enumor.CloudKeyPairAuditResType,
+			Action:     enumor.Delete,
+			BkBizID:    keyPair.BkBizID,
+			Vendor:     keyPair.Vendor,
+			AccountID:  keyPair.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data: keyPair,
+			},
+		})
+	}
+
+	return audits, nil
+}
+
+func (ad Audit) listCloudKeyPair(kt *kit.Kit, ids []string) (map[string]tablekp.CloudKeyPairTable, error) {
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := ad.dao.CloudKeyPair().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list cloud key pair failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	result := make(map[string]tablekp.CloudKeyPairTable, len(list.Details))
+	for _, one := range list.Details {
+		result[one.ID] = one
+	}
+
+	return result, nil
+}
+
+func (ad Audit) keyPairDeleteAuditBuild(kt *kit.Kit, deletes []protoaudit.CloudResourceDeleteInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(deletes))
+	for _, one := range deletes {
+		ids = append(ids, one.ResID)
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := ad.dao.KeyPair().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(list.Details))
+	for _, one := range list.Details {
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:    one.ID,
+			ResName:  one.Name,
+			ResType:  enumor.KeyPairAuditResType,
+			Action:   enumor.Delete,
+			BkBizID:  one.BkBizID,
+			Operator: kt.User,
+			Source:   kt.GetRequestSource(),
+			Rid:      kt.Rid,
+			AppCode:  kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data: one,
+			},
+		})
+	}
+
+	return audits, nil
+}
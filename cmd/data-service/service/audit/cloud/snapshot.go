@@ -0,0 +1,229 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaudit "hcm/pkg/dal/table/audit"
+	tablesnap "hcm/pkg/dal/table/cloud/snapshot"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+func (ad Audit) snapshotAssignAuditBuild(kt *kit.Kit, assigns []protoaudit.CloudResourceAssignInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(assigns))
+	for _, one := range assigns {
+		ids = append(ids, one.ResID)
+	}
+	idSnapMap, err := ad.listSnapshot(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(assigns))
+	for _, one := range assigns {
+		snap, exist := idSnapMap[one.ResID]
+		if !exist {
+			continue
+		}
+
+		if one.AssignedResType != enumor.BizAuditAssignedResType {
+			return nil, errf.New(errf.InvalidParameter, "assigned resource type is invalid")
+		}
+		changed := map[string]interface{}{"bk_biz_id": one.AssignedResID}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: snap.CloudID,
+			ResName:    snap.Name,
+			ResType:    enumor.SnapshotAuditResType,
+			Action:     enumor.Assign,
+			BkBizID:    snap.BkBizID,
+			Vendor:     snap.Vendor,
+			AccountID:  snap.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Changed: changed,
+			},
+		})
+	}
+
+	return audits, nil
+}
+
+func (ad Audit) snapshotDeleteAuditBuild(kt *kit.Kit, deletes []protoaudit.CloudResourceDeleteInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(deletes))
+	for _, one := range deletes {
+		ids = append(ids, one.ResID)
+	}
+	idSnapMap, err := ad.listSnapshot(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(deletes))
+	for _, one := range deletes {
+		snap, exist := idSnapMap[one.ResID]
+		if !exist {
+			continue
+		}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: snap.CloudID,
+			ResName:    snap.Name,
+			ResType:    enumor.SnapshotAuditResType,
+			Action:     enumor.Delete,
+			BkBizID:    snap.BkBizID,
+			Vendor:     snap.Vendor,
+			AccountID:  snap.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data: snap,
+			},
+		})
+	}
+
+	return audits, nil
+}
+
+func (ad Audit) snapshotOperationAuditBuild(kt *kit.Kit, ops []protoaudit.CloudResourceOperationInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(ops))
+	for _, one := range ops {
+		if one.Action != protoaudit.Rollback {
+			return nil, fmt.Errorf("audit action: %s not support", one.Action)
+		}
+		ids = append(ids, one.ResID)
+	}
+	idSnapMap, err := ad.listSnapshot(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(ops))
+	for _, one := range ops {
+		snap, exist := idSnapMap[one.ResID]
+		if !exist {
+			continue
+		}
+
+		action, err := one.Action.ConvAuditAction()
+		if err != nil {
+			return nil, err
+		}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: snap.CloudID,
+			ResName:    snap.Name,
+			ResType:    enumor.SnapshotAuditResType,
+			Action:     action,
+			BkBizID:    snap.BkBizID,
+			Vendor:     snap.Vendor,
+			AccountID:  snap.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data: map[string]interface{}{"disk_id": snap.DiskID, "cloud_disk_id": snap.CloudDiskID},
+			},
+		})
+	}
+
+	return audits, nil
+}
+
+func (ad Audit) listSnapshot(kt *kit.Kit, ids []string) (map[string]tablesnap.SnapshotTable, error) {
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := ad.dao.Snapshot().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list snapshot failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	result := make(map[string]tablesnap.SnapshotTable, len(list.Details))
+	for _, one := range list.Details {
+		result[one.ID] = one
+	}
+
+	return result, nil
+}
+
+func (ad Audit) snapshotPolicyDeleteAuditBuild(kt *kit.Kit, deletes []protoaudit.CloudResourceDeleteInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(deletes))
+	for _, one := range deletes {
+		ids = append(ids, one.ResID)
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := ad.dao.SnapshotPolicy().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list snapshot policy failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(list.Details))
+	for _, one := range list.Details {
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:    one.ID,
+			ResName:  one.Name,
+			ResType:  enumor.SnapshotPolicyAuditResType,
+			Action:   enumor.Delete,
+			BkBizID:  one.BkBizID,
+			Operator: kt.User,
+			Source:   kt.GetRequestSource(),
+			Rid:      kt.Rid,
+			AppCode:  kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data: one,
+			},
+		})
+	}
+
+	return audits, nil
+}
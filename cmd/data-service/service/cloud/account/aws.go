@@ -17,15 +17,15 @@
  * to the current version of the project delivered to anyone in the future.
  */
 
-package cloud
+package account
 
-import "hcm/pkg/api/core"
+import (
+	protocore "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+)
 
-// SecurityGroupTag define security group tag.
-type SecurityGroupTag struct {
-	ID              string         `json:"id"`
-	SecurityGroupID string         `json:"security_group_id"`
-	Key             string         `json:"key"`
-	Value           string         `json:"value"`
-	Revision        *core.Revision `json:"revision"`
+func init() {
+	registerVendor[protocloud.AwsAccountExtensionCreateReq, protocloud.AwsAccountExtensionUpdateReq,
+		protocore.AwsAccountExtension](enumor.Aws)
 }
@@ -42,20 +42,13 @@ func (svc *service) CreateAccount(cts *rest.Contexts) (interface{}, error) {
 	if err := vendor.Validate(); err != nil {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
-	switch vendor {
-	case enumor.TCloud:
-		return createAccount[protocloud.TCloudAccountExtensionCreateReq](vendor, svc, cts)
-	case enumor.Aws:
-		return createAccount[protocloud.AwsAccountExtensionCreateReq](vendor, svc, cts)
-	case enumor.HuaWei:
-		return createAccount[protocloud.HuaWeiAccountExtensionCreateReq](vendor, svc, cts)
-	case enumor.Gcp:
-		return createAccount[protocloud.GcpAccountExtensionCreateReq](vendor, svc, cts)
-	case enumor.Azure:
-		return createAccount[protocloud.AzureAccountExtensionCreateReq](vendor, svc, cts)
-	default:
-		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.create(vendor, svc, cts)
 }
 
 func createAccount[T protocloud.AccountExtensionCreateReq, PT protocloud.SecretEncryptor[T]](vendor enumor.Vendor,
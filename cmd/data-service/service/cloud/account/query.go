@@ -113,20 +113,12 @@ func (svc *service) GetAccount(cts *rest.Contexts) (interface{}, error) {
 	}
 
 	// 转换为最终的数据结构
-	var account interface{}
-	switch enumor.Vendor(dbAccount.Vendor) {
-	case enumor.TCloud:
-		account, err = convertToAccountResult[protocore.TCloudAccountExtension](baseAccount, dbAccount.Extension, svc)
-	case enumor.Aws:
-		account, err = convertToAccountResult[protocore.AwsAccountExtension](baseAccount, dbAccount.Extension, svc)
-	case enumor.HuaWei:
-		account, err = convertToAccountResult[protocore.HuaWeiAccountExtension](baseAccount, dbAccount.Extension, svc)
-	case enumor.Gcp:
-		account, err = convertToAccountResult[protocore.GcpAccountExtension](baseAccount, dbAccount.Extension, svc)
-	case enumor.Azure:
-		account, err = convertToAccountResult[protocore.AzureAccountExtension](baseAccount, dbAccount.Extension, svc)
+	handler, err := getVendor(enumor.Vendor(dbAccount.Vendor))
+	if err != nil {
+		return nil, err
 	}
 
+	account, err := handler.convertResult(baseAccount, dbAccount.Extension, svc)
 	if err != nil {
 		return nil, err
 	}
@@ -343,17 +335,9 @@ func (svc *service) ListAccountWithExtension(cts *rest.Contexts) (interface{}, e
 	details := make([]*protocloud.BaseAccountWithExtensionListResp, 0, len(daoAccountResp.Details))
 	for _, account := range daoAccountResp.Details {
 		var extension map[string]interface{}
-		switch enumor.Vendor(account.Vendor) {
-		case enumor.TCloud:
-			extension, err = convertToAccountExtension[protocore.TCloudAccountExtension](account.Extension, svc)
-		case enumor.Aws:
-			extension, err = convertToAccountExtension[protocore.AwsAccountExtension](account.Extension, svc)
-		case enumor.HuaWei:
-			extension, err = convertToAccountExtension[protocore.HuaWeiAccountExtension](account.Extension, svc)
-		case enumor.Gcp:
-			extension, err = convertToAccountExtension[protocore.GcpAccountExtension](account.Extension, svc)
-		case enumor.Azure:
-			extension, err = convertToAccountExtension[protocore.AzureAccountExtension](account.Extension, svc)
+		// 未注册的厂商没有扩展字段
+		if handler, exist := accountVendors[enumor.Vendor(account.Vendor)]; exist {
+			extension, err = handler.convertExtension(account.Extension, svc)
 		}
 		if err != nil {
 			return nil, fmt.Errorf("json unmarshal extension to vendor extension failed, err: %v", err)
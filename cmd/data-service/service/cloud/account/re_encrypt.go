@@ -0,0 +1,153 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	protocore "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablecloud "hcm/pkg/dal/table/cloud"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+)
+
+// ReEncryptSecret 使用当前主密钥重新加密账号密钥，用于密钥轮转后将存量密钥迁移到新密钥。
+func (svc *service) ReEncryptSecret(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.AccountSecretReEncryptReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := ReEncryptSecret(cts.Kit, svc.dao, svc.cipher, req.AccountIDs)
+	if err != nil {
+		logs.Errorf("re-encrypt account secret failed, err: %v, result: %+v, rid: %s", err, result, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReEncryptSecret 遍历账号(accountIDs为空时遍历全部账号)，将未使用主密钥加密的密钥解密后使用主密钥重新加密并写回。
+// 每个账号单独更新，任务中断后重新执行即可从未完成的账号继续，已使用主密钥加密的账号会被跳过。
+// Note: 读取与写回之间未加锁，需避免与账号密钥的修改同时执行。
+func ReEncryptSecret(kt *kit.Kit, daoSet dao.Set, cipher cryptography.Crypto, accountIDs []string) (
+	*protocloud.AccountSecretReEncryptResult, error) {
+
+	rotator, ok := cipher.(cryptography.KeyRotator)
+	if !ok {
+		return nil, errors.New("cipher does not support key rotation")
+	}
+
+	expr := tools.AllExpression()
+	if len(accountIDs) != 0 {
+		expr = tools.ContainersExpression("id", accountIDs)
+	}
+
+	result := new(protocloud.AccountSecretReEncryptResult)
+	for start := uint32(0); ; start += uint32(core.DefaultMaxPageLimit) {
+		opt := &types.ListOption{
+			Filter: expr,
+			Page:   &core.BasePage{Start: start, Limit: core.DefaultMaxPageLimit, Sort: "id"},
+			Fields: []string{"id", "vendor", "extension"},
+		}
+		listResult, err := daoSet.Account().List(kt, opt)
+		if err != nil {
+			return result, fmt.Errorf("list account failed, err: %v", err)
+		}
+
+		for _, one := range listResult.Details {
+			result.Total++
+
+			reEncrypted, err := reEncryptAccountSecret(kt, daoSet, rotator, one)
+			if err != nil {
+				return result, fmt.Errorf("re-encrypt account(%s) secret failed, err: %v", one.ID, err)
+			}
+
+			if reEncrypted {
+				result.ReEncrypted++
+			} else {
+				result.Skipped++
+			}
+		}
+
+		if len(listResult.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func reEncryptAccountSecret(kt *kit.Kit, daoSet dao.Set, rotator cryptography.KeyRotator,
+	account *tablecloud.AccountTable) (bool, error) {
+
+	field, exists := protocore.AccountSecretKeyFieldMap[enumor.Vendor(account.Vendor)]
+	if !exists {
+		return false, fmt.Errorf("vendor %s not support", account.Vendor)
+	}
+
+	extension := make(map[string]interface{})
+	if err := json.UnmarshalFromString(string(account.Extension), &extension); err != nil {
+		return false, fmt.Errorf("unmarshal extension failed, err: %v", err)
+	}
+
+	secret, _ := extension[field].(string)
+	if len(secret) == 0 || rotator.IsPrimaryKeyEncrypted(secret) {
+		return false, nil
+	}
+
+	plainSecret, err := rotator.DecryptFromBase64(secret)
+	if err != nil {
+		return false, fmt.Errorf("decrypt secret failed, err: %v", err)
+	}
+
+	updatedExtension, err := json.UpdateMerge(map[string]string{field: rotator.EncryptToBase64(plainSecret)},
+		string(account.Extension))
+	if err != nil {
+		return false, fmt.Errorf("json UpdateMerge extension failed, err: %v", err)
+	}
+
+	model := &tablecloud.AccountTable{
+		Extension: tabletype.JsonField(updatedExtension),
+		Reviser:   kt.User,
+	}
+	if err = daoSet.Account().Update(kt, tools.EqualExpression("id", account.ID), model); err != nil {
+		return false, err
+	}
+
+	logs.Infof("re-encrypt account(%s) secret success, rid: %s", account.ID, kt.Rid)
+	return true, nil
+}
@@ -42,6 +42,7 @@ func InitService(cap *capability.Capability) {
 	h.Add("ListAccountWithExtension", "POST", "/accounts/extensions/list", svc.ListAccountWithExtension)
 	h.Add("DeleteAccount", "DELETE", "/accounts", svc.DeleteAccount)
 	h.Add("DeleteValidate", "POST", "/accounts/{account_id}/delete/validate", svc.DeleteValidate)
+	h.Add("ReEncryptSecret", "POST", "/accounts/secrets/re_encrypt", svc.ReEncryptSecret)
 
 	h.Load(cap.WebService)
 }
@@ -45,20 +45,12 @@ func (svc *service) UpdateAccount(cts *rest.Contexts) (interface{}, error) {
 
 	accountID := cts.PathParameter("account_id").String()
 
-	switch vendor {
-	case enumor.TCloud:
-		return updateAccount[protocloud.TCloudAccountExtensionUpdateReq](accountID, svc, cts)
-	case enumor.Aws:
-		return updateAccount[protocloud.AwsAccountExtensionUpdateReq](accountID, svc, cts)
-	case enumor.HuaWei:
-		return updateAccount[protocloud.HuaWeiAccountExtensionUpdateReq](accountID, svc, cts)
-	case enumor.Gcp:
-		return updateAccount[protocloud.GcpAccountExtensionUpdateReq](accountID, svc, cts)
-	case enumor.Azure:
-		return updateAccount[protocloud.AzureAccountExtensionUpdateReq](accountID, svc, cts)
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return handler.update(accountID, svc, cts)
 }
 
 func getAccountFromTable(accountID string, svc *service, cts *rest.Contexts) (*tablecloud.AccountTable, error) {
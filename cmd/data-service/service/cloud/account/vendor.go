@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	"fmt"
+
+	protocore "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/rest"
+)
+
+// accountVendor 厂商账号扩展字段的处理方法，各厂商在 init 中通过 registerVendor 注册。
+type accountVendor struct {
+	create        func(vendor enumor.Vendor, svc *service, cts *rest.Contexts) (interface{}, error)
+	update        func(accountID string, svc *service, cts *rest.Contexts) (interface{}, error)
+	convertResult func(baseAccount *protocore.BaseAccount, dbExtension tabletype.JsonField, svc *service) (
+		interface{}, error)
+	convertExtension func(dbExtension tabletype.JsonField, svc *service) (map[string]interface{}, error)
+}
+
+// accountVendors 只在 init 中写入，之后只读，无需加锁。
+var accountVendors = make(map[enumor.Vendor]accountVendor)
+
+// registerVendor 按厂商账号的创建、更新和查询扩展字段类型注册账号处理方法，重复注册同一厂商会 panic。
+func registerVendor[C protocloud.AccountExtensionCreateReq, U protocloud.AccountExtensionUpdateReq,
+	E protocloud.AccountExtensionGetResp, CPT protocloud.SecretEncryptor[C], UPT protocloud.SecretEncryptor[U],
+	EPT protocloud.SecretDecryptor[E]](vendor enumor.Vendor) {
+
+	if _, exist := accountVendors[vendor]; exist {
+		panic(fmt.Sprintf("vendor: %s account handler is already registered", vendor))
+	}
+
+	accountVendors[vendor] = accountVendor{
+		create: createAccount[C, CPT],
+		update: updateAccount[U, UPT],
+		convertResult: func(baseAccount *protocore.BaseAccount, dbExtension tabletype.JsonField, svc *service) (
+			interface{}, error) {
+
+			return convertToAccountResult[E, EPT](baseAccount, dbExtension, svc)
+		},
+		convertExtension: convertToAccountExtension[E, EPT],
+	}
+}
+
+func getVendor(vendor enumor.Vendor) (accountVendor, error) {
+	handler, exist := accountVendors[vendor]
+	if !exist {
+		return accountVendor{}, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	}
+
+	return handler, nil
+}
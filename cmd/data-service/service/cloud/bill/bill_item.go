@@ -0,0 +1,354 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	typesbill "hcm/pkg/dal/dao/types/bill"
+	tablebill "hcm/pkg/dal/table/cloud/bill"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/math"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitBillItemService initialize the bill item service.
+func InitBillItemService(cap *capability.Capability) {
+	svc := &billItemSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+	h.Add("ListBillItem", "POST", "/bills/items/list", svc.ListBillItem)
+	h.Add("BatchCreateBillItem", "POST", "/bills/items/batch/create", svc.BatchCreateBillItem)
+	h.Add("BatchDeleteBillItem", "DELETE", "/bills/items/batch", svc.BatchDeleteBillItem)
+	h.Add("ReplaceBillItem", "POST", "/bills/items/replace", svc.ReplaceBillItem)
+	h.Add("ListBillCostAllocation", "POST", "/bills/items/allocations/list", svc.ListBillCostAllocation)
+
+	h.Load(cap.WebService)
+}
+
+type billItemSvc struct {
+	dao dao.Set
+}
+
+// BatchCreateBillItem batch create bill item.
+func (svc *billItemSvc) BatchCreateBillItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.BillItemBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	itemIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.BillItem().CreateWithTx(cts.Kit, txn, convertBillItemTables(cts.Kit, req.Items))
+		if err != nil {
+			return nil, fmt.Errorf("create bill item failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := itemIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create bill item but return id type is not string, id type: %v",
+			reflect.TypeOf(itemIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+func convertBillItemTables(kt *kit.Kit, reqs []dsbill.BillItemCreateReq) []tablebill.BillItemTable {
+	items := make([]tablebill.BillItemTable, 0, len(reqs))
+	for _, one := range reqs {
+		items = append(items, tablebill.BillItemTable{
+			Vendor:      one.Vendor,
+			AccountID:   one.AccountID,
+			BillMonth:   one.BillMonth,
+			ProductCode: one.ProductCode,
+			ProductName: one.ProductName,
+			CloudResID:  one.CloudResID,
+			ResName:     one.ResName,
+			Region:      one.Region,
+			Cost:        one.Cost,
+			Currency:    one.Currency,
+			Extension:   one.Extension,
+			Creator:     kt.User,
+			Reviser:     kt.User,
+		})
+	}
+
+	return items
+}
+
+// ReplaceBillItem replace all bill items of the account in the bill month, deleting the existing items and creating
+// the new items are in one transaction, so readers never see a partial month.
+func (svc *billItemSvc) ReplaceBillItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.BillItemReplaceReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		delFilter := &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: req.Vendor},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: req.AccountID},
+				&filter.AtomRule{Field: "bill_month", Op: filter.Equal.Factory(), Value: req.BillMonth},
+			},
+		}
+		if err := svc.dao.BillItem().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+			return nil, err
+		}
+
+		for _, batch := range slice.Split(req.Items, constant.BatchOperationMaxLimit) {
+			if _, err := svc.dao.BillItem().CreateWithTx(cts.Kit, txn, convertBillItemTables(cts.Kit,
+				batch)); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("replace %s bill item failed, err: %v, account: %s, month: %s, rid: %s", req.Vendor, err,
+			req.AccountID, req.BillMonth, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBillItem list bill item.
+func (svc *billItemSvc) ListBillItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.BillItem().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list bill item failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list bill item failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dsbill.BillItemListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]cloud.BillItem, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, convertBillItem(one))
+	}
+
+	return &dsbill.BillItemListResult{Details: details}, nil
+}
+
+func convertBillItem(one tablebill.BillItemTable) cloud.BillItem {
+	return cloud.BillItem{
+		ID:          one.ID,
+		Vendor:      one.Vendor,
+		AccountID:   one.AccountID,
+		BillMonth:   one.BillMonth,
+		ProductCode: one.ProductCode,
+		ProductName: one.ProductName,
+		CloudResID:  one.CloudResID,
+		ResName:     one.ResName,
+		Region:      one.Region,
+		Cost:        one.Cost,
+		Currency:    one.Currency,
+		Extension:   one.Extension,
+		Revision: &core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+// BatchDeleteBillItem batch delete bill item.
+func (svc *billItemSvc) BatchDeleteBillItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.BillItem().DeleteWithTx(cts.Kit, txn, req.Filter); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete bill item failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBillCostAllocation list bill cost allocation by biz, vendor and resource type.
+func (svc *billItemSvc) ListBillCostAllocation(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.BillCostAllocationReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typesbill.BillCostAllocationOption{
+		BillMonth:  req.BillMonth,
+		Vendors:    req.Vendors,
+		AccountIDs: req.AccountIDs,
+		BkBizIDs:   req.BkBizIDs,
+	}
+	allocations, err := svc.dao.BillItem().ListCostAllocation(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list bill cost allocation failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(req.ReportCurrency) != 0 {
+		allocations, err = convertAllocationCurrency(cts.Kit, svc.dao, req.BillMonth, req.ReportCurrency, allocations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	details := make([]cloud.BillCostAllocation, 0, len(allocations))
+	for _, one := range allocations {
+		details = append(details, cloud.BillCostAllocation{
+			BkBizID:   one.BkBizID,
+			Vendor:    one.Vendor,
+			ResType:   one.ResType,
+			Currency:  one.Currency,
+			Cost:      one.Cost,
+			ItemCount: one.ItemCount,
+		})
+	}
+
+	return &dsbill.BillCostAllocationResult{Details: details}, nil
+}
+
+// costScale is the decimal scale of bill cost column.
+const costScale = 10
+
+// convertAllocationCurrency convert the cost of allocations to report currency using exchange rate of the month,
+// allocations with the same biz, vendor and resource type are merged after converted.
+func convertAllocationCurrency(kt *kit.Kit, daoSet dao.Set, month, reportCurrency string,
+	allocations []typesbill.BillCostAllocation) ([]typesbill.BillCostAllocation, error) {
+
+	fromCurrencies := make([]string, 0)
+	for _, one := range allocations {
+		if one.Currency != reportCurrency && !slice.IsItemInSlice(fromCurrencies, one.Currency) {
+			fromCurrencies = append(fromCurrencies, one.Currency)
+		}
+	}
+
+	rates, err := getExchangeRates(kt, daoSet, month, reportCurrency, fromCurrencies)
+	if err != nil {
+		return nil, err
+	}
+
+	type allocKey struct {
+		bkBizID int64
+		vendor  enumor.Vendor
+		resType string
+	}
+	keys := make([]allocKey, 0)
+	costs := make(map[allocKey]math.Decimal)
+	counts := make(map[allocKey]uint64)
+	for _, one := range allocations {
+		cost, err := math.NewDecimalFromString(one.Cost)
+		if err != nil {
+			return nil, fmt.Errorf("parse cost %s failed, err: %v", one.Cost, err)
+		}
+
+		if one.Currency != reportCurrency {
+			// round to the scale of cost column, otherwise each conversion adds fractional digits.
+			cost = cost.Mul(rates[one.Currency]).Round(costScale)
+		}
+
+		key := allocKey{bkBizID: one.BkBizID, vendor: one.Vendor, resType: one.ResType}
+		if _, exist := costs[key]; !exist {
+			keys = append(keys, key)
+		}
+		costs[key] = costs[key].Add(cost)
+		counts[key] += one.ItemCount
+	}
+
+	result := make([]typesbill.BillCostAllocation, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, typesbill.BillCostAllocation{
+			BkBizID:   key.bkBizID,
+			Vendor:    key.vendor,
+			ResType:   key.resType,
+			Currency:  reportCurrency,
+			Cost:      costs[key].ToString(),
+			ItemCount: counts[key],
+		})
+	}
+
+	return result, nil
+}
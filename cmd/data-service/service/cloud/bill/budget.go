@@ -0,0 +1,389 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablebill "hcm/pkg/dal/table/cloud/bill"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitBudgetService initialize the budget service.
+func InitBudgetService(cap *capability.Capability) {
+	svc := &budgetSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+	h.Add("ListBudget", "POST", "/budgets/list", svc.ListBudget)
+	h.Add("BatchCreateBudget", "POST", "/budgets/batch/create", svc.BatchCreateBudget)
+	h.Add("BatchUpdateBudget", "PATCH", "/budgets/batch", svc.BatchUpdateBudget)
+	h.Add("BatchDeleteBudget", "DELETE", "/budgets/batch", svc.BatchDeleteBudget)
+
+	h.Add("ListBudgetAlert", "POST", "/budgets/alerts/list", svc.ListBudgetAlert)
+	h.Add("BatchCreateBudgetAlert", "POST", "/budgets/alerts/batch/create", svc.BatchCreateBudgetAlert)
+	h.Add("BatchUpdateBudgetAlert", "PATCH", "/budgets/alerts/batch", svc.BatchUpdateBudgetAlert)
+
+	h.Load(cap.WebService)
+}
+
+type budgetSvc struct {
+	dao dao.Set
+}
+
+// BatchCreateBudget batch create budget.
+func (svc *budgetSvc) BatchCreateBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.BudgetBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	budgetIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		budgets := make([]tablebill.BudgetTable, 0, len(req.Budgets))
+		for _, one := range req.Budgets {
+			budgets = append(budgets, tablebill.BudgetTable{
+				Name:       one.Name,
+				ScopeType:  one.ScopeType,
+				ScopeValue: one.ScopeValue,
+				Amount:     one.Amount,
+				Currency:   one.Currency,
+				Thresholds: one.Thresholds,
+				Memo:       one.Memo,
+				Creator:    cts.Kit.User,
+				Reviser:    cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.Budget().CreateWithTx(cts.Kit, txn, budgets)
+		if err != nil {
+			return nil, fmt.Errorf("create budget failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := budgetIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create budget but return id type is not string, id type: %v",
+			reflect.TypeOf(budgetIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateBudget batch update budget.
+func (svc *budgetSvc) BatchUpdateBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.BudgetBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Budgets {
+			budget := &tablebill.BudgetTable{
+				Name:     one.Name,
+				Amount:   one.Amount,
+				Currency: one.Currency,
+				Memo:     one.Memo,
+				Reviser:  cts.Kit.User,
+			}
+			if len(one.Thresholds) != 0 {
+				budget.Thresholds = tabletype.Int64Array(one.Thresholds)
+			}
+
+			if err := svc.dao.Budget().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", one.ID),
+				budget); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update budget failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBudget list budget.
+func (svc *budgetSvc) ListBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.Budget().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list budget failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list budget failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dsbill.BudgetListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]cloud.Budget, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, cloud.Budget{
+			ID:         one.ID,
+			Name:       one.Name,
+			ScopeType:  one.ScopeType,
+			ScopeValue: one.ScopeValue,
+			Amount:     one.Amount,
+			Currency:   one.Currency,
+			Thresholds: one.Thresholds,
+			Memo:       one.Memo,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dsbill.BudgetListResult{Details: details}, nil
+}
+
+// BatchDeleteBudget batch delete budget and its alerts.
+func (svc *budgetSvc) BatchDeleteBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	listResp, err := svc.dao.Budget().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list budget failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list budget failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.BudgetAlert().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("budget_id", delIDs)); err != nil {
+			return nil, err
+		}
+
+		if err := svc.dao.Budget().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", delIDs)); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete budget failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchCreateBudgetAlert batch create budget alert.
+func (svc *budgetSvc) BatchCreateBudgetAlert(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.BudgetAlertBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	alertIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		alerts := make([]tablebill.BudgetAlertTable, 0, len(req.Alerts))
+		for _, one := range req.Alerts {
+			alerts = append(alerts, tablebill.BudgetAlertTable{
+				BudgetID:     one.BudgetID,
+				ScopeType:    one.ScopeType,
+				ScopeValue:   one.ScopeValue,
+				BillMonth:    one.BillMonth,
+				Threshold:    one.Threshold,
+				Amount:       one.Amount,
+				Spend:        one.Spend,
+				Currency:     one.Currency,
+				NotifyStatus: one.NotifyStatus,
+				NotifyMsg:    one.NotifyMsg,
+				Creator:      cts.Kit.User,
+				Reviser:      cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.BudgetAlert().CreateWithTx(cts.Kit, txn, alerts)
+		if err != nil {
+			return nil, fmt.Errorf("create budget alert failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := alertIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create budget alert but return id type is not string, id type: %v",
+			reflect.TypeOf(alertIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateBudgetAlert batch update budget alert.
+func (svc *budgetSvc) BatchUpdateBudgetAlert(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.BudgetAlertBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Alerts {
+			alert := &tablebill.BudgetAlertTable{
+				Spend:        one.Spend,
+				NotifyStatus: one.NotifyStatus,
+				NotifyMsg:    one.NotifyMsg,
+				Reviser:      cts.Kit.User,
+			}
+
+			if err := svc.dao.BudgetAlert().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", one.ID),
+				alert); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update budget alert failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBudgetAlert list budget alert.
+func (svc *budgetSvc) ListBudgetAlert(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.BudgetAlert().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list budget alert failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list budget alert failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dsbill.BudgetAlertListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]cloud.BudgetAlert, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, cloud.BudgetAlert{
+			ID:           one.ID,
+			BudgetID:     one.BudgetID,
+			ScopeType:    one.ScopeType,
+			ScopeValue:   one.ScopeValue,
+			BillMonth:    one.BillMonth,
+			Threshold:    one.Threshold,
+			Amount:       one.Amount,
+			Spend:        one.Spend,
+			Currency:     one.Currency,
+			NotifyStatus: one.NotifyStatus,
+			NotifyMsg:    one.NotifyMsg,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dsbill.BudgetAlertListResult{Details: details}, nil
+}
@@ -0,0 +1,256 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablebill "hcm/pkg/dal/table/cloud/bill"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/math"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitExchangeRateService initialize the exchange rate service.
+func InitExchangeRateService(cap *capability.Capability) {
+	svc := &exchangeRateSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+	h.Add("ListExchangeRate", "POST", "/exchange_rates/list", svc.ListExchangeRate)
+	h.Add("BatchCreateExchangeRate", "POST", "/exchange_rates/batch/create", svc.BatchCreateExchangeRate)
+	h.Add("BatchUpdateExchangeRate", "PATCH", "/exchange_rates/batch", svc.BatchUpdateExchangeRate)
+	h.Add("BatchDeleteExchangeRate", "DELETE", "/exchange_rates/batch", svc.BatchDeleteExchangeRate)
+
+	h.Load(cap.WebService)
+}
+
+type exchangeRateSvc struct {
+	dao dao.Set
+}
+
+// BatchCreateExchangeRate batch create exchange rate.
+func (svc *exchangeRateSvc) BatchCreateExchangeRate(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.ExchangeRateBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rateIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		rates := make([]tablebill.ExchangeRateTable, 0, len(req.Rates))
+		for _, one := range req.Rates {
+			rates = append(rates, tablebill.ExchangeRateTable{
+				FromCurrency: one.FromCurrency,
+				ToCurrency:   one.ToCurrency,
+				Month:        one.Month,
+				Rate:         one.Rate,
+				Creator:      cts.Kit.User,
+				Reviser:      cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.ExchangeRate().CreateWithTx(cts.Kit, txn, rates)
+		if err != nil {
+			return nil, fmt.Errorf("create exchange rate failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := rateIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create exchange rate but return id type is not string, id type: %v",
+			reflect.TypeOf(rateIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateExchangeRate batch update exchange rate.
+func (svc *exchangeRateSvc) BatchUpdateExchangeRate(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.ExchangeRateBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Rates {
+			rate := &tablebill.ExchangeRateTable{
+				Rate:    one.Rate,
+				Reviser: cts.Kit.User,
+			}
+			if err := svc.dao.ExchangeRate().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", one.ID),
+				rate); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update exchange rate failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListExchangeRate list exchange rate.
+func (svc *exchangeRateSvc) ListExchangeRate(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.ExchangeRate().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list exchange rate failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list exchange rate failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dsbill.ExchangeRateListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]cloud.ExchangeRate, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, cloud.ExchangeRate{
+			ID:           one.ID,
+			FromCurrency: one.FromCurrency,
+			ToCurrency:   one.ToCurrency,
+			Month:        one.Month,
+			Rate:         one.Rate,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dsbill.ExchangeRateListResult{Details: details}, nil
+}
+
+// BatchDeleteExchangeRate batch delete exchange rate.
+func (svc *exchangeRateSvc) BatchDeleteExchangeRate(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.ExchangeRate().DeleteWithTx(cts.Kit, txn, req.Filter); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete exchange rate failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// getExchangeRates get the exchange rates of the month which convert from currencies to the target currency,
+// returns map of from currency to rate.
+func getExchangeRates(kt *kit.Kit, daoSet dao.Set, month, toCurrency string, fromCurrencies []string) (
+	map[string]math.Decimal, error) {
+
+	rates := make(map[string]math.Decimal, len(fromCurrencies))
+	if len(fromCurrencies) == 0 {
+		return rates, nil
+	}
+
+	opt := &types.ListOption{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "month", Op: filter.Equal.Factory(), Value: month},
+				filter.AtomRule{Field: "to_currency", Op: filter.Equal.Factory(), Value: toCurrency},
+				filter.AtomRule{Field: "from_currency", Op: filter.In.Factory(), Value: fromCurrencies},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := daoSet.ExchangeRate().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list exchange rate failed, err: %v, month: %s, rid: %s", err, month, kt.Rid)
+		return nil, err
+	}
+
+	for _, one := range result.Details {
+		rate, err := math.NewDecimalFromString(one.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("parse exchange rate %s failed, err: %v", one.Rate, err)
+		}
+		rates[one.FromCurrency] = rate
+	}
+
+	for _, currency := range fromCurrencies {
+		if _, exist := rates[currency]; !exist {
+			return nil, errf.Newf(errf.RecordNotFound, "exchange rate from %s to %s of %s is not configured",
+				currency, toCurrency, month)
+		}
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,283 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bucket ...
+package bucket
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corebucket "hcm/pkg/api/core/cloud/bucket"
+	protobucket "hcm/pkg/api/data-service/cloud/bucket"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablebucket "hcm/pkg/dal/table/cloud/bucket"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the bucket service
+func InitService(cap *capability.Capability) {
+	svc := &bucketSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateBucket", http.MethodPost, "/buckets/batch/create", svc.BatchCreateBucket)
+	h.Add("BatchUpdateBucket", http.MethodPatch, "/buckets/batch/update", svc.BatchUpdateBucket)
+	h.Add("BatchUpdateBucketCommonInfo", http.MethodPatch, "/buckets/common/info/batch/update",
+		svc.BatchUpdateBucketCommonInfo)
+	h.Add("ListBucket", http.MethodPost, "/buckets/list", svc.ListBucket)
+	h.Add("BatchDeleteBucket", http.MethodDelete, "/buckets/batch", svc.BatchDeleteBucket)
+
+	h.Load(cap.WebService)
+}
+
+type bucketSvc struct {
+	dao dao.Set
+}
+
+// BatchCreateBucket bucket.
+func (svc *bucketSvc) BatchCreateBucket(cts *rest.Contexts) (interface{}, error) {
+	req := new(protobucket.BucketBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablebucket.BucketTable, 0, len(req.Buckets))
+		for _, one := range req.Buckets {
+			models = append(models, &tablebucket.BucketTable{
+				Vendor:              one.Vendor,
+				AccountID:           one.AccountID,
+				Region:              one.Region,
+				CloudID:             one.CloudID,
+				Name:                one.Name,
+				BkBizID:             one.BkBizID,
+				Encrypted:           converter.ValToPtr(one.Encrypted),
+				EncryptionType:      one.EncryptionType,
+				VersioningEnabled:   converter.ValToPtr(one.VersioningEnabled),
+				PublicRead:          converter.ValToPtr(one.PublicRead),
+				PublicWrite:         converter.ValToPtr(one.PublicWrite),
+				PublicAccessReasons: one.PublicAccessReasons,
+				CloudCreatedTime:    one.CloudCreatedTime,
+				Creator:             cts.Kit.User,
+				Reviser:             cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.Bucket().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create bucket failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create bucket but return id type is not []string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateBucket bucket.
+func (svc *bucketSvc) BatchUpdateBucket(cts *rest.Contexts) (interface{}, error) {
+	req := new(protobucket.BucketBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Buckets {
+			update := &tablebucket.BucketTable{
+				Region:              one.Region,
+				Name:                one.Name,
+				Encrypted:           one.Encrypted,
+				EncryptionType:      one.EncryptionType,
+				VersioningEnabled:   one.VersioningEnabled,
+				PublicRead:          one.PublicRead,
+				PublicWrite:         one.PublicWrite,
+				PublicAccessReasons: one.PublicAccessReasons,
+				Reviser:             cts.Kit.User,
+			}
+
+			if err := svc.dao.Bucket().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				logs.Errorf("update bucket by id failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+				return nil, fmt.Errorf("update bucket failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchUpdateBucketCommonInfo bucket.
+func (svc *bucketSvc) BatchUpdateBucketCommonInfo(cts *rest.Contexts) (interface{}, error) {
+	req := new(protobucket.BucketCommonInfoBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	updateFilter := tools.ContainersExpression("id", req.IDs)
+	updateField := &tablebucket.BucketTable{
+		BkBizID: req.BkBizID,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.Bucket().Update(cts.Kit, updateFilter, updateField); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBucket bucket.
+func (svc *bucketSvc) ListBucket(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.Bucket().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list bucket failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list bucket failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protobucket.BucketListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corebucket.Bucket, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corebucket.Bucket{
+			ID:                  one.ID,
+			Vendor:              one.Vendor,
+			AccountID:           one.AccountID,
+			Region:              one.Region,
+			CloudID:             one.CloudID,
+			Name:                one.Name,
+			BkBizID:             one.BkBizID,
+			Encrypted:           converter.PtrToVal(one.Encrypted),
+			EncryptionType:      one.EncryptionType,
+			VersioningEnabled:   converter.PtrToVal(one.VersioningEnabled),
+			PublicRead:          converter.PtrToVal(one.PublicRead),
+			PublicWrite:         converter.PtrToVal(one.PublicWrite),
+			PublicAccessReasons: one.PublicAccessReasons,
+			CloudCreatedTime:    one.CloudCreatedTime,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protobucket.BucketListResult{Details: details}, nil
+}
+
+// BatchDeleteBucket bucket.
+func (svc *bucketSvc) BatchDeleteBucket(cts *rest.Contexts) (interface{}, error) {
+	req := new(protobucket.BucketBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.Bucket().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list bucket failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list bucket failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	delFilter := tools.ContainersExpression("id", delIDs)
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.Bucket().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete bucket failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -143,6 +143,12 @@ var assignResAuditTypeMap = map[enumor.CloudResourceType]enumor.AuditResourceTyp
 	enumor.RouteTableCloudResType:       enumor.RouteTableAuditResType,
 	enumor.GcpFirewallRuleCloudResType:  enumor.GcpFirewallRuleAuditResType,
 	enumor.NetworkInterfaceCloudResType: enumor.NetworkInterfaceAuditResType,
+	enumor.LoadBalancerCloudResType:     enumor.LoadBalancerAuditResType,
+	enumor.NatGatewayCloudResType:       enumor.NatGatewayAuditResType,
+	enumor.SnapshotCloudResType:         enumor.SnapshotAuditResType,
+	enumor.KeyPairCloudResType:          enumor.CloudKeyPairAuditResType,
+	enumor.BucketCloudResType:           enumor.BucketAuditResType,
+	enumor.VpcPeeringCloudResType:       enumor.VpcPeeringAuditResType,
 }
 
 // AssignResourceToBiz assign an account's cloud resource to biz, **only for ui**.
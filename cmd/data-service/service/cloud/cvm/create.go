@@ -46,20 +46,12 @@ func (svc *cvmSvc) BatchCreateCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return batchCreateCvm[corecvm.TCloudCvmExtension](cts, svc, vendor)
-	case enumor.Aws:
-		return batchCreateCvm[corecvm.AwsCvmExtension](cts, svc, vendor)
-	case enumor.HuaWei:
-		return batchCreateCvm[corecvm.HuaWeiCvmExtension](cts, svc, vendor)
-	case enumor.Azure:
-		return batchCreateCvm[corecvm.AzureCvmExtension](cts, svc, vendor)
-	case enumor.Gcp:
-		return batchCreateCvm[corecvm.GcpCvmExtension](cts, svc, vendor)
-	default:
-		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.batchCreate(cts, svc, vendor)
 }
 
 func batchCreateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor enumor.Vendor) (interface{}, error) {
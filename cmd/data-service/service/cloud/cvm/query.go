@@ -92,21 +92,12 @@ func (svc *cvmSvc) GetCvm(cts *rest.Contexts) (interface{}, error) {
 
 	base := convTableToBaseCvm(cvmTable)
 
-	switch cvmTable.Vendor {
-	case enumor.TCloud:
-		return convCvmGetResult[corecvm.TCloudCvmExtension](base, cvmTable.Extension)
-	case enumor.Aws:
-		return convCvmGetResult[corecvm.AwsCvmExtension](base, cvmTable.Extension)
-	case enumor.HuaWei:
-		return convCvmGetResult[corecvm.HuaWeiCvmExtension](base, cvmTable.Extension)
-	case enumor.Azure:
-		return convCvmGetResult[corecvm.AzureCvmExtension](base, cvmTable.Extension)
-	case enumor.Gcp:
-		return convCvmGetResult[corecvm.GcpCvmExtension](base, cvmTable.Extension)
-
-	default:
-		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	handler, err := getVendor(cvmTable.Vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.convertResult(base, cvmTable.Extension)
 }
 
 func convCvmGetResult[T corecvm.Extension](base *corecvm.BaseCvm, extJson tabletype.JsonField) (
@@ -213,21 +204,12 @@ func (svc *cvmSvc) ListCvmExt(cts *rest.Contexts) (interface{}, error) {
 		return &protocloud.CvmExtListResult[corecvm.TCloudCvmExtension]{Count: result.Count}, nil
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return convCvmListResult[corecvm.TCloudCvmExtension](result.Details)
-	case enumor.Aws:
-		return convCvmListResult[corecvm.AwsCvmExtension](result.Details)
-	case enumor.HuaWei:
-		return convCvmListResult[corecvm.HuaWeiCvmExtension](result.Details)
-	case enumor.Azure:
-		return convCvmListResult[corecvm.AzureCvmExtension](result.Details)
-	case enumor.Gcp:
-		return convCvmListResult[corecvm.GcpCvmExtension](result.Details)
-
-	default:
-		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.convertExtList(result.Details)
 }
 
 func convCvmListResult[T corecvm.Extension](tables []tablecvm.Table) (*protocloud.CvmExtListResult[T], error) {
@@ -24,7 +24,6 @@ import (
 	"time"
 
 	"hcm/pkg/api/core"
-	corecvm "hcm/pkg/api/core/cloud/cvm"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/dal/dao/tools"
@@ -51,6 +50,10 @@ func SyncCvmToCmdb(kt *kit.Kit, accountID string, bkBizID int64) error {
 		return fmt.Errorf("account: %s not found", accountID)
 	}
 	vendor := enumor.Vendor(list.Details[0].Vendor)
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return err
+	}
 
 	listCvmOpt := &types.ListOption{
 		Filter: tools.EqualExpression("account_id", accountID),
@@ -76,23 +79,7 @@ func SyncCvmToCmdb(kt *kit.Kit, accountID string, bkBizID int64) error {
 			result.Details[index].BkBizID = bkBizID
 		}
 
-		switch vendor {
-		case enumor.TCloud:
-			err = upsertCmdbHosts[corecvm.TCloudCvmExtension](svc, kt, enumor.TCloud,
-				converter.SliceToPtr(result.Details))
-		case enumor.Aws:
-			err = upsertCmdbHosts[corecvm.AwsCvmExtension](svc, kt, enumor.Aws,
-				converter.SliceToPtr(result.Details))
-		case enumor.HuaWei:
-			err = upsertCmdbHosts[corecvm.HuaWeiCvmExtension](svc, kt, enumor.HuaWei,
-				converter.SliceToPtr(result.Details))
-		case enumor.Gcp:
-			err = upsertCmdbHosts[corecvm.GcpCvmExtension](svc, kt, enumor.Gcp,
-				converter.SliceToPtr(result.Details))
-		case enumor.Azure:
-			err = upsertCmdbHosts[corecvm.AzureCvmExtension](svc, kt, enumor.Azure,
-				converter.SliceToPtr(result.Details))
-		}
+		err = handler.upsertCmdbHosts(svc, kt, vendor, converter.SliceToPtr(result.Details))
 		if err != nil {
 			logs.Errorf("upsertCmdbHosts failed, err: %v, rid; %s", err, kt.Rid)
 			return err
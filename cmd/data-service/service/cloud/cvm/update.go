@@ -47,20 +47,12 @@ func (svc *cvmSvc) BatchUpdateCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return batchUpdateCvm[corecvm.TCloudCvmExtension](cts, svc, vendor)
-	case enumor.Aws:
-		return batchUpdateCvm[corecvm.AwsCvmExtension](cts, svc, vendor)
-	case enumor.HuaWei:
-		return batchUpdateCvm[corecvm.HuaWeiCvmExtension](cts, svc, vendor)
-	case enumor.Azure:
-		return batchUpdateCvm[corecvm.AzureCvmExtension](cts, svc, vendor)
-	case enumor.Gcp:
-		return batchUpdateCvm[corecvm.GcpCvmExtension](cts, svc, vendor)
-	default:
-		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.batchUpdate(cts, svc, vendor)
 }
 
 func batchUpdateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor enumor.Vendor) (interface{}, error) {
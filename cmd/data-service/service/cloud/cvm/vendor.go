@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"fmt"
+
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	"hcm/pkg/criteria/enumor"
+	tablecvm "hcm/pkg/dal/table/cloud/cvm"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// cvmVendor 厂商主机扩展字段的处理方法，各厂商在 init 中通过 registerVendor 注册。
+type cvmVendor struct {
+	batchCreate     func(cts *rest.Contexts, svc *cvmSvc, vendor enumor.Vendor) (interface{}, error)
+	batchUpdate     func(cts *rest.Contexts, svc *cvmSvc, vendor enumor.Vendor) (interface{}, error)
+	convertResult   func(base *corecvm.BaseCvm, extJson tabletype.JsonField) (interface{}, error)
+	convertExtList  func(tables []tablecvm.Table) (interface{}, error)
+	upsertCmdbHosts func(svc *cvmSvc, kt *kit.Kit, vendor enumor.Vendor, models []*tablecvm.Table) error
+}
+
+// cvmVendors 只在 init 中写入，之后只读，无需加锁。
+var cvmVendors = make(map[enumor.Vendor]cvmVendor)
+
+// registerVendor 按厂商主机扩展字段类型注册主机处理方法，重复注册同一厂商会 panic。
+func registerVendor[T corecvm.Extension](vendor enumor.Vendor) {
+	if _, exist := cvmVendors[vendor]; exist {
+		panic(fmt.Sprintf("vendor: %s cvm handler is already registered", vendor))
+	}
+
+	cvmVendors[vendor] = cvmVendor{
+		batchCreate: batchCreateCvm[T],
+		batchUpdate: batchUpdateCvm[T],
+		convertResult: func(base *corecvm.BaseCvm, extJson tabletype.JsonField) (interface{}, error) {
+			return convCvmGetResult[T](base, extJson)
+		},
+		convertExtList: func(tables []tablecvm.Table) (interface{}, error) {
+			return convCvmListResult[T](tables)
+		},
+		upsertCmdbHosts: upsertCmdbHosts[T],
+	}
+}
+
+func getVendor(vendor enumor.Vendor) (cvmVendor, error) {
+	handler, exist := cvmVendors[vendor]
+	if !exist {
+		return cvmVendor{}, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+
+	return handler, nil
+}
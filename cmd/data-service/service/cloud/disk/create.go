@@ -40,20 +40,13 @@ func (dSvc *diskSvc) BatchCreateDiskExt(cts *rest.Contexts) (interface{}, error)
 	if err := vendor.Validate(); err != nil {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
-	switch vendor {
-	case enumor.TCloud:
-		return batchCreateDiskExt[dataproto.TCloudDiskExtensionCreateReq](cts, dSvc, vendor)
-	case enumor.Aws:
-		return batchCreateDiskExt[dataproto.AwsDiskExtensionCreateReq](cts, dSvc, vendor)
-	case enumor.Gcp:
-		return batchCreateDiskExt[dataproto.GcpDiskExtensionCreateReq](cts, dSvc, vendor)
-	case enumor.Azure:
-		return batchCreateDiskExt[dataproto.AzureDiskExtensionCreateReq](cts, dSvc, vendor)
-	case enumor.HuaWei:
-		return batchCreateDiskExt[dataproto.HuaWeiDiskExtensionCreateReq](cts, dSvc, vendor)
-	default:
-		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.batchCreate(cts, dSvc, vendor)
 }
 
 func batchCreateDiskExt[T dataproto.DiskExtensionCreateReq](
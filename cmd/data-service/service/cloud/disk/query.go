@@ -58,20 +58,12 @@ func (dSvc *diskSvc) RetrieveDiskExt(cts *rest.Contexts) (interface{}, error) {
 	}
 
 	diskData := data.Details[0]
-	switch vendor {
-	case enumor.TCloud:
-		return toProtoDiskExtResult[dataproto.TCloudDiskExtensionResult](diskData)
-	case enumor.Aws:
-		return toProtoDiskExtResult[dataproto.AwsDiskExtensionResult](diskData)
-	case enumor.Gcp:
-		return toProtoDiskExtResult[dataproto.GcpDiskExtensionResult](diskData)
-	case enumor.Azure:
-		return toProtoDiskExtResult[dataproto.AzureDiskExtensionResult](diskData)
-	case enumor.HuaWei:
-		return toProtoDiskExtResult[dataproto.HuaWeiDiskExtensionResult](diskData)
-	default:
-		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.convertResult(diskData)
 }
 
 // ListDisk 查询云盘列表
@@ -131,20 +123,12 @@ func (dSvc *diskSvc) ListDiskExt(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return toProtoDiskExtListResult[dataproto.TCloudDiskExtensionResult](data)
-	case enumor.Aws:
-		return toProtoDiskExtListResult[dataproto.AwsDiskExtensionResult](data)
-	case enumor.Gcp:
-		return toProtoDiskExtListResult[dataproto.GcpDiskExtensionResult](data)
-	case enumor.Azure:
-		return toProtoDiskExtListResult[dataproto.AzureDiskExtensionResult](data)
-	case enumor.HuaWei:
-		return toProtoDiskExtListResult[dataproto.HuaWeiDiskExtensionResult](data)
-	default:
-		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.convertExtList(data)
 }
 
 // CountDisk 统计云盘数量
@@ -44,20 +44,13 @@ func (dSvc *diskSvc) BatchUpdateDiskExt(cts *rest.Contexts) (interface{}, error)
 	if err := vendor.Validate(); err != nil {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
-	switch vendor {
-	case enumor.TCloud:
-		return batchUpdateDiskExt[dataproto.TCloudDiskExtensionUpdateReq](cts, dSvc)
-	case enumor.Aws:
-		return batchUpdateDiskExt[dataproto.AwsDiskExtensionUpdateReq](cts, dSvc)
-	case enumor.Gcp:
-		return batchUpdateDiskExt[dataproto.GcpDiskExtensionUpdateReq](cts, dSvc)
-	case enumor.Azure:
-		return batchUpdateDiskExt[dataproto.AzureDiskExtensionUpdateReq](cts, dSvc)
-	case enumor.HuaWei:
-		return batchUpdateDiskExt[dataproto.HuaWeiDiskExtensionUpdateReq](cts, dSvc)
-	default:
-		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+
+	handler, err := getVendor(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.batchUpdate(cts, dSvc)
 }
 
 // BatchUpdateDisk ...
@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	"fmt"
+
+	dataproto "hcm/pkg/api/data-service/cloud/disk"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types/cloud"
+	tablecloud "hcm/pkg/dal/table/cloud/disk"
+	"hcm/pkg/rest"
+)
+
+// diskVendor 厂商云盘扩展字段的处理方法，各厂商在 init 中通过 registerVendor 注册。
+type diskVendor struct {
+	batchCreate    func(cts *rest.Contexts, dSvc *diskSvc, vendor enumor.Vendor) (interface{}, error)
+	batchUpdate    func(cts *rest.Contexts, dSvc *diskSvc) (interface{}, error)
+	convertResult  func(m *tablecloud.DiskModel) (interface{}, error)
+	convertExtList func(data *cloud.DiskListResult) (interface{}, error)
+}
+
+// diskVendors 只在 init 中写入，之后只读，无需加锁。
+var diskVendors = make(map[enumor.Vendor]diskVendor)
+
+// registerVendor 按厂商云盘的创建、更新和查询扩展字段类型注册云盘处理方法，重复注册同一厂商会 panic。
+func registerVendor[C dataproto.DiskExtensionCreateReq, U dataproto.DiskExtensionUpdateReq,
+	E dataproto.DiskExtensionResult](vendor enumor.Vendor) {
+
+	if _, exist := diskVendors[vendor]; exist {
+		panic(fmt.Sprintf("vendor: %s disk handler is already registered", vendor))
+	}
+
+	diskVendors[vendor] = diskVendor{
+		batchCreate: batchCreateDiskExt[C],
+		batchUpdate: batchUpdateDiskExt[U],
+		convertResult: func(m *tablecloud.DiskModel) (interface{}, error) {
+			return toProtoDiskExtResult[E](m)
+		},
+		convertExtList: func(data *cloud.DiskListResult) (interface{}, error) {
+			return toProtoDiskExtListResult[E](data)
+		},
+	}
+}
+
+func getVendor(vendor enumor.Vendor) (diskVendor, error) {
+	handler, exist := diskVendors[vendor]
+	if !exist {
+		return diskVendor{}, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	}
+
+	return handler, nil
+}
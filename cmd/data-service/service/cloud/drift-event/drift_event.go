@@ -0,0 +1,134 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package driftevent ...
+package driftevent
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/types"
+	driftevent "hcm/pkg/dal/table/cloud/drift-event"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitResDriftEventService initialize the resource drift event service.
+func InitResDriftEventService(cap *capability.Capability) {
+	svc := &driftEventSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+	h.Add("BatchCreateResDriftEvent", http.MethodPost, "/drift_events/batch/create", svc.BatchCreateResDriftEvent)
+	h.Add("ListResDriftEvent", http.MethodPost, "/drift_events/list", svc.ListResDriftEvent)
+
+	h.Load(cap.WebService)
+}
+
+type driftEventSvc struct {
+	dao dao.Set
+}
+
+// BatchCreateResDriftEvent batch create resource drift event.
+func (svc *driftEventSvc) BatchCreateResDriftEvent(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.ResDriftEventBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	events := make([]*driftevent.ResDriftEventTable, 0, len(req.Events))
+	for _, one := range req.Events {
+		events = append(events, &driftevent.ResDriftEventTable{
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			ResType:    one.ResType,
+			ResID:      one.ResID,
+			CloudResID: one.CloudResID,
+			Action:     one.Action,
+			BeforeData: one.BeforeData,
+			AfterData:  one.AfterData,
+			Rid:        cts.Kit.Rid,
+		})
+	}
+
+	if err := svc.dao.ResDriftEvent().BatchCreate(cts.Kit, events); err != nil {
+		logs.Errorf("batch create drift event failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListResDriftEvent list resource drift event.
+func (svc *driftEventSvc) ListResDriftEvent(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.ResDriftEvent().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list drift event failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list drift event failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protocloud.ResDriftEventListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]corecloud.ResDriftEvent, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, corecloud.ResDriftEvent{
+			ID:         one.ID,
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			ResType:    one.ResType,
+			ResID:      one.ResID,
+			CloudResID: one.CloudResID,
+			Action:     one.Action,
+			BeforeData: one.BeforeData,
+			AfterData:  one.AfterData,
+			Rid:        one.Rid,
+			CreatedAt:  one.CreatedAt.String(),
+		})
+	}
+
+	return &protocloud.ResDriftEventListResult{Details: details}, nil
+}
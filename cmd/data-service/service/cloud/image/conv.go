@@ -54,6 +54,7 @@ func toProtoImageExtResult[T dataproto.ImageExtensionResult](
 	return &dataproto.ImageExtResult[T]{
 		ID:           m.ID,
 		Vendor:       m.Vendor,
+		AccountID:    m.AccountID,
 		CloudID:      m.CloudID,
 		Name:         m.Name,
 		Architecture: m.Architecture,
@@ -72,6 +73,7 @@ func toProtoImageResult(m *tablecloud.ImageModel) *dataproto.ImageResult {
 	return &dataproto.ImageResult{
 		ID:           m.ID,
 		Vendor:       m.Vendor,
+		AccountID:    m.AccountID,
 		CloudID:      m.CloudID,
 		Name:         m.Name,
 		Architecture: m.Architecture,
@@ -79,6 +79,7 @@ func batchCreateImageExt[T dataproto.ImageExtensionCreateReq](
 			}
 			images[indx] = &tablecloud.ImageModel{
 				Vendor:       string(vendor),
+				AccountID:    imageReq.AccountID,
 				CloudID:      imageReq.CloudID,
 				Name:         imageReq.Name,
 				Architecture: imageReq.Architecture,
@@ -85,6 +85,7 @@ func batchUpdateImageExt[T dataproto.ImageExtensionUpdateReq](
 	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		for _, imageReq := range *req {
 			updateData := &tablecloud.ImageModel{
+				Name:  imageReq.Name,
 				State: imageReq.State,
 			}
 
@@ -0,0 +1,280 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	coreipam "hcm/pkg/api/core/cloud/ipam"
+	dataservice "hcm/pkg/api/data-service"
+	protoipam "hcm/pkg/api/data-service/cloud/ipam"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableipam "hcm/pkg/dal/table/cloud/ipam"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/cidr"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateCidrAllocation batch create cidr allocation, the cidr must be in the cidr pool and can not overlap
+// with cidrs already allocated from the same pool.
+func (svc *ipamSvc) BatchCreateCidrAllocation(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoipam.CidrAllocationBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	allocIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		poolMap := make(map[string]*poolAllocated)
+		allocations := make([]tableipam.CidrAllocationTable, 0, len(req.Allocations))
+		for _, one := range req.Allocations {
+			pool, exists := poolMap[one.PoolID]
+			if !exists {
+				var err error
+				pool, err = svc.getPoolAllocatedWithTx(cts.Kit, txn, one.PoolID)
+				if err != nil {
+					return nil, err
+				}
+				poolMap[one.PoolID] = pool
+			}
+
+			if err := pool.check(one.Cidr); err != nil {
+				return nil, err
+			}
+			pool.allocated = append(pool.allocated, one.Cidr)
+
+			allocations = append(allocations, tableipam.CidrAllocationTable{
+				PoolID:    one.PoolID,
+				Cidr:      one.Cidr,
+				Status:    one.Status,
+				Vendor:    one.Vendor,
+				AccountID: one.AccountID,
+				Region:    one.Region,
+				VpcID:     one.VpcID,
+				Memo:      one.Memo,
+				Creator:   cts.Kit.User,
+				Reviser:   cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.CidrAllocation().CreateWithTx(cts.Kit, txn, allocations)
+		if err != nil {
+			return nil, fmt.Errorf("create cidr allocation failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := allocIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create cidr allocation but return id type is not string, id type: %v",
+			reflect.TypeOf(allocIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// poolAllocated is the cidr pool and cidrs already allocated from it.
+type poolAllocated struct {
+	id        string
+	cidr      string
+	allocated []string
+}
+
+// check cidr is in the pool and not overlapped with allocated cidrs.
+func (p *poolAllocated) check(allocCidr string) error {
+	contains, err := cidr.CidrContains(p.cidr, allocCidr)
+	if err != nil {
+		return err
+	}
+
+	if !contains {
+		return errf.Newf(errf.InvalidParameter, "cidr %s is out of cidr pool %s(%s)", allocCidr, p.id, p.cidr)
+	}
+
+	for _, used := range p.allocated {
+		overlap, err := cidr.CidrOverlap(used, allocCidr)
+		if err != nil {
+			return err
+		}
+
+		if overlap {
+			return errf.Newf(errf.InvalidParameter, "cidr %s overlaps with allocated cidr %s of pool %s",
+				allocCidr, used, p.id)
+		}
+	}
+
+	return nil
+}
+
+// getPoolAllocatedWithTx lock cidr pool and list cidrs already allocated from it, the pool row is locked until
+// the tx is finished, so the concurrent allocations from the same pool are serialized and can not overlap.
+func (svc *ipamSvc) getPoolAllocatedWithTx(kt *kit.Kit, txn *sqlx.Tx, poolID string) (*poolAllocated, error) {
+	poolTable, err := svc.dao.CidrPool().LockWithTx(kt, txn, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := &poolAllocated{id: poolID, cidr: poolTable.IPv4Cidr, allocated: make([]string, 0)}
+	allocOpt := &types.ListOption{
+		Filter: tools.EqualExpression("pool_id", poolID),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"cidr"},
+	}
+	for {
+		allocResp, err := svc.dao.CidrAllocation().ListWithTx(kt, txn, allocOpt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range allocResp.Details {
+			pool.allocated = append(pool.allocated, one.Cidr)
+		}
+
+		if uint(len(allocResp.Details)) < allocOpt.Page.Limit {
+			break
+		}
+		allocOpt.Page.Start += uint32(allocOpt.Page.Limit)
+	}
+
+	return pool, nil
+}
+
+// BatchUpdateCidrAllocation batch update cidr allocation.
+func (svc *ipamSvc) BatchUpdateCidrAllocation(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoipam.CidrAllocationBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Allocations {
+			allocation := &tableipam.CidrAllocationTable{
+				Status:  one.Status,
+				VpcID:   one.VpcID,
+				Memo:    one.Memo,
+				Reviser: cts.Kit.User,
+			}
+
+			if err := svc.dao.CidrAllocation().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", one.ID),
+				allocation); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update cidr allocation failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListCidrAllocation list cidr allocation.
+func (svc *ipamSvc) ListCidrAllocation(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.CidrAllocation().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cidr allocation failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list cidr allocation failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protoipam.CidrAllocationListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coreipam.CidrAllocation, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, coreipam.CidrAllocation{
+			ID:        one.ID,
+			PoolID:    one.PoolID,
+			Cidr:      one.Cidr,
+			Status:    one.Status,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			Region:    one.Region,
+			VpcID:     one.VpcID,
+			Memo:      one.Memo,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protoipam.CidrAllocationListResult{Details: details}, nil
+}
+
+// BatchDeleteCidrAllocation batch delete cidr allocation, which releases the cidr back to the pool.
+func (svc *ipamSvc) BatchDeleteCidrAllocation(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.CidrAllocation().DeleteWithTx(cts.Kit, txn, req.Filter)
+	})
+	if err != nil {
+		logs.Errorf("delete cidr allocation failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,234 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	coreipam "hcm/pkg/api/core/cloud/ipam"
+	dataservice "hcm/pkg/api/data-service"
+	protoipam "hcm/pkg/api/data-service/cloud/ipam"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableipam "hcm/pkg/dal/table/cloud/ipam"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateCidrPool batch create cidr pool.
+func (svc *ipamSvc) BatchCreateCidrPool(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoipam.CidrPoolBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	poolIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		pools := make([]tableipam.CidrPoolTable, 0, len(req.CidrPools))
+		for _, one := range req.CidrPools {
+			pools = append(pools, tableipam.CidrPoolTable{
+				Name:        one.Name,
+				BkBizID:     one.BkBizID,
+				Region:      one.Region,
+				Environment: one.Environment,
+				IPv4Cidr:    one.IPv4Cidr,
+				Memo:        one.Memo,
+				Creator:     cts.Kit.User,
+				Reviser:     cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.CidrPool().CreateWithTx(cts.Kit, txn, pools)
+		if err != nil {
+			return nil, fmt.Errorf("create cidr pool failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := poolIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create cidr pool but return id type is not string, id type: %v",
+			reflect.TypeOf(poolIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateCidrPool batch update cidr pool.
+func (svc *ipamSvc) BatchUpdateCidrPool(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoipam.CidrPoolBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.CidrPools {
+			pool := &tableipam.CidrPoolTable{
+				Name:    one.Name,
+				BkBizID: one.BkBizID,
+				Memo:    one.Memo,
+				Reviser: cts.Kit.User,
+			}
+			if one.Region != nil {
+				pool.Region = *one.Region
+			}
+			if one.Environment != nil {
+				pool.Environment = *one.Environment
+			}
+
+			if err := svc.dao.CidrPool().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", one.ID),
+				pool); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update cidr pool failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListCidrPool list cidr pool.
+func (svc *ipamSvc) ListCidrPool(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.CidrPool().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cidr pool failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list cidr pool failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protoipam.CidrPoolListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coreipam.CidrPool, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, coreipam.CidrPool{
+			ID:          one.ID,
+			Name:        one.Name,
+			BkBizID:     one.BkBizID,
+			Region:      one.Region,
+			Environment: one.Environment,
+			IPv4Cidr:    one.IPv4Cidr,
+			Memo:        one.Memo,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protoipam.CidrPoolListResult{Details: details}, nil
+}
+
+// BatchDeleteCidrPool batch delete cidr pool, pools which still have cidr allocations can not be deleted.
+func (svc *ipamSvc) BatchDeleteCidrPool(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	listResp, err := svc.dao.CidrPool().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cidr pool failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list cidr pool failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		allocOpt := &types.ListOption{
+			Filter: tools.ContainersExpression("pool_id", delIDs),
+			Page:   core.NewCountPage(),
+		}
+		allocResp, err := svc.dao.CidrAllocation().ListWithTx(cts.Kit, txn, allocOpt)
+		if err != nil {
+			return nil, err
+		}
+
+		if allocResp.Count != 0 {
+			return nil, errf.Newf(errf.InvalidParameter, "cidr pool still has %d cidr allocations, release them first",
+				allocResp.Count)
+		}
+
+		if err = svc.dao.CidrPool().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", delIDs)); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete cidr pool failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
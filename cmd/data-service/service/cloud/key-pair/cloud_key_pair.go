@@ -0,0 +1,243 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	protokp "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablekp "hcm/pkg/dal/table/cloud/key-pair"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateCloudKeyPair cloud key pair.
+func (svc *kpSvc) BatchCreateCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(protokp.CloudKeyPairBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablekp.CloudKeyPairTable, 0, len(req.KeyPairs))
+		for _, one := range req.KeyPairs {
+			models = append(models, &tablekp.CloudKeyPairTable{
+				Vendor:           one.Vendor,
+				AccountID:        one.AccountID,
+				Region:           one.Region,
+				CloudID:          one.CloudID,
+				Name:             one.Name,
+				Fingerprint:      one.Fingerprint,
+				KeyPairID:        one.KeyPairID,
+				BkBizID:          one.BkBizID,
+				CloudCreatedTime: one.CloudCreatedTime,
+				Creator:          cts.Kit.User,
+				Reviser:          cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.CloudKeyPair().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create cloud key pair failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create cloud key pair but return id type is not []string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateCloudKeyPair cloud key pair.
+func (svc *kpSvc) BatchUpdateCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(protokp.CloudKeyPairBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.KeyPairs {
+			update := &tablekp.CloudKeyPairTable{
+				Name:        one.Name,
+				Fingerprint: one.Fingerprint,
+				Reviser:     cts.Kit.User,
+			}
+
+			if err := svc.dao.CloudKeyPair().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				logs.Errorf("update cloud key pair by id failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, fmt.Errorf("update cloud key pair failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchUpdateCloudKeyPairCommonInfo cloud key pair.
+func (svc *kpSvc) BatchUpdateCloudKeyPairCommonInfo(cts *rest.Contexts) (interface{}, error) {
+	req := new(protokp.CloudKeyPairCommonInfoBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	updateFilter := tools.ContainersExpression("id", req.IDs)
+	updateField := &tablekp.CloudKeyPairTable{
+		BkBizID: req.BkBizID,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.CloudKeyPair().Update(cts.Kit, updateFilter, updateField); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListCloudKeyPair cloud key pair.
+func (svc *kpSvc) ListCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.CloudKeyPair().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cloud key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list cloud key pair failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protokp.CloudKeyPairListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corekp.CloudKeyPair, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corekp.CloudKeyPair{
+			ID:               one.ID,
+			Vendor:           one.Vendor,
+			AccountID:        one.AccountID,
+			Region:           one.Region,
+			CloudID:          one.CloudID,
+			Name:             one.Name,
+			Fingerprint:      one.Fingerprint,
+			KeyPairID:        one.KeyPairID,
+			BkBizID:          one.BkBizID,
+			CloudCreatedTime: one.CloudCreatedTime,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protokp.CloudKeyPairListResult{Details: details}, nil
+}
+
+// BatchDeleteCloudKeyPair cloud key pair.
+func (svc *kpSvc) BatchDeleteCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(protokp.KeyPairBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.CloudKeyPair().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cloud key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list cloud key pair failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	delFilter := tools.ContainersExpression("id", delIDs)
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.CloudKeyPair().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete cloud key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,242 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair ...
+package keypair
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	protokp "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablekp "hcm/pkg/dal/table/cloud/key-pair"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the key pair service
+func InitService(cap *capability.Capability) {
+	svc := &kpSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateKeyPair", http.MethodPost, "/key_pairs/batch/create", svc.BatchCreateKeyPair)
+	h.Add("BatchUpdateKeyPair", http.MethodPatch, "/key_pairs/batch/update", svc.BatchUpdateKeyPair)
+	h.Add("ListKeyPair", http.MethodPost, "/key_pairs/list", svc.ListKeyPair)
+	h.Add("BatchDeleteKeyPair", http.MethodDelete, "/key_pairs/batch", svc.BatchDeleteKeyPair)
+
+	h.Add("BatchCreateCloudKeyPair", http.MethodPost, "/cloud_key_pairs/batch/create", svc.BatchCreateCloudKeyPair)
+	h.Add("BatchUpdateCloudKeyPair", http.MethodPatch, "/cloud_key_pairs/batch/update", svc.BatchUpdateCloudKeyPair)
+	h.Add("BatchUpdateCloudKeyPairCommonInfo", http.MethodPatch, "/cloud_key_pairs/common/info/batch/update",
+		svc.BatchUpdateCloudKeyPairCommonInfo)
+	h.Add("ListCloudKeyPair", http.MethodPost, "/cloud_key_pairs/list", svc.ListCloudKeyPair)
+	h.Add("BatchDeleteCloudKeyPair", http.MethodDelete, "/cloud_key_pairs/batch", svc.BatchDeleteCloudKeyPair)
+
+	h.Load(cap.WebService)
+}
+
+type kpSvc struct {
+	dao dao.Set
+}
+
+// BatchCreateKeyPair key pair.
+func (svc *kpSvc) BatchCreateKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(protokp.KeyPairBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablekp.KeyPairTable, 0, len(req.KeyPairs))
+		for _, one := range req.KeyPairs {
+			models = append(models, &tablekp.KeyPairTable{
+				Name:        one.Name,
+				BkBizID:     one.BkBizID,
+				PublicKey:   one.PublicKey,
+				Fingerprint: one.Fingerprint,
+				Memo:        one.Memo,
+				Creator:     cts.Kit.User,
+				Reviser:     cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.KeyPair().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create key pair failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create key pair but return id type is not []string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateKeyPair key pair.
+func (svc *kpSvc) BatchUpdateKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(protokp.KeyPairBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.KeyPairs {
+			update := &tablekp.KeyPairTable{
+				Name:    one.Name,
+				Memo:    one.Memo,
+				Reviser: cts.Kit.User,
+			}
+
+			if err := svc.dao.KeyPair().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				logs.Errorf("update key pair by id failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+				return nil, fmt.Errorf("update key pair failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListKeyPair key pair.
+func (svc *kpSvc) ListKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.KeyPair().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list key pair failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protokp.KeyPairListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corekp.KeyPair, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corekp.KeyPair{
+			ID:          one.ID,
+			Name:        one.Name,
+			BkBizID:     one.BkBizID,
+			PublicKey:   one.PublicKey,
+			Fingerprint: one.Fingerprint,
+			Memo:        one.Memo,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protokp.KeyPairListResult{Details: details}, nil
+}
+
+// BatchDeleteKeyPair key pair.
+func (svc *kpSvc) BatchDeleteKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(protokp.KeyPairBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.KeyPair().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list key pair failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	delFilter := tools.ContainersExpression("id", delIDs)
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.KeyPair().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
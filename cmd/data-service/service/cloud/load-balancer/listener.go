@@ -0,0 +1,384 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protolb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateListener load balancer listener.
+func (svc *lbSvc) BatchCreateListener(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return batchCreateListener[corelb.TCloudListenerExtension](cts, svc, vendor)
+	case enumor.Aws:
+		return batchCreateListener[corelb.AwsListenerExtension](cts, svc, vendor)
+	case enumor.HuaWei:
+		return batchCreateListener[corelb.HuaWeiListenerExtension](cts, svc, vendor)
+	case enumor.Azure:
+		return batchCreateListener[corelb.AzureListenerExtension](cts, svc, vendor)
+	case enumor.Gcp:
+		return batchCreateListener[corelb.GcpListenerExtension](cts, svc, vendor)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func batchCreateListener[T corelb.ListenerExtension](cts *rest.Contexts, svc *lbSvc, vendor enumor.Vendor) (
+	interface{}, error) {
+
+	req := new(protolb.ListenerBatchCreateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablelb.ListenerTable, 0, len(req.Listeners))
+		for _, one := range req.Listeners {
+			extension, err := json.MarshalToString(one.Extension)
+			if err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+
+			models = append(models, &tablelb.ListenerTable{
+				CloudID:   one.CloudID,
+				Name:      one.Name,
+				Vendor:    vendor,
+				AccountID: one.AccountID,
+				LbID:      one.LbID,
+				CloudLbID: one.CloudLbID,
+				Protocol:  one.Protocol,
+				Port:      one.Port,
+				EndPort:   one.EndPort,
+				Extension: tabletype.JsonField(extension),
+				Creator:   cts.Kit.User,
+				Reviser:   cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.LBListener().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create load balancer listener failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create load balancer listener but return id type is not []string, "+
+			"id type: %v", reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateListener load balancer listener.
+func (svc *lbSvc) BatchUpdateListener(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return batchUpdateListener[corelb.TCloudListenerExtension](cts, svc)
+	case enumor.Aws:
+		return batchUpdateListener[corelb.AwsListenerExtension](cts, svc)
+	case enumor.HuaWei:
+		return batchUpdateListener[corelb.HuaWeiListenerExtension](cts, svc)
+	case enumor.Azure:
+		return batchUpdateListener[corelb.AzureListenerExtension](cts, svc)
+	case enumor.Gcp:
+		return batchUpdateListener[corelb.GcpListenerExtension](cts, svc)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func batchUpdateListener[T corelb.ListenerExtension](cts *rest.Contexts, svc *lbSvc) (interface{}, error) {
+	req := new(protolb.ListenerBatchUpdateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, 0, len(req.Listeners))
+	for _, one := range req.Listeners {
+		ids = append(ids, one.ID)
+	}
+	opt := &types.ListOption{
+		Fields: []string{"id", "extension"},
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := svc.dao.LBListener().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer listener failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+	existExtMap := make(map[string]tabletype.JsonField, len(list.Details))
+	for _, one := range list.Details {
+		existExtMap[one.ID] = one.Extension
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Listeners {
+			existExt, exist := existExtMap[one.ID]
+			if !exist {
+				continue
+			}
+
+			update := &tablelb.ListenerTable{
+				Name:     one.Name,
+				Protocol: one.Protocol,
+				Port:     one.Port,
+				EndPort:  one.EndPort,
+				Reviser:  cts.Kit.User,
+			}
+
+			if one.Extension != nil {
+				merge, err := json.UpdateMerge(one.Extension, string(existExt))
+				if err != nil {
+					return nil, fmt.Errorf("json UpdateMerge extension failed, err: %v", err)
+				}
+				update.Extension = tabletype.JsonField(merge)
+			}
+
+			if err := svc.dao.LBListener().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				logs.Errorf("update load balancer listener by id failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, fmt.Errorf("update load balancer listener failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListListener load balancer listener.
+func (svc *lbSvc) ListListener(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.LBListener().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer listener failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protolb.ListenerListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corelb.BaseListener, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, *convTableToBaseListener(&one))
+	}
+
+	return &protolb.ListenerListResult{Details: details}, nil
+}
+
+// ListListenerExt load balancer listener with extension.
+func (svc *lbSvc) ListListenerExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.LBListener().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer listener failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protolb.ListenerExtListResult[corelb.TCloudListenerExtension]{Count: result.Count}, nil
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convListenerListResult[corelb.TCloudListenerExtension](result.Details)
+	case enumor.Aws:
+		return convListenerListResult[corelb.AwsListenerExtension](result.Details)
+	case enumor.HuaWei:
+		return convListenerListResult[corelb.HuaWeiListenerExtension](result.Details)
+	case enumor.Azure:
+		return convListenerListResult[corelb.AzureListenerExtension](result.Details)
+	case enumor.Gcp:
+		return convListenerListResult[corelb.GcpListenerExtension](result.Details)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convListenerListResult[T corelb.ListenerExtension](tables []tablelb.ListenerTable) (
+	*protolb.ListenerExtListResult[T], error) {
+
+	details := make([]corelb.Listener[T], 0, len(tables))
+	for _, one := range tables {
+		extension, err := unmarshalExtension[T](one.Extension)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal load balancer listener json extension failed, err: %v", err)
+		}
+
+		details = append(details, corelb.Listener[T]{
+			BaseListener: *convTableToBaseListener(&one),
+			Extension:    extension,
+		})
+	}
+
+	return &protolb.ListenerExtListResult[T]{Details: details}, nil
+}
+
+func convTableToBaseListener(one *tablelb.ListenerTable) *corelb.BaseListener {
+	return &corelb.BaseListener{
+		ID:        one.ID,
+		CloudID:   one.CloudID,
+		Name:      one.Name,
+		Vendor:    one.Vendor,
+		AccountID: one.AccountID,
+		LbID:      one.LbID,
+		CloudLbID: one.CloudLbID,
+		Protocol:  one.Protocol,
+		Port:      one.Port,
+		EndPort:   one.EndPort,
+		Revision: &core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+// BatchDeleteListener load balancer listener, targets belonging to it are deleted together.
+func (svc *lbSvc) BatchDeleteListener(cts *rest.Contexts) (interface{}, error) {
+	req := new(protolb.ListenerBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.LBListener().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer listener failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		targetFilter := tools.ContainersExpression("listener_id", delIDs)
+		if err := svc.dao.LBTarget().DeleteWithTx(cts.Kit, txn, targetFilter); err != nil {
+			return nil, err
+		}
+
+		delFilter := tools.ContainersExpression("id", delIDs)
+		if err := svc.dao.LBListener().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete load balancer listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"testing"
+
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+)
+
+func TestConvLoadBalancerListResult(t *testing.T) {
+	tables := []tablelb.LoadBalancerTable{
+		{ID: "00000001", CloudID: "lb-1", Vendor: enumor.TCloud, Zones: []string{"ap-guangzhou-3"},
+			Extension: `{"vip_isp":"CMCC","cloud_security_group_ids":["sg-1"]}`},
+		// empty extension is converted to empty struct.
+		{ID: "00000002", CloudID: "lb-2", Vendor: enumor.TCloud},
+	}
+
+	result, err := convLoadBalancerListResult[corelb.TCloudLoadBalancerExtension](tables)
+	if err != nil {
+		t.Fatalf("conv load balancer failed, err: %v", err)
+	}
+	if len(result.Details) != 2 {
+		t.Fatalf("expect 2 load balancers, got: %d", len(result.Details))
+	}
+
+	first := result.Details[0]
+	if first.ID != "00000001" || first.CloudID != "lb-1" || len(first.Zones) != 1 {
+		t.Errorf("unexpected base load balancer: %+v", first.BaseLoadBalancer)
+	}
+	if first.Extension.VipIsp == nil || *first.Extension.VipIsp != "CMCC" ||
+		len(first.Extension.CloudSecurityGroupIDs) != 1 {
+		t.Errorf("unexpected load balancer extension: %+v", first.Extension)
+	}
+	if result.Details[1].Extension == nil || result.Details[1].Extension.VipIsp != nil {
+		t.Errorf("empty extension should be converted to empty struct, got: %+v", result.Details[1].Extension)
+	}
+
+	if _, err = convLoadBalancerListResult[corelb.TCloudLoadBalancerExtension]([]tablelb.LoadBalancerTable{
+		{ID: "00000003", Extension: `{invalid`}}); err == nil {
+		t.Errorf("conv invalid extension should fail")
+	}
+}
+
+func TestConvTargetListResult(t *testing.T) {
+	tables := []tablelb.TargetTable{
+		{ID: "00000001", CloudID: corelb.GenTargetCloudID("ins-1", 80), CloudTargetID: "ins-1", Port: 80,
+			Weight: 10, Extension: `{"type":"CVM"}`},
+	}
+
+	result, err := convTargetListResult[corelb.TCloudTargetExtension](tables)
+	if err != nil {
+		t.Fatalf("conv target failed, err: %v", err)
+	}
+
+	target := result.Details[0]
+	if target.CloudID != "ins-1:80" || target.Port != 80 || target.Weight != 10 {
+		t.Errorf("unexpected base target: %+v", target.BaseTarget)
+	}
+	if target.Extension.Type == nil || *target.Extension.Type != "CVM" {
+		t.Errorf("unexpected target extension: %+v", target.Extension)
+	}
+}
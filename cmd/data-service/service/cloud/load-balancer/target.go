@@ -0,0 +1,377 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protolb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateTarget load balancer target.
+func (svc *lbSvc) BatchCreateTarget(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return batchCreateTarget[corelb.TCloudTargetExtension](cts, svc, vendor)
+	case enumor.Aws:
+		return batchCreateTarget[corelb.AwsTargetExtension](cts, svc, vendor)
+	case enumor.HuaWei:
+		return batchCreateTarget[corelb.HuaWeiTargetExtension](cts, svc, vendor)
+	case enumor.Azure:
+		return batchCreateTarget[corelb.AzureTargetExtension](cts, svc, vendor)
+	case enumor.Gcp:
+		return batchCreateTarget[corelb.GcpTargetExtension](cts, svc, vendor)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func batchCreateTarget[T corelb.TargetExtension](cts *rest.Contexts, svc *lbSvc, vendor enumor.Vendor) (
+	interface{}, error) {
+
+	req := new(protolb.TargetBatchCreateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablelb.TargetTable, 0, len(req.Targets))
+		for _, one := range req.Targets {
+			extension, err := json.MarshalToString(one.Extension)
+			if err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+
+			models = append(models, &tablelb.TargetTable{
+				CloudID:         one.CloudID,
+				Vendor:          vendor,
+				AccountID:       one.AccountID,
+				LbID:            one.LbID,
+				ListenerID:      one.ListenerID,
+				CloudListenerID: one.CloudListenerID,
+				TargetType:      one.TargetType,
+				CloudTargetID:   one.CloudTargetID,
+				IP:              one.IP,
+				Port:            one.Port,
+				Weight:          one.Weight,
+				Extension:       tabletype.JsonField(extension),
+				Creator:         cts.Kit.User,
+				Reviser:         cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.LBTarget().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create load balancer target failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create load balancer target but return id type is not []string, "+
+			"id type: %v", reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateTarget load balancer target.
+func (svc *lbSvc) BatchUpdateTarget(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return batchUpdateTarget[corelb.TCloudTargetExtension](cts, svc)
+	case enumor.Aws:
+		return batchUpdateTarget[corelb.AwsTargetExtension](cts, svc)
+	case enumor.HuaWei:
+		return batchUpdateTarget[corelb.HuaWeiTargetExtension](cts, svc)
+	case enumor.Azure:
+		return batchUpdateTarget[corelb.AzureTargetExtension](cts, svc)
+	case enumor.Gcp:
+		return batchUpdateTarget[corelb.GcpTargetExtension](cts, svc)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func batchUpdateTarget[T corelb.TargetExtension](cts *rest.Contexts, svc *lbSvc) (interface{}, error) {
+	req := new(protolb.TargetBatchUpdateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, 0, len(req.Targets))
+	for _, one := range req.Targets {
+		ids = append(ids, one.ID)
+	}
+	opt := &types.ListOption{
+		Fields: []string{"id", "extension"},
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := svc.dao.LBTarget().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer target failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+	existExtMap := make(map[string]tabletype.JsonField, len(list.Details))
+	for _, one := range list.Details {
+		existExtMap[one.ID] = one.Extension
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Targets {
+			existExt, exist := existExtMap[one.ID]
+			if !exist {
+				continue
+			}
+
+			update := &tablelb.TargetTable{
+				IP:      one.IP,
+				Weight:  one.Weight,
+				Reviser: cts.Kit.User,
+			}
+
+			if one.Extension != nil {
+				merge, err := json.UpdateMerge(one.Extension, string(existExt))
+				if err != nil {
+					return nil, fmt.Errorf("json UpdateMerge extension failed, err: %v", err)
+				}
+				update.Extension = tabletype.JsonField(merge)
+			}
+
+			if err := svc.dao.LBTarget().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				logs.Errorf("update load balancer target by id failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, fmt.Errorf("update load balancer target failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListTarget load balancer target.
+func (svc *lbSvc) ListTarget(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.LBTarget().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer target failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer target failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protolb.TargetListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corelb.BaseTarget, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, *convTableToBaseTarget(&one))
+	}
+
+	return &protolb.TargetListResult{Details: details}, nil
+}
+
+// ListTargetExt load balancer target with extension.
+func (svc *lbSvc) ListTargetExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.LBTarget().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer target failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer target failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protolb.TargetExtListResult[corelb.TCloudTargetExtension]{Count: result.Count}, nil
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convTargetListResult[corelb.TCloudTargetExtension](result.Details)
+	case enumor.Aws:
+		return convTargetListResult[corelb.AwsTargetExtension](result.Details)
+	case enumor.HuaWei:
+		return convTargetListResult[corelb.HuaWeiTargetExtension](result.Details)
+	case enumor.Azure:
+		return convTargetListResult[corelb.AzureTargetExtension](result.Details)
+	case enumor.Gcp:
+		return convTargetListResult[corelb.GcpTargetExtension](result.Details)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convTargetListResult[T corelb.TargetExtension](tables []tablelb.TargetTable) (
+	*protolb.TargetExtListResult[T], error) {
+
+	details := make([]corelb.Target[T], 0, len(tables))
+	for _, one := range tables {
+		extension, err := unmarshalExtension[T](one.Extension)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal load balancer target json extension failed, err: %v", err)
+		}
+
+		details = append(details, corelb.Target[T]{
+			BaseTarget: *convTableToBaseTarget(&one),
+			Extension:  extension,
+		})
+	}
+
+	return &protolb.TargetExtListResult[T]{Details: details}, nil
+}
+
+func convTableToBaseTarget(one *tablelb.TargetTable) *corelb.BaseTarget {
+	return &corelb.BaseTarget{
+		ID:              one.ID,
+		CloudID:         one.CloudID,
+		Vendor:          one.Vendor,
+		AccountID:       one.AccountID,
+		LbID:            one.LbID,
+		ListenerID:      one.ListenerID,
+		CloudListenerID: one.CloudListenerID,
+		TargetType:      one.TargetType,
+		CloudTargetID:   one.CloudTargetID,
+		IP:              one.IP,
+		Port:            one.Port,
+		Weight:          one.Weight,
+		Revision: &core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+// BatchDeleteTarget load balancer target.
+func (svc *lbSvc) BatchDeleteTarget(cts *rest.Contexts) (interface{}, error) {
+	req := new(protolb.TargetBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.LBTarget().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer target failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer target failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		delFilter := tools.ContainersExpression("id", delIDs)
+		return nil, svc.dao.LBTarget().DeleteWithTx(cts.Kit, txn, delFilter)
+	})
+	if err != nil {
+		logs.Errorf("delete load balancer target failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway ...
+package natgateway
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the nat gateway service
+func InitService(cap *capability.Capability) {
+	svc := &natSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateNatGateway", http.MethodPost, "/vendors/{vendor}/nat_gateways/batch/create",
+		svc.BatchCreateNatGateway)
+	h.Add("BatchUpdateNatGateway", http.MethodPatch, "/vendors/{vendor}/nat_gateways/batch/update",
+		svc.BatchUpdateNatGateway)
+	h.Add("BatchUpdateNatGatewayCommonInfo", http.MethodPatch, "/nat_gateways/common/info/batch/update",
+		svc.BatchUpdateNatGatewayCommonInfo)
+	h.Add("GetNatGateway", http.MethodGet, "/vendors/{vendor}/nat_gateways/{id}", svc.GetNatGateway)
+	h.Add("ListNatGateway", http.MethodPost, "/nat_gateways/list", svc.ListNatGateway)
+	h.Add("ListNatGatewayExt", http.MethodPost, "/vendors/{vendor}/nat_gateways/list", svc.ListNatGatewayExt)
+	h.Add("BatchDeleteNatGateway", http.MethodDelete, "/nat_gateways/batch", svc.BatchDeleteNatGateway)
+
+	h.Load(cap.WebService)
+}
+
+type natSvc struct {
+	dao dao.Set
+}
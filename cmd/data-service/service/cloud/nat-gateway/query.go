@@ -0,0 +1,237 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	protonat "hcm/pkg/api/data-service/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablenat "hcm/pkg/dal/table/cloud/nat-gateway"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+)
+
+// ListNatGateway nat gateway.
+func (svc *natSvc) ListNatGateway(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.NatGateway().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list nat gateway failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list nat gateway failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protonat.NatGatewayListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corenat.BaseNatGateway, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, *convTableToBaseNatGateway(&one))
+	}
+
+	return &protonat.NatGatewayListResult{Details: details}, nil
+}
+
+// GetNatGateway nat gateway.
+func (svc *natSvc) GetNatGateway(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "nat gateway id is required")
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.NatGateway().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list nat gateway failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, fmt.Errorf("list nat gateway failed, err: %v", err)
+	}
+
+	if len(result.Details) != 1 {
+		return nil, errf.New(errf.RecordNotFound, "nat gateway not found")
+	}
+
+	lb := result.Details[0]
+	if lb.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "nat gateway %s vendor is %s, not %s", id, lb.Vendor, vendor)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convNatGatewayWithExt[corenat.TCloudNatGatewayExtension](&lb)
+	case enumor.Aws:
+		return convNatGatewayWithExt[corenat.AwsNatGatewayExtension](&lb)
+	case enumor.HuaWei:
+		return convNatGatewayWithExt[corenat.HuaWeiNatGatewayExtension](&lb)
+	case enumor.Azure:
+		return convNatGatewayWithExt[corenat.AzureNatGatewayExtension](&lb)
+	case enumor.Gcp:
+		return convNatGatewayWithExt[corenat.GcpNatGatewayExtension](&lb)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+// ListNatGatewayExt nat gateway with extension.
+func (svc *natSvc) ListNatGatewayExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.NatGateway().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list nat gateway failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list nat gateway failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protonat.NatGatewayExtListResult[corenat.TCloudNatGatewayExtension]{Count: result.Count}, nil
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convNatGatewayListResult[corenat.TCloudNatGatewayExtension](result.Details)
+	case enumor.Aws:
+		return convNatGatewayListResult[corenat.AwsNatGatewayExtension](result.Details)
+	case enumor.HuaWei:
+		return convNatGatewayListResult[corenat.HuaWeiNatGatewayExtension](result.Details)
+	case enumor.Azure:
+		return convNatGatewayListResult[corenat.AzureNatGatewayExtension](result.Details)
+	case enumor.Gcp:
+		return convNatGatewayListResult[corenat.GcpNatGatewayExtension](result.Details)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convNatGatewayListResult[T corenat.Extension](tables []tablenat.NatGatewayTable) (
+	*protonat.NatGatewayExtListResult[T], error) {
+
+	details := make([]corenat.NatGateway[T], 0, len(tables))
+	for _, one := range tables {
+		lb, err := convNatGatewayWithExt[T](&one)
+		if err != nil {
+			return nil, err
+		}
+
+		details = append(details, *lb)
+	}
+
+	return &protonat.NatGatewayExtListResult[T]{Details: details}, nil
+}
+
+func convNatGatewayWithExt[T corenat.Extension](one *tablenat.NatGatewayTable) (*corenat.NatGateway[T], error) {
+	extension, err := unmarshalExtension[T](one.Extension)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal nat gateway json extension failed, err: %v", err)
+	}
+
+	return &corenat.NatGateway[T]{
+		BaseNatGateway: *convTableToBaseNatGateway(one),
+		Extension:      extension,
+	}, nil
+}
+
+func convTableToBaseNatGateway(one *tablenat.NatGatewayTable) *corenat.BaseNatGateway {
+	return &corenat.BaseNatGateway{
+		ID:                 one.ID,
+		CloudID:            one.CloudID,
+		Name:               one.Name,
+		Vendor:             one.Vendor,
+		AccountID:          one.AccountID,
+		BkBizID:            one.BkBizID,
+		Region:             one.Region,
+		Zone:               one.Zone,
+		NatType:            one.NatType,
+		Status:             one.Status,
+		CloudVpcID:         one.CloudVpcID,
+		VpcID:              one.VpcID,
+		CloudSubnetID:      one.CloudSubnetID,
+		SubnetID:           one.SubnetID,
+		PublicIPAddresses:  one.PublicIPAddresses,
+		PrivateIPAddresses: one.PrivateIPAddresses,
+		CloudEipIDs:        one.CloudEipIDs,
+		EipIDs:             one.EipIDs,
+		Memo:               one.Memo,
+		CloudCreatedTime:   one.CloudCreatedTime,
+		Revision: &core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+func unmarshalExtension[T any](extJson tabletype.JsonField) (*T, error) {
+	extension := new(T)
+	if len(extJson) == 0 {
+		return extension, nil
+	}
+
+	if err := json.UnmarshalFromString(string(extJson), extension); err != nil {
+		return nil, err
+	}
+
+	return extension, nil
+}
@@ -0,0 +1,172 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	protonat "hcm/pkg/api/data-service/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablenat "hcm/pkg/dal/table/cloud/nat-gateway"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchUpdateNatGateway nat gateway.
+func (svc *natSvc) BatchUpdateNatGateway(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return batchUpdateNatGateway[corenat.TCloudNatGatewayExtension](cts, svc)
+	case enumor.Aws:
+		return batchUpdateNatGateway[corenat.AwsNatGatewayExtension](cts, svc)
+	case enumor.HuaWei:
+		return batchUpdateNatGateway[corenat.HuaWeiNatGatewayExtension](cts, svc)
+	case enumor.Azure:
+		return batchUpdateNatGateway[corenat.AzureNatGatewayExtension](cts, svc)
+	case enumor.Gcp:
+		return batchUpdateNatGateway[corenat.GcpNatGatewayExtension](cts, svc)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func batchUpdateNatGateway[T corenat.Extension](cts *rest.Contexts, svc *natSvc) (interface{}, error) {
+	req := new(protonat.NatGatewayBatchUpdateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, 0, len(req.NatGateways))
+	for _, one := range req.NatGateways {
+		ids = append(ids, one.ID)
+	}
+	existLbMap, err := svc.listNatGatewayMap(cts.Kit, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.NatGateways {
+			existLb, exist := existLbMap[one.ID]
+			if !exist {
+				continue
+			}
+
+			update := &tablenat.NatGatewayTable{
+				Name:               one.Name,
+				Zone:               one.Zone,
+				NatType:            one.NatType,
+				Status:             one.Status,
+				CloudVpcID:         one.CloudVpcID,
+				VpcID:              one.VpcID,
+				CloudSubnetID:      one.CloudSubnetID,
+				SubnetID:           one.SubnetID,
+				PublicIPAddresses:  one.PublicIPAddresses,
+				PrivateIPAddresses: one.PrivateIPAddresses,
+				CloudEipIDs:        one.CloudEipIDs,
+				EipIDs:             one.EipIDs,
+				Memo:               one.Memo,
+				Reviser:            cts.Kit.User,
+			}
+
+			if one.Extension != nil {
+				merge, err := json.UpdateMerge(one.Extension, string(existLb.Extension))
+				if err != nil {
+					return nil, fmt.Errorf("json UpdateMerge extension failed, err: %v", err)
+				}
+				update.Extension = tabletype.JsonField(merge)
+			}
+
+			if err := svc.dao.NatGateway().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				logs.Errorf("update nat gateway by id failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+				return nil, fmt.Errorf("update nat gateway failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *natSvc) listNatGatewayMap(kt *kit.Kit, ids []string) (map[string]tablenat.NatGatewayTable, error) {
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := svc.dao.NatGateway().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list nat gateway failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	result := make(map[string]tablenat.NatGatewayTable, len(list.Details))
+	for _, one := range list.Details {
+		result[one.ID] = one
+	}
+
+	return result, nil
+}
+
+// BatchUpdateNatGatewayCommonInfo nat gateway.
+func (svc *natSvc) BatchUpdateNatGatewayCommonInfo(cts *rest.Contexts) (interface{}, error) {
+	req := new(protonat.NatGatewayCommonInfoBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	updateFilter := tools.ContainersExpression("id", req.IDs)
+	updateField := &tablenat.NatGatewayTable{
+		BkBizID: req.BkBizID,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.NatGateway().Update(cts.Kit, updateFilter, updateField); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
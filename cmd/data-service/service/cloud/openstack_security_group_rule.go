@@ -0,0 +1,288 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablecloud "hcm/pkg/dal/table/cloud"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// initOpenStackSGRuleService initial the openstack security group rule service
+func initOpenStackSGRuleService(cap *capability.Capability) {
+	svc := &openStackSGRuleSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateOpenStackRule", "POST",
+		"/vendors/openstack/security_groups/{security_group_id}/rules/batch/create", svc.BatchCreateOpenStackRule)
+	h.Add("BatchUpdateOpenStackRule", "PUT", "/vendors/openstack/security_groups/{security_group_id}/rules/batch",
+		svc.BatchUpdateOpenStackRule)
+	h.Add("ListOpenStackRule", "POST", "/vendors/openstack/security_groups/{security_group_id}/rules/list",
+		svc.ListOpenStackRule)
+	h.Add("DeleteOpenStackRule", "DELETE", "/vendors/openstack/security_groups/{security_group_id}/rules/batch",
+		svc.DeleteOpenStackRule)
+
+	h.Load(cap.WebService)
+}
+
+type openStackSGRuleSvc struct {
+	dao dao.Set
+}
+
+// BatchCreateOpenStackRule create openstack rule.
+func (svc *openStackSGRuleSvc) BatchCreateOpenStackRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.OpenStackSGRuleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rules := make([]*tablecloud.OpenStackSecurityGroupRuleTable, 0, len(req.Rules))
+	for _, rule := range req.Rules {
+		rules = append(rules, &tablecloud.OpenStackSecurityGroupRuleTable{
+			Region:               rule.Region,
+			CloudID:              rule.CloudID,
+			Type:                 string(rule.Type),
+			CloudSecurityGroupID: rule.CloudSecurityGroupID,
+			SecurityGroupID:      rule.SecurityGroupID,
+			AccountID:            rule.AccountID,
+			CloudProjectID:       rule.CloudProjectID,
+			Memo:                 rule.Memo,
+			Protocol:             rule.Protocol,
+			Ethertype:            rule.Ethertype,
+			CloudRemoteGroupID:   rule.CloudRemoteGroupID,
+			RemoteIPPrefix:       rule.RemoteIPPrefix,
+			Port:                 rule.Port,
+			Creator:              cts.Kit.User,
+			Reviser:              cts.Kit.User,
+		})
+	}
+	ruleIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ruleIDs, err := svc.dao.OpenStackSGRule().BatchCreateWithTx(cts.Kit, txn, rules)
+		if err != nil {
+			return nil, fmt.Errorf("batch create openstack security group rule failed, err: %v", err)
+		}
+
+		return ruleIDs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := ruleIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create openstack security group rule but return id type is not string, "+
+			"id type: %v", reflect.TypeOf(ruleIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateOpenStackRule update openstack rule.
+func (svc *openStackSGRuleSvc) BatchUpdateOpenStackRule(cts *rest.Contexts) (interface{}, error) {
+	sgID := cts.PathParameter("security_group_id").String()
+	if len(sgID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "security group id is required")
+	}
+
+	req := new(protocloud.OpenStackSGRuleBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Rules {
+			rule := &tablecloud.OpenStackSecurityGroupRuleTable{
+				Region:               one.Region,
+				CloudID:              one.CloudID,
+				Type:                 string(one.Type),
+				CloudSecurityGroupID: one.CloudSecurityGroupID,
+				SecurityGroupID:      one.SecurityGroupID,
+				AccountID:            one.AccountID,
+				CloudProjectID:       one.CloudProjectID,
+				Memo:                 one.Memo,
+				Protocol:             one.Protocol,
+				Ethertype:            one.Ethertype,
+				CloudRemoteGroupID:   one.CloudRemoteGroupID,
+				RemoteIPPrefix:       one.RemoteIPPrefix,
+				Port:                 one.Port,
+				Reviser:              cts.Kit.User,
+			}
+
+			flt := &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{
+						Field: "id",
+						Op:    filter.Equal.Factory(),
+						Value: one.ID,
+					},
+					&filter.AtomRule{
+						Field: "security_group_id",
+						Op:    filter.Equal.Factory(),
+						Value: sgID,
+					},
+				},
+			}
+			if err := svc.dao.OpenStackSGRule().UpdateWithTx(cts.Kit, txn, flt, rule); err != nil {
+				logs.Errorf("update openstack security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+				return nil, fmt.Errorf("update openstack security group rule failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListOpenStackRule list openstack rule.
+func (svc *openStackSGRuleSvc) ListOpenStackRule(cts *rest.Contexts) (interface{}, error) {
+	sgID := cts.PathParameter("security_group_id").String()
+	if len(sgID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "security group id is required")
+	}
+
+	req := new(protocloud.OpenStackSGRuleListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.SGRuleListOption{
+		SecurityGroupID: sgID,
+		Fields:          req.Field,
+		Filter:          req.Filter,
+		Page:            req.Page,
+	}
+	result, err := svc.dao.OpenStackSGRule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list openstack security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list openstack security group rule failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protocloud.OpenStackSGRuleListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corecloud.OpenStackSecurityGroupRule, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corecloud.OpenStackSecurityGroupRule{
+			ID:                   one.ID,
+			Region:               one.Region,
+			CloudID:              one.CloudID,
+			Memo:                 one.Memo,
+			Protocol:             one.Protocol,
+			Ethertype:            one.Ethertype,
+			CloudRemoteGroupID:   one.CloudRemoteGroupID,
+			RemoteIPPrefix:       one.RemoteIPPrefix,
+			Port:                 one.Port,
+			Type:                 enumor.SecurityGroupRuleType(one.Type),
+			CloudSecurityGroupID: one.CloudSecurityGroupID,
+			CloudProjectID:       one.CloudProjectID,
+			AccountID:            one.AccountID,
+			SecurityGroupID:      one.SecurityGroupID,
+			Creator:              one.Creator,
+			Reviser:              one.Reviser,
+			CreatedAt:            one.CreatedAt.String(),
+			UpdatedAt:            one.UpdatedAt.String(),
+		})
+	}
+
+	return &protocloud.OpenStackSGRuleListResult{Details: details}, nil
+}
+
+// DeleteOpenStackRule delete openstack rule.
+func (svc *openStackSGRuleSvc) DeleteOpenStackRule(cts *rest.Contexts) (interface{}, error) {
+	sgID := cts.PathParameter("security_group_id").String()
+	if len(sgID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "security group id is required")
+	}
+
+	req := new(protocloud.OpenStackSGRuleBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.SGRuleListOption{
+		SecurityGroupID: sgID,
+		Fields:          []string{"id"},
+		Filter:          req.Filter,
+		Page:            core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.OpenStackSGRule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list openstack security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list openstack security group rule failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	delFilter := tools.ContainersExpression("id", delIDs)
+	if err := svc.dao.OpenStackSGRule().Delete(cts.Kit, delFilter); err != nil {
+		logs.Errorf("delete openstack security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
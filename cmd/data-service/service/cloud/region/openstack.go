@@ -0,0 +1,163 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package region
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coreregion "hcm/pkg/api/core/cloud/region"
+	protoregion "hcm/pkg/api/data-service/cloud/region"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	tableregion "hcm/pkg/dal/table/cloud/region"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitOpenStackRegionService initial the openstack region service
+func InitOpenStackRegionService(cap *capability.Capability) {
+	svc := &openStackRegionSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListOpenStackRegion", "POST", "/vendors/openstack/regions/list", svc.ListOpenStackRegion)
+	h.Add("DeleteOpenStackRegion", "DELETE", "/vendors/openstack/regions/batch", svc.DeleteOpenStackRegion)
+	h.Add("CreateOpenStackRegion", "POST", "/vendors/openstack/regions/batch/create", svc.CreateOpenStackRegion)
+
+	h.Load(cap.WebService)
+}
+
+type openStackRegionSvc struct {
+	dao dao.Set
+}
+
+// CreateOpenStackRegion create openstack region.
+func (svc *openStackRegionSvc) CreateOpenStackRegion(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoregion.OpenStackRegionBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	regions := make([]tableregion.OpenStackRegionTable, 0, len(req.Regions))
+	for _, one := range req.Regions {
+		regions = append(regions, tableregion.OpenStackRegionTable{
+			AccountID: one.AccountID,
+			RegionID:  one.RegionID,
+			Creator:   cts.Kit.User,
+			Reviser:   cts.Kit.User,
+		})
+	}
+
+	regionIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.OpenStackRegion().BatchCreateWithTx(cts.Kit, txn, regions)
+		if err != nil {
+			return nil, fmt.Errorf("batch create openstack region failed, err: %v", err)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := regionIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create openstack region but return id type is not string, id type: %v",
+			reflect.TypeOf(regionIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// DeleteOpenStackRegion delete openstack region.
+func (svc *openStackRegionSvc) DeleteOpenStackRegion(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoregion.OpenStackRegionBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.OpenStackRegion().BatchDeleteWithTx(cts.Kit, txn, req.Filter)
+	})
+	if err != nil {
+		logs.Errorf("delete openstack region failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListOpenStackRegion list openstack region with filter
+func (svc *openStackRegionSvc) ListOpenStackRegion(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoregion.OpenStackRegionListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Field,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.OpenStackRegion().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list openstack region failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list openstack region failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protoregion.OpenStackRegionListResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreregion.OpenStackRegion, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coreregion.OpenStackRegion{
+			ID:        one.ID,
+			AccountID: one.AccountID,
+			RegionID:  one.RegionID,
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		})
+	}
+
+	return &protoregion.OpenStackRegionListResult{Details: details}, nil
+}
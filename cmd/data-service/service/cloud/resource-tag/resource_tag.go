@@ -0,0 +1,202 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag ...
+package resourcetag
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corertag "hcm/pkg/api/core/cloud/resource-tag"
+	dataservice "hcm/pkg/api/data-service"
+	protortag "hcm/pkg/api/data-service/cloud/resource-tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	tablertag "hcm/pkg/dal/table/cloud/resource-tag"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the resource tag service
+func InitService(cap *capability.Capability) {
+	svc := &resourceTagSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchUpsertResourceTag", http.MethodPost, "/resource_tags/batch/upsert", svc.BatchUpsertResourceTag)
+	h.Add("ListResourceTag", http.MethodPost, "/resource_tags/list", svc.ListResourceTag)
+	h.Add("BatchDeleteResourceTag", http.MethodDelete, "/resource_tags/batch", svc.BatchDeleteResourceTag)
+
+	h.Load(cap.WebService)
+}
+
+type resourceTagSvc struct {
+	dao dao.Set
+}
+
+// BatchUpsertResourceTag batch upsert resource tag. if replace all, all tags of the resources are replaced by the
+// request tags, otherwise only the tags with the same key are replaced.
+func (svc *resourceTagSvc) BatchUpsertResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(protortag.ResourceTagBatchUpsertReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]tablertag.ResourceTagTable, 0)
+		for _, one := range req.Resources {
+			keys := make([]string, 0, len(one.Tags))
+			for _, tag := range one.Tags {
+				keys = append(keys, tag.Key)
+				models = append(models, tablertag.ResourceTagTable{
+					Vendor:     one.Vendor,
+					AccountID:  one.AccountID,
+					ResType:    req.ResType,
+					ResID:      one.ResID,
+					ResCloudID: one.ResCloudID,
+					TagKey:     tag.Key,
+					TagValue:   tag.Value,
+					Creator:    cts.Kit.User,
+					Reviser:    cts.Kit.User,
+				})
+			}
+
+			if !req.ReplaceAll && len(keys) == 0 {
+				continue
+			}
+
+			delFilter := &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: req.ResType},
+					&filter.AtomRule{Field: "res_id", Op: filter.Equal.Factory(), Value: one.ResID},
+				},
+			}
+			if !req.ReplaceAll {
+				delFilter.Rules = append(delFilter.Rules,
+					&filter.AtomRule{Field: "tag_key", Op: filter.In.Factory(), Value: keys})
+			}
+
+			if err := svc.dao.ResourceTag().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(models) == 0 {
+			return nil, nil
+		}
+
+		if _, err := svc.dao.ResourceTag().CreateWithTx(cts.Kit, txn, models); err != nil {
+			return nil, fmt.Errorf("create resource tag failed, err: %v", err)
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch upsert resource tag failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListResourceTag list resource tag.
+func (svc *resourceTagSvc) ListResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.ResourceTag().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list resource tag failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list resource tag failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protortag.ResourceTagListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]corertag.ResourceTag, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, corertag.ResourceTag{
+			ID:         one.ID,
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			ResType:    one.ResType,
+			ResID:      one.ResID,
+			ResCloudID: one.ResCloudID,
+			Key:        one.TagKey,
+			Value:      one.TagValue,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protortag.ResourceTagListResult{Details: details}, nil
+}
+
+// BatchDeleteResourceTag batch delete resource tag.
+func (svc *resourceTagSvc) BatchDeleteResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.ResourceTag().DeleteWithTx(cts.Kit, txn, req.Filter)
+	})
+	if err != nil {
+		logs.Errorf("delete resource tag failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -39,6 +39,7 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
 	"hcm/pkg/tools/json"
 
 	"github.com/jmoiron/sqlx"
@@ -51,6 +52,7 @@ func InitSecurityGroupService(cap *capability.Capability) {
 	initHuaWeiSGRuleService(cap)
 	initAzureSGRuleService(cap)
 	initAwsSGRuleService(cap)
+	initOpenStackSGRuleService(cap)
 }
 
 // initSecurityGroupService initial the security group service
@@ -80,6 +82,39 @@ type securityGroupSvc struct {
 	dao dao.Set
 }
 
+// sgRuleDao 厂商安全组规则的通用操作。
+type sgRuleDao interface {
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+// sgVendor 厂商安全组扩展字段和安全组规则的处理方法。
+type sgVendor struct {
+	batchCreate    func(vendor enumor.Vendor, svc *securityGroupSvc, cts *rest.Contexts) (interface{}, error)
+	batchUpdate    func(cts *rest.Contexts, svc *securityGroupSvc) (interface{}, error)
+	convertResult  func(base *corecloud.BaseSecurityGroup, extJson tabletype.JsonField) (interface{}, error)
+	convertExtList func(tables []tablecloud.SecurityGroupTable) (interface{}, error)
+	ruleDao        func(daoSet dao.Set) sgRuleDao
+}
+
+var sgVendors = newVendorRegistry[sgVendor](enumor.SecurityGroupCloudResType)
+
+// registerSGVendor 按厂商安全组扩展字段类型注册安全组处理方法，ruleDao 返回厂商的安全组规则 dao。
+func registerSGVendor[T corecloud.SecurityGroupExtension](vendor enumor.Vendor,
+	ruleDao func(daoSet dao.Set) sgRuleDao) {
+
+	sgVendors.register(vendor, sgVendor{
+		batchCreate: batchCreateSecurityGroup[T],
+		batchUpdate: batchUpdateSecurityGroup[T],
+		convertResult: func(base *corecloud.BaseSecurityGroup, extJson tabletype.JsonField) (interface{}, error) {
+			return convertToSGResult[T](base, extJson)
+		},
+		convertExtList: func(tables []tablecloud.SecurityGroupTable) (interface{}, error) {
+			return convSecurityGroupExtListResult[T](tables)
+		},
+		ruleDao: ruleDao,
+	})
+}
+
 // BatchCreateSecurityGroup create security group.
 func (svc *securityGroupSvc) BatchCreateSecurityGroup(cts *rest.Contexts) (interface{}, error) {
 	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
@@ -87,18 +122,12 @@ func (svc *securityGroupSvc) BatchCreateSecurityGroup(cts *rest.Contexts) (inter
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return batchCreateSecurityGroup[corecloud.TCloudSecurityGroupExtension](vendor, svc, cts)
-	case enumor.Aws:
-		return batchCreateSecurityGroup[corecloud.AwsSecurityGroupExtension](vendor, svc, cts)
-	case enumor.HuaWei:
-		return batchCreateSecurityGroup[corecloud.HuaWeiSecurityGroupExtension](vendor, svc, cts)
-	case enumor.Azure:
-		return batchCreateSecurityGroup[corecloud.AzureSecurityGroupExtension](vendor, svc, cts)
-	default:
-		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	handler, err := sgVendors.get(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.batchCreate(vendor, svc, cts)
 }
 
 // BatchUpdateSecurityGroup update security group.
@@ -108,18 +137,12 @@ func (svc *securityGroupSvc) BatchUpdateSecurityGroup(cts *rest.Contexts) (inter
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return batchUpdateSecurityGroup[corecloud.TCloudSecurityGroupExtension](cts, svc)
-	case enumor.Aws:
-		return batchUpdateSecurityGroup[corecloud.AwsSecurityGroupExtension](cts, svc)
-	case enumor.HuaWei:
-		return batchUpdateSecurityGroup[corecloud.HuaWeiSecurityGroupExtension](cts, svc)
-	case enumor.Azure:
-		return batchUpdateSecurityGroup[corecloud.AzureSecurityGroupExtension](cts, svc)
-	default:
-		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	handler, err := sgVendors.get(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.batchUpdate(cts, svc)
 }
 
 // ListSecurityGroup list security group.
@@ -232,20 +255,13 @@ func (svc *securityGroupSvc) deleteSecurityGroupRule(kt *kit.Kit, txn *sqlx.Tx,
 		vendorSGMap[one.Vendor] = append(vendorSGMap[one.Vendor], one.ID)
 	}
 
-	var err error
 	for vendor, sgIDs := range vendorSGMap {
-		switch vendor {
-		case enumor.TCloud:
-			err = svc.dao.TCloudSGRule().DeleteWithTx(kt, txn, tools.ContainersExpression("security_group_id", sgIDs))
-		case enumor.Aws:
-			err = svc.dao.AwsSGRule().DeleteWithTx(kt, txn, tools.ContainersExpression("security_group_id", sgIDs))
-		case enumor.HuaWei:
-			err = svc.dao.HuaWeiSGRule().DeleteWithTx(kt, txn, tools.ContainersExpression("security_group_id", sgIDs))
-		case enumor.Azure:
-			err = svc.dao.AzureSGRule().DeleteWithTx(kt, txn, tools.ContainersExpression("security_group_id", sgIDs))
-		default:
-			return fmt.Errorf("vendor: %s not support", vendor)
+		handler, err := sgVendors.get(vendor)
+		if err != nil {
+			return err
 		}
+
+		err = handler.ruleDao(svc.dao).DeleteWithTx(kt, txn, tools.ContainersExpression("security_group_id", sgIDs))
 		if err != nil {
 			return err
 		}
@@ -272,18 +288,12 @@ func (svc *securityGroupSvc) GetSecurityGroup(cts *rest.Contexts) (interface{},
 	}
 
 	base := convTableToBaseSG(sgTable)
-	switch sgTable.Vendor {
-	case enumor.TCloud:
-		return convertToSGResult[corecloud.TCloudSecurityGroupExtension](base, sgTable.Extension)
-	case enumor.Aws:
-		return convertToSGResult[corecloud.AwsSecurityGroupExtension](base, sgTable.Extension)
-	case enumor.HuaWei:
-		return convertToSGResult[corecloud.HuaWeiSecurityGroupExtension](base, sgTable.Extension)
-	case enumor.Azure:
-		return convertToSGResult[corecloud.AzureSecurityGroupExtension](base, sgTable.Extension)
-	default:
-		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	handler, err := sgVendors.get(sgTable.Vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.convertResult(base, sgTable.Extension)
 }
 
 func convTableToBaseSG(sgTable *tablecloud.SecurityGroupTable) *corecloud.BaseSecurityGroup {
@@ -521,18 +531,12 @@ func (svc *securityGroupSvc) ListSecurityGroupExt(cts *rest.Contexts) (interface
 		return nil, err
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return convSecurityGroupExtListResult[corecloud.TCloudSecurityGroupExtension](listResp.Details)
-	case enumor.Aws:
-		return convSecurityGroupExtListResult[corecloud.AwsSecurityGroupExtension](listResp.Details)
-	case enumor.Azure:
-		return convSecurityGroupExtListResult[corecloud.AzureSecurityGroupExtension](listResp.Details)
-	case enumor.HuaWei:
-		return convSecurityGroupExtListResult[corecloud.HuaWeiSecurityGroupExtension](listResp.Details)
-	default:
-		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	handler, err := sgVendors.get(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.convertExtList(listResp.Details)
 }
 
 func convSecurityGroupExtListResult[T corecloud.SecurityGroupExtension](tables []tablecloud.SecurityGroupTable) (
@@ -0,0 +1,294 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package sgtemplate ...
+package sgtemplate
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coresgt "hcm/pkg/api/core/cloud/sg-template"
+	dataservice "hcm/pkg/api/data-service"
+	protosgt "hcm/pkg/api/data-service/cloud/sg-template"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesgt "hcm/pkg/dal/table/cloud/sg-template"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the security group template and security group template rel service
+func InitService(cap *capability.Capability) {
+	svc := &sgTemplateSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateSGTemplate", http.MethodPost, "/security_group_templates/batch/create",
+		svc.BatchCreateSGTemplate)
+	h.Add("BatchUpdateSGTemplate", http.MethodPatch, "/security_group_templates/batch", svc.BatchUpdateSGTemplate)
+	h.Add("ListSGTemplate", http.MethodPost, "/security_group_templates/list", svc.ListSGTemplate)
+	h.Add("BatchDeleteSGTemplate", http.MethodDelete, "/security_group_templates/batch", svc.BatchDeleteSGTemplate)
+
+	h.Add("BatchCreateSGTemplateRel", http.MethodPost, "/security_group_template_rels/batch/create",
+		svc.BatchCreateSGTemplateRel)
+	h.Add("BatchUpdateSGTemplateRel", http.MethodPatch, "/security_group_template_rels/batch",
+		svc.BatchUpdateSGTemplateRel)
+	h.Add("ListSGTemplateRel", http.MethodPost, "/security_group_template_rels/list", svc.ListSGTemplateRel)
+	h.Add("BatchDeleteSGTemplateRel", http.MethodDelete, "/security_group_template_rels/batch",
+		svc.BatchDeleteSGTemplateRel)
+
+	h.Load(cap.WebService)
+}
+
+type sgTemplateSvc struct {
+	dao dao.Set
+}
+
+// BatchCreateSGTemplate batch create security group template.
+func (svc *sgTemplateSvc) BatchCreateSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(protosgt.SGTemplateBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	templateIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		templates := make([]tablesgt.SGTemplateTable, 0, len(req.Templates))
+		for _, one := range req.Templates {
+			rules, err := tabletypes.NewJsonField(one.Rules)
+			if err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+
+			templates = append(templates, tablesgt.SGTemplateTable{
+				Name:    one.Name,
+				Rules:   rules,
+				Version: 1,
+				Memo:    one.Memo,
+				Creator: cts.Kit.User,
+				Reviser: cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.SGTemplate().CreateWithTx(cts.Kit, txn, templates)
+		if err != nil {
+			return nil, fmt.Errorf("create security group template failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := templateIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create security group template but return id type is not string, id type: %v",
+			reflect.TypeOf(templateIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateSGTemplate batch update security group template, version of template is increased if rules changed.
+func (svc *sgTemplateSvc) BatchUpdateSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(protosgt.SGTemplateBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Templates {
+			template := &tablesgt.SGTemplateTable{
+				Name:    one.Name,
+				Memo:    one.Memo,
+				Reviser: cts.Kit.User,
+			}
+
+			if len(one.Rules) != 0 {
+				listOpt := &types.ListOption{
+					Filter: tools.EqualExpression("id", one.ID),
+					Page:   core.NewDefaultBasePage(),
+					Fields: []string{"id", "version"},
+				}
+				listResp, err := svc.dao.SGTemplate().ListWithTx(cts.Kit, txn, listOpt)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(listResp.Details) == 0 {
+					return nil, errf.Newf(errf.RecordNotFound, "security group template %s not found", one.ID)
+				}
+
+				if template.Rules, err = tabletypes.NewJsonField(one.Rules); err != nil {
+					return nil, errf.NewFromErr(errf.InvalidParameter, err)
+				}
+				template.Version = listResp.Details[0].Version + 1
+			}
+
+			if err := svc.dao.SGTemplate().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", one.ID),
+				template); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update security group template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSGTemplate list security group template.
+func (svc *sgTemplateSvc) ListSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.SGTemplate().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list security group template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list security group template failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protosgt.SGTemplateListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coresgt.SecurityGroupTemplate, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		rules := make([]coresgt.TemplateRule, 0)
+		if len(one.Rules) != 0 {
+			if err = json.UnmarshalFromString(string(one.Rules), &rules); err != nil {
+				logs.Errorf("unmarshal security group template rules failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, coresgt.SecurityGroupTemplate{
+			ID:      one.ID,
+			Name:    one.Name,
+			Rules:   rules,
+			Version: one.Version,
+			Memo:    one.Memo,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protosgt.SGTemplateListResult{Details: details}, nil
+}
+
+// BatchDeleteSGTemplate batch delete security group template, templates which are still applied to resources can
+// not be deleted.
+func (svc *sgTemplateSvc) BatchDeleteSGTemplate(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	listResp, err := svc.dao.SGTemplate().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list security group template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list security group template failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		relOpt := &types.ListOption{
+			Filter: tools.ContainersExpression("template_id", delIDs),
+			Page:   core.NewCountPage(),
+		}
+		relResp, err := svc.dao.SGTemplateRel().ListWithTx(cts.Kit, txn, relOpt)
+		if err != nil {
+			return nil, err
+		}
+
+		if relResp.Count != 0 {
+			return nil, errf.Newf(errf.InvalidParameter, "security group template is still applied to %d resources, "+
+				"unbind them first", relResp.Count)
+		}
+
+		if err = svc.dao.SGTemplate().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", delIDs)); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete security group template failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
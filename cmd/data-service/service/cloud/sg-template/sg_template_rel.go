@@ -0,0 +1,213 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgtemplate
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	coresgt "hcm/pkg/api/core/cloud/sg-template"
+	dataservice "hcm/pkg/api/data-service"
+	protosgt "hcm/pkg/api/data-service/cloud/sg-template"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesgt "hcm/pkg/dal/table/cloud/sg-template"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateSGTemplateRel batch create security group template rel.
+func (svc *sgTemplateSvc) BatchCreateSGTemplateRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(protosgt.SGTemplateRelBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	templateIDs := make([]string, 0, len(req.Rels))
+	for _, one := range req.Rels {
+		templateIDs = append(templateIDs, one.TemplateID)
+	}
+	templateIDs = slice.Unique(templateIDs)
+
+	relIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		listOpt := &types.ListOption{
+			Filter: tools.ContainersExpression("id", templateIDs),
+			Page:   core.NewCountPage(),
+		}
+		listResp, err := svc.dao.SGTemplate().ListWithTx(cts.Kit, txn, listOpt)
+		if err != nil {
+			return nil, err
+		}
+
+		if int(listResp.Count) != len(templateIDs) {
+			return nil, errf.Newf(errf.RecordNotFound, "security group templates %v not all exist", templateIDs)
+		}
+
+		rels := make([]tablesgt.SGTemplateRelTable, 0, len(req.Rels))
+		for _, one := range req.Rels {
+			rels = append(rels, tablesgt.SGTemplateRelTable{
+				TemplateID: one.TemplateID,
+				Vendor:     one.Vendor,
+				AccountID:  one.AccountID,
+				Region:     one.Region,
+				ResType:    one.ResType,
+				ResID:      one.ResID,
+				TargetTag:  one.TargetTag,
+				Creator:    cts.Kit.User,
+				Reviser:    cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.SGTemplateRel().CreateWithTx(cts.Kit, txn, rels)
+		if err != nil {
+			return nil, fmt.Errorf("create security group template rel failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := relIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create security group template rel but return id type is not string, "+
+			"id type: %v", reflect.TypeOf(relIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateSGTemplateRel batch update synced template version of security group template rel.
+func (svc *sgTemplateSvc) BatchUpdateSGTemplateRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(protosgt.SGTemplateRelBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Rels {
+			rel := &tablesgt.SGTemplateRelTable{
+				Version: one.Version,
+				Reviser: cts.Kit.User,
+			}
+
+			if err := svc.dao.SGTemplateRel().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", one.ID),
+				rel); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update security group template rel failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSGTemplateRel list security group template rel.
+func (svc *sgTemplateSvc) ListSGTemplateRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.SGTemplateRel().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list security group template rel failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list security group template rel failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protosgt.SGTemplateRelListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]coresgt.SecurityGroupTemplateRel, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, coresgt.SecurityGroupTemplateRel{
+			ID:         one.ID,
+			TemplateID: one.TemplateID,
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			Region:     one.Region,
+			ResType:    one.ResType,
+			ResID:      one.ResID,
+			TargetTag:  one.TargetTag,
+			Version:    one.Version,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protosgt.SGTemplateRelListResult{Details: details}, nil
+}
+
+// BatchDeleteSGTemplateRel batch delete security group template rel, the rules already synced to the resources
+// are kept.
+func (svc *sgTemplateSvc) BatchDeleteSGTemplateRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.SGTemplateRel().DeleteWithTx(cts.Kit, txn, req.Filter)
+	})
+	if err != nil {
+		logs.Errorf("delete security group template rel failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
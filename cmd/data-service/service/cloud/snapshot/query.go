@@ -0,0 +1,233 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	protosnap "hcm/pkg/api/data-service/cloud/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesnap "hcm/pkg/dal/table/cloud/snapshot"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+)
+
+// ListSnapshot snapshot.
+func (svc *snapSvc) ListSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.Snapshot().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list snapshot failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protosnap.SnapshotListResult{Count: result.Count}, nil
+	}
+
+	details := make([]coresnap.BaseSnapshot, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, *convTableToBaseSnapshot(&one))
+	}
+
+	return &protosnap.SnapshotListResult{Details: details}, nil
+}
+
+// GetSnapshot snapshot.
+func (svc *snapSvc) GetSnapshot(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "snapshot id is required")
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.Snapshot().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, fmt.Errorf("list snapshot failed, err: %v", err)
+	}
+
+	if len(result.Details) != 1 {
+		return nil, errf.New(errf.RecordNotFound, "snapshot not found")
+	}
+
+	snap := result.Details[0]
+	if snap.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "snapshot %s vendor is %s, not %s", id, snap.Vendor, vendor)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convSnapshotWithExt[coresnap.TCloudSnapshotExtension](&snap)
+	case enumor.Aws:
+		return convSnapshotWithExt[coresnap.AwsSnapshotExtension](&snap)
+	case enumor.HuaWei:
+		return convSnapshotWithExt[coresnap.HuaWeiSnapshotExtension](&snap)
+	case enumor.Azure:
+		return convSnapshotWithExt[coresnap.AzureSnapshotExtension](&snap)
+	case enumor.Gcp:
+		return convSnapshotWithExt[coresnap.GcpSnapshotExtension](&snap)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+// ListSnapshotExt snapshot with extension.
+func (svc *snapSvc) ListSnapshotExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.Snapshot().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list snapshot failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protosnap.SnapshotExtListResult[coresnap.TCloudSnapshotExtension]{Count: result.Count}, nil
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convSnapshotListResult[coresnap.TCloudSnapshotExtension](result.Details)
+	case enumor.Aws:
+		return convSnapshotListResult[coresnap.AwsSnapshotExtension](result.Details)
+	case enumor.HuaWei:
+		return convSnapshotListResult[coresnap.HuaWeiSnapshotExtension](result.Details)
+	case enumor.Azure:
+		return convSnapshotListResult[coresnap.AzureSnapshotExtension](result.Details)
+	case enumor.Gcp:
+		return convSnapshotListResult[coresnap.GcpSnapshotExtension](result.Details)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convSnapshotListResult[T coresnap.Extension](tables []tablesnap.SnapshotTable) (
+	*protosnap.SnapshotExtListResult[T], error) {
+
+	details := make([]coresnap.Snapshot[T], 0, len(tables))
+	for _, one := range tables {
+		snap, err := convSnapshotWithExt[T](&one)
+		if err != nil {
+			return nil, err
+		}
+
+		details = append(details, *snap)
+	}
+
+	return &protosnap.SnapshotExtListResult[T]{Details: details}, nil
+}
+
+func convSnapshotWithExt[T coresnap.Extension](one *tablesnap.SnapshotTable) (*coresnap.Snapshot[T], error) {
+	extension, err := unmarshalExtension[T](one.Extension)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal snapshot json extension failed, err: %v", err)
+	}
+
+	return &coresnap.Snapshot[T]{
+		BaseSnapshot: *convTableToBaseSnapshot(one),
+		Extension:    extension,
+	}, nil
+}
+
+func convTableToBaseSnapshot(one *tablesnap.SnapshotTable) *coresnap.BaseSnapshot {
+	return &coresnap.BaseSnapshot{
+		ID:               one.ID,
+		CloudID:          one.CloudID,
+		Name:             one.Name,
+		Vendor:           one.Vendor,
+		AccountID:        one.AccountID,
+		BkBizID:          one.BkBizID,
+		Region:           one.Region,
+		Zone:             one.Zone,
+		Status:           one.Status,
+		CloudDiskID:      one.CloudDiskID,
+		DiskID:           one.DiskID,
+		DiskSize:         one.DiskSize,
+		Encrypted:        one.Encrypted,
+		SnapshotPolicyID: one.SnapshotPolicyID,
+		Memo:             one.Memo,
+		CloudCreatedTime: one.CloudCreatedTime,
+		Revision: &core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+func unmarshalExtension[T any](extJson tabletype.JsonField) (*T, error) {
+	extension := new(T)
+	if len(extJson) == 0 {
+		return extension, nil
+	}
+
+	if err := json.UnmarshalFromString(string(extJson), extension); err != nil {
+		return nil, err
+	}
+
+	return extension, nil
+}
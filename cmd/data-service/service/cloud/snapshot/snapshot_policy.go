@@ -0,0 +1,226 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	protosnap "hcm/pkg/api/data-service/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesnap "hcm/pkg/dal/table/cloud/snapshot"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateSnapshotPolicy snapshot policy.
+func (svc *snapSvc) BatchCreateSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(protosnap.SnapshotPolicyBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablesnap.SnapshotPolicyTable, 0, len(req.Policies))
+		for _, one := range req.Policies {
+			models = append(models, &tablesnap.SnapshotPolicyTable{
+				Name:           one.Name,
+				BkBizID:        one.BkBizID,
+				Scope:          one.Scope,
+				DiskIDs:        one.DiskIDs,
+				Schedule:       one.Schedule,
+				RetentionCount: one.RetentionCount,
+				Enabled:        converter.ValToPtr(one.Enabled),
+				Memo:           one.Memo,
+				Creator:        cts.Kit.User,
+				Reviser:        cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.SnapshotPolicy().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create snapshot policy failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create snapshot policy but return id type is not []string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateSnapshotPolicy snapshot policy.
+func (svc *snapSvc) BatchUpdateSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(protosnap.SnapshotPolicyBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Policies {
+			update := &tablesnap.SnapshotPolicyTable{
+				Name:           one.Name,
+				Scope:          one.Scope,
+				DiskIDs:        one.DiskIDs,
+				Schedule:       one.Schedule,
+				RetentionCount: one.RetentionCount,
+				Enabled:        one.Enabled,
+				LastRunAt:      one.LastRunAt,
+				Memo:           one.Memo,
+				Reviser:        cts.Kit.User,
+			}
+
+			if err := svc.dao.SnapshotPolicy().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				logs.Errorf("update snapshot policy by id failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, fmt.Errorf("update snapshot policy failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSnapshotPolicy snapshot policy.
+func (svc *snapSvc) ListSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.SnapshotPolicy().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list snapshot policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list snapshot policy failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protosnap.SnapshotPolicyListResult{Count: result.Count}, nil
+	}
+
+	details := make([]coresnap.SnapshotPolicy, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coresnap.SnapshotPolicy{
+			ID:             one.ID,
+			Name:           one.Name,
+			BkBizID:        one.BkBizID,
+			Scope:          one.Scope,
+			DiskIDs:        one.DiskIDs,
+			Schedule:       one.Schedule,
+			RetentionCount: one.RetentionCount,
+			Enabled:        converter.PtrToVal(one.Enabled),
+			LastRunAt:      one.LastRunAt,
+			Memo:           one.Memo,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protosnap.SnapshotPolicyListResult{Details: details}, nil
+}
+
+// BatchDeleteSnapshotPolicy snapshot policy.
+func (svc *snapSvc) BatchDeleteSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(protosnap.SnapshotPolicyBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.SnapshotPolicy().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list snapshot policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list snapshot policy failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	delFilter := tools.ContainersExpression("id", delIDs)
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.SnapshotPolicy().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete snapshot policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,166 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	protosnap "hcm/pkg/api/data-service/cloud/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesnap "hcm/pkg/dal/table/cloud/snapshot"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchUpdateSnapshot snapshot.
+func (svc *snapSvc) BatchUpdateSnapshot(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return batchUpdateSnapshot[coresnap.TCloudSnapshotExtension](cts, svc)
+	case enumor.Aws:
+		return batchUpdateSnapshot[coresnap.AwsSnapshotExtension](cts, svc)
+	case enumor.HuaWei:
+		return batchUpdateSnapshot[coresnap.HuaWeiSnapshotExtension](cts, svc)
+	case enumor.Azure:
+		return batchUpdateSnapshot[coresnap.AzureSnapshotExtension](cts, svc)
+	case enumor.Gcp:
+		return batchUpdateSnapshot[coresnap.GcpSnapshotExtension](cts, svc)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func batchUpdateSnapshot[T coresnap.Extension](cts *rest.Contexts, svc *snapSvc) (interface{}, error) {
+	req := new(protosnap.SnapshotBatchUpdateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, 0, len(req.Snapshots))
+	for _, one := range req.Snapshots {
+		ids = append(ids, one.ID)
+	}
+	existSnapMap, err := svc.listSnapshotMap(cts.Kit, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Snapshots {
+			existSnap, exist := existSnapMap[one.ID]
+			if !exist {
+				continue
+			}
+
+			update := &tablesnap.SnapshotTable{
+				Name:        one.Name,
+				Status:      one.Status,
+				CloudDiskID: one.CloudDiskID,
+				DiskID:      one.DiskID,
+				DiskSize:    one.DiskSize,
+				Encrypted:   one.Encrypted,
+				Memo:        one.Memo,
+				Reviser:     cts.Kit.User,
+			}
+
+			if one.Extension != nil {
+				merge, err := json.UpdateMerge(one.Extension, string(existSnap.Extension))
+				if err != nil {
+					return nil, fmt.Errorf("json UpdateMerge extension failed, err: %v", err)
+				}
+				update.Extension = tabletype.JsonField(merge)
+			}
+
+			if err := svc.dao.Snapshot().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				logs.Errorf("update snapshot by id failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+				return nil, fmt.Errorf("update snapshot failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *snapSvc) listSnapshotMap(kt *kit.Kit, ids []string) (map[string]tablesnap.SnapshotTable, error) {
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := svc.dao.Snapshot().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list snapshot failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	result := make(map[string]tablesnap.SnapshotTable, len(list.Details))
+	for _, one := range list.Details {
+		result[one.ID] = one
+	}
+
+	return result, nil
+}
+
+// BatchUpdateSnapshotCommonInfo snapshot.
+func (svc *snapSvc) BatchUpdateSnapshotCommonInfo(cts *rest.Contexts) (interface{}, error) {
+	req := new(protosnap.SnapshotCommonInfoBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	updateFilter := tools.ContainersExpression("id", req.IDs)
+	updateField := &tablesnap.SnapshotTable{
+		BkBizID: req.BkBizID,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.Snapshot().Update(cts.Kit, updateFilter, updateField); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
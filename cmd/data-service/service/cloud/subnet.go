@@ -68,6 +68,32 @@ type subnetSvc struct {
 	dao dao.Set
 }
 
+// subnetVendor 厂商子网扩展字段的处理方法。
+type subnetVendor struct {
+	batchCreate    func(cts *rest.Contexts, vendor enumor.Vendor, svc *subnetSvc) (interface{}, error)
+	batchUpdate    func(cts *rest.Contexts, svc *subnetSvc) (interface{}, error)
+	convertResult  func(baseSubnet *protocore.BaseSubnet, dbExtension tabletype.JsonField) (interface{}, error)
+	convertExtList func(tables []tablecloud.SubnetTable) (interface{}, error)
+}
+
+var subnetVendors = newVendorRegistry[subnetVendor](enumor.SubnetCloudResType)
+
+// registerSubnetVendor 按厂商子网的创建、更新和查询扩展字段类型注册子网处理方法。
+func registerSubnetVendor[C protocloud.SubnetCreateExtension, U protocloud.SubnetUpdateExtension,
+	E protocore.SubnetExtension](vendor enumor.Vendor) {
+
+	subnetVendors.register(vendor, subnetVendor{
+		batchCreate: batchCreateSubnet[C],
+		batchUpdate: batchUpdateSubnet[U],
+		convertResult: func(baseSubnet *protocore.BaseSubnet, dbExtension tabletype.JsonField) (interface{}, error) {
+			return convertToSubnetResult[E](baseSubnet, dbExtension)
+		},
+		convertExtList: func(tables []tablecloud.SubnetTable) (interface{}, error) {
+			return conSubnetExtListResult[E](tables)
+		},
+	})
+}
+
 // BatchCreateSubnet batch create subnet.
 func (svc *subnetSvc) BatchCreateSubnet(cts *rest.Contexts) (interface{}, error) {
 	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
@@ -75,20 +101,12 @@ func (svc *subnetSvc) BatchCreateSubnet(cts *rest.Contexts) (interface{}, error)
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return batchCreateSubnet[protocloud.TCloudSubnetCreateExt](cts, vendor, svc)
-	case enumor.Aws:
-		return batchCreateSubnet[protocloud.AwsSubnetCreateExt](cts, vendor, svc)
-	case enumor.Gcp:
-		return batchCreateSubnet[protocloud.GcpSubnetCreateExt](cts, vendor, svc)
-	case enumor.HuaWei:
-		return batchCreateSubnet[protocloud.HuaWeiSubnetCreateExt](cts, vendor, svc)
-	case enumor.Azure:
-		return batchCreateSubnet[protocloud.AzureSubnetCreateExt](cts, vendor, svc)
+	handler, err := subnetVendors.get(vendor)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return handler.batchCreate(cts, vendor, svc)
 }
 
 // batchCreateSubnet batch create subnet.
@@ -173,20 +191,12 @@ func (svc *subnetSvc) BatchUpdateSubnet(cts *rest.Contexts) (interface{}, error)
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return batchUpdateSubnet[protocloud.TCloudSubnetUpdateExt](cts, svc)
-	case enumor.Aws:
-		return batchUpdateSubnet[protocloud.AwsSubnetUpdateExt](cts, svc)
-	case enumor.Gcp:
-		return batchUpdateSubnet[protocloud.GcpSubnetUpdateExt](cts, svc)
-	case enumor.HuaWei:
-		return batchUpdateSubnet[protocloud.HuaWeiSubnetUpdateExt](cts, svc)
-	case enumor.Azure:
-		return batchUpdateSubnet[protocloud.AzureSubnetUpdateExt](cts, svc)
+	handler, err := subnetVendors.get(vendor)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return handler.batchUpdate(cts, svc)
 }
 
 // batchUpdateSubnet batch update subnet.
@@ -334,20 +344,12 @@ func (svc *subnetSvc) GetSubnet(cts *rest.Contexts) (interface{}, error) {
 
 	base := convertBaseSubnet(dbSubnet)
 
-	switch vendor {
-	case enumor.TCloud:
-		return convertToSubnetResult[protocore.TCloudSubnetExtension](base, dbSubnet.Extension)
-	case enumor.Aws:
-		return convertToSubnetResult[protocore.AwsSubnetExtension](base, dbSubnet.Extension)
-	case enumor.Gcp:
-		return convertToSubnetResult[protocore.GcpSubnetExtension](base, dbSubnet.Extension)
-	case enumor.HuaWei:
-		return convertToSubnetResult[protocore.HuaWeiSubnetExtension](base, dbSubnet.Extension)
-	case enumor.Azure:
-		return convertToSubnetResult[protocore.AzureSubnetExtension](base, dbSubnet.Extension)
+	handler, err := subnetVendors.get(vendor)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return handler.convertResult(base, dbSubnet.Extension)
 }
 
 func convertToSubnetResult[T protocore.SubnetExtension](baseSubnet *protocore.BaseSubnet, dbExtension tabletype.JsonField) (
@@ -522,20 +524,12 @@ func (svc *subnetSvc) ListSubnetExt(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return conSubnetExtListResult[protocore.TCloudSubnetExtension](listResp.Details)
-	case enumor.Aws:
-		return conSubnetExtListResult[protocore.AwsSubnetExtension](listResp.Details)
-	case enumor.Azure:
-		return conSubnetExtListResult[protocore.AzureSubnetExtension](listResp.Details)
-	case enumor.HuaWei:
-		return conSubnetExtListResult[protocore.HuaWeiSubnetExtension](listResp.Details)
-	case enumor.Gcp:
-		return conSubnetExtListResult[protocore.GcpSubnetExtension](listResp.Details)
-	default:
-		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	handler, err := subnetVendors.get(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.convertExtList(listResp.Details)
 }
 
 func conSubnetExtListResult[T protocore.SubnetExtension](tables []tablecloud.SubnetTable) (
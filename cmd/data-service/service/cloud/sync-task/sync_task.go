@@ -0,0 +1,325 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package synctask ...
+package synctask
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesynctask "hcm/pkg/dal/table/cloud/sync-task"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitSyncTaskService initialize the sync task service.
+func InitSyncTaskService(cap *capability.Capability) {
+	svc := &syncTaskSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+	h.Add("CreateSyncTask", http.MethodPost, "/sync_tasks/create", svc.CreateSyncTask)
+	h.Add("UpdateSyncTask", http.MethodPatch, "/sync_tasks/{id}", svc.UpdateSyncTask)
+	h.Add("ListSyncTask", http.MethodPost, "/sync_tasks/list", svc.ListSyncTask)
+
+	h.Add("BatchCreateSyncDetail", http.MethodPost, "/sync_details/batch/create", svc.BatchCreateSyncDetail)
+	h.Add("UpdateSyncDetail", http.MethodPatch, "/sync_details/{id}", svc.UpdateSyncDetail)
+	h.Add("ListSyncDetail", http.MethodPost, "/sync_details/list", svc.ListSyncDetail)
+
+	h.Add("SetSyncWatermark", http.MethodPut, "/sync_watermarks/set", svc.SetSyncWatermark)
+	h.Add("ListSyncWatermark", http.MethodPost, "/sync_watermarks/list", svc.ListSyncWatermark)
+
+	h.Load(cap.WebService)
+}
+
+type syncTaskSvc struct {
+	dao dao.Set
+}
+
+// CreateSyncTask create sync task.
+func (svc *syncTaskSvc) CreateSyncTask(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.SyncTaskCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	taskIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		task := tablesynctask.SyncTaskTable{
+			Vendor:      req.Vendor,
+			AccountID:   req.AccountID,
+			TriggerType: req.TriggerType,
+			Status:      enumor.RunningSyncTaskStatus,
+			StartAt:     req.StartAt,
+			Creator:     cts.Kit.User,
+			Reviser:     cts.Kit.User,
+		}
+
+		ids, err := svc.dao.SyncTask().CreateWithTx(cts.Kit, txn, []tablesynctask.SyncTaskTable{task})
+		if err != nil {
+			return nil, fmt.Errorf("create sync task failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := taskIDs.([]string)
+	if !ok || len(ids) != 1 {
+		return nil, fmt.Errorf("create sync task but return ids is invalid, ids type: %v",
+			reflect.TypeOf(taskIDs).String())
+	}
+
+	return &core.CreateResult{ID: ids[0]}, nil
+}
+
+// UpdateSyncTask update sync task result.
+func (svc *syncTaskSvc) UpdateSyncTask(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "sync task id is required")
+	}
+
+	req := new(protocloud.SyncTaskUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		task := &tablesynctask.SyncTaskTable{
+			Status:      req.Status,
+			ChangeCount: req.ChangeCount,
+			Reason:      req.Reason,
+			EndAt:       req.EndAt,
+			Reviser:     cts.Kit.User,
+		}
+		return nil, svc.dao.SyncTask().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", id), task)
+	})
+	if err != nil {
+		logs.Errorf("update sync task failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSyncTask list sync task.
+func (svc *syncTaskSvc) ListSyncTask(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.SyncTask().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list sync task failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list sync task failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protocloud.SyncTaskListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]corecloud.SyncTask, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, corecloud.SyncTask{
+			ID:          one.ID,
+			Vendor:      one.Vendor,
+			AccountID:   one.AccountID,
+			TriggerType: one.TriggerType,
+			Status:      one.Status,
+			ChangeCount: one.ChangeCount,
+			Reason:      one.Reason,
+			StartAt:     one.StartAt,
+			EndAt:       one.EndAt,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protocloud.SyncTaskListResult{Details: details}, nil
+}
+
+// BatchCreateSyncDetail batch create sync detail.
+func (svc *syncTaskSvc) BatchCreateSyncDetail(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.SyncDetailBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	detailIDs, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		details := make([]tablesynctask.SyncDetailTable, 0, len(req.Details))
+		for _, one := range req.Details {
+			details = append(details, tablesynctask.SyncDetailTable{
+				TaskID:    one.TaskID,
+				Vendor:    one.Vendor,
+				AccountID: one.AccountID,
+				ResType:   one.ResType,
+				Status:    enumor.RunningSyncTaskStatus,
+				StartAt:   one.StartAt,
+				Creator:   cts.Kit.User,
+				Reviser:   cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.SyncDetail().CreateWithTx(cts.Kit, txn, details)
+		if err != nil {
+			return nil, fmt.Errorf("create sync detail failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := detailIDs.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create sync detail but return id type is not string, id type: %v",
+			reflect.TypeOf(detailIDs).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// UpdateSyncDetail update sync detail result.
+func (svc *syncTaskSvc) UpdateSyncDetail(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "sync detail id is required")
+	}
+
+	req := new(protocloud.SyncTaskUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		detail := &tablesynctask.SyncDetailTable{
+			Status:      req.Status,
+			ChangeCount: req.ChangeCount,
+			Reason:      req.Reason,
+			EndAt:       req.EndAt,
+			Reviser:     cts.Kit.User,
+		}
+		return nil, svc.dao.SyncDetail().UpdateWithTx(cts.Kit, txn, tools.EqualExpression("id", id), detail)
+	})
+	if err != nil {
+		logs.Errorf("update sync detail failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSyncDetail list sync detail.
+func (svc *syncTaskSvc) ListSyncDetail(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.SyncDetail().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list sync detail failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list sync detail failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protocloud.SyncDetailListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]corecloud.SyncDetail, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, corecloud.SyncDetail{
+			ID:          one.ID,
+			TaskID:      one.TaskID,
+			Vendor:      one.Vendor,
+			AccountID:   one.AccountID,
+			ResType:     one.ResType,
+			Status:      one.Status,
+			ChangeCount: one.ChangeCount,
+			Reason:      one.Reason,
+			StartAt:     one.StartAt,
+			EndAt:       one.EndAt,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protocloud.SyncDetailListResult{Details: details}, nil
+}
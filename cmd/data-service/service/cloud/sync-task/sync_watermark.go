@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package synctask
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesynctask "hcm/pkg/dal/table/cloud/sync-task"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SetSyncWatermark set account incremental sync watermark, create it if account has no watermark.
+func (svc *syncTaskSvc) SetSyncWatermark(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.SyncWatermarkSetReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listOpt := &types.ListOption{
+		Filter: tools.EqualExpression("account_id", req.AccountID),
+		Page:   core.NewCountPage(),
+	}
+	result, err := svc.dao.SyncWatermark().List(cts.Kit, listOpt)
+	if err != nil {
+		logs.Errorf("count sync watermark failed, err: %v, account: %s, rid: %s", err, req.AccountID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if result.Count == 0 {
+			watermark := tablesynctask.SyncWatermarkTable{
+				Vendor:    req.Vendor,
+				AccountID: req.AccountID,
+				Watermark: req.Watermark,
+				Creator:   cts.Kit.User,
+				Reviser:   cts.Kit.User,
+			}
+			_, err := svc.dao.SyncWatermark().CreateWithTx(cts.Kit, txn,
+				[]tablesynctask.SyncWatermarkTable{watermark})
+			return nil, err
+		}
+
+		watermark := &tablesynctask.SyncWatermarkTable{
+			Watermark: req.Watermark,
+			Reviser:   cts.Kit.User,
+		}
+		return nil, svc.dao.SyncWatermark().UpdateWithTx(cts.Kit, txn,
+			tools.EqualExpression("account_id", req.AccountID), watermark)
+	})
+	if err != nil {
+		logs.Errorf("set sync watermark failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSyncWatermark list sync watermark.
+func (svc *syncTaskSvc) ListSyncWatermark(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.SyncWatermark().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list sync watermark failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list sync watermark failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protocloud.SyncWatermarkListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]corecloud.SyncWatermark, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, corecloud.SyncWatermark{
+			ID:        one.ID,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			Watermark: one.Watermark,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protocloud.SyncWatermarkListResult{Details: details}, nil
+}
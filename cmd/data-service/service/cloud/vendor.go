@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+)
+
+// vendorRegistry 资源扩展字段的厂商注册表，各厂商在自身文件的 init 中注册扩展字段的处理方法，
+// vpc、子网、安全组等通用接口通过注册表查找厂商，新增厂商不需要修改这些接口。
+// 注册表只在 init 中写入，之后只读，无需加锁。
+type vendorRegistry[H any] struct {
+	resType  enumor.CloudResourceType
+	handlers map[enumor.Vendor]H
+}
+
+func newVendorRegistry[H any](resType enumor.CloudResourceType) *vendorRegistry[H] {
+	return &vendorRegistry[H]{
+		resType:  resType,
+		handlers: make(map[enumor.Vendor]H),
+	}
+}
+
+// register vendor handler, register the same vendor twice will panic.
+func (r *vendorRegistry[H]) register(vendor enumor.Vendor, handler H) {
+	if _, exist := r.handlers[vendor]; exist {
+		panic(fmt.Sprintf("vendor: %s %s handler is already registered", vendor, r.resType))
+	}
+	r.handlers[vendor] = handler
+}
+
+// get returns handler of the vendor.
+func (r *vendorRegistry[H]) get(vendor enumor.Vendor) (H, error) {
+	handler, exist := r.handlers[vendor]
+	if !exist {
+		return handler, errf.Newf(errf.InvalidParameter, "unsupported %s vendor: %s", r.resType, vendor)
+	}
+
+	return handler, nil
+}
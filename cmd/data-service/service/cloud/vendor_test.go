@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestVendorsRegistered(t *testing.T) {
+	vendors := []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei, enumor.Gcp, enumor.Azure, enumor.OpenStack}
+	for _, vendor := range vendors {
+		if _, err := vpcVendors.get(vendor); err != nil {
+			t.Errorf("vendor %s vpc handler expect registered, but not", vendor)
+		}
+		if _, err := subnetVendors.get(vendor); err != nil {
+			t.Errorf("vendor %s subnet handler expect registered, but not", vendor)
+		}
+		if _, err := sgVendors.get(vendor); err != nil && vendor != enumor.Gcp {
+			t.Errorf("vendor %s security group handler expect registered, but not", vendor)
+		}
+	}
+
+	// gcp 使用防火墙规则，没有安全组
+	if _, err := sgVendors.get(enumor.Gcp); err == nil {
+		t.Errorf("vendor gcp security group handler expect not registered, but registered")
+	}
+}
+
+func TestVendorRegistryRegisterTwice(t *testing.T) {
+	registry := newVendorRegistry[int](enumor.VpcCloudResType)
+	registry.register(enumor.TCloud, 1)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("register vendor twice expect panic, but not")
+		}
+	}()
+	registry.register(enumor.TCloud, 2)
+}
@@ -0,0 +1,301 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package vpcpeering ...
+package vpcpeering
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	corevpcpeering "hcm/pkg/api/core/cloud/vpc-peering"
+	protovpcpeering "hcm/pkg/api/data-service/cloud/vpc-peering"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablevpcpeering "hcm/pkg/dal/table/cloud/vpc-peering"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the vpc peering service
+func InitService(cap *capability.Capability) {
+	svc := &vpcPeeringSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateVpcPeering", http.MethodPost, "/vpc_peerings/batch/create", svc.BatchCreateVpcPeering)
+	h.Add("BatchUpdateVpcPeering", http.MethodPatch, "/vpc_peerings/batch/update", svc.BatchUpdateVpcPeering)
+	h.Add("BatchUpdateVpcPeeringCommonInfo", http.MethodPatch, "/vpc_peerings/common/info/batch/update",
+		svc.BatchUpdateVpcPeeringCommonInfo)
+	h.Add("ListVpcPeering", http.MethodPost, "/vpc_peerings/list", svc.ListVpcPeering)
+	h.Add("BatchDeleteVpcPeering", http.MethodDelete, "/vpc_peerings/batch", svc.BatchDeleteVpcPeering)
+
+	h.Load(cap.WebService)
+}
+
+type vpcPeeringSvc struct {
+	dao dao.Set
+}
+
+// BatchCreateVpcPeering vpc peering.
+func (svc *vpcPeeringSvc) BatchCreateVpcPeering(cts *rest.Contexts) (interface{}, error) {
+	req := new(protovpcpeering.VpcPeeringBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablevpcpeering.VpcPeeringTable, 0, len(req.VpcPeerings))
+		for _, one := range req.VpcPeerings {
+			models = append(models, &tablevpcpeering.VpcPeeringTable{
+				Vendor:             one.Vendor,
+				AccountID:          one.AccountID,
+				Region:             one.Region,
+				CloudID:            one.CloudID,
+				Name:               one.Name,
+				Type:               one.Type,
+				Status:             one.Status,
+				CloudStatus:        one.CloudStatus,
+				CloudGatewayID:     one.CloudGatewayID,
+				LocalVpcID:         one.LocalVpcID,
+				LocalCloudVpcID:    one.LocalCloudVpcID,
+				LocalCidrs:         one.LocalCidrs,
+				PeerVpcID:          one.PeerVpcID,
+				PeerCloudVpcID:     one.PeerCloudVpcID,
+				PeerCloudAccountID: one.PeerCloudAccountID,
+				PeerRegion:         one.PeerRegion,
+				PeerCidrs:          one.PeerCidrs,
+				BkBizID:            one.BkBizID,
+				Memo:               one.Memo,
+				CloudCreatedTime:   one.CloudCreatedTime,
+				Creator:            cts.Kit.User,
+				Reviser:            cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.VpcPeering().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create vpc peering failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create vpc peering but return id type is not []string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateVpcPeering vpc peering.
+func (svc *vpcPeeringSvc) BatchUpdateVpcPeering(cts *rest.Contexts) (interface{}, error) {
+	req := new(protovpcpeering.VpcPeeringBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.VpcPeerings {
+			update := &tablevpcpeering.VpcPeeringTable{
+				Name:               one.Name,
+				Status:             one.Status,
+				CloudStatus:        one.CloudStatus,
+				CloudGatewayID:     one.CloudGatewayID,
+				LocalVpcID:         one.LocalVpcID,
+				LocalCloudVpcID:    one.LocalCloudVpcID,
+				LocalCidrs:         one.LocalCidrs,
+				PeerVpcID:          one.PeerVpcID,
+				PeerCloudVpcID:     one.PeerCloudVpcID,
+				PeerCloudAccountID: one.PeerCloudAccountID,
+				PeerRegion:         one.PeerRegion,
+				PeerCidrs:          one.PeerCidrs,
+				Memo:               one.Memo,
+				Reviser:            cts.Kit.User,
+			}
+
+			if err := svc.dao.VpcPeering().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				logs.Errorf("update vpc peering by id failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+				return nil, fmt.Errorf("update vpc peering failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchUpdateVpcPeeringCommonInfo vpc peering.
+func (svc *vpcPeeringSvc) BatchUpdateVpcPeeringCommonInfo(cts *rest.Contexts) (interface{}, error) {
+	req := new(protovpcpeering.VpcPeeringCommonInfoBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	updateFilter := tools.ContainersExpression("id", req.IDs)
+	updateField := &tablevpcpeering.VpcPeeringTable{
+		BkBizID: req.BkBizID,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.VpcPeering().Update(cts.Kit, updateFilter, updateField); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListVpcPeering vpc peering.
+func (svc *vpcPeeringSvc) ListVpcPeering(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.VpcPeering().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list vpc peering failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list vpc peering failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protovpcpeering.VpcPeeringListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corevpcpeering.VpcPeering, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corevpcpeering.VpcPeering{
+			ID:                 one.ID,
+			Vendor:             one.Vendor,
+			AccountID:          one.AccountID,
+			Region:             one.Region,
+			CloudID:            one.CloudID,
+			Name:               one.Name,
+			Type:               one.Type,
+			Status:             one.Status,
+			CloudStatus:        one.CloudStatus,
+			CloudGatewayID:     one.CloudGatewayID,
+			LocalVpcID:         one.LocalVpcID,
+			LocalCloudVpcID:    one.LocalCloudVpcID,
+			LocalCidrs:         one.LocalCidrs,
+			PeerVpcID:          one.PeerVpcID,
+			PeerCloudVpcID:     one.PeerCloudVpcID,
+			PeerCloudAccountID: one.PeerCloudAccountID,
+			PeerRegion:         one.PeerRegion,
+			PeerCidrs:          one.PeerCidrs,
+			BkBizID:            one.BkBizID,
+			Memo:               one.Memo,
+			CloudCreatedTime:   one.CloudCreatedTime,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protovpcpeering.VpcPeeringListResult{Details: details}, nil
+}
+
+// BatchDeleteVpcPeering vpc peering.
+func (svc *vpcPeeringSvc) BatchDeleteVpcPeering(cts *rest.Contexts) (interface{}, error) {
+	req := new(protovpcpeering.VpcPeeringBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.VpcPeering().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list vpc peering failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list vpc peering failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	delFilter := tools.ContainersExpression("id", delIDs)
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.VpcPeering().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete vpc peering failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -70,6 +70,32 @@ type vpcSvc struct {
 	dao dao.Set
 }
 
+// vpcVendor 厂商 vpc 扩展字段的处理方法。
+type vpcVendor struct {
+	batchCreate    func(cts *rest.Contexts, vendor enumor.Vendor, svc *vpcSvc) (interface{}, error)
+	batchUpdate    func(cts *rest.Contexts, svc *vpcSvc) (interface{}, error)
+	convertResult  func(baseVpc *protocore.BaseVpc, dbExtension tabletype.JsonField) (interface{}, error)
+	convertExtList func(tables []tablecloud.VpcTable) (interface{}, error)
+}
+
+var vpcVendors = newVendorRegistry[vpcVendor](enumor.VpcCloudResType)
+
+// registerVpcVendor 按厂商 vpc 的创建、更新和查询扩展字段类型注册 vpc 处理方法。
+func registerVpcVendor[C protocloud.VpcCreateExtension, U protocloud.VpcUpdateExtension,
+	E protocore.VpcExtension](vendor enumor.Vendor) {
+
+	vpcVendors.register(vendor, vpcVendor{
+		batchCreate: batchCreateVpc[C],
+		batchUpdate: batchUpdateVpc[U],
+		convertResult: func(baseVpc *protocore.BaseVpc, dbExtension tabletype.JsonField) (interface{}, error) {
+			return convertToVpcResult[E](baseVpc, dbExtension)
+		},
+		convertExtList: func(tables []tablecloud.VpcTable) (interface{}, error) {
+			return conVpcExtListResult[E](tables)
+		},
+	})
+}
+
 // BatchCreateVpc batch create vpc.
 func (svc *vpcSvc) BatchCreateVpc(cts *rest.Contexts) (interface{}, error) {
 	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
@@ -77,20 +103,12 @@ func (svc *vpcSvc) BatchCreateVpc(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return batchCreateVpc[protocloud.TCloudVpcCreateExt](cts, vendor, svc)
-	case enumor.Aws:
-		return batchCreateVpc[protocloud.AwsVpcCreateExt](cts, vendor, svc)
-	case enumor.Gcp:
-		return batchCreateVpc[protocloud.GcpVpcCreateExt](cts, vendor, svc)
-	case enumor.HuaWei:
-		return batchCreateVpc[protocloud.HuaWeiVpcCreateExt](cts, vendor, svc)
-	case enumor.Azure:
-		return batchCreateVpc[protocloud.AzureVpcCreateExt](cts, vendor, svc)
+	handler, err := vpcVendors.get(vendor)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return handler.batchCreate(cts, vendor, svc)
 }
 
 // batchCreateVpc batch create vpc.
@@ -160,20 +178,12 @@ func (svc *vpcSvc) BatchUpdateVpc(cts *rest.Contexts) (interface{}, error) {
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return batchUpdateVpc[protocloud.TCloudVpcUpdateExt](cts, svc)
-	case enumor.Aws:
-		return batchUpdateVpc[protocloud.AwsVpcUpdateExt](cts, svc)
-	case enumor.Gcp:
-		return batchUpdateVpc[protocloud.GcpVpcUpdateExt](cts, svc)
-	case enumor.HuaWei:
-		return batchUpdateVpc[protocloud.HuaWeiVpcUpdateExt](cts, svc)
-	case enumor.Azure:
-		return batchUpdateVpc[protocloud.AzureVpcUpdateExt](cts, svc)
+	handler, err := vpcVendors.get(vendor)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return handler.batchUpdate(cts, svc)
 }
 
 // batchUpdateVpc batch update vpc.
@@ -340,20 +350,12 @@ func (svc *vpcSvc) GetVpc(cts *rest.Contexts) (interface{}, error) {
 
 	base := convertBaseVpc(dbVpc)
 
-	switch vendor {
-	case enumor.TCloud:
-		return convertToVpcResult[protocore.TCloudVpcExtension](base, dbVpc.Extension)
-	case enumor.Aws:
-		return convertToVpcResult[protocore.AwsVpcExtension](base, dbVpc.Extension)
-	case enumor.Gcp:
-		return convertToVpcResult[protocore.GcpVpcExtension](base, dbVpc.Extension)
-	case enumor.HuaWei:
-		return convertToVpcResult[protocore.HuaWeiVpcExtension](base, dbVpc.Extension)
-	case enumor.Azure:
-		return convertToVpcResult[protocore.AzureVpcExtension](base, dbVpc.Extension)
+	handler, err := vpcVendors.get(vendor)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return handler.convertResult(base, dbVpc.Extension)
 }
 
 func convertToVpcResult[T protocore.VpcExtension](baseVpc *protocore.BaseVpc, dbExtension tabletype.JsonField) (
@@ -528,20 +530,12 @@ func (svc *vpcSvc) ListVpcExt(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	switch vendor {
-	case enumor.TCloud:
-		return conVpcExtListResult[protocore.TCloudVpcExtension](listResp.Details)
-	case enumor.Aws:
-		return conVpcExtListResult[protocore.AwsVpcExtension](listResp.Details)
-	case enumor.Azure:
-		return conVpcExtListResult[protocore.AzureVpcExtension](listResp.Details)
-	case enumor.HuaWei:
-		return conVpcExtListResult[protocore.HuaWeiVpcExtension](listResp.Details)
-	case enumor.Gcp:
-		return conVpcExtListResult[protocore.GcpVpcExtension](listResp.Details)
-	default:
-		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	handler, err := vpcVendors.get(vendor)
+	if err != nil {
+		return nil, err
 	}
+
+	return handler.convertExtList(listResp.Details)
 }
 
 func conVpcExtListResult[T protocore.VpcExtension](tables []tablecloud.VpcTable) (
@@ -36,25 +36,38 @@ import (
 	"hcm/cmd/data-service/service/cloud/account"
 	accountbizrel "hcm/cmd/data-service/service/cloud/account-biz-rel"
 	"hcm/cmd/data-service/service/cloud/bill"
+	"hcm/cmd/data-service/service/cloud/bucket"
 	"hcm/cmd/data-service/service/cloud/cvm"
 	"hcm/cmd/data-service/service/cloud/disk"
 	diskcvmrel "hcm/cmd/data-service/service/cloud/disk-cvm-rel"
+	driftevent "hcm/cmd/data-service/service/cloud/drift-event"
 	"hcm/cmd/data-service/service/cloud/eip"
 	eipcvmrel "hcm/cmd/data-service/service/cloud/eip-cvm-rel"
 	"hcm/cmd/data-service/service/cloud/image"
+	"hcm/cmd/data-service/service/cloud/ipam"
+	keypair "hcm/cmd/data-service/service/cloud/key-pair"
+	loadbalancer "hcm/cmd/data-service/service/cloud/load-balancer"
+	natgateway "hcm/cmd/data-service/service/cloud/nat-gateway"
 	networkinterface "hcm/cmd/data-service/service/cloud/network-interface"
 	networkcvmrel "hcm/cmd/data-service/service/cloud/network-interface-cvm-rel"
 	"hcm/cmd/data-service/service/cloud/region"
 	resourcegroup "hcm/cmd/data-service/service/cloud/resource-group"
+	resourcetag "hcm/cmd/data-service/service/cloud/resource-tag"
 	routetable "hcm/cmd/data-service/service/cloud/route-table"
 	sgcvmrel "hcm/cmd/data-service/service/cloud/security-group-cvm-rel"
+	sgtemplate "hcm/cmd/data-service/service/cloud/sg-template"
+	"hcm/cmd/data-service/service/cloud/snapshot"
+	synctask "hcm/cmd/data-service/service/cloud/sync-task"
+	vpcpeering "hcm/cmd/data-service/service/cloud/vpc-peering"
 	"hcm/cmd/data-service/service/cloud/zone"
 	recyclerecord "hcm/cmd/data-service/service/recycle-record"
 	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/handler"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
 	"hcm/pkg/rest"
@@ -107,7 +120,33 @@ func NewService() (*Service, error) {
 func newCipherFromConfig(cryptoConfig cc.Crypto) (cryptography.Crypto, error) {
 	// TODO: 目前只支持国际加密，还未支持中国国家商业加密，待后续支持再调整
 	cfg := cryptoConfig.AesGcm
-	return cryptography.NewAESGcm([]byte(cfg.Key), []byte(cfg.Nonce))
+
+	var legacy *cryptography.AESGcm
+	if len(cfg.Key) != 0 {
+		var err error
+		legacy, err = cryptography.NewAESGcm([]byte(cfg.Key), []byte(cfg.Nonce))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	primaryKeyID, keys := cfg.KeyringKeys()
+	return cryptography.NewAESGcmKeyring(primaryKeyID, keys, legacy)
+}
+
+// ReEncryptAccountSecret 离线使用当前主密钥重新加密全部账号密钥
+func (s *Service) ReEncryptAccountSecret() error {
+	kt := kit.New()
+	kt.User = constant.ReEncryptUserKey
+
+	result, err := account.ReEncryptSecret(kt, s.dao, s.cipher, nil)
+	if err != nil {
+		logs.Errorf("re-encrypt account secret failed, err: %v, result: %+v, rid: %s", err, result, kt.Rid)
+		return err
+	}
+
+	logs.Infof("re-encrypt account secret success, result: %+v, rid: %s", result, kt.Rid)
+	return nil
 }
 
 // ListenAndServeRest listen and serve the restful server
@@ -192,6 +231,7 @@ func (s *Service) apiSet() *restful.Container {
 	region.InitHuaWeiRegionService(capability)
 	resourcegroup.InitAzureResourceGroupService(capability)
 	region.InitAzureRegionService(capability)
+	region.InitOpenStackRegionService(capability)
 	audit.InitAuditService(capability)
 	eip.InitEipService(capability)
 	zone.InitZoneService(capability)
@@ -207,6 +247,20 @@ func (s *Service) apiSet() *restful.Container {
 	networkcvmrel.InitService(capability)
 	recyclerecord.InitRecycleRecordService(capability)
 	bill.InitBillConfigService(capability)
+	bill.InitBillItemService(capability)
+	bill.InitExchangeRateService(capability)
+	bill.InitBudgetService(capability)
+	driftevent.InitResDriftEventService(capability)
+	synctask.InitSyncTaskService(capability)
+	loadbalancer.InitService(capability)
+	natgateway.InitService(capability)
+	snapshot.InitService(capability)
+	keypair.InitService(capability)
+	bucket.InitService(capability)
+	vpcpeering.InitService(capability)
+	ipam.InitService(capability)
+	sgtemplate.InitService(capability)
+	resourcetag.InitService(capability)
 
 	return restful.NewContainer().Add(capability.WebService)
 }
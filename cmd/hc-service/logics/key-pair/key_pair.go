@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair defines ssh key pair logics.
+package keypair
+
+import (
+	"fmt"
+
+	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
+	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
+	cloudclient "hcm/cmd/hc-service/service/cloud-adaptor"
+	typekp "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/api/core"
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// KeyPair logics.
+type KeyPair struct {
+	client  *client.ClientSet
+	adaptor *cloudclient.CloudAdaptorClient
+}
+
+// NewKeyPair new key pair logics.
+func NewKeyPair(client *client.ClientSet, adaptor *cloudclient.CloudAdaptorClient) *KeyPair {
+	return &KeyPair{
+		client:  client,
+		adaptor: adaptor,
+	}
+}
+
+// Get hcm key pair by id.
+func (k *KeyPair) Get(kt *kit.Kit, id string) (*corekp.KeyPair, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := k.client.DataService().Global.KeyPair.ListKeyPair(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "key pair: %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
+
+// Push hcm key pair to the region of cloud account and return the cloud key pair. if the key pair has been pushed,
+// the existing cloud key pair is returned directly, so it can be called before every cvm creation.
+func (k *KeyPair) Push(kt *kit.Kit, vendor enumor.Vendor, accountID, region, keyPairID string) (
+	*corekp.CloudKeyPair, error) {
+
+	rules := []filter.RuleFactory{
+		&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+		&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+		&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+	}
+	pushed, err := k.getCloudKeyPair(kt, append(rules,
+		&filter.AtomRule{Field: "key_pair_id", Op: filter.Equal.Factory(), Value: keyPairID}))
+	if err != nil {
+		return nil, err
+	}
+
+	if pushed != nil {
+		return pushed, nil
+	}
+
+	keyPair, err := k.Get(kt, keyPairID)
+	if err != nil {
+		return nil, err
+	}
+
+	importOpt := &typekp.ImportOption{
+		Region:    region,
+		Name:      keyPair.Name,
+		PublicKey: keyPair.PublicKey,
+	}
+	cloudID, err := k.importAndSync(kt, vendor, accountID, importOpt, keyPair)
+	if err != nil {
+		logs.Errorf("push key pair to cloud failed, err: %v, vendor: %s, account: %s, region: %s, key pair: %s, "+
+			"rid: %s", err, vendor, accountID, region, keyPairID, kt.Rid)
+		return nil, err
+	}
+
+	created, err := k.getCloudKeyPair(kt, append(rules,
+		&filter.AtomRule{Field: "cloud_id", Op: filter.Equal.Factory(), Value: cloudID}))
+	if err != nil {
+		return nil, err
+	}
+
+	if created == nil {
+		return nil, fmt.Errorf("cloud key pair %s is not synced to db after push", cloudID)
+	}
+
+	return created, nil
+}
+
+func (k *KeyPair) importAndSync(kt *kit.Kit, vendor enumor.Vendor, accountID string, opt *typekp.ImportOption,
+	keyPair *corekp.KeyPair) (string, error) {
+
+	dataCli := k.client.DataService()
+	switch vendor {
+	case enumor.TCloud:
+		cli, err := k.adaptor.TCloud(kt, accountID)
+		if err != nil {
+			return "", err
+		}
+
+		cloudID, err := cli.ImportKeyPair(kt, opt)
+		if err != nil {
+			return "", err
+		}
+
+		params := &synctcloud.SyncBaseParams{AccountID: accountID, Region: opt.Region, CloudIDs: []string{cloudID}}
+		syncOpt := &synctcloud.SyncKeyPairOption{BkBizID: keyPair.BkBizID, KeyPairID: keyPair.ID}
+		if _, err = synctcloud.NewClient(dataCli, cli).KeyPair(kt, params, syncOpt); err != nil {
+			return "", err
+		}
+
+		return cloudID, nil
+
+	case enumor.Aws:
+		cli, err := k.adaptor.Aws(kt, accountID)
+		if err != nil {
+			return "", err
+		}
+
+		cloudID, err := cli.ImportKeyPair(kt, opt)
+		if err != nil {
+			return "", err
+		}
+
+		params := &syncaws.SyncBaseParams{AccountID: accountID, Region: opt.Region, CloudIDs: []string{cloudID}}
+		syncOpt := &syncaws.SyncKeyPairOption{BkBizID: keyPair.BkBizID, KeyPairID: keyPair.ID}
+		if _, err = syncaws.NewClient(dataCli, cli).KeyPair(kt, params, syncOpt); err != nil {
+			return "", err
+		}
+
+		return cloudID, nil
+
+	case enumor.HuaWei:
+		cli, err := k.adaptor.HuaWei(kt, accountID)
+		if err != nil {
+			return "", err
+		}
+
+		cloudID, err := cli.ImportKeyPair(kt, opt)
+		if err != nil {
+			return "", err
+		}
+
+		params := &synchuawei.SyncBaseParams{AccountID: accountID, Region: opt.Region, CloudIDs: []string{cloudID}}
+		syncOpt := &synchuawei.SyncKeyPairOption{BkBizID: keyPair.BkBizID, KeyPairID: keyPair.ID}
+		if _, err = synchuawei.NewClient(dataCli, cli).KeyPair(kt, params, syncOpt); err != nil {
+			return "", err
+		}
+
+		return cloudID, nil
+
+	default:
+		return "", fmt.Errorf("vendor %s does not support push key pair", vendor)
+	}
+}
+
+func (k *KeyPair) getCloudKeyPair(kt *kit.Kit, rules []filter.RuleFactory) (*corekp.CloudKeyPair, error) {
+	req := &core.ListReq{
+		Filter: &filter.Expression{Op: filter.And, Rules: rules},
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := k.client.DataService().Global.KeyPair.ListCloudKeyPair(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list cloud key pair failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, nil
+	}
+
+	return &result.Details[0], nil
+}
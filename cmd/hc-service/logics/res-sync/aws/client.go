@@ -22,6 +22,7 @@ package aws
 import (
 	"strings"
 
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	"hcm/pkg/adaptor/aws"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/kit"
@@ -40,6 +41,16 @@ type Interface interface {
 
 	Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption) (*SyncResult, error)
 	RemoveEipDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
+	LoadBalancer(kt *kit.Kit, params *SyncBaseParams, opt *SyncLoadBalancerOption) (*SyncResult, error)
+	RemoveLoadBalancerDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
+	NatGateway(kt *kit.Kit, params *SyncBaseParams, opt *SyncNatGatewayOption) (*SyncResult, error)
+	RemoveNatGatewayDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
+	Snapshot(kt *kit.Kit, params *SyncBaseParams, opt *SyncSnapshotOption) (*SyncResult, error)
+	RemoveSnapshotDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
+	Bucket(kt *kit.Kit, params *common.SyncBucketParams) (*SyncResult, error)
+	VpcPeering(kt *kit.Kit, params *common.SyncVpcPeeringParams) (*SyncResult, error)
+	KeyPair(kt *kit.Kit, params *SyncBaseParams, opt *SyncKeyPairOption) (*SyncResult, error)
+	RemoveKeyPairDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
 
 	RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRouteTableOption) (*SyncResult, error)
 	RemoveRouteTableDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
@@ -53,6 +64,9 @@ type Interface interface {
 	Image(kt *kit.Kit, params *SyncBaseParams, opt *SyncImageOption) (*SyncResult, error)
 	RemoveImageDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
 
+	PrivateImage(kt *kit.Kit, params *SyncBaseParams, opt *SyncPrivateImageOption) (*SyncResult, error)
+	RemovePrivateImageDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
+
 	Vpc(kt *kit.Kit, params *SyncBaseParams, opt *SyncVpcOption) (*SyncResult, error)
 	RemoveVpcDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
 
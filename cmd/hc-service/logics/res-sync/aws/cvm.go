@@ -76,6 +76,10 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 	addSlice, updateMap, delCloudIDs := common.Diff[typescvm.AwsCvm, corecvm.Cvm[cvm.AwsCvmExtension]](
 		cvmFromCloud, cvmFromDB, isCvmChange)
 
+	if common.ReportDiff(kt, enumor.CvmCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteCvm(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
@@ -94,6 +98,9 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Aws, AccountID: params.AccountID,
+		ResType: enumor.CvmCloudResType}, cvmFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -484,6 +491,10 @@ func (cli *client) getImageMap(kt *kit.Kit, accountID string, region string,
 }
 
 func (cli *client) deleteCvm(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.CvmCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("cvm delCloudIDs is <= 0, not delete")
 	}
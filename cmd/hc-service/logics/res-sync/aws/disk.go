@@ -74,6 +74,10 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 	addSlice, updateMap, delCloudIDs := common.Diff[adaptordisk.AwsDisk, *disk.DiskExtResult[disk.AwsDiskExtensionResult]](
 		diskFromCloud, diskFromDB, isDiskChange)
 
+	if common.ReportDiff(kt, enumor.DiskCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteDisk(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
@@ -92,6 +96,9 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Aws, AccountID: params.AccountID,
+		ResType: enumor.DiskCloudResType}, diskFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -240,6 +247,10 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, region string,
 }
 
 func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.DiskCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("delCloudIDs is <= 0, not delete")
 	}
@@ -0,0 +1,417 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	protonat "hcm/pkg/api/data-service/cloud/nat-gateway"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncNatGatewayOption ...
+type SyncNatGatewayOption struct {
+	// BkBizID NAT网关创建时，通过同步写入DB，需要传入业务ID
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt SyncNatGatewayOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// NatGateway sync nat gateway, vpc, subnet and eips related to nat gateway are resolved from db, so they should be
+// synced before nat gateway.
+func (cli *client) NatGateway(kt *kit.Kit, params *SyncBaseParams, opt *SyncNatGatewayOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	natFromCloud, err := cli.listNatGatewayFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	natFromDB, err := cli.listNatGatewayFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(natFromCloud) == 0 && len(natFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typenat.AwsNatGateway,
+		corenat.NatGateway[corenat.AwsNatGatewayExtension]](natFromCloud, natFromDB, isNatGatewayChange)
+
+	if common.ReportDiff(kt, enumor.NatGatewayCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteNatGateway(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if _, err = cli.createNatGateway(kt, params.AccountID, addSlice, opt.BkBizID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateNatGateway(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Aws, AccountID: params.AccountID,
+		ResType: enumor.NatGatewayCloudResType}, natFromDB, addSlice, updateMap, delCloudIDs)
+
+	return new(SyncResult), nil
+}
+
+// RemoveNatGatewayDeleteFromCloud ...
+func (cli *client) RemoveNatGatewayDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.Aws},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.CloudResourceSyncMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Global.NatGateway.ListNatGateway(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list nat gateway failed, err: %v, req: %v, rid: %s",
+				enumor.Aws, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listNatGatewayFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.CloudID)
+			}
+
+			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if err = cli.deleteNatGateway(kt, accountID, region, delCloudIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.CloudResourceSyncMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.CloudResourceSyncMaxLimit
+	}
+
+	return nil
+}
+
+func (cli *client) deleteNatGateway(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.NatGatewayCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete nat gateway, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delFromCloud, err := cli.listNatGatewayFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delFromCloud) > 0 {
+		logs.Errorf("[%s] validate nat gateway not exist failed, before delete, opt: %v, failed_count: %d, "+
+			"rid: %s", enumor.Aws, checkParams, len(delFromCloud), kt.Rid)
+		return fmt.Errorf("validate nat gateway not exist failed, before delete")
+	}
+
+	deleteReq := &protonat.NatGatewayBatchDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if err = cli.dbCli.Global.NatGateway.BatchDeleteNatGateway(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete nat gateway failed, err: %v, rid: %s",
+			enumor.Aws, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync nat gateway to delete nat gateway success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateNatGateway(kt *kit.Kit, accountID string,
+	updateMap map[string]typenat.AwsNatGateway) error {
+
+	if len(updateMap) == 0 {
+		return fmt.Errorf("update nat gateway, nat gateways is required")
+	}
+
+	updateSlice := make([]typenat.AwsNatGateway, 0, len(updateMap))
+	for _, one := range updateMap {
+		updateSlice = append(updateSlice, one)
+	}
+
+	relMap, err := cli.getNatGatewayRelResMap(kt, accountID, updateSlice)
+	if err != nil {
+		return err
+	}
+
+	nats := make([]protonat.NatGatewayBatchUpdate[corenat.AwsNatGatewayExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		nats = append(nats, protonat.NatGatewayBatchUpdate[corenat.AwsNatGatewayExtension]{
+			ID:                 id,
+			Name:               one.Name,
+			Zone:               one.Zone,
+			NatType:            one.NatType,
+			Status:             one.Status,
+			CloudVpcID:         one.CloudVpcID,
+			VpcID:              relMap.VpcMap[one.CloudVpcID],
+			CloudSubnetID:      one.CloudSubnetID,
+			SubnetID:           relMap.SubnetMap[one.CloudSubnetID],
+			PublicIPAddresses:  one.PublicIPAddresses,
+			PrivateIPAddresses: one.PrivateIPAddresses,
+			CloudEipIDs:        one.CloudEipIDs,
+			EipIDs:             relMap.EipIDs(one.CloudEipIDs),
+			Memo:               one.Memo,
+			Extension:          one.Extension,
+		})
+	}
+
+	for _, part := range slice.Split(nats, constant.BatchOperationMaxLimit) {
+		updateReq := &protonat.NatGatewayBatchUpdateReq[corenat.AwsNatGatewayExtension]{NatGateways: part}
+		if err = cli.dbCli.Aws.NatGateway.BatchUpdateNatGateway(kt.Ctx, kt.Header(), updateReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch update nat gateway failed, err: %v, rid: %s",
+				enumor.Aws, err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync nat gateway to update nat gateway success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createNatGateway(kt *kit.Kit, accountID string, addSlice []typenat.AwsNatGateway,
+	bizID int64) ([]string, error) {
+
+	if len(addSlice) == 0 {
+		return nil, fmt.Errorf("create nat gateway, nat gateways is required")
+	}
+
+	if bizID == 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	relMap, err := cli.getNatGatewayRelResMap(kt, accountID, addSlice)
+	if err != nil {
+		return nil, err
+	}
+
+	nats := make([]protonat.NatGatewayBatchCreate[corenat.AwsNatGatewayExtension], 0, len(addSlice))
+	for _, one := range addSlice {
+		nats = append(nats, protonat.NatGatewayBatchCreate[corenat.AwsNatGatewayExtension]{
+			CloudID:            one.CloudID,
+			Name:               one.Name,
+			AccountID:          accountID,
+			BkBizID:            bizID,
+			Region:             one.Region,
+			Zone:               one.Zone,
+			NatType:            one.NatType,
+			Status:             one.Status,
+			CloudVpcID:         one.CloudVpcID,
+			VpcID:              relMap.VpcMap[one.CloudVpcID],
+			CloudSubnetID:      one.CloudSubnetID,
+			SubnetID:           relMap.SubnetMap[one.CloudSubnetID],
+			PublicIPAddresses:  one.PublicIPAddresses,
+			PrivateIPAddresses: one.PrivateIPAddresses,
+			CloudEipIDs:        one.CloudEipIDs,
+			EipIDs:             relMap.EipIDs(one.CloudEipIDs),
+			Memo:               one.Memo,
+			CloudCreatedTime:   one.CloudCreatedTime,
+			Extension:          one.Extension,
+		})
+	}
+
+	createdIDs := make([]string, 0, len(addSlice))
+	for _, part := range slice.Split(nats, constant.BatchOperationMaxLimit) {
+		createReq := &protonat.NatGatewayBatchCreateReq[corenat.AwsNatGatewayExtension]{NatGateways: part}
+		result, err := cli.dbCli.Aws.NatGateway.BatchCreateNatGateway(kt.Ctx, kt.Header(), createReq)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to batch create nat gateway failed, err: %v, rid: %s",
+				enumor.Aws, err, kt.Rid)
+			return nil, err
+		}
+		createdIDs = append(createdIDs, result.IDs...)
+	}
+
+	logs.Infof("[%s] sync nat gateway to create nat gateway success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(addSlice), kt.Rid)
+
+	return createdIDs, nil
+}
+
+func (cli *client) getNatGatewayRelResMap(kt *kit.Kit, accountID string, nats []typenat.AwsNatGateway) (
+	*common.NatGatewayRelResMap, error) {
+
+	cloudVpcIDs, cloudSubnetIDs, cloudEipIDs := make([]string, 0), make([]string, 0), make([]string, 0)
+	for _, one := range nats {
+		cloudVpcIDs = append(cloudVpcIDs, one.CloudVpcID)
+		if len(one.CloudSubnetID) != 0 {
+			cloudSubnetIDs = append(cloudSubnetIDs, one.CloudSubnetID)
+		}
+		cloudEipIDs = append(cloudEipIDs, one.CloudEipIDs...)
+	}
+
+	return common.GetNatGatewayRelResMap(kt, cli.dbCli, accountID, cloudVpcIDs, cloudSubnetIDs, cloudEipIDs)
+}
+
+func (cli *client) listNatGatewayFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typenat.AwsNatGateway,
+	error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &adcore.AwsListOption{
+		Region:   params.Region,
+		CloudIDs: params.CloudIDs,
+	}
+	result, _, err := cli.cloudCli.ListNatGateway(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list nat gateway from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+			enumor.Aws, err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listNatGatewayFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]corenat.NatGateway[corenat.AwsNatGatewayExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: params.Region},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Aws.NatGateway.ListNatGatewayExt(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list nat gateway from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.Aws, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isNatGatewayChange(cloud typenat.AwsNatGateway,
+	db corenat.NatGateway[corenat.AwsNatGatewayExtension]) bool {
+
+	if cloud.Name != db.Name || cloud.Zone != db.Zone || cloud.NatType != db.NatType || cloud.Status != db.Status ||
+		cloud.CloudVpcID != db.CloudVpcID || cloud.CloudSubnetID != db.CloudSubnetID {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.PublicIPAddresses, db.PublicIPAddresses) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.PrivateIPAddresses, db.PrivateIPAddresses) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.CloudEipIDs, db.CloudEipIDs) {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(cloud.Memo, db.Memo) {
+		return true
+	}
+
+	return !assert.IsJsonEqual(cloud.Extension, db.Extension)
+}
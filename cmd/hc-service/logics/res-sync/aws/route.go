@@ -128,6 +128,10 @@ func (cli *client) route(kt *kit.Kit, opt *syncRouteOption) (*SyncResult, error)
 	addSlice, updateMap, delCloudIDs := common.Diff[typesroutetable.AwsRoute,
 		routetable.AwsRoute](routeFromCloud, routeFromDB, isRouteChange)
 
+	if common.ReportDiff(kt, enumor.RouteCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteRoute(kt, opt.AccountID, opt.Region, opt.CloudRouteTableID, routeTable.ID,
 			delCloudIDs, routeFromDB); err != nil {
@@ -149,6 +153,9 @@ func (cli *client) route(kt *kit.Kit, opt *syncRouteOption) (*SyncResult, error)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Aws, AccountID: opt.AccountID,
+		ResType: enumor.RouteCloudResType}, routeFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -126,6 +126,10 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 	addSlice, updateMap, delCloudIDs := common.Diff[securitygrouprule.AwsSGRule,
 		corecloud.AwsSecurityGroupRule](sgRuleFromCloud, sgRuleFromDB, isSGRuleChange)
 
+	if common.ReportDiff(kt, enumor.SecurityGroupRuleCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		err := cli.deleteSGRule(kt, opt, delCloudIDs)
 		if err != nil {
@@ -147,6 +151,9 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Aws, AccountID: opt.AccountID,
+		ResType: enumor.SecurityGroupRuleCloudResType}, sgRuleFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
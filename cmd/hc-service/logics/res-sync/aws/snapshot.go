@@ -0,0 +1,403 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	typessnap "hcm/pkg/adaptor/types/snapshot"
+	"hcm/pkg/api/core"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	protosnap "hcm/pkg/api/data-service/cloud/snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncSnapshotOption ...
+type SyncSnapshotOption struct {
+	// BkBizID 快照创建时，通过同步写入DB，需要传入业务ID
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+	// SnapshotPolicyID 由快照策略触发创建时传入，用于记录快照所属策略，以便按保留数量清理
+	SnapshotPolicyID string `json:"snapshot_policy_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt SyncSnapshotOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// Snapshot sync disk snapshot, source disk id is resolved from db, so disk should be synced before snapshot.
+func (cli *client) Snapshot(kt *kit.Kit, params *SyncBaseParams, opt *SyncSnapshotOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	snapFromCloud, err := cli.listSnapshotFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	snapFromDB, err := cli.listSnapshotFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(snapFromCloud) == 0 && len(snapFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typessnap.AwsSnapshot,
+		coresnap.Snapshot[coresnap.AwsSnapshotExtension]](snapFromCloud, snapFromDB, isSnapshotChange)
+
+	if common.ReportDiff(kt, enumor.SnapshotCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteSnapshot(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if _, err = cli.createSnapshot(kt, params.AccountID, addSlice, opt); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateSnapshot(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Aws, AccountID: params.AccountID,
+		ResType: enumor.SnapshotCloudResType}, snapFromDB, addSlice, updateMap, delCloudIDs)
+
+	return new(SyncResult), nil
+}
+
+// RemoveSnapshotDeleteFromCloud ...
+func (cli *client) RemoveSnapshotDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.Aws},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.CloudResourceSyncMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Global.Snapshot.ListSnapshot(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list snapshot failed, err: %v, req: %v, rid: %s",
+				enumor.Aws, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listSnapshotFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.CloudID)
+			}
+
+			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if err = cli.deleteSnapshot(kt, accountID, region, delCloudIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.CloudResourceSyncMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.CloudResourceSyncMaxLimit
+	}
+
+	return nil
+}
+
+func (cli *client) deleteSnapshot(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.SnapshotCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete snapshot, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delFromCloud, err := cli.listSnapshotFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delFromCloud) > 0 {
+		logs.Errorf("[%s] validate snapshot not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.Aws, checkParams, len(delFromCloud), kt.Rid)
+		return fmt.Errorf("validate snapshot not exist failed, before delete")
+	}
+
+	deleteReq := &protosnap.SnapshotBatchDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if err = cli.dbCli.Global.Snapshot.BatchDeleteSnapshot(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete snapshot failed, err: %v, rid: %s", enumor.Aws, err,
+			kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync snapshot to delete snapshot success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateSnapshot(kt *kit.Kit, accountID string, updateMap map[string]typessnap.AwsSnapshot) error {
+	if len(updateMap) == 0 {
+		return fmt.Errorf("update snapshot, snapshots is required")
+	}
+
+	cloudDiskIDs := make([]string, 0, len(updateMap))
+	for _, one := range updateMap {
+		cloudDiskIDs = append(cloudDiskIDs, one.CloudDiskID)
+	}
+
+	diskMap, err := common.GetSnapshotDiskIDMap(kt, cli.dbCli, accountID, cloudDiskIDs)
+	if err != nil {
+		return err
+	}
+
+	snaps := make([]protosnap.SnapshotBatchUpdate[coresnap.AwsSnapshotExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		snaps = append(snaps, protosnap.SnapshotBatchUpdate[coresnap.AwsSnapshotExtension]{
+			ID:          id,
+			Name:        one.Name,
+			Status:      one.Status,
+			CloudDiskID: one.CloudDiskID,
+			DiskID:      diskMap[one.CloudDiskID],
+			DiskSize:    one.DiskSize,
+			Encrypted:   one.Encrypted,
+			Memo:        one.Memo,
+			Extension:   one.Extension,
+		})
+	}
+
+	for _, part := range slice.Split(snaps, constant.BatchOperationMaxLimit) {
+		updateReq := &protosnap.SnapshotBatchUpdateReq[coresnap.AwsSnapshotExtension]{Snapshots: part}
+		if err = cli.dbCli.Aws.Snapshot.BatchUpdateSnapshot(kt.Ctx, kt.Header(), updateReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch update snapshot failed, err: %v, rid: %s", enumor.Aws, err,
+				kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync snapshot to update snapshot success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createSnapshot(kt *kit.Kit, accountID string, addSlice []typessnap.AwsSnapshot,
+	opt *SyncSnapshotOption) ([]string, error) {
+
+	if len(addSlice) == 0 {
+		return nil, fmt.Errorf("create snapshot, snapshots is required")
+	}
+
+	bizID := opt.BkBizID
+	if bizID == 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	cloudDiskIDs := make([]string, 0, len(addSlice))
+	for _, one := range addSlice {
+		cloudDiskIDs = append(cloudDiskIDs, one.CloudDiskID)
+	}
+
+	diskMap, err := common.GetSnapshotDiskIDMap(kt, cli.dbCli, accountID, cloudDiskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	snaps := make([]protosnap.SnapshotBatchCreate[coresnap.AwsSnapshotExtension], 0, len(addSlice))
+	for _, one := range addSlice {
+		snaps = append(snaps, protosnap.SnapshotBatchCreate[coresnap.AwsSnapshotExtension]{
+			CloudID:          one.CloudID,
+			Name:             one.Name,
+			AccountID:        accountID,
+			BkBizID:          bizID,
+			Region:           one.Region,
+			Zone:             one.Zone,
+			Status:           one.Status,
+			CloudDiskID:      one.CloudDiskID,
+			DiskID:           diskMap[one.CloudDiskID],
+			DiskSize:         one.DiskSize,
+			Encrypted:        one.Encrypted,
+			SnapshotPolicyID: opt.SnapshotPolicyID,
+			Memo:             one.Memo,
+			CloudCreatedTime: one.CloudCreatedTime,
+			Extension:        one.Extension,
+		})
+	}
+
+	createdIDs := make([]string, 0, len(addSlice))
+	for _, part := range slice.Split(snaps, constant.BatchOperationMaxLimit) {
+		createReq := &protosnap.SnapshotBatchCreateReq[coresnap.AwsSnapshotExtension]{Snapshots: part}
+		result, err := cli.dbCli.Aws.Snapshot.BatchCreateSnapshot(kt.Ctx, kt.Header(), createReq)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to batch create snapshot failed, err: %v, rid: %s", enumor.Aws, err,
+				kt.Rid)
+			return nil, err
+		}
+		createdIDs = append(createdIDs, result.IDs...)
+	}
+
+	logs.Infof("[%s] sync snapshot to create snapshot success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(addSlice), kt.Rid)
+
+	return createdIDs, nil
+}
+
+func (cli *client) listSnapshotFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typessnap.AwsSnapshot, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &adcore.AwsListOption{
+		Region:   params.Region,
+		CloudIDs: params.CloudIDs,
+	}
+	result, _, err := cli.cloudCli.ListSnapshot(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list snapshot from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+			enumor.Aws, err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listSnapshotFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]coresnap.Snapshot[coresnap.AwsSnapshotExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: params.Region},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Aws.Snapshot.ListSnapshotExt(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list snapshot from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.Aws, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isSnapshotChange(cloud typessnap.AwsSnapshot, db coresnap.Snapshot[coresnap.AwsSnapshotExtension]) bool {
+	if cloud.Name != db.Name || cloud.Status != db.Status || cloud.CloudDiskID != db.CloudDiskID ||
+		cloud.DiskSize != db.DiskSize {
+		return true
+	}
+
+	if !assert.IsPtrBoolEqual(cloud.Encrypted, db.Encrypted) {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(cloud.Memo, db.Memo) {
+		return true
+	}
+
+	if cloud.Extension == nil || db.Extension == nil {
+		return cloud.Extension != db.Extension
+	}
+
+	if !assert.IsPtrStringEqual(cloud.Extension.Progress, db.Extension.Progress) {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(cloud.Extension.StorageTier, db.Extension.StorageTier) {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(cloud.Extension.KmsKeyID, db.Extension.KmsKeyID) {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(cloud.Extension.StateMessage, db.Extension.StateMessage) {
+		return true
+	}
+
+	return false
+}
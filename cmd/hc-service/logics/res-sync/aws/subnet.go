@@ -75,6 +75,10 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 	addSubnet, updateMap, delCloudIDs := common.Diff[adtysubnet.AwsSubnet, cloudcore.Subnet[cloudcore.AwsSubnetExtension]](
 		subnetFromCloud, subnetFromDB, isAwsSubnetChange)
 
+	if common.ReportDiff(kt, enumor.SubnetCloudResType, addSubnet, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteSubnet(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
@@ -93,10 +97,17 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Aws, AccountID: params.AccountID,
+		ResType: enumor.SubnetCloudResType}, subnetFromDB, addSubnet, updateMap, delCloudIDs)
+
 	return nil, nil
 }
 
 func (cli *client) deleteSubnet(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.SubnetCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete subnet, cloudIDs is required")
 	}
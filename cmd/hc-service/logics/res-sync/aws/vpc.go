@@ -75,6 +75,10 @@ func (cli *client) Vpc(kt *kit.Kit, params *SyncBaseParams, opt *SyncVpcOption)
 	addVpc, updateMap, delCloudIDs := common.Diff[types.AwsVpc, cloudcore.Vpc[cloudcore.AwsVpcExtension]](
 		vpcFromCloud, vpcFromDB, isAwsVpcChange)
 
+	if common.ReportDiff(kt, enumor.VpcCloudResType, addVpc, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteVpc(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
@@ -93,10 +97,17 @@ func (cli *client) Vpc(kt *kit.Kit, params *SyncBaseParams, opt *SyncVpcOption)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Aws, AccountID: params.AccountID,
+		ResType: enumor.VpcCloudResType}, vpcFromDB, addVpc, updateMap, delCloudIDs)
+
 	return nil, nil
 }
 
 func (cli *client) deleteVpc(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.VpcCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete vpc, cloudIDs is required")
 	}
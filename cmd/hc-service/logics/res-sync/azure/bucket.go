@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Bucket sync object storage bucket of account.
+func (cli *client) Bucket(kt *kit.Kit, params *common.SyncBucketParams) (*SyncResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	buckets, err := cli.cloudCli.ListStorageBucket(kt)
+	if err != nil {
+		logs.Errorf("[%s] list bucket from cloud failed, err: %v, account: %s, rid: %s", enumor.Azure, err,
+			params.AccountID, kt.Rid)
+		return nil, err
+	}
+
+	if err = common.SyncBucket(kt, cli.dbCli, enumor.Azure, params, buckets); err != nil {
+		return nil, err
+	}
+
+	return new(SyncResult), nil
+}
@@ -20,6 +20,7 @@
 package azure
 
 import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	"hcm/pkg/adaptor/azure"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/kit"
@@ -38,6 +39,14 @@ type Interface interface {
 
 	Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption) (*SyncResult, error)
 	RemoveEipDeleteFromCloud(kt *kit.Kit, accountID string, resGroupName string) error
+	LoadBalancer(kt *kit.Kit, params *SyncBaseParams, opt *SyncLoadBalancerOption) (*SyncResult, error)
+	RemoveLoadBalancerDeleteFromCloud(kt *kit.Kit, accountID string, resGroupName string) error
+	NatGateway(kt *kit.Kit, params *SyncBaseParams, opt *SyncNatGatewayOption) (*SyncResult, error)
+	RemoveNatGatewayDeleteFromCloud(kt *kit.Kit, accountID string, resGroupName string) error
+	Snapshot(kt *kit.Kit, params *SyncBaseParams, opt *SyncSnapshotOption) (*SyncResult, error)
+	RemoveSnapshotDeleteFromCloud(kt *kit.Kit, accountID string, resGroupName string) error
+	Bucket(kt *kit.Kit, params *common.SyncBucketParams) (*SyncResult, error)
+	VpcPeering(kt *kit.Kit, params *common.SyncVpcPeeringParams) (*SyncResult, error)
 
 	RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRouteTableOption) (*SyncResult, error)
 	RemoveRouteTableDeleteFromCloud(kt *kit.Kit, accountID string, resGroupName string) error
@@ -75,6 +75,10 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 	addSlice, updateMap, delCloudIDs := common.Diff[typescvm.AzureCvm, corecvm.Cvm[cvm.AzureCvmExtension]](
 		cvmFromCloud, cvmFromDB, isCvmChange)
 
+	if common.ReportDiff(kt, enumor.CvmCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteCvm(kt, params.AccountID, params.ResourceGroupName, delCloudIDs); err != nil {
 			return nil, err
@@ -93,6 +97,9 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Azure, AccountID: params.AccountID,
+		ResType: enumor.CvmCloudResType}, cvmFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -550,6 +557,10 @@ func (cli *client) getImageMap(kt *kit.Kit, accountID string, resGroupName strin
 }
 
 func (cli *client) deleteCvm(kt *kit.Kit, accountID string, resGroupName string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.CvmCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("cvm delCloudIDs is <= 0, not delete")
 	}
@@ -72,6 +72,10 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 	addSlice, updateMap, delCloudIDs := common.Diff[typesdisk.AzureDisk, *disk.DiskExtResult[disk.AzureDiskExtensionResult]](
 		diskFromCloud, diskFromDB, isDiskChange)
 
+	if common.ReportDiff(kt, enumor.DiskCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteDisk(kt, params.AccountID, params.ResourceGroupName, delCloudIDs); err != nil {
 			return nil, err
@@ -90,6 +94,9 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Azure, AccountID: params.AccountID,
+		ResType: enumor.DiskCloudResType}, diskFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -192,6 +199,10 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, resGroupName string
 func (cli *client) deleteDisk(kt *kit.Kit, accountID string, resGroupName string,
 	delCloudIDs []string) error {
 
+	if common.ReportDiffCloudIDs(kt, enumor.DiskCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("delCloudIDs is <= 0, not delete")
 	}
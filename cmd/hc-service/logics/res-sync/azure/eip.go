@@ -73,6 +73,10 @@ func (cli *client) Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption)
 	addEip, updateMap, delCloudIDs := common.Diff[*typeseip.AzureEip,
 		*dataeip.EipExtResult[dataeip.AzureEipExtensionResult]](eipFromCloud, eipFromDB, isEipChange)
 
+	if common.ReportDiff(kt, enumor.EipCloudResType, addEip, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteEip(kt, params.AccountID, params.ResourceGroupName, delCloudIDs); err != nil {
 			return nil, err
@@ -91,6 +95,9 @@ func (cli *client) Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Azure, AccountID: params.AccountID,
+		ResType: enumor.EipCloudResType}, eipFromDB, addEip, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -166,6 +173,10 @@ func (cli *client) RemoveEipDeleteFromCloud(kt *kit.Kit, accountID string, resGr
 }
 
 func (cli *client) deleteEip(kt *kit.Kit, accountID string, resGroupName string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.EipCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("eip delCloudIDs is <= 0, not delete")
 	}
@@ -75,6 +75,10 @@ func (cli *client) NetworkInterface(kt *kit.Kit, params *SyncBaseParams, opt *Sy
 	addNetworkInterface, updateMap, delCloudIDs := common.Diff[typesni.AzureNI,
 		coreni.NetworkInterface[coreni.AzureNIExtension]](niFromCloud, niFromDB, isNIChange)
 
+	if common.ReportDiff(kt, enumor.NetworkInterfaceCloudResType, addNetworkInterface, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteNetworkInterface(kt, params.AccountID, params.ResourceGroupName, delCloudIDs); err != nil {
 			return nil, err
@@ -94,6 +98,9 @@ func (cli *client) NetworkInterface(kt *kit.Kit, params *SyncBaseParams, opt *Sy
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Azure, AccountID: params.AccountID,
+		ResType: enumor.NetworkInterfaceCloudResType}, niFromDB, addNetworkInterface, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -170,6 +177,10 @@ func (cli *client) RemoveNetworkInterfaceDeleteFromCloud(kt *kit.Kit, accountID
 
 func (cli *client) deleteNetworkInterface(kt *kit.Kit, accountID string, resGroupName string, delCloudIDs []string) error {
 
+	if common.ReportDiffCloudIDs(kt, enumor.NetworkInterfaceCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete network interface, network interfaces is required")
 	}
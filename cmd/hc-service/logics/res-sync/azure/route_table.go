@@ -72,6 +72,17 @@ func (cli *client) RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRout
 	addSlice, updateMap, delCloudIDs := common.Diff[typesroutetable.AzureRouteTable,
 		routetable.AzureRouteTable](routeTableFromCloud, routeTableFromDB, isRouteTableChange)
 
+	// 演练模式下不写入db，仅继续对比云上仍存在的路由表的路由
+	if common.ReportDiff(kt, enumor.RouteTableCloudResType, addSlice, updateMap, delCloudIDs) {
+		dryRunParams := *params
+		dryRunParams.CloudIDs = common.ExcludeCloudIDs(params.CloudIDs, delCloudIDs)
+		if len(dryRunParams.CloudIDs) == 0 {
+			return new(SyncResult), nil
+		}
+		params = &dryRunParams
+		addSlice, updateMap, delCloudIDs = nil, nil, nil
+	}
+
 	subnetMap := make(map[string]dataproto.RouteTableSubnetReq, 0)
 
 	if len(delCloudIDs) > 0 {
@@ -120,6 +131,9 @@ func (cli *client) RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRout
 		return nil, err
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Azure, AccountID: params.AccountID,
+		ResType: enumor.RouteTableCloudResType}, routeTableFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -308,6 +322,10 @@ func (cli *client) updateRouteTalbe(kt *kit.Kit, accountID string, resGroupName
 }
 
 func (cli *client) deleteRouteTable(kt *kit.Kit, accountID string, resGroupName string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.RouteTableCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("routeTable delCloudIDs is <= 0, not delete")
 	}
@@ -136,6 +136,10 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 	addSlice, updateMap, delCloudIDs := common.Diff[securitygrouprule.AzureSGRule,
 		corecloud.AzureSecurityGroupRule](sgRuleFromCloud, sgRuleFromDB, isSGRuleChange)
 
+	if common.ReportDiff(kt, enumor.SecurityGroupRuleCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		err := cli.deleteSGRule(kt, opt, delCloudIDs)
 		if err != nil {
@@ -157,6 +161,9 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Azure, AccountID: opt.AccountID,
+		ResType: enumor.SecurityGroupRuleCloudResType}, sgRuleFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
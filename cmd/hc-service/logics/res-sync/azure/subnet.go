@@ -74,6 +74,10 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 	addSubnet, updateMap, delCloudIDs := common.Diff[adtysubnet.AzureSubnet,
 		cloudcore.Subnet[cloudcore.AzureSubnetExtension]](subnetFromCloud, subnetFromDB, isSubnetChange)
 
+	if common.ReportDiff(kt, enumor.SubnetCloudResType, addSubnet, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteSubnet(kt, params.AccountID, params.ResourceGroupName, opt.CloudVpcID,
 			delCloudIDs); err != nil {
@@ -94,6 +98,9 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Azure, AccountID: params.AccountID,
+		ResType: enumor.SubnetCloudResType}, subnetFromDB, addSubnet, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -171,6 +178,10 @@ func (cli *client) RemoveSubnetDeleteFromCloud(kt *kit.Kit, accountID, resGroupN
 
 func (cli *client) deleteSubnet(kt *kit.Kit, accountID, resGroupName, cloudVpcID string, delCloudIDs []string) error {
 
+	if common.ReportDiffCloudIDs(kt, enumor.SubnetCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete subnet, cloudIDs is required")
 	}
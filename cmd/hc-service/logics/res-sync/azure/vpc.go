@@ -73,6 +73,10 @@ func (cli *client) Vpc(kt *kit.Kit, params *SyncBaseParams, opt *SyncVpcOption)
 	addVpc, updateMap, delCloudIDs := common.Diff[types.AzureVpc, cloudcore.Vpc[cloudcore.AzureVpcExtension]](
 		vpcFromCloud, vpcFromDB, isVpcChange)
 
+	if common.ReportDiff(kt, enumor.VpcCloudResType, addVpc, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteVpc(kt, params.AccountID, params.ResourceGroupName, delCloudIDs); err != nil {
 			return nil, err
@@ -91,6 +95,9 @@ func (cli *client) Vpc(kt *kit.Kit, params *SyncBaseParams, opt *SyncVpcOption)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Azure, AccountID: params.AccountID,
+		ResType: enumor.VpcCloudResType}, vpcFromDB, addVpc, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -167,6 +174,10 @@ func (cli *client) RemoveVpcDeleteFromCloud(kt *kit.Kit, accountID string, resGr
 
 func (cli *client) deleteVpc(kt *kit.Kit, accountID string, resGroupName string, delCloudIDs []string) error {
 
+	if common.ReportDiffCloudIDs(kt, enumor.VpcCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete vpc, cloudIDs is required")
 	}
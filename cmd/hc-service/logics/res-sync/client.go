@@ -24,6 +24,7 @@ import (
 	"hcm/cmd/hc-service/logics/res-sync/azure"
 	"hcm/cmd/hc-service/logics/res-sync/gcp"
 	"hcm/cmd/hc-service/logics/res-sync/huawei"
+	"hcm/cmd/hc-service/logics/res-sync/openstack"
 	"hcm/cmd/hc-service/logics/res-sync/tcloud"
 	cloudclient "hcm/cmd/hc-service/service/cloud-adaptor"
 	dataservice "hcm/pkg/client/data-service"
@@ -37,6 +38,7 @@ type Interface interface {
 	HuaWei(kt *kit.Kit, accountID string) (huawei.Interface, error)
 	Gcp(kt *kit.Kit, accountID string) (gcp.Interface, error)
 	Azure(kt *kit.Kit, accountID string) (azure.Interface, error)
+	OpenStack(kt *kit.Kit, accountID string) (openstack.Interface, error)
 }
 
 var _ Interface = new(client)
@@ -104,3 +106,13 @@ func (cli *client) Azure(kt *kit.Kit, accountID string) (azure.Interface, error)
 
 	return azure.NewClient(cli.dataCli, cloudCli), nil
 }
+
+// OpenStack ...
+func (cli *client) OpenStack(kt *kit.Kit, accountID string) (openstack.Interface, error) {
+	cloudCli, err := cli.ad.OpenStack(kt, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return openstack.NewClient(cli.dataCli, cloudCli), nil
+}
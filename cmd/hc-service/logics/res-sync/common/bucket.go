@@ -0,0 +1,279 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"strings"
+
+	typebucket "hcm/pkg/adaptor/types/bucket"
+	"hcm/pkg/api/core"
+	corebucket "hcm/pkg/api/core/cloud/bucket"
+	protobucket "hcm/pkg/api/data-service/cloud/bucket"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// 对象存储桶的列表接口均返回账号下全部地域的存储桶，所以存储桶按账号维度全量同步，不区分地域，
+// 各云厂商只负责从云上拉取存储桶，对比和db操作在这里统一实现。
+
+// SyncBucketParams defines params to sync bucket of account.
+type SyncBucketParams struct {
+	AccountID string   `json:"account_id" validate:"required"`
+	CloudIDs  []string `json:"cloud_ids" validate:"omitempty,max=500"`
+}
+
+// Validate SyncBucketParams.
+func (p SyncBucketParams) Validate() error {
+	return validator.Validate.Struct(p)
+}
+
+// SyncBucket sync buckets listed from cloud to db, only buckets in cloud ids are synced when they are specified.
+func SyncBucket(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, params *SyncBucketParams,
+	bucketFromCloud []typebucket.Bucket) error {
+
+	if err := params.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(params.CloudIDs) != 0 {
+		cloudIDMap := converter.StringSliceToMap(params.CloudIDs)
+		bucketFromCloud = slice.Filter(bucketFromCloud, func(one typebucket.Bucket) bool {
+			_, exist := cloudIDMap[one.CloudID]
+			return exist
+		})
+	}
+
+	bucketFromDB, err := ListBucketFromDB(kt, dataCli, vendor, params.AccountID, params.CloudIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(bucketFromCloud) == 0 && len(bucketFromDB) == 0 {
+		return nil
+	}
+
+	addSlice, updateMap, delCloudIDs := Diff[typebucket.Bucket, corebucket.Bucket](bucketFromCloud, bucketFromDB,
+		IsBucketChange)
+
+	if ReportDiff(kt, enumor.BucketCloudResType, addSlice, updateMap, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = DeleteBucket(kt, dataCli, vendor, params.AccountID, delCloudIDs); err != nil {
+			return err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = CreateBucket(kt, dataCli, vendor, params.AccountID, addSlice); err != nil {
+			return err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = UpdateBucket(kt, dataCli, vendor, params.AccountID, updateMap); err != nil {
+			return err
+		}
+	}
+
+	RecordDrift(kt, dataCli, &DriftOption{Vendor: vendor, AccountID: params.AccountID,
+		ResType: enumor.BucketCloudResType}, bucketFromDB, addSlice, updateMap, delCloudIDs)
+
+	return nil
+}
+
+// IsBucketChange check if bucket is changed.
+func IsBucketChange(cloud typebucket.Bucket, db corebucket.Bucket) bool {
+	if cloud.Name != db.Name || cloud.Region != db.Region {
+		return true
+	}
+
+	if cloud.Encrypted != db.Encrypted || cloud.EncryptionType != db.EncryptionType {
+		return true
+	}
+
+	if cloud.VersioningEnabled != db.VersioningEnabled {
+		return true
+	}
+
+	if cloud.PublicRead != db.PublicRead || cloud.PublicWrite != db.PublicWrite {
+		return true
+	}
+
+	if strings.Join(cloud.PublicAccessReasons, "\n") != strings.Join(db.PublicAccessReasons, "\n") {
+		return true
+	}
+
+	return false
+}
+
+// ListBucketFromDB list bucket of account from db, all buckets of account are returned when cloud ids is empty.
+func ListBucketFromDB(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, accountID string,
+	cloudIDs []string) ([]corebucket.Bucket, error) {
+
+	rules := []filter.RuleFactory{
+		&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+		&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+	}
+	if len(cloudIDs) != 0 {
+		rules = append(rules, &filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs})
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op:    filter.And,
+			Rules: rules,
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: core.DefaultMaxPageLimit,
+		},
+	}
+
+	buckets := make([]corebucket.Bucket, 0)
+	for {
+		result, err := dataCli.Global.Bucket.ListBucket(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] list bucket from db failed, err: %v, account: %s, req: %v, rid: %s", vendor, err,
+				accountID, req, kt.Rid)
+			return nil, err
+		}
+
+		buckets = append(buckets, result.Details...)
+
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return buckets, nil
+}
+
+// CreateBucket create bucket in db, new bucket is not assigned to any biz.
+func CreateBucket(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, accountID string,
+	addSlice []typebucket.Bucket) error {
+
+	buckets := make([]protobucket.BucketBatchCreate, 0, len(addSlice))
+	for _, one := range addSlice {
+		buckets = append(buckets, protobucket.BucketBatchCreate{
+			Vendor:              vendor,
+			AccountID:           accountID,
+			Region:              one.Region,
+			CloudID:             one.CloudID,
+			Name:                one.Name,
+			BkBizID:             constant.UnassignedBiz,
+			Encrypted:           one.Encrypted,
+			EncryptionType:      one.EncryptionType,
+			VersioningEnabled:   one.VersioningEnabled,
+			PublicRead:          one.PublicRead,
+			PublicWrite:         one.PublicWrite,
+			PublicAccessReasons: one.PublicAccessReasons,
+			CloudCreatedTime:    one.CloudCreatedTime,
+		})
+	}
+
+	for _, part := range slice.Split(buckets, constant.BatchOperationMaxLimit) {
+		createReq := &protobucket.BucketBatchCreateReq{Buckets: part}
+		if _, err := dataCli.Global.Bucket.BatchCreateBucket(kt.Ctx, kt.Header(), createReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch create bucket failed, err: %v, rid: %s", vendor, err,
+				kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync bucket to create bucket success, accountID: %s, count: %d, rid: %s", vendor,
+		accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+// UpdateBucket update bucket in db, updateMap key is the id of bucket.
+func UpdateBucket(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, accountID string,
+	updateMap map[string]typebucket.Bucket) error {
+
+	buckets := make([]protobucket.BucketBatchUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		buckets = append(buckets, protobucket.BucketBatchUpdate{
+			ID:                  id,
+			Region:              one.Region,
+			Name:                one.Name,
+			Encrypted:           converter.ValToPtr(one.Encrypted),
+			EncryptionType:      one.EncryptionType,
+			VersioningEnabled:   converter.ValToPtr(one.VersioningEnabled),
+			PublicRead:          converter.ValToPtr(one.PublicRead),
+			PublicWrite:         converter.ValToPtr(one.PublicWrite),
+			PublicAccessReasons: one.PublicAccessReasons,
+		})
+	}
+
+	for _, part := range slice.Split(buckets, constant.BatchOperationMaxLimit) {
+		updateReq := &protobucket.BucketBatchUpdateReq{Buckets: part}
+		if err := dataCli.Global.Bucket.BatchUpdateBucket(kt.Ctx, kt.Header(), updateReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch update bucket failed, err: %v, rid: %s", vendor, err,
+				kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync bucket to update bucket success, accountID: %s, count: %d, rid: %s", vendor,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+// DeleteBucket delete bucket in db, buckets are listed from cloud entirely, so buckets not returned by cloud
+// have been deleted from cloud.
+func DeleteBucket(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, accountID string,
+	delCloudIDs []string) error {
+
+	for _, part := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		deleteReq := &protobucket.BucketBatchDeleteReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+					&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+					&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: part},
+				},
+			},
+		}
+		if err := dataCli.Global.Bucket.BatchDeleteBucket(kt.Ctx, kt.Header(), deleteReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch delete bucket failed, err: %v, rid: %s", vendor, err,
+				kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync bucket to delete bucket success, accountID: %s, count: %d, rid: %s", vendor,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
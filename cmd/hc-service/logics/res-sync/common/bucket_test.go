@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"testing"
+
+	typebucket "hcm/pkg/adaptor/types/bucket"
+	corebucket "hcm/pkg/api/core/cloud/bucket"
+)
+
+func TestIsBucketChange(t *testing.T) {
+	db := corebucket.Bucket{ID: "00000001", CloudID: "bucket-1", Name: "bucket-1", Region: "ap-guangzhou"}
+
+	cloud := typebucket.Bucket{CloudID: "bucket-1", Name: "bucket-1", Region: "ap-guangzhou"}
+	if IsBucketChange(cloud, db) {
+		t.Errorf("same bucket should not be changed")
+	}
+
+	// bucket becomes public read by policy.
+	cloud.PublicRead = true
+	cloud.PublicAccessReasons = []string{typebucket.NewReason(typebucket.PolicyReasonSource, "public", "s3:GetObject")}
+	if !IsBucketChange(cloud, db) {
+		t.Errorf("bucket becomes public should be changed")
+	}
+
+	db.PublicRead = true
+	db.PublicAccessReasons = cloud.PublicAccessReasons
+	if IsBucketChange(cloud, db) {
+		t.Errorf("bucket with the same public access should not be changed")
+	}
+
+	cloud.VersioningEnabled = true
+	if !IsBucketChange(cloud, db) {
+		t.Errorf("bucket with versioning enabled should be changed")
+	}
+}
@@ -21,25 +21,37 @@ package common
 
 import (
 	"hcm/pkg/adaptor/types"
+	typebucket "hcm/pkg/adaptor/types/bucket"
 	typescvm "hcm/pkg/adaptor/types/cvm"
 	typesdisk "hcm/pkg/adaptor/types/disk"
 	typeseip "hcm/pkg/adaptor/types/eip"
 	firewallrule "hcm/pkg/adaptor/types/firewall-rule"
 	typesimage "hcm/pkg/adaptor/types/image"
+	typekp "hcm/pkg/adaptor/types/key-pair"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
 	typesni "hcm/pkg/adaptor/types/network-interface"
 	typesregion "hcm/pkg/adaptor/types/region"
 	typesresourcegroup "hcm/pkg/adaptor/types/resource-group"
 	typesroutetable "hcm/pkg/adaptor/types/route-table"
 	securitygroup "hcm/pkg/adaptor/types/security-group"
 	typessecuritygrouprule "hcm/pkg/adaptor/types/security-group-rule"
+	typessnap "hcm/pkg/adaptor/types/snapshot"
 	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	typevpcpeering "hcm/pkg/adaptor/types/vpc-peering"
 	typeszone "hcm/pkg/adaptor/types/zone"
 	cloudcore "hcm/pkg/api/core/cloud"
+	corebucket "hcm/pkg/api/core/cloud/bucket"
 	corecvm "hcm/pkg/api/core/cloud/cvm"
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
 	corecloudni "hcm/pkg/api/core/cloud/network-interface"
 	coreregion "hcm/pkg/api/core/cloud/region"
 	coreresourcegroup "hcm/pkg/api/core/cloud/resource-group"
 	cloudcoreroutetable "hcm/pkg/api/core/cloud/route-table"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	corevpcpeering "hcm/pkg/api/core/cloud/vpc-peering"
 	corezone "hcm/pkg/api/core/cloud/zone"
 	"hcm/pkg/api/data-service/cloud/disk"
 	dataeip "hcm/pkg/api/data-service/cloud/eip"
@@ -54,6 +66,7 @@ type CloudResType interface {
 		typesregion.TCloudRegion |
 		typesregion.AwsRegion |
 		typesregion.GcpRegion |
+		typesregion.OpenStackRegion |
 
 		typesresourcegroup.AzureResourceGroup |
 
@@ -69,6 +82,7 @@ type CloudResType interface {
 		typesimage.GcpImage |
 
 		typessecuritygrouprule.HuaWeiSGRule |
+		typessecuritygrouprule.OpenStackSGRule |
 		typessecuritygrouprule.AwsSGRule |
 		typessecuritygrouprule.AzureSGRule |
 
@@ -77,23 +91,27 @@ type CloudResType interface {
 		types.GcpVpc |
 		types.HuaWeiVpc |
 		types.AzureVpc |
+		types.OpenStackVpc |
 
 		adtysubnet.TCloudSubnet |
 		adtysubnet.AwsSubnet |
 		adtysubnet.HuaWeiSubnet |
 		adtysubnet.GcpSubnet |
 		adtysubnet.AzureSubnet |
+		adtysubnet.OpenStackSubnet |
 
 		typesdisk.TCloudDisk |
 		typesdisk.HuaWeiDisk |
 		typesdisk.AwsDisk |
 		typesdisk.GcpDisk |
 		typesdisk.AzureDisk |
+		typesdisk.OpenStackDisk |
 
 		securitygroup.TCloudSG |
 		securitygroup.HuaWeiSG |
 		securitygroup.AwsSG |
 		securitygroup.AzureSecurityGroup |
+		securitygroup.OpenStackSecurityGroup |
 
 		firewallrule.GcpFirewall |
 
@@ -102,6 +120,7 @@ type CloudResType interface {
 		typescvm.AwsCvm |
 		typescvm.GcpCvm |
 		typescvm.AzureCvm |
+		typescvm.OpenStackCvm |
 
 		*typeseip.TCloudEip |
 		*typeseip.HuaWeiEip |
@@ -122,7 +141,40 @@ type CloudResType interface {
 		typesroutetable.TCloudRoute |
 		typesroutetable.HuaWeiRoute |
 		typesroutetable.AzureRoute |
-		typesroutetable.AwsRoute
+		typesroutetable.AwsRoute |
+
+		typelb.TCloudLoadBalancer |
+		typelb.AwsLoadBalancer |
+		typelb.HuaWeiLoadBalancer |
+		typelb.AzureLoadBalancer |
+		typelb.GcpLoadBalancer |
+
+		typelb.TCloudListener |
+		typelb.AwsListener |
+		typelb.HuaWeiListener |
+		typelb.AzureListener |
+		typelb.GcpListener |
+
+		*typelb.TCloudTarget |
+		*typelb.AwsTarget |
+		*typelb.HuaWeiTarget |
+		*typelb.AzureTarget |
+		*typelb.GcpTarget |
+
+		typenat.TCloudNatGateway |
+		typenat.AwsNatGateway |
+		typenat.HuaWeiNatGateway |
+		typenat.AzureNatGateway |
+		typenat.GcpNatGateway |
+		typessnap.TCloudSnapshot |
+		typessnap.AwsSnapshot |
+		typessnap.HuaWeiSnapshot |
+		typessnap.AzureSnapshot |
+		typessnap.GcpSnapshot |
+
+		typekp.KeyPair |
+		typebucket.Bucket |
+		typevpcpeering.VpcPeering
 }
 
 type DBResType interface {
@@ -134,6 +186,7 @@ type DBResType interface {
 		cloudcore.TCloudRegion |
 		cloudcore.AwsRegion |
 		cloudcore.GcpRegion |
+		coreregion.OpenStackRegion |
 
 		coreresourcegroup.AzureRG |
 
@@ -146,6 +199,7 @@ type DBResType interface {
 		dateimage.ImageExtResult[dateimage.GcpImageExtensionResult] |
 
 		cloudcore.HuaWeiSecurityGroupRule |
+		cloudcore.OpenStackSecurityGroupRule |
 		cloudcore.AwsSecurityGroupRule |
 		cloudcore.AzureSecurityGroupRule |
 
@@ -154,23 +208,27 @@ type DBResType interface {
 		cloudcore.Vpc[cloudcore.GcpVpcExtension] |
 		cloudcore.Vpc[cloudcore.HuaWeiVpcExtension] |
 		cloudcore.Vpc[cloudcore.AzureVpcExtension] |
+		cloudcore.Vpc[cloudcore.OpenStackVpcExtension] |
 
 		cloudcore.Subnet[cloudcore.TCloudSubnetExtension] |
 		cloudcore.Subnet[cloudcore.AwsSubnetExtension] |
 		cloudcore.Subnet[cloudcore.HuaWeiSubnetExtension] |
 		cloudcore.Subnet[cloudcore.GcpSubnetExtension] |
 		cloudcore.Subnet[cloudcore.AzureSubnetExtension] |
+		cloudcore.Subnet[cloudcore.OpenStackSubnetExtension] |
 
 		*disk.DiskExtResult[disk.TCloudDiskExtensionResult] |
 		*disk.DiskExtResult[disk.HuaWeiDiskExtensionResult] |
 		*disk.DiskExtResult[disk.AwsDiskExtensionResult] |
 		*disk.DiskExtResult[disk.GcpDiskExtensionResult] |
 		*disk.DiskExtResult[disk.AzureDiskExtensionResult] |
+		*disk.DiskExtResult[disk.OpenStackDiskExtensionResult] |
 
 		cloudcore.SecurityGroup[cloudcore.TCloudSecurityGroupExtension] |
 		cloudcore.SecurityGroup[cloudcore.HuaWeiSecurityGroupExtension] |
 		cloudcore.SecurityGroup[cloudcore.AwsSecurityGroupExtension] |
 		cloudcore.SecurityGroup[cloudcore.AzureSecurityGroupExtension] |
+		cloudcore.SecurityGroup[cloudcore.OpenStackSecurityGroupExtension] |
 
 		cloudcore.GcpFirewallRule |
 
@@ -179,6 +237,7 @@ type DBResType interface {
 		corecvm.Cvm[corecvm.AwsCvmExtension] |
 		corecvm.Cvm[corecvm.GcpCvmExtension] |
 		corecvm.Cvm[corecvm.AzureCvmExtension] |
+		corecvm.Cvm[corecvm.OpenStackCvmExtension] |
 
 		*dataeip.EipExtResult[dataeip.TCloudEipExtensionResult] |
 		*dataeip.EipExtResult[dataeip.HuaWeiEipExtensionResult] |
@@ -199,7 +258,40 @@ type DBResType interface {
 		cloudcoreroutetable.TCloudRoute |
 		cloudcoreroutetable.HuaWeiRoute |
 		cloudcoreroutetable.AzureRoute |
-		cloudcoreroutetable.AwsRoute
+		cloudcoreroutetable.AwsRoute |
+
+		corelb.LoadBalancer[corelb.TCloudLoadBalancerExtension] |
+		corelb.LoadBalancer[corelb.AwsLoadBalancerExtension] |
+		corelb.LoadBalancer[corelb.HuaWeiLoadBalancerExtension] |
+		corelb.LoadBalancer[corelb.AzureLoadBalancerExtension] |
+		corelb.LoadBalancer[corelb.GcpLoadBalancerExtension] |
+
+		corelb.Listener[corelb.TCloudListenerExtension] |
+		corelb.Listener[corelb.AwsListenerExtension] |
+		corelb.Listener[corelb.HuaWeiListenerExtension] |
+		corelb.Listener[corelb.AzureListenerExtension] |
+		corelb.Listener[corelb.GcpListenerExtension] |
+
+		corelb.Target[corelb.TCloudTargetExtension] |
+		corelb.Target[corelb.AwsTargetExtension] |
+		corelb.Target[corelb.HuaWeiTargetExtension] |
+		corelb.Target[corelb.AzureTargetExtension] |
+		corelb.Target[corelb.GcpTargetExtension] |
+
+		corenat.NatGateway[corenat.TCloudNatGatewayExtension] |
+		corenat.NatGateway[corenat.AwsNatGatewayExtension] |
+		corenat.NatGateway[corenat.HuaWeiNatGatewayExtension] |
+		corenat.NatGateway[corenat.AzureNatGatewayExtension] |
+		corenat.NatGateway[corenat.GcpNatGatewayExtension] |
+		coresnap.Snapshot[coresnap.TCloudSnapshotExtension] |
+		coresnap.Snapshot[coresnap.AwsSnapshotExtension] |
+		coresnap.Snapshot[coresnap.HuaWeiSnapshotExtension] |
+		coresnap.Snapshot[coresnap.AzureSnapshotExtension] |
+		coresnap.Snapshot[coresnap.GcpSnapshotExtension] |
+
+		corekp.CloudKeyPair |
+		corebucket.Bucket |
+		corevpcpeering.VpcPeering
 }
 
 // Diff 对比云和db资源，划分出新增数据，更新数据，删除数据。
@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// DriftOption define resource drift event record option.
+type DriftOption struct {
+	Vendor    enumor.Vendor
+	AccountID string
+	ResType   enumor.CloudResourceType
+}
+
+// RecordDrift 将 Diff 对比出的新增、更新、删除数据记录为资源漂移事件，变更前快照取自db数据，变更后快照取自云上数据。
+// 需要在数据写入db成功后调用，漂移事件仅用于追溯资源变更，记录失败只打印日志，不影响资源同步。
+func RecordDrift[CloudType CloudResType, DBType DBResType](kt *kit.Kit, dbCli *dataservice.Client, opt *DriftOption,
+	dataFromDB []DBType, addSlice []CloudType, updateMap map[string]CloudType, delCloudIDs []string) {
+
+	if len(addSlice) == 0 && len(updateMap) == 0 && len(delCloudIDs) == 0 {
+		return
+	}
+
+	SaveDriftEvents(kt, dbCli, BuildDriftEvents(kt, opt, dataFromDB, addSlice, updateMap, delCloudIDs))
+}
+
+// BuildDriftEvents 根据 Diff 对比出的新增、更新、删除数据生成资源漂移事件，构造失败的事件只打印日志。
+func BuildDriftEvents[CloudType CloudResType, DBType DBResType](kt *kit.Kit, opt *DriftOption, dataFromDB []DBType,
+	addSlice []CloudType, updateMap map[string]CloudType, delCloudIDs []string) []protocloud.ResDriftEventCreate {
+
+	dbMap := make(map[string]DBType, len(dataFromDB))
+	for _, one := range dataFromDB {
+		dbMap[one.GetCloudID()] = one
+	}
+
+	events := make([]protocloud.ResDriftEventCreate, 0, len(addSlice)+len(updateMap)+len(delCloudIDs))
+	for _, one := range addSlice {
+		event, err := NewDriftEvent(opt, enumor.AddDriftEvent, "", one.GetCloudID(), nil, one)
+		if err != nil {
+			logs.Errorf("[%s] build %s add drift event failed, err: %v, cloud_id: %s, rid: %s", opt.Vendor,
+				opt.ResType, err, one.GetCloudID(), kt.Rid)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	for _, one := range dataFromDB {
+		cloud, exist := updateMap[one.GetID()]
+		if !exist {
+			continue
+		}
+
+		event, err := NewDriftEvent(opt, enumor.UpdateDriftEvent, one.GetID(), one.GetCloudID(), one, cloud)
+		if err != nil {
+			logs.Errorf("[%s] build %s update drift event failed, err: %v, id: %s, rid: %s", opt.Vendor,
+				opt.ResType, err, one.GetID(), kt.Rid)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	for _, cloudID := range delCloudIDs {
+		one, exist := dbMap[cloudID]
+		if !exist {
+			continue
+		}
+
+		event, err := NewDriftEvent(opt, enumor.DeleteDriftEvent, one.GetID(), cloudID, one, nil)
+		if err != nil {
+			logs.Errorf("[%s] build %s delete drift event failed, err: %v, id: %s, rid: %s", opt.Vendor,
+				opt.ResType, err, one.GetID(), kt.Rid)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// NewDriftEvent build resource drift event, before and after is resource snapshot, nil means resource not exist.
+func NewDriftEvent(opt *DriftOption, action enumor.DriftEventAction, resID, cloudResID string, before,
+	after any) (protocloud.ResDriftEventCreate, error) {
+
+	beforeData, err := types.NewJsonField(before)
+	if err != nil {
+		return protocloud.ResDriftEventCreate{}, err
+	}
+
+	afterData, err := types.NewJsonField(after)
+	if err != nil {
+		return protocloud.ResDriftEventCreate{}, err
+	}
+
+	return protocloud.ResDriftEventCreate{
+		Vendor:     opt.Vendor,
+		AccountID:  opt.AccountID,
+		ResType:    opt.ResType,
+		ResID:      resID,
+		CloudResID: cloudResID,
+		Action:     action,
+		BeforeData: beforeData,
+		AfterData:  afterData,
+	}, nil
+}
+
+// SaveDriftEvents save resource drift events, failed events are only logged.
+func SaveDriftEvents(kt *kit.Kit, dbCli *dataservice.Client, events []protocloud.ResDriftEventCreate) {
+	if !IsDriftRecordEnabled(kt) {
+		return
+	}
+
+	for _, batch := range slice.Split(events, constant.BatchOperationMaxLimit) {
+		req := &protocloud.ResDriftEventBatchCreateReq{Events: batch}
+		if err := dbCli.Global.DriftEvent.BatchCreateResDriftEvent(kt.Ctx, kt.Header(), req); err != nil {
+			logs.Errorf("request dataservice to create drift event failed, err: %v, count: %d, rid: %s", err,
+				len(batch), kt.Rid)
+		}
+	}
+}
+
+// IsDriftRecordEnabled 判断是否需要记录漂移事件，只有通过同步接口发起的同步(定时同步、手动同步)才会开启同步报告并记录漂移，
+// HCM 操作资源后在进程内直接调用的同步写入的是 HCM 自身发起的变更，不属于云上漂移，不记录。
+func IsDriftRecordEnabled(kt *kit.Kit) bool {
+	return GetSyncReport(kt) != nil
+}
@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	typekp "hcm/pkg/adaptor/types/key-pair"
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+func TestBuildDriftEvents(t *testing.T) {
+	opt := &DriftOption{Vendor: enumor.TCloud, AccountID: "00000001", ResType: enumor.KeyPairCloudResType}
+	dataFromDB := []corekp.CloudKeyPair{
+		{ID: "00000010", CloudID: "skey-changed", Name: "old-name", Fingerprint: "fp1"},
+		{ID: "00000011", CloudID: "skey-unchanged", Name: "same", Fingerprint: "fp2"},
+		{ID: "00000012", CloudID: "skey-deleted", Name: "deleted", Fingerprint: "fp3"},
+	}
+	dataFromCloud := []typekp.KeyPair{
+		{CloudID: "skey-changed", Name: "new-name", Fingerprint: "fp1"},
+		{CloudID: "skey-unchanged", Name: "same", Fingerprint: "fp2"},
+		{CloudID: "skey-added", Name: "added", Fingerprint: "fp4"},
+	}
+
+	addSlice, updateMap, delCloudIDs := Diff[typekp.KeyPair, corekp.CloudKeyPair](dataFromCloud, dataFromDB,
+		IsKeyPairChange)
+	events := BuildDriftEvents(kit.New(), opt, dataFromDB, addSlice, updateMap, delCloudIDs)
+
+	eventMap := make(map[string]enumor.DriftEventAction)
+	for _, one := range events {
+		if one.Vendor != opt.Vendor || one.AccountID != opt.AccountID || one.ResType != opt.ResType {
+			t.Errorf("drift event option is not set, event: %+v", one)
+		}
+		eventMap[one.CloudResID] = one.Action
+	}
+
+	if len(events) != 3 {
+		t.Errorf("expect 3 drift events, got: %+v", events)
+	}
+	if eventMap["skey-changed"] != enumor.UpdateDriftEvent {
+		t.Errorf("changed field should record update drift event, got: %s", eventMap["skey-changed"])
+	}
+	if _, exist := eventMap["skey-unchanged"]; exist {
+		t.Errorf("unchanged resource should not record drift event")
+	}
+	if eventMap["skey-added"] != enumor.AddDriftEvent {
+		t.Errorf("added resource should record add drift event, got: %s", eventMap["skey-added"])
+	}
+	if eventMap["skey-deleted"] != enumor.DeleteDriftEvent {
+		t.Errorf("deleted resource should record delete drift event, got: %s", eventMap["skey-deleted"])
+	}
+
+	for _, one := range events {
+		if one.CloudResID != "skey-changed" {
+			continue
+		}
+
+		if one.ResID != "00000010" {
+			t.Errorf("update drift event res id, expect: 00000010, got: %s", one.ResID)
+		}
+
+		before, after := string(one.BeforeData), string(one.AfterData)
+		if !strings.Contains(before, `"name":"old-name"`) || !strings.Contains(after, `"name":"new-name"`) {
+			t.Errorf("update drift event snapshot is wrong, before: %s, after: %s", before, after)
+		}
+	}
+}
+
+func TestBuildDriftEventsUnchanged(t *testing.T) {
+	dataFromDB := []corekp.CloudKeyPair{{ID: "00000010", CloudID: "skey-1", Name: "same", Fingerprint: "fp"}}
+	// empty fingerprint returned by cloud is not compared.
+	dataFromCloud := []typekp.KeyPair{{CloudID: "skey-1", Name: "same"}}
+
+	addSlice, updateMap, delCloudIDs := Diff[typekp.KeyPair, corekp.CloudKeyPair](dataFromCloud, dataFromDB,
+		IsKeyPairChange)
+	events := BuildDriftEvents(kit.New(), &DriftOption{Vendor: enumor.HuaWei}, dataFromDB, addSlice, updateMap,
+		delCloudIDs)
+	if len(events) != 0 {
+		t.Errorf("unchanged resource should not record drift event, got: %+v", events)
+	}
+}
+
+func TestIsDriftRecordEnabled(t *testing.T) {
+	kt := kit.New()
+	if IsDriftRecordEnabled(kt) {
+		t.Errorf("drift should not be recorded by sync called after hcm operation")
+	}
+
+	EnableSyncReport(kt, false)
+	if !IsDriftRecordEnabled(kt) {
+		t.Errorf("drift should be recorded by sync requested by sync api")
+	}
+}
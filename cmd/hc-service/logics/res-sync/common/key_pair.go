@@ -0,0 +1,173 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	typekp "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/api/core"
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	protokp "hcm/pkg/api/data-service/cloud/key-pair"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// cloud key pair has no vendor extension, all vendors share the same data-service api, so the db operations of key
+// pair sync are implemented here instead of each vendor.
+
+// IsKeyPairChange check if cloud key pair is changed, fingerprint returned by some vendors may be empty, in this
+// case fingerprint is not compared.
+func IsKeyPairChange(cloud typekp.KeyPair, db corekp.CloudKeyPair) bool {
+	if cloud.Name != db.Name {
+		return true
+	}
+
+	if len(cloud.Fingerprint) != 0 && cloud.Fingerprint != db.Fingerprint {
+		return true
+	}
+
+	return false
+}
+
+// ListCloudKeyPairFromDB list cloud key pair from db by cloud ids.
+func ListCloudKeyPairFromDB(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, accountID, region string,
+	cloudIDs []string) ([]corekp.CloudKeyPair, error) {
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := dataCli.Global.KeyPair.ListCloudKeyPair(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list key pair from db failed, err: %v, account: %s, req: %v, rid: %s", vendor, err,
+			accountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// CreateCloudKeyPair create cloud key pair in db, bizID and keyPairID are set when key pair is pushed by hcm.
+func CreateCloudKeyPair(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, accountID string,
+	addSlice []typekp.KeyPair, bizID int64, keyPairID string) ([]string, error) {
+
+	if bizID == 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	keyPairs := make([]protokp.CloudKeyPairBatchCreate, 0, len(addSlice))
+	for _, one := range addSlice {
+		keyPairs = append(keyPairs, protokp.CloudKeyPairBatchCreate{
+			Vendor:           vendor,
+			AccountID:        accountID,
+			Region:           one.Region,
+			CloudID:          one.CloudID,
+			Name:             one.Name,
+			Fingerprint:      one.Fingerprint,
+			KeyPairID:        keyPairID,
+			BkBizID:          bizID,
+			CloudCreatedTime: one.CloudCreatedTime,
+		})
+	}
+
+	createdIDs := make([]string, 0, len(addSlice))
+	for _, part := range slice.Split(keyPairs, constant.BatchOperationMaxLimit) {
+		createReq := &protokp.CloudKeyPairBatchCreateReq{KeyPairs: part}
+		result, err := dataCli.Global.KeyPair.BatchCreateCloudKeyPair(kt.Ctx, kt.Header(), createReq)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to batch create key pair failed, err: %v, rid: %s", vendor, err,
+				kt.Rid)
+			return nil, err
+		}
+		createdIDs = append(createdIDs, result.IDs...)
+	}
+
+	logs.Infof("[%s] sync key pair to create key pair success, accountID: %s, count: %d, rid: %s", vendor,
+		accountID, len(addSlice), kt.Rid)
+
+	return createdIDs, nil
+}
+
+// UpdateCloudKeyPair update cloud key pair in db, updateMap key is the id of cloud key pair.
+func UpdateCloudKeyPair(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, accountID string,
+	updateMap map[string]typekp.KeyPair) error {
+
+	keyPairs := make([]protokp.CloudKeyPairBatchUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		keyPairs = append(keyPairs, protokp.CloudKeyPairBatchUpdate{
+			ID:          id,
+			Name:        one.Name,
+			Fingerprint: one.Fingerprint,
+		})
+	}
+
+	for _, part := range slice.Split(keyPairs, constant.BatchOperationMaxLimit) {
+		updateReq := &protokp.CloudKeyPairBatchUpdateReq{KeyPairs: part}
+		if err := dataCli.Global.KeyPair.BatchUpdateCloudKeyPair(kt.Ctx, kt.Header(), updateReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch update key pair failed, err: %v, rid: %s", vendor, err,
+				kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync key pair to update key pair success, accountID: %s, count: %d, rid: %s", vendor,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+// DeleteCloudKeyPair delete cloud key pair in db, caller should make sure key pairs are not exist in cloud.
+func DeleteCloudKeyPair(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, accountID, region string,
+	delCloudIDs []string) error {
+
+	deleteReq := &protokp.KeyPairBatchDeleteReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: delCloudIDs},
+			},
+		},
+	}
+	if err := dataCli.Global.KeyPair.BatchDeleteCloudKeyPair(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete key pair failed, err: %v, rid: %s", vendor, err,
+			kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync key pair to delete key pair success, accountID: %s, count: %d, rid: %s", vendor,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"hcm/pkg/api/core"
+	protoeip "hcm/pkg/api/data-service/cloud/eip"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// NatGatewayRelResMap nat gateway related resource's cloud id to id map.
+type NatGatewayRelResMap struct {
+	VpcMap    map[string]string
+	SubnetMap map[string]string
+	EipMap    map[string]string
+}
+
+// GetNatGatewayRelResMap get vpc, subnet and eip id by cloud id, used to fill related resource ids of nat gateway.
+// related resources which are not synced to db yet are not returned, they are filled in the next sync.
+func GetNatGatewayRelResMap(kt *kit.Kit, dataCli *dataclient.Client, accountID string, cloudVpcIDs,
+	cloudSubnetIDs, cloudEipIDs []string) (*NatGatewayRelResMap, error) {
+
+	relMap := &NatGatewayRelResMap{
+		VpcMap:    make(map[string]string),
+		SubnetMap: make(map[string]string),
+		EipMap:    make(map[string]string),
+	}
+
+	for _, parts := range slice.Split(slice.Unique(cloudVpcIDs), constant.CloudResourceSyncMaxLimit) {
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id"},
+			Filter: genAccountCloudIDsFilter(accountID, parts),
+			Page:   core.NewDefaultBasePage(),
+		}
+		result, err := dataCli.Global.Vpc.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list vpc for nat gateway failed, err: %v, cloud ids: %v, rid: %s", err, parts, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			relMap.VpcMap[one.CloudID] = one.ID
+		}
+	}
+
+	for _, parts := range slice.Split(slice.Unique(cloudSubnetIDs), constant.CloudResourceSyncMaxLimit) {
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id"},
+			Filter: genAccountCloudIDsFilter(accountID, parts),
+			Page:   core.NewDefaultBasePage(),
+		}
+		result, err := dataCli.Global.Subnet.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list subnet for nat gateway failed, err: %v, cloud ids: %v, rid: %s", err, parts, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			relMap.SubnetMap[one.CloudID] = one.ID
+		}
+	}
+
+	for _, parts := range slice.Split(slice.Unique(cloudEipIDs), constant.CloudResourceSyncMaxLimit) {
+		req := &protoeip.EipListReq{
+			Fields: []string{"id", "cloud_id"},
+			Filter: genAccountCloudIDsFilter(accountID, parts),
+			Page:   core.NewDefaultBasePage(),
+		}
+		result, err := dataCli.Global.ListEip(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list eip for nat gateway failed, err: %v, cloud ids: %v, rid: %s", err, parts, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			relMap.EipMap[one.CloudID] = one.ID
+		}
+	}
+
+	return relMap, nil
+}
+
+// EipIDs convert eip cloud ids to eip ids, eips not synced to db yet are skipped.
+func (m *NatGatewayRelResMap) EipIDs(cloudEipIDs []string) []string {
+	ids := make([]string, 0, len(cloudEipIDs))
+	for _, cloudID := range cloudEipIDs {
+		if id, exist := m.EipMap[cloudID]; exist {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+func genAccountCloudIDsFilter(accountID string, cloudIDs []string) *filter.Expression {
+	return &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+			&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+		},
+	}
+}
@@ -0,0 +1,38 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"testing"
+)
+
+func TestNatGatewayRelResMapEipIDs(t *testing.T) {
+	relMap := &NatGatewayRelResMap{EipMap: map[string]string{"eip-1": "00000001", "eip-2": "00000002"}}
+
+	// eip not synced to db yet is skipped.
+	ids := relMap.EipIDs([]string{"eip-2", "eip-not-synced", "eip-1"})
+	if len(ids) != 2 || ids[0] != "00000002" || ids[1] != "00000001" {
+		t.Errorf("eip ids, expect: [00000002 00000001], got: %v", ids)
+	}
+
+	if ids = relMap.EipIDs(nil); len(ids) != 0 {
+		t.Errorf("eip ids of empty cloud ids should be empty, got: %v", ids)
+	}
+}
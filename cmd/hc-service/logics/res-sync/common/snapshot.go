@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"hcm/pkg/api/core"
+	protodisk "hcm/pkg/api/data-service/cloud/disk"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// GetSnapshotDiskIDMap get disk id by cloud disk id, used to fill source disk id of snapshot. disks which are not
+// synced to db yet or have been deleted are not returned, snapshot's disk id is left empty in this case.
+func GetSnapshotDiskIDMap(kt *kit.Kit, dataCli *dataclient.Client, accountID string, cloudDiskIDs []string) (
+	map[string]string, error) {
+
+	diskMap := make(map[string]string)
+	for _, parts := range slice.Split(slice.Unique(cloudDiskIDs), constant.CloudResourceSyncMaxLimit) {
+		req := &protodisk.DiskListReq{
+			Fields: []string{"id", "cloud_id"},
+			Filter: genAccountCloudIDsFilter(accountID, parts),
+			Page:   core.NewDefaultBasePage(),
+		}
+		result, err := dataCli.Global.ListDisk(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list disk for snapshot failed, err: %v, cloud ids: %v, rid: %s", err, parts, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			diskMap[one.CloudID] = one.ID
+		}
+	}
+
+	return diskMap, nil
+}
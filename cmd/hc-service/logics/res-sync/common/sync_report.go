@@ -0,0 +1,191 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"context"
+	"sync"
+
+	hcsync "hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// dryRunSupportedResType 支持演练同步的资源类型，这些资源在 Diff 对比后、写入db前会检查演练模式。
+var dryRunSupportedResType = map[enumor.CloudResourceType]struct{}{
+	enumor.CvmCloudResType:              {},
+	enumor.DiskCloudResType:             {},
+	enumor.EipCloudResType:              {},
+	enumor.VpcCloudResType:              {},
+	enumor.SubnetCloudResType:           {},
+	enumor.SecurityGroupCloudResType:    {},
+	enumor.GcpFirewallRuleCloudResType:  {},
+	enumor.RouteTableCloudResType:       {},
+	enumor.RouteCloudResType:            {},
+	enumor.NetworkInterfaceCloudResType: {},
+	enumor.LoadBalancerCloudResType:     {},
+	enumor.NatGatewayCloudResType:       {},
+	enumor.SnapshotCloudResType:         {},
+	enumor.KeyPairCloudResType:          {},
+	enumor.BucketCloudResType:           {},
+	enumor.VpcPeeringCloudResType:       {},
+	enumor.ImageCloudResType:            {},
+	enumor.ResourceTagCloudResType:      {},
+}
+
+// IsDryRunSupported 判断资源类型是否支持演练同步。
+func IsDryRunSupported(resType enumor.CloudResourceType) bool {
+	_, exist := dryRunSupportedResType[resType]
+	return exist
+}
+
+type syncReportCtxKey struct{}
+
+// SyncReport 同步报告，记录同步过程中 Diff 对比出的新增、更新、删除的资源，演练模式下同步流程只记录对比结果，不写入db。
+type SyncReport struct {
+	dryRun  bool
+	lock    sync.Mutex
+	details map[enumor.CloudResourceType]*syncReportDetail
+}
+
+type syncReportDetail struct {
+	add    map[string]struct{}
+	update map[string]struct{}
+	delete map[string]struct{}
+}
+
+// EnableSyncReport 开启同步报告，报告保存在 kit 的上下文中，同一请求内的同步流程共享同一份报告，已开启时不重复开启。
+func EnableSyncReport(kt *kit.Kit, dryRun bool) {
+	if GetSyncReport(kt) != nil {
+		return
+	}
+
+	report := &SyncReport{
+		dryRun:  dryRun,
+		details: make(map[enumor.CloudResourceType]*syncReportDetail),
+	}
+	kt.Ctx = context.WithValue(kt.Ctx, syncReportCtxKey{}, report)
+}
+
+// EnableDryRun 开启演练模式。
+func EnableDryRun(kt *kit.Kit) {
+	EnableSyncReport(kt, true)
+}
+
+// GetSyncReport 获取同步报告，未开启时返回nil。
+func GetSyncReport(kt *kit.Kit) *SyncReport {
+	if kt == nil || kt.Ctx == nil {
+		return nil
+	}
+
+	report, _ := kt.Ctx.Value(syncReportCtxKey{}).(*SyncReport)
+	return report
+}
+
+// IsDryRun 判断是否为演练模式。
+func IsDryRun(kt *kit.Kit) bool {
+	report := GetSyncReport(kt)
+	return report != nil && report.dryRun
+}
+
+// ReportDiff 记录 Diff 对比出的新增、更新、删除数据，返回是否为演练模式，为 true 时调用方不能写入db。
+func ReportDiff[CloudType CloudResType](kt *kit.Kit, resType enumor.CloudResourceType, addSlice []CloudType,
+	updateMap map[string]CloudType, delCloudIDs []string) bool {
+
+	if GetSyncReport(kt) == nil {
+		return false
+	}
+
+	addCloudIDs := make([]string, 0, len(addSlice))
+	for _, one := range addSlice {
+		addCloudIDs = append(addCloudIDs, one.GetCloudID())
+	}
+
+	updateCloudIDs := make([]string, 0, len(updateMap))
+	for _, one := range updateMap {
+		updateCloudIDs = append(updateCloudIDs, one.GetCloudID())
+	}
+
+	return ReportDiffCloudIDs(kt, resType, addCloudIDs, updateCloudIDs, delCloudIDs)
+}
+
+// ReportDiffCloudIDs 按云ID记录新增、更新、删除的资源，返回是否为演练模式，为 true 时调用方不能写入db。
+func ReportDiffCloudIDs(kt *kit.Kit, resType enumor.CloudResourceType, addCloudIDs, updateCloudIDs,
+	delCloudIDs []string) bool {
+
+	report := GetSyncReport(kt)
+	if report == nil {
+		return false
+	}
+
+	report.lock.Lock()
+	defer report.lock.Unlock()
+
+	detail, exist := report.details[resType]
+	if !exist {
+		detail = &syncReportDetail{
+			add:    make(map[string]struct{}),
+			update: make(map[string]struct{}),
+			delete: make(map[string]struct{}),
+		}
+		report.details[resType] = detail
+	}
+
+	for _, id := range addCloudIDs {
+		detail.add[id] = struct{}{}
+	}
+	for _, id := range updateCloudIDs {
+		detail.update[id] = struct{}{}
+	}
+	for _, id := range delCloudIDs {
+		detail.delete[id] = struct{}{}
+	}
+
+	return report.dryRun
+}
+
+// Result 将同步报告转换为同步结果。
+func (r *SyncReport) Result() *hcsync.SyncResult {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	result := &hcsync.SyncResult{Details: make([]hcsync.SyncResultDetail, 0, len(r.details))}
+	for resType, detail := range r.details {
+		result.Merge(&hcsync.SyncResult{Details: []hcsync.SyncResultDetail{{
+			ResType:        resType,
+			AddCloudIDs:    converter.MapKeyToStringSlice(detail.add),
+			UpdateCloudIDs: converter.MapKeyToStringSlice(detail.update),
+			DeleteCloudIDs: converter.MapKeyToStringSlice(detail.delete),
+		}}})
+	}
+
+	return result
+}
+
+// ExcludeCloudIDs 返回 cloudIDs 中不在 excludeIDs 内的云ID，用于演练模式下剔除将会被删除的资源。
+func ExcludeCloudIDs(cloudIDs []string, excludeIDs []string) []string {
+	excludeMap := converter.StringSliceToMap(excludeIDs)
+	return slice.Filter(cloudIDs, func(id string) bool {
+		_, exist := excludeMap[id]
+		return !exist
+	})
+}
@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"sort"
+	"testing"
+
+	typekp "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+func TestReportDiffWithoutReport(t *testing.T) {
+	kt := kit.New()
+	if ReportDiff(kt, enumor.KeyPairCloudResType, []typekp.KeyPair{{CloudID: "skey-1"}}, nil, nil) {
+		t.Errorf("report diff without sync report should not be dry run")
+	}
+	if GetSyncReport(kt) != nil {
+		t.Errorf("sync report should not be enabled by report diff")
+	}
+}
+
+func TestReportDiff(t *testing.T) {
+	cases := []struct {
+		name   string
+		dryRun bool
+	}{
+		{name: "sync", dryRun: false},
+		{name: "dry run", dryRun: true},
+	}
+
+	for _, c := range cases {
+		kt := kit.New()
+		EnableSyncReport(kt, c.dryRun)
+		// enable again should keep the existing report.
+		EnableSyncReport(kt, !c.dryRun)
+
+		addSlice := []typekp.KeyPair{{CloudID: "skey-add"}}
+		updateMap := map[string]typekp.KeyPair{"00000001": {CloudID: "skey-update"}}
+		if got := ReportDiff(kt, enumor.KeyPairCloudResType, addSlice, updateMap, []string{"skey-del"}); got !=
+			c.dryRun {
+			t.Errorf("%s: report diff returns dry run %v, expect: %v", c.name, got, c.dryRun)
+		}
+		// the same cloud id reported twice is only recorded once.
+		ReportDiff(kt, enumor.KeyPairCloudResType, addSlice, nil, []string{"skey-del-2"})
+
+		result := GetSyncReport(kt).Result()
+		if len(result.Details) != 1 {
+			t.Errorf("%s: expect 1 sync result detail, got: %+v", c.name, result.Details)
+			continue
+		}
+
+		detail := result.Details[0]
+		sort.Strings(detail.DeleteCloudIDs)
+		if detail.ResType != enumor.KeyPairCloudResType || len(detail.AddCloudIDs) != 1 ||
+			len(detail.UpdateCloudIDs) != 1 || detail.UpdateCloudIDs[0] != "skey-update" ||
+			len(detail.DeleteCloudIDs) != 2 || detail.DeleteCloudIDs[0] != "skey-del" {
+			t.Errorf("%s: unexpected sync result detail: %+v", c.name, detail)
+		}
+		if result.ChangeCount() != 4 {
+			t.Errorf("%s: expect change count 4, got: %d", c.name, result.ChangeCount())
+		}
+	}
+}
+
+func TestExcludeCloudIDs(t *testing.T) {
+	got := ExcludeCloudIDs([]string{"a", "b", "c"}, []string{"b"})
+	if len(got) != 2 || got[0] != "a" || got[1] != "c" {
+		t.Errorf("exclude cloud ids, expect: [a c], got: %v", got)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"context"
+
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/slice"
+)
+
+type syncCloudIDsCtxKey struct{}
+
+// SetSyncCloudIDs 设置本次请求指定同步的资源云ID，设置后同步流程只同步这些资源，不再全量对比云上和db的数据。
+func SetSyncCloudIDs(kt *kit.Kit, cloudIDs []string) {
+	if len(cloudIDs) == 0 {
+		return
+	}
+
+	kt.Ctx = context.WithValue(kt.Ctx, syncCloudIDsCtxKey{}, slice.Unique(cloudIDs))
+}
+
+// GetSyncCloudIDs 获取本次请求指定同步的资源云ID，未指定时返回nil，表示全量同步。
+func GetSyncCloudIDs(kt *kit.Kit) []string {
+	if kt == nil || kt.Ctx == nil {
+		return nil
+	}
+
+	cloudIDs, _ := kt.Ctx.Value(syncCloudIDsCtxKey{}).([]string)
+	return cloudIDs
+}
@@ -0,0 +1,356 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"strings"
+
+	typevpcpeering "hcm/pkg/adaptor/types/vpc-peering"
+	"hcm/pkg/api/core"
+	corevpcpeering "hcm/pkg/api/core/cloud/vpc-peering"
+	protovpcpeering "hcm/pkg/api/data-service/cloud/vpc-peering"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// 对等连接及中转网关/云联网关联的列表接口均为全量拉取，按账号（区分地域的云厂商按账号+地域）维度全量同步，
+// 各云厂商只负责从云上拉取，对比和db操作在这里统一实现。
+
+// SyncVpcPeeringParams defines params to sync vpc peering of account.
+type SyncVpcPeeringParams struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// Region 为空表示同步账号下全部对等连接，适用于对等连接为全局资源的云厂商
+	Region   string   `json:"region"`
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
+}
+
+// Validate SyncVpcPeeringParams.
+func (p SyncVpcPeeringParams) Validate() error {
+	return validator.Validate.Struct(p)
+}
+
+// SyncVpcPeering sync vpc peerings listed from cloud to db, only vpc peerings in cloud ids are synced when they
+// are specified. local and peer vpc of vpc peering are linked to vpc records synced to db by cloud vpc id.
+func SyncVpcPeering(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, params *SyncVpcPeeringParams,
+	peeringFromCloud []typevpcpeering.VpcPeering) error {
+
+	if err := params.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(params.CloudIDs) != 0 {
+		cloudIDMap := converter.StringSliceToMap(params.CloudIDs)
+		peeringFromCloud = slice.Filter(peeringFromCloud, func(one typevpcpeering.VpcPeering) bool {
+			_, exist := cloudIDMap[one.CloudID]
+			return exist
+		})
+	}
+
+	peeringFromDB, err := ListVpcPeeringFromDB(kt, dataCli, vendor, params)
+	if err != nil {
+		return err
+	}
+
+	if len(peeringFromCloud) == 0 && len(peeringFromDB) == 0 {
+		return nil
+	}
+
+	cloudVpcIDs := make([]string, 0, 2*len(peeringFromCloud))
+	for _, one := range peeringFromCloud {
+		cloudVpcIDs = append(cloudVpcIDs, one.LocalCloudVpcID, one.PeerCloudVpcID)
+	}
+	vpcMap, err := GetVpcIDMapByCloudIDs(kt, dataCli, vendor, cloudVpcIDs)
+	if err != nil {
+		return err
+	}
+
+	isChange := func(cloud typevpcpeering.VpcPeering, db corevpcpeering.VpcPeering) bool {
+		// 对等连接先于VPC同步时关联的VPC ID为空，VPC同步后需要补全
+		if vpcMap[cloud.LocalCloudVpcID] != db.LocalVpcID || vpcMap[cloud.PeerCloudVpcID] != db.PeerVpcID {
+			return true
+		}
+		return IsVpcPeeringChange(cloud, db)
+	}
+	addSlice, updateMap, delCloudIDs := Diff[typevpcpeering.VpcPeering, corevpcpeering.VpcPeering](
+		peeringFromCloud, peeringFromDB, isChange)
+
+	if ReportDiff(kt, enumor.VpcPeeringCloudResType, addSlice, updateMap, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = DeleteVpcPeering(kt, dataCli, vendor, params.AccountID, delCloudIDs); err != nil {
+			return err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = CreateVpcPeering(kt, dataCli, vendor, params.AccountID, vpcMap, addSlice); err != nil {
+			return err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = UpdateVpcPeering(kt, dataCli, vendor, params.AccountID, vpcMap, updateMap); err != nil {
+			return err
+		}
+	}
+
+	RecordDrift(kt, dataCli, &DriftOption{Vendor: vendor, AccountID: params.AccountID,
+		ResType: enumor.VpcPeeringCloudResType}, peeringFromDB, addSlice, updateMap, delCloudIDs)
+
+	return nil
+}
+
+// IsVpcPeeringChange check if vpc peering is changed.
+func IsVpcPeeringChange(cloud typevpcpeering.VpcPeering, db corevpcpeering.VpcPeering) bool {
+	if cloud.Name != db.Name || cloud.Status != db.Status || cloud.CloudStatus != db.CloudStatus {
+		return true
+	}
+
+	if cloud.CloudGatewayID != db.CloudGatewayID {
+		return true
+	}
+
+	if cloud.LocalCloudVpcID != db.LocalCloudVpcID || cloud.PeerCloudVpcID != db.PeerCloudVpcID {
+		return true
+	}
+
+	if cloud.PeerCloudAccountID != db.PeerCloudAccountID || cloud.PeerRegion != db.PeerRegion {
+		return true
+	}
+
+	if strings.Join(cloud.LocalCidrs, ",") != strings.Join(db.LocalCidrs, ",") ||
+		strings.Join(cloud.PeerCidrs, ",") != strings.Join(db.PeerCidrs, ",") {
+		return true
+	}
+
+	if converter.PtrToVal(cloud.Memo) != converter.PtrToVal(db.Memo) {
+		return true
+	}
+
+	return false
+}
+
+// GetVpcIDMapByCloudIDs get vpc id map by cloud vpc ids of vendor, key is cloud id. vpc of vpc peering may belong
+// to other account, so vpcs are not filtered by account. vpcs not synced to db yet are not returned.
+func GetVpcIDMapByCloudIDs(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, cloudIDs []string) (
+	map[string]string, error) {
+
+	cloudIDs = slice.Filter(slice.Unique(cloudIDs), func(one string) bool {
+		return len(one) != 0
+	})
+
+	vpcMap := make(map[string]string, len(cloudIDs))
+	for _, parts := range slice.Split(cloudIDs, constant.CloudResourceSyncMaxLimit) {
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id"},
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+					&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: parts},
+				},
+			},
+			Page: core.NewDefaultBasePage(),
+		}
+		result, err := dataCli.Global.Vpc.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] list vpc by cloud ids failed, err: %v, cloud ids: %v, rid: %s", vendor, err, parts,
+				kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			vpcMap[one.CloudID] = one.ID
+		}
+	}
+
+	return vpcMap, nil
+}
+
+// ListVpcPeeringFromDB list vpc peering of account from db, region and cloud ids are used as filter when they
+// are specified.
+func ListVpcPeeringFromDB(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor,
+	params *SyncVpcPeeringParams) ([]corevpcpeering.VpcPeering, error) {
+
+	rules := []filter.RuleFactory{
+		&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+		&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+	}
+	if len(params.Region) != 0 {
+		rules = append(rules, &filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: params.Region})
+	}
+	if len(params.CloudIDs) != 0 {
+		rules = append(rules, &filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs})
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op:    filter.And,
+			Rules: rules,
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: core.DefaultMaxPageLimit,
+		},
+	}
+
+	peerings := make([]corevpcpeering.VpcPeering, 0)
+	for {
+		result, err := dataCli.Global.VpcPeering.ListVpcPeering(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] list vpc peering from db failed, err: %v, account: %s, req: %v, rid: %s", vendor,
+				err, params.AccountID, req, kt.Rid)
+			return nil, err
+		}
+
+		peerings = append(peerings, result.Details...)
+
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return peerings, nil
+}
+
+// CreateVpcPeering create vpc peering in db, new vpc peering is not assigned to any biz.
+func CreateVpcPeering(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, accountID string,
+	vpcMap map[string]string, addSlice []typevpcpeering.VpcPeering) error {
+
+	peerings := make([]protovpcpeering.VpcPeeringBatchCreate, 0, len(addSlice))
+	for _, one := range addSlice {
+		peerings = append(peerings, protovpcpeering.VpcPeeringBatchCreate{
+			Vendor:             vendor,
+			AccountID:          accountID,
+			Region:             one.Region,
+			CloudID:            one.CloudID,
+			Name:               one.Name,
+			Type:               one.Type,
+			Status:             one.Status,
+			CloudStatus:        one.CloudStatus,
+			CloudGatewayID:     one.CloudGatewayID,
+			LocalVpcID:         vpcMap[one.LocalCloudVpcID],
+			LocalCloudVpcID:    one.LocalCloudVpcID,
+			LocalCidrs:         one.LocalCidrs,
+			PeerVpcID:          vpcMap[one.PeerCloudVpcID],
+			PeerCloudVpcID:     one.PeerCloudVpcID,
+			PeerCloudAccountID: one.PeerCloudAccountID,
+			PeerRegion:         one.PeerRegion,
+			PeerCidrs:          one.PeerCidrs,
+			BkBizID:            constant.UnassignedBiz,
+			Memo:               one.Memo,
+			CloudCreatedTime:   one.CloudCreatedTime,
+		})
+	}
+
+	for _, part := range slice.Split(peerings, constant.BatchOperationMaxLimit) {
+		createReq := &protovpcpeering.VpcPeeringBatchCreateReq{VpcPeerings: part}
+		if _, err := dataCli.Global.VpcPeering.BatchCreateVpcPeering(kt.Ctx, kt.Header(), createReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch create vpc peering failed, err: %v, rid: %s", vendor,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync vpc peering to create vpc peering success, accountID: %s, count: %d, rid: %s", vendor,
+		accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+// UpdateVpcPeering update vpc peering in db, updateMap key is the id of vpc peering.
+func UpdateVpcPeering(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, accountID string,
+	vpcMap map[string]string, updateMap map[string]typevpcpeering.VpcPeering) error {
+
+	peerings := make([]protovpcpeering.VpcPeeringBatchUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		peerings = append(peerings, protovpcpeering.VpcPeeringBatchUpdate{
+			ID:                 id,
+			Name:               one.Name,
+			Status:             one.Status,
+			CloudStatus:        one.CloudStatus,
+			CloudGatewayID:     one.CloudGatewayID,
+			LocalVpcID:         vpcMap[one.LocalCloudVpcID],
+			LocalCloudVpcID:    one.LocalCloudVpcID,
+			LocalCidrs:         one.LocalCidrs,
+			PeerVpcID:          vpcMap[one.PeerCloudVpcID],
+			PeerCloudVpcID:     one.PeerCloudVpcID,
+			PeerCloudAccountID: one.PeerCloudAccountID,
+			PeerRegion:         one.PeerRegion,
+			PeerCidrs:          one.PeerCidrs,
+			Memo:               one.Memo,
+		})
+	}
+
+	for _, part := range slice.Split(peerings, constant.BatchOperationMaxLimit) {
+		updateReq := &protovpcpeering.VpcPeeringBatchUpdateReq{VpcPeerings: part}
+		if err := dataCli.Global.VpcPeering.BatchUpdateVpcPeering(kt.Ctx, kt.Header(), updateReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch update vpc peering failed, err: %v, rid: %s", vendor,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync vpc peering to update vpc peering success, accountID: %s, count: %d, rid: %s", vendor,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+// DeleteVpcPeering delete vpc peering in db, vpc peerings are listed from cloud entirely, so vpc peerings not
+// returned by cloud have been deleted from cloud.
+func DeleteVpcPeering(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, accountID string,
+	delCloudIDs []string) error {
+
+	for _, part := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		deleteReq := &protovpcpeering.VpcPeeringBatchDeleteReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+					&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+					&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: part},
+				},
+			},
+		}
+		if err := dataCli.Global.VpcPeering.BatchDeleteVpcPeering(kt.Ctx, kt.Header(), deleteReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch delete vpc peering failed, err: %v, rid: %s", vendor,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync vpc peering to delete vpc peering success, accountID: %s, count: %d, rid: %s", vendor,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
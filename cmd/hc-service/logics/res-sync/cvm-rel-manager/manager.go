@@ -70,6 +70,11 @@ func (mgr *CvmRelManger) SyncRel(kt *kit.Kit, opt *SyncRelOption) error {
 		return err
 	}
 
+	// 演练模式下不写入关联关系
+	if common.IsDryRun(kt) {
+		return nil
+	}
+
 	if err := mgr.validateSyncRelParams(opt.Vendor, opt.ResType); err != nil {
 		return err
 	}
@@ -229,6 +234,13 @@ func (mgr *CvmRelManger) CvmAppendAssResCloudID(cvmCloudID string,
 	return
 }
 
+// AddCvm 添加需要同步的主机，主机没有关联资源时也会同步主机并清理其在关系表中的关联关系
+func (mgr *CvmRelManger) AddCvm(cvmCloudID string) {
+	if _, exist := mgr.cvmAssResMap[cvmCloudID]; !exist {
+		mgr.cvmAssResMap[cvmCloudID] = make(map[enumor.CloudResourceType][]string)
+	}
+}
+
 // AddAssParentWithChildRes 添加关联资源的父子资源关系，因为有部分子资源同步依赖父资源
 func (mgr *CvmRelManger) AddAssParentWithChildRes(assResType enumor.CloudResourceType, relMap map[string][]string) {
 
@@ -330,6 +342,12 @@ func (mgr *CvmRelManger) validateSyncRelParams(vendor enumor.Vendor, resType enu
 		default:
 			return fmt.Errorf("vendor: %s cvm and %s are not associated", vendor, resType)
 		}
+	case enumor.OpenStack:
+		switch resType {
+		case enumor.SecurityGroupCloudResType, enumor.DiskCloudResType:
+		default:
+			return fmt.Errorf("vendor: %s cvm and %s are not associated", vendor, resType)
+		}
 	default:
 		return fmt.Errorf("vendor: %s not support", vendor)
 	}
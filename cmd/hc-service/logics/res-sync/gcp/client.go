@@ -20,6 +20,7 @@
 package gcp
 
 import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	"hcm/pkg/adaptor/gcp"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/kit"
@@ -38,6 +39,14 @@ type Interface interface {
 
 	Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption) (*SyncResult, error)
 	RemoveEipDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
+	LoadBalancer(kt *kit.Kit, params *SyncBaseParams, opt *SyncLoadBalancerOption) (*SyncResult, error)
+	RemoveLoadBalancerDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
+	NatGateway(kt *kit.Kit, params *SyncBaseParams, opt *SyncNatGatewayOption) (*SyncResult, error)
+	RemoveNatGatewayDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
+	Snapshot(kt *kit.Kit, params *SyncBaseParams, opt *SyncSnapshotOption) (*SyncResult, error)
+	RemoveSnapshotDeleteFromCloud(kt *kit.Kit, accountID string) error
+	Bucket(kt *kit.Kit, params *common.SyncBucketParams) (*SyncResult, error)
+	VpcPeering(kt *kit.Kit, params *common.SyncVpcPeeringParams) (*SyncResult, error)
 
 	Route(kt *kit.Kit, params *SyncBaseParams, opt *SyncRouteOption) (*SyncResult, error)
 	RemoveRouteDeleteFromCloud(kt *kit.Kit, accountID string, zone string) error
@@ -79,6 +79,10 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 	addSlice, updateMap, delCloudIDs := common.Diff[typescvm.GcpCvm, corecvm.Cvm[cvm.GcpCvmExtension]](
 		cvmFromCloud, cvmFromDB, isCvmChange)
 
+	if common.ReportDiff(kt, enumor.CvmCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteCvm(kt, params.AccountID, opt.Zone, delCloudIDs); err != nil {
 			return nil, err
@@ -97,6 +101,9 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Gcp, AccountID: params.AccountID,
+		ResType: enumor.CvmCloudResType}, cvmFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -558,6 +565,10 @@ func (cli *client) getDiskMap(kt *kit.Kit, accountID string, zone string,
 }
 
 func (cli *client) deleteCvm(kt *kit.Kit, accountID string, zone string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.CvmCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("cvm delCloudIDs is <= 0, not delete")
 	}
@@ -71,6 +71,10 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 	addSlice, updateMap, delCloudIDs := common.Diff[adaptordisk.GcpDisk, *disk.DiskExtResult[disk.GcpDiskExtensionResult]](
 		diskFromCloud, diskFromDB, isDiskChange)
 
+	if common.ReportDiff(kt, enumor.DiskCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteDisk(kt, params.AccountID, opt.Zone, delCloudIDs); err != nil {
 			return nil, err
@@ -89,6 +93,9 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Gcp, AccountID: params.AccountID,
+		ResType: enumor.DiskCloudResType}, diskFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -292,6 +299,10 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, zone string, bootMa
 }
 
 func (cli *client) deleteDisk(kt *kit.Kit, accountID string, zone string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.DiskCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("delCloudIDs is <= 0, not delete")
 	}
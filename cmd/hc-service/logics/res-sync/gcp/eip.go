@@ -74,6 +74,10 @@ func (cli *client) Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption)
 	addEip, updateMap, delCloudIDs := common.Diff[*typeseip.GcpEip,
 		*dataeip.EipExtResult[dataeip.GcpEipExtensionResult]](eipFromCloud, eipFromDB, isEipChange)
 
+	if common.ReportDiff(kt, enumor.EipCloudResType, addEip, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteEip(kt, params.AccountID, opt.Region, delCloudIDs); err != nil {
 			return nil, err
@@ -92,6 +96,9 @@ func (cli *client) Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Gcp, AccountID: params.AccountID,
+		ResType: enumor.EipCloudResType}, eipFromDB, addEip, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -165,6 +172,10 @@ func (cli *client) RemoveEipDeleteFromCloud(kt *kit.Kit, accountID string, regio
 }
 
 func (cli *client) deleteEip(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.EipCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete eip, cloudIDs is required")
 	}
@@ -71,6 +71,10 @@ func (cli *client) Firewall(kt *kit.Kit, params *SyncBaseParams, opt *SyncFirewa
 	addSlice, updateMap, delCloudIDs := common.Diff[firewallrule.GcpFirewall, cloudcore.GcpFirewallRule](
 		firewallFromCloud, firewallFromDB, isFirewallChange)
 
+	if common.ReportDiff(kt, enumor.GcpFirewallRuleCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteFirewall(kt, params.AccountID, delCloudIDs); err != nil {
 			return nil, err
@@ -89,6 +93,9 @@ func (cli *client) Firewall(kt *kit.Kit, params *SyncBaseParams, opt *SyncFirewa
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Gcp, AccountID: params.AccountID,
+		ResType: enumor.GcpFirewallRuleCloudResType}, firewallFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -268,6 +275,10 @@ func (cli *client) updateFirewall(kt *kit.Kit, accountID string,
 }
 
 func (cli *client) deleteFirewall(kt *kit.Kit, accountID string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.GcpFirewallRuleCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("firewall delCloudIDs is <= 0, not delete")
 	}
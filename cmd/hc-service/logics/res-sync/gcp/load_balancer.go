@@ -0,0 +1,709 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protolb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncLoadBalancerOption ...
+type SyncLoadBalancerOption struct {
+	Region string `json:"region" validate:"required"`
+	// BkBizID 负载均衡创建时，通过同步写入DB，需要传入业务ID
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt SyncLoadBalancerOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// LoadBalancer sync load balancer, and then sync listeners and targets of these load balancers.
+func (cli *client) LoadBalancer(kt *kit.Kit, params *SyncBaseParams, opt *SyncLoadBalancerOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	lbFromCloud, err := cli.listLoadBalancerFromCloud(kt, params, opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	lbFromDB, err := cli.listLoadBalancerFromDB(kt, params, opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lbFromCloud) == 0 && len(lbFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typelb.GcpLoadBalancer,
+		corelb.LoadBalancer[corelb.GcpLoadBalancerExtension]](lbFromCloud, lbFromDB, isLoadBalancerChange)
+
+	if common.ReportDiff(kt, enumor.LoadBalancerCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteLoadBalancer(kt, params.AccountID, opt.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		_, err = cli.createLoadBalancer(kt, params.AccountID, addSlice, opt.BkBizID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateLoadBalancer(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(lbFromCloud) > 0 {
+		if err = cli.syncLoadBalancerListener(kt, params, opt.Region); err != nil {
+			return nil, err
+		}
+	}
+
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Gcp, AccountID: params.AccountID,
+		ResType: enumor.LoadBalancerCloudResType}, lbFromDB, addSlice, updateMap, delCloudIDs)
+
+	return new(SyncResult), nil
+}
+
+// RemoveLoadBalancerDeleteFromCloud ...
+func (cli *client) RemoveLoadBalancerDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.Gcp},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.CloudResourceSyncMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Global.LoadBalancer.ListLoadBalancer(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list load balancer failed, err: %v, req: %v, rid: %s",
+				enumor.Gcp, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listLoadBalancerFromCloud(kt, params, region)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.CloudID)
+			}
+
+			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if err = cli.deleteLoadBalancer(kt, accountID, region, delCloudIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.CloudResourceSyncMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.CloudResourceSyncMaxLimit
+	}
+
+	return nil
+}
+
+func (cli *client) deleteLoadBalancer(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.LoadBalancerCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete load balancer, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		CloudIDs:  delCloudIDs,
+	}
+	delFromCloud, err := cli.listLoadBalancerFromCloud(kt, checkParams, region)
+	if err != nil {
+		return err
+	}
+
+	if len(delFromCloud) > 0 {
+		logs.Errorf("[%s] validate load balancer not exist failed, before delete, opt: %v, failed_count: %d, "+
+			"rid: %s", enumor.Gcp, checkParams, len(delFromCloud), kt.Rid)
+		return fmt.Errorf("validate load balancer not exist failed, before delete")
+	}
+
+	deleteReq := &protolb.LoadBalancerBatchDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if err = cli.dbCli.Global.LoadBalancer.BatchDeleteLoadBalancer(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete load balancer failed, err: %v, rid: %s",
+			enumor.Gcp, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync load balancer to delete load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.Gcp, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateLoadBalancer(kt *kit.Kit, accountID string,
+	updateMap map[string]typelb.GcpLoadBalancer) error {
+
+	if len(updateMap) == 0 {
+		return fmt.Errorf("update load balancer, load balancers is required")
+	}
+
+	cloudVpcIDs := make([]string, 0, len(updateMap))
+	for _, one := range updateMap {
+		cloudVpcIDs = append(cloudVpcIDs, one.CloudVpcID)
+	}
+
+	vpcMap, err := cli.getVpcMap(kt, accountID, slice.Unique(cloudVpcIDs))
+	if err != nil {
+		return err
+	}
+
+	lbs := make([]protolb.LoadBalancerBatchUpdate[corelb.GcpLoadBalancerExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		lb := protolb.LoadBalancerBatchUpdate[corelb.GcpLoadBalancerExtension]{
+			ID:                   id,
+			Name:                 one.Name,
+			Zones:                one.Zones,
+			LBType:               one.LBType,
+			Status:               one.Status,
+			CloudVpcID:           one.CloudVpcID,
+			Domain:               one.Domain,
+			PublicIPv4Addresses:  one.PublicIPv4Addresses,
+			PrivateIPv4Addresses: one.PrivateIPv4Addresses,
+			Memo:                 one.Memo,
+			Extension:            one.Extension,
+		}
+
+		if vpc, exist := vpcMap[one.CloudVpcID]; exist {
+			lb.VpcID = vpc.VpcID
+		}
+
+		lbs = append(lbs, lb)
+	}
+
+	for _, part := range slice.Split(lbs, constant.BatchOperationMaxLimit) {
+		updateReq := &protolb.LoadBalancerBatchUpdateReq[corelb.GcpLoadBalancerExtension]{LoadBalancers: part}
+		if err = cli.dbCli.Gcp.LoadBalancer.BatchUpdateLoadBalancer(kt.Ctx, kt.Header(), updateReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch update load balancer failed, err: %v, rid: %s",
+				enumor.Gcp, err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync load balancer to update load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.Gcp, accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createLoadBalancer(kt *kit.Kit, accountID string,
+	addSlice []typelb.GcpLoadBalancer, bizID int64) ([]string, error) {
+
+	if len(addSlice) == 0 {
+		return nil, fmt.Errorf("create load balancer, load balancers is required")
+	}
+
+	if bizID == 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	cloudVpcIDs := make([]string, 0, len(addSlice))
+	for _, one := range addSlice {
+		cloudVpcIDs = append(cloudVpcIDs, one.CloudVpcID)
+	}
+
+	vpcMap, err := cli.getVpcMap(kt, accountID, slice.Unique(cloudVpcIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	lbs := make([]protolb.LoadBalancerBatchCreate[corelb.GcpLoadBalancerExtension], 0, len(addSlice))
+	for _, one := range addSlice {
+		lb := protolb.LoadBalancerBatchCreate[corelb.GcpLoadBalancerExtension]{
+			CloudID:              one.CloudID,
+			Name:                 one.Name,
+			AccountID:            accountID,
+			BkBizID:              bizID,
+			Region:               one.Region,
+			Zones:                one.Zones,
+			LBType:               one.LBType,
+			Status:               one.Status,
+			CloudVpcID:           one.CloudVpcID,
+			Domain:               one.Domain,
+			PublicIPv4Addresses:  one.PublicIPv4Addresses,
+			PrivateIPv4Addresses: one.PrivateIPv4Addresses,
+			Memo:                 one.Memo,
+			CloudCreatedTime:     one.CloudCreatedTime,
+			Extension:            one.Extension,
+		}
+
+		if vpc, exist := vpcMap[one.CloudVpcID]; exist {
+			lb.VpcID = vpc.VpcID
+		}
+
+		lbs = append(lbs, lb)
+	}
+
+	createdIDs := make([]string, 0, len(addSlice))
+	for _, part := range slice.Split(lbs, constant.BatchOperationMaxLimit) {
+		createReq := &protolb.LoadBalancerBatchCreateReq[corelb.GcpLoadBalancerExtension]{LoadBalancers: part}
+		result, err := cli.dbCli.Gcp.LoadBalancer.BatchCreateLoadBalancer(kt.Ctx, kt.Header(), createReq)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to batch create load balancer failed, err: %v, rid: %s",
+				enumor.Gcp, err, kt.Rid)
+			return nil, err
+		}
+		createdIDs = append(createdIDs, result.IDs...)
+	}
+
+	logs.Infof("[%s] sync load balancer to create load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.Gcp, accountID, len(addSlice), kt.Rid)
+
+	return createdIDs, nil
+}
+
+func (cli *client) listLoadBalancerFromCloud(kt *kit.Kit, params *SyncBaseParams, region string) (
+	[]typelb.GcpLoadBalancer, error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typelb.GcpListOption{
+		Region:   region,
+		CloudIDs: params.CloudIDs,
+		Page: &adcore.GcpPage{
+			PageSize: adcore.GcpQueryLimit,
+		},
+	}
+	result, _, err := cli.cloudCli.ListLoadBalancer(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list load balancer from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+			enumor.Gcp, err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listLoadBalancerFromDB(kt *kit.Kit, params *SyncBaseParams, region string) (
+	[]corelb.LoadBalancer[corelb.GcpLoadBalancerExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Gcp.LoadBalancer.ListLoadBalancerExt(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list load balancer from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.Gcp, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isLoadBalancerChange(cloud typelb.GcpLoadBalancer,
+	db corelb.LoadBalancer[corelb.GcpLoadBalancerExtension]) bool {
+
+	if cloud.Name != db.Name || cloud.LBType != db.LBType || cloud.Status != db.Status ||
+		cloud.CloudVpcID != db.CloudVpcID || cloud.Domain != db.Domain {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.Zones, db.Zones) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.PublicIPv4Addresses, db.PublicIPv4Addresses) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.PrivateIPv4Addresses, db.PrivateIPv4Addresses) {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(cloud.Memo, db.Memo) {
+		return true
+	}
+
+	return !assert.IsJsonEqual(cloud.Extension, db.Extension)
+}
+
+// syncLoadBalancerListener 同步负载均衡的监听器及后端目标，云上监听器查询需要指定负载均衡，所以逐个负载均衡同步
+func (cli *client) syncLoadBalancerListener(kt *kit.Kit, params *SyncBaseParams, region string) error {
+	lbFromDB, err := cli.listLoadBalancerFromDB(kt, params, region)
+	if err != nil {
+		return err
+	}
+
+	for _, lb := range lbFromDB {
+		listenerFromCloud, err := cli.cloudCli.ListLoadBalancerListener(kt, &typelb.ListenerListOption{
+			Region:    lb.Region,
+			CloudLbID: lb.CloudID,
+		})
+		if err != nil {
+			logs.Errorf("[%s] list load balancer listener from cloud failed, err: %v, lb: %s, rid: %s",
+				enumor.Gcp, err, lb.CloudID, kt.Rid)
+			return err
+		}
+
+		if err = cli.syncListener(kt, &lb.BaseLoadBalancer, listenerFromCloud); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) syncListener(kt *kit.Kit, lb *corelb.BaseLoadBalancer,
+	listenerFromCloud []typelb.GcpListener) error {
+
+	listenerFromDB, err := cli.listListenerFromDB(kt, lb.ID)
+	if err != nil {
+		return err
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typelb.GcpListener,
+		corelb.Listener[corelb.GcpListenerExtension]](listenerFromCloud, listenerFromDB, isListenerChange)
+
+	if common.ReportDiff(kt, enumor.LBListenerCloudResType, addSlice, updateMap, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		deleteReq := &protolb.ListenerBatchDeleteReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "lb_id", Op: filter.Equal.Factory(), Value: lb.ID},
+					&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: delCloudIDs},
+				},
+			},
+		}
+		if err = cli.dbCli.Global.LoadBalancer.BatchDeleteListener(kt.Ctx, kt.Header(), deleteReq); err != nil {
+			logs.Errorf("[%s] request dataservice to delete listener failed, err: %v, lb: %s, rid: %s",
+				enumor.Gcp, err, lb.ID, kt.Rid)
+			return err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		listeners := make([]protolb.ListenerBatchCreate[corelb.GcpListenerExtension], 0, len(addSlice))
+		for _, one := range addSlice {
+			listeners = append(listeners, protolb.ListenerBatchCreate[corelb.GcpListenerExtension]{
+				CloudID:   one.CloudID,
+				Name:      one.Name,
+				AccountID: lb.AccountID,
+				LbID:      lb.ID,
+				CloudLbID: lb.CloudID,
+				Protocol:  one.Protocol,
+				Port:      one.Port,
+				EndPort:   one.EndPort,
+				Extension: one.Extension,
+			})
+		}
+
+		for _, part := range slice.Split(listeners, constant.BatchOperationMaxLimit) {
+			createReq := &protolb.ListenerBatchCreateReq[corelb.GcpListenerExtension]{Listeners: part}
+			if _, err = cli.dbCli.Gcp.LoadBalancer.BatchCreateListener(kt.Ctx, kt.Header(), createReq); err != nil {
+				logs.Errorf("[%s] request dataservice to create listener failed, err: %v, lb: %s, rid: %s",
+					enumor.Gcp, err, lb.ID, kt.Rid)
+				return err
+			}
+		}
+	}
+
+	if len(updateMap) > 0 {
+		listeners := make([]protolb.ListenerBatchUpdate[corelb.GcpListenerExtension], 0, len(updateMap))
+		for id, one := range updateMap {
+			listeners = append(listeners, protolb.ListenerBatchUpdate[corelb.GcpListenerExtension]{
+				ID:        id,
+				Name:      one.Name,
+				Protocol:  one.Protocol,
+				Port:      one.Port,
+				EndPort:   one.EndPort,
+				Extension: one.Extension,
+			})
+		}
+
+		for _, part := range slice.Split(listeners, constant.BatchOperationMaxLimit) {
+			updateReq := &protolb.ListenerBatchUpdateReq[corelb.GcpListenerExtension]{Listeners: part}
+			if err = cli.dbCli.Gcp.LoadBalancer.BatchUpdateListener(kt.Ctx, kt.Header(), updateReq); err != nil {
+				logs.Errorf("[%s] request dataservice to update listener failed, err: %v, lb: %s, rid: %s",
+					enumor.Gcp, err, lb.ID, kt.Rid)
+				return err
+			}
+		}
+	}
+
+	if len(listenerFromCloud) == 0 {
+		return nil
+	}
+
+	// 新增的监听器需要重新查询获取ID，再同步监听器的后端目标
+	if len(addSlice) > 0 {
+		if listenerFromDB, err = cli.listListenerFromDB(kt, lb.ID); err != nil {
+			return err
+		}
+	}
+
+	listenerMap := make(map[string]corelb.Listener[corelb.GcpListenerExtension], len(listenerFromDB))
+	for _, one := range listenerFromDB {
+		listenerMap[one.CloudID] = one
+	}
+
+	for _, one := range listenerFromCloud {
+		listener, exist := listenerMap[one.CloudID]
+		if !exist {
+			continue
+		}
+
+		if err = cli.syncTarget(kt, &listener.BaseListener, one.Targets); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) syncTarget(kt *kit.Kit, listener *corelb.BaseListener, targetFromCloud []*typelb.GcpTarget) error {
+	targetFromDB, err := cli.listTargetFromDB(kt, listener.ID)
+	if err != nil {
+		return err
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[*typelb.GcpTarget,
+		corelb.Target[corelb.GcpTargetExtension]](targetFromCloud, targetFromDB, isTargetChange)
+
+	if common.ReportDiff(kt, enumor.LBTargetCloudResType, addSlice, updateMap, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		deleteReq := &protolb.TargetBatchDeleteReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "listener_id", Op: filter.Equal.Factory(), Value: listener.ID},
+					&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: delCloudIDs},
+				},
+			},
+		}
+		if err = cli.dbCli.Global.LoadBalancer.BatchDeleteTarget(kt.Ctx, kt.Header(), deleteReq); err != nil {
+			logs.Errorf("[%s] request dataservice to delete target failed, err: %v, listener: %s, rid: %s",
+				enumor.Gcp, err, listener.ID, kt.Rid)
+			return err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		targets := make([]protolb.TargetBatchCreate[corelb.GcpTargetExtension], 0, len(addSlice))
+		for _, one := range addSlice {
+			targets = append(targets, protolb.TargetBatchCreate[corelb.GcpTargetExtension]{
+				CloudID:         one.GetCloudID(),
+				AccountID:       listener.AccountID,
+				LbID:            listener.LbID,
+				ListenerID:      listener.ID,
+				CloudListenerID: listener.CloudID,
+				TargetType:      one.TargetType,
+				CloudTargetID:   one.CloudTargetID,
+				IP:              one.IP,
+				Port:            one.Port,
+				Weight:          one.Weight,
+				Extension:       one.Extension,
+			})
+		}
+
+		for _, part := range slice.Split(targets, constant.BatchOperationMaxLimit) {
+			createReq := &protolb.TargetBatchCreateReq[corelb.GcpTargetExtension]{Targets: part}
+			if _, err = cli.dbCli.Gcp.LoadBalancer.BatchCreateTarget(kt.Ctx, kt.Header(), createReq); err != nil {
+				logs.Errorf("[%s] request dataservice to create target failed, err: %v, listener: %s, rid: %s",
+					enumor.Gcp, err, listener.ID, kt.Rid)
+				return err
+			}
+		}
+	}
+
+	if len(updateMap) > 0 {
+		targets := make([]protolb.TargetBatchUpdate[corelb.GcpTargetExtension], 0, len(updateMap))
+		for id, one := range updateMap {
+			targets = append(targets, protolb.TargetBatchUpdate[corelb.GcpTargetExtension]{
+				ID:        id,
+				IP:        one.IP,
+				Weight:    one.Weight,
+				Extension: one.Extension,
+			})
+		}
+
+		for _, part := range slice.Split(targets, constant.BatchOperationMaxLimit) {
+			updateReq := &protolb.TargetBatchUpdateReq[corelb.GcpTargetExtension]{Targets: part}
+			if err = cli.dbCli.Gcp.LoadBalancer.BatchUpdateTarget(kt.Ctx, kt.Header(), updateReq); err != nil {
+				logs.Errorf("[%s] request dataservice to update target failed, err: %v, listener: %s, rid: %s",
+					enumor.Gcp, err, listener.ID, kt.Rid)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) listListenerFromDB(kt *kit.Kit, lbID string) ([]corelb.Listener[corelb.GcpListenerExtension],
+	error) {
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("lb_id", lbID),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	listeners := make([]corelb.Listener[corelb.GcpListenerExtension], 0)
+	for {
+		result, err := cli.dbCli.Gcp.LoadBalancer.ListListenerExt(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] list listener from db failed, err: %v, lb: %s, rid: %s", enumor.Gcp, err, lbID,
+				kt.Rid)
+			return nil, err
+		}
+
+		listeners = append(listeners, result.Details...)
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return listeners, nil
+}
+
+func (cli *client) listTargetFromDB(kt *kit.Kit, listenerID string) ([]corelb.Target[corelb.GcpTargetExtension],
+	error) {
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("listener_id", listenerID),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	targets := make([]corelb.Target[corelb.GcpTargetExtension], 0)
+	for {
+		result, err := cli.dbCli.Gcp.LoadBalancer.ListTargetExt(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] list target from db failed, err: %v, listener: %s, rid: %s", enumor.Gcp, err,
+				listenerID, kt.Rid)
+			return nil, err
+		}
+
+		targets = append(targets, result.Details...)
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return targets, nil
+}
+
+func isListenerChange(cloud typelb.GcpListener, db corelb.Listener[corelb.GcpListenerExtension]) bool {
+	if cloud.Name != db.Name || cloud.Protocol != db.Protocol || cloud.Port != db.Port ||
+		cloud.EndPort != db.EndPort {
+		return true
+	}
+
+	return !assert.IsJsonEqual(cloud.Extension, db.Extension)
+}
+
+func isTargetChange(cloud *typelb.GcpTarget, db corelb.Target[corelb.GcpTargetExtension]) bool {
+	if cloud.IP != db.IP || cloud.Weight != db.Weight {
+		return true
+	}
+
+	return !assert.IsJsonEqual(cloud.Extension, db.Extension)
+}
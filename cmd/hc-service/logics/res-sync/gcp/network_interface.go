@@ -118,6 +118,10 @@ func (cli *client) syncNetworkInterface(kt *kit.Kit, opt *syncNIOption) (*SyncRe
 	addSlice, updateMap, delCloudIDs := common.Diff[typesni.GcpNI, coreni.
 		NetworkInterface[coreni.GcpNIExtension]](networkInterfaceFromCloud, networkInterfaceFromDB, isNIChange)
 
+	if common.ReportDiff(kt, enumor.NetworkInterfaceCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteNetworkInterface(kt, delCloudIDs, opt); err != nil {
 			return nil, err
@@ -136,6 +140,9 @@ func (cli *client) syncNetworkInterface(kt *kit.Kit, opt *syncNIOption) (*SyncRe
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Gcp, AccountID: opt.AccountID,
+		ResType: enumor.NetworkInterfaceCloudResType}, networkInterfaceFromDB, addSlice, updateMap, delCloudIDs)
+
 	return nil, nil
 }
 
@@ -74,6 +74,10 @@ func (cli *client) Route(kt *kit.Kit, params *SyncBaseParams, opt *SyncRouteOpti
 	addSlice, updateMap, delCloudIDs := common.Diff[typesroutetable.GcpRoute, cloudcoreroutetable.GcpRoute](
 		routeFromCloud, routeFromDB, isRouteChange)
 
+	if common.ReportDiff(kt, enumor.RouteCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteRoute(kt, params.AccountID, opt.Zone, delCloudIDs, routeFromDB); err != nil {
 			return nil, err
@@ -92,6 +96,9 @@ func (cli *client) Route(kt *kit.Kit, params *SyncBaseParams, opt *SyncRouteOpti
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Gcp, AccountID: params.AccountID,
+		ResType: enumor.RouteCloudResType}, routeFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -159,6 +166,10 @@ func (cli *client) updateRoute(kt *kit.Kit, accountID string,
 func (cli *client) deleteRoute(kt *kit.Kit, accountID string, zone string, delCloudIDs []string,
 	routeFromDB []cloudcoreroutetable.GcpRoute) error {
 
+	if common.ReportDiffCloudIDs(kt, enumor.RouteCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("route delCloudIDs is <= 0, not delete")
 	}
@@ -74,6 +74,10 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 	addSubnet, updateMap, delCloudIDs := common.Diff[adtysubnet.GcpSubnet, cloudcore.Subnet[cloudcore.GcpSubnetExtension]](
 		subnetFromCloud, subnetFromDB, isGcpSubnetChange)
 
+	if common.ReportDiff(kt, enumor.SubnetCloudResType, addSubnet, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteSubnet(kt, params.AccountID, opt.Region, delCloudIDs); err != nil {
 			return nil, err
@@ -92,6 +96,9 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Gcp, AccountID: params.AccountID,
+		ResType: enumor.SubnetCloudResType}, subnetFromDB, addSubnet, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -165,6 +172,10 @@ func (cli *client) RemoveSubnetDeleteFromCloud(kt *kit.Kit, accountID, region st
 }
 
 func (cli *client) deleteSubnet(kt *kit.Kit, accountID, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.SubnetCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete subnet, cloudIDs is required")
 	}
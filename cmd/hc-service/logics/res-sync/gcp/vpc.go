@@ -73,6 +73,10 @@ func (cli *client) Vpc(kt *kit.Kit, params *SyncBaseParams, opt *SyncVpcOption)
 	addVpc, updateMap, delCloudIDs := common.Diff[types.GcpVpc, cloudcore.Vpc[cloudcore.GcpVpcExtension]](
 		vpcFromCloud, vpcFromDB, isGcpVpcChange)
 
+	if common.ReportDiff(kt, enumor.VpcCloudResType, addVpc, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteVpc(kt, params.AccountID, delCloudIDs); err != nil {
 			return nil, err
@@ -91,6 +95,9 @@ func (cli *client) Vpc(kt *kit.Kit, params *SyncBaseParams, opt *SyncVpcOption)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.Gcp, AccountID: params.AccountID,
+		ResType: enumor.VpcCloudResType}, vpcFromDB, addVpc, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -163,6 +170,10 @@ func (cli *client) RemoveVpcDeleteFromCloud(kt *kit.Kit, accountID string) error
 }
 
 func (cli *client) deleteVpc(kt *kit.Kit, accountID string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.VpcCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete vpc, cloudIDs is required")
 	}
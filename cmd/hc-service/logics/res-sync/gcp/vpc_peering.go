@@ -0,0 +1,49 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// VpcPeering sync vpc peering of account, vpc peering is global resource, so region in params is ignored.
+func (cli *client) VpcPeering(kt *kit.Kit, params *common.SyncVpcPeeringParams) (*SyncResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	params.Region = ""
+
+	peerings, err := cli.cloudCli.ListVpcPeering(kt)
+	if err != nil {
+		logs.Errorf("[%s] list vpc peering from cloud failed, err: %v, account: %s, rid: %s", enumor.Gcp,
+			err, params.AccountID, kt.Rid)
+		return nil, err
+	}
+
+	if err = common.SyncVpcPeering(kt, cli.dbCli, enumor.Gcp, params, peerings); err != nil {
+		return nil, err
+	}
+
+	return new(SyncResult), nil
+}
@@ -82,6 +82,10 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 	addSlice, updateMap, delCloudIDs := common.Diff[typescvm.HuaWeiCvm, corecvm.Cvm[cvm.HuaWeiCvmExtension]](
 		cvmFromCloud, cvmFromDB, cli.isCvmChange)
 
+	if common.ReportDiff(kt, enumor.CvmCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteCvm(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
@@ -100,6 +104,9 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.HuaWei, AccountID: params.AccountID,
+		ResType: enumor.CvmCloudResType}, cvmFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -542,6 +549,10 @@ func (cli *client) getSubnets(kt *kit.Kit, accountID, region, serverID string,
 }
 
 func (cli *client) deleteCvm(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.CvmCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("cvm delCloudIDs is <= 0, not delete")
 	}
@@ -73,6 +73,10 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 	addSlice, updateMap, delCloudIDs := common.Diff[adaptordisk.HuaWeiDisk, *disk.DiskExtResult[disk.HuaWeiDiskExtensionResult]](
 		diskFromCloud, diskFromDB, isDiskChange)
 
+	if common.ReportDiff(kt, enumor.DiskCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteDisk(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
@@ -91,10 +95,17 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.HuaWei, AccountID: params.AccountID,
+		ResType: enumor.DiskCloudResType}, diskFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
 func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.DiskCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("delCloudIDs is <= 0, not delete")
 	}
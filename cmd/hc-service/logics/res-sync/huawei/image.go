@@ -280,6 +280,7 @@ func (cli *client) RemoveImageDeleteFromCloud(kt *kit.Kit, accountID, region str
 				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.HuaWei},
 				&filter.AtomRule{Field: "platform", Op: filter.Equal.Factory(), Value: platform.Value()},
 				&filter.AtomRule{Field: "extension.region", Op: filter.JSONEqual.Factory(), Value: region},
+				&filter.AtomRule{Field: "type", Op: filter.Equal.Factory(), Value: constant.PublicImageType},
 			},
 		},
 		Page: &core.BasePage{
@@ -114,6 +114,10 @@ func (cli *client) syncNetworkInterface(kt *kit.Kit, opt *syncNIOption) (*SyncRe
 	addSlice, updateMap, delCloudIDs := common.Diff[typesni.HuaWeiNI, coreni.
 		NetworkInterface[coreni.HuaWeiNIExtension]](networkInterfaceFromCloud, networkInterfaceFromDB, isNIChange)
 
+	if common.ReportDiff(kt, enumor.NetworkInterfaceCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteNetworkInterface(kt, delCloudIDs, opt); err != nil {
 			return nil, err
@@ -132,6 +136,9 @@ func (cli *client) syncNetworkInterface(kt *kit.Kit, opt *syncNIOption) (*SyncRe
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.HuaWei, AccountID: opt.AccountID,
+		ResType: enumor.NetworkInterfaceCloudResType}, networkInterfaceFromDB, addSlice, updateMap, delCloudIDs)
+
 	return nil, nil
 }
 
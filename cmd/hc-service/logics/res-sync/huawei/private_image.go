@@ -0,0 +1,351 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typesimage "hcm/pkg/adaptor/types/image"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud/image"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+)
+
+// SyncPrivateImageOption ...
+type SyncPrivateImageOption struct {
+}
+
+// Validate ...
+func (opt SyncPrivateImageOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// PrivateImage sync private image of account, private image is bound to the account it belongs to.
+func (cli *client) PrivateImage(kt *kit.Kit, params *SyncBaseParams, opt *SyncPrivateImageOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	imageFromCloud, err := cli.listPrivateImageFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	imageFromDB, err := cli.listPrivateImageFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(imageFromCloud) == 0 && len(imageFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typesimage.HuaWeiImage,
+		dataproto.ImageExtResult[dataproto.HuaWeiImageExtensionResult]](imageFromCloud, imageFromDB,
+		isPrivateImageChange)
+
+	if common.ReportDiff(kt, enumor.ImageCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deletePrivateImage(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createPrivateImage(kt, params.AccountID, params.Region, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updatePrivateImage(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.HuaWei, AccountID: params.AccountID,
+		ResType: enumor.ImageCloudResType}, imageFromDB, addSlice, updateMap, delCloudIDs)
+
+	return new(SyncResult), nil
+}
+
+// RemovePrivateImageDeleteFromCloud ...
+func (cli *client) RemovePrivateImageDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &dataproto.ImageListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.HuaWei},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "type", Op: filter.Equal.Factory(), Value: constant.PrivateImageType},
+				&filter.AtomRule{Field: "extension.region", Op: filter.JSONEqual.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.CloudResourceSyncMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.HuaWei.ListImage(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list private image failed, err: %v, req: %v, rid: %s",
+				enumor.HuaWei, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listPrivateImageFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.CloudID)
+			}
+
+			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if err = cli.deletePrivateImage(kt, accountID, region, delCloudIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.CloudResourceSyncMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.CloudResourceSyncMaxLimit
+	}
+
+	return nil
+}
+
+func (cli *client) createPrivateImage(kt *kit.Kit, accountID string, region string,
+	addSlice []typesimage.HuaWeiImage) error {
+
+	if len(addSlice) == 0 {
+		return fmt.Errorf("create private image, images is required")
+	}
+
+	createReq := make(dataproto.ImageExtBatchCreateReq[dataproto.HuaWeiImageExtensionCreateReq], 0, len(addSlice))
+	for _, one := range addSlice {
+		createReq = append(createReq, &dataproto.ImageExtCreateReq[dataproto.HuaWeiImageExtensionCreateReq]{
+			AccountID:    accountID,
+			CloudID:      one.CloudID,
+			Name:         one.Name,
+			Architecture: one.Architecture,
+			Platform:     one.Platform,
+			State:        one.State,
+			Type:         one.Type,
+			Extension: &dataproto.HuaWeiImageExtensionCreateReq{
+				Region: region,
+			},
+		})
+	}
+
+	_, err := cli.dbCli.HuaWei.BatchCreateImage(kt.Ctx, kt.Header(), &createReq)
+	if err != nil {
+		logs.Errorf("[%s] request dataservice to create private image failed, err: %v, rid: %s", enumor.HuaWei,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync private image to create image success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updatePrivateImage(kt *kit.Kit, accountID string,
+	updateMap map[string]typesimage.HuaWeiImage) error {
+
+	if len(updateMap) == 0 {
+		return fmt.Errorf("update private image, images is required")
+	}
+
+	updateReq := make(dataproto.ImageExtBatchUpdateReq[dataproto.HuaWeiImageExtensionUpdateReq], 0,
+		len(updateMap))
+	for id, one := range updateMap {
+		updateReq = append(updateReq, &dataproto.ImageExtUpdateReq[dataproto.HuaWeiImageExtensionUpdateReq]{
+			ID:    id,
+			Name:  one.Name,
+			State: one.State,
+		})
+	}
+
+	if _, err := cli.dbCli.HuaWei.BatchUpdateImage(kt.Ctx, kt.Header(), &updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice to update private image failed, err: %v, rid: %s", enumor.HuaWei,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync private image to update image success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) deletePrivateImage(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.ImageCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete private image, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delFromCloud, err := cli.listPrivateImageFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delFromCloud) > 0 {
+		logs.Errorf("[%s] validate private image not exist failed, before delete, opt: %v, failed_count: %d, "+
+			"rid: %s", enumor.HuaWei, checkParams, len(delFromCloud), kt.Rid)
+		return fmt.Errorf("validate private image not exist failed, before delete")
+	}
+
+	deleteReq := &dataproto.ImageDeleteReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: delCloudIDs},
+			},
+		},
+	}
+	if _, err = cli.dbCli.Global.DeleteImage(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to delete private image failed, err: %v, rid: %s", enumor.HuaWei,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync private image to delete image success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listPrivateImageFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typesimage.HuaWeiImage,
+	error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	results := make([]typesimage.HuaWeiImage, 0, len(params.CloudIDs))
+	for _, id := range params.CloudIDs {
+		opt := &typesimage.HuaWeiPrivateImageListOption{
+			Region:  params.Region,
+			CloudID: id,
+		}
+		result, err := cli.cloudCli.ListPrivateImage(kt, opt)
+		if err != nil {
+			logs.Errorf("[%s] list private image from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+				enumor.HuaWei, err, params.AccountID, opt, kt.Rid)
+			return nil, err
+		}
+		results = append(results, result.Details...)
+	}
+
+	return results, nil
+}
+
+func (cli *client) listPrivateImageFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]dataproto.ImageExtResult[dataproto.HuaWeiImageExtensionResult], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &dataproto.ImageListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.HuaWei},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+				&filter.AtomRule{Field: "extension.region", Op: filter.JSONEqual.Factory(), Value: params.Region},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	images, err := cli.dbCli.HuaWei.ListImage(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list private image from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.HuaWei, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	results := make([]dataproto.ImageExtResult[dataproto.HuaWeiImageExtensionResult], 0, len(images.Details))
+	for _, one := range images.Details {
+		results = append(results, converter.PtrToVal(one))
+	}
+
+	return results, nil
+}
+
+func isPrivateImageChange(cloud typesimage.HuaWeiImage,
+	db dataproto.ImageExtResult[dataproto.HuaWeiImageExtensionResult]) bool {
+
+	if cloud.Name != db.Name {
+		return true
+	}
+
+	if cloud.State != db.State {
+		return true
+	}
+
+	return false
+}
@@ -124,6 +124,10 @@ func (cli *client) route(kt *kit.Kit, opt *syncRouteOption) (*SyncResult, error)
 	addSlice, updateMap, delCloudIDs := common.Diff[typesroutetable.HuaWeiRoute,
 		routetable.HuaWeiRoute](routeFromCloud, routeFromDB, isRouteChange)
 
+	if common.ReportDiff(kt, enumor.RouteCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteRoute(kt, opt.AccountID, opt.Region, opt.CloudRouteTableID, routeTable.ID, delCloudIDs,
 			routeFromDB); err != nil {
@@ -146,6 +150,9 @@ func (cli *client) route(kt *kit.Kit, opt *syncRouteOption) (*SyncResult, error)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.HuaWei, AccountID: opt.AccountID,
+		ResType: enumor.RouteCloudResType}, routeFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
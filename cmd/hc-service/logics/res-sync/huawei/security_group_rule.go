@@ -125,6 +125,10 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 	addSlice, updateMap, delCloudIDs := common.Diff[securitygrouprule.HuaWeiSGRule,
 		corecloud.HuaWeiSecurityGroupRule](sgRuleFromCloud, sgRuleFromDB, isSGRuleChange)
 
+	if common.ReportDiff(kt, enumor.SecurityGroupRuleCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		err := cli.deleteSGRule(kt, opt, delCloudIDs)
 		if err != nil {
@@ -146,6 +150,9 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.HuaWei, AccountID: opt.AccountID,
+		ResType: enumor.SecurityGroupRuleCloudResType}, sgRuleFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
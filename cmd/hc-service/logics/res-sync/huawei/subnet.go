@@ -73,6 +73,10 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 	addSubnet, updateMap, delCloudIDs := common.Diff[adtysubnet.HuaWeiSubnet,
 		cloudcore.Subnet[cloudcore.HuaWeiSubnetExtension]](subnetFromCloud, subnetFromDB, isHuaWeiSubnetChange)
 
+	if common.ReportDiff(kt, enumor.SubnetCloudResType, addSubnet, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteSubnet(kt, params.AccountID, params.Region, opt.CloudVpcID, delCloudIDs); err != nil {
 			return nil, err
@@ -91,6 +95,9 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.HuaWei, AccountID: params.AccountID,
+		ResType: enumor.SubnetCloudResType}, subnetFromDB, addSubnet, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -166,6 +173,10 @@ func (cli *client) RemoveSubnetDeleteFromCloud(kt *kit.Kit, accountID, region, c
 }
 
 func (cli *client) deleteSubnet(kt *kit.Kit, accountID, region, cloudVpcID string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.SubnetCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete subnet, cloudIDs is required")
 	}
@@ -0,0 +1,483 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	typescvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+const (
+	// cvmDeletedStatus nova 已删除的云服务器在删除完成前仍可能被查询到
+	cvmDeletedStatus = "DELETED"
+	// bootFromVolumeImageID 从云硬盘启动的云服务器没有镜像，使用该值占位
+	bootFromVolumeImageID = "-"
+)
+
+// SyncCvmOption ...
+type SyncCvmOption struct {
+}
+
+// Validate ...
+func (opt SyncCvmOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// Cvm sync cvm, relations between cvm and security group, disk are synced by CvmWithRelRes.
+func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvmFromCloud, err := cli.listCvmFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	cvmFromDB, err := cli.listCvmFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cvmFromCloud) == 0 && len(cvmFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typescvm.OpenStackCvm,
+		corecvm.Cvm[corecvm.OpenStackCvmExtension]](cvmFromCloud, cvmFromDB, isCvmChange)
+
+	if common.ReportDiff(kt, enumor.CvmCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteCvm(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createCvm(kt, params.AccountID, params.Region, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateCvm(kt, params.AccountID, params.Region, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.OpenStack, AccountID: params.AccountID,
+		ResType: enumor.CvmCloudResType}, cvmFromDB, addSlice, updateMap, delCloudIDs)
+
+	return new(SyncResult), nil
+}
+
+// RemoveCvmDeleteFromCloud ...
+func (cli *client) RemoveCvmDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	listDB := func(req *core.ListReq) ([]string, error) {
+		listReq := &protocloud.CvmListReq{Field: req.Fields, Filter: req.Filter, Page: req.Page}
+		result, err := cli.dbCli.Global.Cvm.ListCvm(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list cvm failed, err: %v, req: %v, rid: %s", enumor.OpenStack,
+				err, req, kt.Rid)
+			return nil, err
+		}
+
+		cloudIDs := make([]string, 0, len(result.Details))
+		for _, one := range result.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+		return cloudIDs, nil
+	}
+
+	listCloud := func(params *SyncBaseParams) ([]string, error) {
+		cvms, err := cli.listCvmFromCloud(kt, params)
+		if err != nil {
+			return nil, err
+		}
+
+		cloudIDs := make([]string, 0, len(cvms))
+		for _, one := range cvms {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+		return cloudIDs, nil
+	}
+
+	return removeDeleteFromCloud(kt, accountID, region, listDB, listCloud, func(delCloudIDs []string) error {
+		return cli.deleteCvm(kt, accountID, region, delCloudIDs)
+	})
+}
+
+// cvmNetwork network resource ids in hcm of cvm.
+type cvmNetwork struct {
+	vpcIDs    []string
+	subnetIDs []string
+	bkCloudID int64
+}
+
+// getCvmNetwork get vpc and subnet ids in hcm of cvms, returns map of cvm cloud id and network.
+func (cli *client) getCvmNetwork(kt *kit.Kit, accountID, region string, cvms []typescvm.OpenStackCvm) (
+	map[string]cvmNetwork, error) {
+
+	cloudVpcIDs, cloudSubnetIDs := make([]string, 0), make([]string, 0)
+	for _, one := range cvms {
+		cloudVpcIDs = append(cloudVpcIDs, one.CloudVpcIDs...)
+		cloudSubnetIDs = append(cloudSubnetIDs, one.CloudSubnetIDs...)
+	}
+
+	vpcMap := make(map[string]*common.VpcDB)
+	for _, parts := range slice.Split(slice.Unique(cloudVpcIDs), constant.CloudResourceSyncMaxLimit) {
+		vpcs, err := cli.listVpcFromDB(kt, &SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: parts})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vpc := range vpcs {
+			vpcMap[vpc.CloudID] = &common.VpcDB{VpcCloudID: vpc.CloudID, VpcID: vpc.ID, BkCloudID: vpc.BkCloudID}
+		}
+	}
+
+	subnetMap := make(map[string]string)
+	for _, parts := range slice.Split(slice.Unique(cloudSubnetIDs), constant.CloudResourceSyncMaxLimit) {
+		subnets, err := cli.listSubnetFromDB(kt, &SyncBaseParams{AccountID: accountID, Region: region,
+			CloudIDs: parts})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, subnet := range subnets {
+			subnetMap[subnet.CloudID] = subnet.ID
+		}
+	}
+
+	result := make(map[string]cvmNetwork, len(cvms))
+	for _, one := range cvms {
+		network := cvmNetwork{vpcIDs: make([]string, 0), subnetIDs: make([]string, 0),
+			bkCloudID: constant.UnbindBkCloudID}
+		for _, cloudVpcID := range one.CloudVpcIDs {
+			vpc, exist := vpcMap[cloudVpcID]
+			if !exist {
+				return nil, fmt.Errorf("vpc: %s of cvm: %s not found in db", cloudVpcID, one.CloudID)
+			}
+
+			network.vpcIDs = append(network.vpcIDs, vpc.VpcID)
+			// 多网卡的云服务器以第一个vpc所属的管控区域为准
+			if len(network.vpcIDs) == 1 {
+				network.bkCloudID = vpc.BkCloudID
+			}
+		}
+
+		for _, cloudSubnetID := range one.CloudSubnetIDs {
+			subnetID, exist := subnetMap[cloudSubnetID]
+			if !exist {
+				return nil, fmt.Errorf("subnet: %s of cvm: %s not found in db", cloudSubnetID, one.CloudID)
+			}
+			network.subnetIDs = append(network.subnetIDs, subnetID)
+		}
+
+		result[one.CloudID] = network
+	}
+
+	return result, nil
+}
+
+func convCvmExtension(one typescvm.OpenStackCvm) *corecvm.OpenStackCvmExtension {
+	return &corecvm.OpenStackCvmExtension{
+		CloudFlavorID:         one.CloudFlavorID,
+		KeyName:               one.KeyName,
+		CloudHostID:           one.CloudHostID,
+		BootFromVolume:        len(one.CloudImageID) == 0,
+		CloudSecurityGroupIDs: one.CloudSecurityGroupIDs,
+		CloudVolumeIDs:        one.CloudVolumeIDs,
+		Metadata:              one.Metadata,
+	}
+}
+
+func cloudImageID(one typescvm.OpenStackCvm) string {
+	if len(one.CloudImageID) == 0 {
+		return bootFromVolumeImageID
+	}
+
+	return one.CloudImageID
+}
+
+func (cli *client) updateCvm(kt *kit.Kit, accountID, region string,
+	updateMap map[string]typescvm.OpenStackCvm) error {
+
+	if len(updateMap) <= 0 {
+		return fmt.Errorf("cvm updateMap is <= 0, not update")
+	}
+
+	cvms := make([]typescvm.OpenStackCvm, 0, len(updateMap))
+	for _, one := range updateMap {
+		cvms = append(cvms, one)
+	}
+	networkMap, err := cli.getCvmNetwork(kt, accountID, region, cvms)
+	if err != nil {
+		return err
+	}
+
+	lists := make([]protocloud.CvmBatchUpdate[corecvm.OpenStackCvmExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		network := networkMap[one.CloudID]
+		lists = append(lists, protocloud.CvmBatchUpdate[corecvm.OpenStackCvmExtension]{
+			ID:                   id,
+			Name:                 one.Name,
+			BkBizID:              constant.UnassignedBiz,
+			BkCloudID:            network.bkCloudID,
+			CloudVpcIDs:          one.CloudVpcIDs,
+			VpcIDs:               network.vpcIDs,
+			CloudSubnetIDs:       one.CloudSubnetIDs,
+			SubnetIDs:            network.subnetIDs,
+			CloudImageID:         cloudImageID(one),
+			Memo:                 converter.ValToPtr(one.Memo),
+			Status:               one.Status,
+			PrivateIPv4Addresses: one.PrivateIPv4Addresses,
+			PrivateIPv6Addresses: one.PrivateIPv6Addresses,
+			PublicIPv4Addresses:  one.PublicIPv4Addresses,
+			PublicIPv6Addresses:  one.PublicIPv6Addresses,
+			CloudLaunchedTime:    one.LaunchedAt,
+			Extension:            convCvmExtension(one),
+		})
+	}
+
+	updateReq := &protocloud.CvmBatchUpdateReq[corecvm.OpenStackCvmExtension]{
+		Cvms: lists,
+	}
+	if err = cli.dbCli.OpenStack.Cvm.BatchUpdateCvm(kt.Ctx, kt.Header(), updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice BatchUpdateCvm failed, err: %v, rid: %s", enumor.OpenStack, err,
+			kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cvm to update cvm success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createCvm(kt *kit.Kit, accountID, region string, addSlice []typescvm.OpenStackCvm) error {
+	if len(addSlice) <= 0 {
+		return fmt.Errorf("cvm addSlice is <= 0, not create")
+	}
+
+	networkMap, err := cli.getCvmNetwork(kt, accountID, region, addSlice)
+	if err != nil {
+		return err
+	}
+
+	lists := make([]protocloud.CvmBatchCreate[corecvm.OpenStackCvmExtension], 0, len(addSlice))
+	for _, one := range addSlice {
+		network := networkMap[one.CloudID]
+		lists = append(lists, protocloud.CvmBatchCreate[corecvm.OpenStackCvmExtension]{
+			CloudID:              one.CloudID,
+			Name:                 one.Name,
+			BkBizID:              constant.UnassignedBiz,
+			BkCloudID:            network.bkCloudID,
+			AccountID:            accountID,
+			Region:               region,
+			Zone:                 one.Zone,
+			CloudVpcIDs:          one.CloudVpcIDs,
+			VpcIDs:               network.vpcIDs,
+			CloudSubnetIDs:       one.CloudSubnetIDs,
+			SubnetIDs:            network.subnetIDs,
+			CloudImageID:         cloudImageID(one),
+			Memo:                 converter.ValToPtr(one.Memo),
+			Status:               one.Status,
+			PrivateIPv4Addresses: one.PrivateIPv4Addresses,
+			PrivateIPv6Addresses: one.PrivateIPv6Addresses,
+			PublicIPv4Addresses:  one.PublicIPv4Addresses,
+			PublicIPv6Addresses:  one.PublicIPv6Addresses,
+			MachineType:          one.FlavorName,
+			CloudCreatedTime:     one.CreatedAt,
+			CloudLaunchedTime:    one.LaunchedAt,
+			Extension:            convCvmExtension(one),
+		})
+	}
+
+	createReq := &protocloud.CvmBatchCreateReq[corecvm.OpenStackCvmExtension]{
+		Cvms: lists,
+	}
+	if _, err = cli.dbCli.OpenStack.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), createReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch create cvm failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cvm to create cvm success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) deleteCvm(kt *kit.Kit, accountID, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.CvmCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("cvm delCloudIDs is <= 0, not delete")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delCvmFromCloud, err := cli.listCvmFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delCvmFromCloud) > 0 {
+		logs.Errorf("[%s] validate cvm not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.OpenStack, checkParams, len(delCvmFromCloud), kt.Rid)
+		return fmt.Errorf("validate cvm not exist failed, before delete")
+	}
+
+	deleteReq := &protocloud.CvmBatchDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if err = cli.dbCli.Global.Cvm.BatchDeleteCvm(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete cvm failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync cvm to delete cvm success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+// listCvmFromCloud list cvm from cloud, cvm in deleted status is regarded as not existing.
+func (cli *client) listCvmFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typescvm.OpenStackCvm, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typescvm.OpenStackListOption{
+		OpenStackListOption: adcore.OpenStackListOption{
+			Region:   params.Region,
+			CloudIDs: params.CloudIDs,
+		},
+	}
+	result, err := cli.cloudCli.ListCvm(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list cvm from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	cvms := make([]typescvm.OpenStackCvm, 0, len(result))
+	for _, one := range result {
+		if one.Status != cvmDeletedStatus {
+			cvms = append(cvms, one)
+		}
+	}
+
+	return cvms, nil
+}
+
+func (cli *client) listCvmFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]corecvm.Cvm[corecvm.OpenStackCvmExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &protocloud.CvmListReq{
+		Filter: accountRegionCloudIDsFilter(params),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.OpenStack.Cvm.ListCvmExt(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list cvm from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isCvmChange(cloud typescvm.OpenStackCvm, db corecvm.Cvm[corecvm.OpenStackCvmExtension]) bool {
+	if db.Name != cloud.Name || db.Status != cloud.Status || db.CloudImageID != cloudImageID(cloud) {
+		return true
+	}
+
+	if converter.PtrToVal(db.Memo) != cloud.Memo || db.MachineType != cloud.FlavorName {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(db.CloudVpcIDs, cloud.CloudVpcIDs) ||
+		!assert.IsStringSliceEqual(db.CloudSubnetIDs, cloud.CloudSubnetIDs) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(db.PrivateIPv4Addresses, cloud.PrivateIPv4Addresses) ||
+		!assert.IsStringSliceEqual(db.PrivateIPv6Addresses, cloud.PrivateIPv6Addresses) ||
+		!assert.IsStringSliceEqual(db.PublicIPv4Addresses, cloud.PublicIPv4Addresses) ||
+		!assert.IsStringSliceEqual(db.PublicIPv6Addresses, cloud.PublicIPv6Addresses) {
+		return true
+	}
+
+	if db.CloudLaunchedTime != cloud.LaunchedAt {
+		return true
+	}
+
+	ext := db.Extension
+	if ext == nil {
+		return true
+	}
+
+	if ext.CloudFlavorID != cloud.CloudFlavorID || ext.KeyName != cloud.KeyName ||
+		ext.CloudHostID != cloud.CloudHostID {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(ext.CloudSecurityGroupIDs, cloud.CloudSecurityGroupIDs) ||
+		!assert.IsStringSliceEqual(ext.CloudVolumeIDs, cloud.CloudVolumeIDs) {
+		return true
+	}
+
+	if !assert.IsStringMapEqual(ext.Metadata, cloud.Metadata) {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"testing"
+
+	typescvm "hcm/pkg/adaptor/types/cvm"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	"hcm/pkg/tools/converter"
+)
+
+func TestCloudImageID(t *testing.T) {
+	if id := cloudImageID(typescvm.OpenStackCvm{CloudImageID: "image-1"}); id != "image-1" {
+		t.Errorf("cloud image id %s is not as expected image-1", id)
+	}
+
+	// cvm boot from volume has no image, a placeholder is used.
+	if id := cloudImageID(typescvm.OpenStackCvm{}); id != bootFromVolumeImageID {
+		t.Errorf("cloud image id %s is not as expected %s", id, bootFromVolumeImageID)
+	}
+
+	if !convCvmExtension(typescvm.OpenStackCvm{}).BootFromVolume {
+		t.Errorf("cvm without image should be boot from volume")
+	}
+}
+
+func TestIsCvmChange(t *testing.T) {
+	cloud := typescvm.OpenStackCvm{CloudID: "cvm-1", Name: "cvm", Status: "ACTIVE", CloudFlavorID: "flavor-1",
+		FlavorName: "m1.small", CloudImageID: "image-1", KeyName: "key", CloudHostID: "host", Memo: "memo",
+		CloudVpcIDs: []string{"net-1"}, CloudSubnetIDs: []string{"subnet-1"},
+		CloudSecurityGroupIDs: []string{"sg-1"}, CloudVolumeIDs: []string{"vol-1"},
+		PrivateIPv4Addresses: []string{"10.0.0.2"}, PublicIPv4Addresses: []string{"1.1.1.1"},
+		Metadata: map[string]string{"k": "v"}, LaunchedAt: "2024-01-01T00:00:00"}
+
+	type dbCvm = corecvm.Cvm[corecvm.OpenStackCvmExtension]
+	newDB := func() dbCvm {
+		return dbCvm{
+			BaseCvm: corecvm.BaseCvm{ID: "1", CloudID: "cvm-1", Name: "cvm", Status: "ACTIVE",
+				CloudImageID: "image-1", Memo: converter.ValToPtr("memo"), MachineType: "m1.small",
+				CloudVpcIDs: []string{"net-1"}, CloudSubnetIDs: []string{"subnet-1"},
+				PrivateIPv4Addresses: []string{"10.0.0.2"}, PublicIPv4Addresses: []string{"1.1.1.1"},
+				CloudLaunchedTime: "2024-01-01T00:00:00"},
+			Extension: convCvmExtension(cloud),
+		}
+	}
+
+	if db := newDB(); isCvmChange(cloud, db) {
+		t.Errorf("cvm should not be changed, cloud: %v, db: %v", cloud, db)
+	}
+
+	changes := map[string]func(db *dbCvm){
+		"status":         func(db *dbCvm) { db.Status = "SHUTOFF" },
+		"image":          func(db *dbCvm) { db.CloudImageID = "image-2" },
+		"subnet":         func(db *dbCvm) { db.CloudSubnetIDs = nil },
+		"public ip":      func(db *dbCvm) { db.PublicIPv4Addresses = nil },
+		"nil extension":  func(db *dbCvm) { db.Extension = nil },
+		"security group": func(db *dbCvm) { db.Extension.CloudSecurityGroupIDs = nil },
+		"volume":         func(db *dbCvm) { db.Extension.CloudVolumeIDs = nil },
+		"metadata":       func(db *dbCvm) { db.Extension.Metadata = nil },
+	}
+	for name, change := range changes {
+		db := newDB()
+		change(&db)
+		if !isCvmChange(cloud, db) {
+			t.Errorf("cvm should be changed when %s is different", name)
+		}
+	}
+}
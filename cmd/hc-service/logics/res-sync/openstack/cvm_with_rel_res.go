@@ -0,0 +1,187 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	cvmrelmgr "hcm/cmd/hc-service/logics/res-sync/cvm-rel-manager"
+	typescvm "hcm/pkg/adaptor/types/cvm"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// SyncCvmWithRelResOption ...
+type SyncCvmWithRelResOption struct {
+}
+
+// Validate ...
+func (opt SyncCvmWithRelResOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// CvmWithRelRes ...
+/*
+	同步流程：
+		step1: 如果cvm全部不存在，仅同步主机即可，有可能主机被从云上删除
+		step2: 获取cvm和关联资源的关联关系
+		step3: sync vpc
+		step4: sync subnet
+		step5: sync security group
+		step6: sync disk
+		step7: sync cvm
+		step8: sync cvm_sg_rel
+		step9: sync cvm_disk_rel
+*/
+func (cli *client) CvmWithRelRes(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmWithRelResOption) (
+	*SyncResult, error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvmFromCloud, err := cli.listCvmFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// step1: 如果cvm全部不存在，仅同步主机即可，有可能主机被从云上删除
+	if len(cvmFromCloud) == 0 {
+		if _, err = cli.Cvm(kt, params, new(SyncCvmOption)); err != nil {
+			return nil, err
+		}
+
+		return new(SyncResult), nil
+	}
+
+	// step2: 获取cvm和关联资源的关联关系
+	mgr, err := buildCvmRelManager(cli.dbCli, cvmFromCloud)
+	if err != nil {
+		logs.Errorf("[%s] build cvm rel manager failed, err: %v, rid: %s", enumor.OpenStack, err, kt.Rid)
+		return nil, err
+	}
+
+	assResParams := func(cloudIDs []string) *SyncBaseParams {
+		return &SyncBaseParams{AccountID: params.AccountID, Region: params.Region, CloudIDs: cloudIDs}
+	}
+
+	// step3: sync vpc
+	if err = mgr.Sync(kt, enumor.VpcCloudResType, func(kt *kit.Kit, cloudIDs []string) error {
+		_, err := cli.Vpc(kt, assResParams(cloudIDs), new(SyncVpcOption))
+		return err
+	}); err != nil {
+		logs.Errorf("[%s] sync cvm associate vpc failed, err: %v, rid: %s", enumor.OpenStack, err, kt.Rid)
+		return nil, err
+	}
+
+	// step4: sync subnet
+	if err = mgr.Sync(kt, enumor.SubnetCloudResType, func(kt *kit.Kit, cloudIDs []string) error {
+		_, err := cli.Subnet(kt, assResParams(cloudIDs), new(SyncSubnetOption))
+		return err
+	}); err != nil {
+		logs.Errorf("[%s] sync cvm associate subnet failed, err: %v, rid: %s", enumor.OpenStack, err, kt.Rid)
+		return nil, err
+	}
+
+	// step5: sync security group
+	if err = mgr.Sync(kt, enumor.SecurityGroupCloudResType, func(kt *kit.Kit, cloudIDs []string) error {
+		_, err := cli.SecurityGroup(kt, assResParams(cloudIDs), new(SyncSGOption))
+		return err
+	}); err != nil {
+		logs.Errorf("[%s] sync cvm associate security group failed, err: %v, rid: %s", enumor.OpenStack, err,
+			kt.Rid)
+		return nil, err
+	}
+
+	// step6: sync disk
+	if err = mgr.Sync(kt, enumor.DiskCloudResType, func(kt *kit.Kit, cloudIDs []string) error {
+		_, err := cli.Disk(kt, assResParams(cloudIDs), new(SyncDiskOption))
+		return err
+	}); err != nil {
+		logs.Errorf("[%s] sync cvm associate disk failed, err: %v, rid: %s", enumor.OpenStack, err, kt.Rid)
+		return nil, err
+	}
+
+	// step7: sync cvm
+	if err = mgr.Sync(kt, enumor.CvmCloudResType, func(kt *kit.Kit, cloudIDs []string) error {
+		_, err := cli.Cvm(kt, assResParams(cloudIDs), new(SyncCvmOption))
+		return err
+	}); err != nil {
+		logs.Errorf("[%s] sync cvm failed, err: %v, rid: %s", enumor.OpenStack, err, kt.Rid)
+		return nil, err
+	}
+
+	syncRelOpt := &cvmrelmgr.SyncRelOption{
+		Vendor: enumor.OpenStack,
+	}
+
+	// step8: sync cvm_sg_rel
+	syncRelOpt.ResType = enumor.SecurityGroupCloudResType
+	if err = mgr.SyncRel(kt, syncRelOpt); err != nil {
+		logs.Errorf("[%s] sync cvm_securityGroup_rel failed, err: %v, rid: %s", enumor.OpenStack, err, kt.Rid)
+		return nil, err
+	}
+
+	// step9: sync cvm_disk_rel
+	syncRelOpt.ResType = enumor.DiskCloudResType
+	if err = mgr.SyncRel(kt, syncRelOpt); err != nil {
+		logs.Errorf("[%s] sync cvm_disk_rel failed, err: %v, rid: %s", enumor.OpenStack, err, kt.Rid)
+		return nil, err
+	}
+
+	return new(SyncResult), nil
+}
+
+// buildCvmRelManager 云服务器的安全组、网络和子网来自绑定的 neutron port，云硬盘来自挂载的卷
+func buildCvmRelManager(dbCli *dataservice.Client, cvmFromCloud []typescvm.OpenStackCvm) (
+	*cvmrelmgr.CvmRelManger, error) {
+
+	if len(cvmFromCloud) == 0 {
+		return nil, fmt.Errorf("cvms that from cloud is required")
+	}
+
+	mgr := cvmrelmgr.NewCvmRelManager(dbCli)
+	for _, cvm := range cvmFromCloud {
+		// 没有挂载云硬盘、未绑定安全组的主机也需要同步，以便清理已解除的关联关系
+		mgr.AddCvm(cvm.CloudID)
+
+		for _, cloudVpcID := range cvm.CloudVpcIDs {
+			mgr.CvmAppendAssResCloudID(cvm.CloudID, enumor.VpcCloudResType, cloudVpcID)
+		}
+
+		for _, cloudSubnetID := range cvm.CloudSubnetIDs {
+			mgr.CvmAppendAssResCloudID(cvm.CloudID, enumor.SubnetCloudResType, cloudSubnetID)
+		}
+
+		for _, cloudSGID := range cvm.CloudSecurityGroupIDs {
+			mgr.CvmAppendAssResCloudID(cvm.CloudID, enumor.SecurityGroupCloudResType, cloudSGID)
+		}
+
+		for _, cloudVolumeID := range cvm.CloudVolumeIDs {
+			mgr.CvmAppendAssResCloudID(cvm.CloudID, enumor.DiskCloudResType, cloudVolumeID)
+		}
+	}
+
+	return mgr, nil
+}
@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	typescvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/slice"
+)
+
+func TestBuildCvmRelManager(t *testing.T) {
+	cvms := []typescvm.OpenStackCvm{
+		{CloudID: "cvm-1", CloudVpcIDs: []string{"net-1"}, CloudSubnetIDs: []string{"subnet-1"},
+			CloudSecurityGroupIDs: []string{"sg-1", "sg-2"}, CloudVolumeIDs: []string{"vol-1", "vol-2"}},
+		{CloudID: "cvm-2", CloudVpcIDs: []string{"net-1"}, CloudSubnetIDs: []string{"subnet-2"},
+			CloudSecurityGroupIDs: []string{"sg-1"}},
+		// cvm without port and volume should also be synced to clean its relations.
+		{CloudID: "cvm-3"},
+	}
+
+	mgr, err := buildCvmRelManager(nil, cvms)
+	if err != nil {
+		t.Errorf("build cvm rel manager failed, err: %v", err)
+		return
+	}
+
+	expects := map[enumor.CloudResourceType][]string{
+		enumor.VpcCloudResType:           {"net-1"},
+		enumor.SubnetCloudResType:        {"subnet-1", "subnet-2"},
+		enumor.SecurityGroupCloudResType: {"sg-1", "sg-2"},
+		enumor.DiskCloudResType:          {"vol-1", "vol-2"},
+		enumor.CvmCloudResType:           {"cvm-1", "cvm-2", "cvm-3"},
+	}
+	for resType, expect := range expects {
+		got := make([]string, 0)
+		err = mgr.Sync(kit.New(), resType, func(kt *kit.Kit, cloudIDs []string) error {
+			got = append(got, cloudIDs...)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("sync %s failed, err: %v", resType, err)
+			continue
+		}
+
+		got = slice.Unique(got)
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, expect) {
+			t.Errorf("sync %s, expect cloud ids: %v, got: %v", resType, expect, got)
+		}
+	}
+
+	if _, err = buildCvmRelManager(nil, nil); err == nil {
+		t.Errorf("build cvm rel manager without cvm should be failed")
+	}
+}
@@ -0,0 +1,335 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	adaptordisk "hcm/pkg/adaptor/types/disk"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/data-service/cloud/disk"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+)
+
+// SyncDiskOption ...
+type SyncDiskOption struct {
+}
+
+// Validate ...
+func (opt SyncDiskOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// Disk ...
+func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	diskFromCloud, err := cli.listDiskFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	diskFromDB, err := cli.listDiskFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(diskFromCloud) == 0 && len(diskFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[adaptordisk.OpenStackDisk,
+		*disk.DiskExtResult[disk.OpenStackDiskExtensionResult]](diskFromCloud, diskFromDB, isDiskChange)
+
+	if common.ReportDiff(kt, enumor.DiskCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteDisk(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createDisk(kt, params.AccountID, params.Region, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateDisk(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.OpenStack, AccountID: params.AccountID,
+		ResType: enumor.DiskCloudResType}, diskFromDB, addSlice, updateMap, delCloudIDs)
+
+	return new(SyncResult), nil
+}
+
+// RemoveDiskDeleteFromCloud ...
+func (cli *client) RemoveDiskDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	listDB := func(req *core.ListReq) ([]string, error) {
+		listReq := &disk.DiskListReq{Fields: req.Fields, Filter: req.Filter, Page: req.Page}
+		result, err := cli.dbCli.Global.ListDisk(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list disk failed, err: %v, req: %v, rid: %s", enumor.OpenStack,
+				err, req, kt.Rid)
+			return nil, err
+		}
+
+		cloudIDs := make([]string, 0, len(result.Details))
+		for _, one := range result.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+		return cloudIDs, nil
+	}
+
+	listCloud := func(params *SyncBaseParams) ([]string, error) {
+		disks, err := cli.listDiskFromCloud(kt, params)
+		if err != nil {
+			return nil, err
+		}
+
+		cloudIDs := make([]string, 0, len(disks))
+		for _, one := range disks {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+		return cloudIDs, nil
+	}
+
+	return removeDeleteFromCloud(kt, accountID, region, listDB, listCloud, func(delCloudIDs []string) error {
+		return cli.deleteDisk(kt, accountID, region, delCloudIDs)
+	})
+}
+
+func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.DiskCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("disk delCloudIDs is <= 0, not delete")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delDiskFromCloud, err := cli.listDiskFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delDiskFromCloud) > 0 {
+		logs.Errorf("[%s] validate disk not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.OpenStack, checkParams, len(delDiskFromCloud), kt.Rid)
+		return fmt.Errorf("validate disk not exist failed, before delete")
+	}
+
+	deleteReq := &disk.DiskDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if _, err = cli.dbCli.Global.DeleteDisk(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete disk failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync disk to delete disk success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func convDiskAttachment(one adaptordisk.OpenStackDisk) []*disk.OpenStackAttachment {
+	attachments := make([]*disk.OpenStackAttachment, 0, len(one.Attachments))
+	for _, v := range one.Attachments {
+		attachments = append(attachments, &disk.OpenStackAttachment{
+			AttachmentID:    v.AttachmentID,
+			CloudInstanceID: v.ServerID,
+			DeviceName:      v.Device,
+			AttachedAt:      v.AttachedAt,
+		})
+	}
+
+	return attachments
+}
+
+// isSystemDisk openstack 没有系统盘的概念，可启动且已挂载的云硬盘视为云服务器的系统盘
+func isSystemDisk(one adaptordisk.OpenStackDisk) bool {
+	return one.Bootable && len(one.Attachments) > 0
+}
+
+func (cli *client) updateDisk(kt *kit.Kit, accountID string, updateMap map[string]adaptordisk.OpenStackDisk) error {
+	if len(updateMap) <= 0 {
+		return fmt.Errorf("disk updateMap is <= 0, not update")
+	}
+
+	updateReq := make(disk.DiskExtBatchUpdateReq[disk.OpenStackDiskExtensionUpdateReq], 0, len(updateMap))
+	for id, one := range updateMap {
+		updateReq = append(updateReq, &disk.DiskExtUpdateReq[disk.OpenStackDiskExtensionUpdateReq]{
+			ID:           id,
+			Name:         one.Name,
+			Memo:         converter.ValToPtr(one.Description),
+			Status:       one.Status,
+			IsSystemDisk: converter.ValToPtr(isSystemDisk(one)),
+			Extension: &disk.OpenStackDiskExtensionUpdateReq{
+				Bootable:   converter.ValToPtr(one.Bootable),
+				Attachment: convDiskAttachment(one),
+			},
+		})
+	}
+
+	if _, err := cli.dbCli.OpenStack.BatchUpdateDisk(kt.Ctx, kt.Header(), &updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice BatchUpdateDisk failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync disk to update disk success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createDisk(kt *kit.Kit, accountID string, region string,
+	addSlice []adaptordisk.OpenStackDisk) error {
+
+	if len(addSlice) <= 0 {
+		return fmt.Errorf("disk addSlice is <= 0, not create")
+	}
+
+	createReq := make(disk.DiskExtBatchCreateReq[disk.OpenStackDiskExtensionCreateReq], 0, len(addSlice))
+	for _, one := range addSlice {
+		createReq = append(createReq, &disk.DiskExtCreateReq[disk.OpenStackDiskExtensionCreateReq]{
+			AccountID:    accountID,
+			Name:         one.Name,
+			CloudID:      one.CloudID,
+			Region:       region,
+			Zone:         one.Zone,
+			DiskSize:     one.Size,
+			DiskType:     one.VolumeType,
+			IsSystemDisk: isSystemDisk(one),
+			Status:       one.Status,
+			Memo:         converter.ValToPtr(one.Description),
+			Extension: &disk.OpenStackDiskExtensionCreateReq{
+				Bootable:    one.Bootable,
+				Encrypted:   one.Encrypted,
+				Multiattach: one.Multiattach,
+				Attachment:  convDiskAttachment(one),
+			},
+		})
+	}
+
+	if _, err := cli.dbCli.OpenStack.BatchCreateDisk(kt.Ctx, kt.Header(), &createReq); err != nil {
+		logs.Errorf("[%s] request dataservice to create disk failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync disk to create disk success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listDiskFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]adaptordisk.OpenStackDisk, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &adaptordisk.OpenStackDiskListOption{
+		OpenStackListOption: adcore.OpenStackListOption{
+			Region:   params.Region,
+			CloudIDs: params.CloudIDs,
+		},
+	}
+	result, err := cli.cloudCli.ListDisk(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list disk from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listDiskFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]*disk.DiskExtResult[disk.OpenStackDiskExtensionResult], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &disk.DiskListReq{
+		Filter: accountRegionCloudIDsFilter(params),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.OpenStack.ListDisk(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list disk from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isDiskChange(cloud adaptordisk.OpenStackDisk, db *disk.DiskExtResult[disk.OpenStackDiskExtensionResult]) bool {
+	if db.Name != cloud.Name || db.Status != cloud.Status {
+		return true
+	}
+
+	if converter.PtrToVal(db.Memo) != cloud.Description || db.IsSystemDisk != isSystemDisk(cloud) {
+		return true
+	}
+
+	if db.Extension == nil || db.Extension.Bootable != cloud.Bootable {
+		return true
+	}
+
+	if len(db.Extension.Attachment) != len(cloud.Attachments) {
+		return true
+	}
+
+	for idx, one := range cloud.Attachments {
+		dbAttach := db.Extension.Attachment[idx]
+		if dbAttach == nil || dbAttach.AttachmentID != one.AttachmentID ||
+			dbAttach.CloudInstanceID != one.ServerID || dbAttach.DeviceName != one.Device {
+			return true
+		}
+	}
+
+	return false
+}
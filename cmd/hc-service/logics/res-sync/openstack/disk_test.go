@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"testing"
+
+	adaptordisk "hcm/pkg/adaptor/types/disk"
+	"hcm/pkg/api/data-service/cloud/disk"
+)
+
+func TestIsDiskChange(t *testing.T) {
+	cloud := adaptordisk.OpenStackDisk{CloudID: "vol-1", Name: "vol", Status: "in-use", Bootable: true,
+		Attachments: []adaptordisk.OpenStackAttachment{{AttachmentID: "att-1", ServerID: "cvm-1", Device: "/dev/vda"}}}
+
+	type dbDisk = disk.DiskExtResult[disk.OpenStackDiskExtensionResult]
+	newDB := func() *dbDisk {
+		return &dbDisk{
+			ID: "1", CloudID: "vol-1", Name: "vol", Status: "in-use", IsSystemDisk: true,
+			Extension: &disk.OpenStackDiskExtensionResult{Bootable: true, Attachment: convDiskAttachment(cloud)},
+		}
+	}
+
+	if db := newDB(); isDiskChange(cloud, db) {
+		t.Errorf("disk should not be changed, cloud: %v, db: %v", cloud, db)
+	}
+
+	changes := map[string]func(db *dbDisk){
+		"status":      func(db *dbDisk) { db.Status = "available" },
+		"system disk": func(db *dbDisk) { db.IsSystemDisk = false },
+		"detached":    func(db *dbDisk) { db.Extension.Attachment = nil },
+		"attach to other cvm": func(db *dbDisk) {
+			db.Extension.Attachment[0].CloudInstanceID = "cvm-2"
+		},
+	}
+	for name, change := range changes {
+		db := newDB()
+		change(db)
+		if !isDiskChange(cloud, db) {
+			t.Errorf("disk should be changed when %s is different", name)
+		}
+	}
+}
+
+func TestIsSystemDisk(t *testing.T) {
+	attached := []adaptordisk.OpenStackAttachment{{AttachmentID: "att-1", ServerID: "cvm-1"}}
+
+	if !isSystemDisk(adaptordisk.OpenStackDisk{Bootable: true, Attachments: attached}) {
+		t.Errorf("bootable and attached disk should be system disk")
+	}
+
+	if isSystemDisk(adaptordisk.OpenStackDisk{Bootable: true}) {
+		t.Errorf("detached disk should not be system disk")
+	}
+
+	if isSystemDisk(adaptordisk.OpenStackDisk{Attachments: attached}) {
+		t.Errorf("not bootable disk should not be system disk")
+	}
+}
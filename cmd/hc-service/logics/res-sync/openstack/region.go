@@ -0,0 +1,229 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"errors"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typesregion "hcm/pkg/adaptor/types/region"
+	"hcm/pkg/api/core"
+	coreregion "hcm/pkg/api/core/cloud/region"
+	dataregion "hcm/pkg/api/data-service/cloud/region"
+	datazone "hcm/pkg/api/data-service/cloud/zone"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncRegionOption ...
+type SyncRegionOption struct {
+	AccountID string `json:"account_id" validate:"required"`
+}
+
+// Validate ...
+func (opt SyncRegionOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// Region sync regions of the account, openstack regions are defined by each deployment, so they are stored
+// by account, and zones of the deleted regions are deleted together.
+func (cli *client) Region(kt *kit.Kit, opt *SyncRegionOption) (*SyncResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	regionFromCloud, err := cli.listRegionFromCloud(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	regionFromDB, err := cli.listRegionFromDB(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(regionFromCloud) == 0 && len(regionFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, _, delCloudIDs := common.Diff[typesregion.OpenStackRegion, coreregion.OpenStackRegion](
+		regionFromCloud, regionFromDB, isRegionChange)
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteRegion(kt, opt, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createRegion(kt, opt, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+func (cli *client) createRegion(kt *kit.Kit, opt *SyncRegionOption, addSlice []typesregion.OpenStackRegion) error {
+	if len(addSlice) <= 0 {
+		return errors.New("region addSlice is <= 0, not create")
+	}
+
+	list := make([]dataregion.OpenStackRegionBatchCreate, 0, len(addSlice))
+	for _, one := range addSlice {
+		list = append(list, dataregion.OpenStackRegionBatchCreate{
+			AccountID: opt.AccountID,
+			RegionID:  one.RegionID,
+		})
+	}
+
+	createReq := &dataregion.OpenStackRegionBatchCreateReq{
+		Regions: list,
+	}
+	if _, err := cli.dbCli.OpenStack.Region.BatchCreateRegion(kt.Ctx, kt.Header(), createReq); err != nil {
+		logs.Errorf("[%s] create region failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, opt.AccountID, opt, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync region to create region success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		opt.AccountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) deleteRegion(kt *kit.Kit, opt *SyncRegionOption, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return errors.New("region delCloudIDs is <= 0, not delete")
+	}
+
+	delRegionFromCloud, err := cli.listRegionFromCloud(kt, opt)
+	if err != nil {
+		return err
+	}
+
+	delCloudMap := converter.StringSliceToMap(delCloudIDs)
+	for _, one := range delRegionFromCloud {
+		if _, exsit := delCloudMap[one.RegionID]; exsit {
+			logs.Errorf("[%s] validate region not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+				enumor.OpenStack, opt, len(delRegionFromCloud), kt.Rid)
+			return errors.New("validate region not exist failed, before delete")
+		}
+	}
+
+	elems := slice.Split(delCloudIDs, constant.CloudResourceSyncMaxLimit)
+	for _, parts := range elems {
+		zoneReq := &datazone.ZoneBatchDeleteReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.OpenStack},
+					&filter.AtomRule{Field: "region", Op: filter.In.Factory(), Value: parts},
+					&filter.AtomRule{Field: "extension.account_id", Op: filter.JSONEqual.Factory(),
+						Value: opt.AccountID},
+				},
+			},
+		}
+		if err = cli.dbCli.Global.Zone.BatchDeleteZone(kt.Ctx, kt.Header(), zoneReq); err != nil {
+			logs.Errorf("[%s] delete zone of region failed, err: %v, account: %s, regions: %v, rid: %s",
+				enumor.OpenStack, err, opt.AccountID, parts, kt.Rid)
+			return err
+		}
+
+		deleteReq := &dataregion.OpenStackRegionBatchDeleteReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: opt.AccountID},
+					&filter.AtomRule{Field: "region_id", Op: filter.In.Factory(), Value: parts},
+				},
+			},
+		}
+		if err = cli.dbCli.OpenStack.Region.BatchDeleteRegion(kt.Ctx, kt.Header(), deleteReq); err != nil {
+			logs.Errorf("[%s] delete region failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+				err, opt.AccountID, opt, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync region to delete region success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		opt.AccountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listRegionFromCloud(kt *kit.Kit, opt *SyncRegionOption) ([]typesregion.OpenStackRegion, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	regions, err := cli.cloudCli.ListRegion(kt)
+	if err != nil {
+		logs.Errorf("[%s] list region from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, opt.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return regions, nil
+}
+
+func (cli *client) listRegionFromDB(kt *kit.Kit, opt *SyncRegionOption) ([]coreregion.OpenStackRegion, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &dataregion.OpenStackRegionListReq{
+		Filter: tools.EqualExpression("account_id", opt.AccountID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	start := uint32(0)
+	results := make([]coreregion.OpenStackRegion, 0)
+	for {
+		req.Page.Start = start
+		regions, err := cli.dbCli.OpenStack.Region.ListRegion(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] list region from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.OpenStack,
+				err, opt.AccountID, req, kt.Rid)
+			return nil, err
+		}
+		results = append(results, regions.Details...)
+
+		if len(regions.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return results, nil
+}
+
+// isRegionChange openstack region only has region id, which is the cloud id, so it never changes.
+func isRegionChange(_ typesregion.OpenStackRegion, _ coreregion.OpenStackRegion) bool {
+	return false
+}
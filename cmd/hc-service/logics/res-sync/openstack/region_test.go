@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typesregion "hcm/pkg/adaptor/types/region"
+	coreregion "hcm/pkg/api/core/cloud/region"
+)
+
+func TestRegionDiff(t *testing.T) {
+	regionFromCloud := []typesregion.OpenStackRegion{{RegionID: "RegionOne"}, {RegionID: "RegionTwo"}}
+	regionFromDB := []coreregion.OpenStackRegion{
+		{ID: "1", AccountID: "account", RegionID: "RegionOne"},
+		{ID: "2", AccountID: "account", RegionID: "RegionOld"},
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typesregion.OpenStackRegion, coreregion.OpenStackRegion](
+		regionFromCloud, regionFromDB, isRegionChange)
+
+	expectAdd := []typesregion.OpenStackRegion{{RegionID: "RegionTwo"}}
+	if !reflect.DeepEqual(addSlice, expectAdd) {
+		t.Errorf("add regions %v is not as expected %v", addSlice, expectAdd)
+	}
+
+	if len(updateMap) != 0 {
+		t.Errorf("openstack region should not be updated, got: %v", updateMap)
+	}
+
+	expectDel := []string{"RegionOld"}
+	if !reflect.DeepEqual(delCloudIDs, expectDel) {
+		t.Errorf("delete region cloud ids %v is not as expected %v", delCloudIDs, expectDel)
+	}
+}
@@ -0,0 +1,344 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	"hcm/pkg/api/core"
+	cloudcore "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+)
+
+// SyncSGOption ...
+type SyncSGOption struct {
+}
+
+// Validate ...
+func (opt SyncSGOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// SecurityGroup sync security group and rules of the security group.
+func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncSGOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	sgFromCloud, err := cli.listSGFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	sgFromDB, err := cli.listSGFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sgFromCloud) == 0 && len(sgFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSG, updateMap, delCloudIDs := common.Diff[securitygroup.OpenStackSecurityGroup,
+		cloudcore.SecurityGroup[cloudcore.OpenStackSecurityGroupExtension]](sgFromCloud, sgFromDB, isSGChange)
+
+	// 演练模式下不写入db，仅继续对比云上仍存在的安全组的安全组规则
+	if common.ReportDiff(kt, enumor.SecurityGroupCloudResType, addSG, updateMap, delCloudIDs) {
+		dryRunParams := *params
+		dryRunParams.CloudIDs = common.ExcludeCloudIDs(params.CloudIDs, delCloudIDs)
+		if len(dryRunParams.CloudIDs) == 0 {
+			return new(SyncResult), nil
+		}
+		params = &dryRunParams
+		addSG, updateMap, delCloudIDs = nil, nil, nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteSG(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSG) > 0 {
+		if err = cli.createSG(kt, params.AccountID, addSG); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateSG(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	// 同步安全组规则
+	sgFromDB, err = cli.listSGFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sgFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	cloudSGIDs := make([]string, 0, len(sgFromDB))
+	for _, one := range sgFromDB {
+		cloudSGIDs = append(cloudSGIDs, one.CloudID)
+	}
+
+	sgRuleParams := &SyncBaseParams{
+		AccountID: params.AccountID,
+		Region:    params.Region,
+		CloudIDs:  cloudSGIDs,
+	}
+	if _, err = cli.SecurityGroupRule(kt, sgRuleParams, &SyncSGRuleOption{}); err != nil {
+		logs.Errorf("[%s] sg sync sgRule failed, err: %v, accountID: %s, region: %s, rid: %s", enumor.OpenStack,
+			err, params.AccountID, params.Region, kt.Rid)
+		return nil, err
+	}
+
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.OpenStack, AccountID: params.AccountID,
+		ResType: enumor.SecurityGroupCloudResType}, sgFromDB, addSG, updateMap, delCloudIDs)
+
+	return new(SyncResult), nil
+}
+
+// RemoveSecurityGroupDeleteFromCloud ...
+func (cli *client) RemoveSecurityGroupDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	listDB := func(req *core.ListReq) ([]string, error) {
+		result, err := cli.dbCli.OpenStack.SecurityGroup.ListSecurityGroupExt(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list sg failed, err: %v, req: %v, rid: %s", enumor.OpenStack,
+				err, req, kt.Rid)
+			return nil, err
+		}
+
+		cloudIDs := make([]string, 0, len(result.Details))
+		for _, one := range result.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+		return cloudIDs, nil
+	}
+
+	listCloud := func(params *SyncBaseParams) ([]string, error) {
+		sgs, err := cli.listSGFromCloud(kt, params)
+		if err != nil {
+			return nil, err
+		}
+
+		cloudIDs := make([]string, 0, len(sgs))
+		for _, one := range sgs {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+		return cloudIDs, nil
+	}
+
+	return removeDeleteFromCloud(kt, accountID, region, listDB, listCloud, func(delCloudIDs []string) error {
+		return cli.deleteSG(kt, accountID, region, delCloudIDs)
+	})
+}
+
+func (cli *client) updateSG(kt *kit.Kit, accountID string,
+	updateMap map[string]securitygroup.OpenStackSecurityGroup) error {
+
+	if len(updateMap) <= 0 {
+		return fmt.Errorf("sg updateMap is <= 0, not update")
+	}
+
+	securityGroups := make([]protocloud.SecurityGroupBatchUpdate[cloudcore.OpenStackSecurityGroupExtension], 0)
+	for id, one := range updateMap {
+		securityGroups = append(securityGroups,
+			protocloud.SecurityGroupBatchUpdate[cloudcore.OpenStackSecurityGroupExtension]{
+				ID:   id,
+				Name: one.Name,
+				Memo: converter.ValToPtr(one.Description),
+				Extension: &cloudcore.OpenStackSecurityGroupExtension{
+					CloudProjectID: one.CloudProjectID,
+					Stateful:       one.Stateful,
+				},
+			})
+	}
+
+	updateReq := &protocloud.SecurityGroupBatchUpdateReq[cloudcore.OpenStackSecurityGroupExtension]{
+		SecurityGroups: securityGroups,
+	}
+	if err := cli.dbCli.OpenStack.SecurityGroup.BatchUpdateSecurityGroup(kt.Ctx, kt.Header(), updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice BatchUpdateSecurityGroup failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync sg to update sg success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createSG(kt *kit.Kit, accountID string, addSG []securitygroup.OpenStackSecurityGroup) error {
+	if len(addSG) <= 0 {
+		return fmt.Errorf("sg addSG is <= 0, not create")
+	}
+
+	securityGroups := make([]protocloud.SecurityGroupBatchCreate[cloudcore.OpenStackSecurityGroupExtension], 0,
+		len(addSG))
+	for _, one := range addSG {
+		securityGroups = append(securityGroups,
+			protocloud.SecurityGroupBatchCreate[cloudcore.OpenStackSecurityGroupExtension]{
+				CloudID:   one.CloudID,
+				BkBizID:   constant.UnassignedBiz,
+				Region:    one.Region,
+				Name:      one.Name,
+				Memo:      converter.ValToPtr(one.Description),
+				AccountID: accountID,
+				Extension: &cloudcore.OpenStackSecurityGroupExtension{
+					CloudProjectID: one.CloudProjectID,
+					Stateful:       one.Stateful,
+				},
+			})
+	}
+
+	createReq := &protocloud.SecurityGroupBatchCreateReq[cloudcore.OpenStackSecurityGroupExtension]{
+		SecurityGroups: securityGroups,
+	}
+	if _, err := cli.dbCli.OpenStack.SecurityGroup.BatchCreateSecurityGroup(kt.Ctx, kt.Header(),
+		createReq); err != nil {
+		logs.Errorf("[%s] request dataservice BatchCreateSecurityGroup failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync sg to create sg success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(addSG), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) deleteSG(kt *kit.Kit, accountID, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.SecurityGroupCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("sg delCloudIDs is <= 0, not delete")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delSGFromCloud, err := cli.listSGFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delSGFromCloud) > 0 {
+		logs.Errorf("[%s] validate sg not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.OpenStack, checkParams, len(delSGFromCloud), kt.Rid)
+		return fmt.Errorf("validate sg not exist failed, before delete")
+	}
+
+	deleteReq := &protocloud.SecurityGroupBatchDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if err = cli.dbCli.Global.SecurityGroup.BatchDeleteSecurityGroup(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete sg failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync sg to delete sg success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listSGFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]securitygroup.OpenStackSecurityGroup,
+	error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &securitygroup.OpenStackListOption{
+		OpenStackListOption: adcore.OpenStackListOption{
+			Region:   params.Region,
+			CloudIDs: params.CloudIDs,
+		},
+	}
+	result, err := cli.cloudCli.ListSecurityGroup(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list sg from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listSGFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]cloudcore.SecurityGroup[cloudcore.OpenStackSecurityGroupExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: accountRegionCloudIDsFilter(params),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.OpenStack.SecurityGroup.ListSecurityGroupExt(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list sg from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isSGChange(cloud securitygroup.OpenStackSecurityGroup,
+	db cloudcore.SecurityGroup[cloudcore.OpenStackSecurityGroupExtension]) bool {
+
+	if cloud.Name != db.Name {
+		return true
+	}
+
+	if converter.PtrToVal(db.Memo) != cloud.Description {
+		return true
+	}
+
+	if cloud.CloudProjectID != db.Extension.CloudProjectID || cloud.Stateful != db.Extension.Stateful {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,376 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	securitygrouprule "hcm/pkg/adaptor/types/security-group-rule"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/concurrence"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncSGRuleOption ...
+type SyncSGRuleOption struct {
+}
+
+// Validate ...
+func (opt SyncSGRuleOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// SecurityGroupRule sync rules of the security groups, security groups must be synced to db first.
+func (cli *client) SecurityGroupRule(kt *kit.Kit, params *SyncBaseParams, opt *SyncSGRuleOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	sgFromDB, err := cli.listSGFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	sgMap := make(map[string]string)
+	for _, one := range sgFromDB {
+		sgMap[one.CloudID] = one.ID
+	}
+
+	if len(sgMap) != len(params.CloudIDs) {
+		return nil, fmt.Errorf("sg num is not match")
+	}
+
+	err = concurrence.BaseExec(constant.SyncConcurrencyDefaultMaxLimit, params.CloudIDs, func(param string) error {
+		syncOpt := &syncSGRuleOption{
+			AccountID: params.AccountID,
+			Region:    params.Region,
+			CloudSGID: param,
+			SGID:      sgMap[param],
+		}
+		if _, err := cli.securityGroupRule(kt, syncOpt); err != nil {
+			logs.ErrorDepthf(1, "[%s] account: %s sg: %s sync sgRule failed, err: %v, rid: %s",
+				enumor.OpenStack, params.AccountID, param, err, kt.Rid)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return new(SyncResult), nil
+}
+
+type syncSGRuleOption struct {
+	AccountID string `json:"account_id" validate:"required"`
+	Region    string `json:"region" validate:"required"`
+	CloudSGID string `json:"cloud_sgid" validate:"required"`
+	SGID      string `json:"sgid" validate:"required"`
+}
+
+// Validate ...
+func (opt syncSGRuleOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	sgRuleFromDB, err := cli.listSGRuleFromDB(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	sgRuleFromCloud, err := cli.listSGRuleFromCloud(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sgRuleFromCloud) == 0 && len(sgRuleFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[securitygrouprule.OpenStackSGRule,
+		corecloud.OpenStackSecurityGroupRule](sgRuleFromCloud, sgRuleFromDB, isSGRuleChange)
+
+	if common.ReportDiff(kt, enumor.SecurityGroupRuleCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteSGRule(kt, opt, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createSGRule(kt, opt, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateSGRule(kt, opt, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.OpenStack, AccountID: opt.AccountID,
+		ResType: enumor.SecurityGroupRuleCloudResType}, sgRuleFromDB, addSlice, updateMap, delCloudIDs)
+
+	return new(SyncResult), nil
+}
+
+func (cli *client) createSGRule(kt *kit.Kit, opt *syncSGRuleOption,
+	addSlice []securitygrouprule.OpenStackSGRule) error {
+
+	if len(addSlice) <= 0 {
+		return fmt.Errorf("sgRule addSlice is <= 0, not create")
+	}
+
+	list := make([]protocloud.OpenStackSGRuleBatchCreate, 0, len(addSlice))
+	for _, one := range addSlice {
+		list = append(list, protocloud.OpenStackSGRuleBatchCreate{
+			CloudID:              one.CloudID,
+			Memo:                 converter.ValToPtr(one.Description),
+			Protocol:             one.Protocol,
+			Ethertype:            one.Ethertype,
+			CloudRemoteGroupID:   one.RemoteGroupID,
+			RemoteIPPrefix:       one.RemoteIPPrefix,
+			Port:                 one.Port,
+			Type:                 enumor.SecurityGroupRuleType(one.Direction),
+			CloudSecurityGroupID: opt.CloudSGID,
+			CloudProjectID:       one.CloudProjectID,
+			AccountID:            opt.AccountID,
+			Region:               opt.Region,
+			SecurityGroupID:      opt.SGID,
+		})
+	}
+
+	for _, parts := range slice.Split(list, constant.BatchOperationMaxLimit) {
+		createReq := &protocloud.OpenStackSGRuleCreateReq{
+			Rules: parts,
+		}
+		_, err := cli.dbCli.OpenStack.SecurityGroup.BatchCreateSecurityGroupRule(kt.Ctx, kt.Header(), createReq,
+			opt.SGID)
+		if err != nil {
+			logs.Errorf("[%s] dataservice create openstack security group rules failed, err: %v, rid: %s",
+				enumor.OpenStack, err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync sgRule to create sgRule success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		opt.AccountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateSGRule(kt *kit.Kit, opt *syncSGRuleOption,
+	updateMap map[string]securitygrouprule.OpenStackSGRule) error {
+
+	if len(updateMap) <= 0 {
+		return fmt.Errorf("sgRule updateMap is <= 0, not update")
+	}
+
+	list := make([]protocloud.OpenStackSGRuleBatchUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		list = append(list, protocloud.OpenStackSGRuleBatchUpdate{
+			ID:                   id,
+			CloudID:              one.CloudID,
+			Memo:                 converter.ValToPtr(one.Description),
+			Protocol:             one.Protocol,
+			Ethertype:            one.Ethertype,
+			CloudRemoteGroupID:   one.RemoteGroupID,
+			RemoteIPPrefix:       one.RemoteIPPrefix,
+			Port:                 one.Port,
+			Type:                 enumor.SecurityGroupRuleType(one.Direction),
+			CloudSecurityGroupID: opt.CloudSGID,
+			CloudProjectID:       one.CloudProjectID,
+			AccountID:            opt.AccountID,
+			Region:               opt.Region,
+			SecurityGroupID:      opt.SGID,
+		})
+	}
+
+	for _, parts := range slice.Split(list, constant.BatchOperationMaxLimit) {
+		updateReq := &protocloud.OpenStackSGRuleBatchUpdateReq{
+			Rules: parts,
+		}
+		err := cli.dbCli.OpenStack.SecurityGroup.BatchUpdateSecurityGroupRule(kt.Ctx, kt.Header(), updateReq,
+			opt.SGID)
+		if err != nil {
+			logs.Errorf("[%s] dataservice update openstack security group rules failed, err: %v, rid: %s",
+				enumor.OpenStack, err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync sgRule to update sgRule success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		opt.AccountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) deleteSGRule(kt *kit.Kit, opt *syncSGRuleOption, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return fmt.Errorf("sgRule delCloudIDs is <= 0, not delete")
+	}
+
+	delSGRuleFromCloud, err := cli.listSGRuleFromCloud(kt, opt)
+	if err != nil {
+		return err
+	}
+
+	delCloudMap := converter.StringSliceToMap(delCloudIDs)
+	for _, one := range delSGRuleFromCloud {
+		if _, exsit := delCloudMap[one.CloudID]; exsit {
+			logs.Errorf("[%s] validate sgRule not exist failed, before delete, failed_count: %d, rid: %s",
+				enumor.OpenStack, len(delSGRuleFromCloud), kt.Rid)
+			return fmt.Errorf("validate sgRule not exist failed, before delete")
+		}
+	}
+
+	for _, parts := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		deleteReq := &protocloud.OpenStackSGRuleBatchDeleteReq{
+			Filter: tools.ContainersExpression("cloud_id", parts),
+		}
+		err = cli.dbCli.OpenStack.SecurityGroup.BatchDeleteSecurityGroupRule(kt.Ctx, kt.Header(), deleteReq,
+			opt.SGID)
+		if err != nil {
+			logs.Errorf("[%s] dataservice delete openstack security group rules failed, err: %v, rid: %s",
+				enumor.OpenStack, err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync sgRule to delete sgRule success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		opt.AccountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listSGRuleFromCloud(kt *kit.Kit, opt *syncSGRuleOption) ([]securitygrouprule.OpenStackSGRule,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listOpt := &securitygrouprule.OpenStackListOption{
+		Region:               opt.Region,
+		CloudSecurityGroupID: opt.CloudSGID,
+	}
+	rules, err := cli.cloudCli.ListSecurityGroupRule(kt, listOpt)
+	if err != nil {
+		logs.Errorf("[%s] request adaptor to list openstack security group rule failed, err: %v, opt: %v, rid: %s",
+			enumor.OpenStack, err, listOpt, kt.Rid)
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (cli *client) listSGRuleFromDB(kt *kit.Kit, opt *syncSGRuleOption) ([]corecloud.OpenStackSecurityGroupRule,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &protocloud.OpenStackSGRuleListReq{
+		Filter: tools.EqualExpression("security_group_id", opt.SGID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	start := uint32(0)
+	rules := make([]corecloud.OpenStackSecurityGroupRule, 0)
+	for {
+		listReq.Page.Start = start
+		listResp, err := cli.dbCli.OpenStack.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), listReq,
+			opt.SGID)
+		if err != nil {
+			logs.Errorf("[%s] dataservice list openstack security group rules failed, err: %v, rid: %s",
+				enumor.OpenStack, err, kt.Rid)
+			return nil, err
+		}
+
+		rules = append(rules, listResp.Details...)
+
+		if len(listResp.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return rules, nil
+}
+
+func isSGRuleChange(cloud securitygrouprule.OpenStackSGRule, db corecloud.OpenStackSecurityGroupRule) bool {
+	if converter.PtrToVal(db.Memo) != cloud.Description {
+		return true
+	}
+
+	if db.Protocol != cloud.Protocol {
+		return true
+	}
+
+	if db.Ethertype != cloud.Ethertype {
+		return true
+	}
+
+	if db.CloudRemoteGroupID != cloud.RemoteGroupID {
+		return true
+	}
+
+	if db.RemoteIPPrefix != cloud.RemoteIPPrefix {
+		return true
+	}
+
+	if db.Port != cloud.Port {
+		return true
+	}
+
+	if db.Type != enumor.SecurityGroupRuleType(cloud.Direction) {
+		return true
+	}
+
+	if db.CloudProjectID != cloud.CloudProjectID {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	securitygrouprule "hcm/pkg/adaptor/types/security-group-rule"
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+func TestSGRuleDiff(t *testing.T) {
+	ruleFromCloud := []securitygrouprule.OpenStackSGRule{
+		{CloudID: "rule-1", Direction: "ingress", Ethertype: "IPv4", Protocol: "tcp", Port: "22",
+			RemoteIPPrefix: "0.0.0.0/0"},
+		{CloudID: "rule-2", Direction: "egress", Ethertype: "IPv4", Description: "allow all"},
+		{CloudID: "rule-new", Direction: "ingress", Ethertype: "IPv6", RemoteGroupID: "sg-1"},
+	}
+	ruleFromDB := []corecloud.OpenStackSecurityGroupRule{
+		{ID: "1", CloudID: "rule-1", Type: enumor.Ingress, Ethertype: "IPv4", Protocol: "tcp", Port: "22",
+			RemoteIPPrefix: "0.0.0.0/0", Memo: converter.ValToPtr("")},
+		{ID: "2", CloudID: "rule-2", Type: enumor.Egress, Ethertype: "IPv4", Memo: converter.ValToPtr("old")},
+		{ID: "3", CloudID: "rule-old", Type: enumor.Ingress, Ethertype: "IPv4"},
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[securitygrouprule.OpenStackSGRule,
+		corecloud.OpenStackSecurityGroupRule](ruleFromCloud, ruleFromDB, isSGRuleChange)
+
+	expectAdd := []securitygrouprule.OpenStackSGRule{ruleFromCloud[2]}
+	if !reflect.DeepEqual(addSlice, expectAdd) {
+		t.Errorf("add rules %v is not as expected %v", addSlice, expectAdd)
+	}
+
+	expectUpdate := map[string]securitygrouprule.OpenStackSGRule{"2": ruleFromCloud[1]}
+	if !reflect.DeepEqual(updateMap, expectUpdate) {
+		t.Errorf("update rules %v is not as expected %v", updateMap, expectUpdate)
+	}
+
+	expectDel := []string{"rule-old"}
+	if !reflect.DeepEqual(delCloudIDs, expectDel) {
+		t.Errorf("delete rule cloud ids %v is not as expected %v", delCloudIDs, expectDel)
+	}
+}
+
+func TestIsSGRuleChange(t *testing.T) {
+	cloud := securitygrouprule.OpenStackSGRule{CloudID: "rule-1", Direction: "ingress", Ethertype: "IPv4",
+		Protocol: "tcp", Port: "80-443", RemoteGroupID: "sg-1", CloudProjectID: "project"}
+	db := corecloud.OpenStackSecurityGroupRule{CloudID: "rule-1", Type: enumor.Ingress, Ethertype: "IPv4",
+		Protocol: "tcp", Port: "80-443", CloudRemoteGroupID: "sg-1", CloudProjectID: "project"}
+
+	if isSGRuleChange(cloud, db) {
+		t.Errorf("rule should not be changed, cloud: %v, db: %v", cloud, db)
+	}
+
+	changes := map[string]func(rule *corecloud.OpenStackSecurityGroupRule){
+		"memo":      func(rule *corecloud.OpenStackSecurityGroupRule) { rule.Memo = converter.ValToPtr("memo") },
+		"type":      func(rule *corecloud.OpenStackSecurityGroupRule) { rule.Type = enumor.Egress },
+		"port":      func(rule *corecloud.OpenStackSecurityGroupRule) { rule.Port = "80" },
+		"protocol":  func(rule *corecloud.OpenStackSecurityGroupRule) { rule.Protocol = "udp" },
+		"ethertype": func(rule *corecloud.OpenStackSecurityGroupRule) { rule.Ethertype = "IPv6" },
+		"remote":    func(rule *corecloud.OpenStackSecurityGroupRule) { rule.CloudRemoteGroupID = "sg-2" },
+	}
+	for name, change := range changes {
+		changed := db
+		change(&changed)
+		if !isSGRuleChange(cloud, changed) {
+			t.Errorf("rule should be changed when %s is different", name)
+		}
+	}
+}
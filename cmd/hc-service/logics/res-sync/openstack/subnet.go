@@ -0,0 +1,381 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/api/core"
+	cloudcore "hcm/pkg/api/core/cloud"
+	dataservice "hcm/pkg/api/data-service"
+	"hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncSubnetOption ...
+type SyncSubnetOption struct {
+}
+
+// Validate ...
+func (opt SyncSubnetOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// Subnet neutron subnet can be queried by id without network, so subnet is synced by region like vpc.
+func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	subnetFromCloud, err := cli.listSubnetFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetFromDB, err := cli.listSubnetFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subnetFromCloud) == 0 && len(subnetFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSubnet, updateMap, delCloudIDs := common.Diff[adtysubnet.OpenStackSubnet,
+		cloudcore.Subnet[cloudcore.OpenStackSubnetExtension]](subnetFromCloud, subnetFromDB, isOpenStackSubnetChange)
+
+	if common.ReportDiff(kt, enumor.SubnetCloudResType, addSubnet, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteSubnet(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSubnet) > 0 {
+		if err = cli.createSubnet(kt, params.AccountID, addSubnet); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateSubnet(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.OpenStack, AccountID: params.AccountID,
+		ResType: enumor.SubnetCloudResType}, subnetFromDB, addSubnet, updateMap, delCloudIDs)
+
+	return new(SyncResult), nil
+}
+
+// RemoveSubnetDeleteFromCloud ...
+func (cli *client) RemoveSubnetDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	listDB := func(req *core.ListReq) ([]string, error) {
+		result, err := cli.dbCli.Global.Subnet.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list subnet failed, err: %v, req: %v, rid: %s",
+				enumor.OpenStack, err, req, kt.Rid)
+			return nil, err
+		}
+
+		cloudIDs := make([]string, 0, len(result.Details))
+		for _, one := range result.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+		return cloudIDs, nil
+	}
+
+	listCloud := func(params *SyncBaseParams) ([]string, error) {
+		subnets, err := cli.listSubnetFromCloud(kt, params)
+		if err != nil {
+			return nil, err
+		}
+
+		cloudIDs := make([]string, 0, len(subnets))
+		for _, one := range subnets {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+		return cloudIDs, nil
+	}
+
+	return removeDeleteFromCloud(kt, accountID, region, listDB, listCloud, func(delCloudIDs []string) error {
+		return cli.deleteSubnet(kt, accountID, region, delCloudIDs)
+	})
+}
+
+func (cli *client) deleteSubnet(kt *kit.Kit, accountID, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.SubnetCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete subnet, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delSubnetFromCloud, err := cli.listSubnetFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delSubnetFromCloud) > 0 {
+		logs.Errorf("[%s] validate subnet not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.OpenStack, checkParams, len(delSubnetFromCloud), kt.Rid)
+		return fmt.Errorf("validate subnet not exist failed, before delete")
+	}
+
+	deleteReq := &dataservice.BatchDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if err = cli.dbCli.Global.Subnet.BatchDelete(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete subnet failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync subnet to delete subnet success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateSubnet(kt *kit.Kit, accountID string, updateMap map[string]adtysubnet.OpenStackSubnet) error {
+	if len(updateMap) == 0 {
+		return fmt.Errorf("update subnet, subnets is required")
+	}
+
+	subnets := make([]cloud.SubnetUpdateReq[cloud.OpenStackSubnetUpdateExt], 0)
+	for id, item := range updateMap {
+		subnets = append(subnets, cloud.SubnetUpdateReq[cloud.OpenStackSubnetUpdateExt]{
+			ID: id,
+			SubnetUpdateBaseInfo: cloud.SubnetUpdateBaseInfo{
+				Region:   item.Extension.Region,
+				Name:     converter.ValToPtr(item.Name),
+				Ipv4Cidr: item.Ipv4Cidr,
+				Ipv6Cidr: item.Ipv6Cidr,
+				Memo:     item.Memo,
+			},
+			Extension: &cloud.OpenStackSubnetUpdateExt{
+				GatewayIp:      item.Extension.GatewayIp,
+				EnableDhcp:     converter.ValToPtr(item.Extension.EnableDhcp),
+				DnsNameservers: item.Extension.DnsNameservers,
+			},
+		})
+	}
+
+	updateReq := &cloud.SubnetBatchUpdateReq[cloud.OpenStackSubnetUpdateExt]{
+		Subnets: subnets,
+	}
+	if err := cli.dbCli.OpenStack.Subnet.BatchUpdate(kt.Ctx, kt.Header(), updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch update db subnet failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync subnet to update subnet success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createSubnet(kt *kit.Kit, accountID string, addSubnet []adtysubnet.OpenStackSubnet) error {
+	if len(addSubnet) == 0 {
+		return fmt.Errorf("create subnet, subnets is required")
+	}
+
+	cloudVpcIDs := make([]string, 0, len(addSubnet))
+	for _, item := range addSubnet {
+		cloudVpcIDs = append(cloudVpcIDs, item.CloudVpcID)
+	}
+	vpcIDMap, err := cli.getVpcIDMap(kt, accountID, slice.Unique(cloudVpcIDs))
+	if err != nil {
+		return err
+	}
+
+	subnets := make([]cloud.SubnetCreateReq[cloud.OpenStackSubnetCreateExt], 0, len(addSubnet))
+	for _, item := range addSubnet {
+		vpcID, exist := vpcIDMap[item.CloudVpcID]
+		if !exist {
+			return fmt.Errorf("vpc: %s of subnet: %s not found", item.CloudVpcID, item.CloudID)
+		}
+
+		ipv4Cidr := item.Ipv4Cidr
+		if ipv4Cidr == nil {
+			// 仅包含 ipv6 网段的子网
+			ipv4Cidr = make([]string, 0)
+		}
+
+		subnets = append(subnets, cloud.SubnetCreateReq[cloud.OpenStackSubnetCreateExt]{
+			AccountID:  accountID,
+			CloudVpcID: item.CloudVpcID,
+			VpcID:      vpcID,
+			BkBizID:    constant.UnassignedBiz,
+			CloudID:    item.CloudID,
+			Name:       converter.ValToPtr(item.Name),
+			Region:     item.Extension.Region,
+			Ipv4Cidr:   ipv4Cidr,
+			Ipv6Cidr:   item.Ipv6Cidr,
+			Memo:       item.Memo,
+			Extension: &cloud.OpenStackSubnetCreateExt{
+				IpVersion:      item.Extension.IpVersion,
+				GatewayIp:      item.Extension.GatewayIp,
+				EnableDhcp:     item.Extension.EnableDhcp,
+				DnsNameservers: item.Extension.DnsNameservers,
+			},
+		})
+	}
+
+	createReq := &cloud.SubnetBatchCreateReq[cloud.OpenStackSubnetCreateExt]{
+		Subnets: subnets,
+	}
+	if _, err = cli.dbCli.OpenStack.Subnet.BatchCreate(kt.Ctx, kt.Header(), createReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch create subnet failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync subnet to create subnet success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(addSubnet), kt.Rid)
+
+	return nil
+}
+
+// getVpcIDMap get vpc cloud id and id map.
+func (cli *client) getVpcIDMap(kt *kit.Kit, accountID string, cloudVpcIDs []string) (map[string]string, error) {
+	result := make(map[string]string, len(cloudVpcIDs))
+	for _, ids := range slice.Split(cloudVpcIDs, int(core.DefaultMaxPageLimit)) {
+		req := &core.ListReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+					&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: ids},
+				},
+			},
+			Page:   core.NewDefaultBasePage(),
+			Fields: []string{"id", "cloud_id"},
+		}
+		vpcs, err := cli.dbCli.Global.Vpc.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] list vpc from db failed, err: %v, cloud ids: %v, rid: %s", enumor.OpenStack, err, ids,
+				kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range vpcs.Details {
+			result[one.CloudID] = one.ID
+		}
+	}
+
+	return result, nil
+}
+
+func (cli *client) listSubnetFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]adtysubnet.OpenStackSubnet, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &adtysubnet.OpenStackSubnetListOption{
+		OpenStackListOption: adcore.OpenStackListOption{
+			Region:   params.Region,
+			CloudIDs: params.CloudIDs,
+		},
+	}
+	result, err := cli.cloudCli.ListSubnet(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list subnet from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func (cli *client) listSubnetFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]cloudcore.Subnet[cloudcore.OpenStackSubnetExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: accountRegionCloudIDsFilter(params),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.OpenStack.Subnet.ListSubnetExt(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list subnet from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.OpenStack, err,
+			params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isOpenStackSubnetChange(item adtysubnet.OpenStackSubnet,
+	info cloudcore.Subnet[cloudcore.OpenStackSubnetExtension]) bool {
+
+	if info.Name != item.Name {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(info.Ipv4Cidr, item.Ipv4Cidr) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(info.Ipv6Cidr, item.Ipv6Cidr) {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(info.Memo, item.Memo) {
+		return true
+	}
+
+	if info.Extension.GatewayIp != item.Extension.GatewayIp || info.Extension.EnableDhcp != item.Extension.EnableDhcp {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(info.Extension.DnsNameservers, item.Extension.DnsNameservers) {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,343 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/pkg/adaptor/types"
+	adcore "hcm/pkg/adaptor/types/core"
+	"hcm/pkg/api/core"
+	cloudcore "hcm/pkg/api/core/cloud"
+	dataservice "hcm/pkg/api/data-service"
+	"hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+)
+
+// SyncVpcOption ...
+type SyncVpcOption struct {
+}
+
+// Validate ...
+func (opt SyncVpcOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// Vpc ...
+func (cli *client) Vpc(kt *kit.Kit, params *SyncBaseParams, opt *SyncVpcOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	vpcFromCloud, err := cli.listVpcFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcFromDB, err := cli.listVpcFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vpcFromCloud) == 0 && len(vpcFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addVpc, updateMap, delCloudIDs := common.Diff[types.OpenStackVpc, cloudcore.Vpc[cloudcore.OpenStackVpcExtension]](
+		vpcFromCloud, vpcFromDB, isOpenStackVpcChange)
+
+	if common.ReportDiff(kt, enumor.VpcCloudResType, addVpc, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteVpc(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addVpc) > 0 {
+		if err = cli.createVpc(kt, params.AccountID, addVpc); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateVpc(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.OpenStack, AccountID: params.AccountID,
+		ResType: enumor.VpcCloudResType}, vpcFromDB, addVpc, updateMap, delCloudIDs)
+
+	return new(SyncResult), nil
+}
+
+// RemoveVpcDeleteFromCloud ...
+func (cli *client) RemoveVpcDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	listDB := func(req *core.ListReq) ([]string, error) {
+		result, err := cli.dbCli.Global.Vpc.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list vpc failed, err: %v, req: %v, rid: %s", enumor.OpenStack,
+				err, req, kt.Rid)
+			return nil, err
+		}
+
+		cloudIDs := make([]string, 0, len(result.Details))
+		for _, one := range result.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+		return cloudIDs, nil
+	}
+
+	listCloud := func(params *SyncBaseParams) ([]string, error) {
+		vpcs, err := cli.listVpcFromCloud(kt, params)
+		if err != nil {
+			return nil, err
+		}
+
+		cloudIDs := make([]string, 0, len(vpcs))
+		for _, one := range vpcs {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+		return cloudIDs, nil
+	}
+
+	return removeDeleteFromCloud(kt, accountID, region, listDB, listCloud, func(delCloudIDs []string) error {
+		return cli.deleteVpc(kt, accountID, region, delCloudIDs)
+	})
+}
+
+func (cli *client) deleteVpc(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.VpcCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete vpc, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delVpcFromCloud, err := cli.listVpcFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delVpcFromCloud) > 0 {
+		logs.Errorf("[%s] validate vpc not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.OpenStack, checkParams, len(delVpcFromCloud), kt.Rid)
+		return fmt.Errorf("validate vpc not exist failed, before delete")
+	}
+
+	deleteReq := &dataservice.BatchDeleteReq{
+		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
+	}
+	if err = cli.dbCli.Global.Vpc.BatchDelete(kt.Ctx, kt.Header(), deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete vpc failed, err: %v, rid: %s", enumor.OpenStack, err,
+			kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync vpc to delete vpc success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateVpc(kt *kit.Kit, accountID string, updateMap map[string]types.OpenStackVpc) error {
+	if len(updateMap) == 0 {
+		return fmt.Errorf("update vpc, vpcs is required")
+	}
+
+	vpcs := make([]cloud.VpcUpdateReq[cloud.OpenStackVpcUpdateExt], 0)
+	for id, one := range updateMap {
+		vpcs = append(vpcs, cloud.VpcUpdateReq[cloud.OpenStackVpcUpdateExt]{
+			ID: id,
+			VpcUpdateBaseInfo: cloud.VpcUpdateBaseInfo{
+				Name: converter.ValToPtr(one.Name),
+				Memo: one.Memo,
+			},
+			Extension: &cloud.OpenStackVpcUpdateExt{
+				Cidr:         convOpenStackCidr(one.Extension.Cidr),
+				Status:       one.Extension.Status,
+				Shared:       converter.ValToPtr(one.Extension.Shared),
+				External:     converter.ValToPtr(one.Extension.External),
+				AdminStateUp: converter.ValToPtr(one.Extension.AdminStateUp),
+				Mtu:          one.Extension.Mtu,
+			},
+		})
+	}
+
+	updateReq := &cloud.VpcBatchUpdateReq[cloud.OpenStackVpcUpdateExt]{
+		Vpcs: vpcs,
+	}
+	if err := cli.dbCli.OpenStack.Vpc.BatchUpdate(kt.Ctx, kt.Header(), updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch update db vpc failed, err: %v, rid: %s", enumor.OpenStack,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync vpc to update vpc success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createVpc(kt *kit.Kit, accountID string, addVpc []types.OpenStackVpc) error {
+	if len(addVpc) == 0 {
+		return fmt.Errorf("create vpc, vpcs is required")
+	}
+
+	vpcs := make([]cloud.VpcCreateReq[cloud.OpenStackVpcCreateExt], 0, len(addVpc))
+	for _, one := range addVpc {
+		vpcs = append(vpcs, cloud.VpcCreateReq[cloud.OpenStackVpcCreateExt]{
+			AccountID: accountID,
+			CloudID:   one.CloudID,
+			Name:      converter.ValToPtr(one.Name),
+			BkBizID:   constant.UnassignedBiz,
+			BkCloudID: constant.UnbindBkCloudID,
+			Region:    one.Region,
+			Category:  enumor.BizVpcCategory,
+			Memo:      one.Memo,
+			Extension: &cloud.OpenStackVpcCreateExt{
+				Cidr:         convOpenStackCidr(one.Extension.Cidr),
+				Status:       one.Extension.Status,
+				Shared:       one.Extension.Shared,
+				External:     one.Extension.External,
+				AdminStateUp: one.Extension.AdminStateUp,
+				Mtu:          one.Extension.Mtu,
+			},
+		})
+	}
+
+	createReq := &cloud.VpcBatchCreateReq[cloud.OpenStackVpcCreateExt]{
+		Vpcs: vpcs,
+	}
+	if _, err := cli.dbCli.OpenStack.Vpc.BatchCreate(kt.Ctx, kt.Header(), createReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch create vpc failed, err: %v, rid: %s", enumor.OpenStack, err,
+			kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync vpc to create vpc success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		accountID, len(addVpc), kt.Rid)
+
+	return nil
+}
+
+func convOpenStackCidr(cidrs []cloudcore.OpenStackCidr) []cloud.OpenStackCidr {
+	result := make([]cloud.OpenStackCidr, 0, len(cidrs))
+	for _, one := range cidrs {
+		result = append(result, cloud.OpenStackCidr{Type: one.Type, Cidr: one.Cidr})
+	}
+
+	return result
+}
+
+func (cli *client) listVpcFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]types.OpenStackVpc, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &adcore.OpenStackListOption{
+		Region:   params.Region,
+		CloudIDs: params.CloudIDs,
+	}
+	result, err := cli.cloudCli.ListVpc(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list vpc from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func (cli *client) listVpcFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]cloudcore.Vpc[cloudcore.OpenStackVpcExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: accountRegionCloudIDsFilter(params),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.OpenStack.Vpc.ListVpcExt(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("[%s] list vpc from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.OpenStack, err,
+			params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isOpenStackVpcChange(item types.OpenStackVpc, info cloudcore.Vpc[cloudcore.OpenStackVpcExtension]) bool {
+	if info.Name != item.Name {
+		return true
+	}
+
+	if info.Region != item.Region {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(info.Memo, item.Memo) {
+		return true
+	}
+
+	if info.Extension.Status != item.Extension.Status || info.Extension.Shared != item.Extension.Shared ||
+		info.Extension.External != item.Extension.External ||
+		info.Extension.AdminStateUp != item.Extension.AdminStateUp || info.Extension.Mtu != item.Extension.Mtu {
+		return true
+	}
+
+	if len(info.Extension.Cidr) != len(item.Extension.Cidr) {
+		return true
+	}
+
+	cidrMap := make(map[string]cloudcore.OpenStackCidr)
+	for _, one := range item.Extension.Cidr {
+		cidrMap[one.Cidr] = one
+	}
+	for _, db := range info.Extension.Cidr {
+		cloud, exist := cidrMap[db.Cidr]
+		if !exist || db.Type != cloud.Type {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,310 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"errors"
+	"fmt"
+
+	typeszone "hcm/pkg/adaptor/types/zone"
+	"hcm/pkg/api/core"
+	corezone "hcm/pkg/api/core/cloud/zone"
+	datazone "hcm/pkg/api/data-service/cloud/zone"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+const (
+	zoneAvailableState   = "available"
+	zoneUnavailableState = "unavailable"
+)
+
+// SyncZoneOption ...
+type SyncZoneOption struct {
+	AccountID string `json:"account_id" validate:"required"`
+	Region    string `json:"region" validate:"required"`
+}
+
+// Validate ...
+func (opt SyncZoneOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// Zone sync zones of the account region. openstack zone has no id and zone name is only unique in the region
+// of one deployment, so zones are compared by name, and stored with the cloud id made of account, region and name.
+func (cli *client) Zone(kt *kit.Kit, opt *SyncZoneOption) (*SyncResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	zoneFromCloud, err := cli.listZoneFromCloud(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneFromDB, err := cli.listZoneFromDB(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(zoneFromCloud) == 0 && len(zoneFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := diffZone(zoneFromCloud, zoneFromDB)
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteZone(kt, opt, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createZone(kt, opt, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateZone(kt, opt, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+// zoneCloudID return the cloud id of openstack zone stored in db.
+func zoneCloudID(accountID, region, name string) string {
+	return fmt.Sprintf("%s/%s/%s", accountID, region, name)
+}
+
+func zoneState(zone typeszone.OpenStackZone) string {
+	if zone.Available {
+		return zoneAvailableState
+	}
+
+	return zoneUnavailableState
+}
+
+// diffZone compare zones from cloud and db by zone name, return zones to add, zones to update by db id
+// and cloud ids of zones to delete.
+func diffZone(zoneFromCloud []typeszone.OpenStackZone, zoneFromDB []corezone.BaseZone) (
+	[]typeszone.OpenStackZone, map[string]typeszone.OpenStackZone, []string) {
+
+	dbMap := make(map[string]corezone.BaseZone, len(zoneFromDB))
+	for _, one := range zoneFromDB {
+		dbMap[one.Name] = one
+	}
+
+	addSlice := make([]typeszone.OpenStackZone, 0)
+	updateMap := make(map[string]typeszone.OpenStackZone)
+	for _, one := range zoneFromCloud {
+		db, exist := dbMap[one.Name]
+		if !exist {
+			addSlice = append(addSlice, one)
+			continue
+		}
+
+		delete(dbMap, one.Name)
+		if isZoneChange(one, db) {
+			updateMap[db.ID] = one
+		}
+	}
+
+	delCloudIDs := make([]string, 0, len(dbMap))
+	for _, one := range dbMap {
+		delCloudIDs = append(delCloudIDs, one.CloudID)
+	}
+
+	return addSlice, updateMap, delCloudIDs
+}
+
+func (cli *client) createZone(kt *kit.Kit, opt *SyncZoneOption, addSlice []typeszone.OpenStackZone) error {
+	if len(addSlice) <= 0 {
+		return errors.New("zone addSlice is <= 0, not create")
+	}
+
+	list := make([]datazone.ZoneBatchCreate[corezone.OpenStackZoneExtension], 0, len(addSlice))
+	for _, one := range addSlice {
+		list = append(list, datazone.ZoneBatchCreate[corezone.OpenStackZoneExtension]{
+			CloudID: zoneCloudID(opt.AccountID, opt.Region, one.Name),
+			Name:    one.Name,
+			State:   zoneState(one),
+			Region:  opt.Region,
+			Extension: &corezone.OpenStackZoneExtension{
+				AccountID: opt.AccountID,
+			},
+		})
+	}
+
+	createReq := &datazone.ZoneBatchCreateReq[corezone.OpenStackZoneExtension]{
+		Zones: list,
+	}
+	if _, err := cli.dbCli.OpenStack.Zone.BatchCreateZone(kt.Ctx, kt.Header(), createReq); err != nil {
+		logs.Errorf("[%s] create zone failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, opt.AccountID, opt, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync zone to create zone success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		opt.AccountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateZone(kt *kit.Kit, opt *SyncZoneOption, updateMap map[string]typeszone.OpenStackZone) error {
+	if len(updateMap) <= 0 {
+		return errors.New("zone updateMap is <= 0, not update")
+	}
+
+	list := make([]datazone.ZoneBatchUpdate[corezone.OpenStackZoneExtension], 0, len(updateMap))
+	for id, one := range updateMap {
+		list = append(list, datazone.ZoneBatchUpdate[corezone.OpenStackZoneExtension]{
+			ID:    id,
+			State: zoneState(one),
+		})
+	}
+
+	updateReq := &datazone.ZoneBatchUpdateReq[corezone.OpenStackZoneExtension]{
+		Zones: list,
+	}
+	if err := cli.dbCli.OpenStack.Zone.BatchUpdateZone(kt.Ctx, kt.Header(), updateReq); err != nil {
+		logs.Errorf("[%s] update zone failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, opt.AccountID, opt, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync zone to update zone success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		opt.AccountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) deleteZone(kt *kit.Kit, opt *SyncZoneOption, delCloudIDs []string) error {
+	if len(delCloudIDs) <= 0 {
+		return errors.New("zone delCloudIDs is <= 0, not delete")
+	}
+
+	delZoneFromCloud, err := cli.listZoneFromCloud(kt, opt)
+	if err != nil {
+		return err
+	}
+
+	delCloudMap := converter.StringSliceToMap(delCloudIDs)
+	for _, one := range delZoneFromCloud {
+		if _, exsit := delCloudMap[zoneCloudID(opt.AccountID, opt.Region, one.Name)]; exsit {
+			logs.Errorf("[%s] validate zone not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+				enumor.OpenStack, opt, len(delZoneFromCloud), kt.Rid)
+			return errors.New("validate zone not exist failed, before delete")
+		}
+	}
+
+	elems := slice.Split(delCloudIDs, constant.CloudResourceSyncMaxLimit)
+	for _, parts := range elems {
+		deleteReq := &datazone.ZoneBatchDeleteReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.OpenStack},
+					&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: parts},
+				},
+			},
+		}
+		if err = cli.dbCli.Global.Zone.BatchDeleteZone(kt.Ctx, kt.Header(), deleteReq); err != nil {
+			logs.Errorf("[%s] delete zone failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+				err, opt.AccountID, opt, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync zone to delete zone success, accountID: %s, count: %d, rid: %s", enumor.OpenStack,
+		opt.AccountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listZoneFromCloud(kt *kit.Kit, opt *SyncZoneOption) ([]typeszone.OpenStackZone, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	zoneOpt := &typeszone.OpenStackZoneListOption{
+		Region: opt.Region,
+	}
+	results, err := cli.cloudCli.ListZone(kt, zoneOpt)
+	if err != nil {
+		logs.Errorf("[%s] list zone from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.OpenStack,
+			err, opt.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (cli *client) listZoneFromDB(kt *kit.Kit, opt *SyncZoneOption) ([]corezone.BaseZone, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &datazone.ZoneListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.OpenStack},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: opt.Region},
+				&filter.AtomRule{Field: "extension.account_id", Op: filter.JSONEqual.Factory(),
+					Value: opt.AccountID},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	start := uint32(0)
+	results := make([]corezone.BaseZone, 0)
+	for {
+		req.Page.Start = start
+		zones, err := cli.dbCli.Global.Zone.ListZone(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] list zone from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.OpenStack,
+				err, opt.AccountID, req, kt.Rid)
+			return nil, err
+		}
+		results = append(results, zones.Details...)
+
+		if len(zones.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return results, nil
+}
+
+func isZoneChange(cloud typeszone.OpenStackZone, db corezone.BaseZone) bool {
+	return zoneState(cloud) != db.State
+}
@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	typeszone "hcm/pkg/adaptor/types/zone"
+	corezone "hcm/pkg/api/core/cloud/zone"
+)
+
+func TestDiffZone(t *testing.T) {
+	zoneFromCloud := []typeszone.OpenStackZone{
+		{Name: "nova", Available: true},
+		{Name: "az-1", Available: false},
+		{Name: "az-new", Available: true},
+	}
+	zoneFromDB := []corezone.BaseZone{
+		{ID: "1", CloudID: zoneCloudID("account", "RegionOne", "nova"), Name: "nova", State: zoneAvailableState},
+		{ID: "2", CloudID: zoneCloudID("account", "RegionOne", "az-1"), Name: "az-1", State: zoneAvailableState},
+		{ID: "3", CloudID: zoneCloudID("account", "RegionOne", "az-old"), Name: "az-old",
+			State: zoneAvailableState},
+	}
+
+	addSlice, updateMap, delCloudIDs := diffZone(zoneFromCloud, zoneFromDB)
+
+	expectAdd := []typeszone.OpenStackZone{{Name: "az-new", Available: true}}
+	if !reflect.DeepEqual(addSlice, expectAdd) {
+		t.Errorf("add zones %v is not as expected %v", addSlice, expectAdd)
+	}
+
+	expectUpdate := map[string]typeszone.OpenStackZone{"2": {Name: "az-1", Available: false}}
+	if !reflect.DeepEqual(updateMap, expectUpdate) {
+		t.Errorf("update zones %v is not as expected %v", updateMap, expectUpdate)
+	}
+
+	sort.Strings(delCloudIDs)
+	expectDel := []string{"account/RegionOne/az-old"}
+	if !reflect.DeepEqual(delCloudIDs, expectDel) {
+		t.Errorf("delete zone cloud ids %v is not as expected %v", delCloudIDs, expectDel)
+	}
+}
+
+func TestZoneCloudID(t *testing.T) {
+	// the same zone name in different accounts or regions should not conflict.
+	ids := map[string]struct{}{
+		zoneCloudID("account-1", "RegionOne", "nova"): {},
+		zoneCloudID("account-2", "RegionOne", "nova"): {},
+		zoneCloudID("account-1", "RegionTwo", "nova"): {},
+	}
+	if len(ids) != 3 {
+		t.Errorf("zone cloud ids should be different, got: %v", ids)
+	}
+}
@@ -67,13 +67,17 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 		return nil, err
 	}
 
-	if len(cvmFromCloud) == 0 && len(cvmFromCloud) == 0 {
+	if len(cvmFromCloud) == 0 {
 		return new(SyncResult), nil
 	}
 
 	addSlice, updateMap, delCloudIDs := common.Diff[typescvm.TCloudCvm, corecvm.Cvm[cvm.TCloudCvmExtension]](
 		cvmFromCloud, cvmFromDB, isCvmChange)
 
+	if common.ReportDiff(kt, enumor.CvmCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err := cli.deleteCvm(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
@@ -92,6 +96,9 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.TCloud, AccountID: params.AccountID,
+		ResType: enumor.CvmCloudResType}, cvmFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -429,6 +436,10 @@ func (cli *client) getImageMap(kt *kit.Kit, accountID string, region string,
 }
 
 func (cli *client) deleteCvm(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.CvmCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("cvm delCloudIDs is <= 0, not delete")
 	}
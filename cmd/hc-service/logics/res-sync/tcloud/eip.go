@@ -73,6 +73,10 @@ func (cli *client) Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption)
 	addEip, updateMap, delCloudIDs := common.Diff[*typeseip.TCloudEip,
 		*dataeip.EipExtResult[dataeip.TCloudEipExtensionResult]](eipFromCloud, eipFromDB, isEipChange)
 
+	if common.ReportDiff(kt, enumor.EipCloudResType, addEip, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteEip(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
@@ -91,6 +95,9 @@ func (cli *client) Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption)
 		}
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.TCloud, AccountID: params.AccountID,
+		ResType: enumor.EipCloudResType}, eipFromDB, addEip, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -165,6 +172,10 @@ func (cli *client) RemoveEipDeleteFromCloud(kt *kit.Kit, accountID string, regio
 }
 
 func (cli *client) deleteEip(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.EipCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) == 0 {
 		return fmt.Errorf("delete eip, cloudIDs is required")
 	}
@@ -287,6 +287,7 @@ func (cli *client) RemoveImageDeleteFromCloud(kt *kit.Kit, accountID string, reg
 			Rules: []filter.RuleFactory{
 				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.TCloud},
 				&filter.AtomRule{Field: "extension.region", Op: filter.JSONEqual.Factory(), Value: region},
+				&filter.AtomRule{Field: "type", Op: filter.Equal.Factory(), Value: constant.PublicImageType},
 			},
 		},
 		Page: &core.BasePage{
@@ -0,0 +1,237 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	typekp "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/api/core"
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncKeyPairOption ...
+type SyncKeyPairOption struct {
+	// BkBizID 由 HCM 密钥对推送到云上时，通过同步写入DB，需要传入业务ID
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+	// KeyPairID 由 HCM 密钥对推送到云上时传入，用于记录云上密钥对来源
+	KeyPairID string `json:"key_pair_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt SyncKeyPairOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// KeyPair sync ssh key pair.
+func (cli *client) KeyPair(kt *kit.Kit, params *SyncBaseParams, opt *SyncKeyPairOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	kpFromCloud, err := cli.listKeyPairFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	kpFromDB, err := cli.listKeyPairFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(kpFromCloud) == 0 && len(kpFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typekp.KeyPair, corekp.CloudKeyPair](kpFromCloud, kpFromDB,
+		common.IsKeyPairChange)
+
+	if common.ReportDiff(kt, enumor.KeyPairCloudResType, addSlice, updateMap, delCloudIDs) {
+		return new(SyncResult), nil
+	}
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteKeyPair(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	createdIDs := make([]string, 0)
+	if len(addSlice) > 0 {
+		createdIDs, err = common.CreateCloudKeyPair(kt, cli.dbCli, enumor.TCloud, params.AccountID, addSlice,
+			opt.BkBizID, opt.KeyPairID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = common.UpdateCloudKeyPair(kt, cli.dbCli, enumor.TCloud, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.TCloud, AccountID: params.AccountID,
+		ResType: enumor.KeyPairCloudResType}, kpFromDB, addSlice, updateMap, delCloudIDs)
+
+	return &SyncResult{CreatedIds: createdIDs}, nil
+}
+
+// RemoveKeyPairDeleteFromCloud ...
+func (cli *client) RemoveKeyPairDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.TCloud},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.CloudResourceSyncMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Global.KeyPair.ListCloudKeyPair(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list key pair failed, err: %v, req: %v, rid: %s",
+				enumor.TCloud, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listKeyPairFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.CloudID)
+			}
+
+			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if err = cli.deleteKeyPair(kt, accountID, region, delCloudIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.CloudResourceSyncMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.CloudResourceSyncMaxLimit
+	}
+
+	return nil
+}
+
+func (cli *client) deleteKeyPair(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.KeyPairCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete key pair, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delFromCloud, err := cli.listKeyPairFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delFromCloud) > 0 {
+		logs.Errorf("[%s] validate key pair not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.TCloud, checkParams, len(delFromCloud), kt.Rid)
+		return fmt.Errorf("validate key pair not exist failed, before delete")
+	}
+
+	return common.DeleteCloudKeyPair(kt, cli.dbCli, enumor.TCloud, accountID, region, delCloudIDs)
+}
+
+func (cli *client) listKeyPairFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typekp.KeyPair, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	keyPairs := make([]typekp.KeyPair, 0, len(params.CloudIDs))
+	for _, part := range slice.Split(params.CloudIDs, adcore.TCloudQueryLimit) {
+		opt := &adcore.TCloudListOption{
+			Region:   params.Region,
+			CloudIDs: part,
+			Page: &adcore.TCloudPage{
+				Offset: 0,
+				Limit:  adcore.TCloudQueryLimit,
+			},
+		}
+		result, err := cli.cloudCli.ListKeyPair(kt, opt)
+		if err != nil {
+			logs.Errorf("[%s] list key pair from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+				enumor.TCloud, err, params.AccountID, opt, kt.Rid)
+			return nil, err
+		}
+		keyPairs = append(keyPairs, result...)
+	}
+
+	return keyPairs, nil
+}
+
+func (cli *client) listKeyPairFromDB(kt *kit.Kit, params *SyncBaseParams) ([]corekp.CloudKeyPair, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return common.ListCloudKeyPairFromDB(kt, cli.dbCli, enumor.TCloud, params.AccountID, params.Region,
+		params.CloudIDs)
+}
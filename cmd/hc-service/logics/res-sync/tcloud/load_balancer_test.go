@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"sort"
+	"testing"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/tools/converter"
+)
+
+type tcloudLB = corelb.LoadBalancer[corelb.TCloudLoadBalancerExtension]
+
+func newDBLoadBalancer(id, cloudID, name string, zones []string) tcloudLB {
+	return tcloudLB{
+		BaseLoadBalancer: corelb.BaseLoadBalancer{ID: id, CloudID: cloudID, Name: name, LBType: "OPEN",
+			Status: "1", Zones: zones},
+		Extension: &corelb.TCloudLoadBalancerExtension{VipIsp: converter.ValToPtr("CMCC")},
+	}
+}
+
+func newCloudLoadBalancer(cloudID, name string, zones []string) typelb.TCloudLoadBalancer {
+	return typelb.TCloudLoadBalancer{CloudID: cloudID, Name: name, LBType: "OPEN", Status: "1", Zones: zones,
+		Extension: &corelb.TCloudLoadBalancerExtension{VipIsp: converter.ValToPtr("CMCC")}}
+}
+
+func TestLoadBalancerDiff(t *testing.T) {
+	dataFromDB := []tcloudLB{
+		newDBLoadBalancer("00000001", "lb-same", "same", []string{"ap-guangzhou-3", "ap-guangzhou-4"}),
+		newDBLoadBalancer("00000002", "lb-renamed", "old", nil),
+		newDBLoadBalancer("00000003", "lb-deleted", "deleted", nil),
+	}
+	dataFromCloud := []typelb.TCloudLoadBalancer{
+		// zones in different order are not changed.
+		newCloudLoadBalancer("lb-same", "same", []string{"ap-guangzhou-4", "ap-guangzhou-3"}),
+		newCloudLoadBalancer("lb-renamed", "new", nil),
+		newCloudLoadBalancer("lb-added", "added", nil),
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typelb.TCloudLoadBalancer, tcloudLB](dataFromCloud,
+		dataFromDB, isLoadBalancerChange)
+
+	if len(addSlice) != 1 || addSlice[0].CloudID != "lb-added" {
+		t.Errorf("expect lb-added to be added, got: %+v", addSlice)
+	}
+	if len(updateMap) != 1 || updateMap["00000002"].Name != "new" {
+		t.Errorf("expect lb-renamed to be updated, got: %+v", updateMap)
+	}
+	if len(delCloudIDs) != 1 || delCloudIDs[0] != "lb-deleted" {
+		t.Errorf("expect lb-deleted to be deleted, got: %v", delCloudIDs)
+	}
+}
+
+func TestIsLoadBalancerChange(t *testing.T) {
+	db := newDBLoadBalancer("00000001", "lb-1", "lb", []string{"ap-guangzhou-3"})
+
+	cloud := newCloudLoadBalancer("lb-1", "lb", []string{"ap-guangzhou-3"})
+	if isLoadBalancerChange(cloud, db) {
+		t.Errorf("same load balancer should not be changed")
+	}
+
+	cloud.Extension = &corelb.TCloudLoadBalancerExtension{VipIsp: converter.ValToPtr("CTCC")}
+	if !isLoadBalancerChange(cloud, db) {
+		t.Errorf("load balancer with changed extension should be changed")
+	}
+
+	cloud = newCloudLoadBalancer("lb-1", "lb", []string{"ap-guangzhou-3"})
+	cloud.PublicIPv4Addresses = []string{"1.1.1.1"}
+	if !isLoadBalancerChange(cloud, db) {
+		t.Errorf("load balancer with changed public ip should be changed")
+	}
+}
+
+func TestListenerAndTargetDiff(t *testing.T) {
+	listenerFromDB := []corelb.Listener[corelb.TCloudListenerExtension]{
+		{BaseListener: corelb.BaseListener{ID: "00000001", CloudID: "lbl-1", Name: "http", Protocol: "TCP",
+			Port: 80}},
+	}
+	listenerFromCloud := []typelb.TCloudListener{
+		{CloudID: "lbl-1", Name: "http", Protocol: "TCP", Port: 8080},
+	}
+	_, updateListener, _ := common.Diff[typelb.TCloudListener, corelb.Listener[corelb.TCloudListenerExtension]](
+		listenerFromCloud, listenerFromDB, isListenerChange)
+	if len(updateListener) != 1 {
+		t.Errorf("listener with changed port should be updated, got: %+v", updateListener)
+	}
+
+	targetFromDB := []corelb.Target[corelb.TCloudTargetExtension]{
+		{BaseTarget: corelb.BaseTarget{ID: "00000010", CloudID: corelb.GenTargetCloudID("ins-1", 80),
+			CloudTargetID: "ins-1", IP: "10.0.0.1", Port: 80, Weight: 10}},
+		{BaseTarget: corelb.BaseTarget{ID: "00000011", CloudID: corelb.GenTargetCloudID("ins-1", 81),
+			CloudTargetID: "ins-1", IP: "10.0.0.1", Port: 81, Weight: 10}},
+	}
+	// the same instance with different port is a different target.
+	targetFromCloud := []*typelb.TCloudTarget{
+		{CloudTargetID: "ins-1", IP: "10.0.0.1", Port: 80, Weight: 20},
+		{CloudTargetID: "ins-1", IP: "10.0.0.1", Port: 82, Weight: 10},
+	}
+	addTarget, updateTarget, delTarget := common.Diff[*typelb.TCloudTarget,
+		corelb.Target[corelb.TCloudTargetExtension]](targetFromCloud, targetFromDB, isTargetChange)
+
+	if len(addTarget) != 1 || addTarget[0].GetCloudID() != "ins-1:82" {
+		t.Errorf("expect target ins-1:82 to be added, got: %+v", addTarget)
+	}
+	if len(updateTarget) != 1 || updateTarget["00000010"] == nil || updateTarget["00000010"].Weight != 20 {
+		t.Errorf("expect target ins-1:80 weight to be updated, got: %+v", updateTarget)
+	}
+	sort.Strings(delTarget)
+	if len(delTarget) != 1 || delTarget[0] != "ins-1:81" {
+		t.Errorf("expect target ins-1:81 to be deleted, got: %v", delTarget)
+	}
+}
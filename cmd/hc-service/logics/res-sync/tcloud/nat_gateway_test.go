@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"testing"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/tools/converter"
+)
+
+type tcloudNat = corenat.NatGateway[corenat.TCloudNatGatewayExtension]
+
+func newDBNatGateway(id, cloudID string, cloudEipIDs []string) tcloudNat {
+	return tcloudNat{
+		BaseNatGateway: corenat.BaseNatGateway{ID: id, CloudID: cloudID, Name: "nat", Status: "AVAILABLE",
+			CloudVpcID: "vpc-1", CloudEipIDs: cloudEipIDs},
+		Extension: &corenat.TCloudNatGatewayExtension{NetworkState: converter.ValToPtr("AVAILABLE")},
+	}
+}
+
+func newCloudNatGateway(cloudID string, cloudEipIDs []string) typenat.TCloudNatGateway {
+	return typenat.TCloudNatGateway{CloudID: cloudID, Name: "nat", Status: "AVAILABLE", CloudVpcID: "vpc-1",
+		CloudEipIDs: cloudEipIDs,
+		Extension:   &corenat.TCloudNatGatewayExtension{NetworkState: converter.ValToPtr("AVAILABLE")}}
+}
+
+func TestNatGatewayDiff(t *testing.T) {
+	dataFromDB := []tcloudNat{
+		newDBNatGateway("00000001", "nat-same", []string{"eip-1", "eip-2"}),
+		newDBNatGateway("00000002", "nat-eip-changed", []string{"eip-3"}),
+		newDBNatGateway("00000003", "nat-deleted", nil),
+	}
+	dataFromCloud := []typenat.TCloudNatGateway{
+		// eips in different order are not changed.
+		newCloudNatGateway("nat-same", []string{"eip-2", "eip-1"}),
+		newCloudNatGateway("nat-eip-changed", []string{"eip-3", "eip-4"}),
+		newCloudNatGateway("nat-added", nil),
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typenat.TCloudNatGateway, tcloudNat](dataFromCloud,
+		dataFromDB, isNatGatewayChange)
+
+	if len(addSlice) != 1 || addSlice[0].CloudID != "nat-added" {
+		t.Errorf("expect nat-added to be added, got: %+v", addSlice)
+	}
+	if len(updateMap) != 1 || len(updateMap["00000002"].CloudEipIDs) != 2 {
+		t.Errorf("expect nat with bound eip changed to be updated, got: %+v", updateMap)
+	}
+	if len(delCloudIDs) != 1 || delCloudIDs[0] != "nat-deleted" {
+		t.Errorf("expect nat-deleted to be deleted, got: %v", delCloudIDs)
+	}
+}
+
+func TestIsNatGatewayChange(t *testing.T) {
+	db := newDBNatGateway("00000001", "nat-1", nil)
+
+	cloud := newCloudNatGateway("nat-1", nil)
+	if isNatGatewayChange(cloud, db) {
+		t.Errorf("same nat gateway should not be changed")
+	}
+
+	cloud.Extension = &corenat.TCloudNatGatewayExtension{NetworkState: converter.ValToPtr("UNAVAILABLE")}
+	if !isNatGatewayChange(cloud, db) {
+		t.Errorf("nat gateway with changed network state should be changed")
+	}
+
+	cloud = newCloudNatGateway("nat-1", nil)
+	cloud.CloudSubnetID = "subnet-1"
+	if !isNatGatewayChange(cloud, db) {
+		t.Errorf("nat gateway with changed subnet should be changed")
+	}
+}
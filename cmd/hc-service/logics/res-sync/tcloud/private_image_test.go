@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"testing"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typesimage "hcm/pkg/adaptor/types/image"
+	dataproto "hcm/pkg/api/data-service/cloud/image"
+	"hcm/pkg/criteria/constant"
+)
+
+type tcloudImage = dataproto.ImageExtResult[dataproto.TCloudImageExtensionResult]
+
+func newDBPrivateImage(id, cloudID, name, state string) tcloudImage {
+	return tcloudImage{ID: id, CloudID: cloudID, Name: name, State: state, Type: constant.PrivateImageType,
+		Extension: &dataproto.TCloudImageExtensionResult{Region: "ap-guangzhou"}}
+}
+
+func newCloudPrivateImage(cloudID, name, state string) typesimage.TCloudImage {
+	return typesimage.TCloudImage{CloudID: cloudID, Name: name, State: state, Type: constant.PrivateImageType}
+}
+
+func TestPrivateImageDiff(t *testing.T) {
+	dataFromDB := []tcloudImage{
+		newDBPrivateImage("00000001", "img-same", "image", "NORMAL"),
+		newDBPrivateImage("00000002", "img-creating", "image", "CREATING"),
+		newDBPrivateImage("00000003", "img-deleted", "image", "NORMAL"),
+	}
+	dataFromCloud := []typesimage.TCloudImage{
+		newCloudPrivateImage("img-same", "image", "NORMAL"),
+		newCloudPrivateImage("img-creating", "image", "NORMAL"),
+		newCloudPrivateImage("img-copied", "image", "SYNCING"),
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typesimage.TCloudImage, tcloudImage](dataFromCloud,
+		dataFromDB, isPrivateImageChange)
+
+	if len(addSlice) != 1 || addSlice[0].CloudID != "img-copied" {
+		t.Errorf("expect img-copied to be added, got: %+v", addSlice)
+	}
+	if len(updateMap) != 1 || updateMap["00000002"].State != "NORMAL" {
+		t.Errorf("expect image finished creating to be updated, got: %+v", updateMap)
+	}
+	if len(delCloudIDs) != 1 || delCloudIDs[0] != "img-deleted" {
+		t.Errorf("expect img-deleted to be deleted, got: %v", delCloudIDs)
+	}
+}
+
+func TestIsPrivateImageChange(t *testing.T) {
+	db := newDBPrivateImage("00000001", "img-1", "image", "NORMAL")
+
+	if isPrivateImageChange(newCloudPrivateImage("img-1", "image", "NORMAL"), db) {
+		t.Errorf("same private image should not be changed")
+	}
+
+	if !isPrivateImageChange(newCloudPrivateImage("img-1", "image-renamed", "NORMAL"), db) {
+		t.Errorf("private image with name changed should be changed")
+	}
+
+	if !isPrivateImageChange(newCloudPrivateImage("img-1", "image", "IMPORTFAILED"), db) {
+		t.Errorf("private image with state changed should be changed")
+	}
+}
@@ -73,6 +73,17 @@ func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncS
 	addSlice, updateMap, delCloudIDs := common.Diff[securitygroup.TCloudSG, cloudcore.SecurityGroup[cloudcore.TCloudSecurityGroupExtension]](
 		sgFromCloud, sgFromDB, isSGChange)
 
+	// 演练模式下不写入db，仅继续对比云上仍存在的安全组的安全组规则
+	if common.ReportDiff(kt, enumor.SecurityGroupCloudResType, addSlice, updateMap, delCloudIDs) {
+		dryRunParams := *params
+		dryRunParams.CloudIDs = common.ExcludeCloudIDs(params.CloudIDs, delCloudIDs)
+		if len(dryRunParams.CloudIDs) == 0 {
+			return new(SyncResult), nil
+		}
+		params = &dryRunParams
+		addSlice, updateMap, delCloudIDs = nil, nil, nil
+	}
+
 	if len(delCloudIDs) > 0 {
 		if err = cli.deleteSG(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
 			return nil, err
@@ -115,6 +126,9 @@ func (cli *client) SecurityGroup(kt *kit.Kit, params *SyncBaseParams, opt *SyncS
 		return nil, err
 	}
 
+	common.RecordDrift(kt, cli.dbCli, &common.DriftOption{Vendor: enumor.TCloud, AccountID: params.AccountID,
+		ResType: enumor.SecurityGroupCloudResType}, sgFromDB, addSlice, updateMap, delCloudIDs)
+
 	return new(SyncResult), nil
 }
 
@@ -196,6 +210,10 @@ func (cli *client) createSG(kt *kit.Kit, accountID string, region string,
 }
 
 func (cli *client) deleteSG(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if common.ReportDiffCloudIDs(kt, enumor.SecurityGroupCloudResType, nil, nil, delCloudIDs) {
+		return nil
+	}
+
 	if len(delCloudIDs) <= 0 {
 		return fmt.Errorf("sg delCloudIDs is <= 0, not delete")
 	}
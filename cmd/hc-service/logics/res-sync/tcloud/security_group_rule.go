@@ -20,6 +20,9 @@
 package tcloud
 
 import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	securitygrouprule "hcm/pkg/adaptor/types/security-group-rule"
 	"hcm/pkg/api/core"
 	corecloud "hcm/pkg/api/core/cloud"
@@ -143,6 +146,10 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 		createRules = append(createRules, *rule)
 	}
 
+	if reportSGRuleDiff(kt, rulesFromDB, createRules, updateRules, deleteRuleIDs) {
+		return new(SyncResult), nil
+	}
+
 	if len(deleteRuleIDs) != 0 {
 		if err = cli.deleteSGRule(kt, sg.ID, deleteRuleIDs); err != nil {
 			return nil, err
@@ -163,9 +170,89 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 		}
 	}
 
+	cli.recordSGRuleDrift(kt, opt.AccountID, rulesFromDB, createRules, updateRules, deleteRuleIDs)
+
 	return syncResult, nil
 }
 
+// recordSGRuleDrift 记录安全组规则漂移事件，腾讯云安全组规则没有云ID，使用"云安全组ID/规则类型/规则索引"作为云资源ID。
+func (cli *client) recordSGRuleDrift(kt *kit.Kit, accountID string, rulesFromDB []corecloud.TCloudSecurityGroupRule,
+	createRules []corecloud.TCloudSecurityGroupRule, updateRules map[string]*corecloud.TCloudSecurityGroupRule,
+	deleteRuleIDs []string) {
+
+	if len(createRules) == 0 && len(updateRules) == 0 && len(deleteRuleIDs) == 0 {
+		return
+	}
+
+	opt := &common.DriftOption{Vendor: enumor.TCloud, AccountID: accountID,
+		ResType: enumor.SecurityGroupRuleCloudResType}
+	events := make([]protocloud.ResDriftEventCreate, 0, len(createRules)+len(updateRules)+len(deleteRuleIDs))
+	addEvent := func(action enumor.DriftEventAction, resID string, rule *corecloud.TCloudSecurityGroupRule,
+		before, after any) {
+
+		cloudResID := sgRuleCloudResID(rule)
+		event, err := common.NewDriftEvent(opt, action, resID, cloudResID, before, after)
+		if err != nil {
+			logs.Errorf("[%s] build sg rule %s drift event failed, err: %v, cloud_res_id: %s, rid: %s",
+				enumor.TCloud, action, err, cloudResID, kt.Rid)
+			return
+		}
+		events = append(events, event)
+	}
+
+	for index := range createRules {
+		addEvent(enumor.AddDriftEvent, "", &createRules[index], nil, createRules[index])
+	}
+
+	for index, one := range rulesFromDB {
+		if rule, exist := updateRules[one.ID]; exist {
+			addEvent(enumor.UpdateDriftEvent, one.ID, &rulesFromDB[index], one, rule)
+			continue
+		}
+
+		if slice.IsItemInSlice(deleteRuleIDs, one.ID) {
+			addEvent(enumor.DeleteDriftEvent, one.ID, &rulesFromDB[index], one, nil)
+		}
+	}
+
+	common.SaveDriftEvents(kt, cli.dbCli, events)
+}
+
+// reportSGRuleDiff 记录安全组规则对比结果，返回是否为演练模式。
+func reportSGRuleDiff(kt *kit.Kit, rulesFromDB []corecloud.TCloudSecurityGroupRule,
+	createRules []corecloud.TCloudSecurityGroupRule, updateRules map[string]*corecloud.TCloudSecurityGroupRule,
+	deleteRuleIDs []string) bool {
+
+	if common.GetSyncReport(kt) == nil {
+		return false
+	}
+
+	addIDs := make([]string, 0, len(createRules))
+	for index := range createRules {
+		addIDs = append(addIDs, sgRuleCloudResID(&createRules[index]))
+	}
+
+	updateIDs := make([]string, 0, len(updateRules))
+	delIDs := make([]string, 0, len(deleteRuleIDs))
+	for index, one := range rulesFromDB {
+		if _, exist := updateRules[one.ID]; exist {
+			updateIDs = append(updateIDs, sgRuleCloudResID(&rulesFromDB[index]))
+			continue
+		}
+
+		if slice.IsItemInSlice(deleteRuleIDs, one.ID) {
+			delIDs = append(delIDs, sgRuleCloudResID(&rulesFromDB[index]))
+		}
+	}
+
+	return common.ReportDiffCloudIDs(kt, enumor.SecurityGroupRuleCloudResType, addIDs, updateIDs, delIDs)
+}
+
+// sgRuleCloudResID 腾讯云安全组规则没有云ID，使用"云安全组ID/规则类型/规则索引"作为云资源ID。
+func sgRuleCloudResID(rule *corecloud.TCloudSecurityGroupRule) string {
+	return fmt.Sprintf("%s/%s/%d", rule.CloudSecurityGroupID, rule.Type, rule.CloudPolicyIndex)
+}
+
 // listSGRuleFromCloud list tcloud security group rule from database
 func (cli *client) listSGRuleFromDB(kt *kit.Kit, sgID string) (
 	[]corecloud.TCloudSecurityGroupRule, error) {
@@ -101,6 +101,29 @@ func (svc *service) HuaWeiAccountCheck(cts *rest.Contexts) (interface{}, error)
 	return nil, err
 }
 
+// OpenStackAccountCheck authentication information and project of application credential.
+func (svc *service) OpenStackAccountCheck(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.OpenStackAccountCheckReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Adaptor().OpenStack(&types.OpenStackCredential{
+		CloudAuthUrl:   req.CloudAuthUrl,
+		CloudProjectID: req.CloudProjectID,
+		CloudSecretID:  req.CloudSecretID,
+		CloudSecretKey: req.CloudSecretKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, client.AccountCheck(cts.Kit)
+}
+
 // GcpAccountCheck ...
 func (svc *service) GcpAccountCheck(cts *rest.Contexts) (interface{}, error) {
 	req := new(proto.GcpAccountCheckReq)
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package account
+
+import (
+	proto "hcm/pkg/api/hc-service/account"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// ListOpenStackAccountRegion 获取 openstack 账号可用的地域，openstack 地域来自 keystone 服务目录，各部署之间互不相同，不入库。
+func (svc *service) ListOpenStackAccountRegion(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.ListOpenStackAccountRegionReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.OpenStack(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	regions, err := client.ListRegion(cts.Kit)
+	if err != nil {
+		logs.Errorf("request adaptor list openstack region failed, err: %v, account: %s, rid: %s", err,
+			req.AccountID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return regions, nil
+}
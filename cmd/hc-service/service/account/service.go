@@ -41,6 +41,7 @@ func InitAccountService(cap *capability.Capability) {
 	h.Add("HuaWeiAccountCheck", http.MethodPost, "/vendors/huawei/accounts/check", svc.HuaWeiAccountCheck)
 	h.Add("GcpAccountCheck", http.MethodPost, "/vendors/gcp/accounts/check", svc.GcpAccountCheck)
 	h.Add("AzureAccountCheck", http.MethodPost, "/vendors/azure/accounts/check", svc.AzureAccountCheck)
+	h.Add("OpenStackAccountCheck", http.MethodPost, "/vendors/openstack/accounts/check", svc.OpenStackAccountCheck)
 
 	// 获取账号配额
 	h.Add("GetTCloudAccountZoneQuota", http.MethodPost, "/vendors/tcloud/accounts/zones/quotas",
@@ -50,6 +51,10 @@ func InitAccountService(cap *capability.Capability) {
 	h.Add("GetGcpAccountRegionQuota", http.MethodPost, "/vendors/gcp/accounts/regions/quotas",
 		svc.GetGcpAccountRegionQuota)
 
+	// 获取账号可用地域
+	h.Add("ListOpenStackAccountRegion", http.MethodPost, "/vendors/openstack/accounts/regions/list",
+		svc.ListOpenStackAccountRegion)
+
 	h.Load(cap.WebService)
 }
 
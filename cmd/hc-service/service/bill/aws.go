@@ -28,6 +28,7 @@ import (
 
 	"hcm/pkg/adaptor/aws"
 	typesBill "hcm/pkg/adaptor/types/bill"
+	adcore "hcm/pkg/adaptor/types/core"
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/core/cloud"
 	dataservice "hcm/pkg/api/data-service"
@@ -752,3 +753,75 @@ func sanitizeString(str string) string {
 	reg := regexp.MustCompile(`[^a-z0-9.\-]`)
 	return reg.ReplaceAllString(strings.ToLower(str), "")
 }
+
+// AwsSyncBillItem sync aws bill items of the month into db.
+func (b bill) AwsSyncBillItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcbillservice.BillItemSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	beginDate, endDate, err := billMonthDateRange(req.BillMonth)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cli, err := b.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		logs.Errorf("aws bill get cloud client failed, req: %+v, err: %+v", req, err)
+		return nil, err
+	}
+
+	billInfo, err := b.GetBillInfo(cts.Kit, req.AccountID)
+	if err != nil {
+		logs.Errorf("aws bill config get base info db failed, accountID: %s, err: %+v", req.AccountID, err)
+		return nil, err
+	}
+	if billInfo == nil {
+		return nil, errf.Newf(errf.RecordNotFound, "account_id: %s is not found", req.AccountID)
+	}
+
+	if billInfo.Status != constant.StatusSuccess {
+		return nil, errf.Newf(errf.Aborted, "account_id: %s has not ready yet", req.AccountID)
+	}
+
+	aggregator := newBillItemAggregator(enumor.Aws, req.AccountID, req.BillMonth)
+	opt := &typesBill.AwsBillListOption{
+		AccountID: req.AccountID,
+		BeginDate: beginDate,
+		EndDate:   endDate,
+		Page:      &typesBill.AwsBillPage{Offset: 0, Limit: adcore.AwsQueryLimit},
+	}
+	for {
+		list, err := cli.GetBillItemSummary(cts.Kit, opt, billInfo)
+		if err != nil {
+			logs.Errorf("request adaptor aws bill summary failed, opt: %+v, err: %v, rid: %s", opt, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range list {
+			item := protobill.BillItemCreateReq{
+				ProductCode: one["line_item_product_code"],
+				ProductName: one["product_product_name"],
+				CloudResID:  one["line_item_resource_id"],
+				Region:      one["product_region"],
+				Currency:    one["line_item_currency_code"],
+			}
+			if err = aggregator.Add(item, one["cost"]); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(list) < int(opt.Page.Limit) {
+			break
+		}
+
+		opt.Page.Offset += opt.Page.Limit
+	}
+
+	return b.saveBillItems(cts.Kit, enumor.Aws, req.AccountID, req.BillMonth, aggregator.Items())
+}
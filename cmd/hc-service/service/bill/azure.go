@@ -22,11 +22,15 @@ package bill
 
 import (
 	typesBill "hcm/pkg/adaptor/types/bill"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
 	hcbillservice "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/consumption/armconsumption"
 )
 
 // AzureGetBillList get azure bill list.
@@ -64,3 +68,92 @@ func (b bill) AzureGetBillList(cts *rest.Contexts) (interface{}, error) {
 		Details:  list.Value,
 	}, nil
 }
+
+// AzureSyncBillItem sync azure bill items of the month into db.
+func (b bill) AzureSyncBillItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcbillservice.BillItemSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	beginDate, endDate, err := billMonthDateRange(req.BillMonth)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cli, err := b.ad.Azure(cts.Kit, req.AccountID)
+	if err != nil {
+		logs.Errorf("azure request adaptor client err, req: %+v, err: %+v", req, err)
+		return nil, err
+	}
+
+	aggregator := newBillItemAggregator(enumor.Azure, req.AccountID, req.BillMonth)
+	opt := &typesBill.AzureBillListOption{
+		AccountID: req.AccountID,
+		BeginDate: beginDate,
+		EndDate:   endDate,
+		Page:      &typesBill.AzureBillPage{Limit: typesBill.AzureQueryLimit},
+	}
+	for {
+		list, err := cli.GetBillList(cts.Kit, opt)
+		if err != nil {
+			logs.Errorf("azure request adaptor list bill failed, opt: %+v, err: %v, rid: %s", opt, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range list.Value {
+			if err = addAzureUsageDetail(aggregator, one); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(converter.PtrToVal(list.NextLink)) == 0 {
+			break
+		}
+
+		opt.Page.NextLink = converter.PtrToVal(list.NextLink)
+	}
+
+	return b.saveBillItems(cts.Kit, enumor.Azure, req.AccountID, req.BillMonth, aggregator.Items())
+}
+
+// addAzureUsageDetail add azure legacy or modern usage detail into aggregator.
+func addAzureUsageDetail(aggregator *billItemAggregator, detail armconsumption.UsageDetailClassification) error {
+	switch one := detail.(type) {
+	case *armconsumption.LegacyUsageDetail:
+		if one.Properties == nil {
+			return nil
+		}
+
+		item := dsbill.BillItemCreateReq{
+			ProductCode: converter.PtrToVal(one.Properties.ConsumedService),
+			ProductName: converter.PtrToVal(one.Properties.Product),
+			CloudResID:  converter.PtrToVal(one.Properties.ResourceID),
+			ResName:     converter.PtrToVal(one.Properties.ResourceName),
+			Region:      converter.PtrToVal(one.Properties.ResourceLocation),
+			Currency:    converter.PtrToVal(one.Properties.BillingCurrency),
+		}
+		return aggregator.AddFloat(item, converter.PtrToVal(one.Properties.Cost))
+
+	case *armconsumption.ModernUsageDetail:
+		if one.Properties == nil {
+			return nil
+		}
+
+		item := dsbill.BillItemCreateReq{
+			ProductCode: converter.PtrToVal(one.Properties.ConsumedService),
+			ProductName: converter.PtrToVal(one.Properties.Product),
+			CloudResID:  converter.PtrToVal(one.Properties.InstanceName),
+			Region:      converter.PtrToVal(one.Properties.ResourceLocation),
+			Currency:    converter.PtrToVal(one.Properties.BillingCurrencyCode),
+		}
+		return aggregator.AddFloat(item, converter.PtrToVal(one.Properties.CostInBillingCurrency))
+
+	default:
+		return nil
+	}
+}
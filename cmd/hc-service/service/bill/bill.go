@@ -53,6 +53,12 @@ func InitBillService(cap *capability.Capability) {
 	h.Add("AzureGetBillList", "POST", "/vendors/azure/bills/list", v.AzureGetBillList)
 	h.Add("GcpGetBillList", "POST", "/vendors/gcp/bills/list", v.GcpGetBillList)
 
+	h.Add("TCloudSyncBillItem", "POST", "/vendors/tcloud/bills/items/sync", v.TCloudSyncBillItem)
+	h.Add("AwsSyncBillItem", "POST", "/vendors/aws/bills/items/sync", v.AwsSyncBillItem)
+	h.Add("HuaWeiSyncBillItem", "POST", "/vendors/huawei/bills/items/sync", v.HuaWeiSyncBillItem)
+	h.Add("AzureSyncBillItem", "POST", "/vendors/azure/bills/items/sync", v.AzureSyncBillItem)
+	h.Add("GcpSyncBillItem", "POST", "/vendors/gcp/bills/items/sync", v.GcpSyncBillItem)
+
 	h.Load(cap.WebService)
 }
 
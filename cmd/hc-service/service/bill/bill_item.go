@@ -0,0 +1,175 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
+	hcbillservice "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/math"
+)
+
+// billItemAggregator 按云产品、云资源聚合账单明细。云厂商返回的账单通常按天或按计费项拆分为多条记录，
+// 入库前需要汇总为每月每个资源一条记录。
+type billItemAggregator struct {
+	vendor    enumor.Vendor
+	accountID string
+	billMonth string
+
+	keys  []string
+	items map[string]*billItemCost
+}
+
+type billItemCost struct {
+	item  dsbill.BillItemCreateReq
+	cost  math.Decimal
+	lines int
+}
+
+func newBillItemAggregator(vendor enumor.Vendor, accountID, billMonth string) *billItemAggregator {
+	return &billItemAggregator{
+		vendor:    vendor,
+		accountID: accountID,
+		billMonth: billMonth,
+		keys:      make([]string, 0),
+		items:     make(map[string]*billItemCost),
+	}
+}
+
+// Add add one bill line into aggregator, cost is decimal string.
+func (a *billItemAggregator) Add(item dsbill.BillItemCreateReq, cost string) error {
+	if len(cost) == 0 {
+		cost = "0"
+	}
+
+	decimal, err := math.NewDecimalFromString(cost)
+	if err != nil {
+		return fmt.Errorf("parse bill cost %s failed, err: %v", cost, err)
+	}
+
+	a.add(item, decimal)
+	return nil
+}
+
+// AddFloat add one bill line into aggregator, cost is float.
+func (a *billItemAggregator) AddFloat(item dsbill.BillItemCreateReq, cost float64) error {
+	decimal, err := math.NewDecimalFromFloat(cost)
+	if err != nil {
+		return err
+	}
+
+	a.add(item, decimal)
+	return nil
+}
+
+// vendorDefaultCurrency 云厂商账单未返回币种时使用的默认币种
+var vendorDefaultCurrency = map[enumor.Vendor]string{
+	enumor.TCloud: constant.CurrencyCNY,
+	enumor.HuaWei: constant.CurrencyCNY,
+	enumor.Aws:    constant.CurrencyUSD,
+	enumor.Azure:  constant.CurrencyUSD,
+	enumor.Gcp:    constant.CurrencyUSD,
+}
+
+func (a *billItemAggregator) add(item dsbill.BillItemCreateReq, cost math.Decimal) {
+	item.Currency = strings.ToUpper(strings.TrimSpace(item.Currency))
+	if len(item.Currency) == 0 {
+		item.Currency = vendorDefaultCurrency[a.vendor]
+	}
+
+	key := item.ProductCode + "/" + item.CloudResID + "/" + item.Currency
+	exist, ok := a.items[key]
+	if !ok {
+		item.Vendor = a.vendor
+		item.AccountID = a.accountID
+		item.BillMonth = a.billMonth
+		a.keys = append(a.keys, key)
+		a.items[key] = &billItemCost{item: item, cost: cost, lines: 1}
+		return
+	}
+
+	exist.cost = exist.cost.Add(cost)
+	exist.lines++
+	if len(exist.item.ResName) == 0 {
+		exist.item.ResName = item.ResName
+	}
+	if len(exist.item.Region) == 0 {
+		exist.item.Region = item.Region
+	}
+}
+
+// Items return aggregated bill items.
+func (a *billItemAggregator) Items() []dsbill.BillItemCreateReq {
+	result := make([]dsbill.BillItemCreateReq, 0, len(a.keys))
+	for _, key := range a.keys {
+		one := a.items[key]
+		item := one.item
+		item.Cost = one.cost.ToString()
+		item.Extension = billItemExtension(one.lines)
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// billItemExtension records how many bill lines are aggregated into one bill item.
+func billItemExtension(lines int) tabletype.JsonField {
+	return tabletype.JsonField(fmt.Sprintf(`{"line_count":%d}`, lines))
+}
+
+// saveBillItems 使用最新拉取的账单明细替换db中该账号当月的账单明细。
+func (b bill) saveBillItems(kt *kit.Kit, vendor enumor.Vendor, accountID, billMonth string,
+	items []dsbill.BillItemCreateReq) (*hcbillservice.BillItemSyncResult, error) {
+
+	req := &dsbill.BillItemReplaceReq{
+		Vendor:    vendor,
+		AccountID: accountID,
+		BillMonth: billMonth,
+		Items:     items,
+	}
+	if err := b.cs.DataService().Global.Bill.ReplaceBillItem(kt.Ctx, kt.Header(), req); err != nil {
+		logs.Errorf("replace %s bill item failed, accountID: %s, month: %s, err: %v, rid: %s", vendor, accountID,
+			billMonth, err, kt.Rid)
+		return nil, err
+	}
+
+	logs.V(3).Infof("sync %s bill item success, accountID: %s, month: %s, count: %d, rid: %s", vendor, accountID,
+		billMonth, len(items), kt.Rid)
+
+	return &hcbillservice.BillItemSyncResult{Count: len(items)}, nil
+}
+
+// billMonthDateRange return the first and last date of bill month, format is yyyy-mm-dd.
+func billMonthDateRange(billMonth string) (string, string, error) {
+	month, err := time.Parse(constant.MonthLayout, billMonth)
+	if err != nil {
+		return "", "", err
+	}
+
+	return month.Format(constant.DateLayout), month.AddDate(0, 1, -1).Format(constant.DateLayout), nil
+}
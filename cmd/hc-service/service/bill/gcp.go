@@ -22,14 +22,22 @@ package bill
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	typesBill "hcm/pkg/adaptor/types/bill"
+	"hcm/pkg/api/core"
 	"hcm/pkg/api/core/cloud"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
 	hcbillservice "hcm/pkg/api/hc-service/bill"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+
+	"cloud.google.com/go/bigquery"
 )
 
 // GcpGetBillList get gcp bill list.
@@ -96,3 +104,130 @@ func (b bill) GcpGetBillList(cts *rest.Contexts) (interface{}, error) {
 		Details: resp,
 	}, nil
 }
+
+// gcpBillItemQueryLimit gcp bill item summary query page limit.
+const gcpBillItemQueryLimit = 1000
+
+// GcpSyncBillItem sync gcp bill items of the month into db.
+func (b bill) GcpSyncBillItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcbillservice.BillItemSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	billAccountID, err := b.getGcpBillAccountID(cts.Kit, req.BillAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	billInfo, err := getBillInfo[cloud.GcpBillConfigExtension](cts.Kit, billAccountID, b.cs.DataService())
+	if err != nil {
+		logs.Errorf("gcp bill config get base info db failed, billAccID: %s, err: %+v", billAccountID, err)
+		return nil, err
+	}
+	if billInfo == nil {
+		return nil, errf.Newf(errf.RecordNotFound, "bill_account_id: %s is not found", billAccountID)
+	}
+
+	resAccountInfo, err := b.cs.DataService().Gcp.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), req.AccountID)
+	if err != nil {
+		logs.Errorf("get gcp resource account failed, accountID: %s, err: %+v", req.AccountID, err)
+		return nil, err
+	}
+	if resAccountInfo.Extension == nil || resAccountInfo.Extension.CloudProjectID == "" {
+		return nil, fmt.Errorf("account: %s cloud_project_id is empty", req.AccountID)
+	}
+
+	cli, err := b.ad.GcpProxy(cts.Kit, billAccountID)
+	if err != nil {
+		logs.Errorf("gcp request adaptor client err, req: %+v, err: %+v", req, err)
+		return nil, err
+	}
+
+	aggregator := newBillItemAggregator(enumor.Gcp, req.AccountID, req.BillMonth)
+	opt := &typesBill.GcpBillListOption{
+		BillAccountID: billAccountID,
+		AccountID:     req.AccountID,
+		// gcp账单月份格式为YYYYMM
+		Month:     strings.ReplaceAll(req.BillMonth, "-", ""),
+		ProjectID: resAccountInfo.Extension.CloudProjectID,
+		Page:      &typesBill.GcpBillPage{Offset: 0, Limit: gcpBillItemQueryLimit},
+	}
+	for {
+		list, err := cli.GetBillItemSummary(cts.Kit, opt, billInfo)
+		if err != nil {
+			logs.Errorf("request adaptor gcp bill summary failed, opt: %+v, err: %v, rid: %s", opt, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range list {
+			resID := bigQueryValueToString(one["resource_global_name"])
+			if len(resID) == 0 {
+				resID = bigQueryValueToString(one["resource_name"])
+			}
+
+			item := dsbill.BillItemCreateReq{
+				ProductCode: bigQueryValueToString(one["service_id"]),
+				ProductName: bigQueryValueToString(one["service_description"]),
+				CloudResID:  resID,
+				ResName:     bigQueryValueToString(one["resource_name"]),
+				Region:      bigQueryValueToString(one["region"]),
+				Currency:    bigQueryValueToString(one["currency"]),
+			}
+			if err = aggregator.Add(item, bigQueryValueToString(one["total_cost"])); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(list) < gcpBillItemQueryLimit {
+			break
+		}
+
+		opt.Page.Offset += gcpBillItemQueryLimit
+	}
+
+	return b.saveBillItems(cts.Kit, enumor.Gcp, req.AccountID, req.BillMonth, aggregator.Items())
+}
+
+// getGcpBillAccountID return the gcp bill account id, if not specified, use the configured gcp bill account.
+func (b bill) getGcpBillAccountID(kt *kit.Kit, billAccountID string) (string, error) {
+	if len(billAccountID) != 0 {
+		return billAccountID, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("vendor", enumor.Gcp),
+		Page:   &core.BasePage{Count: false, Start: 0, Limit: 1},
+	}
+	billList, err := b.cs.DataService().Global.Bill.List(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list gcp bill config failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	if len(billList.Details) == 0 {
+		return "", errf.New(errf.RecordNotFound, "gcp bill account is not configured")
+	}
+
+	return billList.Details[0].AccountID, nil
+}
+
+// bigQueryValueToString convert big query value to string.
+func bigQueryValueToString(value bigquery.Value) string {
+	if value == nil {
+		return ""
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -22,10 +22,13 @@ package bill
 
 import (
 	typesBill "hcm/pkg/adaptor/types/bill"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
 	hcbillservice "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
 
 	"github.com/golang/protobuf/proto"
 )
@@ -70,3 +73,58 @@ func (b bill) HuaWeiGetBillList(cts *rest.Contexts) (interface{}, error) {
 		Currency: resp.Currency,
 	}, nil
 }
+
+// HuaWeiSyncBillItem sync huawei bill items of the month into db.
+func (b bill) HuaWeiSyncBillItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcbillservice.BillItemSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cli, err := b.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		logs.Errorf("huawei request adaptor client err, req: %+v, err: %+v", req, err)
+		return nil, err
+	}
+
+	aggregator := newBillItemAggregator(enumor.HuaWei, req.AccountID, req.BillMonth)
+	opt := &typesBill.HuaWeiBillListOption{
+		AccountID: req.AccountID,
+		Month:     req.BillMonth,
+		Page:      &typesBill.HuaWeiBillPage{Offset: proto.Int32(0), Limit: proto.Int32(typesBill.HuaWeiQueryLimit)},
+	}
+	for {
+		resp, err := cli.GetBillList(cts.Kit, opt)
+		if err != nil {
+			logs.Errorf("huawei request adaptor list bill failed, opt: %+v, err: %v, rid: %s", opt, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		records := converter.PtrToVal(resp.MonthlyRecords)
+		for _, one := range records {
+			item := dsbill.BillItemCreateReq{
+				ProductCode: converter.PtrToVal(one.CloudServiceType),
+				ProductName: converter.PtrToVal(one.CloudServiceTypeName),
+				CloudResID:  converter.PtrToVal(one.ResInstanceId),
+				ResName:     converter.PtrToVal(one.ResourceName),
+				Region:      converter.PtrToVal(one.Region),
+				Currency:    converter.PtrToVal(resp.Currency),
+			}
+			if err = aggregator.AddFloat(item, converter.PtrToVal(one.ConsumeAmount)); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(records) < typesBill.HuaWeiQueryLimit {
+			break
+		}
+
+		opt.Page.Offset = proto.Int32(*opt.Page.Offset + typesBill.HuaWeiQueryLimit)
+	}
+
+	return b.saveBillItems(cts.Kit, enumor.HuaWei, req.AccountID, req.BillMonth, aggregator.Items())
+}
@@ -23,10 +23,14 @@ package bill
 import (
 	typesBill "hcm/pkg/adaptor/types/bill"
 	"hcm/pkg/adaptor/types/core"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
 	hcbillservice "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
 )
 
 // TCloudGetBillList get tcloud bill list.
@@ -76,3 +80,60 @@ func (b bill) TCloudGetBillList(cts *rest.Contexts) (interface{}, error) {
 		RequestId: resp.RequestId,
 	}, nil
 }
+
+// TCloudSyncBillItem sync tcloud bill items of the month into db.
+func (b bill) TCloudSyncBillItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcbillservice.BillItemSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cli, err := b.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		logs.Errorf("tcloud request adaptor client err, req: %+v, err: %+v", req, err)
+		return nil, err
+	}
+
+	aggregator := newBillItemAggregator(enumor.TCloud, req.AccountID, req.BillMonth)
+	opt := &typesBill.TCloudBillListOption{
+		AccountID: req.AccountID,
+		Month:     req.BillMonth,
+		Page:      &core.TCloudPage{Offset: 0, Limit: core.TCloudQueryLimit},
+	}
+	for {
+		resp, err := cli.GetBillList(cts.Kit, opt)
+		if err != nil {
+			logs.Errorf("tcloud request adaptor list bill failed, opt: %+v, err: %v, rid: %s", opt, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.DetailSet {
+			item := dsbill.BillItemCreateReq{
+				ProductCode: converter.PtrToVal(one.BusinessCode),
+				ProductName: converter.PtrToVal(one.BusinessCodeName),
+				CloudResID:  converter.PtrToVal(one.ResourceId),
+				ResName:     converter.PtrToVal(one.ResourceName),
+				Region:      converter.PtrToVal(one.RegionId),
+				Currency:    constant.CurrencyCNY,
+			}
+			for _, component := range one.ComponentSet {
+				if err = aggregator.Add(item, converter.PtrToVal(component.RealCost)); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if len(resp.DetailSet) < int(opt.Page.Limit) {
+			break
+		}
+
+		opt.Page.Offset += opt.Page.Limit
+		opt.Context = resp.Context
+	}
+
+	return b.saveBillItems(cts.Kit, enumor.TCloud, req.AccountID, req.BillMonth, aggregator.Items())
+}
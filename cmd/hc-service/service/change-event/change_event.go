@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package changeevent ...
+package changeevent
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/service/capability"
+	cloudclient "hcm/cmd/hc-service/service/cloud-adaptor"
+	typeschangeevent "hcm/pkg/adaptor/types/change-event"
+	proto "hcm/pkg/api/hc-service/change-event"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitChangeEventService initial the change event service
+func InitChangeEventService(cap *capability.Capability) {
+	svc := &changeEventSvc{
+		ad: cap.CloudAdaptor,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListChangeEvent", "POST", "/vendors/{vendor}/change_events/list", svc.ListChangeEvent)
+
+	h.Load(cap.WebService)
+}
+
+type changeEventSvc struct {
+	ad *cloudclient.CloudAdaptorClient
+}
+
+// changeEventLister 云资源变更事件查询接口，各云适配器均实现了该接口。
+type changeEventLister interface {
+	ListChangeEvent(kt *kit.Kit, opt *typeschangeevent.ListOption) ([]typeschangeevent.ChangeEvent, error)
+}
+
+// ListChangeEvent list cloud resource change event from cloud audit trail.
+func (svc *changeEventSvc) ListChangeEvent(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(proto.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	// 腾讯云、aws、华为云的操作审计按地域查询
+	regionRequired := vendor == enumor.TCloud || vendor == enumor.Aws || vendor == enumor.HuaWei
+	if err := req.Validate(regionRequired); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.getLister(cts.Kit, vendor, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typeschangeevent.ListOption{
+		Region:    req.Region,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+	events, err := client.ListChangeEvent(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list %s change event failed, err: %v, account: %s, opt: %v, rid: %s", vendor, err,
+			req.AccountID, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (svc *changeEventSvc) getLister(kt *kit.Kit, vendor enumor.Vendor, accountID string) (changeEventLister,
+	error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		return svc.ad.TCloud(kt, accountID)
+	case enumor.Aws:
+		return svc.ad.Aws(kt, accountID)
+	case enumor.HuaWei:
+		return svc.ad.HuaWei(kt, accountID)
+	case enumor.Gcp:
+		return svc.ad.Gcp(kt, accountID)
+	case enumor.Azure:
+		return svc.ad.Azure(kt, accountID)
+	default:
+		return nil, errf.New(errf.InvalidParameter, fmt.Sprintf("vendor: %s not support", vendor))
+	}
+}
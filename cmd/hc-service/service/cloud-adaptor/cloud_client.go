@@ -25,8 +25,12 @@ import (
 	"hcm/pkg/adaptor/azure"
 	"hcm/pkg/adaptor/gcp"
 	"hcm/pkg/adaptor/huawei"
+	"hcm/pkg/adaptor/openstack"
+	"hcm/pkg/adaptor/operator"
 	"hcm/pkg/adaptor/tcloud"
 	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 )
 
@@ -108,3 +112,30 @@ func (cli *CloudAdaptorClient) Azure(kt *kit.Kit, accountID string) (*azure.Azur
 
 	return cli.adaptor.Azure(cred)
 }
+
+// OpenStack return openstack client.
+func (cli *CloudAdaptorClient) OpenStack(kt *kit.Kit, accountID string) (*openstack.OpenStack, error) {
+	cred, err := cli.secretCli.OpenStackCredential(kt, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.adaptor.OpenStack(cred)
+}
+
+// Operator return vendor-agnostic operator of the account.
+func (cli *CloudAdaptorClient) Operator(kt *kit.Kit, vendor enumor.Vendor, accountID string) (operator.Operator,
+	error) {
+
+	loader, exist := getCredentialLoader(vendor)
+	if !exist {
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s operator is not supported", vendor)
+	}
+
+	cred, err := loader(kt, cli.secretCli, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.adaptor.Operator(vendor, cred)
+}
@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudadaptor
+
+import (
+	"fmt"
+	"sync"
+
+	"hcm/pkg/adaptor/operator"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+// CredentialLoader load the operator credential of the account from secret client.
+type CredentialLoader func(kt *kit.Kit, secretCli *SecretClient, accountID string) (*operator.Credential, error)
+
+var (
+	credLoaderLock sync.RWMutex
+	credLoaders    = make(map[enumor.Vendor]CredentialLoader)
+)
+
+// RegisterCredentialLoader register vendor credential loader, register the same vendor twice will panic.
+func RegisterCredentialLoader(vendor enumor.Vendor, loader CredentialLoader) {
+	if len(vendor) == 0 || loader == nil {
+		panic("register credential loader vendor and loader is required")
+	}
+
+	credLoaderLock.Lock()
+	defer credLoaderLock.Unlock()
+
+	if _, exist := credLoaders[vendor]; exist {
+		panic(fmt.Sprintf("vendor: %s credential loader is already registered", vendor))
+	}
+	credLoaders[vendor] = loader
+}
+
+// getCredentialLoader returns the credential loader of the vendor.
+func getCredentialLoader(vendor enumor.Vendor) (CredentialLoader, bool) {
+	credLoaderLock.RLock()
+	defer credLoaderLock.RUnlock()
+
+	loader, exist := credLoaders[vendor]
+	return loader, exist
+}
+
+func init() {
+	RegisterCredentialLoader(enumor.TCloud, func(kt *kit.Kit, secretCli *SecretClient, accountID string) (
+		*operator.Credential, error) {
+
+		secret, err := secretCli.TCloudSecret(kt, accountID)
+		if err != nil {
+			return nil, err
+		}
+		return &operator.Credential{Secret: secret}, nil
+	})
+
+	RegisterCredentialLoader(enumor.Aws, func(kt *kit.Kit, secretCli *SecretClient, accountID string) (
+		*operator.Credential, error) {
+
+		secret, cloudAccountID, err := secretCli.AwsSecret(kt, accountID)
+		if err != nil {
+			return nil, err
+		}
+		return &operator.Credential{Secret: secret, CloudAccountID: cloudAccountID}, nil
+	})
+
+	RegisterCredentialLoader(enumor.HuaWei, func(kt *kit.Kit, secretCli *SecretClient, accountID string) (
+		*operator.Credential, error) {
+
+		secret, err := secretCli.HuaWeiSecret(kt, accountID)
+		if err != nil {
+			return nil, err
+		}
+		return &operator.Credential{Secret: secret}, nil
+	})
+
+	RegisterCredentialLoader(enumor.Gcp, func(kt *kit.Kit, secretCli *SecretClient, accountID string) (
+		*operator.Credential, error) {
+
+		cred, err := secretCli.GcpCredential(kt, accountID)
+		if err != nil {
+			return nil, err
+		}
+		return &operator.Credential{Gcp: cred}, nil
+	})
+
+	RegisterCredentialLoader(enumor.Azure, func(kt *kit.Kit, secretCli *SecretClient, accountID string) (
+		*operator.Credential, error) {
+
+		cred, err := secretCli.AzureCredential(kt, accountID)
+		if err != nil {
+			return nil, err
+		}
+		return &operator.Credential{Azure: cred}, nil
+	})
+
+	RegisterCredentialLoader(enumor.OpenStack, func(kt *kit.Kit, secretCli *SecretClient, accountID string) (
+		*operator.Credential, error) {
+
+		cred, err := secretCli.OpenStackCredential(kt, accountID)
+		if err != nil {
+			return nil, err
+		}
+		return &operator.Credential{OpenStack: cred}, nil
+	})
+}
@@ -198,3 +198,32 @@ func (cli *SecretClient) GcpRegisterCredential(kt *kit.Kit, accountID string) (*
 
 	return cred, nil
 }
+
+// OpenStackCredential get openstack credential and validate credential.
+func (cli *SecretClient) OpenStackCredential(kt *kit.Kit, accountID string) (*types.OpenStackCredential, error) {
+	account, err := cli.data.OpenStack.Account.Get(kt.Ctx, kt.Header(), accountID)
+	if err != nil {
+		return nil, fmt.Errorf("get openstack account failed, err: %v", err)
+	}
+
+	if account.Type != enumor.ResourceAccount {
+		return nil, fmt.Errorf("account: %s not resource account type", accountID)
+	}
+
+	if account.Extension == nil {
+		return nil, errors.New("openstack account extension is nil")
+	}
+
+	cred := &types.OpenStackCredential{
+		CloudAuthUrl:   account.Extension.CloudAuthUrl,
+		CloudProjectID: account.Extension.CloudProjectID,
+		CloudSecretID:  account.Extension.CloudSecretID,
+		CloudSecretKey: account.Extension.CloudSecretKey,
+	}
+
+	if err := cred.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cred, nil
+}
@@ -24,12 +24,17 @@ import (
 
 	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/adaptor/aws"
+	"hcm/pkg/adaptor/operator"
 	typecvm "hcm/pkg/adaptor/types/cvm"
 	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	protocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 )
@@ -42,6 +47,12 @@ func (svc *cvmSvc) initAwsCvmService(cap *capability.Capability) {
 	h.Add("BatchStopAwsCvm", http.MethodPost, "/vendors/aws/cvms/batch/stop", svc.BatchStopAwsCvm)
 	h.Add("BatchRebootAwsCvm", http.MethodPost, "/vendors/aws/cvms/batch/reboot", svc.BatchRebootAwsCvm)
 	h.Add("BatchDeleteAwsCvm", http.MethodDelete, "/vendors/aws/cvms/batch", svc.BatchDeleteAwsCvm)
+	h.Add("ChangeAwsCvmInstanceType", http.MethodPost, "/vendors/aws/cvms/{id}/instance_type/change",
+		svc.ChangeAwsCvmInstanceType)
+	h.Add("ResizeAwsCvmDisk", http.MethodPost, "/vendors/aws/cvms/{id}/disks/resize", svc.ResizeAwsCvmDisk)
+	h.Add("RenameAwsCvm", http.MethodPost, "/vendors/aws/cvms/{id}/rename", svc.RenameAwsCvm)
+	h.Add("ChangeAwsCvmSecurityGroup", http.MethodPost, "/vendors/aws/cvms/{id}/security_groups/change",
+		svc.ChangeAwsCvmSecurityGroup)
 
 	h.Load(cap.WebService)
 }
@@ -76,6 +87,16 @@ func (svc *cvmSvc) BatchCreateAwsCvm(cts *rest.Contexts) (interface{}, error) {
 		BlockDeviceMapping:    req.BlockDeviceMapping,
 		PublicIPAssigned:      req.PublicIPAssigned,
 	}
+	if len(req.KeyPairID) != 0 {
+		cloudKeyPair, err := svc.keyPair.Push(cts.Kit, enumor.Aws, req.AccountID, req.Region, req.KeyPairID)
+		if err != nil {
+			logs.Errorf("push key pair to aws failed, err: %v, keyPairID: %s, rid: %s", err, req.KeyPairID,
+				cts.Kit.Rid)
+			return nil, err
+		}
+		createOpt.KeyName = cloudKeyPair.Name
+	}
+
 	result, err := awsCli.CreateCvm(cts.Kit, createOpt)
 	if err != nil {
 		logs.Errorf("create aws cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -137,20 +158,22 @@ func (svc *cvmSvc) BatchStartAwsCvm(cts *rest.Contexts) (interface{}, error) {
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Aws, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.AwsStartOption{
-		Region:   req.Region,
-		CloudIDs: cloudIDs,
-	}
-	if err = client.StartCvm(cts.Kit, opt); err != nil {
+	opt := &operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)}
+	if err = op.StartCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to start aws cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncaws.NewClient(svc.dataCli, client)
 
 	params := &syncaws.SyncBaseParams{
@@ -195,22 +218,26 @@ func (svc *cvmSvc) BatchStopAwsCvm(cts *rest.Contexts) (interface{}, error) {
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Aws, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.AwsStopOption{
-		Region:    req.Region,
-		CloudIDs:  cloudIDs,
-		Force:     req.Force,
-		Hibernate: req.Hibernate,
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)},
+		Force:            req.Force,
+		Hibernate:        req.Hibernate,
 	}
-	if err = client.StopCvm(cts.Kit, opt); err != nil {
+	if err = op.StopCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to stop aws cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncaws.NewClient(svc.dataCli, client)
 
 	params := &syncaws.SyncBaseParams{
@@ -255,20 +282,24 @@ func (svc *cvmSvc) BatchRebootAwsCvm(cts *rest.Contexts) (interface{}, error) {
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Aws, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.AwsRebootOption{
-		Region:   req.Region,
-		CloudIDs: cloudIDs,
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)},
 	}
-	if err = client.RebootCvm(cts.Kit, opt); err != nil {
+	if err = op.RebootCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to reboot aws cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncaws.NewClient(svc.dataCli, client)
 
 	params := &syncaws.SyncBaseParams{
@@ -313,16 +344,15 @@ func (svc *cvmSvc) BatchDeleteAwsCvm(cts *rest.Contexts) (interface{}, error) {
 		delCloudIDs = append(delCloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Aws, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.AwsDeleteOption{
-		Region:   req.Region,
-		CloudIDs: delCloudIDs,
+	opt := &operator.CvmDeleteOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, delCloudIDs)},
 	}
-	if err = client.DeleteCvm(cts.Kit, opt); err != nil {
+	if err = op.DeleteCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to delete aws cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
@@ -337,3 +367,171 @@ func (svc *cvmSvc) BatchDeleteAwsCvm(cts *rest.Contexts) (interface{}, error) {
 
 	return nil, nil
 }
+
+// ChangeAwsCvmInstanceType ...
+func (svc *cvmSvc) ChangeAwsCvmInstanceType(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.ChangeInstanceTypeReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.Aws, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.AwsChangeInstanceTypeOption{
+		Region:       cvm.Region,
+		CloudID:      cvm.CloudID,
+		InstanceType: req.InstanceType,
+	}
+	if err = client.ChangeCvmInstanceType(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to change aws cvm instance type failed, err: %v, opt: %v, rid: %s", err, opt,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncAwsCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// ResizeAwsCvmDisk ...
+func (svc *cvmSvc) ResizeAwsCvmDisk(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.ResizeDiskReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.Aws, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	disk, err := svc.getCvmDisk(cts.Kit, cvm, req.DiskID, req.DiskSize)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.AwsResizeDiskOption{
+		Region:      cvm.Region,
+		CloudID:     cvm.CloudID,
+		CloudDiskID: disk.CloudID,
+		DiskSize:    req.DiskSize,
+	}
+	if err = client.ResizeCvmDisk(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to resize aws cvm disk failed, err: %v, opt: %v, rid: %s", err, opt,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncAwsCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// RenameAwsCvm ...
+func (svc *cvmSvc) RenameAwsCvm(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.RenameReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.Aws, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.AwsRenameOption{
+		Region:  cvm.Region,
+		CloudID: cvm.CloudID,
+		Name:    req.Name,
+	}
+	if err = client.RenameCvm(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to rename aws cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncAwsCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// ChangeAwsCvmSecurityGroup ...
+func (svc *cvmSvc) ChangeAwsCvmSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.ChangeSecurityGroupReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.Aws, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	cloudSGIDs, err := svc.getCloudSecurityGroupIDs(cts.Kit, cvm, req.SecurityGroupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.AwsChangeSecurityGroupOption{
+		Region:                cvm.Region,
+		CloudID:               cvm.CloudID,
+		CloudSecurityGroupIDs: cloudSGIDs,
+	}
+	if err = client.ChangeCvmSecurityGroup(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to change aws cvm security group failed, err: %v, opt: %v, rid: %s", err,
+			opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncAwsCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// syncAwsCvmWithRelRes 主机变更后同步主机及其关联的云盘、安全组等资源。
+func (svc *cvmSvc) syncAwsCvmWithRelRes(kt *kit.Kit, client *aws.Aws, cvm *corecvm.BaseCvm) error {
+	syncClient := syncaws.NewClient(svc.dataCli, client)
+
+	params := &syncaws.SyncBaseParams{
+		AccountID: cvm.AccountID,
+		Region:    cvm.Region,
+		CloudIDs:  []string{cvm.CloudID},
+	}
+
+	if _, err := syncClient.CvmWithRelRes(kt, params, &syncaws.SyncCvmWithRelResOption{}); err != nil {
+		logs.Errorf("sync aws cvm with rel res failed, err: %v, cvm: %s, rid: %s", err, cvm.ID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	syncazure "hcm/cmd/hc-service/logics/res-sync/azure"
 	"hcm/cmd/hc-service/service/capability"
 	"hcm/pkg/adaptor/azure"
+	"hcm/pkg/adaptor/operator"
 	typecvm "hcm/pkg/adaptor/types/cvm"
 	"hcm/pkg/api/core"
 	dataproto "hcm/pkg/api/data-service/cloud"
@@ -91,7 +92,16 @@ func (svc *cvmSvc) BatchCreateAzureCvm(cts *rest.Contexts) (interface{}, error)
 		return nil, fmt.Errorf("image: %s not found", req.CloudImageID)
 	}
 
-	result := svc.bulkCreateAzureCvm(cts.Kit, req, imageResult.Details[0])
+	sshPublicKey := ""
+	if len(req.KeyPairID) != 0 {
+		keyPair, err := svc.keyPair.Get(cts.Kit, req.KeyPairID)
+		if err != nil {
+			return nil, err
+		}
+		sshPublicKey = keyPair.PublicKey
+	}
+
+	result := svc.bulkCreateAzureCvm(cts.Kit, req, imageResult.Details[0], sshPublicKey)
 
 	if len(result.SuccessCloudIDs) == 0 {
 		return result, nil
@@ -120,7 +130,8 @@ func (svc *cvmSvc) BatchCreateAzureCvm(cts *rest.Contexts) (interface{}, error)
 }
 
 func (svc *cvmSvc) bulkCreateAzureCvm(kt *kit.Kit, req *protocvm.AzureBatchCreateReq,
-	image *imageproto.ImageExtResult[imageproto.AzureImageExtensionResult]) *protocvm.BatchCreateResult {
+	image *imageproto.ImageExtResult[imageproto.AzureImageExtensionResult], sshPublicKey string,
+) *protocvm.BatchCreateResult {
 
 	result := &protocvm.BatchCreateResult{
 		SuccessCloudIDs: make([]string, 0),
@@ -162,6 +173,7 @@ func (svc *cvmSvc) bulkCreateAzureCvm(kt *kit.Kit, req *protocvm.AzureBatchCreat
 				},
 				Username:             req.Username,
 				Password:             req.Password,
+				SSHPublicKey:         sshPublicKey,
 				CloudSubnetID:        req.CloudSubnetID,
 				CloudSecurityGroupID: req.CloudSecurityGroupID,
 				OSDisk: &typecvm.AzureOSDisk{
@@ -212,20 +224,27 @@ func (svc *cvmSvc) StartAzureCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.ad.Azure(cts.Kit, cvmFromDB.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Azure, cvmFromDB.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.AzureStartOption{
+	cvmRef := operator.ResourceRef{
 		ResourceGroupName: cvmFromDB.Extension.ResourceGroupName,
 		Name:              cvmFromDB.Name,
+		CloudID:           cvmFromDB.CloudID,
 	}
-	if err = client.StartCvm(cts.Kit, opt); err != nil {
+	opt := &operator.CvmOperateOption{Cvms: []operator.ResourceRef{cvmRef}}
+	if err = op.StartCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to start azure cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.Azure(cts.Kit, cvmFromDB.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncazure.NewClient(svc.dataCli, client)
 
 	params := &syncazure.SyncBaseParams{
@@ -266,21 +285,30 @@ func (svc *cvmSvc) StopAzureCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.ad.Azure(cts.Kit, cvmFromDB.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Azure, cvmFromDB.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.AzureStopOption{
+	cvmRef := operator.ResourceRef{
 		ResourceGroupName: cvmFromDB.Extension.ResourceGroupName,
 		Name:              cvmFromDB.Name,
-		SkipShutdown:      req.SkipShutdown,
+		CloudID:           cvmFromDB.CloudID,
 	}
-	if err = client.StopCvm(cts.Kit, opt); err != nil {
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: []operator.ResourceRef{cvmRef}},
+		Force:            req.SkipShutdown,
+	}
+	if err = op.StopCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to stop azure cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.Azure(cts.Kit, cvmFromDB.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncazure.NewClient(svc.dataCli, client)
 
 	params := &syncazure.SyncBaseParams{
@@ -312,20 +340,29 @@ func (svc *cvmSvc) RebootAzureCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.ad.Azure(cts.Kit, cvmFromDB.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Azure, cvmFromDB.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.AzureRebootOption{
+	cvmRef := operator.ResourceRef{
 		ResourceGroupName: cvmFromDB.Extension.ResourceGroupName,
 		Name:              cvmFromDB.Name,
+		CloudID:           cvmFromDB.CloudID,
+	}
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: []operator.ResourceRef{cvmRef}},
 	}
-	if err = client.RebootCvm(cts.Kit, opt); err != nil {
+	if err = op.RebootCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to reboot azure cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.Azure(cts.Kit, cvmFromDB.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncazure.NewClient(svc.dataCli, client)
 
 	params := &syncazure.SyncBaseParams{
@@ -366,17 +403,21 @@ func (svc *cvmSvc) DeleteAzureCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.ad.Azure(cts.Kit, cvm.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Azure, cvm.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.AzureDeleteOption{
+	cvmRef := operator.ResourceRef{
 		ResourceGroupName: cvm.Extension.ResourceGroupName,
 		Name:              cvm.Name,
-		Force:             req.Force,
+		CloudID:           cvm.CloudID,
+	}
+	opt := &operator.CvmDeleteOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: []operator.ResourceRef{cvmRef}},
+		Force:            req.Force,
 	}
-	if err = client.DeleteCvm(cts.Kit, opt); err != nil {
+	if err = op.DeleteCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to delete azure cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
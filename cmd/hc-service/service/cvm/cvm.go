@@ -20,8 +20,10 @@
 package cvm
 
 import (
+	kplogics "hcm/cmd/hc-service/logics/key-pair"
 	"hcm/cmd/hc-service/service/capability"
 	cloudadaptor "hcm/cmd/hc-service/service/cloud-adaptor"
+	"hcm/pkg/adaptor/operator"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 )
@@ -31,6 +33,7 @@ func InitCvmService(cap *capability.Capability) {
 	svc := &cvmSvc{
 		ad:      cap.CloudAdaptor,
 		dataCli: cap.ClientSet.DataService(),
+		keyPair: kplogics.NewKeyPair(cap.ClientSet, cap.CloudAdaptor),
 	}
 
 	svc.initTCloudCvmService(cap)
@@ -38,10 +41,22 @@ func InitCvmService(cap *capability.Capability) {
 	svc.initAzureCvmService(cap)
 	svc.initGcpCvmService(cap)
 	svc.initHuaWeiCvmService(cap)
+	svc.initOpenStackCvmService(cap)
 }
 
 type cvmSvc struct {
 	ad      *cloudadaptor.CloudAdaptorClient
 	dataCli *dataservice.Client
 	client  *client.ClientSet
+	keyPair *kplogics.KeyPair
+}
+
+// regionCvmRefs convert cloud ids of the cvms in the region to operator resource refs.
+func regionCvmRefs(region string, cloudIDs []string) []operator.ResourceRef {
+	refs := make([]operator.ResourceRef, 0, len(cloudIDs))
+	for _, cloudID := range cloudIDs {
+		refs = append(refs, operator.ResourceRef{Region: region, CloudID: cloudID})
+	}
+
+	return refs
 }
@@ -26,11 +26,13 @@ import (
 	syncgcp "hcm/cmd/hc-service/logics/res-sync/gcp"
 	"hcm/cmd/hc-service/service/capability"
 	"hcm/pkg/adaptor/gcp"
+	"hcm/pkg/adaptor/operator"
 	typecvm "hcm/pkg/adaptor/types/cvm"
 	"hcm/pkg/api/core"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	imageproto "hcm/pkg/api/data-service/cloud/image"
 	protocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/kit"
@@ -101,6 +103,14 @@ func (svc *cvmSvc) BatchCreateGcpCvm(cts *rest.Contexts) (interface{}, error) {
 		SystemDisk:          req.SystemDisk,
 		DataDisk:            req.DataDisk,
 	}
+	if len(req.KeyPairID) != 0 {
+		keyPair, err := svc.keyPair.Get(cts.Kit, req.KeyPairID)
+		if err != nil {
+			return nil, err
+		}
+		createOpt.SSHPublicKey = keyPair.PublicKey
+	}
+
 	result, err := gcpCli.CreateCvm(cts.Kit, createOpt)
 	if err != nil {
 		logs.Errorf("create cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -205,20 +215,27 @@ func (svc *cvmSvc) StartGcpCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.ad.Gcp(cts.Kit, cvmFromDB.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Gcp, cvmFromDB.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.GcpStartOption{
-		Zone: cvmFromDB.Zone,
-		Name: cvmFromDB.Name,
+	cvmRef := operator.ResourceRef{
+		Zone:    cvmFromDB.Zone,
+		Name:    cvmFromDB.Name,
+		CloudID: cvmFromDB.CloudID,
 	}
-	if err = client.StartCvm(cts.Kit, opt); err != nil {
+	opt := &operator.CvmOperateOption{Cvms: []operator.ResourceRef{cvmRef}}
+	if err = op.StartCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to start gcp cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.Gcp(cts.Kit, cvmFromDB.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncgcp.NewClient(svc.dataCli, client)
 
 	params := &syncgcp.SyncBaseParams{
@@ -250,20 +267,29 @@ func (svc *cvmSvc) StopGcpCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.ad.Gcp(cts.Kit, cvmFromDB.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Gcp, cvmFromDB.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.GcpStopOption{
-		Zone: cvmFromDB.Zone,
-		Name: cvmFromDB.Name,
+	cvmRef := operator.ResourceRef{
+		Zone:    cvmFromDB.Zone,
+		Name:    cvmFromDB.Name,
+		CloudID: cvmFromDB.CloudID,
 	}
-	if err = client.StopCvm(cts.Kit, opt); err != nil {
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: []operator.ResourceRef{cvmRef}},
+	}
+	if err = op.StopCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to stop gcp cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.Gcp(cts.Kit, cvmFromDB.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncgcp.NewClient(svc.dataCli, client)
 
 	params := &syncgcp.SyncBaseParams{
@@ -295,20 +321,29 @@ func (svc *cvmSvc) RebootGcpCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.ad.Gcp(cts.Kit, cvmFromDB.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Gcp, cvmFromDB.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.GcpResetOption{
-		Zone: cvmFromDB.Zone,
-		Name: cvmFromDB.Name,
+	cvmRef := operator.ResourceRef{
+		Zone:    cvmFromDB.Zone,
+		Name:    cvmFromDB.Name,
+		CloudID: cvmFromDB.CloudID,
+	}
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: []operator.ResourceRef{cvmRef}},
 	}
-	if err = client.ResetCvm(cts.Kit, opt); err != nil {
+	if err = op.RebootCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to reset gcp cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.Gcp(cts.Kit, cvmFromDB.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncgcp.NewClient(svc.dataCli, client)
 
 	params := &syncgcp.SyncBaseParams{
@@ -340,16 +375,20 @@ func (svc *cvmSvc) DeleteGcpCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.ad.Gcp(cts.Kit, cvm.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.Gcp, cvm.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.GcpDeleteOption{
-		Zone: cvm.Zone,
-		Name: cvm.Name,
+	cvmRef := operator.ResourceRef{
+		Zone:    cvm.Zone,
+		Name:    cvm.Name,
+		CloudID: cvm.CloudID,
+	}
+	opt := &operator.CvmDeleteOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: []operator.ResourceRef{cvmRef}},
 	}
-	if err = client.DeleteCvm(cts.Kit, opt); err != nil {
+	if err = op.DeleteCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to delete gcp cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
@@ -24,12 +24,16 @@ import (
 
 	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/adaptor/huawei"
+	"hcm/pkg/adaptor/operator"
 	typecvm "hcm/pkg/adaptor/types/cvm"
 	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	datadisk "hcm/pkg/api/data-service/cloud/disk"
 	dataeip "hcm/pkg/api/data-service/cloud/eip"
 	protocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/kit"
@@ -46,6 +50,12 @@ func (svc *cvmSvc) initHuaWeiCvmService(cap *capability.Capability) {
 	h.Add("BatchRebootHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/batch/reboot", svc.BatchRebootHuaWeiCvm)
 	h.Add("BatchDeleteHuaWeiCvm", http.MethodDelete, "/vendors/huawei/cvms/batch", svc.BatchDeleteHuaWeiCvm)
 	h.Add("BatchResetHuaWeiCvmPwd", http.MethodPost, "/vendors/huawei/cvms/batch/reset/pwd", svc.BatchResetHuaWeiCvmPwd)
+	h.Add("ChangeHuaWeiCvmInstanceType", http.MethodPost, "/vendors/huawei/cvms/{id}/instance_type/change",
+		svc.ChangeHuaWeiCvmInstanceType)
+	h.Add("ResizeHuaWeiCvmDisk", http.MethodPost, "/vendors/huawei/cvms/{id}/disks/resize", svc.ResizeHuaWeiCvmDisk)
+	h.Add("RenameHuaWeiCvm", http.MethodPost, "/vendors/huawei/cvms/{id}/rename", svc.RenameHuaWeiCvm)
+	h.Add("ChangeHuaWeiCvmSecurityGroup", http.MethodPost, "/vendors/huawei/cvms/{id}/security_groups/change",
+		svc.ChangeHuaWeiCvmSecurityGroup)
 
 	h.Load(cap.WebService)
 }
@@ -84,6 +94,16 @@ func (svc *cvmSvc) BatchCreateHuaWeiCvm(cts *rest.Contexts) (interface{}, error)
 		DataVolume:            req.DataVolume,
 		InstanceCharge:        req.InstanceCharge,
 	}
+	if len(req.KeyPairID) != 0 {
+		cloudKeyPair, err := svc.keyPair.Push(cts.Kit, enumor.HuaWei, req.AccountID, req.Region, req.KeyPairID)
+		if err != nil {
+			logs.Errorf("push key pair to huawei failed, err: %v, keyPairID: %s, rid: %s", err, req.KeyPairID,
+				cts.Kit.Rid)
+			return nil, err
+		}
+		createOpt.KeyName = cloudKeyPair.Name
+	}
+
 	result, err := huawei.CreateCvm(cts.Kit, createOpt)
 	if err != nil {
 		logs.Errorf("create huawei cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -204,20 +224,22 @@ func (svc *cvmSvc) BatchStartHuaWeiCvm(cts *rest.Contexts) (interface{}, error)
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.HuaWei, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.HuaWeiStartOption{
-		Region:   req.Region,
-		CloudIDs: cloudIDs,
-	}
-	if err = client.StartCvm(cts.Kit, opt); err != nil {
+	opt := &operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)}
+	if err = op.StartCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to start huawei cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := synchuawei.NewClient(svc.dataCli, client)
 
 	params := &synchuawei.SyncBaseParams{
@@ -262,21 +284,25 @@ func (svc *cvmSvc) BatchStopHuaWeiCvm(cts *rest.Contexts) (interface{}, error) {
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.HuaWei, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.HuaWeiStopOption{
-		Region:   req.Region,
-		CloudIDs: cloudIDs,
-		Force:    req.Force,
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)},
+		Force:            req.Force,
 	}
-	if err = client.StopCvm(cts.Kit, opt); err != nil {
+	if err = op.StopCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to stop huawei cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := synchuawei.NewClient(svc.dataCli, client)
 
 	params := &synchuawei.SyncBaseParams{
@@ -321,21 +347,25 @@ func (svc *cvmSvc) BatchRebootHuaWeiCvm(cts *rest.Contexts) (interface{}, error)
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.HuaWei, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.HuaWeiRebootOption{
-		Region:   req.Region,
-		CloudIDs: cloudIDs,
-		Force:    req.Force,
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)},
+		Force:            req.Force,
 	}
-	if err = client.RebootCvm(cts.Kit, opt); err != nil {
+	if err = op.RebootCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to reboot huawei cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := synchuawei.NewClient(svc.dataCli, client)
 
 	params := &synchuawei.SyncBaseParams{
@@ -380,18 +410,17 @@ func (svc *cvmSvc) BatchDeleteHuaWeiCvm(cts *rest.Contexts) (interface{}, error)
 		delCloudIDs = append(delCloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.HuaWei, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.HuaWeiDeleteOption{
-		Region:         req.Region,
-		CloudIDs:       delCloudIDs,
-		DeletePublicIP: req.DeletePublicIP,
-		DeleteVolume:   req.DeleteDisk,
+	opt := &operator.CvmDeleteOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, delCloudIDs)},
+		DeletePublicIP:   req.DeletePublicIP,
+		DeleteDisk:       req.DeleteDisk,
 	}
-	if err = client.DeleteCvm(cts.Kit, opt); err != nil {
+	if err = op.DeleteCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to delete huawei cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
@@ -531,3 +560,171 @@ func (svc cvmSvc) syncCvmRelDisk(kt *kit.Kit, accountID, region string, cvmIDs [
 
 	return nil
 }
+
+// ChangeHuaWeiCvmInstanceType ...
+func (svc *cvmSvc) ChangeHuaWeiCvmInstanceType(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.ChangeInstanceTypeReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.HuaWei, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.HuaWeiChangeInstanceTypeOption{
+		Region:       cvm.Region,
+		CloudID:      cvm.CloudID,
+		InstanceType: req.InstanceType,
+	}
+	if err = client.ChangeCvmInstanceType(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to change huawei cvm instance type failed, err: %v, opt: %v, rid: %s", err, opt,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncHuaWeiCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// ResizeHuaWeiCvmDisk ...
+func (svc *cvmSvc) ResizeHuaWeiCvmDisk(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.ResizeDiskReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.HuaWei, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	disk, err := svc.getCvmDisk(cts.Kit, cvm, req.DiskID, req.DiskSize)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.HuaWeiResizeDiskOption{
+		Region:      cvm.Region,
+		CloudID:     cvm.CloudID,
+		CloudDiskID: disk.CloudID,
+		DiskSize:    req.DiskSize,
+	}
+	if err = client.ResizeCvmDisk(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to resize huawei cvm disk failed, err: %v, opt: %v, rid: %s", err, opt,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncHuaWeiCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// RenameHuaWeiCvm ...
+func (svc *cvmSvc) RenameHuaWeiCvm(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.RenameReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.HuaWei, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.HuaWeiRenameOption{
+		Region:  cvm.Region,
+		CloudID: cvm.CloudID,
+		Name:    req.Name,
+	}
+	if err = client.RenameCvm(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to rename huawei cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncHuaWeiCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// ChangeHuaWeiCvmSecurityGroup ...
+func (svc *cvmSvc) ChangeHuaWeiCvmSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.ChangeSecurityGroupReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.HuaWei, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	cloudSGIDs, err := svc.getCloudSecurityGroupIDs(cts.Kit, cvm, req.SecurityGroupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.HuaWeiChangeSecurityGroupOption{
+		Region:                cvm.Region,
+		CloudID:               cvm.CloudID,
+		CloudSecurityGroupIDs: cloudSGIDs,
+	}
+	if err = client.ChangeCvmSecurityGroup(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to change huawei cvm security group failed, err: %v, opt: %v, rid: %s", err,
+			opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncHuaWeiCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// syncHuaWeiCvmWithRelRes 主机变更后同步主机及其关联的云盘、安全组等资源。
+func (svc *cvmSvc) syncHuaWeiCvmWithRelRes(kt *kit.Kit, client *huawei.HuaWei, cvm *corecvm.BaseCvm) error {
+	syncClient := synchuawei.NewClient(svc.dataCli, client)
+
+	params := &synchuawei.SyncBaseParams{
+		AccountID: cvm.AccountID,
+		Region:    cvm.Region,
+		CloudIDs:  []string{cvm.CloudID},
+	}
+
+	if _, err := syncClient.CvmWithRelRes(kt, params, &synchuawei.SyncCvmWithRelResOption{}); err != nil {
+		logs.Errorf("sync huawei cvm with rel res failed, err: %v, cvm: %s, rid: %s", err, cvm.ID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
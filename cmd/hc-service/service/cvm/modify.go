@@ -0,0 +1,136 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	datadisk "hcm/pkg/api/data-service/cloud/disk"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// getCvm 查询指定厂商的主机信息，用于主机变更类操作。
+func (svc *cvmSvc) getCvm(kt *kit.Kit, vendor enumor.Vendor, id string) (*corecvm.BaseCvm, error) {
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "cvm id is required")
+	}
+
+	listReq := &dataproto.CvmListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dataCli.Global.Cvm.ListCvm(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("request dataservice list cvm failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "cvm: %s not found", id)
+	}
+
+	cvm := listResp.Details[0]
+	if cvm.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "cvm: %s vendor is %s, not %s", id, cvm.Vendor, vendor)
+	}
+
+	return &cvm, nil
+}
+
+// getCvmDisk 查询主机挂载的云盘，并校验扩容后的大小必须大于当前大小。
+func (svc *cvmSvc) getCvmDisk(kt *kit.Kit, cvm *corecvm.BaseCvm, diskID string, diskSize int64) (
+	*datadisk.DiskResult, error) {
+
+	relReq := &dataproto.DiskCvmRelListReq{
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{"cvm_id": cvm.ID, "disk_id": diskID}),
+		Page:   core.NewDefaultBasePage(),
+	}
+	relResp, err := svc.dataCli.Global.ListDiskCvmRel(kt.Ctx, kt.Header(), relReq)
+	if err != nil {
+		logs.Errorf("list disk cvm rel failed, err: %v, cvm: %s, disk: %s, rid: %s", err, cvm.ID, diskID, kt.Rid)
+		return nil, err
+	}
+
+	if len(relResp.Details) == 0 {
+		return nil, errf.Newf(errf.InvalidParameter, "disk: %s is not attached to cvm: %s", diskID, cvm.ID)
+	}
+
+	diskReq := &datadisk.DiskListReq{
+		Filter: tools.EqualExpression("id", diskID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	diskResp, err := svc.dataCli.Global.ListDisk(kt.Ctx, kt.Header(), diskReq)
+	if err != nil {
+		logs.Errorf("list disk failed, err: %v, id: %s, rid: %s", err, diskID, kt.Rid)
+		return nil, err
+	}
+
+	if len(diskResp.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "disk: %s not found", diskID)
+	}
+
+	disk := diskResp.Details[0]
+	if diskSize <= int64(disk.DiskSize) {
+		return nil, errf.Newf(errf.InvalidParameter, "disk size %d must be greater than current size %d", diskSize,
+			disk.DiskSize)
+	}
+
+	return disk, nil
+}
+
+// getCloudSecurityGroupIDs 将安全组ID转换为云上ID，安全组必须和主机属于同一账号、同一地域。
+func (svc *cvmSvc) getCloudSecurityGroupIDs(kt *kit.Kit, cvm *corecvm.BaseCvm, sgIDs []string) ([]string, error) {
+	listReq := &dataproto.SecurityGroupListReq{
+		Field:  []string{"id", "cloud_id", "account_id", "region"},
+		Filter: tools.ContainersExpression("id", sgIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dataCli.Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list security group failed, err: %v, ids: %v, rid: %s", err, sgIDs, kt.Rid)
+		return nil, err
+	}
+
+	cloudIDMap := make(map[string]string, len(listResp.Details))
+	for _, one := range listResp.Details {
+		if one.AccountID != cvm.AccountID || one.Region != cvm.Region {
+			return nil, errf.Newf(errf.InvalidParameter, "security group: %s not belongs to account: %s region: %s",
+				one.ID, cvm.AccountID, cvm.Region)
+		}
+		cloudIDMap[one.ID] = one.CloudID
+	}
+
+	cloudIDs := make([]string, 0, len(sgIDs))
+	for _, id := range sgIDs {
+		cloudID, exist := cloudIDMap[id]
+		if !exist {
+			return nil, errf.Newf(errf.RecordNotFound, "security group: %s not found", id)
+		}
+		cloudIDs = append(cloudIDs, cloudID)
+	}
+
+	return cloudIDs, nil
+}
@@ -0,0 +1,244 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"net/http"
+
+	syncopenstack "hcm/cmd/hc-service/logics/res-sync/openstack"
+	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/adaptor/openstack"
+	"hcm/pkg/adaptor/operator"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	protocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+func (svc *cvmSvc) initOpenStackCvmService(cap *capability.Capability) {
+	h := rest.NewHandler()
+
+	h.Add("BatchStartOpenStackCvm", http.MethodPost, "/vendors/openstack/cvms/batch/start",
+		svc.BatchStartOpenStackCvm)
+	h.Add("BatchStopOpenStackCvm", http.MethodPost, "/vendors/openstack/cvms/batch/stop", svc.BatchStopOpenStackCvm)
+	h.Add("BatchRebootOpenStackCvm", http.MethodPost, "/vendors/openstack/cvms/batch/reboot",
+		svc.BatchRebootOpenStackCvm)
+	h.Add("BatchDeleteOpenStackCvm", http.MethodDelete, "/vendors/openstack/cvms/batch", svc.BatchDeleteOpenStackCvm)
+
+	h.Load(cap.WebService)
+}
+
+// BatchStartOpenStackCvm ...
+func (svc *cvmSvc) BatchStartOpenStackCvm(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.OpenStackBatchOperateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cloudIDs, err := svc.listCvmCloudIDs(cts.Kit, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := svc.ad.Operator(cts.Kit, enumor.OpenStack, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)}
+	if err = op.StartCvm(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to start openstack cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	client, err := svc.ad.OpenStack(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, svc.syncOpenStackCvm(cts.Kit, client, req.AccountID, req.Region, cloudIDs)
+}
+
+// BatchStopOpenStackCvm ...
+func (svc *cvmSvc) BatchStopOpenStackCvm(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.OpenStackBatchOperateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cloudIDs, err := svc.listCvmCloudIDs(cts.Kit, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := svc.ad.Operator(cts.Kit, enumor.OpenStack, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)},
+	}
+	if err = op.StopCvm(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to stop openstack cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	client, err := svc.ad.OpenStack(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, svc.syncOpenStackCvm(cts.Kit, client, req.AccountID, req.Region, cloudIDs)
+}
+
+// BatchRebootOpenStackCvm ...
+func (svc *cvmSvc) BatchRebootOpenStackCvm(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.OpenStackBatchRebootReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cloudIDs, err := svc.listCvmCloudIDs(cts.Kit, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := svc.ad.Operator(cts.Kit, enumor.OpenStack, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)},
+		Force:            req.Force,
+	}
+	if err = op.RebootCvm(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to reboot openstack cvm failed, err: %v, opt: %v, rid: %s", err, opt,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	client, err := svc.ad.OpenStack(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, svc.syncOpenStackCvm(cts.Kit, client, req.AccountID, req.Region, cloudIDs)
+}
+
+// BatchDeleteOpenStackCvm ...
+func (svc *cvmSvc) BatchDeleteOpenStackCvm(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.OpenStackBatchOperateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	delCloudIDs, err := svc.listCvmCloudIDs(cts.Kit, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := svc.ad.Operator(cts.Kit, enumor.OpenStack, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &operator.CvmDeleteOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, delCloudIDs)},
+	}
+	if err = op.DeleteCvm(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to delete openstack cvm failed, err: %v, opt: %v, rid: %s", err, opt,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	delReq := &dataproto.CvmBatchDeleteReq{
+		Filter: tools.ContainersExpression("id", req.IDs),
+	}
+	if err = svc.dataCli.Global.Cvm.BatchDeleteCvm(cts.Kit.Ctx, cts.Kit.Header(), delReq); err != nil {
+		logs.Errorf("request dataservice delete openstack cvm failed, err: %v, ids: %v, rid: %s", err,
+			req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// listCvmCloudIDs list cloud ids of cvms by ids.
+func (svc *cvmSvc) listCvmCloudIDs(kt *kit.Kit, ids []string) ([]string, error) {
+	listReq := &dataproto.CvmListReq{
+		Field:  []string{"cloud_id"},
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dataCli.Global.Cvm.ListCvm(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("request dataservice list cvm failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	cloudIDs := make([]string, 0, len(listResp.Details))
+	for _, one := range listResp.Details {
+		cloudIDs = append(cloudIDs, one.CloudID)
+	}
+
+	return cloudIDs, nil
+}
+
+// syncOpenStackCvm sync cvm status after operation, openstack cvm operation is asynchronous, status may be
+// transitional.
+func (svc *cvmSvc) syncOpenStackCvm(kt *kit.Kit, client *openstack.OpenStack, accountID, region string,
+	cloudIDs []string) error {
+
+	params := &syncopenstack.SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  cloudIDs,
+	}
+	syncCli := syncopenstack.NewClient(svc.dataCli, client)
+	if _, err := syncCli.Cvm(kt, params, new(syncopenstack.SyncCvmOption)); err != nil {
+		logs.Errorf("sync openstack cvm failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
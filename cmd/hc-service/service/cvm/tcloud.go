@@ -24,12 +24,17 @@ import (
 
 	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
 	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/adaptor/operator"
+	"hcm/pkg/adaptor/tcloud"
 	typecvm "hcm/pkg/adaptor/types/cvm"
 	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	protocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 )
@@ -43,6 +48,12 @@ func (svc *cvmSvc) initTCloudCvmService(cap *capability.Capability) {
 	h.Add("BatchRebootTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/batch/reboot", svc.BatchRebootTCloudCvm)
 	h.Add("BatchDeleteTCloudCvm", http.MethodDelete, "/vendors/tcloud/cvms/batch", svc.BatchDeleteTCloudCvm)
 	h.Add("BatchResetTCloudCvmPwd", http.MethodPost, "/vendors/tcloud/cvms/batch/reset/pwd", svc.BatchResetTCloudCvmPwd)
+	h.Add("ChangeTCloudCvmInstanceType", http.MethodPost, "/vendors/tcloud/cvms/{id}/instance_type/change",
+		svc.ChangeTCloudCvmInstanceType)
+	h.Add("ResizeTCloudCvmDisk", http.MethodPost, "/vendors/tcloud/cvms/{id}/disks/resize", svc.ResizeTCloudCvmDisk)
+	h.Add("RenameTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/{id}/rename", svc.RenameTCloudCvm)
+	h.Add("ChangeTCloudCvmSecurityGroup", http.MethodPost, "/vendors/tcloud/cvms/{id}/security_groups/change",
+		svc.ChangeTCloudCvmSecurityGroup)
 
 	h.Load(cap.WebService)
 }
@@ -82,6 +93,16 @@ func (svc *cvmSvc) BatchCreateTCloudCvm(cts *rest.Contexts) (interface{}, error)
 		DataDisk:              req.DataDisk,
 		PublicIPAssigned:      req.PublicIPAssigned,
 	}
+	if len(req.KeyPairID) != 0 {
+		cloudKeyPair, err := svc.keyPair.Push(cts.Kit, enumor.TCloud, req.AccountID, req.Region, req.KeyPairID)
+		if err != nil {
+			logs.Errorf("push key pair to tcloud failed, err: %v, keyPairID: %s, rid: %s", err, req.KeyPairID,
+				cts.Kit.Rid)
+			return nil, err
+		}
+		createOpt.CloudKeyPairID = cloudKeyPair.CloudID
+	}
+
 	result, err := tcloud.CreateCvm(cts.Kit, createOpt)
 	if err != nil {
 		logs.Errorf("create cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -204,20 +225,22 @@ func (svc *cvmSvc) BatchStartTCloudCvm(cts *rest.Contexts) (interface{}, error)
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.TCloud, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.TCloudStartOption{
-		Region:   req.Region,
-		CloudIDs: cloudIDs,
-	}
-	if err = client.StartCvm(cts.Kit, opt); err != nil {
+	opt := &operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)}
+	if err = op.StartCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to start tcloud cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := synctcloud.NewClient(svc.dataCli, client)
 
 	params := &synctcloud.SyncBaseParams{
@@ -262,22 +285,27 @@ func (svc *cvmSvc) BatchStopTCloudCvm(cts *rest.Contexts) (interface{}, error) {
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.TCloud, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.TCloudStopOption{
-		Region:      req.Region,
-		CloudIDs:    cloudIDs,
-		StopType:    req.StopType,
-		StoppedMode: req.StoppedMode,
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)},
+		Force:            req.StopType == typecvm.Hard,
+		SoftFirst:        req.StopType == typecvm.SoftFirst,
+		StopCharging:     req.StoppedMode == typecvm.StopCharging,
 	}
-	if err = client.StopCvm(cts.Kit, opt); err != nil {
+	if err = op.StopCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to stop tcloud cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := synctcloud.NewClient(svc.dataCli, client)
 
 	params := &synctcloud.SyncBaseParams{
@@ -322,21 +350,26 @@ func (svc *cvmSvc) BatchRebootTCloudCvm(cts *rest.Contexts) (interface{}, error)
 		cloudIDs = append(cloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.TCloud, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.TCloudRebootOption{
-		Region:   req.Region,
-		CloudIDs: cloudIDs,
-		StopType: req.StopType,
+	opt := &operator.CvmStopOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, cloudIDs)},
+		Force:            req.StopType == typecvm.Hard,
+		SoftFirst:        req.StopType == typecvm.SoftFirst,
 	}
-	if err = client.RebootCvm(cts.Kit, opt); err != nil {
+	if err = op.RebootCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to reboot tcloud cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
 
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := synctcloud.NewClient(svc.dataCli, client)
 
 	params := &synctcloud.SyncBaseParams{
@@ -381,16 +414,15 @@ func (svc *cvmSvc) BatchDeleteTCloudCvm(cts *rest.Contexts) (interface{}, error)
 		delCloudIDs = append(delCloudIDs, one.CloudID)
 	}
 
-	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	op, err := svc.ad.Operator(cts.Kit, enumor.TCloud, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	opt := &typecvm.TCloudDeleteOption{
-		Region:   req.Region,
-		CloudIDs: delCloudIDs,
+	opt := &operator.CvmDeleteOption{
+		CvmOperateOption: operator.CvmOperateOption{Cvms: regionCvmRefs(req.Region, delCloudIDs)},
 	}
-	if err = client.DeleteCvm(cts.Kit, opt); err != nil {
+	if err = op.DeleteCvm(cts.Kit, opt); err != nil {
 		logs.Errorf("request adaptor to delete tcloud cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
 		return nil, err
 	}
@@ -406,3 +438,172 @@ func (svc *cvmSvc) BatchDeleteTCloudCvm(cts *rest.Contexts) (interface{}, error)
 
 	return nil, nil
 }
+
+// ChangeTCloudCvmInstanceType ...
+func (svc *cvmSvc) ChangeTCloudCvmInstanceType(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.ChangeInstanceTypeReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.TCloud, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.TCloudChangeInstanceTypeOption{
+		Region:       cvm.Region,
+		CloudID:      cvm.CloudID,
+		InstanceType: req.InstanceType,
+	}
+	if err = client.ChangeCvmInstanceType(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to change tcloud cvm instance type failed, err: %v, opt: %v, rid: %s", err, opt,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncTCloudCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// ResizeTCloudCvmDisk ...
+func (svc *cvmSvc) ResizeTCloudCvmDisk(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.ResizeDiskReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.TCloud, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	disk, err := svc.getCvmDisk(cts.Kit, cvm, req.DiskID, req.DiskSize)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.TCloudResizeDiskOption{
+		Region:       cvm.Region,
+		CloudID:      cvm.CloudID,
+		CloudDiskID:  disk.CloudID,
+		IsSystemDisk: disk.IsSystemDisk,
+		DiskSize:     req.DiskSize,
+	}
+	if err = client.ResizeCvmDisk(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to resize tcloud cvm disk failed, err: %v, opt: %v, rid: %s", err, opt,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncTCloudCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// RenameTCloudCvm ...
+func (svc *cvmSvc) RenameTCloudCvm(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.RenameReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.TCloud, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.TCloudRenameOption{
+		Region:  cvm.Region,
+		CloudID: cvm.CloudID,
+		Name:    req.Name,
+	}
+	if err = client.RenameCvm(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to rename tcloud cvm failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncTCloudCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// ChangeTCloudCvmSecurityGroup ...
+func (svc *cvmSvc) ChangeTCloudCvmSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.ChangeSecurityGroupReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getCvm(cts.Kit, enumor.TCloud, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	cloudSGIDs, err := svc.getCloudSecurityGroupIDs(cts.Kit, cvm, req.SecurityGroupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.TCloudChangeSecurityGroupOption{
+		Region:                cvm.Region,
+		CloudID:               cvm.CloudID,
+		CloudSecurityGroupIDs: cloudSGIDs,
+	}
+	if err = client.ChangeCvmSecurityGroup(cts.Kit, opt); err != nil {
+		logs.Errorf("request adaptor to change tcloud cvm security group failed, err: %v, opt: %v, rid: %s", err,
+			opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.syncTCloudCvmWithRelRes(cts.Kit, client, cvm)
+}
+
+// syncTCloudCvmWithRelRes 主机变更后同步主机及其关联的云盘、安全组等资源。
+func (svc *cvmSvc) syncTCloudCvmWithRelRes(kt *kit.Kit, client *tcloud.TCloud, cvm *corecvm.BaseCvm) error {
+	syncClient := synctcloud.NewClient(svc.dataCli, client)
+
+	params := &synctcloud.SyncBaseParams{
+		AccountID: cvm.AccountID,
+		Region:    cvm.Region,
+		CloudIDs:  []string{cvm.CloudID},
+	}
+
+	if _, err := syncClient.CvmWithRelRes(kt, params, &synctcloud.SyncCvmWithRelResOption{}); err != nil {
+		logs.Errorf("sync tcloud cvm with rel res failed, err: %v, cvm: %s, rid: %s", err, cvm.ID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -23,9 +23,11 @@ import (
 	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
 	cloudclient "hcm/cmd/hc-service/service/cloud-adaptor"
 	"hcm/cmd/hc-service/service/disk/datasvc"
+	"hcm/pkg/adaptor/operator"
 	"hcm/pkg/adaptor/types/disk"
 	proto "hcm/pkg/api/hc-service/disk"
 	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -111,12 +113,12 @@ func (svc *DiskSvc) DeleteDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.Aws(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.Aws, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.DeleteDisk(cts.Kit, opt)
+	err = op.DeleteDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -140,12 +142,17 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.Aws(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.Aws, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.AttachDisk(cts.Kit, opt)
+	err = op.AttachDisk(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.Aws(cts.Kit, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
@@ -154,8 +161,8 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 
 	params := &syncaws.SyncBaseParams{
 		AccountID: req.AccountID,
-		Region:    opt.Region,
-		CloudIDs:  []string{opt.CloudDiskID},
+		Region:    opt.Disk.Region,
+		CloudIDs:  []string{opt.Disk.CloudID},
 	}
 
 	_, err = syncClient.Disk(cts.Kit, params, &syncaws.SyncDiskOption{BootMap: nil})
@@ -164,7 +171,7 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	params.CloudIDs = []string{opt.CloudCvmID}
+	params.CloudIDs = []string{opt.Cvm.CloudID}
 	_, err = syncClient.CvmWithRelRes(cts.Kit, params, &syncaws.SyncCvmWithRelResOption{})
 	if err != nil {
 		logs.Errorf("sync aws cvm with rel res failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -189,12 +196,12 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.Aws(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.Aws, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.DetachDisk(cts.Kit, opt)
+	err = op.DetachDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -205,12 +212,17 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	client, err := svc.Adaptor.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncaws.NewClient(svc.DataCli, client)
 
 	params := &syncaws.SyncBaseParams{
 		AccountID: req.AccountID,
-		Region:    opt.Region,
-		CloudIDs:  []string{opt.CloudDiskID},
+		Region:    opt.Disk.Region,
+		CloudIDs:  []string{opt.Disk.CloudID},
 	}
 
 	_, err = syncClient.Disk(cts.Kit, params, &syncaws.SyncDiskOption{BootMap: nil})
@@ -219,7 +231,7 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	params.CloudIDs = []string{opt.CloudCvmID}
+	params.CloudIDs = []string{opt.Cvm.CloudID}
 	_, err = syncClient.Cvm(cts.Kit, params, &syncaws.SyncCvmOption{})
 	if err != nil {
 		logs.Errorf("sync aws cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -232,7 +244,7 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 func (svc *DiskSvc) makeDiskAttachOption(
 	kt *kit.Kit,
 	req *proto.AwsDiskAttachReq,
-) (*disk.AwsDiskAttachOption, error) {
+) (*operator.DiskAttachOption, error) {
 	dataCli := svc.DataCli.Aws
 
 	diskData, err := dataCli.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
@@ -245,18 +257,17 @@ func (svc *DiskSvc) makeDiskAttachOption(
 		return nil, err
 	}
 
-	return &disk.AwsDiskAttachOption{
-		Region:      diskData.Region,
-		CloudCvmID:  cvmData.CloudID,
-		CloudDiskID: diskData.CloudID,
-		DeviceName:  req.DeviceName,
+	return &operator.DiskAttachOption{
+		Cvm:        operator.ResourceRef{Region: cvmData.Region, CloudID: cvmData.CloudID},
+		Disk:       operator.ResourceRef{Region: diskData.Region, CloudID: diskData.CloudID},
+		DeviceName: req.DeviceName,
 	}, nil
 }
 
 func (svc *DiskSvc) makeDiskDetachOption(
 	kt *kit.Kit,
 	req *proto.DiskDetachReq,
-) (*disk.AwsDiskDetachOption, error) {
+) (*operator.DiskAttachOption, error) {
 	dataCli := svc.DataCli.Aws
 
 	diskData, err := dataCli.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
@@ -269,24 +280,22 @@ func (svc *DiskSvc) makeDiskDetachOption(
 		return nil, err
 	}
 
-	return &disk.AwsDiskDetachOption{
-		Region:      diskData.Region,
-		CloudCvmID:  cvmData.CloudID,
-		CloudDiskID: diskData.CloudID,
+	return &operator.DiskAttachOption{
+		Cvm:  operator.ResourceRef{Region: cvmData.Region, CloudID: cvmData.CloudID},
+		Disk: operator.ResourceRef{Region: diskData.Region, CloudID: diskData.CloudID},
 	}, nil
 }
 
 func (svc *DiskSvc) makeDiskDeleteOption(
 	kt *kit.Kit,
 	req *proto.DiskDeleteReq,
-) (*disk.AwsDiskDeleteOption, error) {
+) (*operator.DiskDeleteOption, error) {
 	diskData, err := svc.DataCli.Aws.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &disk.AwsDiskDeleteOption{
-		Region:  diskData.Region,
-		CloudID: diskData.CloudID,
+	return &operator.DiskDeleteOption{
+		Disk: operator.ResourceRef{Region: diskData.Region, CloudID: diskData.CloudID},
 	}, nil
 }
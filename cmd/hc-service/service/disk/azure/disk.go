@@ -23,9 +23,11 @@ import (
 	syncazure "hcm/cmd/hc-service/logics/res-sync/azure"
 	cloudclient "hcm/cmd/hc-service/service/cloud-adaptor"
 	"hcm/cmd/hc-service/service/disk/datasvc"
+	"hcm/pkg/adaptor/operator"
 	"hcm/pkg/adaptor/types/disk"
 	proto "hcm/pkg/api/hc-service/disk"
 	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -102,12 +104,12 @@ func (svc *DiskSvc) DeleteDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.Azure(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.Azure, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.DeleteDisk(cts.Kit, opt)
+	err = op.DeleteDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -131,12 +133,12 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.Azure(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.Azure, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.AttachDisk(cts.Kit, opt)
+	err = op.AttachDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -152,11 +154,16 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	client, err := svc.Adaptor.Azure(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncazure.NewClient(svc.DataCli, client)
 
 	params := &syncazure.SyncBaseParams{
 		AccountID:         req.AccountID,
-		ResourceGroupName: opt.ResourceGroupName,
+		ResourceGroupName: opt.Disk.ResourceGroupName,
 		CloudIDs:          []string{diskData.CloudID},
 	}
 
@@ -196,12 +203,12 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.Azure(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.Azure, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.DetachDisk(cts.Kit, opt)
+	err = op.DetachDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -211,11 +218,16 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	client, err := svc.Adaptor.Azure(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncazure.NewClient(svc.DataCli, client)
 
 	params := &syncazure.SyncBaseParams{
 		AccountID:         req.AccountID,
-		ResourceGroupName: opt.ResourceGroupName,
+		ResourceGroupName: opt.Disk.ResourceGroupName,
 		CloudIDs:          []string{diskData.CloudID},
 	}
 
@@ -243,7 +255,7 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 func (svc *DiskSvc) makeDiskAttachOption(
 	kt *kit.Kit,
 	req *proto.AzureDiskAttachReq,
-) (*disk.AzureDiskAttachOption, error) {
+) (*operator.DiskAttachOption, error) {
 	dataCli := svc.DataCli.Azure
 
 	diskData, err := dataCli.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
@@ -256,18 +268,25 @@ func (svc *DiskSvc) makeDiskAttachOption(
 		return nil, err
 	}
 
-	return &disk.AzureDiskAttachOption{
-		ResourceGroupName: diskData.Extension.ResourceGroupName,
-		CvmName:           cvmData.Name,
-		DiskName:          diskData.Name,
-		CachingType:       req.CachingType,
+	return &operator.DiskAttachOption{
+		Cvm: operator.ResourceRef{
+			ResourceGroupName: cvmData.Extension.ResourceGroupName,
+			Name:              cvmData.Name,
+			CloudID:           cvmData.CloudID,
+		},
+		Disk: operator.ResourceRef{
+			ResourceGroupName: diskData.Extension.ResourceGroupName,
+			Name:              diskData.Name,
+			CloudID:           diskData.CloudID,
+		},
+		CachingType: req.CachingType,
 	}, nil
 }
 
 func (svc *DiskSvc) makeDiskDetachOption(
 	kt *kit.Kit,
 	req *proto.DiskDetachReq,
-) (*disk.AzureDiskDetachOption, error) {
+) (*operator.DiskAttachOption, error) {
 	dataCli := svc.DataCli.Azure
 
 	diskData, err := dataCli.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
@@ -280,24 +299,34 @@ func (svc *DiskSvc) makeDiskDetachOption(
 		return nil, err
 	}
 
-	return &disk.AzureDiskDetachOption{
-		ResourceGroupName: diskData.Extension.ResourceGroupName,
-		CvmName:           cvmData.Name,
-		DiskName:          diskData.Name,
+	return &operator.DiskAttachOption{
+		Cvm: operator.ResourceRef{
+			ResourceGroupName: cvmData.Extension.ResourceGroupName,
+			Name:              cvmData.Name,
+			CloudID:           cvmData.CloudID,
+		},
+		Disk: operator.ResourceRef{
+			ResourceGroupName: diskData.Extension.ResourceGroupName,
+			Name:              diskData.Name,
+			CloudID:           diskData.CloudID,
+		},
 	}, nil
 }
 
 func (svc *DiskSvc) makeDiskDeleteOption(
 	kt *kit.Kit,
 	req *proto.DiskDeleteReq,
-) (*disk.AzureDiskDeleteOption, error) {
+) (*operator.DiskDeleteOption, error) {
 	diskData, err := svc.DataCli.Azure.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &disk.AzureDiskDeleteOption{
-		ResourceGroupName: diskData.Extension.ResourceGroupName,
-		DiskName:          diskData.Name,
+	return &operator.DiskDeleteOption{
+		Disk: operator.ResourceRef{
+			ResourceGroupName: diskData.Extension.ResourceGroupName,
+			Name:              diskData.Name,
+			CloudID:           diskData.CloudID,
+		},
 	}, nil
 }
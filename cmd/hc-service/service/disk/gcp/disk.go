@@ -23,9 +23,11 @@ import (
 	syncgcp "hcm/cmd/hc-service/logics/res-sync/gcp"
 	cloudclient "hcm/cmd/hc-service/service/cloud-adaptor"
 	"hcm/cmd/hc-service/service/disk/datasvc"
+	"hcm/pkg/adaptor/operator"
 	"hcm/pkg/adaptor/types/disk"
 	proto "hcm/pkg/api/hc-service/disk"
 	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -112,12 +114,12 @@ func (svc *DiskSvc) DeleteDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.Gcp(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.Gcp, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.DeleteDisk(cts.Kit, opt)
+	err = op.DeleteDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -141,12 +143,12 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.Gcp(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.Gcp, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.AttachDisk(cts.Kit, opt)
+	err = op.AttachDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +164,11 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	client, err := svc.Adaptor.Gcp(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncgcp.NewClient(svc.DataCli, client)
 
 	params := &syncgcp.SyncBaseParams{
@@ -170,7 +177,7 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 	}
 
 	_, err = syncClient.Disk(cts.Kit, params, &syncgcp.SyncDiskOption{BootMap: nil,
-		Zone: opt.Zone})
+		Zone: opt.Disk.Zone})
 	if err != nil {
 		logs.Errorf("sync gcp disk failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
@@ -183,7 +190,7 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 
 	params.CloudIDs = []string{cvmData.CloudID}
 	_, err = syncClient.Cvm(cts.Kit, params, &syncgcp.SyncCvmOption{Region: cvmData.Region,
-		Zone: opt.Zone})
+		Zone: opt.Disk.Zone})
 	if err != nil {
 		logs.Errorf("sync gcp cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
@@ -207,12 +214,12 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.Gcp(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.Gcp, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.DetachDisk(cts.Kit, opt)
+	err = op.DetachDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -222,6 +229,11 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	client, err := svc.Adaptor.Gcp(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := syncgcp.NewClient(svc.DataCli, client)
 
 	params := &syncgcp.SyncBaseParams{
@@ -230,7 +242,7 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 	}
 
 	_, err = syncClient.Disk(cts.Kit, params, &syncgcp.SyncDiskOption{BootMap: nil,
-		Zone: opt.Zone})
+		Zone: opt.Disk.Zone})
 	if err != nil {
 		logs.Errorf("sync gcp disk failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
@@ -254,7 +266,7 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 func (svc *DiskSvc) makeDiskAttachOption(
 	kt *kit.Kit,
 	req *proto.GcpDiskAttachReq,
-) (*disk.GcpDiskAttachOption, error) {
+) (*operator.DiskAttachOption, error) {
 	dataCli := svc.DataCli.Gcp
 
 	diskData, err := dataCli.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
@@ -267,10 +279,9 @@ func (svc *DiskSvc) makeDiskAttachOption(
 		return nil, err
 	}
 
-	return &disk.GcpDiskAttachOption{
-		Zone:       diskData.Zone,
-		CvmName:    cvmData.Name,
-		DiskName:   diskData.Name,
+	return &operator.DiskAttachOption{
+		Cvm:        operator.ResourceRef{Zone: cvmData.Zone, Name: cvmData.Name, CloudID: cvmData.CloudID},
+		Disk:       operator.ResourceRef{Zone: diskData.Zone, Name: diskData.Name, CloudID: diskData.CloudID},
 		DeviceName: req.DeviceName,
 	}, nil
 }
@@ -278,7 +289,7 @@ func (svc *DiskSvc) makeDiskAttachOption(
 func (svc *DiskSvc) makeDiskDetachOption(
 	kt *kit.Kit,
 	req *proto.DiskDetachReq,
-) (*disk.GcpDiskDetachOption, error) {
+) (*operator.DiskAttachOption, error) {
 	dataCli := svc.DataCli.Gcp
 
 	diskData, err := dataCli.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
@@ -299,22 +310,23 @@ func (svc *DiskSvc) makeDiskDetachOption(
 		}
 	}
 
-	return &disk.GcpDiskDetachOption{
-		Zone:       diskData.Zone,
-		CvmName:    cvmData.Name,
+	return &operator.DiskAttachOption{
+		Cvm:        operator.ResourceRef{Zone: cvmData.Zone, Name: cvmData.Name, CloudID: cvmData.CloudID},
+		Disk:       operator.ResourceRef{Zone: diskData.Zone, Name: diskData.Name, CloudID: diskData.CloudID},
 		DeviceName: deviceName,
-		DiskName:   diskData.Name,
 	}, nil
 }
 
 func (svc *DiskSvc) makeDiskDeleteOption(
 	kt *kit.Kit,
 	req *proto.DiskDeleteReq,
-) (*disk.GcpDiskDeleteOption, error) {
+) (*operator.DiskDeleteOption, error) {
 	diskData, err := svc.DataCli.Gcp.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &disk.GcpDiskDeleteOption{Zone: diskData.Zone, DiskName: diskData.Name}, nil
+	return &operator.DiskDeleteOption{
+		Disk: operator.ResourceRef{Zone: diskData.Zone, Name: diskData.Name, CloudID: diskData.CloudID},
+	}, nil
 }
@@ -23,13 +23,16 @@ import (
 	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
 	cloudclient "hcm/cmd/hc-service/service/cloud-adaptor"
 	"hcm/cmd/hc-service/service/disk/datasvc"
+	"hcm/pkg/adaptor/operator"
 	"hcm/pkg/adaptor/types/disk"
 	proto "hcm/pkg/api/hc-service/disk"
 	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
 )
 
 // DiskSvc ...
@@ -121,12 +124,12 @@ func (svc *DiskSvc) DeleteDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.HuaWei(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.HuaWei, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.DeleteDisk(cts.Kit, opt)
+	err = op.DeleteDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -150,12 +153,12 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.HuaWei(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.HuaWei, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.AttachDisk(cts.Kit, opt)
+	err = op.AttachDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -166,12 +169,17 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	client, err := svc.Adaptor.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := synchuawei.NewClient(svc.DataCli, client)
 
 	params := &synchuawei.SyncBaseParams{
 		AccountID: req.AccountID,
-		Region:    opt.Region,
-		CloudIDs:  []string{opt.CloudDiskID},
+		Region:    opt.Disk.Region,
+		CloudIDs:  []string{opt.Disk.CloudID},
 	}
 
 	_, err = syncClient.Disk(cts.Kit, params, &synchuawei.SyncDiskOption{BootMap: nil})
@@ -180,7 +188,7 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	params.CloudIDs = []string{opt.CloudCvmID}
+	params.CloudIDs = []string{opt.Cvm.CloudID}
 	_, err = syncClient.Cvm(cts.Kit, params, &synchuawei.SyncCvmOption{})
 	if err != nil {
 		logs.Errorf("sync huawei cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -205,12 +213,17 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.HuaWei(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.HuaWei, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.DetachDisk(cts.Kit, opt)
+	err = op.DetachDisk(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.HuaWei(cts.Kit, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
@@ -219,8 +232,8 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 
 	params := &synchuawei.SyncBaseParams{
 		AccountID: req.AccountID,
-		Region:    opt.Region,
-		CloudIDs:  []string{opt.CloudDiskID},
+		Region:    opt.Disk.Region,
+		CloudIDs:  []string{opt.Disk.CloudID},
 	}
 
 	_, err = syncClient.Disk(cts.Kit, params, &synchuawei.SyncDiskOption{BootMap: nil})
@@ -229,7 +242,7 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	params.CloudIDs = []string{opt.CloudCvmID}
+	params.CloudIDs = []string{opt.Cvm.CloudID}
 	_, err = syncClient.CvmWithRelRes(cts.Kit, params, &synchuawei.SyncCvmWithRelResOption{})
 	if err != nil {
 		logs.Errorf("sync huawei cvm with rel res failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -242,7 +255,7 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 func (svc *DiskSvc) makeDiskAttachOption(
 	kt *kit.Kit,
 	req *proto.HuaWeiDiskAttachReq,
-) (*disk.HuaWeiDiskAttachOption, error) {
+) (*operator.DiskAttachOption, error) {
 	dataCli := svc.DataCli.HuaWei
 
 	diskData, err := dataCli.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
@@ -255,18 +268,17 @@ func (svc *DiskSvc) makeDiskAttachOption(
 		return nil, err
 	}
 
-	return &disk.HuaWeiDiskAttachOption{
-		Region:      diskData.Region,
-		CloudCvmID:  cvmData.CloudID,
-		CloudDiskID: diskData.CloudID,
-		DeviceName:  req.DeviceName,
+	return &operator.DiskAttachOption{
+		Cvm:        operator.ResourceRef{Region: cvmData.Region, CloudID: cvmData.CloudID},
+		Disk:       operator.ResourceRef{Region: diskData.Region, CloudID: diskData.CloudID},
+		DeviceName: converter.PtrToVal(req.DeviceName),
 	}, nil
 }
 
 func (svc *DiskSvc) makeDiskDetachOption(
 	kt *kit.Kit,
 	req *proto.DiskDetachReq,
-) (*disk.HuaWeiDiskDetachOption, error) {
+) (*operator.DiskAttachOption, error) {
 	dataCli := svc.DataCli.HuaWei
 
 	diskData, err := dataCli.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
@@ -279,21 +291,22 @@ func (svc *DiskSvc) makeDiskDetachOption(
 		return nil, err
 	}
 
-	return &disk.HuaWeiDiskDetachOption{
-		Region:      diskData.Region,
-		CloudCvmID:  cvmData.CloudID,
-		CloudDiskID: diskData.CloudID,
+	return &operator.DiskAttachOption{
+		Cvm:  operator.ResourceRef{Region: cvmData.Region, CloudID: cvmData.CloudID},
+		Disk: operator.ResourceRef{Region: diskData.Region, CloudID: diskData.CloudID},
 	}, nil
 }
 
 func (svc *DiskSvc) makeDiskDeleteOption(
 	kt *kit.Kit,
 	req *proto.DiskDeleteReq,
-) (*disk.HuaWeiDiskDeleteOption, error) {
+) (*operator.DiskDeleteOption, error) {
 	diskData, err := svc.DataCli.HuaWei.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &disk.HuaWeiDiskDeleteOption{Region: diskData.Region, CloudID: diskData.CloudID}, nil
+	return &operator.DiskDeleteOption{
+		Disk: operator.ResourceRef{Region: diskData.Region, CloudID: diskData.CloudID},
+	}, nil
 }
@@ -23,9 +23,11 @@ import (
 	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
 	cloudclient "hcm/cmd/hc-service/service/cloud-adaptor"
 	"hcm/cmd/hc-service/service/disk/datasvc"
+	"hcm/pkg/adaptor/operator"
 	"hcm/pkg/adaptor/types/disk"
 	proto "hcm/pkg/api/hc-service/disk"
 	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -120,12 +122,12 @@ func (svc *DiskSvc) DeleteDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.TCloud(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.TCloud, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.DeleteDisk(cts.Kit, opt)
+	err = op.DeleteDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -149,12 +151,12 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.TCloud(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.TCloud, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.AttachDisk(cts.Kit, opt)
+	err = op.AttachDisk(cts.Kit, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -165,12 +167,17 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	client, err := svc.Adaptor.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	syncClient := synctcloud.NewClient(svc.DataCli, client)
 
 	params := &synctcloud.SyncBaseParams{
 		AccountID: req.AccountID,
-		Region:    opt.Region,
-		CloudIDs:  opt.CloudDiskIDs,
+		Region:    opt.Disk.Region,
+		CloudIDs:  []string{opt.Disk.CloudID},
 	}
 
 	_, err = syncClient.Disk(cts.Kit, params, &synctcloud.SyncDiskOption{})
@@ -179,7 +186,7 @@ func (svc *DiskSvc) AttachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	params.CloudIDs = []string{opt.CloudCvmID}
+	params.CloudIDs = []string{opt.Cvm.CloudID}
 	_, err = syncClient.Cvm(cts.Kit, params, &synctcloud.SyncCvmOption{})
 	if err != nil {
 		logs.Errorf("sync tcloud cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -204,12 +211,17 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	client, err := svc.Adaptor.TCloud(cts.Kit, req.AccountID)
+	op, err := svc.Adaptor.Operator(cts.Kit, enumor.TCloud, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.DetachDisk(cts.Kit, opt)
+	err = op.DetachDisk(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.Adaptor.TCloud(cts.Kit, req.AccountID)
 	if err != nil {
 		return nil, err
 	}
@@ -218,8 +230,8 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 
 	params := &synctcloud.SyncBaseParams{
 		AccountID: req.AccountID,
-		Region:    opt.Region,
-		CloudIDs:  opt.CloudDiskIDs,
+		Region:    opt.Disk.Region,
+		CloudIDs:  []string{opt.Disk.CloudID},
 	}
 
 	_, err = syncClient.Disk(cts.Kit, params, &synctcloud.SyncDiskOption{})
@@ -228,7 +240,7 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	params.CloudIDs = []string{opt.CloudCvmID}
+	params.CloudIDs = []string{opt.Cvm.CloudID}
 	_, err = syncClient.CvmWithRelRes(cts.Kit, params, &synctcloud.SyncCvmWithRelResOption{})
 	if err != nil {
 		logs.Errorf("sync tcloud cvm with rel res failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -241,7 +253,7 @@ func (svc *DiskSvc) DetachDisk(cts *rest.Contexts) (interface{}, error) {
 func (svc *DiskSvc) makeDiskAttachOption(
 	kt *kit.Kit,
 	req *proto.TCloudDiskAttachReq,
-) (*disk.TCloudDiskAttachOption, error) {
+) (*operator.DiskAttachOption, error) {
 	dataCli := svc.DataCli.TCloud
 
 	diskData, err := dataCli.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
@@ -254,17 +266,16 @@ func (svc *DiskSvc) makeDiskAttachOption(
 		return nil, err
 	}
 
-	return &disk.TCloudDiskAttachOption{
-		Region:       diskData.Region,
-		CloudCvmID:   cvmData.CloudID,
-		CloudDiskIDs: []string{diskData.CloudID},
+	return &operator.DiskAttachOption{
+		Cvm:  operator.ResourceRef{Region: cvmData.Region, CloudID: cvmData.CloudID},
+		Disk: operator.ResourceRef{Region: diskData.Region, CloudID: diskData.CloudID},
 	}, nil
 }
 
 func (svc *DiskSvc) makeDiskDetachOption(
 	kt *kit.Kit,
 	req *proto.DiskDetachReq,
-) (*disk.TCloudDiskDetachOption, error) {
+) (*operator.DiskAttachOption, error) {
 	dataCli := svc.DataCli.TCloud
 
 	diskData, err := dataCli.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
@@ -277,20 +288,21 @@ func (svc *DiskSvc) makeDiskDetachOption(
 		return nil, err
 	}
 
-	return &disk.TCloudDiskDetachOption{
-		Region:       diskData.Region,
-		CloudCvmID:   cvmData.CloudID,
-		CloudDiskIDs: []string{diskData.CloudID},
+	return &operator.DiskAttachOption{
+		Cvm:  operator.ResourceRef{Region: cvmData.Region, CloudID: cvmData.CloudID},
+		Disk: operator.ResourceRef{Region: diskData.Region, CloudID: diskData.CloudID},
 	}, nil
 }
 
 func (svc *DiskSvc) makeDiskDeleteOption(
 	kt *kit.Kit,
 	req *proto.DiskDeleteReq,
-) (*disk.TCloudDiskDeleteOption, error) {
+) (*operator.DiskDeleteOption, error) {
 	diskData, err := svc.DataCli.TCloud.RetrieveDisk(kt.Ctx, kt.Header(), req.DiskID)
 	if err != nil {
 		return nil, err
 	}
-	return &disk.TCloudDiskDeleteOption{Region: diskData.Region, CloudIDs: []string{diskData.CloudID}}, nil
+	return &operator.DiskDeleteOption{
+		Disk: operator.ResourceRef{Region: diskData.Region, CloudID: diskData.CloudID},
+	}, nil
 }
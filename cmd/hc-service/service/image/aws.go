@@ -0,0 +1,227 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package image
+
+import (
+	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
+	adcore "hcm/pkg/adaptor/types/core"
+	typesimage "hcm/pkg/adaptor/types/image"
+	hcimage "hcm/pkg/api/hc-service/image"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// AwsCreateImage create aws private image from stopped cvm.
+func (svc *imageSvc) AwsCreateImage(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcimage.ImageCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getStoppedCvm(cts.Kit, enumor.Aws, req.CvmID, "stopped")
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesimage.AwsImageCreateOption{
+		Region:      cvm.Region,
+		CloudCvmID:  cvm.CloudID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	cloudID, err := client.CreateImage(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create aws image failed, err: %v, cvm: %s, rid: %s", err, req.CvmID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := syncaws.NewClient(svc.dataCli, client)
+	params := &syncaws.SyncBaseParams{
+		AccountID: cvm.AccountID,
+		Region:    cvm.Region,
+		CloudIDs:  []string{cloudID},
+	}
+	if _, err = syncClient.PrivateImage(cts.Kit, params, new(syncaws.SyncPrivateImageOption)); err != nil {
+		logs.Errorf("sync aws private image failed, err: %v, cloudID: %s, rid: %s", err, cloudID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.getImageID(cts.Kit, enumor.Aws, cvm.AccountID, cloudID)
+}
+
+// AwsCopyImage copy aws private image to other regions.
+func (svc *imageSvc) AwsCopyImage(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(hcimage.ImageCopyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	img, err := svc.dataCli.Aws.RetrieveImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkPrivateImage(img); err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, img.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := req.Name
+	if len(name) == 0 {
+		name = img.Name
+	}
+
+	opt := &typesimage.AwsImageCopyOption{
+		Region:             img.Extension.Region,
+		CloudID:            img.CloudID,
+		Name:               name,
+		DestinationRegions: req.DestinationRegions,
+	}
+	result, err := client.CopyImage(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("copy aws image failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 云上复制已经成功，同步失败时由定时同步补齐，不影响复制结果
+	syncClient := syncaws.NewClient(svc.dataCli, client)
+	for region, cloudID := range result {
+		if len(cloudID) == 0 {
+			continue
+		}
+
+		params := &syncaws.SyncBaseParams{
+			AccountID: img.AccountID,
+			Region:    region,
+			CloudIDs:  []string{cloudID},
+		}
+		if _, err = syncClient.PrivateImage(cts.Kit, params, new(syncaws.SyncPrivateImageOption)); err != nil {
+			logs.Errorf("sync aws copied image failed, err: %v, region: %s, cloudID: %s, rid: %s", err, region,
+				cloudID, cts.Kit.Rid)
+		}
+	}
+
+	return &hcimage.ImageCopyResult{CloudIDs: result}, nil
+}
+
+// AwsShareImage share aws private image with other aws accounts.
+func (svc *imageSvc) AwsShareImage(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(hcimage.ImageShareReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	img, err := svc.dataCli.Aws.RetrieveImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkPrivateImage(img); err != nil {
+		return nil, err
+	}
+
+	cloudAccountIDs := make([]string, 0, len(req.AccountIDs))
+	for _, accountID := range req.AccountIDs {
+		account, err := svc.dataCli.Aws.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = checkShareAccount(enumor.Aws, img.AccountID, &account.BaseAccount); err != nil {
+			return nil, err
+		}
+
+		if account.Extension == nil || len(account.Extension.CloudAccountID) == 0 {
+			return nil, errf.Newf(errf.InvalidParameter, "account: %s cloud account id is empty", accountID)
+		}
+		cloudAccountIDs = append(cloudAccountIDs, account.Extension.CloudAccountID)
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, img.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesimage.AwsImageShareOption{
+		Region:          img.Extension.Region,
+		CloudID:         img.CloudID,
+		CloudAccountIDs: cloudAccountIDs,
+	}
+	if err = client.ShareImage(cts.Kit, opt); err != nil {
+		logs.Errorf("share aws image failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// AwsDeleteImage delete aws private image.
+func (svc *imageSvc) AwsDeleteImage(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	img, err := svc.dataCli.Aws.RetrieveImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkPrivateImage(img); err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, img.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &adcore.BaseRegionalDeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: img.CloudID},
+		Region:           img.Extension.Region,
+	}
+	if err = client.DeleteImage(cts.Kit, opt); err != nil {
+		logs.Errorf("delete aws image failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteImageFromDB(cts.Kit, id)
+}
@@ -0,0 +1,237 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package image
+
+import (
+	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
+	adcore "hcm/pkg/adaptor/types/core"
+	typesimage "hcm/pkg/adaptor/types/image"
+	hcimage "hcm/pkg/api/hc-service/image"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// HuaWeiCreateImage create huawei private image from stopped cvm.
+func (svc *imageSvc) HuaWeiCreateImage(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcimage.ImageCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getStoppedCvm(cts.Kit, enumor.HuaWei, req.CvmID, "SHUTOFF")
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesimage.HuaWeiImageCreateOption{
+		Region:      cvm.Region,
+		CloudCvmID:  cvm.CloudID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	cloudID, err := client.CreateImage(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create huawei image failed, err: %v, cvm: %s, rid: %s", err, req.CvmID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := synchuawei.NewClient(svc.dataCli, client)
+	params := &synchuawei.SyncBaseParams{
+		AccountID: cvm.AccountID,
+		Region:    cvm.Region,
+		CloudIDs:  []string{cloudID},
+	}
+	if _, err = syncClient.PrivateImage(cts.Kit, params, new(synchuawei.SyncPrivateImageOption)); err != nil {
+		logs.Errorf("sync huawei private image failed, err: %v, cloudID: %s, rid: %s", err, cloudID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.getImageID(cts.Kit, enumor.HuaWei, cvm.AccountID, cloudID)
+}
+
+// HuaWeiCopyImage copy huawei private image to other regions.
+func (svc *imageSvc) HuaWeiCopyImage(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(hcimage.ImageCopyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	img, err := svc.dataCli.HuaWei.RetrieveImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkPrivateImage(img); err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, img.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := req.Name
+	if len(name) == 0 {
+		name = img.Name
+	}
+
+	opt := &typesimage.HuaWeiImageCopyOption{
+		Region:             img.Extension.Region,
+		CloudID:            img.CloudID,
+		Name:               name,
+		DestinationRegions: req.DestinationRegions,
+		AgencyName:         req.AgencyName,
+	}
+	result, err := client.CopyImage(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("copy huawei image failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 云上复制已经成功，同步失败时由定时同步补齐，不影响复制结果
+	syncClient := synchuawei.NewClient(svc.dataCli, client)
+	for region, cloudID := range result {
+		if len(cloudID) == 0 {
+			continue
+		}
+
+		params := &synchuawei.SyncBaseParams{
+			AccountID: img.AccountID,
+			Region:    region,
+			CloudIDs:  []string{cloudID},
+		}
+		if _, err = syncClient.PrivateImage(cts.Kit, params, new(synchuawei.SyncPrivateImageOption)); err != nil {
+			logs.Errorf("sync huawei copied image failed, err: %v, region: %s, cloudID: %s, rid: %s", err, region,
+				cloudID, cts.Kit.Rid)
+		}
+	}
+
+	return &hcimage.ImageCopyResult{CloudIDs: result}, nil
+}
+
+// HuaWeiShareImage share huawei private image with other huawei accounts.
+func (svc *imageSvc) HuaWeiShareImage(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(hcimage.ImageShareReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	img, err := svc.dataCli.HuaWei.RetrieveImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkPrivateImage(img); err != nil {
+		return nil, err
+	}
+
+	// 华为云镜像共享的目标为租户在镜像所在地域下的项目，需要使用目标账号查询项目ID
+	cloudProjectIDs := make([]string, 0, len(req.AccountIDs))
+	for _, accountID := range req.AccountIDs {
+		account, err := svc.dataCli.HuaWei.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = checkShareAccount(enumor.HuaWei, img.AccountID, &account.BaseAccount); err != nil {
+			return nil, err
+		}
+
+		targetCli, err := svc.ad.HuaWei(cts.Kit, accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		projectID, err := targetCli.GetRegionProjectID(cts.Kit, img.Extension.Region)
+		if err != nil {
+			logs.Errorf("get huawei project id failed, err: %v, account: %s, region: %s, rid: %s", err, accountID,
+				img.Extension.Region, cts.Kit.Rid)
+			return nil, err
+		}
+		cloudProjectIDs = append(cloudProjectIDs, projectID)
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, img.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesimage.HuaWeiImageShareOption{
+		Region:          img.Extension.Region,
+		CloudID:         img.CloudID,
+		CloudProjectIDs: cloudProjectIDs,
+	}
+	if err = client.ShareImage(cts.Kit, opt); err != nil {
+		logs.Errorf("share huawei image failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// HuaWeiDeleteImage delete huawei private image.
+func (svc *imageSvc) HuaWeiDeleteImage(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	img, err := svc.dataCli.HuaWei.RetrieveImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkPrivateImage(img); err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, img.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &adcore.BaseRegionalDeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: img.CloudID},
+		Region:           img.Extension.Region,
+	}
+	if err = client.DeleteImage(cts.Kit, opt); err != nil {
+		logs.Errorf("delete huawei image failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteImageFromDB(cts.Kit, id)
+}
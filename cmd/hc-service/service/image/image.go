@@ -0,0 +1,175 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package image defines private image service.
+package image
+
+import (
+	"net/http"
+
+	"hcm/cmd/hc-service/service/capability"
+	cloudadaptor "hcm/cmd/hc-service/service/cloud-adaptor"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	dataimage "hcm/pkg/api/data-service/cloud/image"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitImageService initial the private image service
+func InitImageService(cap *capability.Capability) {
+	svc := &imageSvc{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	// 仅腾讯云、亚马逊、华为云支持自定义镜像的创建、复制、共享和删除
+	h.Add("TCloudCreateImage", http.MethodPost, "/vendors/tcloud/images/create", svc.TCloudCreateImage)
+	h.Add("AwsCreateImage", http.MethodPost, "/vendors/aws/images/create", svc.AwsCreateImage)
+	h.Add("HuaWeiCreateImage", http.MethodPost, "/vendors/huawei/images/create", svc.HuaWeiCreateImage)
+
+	h.Add("TCloudCopyImage", http.MethodPost, "/vendors/tcloud/images/{id}/copy", svc.TCloudCopyImage)
+	h.Add("AwsCopyImage", http.MethodPost, "/vendors/aws/images/{id}/copy", svc.AwsCopyImage)
+	h.Add("HuaWeiCopyImage", http.MethodPost, "/vendors/huawei/images/{id}/copy", svc.HuaWeiCopyImage)
+
+	h.Add("TCloudShareImage", http.MethodPost, "/vendors/tcloud/images/{id}/share", svc.TCloudShareImage)
+	h.Add("AwsShareImage", http.MethodPost, "/vendors/aws/images/{id}/share", svc.AwsShareImage)
+	h.Add("HuaWeiShareImage", http.MethodPost, "/vendors/huawei/images/{id}/share", svc.HuaWeiShareImage)
+
+	h.Add("TCloudDeleteImage", http.MethodDelete, "/vendors/tcloud/images/{id}", svc.TCloudDeleteImage)
+	h.Add("AwsDeleteImage", http.MethodDelete, "/vendors/aws/images/{id}", svc.AwsDeleteImage)
+	h.Add("HuaWeiDeleteImage", http.MethodDelete, "/vendors/huawei/images/{id}", svc.HuaWeiDeleteImage)
+
+	h.Load(cap.WebService)
+}
+
+type imageSvc struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+}
+
+// getStoppedCvm 查询用于创建镜像的主机，主机需处于关机状态，保证镜像数据一致
+func (svc *imageSvc) getStoppedCvm(kt *kit.Kit, vendor enumor.Vendor, id string, stoppedStatus string) (
+	*corecvm.BaseCvm, error) {
+
+	listReq := &dataproto.CvmListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dataCli.Global.Cvm.ListCvm(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("request dataservice list cvm failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "cvm: %s not found", id)
+	}
+
+	cvm := listResp.Details[0]
+	if cvm.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "cvm: %s vendor is %s, not %s", id, cvm.Vendor, vendor)
+	}
+
+	if cvm.Status != stoppedStatus {
+		return nil, errf.Newf(errf.InvalidParameter, "cvm: %s status is %s, only stopped cvm can create image",
+			id, cvm.Status)
+	}
+
+	return &cvm, nil
+}
+
+// checkPrivateImage 只有账号下的自定义镜像支持复制、共享和删除，公共镜像和共享镜像不允许操作
+func checkPrivateImage[T dataimage.ImageExtensionResult](img *dataimage.ImageExtResult[T]) error {
+	if img.Type != constant.PrivateImageType || len(img.AccountID) == 0 {
+		return errf.Newf(errf.InvalidParameter, "image: %s is not private image", img.ID)
+	}
+
+	if img.Extension == nil {
+		return errf.Newf(errf.InvalidParameter, "image: %s extension is empty", img.ID)
+	}
+
+	return nil
+}
+
+// getImageID 自定义镜像创建后通过同步写入DB，根据云ID查询镜像在hcm中的ID
+func (svc *imageSvc) getImageID(kt *kit.Kit, vendor enumor.Vendor, accountID string, cloudID string) (
+	*core.CreateResult, error) {
+
+	req := &dataimage.ImageListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.Equal.Factory(), Value: cloudID},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.ListImage(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list %s image failed, err: %v, cloudID: %s, rid: %s", vendor, err, cloudID, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "image: %s not found after sync", cloudID)
+	}
+
+	return &core.CreateResult{ID: result.Details[0].ID}, nil
+}
+
+// deleteImageFromDB 云上镜像删除后，删除DB中的镜像
+func (svc *imageSvc) deleteImageFromDB(kt *kit.Kit, id string) error {
+	req := &dataimage.ImageDeleteReq{
+		Filter: tools.EqualExpression("id", id),
+	}
+	if _, err := svc.dataCli.Global.DeleteImage(kt.Ctx, kt.Header(), req); err != nil {
+		logs.Errorf("delete image from db failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// checkShareAccount 校验共享的目标账号与镜像属于同一云厂商，且不为镜像所属账号
+func checkShareAccount(vendor enumor.Vendor, ownerAccountID string, account *corecloud.BaseAccount) error {
+	if account.Vendor != vendor {
+		return errf.Newf(errf.InvalidParameter, "account: %s vendor is %s, not %s", account.ID, account.Vendor,
+			vendor)
+	}
+
+	if account.ID == ownerAccountID {
+		return errf.Newf(errf.InvalidParameter, "can not share image with its owner account: %s", account.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package image
+
+import (
+	"testing"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	dataimage "hcm/pkg/api/data-service/cloud/image"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestCheckPrivateImage(t *testing.T) {
+	ext := &dataimage.TCloudImageExtensionResult{Region: "ap-guangzhou"}
+	cases := []struct {
+		name    string
+		img     *dataimage.ImageExtResult[dataimage.TCloudImageExtensionResult]
+		wantErr bool
+	}{
+		{
+			name: "private image",
+			img: &dataimage.ImageExtResult[dataimage.TCloudImageExtensionResult]{ID: "00000001",
+				AccountID: "00000001", Type: constant.PrivateImageType, Extension: ext},
+			wantErr: false,
+		},
+		{
+			name: "public image",
+			img: &dataimage.ImageExtResult[dataimage.TCloudImageExtensionResult]{ID: "00000002",
+				Type: constant.PublicImageType, Extension: ext},
+			wantErr: true,
+		},
+		{
+			name: "shared image",
+			img: &dataimage.ImageExtResult[dataimage.TCloudImageExtensionResult]{ID: "00000005",
+				AccountID: "00000001", Type: constant.SharedImageType, Extension: ext},
+			wantErr: true,
+		},
+		{
+			name: "private image without account",
+			img: &dataimage.ImageExtResult[dataimage.TCloudImageExtensionResult]{ID: "00000003",
+				Type: constant.PrivateImageType, Extension: ext},
+			wantErr: true,
+		},
+		{
+			name: "private image without extension",
+			img: &dataimage.ImageExtResult[dataimage.TCloudImageExtensionResult]{ID: "00000004",
+				AccountID: "00000001", Type: constant.PrivateImageType},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		err := checkPrivateImage(c.img)
+		if c.wantErr && err == nil {
+			t.Errorf("%s: expect check failed, but got nil", c.name)
+		}
+
+		if !c.wantErr && err != nil {
+			t.Errorf("%s: expect check success, but got err: %v", c.name, err)
+		}
+	}
+}
+
+func TestCheckShareAccount(t *testing.T) {
+	cases := []struct {
+		name    string
+		account *corecloud.BaseAccount
+		wantErr bool
+	}{
+		{"same vendor account", &corecloud.BaseAccount{ID: "00000002", Vendor: enumor.TCloud}, false},
+		{"other vendor account", &corecloud.BaseAccount{ID: "00000003", Vendor: enumor.Aws}, true},
+		{"owner account", &corecloud.BaseAccount{ID: "00000001", Vendor: enumor.TCloud}, true},
+	}
+
+	for _, c := range cases {
+		err := checkShareAccount(enumor.TCloud, "00000001", c.account)
+		if c.wantErr && err == nil {
+			t.Errorf("%s: expect check failed, but got nil", c.name)
+		}
+
+		if !c.wantErr && err != nil {
+			t.Errorf("%s: expect check success, but got err: %v", c.name, err)
+		}
+	}
+}
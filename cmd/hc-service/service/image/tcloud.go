@@ -0,0 +1,227 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package image
+
+import (
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
+	adcore "hcm/pkg/adaptor/types/core"
+	typesimage "hcm/pkg/adaptor/types/image"
+	"hcm/pkg/api/core"
+	hcimage "hcm/pkg/api/hc-service/image"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// TCloudCreateImage create tcloud private image from stopped cvm.
+func (svc *imageSvc) TCloudCreateImage(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcimage.ImageCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvm, err := svc.getStoppedCvm(cts.Kit, enumor.TCloud, req.CvmID, "STOPPED")
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, cvm.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesimage.TCloudImageCreateOption{
+		Region:      cvm.Region,
+		CloudCvmID:  cvm.CloudID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	cloudID, err := client.CreateImage(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create tcloud image failed, err: %v, cvm: %s, rid: %s", err, req.CvmID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := synctcloud.NewClient(svc.dataCli, client)
+	params := &synctcloud.SyncBaseParams{
+		AccountID: cvm.AccountID,
+		Region:    cvm.Region,
+		CloudIDs:  []string{cloudID},
+	}
+	syncResult, err := syncClient.PrivateImage(cts.Kit, params, new(synctcloud.SyncPrivateImageOption))
+	if err != nil {
+		logs.Errorf("sync tcloud private image failed, err: %v, cloudID: %s, rid: %s", err, cloudID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(syncResult.CreatedIds) == 1 {
+		return &core.CreateResult{ID: syncResult.CreatedIds[0]}, nil
+	}
+
+	return svc.getImageID(cts.Kit, enumor.TCloud, cvm.AccountID, cloudID)
+}
+
+// TCloudCopyImage copy tcloud private image to other regions.
+func (svc *imageSvc) TCloudCopyImage(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(hcimage.ImageCopyReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	img, err := svc.dataCli.TCloud.RetrieveImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkPrivateImage(img); err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, img.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesimage.TCloudImageCopyOption{
+		Region:             img.Extension.Region,
+		CloudID:            img.CloudID,
+		DestinationRegions: req.DestinationRegions,
+	}
+	result, err := client.CopyImage(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("copy tcloud image failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 云上复制已经成功，同步失败时由定时同步补齐，不影响复制结果
+	syncClient := synctcloud.NewClient(svc.dataCli, client)
+	for region, cloudID := range result {
+		if len(cloudID) == 0 {
+			continue
+		}
+
+		params := &synctcloud.SyncBaseParams{
+			AccountID: img.AccountID,
+			Region:    region,
+			CloudIDs:  []string{cloudID},
+		}
+		if _, err = syncClient.PrivateImage(cts.Kit, params, new(synctcloud.SyncPrivateImageOption)); err != nil {
+			logs.Errorf("sync tcloud copied image failed, err: %v, region: %s, cloudID: %s, rid: %s", err, region,
+				cloudID, cts.Kit.Rid)
+		}
+	}
+
+	return &hcimage.ImageCopyResult{CloudIDs: result}, nil
+}
+
+// TCloudShareImage share tcloud private image with other tcloud accounts.
+func (svc *imageSvc) TCloudShareImage(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(hcimage.ImageShareReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	img, err := svc.dataCli.TCloud.RetrieveImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkPrivateImage(img); err != nil {
+		return nil, err
+	}
+
+	cloudAccountIDs := make([]string, 0, len(req.AccountIDs))
+	for _, accountID := range req.AccountIDs {
+		account, err := svc.dataCli.TCloud.Account.Get(cts.Kit.Ctx, cts.Kit.Header(), accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = checkShareAccount(enumor.TCloud, img.AccountID, &account.BaseAccount); err != nil {
+			return nil, err
+		}
+
+		if account.Extension == nil || len(account.Extension.CloudMainAccountID) == 0 {
+			return nil, errf.Newf(errf.InvalidParameter, "account: %s main account id is empty", accountID)
+		}
+		cloudAccountIDs = append(cloudAccountIDs, account.Extension.CloudMainAccountID)
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, img.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesimage.TCloudImageShareOption{
+		Region:          img.Extension.Region,
+		CloudID:         img.CloudID,
+		CloudAccountIDs: cloudAccountIDs,
+	}
+	if err = client.ShareImage(cts.Kit, opt); err != nil {
+		logs.Errorf("share tcloud image failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// TCloudDeleteImage delete tcloud private image.
+func (svc *imageSvc) TCloudDeleteImage(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	img, err := svc.dataCli.TCloud.RetrieveImage(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkPrivateImage(img); err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, img.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &adcore.BaseRegionalDeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: img.CloudID},
+		Region:           img.Extension.Region,
+	}
+	if err = client.DeleteImage(cts.Kit, opt); err != nil {
+		logs.Errorf("delete tcloud image failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteImageFromDB(cts.Kit, id)
+}
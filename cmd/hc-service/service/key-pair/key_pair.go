@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair defines ssh key pair service.
+package keypair
+
+import (
+	"fmt"
+	"net/http"
+
+	kplogics "hcm/cmd/hc-service/logics/key-pair"
+	"hcm/cmd/hc-service/service/capability"
+	cloudadaptor "hcm/cmd/hc-service/service/cloud-adaptor"
+	adcore "hcm/pkg/adaptor/types/core"
+	typekp "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/api/core"
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	protokp "hcm/pkg/api/data-service/cloud/key-pair"
+	hckp "hcm/pkg/api/hc-service/key-pair"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitKeyPairService initial the key pair service
+func InitKeyPairService(cap *capability.Capability) {
+	svc := &kpSvc{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+		keyPair: kplogics.NewKeyPair(cap.ClientSet, cap.CloudAdaptor),
+	}
+
+	h := rest.NewHandler()
+
+	// 微软云、谷歌云创建主机时直接注入公钥，不需要将密钥对推送到云上
+	h.Add("TCloudPushKeyPair", http.MethodPost, "/vendors/tcloud/key_pairs/push", svc.TCloudPushKeyPair)
+	h.Add("AwsPushKeyPair", http.MethodPost, "/vendors/aws/key_pairs/push", svc.AwsPushKeyPair)
+	h.Add("HuaWeiPushKeyPair", http.MethodPost, "/vendors/huawei/key_pairs/push", svc.HuaWeiPushKeyPair)
+
+	h.Add("TCloudDeleteCloudKeyPair", http.MethodDelete, "/vendors/tcloud/cloud_key_pairs/{id}",
+		svc.TCloudDeleteCloudKeyPair)
+	h.Add("AwsDeleteCloudKeyPair", http.MethodDelete, "/vendors/aws/cloud_key_pairs/{id}", svc.AwsDeleteCloudKeyPair)
+	h.Add("HuaWeiDeleteCloudKeyPair", http.MethodDelete, "/vendors/huawei/cloud_key_pairs/{id}",
+		svc.HuaWeiDeleteCloudKeyPair)
+
+	h.Load(cap.WebService)
+}
+
+type kpSvc struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+	keyPair *kplogics.KeyPair
+}
+
+// TCloudPushKeyPair push hcm key pair to tcloud.
+func (svc *kpSvc) TCloudPushKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.pushKeyPair(cts, enumor.TCloud)
+}
+
+// AwsPushKeyPair push hcm key pair to aws.
+func (svc *kpSvc) AwsPushKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.pushKeyPair(cts, enumor.Aws)
+}
+
+// HuaWeiPushKeyPair push hcm key pair to huawei.
+func (svc *kpSvc) HuaWeiPushKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.pushKeyPair(cts, enumor.HuaWei)
+}
+
+func (svc *kpSvc) pushKeyPair(cts *rest.Contexts, vendor enumor.Vendor) (interface{}, error) {
+	req := new(hckp.KeyPairPushReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.keyPair.Push(cts.Kit, vendor, req.AccountID, req.Region, req.KeyPairID)
+}
+
+// TCloudDeleteCloudKeyPair delete tcloud key pair.
+func (svc *kpSvc) TCloudDeleteCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	keyPair, opt, err := svc.prepareDelete(cts, enumor.TCloud)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, keyPair.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = client.DeleteKeyPair(cts.Kit, opt); err != nil {
+		logs.Errorf("delete tcloud key pair failed, err: %v, id: %s, rid: %s", err, keyPair.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteCloudKeyPairFromDB(cts.Kit, keyPair.ID)
+}
+
+// AwsDeleteCloudKeyPair delete aws key pair.
+func (svc *kpSvc) AwsDeleteCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	keyPair, opt, err := svc.prepareDelete(cts, enumor.Aws)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, keyPair.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = client.DeleteKeyPair(cts.Kit, opt); err != nil {
+		logs.Errorf("delete aws key pair failed, err: %v, id: %s, rid: %s", err, keyPair.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteCloudKeyPairFromDB(cts.Kit, keyPair.ID)
+}
+
+// HuaWeiDeleteCloudKeyPair delete huawei key pair.
+func (svc *kpSvc) HuaWeiDeleteCloudKeyPair(cts *rest.Contexts) (interface{}, error) {
+	keyPair, opt, err := svc.prepareDelete(cts, enumor.HuaWei)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, keyPair.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = client.DeleteKeyPair(cts.Kit, opt); err != nil {
+		logs.Errorf("delete huawei key pair failed, err: %v, id: %s, rid: %s", err, keyPair.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteCloudKeyPairFromDB(cts.Kit, keyPair.ID)
+}
+
+func (svc *kpSvc) prepareDelete(cts *rest.Contexts, vendor enumor.Vendor) (*corekp.CloudKeyPair,
+	*typekp.DeleteOption, error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.KeyPair.ListCloudKeyPair(cts.Kit.Ctx, cts.Kit.Header(), req)
+	if err != nil {
+		logs.Errorf("list cloud key pair failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, nil, errf.Newf(errf.RecordNotFound, "cloud key pair: %s not found", id)
+	}
+
+	keyPair := result.Details[0]
+	if keyPair.Vendor != vendor {
+		return nil, nil, fmt.Errorf("cloud key pair: %s vendor is %s, not %s", id, keyPair.Vendor, vendor)
+	}
+
+	opt := &typekp.DeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: keyPair.CloudID},
+		Region:           keyPair.Region,
+	}
+	return &keyPair, opt, nil
+}
+
+// deleteCloudKeyPairFromDB 云上密钥对删除后，删除DB中的密钥对
+func (svc *kpSvc) deleteCloudKeyPairFromDB(kt *kit.Kit, id string) error {
+	req := &protokp.KeyPairBatchDeleteReq{
+		Filter: tools.EqualExpression("id", id),
+	}
+	if err := svc.dataCli.Global.KeyPair.BatchDeleteCloudKeyPair(kt.Ctx, kt.Header(), req); err != nil {
+		logs.Errorf("delete cloud key pair from db failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
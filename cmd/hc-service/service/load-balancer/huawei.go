@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
+	adcore "hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// HuaWeiCreateLoadBalancer create huawei load balancer.
+func (svc *lbSvc) HuaWeiCreateLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(hclb.HuaWeiLoadBalancerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CreateLoadBalancer(cts.Kit, req.HuaWeiCreateOption)
+	if err != nil {
+		logs.Errorf("create huawei load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.UnknownCloudIDs) > 0 {
+		logs.Errorf("load balancer(%v) is unknown, rid: %s", result.UnknownCloudIDs, cts.Kit.Rid)
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		return nil, errf.New(errf.Aborted, "create result is invalid")
+	}
+	cloudIDs := result.SuccessCloudIDs
+
+	syncClient := synchuawei.NewClient(svc.dataCli, client)
+	params := &synchuawei.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  cloudIDs,
+	}
+	_, err = syncClient.LoadBalancer(cts.Kit, params, &synchuawei.SyncLoadBalancerOption{
+		BkBizID: req.BkBizID,
+	})
+	if err != nil {
+		logs.Errorf("sync huawei load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.listLoadBalancerIDs(cts.Kit, enumor.HuaWei, cloudIDs)
+}
+
+// HuaWeiDeleteLoadBalancer delete huawei load balancer.
+func (svc *lbSvc) HuaWeiDeleteLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	lb, err := svc.dataCli.HuaWei.LoadBalancer.GetLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, lb.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typelb.DeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: lb.CloudID},
+		Region:           lb.Region,
+	}
+	if err = client.DeleteLoadBalancer(cts.Kit, opt); err != nil {
+		logs.Errorf("delete huawei load balancer failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteLoadBalancerFromDB(cts.Kit, id)
+}
@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer defines load balancer service.
+package loadbalancer
+
+import (
+	"net/http"
+
+	"hcm/cmd/hc-service/service/capability"
+	cloudadaptor "hcm/cmd/hc-service/service/cloud-adaptor"
+	"hcm/pkg/api/core"
+	protolb "hcm/pkg/api/data-service/cloud/load-balancer"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitLoadBalancerService initial the load balancer service
+func InitLoadBalancerService(cap *capability.Capability) {
+	svc := &lbSvc{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("TCloudCreateLoadBalancer", http.MethodPost, "/vendors/tcloud/load_balancers/create",
+		svc.TCloudCreateLoadBalancer)
+	h.Add("AwsCreateLoadBalancer", http.MethodPost, "/vendors/aws/load_balancers/create", svc.AwsCreateLoadBalancer)
+	h.Add("HuaWeiCreateLoadBalancer", http.MethodPost, "/vendors/huawei/load_balancers/create",
+		svc.HuaWeiCreateLoadBalancer)
+	h.Add("AzureCreateLoadBalancer", http.MethodPost, "/vendors/azure/load_balancers/create",
+		svc.AzureCreateLoadBalancer)
+	h.Add("GcpCreateLoadBalancer", http.MethodPost, "/vendors/gcp/load_balancers/create", svc.GcpCreateLoadBalancer)
+
+	h.Add("TCloudDeleteLoadBalancer", http.MethodDelete, "/vendors/tcloud/load_balancers/{id}",
+		svc.TCloudDeleteLoadBalancer)
+	h.Add("AwsDeleteLoadBalancer", http.MethodDelete, "/vendors/aws/load_balancers/{id}", svc.AwsDeleteLoadBalancer)
+	h.Add("HuaWeiDeleteLoadBalancer", http.MethodDelete, "/vendors/huawei/load_balancers/{id}",
+		svc.HuaWeiDeleteLoadBalancer)
+	h.Add("AzureDeleteLoadBalancer", http.MethodDelete, "/vendors/azure/load_balancers/{id}",
+		svc.AzureDeleteLoadBalancer)
+	h.Add("GcpDeleteLoadBalancer", http.MethodDelete, "/vendors/gcp/load_balancers/{id}", svc.GcpDeleteLoadBalancer)
+
+	h.Load(cap.WebService)
+}
+
+type lbSvc struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+}
+
+// listLoadBalancerIDs 负载均衡创建后会通过同步写入DB，根据云ID查询负载均衡在hcm中的ID
+func (svc *lbSvc) listLoadBalancerIDs(kt *kit.Kit, vendor enumor.Vendor, cloudIDs []string) (
+	*core.BatchCreateResult, error) {
+
+	req := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.LoadBalancer.ListLoadBalancer(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list %s load balancer failed, err: %v, cloudIDs: %v, rid: %s", vendor, err, cloudIDs, kt.Rid)
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(result.Details))
+	for _, one := range result.Details {
+		ids = append(ids, one.ID)
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// deleteLoadBalancerFromDB 删除负载均衡，监听器及后端目标由 data-service 级联删除
+func (svc *lbSvc) deleteLoadBalancerFromDB(kt *kit.Kit, id string) error {
+	req := &protolb.LoadBalancerBatchDeleteReq{
+		Filter: tools.EqualExpression("id", id),
+	}
+	if err := svc.dataCli.Global.LoadBalancer.BatchDeleteLoadBalancer(kt.Ctx, kt.Header(), req); err != nil {
+		logs.Errorf("delete load balancer from db failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
+	adcore "hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// TCloudCreateLoadBalancer create tcloud load balancer.
+func (svc *lbSvc) TCloudCreateLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(hclb.TCloudLoadBalancerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CreateLoadBalancer(cts.Kit, req.TCloudCreateOption)
+	if err != nil {
+		logs.Errorf("create tcloud load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.UnknownCloudIDs) > 0 {
+		logs.Errorf("load balancer(%v) is unknown, rid: %s", result.UnknownCloudIDs, cts.Kit.Rid)
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		return nil, errf.New(errf.Aborted, "create result is invalid")
+	}
+
+	syncClient := synctcloud.NewClient(svc.dataCli, client)
+	params := &synctcloud.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  result.SuccessCloudIDs,
+	}
+	syncResult, err := syncClient.LoadBalancer(cts.Kit, params, &synctcloud.SyncLoadBalancerOption{
+		BkBizID: req.BkBizID,
+	})
+	if err != nil {
+		logs.Errorf("sync tcloud load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(syncResult.CreatedIds) == len(result.SuccessCloudIDs) {
+		return &core.BatchCreateResult{IDs: syncResult.CreatedIds}, nil
+	}
+
+	return svc.listLoadBalancerIDs(cts.Kit, enumor.TCloud, result.SuccessCloudIDs)
+}
+
+// TCloudDeleteLoadBalancer delete tcloud load balancer.
+func (svc *lbSvc) TCloudDeleteLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	lb, err := svc.dataCli.TCloud.LoadBalancer.GetLoadBalancer(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, lb.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typelb.DeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: lb.CloudID},
+		Region:           lb.Region,
+	}
+	if err = client.DeleteLoadBalancer(cts.Kit, opt); err != nil {
+		logs.Errorf("delete tcloud load balancer failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteLoadBalancerFromDB(cts.Kit, id)
+}
@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
+	adcore "hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	hcnat "hcm/pkg/api/hc-service/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// HuaWeiCreateNatGateway create huawei nat gateway.
+func (svc *natSvc) HuaWeiCreateNatGateway(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcnat.HuaWeiNatGatewayCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CreateNatGateway(cts.Kit, req.HuaWeiCreateOption)
+	if err != nil {
+		logs.Errorf("create huawei nat gateway failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.UnknownCloudIDs) > 0 {
+		logs.Errorf("nat gateway(%v) is unknown, rid: %s", result.UnknownCloudIDs, cts.Kit.Rid)
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		return nil, errf.New(errf.Aborted, "create result is invalid")
+	}
+	cloudIDs := result.SuccessCloudIDs
+
+	syncClient := synchuawei.NewClient(svc.dataCli, client)
+	params := &synchuawei.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  cloudIDs,
+	}
+	_, err = syncClient.NatGateway(cts.Kit, params, &synchuawei.SyncNatGatewayOption{
+		BkBizID: req.BkBizID,
+	})
+	if err != nil {
+		logs.Errorf("sync huawei nat gateway failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.listNatGatewayIDs(cts.Kit, enumor.HuaWei, cloudIDs)
+}
+
+// HuaWeiDeleteNatGateway delete huawei nat gateway.
+func (svc *natSvc) HuaWeiDeleteNatGateway(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	nat, err := svc.dataCli.HuaWei.NatGateway.GetNatGateway(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, nat.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typenat.DeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: nat.CloudID},
+		Region:           nat.Region,
+	}
+	if err = client.DeleteNatGateway(cts.Kit, opt); err != nil {
+		logs.Errorf("delete huawei nat gateway failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteNatGatewayFromDB(cts.Kit, id)
+}
@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway defines nat gateway service.
+package natgateway
+
+import (
+	"net/http"
+
+	"hcm/cmd/hc-service/service/capability"
+	cloudadaptor "hcm/cmd/hc-service/service/cloud-adaptor"
+	"hcm/pkg/api/core"
+	protonat "hcm/pkg/api/data-service/cloud/nat-gateway"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitNatGatewayService initial the nat gateway service
+func InitNatGatewayService(cap *capability.Capability) {
+	svc := &natSvc{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("TCloudCreateNatGateway", http.MethodPost, "/vendors/tcloud/nat_gateways/create",
+		svc.TCloudCreateNatGateway)
+	h.Add("AwsCreateNatGateway", http.MethodPost, "/vendors/aws/nat_gateways/create", svc.AwsCreateNatGateway)
+	h.Add("HuaWeiCreateNatGateway", http.MethodPost, "/vendors/huawei/nat_gateways/create",
+		svc.HuaWeiCreateNatGateway)
+	h.Add("AzureCreateNatGateway", http.MethodPost, "/vendors/azure/nat_gateways/create",
+		svc.AzureCreateNatGateway)
+	h.Add("GcpCreateNatGateway", http.MethodPost, "/vendors/gcp/nat_gateways/create", svc.GcpCreateNatGateway)
+
+	h.Add("TCloudDeleteNatGateway", http.MethodDelete, "/vendors/tcloud/nat_gateways/{id}",
+		svc.TCloudDeleteNatGateway)
+	h.Add("AwsDeleteNatGateway", http.MethodDelete, "/vendors/aws/nat_gateways/{id}", svc.AwsDeleteNatGateway)
+	h.Add("HuaWeiDeleteNatGateway", http.MethodDelete, "/vendors/huawei/nat_gateways/{id}",
+		svc.HuaWeiDeleteNatGateway)
+	h.Add("AzureDeleteNatGateway", http.MethodDelete, "/vendors/azure/nat_gateways/{id}",
+		svc.AzureDeleteNatGateway)
+	h.Add("GcpDeleteNatGateway", http.MethodDelete, "/vendors/gcp/nat_gateways/{id}", svc.GcpDeleteNatGateway)
+
+	h.Load(cap.WebService)
+}
+
+type natSvc struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+}
+
+// listNatGatewayIDs NAT网关创建后会通过同步写入DB，根据云ID查询NAT网关在hcm中的ID
+func (svc *natSvc) listNatGatewayIDs(kt *kit.Kit, vendor enumor.Vendor, cloudIDs []string) (
+	*core.BatchCreateResult, error) {
+
+	req := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.NatGateway.ListNatGateway(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list %s nat gateway failed, err: %v, cloudIDs: %v, rid: %s", vendor, err, cloudIDs, kt.Rid)
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(result.Details))
+	for _, one := range result.Details {
+		ids = append(ids, one.ID)
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// deleteNatGatewayFromDB 云上NAT网关删除后，删除DB中的NAT网关
+func (svc *natSvc) deleteNatGatewayFromDB(kt *kit.Kit, id string) error {
+	req := &protonat.NatGatewayBatchDeleteReq{
+		Filter: tools.EqualExpression("id", id),
+	}
+	if err := svc.dataCli.Global.NatGateway.BatchDeleteNatGateway(kt.Ctx, kt.Header(), req); err != nil {
+		logs.Errorf("delete nat gateway from db failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package resourcetag
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/operator"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	protodisk "hcm/pkg/api/data-service/cloud/disk"
+	protoeip "hcm/pkg/api/data-service/cloud/eip"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+)
+
+// tagResource is the resource in hcm which tags belong to.
+type tagResource struct {
+	ID  string
+	Ref operator.ResourceRef
+}
+
+// listTagResource list resources of the resource type, only the fields used to locate the cloud resource are
+// returned.
+func (svc *resourceTagSvc) listTagResource(kt *kit.Kit, resType enumor.CloudResourceType, expr *filter.Expression,
+	page *core.BasePage) ([]tagResource, error) {
+
+	global := svc.dataCli.Global
+	resources := make([]tagResource, 0)
+	switch resType {
+	case enumor.CvmCloudResType:
+		req := &dataproto.CvmListReq{
+			Field:  []string{"id", "cloud_id", "region", "zone", "name"},
+			Filter: expr,
+			Page:   page,
+		}
+		result, err := global.Cvm.ListCvm(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			resources = append(resources, tagResource{ID: one.ID, Ref: operator.ResourceRef{Region: one.Region,
+				Zone: one.Zone, CloudID: one.CloudID, Name: one.Name}})
+		}
+
+	case enumor.DiskCloudResType:
+		req := &protodisk.DiskListReq{
+			Fields: []string{"id", "cloud_id", "region", "zone", "name"},
+			Filter: expr,
+			Page:   page,
+		}
+		result, err := global.ListDisk(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			resources = append(resources, tagResource{ID: one.ID, Ref: operator.ResourceRef{Region: one.Region,
+				Zone: one.Zone, CloudID: one.CloudID, Name: one.Name}})
+		}
+
+	case enumor.VpcCloudResType:
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id", "region", "name"},
+			Filter: expr,
+			Page:   page,
+		}
+		result, err := global.Vpc.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			resources = append(resources, tagResource{ID: one.ID, Ref: operator.ResourceRef{Region: one.Region,
+				CloudID: one.CloudID, Name: one.Name}})
+		}
+
+	case enumor.SubnetCloudResType:
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id", "region", "zone", "name"},
+			Filter: expr,
+			Page:   page,
+		}
+		result, err := global.Subnet.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			resources = append(resources, tagResource{ID: one.ID, Ref: operator.ResourceRef{Region: one.Region,
+				Zone: one.Zone, CloudID: one.CloudID, Name: one.Name}})
+		}
+
+	case enumor.EipCloudResType:
+		req := &protoeip.EipListReq{
+			Fields: []string{"id", "cloud_id", "region", "name"},
+			Filter: expr,
+			Page:   page,
+		}
+		result, err := global.ListEip(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			resources = append(resources, tagResource{ID: one.ID, Ref: operator.ResourceRef{Region: one.Region,
+				CloudID: one.CloudID, Name: converter.PtrToVal(one.Name)}})
+		}
+
+	case enumor.SecurityGroupCloudResType:
+		req := &dataproto.SecurityGroupListReq{
+			Field:  []string{"id", "cloud_id", "region", "name"},
+			Filter: expr,
+			Page:   page,
+		}
+		result, err := global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			resources = append(resources, tagResource{ID: one.ID, Ref: operator.ResourceRef{Region: one.Region,
+				CloudID: one.CloudID, Name: one.Name}})
+		}
+
+	default:
+		return nil, fmt.Errorf("resource type: %s not support tag", resType)
+	}
+
+	return resources, nil
+}
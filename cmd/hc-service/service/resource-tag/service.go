@@ -0,0 +1,488 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines resource tag service, tags of cvm, disk, vpc, subnet, eip and security group are
+// synced from cloud and written back to cloud by the vendor operator.
+package resourcetag
+
+import (
+	"net/http"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/cmd/hc-service/service/capability"
+	cloudclient "hcm/cmd/hc-service/service/cloud-adaptor"
+	"hcm/pkg/adaptor/operator"
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/api/core"
+	corertag "hcm/pkg/api/core/cloud/resource-tag"
+	dataservice "hcm/pkg/api/data-service"
+	protortag "hcm/pkg/api/data-service/cloud/resource-tag"
+	hcrtag "hcm/pkg/api/hc-service/resource-tag"
+	hcsync "hcm/pkg/api/hc-service/sync"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// InitResourceTagService initial the resource tag service
+func InitResourceTagService(cap *capability.Capability) {
+	svc := &resourceTagSvc{
+		adaptor: cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("SyncResourceTag", http.MethodPost, "/vendors/{vendor}/resource_tags/sync", svc.SyncResourceTag)
+	h.Add("TagResource", http.MethodPost, "/vendors/{vendor}/resource_tags/tag", svc.TagResource)
+	h.Add("UntagResource", http.MethodPost, "/vendors/{vendor}/resource_tags/untag", svc.UntagResource)
+
+	h.Load(cap.WebService)
+}
+
+type resourceTagSvc struct {
+	adaptor *cloudclient.CloudAdaptorClient
+	dataCli *dataclient.Client
+}
+
+// SyncResourceTag sync tags of all resources of the resource type in the account, resource types not support tag
+// of the vendor are skipped.
+func (svc *resourceTagSvc) SyncResourceTag(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(hcsync.ResourceTagSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if req.DryRun {
+		common.EnableDryRun(cts.Kit)
+	}
+	common.EnableSyncReport(cts.Kit, false)
+
+	op, err := svc.adaptor.Operator(cts.Kit, vendor, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !operator.IsTagSupported(op, req.ResType) {
+		return common.GetSyncReport(cts.Kit).Result(), nil
+	}
+
+	expr := tools.EqualWithOpExpression(filter.And, map[string]interface{}{
+		"vendor":     vendor,
+		"account_id": req.AccountID,
+	})
+	existIDs := make(map[string]struct{})
+	page := &core.BasePage{Start: 0, Limit: constant.BatchOperationMaxLimit}
+	for {
+		resources, err := svc.listTagResource(cts.Kit, req.ResType, expr, page)
+		if err != nil {
+			logs.Errorf("list %s resources failed, err: %v, req: %v, rid: %s", req.ResType, err, req, cts.Kit.Rid)
+			return nil, err
+		}
+
+		if len(resources) == 0 {
+			break
+		}
+
+		for _, one := range resources {
+			existIDs[one.ID] = struct{}{}
+		}
+
+		if err = svc.syncResourceTag(cts.Kit, op, req.AccountID, req.ResType, resources); err != nil {
+			logs.Errorf("sync %s resource tags failed, err: %v, req: %v, rid: %s", req.ResType, err, req,
+				cts.Kit.Rid)
+			return nil, err
+		}
+
+		if uint(len(resources)) < page.Limit {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	if err = svc.deleteRemovedResourceTag(cts.Kit, vendor, req.AccountID, req.ResType, existIDs); err != nil {
+		logs.Errorf("delete removed %s resource tags failed, err: %v, req: %v, rid: %s", req.ResType, err, req,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return common.GetSyncReport(cts.Kit).Result(), nil
+}
+
+// syncResourceTag compare tags of the resources in cloud and db, all tags of the changed resources are replaced.
+func (svc *resourceTagSvc) syncResourceTag(kt *kit.Kit, op operator.Operator, accountID string,
+	resType enumor.CloudResourceType, resources []tagResource) error {
+
+	refs := make([]operator.ResourceRef, 0, len(resources))
+	ids := make([]string, 0, len(resources))
+	for _, one := range resources {
+		refs = append(refs, one.Ref)
+		ids = append(ids, one.ID)
+	}
+
+	cloudTags, err := op.ListTag(kt, &operator.TagListOption{ResType: resType, Resources: refs})
+	if err != nil {
+		return err
+	}
+
+	cloudTagMap := make(map[string][]typetag.Tag, len(cloudTags))
+	for _, one := range cloudTags {
+		cloudTagMap[one.CloudID] = one.Tags
+	}
+
+	dbTagMap, err := svc.listDBTag(kt, resType, ids)
+	if err != nil {
+		return err
+	}
+
+	addIDs, updateIDs, deleteIDs := make([]string, 0), make([]string, 0), make([]string, 0)
+	upserts := make([]protortag.ResourceTagUpsert, 0)
+	for _, one := range resources {
+		cloud, db := cloudTagMap[one.Ref.CloudID], dbTagMap[one.ID]
+		if isTagEqual(cloud, db) {
+			continue
+		}
+
+		switch {
+		case len(db) == 0:
+			addIDs = append(addIDs, one.Ref.CloudID)
+		case len(cloud) == 0:
+			deleteIDs = append(deleteIDs, one.Ref.CloudID)
+		default:
+			updateIDs = append(updateIDs, one.Ref.CloudID)
+		}
+
+		tags := make([]corertag.Tag, 0, len(cloud))
+		for _, tag := range cloud {
+			tags = append(tags, corertag.Tag{Key: tag.Key, Value: tag.Value})
+		}
+		upserts = append(upserts, protortag.ResourceTagUpsert{
+			Vendor:     op.Vendor(),
+			AccountID:  accountID,
+			ResID:      one.ID,
+			ResCloudID: one.Ref.CloudID,
+			Tags:       tags,
+		})
+	}
+
+	if len(upserts) == 0 {
+		return nil
+	}
+
+	if common.ReportDiffCloudIDs(kt, enumor.ResourceTagCloudResType, addIDs, updateIDs, deleteIDs) {
+		return nil
+	}
+
+	req := &protortag.ResourceTagBatchUpsertReq{
+		ResType:    resType,
+		ReplaceAll: true,
+		Resources:  upserts,
+	}
+	return svc.dataCli.Global.ResourceTag.BatchUpsertResourceTag(kt.Ctx, kt.Header(), req)
+}
+
+// listDBTag list tags of the resources in db, returns map of resource id to tag key value map.
+func (svc *resourceTagSvc) listDBTag(kt *kit.Kit, resType enumor.CloudResourceType, ids []string) (
+	map[string]map[string]string, error) {
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: resType},
+				&filter.AtomRule{Field: "res_id", Op: filter.In.Factory(), Value: ids},
+			},
+		},
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"res_id", "tag_key", "tag_value"},
+	}
+
+	result := make(map[string]map[string]string, len(ids))
+	for {
+		resp, err := svc.dataCli.Global.ResourceTag.ListResourceTag(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list resource tag failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			if _, exists := result[one.ResID]; !exists {
+				result[one.ResID] = make(map[string]string)
+			}
+			result[one.ResID][one.Key] = one.Value
+		}
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+// deleteRemovedResourceTag delete tags of the resources which are already removed from db.
+func (svc *resourceTagSvc) deleteRemovedResourceTag(kt *kit.Kit, vendor enumor.Vendor, accountID string,
+	resType enumor.CloudResourceType, existIDs map[string]struct{}) error {
+
+	req := &core.ListReq{
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{
+			"vendor":     vendor,
+			"account_id": accountID,
+			"res_type":   resType,
+		}),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"res_id", "res_cloud_id"},
+	}
+
+	removed := make(map[string]string)
+	for {
+		resp, err := svc.dataCli.Global.ResourceTag.ListResourceTag(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list resource tag failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return err
+		}
+
+		for _, one := range resp.Details {
+			if _, exists := existIDs[one.ResID]; !exists {
+				removed[one.ResID] = one.ResCloudID
+			}
+		}
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+
+	resIDs := make([]string, 0, len(removed))
+	cloudIDs := make([]string, 0, len(removed))
+	for resID, cloudID := range removed {
+		resIDs = append(resIDs, resID)
+		cloudIDs = append(cloudIDs, cloudID)
+	}
+
+	if common.ReportDiffCloudIDs(kt, enumor.ResourceTagCloudResType, nil, nil, cloudIDs) {
+		return nil
+	}
+
+	for _, part := range slice.Split(resIDs, constant.BatchOperationMaxLimit) {
+		delReq := &dataservice.BatchDeleteReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: resType},
+					&filter.AtomRule{Field: "res_id", Op: filter.In.Factory(), Value: part},
+				},
+			},
+		}
+		if err := svc.dataCli.Global.ResourceTag.BatchDeleteResourceTag(kt.Ctx, kt.Header(), delReq); err != nil {
+			logs.Errorf("delete resource tag failed, err: %v, ids: %v, rid: %s", err, part, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isTagEqual(cloud []typetag.Tag, db map[string]string) bool {
+	if len(cloud) != len(db) {
+		return false
+	}
+
+	for _, one := range cloud {
+		value, exists := db[one.Key]
+		if !exists || value != one.Value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TagResource add or overwrite tags of resources in cloud, and then update tags in db.
+func (svc *resourceTagSvc) TagResource(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(hcrtag.TagReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	op, resources, err := svc.prepareOperate(cts.Kit, vendor, req.AccountID, req.ResType, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]operator.ResourceRef, 0, len(resources))
+	for _, one := range resources {
+		refs = append(refs, one.Ref)
+	}
+
+	tags := make([]typetag.Tag, 0, len(req.Tags))
+	for _, one := range req.Tags {
+		tags = append(tags, typetag.Tag{Key: one.Key, Value: one.Value})
+	}
+
+	opt := &operator.TagResourceOption{
+		TagListOption: operator.TagListOption{ResType: req.ResType, Resources: refs},
+		Tags:          tags,
+	}
+	if err = op.TagResource(cts.Kit, opt); err != nil {
+		logs.Errorf("tag %s resource failed, err: %v, req: %v, rid: %s", vendor, err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	upserts := make([]protortag.ResourceTagUpsert, 0, len(resources))
+	for _, one := range resources {
+		upserts = append(upserts, protortag.ResourceTagUpsert{
+			Vendor:     vendor,
+			AccountID:  req.AccountID,
+			ResID:      one.ID,
+			ResCloudID: one.Ref.CloudID,
+			Tags:       req.Tags,
+		})
+	}
+
+	upsertReq := &protortag.ResourceTagBatchUpsertReq{ResType: req.ResType, Resources: upserts}
+	if err = svc.dataCli.Global.ResourceTag.BatchUpsertResourceTag(cts.Kit.Ctx, cts.Kit.Header(),
+		upsertReq); err != nil {
+		logs.Errorf("upsert resource tag failed, err: %v, req: %v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// UntagResource remove tags of resources by keys in cloud, and then delete tags in db.
+func (svc *resourceTagSvc) UntagResource(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(hcrtag.UntagReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	op, resources, err := svc.prepareOperate(cts.Kit, vendor, req.AccountID, req.ResType, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]operator.ResourceRef, 0, len(resources))
+	for _, one := range resources {
+		refs = append(refs, one.Ref)
+	}
+
+	opt := &operator.UntagResourceOption{
+		TagListOption: operator.TagListOption{ResType: req.ResType, Resources: refs},
+		Keys:          req.Keys,
+	}
+	if err = op.UntagResource(cts.Kit, opt); err != nil {
+		logs.Errorf("untag %s resource failed, err: %v, req: %v, rid: %s", vendor, err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	delReq := &dataservice.BatchDeleteReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: req.ResType},
+				&filter.AtomRule{Field: "res_id", Op: filter.In.Factory(), Value: req.IDs},
+				&filter.AtomRule{Field: "tag_key", Op: filter.In.Factory(), Value: req.Keys},
+			},
+		},
+	}
+	if err = svc.dataCli.Global.ResourceTag.BatchDeleteResourceTag(cts.Kit.Ctx, cts.Kit.Header(),
+		delReq); err != nil {
+		logs.Errorf("delete resource tag failed, err: %v, req: %v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// prepareOperate get operator of the account and the resources to operate, all resources must belong to the
+// account.
+func (svc *resourceTagSvc) prepareOperate(kt *kit.Kit, vendor enumor.Vendor, accountID string,
+	resType enumor.CloudResourceType, ids []string) (operator.Operator, []tagResource, error) {
+
+	op, err := svc.adaptor.Operator(kt, vendor, accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !operator.IsTagSupported(op, resType) {
+		return nil, nil, operator.NotSupportError(vendor, string(resType)+" tag")
+	}
+
+	expr := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: ids},
+			&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+			&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+		},
+	}
+	resources, err := svc.listTagResource(kt, resType, expr, core.NewDefaultBasePage())
+	if err != nil {
+		logs.Errorf("list %s resources failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+		return nil, nil, err
+	}
+
+	if len(resources) != len(slice.Unique(ids)) {
+		return nil, nil, errf.Newf(errf.RecordNotFound, "%s resources %v not all exist in account: %s", resType,
+			ids, accountID)
+	}
+
+	return op, resources, nil
+}
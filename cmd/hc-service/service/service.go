@@ -32,14 +32,21 @@ import (
 	"hcm/cmd/hc-service/service/account"
 	"hcm/cmd/hc-service/service/bill"
 	"hcm/cmd/hc-service/service/capability"
+	changeevent "hcm/cmd/hc-service/service/change-event"
 	cloudadaptor "hcm/cmd/hc-service/service/cloud-adaptor"
 	"hcm/cmd/hc-service/service/cvm"
 	"hcm/cmd/hc-service/service/disk"
 	"hcm/cmd/hc-service/service/eip"
 	"hcm/cmd/hc-service/service/firewall"
+	"hcm/cmd/hc-service/service/image"
 	instancetype "hcm/cmd/hc-service/service/instance-type"
+	keypair "hcm/cmd/hc-service/service/key-pair"
+	loadbalancer "hcm/cmd/hc-service/service/load-balancer"
+	natgateway "hcm/cmd/hc-service/service/nat-gateway"
+	resourcetag "hcm/cmd/hc-service/service/resource-tag"
 	routetable "hcm/cmd/hc-service/service/route-table"
 	securitygroup "hcm/cmd/hc-service/service/security-group"
+	"hcm/cmd/hc-service/service/snapshot"
 	"hcm/cmd/hc-service/service/subnet"
 	"hcm/cmd/hc-service/service/sync"
 	"hcm/cmd/hc-service/service/vpc"
@@ -164,6 +171,13 @@ func (s *Service) apiSet() *restful.Container {
 	instancetype.InitInstanceTypeService(c)
 	sync.InitService(c)
 	bill.InitBillService(c)
+	changeevent.InitChangeEventService(c)
+	loadbalancer.InitLoadBalancerService(c)
+	natgateway.InitNatGatewayService(c)
+	snapshot.InitSnapshotService(c)
+	keypair.InitKeyPairService(c)
+	image.InitImageService(c)
+	resourcetag.InitResourceTagService(c)
 
 	return restful.NewContainer().Add(c.WebService)
 }
@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
+	adcore "hcm/pkg/adaptor/types/core"
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	hcsnap "hcm/pkg/api/hc-service/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// AwsCreateSnapshot create aws snapshot.
+func (svc *snapSvc) AwsCreateSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcsnap.AwsSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CreateSnapshot(cts.Kit, req.AwsCreateOption)
+	if err != nil {
+		logs.Errorf("create aws snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	cloudIDs := []string{converter.PtrToVal(result)}
+
+	syncClient := syncaws.NewClient(svc.dataCli, client)
+	params := &syncaws.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  cloudIDs,
+	}
+	_, err = syncClient.Snapshot(cts.Kit, params, &syncaws.SyncSnapshotOption{
+		BkBizID:          req.BkBizID,
+		SnapshotPolicyID: req.SnapshotPolicyID,
+	})
+	if err != nil {
+		logs.Errorf("sync aws snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.listSnapshotIDs(cts.Kit, enumor.Aws, cloudIDs)
+}
+
+// AwsDeleteSnapshot delete aws snapshot.
+func (svc *snapSvc) AwsDeleteSnapshot(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	snap, err := svc.dataCli.Aws.Snapshot.GetSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, snap.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnap.DeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: snap.CloudID},
+		Region:           snap.Region,
+	}
+	if err = client.DeleteSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("delete aws snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteSnapshotFromDB(cts.Kit, id)
+}
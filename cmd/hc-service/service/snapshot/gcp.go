@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	syncgcp "hcm/cmd/hc-service/logics/res-sync/gcp"
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	hcsnap "hcm/pkg/api/hc-service/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// GcpCreateSnapshot create gcp snapshot.
+func (svc *snapSvc) GcpCreateSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcsnap.GcpSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Gcp(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CreateSnapshot(cts.Kit, req.GcpCreateOption)
+	if err != nil {
+		logs.Errorf("create gcp snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.UnknownCloudIDs) > 0 {
+		logs.Errorf("snapshot(%v) is unknown, rid: %s", result.UnknownCloudIDs, cts.Kit.Rid)
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		return nil, errf.New(errf.Aborted, "create result is invalid")
+	}
+	cloudIDs := result.SuccessCloudIDs
+
+	syncClient := syncgcp.NewClient(svc.dataCli, client)
+	params := &syncgcp.SyncBaseParams{
+		AccountID: req.AccountID,
+		CloudIDs:  cloudIDs,
+	}
+	_, err = syncClient.Snapshot(cts.Kit, params, &syncgcp.SyncSnapshotOption{
+		BkBizID:          req.BkBizID,
+		SnapshotPolicyID: req.SnapshotPolicyID,
+	})
+	if err != nil {
+		logs.Errorf("sync gcp snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.listSnapshotIDs(cts.Kit, enumor.Gcp, cloudIDs)
+}
+
+// GcpDeleteSnapshot delete gcp snapshot.
+func (svc *snapSvc) GcpDeleteSnapshot(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	snap, err := svc.dataCli.Gcp.Snapshot.GetSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Gcp(cts.Kit, snap.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// gcp 快照为全局资源，通过快照名称删除
+	opt := &typesnap.GcpDeleteOption{ResourceID: snap.Name}
+	if err = client.DeleteSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("delete gcp snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteSnapshotFromDB(cts.Kit, id)
+}
@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
+	adcore "hcm/pkg/adaptor/types/core"
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	hcsnap "hcm/pkg/api/hc-service/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// HuaWeiCreateSnapshot create huawei snapshot.
+func (svc *snapSvc) HuaWeiCreateSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcsnap.HuaWeiSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CreateSnapshot(cts.Kit, req.HuaWeiCreateOption)
+	if err != nil {
+		logs.Errorf("create huawei snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	cloudIDs := []string{converter.PtrToVal(result)}
+
+	syncClient := synchuawei.NewClient(svc.dataCli, client)
+	params := &synchuawei.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  cloudIDs,
+	}
+	_, err = syncClient.Snapshot(cts.Kit, params, &synchuawei.SyncSnapshotOption{
+		BkBizID:          req.BkBizID,
+		SnapshotPolicyID: req.SnapshotPolicyID,
+	})
+	if err != nil {
+		logs.Errorf("sync huawei snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.listSnapshotIDs(cts.Kit, enumor.HuaWei, cloudIDs)
+}
+
+// HuaWeiDeleteSnapshot delete huawei snapshot.
+func (svc *snapSvc) HuaWeiDeleteSnapshot(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	snap, err := svc.dataCli.HuaWei.Snapshot.GetSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, snap.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnap.DeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: snap.CloudID},
+		Region:           snap.Region,
+	}
+	if err = client.DeleteSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("delete huawei snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteSnapshotFromDB(cts.Kit, id)
+}
+
+// HuaWeiRollbackSnapshot rollback huawei snapshot to its source disk.
+func (svc *snapSvc) HuaWeiRollbackSnapshot(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	snap, err := svc.dataCli.HuaWei.Snapshot.GetSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, snap.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnap.HuaWeiRollbackOption{
+		Region:      snap.Region,
+		CloudID:     snap.CloudID,
+		CloudDiskID: snap.CloudDiskID,
+	}
+	if err = client.RollbackSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("rollback huawei snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot defines snapshot service.
+package snapshot
+
+import (
+	"net/http"
+
+	"hcm/cmd/hc-service/service/capability"
+	cloudadaptor "hcm/cmd/hc-service/service/cloud-adaptor"
+	"hcm/pkg/api/core"
+	protosnap "hcm/pkg/api/data-service/cloud/snapshot"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitSnapshotService initial the snapshot service
+func InitSnapshotService(cap *capability.Capability) {
+	svc := &snapSvc{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("TCloudCreateSnapshot", http.MethodPost, "/vendors/tcloud/snapshots/create", svc.TCloudCreateSnapshot)
+	h.Add("AwsCreateSnapshot", http.MethodPost, "/vendors/aws/snapshots/create", svc.AwsCreateSnapshot)
+	h.Add("HuaWeiCreateSnapshot", http.MethodPost, "/vendors/huawei/snapshots/create", svc.HuaWeiCreateSnapshot)
+	h.Add("AzureCreateSnapshot", http.MethodPost, "/vendors/azure/snapshots/create", svc.AzureCreateSnapshot)
+	h.Add("GcpCreateSnapshot", http.MethodPost, "/vendors/gcp/snapshots/create", svc.GcpCreateSnapshot)
+
+	h.Add("TCloudDeleteSnapshot", http.MethodDelete, "/vendors/tcloud/snapshots/{id}", svc.TCloudDeleteSnapshot)
+	h.Add("AwsDeleteSnapshot", http.MethodDelete, "/vendors/aws/snapshots/{id}", svc.AwsDeleteSnapshot)
+	h.Add("HuaWeiDeleteSnapshot", http.MethodDelete, "/vendors/huawei/snapshots/{id}", svc.HuaWeiDeleteSnapshot)
+	h.Add("AzureDeleteSnapshot", http.MethodDelete, "/vendors/azure/snapshots/{id}", svc.AzureDeleteSnapshot)
+	h.Add("GcpDeleteSnapshot", http.MethodDelete, "/vendors/gcp/snapshots/{id}", svc.GcpDeleteSnapshot)
+
+	// 仅腾讯云、华为云支持将快照回滚到源云盘，其余云厂商需通过快照创建新云盘
+	h.Add("TCloudRollbackSnapshot", http.MethodPost, "/vendors/tcloud/snapshots/{id}/rollback",
+		svc.TCloudRollbackSnapshot)
+	h.Add("HuaWeiRollbackSnapshot", http.MethodPost, "/vendors/huawei/snapshots/{id}/rollback",
+		svc.HuaWeiRollbackSnapshot)
+
+	h.Load(cap.WebService)
+}
+
+type snapSvc struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+}
+
+// listSnapshotIDs 快照创建后会通过同步写入DB，根据云ID查询快照在hcm中的ID
+func (svc *snapSvc) listSnapshotIDs(kt *kit.Kit, vendor enumor.Vendor, cloudIDs []string) (
+	*core.BatchCreateResult, error) {
+
+	req := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.Snapshot.ListSnapshot(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list %s snapshot failed, err: %v, cloudIDs: %v, rid: %s", vendor, err, cloudIDs, kt.Rid)
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(result.Details))
+	for _, one := range result.Details {
+		ids = append(ids, one.ID)
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// deleteSnapshotFromDB 云上快照删除后，删除DB中的快照
+func (svc *snapSvc) deleteSnapshotFromDB(kt *kit.Kit, id string) error {
+	req := &protosnap.SnapshotBatchDeleteReq{
+		Filter: tools.EqualExpression("id", id),
+	}
+	if err := svc.dataCli.Global.Snapshot.BatchDeleteSnapshot(kt.Ctx, kt.Header(), req); err != nil {
+		logs.Errorf("delete snapshot from db failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
+	adcore "hcm/pkg/adaptor/types/core"
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	"hcm/pkg/api/core"
+	hcsnap "hcm/pkg/api/hc-service/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// TCloudCreateSnapshot create tcloud snapshot.
+func (svc *snapSvc) TCloudCreateSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcsnap.TCloudSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CreateSnapshot(cts.Kit, req.TCloudCreateOption)
+	if err != nil {
+		logs.Errorf("create tcloud snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.UnknownCloudIDs) > 0 {
+		logs.Errorf("snapshot(%v) is unknown, rid: %s", result.UnknownCloudIDs, cts.Kit.Rid)
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		return nil, errf.New(errf.Aborted, "create result is invalid")
+	}
+
+	syncClient := synctcloud.NewClient(svc.dataCli, client)
+	params := &synctcloud.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  result.SuccessCloudIDs,
+	}
+	syncResult, err := syncClient.Snapshot(cts.Kit, params, &synctcloud.SyncSnapshotOption{
+		BkBizID:          req.BkBizID,
+		SnapshotPolicyID: req.SnapshotPolicyID,
+	})
+	if err != nil {
+		logs.Errorf("sync tcloud snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(syncResult.CreatedIds) == len(result.SuccessCloudIDs) {
+		return &core.BatchCreateResult{IDs: syncResult.CreatedIds}, nil
+	}
+
+	return svc.listSnapshotIDs(cts.Kit, enumor.TCloud, result.SuccessCloudIDs)
+}
+
+// TCloudDeleteSnapshot delete tcloud snapshot.
+func (svc *snapSvc) TCloudDeleteSnapshot(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	snap, err := svc.dataCli.TCloud.Snapshot.GetSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, snap.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnap.DeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: snap.CloudID},
+		Region:           snap.Region,
+	}
+	if err = client.DeleteSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("delete tcloud snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, svc.deleteSnapshotFromDB(cts.Kit, id)
+}
+
+// TCloudRollbackSnapshot rollback tcloud snapshot to its source disk.
+func (svc *snapSvc) TCloudRollbackSnapshot(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(hcsnap.TCloudSnapshotRollbackReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	snap, err := svc.dataCli.TCloud.Snapshot.GetSnapshot(cts.Kit.Ctx, cts.Kit.Header(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, snap.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnap.TCloudRollbackOption{
+		Region:            snap.Region,
+		CloudID:           snap.CloudID,
+		CloudDiskID:       snap.CloudDiskID,
+		AutoStopInstance:  req.AutoStopInstance,
+		AutoStartInstance: req.AutoStartInstance,
+	}
+	if err = client.RollbackSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("rollback tcloud snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
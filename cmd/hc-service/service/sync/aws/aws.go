@@ -23,6 +23,7 @@ package aws
 import (
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/logics/res-sync/aws"
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	"hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/rest"
@@ -38,6 +39,11 @@ func defaultPrepare(cts *rest.Contexts, cli ressync.Interface) (*sync.AwsSyncReq
 		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if req.DryRun {
+		common.EnableDryRun(cts.Kit)
+	}
+	common.SetSyncCloudIDs(cts.Kit, req.CloudIDs)
+
 	syncCli, err := cli.Aws(cts.Kit, req.AccountID)
 	if err != nil {
 		return nil, nil, err
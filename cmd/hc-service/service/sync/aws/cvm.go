@@ -36,7 +36,7 @@ import (
 
 // SyncCvmWithRelRes ....
 func (svc *service) SyncCvmWithRelRes(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &cvmHandler{cli: svc.syncCli})
+	return handler.ResourceSync(cts, &cvmHandler{cli: svc.syncCli})
 }
 
 // cvmHandler cvm sync handler.
@@ -36,7 +36,7 @@ import (
 
 // SyncDisk ....
 func (svc *service) SyncDisk(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &diskHandler{cli: svc.syncCli})
+	return handler.ResourceSync(cts, &diskHandler{cli: svc.syncCli})
 }
 
 // diskHandler disk sync handler.
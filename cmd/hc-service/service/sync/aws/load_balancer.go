@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/aws"
+	"hcm/cmd/hc-service/service/sync/handler"
+	adaws "hcm/pkg/adaptor/aws"
+	typecore "hcm/pkg/adaptor/types/core"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncLoadBalancer ....
+func (svc *service) SyncLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return handler.ResourceSync(cts, &lbHandler{cli: svc.syncCli})
+}
+
+// lbHandler load balancer sync handler.
+type lbHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request *sync.AwsSyncReq
+	syncCli aws.Interface
+	// nextToken 为空时为查询第一页，finished 标识云上数据是否已经查询完
+	nextToken *string
+	finished  bool
+}
+
+var _ handler.Handler = new(lbHandler)
+
+// Prepare ...
+func (hd *lbHandler) Prepare(cts *rest.Contexts) error {
+	request, syncCli, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *lbHandler) Next(kt *kit.Kit) ([]string, error) {
+	if hd.finished {
+		return nil, nil
+	}
+
+	listOpt := &typecore.AwsListOption{
+		Region: hd.request.Region,
+		Page: &typecore.AwsPage{
+			MaxResults: converter.ValToPtr(int64(constant.CloudResourceSyncMaxLimit)),
+			NextToken:  hd.nextToken,
+		},
+	}
+	lbs, nextToken, err := hd.syncCli.CloudCli().ListLoadBalancer(kt, listOpt)
+	if err != nil {
+		logs.Errorf("request adaptor list aws load balancer failed, err: %v, opt: %v, rid: %s", err, listOpt,
+			kt.Rid)
+		return nil, err
+	}
+
+	if len(lbs) == 0 {
+		return nil, nil
+	}
+
+	cloudIDs := make([]string, 0, len(lbs))
+	for _, one := range lbs {
+		cloudIDs = append(cloudIDs, one.CloudID)
+	}
+
+	hd.nextToken = nextToken
+	hd.finished = nextToken == nil
+	return cloudIDs, nil
+}
+
+// Sync ...
+func (hd *lbHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	// aws 按ARN查询负载均衡时单次最多指定20个ARN
+	for _, part := range slice.Split(cloudIDs, adaws.AwsElbArnQueryLimit) {
+		params := &aws.SyncBaseParams{
+			AccountID: hd.request.AccountID,
+			Region:    hd.request.Region,
+			CloudIDs:  part,
+		}
+		if _, err := hd.syncCli.LoadBalancer(kt, params, new(aws.SyncLoadBalancerOption)); err != nil {
+			logs.Errorf("sync aws load balancer failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *lbHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	err := hd.syncCli.RemoveLoadBalancerDeleteFromCloud(kt, hd.request.AccountID, hd.request.Region)
+	if err != nil {
+		logs.Errorf("remove load balancer delete from cloud failed, err: %v, accountID: %s, region: %s, rid: %s",
+			err, hd.request.AccountID, hd.request.Region, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *lbHandler) Name() enumor.CloudResourceType {
+	return enumor.LoadBalancerCloudResType
+}
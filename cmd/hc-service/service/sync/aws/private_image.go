@@ -0,0 +1,140 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/aws"
+	"hcm/cmd/hc-service/service/sync/handler"
+	typecore "hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/image"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// SyncPrivateImage ....
+func (svc *service) SyncPrivateImage(cts *rest.Contexts) (interface{}, error) {
+	return handler.ResourceSync(cts, &privateImageHandler{cli: svc.syncCli})
+}
+
+// privateImageHandler private image sync handler.
+type privateImageHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request *sync.AwsSyncReq
+	syncCli aws.Interface
+	// nextToken 为空时为查询第一页，finished 标识云上数据是否已经查询完
+	nextToken *string
+	finished  bool
+	// shared 标识是否在查询共享给账号的镜像，账号拥有的镜像查询完后再查询共享镜像
+	shared bool
+}
+
+var _ handler.Handler = new(privateImageHandler)
+
+// Prepare ...
+func (hd *privateImageHandler) Prepare(cts *rest.Contexts) error {
+	request, syncCli, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *privateImageHandler) Next(kt *kit.Kit) ([]string, error) {
+	if hd.finished {
+		return nil, nil
+	}
+
+	listOpt := &image.AwsPrivateImageListOption{
+		Region: hd.request.Region,
+		Shared: hd.shared,
+		Page: &typecore.AwsPage{
+			MaxResults: converter.ValToPtr(int64(constant.CloudResourceSyncMaxLimit)),
+			NextToken:  hd.nextToken,
+		},
+	}
+	result, err := hd.syncCli.CloudCli().ListPrivateImage(kt, listOpt)
+	if err != nil {
+		logs.Errorf("request adaptor list aws private image failed, err: %v, opt: %v, rid: %s", err, listOpt,
+			kt.Rid)
+		return nil, err
+	}
+
+	cloudIDs := make([]string, 0, len(result.Details))
+	for _, one := range result.Details {
+		cloudIDs = append(cloudIDs, one.CloudID)
+	}
+
+	hd.nextToken = result.NextToken
+	if result.NextToken == nil {
+		hd.finished = hd.shared
+		hd.shared = true
+	}
+
+	if len(cloudIDs) == 0 && !hd.finished {
+		return hd.Next(kt)
+	}
+
+	return cloudIDs, nil
+}
+
+// Sync ...
+func (hd *privateImageHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	params := &aws.SyncBaseParams{
+		AccountID: hd.request.AccountID,
+		Region:    hd.request.Region,
+		CloudIDs:  cloudIDs,
+	}
+	if _, err := hd.syncCli.PrivateImage(kt, params, new(aws.SyncPrivateImageOption)); err != nil {
+		logs.Errorf("sync aws private image failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *privateImageHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	err := hd.syncCli.RemovePrivateImageDeleteFromCloud(kt, hd.request.AccountID, hd.request.Region)
+	if err != nil {
+		logs.Errorf("remove private image delete from cloud failed, err: %v, accountID: %s, region: %s, rid: %s",
+			err, hd.request.AccountID, hd.request.Region, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *privateImageHandler) Name() enumor.CloudResourceType {
+	return enumor.ImageCloudResType
+}
@@ -0,0 +1,62 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// SyncVpcPeering sync vpc peering of account in region.
+func (svc *service) SyncVpcPeering(cts *rest.Contexts) (interface{}, error) {
+	req := new(sync.VpcPeeringSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if req.DryRun {
+		common.EnableDryRun(cts.Kit)
+	}
+	common.EnableSyncReport(cts.Kit, false)
+
+	syncCli, err := svc.syncCli.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &common.SyncVpcPeeringParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  req.CloudIDs,
+	}
+	if _, err = syncCli.VpcPeering(cts.Kit, params); err != nil {
+		logs.Errorf("sync aws vpc peering failed, err: %v, req: %v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return common.GetSyncReport(cts.Kit).Result(), nil
+}
@@ -22,6 +22,7 @@ package azure
 import (
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/logics/res-sync/azure"
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	"hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/rest"
@@ -37,6 +38,11 @@ func defaultPrepare(cts *rest.Contexts, cli ressync.Interface) (*sync.AzureSyncR
 		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if req.DryRun {
+		common.EnableDryRun(cts.Kit)
+	}
+	common.SetSyncCloudIDs(cts.Kit, req.CloudIDs)
+
 	syncCli, err := cli.Azure(cts.Kit, req.AccountID)
 	if err != nil {
 		return nil, nil, err
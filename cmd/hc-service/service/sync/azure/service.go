@@ -51,6 +51,11 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncResourceGroup", "POST", "/resource_groups/sync", v.SyncResourceGroup)
 	h.Add("SyncRegion", "POST", "/regions/sync", v.SyncRegion)
 	h.Add("SyncImage", "POST", "/images/sync", v.SyncImage)
+	h.Add("SyncLoadBalancer", "POST", "/load_balancers/sync", v.SyncLoadBalancer)
+	h.Add("SyncNatGateway", "POST", "/nat_gateways/sync", v.SyncNatGateway)
+	h.Add("SyncSnapshot", "POST", "/snapshots/sync", v.SyncSnapshot)
+	h.Add("SyncBucket", "POST", "/buckets/sync", v.SyncBucket)
+	h.Add("SyncVpcPeering", "POST", "/vpc_peerings/sync", v.SyncVpcPeering)
 
 	h.Load(cap.WebService)
 }
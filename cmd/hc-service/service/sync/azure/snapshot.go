@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/azure"
+	"hcm/cmd/hc-service/service/sync/handler"
+	typecore "hcm/pkg/adaptor/types/core"
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncSnapshot ....
+func (svc *service) SyncSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return handler.ResourceSync(cts, &snapHandler{cli: svc.syncCli})
+}
+
+// snapHandler snapshot sync handler.
+type snapHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request  *sync.AzureSyncReq
+	syncCli  azure.Interface
+	offset   int
+	snapList [][]typesnap.AzureSnapshot
+}
+
+var _ handler.Handler = new(snapHandler)
+
+// Prepare ...
+func (hd *snapHandler) Prepare(cts *rest.Contexts) error {
+	request, syncCli, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *snapHandler) Next(kt *kit.Kit) ([]string, error) {
+	if hd.snapList == nil {
+		listOpt := &typecore.AzureListOption{
+			ResourceGroupName: hd.request.ResourceGroupName,
+		}
+		snaps, err := hd.syncCli.CloudCli().ListSnapshot(kt, listOpt)
+		if err != nil {
+			logs.Errorf("request adaptor list azure snapshot failed, err: %v, opt: %v, rid: %s", err,
+				listOpt, kt.Rid)
+			return nil, err
+		}
+
+		hd.snapList = slice.Split(snaps, constant.CloudResourceSyncMaxLimit)
+	}
+
+	if len(hd.snapList) <= hd.offset {
+		return nil, nil
+	}
+
+	cloudIDs := make([]string, 0, len(hd.snapList[hd.offset]))
+	for _, one := range hd.snapList[hd.offset] {
+		cloudIDs = append(cloudIDs, one.CloudID)
+	}
+	hd.offset++
+	return cloudIDs, nil
+}
+
+// Sync ...
+func (hd *snapHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	params := &azure.SyncBaseParams{
+		AccountID:         hd.request.AccountID,
+		ResourceGroupName: hd.request.ResourceGroupName,
+		CloudIDs:          cloudIDs,
+	}
+	if _, err := hd.syncCli.Snapshot(kt, params, new(azure.SyncSnapshotOption)); err != nil {
+		logs.Errorf("sync azure snapshot failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *snapHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	err := hd.syncCli.RemoveSnapshotDeleteFromCloud(kt, hd.request.AccountID, hd.request.ResourceGroupName)
+	if err != nil {
+		logs.Errorf("remove snapshot delete from cloud failed, err: %v, accountID: %s, resGroupName: %s, "+
+			"rid: %s", err, hd.request.AccountID, hd.request.ResourceGroupName, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *snapHandler) Name() enumor.CloudResourceType {
+	return enumor.SnapshotCloudResType
+}
@@ -24,6 +24,7 @@ import (
 
 	ressync "hcm/cmd/hc-service/logics/res-sync"
 	"hcm/cmd/hc-service/logics/res-sync/azure"
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	"hcm/cmd/hc-service/service/sync/handler"
 	adazure "hcm/pkg/adaptor/azure"
 	typecore "hcm/pkg/adaptor/types/core"
@@ -42,7 +43,7 @@ import (
 
 // SyncSubnet ....
 func (svc *service) SyncSubnet(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &subnetHandler{cli: svc.syncCli})
+	return handler.ResourceSync(cts, &subnetHandler{cli: svc.syncCli})
 }
 
 // subnetHandler subnet sync handler.
@@ -68,6 +69,11 @@ func (hd *subnetHandler) Prepare(cts *rest.Contexts) error {
 		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if request.DryRun {
+		common.EnableDryRun(cts.Kit)
+	}
+	common.SetSyncCloudIDs(cts.Kit, request.CloudIDs)
+
 	syncCli, err := hd.cli.Azure(cts.Kit, request.AccountID)
 	if err != nil {
 		return err
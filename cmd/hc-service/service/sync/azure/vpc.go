@@ -41,7 +41,7 @@ import (
 
 // SyncVpc ....
 func (svc *service) SyncVpc(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &vpcHandler{cli: svc.syncCli})
+	return handler.ResourceSync(cts, &vpcHandler{cli: svc.syncCli})
 }
 
 // vpcHandler vpc sync handler.
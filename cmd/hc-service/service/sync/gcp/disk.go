@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	"hcm/cmd/hc-service/logics/res-sync/gcp"
 	"hcm/cmd/hc-service/service/sync/handler"
 	typecore "hcm/pkg/adaptor/types/core"
@@ -38,7 +39,7 @@ import (
 
 // SyncDisk ....
 func (svc *service) SyncDisk(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &diskHandler{cli: svc.syncCli})
+	return handler.ResourceSync(cts, &diskHandler{cli: svc.syncCli})
 }
 
 // diskHandler disk sync handler.
@@ -64,6 +65,11 @@ func (hd *diskHandler) Prepare(cts *rest.Contexts) error {
 		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if req.DryRun {
+		common.EnableDryRun(cts.Kit)
+	}
+	common.SetSyncCloudIDs(cts.Kit, req.CloudIDs)
+
 	syncCli, err := hd.cli.Gcp(cts.Kit, req.AccountID)
 	if err != nil {
 		return err
@@ -22,6 +22,7 @@ package gcp
 
 import (
 	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	"hcm/cmd/hc-service/logics/res-sync/gcp"
 	"hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/criteria/errf"
@@ -38,6 +39,11 @@ func defaultPrepare(cts *rest.Contexts, cli ressync.Interface) (*sync.GcpSyncReq
 		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if req.DryRun {
+		common.EnableDryRun(cts.Kit)
+	}
+	common.SetSyncCloudIDs(cts.Kit, req.CloudIDs)
+
 	syncCli, err := cli.Gcp(cts.Kit, req.AccountID)
 	if err != nil {
 		return nil, nil, err
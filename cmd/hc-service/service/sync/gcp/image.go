@@ -21,6 +21,7 @@ package gcp
 
 import (
 	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	"hcm/cmd/hc-service/logics/res-sync/gcp"
 	"hcm/cmd/hc-service/service/sync/handler"
 	adaptorgcp "hcm/pkg/adaptor/gcp"
@@ -41,7 +42,7 @@ func (svc *service) SyncImage(cts *rest.Contexts) (interface{}, error) {
 	for index, projectID := range adaptorgcp.PublicImagePlatforms {
 		imageHandler.index = index
 		imageHandler.projectID = projectID
-		err := handler.ResourceSync(cts, imageHandler)
+		_, err := handler.ResourceSync(cts, imageHandler)
 		if err != nil {
 			return nil, err
 		}
@@ -79,6 +80,11 @@ func (hd *imageHandler) Prepare(cts *rest.Contexts) error {
 		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if req.DryRun {
+		common.EnableDryRun(cts.Kit)
+	}
+	common.SetSyncCloudIDs(cts.Kit, req.CloudIDs)
+
 	syncCli, err := hd.cli.Gcp(cts.Kit, req.AccountID)
 	if err != nil {
 		return err
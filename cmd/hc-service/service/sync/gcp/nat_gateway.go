@@ -0,0 +1,141 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/gcp"
+	"hcm/cmd/hc-service/service/sync/handler"
+	typecore "hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// SyncNatGateway ....
+func (svc *service) SyncNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return handler.ResourceSync(cts, &natHandler{cli: svc.syncCli})
+}
+
+// natHandler nat gateway sync handler.
+type natHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request *sync.GcpSyncReq
+	syncCli gcp.Interface
+	// pageToken 为空时为查询第一页，finished 标识云上数据是否已经查询完
+	pageToken string
+	finished  bool
+	// pending 分页基于路由器，一页路由器下的NAT数量可能超过单次同步上限，超出部分留到下一批同步
+	pending []string
+}
+
+var _ handler.Handler = new(natHandler)
+
+// Prepare ...
+func (hd *natHandler) Prepare(cts *rest.Contexts) error {
+	request, syncCli, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *natHandler) Next(kt *kit.Kit) ([]string, error) {
+	for len(hd.pending) == 0 && !hd.finished {
+		listOpt := &typenat.GcpListOption{
+			Region: hd.request.Region,
+			Page: &typecore.GcpPage{
+				PageSize:  constant.CloudResourceSyncMaxLimit,
+				PageToken: hd.pageToken,
+			},
+		}
+		nats, nextToken, err := hd.syncCli.CloudCli().ListNatGateway(kt, listOpt)
+		if err != nil {
+			logs.Errorf("request adaptor list gcp nat gateway failed, err: %v, opt: %v, rid: %s", err, listOpt,
+				kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range nats {
+			hd.pending = append(hd.pending, one.CloudID)
+		}
+
+		hd.pageToken = nextToken
+		hd.finished = len(nextToken) == 0
+	}
+
+	if len(hd.pending) == 0 {
+		return nil, nil
+	}
+
+	size := len(hd.pending)
+	if size > constant.CloudResourceSyncMaxLimit {
+		size = constant.CloudResourceSyncMaxLimit
+	}
+
+	cloudIDs := hd.pending[:size]
+	hd.pending = hd.pending[size:]
+	return cloudIDs, nil
+}
+
+// Sync ...
+func (hd *natHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	params := &gcp.SyncBaseParams{
+		AccountID: hd.request.AccountID,
+		CloudIDs:  cloudIDs,
+	}
+	opt := &gcp.SyncNatGatewayOption{
+		Region: hd.request.Region,
+	}
+	if _, err := hd.syncCli.NatGateway(kt, params, opt); err != nil {
+		logs.Errorf("sync gcp nat gateway failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *natHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	err := hd.syncCli.RemoveNatGatewayDeleteFromCloud(kt, hd.request.AccountID, hd.request.Region)
+	if err != nil {
+		logs.Errorf("remove nat gateway delete from cloud failed, err: %v, accountID: %s, region: %s, rid: %s",
+			err, hd.request.AccountID, hd.request.Region, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *natHandler) Name() enumor.CloudResourceType {
+	return enumor.NatGatewayCloudResType
+}
@@ -21,6 +21,7 @@ package gcp
 
 import (
 	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	"hcm/cmd/hc-service/logics/res-sync/gcp"
 	"hcm/cmd/hc-service/service/sync/handler"
 	typecore "hcm/pkg/adaptor/types/core"
@@ -35,7 +36,7 @@ import (
 
 // SyncRegion ....
 func (svc *service) SyncRegion(cts *rest.Contexts) (interface{}, error) {
-	return nil, handler.ResourceSync(cts, &regionHandler{cli: svc.syncCli})
+	return handler.ResourceSync(cts, &regionHandler{cli: svc.syncCli})
 }
 
 // regionHandler region sync handler.
@@ -61,6 +62,11 @@ func (hd *regionHandler) Prepare(cts *rest.Contexts) error {
 		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if req.DryRun {
+		common.EnableDryRun(cts.Kit)
+	}
+	common.SetSyncCloudIDs(cts.Kit, req.CloudIDs)
+
 	syncCli, err := hd.cli.Gcp(cts.Kit, req.AccountID)
 	if err != nil {
 		return err
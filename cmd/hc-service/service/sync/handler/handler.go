@@ -21,11 +21,14 @@
 package handler
 
 import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
 )
 
 // Handler 定义了全量同步操作函数。
@@ -42,14 +45,42 @@ type Handler interface {
 	Name() enumor.CloudResourceType
 }
 
-// ResourceSync 资源同步流程。
-func ResourceSync(cts *rest.Contexts, handler Handler) error {
+// ResourceSync 资源同步流程，返回同步结果，演练模式下不写入db。
+func ResourceSync(cts *rest.Contexts, handler Handler) (interface{}, error) {
 	kt := cts.Kit
 
 	// 解析请求参数到handler实现中，构建同步需要的客户端
 	if err := handler.Prepare(cts); err != nil {
 		logs.Errorf("%s sync handler to prepare failed, err: %v, rid: %s", handler.Name(), err, kt.Rid)
-		return err
+		return nil, err
+	}
+
+	// 演练模式在 Prepare 解析请求参数时开启
+	if common.IsDryRun(kt) && !common.IsDryRunSupported(handler.Name()) {
+		return nil, errf.Newf(errf.InvalidParameter, "%s sync not support dry run", handler.Name())
+	}
+	common.EnableSyncReport(kt, false)
+
+	if err := resourceSync(kt, handler); err != nil {
+		return nil, err
+	}
+
+	return common.GetSyncReport(kt).Result(), nil
+}
+
+// resourceSync 先删除云上已删除的资源，再分页同步云上资源，指定云ID时只同步指定的资源。
+func resourceSync(kt *kit.Kit, handler Handler) error {
+	if cloudIDs := common.GetSyncCloudIDs(kt); len(cloudIDs) != 0 {
+		// 指定资源的 Sync 会对比云上和db的数据，云上已删除的资源同样会从db中删除
+		for _, partCloudIDs := range slice.Split(cloudIDs, constant.CloudResourceSyncMaxLimit) {
+			if err := handler.Sync(kt, partCloudIDs); err != nil {
+				logs.Errorf("%s sync handler to sync specified resource failed, err: %v, rid: %s", handler.Name(),
+					err, kt.Rid)
+				return err
+			}
+		}
+
+		return nil
 	}
 
 	if err := handler.RemoveDeleteFromCloud(kt); err != nil {
@@ -43,7 +43,7 @@ func (svc *service) SyncImage(cts *rest.Contexts) (interface{}, error) {
 	for index, platform := range adaptorhuawei.PublicImagePlatforms {
 		imageHandler.index = index
 		imageHandler.platform = platform
-		err := handler.ResourceSync(cts, imageHandler)
+		_, err := handler.ResourceSync(cts, imageHandler)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/huawei"
+	"hcm/cmd/hc-service/service/sync/handler"
+	typecore "hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncNatGateway ....
+func (svc *service) SyncNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return handler.ResourceSync(cts, &natHandler{cli: svc.syncCli})
+}
+
+// natHandler nat gateway sync handler.
+type natHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request *sync.HuaWeiSyncReq
+	syncCli huawei.Interface
+	// 华为云NAT网关查询不支持分页，首次查询全部NAT网关后按批次同步
+	offset  int
+	natList [][]typenat.HuaWeiNatGateway
+}
+
+var _ handler.Handler = new(natHandler)
+
+// Prepare ...
+func (hd *natHandler) Prepare(cts *rest.Contexts) error {
+	request, syncCli, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *natHandler) Next(kt *kit.Kit) ([]string, error) {
+	if hd.natList == nil {
+		listOpt := &typecore.HuaWeiListOption{
+			Region: hd.request.Region,
+			Page: &typecore.HuaWeiPage{
+				Limit: converter.ValToPtr(int32(typecore.HuaWeiQueryLimit)),
+			},
+		}
+		nats, err := hd.syncCli.CloudCli().ListNatGateway(kt, listOpt)
+		if err != nil {
+			logs.Errorf("request adaptor list huawei nat gateway failed, err: %v, opt: %v, rid: %s", err,
+				listOpt, kt.Rid)
+			return nil, err
+		}
+
+		hd.natList = slice.Split(nats, constant.CloudResourceSyncMaxLimit)
+	}
+
+	if len(hd.natList) <= hd.offset {
+		return nil, nil
+	}
+
+	cloudIDs := make([]string, 0, len(hd.natList[hd.offset]))
+	for _, one := range hd.natList[hd.offset] {
+		cloudIDs = append(cloudIDs, one.CloudID)
+	}
+	hd.offset++
+	return cloudIDs, nil
+}
+
+// Sync ...
+func (hd *natHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	params := &huawei.SyncBaseParams{
+		AccountID: hd.request.AccountID,
+		Region:    hd.request.Region,
+		CloudIDs:  cloudIDs,
+	}
+	if _, err := hd.syncCli.NatGateway(kt, params, new(huawei.SyncNatGatewayOption)); err != nil {
+		logs.Errorf("sync huawei nat gateway failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *natHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	err := hd.syncCli.RemoveNatGatewayDeleteFromCloud(kt, hd.request.AccountID, hd.request.Region)
+	if err != nil {
+		logs.Errorf("remove nat gateway delete from cloud failed, err: %v, accountID: %s, region: %s, rid: %s",
+			err, hd.request.AccountID, hd.request.Region, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *natHandler) Name() enumor.CloudResourceType {
+	return enumor.NatGatewayCloudResType
+}
@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/huawei"
+	"hcm/cmd/hc-service/service/sync/handler"
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/image"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// SyncPrivateImage ....
+func (svc *service) SyncPrivateImage(cts *rest.Contexts) (interface{}, error) {
+	return handler.ResourceSync(cts, &privateImageHandler{cli: svc.syncCli})
+}
+
+// privateImageHandler private image sync handler.
+type privateImageHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request *sync.HuaWeiSyncReq
+	syncCli huawei.Interface
+	// marker 分页查询起始的资源ID，为空时查询第一页
+	marker *string
+}
+
+var _ handler.Handler = new(privateImageHandler)
+
+// Prepare ...
+func (hd *privateImageHandler) Prepare(cts *rest.Contexts) error {
+	request, syncCli, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *privateImageHandler) Next(kt *kit.Kit) ([]string, error) {
+	listOpt := &image.HuaWeiPrivateImageListOption{
+		Region: hd.request.Region,
+		Page: &core.HuaWeiPage{
+			Limit:  converter.ValToPtr(int32(constant.CloudResourceSyncMaxLimit)),
+			Marker: hd.marker,
+		},
+	}
+
+	result, err := hd.syncCli.CloudCli().ListPrivateImage(kt, listOpt)
+	if err != nil {
+		logs.Errorf("request adaptor list huawei private image failed, err: %v, opt: %v, rid: %s", err, listOpt,
+			kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, nil
+	}
+
+	cloudIDs := make([]string, 0, len(result.Details))
+	for _, one := range result.Details {
+		cloudIDs = append(cloudIDs, one.CloudID)
+	}
+
+	hd.marker = converter.ValToPtr(result.Details[len(result.Details)-1].CloudID)
+	return cloudIDs, nil
+}
+
+// Sync ...
+func (hd *privateImageHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	params := &huawei.SyncBaseParams{
+		AccountID: hd.request.AccountID,
+		Region:    hd.request.Region,
+		CloudIDs:  cloudIDs,
+	}
+	if _, err := hd.syncCli.PrivateImage(kt, params, new(huawei.SyncPrivateImageOption)); err != nil {
+		logs.Errorf("sync huawei private image failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *privateImageHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	err := hd.syncCli.RemovePrivateImageDeleteFromCloud(kt, hd.request.AccountID, hd.request.Region)
+	if err != nil {
+		logs.Errorf("remove private image delete from cloud failed, err: %v, accountID: %s, region: %s, rid: %s",
+			err, hd.request.AccountID, hd.request.Region, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *privateImageHandler) Name() enumor.CloudResourceType {
+	return enumor.ImageCloudResType
+}
@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package openstack ...
+package openstack
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/cmd/hc-service/logics/res-sync/openstack"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+func defaultPrepare(cts *rest.Contexts, cli ressync.Interface) (*sync.OpenStackSyncReq, openstack.Interface, error) {
+	req := new(sync.OpenStackSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if req.DryRun {
+		common.EnableDryRun(cts.Kit)
+	}
+	common.SetSyncCloudIDs(cts.Kit, req.CloudIDs)
+
+	syncCli, err := cli.OpenStack(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return req, syncCli, nil
+}
+
+// cloudIDPager openstack 接口不支持按 handler 需要的方式分页，首次调用 Next 时查询全部资源云ID，之后按同步上限分批返回。
+type cloudIDPager struct {
+	cloudIDs []string
+	loaded   bool
+}
+
+// next returns next batch of cloud ids, list is only called once.
+func (p *cloudIDPager) next(kt *kit.Kit, list func(kt *kit.Kit) ([]string, error)) ([]string, error) {
+	if !p.loaded {
+		cloudIDs, err := list(kt)
+		if err != nil {
+			return nil, err
+		}
+
+		p.cloudIDs = cloudIDs
+		p.loaded = true
+	}
+
+	if len(p.cloudIDs) == 0 {
+		return nil, nil
+	}
+
+	end := constant.CloudResourceSyncMaxLimit
+	if len(p.cloudIDs) < end {
+		end = len(p.cloudIDs)
+	}
+
+	part := p.cloudIDs[:end]
+	p.cloudIDs = p.cloudIDs[end:]
+	return part, nil
+}
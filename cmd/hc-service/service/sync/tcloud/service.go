@@ -50,6 +50,13 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncZone", "POST", "/zones/sync", v.SyncZone)
 	h.Add("SyncRegion", "POST", "/regions/sync", v.SyncRegion)
 	h.Add("SyncImage", "POST", "/images/sync", v.SyncImage)
+	h.Add("SyncPrivateImage", "POST", "/private_images/sync", v.SyncPrivateImage)
+	h.Add("SyncLoadBalancer", "POST", "/load_balancers/sync", v.SyncLoadBalancer)
+	h.Add("SyncNatGateway", "POST", "/nat_gateways/sync", v.SyncNatGateway)
+	h.Add("SyncSnapshot", "POST", "/snapshots/sync", v.SyncSnapshot)
+	h.Add("SyncKeyPair", "POST", "/key_pairs/sync", v.SyncKeyPair)
+	h.Add("SyncBucket", "POST", "/buckets/sync", v.SyncBucket)
+	h.Add("SyncVpcPeering", "POST", "/vpc_peerings/sync", v.SyncVpcPeering)
 
 	h.Load(cap.WebService)
 }
@@ -25,8 +25,11 @@ import (
 	"hcm/pkg/adaptor/azure"
 	"hcm/pkg/adaptor/gcp"
 	"hcm/pkg/adaptor/huawei"
+	"hcm/pkg/adaptor/openstack"
+	"hcm/pkg/adaptor/operator"
 	"hcm/pkg/adaptor/tcloud"
 	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
 )
 
 // Adaptor holds all the supported operations by the adaptor.
@@ -61,3 +64,13 @@ func (a *Adaptor) Azure(credential *types.AzureCredential) (*azure.Azure, error)
 func (a *Adaptor) HuaWei(s *types.BaseSecret) (*huawei.HuaWei, error) {
 	return huawei.NewHuaWei(s)
 }
+
+// OpenStack returns OpenStack operations.
+func (a *Adaptor) OpenStack(credential *types.OpenStackCredential) (*openstack.OpenStack, error) {
+	return openstack.NewOpenStack(credential)
+}
+
+// Operator returns vendor-agnostic operations of the vendor, vendor operator is registered by vendor adaptor package.
+func (a *Adaptor) Operator(vendor enumor.Vendor, cred *operator.Credential) (operator.Operator, error) {
+	return operator.New(vendor, cred)
+}
@@ -46,6 +46,11 @@ const (
 	QueryBillSQL = "SELECT %s FROM %s.%s %s"
 	// QueryBillTotalSQL 查询云账单总数量的SQL
 	QueryBillTotalSQL = "SELECT COUNT(*) FROM %s.%s %s"
+	// QueryBillItemSummarySQL 按云产品、云资源汇总云账单费用的SQL
+	QueryBillItemSummarySQL = "SELECT line_item_product_code, product_product_name, line_item_resource_id, " +
+		"product_region, line_item_currency_code, SUM(line_item_unblended_cost) AS cost FROM %s.%s %s " +
+		"GROUP BY line_item_product_code, product_product_name, line_item_resource_id, product_region, " +
+		"line_item_currency_code ORDER BY line_item_product_code, line_item_resource_id"
 	BucketNameDefault = "hcm-bill-%s-%s"
 	BucketTimeOut     = 12  // 12小时
 	StackTimeOut      = 120 // 120秒
@@ -108,6 +113,27 @@ func (a *Aws) GetBillList(kt *kit.Kit, opt *typesBill.AwsBillListOption,
 	return total, list, nil
 }
 
+// GetBillItemSummary get bill cost summary group by product and resource, used to store bill items.
+func (a *Aws) GetBillItemSummary(kt *kit.Kit, opt *typesBill.AwsBillListOption,
+	billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) ([]map[string]string, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	where, err := parseCondition(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(QueryBillItemSummarySQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, where)
+	if opt.Page != nil {
+		sql += fmt.Sprintf(" OFFSET %d LIMIT %d", opt.Page.Offset, opt.Page.Limit)
+	}
+
+	return a.GetAwsAthenaQuery(kt, sql, billInfo)
+}
+
 // GetBillTotal get bill total num
 func (a *Aws) GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (
 	int64, error) {
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/pkg/adaptor/s3compat"
+	typebucket "hcm/pkg/adaptor/types/bucket"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListStorageBucket list all s3 buckets of account, s3 bucket name is globally unique, so it's used as cloud id.
+// public access granted by acl or policy which is blocked by bucket public access block is ignored.
+// reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListBuckets.html
+func (a *Aws) ListStorageBucket(kt *kit.Kit) ([]typebucket.Bucket, error) {
+	value, err := a.clientSet.credentials.GetWithContext(kt.Ctx)
+	if err != nil {
+		logs.Errorf("get aws credentials failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	client := s3compat.NewClient(&s3compat.Config{
+		SecretID:      value.AccessKeyID,
+		SecretKey:     value.SecretAccessKey,
+		ServiceRegion: "us-east-1",
+	})
+
+	opt := &s3compat.AttributeOption{PublicAccessBlock: s3compat.GetPublicAccessBlock}
+	buckets, err := client.ListBucket(kt, opt)
+	if err != nil {
+		logs.Errorf("list aws bucket failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return buckets, nil
+}
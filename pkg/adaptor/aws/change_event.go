@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"strings"
+
+	changeevent "hcm/pkg/adaptor/types/change-event"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+)
+
+// cloudTrailQueryLimit CloudTrail 单次查询的最大事件数
+const cloudTrailQueryLimit = 50
+
+// cloudTrailResType CloudTrail 资源类型对应的资源类型
+var cloudTrailResType = map[string]enumor.CloudResourceType{
+	"AWS::EC2::Instance":      enumor.CvmCloudResType,
+	"AWS::EC2::Volume":        enumor.DiskCloudResType,
+	"AWS::EC2::VPC":           enumor.VpcCloudResType,
+	"AWS::EC2::Subnet":        enumor.SubnetCloudResType,
+	"AWS::EC2::EIP":           enumor.EipCloudResType,
+	"AWS::EC2::SecurityGroup": enumor.SecurityGroupCloudResType,
+	"AWS::EC2::RouteTable":    enumor.RouteTableCloudResType,
+}
+
+// ListChangeEvent list resource change event from cloud trail, only write operation events are returned.
+// reference: https://docs.aws.amazon.com/awscloudtrail/latest/APIReference/API_LookupEvents.html
+func (a *Aws) ListChangeEvent(kt *kit.Kit, opt *changeevent.ListOption) ([]changeevent.ChangeEvent, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	client, err := a.clientSet.cloudTrailClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &cloudtrail.LookupEventsInput{
+		StartTime:  aws.Time(opt.StartTime),
+		EndTime:    aws.Time(opt.EndTime),
+		MaxResults: aws.Int64(cloudTrailQueryLimit),
+		LookupAttributes: []*cloudtrail.LookupAttribute{
+			{
+				AttributeKey:   aws.String(cloudtrail.LookupAttributeKeyReadOnly),
+				AttributeValue: aws.String("false"),
+			},
+		},
+	}
+
+	events := make([]changeevent.ChangeEvent, 0)
+	for {
+		resp, err := client.LookupEventsWithContext(kt.Ctx, input)
+		if err != nil {
+			logs.Errorf("look up aws cloud trail events failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Events {
+			for _, resource := range one.Resources {
+				resType, exist := cloudTrailResType[converter.PtrToVal(resource.ResourceType)]
+				if !exist {
+					continue
+				}
+
+				// 弹性IP的资源名称可能为公网IP，只使用分配ID作为云ID
+				cloudID := converter.PtrToVal(resource.ResourceName)
+				if resType == enumor.EipCloudResType && !strings.HasPrefix(cloudID, "eipalloc-") {
+					continue
+				}
+
+				events = append(events, changeevent.ChangeEvent{
+					EventID:   converter.PtrToVal(one.EventId),
+					EventName: converter.PtrToVal(one.EventName),
+					EventTime: converter.PtrToVal(one.EventTime),
+					ResType:   resType,
+					CloudID:   cloudID,
+					Region:    opt.Region,
+				})
+			}
+		}
+
+		if resp.NextToken == nil || len(*resp.NextToken) == 0 {
+			break
+		}
+
+		input.NextToken = resp.NextToken
+	}
+
+	return events, nil
+}
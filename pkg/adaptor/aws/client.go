@@ -27,8 +27,10 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/athena"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
 	curservice "github.com/aws/aws-sdk-go/service/costandusagereportservice"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sts"
 )
@@ -192,3 +194,31 @@ func (c *clientSet) cloudFormationClient(region string) (*cloudformation.CloudFo
 
 	return cloudformation.New(sess, aws.NewConfig().WithRegion(region)), nil
 }
+
+func (c *clientSet) cloudTrailClient(region string) (*cloudtrail.CloudTrail, error) {
+	cfg := &aws.Config{
+		Credentials: c.credentials,
+		Region:      aws.String(region),
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cloudtrail.New(sess), nil
+}
+
+func (c *clientSet) elbClient(region string) (*elbv2.ELBV2, error) {
+	cfg := &aws.Config{
+		Credentials: c.credentials,
+		Region:      aws.String(region),
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return elbv2.New(sess), nil
+}
@@ -254,11 +254,6 @@ func (a *Aws) CreateCvm(kt *kit.Kit, opt *typecvm.AwsCreateOption) (*poller.Base
 		return nil, err
 	}
 
-	userData, err := genCvmBase64UserData(kt, client, opt.CloudImageID, opt.Password)
-	if err != nil {
-		return nil, fmt.Errorf("gen cvm base64 user data failed, err: %v", err)
-	}
-
 	req := &ec2.RunInstancesInput{
 		DryRun:           aws.Bool(opt.DryRun),
 		ClientToken:      opt.ClientToken,
@@ -279,12 +274,22 @@ func (a *Aws) CreateCvm(kt *kit.Kit, opt *typecvm.AwsCreateOption) (*poller.Base
 				},
 			},
 		},
-		UserData: aws.String(userData),
 		Placement: &ec2.Placement{
 			AvailabilityZone: aws.String(opt.Zone),
 		},
 	}
 
+	// 使用密钥对登录时，不通过 user data 开启密码登录
+	if len(opt.KeyName) != 0 {
+		req.KeyName = aws.String(opt.KeyName)
+	} else {
+		userData, err := genCvmBase64UserData(kt, client, opt.CloudImageID, opt.Password)
+		if err != nil {
+			return nil, fmt.Errorf("gen cvm base64 user data failed, err: %v", err)
+		}
+		req.UserData = aws.String(userData)
+	}
+
 	if opt.PublicIPAssigned {
 		req.NetworkInterfaces = []*ec2.InstanceNetworkInterfaceSpecification{
 			{
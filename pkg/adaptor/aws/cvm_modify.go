@@ -0,0 +1,263 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// ChangeCvmInstanceType change instance type of cvm, the instance must be stopped before changing instance type,
+// so running instance is stopped first and started again whether instance type is changed or not.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_ModifyInstanceAttribute.html
+func (a *Aws) ChangeCvmInstanceType(kt *kit.Kit, opt *typecvm.AwsChangeInstanceTypeOption) (err error) {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "change instance type option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	instances, err := poll(a, kt, opt.Region, []*string{aws.String(opt.CloudID)})
+	if err != nil {
+		logs.Errorf("get aws cvm failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	if len(instances) == 0 {
+		return errf.Newf(errf.RecordNotFound, "cvm %s not found from cloud", opt.CloudID)
+	}
+
+	if converter.PtrToVal(instances[0].InstanceType) == opt.InstanceType {
+		return nil
+	}
+
+	running := instances[0].State != nil && converter.PtrToVal(instances[0].State.Code) == 16
+	if running {
+		stopReq := &ec2.StopInstancesInput{InstanceIds: aws.StringSlice([]string{opt.CloudID})}
+		if _, err = client.StopInstancesWithContext(kt.Ctx, stopReq); err != nil {
+			logs.Errorf("stop cvm failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+			return err
+		}
+
+		// 无论规格变更是否成功，都需要将原本运行中的云服务器重新开机，避免主机一直处于关机状态
+		defer func() {
+			startOpt := &typecvm.AwsStartOption{Region: opt.Region, CloudIDs: []string{opt.CloudID}}
+			if startErr := a.StartCvm(kt, startOpt); startErr != nil {
+				logs.Errorf("start cvm after changing instance type failed, err: %v, id: %s, rid: %s", startErr,
+					opt.CloudID, kt.Rid)
+				if err == nil {
+					err = startErr
+				}
+			}
+		}()
+
+		stopPoller := poller.Poller[*Aws, []*ec2.Instance, poller.BaseDoneResult]{
+			Handler: &stopAwsCvmPollingHandler{opt.Region},
+		}
+		result, err := stopPoller.PollUntilDone(a, kt, []*string{aws.String(opt.CloudID)},
+			types.NewModifyCvmPollerOpt())
+		if err != nil {
+			return err
+		}
+
+		if len(result.SuccessCloudIDs) == 0 {
+			return fmt.Errorf("cvm %s is not stopped in time", opt.CloudID)
+		}
+	}
+
+	req := &ec2.ModifyInstanceAttributeInput{
+		InstanceId:   aws.String(opt.CloudID),
+		InstanceType: &ec2.AttributeValue{Value: aws.String(opt.InstanceType)},
+	}
+	if _, err = client.ModifyInstanceAttributeWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("modify cvm instance type failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ResizeCvmDisk resize volume attached to cvm. the new size can be used after the modification enters the
+// optimizing state, extending file system is still required inside the instance.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_ModifyVolume.html
+func (a *Aws) ResizeCvmDisk(kt *kit.Kit, opt *typecvm.AwsResizeDiskOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "resize disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &ec2.ModifyVolumeInput{
+		VolumeId: aws.String(opt.CloudDiskID),
+		Size:     aws.Int64(opt.DiskSize),
+	}
+	if _, err = client.ModifyVolumeWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("modify volume size failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	respPoller := poller.Poller[*Aws, []*ec2.VolumeModification, poller.BaseDoneResult]{
+		Handler: &resizeVolumePollingHandler{opt.Region},
+	}
+	result, err := respPoller.PollUntilDone(a, kt, []*string{aws.String(opt.CloudDiskID)},
+		types.NewModifyCvmPollerOpt())
+	if err != nil {
+		logs.Errorf("poll volume modification failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		if len(result.FailedMessage) != 0 {
+			return fmt.Errorf("resize volume %s failed, err: %s", opt.CloudDiskID, result.FailedMessage)
+		}
+		return fmt.Errorf("resize volume %s is not finished in time", opt.CloudDiskID)
+	}
+
+	return nil
+}
+
+// RenameCvm rename cvm by overwriting the "Name" tag.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_CreateTags.html
+func (a *Aws) RenameCvm(kt *kit.Kit, opt *typecvm.AwsRenameOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "rename option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &ec2.CreateTagsInput{
+		Resources: aws.StringSlice([]string{opt.CloudID}),
+		Tags: []*ec2.Tag{
+			{
+				Key:   aws.String("Name"),
+				Value: aws.String(opt.Name),
+			},
+		},
+	}
+	if _, err = client.CreateTagsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("rename cvm failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ChangeCvmSecurityGroup replace all security groups bound to the primary network interface of cvm.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_ModifyInstanceAttribute.html
+func (a *Aws) ChangeCvmSecurityGroup(kt *kit.Kit, opt *typecvm.AwsChangeSecurityGroupOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "change security group option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &ec2.ModifyInstanceAttributeInput{
+		InstanceId: aws.String(opt.CloudID),
+		Groups:     aws.StringSlice(opt.CloudSecurityGroupIDs),
+	}
+	if _, err = client.ModifyInstanceAttributeWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("change cvm security group failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+type resizeVolumePollingHandler struct {
+	region string
+}
+
+// Done ...
+func (h *resizeVolumePollingHandler) Done(modifications []*ec2.VolumeModification) (bool,
+	*poller.BaseDoneResult) {
+
+	result := new(poller.BaseDoneResult)
+
+	flag := true
+	for _, one := range modifications {
+		switch converter.PtrToVal(one.ModificationState) {
+		case ec2.VolumeModificationStateOptimizing, ec2.VolumeModificationStateCompleted:
+			result.SuccessCloudIDs = append(result.SuccessCloudIDs, converter.PtrToVal(one.VolumeId))
+		case ec2.VolumeModificationStateFailed:
+			result.FailedCloudIDs = append(result.FailedCloudIDs, converter.PtrToVal(one.VolumeId))
+			result.FailedMessage = converter.PtrToVal(one.StatusMessage)
+		default:
+			flag = false
+		}
+	}
+
+	return flag, result
+}
+
+// Poll ...
+func (h *resizeVolumePollingHandler) Poll(client *Aws, kt *kit.Kit, cloudIDs []*string) (
+	[]*ec2.VolumeModification, error) {
+
+	cli, err := client.clientSet.ec2Client(h.region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &ec2.DescribeVolumesModificationsInput{VolumeIds: cloudIDs}
+	resp, err := cli.DescribeVolumesModificationsWithContext(kt.Ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.VolumesModifications, nil
+}
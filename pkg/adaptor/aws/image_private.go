@@ -0,0 +1,221 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/image"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// ListPrivateImage 查询当前账号拥有的自定义镜像(AMI)列表，或其他账号共享给当前账号的镜像列表
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_DescribeImages.html
+func (a *Aws) ListPrivateImage(kt *kit.Kit, opt *image.AwsPrivateImageListOption) (*image.AwsImageListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &ec2.DescribeImagesInput{
+		Owners: aws.StringSlice([]string{"self"}),
+	}
+	imageType := constant.PrivateImageType
+	if opt.Shared {
+		// 共享镜像为其他账号显式授予当前账号启动权限的镜像
+		req = &ec2.DescribeImagesInput{
+			ExecutableUsers: aws.StringSlice([]string{"self"}),
+		}
+		imageType = constant.SharedImageType
+	}
+
+	if len(opt.CloudIDs) > 0 {
+		req.ImageIds = aws.StringSlice(opt.CloudIDs)
+	}
+
+	if opt.Page != nil {
+		req.MaxResults = opt.Page.MaxResults
+		req.NextToken = opt.Page.NextToken
+	}
+
+	resp, err := client.DescribeImagesWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("describe aws private image failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	images := make([]image.AwsImage, 0, len(resp.Images))
+	for _, pImage := range resp.Images {
+		images = append(images, image.AwsImage{
+			CloudID:      converter.PtrToVal(pImage.ImageId),
+			Name:         converter.PtrToVal(pImage.Name),
+			State:        converter.PtrToVal(pImage.State),
+			Architecture: converter.PtrToVal(pImage.Architecture),
+			Platform:     converter.PtrToVal(pImage.PlatformDetails),
+			Type:         imageType,
+		})
+	}
+	return &image.AwsImageListResult{Details: images, NextToken: resp.NextToken}, nil
+}
+
+// CreateImage 基于实例创建自定义镜像(AMI)，返回镜像云ID
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_CreateImage.html
+func (a *Aws) CreateImage(kt *kit.Kit, opt *image.AwsImageCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	req := &ec2.CreateImageInput{
+		InstanceId:  aws.String(opt.CloudCvmID),
+		Name:        aws.String(opt.Name),
+		Description: opt.Description,
+	}
+
+	resp, err := client.CreateImageWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws image failed, err: %v, cvm: %s, rid: %s", err, opt.CloudCvmID, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.ImageId), nil
+}
+
+// CopyImage 复制自定义镜像(AMI)到其他地域，aws 需要在目标地域发起复制请求
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_CopyImage.html
+func (a *Aws) CopyImage(kt *kit.Kit, opt *image.AwsImageCopyOption) (image.CopyImageResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "copy option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result := make(image.CopyImageResult, len(opt.DestinationRegions))
+	for _, destRegion := range opt.DestinationRegions {
+		client, err := a.clientSet.ec2Client(destRegion)
+		if err != nil {
+			return result, err
+		}
+
+		req := &ec2.CopyImageInput{
+			Name:          aws.String(opt.Name),
+			SourceImageId: aws.String(opt.CloudID),
+			SourceRegion:  aws.String(opt.Region),
+		}
+
+		resp, err := client.CopyImageWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("copy aws image failed, err: %v, image: %s, dest region: %s, rid: %s", err, opt.CloudID,
+				destRegion, kt.Rid)
+			return result, err
+		}
+
+		result[destRegion] = converter.PtrToVal(resp.ImageId)
+	}
+
+	return result, nil
+}
+
+// ShareImage 共享自定义镜像(AMI)的启动权限给其他账号
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_ModifyImageAttribute.html
+func (a *Aws) ShareImage(kt *kit.Kit, opt *image.AwsImageShareOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "share option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	permissions := make([]*ec2.LaunchPermission, 0, len(opt.CloudAccountIDs))
+	for _, accountID := range opt.CloudAccountIDs {
+		permissions = append(permissions, &ec2.LaunchPermission{UserId: aws.String(accountID)})
+	}
+
+	req := &ec2.ModifyImageAttributeInput{
+		ImageId:          aws.String(opt.CloudID),
+		LaunchPermission: &ec2.LaunchPermissionModifications{Add: permissions},
+	}
+
+	if _, err = client.ModifyImageAttributeWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("share aws image failed, err: %v, image: %s, accounts: %v, rid: %s", err, opt.CloudID,
+			opt.CloudAccountIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteImage 注销自定义镜像(AMI)，镜像关联的快照不会被删除
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_DeregisterImage.html
+func (a *Aws) DeleteImage(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &ec2.DeregisterImageInput{
+		ImageId: aws.String(opt.ResourceID),
+	}
+
+	if _, err = client.DeregisterImageWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("deregister aws image failed, err: %v, image: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	typekp "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// ImportKeyPair import public key as key pair, returns cloud id of key pair.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_ImportKeyPair.html
+func (a *Aws) ImportKeyPair(kt *kit.Kit, opt *typekp.ImportOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "import option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	req := &ec2.ImportKeyPairInput{
+		KeyName:           aws.String(opt.Name),
+		PublicKeyMaterial: []byte(opt.PublicKey),
+	}
+	resp, err := client.ImportKeyPairWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("import aws key pair failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.KeyPairId), nil
+}
+
+// ListKeyPair list key pair, aws describe key pairs api does not support paging.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeKeyPairs.html
+func (a *Aws) ListKeyPair(kt *kit.Kit, opt *typekp.AwsListOption) ([]typekp.KeyPair, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(ec2.DescribeKeyPairsInput)
+	if len(opt.CloudIDs) != 0 {
+		req.KeyPairIds = aws.StringSlice(opt.CloudIDs)
+	}
+
+	resp, err := client.DescribeKeyPairsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws key pair failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	keyPairs := make([]typekp.KeyPair, 0, len(resp.KeyPairs))
+	for _, one := range resp.KeyPairs {
+		keyPair := typekp.KeyPair{
+			CloudID:     converter.PtrToVal(one.KeyPairId),
+			Name:        converter.PtrToVal(one.KeyName),
+			Region:      opt.Region,
+			Fingerprint: converter.PtrToVal(one.KeyFingerprint),
+		}
+
+		if one.CreateTime != nil {
+			keyPair.CloudCreatedTime = times.ConvStdTimeFormat(*one.CreateTime)
+		}
+
+		keyPairs = append(keyPairs, keyPair)
+	}
+
+	return keyPairs, nil
+}
+
+// DeleteKeyPair delete key pair.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DeleteKeyPair.html
+func (a *Aws) DeleteKeyPair(kt *kit.Kit, opt *typekp.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &ec2.DeleteKeyPairInput{KeyPairId: aws.String(opt.ResourceID)}
+	if _, err = client.DeleteKeyPairWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws key pair failed, err: %v, id: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,341 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"net"
+
+	"hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+const (
+	// awsElbQueryLimit 负载均衡单次查询的最大数量
+	awsElbQueryLimit = 400
+	// AwsElbArnQueryLimit 负载均衡按ARN查询时单次最多指定的ARN数量
+	AwsElbArnQueryLimit = 20
+	// awsInternetFacingScheme 公网负载均衡
+	awsInternetFacingScheme = "internet-facing"
+)
+
+// ListLoadBalancer list load balancer, cloud id of aws load balancer is its arn. return next marker for paging.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeLoadBalancers.html
+func (a *Aws) ListLoadBalancer(kt *kit.Kit, opt *core.AwsListOption) ([]typelb.AwsLoadBalancer, *string, error) {
+	if opt == nil {
+		return nil, nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.CloudIDs) > AwsElbArnQueryLimit {
+		return nil, nil, errf.Newf(errf.InvalidParameter, "cloud_ids length should <= %d", AwsElbArnQueryLimit)
+	}
+
+	client, err := a.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := new(elbv2.DescribeLoadBalancersInput)
+	if len(opt.CloudIDs) > 0 {
+		req.LoadBalancerArns = aws.StringSlice(opt.CloudIDs)
+	}
+
+	if opt.Page != nil {
+		req.Marker = opt.Page.NextToken
+		if opt.Page.MaxResults != nil {
+			req.PageSize = aws.Int64(converter.PtrToVal(opt.Page.MaxResults))
+			if *req.PageSize > awsElbQueryLimit {
+				req.PageSize = aws.Int64(awsElbQueryLimit)
+			}
+		}
+	}
+
+	resp, err := client.DescribeLoadBalancersWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, nil, err
+	}
+
+	lbs := make([]typelb.AwsLoadBalancer, 0, len(resp.LoadBalancers))
+	for _, one := range resp.LoadBalancers {
+		lb := typelb.AwsLoadBalancer{
+			CloudID:    converter.PtrToVal(one.LoadBalancerArn),
+			Name:       converter.PtrToVal(one.LoadBalancerName),
+			Region:     opt.Region,
+			Zones:      make([]string, 0, len(one.AvailabilityZones)),
+			LBType:     enumor.IntranetLoadBalancer,
+			CloudVpcID: converter.PtrToVal(one.VpcId),
+			Domain:     converter.PtrToVal(one.DNSName),
+			Extension: &corelb.AwsLoadBalancerExtension{
+				Type:                  one.Type,
+				Scheme:                one.Scheme,
+				IpAddressType:         one.IpAddressType,
+				CanonicalHostedZoneID: one.CanonicalHostedZoneId,
+				CloudSubnetIDs:        make([]string, 0, len(one.AvailabilityZones)),
+				CloudSecurityGroupIDs: aws.StringValueSlice(one.SecurityGroups),
+			},
+		}
+
+		if converter.PtrToVal(one.Scheme) == awsInternetFacingScheme {
+			lb.LBType = enumor.InternetLoadBalancer
+		}
+
+		if one.State != nil {
+			lb.Status = converter.PtrToVal(one.State.Code)
+		}
+
+		if one.CreatedTime != nil {
+			lb.CloudCreatedTime = times.ConvStdTimeFormat(*one.CreatedTime)
+		}
+
+		// 仅网络负载均衡返回固定IP，应用负载均衡通过域名访问
+		for _, zone := range one.AvailabilityZones {
+			lb.Zones = append(lb.Zones, converter.PtrToVal(zone.ZoneName))
+			lb.Extension.CloudSubnetIDs = append(lb.Extension.CloudSubnetIDs, converter.PtrToVal(zone.SubnetId))
+
+			for _, addr := range zone.LoadBalancerAddresses {
+				if addr.IpAddress != nil {
+					lb.PublicIPv4Addresses = append(lb.PublicIPv4Addresses, *addr.IpAddress)
+				}
+
+				if addr.PrivateIPv4Address != nil {
+					lb.PrivateIPv4Addresses = append(lb.PrivateIPv4Addresses, *addr.PrivateIPv4Address)
+				}
+			}
+		}
+
+		lbs = append(lbs, lb)
+	}
+
+	return lbs, resp.NextMarker, nil
+}
+
+// ListLoadBalancerListener list all listeners of load balancer, targets are registered targets of the target
+// groups that listener forwards to by default.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeListeners.html
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeTargetHealth.html
+func (a *Aws) ListLoadBalancerListener(kt *kit.Kit, opt *typelb.ListenerListOption) ([]typelb.AwsListener, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	client, err := a.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	listeners := make([]typelb.AwsListener, 0)
+	targetGroupTargets := make(map[string][]*typelb.AwsTarget)
+	req := &elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(opt.CloudLbID)}
+	for {
+		resp, err := client.DescribeListenersWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("list aws load balancer listener failed, err: %v, lb: %s, rid: %s", err, opt.CloudLbID,
+				kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Listeners {
+			listener := typelb.AwsListener{
+				CloudID:  converter.PtrToVal(one.ListenerArn),
+				Protocol: converter.PtrToVal(one.Protocol),
+				Port:     converter.PtrToVal(one.Port),
+				Extension: &corelb.AwsListenerExtension{
+					CloudTargetGroupArns: awsListenerTargetGroupArns(one.DefaultActions),
+					SslPolicy:            one.SslPolicy,
+				},
+				Targets: make([]*typelb.AwsTarget, 0),
+			}
+
+			for _, cert := range one.Certificates {
+				listener.Extension.CertificateArns = append(listener.Extension.CertificateArns,
+					converter.PtrToVal(cert.CertificateArn))
+			}
+
+			for _, arn := range listener.Extension.CloudTargetGroupArns {
+				targets, exist := targetGroupTargets[arn]
+				if !exist {
+					targets, err = a.listTargetGroupTarget(kt, client, arn)
+					if err != nil {
+						return nil, err
+					}
+					targetGroupTargets[arn] = targets
+				}
+
+				listener.Targets = append(listener.Targets, targets...)
+			}
+
+			listeners = append(listeners, listener)
+		}
+
+		if resp.NextMarker == nil || len(*resp.NextMarker) == 0 {
+			break
+		}
+		req.Marker = resp.NextMarker
+	}
+
+	return listeners, nil
+}
+
+func awsListenerTargetGroupArns(actions []*elbv2.Action) []string {
+	arns := make([]string, 0)
+	for _, action := range actions {
+		if action.TargetGroupArn != nil {
+			arns = append(arns, *action.TargetGroupArn)
+			continue
+		}
+
+		if action.ForwardConfig != nil {
+			for _, group := range action.ForwardConfig.TargetGroups {
+				arns = append(arns, converter.PtrToVal(group.TargetGroupArn))
+			}
+		}
+	}
+
+	return arns
+}
+
+func (a *Aws) listTargetGroupTarget(kt *kit.Kit, client *elbv2.ELBV2, arn string) ([]*typelb.AwsTarget, error) {
+	resp, err := client.DescribeTargetHealthWithContext(kt.Ctx,
+		&elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(arn)})
+	if err != nil {
+		logs.Errorf("list aws target group target failed, err: %v, target group: %s, rid: %s", err, arn, kt.Rid)
+		return nil, err
+	}
+
+	targets := make([]*typelb.AwsTarget, 0, len(resp.TargetHealthDescriptions))
+	for _, one := range resp.TargetHealthDescriptions {
+		if one.Target == nil {
+			continue
+		}
+
+		cloudTargetID := converter.PtrToVal(one.Target.Id)
+		target := &typelb.AwsTarget{
+			TargetType:    enumor.InstanceLBTargetType,
+			CloudTargetID: cloudTargetID,
+			Port:          converter.PtrToVal(one.Target.Port),
+			Extension: &corelb.AwsTargetExtension{
+				CloudTargetGroupArn: aws.String(arn),
+				AvailabilityZone:    one.Target.AvailabilityZone,
+			},
+		}
+
+		if net.ParseIP(cloudTargetID) != nil {
+			target.TargetType = enumor.IPLBTargetType
+			target.IP = cloudTargetID
+		}
+
+		if one.TargetHealth != nil {
+			target.Extension.HealthState = one.TargetHealth.State
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// CreateLoadBalancer create load balancer, return created load balancer arn.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_CreateLoadBalancer.html
+func (a *Aws) CreateLoadBalancer(kt *kit.Kit, opt *typelb.AwsCreateOption) (*string, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &elbv2.CreateLoadBalancerInput{
+		Name:          aws.String(opt.Name),
+		Type:          opt.Type,
+		Scheme:        opt.Scheme,
+		IpAddressType: opt.IpAddressType,
+		Subnets:       aws.StringSlice(opt.CloudSubnetIDs),
+	}
+
+	if len(opt.CloudSecurityGroupIDs) != 0 {
+		req.SecurityGroups = aws.StringSlice(opt.CloudSecurityGroupIDs)
+	}
+
+	resp, err := client.CreateLoadBalancerWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if len(resp.LoadBalancers) == 0 {
+		return nil, errf.New(errf.Aborted, "create aws load balancer but no load balancer returned")
+	}
+
+	return resp.LoadBalancers[0].LoadBalancerArn, nil
+}
+
+// DeleteLoadBalancer delete load balancer, resource id is load balancer arn.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DeleteLoadBalancer.html
+func (a *Aws) DeleteLoadBalancer(kt *kit.Kit, opt *typelb.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteLoadBalancerWithContext(kt.Ctx,
+		&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(opt.ResourceID)})
+	if err != nil {
+		logs.Errorf("delete aws load balancer failed, err: %v, arn: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
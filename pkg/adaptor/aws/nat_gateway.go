@@ -0,0 +1,202 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	// awsNatGatewayDeletedState 已删除的NAT网关仍会在一段时间内被查询到，同步时需要忽略
+	awsNatGatewayDeletedState = "deleted"
+	// awsPrivateConnectivityType 私网NAT网关
+	awsPrivateConnectivityType = "private"
+)
+
+// ListNatGateway list nat gateway. return next token for paging.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeNatGateways.html
+func (a *Aws) ListNatGateway(kt *kit.Kit, opt *core.AwsListOption) ([]typenat.AwsNatGateway, *string, error) {
+	if opt == nil {
+		return nil, nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := new(ec2.DescribeNatGatewaysInput)
+	if len(opt.CloudIDs) > 0 {
+		req.NatGatewayIds = aws.StringSlice(opt.CloudIDs)
+	}
+
+	if opt.Page != nil {
+		req.NextToken = opt.Page.NextToken
+		req.MaxResults = opt.Page.MaxResults
+	}
+
+	resp, err := client.DescribeNatGatewaysWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, nil, err
+	}
+
+	nats := make([]typenat.AwsNatGateway, 0, len(resp.NatGateways))
+	for _, one := range resp.NatGateways {
+		if converter.PtrToVal(one.State) == awsNatGatewayDeletedState {
+			continue
+		}
+
+		nats = append(nats, convAwsNatGateway(one, opt.Region))
+	}
+
+	return nats, resp.NextToken, nil
+}
+
+func convAwsNatGateway(one *ec2.NatGateway, region string) typenat.AwsNatGateway {
+	name, _ := parseTags(one.Tags)
+
+	nat := typenat.AwsNatGateway{
+		CloudID:       converter.PtrToVal(one.NatGatewayId),
+		Name:          name,
+		Region:        region,
+		NatType:       enumor.PublicNatGateway,
+		Status:        converter.PtrToVal(one.State),
+		CloudVpcID:    converter.PtrToVal(one.VpcId),
+		CloudSubnetID: converter.PtrToVal(one.SubnetId),
+		Extension: &corenat.AwsNatGatewayExtension{
+			Addresses:      make([]corenat.AwsNatGatewayAddress, 0, len(one.NatGatewayAddresses)),
+			FailureCode:    one.FailureCode,
+			FailureMessage: one.FailureMessage,
+		},
+	}
+
+	if converter.PtrToVal(one.ConnectivityType) == awsPrivateConnectivityType {
+		nat.NatType = enumor.PrivateNatGateway
+	}
+
+	if one.CreateTime != nil {
+		nat.CloudCreatedTime = times.ConvStdTimeFormat(*one.CreateTime)
+	}
+
+	for _, address := range one.NatGatewayAddresses {
+		if address == nil {
+			continue
+		}
+
+		nat.Extension.Addresses = append(nat.Extension.Addresses, corenat.AwsNatGatewayAddress{
+			CloudAllocationID:       address.AllocationId,
+			CloudNetworkInterfaceID: address.NetworkInterfaceId,
+			PrivateIP:               address.PrivateIp,
+			PublicIP:                address.PublicIp,
+		})
+
+		if address.AllocationId != nil {
+			nat.CloudEipIDs = append(nat.CloudEipIDs, *address.AllocationId)
+		}
+
+		if address.PublicIp != nil {
+			nat.PublicIPAddresses = append(nat.PublicIPAddresses, *address.PublicIp)
+		}
+
+		if address.PrivateIp != nil {
+			nat.PrivateIPAddresses = append(nat.PrivateIPAddresses, *address.PrivateIp)
+		}
+	}
+
+	return nat
+}
+
+// CreateNatGateway create nat gateway, return cloud id of created nat gateway.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_CreateNatGateway.html
+func (a *Aws) CreateNatGateway(kt *kit.Kit, opt *typenat.AwsCreateOption) (*string, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &ec2.CreateNatGatewayInput{
+		AllocationId:      opt.CloudAllocationID,
+		ConnectivityType:  aws.String(string(opt.NatType)),
+		PrivateIpAddress:  opt.PrivateIPAddress,
+		SubnetId:          aws.String(opt.CloudSubnetID),
+		TagSpecifications: genNameTags(natGatewayTagResType, converter.PtrToVal(opt.Name)),
+	}
+
+	resp, err := client.CreateNatGatewayWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if resp.NatGateway == nil || resp.NatGateway.NatGatewayId == nil {
+		return nil, errf.New(errf.Aborted, "create aws nat gateway but cloud id not returned")
+	}
+
+	return resp.NatGateway.NatGatewayId, nil
+}
+
+// DeleteNatGateway delete nat gateway.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DeleteNatGateway.html
+func (a *Aws) DeleteNatGateway(kt *kit.Kit, opt *typenat.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &ec2.DeleteNatGatewayInput{NatGatewayId: aws.String(opt.ResourceID)}
+	if _, err = client.DeleteNatGatewayWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws nat gateway failed, err: %v, id: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
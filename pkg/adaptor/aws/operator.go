@@ -0,0 +1,354 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/pkg/adaptor/operator"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/adaptor/types/disk"
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+// awsTagResTypes resource types which support tag of aws.
+var awsTagResTypes = []enumor.CloudResourceType{
+	enumor.CvmCloudResType,
+	enumor.DiskCloudResType,
+	enumor.VpcCloudResType,
+	enumor.SubnetCloudResType,
+	enumor.EipCloudResType,
+	enumor.SecurityGroupCloudResType,
+}
+
+func init() {
+	operator.Register(enumor.Aws, func(cred *operator.Credential) (operator.Operator, error) {
+		cli, err := NewAws(cred.Secret, cred.CloudAccountID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &awsOperator{cli: cli}, nil
+	})
+}
+
+// awsOperator implements operator.Operator by aws adaptor.
+type awsOperator struct {
+	cli *Aws
+}
+
+// Vendor ...
+func (op *awsOperator) Vendor() enumor.Vendor {
+	return enumor.Aws
+}
+
+// ListCvm ...
+func (op *awsOperator) ListCvm(kt *kit.Kit, opt *operator.CvmListOption) ([]operator.Cvm, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvms, _, err := op.cli.ListCvm(kt, &typecvm.AwsListOption{Region: opt.Region, CloudIDs: opt.CloudIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]operator.Cvm, 0, len(cvms))
+	for _, one := range cvms {
+		cvm := operator.Cvm{
+			CloudID:      converter.PtrToVal(one.InstanceId),
+			Name:         converter.PtrToVal(GetCvmNameFromTags(one.Tags)),
+			InstanceType: converter.PtrToVal(one.InstanceType),
+		}
+		if one.State != nil {
+			cvm.Status = converter.PtrToVal(one.State.Name)
+		}
+		if one.Placement != nil {
+			cvm.Zone = converter.PtrToVal(one.Placement.AvailabilityZone)
+		}
+		result = append(result, cvm)
+	}
+
+	return result, nil
+}
+
+// StartCvm ...
+func (op *awsOperator) StartCvm(kt *kit.Kit, opt *operator.CvmOperateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "start cvm option is required")
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		if err = op.cli.StartCvm(kt, &typecvm.AwsStartOption{Region: region, CloudIDs: ids}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StopCvm ...
+func (op *awsOperator) StopCvm(kt *kit.Kit, opt *operator.CvmStopOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "stop cvm option is required")
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		stopOpt := &typecvm.AwsStopOption{Region: region, CloudIDs: ids, Force: opt.Force, Hibernate: opt.Hibernate}
+		if err = op.cli.StopCvm(kt, stopOpt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RebootCvm ...
+func (op *awsOperator) RebootCvm(kt *kit.Kit, opt *operator.CvmStopOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "reboot cvm option is required")
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		rebootOpt := &typecvm.AwsRebootOption{Region: region, CloudIDs: ids}
+		if err = op.cli.RebootCvm(kt, rebootOpt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteCvm ...
+func (op *awsOperator) DeleteCvm(kt *kit.Kit, opt *operator.CvmDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete cvm option is required")
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		if err = op.cli.DeleteCvm(kt, &typecvm.AwsDeleteOption{Region: region, CloudIDs: ids}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AttachDisk ...
+func (op *awsOperator) AttachDisk(kt *kit.Kit, opt *operator.DiskAttachOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "attach disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.DeviceName) == 0 {
+		return errf.New(errf.InvalidParameter, "aws attach disk device name is required")
+	}
+
+	return op.cli.AttachDisk(kt, &disk.AwsDiskAttachOption{
+		Region:      opt.Disk.Region,
+		DeviceName:  opt.DeviceName,
+		CloudCvmID:  opt.Cvm.CloudID,
+		CloudDiskID: opt.Disk.CloudID,
+	})
+}
+
+// DetachDisk ...
+func (op *awsOperator) DetachDisk(kt *kit.Kit, opt *operator.DiskAttachOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "detach disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.DetachDisk(kt, &disk.AwsDiskDetachOption{
+		Region:      opt.Disk.Region,
+		CloudCvmID:  opt.Cvm.CloudID,
+		CloudDiskID: opt.Disk.CloudID,
+	})
+}
+
+// DeleteDisk ...
+func (op *awsOperator) DeleteDisk(kt *kit.Kit, opt *operator.DiskDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.DeleteDisk(kt, &disk.AwsDiskDeleteOption{Region: opt.Disk.Region, CloudID: opt.Disk.CloudID})
+}
+
+// DeleteSecurityGroup ...
+func (op *awsOperator) DeleteSecurityGroup(kt *kit.Kit, opt *operator.SecurityGroupDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete security group option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.DeleteSecurityGroup(kt, &securitygroup.AwsDeleteOption{
+		Region:  opt.SecurityGroup.Region,
+		CloudID: opt.SecurityGroup.CloudID,
+	})
+}
+
+// AssociateCvm ...
+func (op *awsOperator) AssociateCvm(kt *kit.Kit, opt *operator.SecurityGroupCvmOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "security group associate cvm option is required")
+	}
+
+	return op.cli.SecurityGroupCvmAssociate(kt, &securitygroup.AwsAssociateCvmOption{
+		Region:               opt.Region,
+		CloudSecurityGroupID: opt.CloudSecurityGroupID,
+		CloudCvmID:           opt.CloudCvmID,
+	})
+}
+
+// DisassociateCvm ...
+func (op *awsOperator) DisassociateCvm(kt *kit.Kit, opt *operator.SecurityGroupCvmOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "security group disassociate cvm option is required")
+	}
+
+	return op.cli.SecurityGroupCvmDisassociate(kt, &securitygroup.AwsAssociateCvmOption{
+		Region:               opt.Region,
+		CloudSecurityGroupID: opt.CloudSecurityGroupID,
+		CloudCvmID:           opt.CloudCvmID,
+	})
+}
+
+// TagResTypes ...
+func (op *awsOperator) TagResTypes() []enumor.CloudResourceType {
+	return awsTagResTypes
+}
+
+// ListTag ...
+func (op *awsOperator) ListTag(kt *kit.Kit, opt *operator.TagListOption) ([]typetag.ResourceTags, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list tag option is required")
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]typetag.ResourceTags, 0, len(opt.Resources))
+	for region, ids := range regionIDs {
+		tags, err := op.cli.ListResourceTag(kt, &typetag.ListOption{Region: region, ResType: opt.ResType,
+			CloudIDs: ids})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tags...)
+	}
+
+	return result, nil
+}
+
+// TagResource ...
+func (op *awsOperator) TagResource(kt *kit.Kit, opt *operator.TagResourceOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		err = op.cli.TagResource(kt, &typetag.TagOption{Region: region, ResType: opt.ResType, CloudIDs: ids,
+			Tags: opt.Tags})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UntagResource ...
+func (op *awsOperator) UntagResource(kt *kit.Kit, opt *operator.UntagResourceOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		err = op.cli.UntagResource(kt, &typetag.UntagOption{Region: region, ResType: opt.ResType, CloudIDs: ids,
+			Keys: opt.Keys})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// awsSnapshotOwnerSelf 只查询当前账号创建的快照，否则会返回所有公共快照
+const awsSnapshotOwnerSelf = "self"
+
+// ListSnapshot list disk snapshot owned by current account. return next token for paging.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeSnapshots.html
+func (a *Aws) ListSnapshot(kt *kit.Kit, opt *core.AwsListOption) ([]typesnap.AwsSnapshot, *string, error) {
+	if opt == nil {
+		return nil, nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := &ec2.DescribeSnapshotsInput{OwnerIds: aws.StringSlice([]string{awsSnapshotOwnerSelf})}
+	// 指定快照ID查询时不能设置分页参数
+	if len(opt.CloudIDs) > 0 {
+		req.SnapshotIds = aws.StringSlice(opt.CloudIDs)
+	} else if opt.Page != nil {
+		req.NextToken = opt.Page.NextToken
+		req.MaxResults = opt.Page.MaxResults
+	}
+
+	resp, err := client.DescribeSnapshotsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, nil, err
+	}
+
+	snapshots := make([]typesnap.AwsSnapshot, 0, len(resp.Snapshots))
+	for _, one := range resp.Snapshots {
+		snapshots = append(snapshots, convAwsSnapshot(one, opt.Region))
+	}
+
+	return snapshots, resp.NextToken, nil
+}
+
+func convAwsSnapshot(one *ec2.Snapshot, region string) typesnap.AwsSnapshot {
+	name, _ := parseTags(one.Tags)
+
+	snapshot := typesnap.AwsSnapshot{
+		CloudID:     converter.PtrToVal(one.SnapshotId),
+		Name:        name,
+		Region:      region,
+		Status:      converter.PtrToVal(one.State),
+		CloudDiskID: converter.PtrToVal(one.VolumeId),
+		DiskSize:    uint64(converter.PtrToVal(one.VolumeSize)),
+		Encrypted:   one.Encrypted,
+		Memo:        one.Description,
+		Extension: &coresnap.AwsSnapshotExtension{
+			OwnerID:      one.OwnerId,
+			Progress:     one.Progress,
+			StorageTier:  one.StorageTier,
+			KmsKeyID:     one.KmsKeyId,
+			StateMessage: one.StateMessage,
+		},
+	}
+
+	if one.StartTime != nil {
+		snapshot.CloudCreatedTime = times.ConvStdTimeFormat(*one.StartTime)
+	}
+
+	return snapshot
+}
+
+// CreateSnapshot create disk snapshot, return cloud id of created snapshot.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_CreateSnapshot.html
+func (a *Aws) CreateSnapshot(kt *kit.Kit, opt *typesnap.AwsCreateOption) (*string, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &ec2.CreateSnapshotInput{
+		Description:       opt.Memo,
+		VolumeId:          aws.String(opt.CloudDiskID),
+		TagSpecifications: genNameTags(snapshotTagResType, opt.Name),
+	}
+
+	resp, err := client.CreateSnapshotWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws snapshot failed, err: %v, disk: %s, rid: %s", err, opt.CloudDiskID, kt.Rid)
+		return nil, err
+	}
+
+	if resp.SnapshotId == nil {
+		return nil, errf.New(errf.Aborted, "create aws snapshot but cloud id not returned")
+	}
+
+	return resp.SnapshotId, nil
+}
+
+// DeleteSnapshot delete disk snapshot.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DeleteSnapshot.html
+func (a *Aws) DeleteSnapshot(kt *kit.Kit, opt *typesnap.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &ec2.DeleteSnapshotInput{SnapshotId: aws.String(opt.ResourceID)}
+	if _, err = client.DeleteSnapshotWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws snapshot failed, err: %v, id: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
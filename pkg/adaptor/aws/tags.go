@@ -20,6 +20,11 @@
 package aws
 
 import (
+	"hcm/pkg/adaptor/types/core"
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
 	"hcm/pkg/tools/converter"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -34,8 +39,10 @@ const tagKeyForResourceName = "Name"
 type tagResourceType string
 
 const (
-	vpcTagResType    tagResourceType = "vpc"
-	subnetTagResType tagResourceType = "subnet"
+	vpcTagResType        tagResourceType = "vpc"
+	subnetTagResType     tagResourceType = "subnet"
+	natGatewayTagResType tagResourceType = "natgateway"
+	snapshotTagResType   tagResourceType = "snapshot"
 )
 
 // genNameTags generate name ec2 tags.
@@ -69,3 +76,124 @@ func parseTags(tags []*ec2.Tag) (string, []*ec2.Tag) {
 
 	return "", tags
 }
+
+// ListResourceTag list tags of ec2 resources, all resource types support tag in ec2.
+// reference: https://docs.aws.amazon.com/zh_cn/AWSEC2/latest/APIReference/API_DescribeTags.html
+func (a *Aws) ListResourceTag(kt *kit.Kit, opt *typetag.ListOption) ([]typetag.ResourceTags, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &ec2.DescribeTagsInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("resource-id"),
+			Values: aws.StringSlice(opt.CloudIDs),
+		}},
+		MaxResults: aws.Int64(core.AwsQueryLimit),
+	}
+
+	tagMap := make(map[string][]typetag.Tag, len(opt.CloudIDs))
+	for {
+		resp, err := client.DescribeTagsWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("describe aws tags failed, err: %v, ids: %v, rid: %s", err, opt.CloudIDs, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Tags {
+			cloudID := converter.PtrToVal(one.ResourceId)
+			tagMap[cloudID] = append(tagMap[cloudID], typetag.Tag{
+				Key:   converter.PtrToVal(one.Key),
+				Value: converter.PtrToVal(one.Value),
+			})
+		}
+
+		if resp.NextToken == nil || len(*resp.NextToken) == 0 {
+			break
+		}
+		req.NextToken = resp.NextToken
+	}
+
+	result := make([]typetag.ResourceTags, 0, len(opt.CloudIDs))
+	for _, cloudID := range opt.CloudIDs {
+		result = append(result, typetag.ResourceTags{CloudID: cloudID, Tags: tagMap[cloudID]})
+	}
+
+	return result, nil
+}
+
+// TagResource add or overwrite tags of ec2 resources.
+// reference: https://docs.aws.amazon.com/zh_cn/AWSEC2/latest/APIReference/API_CreateTags.html
+func (a *Aws) TagResource(kt *kit.Kit, opt *typetag.TagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]*ec2.Tag, 0, len(opt.Tags))
+	for _, one := range opt.Tags {
+		tags = append(tags, &ec2.Tag{Key: aws.String(one.Key), Value: aws.String(one.Value)})
+	}
+
+	req := &ec2.CreateTagsInput{
+		Resources: aws.StringSlice(opt.CloudIDs),
+		Tags:      tags,
+	}
+	if _, err = client.CreateTagsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("create aws tags failed, err: %v, ids: %v, rid: %s", err, opt.CloudIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// UntagResource remove tags of ec2 resources by tag keys.
+// reference: https://docs.aws.amazon.com/zh_cn/AWSEC2/latest/APIReference/API_DeleteTags.html
+func (a *Aws) UntagResource(kt *kit.Kit, opt *typetag.UntagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	// tag without value means delete the tag regardless of its value.
+	tags := make([]*ec2.Tag, 0, len(opt.Keys))
+	for _, key := range opt.Keys {
+		tags = append(tags, &ec2.Tag{Key: aws.String(key)})
+	}
+
+	req := &ec2.DeleteTagsInput{
+		Resources: aws.StringSlice(opt.CloudIDs),
+		Tags:      tags,
+	}
+	if _, err = client.DeleteTagsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws tags failed, err: %v, ids: %v, rid: %s", err, opt.CloudIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
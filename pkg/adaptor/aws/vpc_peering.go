@@ -0,0 +1,186 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	typevpcpeering "hcm/pkg/adaptor/types/vpc-peering"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// ListVpcPeering list all vpc peering connections and transit gateway vpc attachments in region.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeVpcPeeringConnections.html
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeTransitGatewayVpcAttachments.html
+func (a *Aws) ListVpcPeering(kt *kit.Kit, opt *typevpcpeering.ListOption) ([]typevpcpeering.VpcPeering, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	peerings := make([]typevpcpeering.VpcPeering, 0)
+
+	peeringReq := &ec2.DescribeVpcPeeringConnectionsInput{MaxResults: aws.Int64(1000)}
+	err = client.DescribeVpcPeeringConnectionsPagesWithContext(kt.Ctx, peeringReq,
+		func(page *ec2.DescribeVpcPeeringConnectionsOutput, lastPage bool) bool {
+			for _, one := range page.VpcPeeringConnections {
+				if one == nil || one.Status == nil ||
+					converter.PtrToVal(one.Status.Code) == ec2.VpcPeeringConnectionStateReasonCodeDeleted {
+					continue
+				}
+				peerings = append(peerings, convAwsVpcPeering(one, opt.Region))
+			}
+			return true
+		})
+	if err != nil {
+		logs.Errorf("list aws vpc peering connection failed, err: %v, region: %s, rid: %s", err, opt.Region,
+			kt.Rid)
+		return nil, err
+	}
+
+	attachReq := &ec2.DescribeTransitGatewayVpcAttachmentsInput{MaxResults: aws.Int64(1000)}
+	err = client.DescribeTransitGatewayVpcAttachmentsPagesWithContext(kt.Ctx, attachReq,
+		func(page *ec2.DescribeTransitGatewayVpcAttachmentsOutput, lastPage bool) bool {
+			for _, one := range page.TransitGatewayVpcAttachments {
+				if one == nil ||
+					converter.PtrToVal(one.State) == ec2.TransitGatewayAttachmentStateDeleted {
+					continue
+				}
+				peerings = append(peerings, convAwsTransitGatewayAttachment(one, opt.Region))
+			}
+			return true
+		})
+	if err != nil {
+		logs.Errorf("list aws transit gateway vpc attachment failed, err: %v, region: %s, rid: %s", err,
+			opt.Region, kt.Rid)
+		return nil, err
+	}
+
+	return peerings, nil
+}
+
+func convAwsVpcPeering(one *ec2.VpcPeeringConnection, region string) typevpcpeering.VpcPeering {
+	name, _ := parseTags(one.Tags)
+	cloudID := converter.PtrToVal(one.VpcPeeringConnectionId)
+
+	peering := typevpcpeering.VpcPeering{
+		CloudID:        cloudID,
+		Name:           name,
+		Region:         region,
+		Type:           enumor.PeeringConnection,
+		CloudStatus:    converter.PtrToVal(one.Status.Code),
+		CloudGatewayID: cloudID,
+		Memo:           one.Status.Message,
+	}
+
+	switch peering.CloudStatus {
+	case ec2.VpcPeeringConnectionStateReasonCodeActive:
+		peering.Status = enumor.VpcPeeringActive
+	case ec2.VpcPeeringConnectionStateReasonCodeInitiatingRequest,
+		ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance,
+		ec2.VpcPeeringConnectionStateReasonCodeProvisioning:
+		peering.Status = enumor.VpcPeeringPending
+	default:
+		peering.Status = enumor.VpcPeeringInactive
+	}
+
+	if info := one.RequesterVpcInfo; info != nil {
+		peering.LocalCloudVpcID = converter.PtrToVal(info.VpcId)
+		peering.LocalCidrs = awsPeeringVpcCidrs(info)
+	}
+
+	if info := one.AccepterVpcInfo; info != nil {
+		peering.PeerCloudVpcID = converter.PtrToVal(info.VpcId)
+		peering.PeerCloudAccountID = converter.PtrToVal(info.OwnerId)
+		peering.PeerRegion = converter.PtrToVal(info.Region)
+		peering.PeerCidrs = awsPeeringVpcCidrs(info)
+	}
+
+	return peering
+}
+
+func awsPeeringVpcCidrs(info *ec2.VpcPeeringConnectionVpcInfo) []string {
+	cidrs := make([]string, 0, len(info.CidrBlockSet)+len(info.Ipv6CidrBlockSet))
+	for _, one := range info.CidrBlockSet {
+		if one != nil && one.CidrBlock != nil {
+			cidrs = append(cidrs, *one.CidrBlock)
+		}
+	}
+
+	for _, one := range info.Ipv6CidrBlockSet {
+		if one != nil && one.Ipv6CidrBlock != nil {
+			cidrs = append(cidrs, *one.Ipv6CidrBlock)
+		}
+	}
+
+	if len(cidrs) == 0 && info.CidrBlock != nil {
+		cidrs = append(cidrs, *info.CidrBlock)
+	}
+
+	return cidrs
+}
+
+func convAwsTransitGatewayAttachment(one *ec2.TransitGatewayVpcAttachment,
+	region string) typevpcpeering.VpcPeering {
+
+	name, _ := parseTags(one.Tags)
+
+	peering := typevpcpeering.VpcPeering{
+		CloudID:         converter.PtrToVal(one.TransitGatewayAttachmentId),
+		Name:            name,
+		Region:          region,
+		Type:            enumor.TransitGatewayAttachment,
+		CloudStatus:     converter.PtrToVal(one.State),
+		CloudGatewayID:  converter.PtrToVal(one.TransitGatewayId),
+		LocalCloudVpcID: converter.PtrToVal(one.VpcId),
+		LocalCidrs:      make([]string, 0),
+		PeerCidrs:       make([]string, 0),
+	}
+
+	switch peering.CloudStatus {
+	case ec2.TransitGatewayAttachmentStateAvailable, ec2.TransitGatewayAttachmentStateModifying:
+		peering.Status = enumor.VpcPeeringActive
+	case ec2.TransitGatewayAttachmentStateInitiating, ec2.TransitGatewayAttachmentStateInitiatingRequest,
+		ec2.TransitGatewayAttachmentStatePendingAcceptance, ec2.TransitGatewayAttachmentStatePending:
+		peering.Status = enumor.VpcPeeringPending
+	default:
+		peering.Status = enumor.VpcPeeringInactive
+	}
+
+	if one.CreationTime != nil {
+		peering.CloudCreatedTime = times.ConvStdTimeFormat(*one.CreationTime)
+	}
+
+	return peering
+}
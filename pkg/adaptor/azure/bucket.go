@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+
+	typebucket "hcm/pkg/adaptor/types/bucket"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
+)
+
+// ListStorageBucket list all blob containers of subscription as buckets, container is the unit of access control
+// in azure blob storage, so bucket name is combined with storage account name, e.g. account/container.
+// container can only be publicly read when the storage account allows blob public access.
+// reference: https://learn.microsoft.com/en-us/rest/api/storagerp/blob-containers/list
+func (a *Azure) ListStorageBucket(kt *kit.Kit) ([]typebucket.Bucket, error) {
+	accountClient, err := a.clientSet.storageAccountClient()
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]typebucket.Bucket, 0)
+	pager := accountClient.NewListPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure storage account failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("list azure storage account but get next page failed, err: %v", err)
+		}
+
+		for _, account := range page.Value {
+			if account == nil || account.Properties == nil {
+				continue
+			}
+
+			containers, err := a.listAccountContainer(kt, account)
+			if err != nil {
+				return nil, err
+			}
+			buckets = append(buckets, containers...)
+		}
+	}
+
+	return buckets, nil
+}
+
+// listAccountContainer list blob containers of storage account, encryption and versioning are configured
+// on storage account level.
+func (a *Azure) listAccountContainer(kt *kit.Kit, account *armstorage.Account) ([]typebucket.Bucket, error) {
+	accountName := converter.PtrToVal(account.Name)
+	resGroupName := parseResourceGroupName(converter.PtrToVal(account.ID))
+
+	serviceClient, err := a.clientSet.blobServiceClient()
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := serviceClient.GetServiceProperties(kt.Ctx, resGroupName, accountName, nil)
+	if err != nil {
+		logs.Errorf("get azure blob service properties failed, err: %v, account: %s, rid: %s", err,
+			accountName, kt.Rid)
+		return nil, err
+	}
+
+	versioning := false
+	if service.BlobServiceProperties.BlobServiceProperties != nil {
+		versioning = converter.PtrToVal(service.BlobServiceProperties.BlobServiceProperties.IsVersioningEnabled)
+	}
+
+	// azure storage service encryption is always enabled and can not be disabled.
+	encryptionType := string(armstorage.KeySourceMicrosoftStorage)
+	if account.Properties.Encryption != nil && account.Properties.Encryption.KeySource != nil {
+		encryptionType = string(*account.Properties.Encryption.KeySource)
+	}
+
+	// allowBlobPublicAccess is true when it's not set on storage account created before 2023.
+	allowPublic := account.Properties.AllowBlobPublicAccess == nil ||
+		converter.PtrToVal(account.Properties.AllowBlobPublicAccess)
+
+	containerClient, err := a.clientSet.blobContainerClient()
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]typebucket.Bucket, 0)
+	pager := containerClient.NewListPager(resGroupName, accountName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure blob container failed, err: %v, account: %s, rid: %s", err, accountName,
+				kt.Rid)
+			return nil, fmt.Errorf("list azure blob container but get next page failed, err: %v", err)
+		}
+
+		for _, one := range page.Value {
+			if one == nil {
+				continue
+			}
+
+			bucket := typebucket.Bucket{
+				CloudID:           SPtrToLowerStr(one.ID),
+				Name:              accountName + "/" + converter.PtrToVal(one.Name),
+				Region:            converter.PtrToVal(account.Location),
+				Encrypted:         true,
+				EncryptionType:    encryptionType,
+				VersioningEnabled: versioning,
+			}
+
+			if account.Properties.CreationTime != nil {
+				bucket.CloudCreatedTime = times.ConvStdTimeFormat(*account.Properties.CreationTime)
+			}
+
+			access := new(typebucket.PublicAccess)
+			if allowPublic && one.Properties != nil && one.Properties.PublicAccess != nil &&
+				*one.Properties.PublicAccess != armstorage.PublicAccessNone {
+
+				access.Read = true
+				access.Reasons = append(access.Reasons, typebucket.NewReason(typebucket.AccountReasonSource,
+					"AllowBlobPublicAccess", "PublicAccess="+string(*one.Properties.PublicAccess)))
+			}
+			access.Apply(&bucket)
+
+			buckets = append(buckets, bucket)
+		}
+	}
+
+	return buckets, nil
+}
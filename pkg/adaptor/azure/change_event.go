@@ -0,0 +1,146 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	changeevent "hcm/pkg/adaptor/types/change-event"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// activityLogResType 活动日志资源类型对应的资源类型
+var activityLogResType = map[string]enumor.CloudResourceType{
+	"microsoft.compute/virtualmachines":       enumor.CvmCloudResType,
+	"microsoft.compute/disks":                 enumor.DiskCloudResType,
+	"microsoft.network/virtualnetworks":       enumor.VpcCloudResType,
+	"microsoft.network/publicipaddresses":     enumor.EipCloudResType,
+	"microsoft.network/networksecuritygroups": enumor.SecurityGroupCloudResType,
+	"microsoft.network/routetables":           enumor.RouteTableCloudResType,
+	"microsoft.network/networkinterfaces":     enumor.NetworkInterfaceCloudResType,
+}
+
+type activityLogEvent struct {
+	EventDataID       string           `json:"eventDataId"`
+	EventTimestamp    time.Time        `json:"eventTimestamp"`
+	ResourceGroupName string           `json:"resourceGroupName"`
+	ResourceID        string           `json:"resourceId"`
+	OperationName     activityLogValue `json:"operationName"`
+	ResourceType      activityLogValue `json:"resourceType"`
+	Status            activityLogValue `json:"status"`
+	Category          activityLogValue `json:"category"`
+}
+
+type activityLogValue struct {
+	Value string `json:"value"`
+}
+
+type activityLogListResult struct {
+	Value    []activityLogEvent `json:"value"`
+	NextLink string             `json:"nextLink"`
+}
+
+// ListChangeEvent list resource change event from activity log of the subscription.
+// reference: https://learn.microsoft.com/en-us/rest/api/monitor/activity-logs/list
+func (az *Azure) ListChangeEvent(kt *kit.Kit, opt *changeevent.ListOption) ([]changeevent.ChangeEvent, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.activityLogClient()
+	if err != nil {
+		return nil, fmt.Errorf("new activity log client failed, err: %v", err)
+	}
+
+	filter := fmt.Sprintf("eventTimestamp ge '%s' and eventTimestamp le '%s' and eventChannels eq 'Operation'",
+		opt.StartTime.UTC().Format(time.RFC3339), opt.EndTime.UTC().Format(time.RFC3339))
+	query := url.Values{}
+	query.Set("api-version", "2015-04-01")
+	query.Set("$filter", filter)
+	query.Set("$select", "eventDataId,eventTimestamp,resourceGroupName,resourceId,operationName,resourceType,status,"+
+		"category")
+	link := runtime.JoinPaths(client.Endpoint(), "/subscriptions/", url.PathEscape(az.clientSet.credential.
+		CloudSubscriptionID), "/providers/Microsoft.Insights/eventtypes/management/values") + "?" + query.Encode()
+
+	events := make([]changeevent.ChangeEvent, 0)
+	for len(link) != 0 {
+		req, err := runtime.NewRequest(kt.Ctx, http.MethodGet, link)
+		if err != nil {
+			return nil, err
+		}
+		req.Raw().Header["Accept"] = []string{"application/json"}
+
+		resp, err := client.Pipeline().Do(req)
+		if err != nil {
+			logs.Errorf("list azure activity log failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+			return nil, err
+		}
+
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			err = runtime.NewResponseError(resp)
+			logs.Errorf("list azure activity log failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+			return nil, err
+		}
+
+		result := new(activityLogListResult)
+		if err = runtime.UnmarshalAsJSON(resp, result); err != nil {
+			return nil, err
+		}
+
+		for _, one := range result.Value {
+			// 只关注管理类别中成功的写操作
+			if one.Status.Value != "Succeeded" || (len(one.Category.Value) != 0 &&
+				!strings.EqualFold(one.Category.Value, "Administrative")) {
+				continue
+			}
+
+			resType, exist := activityLogResType[strings.ToLower(one.ResourceType.Value)]
+			if !exist || len(one.ResourceID) == 0 {
+				continue
+			}
+
+			events = append(events, changeevent.ChangeEvent{
+				EventID:           one.EventDataID,
+				EventName:         one.OperationName.Value,
+				EventTime:         one.EventTimestamp,
+				ResType:           resType,
+				CloudID:           strings.ToLower(one.ResourceID),
+				ResourceGroupName: strings.ToLower(one.ResourceGroupName),
+			})
+		}
+
+		link = result.NextLink
+	}
+
+	return events, nil
+}
@@ -26,12 +26,14 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
 	armcomputev4 "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armsubscriptions"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/subscription/armsubscription"
 )
 
@@ -113,6 +115,14 @@ func (c *clientSet) diskClient() (*armcompute.DisksClient, error) {
 	return armcompute.NewDisksClient(c.credential.CloudSubscriptionID, credential, nil)
 }
 
+func (c *clientSet) snapshotClient() (*armcompute.SnapshotsClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+	return armcompute.NewSnapshotsClient(c.credential.CloudSubscriptionID, credential, nil)
+}
+
 func (c *clientSet) imageClient() (*armcomputev4.VirtualMachineImagesClient, error) {
 	credential, err := c.newClientSecretCredential()
 	if err != nil {
@@ -184,6 +194,20 @@ func (c *clientSet) resourceGroupsClient() (*armresources.ResourceGroupsClient,
 	return client, nil
 }
 
+func (c *clientSet) tagsClient() (*armresources.TagsClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := armresources.NewTagsClient(c.credential.CloudSubscriptionID, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init tags client failed, err: %v", err)
+	}
+
+	return client, nil
+}
+
 func (c *clientSet) regionClient() (*armsubscriptions.Client, error) {
 	credential, err := c.newClientSecretCredential()
 	if err != nil {
@@ -285,3 +309,81 @@ func (c *clientSet) usageDetailClient(kt *kit.Kit) (*billClient, error) {
 func GenResourceName(namePrefix string, number int) string {
 	return fmt.Sprintf("%s-%04d", namePrefix, number)
 }
+
+func (c *clientSet) activityLogClient() (*arm.Client, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := arm.NewClient("armmonitor.ActivityLogsClient", "v0.9.0", credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init azure activity log client failed, err: %v", err)
+	}
+	return client, nil
+}
+
+func (c *clientSet) loadBalancerClient() (*armnetwork.LoadBalancersClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := armnetwork.NewLoadBalancersClient(c.credential.CloudSubscriptionID, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init azure load balancer client failed, err: %v", err)
+	}
+	return client, nil
+}
+
+func (c *clientSet) natGatewayClient() (*armnetwork.NatGatewaysClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := armnetwork.NewNatGatewaysClient(c.credential.CloudSubscriptionID, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init azure nat gateway client failed, err: %v", err)
+	}
+	return client, nil
+}
+
+func (c *clientSet) storageAccountClient() (*armstorage.AccountsClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := armstorage.NewAccountsClient(c.credential.CloudSubscriptionID, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init azure storage account client failed, err: %v", err)
+	}
+	return client, nil
+}
+
+func (c *clientSet) blobContainerClient() (*armstorage.BlobContainersClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := armstorage.NewBlobContainersClient(c.credential.CloudSubscriptionID, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init azure blob container client failed, err: %v", err)
+	}
+	return client, nil
+}
+
+func (c *clientSet) blobServiceClient() (*armstorage.BlobServicesClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := armstorage.NewBlobServicesClient(c.credential.CloudSubscriptionID, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init azure blob service client failed, err: %v", err)
+	}
+	return client, nil
+}
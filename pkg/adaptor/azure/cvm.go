@@ -527,6 +527,21 @@ func (az *Azure) CreateCvm(kt *kit.Kit, opt *typecvm.AzureCreateOption) (string,
 	if len(opt.Zones) != 0 {
 		instance.Zones = to.SliceOfPtrs(opt.Zones...)
 	}
+	// 使用密钥对登录时，注入管理员用户公钥并禁用密码登录
+	if len(opt.SSHPublicKey) != 0 {
+		instance.Properties.OSProfile.AdminPassword = nil
+		instance.Properties.OSProfile.LinuxConfiguration = &armcompute.LinuxConfiguration{
+			DisablePasswordAuthentication: to.Ptr(true),
+			SSH: &armcompute.SSHConfiguration{
+				PublicKeys: []*armcompute.SSHPublicKey{
+					{
+						KeyData: to.Ptr(opt.SSHPublicKey),
+						Path:    to.Ptr(fmt.Sprintf("/home/%s/.ssh/authorized_keys", opt.Username)),
+					},
+				},
+			},
+		}
+	}
 	poller, err := client.BeginCreateOrUpdate(kt.Ctx, opt.ResourceGroupName, opt.Name, instance, nil)
 	if err != nil {
 		logs.Errorf("begin create cvm failed, err: %v, rid: %s", err, kt.Rid)
@@ -0,0 +1,407 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+)
+
+// ListLoadBalancer list all load balancers in resource group, if cloud ids is set, only these are returned.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/list
+func (a *Azure) ListLoadBalancer(kt *kit.Kit, opt *core.AzureListOption) ([]typelb.AzureLoadBalancer, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.loadBalancerClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cloudIDMap := make(map[string]struct{}, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		cloudIDMap[strings.ToLower(id)] = struct{}{}
+	}
+
+	lbs := make([]typelb.AzureLoadBalancer, 0)
+	pager := client.NewListPager(opt.ResourceGroupName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure load balancer failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("list azure load balancer but get next page failed, err: %v", err)
+		}
+
+		for _, one := range page.Value {
+			if len(cloudIDMap) != 0 {
+				if _, exist := cloudIDMap[SPtrToLowerStr(one.ID)]; !exist {
+					continue
+				}
+			}
+
+			lb, err := a.convLoadBalancer(kt, one, opt.ResourceGroupName)
+			if err != nil {
+				return nil, err
+			}
+			lbs = append(lbs, lb)
+		}
+	}
+
+	return lbs, nil
+}
+
+func (a *Azure) convLoadBalancer(kt *kit.Kit, one *armnetwork.LoadBalancer, resGroupName string) (
+	typelb.AzureLoadBalancer, error) {
+
+	lb := typelb.AzureLoadBalancer{
+		CloudID:              SPtrToLowerStr(one.ID),
+		Name:                 SPtrToLowerStr(one.Name),
+		Region:               SPtrToLowerNoSpaceStr(one.Location),
+		Zones:                make([]string, 0),
+		LBType:               enumor.IntranetLoadBalancer,
+		PublicIPv4Addresses:  make([]string, 0),
+		PrivateIPv4Addresses: make([]string, 0),
+		Extension: &corelb.AzureLoadBalancerExtension{
+			ResourceGroupName:          strings.ToLower(resGroupName),
+			FrontendIPConfigurations:   make([]corelb.AzureFrontendIPConfiguration, 0),
+			CloudBackendAddressPoolIDs: make([]string, 0),
+		},
+	}
+
+	if one.SKU != nil {
+		lb.Extension.SkuName = (*string)(one.SKU.Name)
+		lb.Extension.SkuTier = (*string)(one.SKU.Tier)
+	}
+
+	if one.Properties == nil {
+		return lb, nil
+	}
+
+	if one.Properties.ProvisioningState != nil {
+		lb.Status = string(*one.Properties.ProvisioningState)
+	}
+
+	for _, pool := range one.Properties.BackendAddressPools {
+		lb.Extension.CloudBackendAddressPoolIDs = append(lb.Extension.CloudBackendAddressPoolIDs,
+			SPtrToLowerStr(pool.ID))
+	}
+
+	for _, frontend := range one.Properties.FrontendIPConfigurations {
+		lb.Zones = append(lb.Zones, converter.PtrToSlice(frontend.Zones)...)
+
+		config := corelb.AzureFrontendIPConfiguration{
+			CloudID: SPtrToLowerSPtr(frontend.ID),
+			Name:    frontend.Name,
+		}
+		if frontend.Properties == nil {
+			lb.Extension.FrontendIPConfigurations = append(lb.Extension.FrontendIPConfigurations, config)
+			continue
+		}
+
+		if frontend.Properties.PrivateIPAddress != nil {
+			config.PrivateIPAddress = frontend.Properties.PrivateIPAddress
+			lb.PrivateIPv4Addresses = append(lb.PrivateIPv4Addresses, *frontend.Properties.PrivateIPAddress)
+		}
+
+		if frontend.Properties.Subnet != nil && frontend.Properties.Subnet.ID != nil {
+			config.CloudSubnetID = SPtrToLowerSPtr(frontend.Properties.Subnet.ID)
+			// 子网ID格式为 {vpc id}/subnets/{subnet name}
+			lb.CloudVpcID = (*config.CloudSubnetID)[:strings.LastIndex(*config.CloudSubnetID, "/subnets/")]
+		}
+
+		if frontend.Properties.PublicIPAddress != nil && frontend.Properties.PublicIPAddress.ID != nil {
+			config.CloudPublicIPID = SPtrToLowerSPtr(frontend.Properties.PublicIPAddress.ID)
+			lb.LBType = enumor.InternetLoadBalancer
+
+			publicIP, err := a.getPublicIPAddress(kt, *config.CloudPublicIPID)
+			if err != nil {
+				return lb, err
+			}
+			if len(publicIP) != 0 {
+				lb.PublicIPv4Addresses = append(lb.PublicIPv4Addresses, publicIP)
+			}
+		}
+
+		lb.Extension.FrontendIPConfigurations = append(lb.Extension.FrontendIPConfigurations, config)
+	}
+	lb.Zones = slice.Unique(lb.Zones)
+
+	return lb, nil
+}
+
+// getPublicIPAddress 负载均衡前端只返回公网IP资源ID，需要查询公网IP资源获取IP地址
+func (a *Azure) getPublicIPAddress(kt *kit.Kit, cloudID string) (string, error) {
+	client, err := a.clientSet.publicIPAddressesClient()
+	if err != nil {
+		return "", err
+	}
+
+	resGroupName := parseResourceGroupName(cloudID)
+	resp, err := client.Get(kt.Ctx, resGroupName, parseIDToName(cloudID), nil)
+	if err != nil {
+		logs.Errorf("get azure public ip address failed, err: %v, id: %s, rid: %s", err, cloudID, kt.Rid)
+		return "", err
+	}
+
+	if resp.Properties == nil {
+		return "", nil
+	}
+
+	return converter.PtrToVal(resp.Properties.IPAddress), nil
+}
+
+// parseResourceGroupName parse resource group name from resource id.
+func parseResourceGroupName(id string) string {
+	fields := strings.Split(id, "/")
+	for idx := range fields {
+		if strings.EqualFold(fields[idx], "resourceGroups") && idx+1 < len(fields) {
+			return fields[idx+1]
+		}
+	}
+
+	return ""
+}
+
+// ListLoadBalancerListener list all load balancing rules of load balancer as listeners, targets are addresses of
+// the backend pool that rule forwards to.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/get
+func (a *Azure) ListLoadBalancerListener(kt *kit.Kit, opt *typelb.ListenerListOption) ([]typelb.AzureListener,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.ResourceGroupName) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "resource group name is required")
+	}
+
+	client, err := a.clientSet.loadBalancerClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Get(kt.Ctx, opt.ResourceGroupName, parseIDToName(opt.CloudLbID), nil)
+	if err != nil {
+		logs.Errorf("get azure load balancer failed, err: %v, lb: %s, rid: %s", err, opt.CloudLbID, kt.Rid)
+		return nil, err
+	}
+
+	if resp.Properties == nil {
+		return make([]typelb.AzureListener, 0), nil
+	}
+
+	poolMap := make(map[string]*armnetwork.BackendAddressPool, len(resp.Properties.BackendAddressPools))
+	for _, pool := range resp.Properties.BackendAddressPools {
+		poolMap[SPtrToLowerStr(pool.ID)] = pool
+	}
+
+	listeners := make([]typelb.AzureListener, 0, len(resp.Properties.LoadBalancingRules))
+	for _, rule := range resp.Properties.LoadBalancingRules {
+		listener := typelb.AzureListener{
+			CloudID:   SPtrToLowerStr(rule.ID),
+			Name:      converter.PtrToVal(rule.Name),
+			Extension: new(corelb.AzureListenerExtension),
+			Targets:   make([]*typelb.AzureTarget, 0),
+		}
+
+		prop := rule.Properties
+		if prop == nil {
+			listeners = append(listeners, listener)
+			continue
+		}
+
+		listener.Port = int64(converter.PtrToVal(prop.FrontendPort))
+		if prop.Protocol != nil {
+			listener.Protocol = string(*prop.Protocol)
+		}
+		listener.Extension.IdleTimeoutInMinutes = prop.IdleTimeoutInMinutes
+		listener.Extension.EnableFloatingIP = prop.EnableFloatingIP
+		listener.Extension.LoadDistribution = (*string)(prop.LoadDistribution)
+		if prop.FrontendIPConfiguration != nil {
+			listener.Extension.CloudFrontendIPConfigurationID = SPtrToLowerSPtr(prop.FrontendIPConfiguration.ID)
+		}
+		if prop.Probe != nil {
+			listener.Extension.CloudProbeID = SPtrToLowerSPtr(prop.Probe.ID)
+		}
+
+		if prop.BackendAddressPool != nil && prop.BackendAddressPool.ID != nil {
+			poolID := SPtrToLowerStr(prop.BackendAddressPool.ID)
+			listener.Extension.CloudBackendAddressPoolID = converter.ValToPtr(poolID)
+			listener.Targets = convAzureBackendPoolTarget(poolMap[poolID], int64(converter.PtrToVal(prop.BackendPort)))
+		}
+
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
+func convAzureBackendPoolTarget(pool *armnetwork.BackendAddressPool, port int64) []*typelb.AzureTarget {
+	targets := make([]*typelb.AzureTarget, 0)
+	if pool == nil || pool.Properties == nil {
+		return targets
+	}
+
+	poolID := SPtrToLowerSPtr(pool.ID)
+
+	// 基于网卡的后端池，后端为网卡IP配置，IP配置ID格式为 {network interface id}/ipConfigurations/{name}
+	for _, ipConfig := range pool.Properties.BackendIPConfigurations {
+		ipConfigID := SPtrToLowerStr(ipConfig.ID)
+		idx := strings.LastIndex(ipConfigID, "/ipconfigurations/")
+		if idx < 0 {
+			continue
+		}
+
+		target := &typelb.AzureTarget{
+			TargetType:    enumor.NetworkInterfaceLBTargetType,
+			CloudTargetID: ipConfigID[:idx],
+			Port:          port,
+			Extension: &corelb.AzureTargetExtension{
+				CloudBackendAddressPoolID: poolID,
+				CloudIPConfigurationID:    converter.ValToPtr(ipConfigID),
+			},
+		}
+		if ipConfig.Properties != nil {
+			target.IP = converter.PtrToVal(ipConfig.Properties.PrivateIPAddress)
+		}
+
+		targets = append(targets, target)
+	}
+
+	// 基于IP地址的后端池，后端为虚拟网络中的IP
+	for _, address := range pool.Properties.LoadBalancerBackendAddresses {
+		if address.Properties == nil || address.Properties.IPAddress == nil ||
+			address.Properties.NetworkInterfaceIPConfiguration != nil {
+			continue
+		}
+
+		targets = append(targets, &typelb.AzureTarget{
+			TargetType:    enumor.IPLBTargetType,
+			CloudTargetID: *address.Properties.IPAddress,
+			IP:            *address.Properties.IPAddress,
+			Port:          port,
+			Extension:     &corelb.AzureTargetExtension{CloudBackendAddressPoolID: poolID},
+		})
+	}
+
+	return targets
+}
+
+// CreateLoadBalancer create load balancer with one frontend ip configuration, return created load balancer id.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/create-or-update
+func (a *Azure) CreateLoadBalancer(kt *kit.Kit, opt *typelb.AzureCreateOption) (*string, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.loadBalancerClient()
+	if err != nil {
+		return nil, err
+	}
+
+	frontendProp := new(armnetwork.FrontendIPConfigurationPropertiesFormat)
+	if opt.CloudPublicIPID != nil {
+		frontendProp.PublicIPAddress = &armnetwork.PublicIPAddress{ID: opt.CloudPublicIPID}
+	} else {
+		frontendProp.Subnet = &armnetwork.Subnet{ID: opt.CloudSubnetID}
+	}
+
+	params := armnetwork.LoadBalancer{
+		Location: converter.ValToPtr(opt.Region),
+		Properties: &armnetwork.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{
+				{Name: converter.ValToPtr(opt.Name + "-frontend"), Properties: frontendProp},
+			},
+		},
+	}
+	if opt.SkuName != nil {
+		params.SKU = &armnetwork.LoadBalancerSKU{Name: (*armnetwork.LoadBalancerSKUName)(opt.SkuName)}
+	}
+
+	poller, err := client.BeginCreateOrUpdate(kt.Ctx, opt.ResourceGroupName, opt.Name, params, nil)
+	if err != nil {
+		logs.Errorf("create azure load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	resp, err := poller.PollUntilDone(kt.Ctx, new(runtime.PollUntilDoneOptions))
+	if err != nil {
+		logs.Errorf("poll azure load balancer create result failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return SPtrToLowerSPtr(resp.ID), nil
+}
+
+// DeleteLoadBalancer delete load balancer, resource id is load balancer name.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/delete
+func (a *Azure) DeleteLoadBalancer(kt *kit.Kit, opt *typelb.AzureDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.loadBalancerClient()
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginDelete(kt.Ctx, opt.ResourceGroupName, opt.ResourceID, nil)
+	if err != nil {
+		logs.Errorf("delete azure load balancer failed, err: %v, name: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	if _, err = poller.PollUntilDone(kt.Ctx, new(runtime.PollUntilDoneOptions)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+)
+
+// ListNatGateway list all nat gateways in resource group, if cloud ids is set, only these are returned.
+// reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/nat-gateways/list
+func (a *Azure) ListNatGateway(kt *kit.Kit, opt *core.AzureListOption) ([]typenat.AzureNatGateway, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.natGatewayClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cloudIDMap := make(map[string]struct{}, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		cloudIDMap[strings.ToLower(id)] = struct{}{}
+	}
+
+	nats := make([]typenat.AzureNatGateway, 0)
+	pager := client.NewListPager(opt.ResourceGroupName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("list azure nat gateway but get next page failed, err: %v", err)
+		}
+
+		for _, one := range page.Value {
+			if len(cloudIDMap) != 0 {
+				if _, exist := cloudIDMap[SPtrToLowerStr(one.ID)]; !exist {
+					continue
+				}
+			}
+
+			nat, err := a.convNatGateway(kt, one, opt.ResourceGroupName)
+			if err != nil {
+				return nil, err
+			}
+			nats = append(nats, nat)
+		}
+	}
+
+	return nats, nil
+}
+
+func (a *Azure) convNatGateway(kt *kit.Kit, one *armnetwork.NatGateway, resGroupName string) (
+	typenat.AzureNatGateway, error) {
+
+	nat := typenat.AzureNatGateway{
+		CloudID:           SPtrToLowerStr(one.ID),
+		Name:              SPtrToLowerStr(one.Name),
+		Region:            SPtrToLowerNoSpaceStr(one.Location),
+		NatType:           enumor.PublicNatGateway,
+		PublicIPAddresses: make([]string, 0),
+		CloudEipIDs:       make([]string, 0),
+		Extension: &corenat.AzureNatGatewayExtension{
+			ResourceGroupName:      strings.ToLower(resGroupName),
+			CloudSubnetIDs:         make([]string, 0),
+			CloudPublicIPPrefixIDs: make([]string, 0),
+		},
+	}
+
+	if zones := converter.PtrToSlice(one.Zones); len(zones) != 0 {
+		nat.Zone = zones[0]
+	}
+
+	if one.SKU != nil {
+		nat.Extension.SkuName = (*string)(one.SKU.Name)
+	}
+
+	if one.Properties == nil {
+		return nat, nil
+	}
+
+	if one.Properties.ProvisioningState != nil {
+		nat.Status = string(*one.Properties.ProvisioningState)
+	}
+	nat.Extension.IdleTimeoutInMinutes = one.Properties.IdleTimeoutInMinutes
+
+	// NAT网关可以关联同一虚拟网络下的多个子网，主表记录第一个子网，全部子网记录在扩展字段中
+	for _, subnet := range one.Properties.Subnets {
+		subnetID := SPtrToLowerStr(subnet.ID)
+		if len(subnetID) == 0 {
+			continue
+		}
+
+		nat.Extension.CloudSubnetIDs = append(nat.Extension.CloudSubnetIDs, subnetID)
+		if len(nat.CloudSubnetID) == 0 {
+			nat.CloudSubnetID = subnetID
+			// 子网ID格式为 {vpc id}/subnets/{subnet name}
+			nat.CloudVpcID = subnetID[:strings.LastIndex(subnetID, "/subnets/")]
+		}
+	}
+
+	for _, prefix := range one.Properties.PublicIPPrefixes {
+		nat.Extension.CloudPublicIPPrefixIDs = append(nat.Extension.CloudPublicIPPrefixIDs,
+			SPtrToLowerStr(prefix.ID))
+	}
+
+	for _, publicIP := range one.Properties.PublicIPAddresses {
+		if publicIP.ID == nil {
+			continue
+		}
+
+		cloudID := SPtrToLowerStr(publicIP.ID)
+		nat.CloudEipIDs = append(nat.CloudEipIDs, cloudID)
+
+		address, err := a.getPublicIPAddress(kt, cloudID)
+		if err != nil {
+			return nat, err
+		}
+		if len(address) != 0 {
+			nat.PublicIPAddresses = append(nat.PublicIPAddresses, address)
+		}
+	}
+
+	return nat, nil
+}
+
+// CreateNatGateway create nat gateway with standard sku, return created nat gateway id.
+// reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/nat-gateways/create-or-update
+func (a *Azure) CreateNatGateway(kt *kit.Kit, opt *typenat.AzureCreateOption) (*string, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.natGatewayClient()
+	if err != nil {
+		return nil, err
+	}
+
+	publicIPs := make([]*armnetwork.SubResource, 0, len(opt.CloudPublicIPIDs))
+	for _, id := range opt.CloudPublicIPIDs {
+		publicIPs = append(publicIPs, &armnetwork.SubResource{ID: converter.ValToPtr(id)})
+	}
+
+	params := armnetwork.NatGateway{
+		Location: converter.ValToPtr(opt.Region),
+		SKU:      &armnetwork.NatGatewaySKU{Name: converter.ValToPtr(armnetwork.NatGatewaySKUNameStandard)},
+		Properties: &armnetwork.NatGatewayPropertiesFormat{
+			IdleTimeoutInMinutes: opt.IdleTimeoutInMinutes,
+			PublicIPAddresses:    publicIPs,
+		},
+	}
+	if len(opt.Zones) != 0 {
+		params.Zones = converter.SliceToPtr(opt.Zones)
+	}
+
+	poller, err := client.BeginCreateOrUpdate(kt.Ctx, opt.ResourceGroupName, opt.Name, params, nil)
+	if err != nil {
+		logs.Errorf("create azure nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	resp, err := poller.PollUntilDone(kt.Ctx, new(runtime.PollUntilDoneOptions))
+	if err != nil {
+		logs.Errorf("poll azure nat gateway create result failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return SPtrToLowerSPtr(resp.ID), nil
+}
+
+// DeleteNatGateway delete nat gateway, resource id is nat gateway name.
+// reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/nat-gateways/delete
+func (a *Azure) DeleteNatGateway(kt *kit.Kit, opt *typenat.AzureDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.natGatewayClient()
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginDelete(kt.Ctx, opt.ResourceGroupName, opt.ResourceID, nil)
+	if err != nil {
+		logs.Errorf("delete azure nat gateway failed, err: %v, name: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	if _, err = poller.PollUntilDone(kt.Ctx, new(runtime.PollUntilDoneOptions)); err != nil {
+		logs.Errorf("poll azure nat gateway delete result failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
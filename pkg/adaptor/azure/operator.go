@@ -0,0 +1,303 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"hcm/pkg/adaptor/operator"
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/adaptor/types/disk"
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+// defaultDiskCachingType azure disk default caching type when attaching by operator without caching type.
+const defaultDiskCachingType = "ReadWrite"
+
+// azureTagResTypes resource types which support tag of azure, azure subnet is a child resource of vpc which
+// does not support tag.
+var azureTagResTypes = []enumor.CloudResourceType{
+	enumor.CvmCloudResType,
+	enumor.DiskCloudResType,
+	enumor.VpcCloudResType,
+	enumor.EipCloudResType,
+	enumor.SecurityGroupCloudResType,
+}
+
+func init() {
+	operator.Register(enumor.Azure, func(cred *operator.Credential) (operator.Operator, error) {
+		if cred.Azure == nil {
+			return nil, errf.New(errf.InvalidParameter, "azure credential is required")
+		}
+
+		cli, err := NewAzure(cred.Azure)
+		if err != nil {
+			return nil, err
+		}
+
+		return &azureOperator{cli: cli}, nil
+	})
+}
+
+// azureOperator implements operator.Operator by azure adaptor, azure operates cvm and disk one by one by resource
+// group and name.
+type azureOperator struct {
+	cli *Azure
+}
+
+// Vendor ...
+func (op *azureOperator) Vendor() enumor.Vendor {
+	return enumor.Azure
+}
+
+// ListCvm ...
+func (op *azureOperator) ListCvm(kt *kit.Kit, opt *operator.CvmListOption) ([]operator.Cvm, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvms, err := op.cli.ListCvmByID(kt, &core.AzureListByIDOption{
+		ResourceGroupName: opt.ResourceGroupName,
+		CloudIDs:          opt.CloudIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]operator.Cvm, 0, len(cvms))
+	for _, one := range cvms {
+		cvm := operator.Cvm{
+			CloudID: converter.PtrToVal(one.ID),
+			Name:    converter.PtrToVal(one.Name),
+			Status:  converter.PtrToVal(one.Status),
+		}
+		if len(one.Zones) != 0 {
+			cvm.Zone = converter.PtrToVal(one.Zones[0])
+		}
+		if one.VMSize != nil {
+			cvm.InstanceType = string(*one.VMSize)
+		}
+		result = append(result, cvm)
+	}
+
+	return result, nil
+}
+
+// StartCvm ...
+func (op *azureOperator) StartCvm(kt *kit.Kit, opt *operator.CvmOperateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "start cvm option is required")
+	}
+
+	return eachCvm(opt, func(resGroup, name string) error {
+		return op.cli.StartCvm(kt, &typecvm.AzureStartOption{ResourceGroupName: resGroup, Name: name})
+	})
+}
+
+// StopCvm azure force stop skips the graceful shutdown.
+func (op *azureOperator) StopCvm(kt *kit.Kit, opt *operator.CvmStopOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "stop cvm option is required")
+	}
+
+	return eachCvm(&opt.CvmOperateOption, func(resGroup, name string) error {
+		return op.cli.StopCvm(kt, &typecvm.AzureStopOption{ResourceGroupName: resGroup, Name: name,
+			SkipShutdown: opt.Force})
+	})
+}
+
+// RebootCvm azure reboot cvm does not support force reboot, force is ignored.
+func (op *azureOperator) RebootCvm(kt *kit.Kit, opt *operator.CvmStopOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "reboot cvm option is required")
+	}
+
+	return eachCvm(&opt.CvmOperateOption, func(resGroup, name string) error {
+		return op.cli.RebootCvm(kt, &typecvm.AzureRebootOption{ResourceGroupName: resGroup, Name: name})
+	})
+}
+
+// DeleteCvm ...
+func (op *azureOperator) DeleteCvm(kt *kit.Kit, opt *operator.CvmDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete cvm option is required")
+	}
+
+	return eachCvm(&opt.CvmOperateOption, func(resGroup, name string) error {
+		return op.cli.DeleteCvm(kt, &typecvm.AzureDeleteOption{ResourceGroupName: resGroup, Name: name,
+			Force: opt.Force})
+	})
+}
+
+// AttachDisk ...
+func (op *azureOperator) AttachDisk(kt *kit.Kit, opt *operator.DiskAttachOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "attach disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cachingType := opt.CachingType
+	if len(cachingType) == 0 {
+		cachingType = defaultDiskCachingType
+	}
+
+	return op.cli.AttachDisk(kt, &disk.AzureDiskAttachOption{
+		ResourceGroupName: opt.Disk.ResourceGroupName,
+		CvmName:           opt.Cvm.Name,
+		DiskName:          opt.Disk.Name,
+		CachingType:       cachingType,
+	})
+}
+
+// DetachDisk ...
+func (op *azureOperator) DetachDisk(kt *kit.Kit, opt *operator.DiskAttachOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "detach disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.DetachDisk(kt, &disk.AzureDiskDetachOption{
+		ResourceGroupName: opt.Disk.ResourceGroupName,
+		CvmName:           opt.Cvm.Name,
+		DiskName:          opt.Disk.Name,
+	})
+}
+
+// DeleteDisk ...
+func (op *azureOperator) DeleteDisk(kt *kit.Kit, opt *operator.DiskDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.DeleteDisk(kt, &disk.AzureDiskDeleteOption{
+		ResourceGroupName: opt.Disk.ResourceGroupName,
+		DiskName:          opt.Disk.Name,
+	})
+}
+
+// DeleteSecurityGroup ...
+func (op *azureOperator) DeleteSecurityGroup(kt *kit.Kit, opt *operator.SecurityGroupDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete security group option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.DeleteSecurityGroup(kt, &securitygroup.AzureOption{
+		ResourceGroupName: opt.SecurityGroup.ResourceGroupName,
+		Region:            opt.SecurityGroup.Region,
+		Name:              opt.SecurityGroup.Name,
+	})
+}
+
+// AssociateCvm azure security group is associated with network interface or subnet instead of cvm.
+func (op *azureOperator) AssociateCvm(_ *kit.Kit, _ *operator.SecurityGroupCvmOption) error {
+	return operator.NotSupportError(enumor.Azure, "security group associate cvm")
+}
+
+// DisassociateCvm azure security group is associated with network interface or subnet instead of cvm.
+func (op *azureOperator) DisassociateCvm(_ *kit.Kit, _ *operator.SecurityGroupCvmOption) error {
+	return operator.NotSupportError(enumor.Azure, "security group disassociate cvm")
+}
+
+// TagResTypes ...
+func (op *azureOperator) TagResTypes() []enumor.CloudResourceType {
+	return azureTagResTypes
+}
+
+// ListTag azure resource is located by cloud id, region is not required.
+func (op *azureOperator) ListTag(kt *kit.Kit, opt *operator.TagListOption) ([]typetag.ResourceTags, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list tag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.ListResourceTag(kt, &typetag.ListOption{ResType: opt.ResType, CloudIDs: opt.CloudIDs()})
+}
+
+// TagResource ...
+func (op *azureOperator) TagResource(kt *kit.Kit, opt *operator.TagResourceOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.TagResource(kt, &typetag.TagOption{ResType: opt.ResType, CloudIDs: opt.CloudIDs(),
+		Tags: opt.Tags})
+}
+
+// UntagResource ...
+func (op *azureOperator) UntagResource(kt *kit.Kit, opt *operator.UntagResourceOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.UntagResource(kt, &typetag.UntagOption{ResType: opt.ResType, CloudIDs: opt.CloudIDs(),
+		Keys: opt.Keys})
+}
+
+// eachCvm validate option and operate cvm one by one, azure cvm is located by resource group and name.
+func eachCvm(opt *operator.CvmOperateOption, handle func(resGroup, name string) error) error {
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	for _, one := range opt.Cvms {
+		if len(one.ResourceGroupName) == 0 || len(one.Name) == 0 {
+			return errf.Newf(errf.InvalidParameter, "azure cvm: %s resource group and name are required", one.CloudID)
+		}
+
+		if err := handle(one.ResourceGroupName, one.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
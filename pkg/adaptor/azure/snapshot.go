@@ -0,0 +1,190 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+)
+
+// ListSnapshot list all snapshots in resource group, if cloud ids is set, only these are returned.
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/list-by-resource-group
+func (a *Azure) ListSnapshot(kt *kit.Kit, opt *core.AzureListOption) ([]typesnap.AzureSnapshot, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.snapshotClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cloudIDMap := make(map[string]struct{}, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		cloudIDMap[strings.ToLower(id)] = struct{}{}
+	}
+
+	snapshots := make([]typesnap.AzureSnapshot, 0)
+	pager := client.NewListByResourceGroupPager(opt.ResourceGroupName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure snapshot failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("list azure snapshot but get next page failed, err: %v", err)
+		}
+
+		for _, one := range page.Value {
+			if len(cloudIDMap) != 0 {
+				if _, exist := cloudIDMap[SPtrToLowerStr(one.ID)]; !exist {
+					continue
+				}
+			}
+
+			snapshots = append(snapshots, convAzureSnapshot(one, opt.ResourceGroupName))
+		}
+	}
+
+	return snapshots, nil
+}
+
+func convAzureSnapshot(one *armcompute.Snapshot, resGroupName string) typesnap.AzureSnapshot {
+	snapshot := typesnap.AzureSnapshot{
+		CloudID: SPtrToLowerStr(one.ID),
+		Name:    SPtrToLowerStr(one.Name),
+		Region:  SPtrToLowerNoSpaceStr(one.Location),
+		Extension: &coresnap.AzureSnapshotExtension{
+			ResourceGroupName: strings.ToLower(resGroupName),
+		},
+	}
+
+	if one.SKU != nil {
+		snapshot.Extension.SkuName = (*string)(one.SKU.Name)
+	}
+
+	if one.Properties == nil {
+		return snapshot
+	}
+
+	snapshot.Status = converter.PtrToVal(one.Properties.ProvisioningState)
+	snapshot.DiskSize = uint64(converter.PtrToVal(one.Properties.DiskSizeGB))
+	// azure 托管磁盘快照默认使用平台托管密钥进行静态加密
+	snapshot.Encrypted = converter.ValToPtr(one.Properties.Encryption != nil)
+	snapshot.Extension.Incremental = one.Properties.Incremental
+	snapshot.Extension.OsType = (*string)(one.Properties.OSType)
+	snapshot.Extension.DiskState = (*string)(one.Properties.DiskState)
+
+	if one.Properties.CreationData != nil {
+		snapshot.CloudDiskID = SPtrToLowerStr(one.Properties.CreationData.SourceResourceID)
+	}
+
+	if one.Properties.TimeCreated != nil {
+		snapshot.CloudCreatedTime = times.ConvStdTimeFormat(*one.Properties.TimeCreated)
+	}
+
+	return snapshot
+}
+
+// CreateSnapshot create snapshot from managed disk, return cloud id of created snapshot.
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/create-or-update
+func (a *Azure) CreateSnapshot(kt *kit.Kit, opt *typesnap.AzureCreateOption) (*string, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.snapshotClient()
+	if err != nil {
+		return nil, err
+	}
+
+	params := armcompute.Snapshot{
+		Location: converter.ValToPtr(opt.Region),
+		Properties: &armcompute.SnapshotProperties{
+			CreationData: &armcompute.CreationData{
+				CreateOption:     converter.ValToPtr(armcompute.DiskCreateOptionCopy),
+				SourceResourceID: converter.ValToPtr(opt.CloudDiskID),
+			},
+			Incremental: opt.Incremental,
+		},
+	}
+
+	poller, err := client.BeginCreateOrUpdate(kt.Ctx, opt.ResourceGroupName, opt.Name, params, nil)
+	if err != nil {
+		logs.Errorf("create azure snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	resp, err := poller.PollUntilDone(kt.Ctx, new(runtime.PollUntilDoneOptions))
+	if err != nil {
+		logs.Errorf("poll azure snapshot create result failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return SPtrToLowerSPtr(resp.ID), nil
+}
+
+// DeleteSnapshot delete snapshot, resource id is snapshot name.
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/delete
+func (a *Azure) DeleteSnapshot(kt *kit.Kit, opt *typesnap.AzureDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.snapshotClient()
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginDelete(kt.Ctx, opt.ResourceGroupName, opt.ResourceID, nil)
+	if err != nil {
+		logs.Errorf("delete azure snapshot failed, err: %v, name: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	if _, err = poller.PollUntilDone(kt.Ctx, new(runtime.PollUntilDoneOptions)); err != nil {
+		logs.Errorf("poll azure snapshot delete result failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
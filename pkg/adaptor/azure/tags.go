@@ -0,0 +1,179 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+)
+
+// ListResourceTag list tags of resources, the cloud id of azure resource is the scope of tags. subnet is a child
+// resource of vpc in azure, which does not support tag.
+// reference: https://learn.microsoft.com/en-us/rest/api/resources/tags/get-at-scope
+func (az *Azure) ListResourceTag(kt *kit.Kit, opt *typetag.ListOption) ([]typetag.ResourceTags, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.tagsClient(opt.ResType)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]typetag.ResourceTags, 0, len(opt.CloudIDs))
+	for _, cloudID := range opt.CloudIDs {
+		resp, err := client.GetAtScope(kt.Ctx, cloudID, nil)
+		if err != nil {
+			logs.Errorf("get azure tags at scope failed, err: %v, scope: %s, rid: %s", err, cloudID, kt.Rid)
+			return nil, err
+		}
+
+		details = append(details, typetag.ResourceTags{CloudID: cloudID, Tags: convertAzureTags(resp.Properties)})
+	}
+
+	return details, nil
+}
+
+// TagResource add or overwrite tags of resources by merge operation.
+// reference: https://learn.microsoft.com/en-us/rest/api/resources/tags/update-at-scope
+func (az *Azure) TagResource(kt *kit.Kit, opt *typetag.TagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.tagsClient(opt.ResType)
+	if err != nil {
+		return err
+	}
+
+	tags := make(map[string]*string, len(opt.Tags))
+	for _, one := range opt.Tags {
+		tags[one.Key] = to.Ptr(one.Value)
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		patch := armresources.TagsPatchResource{
+			Operation:  to.Ptr(armresources.TagsPatchOperationMerge),
+			Properties: &armresources.Tags{Tags: tags},
+		}
+		if _, err = client.UpdateAtScope(kt.Ctx, cloudID, patch, nil); err != nil {
+			logs.Errorf("merge azure tags at scope failed, err: %v, scope: %s, rid: %s", err, cloudID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UntagResource remove tags of resources by tag keys. azure delete operation only removes the tags whose key and
+// value both match, so current tags are queried first.
+// reference: https://learn.microsoft.com/en-us/rest/api/resources/tags/update-at-scope
+func (az *Azure) UntagResource(kt *kit.Kit, opt *typetag.UntagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.tagsClient(opt.ResType)
+	if err != nil {
+		return err
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		resp, err := client.GetAtScope(kt.Ctx, cloudID, nil)
+		if err != nil {
+			logs.Errorf("get azure tags at scope failed, err: %v, scope: %s, rid: %s", err, cloudID, kt.Rid)
+			return err
+		}
+
+		current := make(map[string]*string)
+		if resp.Properties != nil && resp.Properties.Tags != nil {
+			current = resp.Properties.Tags
+		}
+
+		tags := make(map[string]*string)
+		for _, key := range opt.Keys {
+			if value, exists := current[key]; exists {
+				tags[key] = value
+			}
+		}
+
+		if len(tags) == 0 {
+			continue
+		}
+
+		patch := armresources.TagsPatchResource{
+			Operation:  to.Ptr(armresources.TagsPatchOperationDelete),
+			Properties: &armresources.Tags{Tags: tags},
+		}
+		if _, err = client.UpdateAtScope(kt.Ctx, cloudID, patch, nil); err != nil {
+			logs.Errorf("delete azure tags at scope failed, err: %v, scope: %s, rid: %s", err, cloudID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (az *Azure) tagsClient(resType enumor.CloudResourceType) (*armresources.TagsClient, error) {
+	if resType == enumor.SubnetCloudResType {
+		return nil, errf.Newf(errf.InvalidParameter, "azure resource type: %s not support tag", resType)
+	}
+
+	client, err := az.clientSet.tagsClient()
+	if err != nil {
+		return nil, fmt.Errorf("new tags client failed, err: %v", err)
+	}
+
+	return client, nil
+}
+
+func convertAzureTags(properties *armresources.Tags) []typetag.Tag {
+	if properties == nil {
+		return nil
+	}
+
+	tags := make([]typetag.Tag, 0, len(properties.Tags))
+	for key, value := range properties.Tags {
+		tags = append(tags, typetag.Tag{Key: key, Value: converter.PtrToVal(value)})
+	}
+
+	return tags
+}
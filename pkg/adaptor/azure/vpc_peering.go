@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	typevpcpeering "hcm/pkg/adaptor/types/vpc-peering"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+)
+
+// ListVpcPeering list all virtual network peerings of subscription. peerings are sub resources of virtual
+// network, so they are listed from all virtual networks, and each side of peering is a separate resource.
+// reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/virtual-networks/list-all
+func (a *Azure) ListVpcPeering(kt *kit.Kit) ([]typevpcpeering.VpcPeering, error) {
+	vpcClient, err := a.clientSet.vpcClient()
+	if err != nil {
+		return nil, fmt.Errorf("new vpc client failed, err: %v", err)
+	}
+
+	peerings := make([]typevpcpeering.VpcPeering, 0)
+	pager := vpcClient.NewListAllPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure vpc for peering failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("list azure vpc but get next page failed, err: %v", err)
+		}
+
+		for _, one := range page.Value {
+			if one == nil || one.Properties == nil {
+				continue
+			}
+
+			for _, peering := range one.Properties.VirtualNetworkPeerings {
+				if peering == nil || peering.Properties == nil {
+					continue
+				}
+				peerings = append(peerings, convAzureVpcPeering(one, peering))
+			}
+		}
+	}
+
+	return peerings, nil
+}
+
+func convAzureVpcPeering(vpc *armnetwork.VirtualNetwork,
+	one *armnetwork.VirtualNetworkPeering) typevpcpeering.VpcPeering {
+
+	peering := typevpcpeering.VpcPeering{
+		CloudID:         SPtrToLowerStr(one.ID),
+		Name:            SPtrToLowerStr(one.Name),
+		Region:          SPtrToLowerNoSpaceStr(vpc.Location),
+		Type:            enumor.PeeringConnection,
+		CloudGatewayID:  SPtrToLowerStr(one.ID),
+		LocalCloudVpcID: SPtrToLowerStr(vpc.ID),
+		LocalCidrs:      make([]string, 0),
+		PeerCidrs:       make([]string, 0),
+	}
+
+	if vpc.Properties.AddressSpace != nil {
+		peering.LocalCidrs = converter.PtrToSlice(vpc.Properties.AddressSpace.AddressPrefixes)
+	}
+
+	prop := one.Properties
+	peering.CloudStatus = string(converter.PtrToVal(prop.PeeringState))
+	switch converter.PtrToVal(prop.PeeringState) {
+	case armnetwork.VirtualNetworkPeeringStateConnected:
+		// 未允许访问远端虚拟网络时，对等连接虽已建立但流量不可达
+		if converter.PtrToVal(prop.AllowVirtualNetworkAccess) {
+			peering.Status = enumor.VpcPeeringActive
+		} else {
+			peering.Status = enumor.VpcPeeringInactive
+		}
+	case armnetwork.VirtualNetworkPeeringStateInitiated:
+		peering.Status = enumor.VpcPeeringPending
+	default:
+		peering.Status = enumor.VpcPeeringInactive
+	}
+
+	if prop.RemoteVirtualNetwork != nil {
+		peering.PeerCloudVpcID = SPtrToLowerStr(prop.RemoteVirtualNetwork.ID)
+		peering.PeerCloudAccountID = parseAzureSubscriptionID(peering.PeerCloudVpcID)
+	}
+
+	if prop.RemoteAddressSpace != nil {
+		peering.PeerCidrs = converter.PtrToSlice(prop.RemoteAddressSpace.AddressPrefixes)
+	}
+
+	return peering
+}
+
+// parseAzureSubscriptionID parse subscription id from resource id like
+// /subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/...
+func parseAzureSubscriptionID(resID string) string {
+	parts := strings.Split(strings.TrimPrefix(resID, "/"), "/")
+	if len(parts) < 2 || parts[0] != "subscriptions" {
+		return ""
+	}
+
+	return parts[1]
+}
@@ -50,6 +50,12 @@ const (
 	QueryBillSQL = "SELECT %s FROM %s.%s %s"
 	// QueryBillTotalSQL 查询云账单总数量的SQL
 	QueryBillTotalSQL = "SELECT COUNT(*) FROM %s.%s %s"
+	// QueryBillItemSummarySQL 按云产品、云资源汇总云账单费用的SQL
+	QueryBillItemSummarySQL = "SELECT service.id as service_id,service.description as service_description," +
+		"resource.global_name as resource_global_name,resource.name as resource_name,location.region as region," +
+		"currency,SUM(cost)+SUM(IFNULL((SELECT SUM(c.amount) FROM UNNEST(credits) c), 0)) AS total_cost " +
+		"FROM %s.%s %s GROUP BY service_id,service_description,resource_global_name,resource_name,region,currency " +
+		"ORDER BY service_id,resource_global_name"
 )
 
 // GetBillList demonstrates issuing a query and reading results.
@@ -83,6 +89,28 @@ func (g *Gcp) GetBillList(kt *kit.Kit, opt *typesBill.GcpBillListOption,
 	return list, total, err
 }
 
+// GetBillItemSummary get bill cost summary group by service and resource, used to store bill items.
+func (g *Gcp) GetBillItemSummary(kt *kit.Kit, opt *typesBill.GcpBillListOption,
+	billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) ([]map[string]bigquery.Value, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	where, err := g.parseCondition(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(QueryBillItemSummarySQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, where)
+	if opt.Page != nil {
+		query += fmt.Sprintf(" LIMIT %d OFFSET %d", opt.Page.Limit, opt.Page.Offset)
+	}
+
+	list, _, err := g.GetBigQuery(kt, query)
+	return list, err
+}
+
 // GetBillTotal get bill total num
 func (g *Gcp) GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (
 	int64, error) {
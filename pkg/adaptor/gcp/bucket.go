@@ -0,0 +1,184 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"strings"
+	"time"
+
+	typebucket "hcm/pkg/adaptor/types/bucket"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/times"
+
+	"google.golang.org/api/storage/v1"
+)
+
+const (
+	// gcpPublicAccessPreventionEnforced bucket is prevented from being publicly accessed.
+	gcpPublicAccessPreventionEnforced = "enforced"
+	gcpAllUsers                       = "allUsers"
+	gcpAllAuthenticatedUsers          = "allAuthenticatedUsers"
+	gcpGoogleManagedEncryption        = "google-managed"
+	gcpCustomerManagedEncryption      = "CMEK"
+)
+
+// ListStorageBucket list all cloud storage buckets of project, gcp encrypts all data at rest, so the bucket
+// is always encrypted, and encryption type is CMEK when default kms key is set.
+// reference: https://cloud.google.com/storage/docs/json_api/v1/buckets/list
+func (g *Gcp) ListStorageBucket(kt *kit.Kit) ([]typebucket.Bucket, error) {
+	client, err := g.clientSet.storageClient(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]typebucket.Bucket, 0)
+	err = client.Buckets.List(g.CloudProjectID()).Projection("full").Pages(kt.Ctx,
+		func(resp *storage.Buckets) error {
+			for _, one := range resp.Items {
+				bucket, err := g.convBucket(kt, client, one)
+				if err != nil {
+					return err
+				}
+				buckets = append(buckets, *bucket)
+			}
+			return nil
+		})
+	if err != nil {
+		logs.Errorf("list gcp bucket failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+func (g *Gcp) convBucket(kt *kit.Kit, client *storage.Service, one *storage.Bucket) (*typebucket.Bucket, error) {
+	bucket := &typebucket.Bucket{
+		CloudID:        one.Id,
+		Name:           one.Name,
+		Region:         strings.ToLower(one.Location),
+		Encrypted:      true,
+		EncryptionType: gcpGoogleManagedEncryption,
+	}
+
+	if one.Encryption != nil && len(one.Encryption.DefaultKmsKeyName) != 0 {
+		bucket.EncryptionType = gcpCustomerManagedEncryption
+	}
+
+	if one.Versioning != nil {
+		bucket.VersioningEnabled = one.Versioning.Enabled
+	}
+
+	if len(one.TimeCreated) != 0 {
+		createTime, err := times.ParseToStdTime(time.RFC3339Nano, one.TimeCreated)
+		if err != nil {
+			logs.Errorf("parse gcp bucket create time failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		bucket.CloudCreatedTime = createTime
+	}
+
+	access := new(typebucket.PublicAccess)
+	prevented := one.IamConfiguration != nil &&
+		one.IamConfiguration.PublicAccessPrevention == gcpPublicAccessPreventionEnforced
+	if !prevented {
+		iamAccess, err := g.getBucketIamPublicAccess(kt, client, one.Name)
+		if err != nil {
+			return nil, err
+		}
+		access.Merge(iamAccess)
+		access.Merge(getBucketAclPublicAccess(one.Acl))
+	}
+	access.Apply(bucket)
+
+	return bucket, nil
+}
+
+// getBucketIamPublicAccess judge public access of bucket by iam policy bindings granted to all users,
+// roles like roles/storage.objectViewer grant read, roles like roles/storage.objectAdmin grant write.
+// reference: https://cloud.google.com/storage/docs/json_api/v1/buckets/getIamPolicy
+func (g *Gcp) getBucketIamPublicAccess(kt *kit.Kit, client *storage.Service, name string) (
+	*typebucket.PublicAccess, error) {
+
+	policy, err := client.Buckets.GetIamPolicy(name).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("get gcp bucket iam policy failed, err: %v, bucket: %s, rid: %s", err, name, kt.Rid)
+		return nil, err
+	}
+
+	access := new(typebucket.PublicAccess)
+	for _, binding := range policy.Bindings {
+		if binding == nil || binding.Condition != nil || !hasPublicMember(binding.Members) {
+			continue
+		}
+
+		role := strings.ToLower(binding.Role)
+		read := strings.Contains(role, "viewer") || strings.Contains(role, "reader") ||
+			strings.Contains(role, "admin") || strings.Contains(role, "owner")
+		write := strings.Contains(role, "creator") || strings.Contains(role, "writer") ||
+			strings.Contains(role, "admin") || strings.Contains(role, "owner") || strings.Contains(role, "user")
+		if !read && !write {
+			continue
+		}
+
+		access.Read = access.Read || read
+		access.Write = access.Write || write
+		access.Reasons = append(access.Reasons, typebucket.NewReason(typebucket.IamReasonSource,
+			strings.Join(binding.Members, ","), binding.Role))
+	}
+
+	return access, nil
+}
+
+func hasPublicMember(members []string) bool {
+	for _, member := range members {
+		if member == gcpAllUsers || member == gcpAllAuthenticatedUsers {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getBucketAclPublicAccess judge public access of bucket by acl granted to all users, acl is empty
+// when uniform bucket level access is enabled.
+func getBucketAclPublicAccess(acls []*storage.BucketAccessControl) *typebucket.PublicAccess {
+	access := new(typebucket.PublicAccess)
+	for _, acl := range acls {
+		if acl == nil || (acl.Entity != gcpAllUsers && acl.Entity != gcpAllAuthenticatedUsers) {
+			continue
+		}
+
+		switch acl.Role {
+		case "READER":
+			access.Read = true
+		case "WRITER", "OWNER":
+			access.Read = true
+			access.Write = true
+		default:
+			continue
+		}
+
+		access.Reasons = append(access.Reasons, typebucket.NewReason(typebucket.AclReasonSource, acl.Entity,
+			acl.Role))
+	}
+
+	return access
+}
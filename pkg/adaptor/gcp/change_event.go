@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	changeevent "hcm/pkg/adaptor/types/change-event"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/json"
+
+	"google.golang.org/api/logging/v2"
+)
+
+// auditLogQueryLimit 审计日志单次查询的最大日志数
+const auditLogQueryLimit = 1000
+
+// auditLogResType 审计日志监控资源类型对应的资源类型，及资源ID所在的标签
+var auditLogResType = map[string]struct {
+	resType   enumor.CloudResourceType
+	idLabel   string
+	hasZone   bool
+	hasRegion bool
+}{
+	"gce_instance":      {resType: enumor.CvmCloudResType, idLabel: "instance_id", hasZone: true},
+	"gce_disk":          {resType: enumor.DiskCloudResType, idLabel: "disk_id", hasZone: true},
+	"gce_network":       {resType: enumor.VpcCloudResType, idLabel: "network_id"},
+	"gce_subnetwork":    {resType: enumor.SubnetCloudResType, idLabel: "subnetwork_id", hasRegion: true},
+	"gce_firewall_rule": {resType: enumor.GcpFirewallRuleCloudResType, idLabel: "firewall_rule_id"},
+}
+
+// ListChangeEvent list resource change event from admin activity audit logs of the project.
+// reference: https://cloud.google.com/logging/docs/reference/v2/rest/v2/entries/list
+func (g *Gcp) ListChangeEvent(kt *kit.Kit, opt *changeevent.ListOption) ([]changeevent.ChangeEvent, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.loggingClient(kt)
+	if err != nil {
+		return nil, fmt.Errorf("new logging client failed, err: %v", err)
+	}
+
+	projectID := g.CloudProjectID()
+	resTypes := make([]string, 0, len(auditLogResType))
+	for resType := range auditLogResType {
+		resTypes = append(resTypes, fmt.Sprintf(`resource.type="%s"`, resType))
+	}
+	filter := fmt.Sprintf(`logName="projects/%s/logs/cloudaudit.googleapis.com%%2Factivity" AND `+
+		`timestamp>="%s" AND timestamp<"%s" AND severity!="ERROR" AND (%s)`, projectID,
+		opt.StartTime.UTC().Format(time.RFC3339), opt.EndTime.UTC().Format(time.RFC3339),
+		strings.Join(resTypes, " OR "))
+
+	req := &logging.ListLogEntriesRequest{
+		ResourceNames: []string{"projects/" + projectID},
+		Filter:        filter,
+		OrderBy:       "timestamp asc",
+		PageSize:      auditLogQueryLimit,
+	}
+
+	events := make([]changeevent.ChangeEvent, 0)
+	err = client.Entries.List(req).Pages(kt.Ctx, func(resp *logging.ListLogEntriesResponse) error {
+		for _, one := range resp.Entries {
+			if one.Resource == nil {
+				continue
+			}
+
+			rule, exist := auditLogResType[one.Resource.Type]
+			if !exist || len(one.Resource.Labels[rule.idLabel]) == 0 {
+				continue
+			}
+
+			event := changeevent.ChangeEvent{
+				EventID:   one.InsertId,
+				EventName: parseAuditLogMethodName(one.ProtoPayload),
+				ResType:   rule.resType,
+				CloudID:   one.Resource.Labels[rule.idLabel],
+			}
+
+			if eventTime, err := time.Parse(time.RFC3339Nano, one.Timestamp); err == nil {
+				event.EventTime = eventTime
+			}
+
+			if rule.hasZone {
+				event.Zone = one.Resource.Labels["zone"]
+				if index := strings.LastIndex(event.Zone, "-"); index > 0 {
+					event.Region = event.Zone[:index]
+				}
+			}
+
+			if rule.hasRegion {
+				event.Region = one.Resource.Labels["location"]
+			}
+
+			events = append(events, event)
+		}
+
+		return nil
+	})
+	if err != nil {
+		logs.Errorf("list gcp audit log entries failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func parseAuditLogMethodName(payload []byte) string {
+	if len(payload) == 0 {
+		return ""
+	}
+
+	auditLog := struct {
+		MethodName string `json:"methodName"`
+	}{}
+	if err := json.Unmarshal(payload, &auditLog); err != nil {
+		return ""
+	}
+
+	return auditLog.MethodName
+}
@@ -27,7 +27,9 @@ import (
 
 	"cloud.google.com/go/bigquery"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/logging/v2"
 	"google.golang.org/api/option"
+	"google.golang.org/api/storage/v1"
 )
 
 type clientSet struct {
@@ -59,3 +61,23 @@ func (c *clientSet) bigQueryClient(kt *kit.Kit) (*bigquery.Client, error) {
 
 	return service, nil
 }
+
+func (c *clientSet) loggingClient(kt *kit.Kit) (*logging.Service, error) {
+	opt := option.WithCredentialsJSON(c.credential.Json)
+	service, err := logging.NewService(kt.Ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (c *clientSet) storageClient(kt *kit.Kit) (*storage.Service, error) {
+	opt := option.WithCredentialsJSON(c.credential.Json)
+	service, err := storage.NewService(kt.Ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
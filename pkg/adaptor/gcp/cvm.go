@@ -242,6 +242,44 @@ func (g *Gcp) ResetCvm(kt *kit.Kit, opt *typecvm.GcpResetOption) error {
 	return nil
 }
 
+// genCvmMetadata generate cvm metadata, password is set by startup script, ssh public key is injected to root user by
+// ssh-keys metadata.
+func genCvmMetadata(opt *typecvm.GcpCreateOption) (*compute.Metadata, error) {
+	if len(opt.SSHPublicKey) == 0 {
+		script, err := opt.ImageProjectType.StartupScript(opt.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		return &compute.Metadata{
+			Items: []*compute.MetadataItems{
+				{
+					Key:   "startup-script",
+					Value: converter.ValToPtr(script),
+				},
+			},
+		}, nil
+	}
+
+	script, err := opt.ImageProjectType.SSHKeyStartupScript()
+	if err != nil {
+		return nil, err
+	}
+
+	return &compute.Metadata{
+		Items: []*compute.MetadataItems{
+			{
+				Key:   "startup-script",
+				Value: converter.ValToPtr(script),
+			},
+			{
+				Key:   "ssh-keys",
+				Value: converter.ValToPtr(fmt.Sprintf("root:%s root", strings.TrimSpace(opt.SSHPublicKey))),
+			},
+		},
+	}, nil
+}
+
 // CreateCvm reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/bulkInsert
 func (g *Gcp) CreateCvm(kt *kit.Kit, opt *typecvm.GcpCreateOption) (*poller.BaseDoneResult, error) {
 	if opt == nil {
@@ -257,7 +295,7 @@ func (g *Gcp) CreateCvm(kt *kit.Kit, opt *typecvm.GcpCreateOption) (*poller.Base
 		return nil, err
 	}
 
-	script, err := opt.ImageProjectType.StartupScript(opt.Password)
+	metadata, err := genCvmMetadata(opt)
 	if err != nil {
 		return nil, err
 	}
@@ -278,14 +316,7 @@ func (g *Gcp) CreateCvm(kt *kit.Kit, opt *typecvm.GcpCreateOption) (*poller.Base
 				},
 			},
 			MachineType: opt.InstanceType,
-			Metadata: &compute.Metadata{
-				Items: []*compute.MetadataItems{
-					{
-						Key:   "startup-script",
-						Value: converter.ValToPtr(script),
-					},
-				},
-			},
+			Metadata:    metadata,
 			NetworkInterfaces: []*compute.NetworkInterface{
 				{
 					Network:    opt.CloudVpcSelfLink,
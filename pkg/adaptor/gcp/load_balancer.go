@@ -0,0 +1,286 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"hcm/pkg/adaptor/poller"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"google.golang.org/api/compute/v1"
+)
+
+const (
+	// gcpForwardingRuleStatus 转发规则没有状态，统一使用该状态
+	gcpForwardingRuleStatus = "READY"
+	// gcpExternalSchemePrefix 外部负载均衡的负载均衡方案前缀
+	gcpExternalSchemePrefix = "EXTERNAL"
+)
+
+// ListLoadBalancer list regional forwarding rules as load balancers, return next page token for paging.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules/list
+func (g *Gcp) ListLoadBalancer(kt *kit.Kit, opt *typelb.GcpListOption) ([]typelb.GcpLoadBalancer, string, error) {
+	if opt == nil {
+		return nil, "", errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	request := client.ForwardingRules.List(g.CloudProjectID(), opt.Region).Context(kt.Ctx)
+	if len(opt.CloudIDs) > 0 {
+		request.Filter(generateResourceIDsFilter(opt.CloudIDs))
+	}
+
+	if opt.Page != nil {
+		request.MaxResults(opt.Page.PageSize).PageToken(opt.Page.PageToken)
+	}
+
+	resp, err := request.Do()
+	if err != nil {
+		logs.Errorf("list gcp forwarding rule failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+		return nil, "", err
+	}
+
+	lbs := make([]typelb.GcpLoadBalancer, 0, len(resp.Items))
+	for _, one := range resp.Items {
+		lb := typelb.GcpLoadBalancer{
+			CloudID:              strconv.FormatUint(one.Id, 10),
+			Name:                 one.Name,
+			Region:               opt.Region,
+			Zones:                make([]string, 0),
+			LBType:               enumor.IntranetLoadBalancer,
+			Status:               gcpForwardingRuleStatus,
+			CloudVpcID:           one.Network,
+			PublicIPv4Addresses:  make([]string, 0),
+			PrivateIPv4Addresses: make([]string, 0),
+			Extension: &corelb.GcpLoadBalancerExtension{
+				SelfLink:            converter.ValToPtr(one.SelfLink),
+				LoadBalancingScheme: converter.ValToPtr(one.LoadBalancingScheme),
+				IPProtocol:          converter.ValToPtr(one.IPProtocol),
+				NetworkTier:         converter.ValToPtr(one.NetworkTier),
+			},
+		}
+
+		if len(one.Description) != 0 {
+			lb.Memo = converter.ValToPtr(one.Description)
+		}
+
+		if len(one.Target) != 0 {
+			lb.Extension.CloudTarget = converter.ValToPtr(one.Target)
+		}
+
+		if len(one.BackendService) != 0 {
+			lb.Extension.CloudBackendService = converter.ValToPtr(one.BackendService)
+		}
+
+		if len(one.Subnetwork) != 0 {
+			lb.Extension.CloudSubnetwork = converter.ValToPtr(one.Subnetwork)
+		}
+
+		if strings.HasPrefix(one.LoadBalancingScheme, gcpExternalSchemePrefix) {
+			lb.LBType = enumor.InternetLoadBalancer
+			lb.PublicIPv4Addresses = append(lb.PublicIPv4Addresses, one.IPAddress)
+		} else {
+			lb.PrivateIPv4Addresses = append(lb.PrivateIPv4Addresses, one.IPAddress)
+		}
+
+		if len(one.CreationTimestamp) != 0 {
+			createTime, err := times.ParseToStdTime(time.RFC3339Nano, one.CreationTimestamp)
+			if err != nil {
+				logs.Errorf("parse gcp forwarding rule create time failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, "", err
+			}
+			lb.CloudCreatedTime = createTime
+		}
+
+		lbs = append(lbs, lb)
+	}
+
+	return lbs, resp.NextPageToken, nil
+}
+
+// ListLoadBalancerListener list listener of forwarding rule, one forwarding rule has exactly one listener whose
+// cloud id is the same as the forwarding rule, targets are instances of the target pool that rule forwards to.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules/get
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/targetPools/get
+func (g *Gcp) ListLoadBalancerListener(kt *kit.Kit, opt *typelb.ListenerListOption) ([]typelb.GcpListener, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.ForwardingRules.List(g.CloudProjectID(), opt.Region).Context(kt.Ctx).
+		Filter(generateResourceIDsFilter([]string{opt.CloudLbID})).Do()
+	if err != nil {
+		logs.Errorf("list gcp forwarding rule failed, err: %v, id: %s, rid: %s", err, opt.CloudLbID, kt.Rid)
+		return nil, err
+	}
+
+	listeners := make([]typelb.GcpListener, 0, len(resp.Items))
+	for _, rule := range resp.Items {
+		listener := typelb.GcpListener{
+			CloudID:  strconv.FormatUint(rule.Id, 10),
+			Name:     rule.Name,
+			Protocol: rule.IPProtocol,
+			Extension: &corelb.GcpListenerExtension{
+				Ports: rule.Ports,
+			},
+			Targets: make([]*typelb.GcpTarget, 0),
+		}
+
+		// 端口范围格式为 {start}-{end}，单端口时起止端口相同
+		if len(rule.PortRange) != 0 {
+			listener.Extension.PortRange = converter.ValToPtr(rule.PortRange)
+			ports := strings.SplitN(rule.PortRange, "-", 2)
+			listener.Port, _ = strconv.ParseInt(ports[0], 10, 64)
+			if len(ports) == 2 && ports[1] != ports[0] {
+				listener.EndPort, _ = strconv.ParseInt(ports[1], 10, 64)
+			}
+		}
+
+		// 仅目标池类型的转发目标有后端实例，后端服务类型的转发目标暂不同步后端
+		if strings.Contains(rule.Target, "/targetPools/") {
+			pool, err := client.TargetPools.Get(g.CloudProjectID(), opt.Region, parseSelfLinkToName(rule.Target)).
+				Context(kt.Ctx).Do()
+			if err != nil {
+				logs.Errorf("get gcp target pool failed, err: %v, pool: %s, rid: %s", err, rule.Target, kt.Rid)
+				return nil, err
+			}
+
+			for _, instance := range pool.Instances {
+				listener.Targets = append(listener.Targets, &typelb.GcpTarget{
+					TargetType:    enumor.InstanceLBTargetType,
+					CloudTargetID: instance,
+					Port:          listener.Port,
+					Extension: &corelb.GcpTargetExtension{
+						Zone:            converter.ValToPtr(parseGcpInstanceZone(instance)),
+						CloudTargetPool: converter.ValToPtr(pool.SelfLink),
+					},
+				})
+			}
+		}
+
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
+// parseGcpInstanceZone parse zone from instance self link, format: .../zones/{zone}/instances/{name}.
+func parseGcpInstanceZone(link string) string {
+	fields := strings.Split(link, "/")
+	for idx := range fields {
+		if fields[idx] == "zones" && idx+1 < len(fields) {
+			return fields[idx+1]
+		}
+	}
+
+	return ""
+}
+
+// CreateLoadBalancer create regional forwarding rule to target pool.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules/insert
+func (g *Gcp) CreateLoadBalancer(kt *kit.Kit, opt *typelb.GcpCreateOption) (*poller.BaseDoneResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &compute.ForwardingRule{
+		Name:        opt.Name,
+		Description: converter.PtrToVal(opt.Memo),
+		IPAddress:   converter.PtrToVal(opt.IPAddress),
+		IPProtocol:  converter.PtrToVal(opt.IPProtocol),
+		PortRange:   converter.PtrToVal(opt.PortRange),
+		Target:      opt.CloudTargetPool,
+	}
+
+	op, err := client.ForwardingRules.Insert(g.CloudProjectID(), opt.Region, rule).Context(kt.Ctx).
+		RequestId(kt.Rid).Do()
+	if err != nil {
+		logs.Errorf("create gcp forwarding rule failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return &poller.BaseDoneResult{SuccessCloudIDs: []string{strconv.FormatUint(op.TargetId, 10)}}, nil
+}
+
+// DeleteLoadBalancer delete regional forwarding rule, resource id is forwarding rule name.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules/delete
+func (g *Gcp) DeleteLoadBalancer(kt *kit.Kit, opt *typelb.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.ForwardingRules.Delete(g.CloudProjectID(), opt.Region, opt.ResourceID).Context(kt.Ctx).
+		RequestId(kt.Rid).Do()
+	if err != nil {
+		logs.Errorf("delete gcp forwarding rule failed, err: %v, name: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,319 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+
+	"google.golang.org/api/compute/v1"
+)
+
+const (
+	// gcpCloudNatStatus Cloud NAT没有状态，统一使用该状态
+	gcpCloudNatStatus = "READY"
+	// gcpNatIPManualOnly 手动分配NAT外部IP地址
+	gcpNatIPManualOnly = "MANUAL_ONLY"
+	// gcpNatIPAutoOnly 自动分配NAT外部IP地址
+	gcpNatIPAutoOnly = "AUTO_ONLY"
+	// gcpNatListOfSubnetworks 仅对指定子网进行地址转换
+	gcpNatListOfSubnetworks = "LIST_OF_SUBNETWORKS"
+	// gcpNatAllSubnetworks 对所有子网的全部IP范围进行地址转换
+	gcpNatAllSubnetworks = "ALL_SUBNETWORKS_ALL_IP_RANGES"
+)
+
+// ListNatGateway list cloud nats configured in regional cloud routers, paging is based on cloud routers, return
+// next page token for paging.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/routers/list
+func (g *Gcp) ListNatGateway(kt *kit.Kit, opt *typenat.GcpListOption) ([]typenat.GcpNatGateway, string, error) {
+	if opt == nil {
+		return nil, "", errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cloudIDMap := make(map[string]struct{}, len(opt.CloudIDs))
+	routerIDs := make([]string, 0, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		routerID, _, err := corenat.ParseGcpNatGatewayCloudID(id)
+		if err != nil {
+			return nil, "", errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		cloudIDMap[id] = struct{}{}
+		routerIDs = append(routerIDs, routerID)
+	}
+
+	request := client.Routers.List(g.CloudProjectID(), opt.Region).Context(kt.Ctx)
+	if len(routerIDs) > 0 {
+		request.Filter(generateResourceIDsFilter(slice.Unique(routerIDs)))
+	}
+
+	if opt.Page != nil {
+		request.MaxResults(opt.Page.PageSize).PageToken(opt.Page.PageToken)
+	}
+
+	resp, err := request.Do()
+	if err != nil {
+		logs.Errorf("list gcp router failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+		return nil, "", err
+	}
+
+	nats := make([]typenat.GcpNatGateway, 0)
+	for _, router := range resp.Items {
+		routerID := strconv.FormatUint(router.Id, 10)
+		for _, one := range router.Nats {
+			cloudID := corenat.GenGcpNatGatewayCloudID(routerID, one.Name)
+			if len(cloudIDMap) != 0 {
+				if _, exist := cloudIDMap[cloudID]; !exist {
+					continue
+				}
+			}
+
+			nat, err := convGcpCloudNat(kt, router, one, opt.Region)
+			if err != nil {
+				return nil, "", err
+			}
+			nats = append(nats, nat)
+		}
+	}
+
+	if err = g.fillNatGatewayAddress(kt, opt.Region, nats); err != nil {
+		return nil, "", err
+	}
+
+	return nats, resp.NextPageToken, nil
+}
+
+func convGcpCloudNat(kt *kit.Kit, router *compute.Router, one *compute.RouterNat, region string) (
+	typenat.GcpNatGateway, error) {
+
+	nat := typenat.GcpNatGateway{
+		CloudID:           corenat.GenGcpNatGatewayCloudID(strconv.FormatUint(router.Id, 10), one.Name),
+		Name:              one.Name,
+		Region:            region,
+		NatType:           enumor.PublicNatGateway,
+		Status:            gcpCloudNatStatus,
+		CloudVpcID:        router.Network,
+		PublicIPAddresses: make([]string, 0),
+		CloudEipIDs:       make([]string, 0),
+		Extension: &corenat.GcpNatGatewayExtension{
+			CloudRouterID:                 strconv.FormatUint(router.Id, 10),
+			RouterName:                    router.Name,
+			RouterSelfLink:                router.SelfLink,
+			NatIPAllocateOption:           converter.ValToPtr(one.NatIpAllocateOption),
+			NatIPSelfLinks:                one.NatIps,
+			SourceSubnetworkIPRangesToNat: converter.ValToPtr(one.SourceSubnetworkIpRangesToNat),
+			SubnetSelfLinks:               make([]string, 0, len(one.Subnetworks)),
+		},
+	}
+
+	if one.MinPortsPerVm != 0 {
+		nat.Extension.MinPortsPerVm = converter.ValToPtr(one.MinPortsPerVm)
+	}
+
+	for _, subnet := range one.Subnetworks {
+		nat.Extension.SubnetSelfLinks = append(nat.Extension.SubnetSelfLinks, subnet.Name)
+	}
+	if len(nat.Extension.SubnetSelfLinks) != 0 {
+		nat.CloudSubnetID = nat.Extension.SubnetSelfLinks[0]
+	}
+
+	if len(router.CreationTimestamp) != 0 {
+		createTime, err := times.ParseToStdTime(time.RFC3339Nano, router.CreationTimestamp)
+		if err != nil {
+			logs.Errorf("parse gcp router create time failed, err: %v, rid: %s", err, kt.Rid)
+			return nat, err
+		}
+		nat.CloudCreatedTime = createTime
+	}
+
+	return nat, nil
+}
+
+// fillNatGatewayAddress Cloud NAT只返回手动分配的外部IP地址链接，需要查询外部IP地址获取地址ID和IP
+func (g *Gcp) fillNatGatewayAddress(kt *kit.Kit, region string, nats []typenat.GcpNatGateway) error {
+	selfLinks := make([]string, 0)
+	for _, one := range nats {
+		selfLinks = append(selfLinks, one.Extension.NatIPSelfLinks...)
+	}
+
+	if len(selfLinks) == 0 {
+		return nil
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	addressMap := make(map[string]*compute.Address)
+	for _, parts := range slice.Split(slice.Unique(selfLinks), core.GcpQueryLimit) {
+		resp, err := client.Addresses.List(g.CloudProjectID(), region).Context(kt.Ctx).
+			Filter(generateResourceFilter("selfLink", parts)).Do()
+		if err != nil {
+			logs.Errorf("list gcp address failed, err: %v, self links: %v, rid: %s", err, parts, kt.Rid)
+			return err
+		}
+
+		for _, address := range resp.Items {
+			addressMap[address.SelfLink] = address
+		}
+	}
+
+	for idx := range nats {
+		for _, link := range nats[idx].Extension.NatIPSelfLinks {
+			address, exist := addressMap[link]
+			if !exist {
+				continue
+			}
+
+			nats[idx].CloudEipIDs = append(nats[idx].CloudEipIDs, strconv.FormatUint(address.Id, 10))
+			nats[idx].PublicIPAddresses = append(nats[idx].PublicIPAddresses, address.Address)
+		}
+	}
+
+	return nil
+}
+
+// CreateNatGateway create cloud nat in cloud router, cloud nat is part of router, so create is patching router.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/routers/patch
+func (g *Gcp) CreateNatGateway(kt *kit.Kit, opt *typenat.GcpCreateOption) (*poller.BaseDoneResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := client.Routers.Get(g.CloudProjectID(), opt.Region, opt.RouterName).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("get gcp router failed, err: %v, router: %s, rid: %s", err, opt.RouterName, kt.Rid)
+		return nil, err
+	}
+
+	for _, one := range router.Nats {
+		if one.Name == opt.Name {
+			return nil, errf.Newf(errf.InvalidParameter, "cloud nat %s already exists in router %s", opt.Name,
+				opt.RouterName)
+		}
+	}
+
+	nat := &compute.RouterNat{
+		Name:                          opt.Name,
+		NatIpAllocateOption:           gcpNatIPAutoOnly,
+		SourceSubnetworkIpRangesToNat: gcpNatAllSubnetworks,
+		MinPortsPerVm:                 converter.PtrToVal(opt.MinPortsPerVm),
+	}
+	if len(opt.NatIPSelfLinks) != 0 {
+		nat.NatIpAllocateOption = gcpNatIPManualOnly
+		nat.NatIps = opt.NatIPSelfLinks
+	}
+	if len(opt.CloudSubnetSelfLinks) != 0 {
+		nat.SourceSubnetworkIpRangesToNat = gcpNatListOfSubnetworks
+		for _, link := range opt.CloudSubnetSelfLinks {
+			nat.Subnetworks = append(nat.Subnetworks, &compute.RouterNatSubnetworkToNat{
+				Name:                link,
+				SourceIpRangesToNat: []string{"ALL_IP_RANGES"},
+			})
+		}
+	}
+
+	patch := &compute.Router{Nats: append(router.Nats, nat)}
+	_, err = client.Routers.Patch(g.CloudProjectID(), opt.Region, opt.RouterName, patch).Context(kt.Ctx).
+		RequestId(kt.Rid).Do()
+	if err != nil {
+		logs.Errorf("patch gcp router to create cloud nat failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	cloudID := corenat.GenGcpNatGatewayCloudID(strconv.FormatUint(router.Id, 10), opt.Name)
+	return &poller.BaseDoneResult{SuccessCloudIDs: []string{cloudID}}, nil
+}
+
+// DeleteNatGateway delete cloud nat from cloud router by patching router without it.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/routers/patch
+func (g *Gcp) DeleteNatGateway(kt *kit.Kit, opt *typenat.GcpDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	router, err := client.Routers.Get(g.CloudProjectID(), opt.Region, opt.RouterName).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("get gcp router failed, err: %v, router: %s, rid: %s", err, opt.RouterName, kt.Rid)
+		return err
+	}
+
+	nats := make([]*compute.RouterNat, 0, len(router.Nats))
+	for _, one := range router.Nats {
+		if one.Name != opt.Name {
+			nats = append(nats, one)
+		}
+	}
+
+	if len(nats) == len(router.Nats) {
+		return fmt.Errorf("cloud nat %s is not found in router %s", opt.Name, opt.RouterName)
+	}
+
+	// Nats为空时需要强制发送该字段，否则patch会忽略空列表
+	patch := &compute.Router{Nats: nats, ForceSendFields: []string{"Nats"}}
+	_, err = client.Routers.Patch(g.CloudProjectID(), opt.Region, opt.RouterName, patch).Context(kt.Ctx).
+		RequestId(kt.Rid).Do()
+	if err != nil {
+		logs.Errorf("patch gcp router to delete cloud nat failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
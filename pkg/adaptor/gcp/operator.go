@@ -0,0 +1,282 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/operator"
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/adaptor/types/disk"
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// gcpTagResTypes resource types which support label of gcp, vpc, subnet and firewall rule do not support label.
+var gcpTagResTypes = []enumor.CloudResourceType{
+	enumor.CvmCloudResType,
+	enumor.DiskCloudResType,
+	enumor.EipCloudResType,
+}
+
+func init() {
+	operator.Register(enumor.Gcp, func(cred *operator.Credential) (operator.Operator, error) {
+		if cred.Gcp == nil {
+			return nil, errf.New(errf.InvalidParameter, "gcp credential is required")
+		}
+
+		cli, err := NewGcp(cred.Gcp)
+		if err != nil {
+			return nil, err
+		}
+
+		return &gcpOperator{cli: cli}, nil
+	})
+}
+
+// gcpOperator implements operator.Operator by gcp adaptor, gcp operates cvm and disk one by one by zone and name.
+type gcpOperator struct {
+	cli *Gcp
+}
+
+// Vendor ...
+func (op *gcpOperator) Vendor() enumor.Vendor {
+	return enumor.Gcp
+}
+
+// ListCvm ...
+func (op *gcpOperator) ListCvm(kt *kit.Kit, opt *operator.CvmListOption) ([]operator.Cvm, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvms, _, err := op.cli.ListCvm(kt, &typecvm.GcpListOption{
+		Zone:     opt.Zone,
+		CloudIDs: opt.CloudIDs,
+		Page:     &core.GcpPage{PageSize: core.GcpQueryLimit},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]operator.Cvm, 0, len(cvms))
+	for _, one := range cvms {
+		result = append(result, operator.Cvm{
+			CloudID:      fmt.Sprint(one.Id),
+			Name:         one.Name,
+			Status:       one.Status,
+			Zone:         opt.Zone,
+			InstanceType: GetMachineType(one.MachineType),
+		})
+	}
+
+	return result, nil
+}
+
+// StartCvm ...
+func (op *gcpOperator) StartCvm(kt *kit.Kit, opt *operator.CvmOperateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "start cvm option is required")
+	}
+
+	return eachCvm(opt, func(zone, name string) error {
+		return op.cli.StartCvm(kt, &typecvm.GcpStartOption{Zone: zone, Name: name})
+	})
+}
+
+// StopCvm gcp stop cvm does not support force stop, force is ignored.
+func (op *gcpOperator) StopCvm(kt *kit.Kit, opt *operator.CvmStopOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "stop cvm option is required")
+	}
+
+	return eachCvm(&opt.CvmOperateOption, func(zone, name string) error {
+		return op.cli.StopCvm(kt, &typecvm.GcpStopOption{Zone: zone, Name: name})
+	})
+}
+
+// RebootCvm gcp reboot cvm by reset, which is always a hard reboot.
+func (op *gcpOperator) RebootCvm(kt *kit.Kit, opt *operator.CvmStopOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "reboot cvm option is required")
+	}
+
+	return eachCvm(&opt.CvmOperateOption, func(zone, name string) error {
+		return op.cli.ResetCvm(kt, &typecvm.GcpResetOption{Zone: zone, Name: name})
+	})
+}
+
+// DeleteCvm ...
+func (op *gcpOperator) DeleteCvm(kt *kit.Kit, opt *operator.CvmDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete cvm option is required")
+	}
+
+	return eachCvm(&opt.CvmOperateOption, func(zone, name string) error {
+		return op.cli.DeleteCvm(kt, &typecvm.GcpDeleteOption{Zone: zone, Name: name})
+	})
+}
+
+// AttachDisk ...
+func (op *gcpOperator) AttachDisk(kt *kit.Kit, opt *operator.DiskAttachOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "attach disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.AttachDisk(kt, &disk.GcpDiskAttachOption{
+		Zone:       opt.Disk.Zone,
+		CvmName:    opt.Cvm.Name,
+		DiskName:   opt.Disk.Name,
+		DeviceName: opt.DeviceName,
+	})
+}
+
+// DetachDisk ...
+func (op *gcpOperator) DetachDisk(kt *kit.Kit, opt *operator.DiskAttachOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "detach disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.DetachDisk(kt, &disk.GcpDiskDetachOption{
+		Zone:       opt.Disk.Zone,
+		CvmName:    opt.Cvm.Name,
+		DiskName:   opt.Disk.Name,
+		DeviceName: opt.DeviceName,
+	})
+}
+
+// DeleteDisk ...
+func (op *gcpOperator) DeleteDisk(kt *kit.Kit, opt *operator.DiskDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.DeleteDisk(kt, &disk.GcpDiskDeleteOption{Zone: opt.Disk.Zone, DiskName: opt.Disk.Name})
+}
+
+// DeleteSecurityGroup gcp uses vpc firewall rules instead of security group.
+func (op *gcpOperator) DeleteSecurityGroup(_ *kit.Kit, _ *operator.SecurityGroupDeleteOption) error {
+	return operator.NotSupportError(enumor.Gcp, "security group")
+}
+
+// AssociateCvm gcp uses vpc firewall rules instead of security group.
+func (op *gcpOperator) AssociateCvm(_ *kit.Kit, _ *operator.SecurityGroupCvmOption) error {
+	return operator.NotSupportError(enumor.Gcp, "security group")
+}
+
+// DisassociateCvm gcp uses vpc firewall rules instead of security group.
+func (op *gcpOperator) DisassociateCvm(_ *kit.Kit, _ *operator.SecurityGroupCvmOption) error {
+	return operator.NotSupportError(enumor.Gcp, "security group")
+}
+
+// TagResTypes ...
+func (op *gcpOperator) TagResTypes() []enumor.CloudResourceType {
+	return gcpTagResTypes
+}
+
+// ListTag gcp resource is located by zone or region and name.
+func (op *gcpOperator) ListTag(kt *kit.Kit, opt *operator.TagListOption) ([]typetag.ResourceTags, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list tag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.ListResourceTag(kt, &typetag.GcpListOption{ResType: opt.ResType,
+		Resources: convertGcpResources(opt.Resources)})
+}
+
+// TagResource ...
+func (op *gcpOperator) TagResource(kt *kit.Kit, opt *operator.TagResourceOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.TagResource(kt, &typetag.GcpTagOption{ResType: opt.ResType,
+		Resources: convertGcpResources(opt.Resources), Tags: opt.Tags})
+}
+
+// UntagResource ...
+func (op *gcpOperator) UntagResource(kt *kit.Kit, opt *operator.UntagResourceOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.UntagResource(kt, &typetag.GcpUntagOption{ResType: opt.ResType,
+		Resources: convertGcpResources(opt.Resources), Keys: opt.Keys})
+}
+
+func convertGcpResources(refs []operator.ResourceRef) []typetag.GcpResource {
+	resources := make([]typetag.GcpResource, 0, len(refs))
+	for _, one := range refs {
+		resources = append(resources, typetag.GcpResource{Zone: one.Zone, Region: one.Region, Name: one.Name,
+			CloudID: one.CloudID})
+	}
+
+	return resources
+}
+
+// eachCvm validate option and operate cvm one by one, gcp cvm is located by zone and name.
+func eachCvm(opt *operator.CvmOperateOption, handle func(zone, name string) error) error {
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	for _, one := range opt.Cvms {
+		if len(one.Zone) == 0 || len(one.Name) == 0 {
+			return errf.Newf(errf.InvalidParameter, "gcp cvm: %s zone and name are required", one.CloudID)
+		}
+
+		if err := handle(one.Zone, one.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/core"
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// ListSnapshot list disk snapshot, gcp snapshot is global resource, zone of list option is ignored.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/snapshots/list
+func (g *Gcp) ListSnapshot(kt *kit.Kit, opt *core.GcpListOption) ([]typesnap.GcpSnapshot, string, error) {
+	if opt == nil {
+		return nil, "", errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	request := client.Snapshots.List(g.CloudProjectID()).Context(kt.Ctx)
+
+	if len(opt.CloudIDs) > 0 {
+		request.Filter(generateResourceIDsFilter(opt.CloudIDs))
+	}
+
+	if len(opt.SelfLinks) > 0 {
+		request.Filter(generateResourceFilter("selfLink", opt.SelfLinks))
+	}
+
+	if opt.Page != nil {
+		request.MaxResults(opt.Page.PageSize).PageToken(opt.Page.PageToken)
+	}
+
+	resp, err := request.Do()
+	if err != nil {
+		logs.Errorf("list gcp snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, "", err
+	}
+
+	snapshots := make([]typesnap.GcpSnapshot, 0, len(resp.Items))
+	for _, one := range resp.Items {
+		snapshots = append(snapshots, convGcpSnapshot(one))
+	}
+
+	return snapshots, resp.NextPageToken, nil
+}
+
+func convGcpSnapshot(one *compute.Snapshot) typesnap.GcpSnapshot {
+	snapshot := typesnap.GcpSnapshot{
+		CloudID:          strconv.FormatUint(one.Id, 10),
+		Name:             one.Name,
+		Status:           one.Status,
+		CloudDiskID:      one.SourceDiskId,
+		DiskSize:         uint64(one.DiskSizeGb),
+		Encrypted:        converter.ValToPtr(one.SnapshotEncryptionKey != nil),
+		CloudCreatedTime: one.CreationTimestamp,
+		Extension: &coresnap.GcpSnapshotExtension{
+			SelfLink:         one.SelfLink,
+			StorageBytes:     converter.ValToPtr(one.StorageBytes),
+			StorageLocations: one.StorageLocations,
+		},
+	}
+
+	if len(one.Description) != 0 {
+		snapshot.Memo = converter.ValToPtr(one.Description)
+	}
+
+	if len(one.SnapshotType) != 0 {
+		snapshot.Extension.SnapshotType = converter.ValToPtr(one.SnapshotType)
+	}
+
+	// 快照是全局资源，使用源云盘所在的可用区与地域作为快照的可用区与地域
+	if len(one.SourceDisk) != 0 {
+		snapshot.Extension.SourceDiskSelfLink = converter.ValToPtr(one.SourceDisk)
+		snapshot.Zone, snapshot.Region = parseGcpDiskSelfLinkZone(one.SourceDisk)
+	}
+
+	return snapshot
+}
+
+// parseGcpDiskSelfLinkZone parse zone and region from disk self link.
+// disk self link format: https://www.googleapis.com/compute/v1/projects/{project}/zones/{zone}/disks/{name}
+func parseGcpDiskSelfLinkZone(link string) (string, string) {
+	const zoneSegment = "/zones/"
+	idx := strings.Index(link, zoneSegment)
+	if idx < 0 {
+		return "", ""
+	}
+
+	zone := link[idx+len(zoneSegment):]
+	if end := strings.Index(zone, "/"); end >= 0 {
+		zone = zone[:end]
+	}
+
+	regionIdx := strings.LastIndex(zone, "-")
+	if regionIdx <= 0 {
+		return zone, ""
+	}
+
+	return zone, zone[:regionIdx]
+}
+
+// CreateSnapshot create disk snapshot.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/snapshots/insert
+func (g *Gcp) CreateSnapshot(kt *kit.Kit, opt *typesnap.GcpCreateOption) (*poller.BaseDoneResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &compute.Snapshot{
+		Name:             opt.Name,
+		Description:      converter.PtrToVal(opt.Memo),
+		SourceDisk:       fmt.Sprintf("projects/%s/zones/%s/disks/%s", g.CloudProjectID(), opt.Zone, opt.CloudDiskName),
+		StorageLocations: opt.StorageLocations,
+	}
+
+	op, err := client.Snapshots.Insert(g.CloudProjectID(), snapshot).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("create gcp snapshot failed, err: %v, disk: %s, rid: %s", err, opt.CloudDiskName, kt.Rid)
+		return nil, err
+	}
+
+	return &poller.BaseDoneResult{SuccessCloudIDs: []string{strconv.FormatUint(op.TargetId, 10)}}, nil
+}
+
+// DeleteSnapshot delete disk snapshot, resource id is snapshot name.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/snapshots/delete
+func (g *Gcp) DeleteSnapshot(kt *kit.Kit, opt *typesnap.GcpDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.Snapshots.Delete(g.CloudProjectID(), opt.ResourceID).Context(kt.Ctx).Do(); err != nil {
+		logs.Errorf("delete gcp snapshot failed, err: %v, name: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// ListResourceTag list labels of resources. gcp labels are supported by cvm, disk and eip, and vpc, subnet and
+// firewall rule do not support labels.
+// reference: https://cloud.google.com/compute/docs/labeling-resources
+func (g *Gcp) ListResourceTag(kt *kit.Kit, opt *typetag.GcpListOption) ([]typetag.ResourceTags, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]typetag.ResourceTags, 0, len(opt.Resources))
+	for _, res := range opt.Resources {
+		labels, _, err := g.getLabels(kt, client, opt.ResType, res)
+		if err != nil {
+			return nil, err
+		}
+
+		tags := make([]typetag.Tag, 0, len(labels))
+		for key, value := range labels {
+			tags = append(tags, typetag.Tag{Key: key, Value: value})
+		}
+		details = append(details, typetag.ResourceTags{CloudID: res.CloudID, Tags: tags})
+	}
+
+	return details, nil
+}
+
+// TagResource add or overwrite labels of resources. gcp set labels api replaces all labels, so current labels
+// are merged with the labels to add.
+func (g *Gcp) TagResource(kt *kit.Kit, opt *typetag.GcpTagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	for _, res := range opt.Resources {
+		labels, fingerprint, err := g.getLabels(kt, client, opt.ResType, res)
+		if err != nil {
+			return err
+		}
+
+		for _, one := range opt.Tags {
+			labels[one.Key] = one.Value
+		}
+
+		if err = g.setLabels(kt, client, opt.ResType, res, labels, fingerprint); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UntagResource remove labels of resources by label keys.
+func (g *Gcp) UntagResource(kt *kit.Kit, opt *typetag.GcpUntagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	for _, res := range opt.Resources {
+		labels, fingerprint, err := g.getLabels(kt, client, opt.ResType, res)
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		for _, key := range opt.Keys {
+			if _, exists := labels[key]; exists {
+				delete(labels, key)
+				changed = true
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err = g.setLabels(kt, client, opt.ResType, res, labels, fingerprint); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getLabels get labels and label fingerprint of the resource, fingerprint is required to set labels.
+func (g *Gcp) getLabels(kt *kit.Kit, client *compute.Service, resType enumor.CloudResourceType,
+	res typetag.GcpResource) (map[string]string, string, error) {
+
+	var labels map[string]string
+	var fingerprint string
+	switch resType {
+	case enumor.CvmCloudResType:
+		instance, err := client.Instances.Get(g.CloudProjectID(), res.Zone, res.Name).Context(kt.Ctx).Do()
+		if err != nil {
+			logs.Errorf("get gcp instance failed, err: %v, name: %s, rid: %s", err, res.Name, kt.Rid)
+			return nil, "", err
+		}
+		labels, fingerprint = instance.Labels, instance.LabelFingerprint
+
+	case enumor.DiskCloudResType:
+		disk, err := client.Disks.Get(g.CloudProjectID(), res.Zone, res.Name).Context(kt.Ctx).Do()
+		if err != nil {
+			logs.Errorf("get gcp disk failed, err: %v, name: %s, rid: %s", err, res.Name, kt.Rid)
+			return nil, "", err
+		}
+		labels, fingerprint = disk.Labels, disk.LabelFingerprint
+
+	case enumor.EipCloudResType:
+		address, err := client.Addresses.Get(g.CloudProjectID(), res.Region, res.Name).Context(kt.Ctx).Do()
+		if err != nil {
+			logs.Errorf("get gcp address failed, err: %v, name: %s, rid: %s", err, res.Name, kt.Rid)
+			return nil, "", err
+		}
+		labels, fingerprint = address.Labels, address.LabelFingerprint
+
+	default:
+		return nil, "", errf.Newf(errf.InvalidParameter, "gcp resource type: %s not support label", resType)
+	}
+
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+
+	return labels, fingerprint, nil
+}
+
+func (g *Gcp) setLabels(kt *kit.Kit, client *compute.Service, resType enumor.CloudResourceType,
+	res typetag.GcpResource, labels map[string]string, fingerprint string) error {
+
+	var err error
+	switch resType {
+	case enumor.CvmCloudResType:
+		req := &compute.InstancesSetLabelsRequest{Labels: labels, LabelFingerprint: fingerprint}
+		_, err = client.Instances.SetLabels(g.CloudProjectID(), res.Zone, res.Name, req).Context(kt.Ctx).Do()
+
+	case enumor.DiskCloudResType:
+		req := &compute.ZoneSetLabelsRequest{Labels: labels, LabelFingerprint: fingerprint}
+		_, err = client.Disks.SetLabels(g.CloudProjectID(), res.Zone, res.Name, req).Context(kt.Ctx).Do()
+
+	case enumor.EipCloudResType:
+		req := &compute.RegionSetLabelsRequest{Labels: labels, LabelFingerprint: fingerprint}
+		_, err = client.Addresses.SetLabels(g.CloudProjectID(), res.Region, res.Name, req).Context(kt.Ctx).Do()
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "gcp resource type: %s not support label", resType)
+	}
+	if err != nil {
+		logs.Errorf("set gcp %s labels failed, err: %v, name: %s, rid: %s", resType, err, res.Name, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"strconv"
+	"strings"
+
+	typevpcpeering "hcm/pkg/adaptor/types/vpc-peering"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"google.golang.org/api/compute/v1"
+)
+
+const (
+	// gcpPeeringStateActive 对等连接双方均已配置，可以转发流量
+	gcpPeeringStateActive = "ACTIVE"
+	// gcpPeeringStateInactive 对端尚未配置对等连接
+	gcpPeeringStateInactive = "INACTIVE"
+)
+
+// ListVpcPeering list all network peerings of project. peerings are properties of network, and each side of
+// peering is configured in its own network.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/networks/list
+func (g *Gcp) ListVpcPeering(kt *kit.Kit) ([]typevpcpeering.VpcPeering, error) {
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := make([]*compute.Network, 0)
+	err = client.Networks.List(g.CloudProjectID()).Context(kt.Ctx).Pages(kt.Ctx,
+		func(page *compute.NetworkList) error {
+			networks = append(networks, page.Items...)
+			return nil
+		})
+	if err != nil {
+		logs.Errorf("list gcp network for peering failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	// 同项目内的对端网络使用ID作为云ID，与同步的VPC保持一致，跨项目的对端网络保留其selfLink
+	selfLinkIDMap := make(map[string]string, len(networks))
+	for _, one := range networks {
+		selfLinkIDMap[one.SelfLink] = strconv.FormatUint(one.Id, 10)
+	}
+
+	peerings := make([]typevpcpeering.VpcPeering, 0)
+	for _, one := range networks {
+		for _, peering := range one.Peerings {
+			if peering == nil {
+				continue
+			}
+			peerings = append(peerings, convGcpVpcPeering(one, peering, selfLinkIDMap))
+		}
+	}
+
+	return peerings, nil
+}
+
+func convGcpVpcPeering(network *compute.Network, one *compute.NetworkPeering,
+	selfLinkIDMap map[string]string) typevpcpeering.VpcPeering {
+
+	localID := strconv.FormatUint(network.Id, 10)
+	peering := typevpcpeering.VpcPeering{
+		// 对等连接名称在网络内唯一，使用网络ID和对等连接名称组合作为云上ID
+		CloudID:            localID + "/" + one.Name,
+		Name:               one.Name,
+		Type:               enumor.PeeringConnection,
+		CloudStatus:        one.State,
+		LocalCloudVpcID:    localID,
+		LocalCidrs:         make([]string, 0),
+		PeerCloudVpcID:     one.Network,
+		PeerCloudAccountID: parseGcpProjectFromSelfLink(one.Network),
+		PeerCidrs:          make([]string, 0),
+	}
+	peering.CloudGatewayID = peering.CloudID
+
+	if id, exist := selfLinkIDMap[one.Network]; exist {
+		peering.PeerCloudVpcID = id
+	}
+
+	if len(one.StateDetails) != 0 {
+		peering.Memo = &one.StateDetails
+	}
+
+	switch one.State {
+	case gcpPeeringStateActive:
+		peering.Status = enumor.VpcPeeringActive
+	case gcpPeeringStateInactive:
+		peering.Status = enumor.VpcPeeringPending
+	default:
+		peering.Status = enumor.VpcPeeringInactive
+	}
+
+	return peering
+}
+
+// parseGcpProjectFromSelfLink parse project id from self link like
+// https://www.googleapis.com/compute/v1/projects/{project}/global/networks/{network}
+func parseGcpProjectFromSelfLink(link string) string {
+	parts := strings.Split(link, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "projects" {
+			return parts[i+1]
+		}
+	}
+
+	return ""
+}
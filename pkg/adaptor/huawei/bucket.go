@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/pkg/adaptor/s3compat"
+	typebucket "hcm/pkg/adaptor/types/bucket"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListStorageBucket list all obs buckets of account by s3 compatible api, obs bucket name is globally unique,
+// so it's used as cloud id.
+// reference: https://support.huaweicloud.com/api-obs/obs_04_0020.html
+func (h *HuaWei) ListStorageBucket(kt *kit.Kit) ([]typebucket.Bucket, error) {
+	client := s3compat.NewClient(&s3compat.Config{
+		SecretID:      h.clientSet.credentials.AK,
+		SecretKey:     h.clientSet.credentials.SK,
+		ServiceRegion: "cn-north-4",
+		Endpoint: func(region string) string {
+			if len(region) == 0 {
+				return "obs.myhuaweicloud.com"
+			}
+			return "obs." + region + ".myhuaweicloud.com"
+		},
+	})
+
+	buckets, err := client.ListBucket(kt, nil)
+	if err != nil {
+		logs.Errorf("list huawei obs bucket failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return buckets, nil
+}
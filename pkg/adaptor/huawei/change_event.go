@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"strings"
+	"time"
+
+	changeevent "hcm/pkg/adaptor/types/change-event"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cts/v3/model"
+)
+
+// ctsQueryLimit 云审计单次查询的最大事件数
+const ctsQueryLimit = 200
+
+// ctsResType 云审计资源类型对应的资源类型，key为小写的资源类型
+var ctsResType = map[string]enumor.CloudResourceType{
+	"ecs":             enumor.CvmCloudResType,
+	"evs":             enumor.DiskCloudResType,
+	"volume":          enumor.DiskCloudResType,
+	"volumes":         enumor.DiskCloudResType,
+	"vpc":             enumor.VpcCloudResType,
+	"vpcs":            enumor.VpcCloudResType,
+	"subnet":          enumor.SubnetCloudResType,
+	"subnets":         enumor.SubnetCloudResType,
+	"eip":             enumor.EipCloudResType,
+	"publicip":        enumor.EipCloudResType,
+	"publicips":       enumor.EipCloudResType,
+	"securitygroup":   enumor.SecurityGroupCloudResType,
+	"security_group":  enumor.SecurityGroupCloudResType,
+	"security-groups": enumor.SecurityGroupCloudResType,
+	"routetable":      enumor.RouteTableCloudResType,
+	"routetables":     enumor.RouteTableCloudResType,
+	"route_table":     enumor.RouteTableCloudResType,
+}
+
+// ListChangeEvent list resource change event from cloud trace service, only management events are returned.
+// reference: https://support.huaweicloud.com/api-cts/ListTraces.html
+func (h *HuaWei) ListChangeEvent(kt *kit.Kit, opt *changeevent.ListOption) ([]changeevent.ChangeEvent, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	client, err := h.clientSet.ctsClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &model.ListTracesRequest{
+		TraceType: model.GetListTracesRequestTraceTypeEnum().SYSTEM,
+		Limit:     converter.ValToPtr(int32(ctsQueryLimit)),
+		From:      converter.ValToPtr(opt.StartTime.UnixMilli()),
+		To:        converter.ValToPtr(opt.EndTime.UnixMilli()),
+	}
+
+	events := make([]changeevent.ChangeEvent, 0)
+	for {
+		resp, err := client.ListTraces(req)
+		if err != nil {
+			logs.Errorf("list huawei cts traces failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+			return nil, err
+		}
+
+		traces := converter.PtrToVal(resp.Traces)
+		for _, one := range traces {
+			resType, exist := ctsResType[strings.ToLower(converter.PtrToVal(one.ResourceType))]
+			if !exist || len(converter.PtrToVal(one.ResourceId)) == 0 {
+				continue
+			}
+
+			events = append(events, changeevent.ChangeEvent{
+				EventID:   converter.PtrToVal(one.TraceId),
+				EventName: converter.PtrToVal(one.TraceName),
+				EventTime: time.UnixMilli(converter.PtrToVal(one.Time)),
+				ResType:   resType,
+				CloudID:   converter.PtrToVal(one.ResourceId),
+				Region:    opt.Region,
+			})
+		}
+
+		if resp.MetaData == nil || len(converter.PtrToVal(resp.MetaData.Marker)) == 0 || len(traces) < ctsQueryLimit {
+			break
+		}
+
+		req.Next = resp.MetaData.Marker
+	}
+
+	return events, nil
+}
@@ -397,7 +397,6 @@ func (h *HuaWei) CreateCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (*polle
 				ImageRef:  opt.CloudImageID,
 				FlavorRef: opt.InstanceType,
 				Name:      opt.Name,
-				AdminPass: converter.ValToPtr(opt.Password),
 				Vpcid:     opt.CloudVpcID,
 				Nics: []model.PrePaidServerNic{
 					{
@@ -420,6 +419,12 @@ func (h *HuaWei) CreateCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (*polle
 		},
 	}
 
+	if len(opt.KeyName) != 0 {
+		req.Body.Server.KeyName = converter.ValToPtr(opt.KeyName)
+	} else {
+		req.Body.Server.AdminPass = converter.ValToPtr(opt.Password)
+	}
+
 	if opt.InstanceCharge.PeriodType != nil {
 		periodType, err := opt.InstanceCharge.PeriodType.PeriodType()
 		if err != nil {
@@ -0,0 +1,397 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2/model"
+	evsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+)
+
+// ChangeCvmInstanceType change flavor of cvm, the instance must be stopped before changing flavor, so running
+// instance is stopped first and started again whether flavor is changed or not. prepaid instance is paid
+// automatically.
+// reference: https://support.huaweicloud.com/api-ecs/ecs_02_0208.html
+func (h *HuaWei) ChangeCvmInstanceType(kt *kit.Kit, opt *typecvm.HuaWeiChangeInstanceTypeOption) (err error) {
+
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "change instance type option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.ecsClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new ecs client failed, err: %v", err)
+	}
+
+	servers, err := poll(h, kt, opt.Region, []*string{converter.ValToPtr(opt.CloudID)})
+	if err != nil {
+		logs.Errorf("get huawei cvm failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	if len(servers) == 0 {
+		return errf.Newf(errf.RecordNotFound, "cvm %s not found from cloud", opt.CloudID)
+	}
+
+	if servers[0].Flavor != nil && servers[0].Flavor.Id == opt.InstanceType {
+		return nil
+	}
+
+	running := servers[0].Status == "ACTIVE"
+	if running {
+		if err = h.stopCvmAndWait(kt, opt.Region, opt.CloudID); err != nil {
+			return err
+		}
+
+		// 无论规格变更是否成功，都需要将原本运行中的云服务器重新开机，避免主机一直处于关机状态
+		defer func() {
+			startOpt := &typecvm.HuaWeiStartOption{Region: opt.Region, CloudIDs: []string{opt.CloudID}}
+			if startErr := h.StartCvm(kt, startOpt); startErr != nil {
+				logs.Errorf("start cvm after changing instance type failed, err: %v, id: %s, rid: %s", startErr,
+					opt.CloudID, kt.Rid)
+				if err == nil {
+					err = startErr
+				}
+			}
+		}()
+	}
+
+	req := &model.ResizeServerRequest{
+		ServerId: opt.CloudID,
+		Body: &model.ResizeServerRequestBody{
+			Resize: &model.ResizePrePaidServerOption{
+				FlavorRef:   opt.InstanceType,
+				Extendparam: &model.ResizeServerExtendParam{IsAutoPay: converter.ValToPtr("true")},
+			},
+		},
+	}
+	if _, err = client.ResizeServer(req); err != nil {
+		logs.Errorf("resize huawei cvm failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	// 包年包月实例变更规格时返回的是订单ID，不一定有任务ID，统一通过实例规格判断变更是否完成
+	resizePoller := poller.Poller[*HuaWei, []model.ServerDetail, poller.BaseDoneResult]{
+		Handler: &resizeCvmPollingHandler{region: opt.Region, flavor: opt.InstanceType},
+	}
+	result, err := resizePoller.PollUntilDone(h, kt, []*string{converter.ValToPtr(opt.CloudID)},
+		types.NewModifyCvmPollerOpt())
+	if err != nil {
+		return err
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		if len(result.FailedMessage) != 0 {
+			return fmt.Errorf("resize cvm %s failed, err: %s", opt.CloudID, result.FailedMessage)
+		}
+		return fmt.Errorf("resize cvm %s is not finished in time", opt.CloudID)
+	}
+
+	return nil
+}
+
+// stopCvmAndWait soft stop cvm and wait until it is stopped.
+func (h *HuaWei) stopCvmAndWait(kt *kit.Kit, region, cloudID string) error {
+	client, err := h.clientSet.ecsClient(region)
+	if err != nil {
+		return fmt.Errorf("new ecs client failed, err: %v", err)
+	}
+
+	stopType := model.GetBatchStopServersOptionTypeEnum().SOFT
+	req := &model.BatchStopServersRequest{
+		Body: &model.BatchStopServersRequestBody{
+			OsStop: &model.BatchStopServersOption{
+				Type:    &stopType,
+				Servers: []model.ServerId{{Id: cloudID}},
+			},
+		},
+	}
+	if _, err = client.BatchStopServers(req); err != nil {
+		logs.Errorf("stop huawei cvm failed, err: %v, id: %s, rid: %s", err, cloudID, kt.Rid)
+		return err
+	}
+
+	stopPoller := poller.Poller[*HuaWei, []model.ServerDetail, poller.BaseDoneResult]{
+		Handler: &stopCvmPollingHandler{region},
+	}
+	result, err := stopPoller.PollUntilDone(h, kt, []*string{converter.ValToPtr(cloudID)},
+		types.NewModifyCvmPollerOpt())
+	if err != nil {
+		return err
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		return fmt.Errorf("cvm %s is not stopped in time", cloudID)
+	}
+
+	return nil
+}
+
+// ResizeCvmDisk resize volume attached to cvm, prepaid volume is paid automatically.
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2024.html
+func (h *HuaWei) ResizeCvmDisk(kt *kit.Kit, opt *typecvm.HuaWeiResizeDiskOption) error {
+
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "resize disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new evs client failed, err: %v", err)
+	}
+
+	autoPay := evsmodel.GetBssParamForResizeVolumeIsAutoPayEnum().TRUE
+	req := &evsmodel.ResizeVolumeRequest{
+		VolumeId: opt.CloudDiskID,
+		Body: &evsmodel.ResizeVolumeRequestBody{
+			BssParam: &evsmodel.BssParamForResizeVolume{IsAutoPay: &autoPay},
+			OsExtend: &evsmodel.OsExtend{NewSize: int32(opt.DiskSize)},
+		},
+	}
+	if _, err = client.ResizeVolume(req); err != nil {
+		logs.Errorf("resize huawei volume failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	respPoller := poller.Poller[*HuaWei, []*evsmodel.VolumeDetail, poller.BaseDoneResult]{
+		Handler: &resizeVolumePollingHandler{region: opt.Region, size: int32(opt.DiskSize)},
+	}
+	result, err := respPoller.PollUntilDone(h, kt, []*string{converter.ValToPtr(opt.CloudDiskID)},
+		types.NewModifyCvmPollerOpt())
+	if err != nil {
+		return err
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		if len(result.FailedMessage) != 0 {
+			return fmt.Errorf("resize volume %s failed, err: %s", opt.CloudDiskID, result.FailedMessage)
+		}
+		return fmt.Errorf("resize volume %s is not finished in time", opt.CloudDiskID)
+	}
+
+	return nil
+}
+
+// RenameCvm rename cvm.
+// reference: https://support.huaweicloud.com/api-ecs/ecs_02_0104.html
+func (h *HuaWei) RenameCvm(kt *kit.Kit, opt *typecvm.HuaWeiRenameOption) error {
+
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "rename option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.ecsClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new ecs client failed, err: %v", err)
+	}
+
+	req := &model.UpdateServerRequest{
+		ServerId: opt.CloudID,
+		Body: &model.UpdateServerRequestBody{
+			Server: &model.UpdateServerOption{Name: converter.ValToPtr(opt.Name)},
+		},
+	}
+	if _, err = client.UpdateServer(req); err != nil {
+		logs.Errorf("rename huawei cvm failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ChangeCvmSecurityGroup replace all security groups bound to cvm. huawei only supports adding or removing one
+// security group at a time, new security groups are added before old ones are removed, so that the cvm is never
+// left without security group.
+// reference: https://support.huaweicloud.com/api-ecs/ecs_03_0601.html
+func (h *HuaWei) ChangeCvmSecurityGroup(kt *kit.Kit, opt *typecvm.HuaWeiChangeSecurityGroupOption) error {
+
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "change security group option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.ecsClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new ecs client failed, err: %v", err)
+	}
+
+	servers, err := poll(h, kt, opt.Region, []*string{converter.ValToPtr(opt.CloudID)})
+	if err != nil {
+		logs.Errorf("get huawei cvm failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	if len(servers) == 0 {
+		return errf.Newf(errf.RecordNotFound, "cvm %s not found from cloud", opt.CloudID)
+	}
+
+	boundIDs := make([]string, 0, len(servers[0].SecurityGroups))
+	for _, one := range servers[0].SecurityGroups {
+		boundIDs = append(boundIDs, one.Id)
+	}
+
+	for _, id := range opt.CloudSecurityGroupIDs {
+		if slice.IsItemInSlice(boundIDs, id) {
+			continue
+		}
+
+		req := &model.NovaAssociateSecurityGroupRequest{
+			ServerId: opt.CloudID,
+			Body: &model.NovaAssociateSecurityGroupRequestBody{
+				AddSecurityGroup: &model.NovaAddSecurityGroupOption{Name: id},
+			},
+		}
+		if _, err = client.NovaAssociateSecurityGroup(req); err != nil {
+			logs.Errorf("associate huawei security group failed, err: %v, cvm: %s, sg: %s, rid: %s", err,
+				opt.CloudID, id, kt.Rid)
+			return err
+		}
+	}
+
+	for _, id := range boundIDs {
+		if slice.IsItemInSlice(opt.CloudSecurityGroupIDs, id) {
+			continue
+		}
+
+		req := &model.NovaDisassociateSecurityGroupRequest{
+			ServerId: opt.CloudID,
+			Body: &model.NovaDisassociateSecurityGroupRequestBody{
+				RemoveSecurityGroup: &model.NovaRemoveSecurityGroupOption{Name: id},
+			},
+		}
+		if _, err = client.NovaDisassociateSecurityGroup(req); err != nil {
+			logs.Errorf("disassociate huawei security group failed, err: %v, cvm: %s, sg: %s, rid: %s", err,
+				opt.CloudID, id, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+type resizeCvmPollingHandler struct {
+	region string
+	flavor string
+}
+
+// Done ...
+func (h *resizeCvmPollingHandler) Done(cvms []model.ServerDetail) (bool, *poller.BaseDoneResult) {
+	result := new(poller.BaseDoneResult)
+
+	flag := true
+	for _, instance := range cvms {
+		if instance.Status == "ERROR" {
+			result.FailedCloudIDs = append(result.FailedCloudIDs, instance.Id)
+			result.FailedMessage = fmt.Sprintf("cvm %s status is ERROR", instance.Id)
+			continue
+		}
+
+		// 变更规格过程中实例状态为 RESIZE，完成后恢复为关机状态
+		if instance.Flavor == nil || instance.Flavor.Id != h.flavor || instance.Status != "SHUTOFF" {
+			flag = false
+			continue
+		}
+
+		result.SuccessCloudIDs = append(result.SuccessCloudIDs, instance.Id)
+	}
+
+	return flag, result
+}
+
+// Poll ...
+func (h *resizeCvmPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*string) ([]model.ServerDetail,
+	error) {
+
+	return poll(client, kt, h.region, cloudIDs)
+}
+
+type resizeVolumePollingHandler struct {
+	region string
+	size   int32
+}
+
+// Done ...
+func (h *resizeVolumePollingHandler) Done(volumes []*evsmodel.VolumeDetail) (bool, *poller.BaseDoneResult) {
+	result := new(poller.BaseDoneResult)
+
+	flag := true
+	for _, volume := range volumes {
+		switch {
+		case volume.Status == "error_extending":
+			result.FailedCloudIDs = append(result.FailedCloudIDs, volume.Id)
+			result.FailedMessage = fmt.Sprintf("volume %s status is error_extending", volume.Id)
+		case volume.Size == h.size && volume.Status != "extending":
+			result.SuccessCloudIDs = append(result.SuccessCloudIDs, volume.Id)
+		default:
+			flag = false
+		}
+	}
+
+	return flag, result
+}
+
+// Poll ...
+func (h *resizeVolumePollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*string) (
+	[]*evsmodel.VolumeDetail, error) {
+
+	evsCli, err := client.clientSet.evsClient(h.region)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]*evsmodel.VolumeDetail, 0, len(cloudIDs))
+	for _, id := range cloudIDs {
+		resp, err := evsCli.ShowVolume(&evsmodel.ShowVolumeRequest{VolumeId: converter.PtrToVal(id)})
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.Volume != nil {
+			volumes = append(volumes, resp.Volume)
+		}
+	}
+
+	return volumes, nil
+}
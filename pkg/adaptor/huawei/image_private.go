@@ -0,0 +1,323 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/image"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	iamregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/iam/v3/region"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ims/v2/model"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ims/v2/region"
+)
+
+// ListPrivateImage 查询私有镜像列表
+// reference: https://support.huaweicloud.com/api-ims/ims_03_0602.html
+func (h *HuaWei) ListPrivateImage(kt *kit.Kit, opt *image.HuaWeiPrivateImageListOption) (
+	*image.HuaWeiImageListResult, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.imsClientV2(region.ValueOf(opt.Region))
+	if err != nil {
+		return nil, err
+	}
+
+	privateType := model.GetListImagesRequestImagetypeEnum().PRIVATE
+	req := &model.ListImagesRequest{
+		Imagetype: &privateType,
+	}
+
+	if opt.CloudID != "" {
+		req.Id = converter.ValToPtr(opt.CloudID)
+	}
+
+	if opt.Page != nil {
+		req.Marker = opt.Page.Marker
+		req.Limit = opt.Page.Limit
+	}
+
+	resp, err := client.ListImages(req)
+	if err != nil {
+		logs.Errorf("list huawei private image failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	images := make([]image.HuaWeiImage, 0)
+	for _, pImage := range converter.PtrToVal(resp.Images) {
+		platform := ""
+		if pImage.Platform != nil {
+			platform = pImage.Platform.Value()
+		}
+
+		images = append(images, image.HuaWeiImage{
+			CloudID:      pImage.Id,
+			Name:         pImage.Name,
+			Architecture: changeArchitecture(pImage.OsBit),
+			Platform:     platform,
+			State:        pImage.Status.Value(),
+			Type:         constant.PrivateImageType,
+		})
+	}
+	return &image.HuaWeiImageListResult{Details: images}, nil
+}
+
+// CreateImage 基于云服务器创建私有镜像，创建为异步任务，等待任务完成后返回镜像云ID
+// reference: https://support.huaweicloud.com/api-ims/ims_03_0603.html
+func (h *HuaWei) CreateImage(kt *kit.Kit, opt *image.HuaWeiImageCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.imsClientV2(region.ValueOf(opt.Region))
+	if err != nil {
+		return "", err
+	}
+
+	req := &model.CreateImageRequest{
+		Body: &model.CreateImageRequestBody{
+			Name:        opt.Name,
+			InstanceId:  converter.ValToPtr(opt.CloudCvmID),
+			Description: opt.Description,
+		},
+	}
+
+	resp, err := client.CreateImage(req)
+	if err != nil {
+		logs.Errorf("create huawei image failed, err: %v, cvm: %s, rid: %s", err, opt.CloudCvmID, kt.Rid)
+		return "", err
+	}
+
+	handler := &imsJobPollingHandler{region: opt.Region}
+	respPoller := poller.Poller[*HuaWei, *model.ShowJobResponse, poller.BaseDoneResult]{Handler: handler}
+	result, err := respPoller.PollUntilDone(h, kt, []*string{resp.JobId}, types.NewCreateImagePollerOpt())
+	if err != nil {
+		return "", err
+	}
+
+	if len(result.FailedCloudIDs) != 0 {
+		return "", errf.Newf(errf.Aborted, "create huawei image failed, job: %s, reason: %s",
+			converter.PtrToVal(resp.JobId), result.FailedMessage)
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		return "", fmt.Errorf("create huawei image job %s not finished in time", converter.PtrToVal(resp.JobId))
+	}
+
+	return result.SuccessCloudIDs[0], nil
+}
+
+// CopyImage 跨区域复制私有镜像，复制为异步任务，目标地域镜像ID需要通过同步获取
+// reference: https://support.huaweicloud.com/api-ims/ims_03_1001.html
+func (h *HuaWei) CopyImage(kt *kit.Kit, opt *image.HuaWeiImageCopyOption) (image.CopyImageResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "copy option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.imsClientV2(region.ValueOf(opt.Region))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(image.CopyImageResult, len(opt.DestinationRegions))
+	for _, destRegion := range opt.DestinationRegions {
+		req := &model.CopyImageCrossRegionRequest{
+			ImageId: opt.CloudID,
+			Body: &model.CopyImageCrossRegionRequestBody{
+				AgencyName:  opt.AgencyName,
+				Name:        opt.Name,
+				ProjectName: destRegion,
+				Region:      destRegion,
+			},
+		}
+
+		if _, err = client.CopyImageCrossRegion(req); err != nil {
+			logs.Errorf("copy huawei image failed, err: %v, image: %s, dest region: %s, rid: %s", err,
+				opt.CloudID, destRegion, kt.Rid)
+			return result, err
+		}
+
+		result[destRegion] = ""
+	}
+
+	return result, nil
+}
+
+// ShareImage 共享私有镜像给其他项目
+// reference: https://support.huaweicloud.com/api-ims/ims_03_0623.html
+func (h *HuaWei) ShareImage(kt *kit.Kit, opt *image.HuaWeiImageShareOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "share option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.imsClientV2(region.ValueOf(opt.Region))
+	if err != nil {
+		return err
+	}
+
+	req := &model.BatchAddMembersRequest{
+		Body: &model.BatchAddMembersRequestBody{
+			Images:   []string{opt.CloudID},
+			Projects: opt.CloudProjectIDs,
+		},
+	}
+
+	if _, err = client.BatchAddMembers(req); err != nil {
+		logs.Errorf("share huawei image failed, err: %v, image: %s, projects: %v, rid: %s", err, opt.CloudID,
+			opt.CloudProjectIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteImage 删除私有镜像
+// reference: https://support.huaweicloud.com/api-ims/ims_03_0605.html
+func (h *HuaWei) DeleteImage(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.imsClientV2(region.ValueOf(opt.Region))
+	if err != nil {
+		return err
+	}
+
+	req := &model.GlanceDeleteImageRequest{
+		ImageId: opt.ResourceID,
+	}
+
+	if _, err = client.GlanceDeleteImage(req); err != nil {
+		logs.Errorf("delete huawei image failed, err: %v, image: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// GetRegionProjectID 获取当前账号在指定地域下的项目ID，镜像共享等接口需要以项目作为目标
+// reference: https://support.huaweicloud.com/api-iam/iam_06_0001.html
+func (h *HuaWei) GetRegionProjectID(kt *kit.Kit, regionID string) (string, error) {
+	client, err := h.clientSet.iamClient(iamregion.AP_SOUTHEAST_1)
+	if err != nil {
+		logs.Errorf("init huawei iam client failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	resp, err := client.KeystoneListAuthProjects(nil)
+	if err != nil {
+		logs.Errorf("list huawei auth projects failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	for _, project := range converter.PtrToVal(resp.Projects) {
+		if project.Name == regionID {
+			return project.Id, nil
+		}
+	}
+
+	return "", errf.Newf(errf.RecordNotFound, "project of region %s not found", regionID)
+}
+
+type imsJobPollingHandler struct {
+	region string
+}
+
+// Done ...
+func (h *imsJobPollingHandler) Done(job *model.ShowJobResponse) (bool, *poller.BaseDoneResult) {
+	result := &poller.BaseDoneResult{
+		SuccessCloudIDs: make([]string, 0),
+		FailedCloudIDs:  make([]string, 0),
+		UnknownCloudIDs: make([]string, 0),
+	}
+
+	if job == nil || job.Status == nil {
+		return false, result
+	}
+
+	switch job.Status.Value() {
+	case model.GetShowJobResponseStatusEnum().SUCCESS.Value():
+		if job.Entities == nil || job.Entities.ImageId == nil {
+			return false, result
+		}
+		result.SuccessCloudIDs = append(result.SuccessCloudIDs, *job.Entities.ImageId)
+		return true, result
+	case model.GetShowJobResponseStatusEnum().FAIL.Value():
+		result.FailedCloudIDs = append(result.FailedCloudIDs, converter.PtrToVal(job.JobId))
+		result.FailedMessage = converter.PtrToVal(job.FailReason)
+		return true, result
+	default:
+		return false, result
+	}
+}
+
+// Poll ...
+func (h *imsJobPollingHandler) Poll(client *HuaWei, kt *kit.Kit, jobIDs []*string) (*model.ShowJobResponse,
+	error) {
+
+	if len(jobIDs) == 0 {
+		return nil, errors.New("job id is required")
+	}
+
+	imsCli, err := client.clientSet.imsClientV2(region.ValueOf(h.region))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := imsCli.ShowJob(&model.ShowJobRequest{JobId: converter.PtrToVal(jobIDs[0])})
+	if err != nil {
+		logs.Errorf("show huawei ims job failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return resp, nil
+}
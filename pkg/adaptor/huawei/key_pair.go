@@ -0,0 +1,142 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	typekp "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2/model"
+)
+
+// ImportKeyPair import public key as key pair. huawei key pair has no id, so the key pair name is returned as
+// cloud id.
+// reference: https://support.huaweicloud.com/api-ecs/zh-cn_topic_0020212678.html
+func (h *HuaWei) ImportKeyPair(kt *kit.Kit, opt *typekp.ImportOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "import option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.ecsClient(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	req := &model.NovaCreateKeypairRequest{
+		Body: &model.NovaCreateKeypairRequestBody{
+			Keypair: &model.NovaCreateKeypairOption{
+				Name:      opt.Name,
+				PublicKey: converter.ValToPtr(opt.PublicKey),
+			},
+		},
+	}
+	resp, err := client.NovaCreateKeypair(req)
+	if err != nil {
+		logs.Errorf("import huawei key pair failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return "", err
+	}
+
+	if resp.Keypair == nil {
+		return opt.Name, nil
+	}
+
+	return resp.Keypair.Name, nil
+}
+
+// ListKeyPair list key pair. next page marker is the name of last key pair.
+// reference: https://support.huaweicloud.com/api-ecs/zh-cn_topic_0020212676.html
+func (h *HuaWei) ListKeyPair(kt *kit.Kit, opt *typekp.HuaWeiListOption) ([]typekp.KeyPair, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.ecsClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(model.NovaListKeypairsRequest)
+	if opt.Page != nil {
+		req.Limit = opt.Page.Limit
+		req.Marker = opt.Page.Marker
+	}
+
+	resp, err := client.NovaListKeypairs(req)
+	if err != nil {
+		logs.Errorf("list huawei key pair failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if resp.Keypairs == nil {
+		return make([]typekp.KeyPair, 0), nil
+	}
+
+	keyPairs := make([]typekp.KeyPair, 0, len(*resp.Keypairs))
+	for _, one := range *resp.Keypairs {
+		if one.Keypair == nil {
+			continue
+		}
+
+		keyPairs = append(keyPairs, typekp.KeyPair{
+			CloudID:     one.Keypair.Name,
+			Name:        one.Keypair.Name,
+			Region:      opt.Region,
+			Fingerprint: one.Keypair.Fingerprint,
+		})
+	}
+
+	return keyPairs, nil
+}
+
+// DeleteKeyPair delete key pair, resource id is the key pair name.
+// reference: https://support.huaweicloud.com/api-ecs/zh-cn_topic_0020212679.html
+func (h *HuaWei) DeleteKeyPair(kt *kit.Kit, opt *typekp.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.ecsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &model.NovaDeleteKeypairRequest{KeypairName: opt.ResourceID}
+	if _, err = client.NovaDeleteKeypair(req); err != nil {
+		logs.Errorf("delete huawei key pair failed, err: %v, name: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v3/model"
+)
+
+// ListLoadBalancer list load balancer, return next marker for paging.
+// reference: https://support.huaweicloud.com/api-elb/ListLoadBalancers.html
+func (h *HuaWei) ListLoadBalancer(kt *kit.Kit, opt *core.HuaWeiListOption) ([]typelb.HuaWeiLoadBalancer, *string,
+	error) {
+
+	if opt == nil {
+		return nil, nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := new(model.ListLoadBalancersRequest)
+	if len(opt.CloudIDs) != 0 {
+		req.Id = &opt.CloudIDs
+	}
+
+	if opt.Page != nil {
+		req.Limit = opt.Page.Limit
+		req.Marker = opt.Page.Marker
+	}
+
+	resp, err := client.ListLoadBalancers(req)
+	if err != nil {
+		logs.Errorf("list huawei load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, nil, err
+	}
+
+	if resp.Loadbalancers == nil {
+		return make([]typelb.HuaWeiLoadBalancer, 0), nil, nil
+	}
+
+	lbs := make([]typelb.HuaWeiLoadBalancer, 0, len(*resp.Loadbalancers))
+	for _, one := range *resp.Loadbalancers {
+		lb := typelb.HuaWeiLoadBalancer{
+			CloudID:              one.Id,
+			Name:                 one.Name,
+			Region:               opt.Region,
+			Zones:                one.AvailabilityZoneList,
+			LBType:               enumor.IntranetLoadBalancer,
+			Status:               one.OperatingStatus,
+			CloudVpcID:           one.VpcId,
+			PrivateIPv4Addresses: make([]string, 0),
+			PublicIPv4Addresses:  make([]string, 0),
+			CloudCreatedTime:     one.CreatedAt,
+			Extension: &corelb.HuaWeiLoadBalancerExtension{
+				Provider:            converter.ValToPtr(one.Provider),
+				Guaranteed:          converter.ValToPtr(one.Guaranteed),
+				CloudVipSubnetID:    converter.ValToPtr(one.VipSubnetCidrId),
+				CloudVipPortID:      converter.ValToPtr(one.VipPortId),
+				L4FlavorID:          converter.ValToPtr(one.L4FlavorId),
+				L7FlavorID:          converter.ValToPtr(one.L7FlavorId),
+				EnterpriseProjectID: converter.ValToPtr(one.EnterpriseProjectId),
+			},
+		}
+
+		if len(one.Description) != 0 {
+			lb.Memo = converter.ValToPtr(one.Description)
+		}
+
+		if len(one.VipAddress) != 0 {
+			lb.PrivateIPv4Addresses = append(lb.PrivateIPv4Addresses, one.VipAddress)
+		}
+
+		// 华为云负载均衡没有公网、内网类型之分，绑定了弹性公网IP的视为公网负载均衡
+		for _, publicIP := range one.Publicips {
+			lb.PublicIPv4Addresses = append(lb.PublicIPv4Addresses, publicIP.PublicipAddress)
+			lb.Extension.CloudEipIDs = append(lb.Extension.CloudEipIDs, publicIP.PublicipId)
+		}
+
+		if len(lb.PublicIPv4Addresses) != 0 {
+			lb.LBType = enumor.InternetLoadBalancer
+		}
+
+		lbs = append(lbs, lb)
+	}
+
+	var nextMarker *string
+	if resp.PageInfo != nil {
+		nextMarker = resp.PageInfo.NextMarker
+	}
+
+	return lbs, nextMarker, nil
+}
+
+// ListLoadBalancerListener list all listeners of load balancer, targets are members of listener default pool.
+// reference: https://support.huaweicloud.com/api-elb/ListListeners.html
+// reference: https://support.huaweicloud.com/api-elb/ListMembers.html
+func (h *HuaWei) ListLoadBalancerListener(kt *kit.Kit, opt *typelb.ListenerListOption) ([]typelb.HuaWeiListener,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	listeners := make([]typelb.HuaWeiListener, 0)
+	req := &model.ListListenersRequest{
+		LoadbalancerId: &[]string{opt.CloudLbID},
+		Limit:          converter.ValToPtr(int32(core.HuaWeiQueryLimit)),
+	}
+	for {
+		resp, err := client.ListListeners(req)
+		if err != nil {
+			logs.Errorf("list huawei load balancer listener failed, err: %v, lb: %s, rid: %s", err, opt.CloudLbID,
+				kt.Rid)
+			return nil, err
+		}
+
+		if resp.Listeners == nil || len(*resp.Listeners) == 0 {
+			break
+		}
+
+		for _, one := range *resp.Listeners {
+			listener := typelb.HuaWeiListener{
+				CloudID:  one.Id,
+				Name:     one.Name,
+				Protocol: one.Protocol,
+				Port:     int64(one.ProtocolPort),
+				Extension: &corelb.HuaWeiListenerExtension{
+					ConnectionLimit: converter.ValToPtr(one.ConnectionLimit),
+					Http2Enable:     converter.ValToPtr(one.Http2Enable),
+				},
+				Targets: make([]*typelb.HuaWeiTarget, 0),
+			}
+
+			if len(one.DefaultPoolId) != 0 {
+				listener.Extension.CloudDefaultPoolID = converter.ValToPtr(one.DefaultPoolId)
+
+				listener.Targets, err = h.listPoolMember(kt, opt.Region, one.DefaultPoolId)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			listeners = append(listeners, listener)
+		}
+
+		if resp.PageInfo == nil || resp.PageInfo.NextMarker == nil {
+			break
+		}
+		req.Marker = resp.PageInfo.NextMarker
+	}
+
+	return listeners, nil
+}
+
+func (h *HuaWei) listPoolMember(kt *kit.Kit, region, poolID string) ([]*typelb.HuaWeiTarget, error) {
+	client, err := h.clientSet.elbClient(region)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]*typelb.HuaWeiTarget, 0)
+	req := &model.ListMembersRequest{PoolId: poolID, Limit: converter.ValToPtr(int32(core.HuaWeiQueryLimit))}
+	for {
+		resp, err := client.ListMembers(req)
+		if err != nil {
+			logs.Errorf("list huawei pool member failed, err: %v, pool: %s, rid: %s", err, poolID, kt.Rid)
+			return nil, err
+		}
+
+		if resp.Members == nil || len(*resp.Members) == 0 {
+			break
+		}
+
+		for _, one := range *resp.Members {
+			target := &typelb.HuaWeiTarget{
+				TargetType:    enumor.InstanceLBTargetType,
+				CloudTargetID: converter.PtrToVal(one.InstanceId),
+				IP:            one.Address,
+				Port:          int64(one.ProtocolPort),
+				Weight:        int64(one.Weight),
+				Extension: &corelb.HuaWeiTargetExtension{
+					CloudPoolID:     converter.ValToPtr(poolID),
+					CloudSubnetID:   one.SubnetCidrId,
+					OperatingStatus: converter.ValToPtr(one.OperatingStatus),
+				},
+			}
+
+			// 跨VPC后端或未关联实例的后端只有IP
+			if len(target.CloudTargetID) == 0 {
+				target.TargetType = enumor.IPLBTargetType
+				target.CloudTargetID = one.Address
+			}
+
+			targets = append(targets, target)
+		}
+
+		if resp.PageInfo == nil || resp.PageInfo.NextMarker == nil {
+			break
+		}
+		req.Marker = resp.PageInfo.NextMarker
+	}
+
+	return targets, nil
+}
+
+// CreateLoadBalancer create load balancer.
+// reference: https://support.huaweicloud.com/api-elb/CreateLoadBalancer.html
+func (h *HuaWei) CreateLoadBalancer(kt *kit.Kit, opt *typelb.HuaWeiCreateOption) (*poller.BaseDoneResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	lbOpt := &model.CreateLoadBalancerOption{
+		Name:                 opt.Name,
+		VpcId:                opt.CloudVpcID,
+		VipSubnetCidrId:      opt.CloudVipSubnetID,
+		AvailabilityZoneList: opt.Zones,
+		L4FlavorId:           opt.L4FlavorID,
+		L7FlavorId:           opt.L7FlavorID,
+	}
+	if len(opt.CloudEipIDs) != 0 {
+		lbOpt.PublicipIds = &opt.CloudEipIDs
+	}
+
+	req := &model.CreateLoadBalancerRequest{Body: &model.CreateLoadBalancerRequestBody{Loadbalancer: lbOpt}}
+	resp, err := client.CreateLoadBalancer(req)
+	if err != nil {
+		logs.Errorf("create huawei load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if resp.LoadbalancerId == nil {
+		return nil, errf.New(errf.Aborted, "create huawei load balancer but no load balancer id returned")
+	}
+
+	return &poller.BaseDoneResult{SuccessCloudIDs: []string{*resp.LoadbalancerId}}, nil
+}
+
+// DeleteLoadBalancer delete load balancer.
+// reference: https://support.huaweicloud.com/api-elb/DeleteLoadBalancer.html
+func (h *HuaWei) DeleteLoadBalancer(kt *kit.Kit, opt *typelb.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.DeleteLoadBalancer(&model.DeleteLoadBalancerRequest{LoadbalancerId: opt.ResourceID}); err != nil {
+		logs.Errorf("delete huawei load balancer failed, err: %v, id: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/nat/v2/model"
+)
+
+// ListNatGateway list public nat gateway. huawei nat gateway list api does not support paging by marker, and only
+// supports query by one id, so cloud ids are queried one by one, otherwise query with the limit of page.
+// reference: https://support.huaweicloud.com/api-natgateway/ListNatGateways.html
+func (h *HuaWei) ListNatGateway(kt *kit.Kit, opt *core.HuaWeiListOption) ([]typenat.HuaWeiNatGateway, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.natClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make([]*model.ListNatGatewaysRequest, 0)
+	if len(opt.CloudIDs) != 0 {
+		for _, id := range opt.CloudIDs {
+			reqs = append(reqs, &model.ListNatGatewaysRequest{Id: converter.ValToPtr(id)})
+		}
+	} else {
+		reqs = append(reqs, &model.ListNatGatewaysRequest{Limit: opt.Page.Limit})
+	}
+
+	gateways := make([]model.NatGatewayResponseBody, 0)
+	for _, req := range reqs {
+		resp, err := client.ListNatGateways(req)
+		if err != nil {
+			logs.Errorf("list huawei nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		if resp.NatGateways != nil {
+			gateways = append(gateways, *resp.NatGateways...)
+		}
+	}
+
+	if len(gateways) == 0 {
+		return make([]typenat.HuaWeiNatGateway, 0), nil
+	}
+
+	cloudIDs := make([]string, 0, len(gateways))
+	for _, one := range gateways {
+		cloudIDs = append(cloudIDs, one.Id)
+	}
+
+	snatRuleMap, err := h.listNatGatewaySnatRule(kt, opt.Region, cloudIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	nats := make([]typenat.HuaWeiNatGateway, 0, len(gateways))
+	for _, one := range gateways {
+		nat := typenat.HuaWeiNatGateway{
+			CloudID:          one.Id,
+			Name:             one.Name,
+			Region:           opt.Region,
+			NatType:          enumor.PublicNatGateway,
+			Status:           one.Status.Value(),
+			CloudVpcID:       one.RouterId,
+			CloudSubnetID:    one.InternalNetworkId,
+			CloudCreatedTime: one.CreatedAt,
+			Extension: &corenat.HuaWeiNatGatewayExtension{
+				Spec: converter.ValToPtr(one.Spec.Value()),
+			},
+		}
+
+		if len(one.Description) != 0 {
+			nat.Memo = converter.ValToPtr(one.Description)
+		}
+
+		if len(one.EnterpriseProjectId) != 0 {
+			nat.Extension.EnterpriseProjectID = converter.ValToPtr(one.EnterpriseProjectId)
+		}
+
+		for _, rule := range snatRuleMap[one.Id] {
+			nat.Extension.CloudSnatRuleIDs = append(nat.Extension.CloudSnatRuleIDs, rule.Id)
+			nat.CloudEipIDs = append(nat.CloudEipIDs, splitHuaWeiSnatRuleField(rule.FloatingIpId)...)
+			nat.PublicIPAddresses = append(nat.PublicIPAddresses, splitHuaWeiSnatRuleField(rule.FloatingIpAddress)...)
+		}
+		nat.CloudEipIDs = slice.Unique(nat.CloudEipIDs)
+		nat.PublicIPAddresses = slice.Unique(nat.PublicIPAddresses)
+
+		nats = append(nats, nat)
+	}
+
+	return nats, nil
+}
+
+// listNatGatewaySnatRule list snat rules of nat gateways, nat gateway is associated with eip through snat rule.
+// reference: https://support.huaweicloud.com/api-natgateway/ListNatGatewaySnatRules.html
+func (h *HuaWei) listNatGatewaySnatRule(kt *kit.Kit, region string, cloudNatIDs []string) (
+	map[string][]model.NatGatewaySnatRuleResponseBody, error) {
+
+	client, err := h.clientSet.natClient(region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &model.ListNatGatewaySnatRulesRequest{
+		NatGatewayId: &cloudNatIDs,
+		Limit:        converter.ValToPtr(int32(core.HuaWeiQueryLimit)),
+	}
+	resp, err := client.ListNatGatewaySnatRules(req)
+	if err != nil {
+		logs.Errorf("list huawei nat gateway snat rule failed, err: %v, nat ids: %v, rid: %s", err, cloudNatIDs,
+			kt.Rid)
+		return nil, err
+	}
+
+	result := make(map[string][]model.NatGatewaySnatRuleResponseBody)
+	if resp.SnatRules == nil {
+		return result, nil
+	}
+
+	for _, one := range *resp.SnatRules {
+		result[one.NatGatewayId] = append(result[one.NatGatewayId], one)
+	}
+
+	return result, nil
+}
+
+// splitHuaWeiSnatRuleField snat规则绑定多个弹性公网IP时，ID和地址字段使用逗号分隔
+func splitHuaWeiSnatRuleField(field string) []string {
+	result := make([]string, 0)
+	for _, one := range strings.Split(field, ",") {
+		one = strings.TrimSpace(one)
+		if len(one) != 0 {
+			result = append(result, one)
+		}
+	}
+
+	return result
+}
+
+// CreateNatGateway create public nat gateway.
+// reference: https://support.huaweicloud.com/api-natgateway/CreateNatGateway.html
+func (h *HuaWei) CreateNatGateway(kt *kit.Kit, opt *typenat.HuaWeiCreateOption) (*poller.BaseDoneResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	spec, err := convHuaWeiNatGatewaySpec(opt.Spec)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.natClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &model.CreateNatGatewayRequest{
+		Body: &model.CreateNatGatewayRequestBody{
+			NatGateway: &model.CreateNatGatewayOption{
+				Name:                opt.Name,
+				RouterId:            opt.CloudVpcID,
+				InternalNetworkId:   opt.CloudSubnetID,
+				Description:         opt.Memo,
+				Spec:                spec,
+				EnterpriseProjectId: opt.EnterpriseProjectID,
+			},
+		},
+	}
+	resp, err := client.CreateNatGateway(req)
+	if err != nil {
+		logs.Errorf("create huawei nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if resp.NatGateway == nil {
+		return nil, errf.New(errf.Aborted, "create huawei nat gateway but no nat gateway returned")
+	}
+
+	return &poller.BaseDoneResult{SuccessCloudIDs: []string{resp.NatGateway.Id}}, nil
+}
+
+func convHuaWeiNatGatewaySpec(spec string) (model.CreateNatGatewayOptionSpec, error) {
+	enum := model.GetCreateNatGatewayOptionSpecEnum()
+	for _, one := range []model.CreateNatGatewayOptionSpec{enum.E_1, enum.E_2, enum.E_3, enum.E_4} {
+		if one.Value() == spec {
+			return one, nil
+		}
+	}
+
+	return model.CreateNatGatewayOptionSpec{}, fmt.Errorf("unsupported huawei nat gateway spec: %s", spec)
+}
+
+// DeleteNatGateway delete public nat gateway.
+// reference: https://support.huaweicloud.com/api-natgateway/DeleteNatGateway.html
+func (h *HuaWei) DeleteNatGateway(kt *kit.Kit, opt *typenat.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.natClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &model.DeleteNatGatewayRequest{NatGatewayId: opt.ResourceID}
+	if _, err = client.DeleteNatGateway(req); err != nil {
+		logs.Errorf("delete huawei nat gateway failed, err: %v, id: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,362 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/pkg/adaptor/operator"
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/adaptor/types/disk"
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+// huaweiTagResTypes resource types which support tag of huawei, huawei security group does not support tag.
+var huaweiTagResTypes = []enumor.CloudResourceType{
+	enumor.CvmCloudResType,
+	enumor.DiskCloudResType,
+	enumor.VpcCloudResType,
+	enumor.SubnetCloudResType,
+	enumor.EipCloudResType,
+}
+
+func init() {
+	operator.Register(enumor.HuaWei, func(cred *operator.Credential) (operator.Operator, error) {
+		cli, err := NewHuaWei(cred.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return &huaweiOperator{cli: cli}, nil
+	})
+}
+
+// huaweiOperator implements operator.Operator by huawei adaptor.
+type huaweiOperator struct {
+	cli *HuaWei
+}
+
+// Vendor ...
+func (op *huaweiOperator) Vendor() enumor.Vendor {
+	return enumor.HuaWei
+}
+
+// ListCvm ...
+func (op *huaweiOperator) ListCvm(kt *kit.Kit, opt *operator.CvmListOption) ([]operator.Cvm, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cvms, err := op.cli.ListCvm(kt, &typecvm.HuaWeiListOption{
+		Region:   opt.Region,
+		CloudIDs: opt.CloudIDs,
+		Page:     &core.HuaWeiCvmOffsetPage{Limit: int32(len(opt.CloudIDs))},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]operator.Cvm, 0, len(cvms))
+	for _, one := range cvms {
+		cvm := operator.Cvm{
+			CloudID: one.Id,
+			Name:    one.Name,
+			Status:  one.Status,
+			Zone:    one.OSEXTAZavailabilityZone,
+		}
+		if one.Flavor != nil {
+			cvm.InstanceType = one.Flavor.Id
+		}
+		result = append(result, cvm)
+	}
+
+	return result, nil
+}
+
+// StartCvm ...
+func (op *huaweiOperator) StartCvm(kt *kit.Kit, opt *operator.CvmOperateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "start cvm option is required")
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		if err = op.cli.StartCvm(kt, &typecvm.HuaWeiStartOption{Region: region, CloudIDs: ids}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StopCvm ...
+func (op *huaweiOperator) StopCvm(kt *kit.Kit, opt *operator.CvmStopOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "stop cvm option is required")
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		stopOpt := &typecvm.HuaWeiStopOption{Region: region, CloudIDs: ids, Force: opt.Force}
+		if err = op.cli.StopCvm(kt, stopOpt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RebootCvm ...
+func (op *huaweiOperator) RebootCvm(kt *kit.Kit, opt *operator.CvmStopOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "reboot cvm option is required")
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		rebootOpt := &typecvm.HuaWeiRebootOption{Region: region, CloudIDs: ids, Force: opt.Force}
+		if err = op.cli.RebootCvm(kt, rebootOpt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteCvm ...
+func (op *huaweiOperator) DeleteCvm(kt *kit.Kit, opt *operator.CvmDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete cvm option is required")
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		deleteOpt := &typecvm.HuaWeiDeleteOption{
+			Region:         region,
+			CloudIDs:       ids,
+			DeletePublicIP: opt.DeletePublicIP,
+			DeleteVolume:   opt.DeleteDisk,
+		}
+		if err = op.cli.DeleteCvm(kt, deleteOpt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AttachDisk ...
+func (op *huaweiOperator) AttachDisk(kt *kit.Kit, opt *operator.DiskAttachOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "attach disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	attachOpt := &disk.HuaWeiDiskAttachOption{
+		Region:      opt.Disk.Region,
+		CloudCvmID:  opt.Cvm.CloudID,
+		CloudDiskID: opt.Disk.CloudID,
+	}
+	if len(opt.DeviceName) != 0 {
+		attachOpt.DeviceName = converter.ValToPtr(opt.DeviceName)
+	}
+
+	return op.cli.AttachDisk(kt, attachOpt)
+}
+
+// DetachDisk ...
+func (op *huaweiOperator) DetachDisk(kt *kit.Kit, opt *operator.DiskAttachOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "detach disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.DetachDisk(kt, &disk.HuaWeiDiskDetachOption{
+		Region:      opt.Disk.Region,
+		CloudCvmID:  opt.Cvm.CloudID,
+		CloudDiskID: opt.Disk.CloudID,
+	})
+}
+
+// DeleteDisk ...
+func (op *huaweiOperator) DeleteDisk(kt *kit.Kit, opt *operator.DiskDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.DeleteDisk(kt, &disk.HuaWeiDiskDeleteOption{Region: opt.Disk.Region, CloudID: opt.Disk.CloudID})
+}
+
+// DeleteSecurityGroup ...
+func (op *huaweiOperator) DeleteSecurityGroup(kt *kit.Kit, opt *operator.SecurityGroupDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete security group option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return op.cli.DeleteSecurityGroup(kt, &securitygroup.HuaWeiDeleteOption{
+		Region:  opt.SecurityGroup.Region,
+		CloudID: opt.SecurityGroup.CloudID,
+	})
+}
+
+// AssociateCvm ...
+func (op *huaweiOperator) AssociateCvm(kt *kit.Kit, opt *operator.SecurityGroupCvmOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "security group associate cvm option is required")
+	}
+
+	return op.cli.SecurityGroupCvmAssociate(kt, &securitygroup.HuaWeiAssociateCvmOption{
+		Region:               opt.Region,
+		CloudSecurityGroupID: opt.CloudSecurityGroupID,
+		CloudCvmID:           opt.CloudCvmID,
+	})
+}
+
+// DisassociateCvm ...
+func (op *huaweiOperator) DisassociateCvm(kt *kit.Kit, opt *operator.SecurityGroupCvmOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "security group disassociate cvm option is required")
+	}
+
+	return op.cli.SecurityGroupCvmDisassociate(kt, &securitygroup.HuaWeiAssociateCvmOption{
+		Region:               opt.Region,
+		CloudSecurityGroupID: opt.CloudSecurityGroupID,
+		CloudCvmID:           opt.CloudCvmID,
+	})
+}
+
+// TagResTypes ...
+func (op *huaweiOperator) TagResTypes() []enumor.CloudResourceType {
+	return huaweiTagResTypes
+}
+
+// ListTag ...
+func (op *huaweiOperator) ListTag(kt *kit.Kit, opt *operator.TagListOption) ([]typetag.ResourceTags, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list tag option is required")
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]typetag.ResourceTags, 0, len(opt.Resources))
+	for region, ids := range regionIDs {
+		tags, err := op.cli.ListResourceTag(kt, &typetag.ListOption{Region: region, ResType: opt.ResType,
+			CloudIDs: ids})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tags...)
+	}
+
+	return result, nil
+}
+
+// TagResource ...
+func (op *huaweiOperator) TagResource(kt *kit.Kit, opt *operator.TagResourceOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		err = op.cli.TagResource(kt, &typetag.TagOption{Region: region, ResType: opt.ResType, CloudIDs: ids,
+			Tags: opt.Tags})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UntagResource ...
+func (op *huaweiOperator) UntagResource(kt *kit.Kit, opt *operator.UntagResourceOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		return err
+	}
+
+	for region, ids := range regionIDs {
+		err = op.cli.UntagResource(kt, &typetag.UntagOption{Region: region, ResType: opt.ResType, CloudIDs: ids,
+			Keys: opt.Keys})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
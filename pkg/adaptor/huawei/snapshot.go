@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"strings"
+
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+)
+
+// ListSnapshot list disk snapshot. evs snapshot list api only supports query by one id, so cloud ids are
+// queried one by one, otherwise query with the offset page.
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2013.html
+func (h *HuaWei) ListSnapshot(kt *kit.Kit, opt *typesnap.HuaWeiListOption) ([]typesnap.HuaWeiSnapshot, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make([]*model.ListSnapshotsRequest, 0)
+	if len(opt.CloudIDs) != 0 {
+		for _, id := range opt.CloudIDs {
+			reqs = append(reqs, &model.ListSnapshotsRequest{Id: converter.ValToPtr(id)})
+		}
+	} else {
+		req := new(model.ListSnapshotsRequest)
+		if opt.Page != nil {
+			req.Offset = converter.ValToPtr(opt.Page.Offset)
+			req.Limit = converter.ValToPtr(opt.Page.Limit)
+		}
+		reqs = append(reqs, req)
+	}
+
+	snapshots := make([]typesnap.HuaWeiSnapshot, 0)
+	for _, req := range reqs {
+		resp, err := client.ListSnapshots(req)
+		if err != nil {
+			if strings.Contains(err.Error(), ErrDataNotFound) {
+				continue
+			}
+			logs.Errorf("list huawei snapshot failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		if resp.Snapshots == nil {
+			continue
+		}
+
+		for _, one := range *resp.Snapshots {
+			snapshots = append(snapshots, convHuaWeiSnapshot(one, opt.Region))
+		}
+	}
+
+	return snapshots, nil
+}
+
+func convHuaWeiSnapshot(one model.SnapshotList, region string) typesnap.HuaWeiSnapshot {
+	snapshot := typesnap.HuaWeiSnapshot{
+		CloudID:          one.Id,
+		Name:             converter.PtrToVal(one.Name),
+		Region:           region,
+		Status:           one.Status,
+		CloudDiskID:      one.VolumeId,
+		DiskSize:         uint64(one.Size),
+		Memo:             one.Description,
+		CloudCreatedTime: one.CreatedAt,
+		Extension: &coresnap.HuaWeiSnapshotExtension{
+			ServiceType: converter.ValToPtr(one.ServiceType),
+			UpdatedAt:   one.UpdatedAt,
+		},
+	}
+
+	if len(one.OsExtendedSnapshotAttributesprogress) != 0 {
+		snapshot.Extension.Progress = converter.ValToPtr(one.OsExtendedSnapshotAttributesprogress)
+	}
+
+	return snapshot
+}
+
+// CreateSnapshot create disk snapshot, return cloud id of created snapshot.
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2011.html
+func (h *HuaWei) CreateSnapshot(kt *kit.Kit, opt *typesnap.HuaWeiCreateOption) (*string, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &model.CreateSnapshotRequest{
+		Body: &model.CreateSnapshotRequestBody{
+			Snapshot: &model.CreateSnapshotOption{
+				VolumeId:    opt.CloudDiskID,
+				Force:       opt.Force,
+				Description: opt.Memo,
+			},
+		},
+	}
+	if len(opt.Name) != 0 {
+		req.Body.Snapshot.Name = converter.ValToPtr(opt.Name)
+	}
+
+	resp, err := client.CreateSnapshot(req)
+	if err != nil {
+		logs.Errorf("create huawei snapshot failed, err: %v, disk: %s, rid: %s", err, opt.CloudDiskID, kt.Rid)
+		return nil, err
+	}
+
+	if resp.Snapshot == nil || resp.Snapshot.Id == nil {
+		return nil, errf.New(errf.Aborted, "create huawei snapshot but cloud id not returned")
+	}
+
+	return resp.Snapshot.Id, nil
+}
+
+// DeleteSnapshot delete disk snapshot.
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2012.html
+func (h *HuaWei) DeleteSnapshot(kt *kit.Kit, opt *typesnap.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &model.DeleteSnapshotRequest{SnapshotId: opt.ResourceID}
+	if _, err = client.DeleteSnapshot(req); err != nil {
+		logs.Errorf("delete huawei snapshot failed, err: %v, id: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RollbackSnapshot rollback disk data from snapshot, the disk must be detached or its server must be stopped.
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2016.html
+func (h *HuaWei) RollbackSnapshot(kt *kit.Kit, opt *typesnap.HuaWeiRollbackOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "rollback option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &model.RollbackSnapshotRequest{
+		SnapshotId: opt.CloudID,
+		Body: &model.RollbackSnapshotRequestBody{
+			Rollback: &model.RollbackSnapshotOption{VolumeId: opt.CloudDiskID},
+		},
+	}
+	if _, err = client.RollbackSnapshot(req); err != nil {
+		logs.Errorf("rollback huawei disk from snapshot failed, err: %v, snapshot: %s, disk: %s, rid: %s", err,
+			opt.CloudID, opt.CloudDiskID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
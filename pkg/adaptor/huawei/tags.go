@@ -0,0 +1,408 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	ecsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2/model"
+	eipmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/eip/v2/model"
+	evsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+	vpcmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v2/model"
+)
+
+// ListResourceTag list tags of resources, huawei security group does not support tag.
+func (h *HuaWei) ListResourceTag(kt *kit.Kit, opt *typetag.ListOption) ([]typetag.ResourceTags, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	details := make([]typetag.ResourceTags, 0, len(opt.CloudIDs))
+	for _, cloudID := range opt.CloudIDs {
+		tags, err := h.showResourceTag(opt.Region, opt.ResType, cloudID)
+		if err != nil {
+			logs.Errorf("show huawei %s: %s tags failed, err: %v, rid: %s", opt.ResType, cloudID, err, kt.Rid)
+			return nil, err
+		}
+
+		details = append(details, typetag.ResourceTags{CloudID: cloudID, Tags: tags})
+	}
+
+	return details, nil
+}
+
+// TagResource add or overwrite tags of resources, huawei creates tags of resources one by one.
+func (h *HuaWei) TagResource(kt *kit.Kit, opt *typetag.TagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		if err := h.createResourceTag(opt.Region, opt.ResType, cloudID, opt.Tags); err != nil {
+			logs.Errorf("create huawei %s: %s tags failed, err: %v, rid: %s", opt.ResType, cloudID, err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UntagResource remove tags of resources by tag keys. huawei delete tags api requires the tag value of some
+// resource types, so current tags are queried first, and only the existing tags are deleted.
+func (h *HuaWei) UntagResource(kt *kit.Kit, opt *typetag.UntagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	keyMap := converter.StringSliceToMap(opt.Keys)
+	for _, cloudID := range opt.CloudIDs {
+		tags, err := h.showResourceTag(opt.Region, opt.ResType, cloudID)
+		if err != nil {
+			logs.Errorf("show huawei %s: %s tags failed, err: %v, rid: %s", opt.ResType, cloudID, err, kt.Rid)
+			return err
+		}
+
+		deleteTags := make([]typetag.Tag, 0, len(opt.Keys))
+		for _, tag := range tags {
+			if _, exists := keyMap[tag.Key]; exists {
+				deleteTags = append(deleteTags, tag)
+			}
+		}
+
+		if len(deleteTags) == 0 {
+			continue
+		}
+
+		if err = h.deleteResourceTag(opt.Region, opt.ResType, cloudID, deleteTags); err != nil {
+			logs.Errorf("delete huawei %s: %s tags failed, err: %v, rid: %s", opt.ResType, cloudID, err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HuaWei) showResourceTag(region string, resType enumor.CloudResourceType, cloudID string) (
+	[]typetag.Tag, error) {
+
+	tags := make([]typetag.Tag, 0)
+	switch resType {
+	case enumor.CvmCloudResType:
+		client, err := h.clientSet.ecsClient(region)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.ShowServerTags(&ecsmodel.ShowServerTagsRequest{ServerId: cloudID})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range converter.PtrToVal(resp.Tags) {
+			tags = append(tags, typetag.Tag{Key: one.Key, Value: one.Value})
+		}
+
+	case enumor.DiskCloudResType:
+		client, err := h.clientSet.evsClient(region)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.ShowVolumeTags(&evsmodel.ShowVolumeTagsRequest{VolumeId: cloudID})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range converter.PtrToVal(resp.Tags) {
+			tags = append(tags, typetag.Tag{Key: one.Key, Value: one.Value})
+		}
+
+	case enumor.VpcCloudResType:
+		client, err := h.clientSet.vpcClientV2(region)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.ShowVpcTags(&vpcmodel.ShowVpcTagsRequest{VpcId: cloudID})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range converter.PtrToVal(resp.Tags) {
+			tags = append(tags, typetag.Tag{Key: one.Key, Value: one.Value})
+		}
+
+	case enumor.SubnetCloudResType:
+		client, err := h.clientSet.vpcClientV2(region)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.ShowSubnetTags(&vpcmodel.ShowSubnetTagsRequest{SubnetId: cloudID})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range converter.PtrToVal(resp.Tags) {
+			tags = append(tags, typetag.Tag{Key: one.Key, Value: one.Value})
+		}
+
+	case enumor.EipCloudResType:
+		client, err := h.clientSet.eipClient(region)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.ShowPublicipTags(&eipmodel.ShowPublicipTagsRequest{PublicipId: cloudID})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range converter.PtrToVal(resp.Tags) {
+			tags = append(tags, typetag.Tag{Key: converter.PtrToVal(one.Key), Value: converter.PtrToVal(one.Value)})
+		}
+
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "huawei resource type: %s not support tag", resType)
+	}
+
+	return tags, nil
+}
+
+func (h *HuaWei) createResourceTag(region string, resType enumor.CloudResourceType, cloudID string,
+	tags []typetag.Tag) error {
+
+	switch resType {
+	case enumor.CvmCloudResType:
+		client, err := h.clientSet.ecsClient(region)
+		if err != nil {
+			return err
+		}
+
+		serverTags := make([]ecsmodel.ServerTag, 0, len(tags))
+		for _, one := range tags {
+			serverTags = append(serverTags, ecsmodel.ServerTag{Key: one.Key, Value: one.Value})
+		}
+
+		_, err = client.BatchCreateServerTags(&ecsmodel.BatchCreateServerTagsRequest{
+			ServerId: cloudID,
+			Body: &ecsmodel.BatchCreateServerTagsRequestBody{
+				Action: ecsmodel.GetBatchCreateServerTagsRequestBodyActionEnum().CREATE,
+				Tags:   serverTags,
+			},
+		})
+		return err
+
+	case enumor.DiskCloudResType:
+		client, err := h.clientSet.evsClient(region)
+		if err != nil {
+			return err
+		}
+
+		volumeTags := make([]evsmodel.Tag, 0, len(tags))
+		for _, one := range tags {
+			volumeTags = append(volumeTags, evsmodel.Tag{Key: one.Key, Value: one.Value})
+		}
+
+		_, err = client.BatchCreateVolumeTags(&evsmodel.BatchCreateVolumeTagsRequest{
+			VolumeId: cloudID,
+			Body: &evsmodel.BatchCreateVolumeTagsRequestBody{
+				Action: evsmodel.GetBatchCreateVolumeTagsRequestBodyActionEnum().CREATE,
+				Tags:   volumeTags,
+			},
+		})
+		return err
+
+	case enumor.VpcCloudResType:
+		client, err := h.clientSet.vpcClientV2(region)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.BatchCreateVpcTags(&vpcmodel.BatchCreateVpcTagsRequest{
+			VpcId: cloudID,
+			Body: &vpcmodel.BatchCreateVpcTagsRequestBody{
+				Action: vpcmodel.GetBatchCreateVpcTagsRequestBodyActionEnum().CREATE,
+				Tags:   convertVpcResourceTags(tags),
+			},
+		})
+		return err
+
+	case enumor.SubnetCloudResType:
+		client, err := h.clientSet.vpcClientV2(region)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.BatchCreateSubnetTags(&vpcmodel.BatchCreateSubnetTagsRequest{
+			SubnetId: cloudID,
+			Body: &vpcmodel.BatchCreateSubnetTagsRequestBody{
+				Action: vpcmodel.GetBatchCreateSubnetTagsRequestBodyActionEnum().CREATE,
+				Tags:   convertVpcResourceTags(tags),
+			},
+		})
+		return err
+
+	case enumor.EipCloudResType:
+		client, err := h.clientSet.eipClient(region)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.BatchCreatePublicipTags(&eipmodel.BatchCreatePublicipTagsRequest{
+			PublicipId: cloudID,
+			Body: &eipmodel.BatchCreatePublicipTagsRequestBody{
+				Action: eipmodel.GetBatchCreatePublicipTagsRequestBodyActionEnum().CREATE,
+				Tags:   convertEipResourceTags(tags),
+			},
+		})
+		return err
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "huawei resource type: %s not support tag", resType)
+	}
+}
+
+func (h *HuaWei) deleteResourceTag(region string, resType enumor.CloudResourceType, cloudID string,
+	tags []typetag.Tag) error {
+
+	switch resType {
+	case enumor.CvmCloudResType:
+		client, err := h.clientSet.ecsClient(region)
+		if err != nil {
+			return err
+		}
+
+		serverTags := make([]ecsmodel.ServerTag, 0, len(tags))
+		for _, one := range tags {
+			serverTags = append(serverTags, ecsmodel.ServerTag{Key: one.Key, Value: one.Value})
+		}
+
+		_, err = client.BatchDeleteServerTags(&ecsmodel.BatchDeleteServerTagsRequest{
+			ServerId: cloudID,
+			Body: &ecsmodel.BatchDeleteServerTagsRequestBody{
+				Action: ecsmodel.GetBatchDeleteServerTagsRequestBodyActionEnum().DELETE,
+				Tags:   serverTags,
+			},
+		})
+		return err
+
+	case enumor.DiskCloudResType:
+		client, err := h.clientSet.evsClient(region)
+		if err != nil {
+			return err
+		}
+
+		keys := make([]evsmodel.DeleteTagsOption, 0, len(tags))
+		for _, one := range tags {
+			keys = append(keys, evsmodel.DeleteTagsOption{Key: one.Key})
+		}
+
+		_, err = client.BatchDeleteVolumeTags(&evsmodel.BatchDeleteVolumeTagsRequest{
+			VolumeId: cloudID,
+			Body: &evsmodel.BatchDeleteVolumeTagsRequestBody{
+				Action: evsmodel.GetBatchDeleteVolumeTagsRequestBodyActionEnum().DELETE,
+				Tags:   keys,
+			},
+		})
+		return err
+
+	case enumor.VpcCloudResType:
+		client, err := h.clientSet.vpcClientV2(region)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.BatchDeleteVpcTags(&vpcmodel.BatchDeleteVpcTagsRequest{
+			VpcId: cloudID,
+			Body: &vpcmodel.BatchDeleteVpcTagsRequestBody{
+				Action: vpcmodel.GetBatchDeleteVpcTagsRequestBodyActionEnum().DELETE,
+				Tags:   convertVpcResourceTags(tags),
+			},
+		})
+		return err
+
+	case enumor.SubnetCloudResType:
+		client, err := h.clientSet.vpcClientV2(region)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.BatchDeleteSubnetTags(&vpcmodel.BatchDeleteSubnetTagsRequest{
+			SubnetId: cloudID,
+			Body: &vpcmodel.BatchDeleteSubnetTagsRequestBody{
+				Action: vpcmodel.GetBatchDeleteSubnetTagsRequestBodyActionEnum().DELETE,
+				Tags:   convertVpcResourceTags(tags),
+			},
+		})
+		return err
+
+	case enumor.EipCloudResType:
+		client, err := h.clientSet.eipClient(region)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.BatchDeletePublicipTags(&eipmodel.BatchDeletePublicipTagsRequest{
+			PublicipId: cloudID,
+			Body: &eipmodel.BatchDeletePublicipTagsRequestBody{
+				Action: eipmodel.GetBatchDeletePublicipTagsRequestBodyActionEnum().DELETE,
+				Tags:   convertEipResourceTags(tags),
+			},
+		})
+		return err
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "huawei resource type: %s not support tag", resType)
+	}
+}
+
+func convertVpcResourceTags(tags []typetag.Tag) []vpcmodel.ResourceTag {
+	result := make([]vpcmodel.ResourceTag, 0, len(tags))
+	for _, one := range tags {
+		result = append(result, vpcmodel.ResourceTag{Key: one.Key, Value: one.Value})
+	}
+	return result
+}
+
+func convertEipResourceTags(tags []typetag.Tag) []eipmodel.ResourceTagOption {
+	result := make([]eipmodel.ResourceTagOption, 0, len(tags))
+	for _, one := range tags {
+		result = append(result, eipmodel.ResourceTagOption{Key: one.Key, Value: one.Value})
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"time"
+
+	"hcm/pkg/adaptor/types/core"
+	typevpcpeering "hcm/pkg/adaptor/types/vpc-peering"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v2/model"
+)
+
+// ListVpcPeering list all vpc peerings in region, huawei vpc peering can only connect vpcs in the same region.
+// reference: https://support.huaweicloud.com/api-vpc/vpc_peering_0001.html
+func (h *HuaWei) ListVpcPeering(kt *kit.Kit, opt *typevpcpeering.ListOption) ([]typevpcpeering.VpcPeering, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.vpcClientV2(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &model.ListVpcPeeringsRequest{Limit: converter.ValToPtr(int32(core.HuaWeiQueryLimit))}
+	peerings := make([]typevpcpeering.VpcPeering, 0)
+	for {
+		resp, err := client.ListVpcPeerings(req)
+		if err != nil {
+			logs.Errorf("list huawei vpc peering failed, err: %v, region: %s, rid: %s", err, opt.Region, kt.Rid)
+			return nil, err
+		}
+
+		list := converter.PtrToVal(resp.Peerings)
+		for _, one := range list {
+			peerings = append(peerings, convHuaWeiVpcPeering(one, opt.Region))
+		}
+
+		if len(list) < core.HuaWeiQueryLimit {
+			break
+		}
+		req.Marker = converter.ValToPtr(list[len(list)-1].Id)
+	}
+
+	return peerings, nil
+}
+
+func convHuaWeiVpcPeering(one model.VpcPeering, region string) typevpcpeering.VpcPeering {
+	peering := typevpcpeering.VpcPeering{
+		CloudID:        one.Id,
+		Name:           one.Name,
+		Region:         region,
+		Type:           enumor.PeeringConnection,
+		CloudStatus:    one.Status.Value(),
+		CloudGatewayID: one.Id,
+		LocalCidrs:     make([]string, 0),
+		PeerRegion:     region,
+		PeerCidrs:      make([]string, 0),
+	}
+
+	if len(one.Description) != 0 {
+		peering.Memo = converter.ValToPtr(one.Description)
+	}
+
+	switch peering.CloudStatus {
+	case model.GetVpcPeeringStatusEnum().ACTIVE.Value():
+		peering.Status = enumor.VpcPeeringActive
+	case model.GetVpcPeeringStatusEnum().PENDING_ACCEPTANCE.Value():
+		peering.Status = enumor.VpcPeeringPending
+	default:
+		peering.Status = enumor.VpcPeeringInactive
+	}
+
+	if one.RequestVpcInfo != nil {
+		peering.LocalCloudVpcID = one.RequestVpcInfo.VpcId
+	}
+
+	if one.AcceptVpcInfo != nil {
+		peering.PeerCloudVpcID = one.AcceptVpcInfo.VpcId
+		peering.PeerCloudAccountID = converter.PtrToVal(one.AcceptVpcInfo.TenantId)
+	}
+
+	if one.CreatedAt != nil {
+		peering.CloudCreatedTime = times.ConvStdTimeFormat(time.Time(*one.CreatedAt))
+	}
+
+	return peering
+}
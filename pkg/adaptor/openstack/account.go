@@ -0,0 +1,42 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// AccountCheck check account authentication information, application credential must be able to issue token
+// and belong to the project of the account.
+// reference: https://docs.openstack.org/api-ref/identity/v3/#authenticating-with-an-application-credential
+func (o *OpenStack) AccountCheck(kt *kit.Kit) error {
+	token, err := o.clientSet.getToken(kt)
+	if err != nil {
+		return err
+	}
+
+	if token.projectID != o.clientSet.credential.CloudProjectID {
+		return errf.Newf(errf.InvalidParameter, "application credential belongs to project: %s, not %s",
+			token.projectID, o.clientSet.credential.CloudProjectID)
+	}
+
+	return nil
+}
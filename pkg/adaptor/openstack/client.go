@@ -0,0 +1,371 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	// computeService nova, cvm、zone
+	computeService = "compute"
+	// networkService neutron, vpc、subnet、security group
+	networkService = "network"
+	// volumeService cinder, disk
+	volumeService = "volumev3"
+
+	// publicInterface 使用 catalog 中的 public 类型 endpoint
+	publicInterface = "public"
+
+	// defaultRequestTimeout openstack api request timeout.
+	defaultRequestTimeout = 30 * time.Second
+	// tokenRefreshAhead token 在过期前提前刷新，避免请求过程中 token 过期
+	tokenRefreshAhead = 5 * time.Minute
+	// maxErrBodyLength 请求失败时记录的响应体最大长度
+	maxErrBodyLength = 1024
+)
+
+type clientSet struct {
+	credential *types.OpenStackCredential
+	httpCli    *http.Client
+
+	lock  sync.Mutex
+	token *authToken
+}
+
+func newClientSet(credential *types.OpenStackCredential) *clientSet {
+	return &clientSet{
+		credential: credential,
+		httpCli:    &http.Client{Timeout: defaultRequestTimeout},
+	}
+}
+
+// authToken keystone token and the service catalog of the project.
+type authToken struct {
+	value     string
+	expiresAt time.Time
+	projectID string
+	catalog   []catalogService
+}
+
+type tokenResp struct {
+	Token struct {
+		ExpiresAt string           `json:"expires_at"`
+		Project   tokenProject     `json:"project"`
+		Catalog   []catalogService `json:"catalog"`
+	} `json:"token"`
+}
+
+type tokenProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type catalogService struct {
+	Type      string            `json:"type"`
+	Endpoints []catalogEndpoint `json:"endpoints"`
+}
+
+type catalogEndpoint struct {
+	Interface string `json:"interface"`
+	RegionID  string `json:"region_id"`
+	Url       string `json:"url"`
+}
+
+// authUrl keystone v3 address, version suffix is added when it is omitted.
+func (c *clientSet) authUrl() string {
+	authUrl := strings.TrimSuffix(c.credential.CloudAuthUrl, "/")
+	if !strings.HasSuffix(authUrl, "/v3") {
+		authUrl += "/v3"
+	}
+
+	return authUrl
+}
+
+// getToken returns the cached token, token is issued again when it is about to expire.
+func (c *clientSet) getToken(kt *kit.Kit) (*authToken, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.token != nil && time.Now().Add(tokenRefreshAhead).Before(c.token.expiresAt) {
+		return c.token, nil
+	}
+
+	token, err := c.issueToken(kt)
+	if err != nil {
+		return nil, err
+	}
+	c.token = token
+
+	return token, nil
+}
+
+// invalidToken drop the cached token, used when openstack api returns unauthorized.
+func (c *clientSet) invalidToken() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.token = nil
+}
+
+// issueToken issue token by application credential.
+// reference: https://docs.openstack.org/api-ref/identity/v3/#authenticating-with-an-application-credential
+func (c *clientSet) issueToken(kt *kit.Kit) (*authToken, error) {
+	body := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []string{"application_credential"},
+				"application_credential": map[string]string{
+					"id":     c.credential.CloudSecretID,
+					"secret": c.credential.CloudSecretKey,
+				},
+			},
+		},
+	}
+
+	resp := new(tokenResp)
+	header, err := c.send(kt, http.MethodPost, c.authUrl()+"/auth/tokens", "", body, resp)
+	if err != nil {
+		logs.Errorf("issue openstack token failed, err: %v, auth url: %s, rid: %s", err, c.authUrl(), kt.Rid)
+		return nil, err
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339Nano, resp.Token.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("parse token expires at %s failed, err: %v", resp.Token.ExpiresAt, err)
+	}
+
+	token := &authToken{
+		value:     header.Get("X-Subject-Token"),
+		expiresAt: expiresAt,
+		projectID: resp.Token.Project.ID,
+		catalog:   resp.Token.Catalog,
+	}
+	if len(token.value) == 0 {
+		return nil, fmt.Errorf("openstack token is not returned by keystone")
+	}
+
+	return token, nil
+}
+
+// endpoint returns the public endpoint of the service in the region.
+func (t *authToken) endpoint(service, region string) (string, error) {
+	for _, one := range t.catalog {
+		if one.Type != service {
+			continue
+		}
+
+		for _, endpoint := range one.Endpoints {
+			if endpoint.Interface == publicInterface && endpoint.RegionID == region {
+				return strings.TrimSuffix(endpoint.Url, "/"), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("openstack %s service endpoint not found in region: %s", service, region)
+}
+
+// regions returns all regions which provide compute service.
+func (t *authToken) regions() []string {
+	regions := make([]string, 0)
+	exists := make(map[string]struct{})
+	for _, one := range t.catalog {
+		if one.Type != computeService {
+			continue
+		}
+
+		for _, endpoint := range one.Endpoints {
+			if _, exist := exists[endpoint.RegionID]; exist || endpoint.Interface != publicInterface {
+				continue
+			}
+
+			exists[endpoint.RegionID] = struct{}{}
+			regions = append(regions, endpoint.RegionID)
+		}
+	}
+
+	return regions
+}
+
+// do request openstack service api in the region, result is decoded from response body when it is not nil.
+func (c *clientSet) do(kt *kit.Kit, method, service, region, path string, query url.Values, body,
+	result interface{}) error {
+
+	token, err := c.getToken(kt)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := token.endpoint(service, region)
+	if err != nil {
+		return err
+	}
+
+	reqUrl := endpoint + path
+	if len(query) != 0 {
+		reqUrl += "?" + query.Encode()
+	}
+
+	if _, err = c.send(kt, method, reqUrl, token.value, body, result); err != nil {
+		if err == errNotFound {
+			return err
+		}
+
+		if err == errUnauthorized {
+			c.invalidToken()
+		}
+
+		logs.Errorf("request openstack %s api failed, err: %v, method: %s, path: %s, region: %s, rid: %s", service,
+			err, method, path, region, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+var (
+	errUnauthorized = fmt.Errorf("openstack request unauthorized")
+	errNotFound     = fmt.Errorf("openstack resource not found")
+)
+
+func (c *clientSet) send(kt *kit.Kit, method, reqUrl, token string, body, result interface{}) (http.Header,
+	error) {
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshal request body failed, err: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, method, reqUrl, reader)
+	if err != nil {
+		return nil, fmt.Errorf("new openstack request failed, err: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(constant.RidKey, kt.Rid)
+	if len(token) != 0 {
+		req.Header.Set("X-Auth-Token", token)
+	}
+
+	resp, err := c.httpCli.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do openstack request failed, err: %v", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, errUnauthorized
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errNotFound
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrBodyLength))
+		return nil, fmt.Errorf("openstack request failed, status code: %d, body: %s", resp.StatusCode,
+			string(respBody))
+	}
+
+	if result == nil {
+		return resp.Header, nil
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("decode openstack response failed, err: %v", err)
+	}
+
+	return resp.Header, nil
+}
+
+// listByMarker list all resources by marker pagination which is supported by nova、neutron and cinder,
+// key is the resource list field name of the response body.
+// reference: https://docs.openstack.org/api-guide/compute/paginated_collections.html
+func listByMarker[T any](kt *kit.Kit, c *clientSet, service, region, path, key string, query url.Values,
+	getID func(T) string) ([]T, error) {
+
+	if query == nil {
+		query = make(url.Values)
+	}
+	query.Set("limit", fmt.Sprintf("%d", constant.BatchOperationMaxLimit))
+
+	result := make([]T, 0)
+	for {
+		resp := make(map[string]json.RawMessage)
+		if err := c.do(kt, http.MethodGet, service, region, path, query, nil, &resp); err != nil {
+			return nil, err
+		}
+
+		page := make([]T, 0)
+		if raw, exist := resp[key]; exist {
+			if err := json.Unmarshal(raw, &page); err != nil {
+				return nil, fmt.Errorf("unmarshal openstack %s failed, err: %v", key, err)
+			}
+		}
+		result = append(result, page...)
+
+		if len(page) < constant.BatchOperationMaxLimit {
+			break
+		}
+		query.Set("marker", getID(page[len(page)-1]))
+	}
+
+	return result, nil
+}
+
+// getByIDs get resources one by one, used by nova and cinder which do not support filtering by id list,
+// resources which not found are ignored.
+func getByIDs[T any](kt *kit.Kit, c *clientSet, service, region, pathFormat, key string, ids []string) ([]T,
+	error) {
+
+	result := make([]T, 0, len(ids))
+	for _, id := range ids {
+		resp := make(map[string]json.RawMessage)
+		err := c.do(kt, http.MethodGet, service, region, fmt.Sprintf(pathFormat, id), nil, nil, &resp)
+		if err != nil {
+			if err == errNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		one := new(T)
+		if err = json.Unmarshal(resp[key], one); err != nil {
+			return nil, fmt.Errorf("unmarshal openstack %s failed, err: %v", key, err)
+		}
+		result = append(result, *one)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,345 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/slice"
+)
+
+// portQueryBatch neutron 按 device_id、port_id 过滤时每次请求携带的最大数量，避免请求url过长
+const portQueryBatch = 50
+
+type server struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Status      string            `json:"status"`
+	Zone        string            `json:"OS-EXT-AZ:availability_zone"`
+	KeyName     string            `json:"key_name"`
+	HostID      string            `json:"hostId"`
+	Metadata    map[string]string `json:"metadata"`
+	Created     string            `json:"created"`
+	LaunchedAt  string            `json:"OS-SRV-USG:launched_at"`
+	Flavor      struct {
+		ID           string `json:"id"`
+		OriginalName string `json:"original_name"`
+	} `json:"flavor"`
+	// Image 从云硬盘启动的云服务器 image 为空字符串，否则为镜像对象
+	Image   json.RawMessage `json:"image"`
+	Volumes []struct {
+		ID string `json:"id"`
+	} `json:"os-extended-volumes:volumes_attached"`
+}
+
+func (s server) imageID() string {
+	image := struct {
+		ID string `json:"id"`
+	}{}
+	if err := json.Unmarshal(s.Image, &image); err != nil {
+		return ""
+	}
+
+	return image.ID
+}
+
+type port struct {
+	ID             string   `json:"id"`
+	NetworkID      string   `json:"network_id"`
+	DeviceID       string   `json:"device_id"`
+	SecurityGroups []string `json:"security_groups"`
+	FixedIPs       []struct {
+		SubnetID  string `json:"subnet_id"`
+		IpAddress string `json:"ip_address"`
+	} `json:"fixed_ips"`
+}
+
+type floatingIP struct {
+	ID                string `json:"id"`
+	PortID            string `json:"port_id"`
+	FloatingIpAddress string `json:"floating_ip_address"`
+}
+
+// ListCvm list cvm, network info of cvm is collected from neutron ports and floating ips of the cvm.
+// reference: https://docs.openstack.org/api-ref/compute/#list-servers-detailed
+func (o *OpenStack) ListCvm(kt *kit.Kit, opt *typecvm.OpenStackListOption) ([]typecvm.OpenStackCvm, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	var servers []server
+	var err error
+	if len(opt.CloudIDs) != 0 {
+		servers, err = getByIDs[server](kt, o.clientSet, computeService, opt.Region, "/servers/%s", "server",
+			opt.CloudIDs)
+	} else {
+		servers, err = listByMarker(kt, o.clientSet, computeService, opt.Region, "/servers/detail", "servers", nil,
+			func(one server) string { return one.ID })
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	serverIDs := make([]string, 0, len(servers))
+	for _, one := range servers {
+		serverIDs = append(serverIDs, one.ID)
+	}
+
+	ports, err := o.listServerPort(kt, opt.Region, serverIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]typecvm.OpenStackCvm, 0, len(servers))
+	for _, one := range servers {
+		cvm := typecvm.OpenStackCvm{
+			CloudID:       one.ID,
+			Name:          one.Name,
+			Region:        opt.Region,
+			Zone:          one.Zone,
+			Status:        one.Status,
+			CloudFlavorID: one.Flavor.ID,
+			FlavorName:    one.Flavor.OriginalName,
+			CloudImageID:  one.imageID(),
+			KeyName:       one.KeyName,
+			CloudHostID:   one.HostID,
+			Memo:          one.Description,
+			Metadata:      one.Metadata,
+			CreatedAt:     one.Created,
+			LaunchedAt:    one.LaunchedAt,
+		}
+		if len(cvm.FlavorName) == 0 {
+			cvm.FlavorName = one.Flavor.ID
+		}
+
+		for _, volume := range one.Volumes {
+			cvm.CloudVolumeIDs = append(cvm.CloudVolumeIDs, volume.ID)
+		}
+
+		fillCvmNetwork(&cvm, ports[one.ID])
+		result = append(result, cvm)
+	}
+
+	return result, nil
+}
+
+// serverPort neutron port bound to the server and the floating ips bound to the port.
+type serverPort struct {
+	port
+	floatingIPs []string
+}
+
+// listServerPort list ports and floating ips of servers, returns map of server id and its ports.
+func (o *OpenStack) listServerPort(kt *kit.Kit, region string, serverIDs []string) (map[string][]serverPort,
+	error) {
+
+	ports := make([]port, 0)
+	for _, ids := range slice.Split(serverIDs, portQueryBatch) {
+		query := make(url.Values)
+		for _, id := range ids {
+			query.Add("device_id", id)
+		}
+
+		result, err := listByMarker(kt, o.clientSet, networkService, region, "/v2.0/ports", "ports", query,
+			func(one port) string { return one.ID })
+		if err != nil {
+			return nil, err
+		}
+		ports = append(ports, result...)
+	}
+
+	portIDs := make([]string, 0, len(ports))
+	for _, one := range ports {
+		portIDs = append(portIDs, one.ID)
+	}
+
+	fipMap := make(map[string][]string)
+	for _, ids := range slice.Split(portIDs, portQueryBatch) {
+		query := make(url.Values)
+		for _, id := range ids {
+			query.Add("port_id", id)
+		}
+
+		fips, err := listByMarker(kt, o.clientSet, networkService, region, "/v2.0/floatingips", "floatingips",
+			query, func(one floatingIP) string { return one.ID })
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fip := range fips {
+			fipMap[fip.PortID] = append(fipMap[fip.PortID], fip.FloatingIpAddress)
+		}
+	}
+
+	result := make(map[string][]serverPort)
+	for _, one := range ports {
+		result[one.DeviceID] = append(result[one.DeviceID], serverPort{port: one, floatingIPs: fipMap[one.ID]})
+	}
+
+	return result, nil
+}
+
+func fillCvmNetwork(cvm *typecvm.OpenStackCvm, ports []serverPort) {
+	for _, one := range ports {
+		cvm.CloudVpcIDs = appendUnique(cvm.CloudVpcIDs, one.NetworkID)
+		for _, sg := range one.SecurityGroups {
+			cvm.CloudSecurityGroupIDs = appendUnique(cvm.CloudSecurityGroupIDs, sg)
+		}
+
+		for _, ip := range one.FixedIPs {
+			cvm.CloudSubnetIDs = appendUnique(cvm.CloudSubnetIDs, ip.SubnetID)
+			if strings.Contains(ip.IpAddress, ":") {
+				cvm.PrivateIPv6Addresses = append(cvm.PrivateIPv6Addresses, ip.IpAddress)
+			} else {
+				cvm.PrivateIPv4Addresses = append(cvm.PrivateIPv4Addresses, ip.IpAddress)
+			}
+		}
+
+		for _, ip := range one.floatingIPs {
+			if strings.Contains(ip, ":") {
+				cvm.PublicIPv6Addresses = append(cvm.PublicIPv6Addresses, ip)
+			} else {
+				cvm.PublicIPv4Addresses = append(cvm.PublicIPv4Addresses, ip)
+			}
+		}
+	}
+}
+
+func appendUnique(list []string, item string) []string {
+	if slice.IsItemInSlice(list, item) {
+		return list
+	}
+
+	return append(list, item)
+}
+
+// StartCvm start cvm.
+// reference: https://docs.openstack.org/api-ref/compute/#start-server-os-start-action
+func (o *OpenStack) StartCvm(kt *kit.Kit, opt *typecvm.OpenStackOperateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "start option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return o.eachServerAction(kt, opt.Region, opt.CloudIDs, map[string]interface{}{"os-start": nil})
+}
+
+// StopCvm stop cvm.
+// reference: https://docs.openstack.org/api-ref/compute/#stop-server-os-stop-action
+func (o *OpenStack) StopCvm(kt *kit.Kit, opt *typecvm.OpenStackOperateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "stop option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return o.eachServerAction(kt, opt.Region, opt.CloudIDs, map[string]interface{}{"os-stop": nil})
+}
+
+// RebootCvm reboot cvm.
+// reference: https://docs.openstack.org/api-ref/compute/#reboot-server-reboot-action
+func (o *OpenStack) RebootCvm(kt *kit.Kit, opt *typecvm.OpenStackRebootOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "reboot option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rebootType := "SOFT"
+	if opt.Force {
+		rebootType = "HARD"
+	}
+
+	body := map[string]interface{}{"reboot": map[string]string{"type": rebootType}}
+	return o.eachServerAction(kt, opt.Region, opt.CloudIDs, body)
+}
+
+// DeleteCvm delete cvm, volumes attached to cvm are deleted only when delete_on_termination is set.
+// reference: https://docs.openstack.org/api-ref/compute/#delete-server
+func (o *OpenStack) DeleteCvm(kt *kit.Kit, opt *typecvm.OpenStackOperateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return o.eachServer(opt.CloudIDs, func(id string) error {
+		err := o.clientSet.do(kt, http.MethodDelete, computeService, opt.Region, fmt.Sprintf("/servers/%s", id),
+			nil, nil, nil)
+		if err == errNotFound {
+			return nil
+		}
+		return err
+	})
+}
+
+// eachServerAction run server action on each server.
+func (o *OpenStack) eachServerAction(kt *kit.Kit, region string, ids []string, body interface{}) error {
+	return o.eachServer(ids, func(id string) error {
+		return o.serverAction(kt, region, id, body)
+	})
+}
+
+// serverAction run server action.
+// reference: https://docs.openstack.org/api-ref/compute/#servers-run-an-action-servers-action
+func (o *OpenStack) serverAction(kt *kit.Kit, region, id string, body interface{}) error {
+	return o.clientSet.do(kt, http.MethodPost, computeService, region, fmt.Sprintf("/servers/%s/action", id), nil,
+		body, nil)
+}
+
+// eachServer nova does not support batch operation, operate servers one by one, the rest servers are still
+// operated when one of them failed.
+func (o *OpenStack) eachServer(ids []string, operate func(id string) error) error {
+	failed := make([]string, 0)
+	var lastErr error
+	for _, id := range ids {
+		if err := operate(id); err != nil {
+			failed = append(failed, id)
+			lastErr = err
+		}
+	}
+
+	if len(failed) != 0 {
+		return fmt.Errorf("operate servers %v failed, err: %v", failed, lastErr)
+	}
+
+	return nil
+}
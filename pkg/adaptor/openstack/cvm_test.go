@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	typecvm "hcm/pkg/adaptor/types/cvm"
+)
+
+func TestFillCvmNetwork(t *testing.T) {
+	ports := make([]port, 0)
+	portJson := `[
+		{"id": "port-1", "network_id": "net-1", "device_id": "cvm-1", "security_groups": ["sg-1", "sg-2"],
+			"fixed_ips": [{"subnet_id": "subnet-1", "ip_address": "10.0.0.2"},
+				{"subnet_id": "subnet-v6", "ip_address": "fd00::2"}]},
+		{"id": "port-2", "network_id": "net-1", "device_id": "cvm-1", "security_groups": ["sg-1"],
+			"fixed_ips": [{"subnet_id": "subnet-1", "ip_address": "10.0.0.3"}]}
+	]`
+	if err := json.Unmarshal([]byte(portJson), &ports); err != nil {
+		t.Errorf("unmarshal ports failed, err: %v", err)
+		return
+	}
+
+	cvm := &typecvm.OpenStackCvm{CloudID: "cvm-1"}
+	fillCvmNetwork(cvm, []serverPort{
+		{port: ports[0], floatingIPs: []string{"1.1.1.1"}},
+		{port: ports[1], floatingIPs: []string{"2001:db8::1"}},
+	})
+
+	expect := &typecvm.OpenStackCvm{
+		CloudID:               "cvm-1",
+		CloudVpcIDs:           []string{"net-1"},
+		CloudSubnetIDs:        []string{"subnet-1", "subnet-v6"},
+		CloudSecurityGroupIDs: []string{"sg-1", "sg-2"},
+		PrivateIPv4Addresses:  []string{"10.0.0.2", "10.0.0.3"},
+		PrivateIPv6Addresses:  []string{"fd00::2"},
+		PublicIPv4Addresses:   []string{"1.1.1.1"},
+		PublicIPv6Addresses:   []string{"2001:db8::1"},
+	}
+	if !reflect.DeepEqual(cvm, expect) {
+		t.Errorf("cvm network %+v is not as expected %+v", cvm, expect)
+	}
+}
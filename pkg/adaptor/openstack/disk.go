@@ -0,0 +1,153 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/pkg/adaptor/types/disk"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// defaultVolumeType cinder 未指定卷类型时使用的默认类型
+const defaultVolumeType = "default"
+
+type volume struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Zone        string `json:"availability_zone"`
+	Size        uint64 `json:"size"`
+	VolumeType  string `json:"volume_type"`
+	Status      string `json:"status"`
+	Description string `json:"description"`
+	// Bootable cinder 返回的是字符串 "true" 或 "false"
+	Bootable    string                     `json:"bootable"`
+	Encrypted   bool                       `json:"encrypted"`
+	Multiattach bool                       `json:"multiattach"`
+	Attachments []disk.OpenStackAttachment `json:"attachments"`
+}
+
+// ListDisk list disk.
+// reference: https://docs.openstack.org/api-ref/block-storage/v3/#list-accessible-volumes-with-details
+func (o *OpenStack) ListDisk(kt *kit.Kit, opt *disk.OpenStackDiskListOption) ([]disk.OpenStackDisk, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	var volumes []volume
+	var err error
+	if len(opt.CloudIDs) != 0 {
+		volumes, err = getByIDs[volume](kt, o.clientSet, volumeService, opt.Region, "/volumes/%s", "volume",
+			opt.CloudIDs)
+	} else {
+		volumes, err = listByMarker(kt, o.clientSet, volumeService, opt.Region, "/volumes/detail", "volumes", nil,
+			func(one volume) string { return one.ID })
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]disk.OpenStackDisk, 0, len(volumes))
+	for _, one := range volumes {
+		volumeType := one.VolumeType
+		if len(volumeType) == 0 {
+			volumeType = defaultVolumeType
+		}
+
+		result = append(result, disk.OpenStackDisk{
+			CloudID:     one.ID,
+			Name:        one.Name,
+			Region:      opt.Region,
+			Zone:        one.Zone,
+			Size:        one.Size,
+			VolumeType:  volumeType,
+			Status:      one.Status,
+			Description: one.Description,
+			Bootable:    one.Bootable == "true",
+			Encrypted:   one.Encrypted,
+			Multiattach: one.Multiattach,
+			Attachments: one.Attachments,
+		})
+	}
+
+	return result, nil
+}
+
+// AttachDisk attach disk to cvm.
+// reference: https://docs.openstack.org/api-ref/compute/#attach-a-volume-to-an-instance
+func (o *OpenStack) AttachDisk(kt *kit.Kit, opt *disk.OpenStackDiskAttachOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "attach option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	attachment := map[string]string{"volumeId": opt.CloudID}
+	if len(opt.DeviceName) != 0 {
+		attachment["device"] = opt.DeviceName
+	}
+
+	return o.clientSet.do(kt, http.MethodPost, computeService, opt.Region,
+		fmt.Sprintf("/servers/%s/os-volume_attachments", opt.CloudCvmID), nil,
+		map[string]interface{}{"volumeAttachment": attachment}, nil)
+}
+
+// DetachDisk detach disk from cvm.
+// reference: https://docs.openstack.org/api-ref/compute/#detach-a-volume-from-an-instance
+func (o *OpenStack) DetachDisk(kt *kit.Kit, opt *disk.OpenStackDiskDetachOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "detach option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return o.clientSet.do(kt, http.MethodDelete, computeService, opt.Region,
+		fmt.Sprintf("/servers/%s/os-volume_attachments/%s", opt.CloudCvmID, opt.CloudID), nil, nil, nil)
+}
+
+// DeleteDisk delete disk.
+// reference: https://docs.openstack.org/api-ref/block-storage/v3/#delete-a-volume
+func (o *OpenStack) DeleteDisk(kt *kit.Kit, opt *disk.OpenStackDiskDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := o.clientSet.do(kt, http.MethodDelete, volumeService, opt.Region, fmt.Sprintf("/volumes/%s", opt.CloudID),
+		nil, nil, nil)
+	if err == errNotFound {
+		return nil
+	}
+
+	return err
+}
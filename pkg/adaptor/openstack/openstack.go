@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package openstack is the adaptor of openstack private cloud, openstack has no official go sdk which can be
+// vendored here, so nova、neutron、cinder rest api are called directly, endpoint of each service is discovered
+// from keystone v3 service catalog.
+package openstack
+
+import (
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/errf"
+)
+
+// NewOpenStack new openstack.
+func NewOpenStack(credential *types.OpenStackCredential) (*OpenStack, error) {
+	if credential == nil {
+		return nil, errf.New(errf.InvalidParameter, "credential is required")
+	}
+
+	if err := credential.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return &OpenStack{clientSet: newClientSet(credential)}, nil
+}
+
+// OpenStack is openstack operator.
+type OpenStack struct {
+	clientSet *clientSet
+}
@@ -0,0 +1,130 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+type securityGroup struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ProjectID   string `json:"project_id"`
+	// Stateful 老版本 neutron 不返回该字段，此时安全组均为有状态
+	Stateful *bool `json:"stateful"`
+}
+
+// ListSecurityGroup list security group.
+// reference: https://docs.openstack.org/api-ref/network/v2/#list-security-groups
+func (o *OpenStack) ListSecurityGroup(kt *kit.Kit, opt *securitygroup.OpenStackListOption) (
+	[]securitygroup.OpenStackSecurityGroup, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	query := make(url.Values)
+	for _, id := range opt.CloudIDs {
+		query.Add("id", id)
+	}
+	sgs, err := listByMarker(kt, o.clientSet, networkService, opt.Region, "/v2.0/security-groups",
+		"security_groups", query, func(one securityGroup) string { return one.ID })
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]securitygroup.OpenStackSecurityGroup, 0, len(sgs))
+	for _, one := range sgs {
+		stateful := true
+		if one.Stateful != nil {
+			stateful = converter.PtrToVal(one.Stateful)
+		}
+
+		result = append(result, securitygroup.OpenStackSecurityGroup{
+			CloudID:        one.ID,
+			Name:           one.Name,
+			Region:         opt.Region,
+			Description:    one.Description,
+			CloudProjectID: one.ProjectID,
+			Stateful:       stateful,
+		})
+	}
+
+	return result, nil
+}
+
+// DeleteSecurityGroup delete security group.
+// reference: https://docs.openstack.org/api-ref/network/v2/#delete-security-group
+func (o *OpenStack) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.OpenStackDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return o.clientSet.do(kt, http.MethodDelete, networkService, opt.Region,
+		fmt.Sprintf("/v2.0/security-groups/%s", opt.CloudID), nil, nil, nil)
+}
+
+// SecurityGroupCvmAssociate associate cvm, nova finds security group by name or id.
+// reference: https://docs.openstack.org/api-ref/compute/#add-security-group-to-a-server-addsecuritygroup-action
+func (o *OpenStack) SecurityGroupCvmAssociate(kt *kit.Kit, opt *securitygroup.OpenStackAssociateCvmOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "associate option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	body := map[string]interface{}{"addSecurityGroup": map[string]string{"name": opt.CloudSecurityGroupID}}
+	return o.serverAction(kt, opt.Region, opt.CloudCvmID, body)
+}
+
+// SecurityGroupCvmDisassociate disassociate cvm.
+// reference: https://docs.openstack.org/api-ref/compute/#remove-security-group-from-a-server-removesecuritygroup-action
+func (o *OpenStack) SecurityGroupCvmDisassociate(kt *kit.Kit,
+	opt *securitygroup.OpenStackAssociateCvmOption) error {
+
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "disassociate option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	body := map[string]interface{}{"removeSecurityGroup": map[string]string{"name": opt.CloudSecurityGroupID}}
+	return o.serverAction(kt, opt.Region, opt.CloudCvmID, body)
+}
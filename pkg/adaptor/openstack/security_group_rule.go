@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+	"net/url"
+
+	securitygrouprule "hcm/pkg/adaptor/types/security-group-rule"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+type securityGroupRule struct {
+	ID              string  `json:"id"`
+	SecurityGroupID string  `json:"security_group_id"`
+	Direction       string  `json:"direction"`
+	Ethertype       string  `json:"ethertype"`
+	Protocol        *string `json:"protocol"`
+	PortRangeMin    *int64  `json:"port_range_min"`
+	PortRangeMax    *int64  `json:"port_range_max"`
+	RemoteIPPrefix  *string `json:"remote_ip_prefix"`
+	RemoteGroupID   *string `json:"remote_group_id"`
+	Description     string  `json:"description"`
+	ProjectID       string  `json:"project_id"`
+}
+
+// port returns port range of the rule, empty means all ports.
+func (rule securityGroupRule) port() string {
+	if rule.PortRangeMin == nil {
+		return ""
+	}
+
+	minPort := converter.PtrToVal(rule.PortRangeMin)
+	if rule.PortRangeMax == nil || *rule.PortRangeMax == minPort {
+		return fmt.Sprintf("%d", minPort)
+	}
+
+	return fmt.Sprintf("%d-%d", minPort, *rule.PortRangeMax)
+}
+
+// ListSecurityGroupRule list rules of security group.
+// reference: https://docs.openstack.org/api-ref/network/v2/#list-security-group-rules
+func (o *OpenStack) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.OpenStackListOption) (
+	[]securitygrouprule.OpenStackSGRule, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	query := make(url.Values)
+	query.Set("security_group_id", opt.CloudSecurityGroupID)
+	rules, err := listByMarker(kt, o.clientSet, networkService, opt.Region, "/v2.0/security-group-rules",
+		"security_group_rules", query, func(one securityGroupRule) string { return one.ID })
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]securitygrouprule.OpenStackSGRule, 0, len(rules))
+	for _, one := range rules {
+		result = append(result, securitygrouprule.OpenStackSGRule{
+			CloudID:              one.ID,
+			CloudSecurityGroupID: one.SecurityGroupID,
+			Direction:            one.Direction,
+			Ethertype:            one.Ethertype,
+			Protocol:             converter.PtrToVal(one.Protocol),
+			RemoteIPPrefix:       converter.PtrToVal(one.RemoteIPPrefix),
+			RemoteGroupID:        converter.PtrToVal(one.RemoteGroupID),
+			Port:                 one.port(),
+			Description:          one.Description,
+			CloudProjectID:       one.ProjectID,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"testing"
+
+	"hcm/pkg/tools/converter"
+)
+
+func TestSecurityGroupRulePort(t *testing.T) {
+	cases := []struct {
+		name   string
+		rule   securityGroupRule
+		expect string
+	}{
+		{name: "all ports", rule: securityGroupRule{}, expect: ""},
+		{name: "single port", rule: securityGroupRule{PortRangeMin: converter.ValToPtr(int64(22)),
+			PortRangeMax: converter.ValToPtr(int64(22))}, expect: "22"},
+		{name: "only min port", rule: securityGroupRule{PortRangeMin: converter.ValToPtr(int64(22))}, expect: "22"},
+		{name: "port range", rule: securityGroupRule{PortRangeMin: converter.ValToPtr(int64(80)),
+			PortRangeMax: converter.ValToPtr(int64(443))}, expect: "80-443"},
+	}
+
+	for _, c := range cases {
+		if got := c.rule.port(); got != c.expect {
+			t.Errorf("%s: port %q is not as expected %q", c.name, got, c.expect)
+		}
+	}
+}
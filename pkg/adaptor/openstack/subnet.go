@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"net/url"
+
+	"hcm/pkg/adaptor/types/core"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+type subnet struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	NetworkID      string   `json:"network_id"`
+	Cidr           string   `json:"cidr"`
+	IpVersion      int64    `json:"ip_version"`
+	GatewayIp      string   `json:"gateway_ip"`
+	EnableDhcp     bool     `json:"enable_dhcp"`
+	DnsNameservers []string `json:"dns_nameservers"`
+}
+
+// ListSubnet list subnet.
+// reference: https://docs.openstack.org/api-ref/network/v2/#list-subnets
+func (o *OpenStack) ListSubnet(kt *kit.Kit, opt *adtysubnet.OpenStackSubnetListOption) (
+	*adtysubnet.OpenStackSubnetListResult, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	query := make(url.Values)
+	if len(opt.CloudVpcID) != 0 {
+		query.Set("network_id", opt.CloudVpcID)
+	}
+	subnets, err := o.listSubnet(kt, &opt.OpenStackListOption, query)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]adtysubnet.OpenStackSubnet, 0, len(subnets))
+	for _, one := range subnets {
+		item := adtysubnet.OpenStackSubnet{
+			CloudVpcID: one.NetworkID,
+			CloudID:    one.ID,
+			Name:       one.Name,
+			Memo:       converter.ValToPtr(one.Description),
+			Extension: &adtysubnet.OpenStackSubnetExtension{
+				Region:         opt.Region,
+				IpVersion:      one.IpVersion,
+				GatewayIp:      one.GatewayIp,
+				EnableDhcp:     one.EnableDhcp,
+				DnsNameservers: one.DnsNameservers,
+			},
+		}
+
+		if one.IpVersion == 6 {
+			item.Ipv6Cidr = []string{one.Cidr}
+		} else {
+			item.Ipv4Cidr = []string{one.Cidr}
+		}
+		details = append(details, item)
+	}
+
+	return &adtysubnet.OpenStackSubnetListResult{Details: details}, nil
+}
+
+func (o *OpenStack) listSubnet(kt *kit.Kit, opt *core.OpenStackListOption, query url.Values) ([]subnet, error) {
+	if query == nil {
+		query = make(url.Values)
+	}
+
+	for _, id := range opt.CloudIDs {
+		query.Add("id", id)
+	}
+
+	return listByMarker(kt, o.clientSet, networkService, opt.Region, "/v2.0/subnets", "subnets", query,
+		func(one subnet) string { return one.ID })
+}
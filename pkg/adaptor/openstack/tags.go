@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// metadataService returns the service and path prefix of the resource which uses metadata as tags, only nova
+// server and cinder volume support metadata.
+func metadataService(resType enumor.CloudResourceType) (string, string, error) {
+	switch resType {
+	case enumor.CvmCloudResType:
+		return computeService, "/servers", nil
+	case enumor.DiskCloudResType:
+		return volumeService, "/volumes", nil
+	default:
+		return "", "", errf.Newf(errf.InvalidParameter, "openstack resource type: %s not support metadata", resType)
+	}
+}
+
+type metadataResp struct {
+	Metadata map[string]string `json:"metadata"`
+}
+
+// ListResourceTag list metadata of servers or volumes.
+// reference: https://docs.openstack.org/api-ref/compute/#list-all-metadata
+func (o *OpenStack) ListResourceTag(kt *kit.Kit, opt *typetag.ListOption) ([]typetag.ResourceTags, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	service, prefix, err := metadataService(opt.ResType)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]typetag.ResourceTags, 0, len(opt.CloudIDs))
+	for _, cloudID := range opt.CloudIDs {
+		resp := new(metadataResp)
+		err = o.clientSet.do(kt, http.MethodGet, service, opt.Region, fmt.Sprintf("%s/%s/metadata", prefix, cloudID),
+			nil, nil, resp)
+		if err != nil {
+			return nil, err
+		}
+
+		tags := make([]typetag.Tag, 0, len(resp.Metadata))
+		for key, value := range resp.Metadata {
+			tags = append(tags, typetag.Tag{Key: key, Value: value})
+		}
+		details = append(details, typetag.ResourceTags{CloudID: cloudID, Tags: tags})
+	}
+
+	return details, nil
+}
+
+// TagResource add or overwrite metadata of servers or volumes, the metadata not in request is not modified.
+// reference: https://docs.openstack.org/api-ref/compute/#create-or-update-metadata-items
+func (o *OpenStack) TagResource(kt *kit.Kit, opt *typetag.TagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	service, prefix, err := metadataService(opt.ResType)
+	if err != nil {
+		return err
+	}
+
+	metadata := make(map[string]string, len(opt.Tags))
+	for _, one := range opt.Tags {
+		metadata[one.Key] = one.Value
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		err = o.clientSet.do(kt, http.MethodPost, service, opt.Region, fmt.Sprintf("%s/%s/metadata", prefix, cloudID),
+			nil, map[string]interface{}{"metadata": metadata}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UntagResource remove metadata of servers or volumes by keys, the key not exists is ignored.
+// reference: https://docs.openstack.org/api-ref/compute/#delete-metadata-item
+func (o *OpenStack) UntagResource(kt *kit.Kit, opt *typetag.UntagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	service, prefix, err := metadataService(opt.ResType)
+	if err != nil {
+		return err
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		for _, key := range opt.Keys {
+			err = o.clientSet.do(kt, http.MethodDelete, service, opt.Region,
+				fmt.Sprintf("%s/%s/metadata/%s", prefix, cloudID, url.PathEscape(key)), nil, nil, nil)
+			if err != nil && err != errNotFound {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
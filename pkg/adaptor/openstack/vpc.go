@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"net/url"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+type network struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Status       string `json:"status"`
+	Shared       bool   `json:"shared"`
+	External     bool   `json:"router:external"`
+	AdminStateUp bool   `json:"admin_state_up"`
+	Mtu          int64  `json:"mtu"`
+}
+
+// ListVpc list vpc, openstack vpc is neutron network, cidr of vpc is collected from subnets of the network.
+// reference: https://docs.openstack.org/api-ref/network/v2/#list-networks
+func (o *OpenStack) ListVpc(kt *kit.Kit, opt *core.OpenStackListOption) (*types.OpenStackVpcListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	query := make(url.Values)
+	for _, id := range opt.CloudIDs {
+		query.Add("id", id)
+	}
+	networks, err := listByMarker(kt, o.clientSet, networkService, opt.Region, "/v2.0/networks", "networks",
+		query, func(one network) string { return one.ID })
+	if err != nil {
+		return nil, err
+	}
+
+	if len(networks) == 0 {
+		return &types.OpenStackVpcListResult{Details: make([]types.OpenStackVpc, 0)}, nil
+	}
+
+	subnetOpt := &core.OpenStackListOption{Region: opt.Region}
+	subnetQuery := make(url.Values)
+	if len(opt.CloudIDs) != 0 {
+		for _, one := range networks {
+			subnetQuery.Add("network_id", one.ID)
+		}
+	}
+	subnets, err := o.listSubnet(kt, subnetOpt, subnetQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	cidrMap := make(map[string][]cloud.OpenStackCidr)
+	for _, one := range subnets {
+		cidrType := enumor.Ipv4
+		if one.IpVersion == 6 {
+			cidrType = enumor.Ipv6
+		}
+		cidrMap[one.NetworkID] = append(cidrMap[one.NetworkID], cloud.OpenStackCidr{Type: cidrType, Cidr: one.Cidr})
+	}
+
+	details := make([]types.OpenStackVpc, 0, len(networks))
+	for _, one := range networks {
+		details = append(details, types.OpenStackVpc{
+			CloudID: one.ID,
+			Name:    one.Name,
+			Region:  opt.Region,
+			Memo:    converter.ValToPtr(one.Description),
+			Extension: &cloud.OpenStackVpcExtension{
+				Cidr:         cidrMap[one.ID],
+				Status:       one.Status,
+				Shared:       one.Shared,
+				External:     one.External,
+				AdminStateUp: one.AdminStateUp,
+				Mtu:          one.Mtu,
+			},
+		})
+	}
+
+	return &types.OpenStackVpcListResult{Details: details}, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package openstack
+
+import (
+	"net/http"
+
+	typeszone "hcm/pkg/adaptor/types/zone"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+type availabilityZoneResp struct {
+	AvailabilityZoneInfo []struct {
+		ZoneName  string `json:"zoneName"`
+		ZoneState struct {
+			Available bool `json:"available"`
+		} `json:"zoneState"`
+	} `json:"availabilityZoneInfo"`
+}
+
+// ListZone list compute availability zones.
+// reference: https://docs.openstack.org/api-ref/compute/#get-availability-zone-information
+func (o *OpenStack) ListZone(kt *kit.Kit, opt *typeszone.OpenStackZoneListOption) ([]typeszone.OpenStackZone,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resp := new(availabilityZoneResp)
+	err := o.clientSet.do(kt, http.MethodGet, computeService, opt.Region, "/os-availability-zone", nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]typeszone.OpenStackZone, 0, len(resp.AvailabilityZoneInfo))
+	for _, one := range resp.AvailabilityZoneInfo {
+		zones = append(zones, typeszone.OpenStackZone{Name: one.ZoneName, Available: one.ZoneState.Available})
+	}
+
+	return zones, nil
+}
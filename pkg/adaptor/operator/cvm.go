@@ -0,0 +1,135 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package operator
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+)
+
+// CvmOperator defines vendor-agnostic cvm operations.
+type CvmOperator interface {
+	ListCvm(kt *kit.Kit, opt *CvmListOption) ([]Cvm, error)
+	StartCvm(kt *kit.Kit, opt *CvmOperateOption) error
+	StopCvm(kt *kit.Kit, opt *CvmStopOption) error
+	RebootCvm(kt *kit.Kit, opt *CvmStopOption) error
+	DeleteCvm(kt *kit.Kit, opt *CvmDeleteOption) error
+}
+
+// Cvm normalized cvm.
+type Cvm struct {
+	CloudID      string `json:"cloud_id"`
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	Zone         string `json:"zone"`
+	InstanceType string `json:"instance_type"`
+}
+
+// CvmListOption list cvm by cloud ids in one location, gcp use Zone, azure use ResourceGroupName, others use Region.
+type CvmListOption struct {
+	Region            string   `json:"region"`
+	Zone              string   `json:"zone"`
+	ResourceGroupName string   `json:"resource_group_name"`
+	CloudIDs          []string `json:"cloud_ids" validate:"required,min=1"`
+}
+
+// Validate CvmListOption.
+func (opt CvmListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("cloud_ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// CvmOperateOption cvm batch operate option.
+type CvmOperateOption struct {
+	Cvms []ResourceRef `json:"cvms" validate:"required,min=1"`
+}
+
+// Validate CvmOperateOption.
+func (opt CvmOperateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.Cvms) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("cvms should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, one := range opt.Cvms {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GroupByRegion validate option and group cvm cloud ids by region.
+func (opt CvmOperateOption) GroupByRegion() (map[string][]string, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return groupByRegion(opt.Cvms)
+}
+
+// CvmStopOption cvm stop or reboot option.
+type CvmStopOption struct {
+	CvmOperateOption `json:",inline"`
+	// Force 是否强制关机，不支持强制关机的云厂商忽略该参数
+	Force bool `json:"force"`
+	// SoftFirst 是否优先软关机，软关机失败后再强制关机，仅腾讯云支持，Force 为 true 时忽略
+	SoftFirst bool `json:"soft_first"`
+	// StopCharging 关机后是否停止收费，仅腾讯云关机支持
+	StopCharging bool `json:"stop_charging"`
+	// Hibernate 是否休眠，仅 aws 关机支持
+	Hibernate bool `json:"hibernate"`
+}
+
+// Validate CvmStopOption.
+func (opt CvmStopOption) Validate() error {
+	return opt.CvmOperateOption.Validate()
+}
+
+// CvmDeleteOption cvm delete option.
+type CvmDeleteOption struct {
+	CvmOperateOption `json:",inline"`
+	// Force 是否强制删除，仅 azure 支持
+	Force bool `json:"force"`
+	// DeletePublicIP 是否同时删除绑定的公网IP，仅华为云支持
+	DeletePublicIP bool `json:"delete_public_ip"`
+	// DeleteDisk 是否同时删除挂载的数据盘，仅华为云支持
+	DeleteDisk bool `json:"delete_disk"`
+}
+
+// Validate CvmDeleteOption.
+func (opt CvmDeleteOption) Validate() error {
+	return opt.CvmOperateOption.Validate()
+}
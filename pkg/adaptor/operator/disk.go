@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package operator
+
+import (
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+)
+
+// DiskOperator defines vendor-agnostic disk operations.
+type DiskOperator interface {
+	AttachDisk(kt *kit.Kit, opt *DiskAttachOption) error
+	DetachDisk(kt *kit.Kit, opt *DiskAttachOption) error
+	DeleteDisk(kt *kit.Kit, opt *DiskDeleteOption) error
+}
+
+// DiskAttachOption disk attach or detach option.
+type DiskAttachOption struct {
+	Cvm  ResourceRef `json:"cvm" validate:"required"`
+	Disk ResourceRef `json:"disk" validate:"required"`
+	// DeviceName 设备名称，aws 挂载、gcp 卸载时必填
+	DeviceName string `json:"device_name"`
+	// CachingType 缓存类型，仅 azure 挂载支持，为空时使用默认缓存类型
+	CachingType string `json:"caching_type"`
+}
+
+// Validate DiskAttachOption.
+func (opt DiskAttachOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// DiskDeleteOption disk delete option.
+type DiskDeleteOption struct {
+	Disk ResourceRef `json:"disk" validate:"required"`
+}
+
+// Validate DiskDeleteOption.
+func (opt DiskDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
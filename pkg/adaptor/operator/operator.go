@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package operator defines vendor-agnostic cloud operation interfaces, every vendor adaptor implements them
+// and registers itself into the registry, so that generic features only need to be implemented once.
+package operator
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+)
+
+// Operator defines all vendor-agnostic operations a vendor adaptor supports.
+type Operator interface {
+	Vendor() enumor.Vendor
+	CvmOperator
+	DiskOperator
+	SecurityGroupOperator
+	TagOperator
+}
+
+// Credential 统一的云账号凭证，各云厂商按需使用其中的字段。
+type Credential struct {
+	// Secret tcloud、aws、huawei 使用的密钥
+	Secret *types.BaseSecret
+	// CloudAccountID aws 主账号ID
+	CloudAccountID string
+	// Gcp gcp 使用的凭证
+	Gcp *types.GcpCredential
+	// Azure azure 使用的凭证
+	Azure *types.AzureCredential
+	// OpenStack openstack 使用的凭证
+	OpenStack *types.OpenStackCredential
+}
+
+// ResourceRef 统一的云资源定位信息，不同云厂商定位资源使用的字段不同：
+// tcloud、aws、huawei 使用 Region + CloudID，gcp 使用 Zone + Name，azure 使用 ResourceGroupName + Name。
+type ResourceRef struct {
+	Region            string `json:"region"`
+	Zone              string `json:"zone"`
+	ResourceGroupName string `json:"resource_group_name"`
+	CloudID           string `json:"cloud_id" validate:"required"`
+	Name              string `json:"name"`
+}
+
+// Validate ResourceRef.
+func (r ResourceRef) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// NotSupportError returns the error of the operation which is not supported by the vendor.
+func NotSupportError(vendor enumor.Vendor, operation string) error {
+	return errf.Newf(errf.InvalidParameter, "vendor: %s not support %s", vendor, operation)
+}
+
+// groupByRegion group resources by region, used by vendors which support batch operation in one region.
+func groupByRegion(refs []ResourceRef) (map[string][]string, error) {
+	result := make(map[string][]string)
+	for _, ref := range refs {
+		if len(ref.Region) == 0 {
+			return nil, fmt.Errorf("resource: %s region is required", ref.CloudID)
+		}
+		result[ref.Region] = append(result[ref.Region], ref.CloudID)
+	}
+
+	return result, nil
+}
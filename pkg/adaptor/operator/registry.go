@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package operator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// Factory create vendor operator by credential.
+type Factory func(cred *Credential) (Operator, error)
+
+var (
+	registryLock sync.RWMutex
+	registry     = make(map[enumor.Vendor]Factory)
+)
+
+// Register register vendor operator factory, it is called in init function of the vendor adaptor package,
+// register the same vendor twice will panic.
+func Register(vendor enumor.Vendor, factory Factory) {
+	if len(vendor) == 0 || factory == nil {
+		panic("register operator vendor and factory is required")
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if _, exist := registry[vendor]; exist {
+		panic(fmt.Sprintf("vendor: %s operator is already registered", vendor))
+	}
+	registry[vendor] = factory
+}
+
+// New create operator of the vendor.
+func New(vendor enumor.Vendor, cred *Credential) (Operator, error) {
+	registryLock.RLock()
+	factory, exist := registry[vendor]
+	registryLock.RUnlock()
+
+	if !exist {
+		return nil, fmt.Errorf("vendor: %s operator is not registered", vendor)
+	}
+
+	if cred == nil {
+		return nil, fmt.Errorf("vendor: %s operator credential is required", vendor)
+	}
+
+	return factory(cred)
+}
+
+// IsRegistered returns if the vendor operator is registered.
+func IsRegistered(vendor enumor.Vendor) bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	_, exist := registry[vendor]
+	return exist
+}
+
+// Vendors returns all registered vendors in order.
+func Vendors() []enumor.Vendor {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	vendors := make([]enumor.Vendor, 0, len(registry))
+	for vendor := range registry {
+		vendors = append(vendors, vendor)
+	}
+	sort.Slice(vendors, func(i, j int) bool { return vendors[i] < vendors[j] })
+
+	return vendors
+}
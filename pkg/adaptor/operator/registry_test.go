@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package operator_test
+
+import (
+	"testing"
+
+	_ "hcm/pkg/adaptor"
+	"hcm/pkg/adaptor/operator"
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestVendorsRegistered(t *testing.T) {
+	for _, vendor := range []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei, enumor.Gcp, enumor.Azure} {
+		if !operator.IsRegistered(vendor) {
+			t.Errorf("vendor %s operator expect registered, but not", vendor)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	cred := &operator.Credential{Secret: &types.BaseSecret{CloudSecretID: "id", CloudSecretKey: "key"}}
+	op, err := operator.New(enumor.TCloud, cred)
+	if err != nil {
+		t.Fatalf("new tcloud operator failed, err: %v", err)
+	}
+
+	if op.Vendor() != enumor.TCloud {
+		t.Errorf("operator vendor expect %s, but got %s", enumor.TCloud, op.Vendor())
+	}
+
+	if _, err = operator.New("not_exist", cred); err == nil {
+		t.Errorf("new not registered vendor operator expect error, but not")
+	}
+
+	if _, err = operator.New(enumor.Gcp, cred); err == nil {
+		t.Errorf("new gcp operator without gcp credential expect error, but not")
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("register duplicate vendor expect panic, but not")
+		}
+	}()
+
+	operator.Register(enumor.TCloud, func(cred *operator.Credential) (operator.Operator, error) {
+		return nil, nil
+	})
+}
+
+func TestGroupByRegion(t *testing.T) {
+	opt := &operator.CvmOperateOption{Cvms: []operator.ResourceRef{
+		{Region: "ap-guangzhou", CloudID: "ins-1"},
+		{Region: "ap-shanghai", CloudID: "ins-2"},
+		{Region: "ap-guangzhou", CloudID: "ins-3"},
+	}}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		t.Fatalf("group by region failed, err: %v", err)
+	}
+
+	if len(regionIDs) != 2 || len(regionIDs["ap-guangzhou"]) != 2 || len(regionIDs["ap-shanghai"]) != 1 {
+		t.Errorf("group by region result not right, got %v", regionIDs)
+	}
+
+	opt.Cvms = append(opt.Cvms, operator.ResourceRef{CloudID: "ins-4"})
+	if _, err = opt.GroupByRegion(); err == nil {
+		t.Errorf("group cvm without region expect error, but not")
+	}
+}
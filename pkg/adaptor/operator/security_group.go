@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package operator
+
+import (
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+)
+
+// SecurityGroupOperator defines vendor-agnostic security group operations.
+type SecurityGroupOperator interface {
+	DeleteSecurityGroup(kt *kit.Kit, opt *SecurityGroupDeleteOption) error
+	AssociateCvm(kt *kit.Kit, opt *SecurityGroupCvmOption) error
+	DisassociateCvm(kt *kit.Kit, opt *SecurityGroupCvmOption) error
+}
+
+// SecurityGroupDeleteOption security group delete option.
+type SecurityGroupDeleteOption struct {
+	SecurityGroup ResourceRef `json:"security_group" validate:"required"`
+}
+
+// Validate SecurityGroupDeleteOption.
+func (opt SecurityGroupDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// SecurityGroupCvmOption security group associate or disassociate cvm option, security group and cvm must be in
+// the same region.
+type SecurityGroupCvmOption struct {
+	Region               string `json:"region" validate:"required"`
+	CloudSecurityGroupID string `json:"cloud_security_group_id" validate:"required"`
+	CloudCvmID           string `json:"cloud_cvm_id" validate:"required"`
+}
+
+// Validate SecurityGroupCvmOption.
+func (opt SecurityGroupCvmOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
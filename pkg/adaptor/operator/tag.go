@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package operator
+
+import (
+	"fmt"
+
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+)
+
+// TagOperator defines vendor-agnostic resource tag operations, tag is called label in gcp and metadata in
+// openstack.
+type TagOperator interface {
+	// TagResTypes returns the resource types which support tag of the vendor.
+	TagResTypes() []enumor.CloudResourceType
+	ListTag(kt *kit.Kit, opt *TagListOption) ([]typetag.ResourceTags, error)
+	TagResource(kt *kit.Kit, opt *TagResourceOption) error
+	UntagResource(kt *kit.Kit, opt *UntagResourceOption) error
+}
+
+// IsTagSupported returns if the resource type supports tag of the vendor operator.
+func IsTagSupported(op TagOperator, resType enumor.CloudResourceType) bool {
+	for _, one := range op.TagResTypes() {
+		if one == resType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TagListOption resource tag list option, resources must be of the same resource type.
+type TagListOption struct {
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Resources []ResourceRef            `json:"resources" validate:"required,min=1"`
+}
+
+// Validate TagListOption.
+func (opt TagListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.Resources) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("resources should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, one := range opt.Resources {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GroupByRegion validate option and group resource cloud ids by region.
+func (opt TagListOption) GroupByRegion() (map[string][]string, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return groupByRegion(opt.Resources)
+}
+
+// CloudIDs returns cloud ids of the resources.
+func (opt TagListOption) CloudIDs() []string {
+	cloudIDs := make([]string, 0, len(opt.Resources))
+	for _, one := range opt.Resources {
+		cloudIDs = append(cloudIDs, one.CloudID)
+	}
+
+	return cloudIDs
+}
+
+// TagResourceOption resource tag option, tags with existing keys are overwritten.
+type TagResourceOption struct {
+	TagListOption `json:",inline"`
+	Tags          []typetag.Tag `json:"tags" validate:"required,min=1,dive"`
+}
+
+// Validate TagResourceOption.
+func (opt TagResourceOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	return opt.TagListOption.Validate()
+}
+
+// UntagResourceOption resource untag option, tags are removed by keys.
+type UntagResourceOption struct {
+	TagListOption `json:",inline"`
+	Keys          []string `json:"keys" validate:"required,min=1"`
+}
+
+// Validate UntagResourceOption.
+func (opt UntagResourceOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	return opt.TagListOption.Validate()
+}
@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package operator_test
+
+import (
+	"testing"
+
+	"hcm/pkg/adaptor/operator"
+	"hcm/pkg/adaptor/types"
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestTagResourceOptionValidate(t *testing.T) {
+	opt := &operator.TagResourceOption{
+		TagListOption: operator.TagListOption{
+			ResType:   enumor.CvmCloudResType,
+			Resources: []operator.ResourceRef{{Region: "ap-guangzhou", CloudID: "ins-1"}},
+		},
+		Tags: []typetag.Tag{{Key: "owner", Value: "tom"}},
+	}
+	if err := opt.Validate(); err != nil {
+		t.Fatalf("validate tag resource option failed, err: %v", err)
+	}
+
+	opt.Tags = []typetag.Tag{{Value: "tom"}}
+	if err := opt.Validate(); err == nil {
+		t.Errorf("validate tag without key expect error, but not")
+	}
+
+	untag := &operator.UntagResourceOption{TagListOption: opt.TagListOption}
+	if err := untag.Validate(); err == nil {
+		t.Errorf("validate untag resource option without keys expect error, but not")
+	}
+
+	refs := make([]operator.ResourceRef, 0)
+	for i := 0; i < 101; i++ {
+		refs = append(refs, operator.ResourceRef{Region: "ap-guangzhou", CloudID: "ins-1"})
+	}
+	untag.Keys = []string{"owner"}
+	untag.Resources = refs
+	if err := untag.Validate(); err == nil {
+		t.Errorf("validate untag resource option with more than 100 resources expect error, but not")
+	}
+}
+
+func TestTagListOptionGroupByRegion(t *testing.T) {
+	opt := &operator.TagListOption{
+		ResType: enumor.DiskCloudResType,
+		Resources: []operator.ResourceRef{
+			{Region: "ap-guangzhou", CloudID: "disk-1"},
+			{Region: "ap-shanghai", CloudID: "disk-2"},
+			{Region: "ap-guangzhou", CloudID: "disk-3"},
+		},
+	}
+
+	regionIDs, err := opt.GroupByRegion()
+	if err != nil {
+		t.Fatalf("group by region failed, err: %v", err)
+	}
+
+	if len(regionIDs["ap-guangzhou"]) != 2 || len(regionIDs["ap-shanghai"]) != 1 {
+		t.Errorf("group by region result is invalid, result: %v", regionIDs)
+	}
+
+	if ids := opt.CloudIDs(); len(ids) != 3 || ids[1] != "disk-2" {
+		t.Errorf("cloud ids is invalid, ids: %v", ids)
+	}
+}
+
+func TestIsTagSupported(t *testing.T) {
+	cred := &operator.Credential{Secret: &types.BaseSecret{CloudSecretID: "id", CloudSecretKey: "key"}}
+	cases := []struct {
+		vendor    enumor.Vendor
+		resType   enumor.CloudResourceType
+		supported bool
+	}{
+		{vendor: enumor.TCloud, resType: enumor.SecurityGroupCloudResType, supported: true},
+		{vendor: enumor.Aws, resType: enumor.SubnetCloudResType, supported: true},
+		{vendor: enumor.HuaWei, resType: enumor.EipCloudResType, supported: true},
+		{vendor: enumor.HuaWei, resType: enumor.SecurityGroupCloudResType, supported: false},
+		{vendor: enumor.TCloud, resType: enumor.LoadBalancerCloudResType, supported: false},
+	}
+
+	for _, c := range cases {
+		op, err := operator.New(c.vendor, cred)
+		if err != nil {
+			t.Fatalf("new %s operator failed, err: %v", c.vendor, err)
+		}
+
+		if operator.IsTagSupported(op, c.resType) != c.supported {
+			t.Errorf("vendor %s resource type %s tag supported expect %v, but not", c.vendor, c.resType, c.supported)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package s3compat
+
+import (
+	"encoding/json"
+	"strings"
+
+	typebucket "hcm/pkg/adaptor/types/bucket"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// public grantee group uri suffix, aws and obs use http://acs.amazonaws.com/groups/global/AllUsers,
+// cos uses http://cam.qcloud.com/groups/global/AllUsers.
+var publicGroupSuffixes = []string{"/groups/global/AllUsers", "/groups/global/AuthenticatedUsers"}
+
+// getAclPublicAccess judge public access of bucket by acl granted to all users group.
+// reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketAcl.html
+func (c *Client) getAclPublicAccess(kt *kit.Kit, client *s3.S3, name string) (*typebucket.PublicAccess, error) {
+	resp, err := client.GetBucketAclWithContext(kt.Ctx, &s3.GetBucketAclInput{Bucket: aws.String(name)})
+	if err != nil {
+		logs.Errorf("get bucket acl failed, err: %v, bucket: %s, rid: %s", err, name, kt.Rid)
+		return nil, err
+	}
+
+	access := new(typebucket.PublicAccess)
+	for _, grant := range resp.Grants {
+		if grant == nil || grant.Grantee == nil || !isPublicGroup(converter.PtrToVal(grant.Grantee.URI)) {
+			continue
+		}
+
+		permission := converter.PtrToVal(grant.Permission)
+		switch permission {
+		case s3.PermissionRead:
+			access.Read = true
+		case s3.PermissionWrite:
+			access.Write = true
+		case s3.PermissionFullControl:
+			access.Read = true
+			access.Write = true
+		default:
+			continue
+		}
+
+		group := converter.PtrToVal(grant.Grantee.URI)
+		group = group[strings.LastIndex(group, "/")+1:]
+		access.Reasons = append(access.Reasons, typebucket.NewReason(typebucket.AclReasonSource, group, permission))
+	}
+
+	return access, nil
+}
+
+func isPublicGroup(uri string) bool {
+	for _, suffix := range publicGroupSuffixes {
+		if strings.HasSuffix(uri, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bucketPolicy is bucket policy document, principal and action can be a string or a list of strings,
+// and principal can also be a map like {"AWS": "*"} or {"qcs": ["qcs::cam::anyone:anyone"]}.
+type bucketPolicy struct {
+	Statement []policyStatement `json:"Statement"`
+}
+
+type policyStatement struct {
+	Sid       string          `json:"Sid"`
+	Effect    string          `json:"Effect"`
+	Principal json.RawMessage `json:"Principal"`
+	Action    json.RawMessage `json:"Action"`
+	Condition json.RawMessage `json:"Condition"`
+}
+
+// getPolicyPublicAccess judge public access of bucket by policy statements which allow anonymous principal
+// without any condition.
+// reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketPolicy.html
+func (c *Client) getPolicyPublicAccess(kt *kit.Kit, client *s3.S3, name string) (*typebucket.PublicAccess,
+	error) {
+
+	resp, err := client.GetBucketPolicyWithContext(kt.Ctx, &s3.GetBucketPolicyInput{Bucket: aws.String(name)})
+	if err != nil {
+		if IsErrorCode(err, errNoSuchBucketPolicy) {
+			return new(typebucket.PublicAccess), nil
+		}
+		logs.Errorf("get bucket policy failed, err: %v, bucket: %s, rid: %s", err, name, kt.Rid)
+		return nil, err
+	}
+
+	return ParsePolicyPublicAccess(converter.PtrToVal(resp.Policy))
+}
+
+// ParsePolicyPublicAccess parse public access from bucket policy document.
+func ParsePolicyPublicAccess(policy string) (*typebucket.PublicAccess, error) {
+	access := new(typebucket.PublicAccess)
+	if len(policy) == 0 {
+		return access, nil
+	}
+
+	doc := new(bucketPolicy)
+	if err := json.Unmarshal([]byte(policy), doc); err != nil {
+		return nil, err
+	}
+
+	for _, statement := range doc.Statement {
+		if !strings.EqualFold(statement.Effect, "Allow") || !isEmptyJson(statement.Condition) {
+			continue
+		}
+
+		if !isAnonymousPrincipal(statement.Principal) {
+			continue
+		}
+
+		actions := flattenStrings(statement.Action)
+		read, write := false, false
+		for _, action := range actions {
+			// action is like s3:GetObject, name/cos:GetObject or obs:object:GetObject.
+			action = strings.ToLower(action[strings.LastIndex(action, ":")+1:])
+			if action == "*" {
+				read, write = true, true
+				break
+			}
+
+			if strings.HasPrefix(action, "get") || strings.HasPrefix(action, "list") ||
+				strings.HasPrefix(action, "head") {
+				read = true
+			}
+
+			if strings.HasPrefix(action, "put") || strings.HasPrefix(action, "delete") ||
+				strings.HasPrefix(action, "post") || strings.HasPrefix(action, "abort") {
+				write = true
+			}
+		}
+
+		if !read && !write {
+			continue
+		}
+
+		access.Read = access.Read || read
+		access.Write = access.Write || write
+		access.Reasons = append(access.Reasons, typebucket.NewReason(typebucket.PolicyReasonSource,
+			statement.Sid, strings.Join(actions, ",")))
+	}
+
+	return access, nil
+}
+
+func isAnonymousPrincipal(principal json.RawMessage) bool {
+	for _, one := range flattenStrings(principal) {
+		if one == "*" || strings.HasPrefix(one, "qcs::cam::anyone") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isEmptyJson(raw json.RawMessage) bool {
+	value := strings.TrimSpace(string(raw))
+	return len(value) == 0 || value == "null" || value == "{}"
+}
+
+// flattenStrings flatten all string values in the json, which can be a string, a list or a map.
+func flattenStrings(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+
+	result := make([]string, 0)
+	var flatten func(v interface{})
+	flatten = func(v interface{}) {
+		switch val := v.(type) {
+		case string:
+			result = append(result, val)
+		case []interface{}:
+			for _, one := range val {
+				flatten(one)
+			}
+		case map[string]interface{}:
+			for _, one := range val {
+				flatten(one)
+			}
+		}
+	}
+	flatten(value)
+
+	return result
+}
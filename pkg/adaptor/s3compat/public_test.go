@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package s3compat
+
+import (
+	"testing"
+)
+
+func TestParsePolicyPublicAccess(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  string
+		read    bool
+		write   bool
+		reasons int
+	}{
+		{
+			name:   "empty policy",
+			policy: "",
+		},
+		{
+			name: "aws anonymous read",
+			policy: `{"Statement":[{"Sid":"public-read","Effect":"Allow","Principal":"*",` +
+				`"Action":["s3:GetObject"],"Resource":"arn:aws:s3:::bucket/*"}]}`,
+			read:    true,
+			reasons: 1,
+		},
+		{
+			name: "cos anonymous full control",
+			policy: `{"Statement":[{"Sid":"all","Effect":"Allow","Principal":{"qcs":["qcs::cam::anyone:anyone"]},` +
+				`"Action":["name/cos:*"]}]}`,
+			read:    true,
+			write:   true,
+			reasons: 1,
+		},
+		{
+			name: "anonymous write by map principal",
+			policy: `{"Statement":[{"Sid":"write","Effect":"Allow","Principal":{"AWS":"*"},` +
+				`"Action":"s3:PutObject"}]}`,
+			write:   true,
+			reasons: 1,
+		},
+		{
+			name: "statement with condition is not public",
+			policy: `{"Statement":[{"Sid":"vpc","Effect":"Allow","Principal":"*","Action":"s3:GetObject",` +
+				`"Condition":{"StringEquals":{"aws:SourceVpce":"vpce-1"}}}]}`,
+		},
+		{
+			name: "deny and specified principal are not public",
+			policy: `{"Statement":[{"Sid":"deny","Effect":"Deny","Principal":"*","Action":"s3:*"},` +
+				`{"Sid":"user","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123:root"},"Action":"s3:*"}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		access, err := ParsePolicyPublicAccess(c.policy)
+		if err != nil {
+			t.Errorf("%s: parse policy failed, err: %v", c.name, err)
+			continue
+		}
+
+		if access.Read != c.read || access.Write != c.write || len(access.Reasons) != c.reasons {
+			t.Errorf("%s: expect read: %v, write: %v, reasons: %d, got: %+v", c.name, c.read, c.write,
+				c.reasons, access)
+		}
+	}
+
+	if _, err := ParsePolicyPublicAccess("{invalid"); err == nil {
+		t.Errorf("parse invalid policy should fail")
+	}
+}
+
+func TestIsPublicGroup(t *testing.T) {
+	if !isPublicGroup("http://acs.amazonaws.com/groups/global/AllUsers") {
+		t.Errorf("aws all users group should be public")
+	}
+	if !isPublicGroup("http://cam.qcloud.com/groups/global/AuthenticatedUsers") {
+		t.Errorf("cos authenticated users group should be public")
+	}
+	if isPublicGroup("http://acs.amazonaws.com/groups/s3/LogDelivery") {
+		t.Errorf("log delivery group should not be public")
+	}
+}
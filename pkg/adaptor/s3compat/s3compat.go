@@ -0,0 +1,308 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package s3compat implements bucket inventory of object storage compatible with aws s3 protocol,
+// aws s3, tencent cloud cos and huawei cloud obs are all accessed by this package.
+package s3compat
+
+import (
+	"encoding/xml"
+	"errors"
+	"time"
+
+	typebucket "hcm/pkg/adaptor/types/bucket"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// error codes returned when the bucket configuration is not set, which are not treated as errors.
+const (
+	errNoSuchBucketPolicy         = "NoSuchBucketPolicy"
+	errNoSuchEncryption           = "ServerSideEncryptionConfigurationNotFoundError"
+	errNoSuchCosEncryption        = "NoSuchEncryptionConfiguration"
+	errNoSuchPublicAccessBlockCfg = "NoSuchPublicAccessBlockConfiguration"
+)
+
+// Config defines s3 compatible client config.
+type Config struct {
+	SecretID  string
+	SecretKey string
+	// ServiceRegion region used to request service level api, e.g. list buckets.
+	ServiceRegion string
+	// Endpoint returns endpoint of the region, empty region means service endpoint.
+	// nil Endpoint means using aws s3 default endpoint.
+	Endpoint func(region string) string
+}
+
+// Client is s3 compatible object storage client.
+type Client struct {
+	cfg         *Config
+	credentials *credentials.Credentials
+}
+
+// NewClient new s3 compatible client.
+func NewClient(cfg *Config) *Client {
+	return &Client{
+		cfg:         cfg,
+		credentials: credentials.NewStaticCredentials(cfg.SecretID, cfg.SecretKey, ""),
+	}
+}
+
+func (c *Client) s3Client(region string) (*s3.S3, error) {
+	cfg := &aws.Config{
+		Credentials: c.credentials,
+	}
+
+	if len(region) != 0 {
+		cfg.Region = aws.String(region)
+	} else {
+		cfg.Region = aws.String(c.cfg.ServiceRegion)
+	}
+
+	if c.cfg.Endpoint != nil {
+		cfg.Endpoint = aws.String(c.cfg.Endpoint(region))
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return s3.New(sess), nil
+}
+
+// listAllMyBucketsResult is list buckets response, cos returns location of bucket in it, which is not
+// supported by aws sdk's output, so the response is decoded by ourselves.
+type listAllMyBucketsResult struct {
+	Buckets []struct {
+		Name         string    `xml:"Name"`
+		Location     string    `xml:"Location"`
+		CreationDate time.Time `xml:"CreationDate"`
+	} `xml:"Buckets>Bucket"`
+}
+
+// ListBucket list all buckets of account and fill the attributes of buckets.
+// reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListBuckets.html
+func (c *Client) ListBucket(kt *kit.Kit, opt *AttributeOption) ([]typebucket.Bucket, error) {
+	client, err := c.s3Client("")
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(listAllMyBucketsResult)
+	req, _ := client.ListBucketsRequest(new(s3.ListBucketsInput))
+	req.SetContext(kt.Ctx)
+	req.Handlers.Unmarshal.Clear()
+	req.Handlers.Unmarshal.PushBack(func(r *request.Request) {
+		defer r.HTTPResponse.Body.Close()
+		if err := xml.NewDecoder(r.HTTPResponse.Body).Decode(result); err != nil {
+			r.Error = awserr.New(request.ErrCodeSerialization, "decode list buckets response failed", err)
+		}
+	})
+	if err = req.Send(); err != nil {
+		logs.Errorf("list buckets failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	buckets := make([]typebucket.Bucket, 0, len(result.Buckets))
+	for _, one := range result.Buckets {
+		region := one.Location
+		if len(region) == 0 {
+			if region, err = c.getBucketLocation(kt, client, one.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		bucket := typebucket.Bucket{
+			CloudID: one.Name,
+			Name:    one.Name,
+			Region:  region,
+		}
+		if !one.CreationDate.IsZero() {
+			bucket.CloudCreatedTime = times.ConvStdTimeFormat(one.CreationDate)
+		}
+
+		if err = c.fillAttribute(kt, &bucket, opt); err != nil {
+			return nil, err
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+// getBucketLocation get region of bucket, aws returns empty location for us-east-1 and EU for eu-west-1.
+// reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLocation.html
+func (c *Client) getBucketLocation(kt *kit.Kit, client *s3.S3, name string) (string, error) {
+	resp, err := client.GetBucketLocationWithContext(kt.Ctx, &s3.GetBucketLocationInput{Bucket: aws.String(name)})
+	if err != nil {
+		logs.Errorf("get bucket location failed, err: %v, bucket: %s, rid: %s", err, name, kt.Rid)
+		return "", err
+	}
+
+	location := converter.PtrToVal(resp.LocationConstraint)
+	switch location {
+	case "":
+		return "us-east-1", nil
+	case "EU":
+		return "eu-west-1", nil
+	default:
+		return location, nil
+	}
+}
+
+// AttributeOption defines options to fill bucket attributes.
+type AttributeOption struct {
+	// PublicAccessBlock returns public access block config of bucket, public access granted by the
+	// blocked acl or policy is ignored. nil means no public access block.
+	PublicAccessBlock func(kt *kit.Kit, client *s3.S3, name string) (*s3.PublicAccessBlockConfiguration, error)
+}
+
+func (c *Client) fillAttribute(kt *kit.Kit, bucket *typebucket.Bucket, opt *AttributeOption) error {
+	client, err := c.s3Client(bucket.Region)
+	if err != nil {
+		return err
+	}
+
+	if err = c.fillEncryption(kt, client, bucket); err != nil {
+		return err
+	}
+
+	if err = c.fillVersioning(kt, client, bucket); err != nil {
+		return err
+	}
+
+	block := new(s3.PublicAccessBlockConfiguration)
+	if opt != nil && opt.PublicAccessBlock != nil {
+		if block, err = opt.PublicAccessBlock(kt, client, bucket.Name); err != nil {
+			return err
+		}
+	}
+
+	access := new(typebucket.PublicAccess)
+	if !converter.PtrToVal(block.IgnorePublicAcls) {
+		aclAccess, err := c.getAclPublicAccess(kt, client, bucket.Name)
+		if err != nil {
+			return err
+		}
+		access.Merge(aclAccess)
+	}
+
+	if !converter.PtrToVal(block.RestrictPublicBuckets) {
+		policyAccess, err := c.getPolicyPublicAccess(kt, client, bucket.Name)
+		if err != nil {
+			return err
+		}
+		access.Merge(policyAccess)
+	}
+
+	access.Apply(bucket)
+
+	return nil
+}
+
+// fillEncryption fill default server side encryption of bucket.
+// reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketEncryption.html
+func (c *Client) fillEncryption(kt *kit.Kit, client *s3.S3, bucket *typebucket.Bucket) error {
+	resp, err := client.GetBucketEncryptionWithContext(kt.Ctx,
+		&s3.GetBucketEncryptionInput{Bucket: aws.String(bucket.Name)})
+	if err != nil {
+		if IsErrorCode(err, errNoSuchEncryption, errNoSuchCosEncryption) {
+			return nil
+		}
+		logs.Errorf("get bucket encryption failed, err: %v, bucket: %s, rid: %s", err, bucket.Name, kt.Rid)
+		return err
+	}
+
+	if resp.ServerSideEncryptionConfiguration == nil {
+		return nil
+	}
+
+	for _, rule := range resp.ServerSideEncryptionConfiguration.Rules {
+		if rule == nil || rule.ApplyServerSideEncryptionByDefault == nil {
+			continue
+		}
+
+		bucket.Encrypted = true
+		bucket.EncryptionType = converter.PtrToVal(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+		return nil
+	}
+
+	return nil
+}
+
+// fillVersioning fill versioning status of bucket.
+// reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketVersioning.html
+func (c *Client) fillVersioning(kt *kit.Kit, client *s3.S3, bucket *typebucket.Bucket) error {
+	resp, err := client.GetBucketVersioningWithContext(kt.Ctx,
+		&s3.GetBucketVersioningInput{Bucket: aws.String(bucket.Name)})
+	if err != nil {
+		logs.Errorf("get bucket versioning failed, err: %v, bucket: %s, rid: %s", err, bucket.Name, kt.Rid)
+		return err
+	}
+
+	bucket.VersioningEnabled = converter.PtrToVal(resp.Status) == s3.BucketVersioningStatusEnabled
+	return nil
+}
+
+// GetPublicAccessBlock get aws s3 public access block config of bucket.
+// reference: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetPublicAccessBlock.html
+func GetPublicAccessBlock(kt *kit.Kit, client *s3.S3, name string) (*s3.PublicAccessBlockConfiguration, error) {
+	resp, err := client.GetPublicAccessBlockWithContext(kt.Ctx,
+		&s3.GetPublicAccessBlockInput{Bucket: aws.String(name)})
+	if err != nil {
+		if IsErrorCode(err, errNoSuchPublicAccessBlockCfg) {
+			return new(s3.PublicAccessBlockConfiguration), nil
+		}
+		logs.Errorf("get bucket public access block failed, err: %v, bucket: %s, rid: %s", err, name, kt.Rid)
+		return nil, err
+	}
+
+	if resp.PublicAccessBlockConfiguration == nil {
+		return new(s3.PublicAccessBlockConfiguration), nil
+	}
+
+	return resp.PublicAccessBlockConfiguration, nil
+}
+
+// IsErrorCode check if the error is aws error with one of the codes.
+func IsErrorCode(err error, codes ...string) bool {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return false
+	}
+
+	for _, code := range codes {
+		if awsErr.Code() == code {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"hcm/pkg/adaptor/s3compat"
+	typebucket "hcm/pkg/adaptor/types/bucket"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ListStorageBucket list all cos buckets of account by s3 compatible api, cos bucket name contains appid and is
+// globally unique, so it's used as cloud id.
+// reference: https://cloud.tencent.com/document/product/436/37421
+func (t *TCloud) ListStorageBucket(kt *kit.Kit) ([]typebucket.Bucket, error) {
+	client := s3compat.NewClient(&s3compat.Config{
+		SecretID:      t.clientSet.credential.SecretId,
+		SecretKey:     t.clientSet.credential.SecretKey,
+		ServiceRegion: "ap-guangzhou",
+		Endpoint: func(region string) string {
+			if len(region) == 0 {
+				return "service.cos.myqcloud.com"
+			}
+			return "cos." + region + ".myqcloud.com"
+		},
+	})
+
+	buckets, err := client.ListBucket(kt, nil)
+	if err != nil {
+		logs.Errorf("list tcloud cos bucket failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return buckets, nil
+}
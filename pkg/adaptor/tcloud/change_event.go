@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	changeevent "hcm/pkg/adaptor/types/change-event"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+)
+
+const (
+	cloudAuditService = "cloudaudit"
+	cloudAuditVersion = "2019-03-19"
+	// cloudAuditQueryLimit 操作审计单次查询的最大事件数
+	cloudAuditQueryLimit = 50
+)
+
+// cloudIDPrefixResType 云资源ID前缀对应的资源类型，操作审计的资源类型为产品维度，需要通过资源ID前缀区分资源类型
+var cloudIDPrefixResType = map[string]enumor.CloudResourceType{
+	"ins-":    enumor.CvmCloudResType,
+	"disk-":   enumor.DiskCloudResType,
+	"vpc-":    enumor.VpcCloudResType,
+	"subnet-": enumor.SubnetCloudResType,
+	"eip-":    enumor.EipCloudResType,
+	"sg-":     enumor.SecurityGroupCloudResType,
+	"rtb-":    enumor.RouteTableCloudResType,
+}
+
+type lookUpEventsResp struct {
+	Response struct {
+		NextToken uint64 `json:"NextToken"`
+		ListOver  bool   `json:"ListOver"`
+		Events    []struct {
+			EventID     string `json:"EventId"`
+			EventName   string `json:"EventName"`
+			EventTime   string `json:"EventTime"`
+			EventRegion string `json:"EventRegion"`
+			ErrorCode   int64  `json:"ErrorCode"`
+			Resources   struct {
+				ResourceType string `json:"ResourceType"`
+				ResourceName string `json:"ResourceName"`
+			} `json:"Resources"`
+		} `json:"Events"`
+	} `json:"Response"`
+}
+
+// ListChangeEvent list resource change event from cloud audit, only write operation events are returned.
+// reference: https://cloud.tencent.com/document/api/629/45225
+func (t *TCloud) ListChangeEvent(kt *kit.Kit, opt *changeevent.ListOption) ([]changeevent.ChangeEvent, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	client := t.clientSet.cloudAuditClient(opt.Region)
+
+	params := map[string]interface{}{
+		"StartTime":  opt.StartTime.Unix(),
+		"EndTime":    opt.EndTime.Unix(),
+		"MaxResults": cloudAuditQueryLimit,
+		"LookupAttributes": []map[string]string{
+			{"AttributeKey": "ReadOnly", "AttributeValue": "false"},
+		},
+	}
+
+	events := make([]changeevent.ChangeEvent, 0)
+	for {
+		req := tchttp.NewCommonRequest(cloudAuditService, cloudAuditVersion, "LookUpEvents")
+		req.SetContext(kt.Ctx)
+		if err := req.SetActionParameters(params); err != nil {
+			return nil, err
+		}
+
+		resp := tchttp.NewCommonResponse()
+		if err := client.Send(req, resp); err != nil {
+			logs.Errorf("look up tcloud cloud audit events failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+			return nil, err
+		}
+
+		result := new(lookUpEventsResp)
+		if err := json.Unmarshal(resp.GetBody(), result); err != nil {
+			return nil, fmt.Errorf("unmarshal look up events response failed, err: %v", err)
+		}
+
+		for _, one := range result.Response.Events {
+			// 操作失败的事件没有改变云上资源，其他地域的事件在查询其他地域时返回
+			if one.ErrorCode != 0 || (len(one.EventRegion) != 0 && one.EventRegion != opt.Region) {
+				continue
+			}
+
+			seconds, err := strconv.ParseInt(one.EventTime, 10, 64)
+			if err != nil {
+				logs.Errorf("parse tcloud event time failed, err: %v, event: %s, rid: %s", err, one.EventID, kt.Rid)
+				continue
+			}
+
+			for _, cloudID := range strings.Split(one.Resources.ResourceName, ",") {
+				cloudID = strings.TrimSpace(cloudID)
+				resType, exist := matchResTypeByCloudID(cloudID)
+				if !exist {
+					continue
+				}
+
+				events = append(events, changeevent.ChangeEvent{
+					EventID:   one.EventID,
+					EventName: one.EventName,
+					EventTime: time.Unix(seconds, 0),
+					ResType:   resType,
+					CloudID:   cloudID,
+					Region:    opt.Region,
+				})
+			}
+		}
+
+		if result.Response.ListOver || len(result.Response.Events) == 0 {
+			break
+		}
+
+		params["NextToken"] = result.Response.NextToken
+	}
+
+	return events, nil
+}
+
+func matchResTypeByCloudID(cloudID string) (enumor.CloudResourceType, bool) {
+	for prefix, resType := range cloudIDPrefixResType {
+		if strings.HasPrefix(cloudID, prefix) {
+			return resType, true
+		}
+	}
+
+	return "", false
+}
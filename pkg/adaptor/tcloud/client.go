@@ -91,3 +91,18 @@ func (c *clientSet) billClient() (*billing.Client, error) {
 
 	return client, nil
 }
+
+// cloudAuditClient 操作审计没有引入对应的sdk，使用通用客户端调用
+func (c *clientSet) cloudAuditClient(region string) *common.Client {
+	return common.NewCommonClient(c.credential, region, c.profile)
+}
+
+// clbClient 负载均衡没有引入对应的sdk，使用通用客户端调用
+func (c *clientSet) clbClient(region string) *common.Client {
+	return common.NewCommonClient(c.credential, region, c.profile)
+}
+
+// tagClient 标签没有引入对应的sdk，使用通用客户端调用
+func (c *clientSet) tagClient(region string) *common.Client {
+	return common.NewCommonClient(c.credential, region, c.profile)
+}
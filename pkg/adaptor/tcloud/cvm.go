@@ -300,8 +300,14 @@ func (t *TCloud) CreateCvm(kt *kit.Kit, opt *typecvm.TCloudCreateOption) (*polle
 		VpcId:    common.StringPtr(opt.CloudVpcID),
 		SubnetId: common.StringPtr(opt.CloudSubnetID),
 	}
-	req.LoginSettings = &cvm.LoginSettings{
-		Password: common.StringPtr(opt.Password),
+	if len(opt.CloudKeyPairID) != 0 {
+		req.LoginSettings = &cvm.LoginSettings{
+			KeyIds: common.StringPtrs([]string{opt.CloudKeyPairID}),
+		}
+	} else {
+		req.LoginSettings = &cvm.LoginSettings{
+			Password: common.StringPtr(opt.Password),
+		}
 	}
 	req.InternetAccessible = &cvm.InternetAccessible{
 		PublicIpAssigned: common.BoolPtr(opt.PublicIPAssigned),
@@ -0,0 +1,277 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// ChangeCvmInstanceType change instance type of cvm, the instance must be stopped before changing instance type,
+// so running instance is stopped first and started again whether instance type is changed or not.
+// reference: https://cloud.tencent.com/document/api/213/15744
+func (t *TCloud) ChangeCvmInstanceType(kt *kit.Kit, opt *typecvm.TCloudChangeInstanceTypeOption) (err error) {
+
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "change instance type option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("init tencent cloud client failed, err: %v", err)
+	}
+
+	instances, err := poll(t, kt, opt.Region, []*string{converter.ValToPtr(opt.CloudID)})
+	if err != nil {
+		logs.Errorf("get tcloud cvm failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	if len(instances) == 0 {
+		return errf.Newf(errf.RecordNotFound, "cvm %s not found from cloud", opt.CloudID)
+	}
+
+	if converter.PtrToVal(instances[0].InstanceType) == opt.InstanceType {
+		return nil
+	}
+
+	running := converter.PtrToVal(instances[0].InstanceState) == "RUNNING"
+	if running {
+		stopOpt := &typecvm.TCloudStopOption{
+			Region:      opt.Region,
+			CloudIDs:    []string{opt.CloudID},
+			StopType:    typecvm.SoftFirst,
+			StoppedMode: typecvm.KeepCharging,
+		}
+		if err = t.StopCvm(kt, stopOpt); err != nil {
+			return err
+		}
+
+		// 无论规格变更是否成功，都需要将原本运行中的云服务器重新开机，避免主机一直处于关机状态
+		defer func() {
+			startOpt := &typecvm.TCloudStartOption{Region: opt.Region, CloudIDs: []string{opt.CloudID}}
+			if startErr := t.StartCvm(kt, startOpt); startErr != nil {
+				logs.Errorf("start cvm after changing instance type failed, err: %v, id: %s, rid: %s", startErr,
+					opt.CloudID, kt.Rid)
+				if err == nil {
+					err = startErr
+				}
+			}
+		}()
+	}
+
+	req := cvm.NewResetInstancesTypeRequest()
+	req.InstanceIds = common.StringPtrs([]string{opt.CloudID})
+	req.InstanceType = common.StringPtr(opt.InstanceType)
+
+	resp, err := client.ResetInstancesTypeWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("reset cvm instance type failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	err = t.waitCvmOperation(kt, opt.Region, opt.CloudID, "ResetInstancesType",
+		converter.PtrToVal(resp.Response.RequestId))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ResizeCvmDisk resize system or data disk of cvm online.
+// reference: https://cloud.tencent.com/document/api/213/15731
+func (t *TCloud) ResizeCvmDisk(kt *kit.Kit, opt *typecvm.TCloudResizeDiskOption) error {
+
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "resize disk option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("init tencent cloud client failed, err: %v", err)
+	}
+
+	req := cvm.NewResizeInstanceDisksRequest()
+	req.InstanceId = common.StringPtr(opt.CloudID)
+	req.ResizeOnline = common.BoolPtr(true)
+	if opt.IsSystemDisk {
+		req.SystemDisk = &cvm.SystemDisk{
+			DiskId:   common.StringPtr(opt.CloudDiskID),
+			DiskSize: common.Int64Ptr(opt.DiskSize),
+		}
+	} else {
+		req.DataDisks = []*cvm.DataDisk{
+			{
+				DiskId:   common.StringPtr(opt.CloudDiskID),
+				DiskSize: common.Int64Ptr(opt.DiskSize),
+			},
+		}
+	}
+
+	resp, err := client.ResizeInstanceDisksWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("resize cvm disk failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return t.waitCvmOperation(kt, opt.Region, opt.CloudID, "ResizeInstanceDisks",
+		converter.PtrToVal(resp.Response.RequestId))
+}
+
+// RenameCvm rename cvm.
+// reference: https://cloud.tencent.com/document/api/213/15739
+func (t *TCloud) RenameCvm(kt *kit.Kit, opt *typecvm.TCloudRenameOption) error {
+
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "rename option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("init tencent cloud client failed, err: %v", err)
+	}
+
+	req := cvm.NewModifyInstancesAttributeRequest()
+	req.InstanceIds = common.StringPtrs([]string{opt.CloudID})
+	req.InstanceName = common.StringPtr(opt.Name)
+
+	_, err = client.ModifyInstancesAttributeWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("rename cvm failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ChangeCvmSecurityGroup replace all security groups bound to cvm, instance name and security groups can not be
+// modified in one request.
+// reference: https://cloud.tencent.com/document/api/213/15739
+func (t *TCloud) ChangeCvmSecurityGroup(kt *kit.Kit, opt *typecvm.TCloudChangeSecurityGroupOption) error {
+
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "change security group option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("init tencent cloud client failed, err: %v", err)
+	}
+
+	req := cvm.NewModifyInstancesAttributeRequest()
+	req.InstanceIds = common.StringPtrs([]string{opt.CloudID})
+	req.SecurityGroups = common.StringPtrs(opt.CloudSecurityGroupIDs)
+
+	_, err = client.ModifyInstancesAttributeWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("change cvm security group failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// waitCvmOperation wait until the operation of cvm launched by the request is finished.
+func (t *TCloud) waitCvmOperation(kt *kit.Kit, region, cloudID, operation, requestID string) error {
+	handler := &operationCvmPollingHandler{
+		region:    region,
+		requestID: requestID,
+	}
+	respPoller := poller.Poller[*TCloud, []*cvm.Instance, poller.BaseDoneResult]{Handler: handler}
+	result, err := respPoller.PollUntilDone(t, kt, []*string{converter.ValToPtr(cloudID)},
+		types.NewModifyCvmPollerOpt())
+	if err != nil {
+		logs.Errorf("poll cvm operation %s failed, err: %v, id: %s, rid: %s", operation, err, cloudID, kt.Rid)
+		return err
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		if len(result.FailedMessage) != 0 {
+			return fmt.Errorf("cvm %s %s failed, err: %s", cloudID, operation, result.FailedMessage)
+		}
+		return fmt.Errorf("cvm %s %s is not finished in time", cloudID, operation)
+	}
+
+	return nil
+}
+
+type operationCvmPollingHandler struct {
+	region    string
+	requestID string
+}
+
+// Done ...
+func (h *operationCvmPollingHandler) Done(cvms []*cvm.Instance) (bool, *poller.BaseDoneResult) {
+	result := new(poller.BaseDoneResult)
+
+	flag := true
+	for _, instance := range cvms {
+		// 操作请求刚下发时，实例的最新操作可能仍为上一次的操作
+		if converter.PtrToVal(instance.LatestOperationRequestId) != h.requestID ||
+			converter.PtrToVal(instance.LatestOperationState) == "OPERATING" {
+			flag = false
+			continue
+		}
+
+		if converter.PtrToVal(instance.LatestOperationState) == "FAILED" {
+			result.FailedCloudIDs = append(result.FailedCloudIDs, *instance.InstanceId)
+			result.FailedMessage = converter.PtrToVal(instance.LatestOperationErrorMsg)
+			continue
+		}
+
+		result.SuccessCloudIDs = append(result.SuccessCloudIDs, *instance.InstanceId)
+	}
+
+	return flag, result
+}
+
+// Poll ...
+func (h *operationCvmPollingHandler) Poll(client *TCloud, kt *kit.Kit, cloudIDs []*string) ([]*cvm.Instance,
+	error) {
+
+	return poll(client, kt, h.region, cloudIDs)
+}
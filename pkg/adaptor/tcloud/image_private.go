@@ -0,0 +1,227 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/adaptor/types/image"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// ListPrivateImage 查询自定义镜像和其他账号共享给当前账号的共享镜像列表
+// reference: https://cloud.tencent.com/document/api/213/15715
+func (t *TCloud) ListPrivateImage(kt *kit.Kit, opt *image.TCloudPrivateImageListOption) (
+	*image.TCloudImageListResult, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := cvm.NewDescribeImagesRequest()
+	if len(opt.CloudIDs) != 0 {
+		// 指定镜像ID时不支持同时指定过滤条件
+		req.ImageIds = common.StringPtrs(opt.CloudIDs)
+		req.Limit = common.Uint64Ptr(uint64(core.TCloudQueryLimit))
+	} else {
+		req.Filters = []*cvm.Filter{
+			{
+				Name:   common.StringPtr("image-type"),
+				Values: common.StringPtrs([]string{"PRIVATE_IMAGE", "SHARED_IMAGE"}),
+			},
+		}
+	}
+
+	if opt.Page != nil {
+		req.Offset = common.Uint64Ptr(opt.Page.Offset)
+		req.Limit = common.Uint64Ptr(opt.Page.Limit)
+	}
+
+	resp, err := client.DescribeImagesWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list tcloud private images failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, fmt.Errorf("list tcloud private images failed, err: %v", err)
+	}
+
+	images := make([]image.TCloudImage, 0, len(resp.Response.ImageSet))
+	for _, pImage := range resp.Response.ImageSet {
+		var imageType string
+		switch converter.PtrToVal(pImage.ImageType) {
+		case "PRIVATE_IMAGE":
+			imageType = constant.PrivateImageType
+		case "SHARED_IMAGE":
+			imageType = constant.SharedImageType
+		default:
+			continue
+		}
+
+		images = append(images, image.TCloudImage{
+			CloudID:      converter.PtrToVal(pImage.ImageId),
+			Name:         converter.PtrToVal(pImage.ImageName),
+			State:        converter.PtrToVal(pImage.ImageState),
+			Platform:     converter.PtrToVal(pImage.Platform),
+			Architecture: changeArchitecture(pImage.Architecture),
+			Type:         imageType,
+			ImageSize:    converter.PtrToVal(pImage.ImageSize),
+			ImageSource:  converter.PtrToVal(pImage.ImageSource),
+		})
+	}
+
+	return &image.TCloudImageListResult{Details: images}, nil
+}
+
+// CreateImage 基于实例创建自定义镜像，返回镜像云ID
+// reference: https://cloud.tencent.com/document/api/213/16726
+func (t *TCloud) CreateImage(kt *kit.Kit, opt *image.TCloudImageCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	req := cvm.NewCreateImageRequest()
+	req.InstanceId = common.StringPtr(opt.CloudCvmID)
+	req.ImageName = common.StringPtr(opt.Name)
+	req.ImageDescription = opt.Description
+
+	resp, err := client.CreateImageWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create tcloud image failed, err: %v, cvm: %s, rid: %s", err, opt.CloudCvmID, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.Response.ImageId), nil
+}
+
+// CopyImage 同步自定义镜像到其他地域
+// reference: https://cloud.tencent.com/document/api/213/15711
+func (t *TCloud) CopyImage(kt *kit.Kit, opt *image.TCloudImageCopyOption) (image.CopyImageResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "copy option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := cvm.NewSyncImagesRequest()
+	req.ImageIds = common.StringPtrs([]string{opt.CloudID})
+	req.DestinationRegions = common.StringPtrs(opt.DestinationRegions)
+	req.ImageSetRequired = common.BoolPtr(true)
+
+	resp, err := client.SyncImagesWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("copy tcloud image failed, err: %v, image: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return nil, err
+	}
+
+	result := make(image.CopyImageResult, len(opt.DestinationRegions))
+	for _, one := range resp.Response.ImageSet {
+		result[converter.PtrToVal(one.Region)] = converter.PtrToVal(one.ImageId)
+	}
+
+	return result, nil
+}
+
+// ShareImage 共享自定义镜像给其他主账号
+// reference: https://cloud.tencent.com/document/api/213/15710
+func (t *TCloud) ShareImage(kt *kit.Kit, opt *image.TCloudImageShareOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "share option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := cvm.NewModifyImageSharePermissionRequest()
+	req.ImageId = common.StringPtr(opt.CloudID)
+	req.AccountIds = common.StringPtrs(opt.CloudAccountIDs)
+	req.Permission = common.StringPtr("SHARE")
+
+	if _, err = client.ModifyImageSharePermissionWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("share tcloud image failed, err: %v, image: %s, accounts: %v, rid: %s", err, opt.CloudID,
+			opt.CloudAccountIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteImage 删除自定义镜像
+// reference: https://cloud.tencent.com/document/api/213/15716
+func (t *TCloud) DeleteImage(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := cvm.NewDeleteImagesRequest()
+	req.ImageIds = common.StringPtrs([]string{opt.ResourceID})
+
+	if _, err = client.DeleteImagesWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete tcloud image failed, err: %v, image: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
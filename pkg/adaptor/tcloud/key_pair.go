@@ -0,0 +1,149 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	typekp "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/sshkey"
+
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// ImportKeyPair import public key as key pair, returns cloud id of key pair.
+// reference: https://cloud.tencent.com/document/api/213/15698
+func (t *TCloud) ImportKeyPair(kt *kit.Kit, opt *typekp.ImportOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "import option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("init tencent cloud cvm client failed, err: %v", err)
+	}
+
+	req := cvm.NewImportKeyPairRequest()
+	req.KeyName = converter.ValToPtr(opt.Name)
+	req.PublicKey = converter.ValToPtr(opt.PublicKey)
+	// 0 表示默认项目
+	req.ProjectId = converter.ValToPtr(int64(0))
+
+	resp, err := client.ImportKeyPairWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("import tcloud key pair failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.Response.KeyId), nil
+}
+
+// ListKeyPair list key pair.
+// reference: https://cloud.tencent.com/document/api/213/15699
+func (t *TCloud) ListKeyPair(kt *kit.Kit, opt *core.TCloudListOption) ([]typekp.KeyPair, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("init tencent cloud cvm client failed, err: %v", err)
+	}
+
+	req := cvm.NewDescribeKeyPairsRequest()
+	if len(opt.CloudIDs) != 0 {
+		req.KeyIds = converter.SliceToPtr(opt.CloudIDs)
+		req.Limit = converter.ValToPtr(int64(core.TCloudQueryLimit))
+	}
+
+	if opt.Page != nil {
+		req.Offset = converter.ValToPtr(int64(opt.Page.Offset))
+		req.Limit = converter.ValToPtr(int64(opt.Page.Limit))
+	}
+
+	resp, err := client.DescribeKeyPairsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list tcloud key pair failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	keyPairs := make([]typekp.KeyPair, 0, len(resp.Response.KeyPairSet))
+	for _, one := range resp.Response.KeyPairSet {
+		keyPair := typekp.KeyPair{
+			CloudID:          converter.PtrToVal(one.KeyId),
+			Name:             converter.PtrToVal(one.KeyName),
+			Region:           opt.Region,
+			CloudCreatedTime: converter.PtrToVal(one.CreatedTime),
+		}
+
+		// 腾讯云不返回密钥对指纹，根据公钥计算
+		if one.PublicKey != nil {
+			fingerprint, err := sshkey.Fingerprint(*one.PublicKey)
+			if err != nil {
+				logs.Warnf("calculate tcloud key pair fingerprint failed, err: %v, id: %s, rid: %s", err,
+					keyPair.CloudID, kt.Rid)
+			}
+			keyPair.Fingerprint = fingerprint
+		}
+
+		keyPairs = append(keyPairs, keyPair)
+	}
+
+	return keyPairs, nil
+}
+
+// DeleteKeyPair delete key pair.
+// reference: https://cloud.tencent.com/document/api/213/15700
+func (t *TCloud) DeleteKeyPair(kt *kit.Kit, opt *typekp.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cvmClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("init tencent cloud cvm client failed, err: %v", err)
+	}
+
+	req := cvm.NewDeleteKeyPairsRequest()
+	req.KeyIds = []*string{converter.ValToPtr(opt.ResourceID)}
+	if _, err = client.DeleteKeyPairsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete tcloud key pair failed, err: %v, id: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,399 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+)
+
+const (
+	clbService = "clb"
+	clbVersion = "2018-03-17"
+
+	// clbOpenType 公网负载均衡
+	clbOpenType = "OPEN"
+)
+
+type clbBackend struct {
+	Type               *string  `json:"Type"`
+	InstanceId         *string  `json:"InstanceId"`
+	Port               int64    `json:"Port"`
+	Weight             int64    `json:"Weight"`
+	PrivateIpAddresses []string `json:"PrivateIpAddresses"`
+	InstanceName       *string  `json:"InstanceName"`
+	EniId              *string  `json:"EniId"`
+}
+
+type describeLoadBalancersResp struct {
+	Response struct {
+		LoadBalancerSet []struct {
+			LoadBalancerId   string   `json:"LoadBalancerId"`
+			LoadBalancerName string   `json:"LoadBalancerName"`
+			LoadBalancerType string   `json:"LoadBalancerType"`
+			Forward          *uint64  `json:"Forward"`
+			Domain           string   `json:"Domain"`
+			LoadBalancerVips []string `json:"LoadBalancerVips"`
+			Status           uint64   `json:"Status"`
+			CreateTime       string   `json:"CreateTime"`
+			VpcId            string   `json:"VpcId"`
+			SubnetId         *string  `json:"SubnetId"`
+			SecureGroups     []string `json:"SecureGroups"`
+			AddressIPVersion *string  `json:"AddressIPVersion"`
+			VipIsp           *string  `json:"VipIsp"`
+			Zones            []string `json:"Zones"`
+			MasterZone       *struct {
+				Zone string `json:"Zone"`
+			} `json:"MasterZone"`
+			NetworkAttributes *struct {
+				InternetChargeType      *string `json:"InternetChargeType"`
+				InternetMaxBandwidthOut *int64  `json:"InternetMaxBandwidthOut"`
+			} `json:"NetworkAttributes"`
+		} `json:"LoadBalancerSet"`
+	} `json:"Response"`
+}
+
+type describeListenersResp struct {
+	Response struct {
+		Listeners []struct {
+			ListenerId        string  `json:"ListenerId"`
+			ListenerName      string  `json:"ListenerName"`
+			Protocol          string  `json:"Protocol"`
+			Port              int64   `json:"Port"`
+			EndPort           int64   `json:"EndPort"`
+			Scheduler         *string `json:"Scheduler"`
+			SessionExpireTime *int64  `json:"SessionExpireTime"`
+			SniSwitch         *int64  `json:"SniSwitch"`
+			HealthCheck       *struct {
+				HealthSwitch *int64 `json:"HealthSwitch"`
+			} `json:"HealthCheck"`
+		} `json:"Listeners"`
+	} `json:"Response"`
+}
+
+type describeTargetsResp struct {
+	Response struct {
+		Listeners []struct {
+			ListenerId string        `json:"ListenerId"`
+			Targets    []*clbBackend `json:"Targets"`
+			Rules      []struct {
+				LocationId string        `json:"LocationId"`
+				Domain     string        `json:"Domain"`
+				Url        string        `json:"Url"`
+				Targets    []*clbBackend `json:"Targets"`
+			} `json:"Rules"`
+		} `json:"Listeners"`
+	} `json:"Response"`
+}
+
+type createLoadBalancerResp struct {
+	Response struct {
+		LoadBalancerIds []string `json:"LoadBalancerIds"`
+	} `json:"Response"`
+}
+
+// sendClbRequest 调用负载均衡接口，并将响应解析到 result 中
+func (t *TCloud) sendClbRequest(kt *kit.Kit, region, action string, params map[string]interface{},
+	result interface{}) error {
+
+	client := t.clientSet.clbClient(region)
+
+	req := tchttp.NewCommonRequest(clbService, clbVersion, action)
+	req.SetContext(kt.Ctx)
+	if err := req.SetActionParameters(params); err != nil {
+		return err
+	}
+
+	resp := tchttp.NewCommonResponse()
+	if err := client.Send(req, resp); err != nil {
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp.GetBody(), result); err != nil {
+		return fmt.Errorf("unmarshal tcloud %s response failed, err: %v", action, err)
+	}
+
+	return nil
+}
+
+// ListLoadBalancer list load balancer.
+// reference: https://cloud.tencent.com/document/api/214/30685
+func (t *TCloud) ListLoadBalancer(kt *kit.Kit, opt *core.TCloudListOption) ([]typelb.TCloudLoadBalancer, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	params := map[string]interface{}{
+		"Offset": opt.Page.Offset,
+		"Limit":  opt.Page.Limit,
+	}
+	if len(opt.CloudIDs) != 0 {
+		params["LoadBalancerIds"] = opt.CloudIDs
+	}
+
+	result := new(describeLoadBalancersResp)
+	if err := t.sendClbRequest(kt, opt.Region, "DescribeLoadBalancers", params, result); err != nil {
+		logs.Errorf("list tcloud load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	lbs := make([]typelb.TCloudLoadBalancer, 0, len(result.Response.LoadBalancerSet))
+	for _, one := range result.Response.LoadBalancerSet {
+		lb := typelb.TCloudLoadBalancer{
+			CloudID:          one.LoadBalancerId,
+			Name:             one.LoadBalancerName,
+			Region:           opt.Region,
+			Zones:            one.Zones,
+			LBType:           enumor.IntranetLoadBalancer,
+			Status:           strconv.FormatUint(one.Status, 10),
+			CloudVpcID:       one.VpcId,
+			Domain:           one.Domain,
+			CloudCreatedTime: one.CreateTime,
+			Extension: &corelb.TCloudLoadBalancerExtension{
+				Forward:               one.Forward,
+				VipIsp:                one.VipIsp,
+				AddressIPVersion:      one.AddressIPVersion,
+				CloudSubnetID:         one.SubnetId,
+				CloudSecurityGroupIDs: one.SecureGroups,
+			},
+		}
+
+		if len(lb.Zones) == 0 && one.MasterZone != nil {
+			lb.Zones = []string{one.MasterZone.Zone}
+		}
+
+		// 公网负载均衡的 vip 为公网IP，内网负载均衡的 vip 为内网IP
+		if one.LoadBalancerType == clbOpenType {
+			lb.LBType = enumor.InternetLoadBalancer
+			lb.PublicIPv4Addresses = one.LoadBalancerVips
+		} else {
+			lb.PrivateIPv4Addresses = one.LoadBalancerVips
+		}
+
+		if one.NetworkAttributes != nil {
+			lb.Extension.InternetChargeType = one.NetworkAttributes.InternetChargeType
+			lb.Extension.InternetMaxBandwidthOut = one.NetworkAttributes.InternetMaxBandwidthOut
+		}
+
+		lbs = append(lbs, lb)
+	}
+
+	return lbs, nil
+}
+
+// ListLoadBalancerListener list all listeners of load balancer, including listener targets.
+// reference: https://cloud.tencent.com/document/api/214/30686
+// reference: https://cloud.tencent.com/document/api/214/30684
+func (t *TCloud) ListLoadBalancerListener(kt *kit.Kit, opt *typelb.ListenerListOption) ([]typelb.TCloudListener,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	params := map[string]interface{}{"LoadBalancerId": opt.CloudLbID}
+
+	listenerResult := new(describeListenersResp)
+	if err := t.sendClbRequest(kt, opt.Region, "DescribeListeners", params, listenerResult); err != nil {
+		logs.Errorf("list tcloud load balancer listener failed, err: %v, lb: %s, rid: %s", err, opt.CloudLbID,
+			kt.Rid)
+		return nil, err
+	}
+
+	targetResult := new(describeTargetsResp)
+	if err := t.sendClbRequest(kt, opt.Region, "DescribeTargets", params, targetResult); err != nil {
+		logs.Errorf("list tcloud load balancer target failed, err: %v, lb: %s, rid: %s", err, opt.CloudLbID,
+			kt.Rid)
+		return nil, err
+	}
+
+	targetMap := make(map[string][]*typelb.TCloudTarget)
+	for _, one := range targetResult.Response.Listeners {
+		targets := make([]*typelb.TCloudTarget, 0)
+		for _, backend := range one.Targets {
+			targets = append(targets, convTCloudTarget(backend, nil, "", ""))
+		}
+
+		for _, rule := range one.Rules {
+			for _, backend := range rule.Targets {
+				targets = append(targets, convTCloudTarget(backend, converter.ValToPtr(rule.LocationId), rule.Domain,
+					rule.Url))
+			}
+		}
+
+		targetMap[one.ListenerId] = targets
+	}
+
+	listeners := make([]typelb.TCloudListener, 0, len(listenerResult.Response.Listeners))
+	for _, one := range listenerResult.Response.Listeners {
+		listener := typelb.TCloudListener{
+			CloudID:  one.ListenerId,
+			Name:     one.ListenerName,
+			Protocol: one.Protocol,
+			Port:     one.Port,
+			EndPort:  one.EndPort,
+			Extension: &corelb.TCloudListenerExtension{
+				Scheduler:         one.Scheduler,
+				SessionExpireTime: one.SessionExpireTime,
+				SniSwitch:         one.SniSwitch,
+			},
+			Targets: targetMap[one.ListenerId],
+		}
+
+		if one.HealthCheck != nil {
+			listener.Extension.HealthCheckSwitch = one.HealthCheck.HealthSwitch
+		}
+
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
+func convTCloudTarget(backend *clbBackend, ruleID *string, domain, url string) *typelb.TCloudTarget {
+	target := &typelb.TCloudTarget{
+		TargetType:    enumor.InstanceLBTargetType,
+		CloudTargetID: converter.PtrToVal(backend.InstanceId),
+		Port:          backend.Port,
+		Weight:        backend.Weight,
+		Extension: &corelb.TCloudTargetExtension{
+			Type:         backend.Type,
+			InstanceName: backend.InstanceName,
+			CloudRuleID:  ruleID,
+		},
+	}
+
+	if len(domain) != 0 {
+		target.Extension.Domain = converter.ValToPtr(domain)
+	}
+
+	if len(url) != 0 {
+		target.Extension.Url = converter.ValToPtr(url)
+	}
+
+	if len(backend.PrivateIpAddresses) != 0 {
+		target.IP = backend.PrivateIpAddresses[0]
+	}
+
+	// 绑定弹性网卡的后端服务没有实例ID，使用网卡ID标识
+	if converter.PtrToVal(backend.Type) == "ENI" {
+		target.TargetType = enumor.NetworkInterfaceLBTargetType
+		target.CloudTargetID = converter.PtrToVal(backend.EniId)
+	}
+
+	if len(target.CloudTargetID) == 0 {
+		target.TargetType = enumor.IPLBTargetType
+		target.CloudTargetID = target.IP
+	}
+
+	return target
+}
+
+// CreateLoadBalancer create load balancer.
+// reference: https://cloud.tencent.com/document/api/214/30692
+func (t *TCloud) CreateLoadBalancer(kt *kit.Kit, opt *typelb.TCloudCreateOption) (*poller.BaseDoneResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	params := map[string]interface{}{"LoadBalancerType": opt.LoadBalancerType}
+	if opt.Name != nil {
+		params["LoadBalancerName"] = *opt.Name
+	}
+	if opt.CloudVpcID != nil {
+		params["VpcId"] = *opt.CloudVpcID
+	}
+	if opt.CloudSubnetID != nil {
+		params["SubnetId"] = *opt.CloudSubnetID
+	}
+	if opt.AddressIPVersion != nil {
+		params["AddressIPVersion"] = *opt.AddressIPVersion
+	}
+	if opt.Zone != nil {
+		params["ZoneId"] = *opt.Zone
+	}
+	if opt.VipIsp != nil {
+		params["VipIsp"] = *opt.VipIsp
+	}
+	if opt.Number != nil {
+		params["Number"] = *opt.Number
+	}
+
+	result := new(createLoadBalancerResp)
+	if err := t.sendClbRequest(kt, opt.Region, "CreateLoadBalancer", params, result); err != nil {
+		logs.Errorf("create tcloud load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return &poller.BaseDoneResult{SuccessCloudIDs: result.Response.LoadBalancerIds}, nil
+}
+
+// DeleteLoadBalancer delete load balancer.
+// reference: https://cloud.tencent.com/document/api/214/30689
+func (t *TCloud) DeleteLoadBalancer(kt *kit.Kit, opt *typelb.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	params := map[string]interface{}{"LoadBalancerIds": []string{opt.ResourceID}}
+	if err := t.sendClbRequest(kt, opt.Region, "DeleteLoadBalancer", params, nil); err != nil {
+		logs.Errorf("delete tcloud load balancer failed, err: %v, id: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
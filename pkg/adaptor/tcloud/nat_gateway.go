@@ -0,0 +1,182 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+)
+
+// ListNatGateway list nat gateway.
+// reference: https://cloud.tencent.com/document/api/215/36034
+func (t *TCloud) ListNatGateway(kt *kit.Kit, opt *core.TCloudListOption) ([]typenat.TCloudNatGateway, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.vpcClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("init tencent cloud vpc client failed, err: %v", err)
+	}
+
+	req := vpc.NewDescribeNatGatewaysRequest()
+	if len(opt.CloudIDs) != 0 {
+		req.NatGatewayIds = converter.SliceToPtr(opt.CloudIDs)
+		req.Limit = converter.ValToPtr(uint64(core.TCloudQueryLimit))
+	}
+
+	if opt.Page != nil {
+		req.Offset = converter.ValToPtr(opt.Page.Offset)
+		req.Limit = converter.ValToPtr(opt.Page.Limit)
+	}
+
+	resp, err := client.DescribeNatGatewaysWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list tcloud nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	nats := make([]typenat.TCloudNatGateway, 0, len(resp.Response.NatGatewaySet))
+	for _, one := range resp.Response.NatGatewaySet {
+		nats = append(nats, convTCloudNatGateway(one, opt.Region))
+	}
+
+	return nats, nil
+}
+
+func convTCloudNatGateway(one *vpc.NatGateway, region string) typenat.TCloudNatGateway {
+	nat := typenat.TCloudNatGateway{
+		CloudID:          converter.PtrToVal(one.NatGatewayId),
+		Name:             converter.PtrToVal(one.NatGatewayName),
+		Region:           region,
+		Zone:             converter.PtrToVal(one.Zone),
+		NatType:          enumor.PublicNatGateway,
+		Status:           converter.PtrToVal(one.State),
+		CloudVpcID:       converter.PtrToVal(one.VpcId),
+		CloudSubnetID:    converter.PtrToVal(one.SubnetId),
+		CloudCreatedTime: converter.PtrToVal(one.CreatedTime),
+		Extension: &corenat.TCloudNatGatewayExtension{
+			InternetMaxBandwidthOut: one.InternetMaxBandwidthOut,
+			MaxConcurrentConnection: one.MaxConcurrentConnection,
+			NetworkState:            one.NetworkState,
+			CloudSecurityGroupIDs:   converter.PtrToSlice(one.SecurityGroupSet),
+			RestrictState:           one.RestrictState,
+		},
+	}
+
+	for _, address := range one.PublicIpAddressSet {
+		if address == nil {
+			continue
+		}
+
+		if address.AddressId != nil {
+			nat.CloudEipIDs = append(nat.CloudEipIDs, *address.AddressId)
+		}
+
+		if address.PublicIpAddress != nil {
+			nat.PublicIPAddresses = append(nat.PublicIPAddresses, *address.PublicIpAddress)
+		}
+	}
+
+	return nat
+}
+
+// CreateNatGateway create nat gateway.
+// reference: https://cloud.tencent.com/document/api/215/36722
+func (t *TCloud) CreateNatGateway(kt *kit.Kit, opt *typenat.TCloudCreateOption) (*poller.BaseDoneResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.vpcClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("init tencent cloud vpc client failed, err: %v", err)
+	}
+
+	req := vpc.NewCreateNatGatewayRequest()
+	req.NatGatewayName = converter.ValToPtr(opt.Name)
+	req.VpcId = converter.ValToPtr(opt.CloudVpcID)
+	req.SubnetId = opt.CloudSubnetID
+	req.Zone = opt.Zone
+	req.InternetMaxBandwidthOut = opt.InternetMaxBandwidthOut
+	req.MaxConcurrentConnection = opt.MaxConcurrentConnection
+	req.AddressCount = opt.AddressCount
+	if len(opt.PublicIPAddresses) != 0 {
+		req.PublicIpAddresses = converter.SliceToPtr(opt.PublicIPAddresses)
+	}
+
+	resp, err := client.CreateNatGatewayWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create tcloud nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	cloudIDs := make([]string, 0, len(resp.Response.NatGatewaySet))
+	for _, one := range resp.Response.NatGatewaySet {
+		cloudIDs = append(cloudIDs, converter.PtrToVal(one.NatGatewayId))
+	}
+
+	return &poller.BaseDoneResult{SuccessCloudIDs: cloudIDs}, nil
+}
+
+// DeleteNatGateway delete nat gateway.
+// reference: https://cloud.tencent.com/document/api/215/36721
+func (t *TCloud) DeleteNatGateway(kt *kit.Kit, opt *typenat.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.vpcClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("init tencent cloud vpc client failed, err: %v", err)
+	}
+
+	req := vpc.NewDeleteNatGatewayRequest()
+	req.NatGatewayId = converter.ValToPtr(opt.ResourceID)
+	if _, err = client.DeleteNatGatewayWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete tcloud nat gateway failed, err: %v, id: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/core"
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
+)
+
+// ListSnapshot list disk snapshot.
+// reference: https://cloud.tencent.com/document/api/362/15647
+func (t *TCloud) ListSnapshot(kt *kit.Kit, opt *core.TCloudListOption) ([]typesnap.TCloudSnapshot, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cbsClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("init tencent cloud cbs client failed, err: %v", err)
+	}
+
+	req := cbs.NewDescribeSnapshotsRequest()
+	if len(opt.CloudIDs) != 0 {
+		req.SnapshotIds = converter.SliceToPtr(opt.CloudIDs)
+		req.Limit = converter.ValToPtr(uint64(core.TCloudQueryLimit))
+	}
+
+	if opt.Page != nil {
+		req.Offset = converter.ValToPtr(opt.Page.Offset)
+		req.Limit = converter.ValToPtr(opt.Page.Limit)
+	}
+
+	resp, err := client.DescribeSnapshotsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list tcloud snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	snapshots := make([]typesnap.TCloudSnapshot, 0, len(resp.Response.SnapshotSet))
+	for _, one := range resp.Response.SnapshotSet {
+		snapshots = append(snapshots, convTCloudSnapshot(one, opt.Region))
+	}
+
+	return snapshots, nil
+}
+
+func convTCloudSnapshot(one *cbs.Snapshot, region string) typesnap.TCloudSnapshot {
+	snapshot := typesnap.TCloudSnapshot{
+		CloudID:          converter.PtrToVal(one.SnapshotId),
+		Name:             converter.PtrToVal(one.SnapshotName),
+		Region:           region,
+		Status:           converter.PtrToVal(one.SnapshotState),
+		CloudDiskID:      converter.PtrToVal(one.DiskId),
+		DiskSize:         converter.PtrToVal(one.DiskSize),
+		Encrypted:        one.Encrypt,
+		CloudCreatedTime: converter.PtrToVal(one.CreateTime),
+		Extension: &coresnap.TCloudSnapshotExtension{
+			SnapshotType: one.SnapshotType,
+			DiskUsage:    one.DiskUsage,
+			Percent:      one.Percent,
+			IsPermanent:  one.IsPermanent,
+			DeadlineTime: one.DeadlineTime,
+		},
+	}
+
+	if one.Placement != nil {
+		snapshot.Zone = converter.PtrToVal(one.Placement.Zone)
+	}
+
+	return snapshot
+}
+
+// CreateSnapshot create disk snapshot.
+// reference: https://cloud.tencent.com/document/api/362/15648
+func (t *TCloud) CreateSnapshot(kt *kit.Kit, opt *typesnap.TCloudCreateOption) (*poller.BaseDoneResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cbsClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("init tencent cloud cbs client failed, err: %v", err)
+	}
+
+	req := cbs.NewCreateSnapshotRequest()
+	req.DiskId = converter.ValToPtr(opt.CloudDiskID)
+	if len(opt.Name) != 0 {
+		req.SnapshotName = converter.ValToPtr(opt.Name)
+	}
+	req.Deadline = opt.Deadline
+
+	resp, err := client.CreateSnapshotWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create tcloud snapshot failed, err: %v, disk: %s, rid: %s", err, opt.CloudDiskID, kt.Rid)
+		return nil, err
+	}
+
+	return &poller.BaseDoneResult{SuccessCloudIDs: []string{converter.PtrToVal(resp.Response.SnapshotId)}}, nil
+}
+
+// DeleteSnapshot delete disk snapshot.
+// reference: https://cloud.tencent.com/document/api/362/15659
+func (t *TCloud) DeleteSnapshot(kt *kit.Kit, opt *typesnap.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cbsClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("init tencent cloud cbs client failed, err: %v", err)
+	}
+
+	req := cbs.NewDeleteSnapshotsRequest()
+	req.SnapshotIds = []*string{converter.ValToPtr(opt.ResourceID)}
+	if _, err = client.DeleteSnapshotsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete tcloud snapshot failed, err: %v, id: %s, rid: %s", err, opt.ResourceID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RollbackSnapshot rollback disk data from snapshot.
+// reference: https://cloud.tencent.com/document/api/362/15644
+func (t *TCloud) RollbackSnapshot(kt *kit.Kit, opt *typesnap.TCloudRollbackOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "rollback option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.cbsClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("init tencent cloud cbs client failed, err: %v", err)
+	}
+
+	req := cbs.NewApplySnapshotRequest()
+	req.SnapshotId = converter.ValToPtr(opt.CloudID)
+	req.DiskId = converter.ValToPtr(opt.CloudDiskID)
+	req.AutoStopInstance = opt.AutoStopInstance
+	req.AutoStartInstance = opt.AutoStartInstance
+	if _, err = client.ApplySnapshotWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("rollback tcloud disk from snapshot failed, err: %v, snapshot: %s, disk: %s, rid: %s", err,
+			opt.CloudID, opt.CloudDiskID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	typetag "hcm/pkg/adaptor/types/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+
+	cam "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cam/v20190116"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+)
+
+const (
+	tagService = "tag"
+	tagVersion = "2018-08-13"
+
+	// tagQueryLimit 查询资源标签每页最大数量
+	tagQueryLimit = 200
+	// tagOperateLimit 单次添加、删除标签的最大资源数量
+	tagOperateLimit = 10
+)
+
+// tagResourceSegment 资源类型对应的标签六段式资源描述中的服务类型和资源前缀
+var tagResourceSegment = map[enumor.CloudResourceType][2]string{
+	enumor.CvmCloudResType:           {"cvm", "instance"},
+	enumor.DiskCloudResType:          {"cvm", "volume"},
+	enumor.EipCloudResType:           {"cvm", "eip"},
+	enumor.SecurityGroupCloudResType: {"cvm", "sg"},
+	enumor.VpcCloudResType:           {"vpc", "vpc"},
+	enumor.SubnetCloudResType:        {"vpc", "subnet"},
+}
+
+type tagResourcesResp struct {
+	Response struct {
+		PaginationToken        string `json:"PaginationToken"`
+		ResourceTagMappingList []struct {
+			Resource string `json:"Resource"`
+			Tags     []struct {
+				TagKey   string `json:"TagKey"`
+				TagValue string `json:"TagValue"`
+			} `json:"Tags"`
+		} `json:"ResourceTagMappingList"`
+	} `json:"Response"`
+}
+
+// ListResourceTag list tags of resources.
+func (t *TCloud) ListResourceTag(kt *kit.Kit, opt *typetag.ListOption) ([]typetag.ResourceTags, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resources, err := t.tagResourceNames(kt, opt.Region, opt.ResType, opt.CloudIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"ResourceList": resources,
+		"MaxResults":   tagQueryLimit,
+	}
+
+	tagMap := make(map[string][]typetag.Tag, len(opt.CloudIDs))
+	for {
+		result := new(tagResourcesResp)
+		if err = t.sendTagRequest(kt, opt.Region, "GetResources", params, result); err != nil {
+			logs.Errorf("get tcloud resource tags failed, err: %v, ids: %v, rid: %s", err, opt.CloudIDs, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Response.ResourceTagMappingList {
+			cloudID := one.Resource[strings.LastIndex(one.Resource, "/")+1:]
+			for _, tag := range one.Tags {
+				tagMap[cloudID] = append(tagMap[cloudID], typetag.Tag{Key: tag.TagKey, Value: tag.TagValue})
+			}
+		}
+
+		if len(result.Response.PaginationToken) == 0 {
+			break
+		}
+		params["PaginationToken"] = result.Response.PaginationToken
+	}
+
+	details := make([]typetag.ResourceTags, 0, len(opt.CloudIDs))
+	for _, cloudID := range opt.CloudIDs {
+		details = append(details, typetag.ResourceTags{CloudID: cloudID, Tags: tagMap[cloudID]})
+	}
+
+	return details, nil
+}
+
+// TagResource add or overwrite tags of resources.
+func (t *TCloud) TagResource(kt *kit.Kit, opt *typetag.TagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resources, err := t.tagResourceNames(kt, opt.Region, opt.ResType, opt.CloudIDs)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]map[string]string, 0, len(opt.Tags))
+	for _, one := range opt.Tags {
+		tags = append(tags, map[string]string{"TagKey": one.Key, "TagValue": one.Value})
+	}
+
+	for _, part := range slice.Split(resources, tagOperateLimit) {
+		params := map[string]interface{}{
+			"ResourceList": part,
+			"Tags":         tags,
+		}
+		if err = t.sendTagRequest(kt, opt.Region, "TagResources", params, nil); err != nil {
+			logs.Errorf("tag tcloud resources failed, err: %v, resources: %v, rid: %s", err, part, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UntagResource remove tags of resources by tag keys.
+func (t *TCloud) UntagResource(kt *kit.Kit, opt *typetag.UntagOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resources, err := t.tagResourceNames(kt, opt.Region, opt.ResType, opt.CloudIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, part := range slice.Split(resources, tagOperateLimit) {
+		params := map[string]interface{}{
+			"ResourceList": part,
+			"TagKeys":      opt.Keys,
+		}
+		if err = t.sendTagRequest(kt, opt.Region, "UnTagResources", params, nil); err != nil {
+			logs.Errorf("untag tcloud resources failed, err: %v, resources: %v, rid: %s", err, part, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tagResourceNames 生成资源的六段式资源描述，格式为 qcs::${ServiceType}:${Region}:uin/${OwnerUin}:${Prefix}/${ID}
+func (t *TCloud) tagResourceNames(kt *kit.Kit, region string, resType enumor.CloudResourceType,
+	cloudIDs []string) ([]string, error) {
+
+	if len(region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	segment, exists := tagResourceSegment[resType]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "tcloud resource type: %s not support tag", resType)
+	}
+
+	camClient, err := t.clientSet.camServiceClient("")
+	if err != nil {
+		return nil, fmt.Errorf("new cam client failed, err: %v", err)
+	}
+
+	resp, err := camClient.GetUserAppIdWithContext(kt.Ctx, cam.NewGetUserAppIdRequest())
+	if err != nil {
+		return nil, fmt.Errorf("get user app id failed, err: %v", err)
+	}
+
+	if resp.Response.OwnerUin == nil {
+		return nil, errors.New("user owner uin is empty")
+	}
+
+	names := make([]string, 0, len(cloudIDs))
+	for _, cloudID := range cloudIDs {
+		names = append(names, fmt.Sprintf("qcs::%s:%s:uin/%s:%s/%s", segment[0], region, *resp.Response.OwnerUin,
+			segment[1], cloudID))
+	}
+
+	return names, nil
+}
+
+// sendTagRequest 调用标签接口，并将响应解析到 result 中
+func (t *TCloud) sendTagRequest(kt *kit.Kit, region, action string, params map[string]interface{},
+	result interface{}) error {
+
+	client := t.clientSet.tagClient(region)
+
+	req := tchttp.NewCommonRequest(tagService, tagVersion, action)
+	req.SetContext(kt.Ctx)
+	if err := req.SetActionParameters(params); err != nil {
+		return err
+	}
+
+	resp := tchttp.NewCommonResponse()
+	if err := client.Send(req, resp); err != nil {
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp.GetBody(), result); err != nil {
+		return fmt.Errorf("unmarshal tcloud %s response failed, err: %v", action, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+	typevpcpeering "hcm/pkg/adaptor/types/vpc-peering"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+)
+
+const (
+	// tcloudCcnInstanceTypeVpc 云联网关联实例类型为私有网络
+	tcloudCcnInstanceTypeVpc = "VPC"
+	// tcloudCcnQueryRegion 云联网为全局资源，任意地域均可查询到账号下全部云联网关联实例
+	tcloudCcnQueryRegion = "ap-guangzhou"
+)
+
+// ListVpcPeering list all vpcs attached to ccn of account, ccn is global resource, vpcs attached to the same ccn
+// can reach each other. tcloud vpc peering connection api is not supported by current sdk, so only ccn
+// attachments are returned.
+// reference: https://cloud.tencent.com/document/api/215/19206
+func (t *TCloud) ListVpcPeering(kt *kit.Kit) ([]typevpcpeering.VpcPeering, error) {
+	client, err := t.clientSet.vpcClient(tcloudCcnQueryRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	req := vpc.NewDescribeCcnAttachedInstancesRequest()
+	req.Filters = []*vpc.Filter{{
+		Name:   common.StringPtr("instance-type"),
+		Values: common.StringPtrs([]string{tcloudCcnInstanceTypeVpc}),
+	}}
+	req.Limit = converter.ValToPtr(uint64(core.TCloudQueryLimit))
+
+	peerings := make([]typevpcpeering.VpcPeering, 0)
+	for offset := uint64(0); ; offset += uint64(core.TCloudQueryLimit) {
+		req.Offset = converter.ValToPtr(offset)
+		resp, err := client.DescribeCcnAttachedInstancesWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("list tcloud ccn attached instances failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Response.InstanceSet {
+			if one == nil {
+				continue
+			}
+			peerings = append(peerings, convTCloudCcnAttachment(one))
+		}
+
+		if len(resp.Response.InstanceSet) < core.TCloudQueryLimit {
+			break
+		}
+	}
+
+	return peerings, nil
+}
+
+func convTCloudCcnAttachment(one *vpc.CcnAttachedInstance) typevpcpeering.VpcPeering {
+	ccnID := converter.PtrToVal(one.CcnId)
+	vpcID := converter.PtrToVal(one.InstanceId)
+
+	peering := typevpcpeering.VpcPeering{
+		// 关联关系本身没有ID，同一VPC只能关联一个云联网，使用云联网ID和VPC ID组合作为云上ID
+		CloudID:          ccnID + "/" + vpcID,
+		Name:             converter.PtrToVal(one.InstanceName),
+		Region:           converter.PtrToVal(one.InstanceRegion),
+		Type:             enumor.CcnAttachment,
+		CloudStatus:      converter.PtrToVal(one.State),
+		CloudGatewayID:   ccnID,
+		LocalCloudVpcID:  vpcID,
+		LocalCidrs:       converter.PtrToSlice(one.CidrBlock),
+		PeerCidrs:        make([]string, 0),
+		Memo:             one.Description,
+		CloudCreatedTime: converter.PtrToVal(one.AttachedTime),
+	}
+
+	switch strings.ToUpper(peering.CloudStatus) {
+	case "ACTIVE":
+		peering.Status = enumor.VpcPeeringActive
+	case "PENDING", "ATTACHING":
+		peering.Status = enumor.VpcPeeringPending
+	default:
+		peering.Status = enumor.VpcPeeringInactive
+	}
+
+	return peering
+}
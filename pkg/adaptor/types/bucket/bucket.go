@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bucket defines object storage bucket adaptor types.
+package bucket
+
+import (
+	"strings"
+)
+
+// Bucket define object storage bucket returned by cloud.
+type Bucket struct {
+	CloudID           string `json:"cloud_id"`
+	Name              string `json:"name"`
+	Region            string `json:"region"`
+	Encrypted         bool   `json:"encrypted"`
+	EncryptionType    string `json:"encryption_type"`
+	VersioningEnabled bool   `json:"versioning_enabled"`
+	PublicRead        bool   `json:"public_read"`
+	PublicWrite       bool   `json:"public_write"`
+	// PublicAccessReasons 存储桶被判定为公开访问的依据，如公共读ACL、允许匿名访问的存储桶策略等
+	PublicAccessReasons []string `json:"public_access_reasons"`
+	CloudCreatedTime    string   `json:"cloud_created_time"`
+}
+
+// GetCloudID ...
+func (b Bucket) GetCloudID() string {
+	return b.CloudID
+}
+
+// PublicAccess define public access of bucket judged by a kind of access control, e.g. acl, policy.
+type PublicAccess struct {
+	Read    bool
+	Write   bool
+	Reasons []string
+}
+
+// Merge other public access into this one.
+func (p *PublicAccess) Merge(other *PublicAccess) {
+	if other == nil {
+		return
+	}
+
+	p.Read = p.Read || other.Read
+	p.Write = p.Write || other.Write
+	p.Reasons = append(p.Reasons, other.Reasons...)
+}
+
+// Apply public access to bucket.
+func (p *PublicAccess) Apply(bucket *Bucket) {
+	bucket.PublicRead = p.Read
+	bucket.PublicWrite = p.Write
+	bucket.PublicAccessReasons = p.Reasons
+	if bucket.PublicAccessReasons == nil {
+		bucket.PublicAccessReasons = make([]string, 0)
+	}
+}
+
+// NewReason build public access reason, e.g. "acl: AllUsers READ".
+func NewReason(source string, details ...string) string {
+	return source + ": " + strings.Join(details, " ")
+}
+
+const (
+	// AclReasonSource public access is granted by acl.
+	AclReasonSource = "acl"
+	// PolicyReasonSource public access is granted by bucket policy.
+	PolicyReasonSource = "policy"
+	// IamReasonSource public access is granted by iam policy.
+	IamReasonSource = "iam"
+	// AccountReasonSource public access is granted by storage account setting.
+	AccountReasonSource = "account"
+)
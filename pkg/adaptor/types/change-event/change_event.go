@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package changeevent ...
+package changeevent
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// MaxTimeRange 单次查询变更事件的最大时间范围
+const MaxTimeRange = 24 * time.Hour
+
+// ListOption define change event list option.
+type ListOption struct {
+	// Region 地域，tcloud、aws、huawei 的操作审计按地域查询，azure、gcp 不需要
+	Region    string    `json:"region" validate:"omitempty"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required"`
+}
+
+// Validate change event list option.
+func (opt ListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if !opt.EndTime.After(opt.StartTime) {
+		return errors.New("end_time should be after start_time")
+	}
+
+	if opt.EndTime.Sub(opt.StartTime) > MaxTimeRange {
+		return fmt.Errorf("time range should <= %v", MaxTimeRange)
+	}
+
+	return nil
+}
+
+// ChangeEvent 云上资源变更事件，由云操作审计（TCloud CloudAudit、AWS CloudTrail、Azure Activity Log、HuaWei CTS、
+// GCP Audit Logs）中的写操作事件转换而来，一个审计事件涉及多个资源时转换为多个变更事件，不支持增量同步的资源类型会被忽略。
+type ChangeEvent struct {
+	EventID   string                   `json:"event_id"`
+	EventName string                   `json:"event_name"`
+	EventTime time.Time                `json:"event_time"`
+	ResType   enumor.CloudResourceType `json:"res_type"`
+	CloudID   string                   `json:"cloud_id"`
+	Region    string                   `json:"region"`
+	// Zone 可用区，仅 gcp 的主机、硬盘有值
+	Zone string `json:"zone,omitempty"`
+	// ResourceGroupName 资源组，仅 azure 有值
+	ResourceGroupName string `json:"resource_group_name,omitempty"`
+}
@@ -22,6 +22,7 @@ package core
 import (
 	"fmt"
 
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 )
@@ -227,3 +228,23 @@ func (opt AzureListByIDOption) Validate() error {
 
 	return nil
 }
+
+// OpenStackListOption defines basic openstack list options, all resources in the region are listed when
+// cloud ids is empty.
+type OpenStackListOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty"`
+}
+
+// Validate openstack list option.
+func (opt OpenStackListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("openstack resource ids length should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
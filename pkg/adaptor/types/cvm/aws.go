@@ -89,7 +89,7 @@ type AwsStopOption struct {
 	// not recommended for Windows instances.
 	//
 	// Default: false
-	Force bool `json:"force" validate:"required"`
+	Force bool `json:"force" validate:"omitempty"`
 	// Hibernates the instance if the instance was enabled for hibernation at launch.
 	// If the instance cannot hibernate successfully, a normal shutdown occurs.
 	// For more information, see Hibernate your instance
@@ -118,6 +118,58 @@ func (opt AwsRebootOption) Validate() error {
 	return validator.Validate.Struct(opt)
 }
 
+// -------------------------- Modify --------------------------
+
+// AwsChangeInstanceTypeOption defines options to change aws cvm instance type.
+type AwsChangeInstanceTypeOption struct {
+	Region       string `json:"region" validate:"required"`
+	CloudID      string `json:"cloud_id" validate:"required"`
+	InstanceType string `json:"instance_type" validate:"required"`
+}
+
+// Validate aws cvm change instance type option.
+func (opt AwsChangeInstanceTypeOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsResizeDiskOption defines options to resize volume attached to aws cvm instance.
+type AwsResizeDiskOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudID     string `json:"cloud_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	// DiskSize 扩容后的卷大小，单位GiB
+	DiskSize int64 `json:"disk_size" validate:"required,min=1"`
+}
+
+// Validate aws cvm resize disk option.
+func (opt AwsResizeDiskOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsRenameOption defines options to rename aws cvm instance, aws instance name is the value of tag "Name".
+type AwsRenameOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+	Name    string `json:"name" validate:"required,max=255"`
+}
+
+// Validate aws cvm rename option.
+func (opt AwsRenameOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsChangeSecurityGroupOption defines options to replace security groups bound to aws cvm instance.
+type AwsChangeSecurityGroupOption struct {
+	Region                string   `json:"region" validate:"required"`
+	CloudID               string   `json:"cloud_id" validate:"required"`
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids" validate:"required,min=1"`
+}
+
+// Validate aws cvm change security group option.
+func (opt AwsChangeSecurityGroupOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
 // -------------------------- Create --------------------------
 
 // AwsCreateOption defines options to create aws cvm instances.
@@ -128,7 +180,8 @@ type AwsCreateOption struct {
 	Zone                  string                  `json:"zone" validate:"required"`
 	InstanceType          string                  `json:"instance_type" validate:"required"`
 	CloudImageID          string                  `json:"cloud_image_id" validate:"required"`
-	Password              string                  `json:"password" validate:"required"`
+	Password              string                  `json:"password" validate:"required_without=KeyName"`
+	KeyName               string                  `json:"key_name" validate:"excluded_with=Password"`
 	RequiredCount         int64                   `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs []string                `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken           *string                 `json:"client_token" validate:"omitempty"`
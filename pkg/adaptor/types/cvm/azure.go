@@ -50,7 +50,7 @@ func (opt AzureListOption) Validate() error {
 type AzureDeleteOption struct {
 	ResourceGroupName string `json:"resource_group_name" validate:"required"`
 	Name              string `json:"name" validate:"required"`
-	Force             bool   `json:"force" validate:"required"`
+	Force             bool   `json:"force" validate:"omitempty"`
 }
 
 // Validate cvm operation option.
@@ -112,7 +112,8 @@ type AzureCreateOption struct {
 	InstanceType         string          `json:"instance_type" validate:"required"`
 	Image                *AzureImage     `json:"image" validate:"required"`
 	Username             string          `json:"username" validate:"required"`
-	Password             string          `json:"password" validate:"required"`
+	Password             string          `json:"password" validate:"required_without=SSHPublicKey"`
+	SSHPublicKey         string          `json:"ssh_public_key" validate:"excluded_with=Password"`
 	CloudSubnetID        string          `json:"cloud_subnet_id" validate:"required"`
 	CloudSecurityGroupID string          `json:"cloud_security_group_id" validate:"required"`
 	OSDisk               *AzureOSDisk    `json:"os_disk" validate:"required"`
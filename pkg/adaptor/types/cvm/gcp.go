@@ -119,7 +119,8 @@ type GcpCreateOption struct {
 	Zone               string `json:"zone" validate:"required"`
 	InstanceType       string `json:"instance_type" validate:"required"`
 	CloudImageSelfLink string `json:"cloud_image_self_link" validate:"required"`
-	Password           string `json:"password" validate:"required"`
+	Password           string `json:"password" validate:"required_without=SSHPublicKey"`
+	SSHPublicKey       string `json:"ssh_public_key" validate:"excluded_with=Password"`
 	RequiredCount      int64  `json:"required_count" validate:"required"`
 	// RequestID 唯一标识支持生产请求
 	RequestID           string `json:"request_id" validate:"omitempty"`
@@ -194,6 +195,20 @@ service sshd restart`, passwd), nil
 	}
 }
 
+// SSHKeyStartupScript return start up script which allows root login with ssh key only, it's used when cvm is
+// created with ssh key pair.
+func (typ *GcpImageProjectType) SSHKeyStartupScript() (string, error) {
+	switch *typ {
+	case Linux:
+		return `#! /bin/bash
+sed -i 's/^#\?PermitRootLogin .*/PermitRootLogin prohibit-password/g' /etc/ssh/sshd_config
+sed -i 's/^#\?PasswordAuthentication .*/PasswordAuthentication no/g' /etc/ssh/sshd_config
+service sshd restart`, nil
+	default:
+		return "", fmt.Errorf("%s image project type does not support ssh key pair login", *typ)
+	}
+}
+
 const (
 	Windows GcpImageProjectType = "windows"
 	Linux   GcpImageProjectType = "linux"
@@ -58,8 +58,8 @@ func (opt HuaWeiListOption) Validate() error {
 type HuaWeiDeleteOption struct {
 	Region         string   `json:"region" validate:"required"`
 	CloudIDs       []string `json:"cloud_ids" validate:"required"`
-	DeletePublicIP bool     `json:"delete_public_ip" validate:"required"`
-	DeleteVolume   bool     `json:"delete_volume" validate:"required"`
+	DeletePublicIP bool     `json:"delete_public_ip" validate:"omitempty"`
+	DeleteVolume   bool     `json:"delete_volume" validate:"omitempty"`
 }
 
 // Validate huawei cvm operation option.
@@ -86,7 +86,7 @@ func (opt HuaWeiStartOption) Validate() error {
 type HuaWeiStopOption struct {
 	Region   string   `json:"region" validate:"required"`
 	CloudIDs []string `json:"cloud_ids" validate:"required"`
-	Force    bool     `json:"force" validate:"required"`
+	Force    bool     `json:"force" validate:"omitempty"`
 }
 
 // Validate cvm operation option.
@@ -100,7 +100,7 @@ func (opt HuaWeiStopOption) Validate() error {
 type HuaWeiRebootOption struct {
 	Region   string   `json:"region" validate:"required"`
 	CloudIDs []string `json:"cloud_ids" validate:"required"`
-	Force    bool     `json:"force" validate:"required"`
+	Force    bool     `json:"force" validate:"omitempty"`
 }
 
 // Validate cvm operation option.
@@ -122,6 +122,58 @@ func (opt HuaWeiResetPwdOption) Validate() error {
 	return validator.Validate.Struct(opt)
 }
 
+// -------------------------- Modify --------------------------
+
+// HuaWeiChangeInstanceTypeOption defines options to change huawei cvm flavor.
+type HuaWeiChangeInstanceTypeOption struct {
+	Region       string `json:"region" validate:"required"`
+	CloudID      string `json:"cloud_id" validate:"required"`
+	InstanceType string `json:"instance_type" validate:"required"`
+}
+
+// Validate huawei cvm change instance type option.
+func (opt HuaWeiChangeInstanceTypeOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiResizeDiskOption defines options to resize volume attached to huawei cvm instance.
+type HuaWeiResizeDiskOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudID     string `json:"cloud_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	// DiskSize 扩容后的云硬盘大小，单位GiB
+	DiskSize int64 `json:"disk_size" validate:"required,min=1"`
+}
+
+// Validate huawei cvm resize disk option.
+func (opt HuaWeiResizeDiskOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiRenameOption defines options to rename huawei cvm instance.
+type HuaWeiRenameOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+	Name    string `json:"name" validate:"required,max=64"`
+}
+
+// Validate huawei cvm rename option.
+func (opt HuaWeiRenameOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiChangeSecurityGroupOption defines options to replace security groups bound to huawei cvm instance.
+type HuaWeiChangeSecurityGroupOption struct {
+	Region                string   `json:"region" validate:"required"`
+	CloudID               string   `json:"cloud_id" validate:"required"`
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids" validate:"required,min=1"`
+}
+
+// Validate huawei cvm change security group option.
+func (opt HuaWeiChangeSecurityGroupOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
 // -------------------------- Create --------------------------
 
 // HuaWeiCreateOption defines options to create aws cvm instances.
@@ -132,7 +184,8 @@ type HuaWeiCreateOption struct {
 	Zone                  string                `json:"zone" validate:"required"`
 	InstanceType          string                `json:"instance_type" validate:"required"`
 	CloudImageID          string                `json:"cloud_image_id" validate:"required"`
-	Password              string                `json:"password" validate:"required"`
+	Password              string                `json:"password" validate:"required_without=KeyName"`
+	KeyName               string                `json:"key_name" validate:"excluded_with=Password"`
 	RequiredCount         int32                 `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs []string              `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken           *string               `json:"client_token" validate:"omitempty"`
@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- List --------------------------
+
+// OpenStackListOption defines options to list openstack cvm instances.
+type OpenStackListOption struct {
+	core.OpenStackListOption `json:",inline"`
+}
+
+// Validate openstack cvm list option.
+func (opt OpenStackListOption) Validate() error {
+	return opt.OpenStackListOption.Validate()
+}
+
+// OpenStackCvm openstack nova server, network info of server is collected from the neutron ports and
+// floating ips bound to the server.
+type OpenStackCvm struct {
+	CloudID       string `json:"cloud_id"`
+	Name          string `json:"name"`
+	Region        string `json:"region"`
+	Zone          string `json:"zone"`
+	Status        string `json:"status"`
+	CloudFlavorID string `json:"cloud_flavor_id"`
+	// FlavorName 规格名称，云上没有返回时为规格ID
+	FlavorName   string `json:"flavor_name"`
+	CloudImageID string `json:"cloud_image_id"`
+	KeyName      string `json:"key_name"`
+	CloudHostID  string `json:"cloud_host_id"`
+	Memo         string `json:"memo"`
+
+	CloudVpcIDs           []string `json:"cloud_vpc_ids"`
+	CloudSubnetIDs        []string `json:"cloud_subnet_ids"`
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids"`
+	CloudVolumeIDs        []string `json:"cloud_volume_ids"`
+	PrivateIPv4Addresses  []string `json:"private_ipv4_addresses"`
+	PrivateIPv6Addresses  []string `json:"private_ipv6_addresses"`
+	PublicIPv4Addresses   []string `json:"public_ipv4_addresses"`
+	PublicIPv6Addresses   []string `json:"public_ipv6_addresses"`
+
+	Metadata   map[string]string `json:"metadata"`
+	CreatedAt  string            `json:"created_at"`
+	LaunchedAt string            `json:"launched_at"`
+}
+
+// GetCloudID ...
+func (cvm OpenStackCvm) GetCloudID() string {
+	return cvm.CloudID
+}
+
+// -------------------------- Operation --------------------------
+
+// OpenStackOperateOption defines options to start or delete openstack cvm instances.
+type OpenStackOperateOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"required,min=1"`
+}
+
+// Validate openstack cvm operation option.
+func (opt OpenStackOperateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// OpenStackRebootOption defines options to reboot openstack cvm instances.
+type OpenStackRebootOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"required,min=1"`
+	// Force 为 true 时硬重启
+	Force bool `json:"force" validate:"omitempty"`
+}
+
+// Validate openstack cvm reboot option.
+func (opt OpenStackRebootOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
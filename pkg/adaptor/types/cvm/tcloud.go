@@ -156,6 +156,60 @@ func (opt TCloudResetPwdOption) Validate() error {
 	return validator.Validate.Struct(opt)
 }
 
+// -------------------------- Modify --------------------------
+
+// TCloudChangeInstanceTypeOption defines options to change tcloud cvm instance type.
+type TCloudChangeInstanceTypeOption struct {
+	Region       string `json:"region" validate:"required"`
+	CloudID      string `json:"cloud_id" validate:"required"`
+	InstanceType string `json:"instance_type" validate:"required"`
+}
+
+// Validate tcloud cvm change instance type option.
+func (opt TCloudChangeInstanceTypeOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudResizeDiskOption defines options to resize system or data disk of tcloud cvm instance.
+type TCloudResizeDiskOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudID     string `json:"cloud_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	// IsSystemDisk 系统盘和数据盘扩容时分别通过 SystemDisk 和 DataDisks 参数指定
+	IsSystemDisk bool `json:"is_system_disk" validate:"omitempty"`
+	// DiskSize 扩容后的云盘大小，单位GB，只能扩容不能缩容
+	DiskSize int64 `json:"disk_size" validate:"required,min=1"`
+}
+
+// Validate tcloud cvm resize disk option.
+func (opt TCloudResizeDiskOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudRenameOption defines options to rename tcloud cvm instance.
+type TCloudRenameOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+	Name    string `json:"name" validate:"required,max=60"`
+}
+
+// Validate tcloud cvm rename option.
+func (opt TCloudRenameOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudChangeSecurityGroupOption defines options to replace security groups bound to tcloud cvm instance.
+type TCloudChangeSecurityGroupOption struct {
+	Region                string   `json:"region" validate:"required"`
+	CloudID               string   `json:"cloud_id" validate:"required"`
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids" validate:"required,min=1"`
+}
+
+// Validate tcloud cvm change security group option.
+func (opt TCloudChangeSecurityGroupOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
 // -------------------------- Create --------------------------
 
 // TCloudCreateOption defines options to create aws cvm instances.
@@ -166,7 +220,8 @@ type TCloudCreateOption struct {
 	Zone                  string                       `json:"zone" validate:"required"`
 	InstanceType          string                       `json:"instance_type" validate:"required"`
 	CloudImageID          string                       `json:"cloud_image_id" validate:"required"`
-	Password              string                       `json:"password" validate:"required"`
+	Password              string                       `json:"password" validate:"required_without=CloudKeyPairID"`
+	CloudKeyPairID        string                       `json:"cloud_key_pair_id" validate:"excluded_with=Password"`
 	RequiredCount         int64                        `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs []string                     `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken           *string                      `json:"client_token" validate:"omitempty"`
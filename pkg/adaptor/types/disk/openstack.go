@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+)
+
+// OpenStackDiskListOption define openstack disk list option.
+type OpenStackDiskListOption struct {
+	core.OpenStackListOption `json:",inline"`
+}
+
+// Validate openstack disk list option.
+func (opt OpenStackDiskListOption) Validate() error {
+	return opt.OpenStackListOption.Validate()
+}
+
+// OpenStackDisk openstack cinder volume.
+type OpenStackDisk struct {
+	CloudID     string                `json:"cloud_id"`
+	Name        string                `json:"name"`
+	Region      string                `json:"region"`
+	Zone        string                `json:"zone"`
+	Size        uint64                `json:"size"`
+	VolumeType  string                `json:"volume_type"`
+	Status      string                `json:"status"`
+	Description string                `json:"description"`
+	Bootable    bool                  `json:"bootable"`
+	Encrypted   bool                  `json:"encrypted"`
+	Multiattach bool                  `json:"multiattach"`
+	Attachments []OpenStackAttachment `json:"attachments"`
+}
+
+// OpenStackAttachment openstack volume attachment.
+type OpenStackAttachment struct {
+	AttachmentID string `json:"attachment_id"`
+	ServerID     string `json:"server_id"`
+	Device       string `json:"device"`
+	AttachedAt   string `json:"attached_at"`
+}
+
+// GetCloudID ...
+func (disk OpenStackDisk) GetCloudID() string {
+	return disk.CloudID
+}
+
+// OpenStackDiskAttachOption define openstack disk attach option.
+type OpenStackDiskAttachOption struct {
+	Region     string `json:"region" validate:"required"`
+	CloudCvmID string `json:"cloud_cvm_id" validate:"required"`
+	CloudID    string `json:"cloud_id" validate:"required"`
+	// DeviceName 挂载点，为空时由云平台自动分配
+	DeviceName string `json:"device_name" validate:"omitempty"`
+}
+
+// Validate openstack disk attach option.
+func (opt OpenStackDiskAttachOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// OpenStackDiskDetachOption define openstack disk detach option.
+type OpenStackDiskDetachOption struct {
+	Region     string `json:"region" validate:"required"`
+	CloudCvmID string `json:"cloud_cvm_id" validate:"required"`
+	CloudID    string `json:"cloud_id" validate:"required"`
+}
+
+// Validate openstack disk detach option.
+func (opt OpenStackDiskDetachOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// OpenStackDiskDeleteOption define openstack disk delete option.
+type OpenStackDiskDeleteOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// Validate openstack disk delete option.
+func (opt OpenStackDiskDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
@@ -66,3 +66,66 @@ type AwsImage struct {
 func (image AwsImage) GetCloudID() string {
 	return image.CloudID
 }
+
+// AwsPrivateImageListOption define aws private image list option.
+type AwsPrivateImageListOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty"`
+	// Shared 为true时查询其他账号共享给当前账号的镜像，否则查询当前账号拥有的镜像
+	Shared bool            `json:"shared"`
+	Page   *adcore.AwsPage `json:"page" validate:"omitempty"`
+}
+
+// Validate aws private image list option.
+func (opt AwsPrivateImageListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AwsImageCreateOption define aws create image from cvm option.
+type AwsImageCreateOption struct {
+	Region      string  `json:"region" validate:"required"`
+	CloudCvmID  string  `json:"cloud_cvm_id" validate:"required"`
+	Name        string  `json:"name" validate:"required,min=3,max=128"`
+	Description *string `json:"description" validate:"omitempty,max=255"`
+}
+
+// Validate aws image create option.
+func (opt AwsImageCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsImageCopyOption define aws copy image to other regions option.
+type AwsImageCopyOption struct {
+	Region             string   `json:"region" validate:"required"`
+	CloudID            string   `json:"cloud_id" validate:"required"`
+	Name               string   `json:"name" validate:"required,min=3,max=128"`
+	DestinationRegions []string `json:"destination_regions" validate:"required,min=1"`
+}
+
+// Validate aws image copy option.
+func (opt AwsImageCopyOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsImageShareOption define aws share image with other accounts option.
+type AwsImageShareOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+	// CloudAccountIDs 共享的目标账号ID
+	CloudAccountIDs []string `json:"cloud_account_ids" validate:"required,min=1"`
+}
+
+// Validate aws image share option.
+func (opt AwsImageShareOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
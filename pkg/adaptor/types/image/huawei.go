@@ -68,3 +68,66 @@ type HuaWeiImage struct {
 func (image HuaWeiImage) GetCloudID() string {
 	return image.CloudID
 }
+
+// HuaWeiPrivateImageListOption define huawei private image list option.
+type HuaWeiPrivateImageListOption struct {
+	Region  string           `json:"region" validate:"required"`
+	CloudID string           `json:"cloud_id" validate:"omitempty"`
+	Page    *core.HuaWeiPage `json:"page" validate:"omitempty"`
+}
+
+// Validate huawei private image list option.
+func (opt HuaWeiPrivateImageListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HuaWeiImageCreateOption define huawei create image from cvm option.
+type HuaWeiImageCreateOption struct {
+	Region      string  `json:"region" validate:"required"`
+	CloudCvmID  string  `json:"cloud_cvm_id" validate:"required"`
+	Name        string  `json:"name" validate:"required,min=1,max=128"`
+	Description *string `json:"description" validate:"omitempty,max=1024"`
+}
+
+// Validate huawei image create option.
+func (opt HuaWeiImageCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiImageCopyOption define huawei copy image to other regions option.
+type HuaWeiImageCopyOption struct {
+	Region             string   `json:"region" validate:"required"`
+	CloudID            string   `json:"cloud_id" validate:"required"`
+	Name               string   `json:"name" validate:"required,min=1,max=128"`
+	DestinationRegions []string `json:"destination_regions" validate:"required,min=1"`
+	// AgencyName 跨区域复制镜像时需要使用的IAM委托名称，委托需要授予IMS跨区域复制权限
+	AgencyName string `json:"agency_name" validate:"required"`
+}
+
+// Validate huawei image copy option.
+func (opt HuaWeiImageCopyOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiImageShareOption define huawei share image with other projects option.
+type HuaWeiImageShareOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+	// CloudProjectIDs 华为云镜像共享的目标为租户在该地域下的项目ID
+	CloudProjectIDs []string `json:"cloud_project_ids" validate:"required,min=1"`
+}
+
+// Validate huawei image share option.
+func (opt HuaWeiImageShareOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
@@ -68,3 +68,63 @@ func (opt TCloudImageListOption) Validate() error {
 
 	return nil
 }
+
+// TCloudPrivateImageListOption define tcloud private image list option.
+type TCloudPrivateImageListOption struct {
+	Region   string           `json:"region" validate:"required"`
+	CloudIDs []string         `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.TCloudPage `json:"page" validate:"omitempty"`
+}
+
+// Validate tcloud private image list option.
+func (opt TCloudPrivateImageListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TCloudImageCreateOption define tcloud create image from cvm option.
+type TCloudImageCreateOption struct {
+	Region      string  `json:"region" validate:"required"`
+	CloudCvmID  string  `json:"cloud_cvm_id" validate:"required"`
+	Name        string  `json:"name" validate:"required,max=60"`
+	Description *string `json:"description" validate:"omitempty,max=256"`
+}
+
+// Validate tcloud image create option.
+func (opt TCloudImageCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudImageCopyOption define tcloud copy image to other regions option.
+type TCloudImageCopyOption struct {
+	Region             string   `json:"region" validate:"required"`
+	CloudID            string   `json:"cloud_id" validate:"required"`
+	DestinationRegions []string `json:"destination_regions" validate:"required,min=1"`
+}
+
+// Validate tcloud image copy option.
+func (opt TCloudImageCopyOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudImageShareOption define tcloud share image with other accounts option.
+type TCloudImageShareOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+	// CloudAccountIDs 共享的目标主账号ID
+	CloudAccountIDs []string `json:"cloud_account_ids" validate:"required,min=1"`
+}
+
+// Validate tcloud image share option.
+func (opt TCloudImageShareOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair defines ssh key pair adaptor types.
+package keypair
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+)
+
+// KeyPair define ssh key pair returned by cloud.
+type KeyPair struct {
+	// CloudID 华为云密钥对没有ID，使用名称作为云ID
+	CloudID          string `json:"cloud_id"`
+	Name             string `json:"name"`
+	Region           string `json:"region"`
+	Fingerprint      string `json:"fingerprint"`
+	CloudCreatedTime string `json:"cloud_created_time"`
+}
+
+// GetCloudID ...
+func (kp KeyPair) GetCloudID() string {
+	return kp.CloudID
+}
+
+// ImportOption defines options to import public key to cloud as key pair.
+type ImportOption struct {
+	Region string `json:"region" validate:"required"`
+	// Name 腾讯云密钥对名称只能由数字、字母和下划线组成，且长度不超过25
+	Name      string `json:"name" validate:"required,max=25"`
+	PublicKey string `json:"public_key" validate:"required"`
+}
+
+// Validate ImportOption.
+func (opt ImportOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsListOption defines options to list aws key pair, aws returns all key pairs without page.
+type AwsListOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty"`
+}
+
+// Validate AwsListOption.
+func (opt AwsListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiListOption defines options to list huawei key pair, huawei key pair has no id, so it can only be paged.
+type HuaWeiListOption struct {
+	Region string           `json:"region" validate:"required"`
+	Page   *core.HuaWeiPage `json:"page" validate:"omitempty"`
+}
+
+// Validate HuaWeiListOption.
+func (opt HuaWeiListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteOption defines options to delete key pair.
+type DeleteOption = core.BaseRegionalDeleteOption
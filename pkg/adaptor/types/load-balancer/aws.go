@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/validator"
+)
+
+// AwsLoadBalancer aws load balancer.
+type AwsLoadBalancer = LoadBalancer[corelb.AwsLoadBalancerExtension]
+
+// AwsListener aws load balancer listener.
+type AwsListener = Listener[corelb.AwsListenerExtension, corelb.AwsTargetExtension]
+
+// AwsTarget aws load balancer target.
+type AwsTarget = Target[corelb.AwsTargetExtension]
+
+// AwsCreateOption defines options to create aws load balancer.
+type AwsCreateOption struct {
+	Region string `json:"region" validate:"required"`
+	Name   string `json:"name" validate:"required,max=32"`
+	// Type application | network | gateway
+	Type *string `json:"type" validate:"omitempty,eq=application|eq=network|eq=gateway"`
+	// Scheme internet-facing | internal
+	Scheme                *string  `json:"scheme" validate:"omitempty,eq=internet-facing|eq=internal"`
+	IpAddressType         *string  `json:"ip_address_type" validate:"omitempty"`
+	CloudSubnetIDs        []string `json:"cloud_subnet_ids" validate:"required,min=1"`
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids" validate:"omitempty"`
+}
+
+// Validate AwsCreateOption.
+func (opt AwsCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
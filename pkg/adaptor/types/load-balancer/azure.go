@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/validator"
+)
+
+// AzureLoadBalancer azure load balancer.
+type AzureLoadBalancer = LoadBalancer[corelb.AzureLoadBalancerExtension]
+
+// AzureListener azure load balancer listener, corresponds to load balancing rule.
+type AzureListener = Listener[corelb.AzureListenerExtension, corelb.AzureTargetExtension]
+
+// AzureTarget azure load balancer target, corresponds to backend address.
+type AzureTarget = Target[corelb.AzureTargetExtension]
+
+// AzureCreateOption defines options to create azure load balancer.
+type AzureCreateOption struct {
+	ResourceGroupName string `json:"resource_group_name" validate:"required"`
+	Region            string `json:"region" validate:"required"`
+	Name              string `json:"name" validate:"required,max=80"`
+	// SkuName Basic | Standard
+	SkuName *string `json:"sku_name" validate:"omitempty,eq=Basic|eq=Standard"`
+	// CloudPublicIPID 公网负载均衡前端关联的公网IP，与 CloudSubnetID 二选一
+	CloudPublicIPID *string `json:"cloud_public_ip_id" validate:"required_without=CloudSubnetID"`
+	// CloudSubnetID 内网负载均衡前端所在子网
+	CloudSubnetID *string `json:"cloud_subnet_id" validate:"required_without=CloudPublicIPID"`
+}
+
+// Validate AzureCreateOption.
+func (opt AzureCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AzureDeleteOption defines options to delete azure load balancer.
+type AzureDeleteOption = core.AzureDeleteOption
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+)
+
+// GcpLoadBalancer gcp load balancer, corresponds to regional forwarding rule.
+type GcpLoadBalancer = LoadBalancer[corelb.GcpLoadBalancerExtension]
+
+// GcpListener gcp load balancer listener.
+type GcpListener = Listener[corelb.GcpListenerExtension, corelb.GcpTargetExtension]
+
+// GcpTarget gcp load balancer target.
+type GcpTarget = Target[corelb.GcpTargetExtension]
+
+// GcpListOption defines options to list gcp regional forwarding rules.
+type GcpListOption struct {
+	Region   string        `json:"region" validate:"required"`
+	CloudIDs []string      `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.GcpPage `json:"page" validate:"omitempty"`
+}
+
+// Validate GcpListOption.
+func (opt GcpListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) > core.GcpQueryLimit {
+		return errf.Newf(errf.InvalidParameter, "gcp resource ids length should <= %d", core.GcpQueryLimit)
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GcpCreateOption defines options to create gcp load balancer, which creates a forwarding rule to target pool.
+type GcpCreateOption struct {
+	Region string `json:"region" validate:"required"`
+	Name   string `json:"name" validate:"required,max=63"`
+	// IPProtocol TCP | UDP | ESP | AH | SCTP | ICMP | L3_DEFAULT
+	IPProtocol *string `json:"ip_protocol" validate:"omitempty"`
+	PortRange  *string `json:"port_range" validate:"omitempty"`
+	// CloudTargetPool 转发目标池的 self link
+	CloudTargetPool string  `json:"cloud_target_pool" validate:"required"`
+	IPAddress       *string `json:"ip_address" validate:"omitempty"`
+	Memo            *string `json:"memo" validate:"omitempty"`
+}
+
+// Validate GcpCreateOption.
+func (opt GcpCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
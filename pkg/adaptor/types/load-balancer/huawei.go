@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/validator"
+)
+
+// HuaWeiLoadBalancer huawei load balancer.
+type HuaWeiLoadBalancer = LoadBalancer[corelb.HuaWeiLoadBalancerExtension]
+
+// HuaWeiListener huawei load balancer listener.
+type HuaWeiListener = Listener[corelb.HuaWeiListenerExtension, corelb.HuaWeiTargetExtension]
+
+// HuaWeiTarget huawei load balancer target.
+type HuaWeiTarget = Target[corelb.HuaWeiTargetExtension]
+
+// HuaWeiCreateOption defines options to create huawei load balancer.
+type HuaWeiCreateOption struct {
+	Region     string  `json:"region" validate:"required"`
+	Name       *string `json:"name" validate:"omitempty,max=255"`
+	CloudVpcID *string `json:"cloud_vpc_id" validate:"omitempty"`
+	// CloudVipSubnetID 负载均衡私网IPv4地址所在子网的IPv4子网ID
+	CloudVipSubnetID *string  `json:"cloud_vip_subnet_id" validate:"omitempty"`
+	Zones            []string `json:"zones" validate:"required,min=1"`
+	L4FlavorID       *string  `json:"l4_flavor_id" validate:"omitempty"`
+	L7FlavorID       *string  `json:"l7_flavor_id" validate:"omitempty"`
+	// CloudEipIDs 绑定的弹性公网IP，为空时创建内网负载均衡
+	CloudEipIDs []string `json:"cloud_eip_ids" validate:"omitempty"`
+}
+
+// Validate HuaWeiCreateOption.
+func (opt HuaWeiCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
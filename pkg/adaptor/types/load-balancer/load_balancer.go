@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer defines load balancer adaptor types.
+package loadbalancer
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// LoadBalancer define load balancer returned by cloud.
+type LoadBalancer[Ext corelb.Extension] struct {
+	CloudID              string                  `json:"cloud_id"`
+	Name                 string                  `json:"name"`
+	Region               string                  `json:"region"`
+	Zones                []string                `json:"zones"`
+	LBType               enumor.LoadBalancerType `json:"lb_type"`
+	Status               string                  `json:"status"`
+	CloudVpcID           string                  `json:"cloud_vpc_id"`
+	Domain               string                  `json:"domain"`
+	PublicIPv4Addresses  []string                `json:"public_ipv4_addresses"`
+	PrivateIPv4Addresses []string                `json:"private_ipv4_addresses"`
+	Memo                 *string                 `json:"memo"`
+	CloudCreatedTime     string                  `json:"cloud_created_time"`
+	Extension            *Ext                    `json:"extension"`
+}
+
+// GetCloudID ...
+func (lb LoadBalancer[Ext]) GetCloudID() string {
+	return lb.CloudID
+}
+
+// Listener define load balancer listener returned by cloud, including its backend targets.
+type Listener[LExt corelb.ListenerExtension, TExt corelb.TargetExtension] struct {
+	CloudID   string          `json:"cloud_id"`
+	Name      string          `json:"name"`
+	Protocol  string          `json:"protocol"`
+	Port      int64           `json:"port"`
+	EndPort   int64           `json:"end_port"`
+	Extension *LExt           `json:"extension"`
+	Targets   []*Target[TExt] `json:"targets"`
+}
+
+// GetCloudID ...
+func (l Listener[LExt, TExt]) GetCloudID() string {
+	return l.CloudID
+}
+
+// Target define load balancer backend target returned by cloud.
+type Target[Ext corelb.TargetExtension] struct {
+	TargetType    enumor.LoadBalancerTargetType `json:"target_type"`
+	CloudTargetID string                        `json:"cloud_target_id"`
+	IP            string                        `json:"ip"`
+	Port          int64                         `json:"port"`
+	Weight        int64                         `json:"weight"`
+	Extension     *Ext                          `json:"extension"`
+}
+
+// GetCloudID ...
+func (t Target[Ext]) GetCloudID() string {
+	return corelb.GenTargetCloudID(t.CloudTargetID, t.Port)
+}
+
+// ListenerListOption defines options to list all listeners of one load balancer.
+type ListenerListOption struct {
+	// Region 负载均衡所属地域，azure 无需设置
+	Region string `json:"region" validate:"omitempty"`
+	// ResourceGroupName 负载均衡所属资源组，仅 azure 需要设置
+	ResourceGroupName string `json:"resource_group_name" validate:"omitempty"`
+	CloudLbID         string `json:"cloud_lb_id" validate:"required"`
+}
+
+// Validate ListenerListOption.
+func (opt ListenerListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// DeleteOption defines options to delete load balancer.
+type DeleteOption = core.BaseRegionalDeleteOption
@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudLoadBalancer tcloud load balancer.
+type TCloudLoadBalancer = LoadBalancer[corelb.TCloudLoadBalancerExtension]
+
+// TCloudListener tcloud load balancer listener.
+type TCloudListener = Listener[corelb.TCloudListenerExtension, corelb.TCloudTargetExtension]
+
+// TCloudTarget tcloud load balancer target.
+type TCloudTarget = Target[corelb.TCloudTargetExtension]
+
+// TCloudCreateOption defines options to create tcloud load balancer.
+type TCloudCreateOption struct {
+	Region string `json:"region" validate:"required"`
+	// LoadBalancerType OPEN：公网，INTERNAL：内网
+	LoadBalancerType string  `json:"load_balancer_type" validate:"required,eq=OPEN|eq=INTERNAL"`
+	Name             *string `json:"name" validate:"omitempty,max=60"`
+	CloudVpcID       *string `json:"cloud_vpc_id" validate:"omitempty"`
+	// CloudSubnetID 内网负载均衡必须指定子网
+	CloudSubnetID *string `json:"cloud_subnet_id" validate:"omitempty"`
+	// AddressIPVersion IPV4 | IPV6 | IPv6FullChain
+	AddressIPVersion *string `json:"address_ip_version" validate:"omitempty"`
+	Zone             *string `json:"zone" validate:"omitempty"`
+	// VipIsp CMCC | CTCC | CUCC，仅公网负载均衡有效
+	VipIsp *string `json:"vip_isp" validate:"omitempty"`
+	Number *uint64 `json:"number" validate:"omitempty,min=1,max=20"`
+}
+
+// Validate TCloudCreateOption.
+func (opt TCloudCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
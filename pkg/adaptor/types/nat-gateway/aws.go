@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	"errors"
+
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// AwsNatGateway aws nat gateway.
+type AwsNatGateway = NatGateway[corenat.AwsNatGatewayExtension]
+
+// AwsCreateOption defines options to create aws nat gateway.
+type AwsCreateOption struct {
+	Region        string                `json:"region" validate:"required"`
+	CloudSubnetID string                `json:"cloud_subnet_id" validate:"required"`
+	NatType       enumor.NatGatewayType `json:"nat_type" validate:"required"`
+	// CloudAllocationID 公网NAT网关需要关联的弹性IP分配ID
+	CloudAllocationID *string `json:"cloud_allocation_id" validate:"omitempty"`
+	PrivateIPAddress  *string `json:"private_ip_address" validate:"omitempty"`
+	Name              *string `json:"name" validate:"omitempty"`
+}
+
+// Validate AwsCreateOption.
+func (opt AwsCreateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if err := opt.NatType.Validate(); err != nil {
+		return err
+	}
+
+	if opt.NatType == enumor.PublicNatGateway && opt.CloudAllocationID == nil {
+		return errors.New("cloud_allocation_id is required when create public nat gateway")
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/validator"
+)
+
+// AzureNatGateway azure nat gateway.
+type AzureNatGateway = NatGateway[corenat.AzureNatGatewayExtension]
+
+// AzureCreateOption defines options to create azure nat gateway.
+type AzureCreateOption struct {
+	ResourceGroupName    string   `json:"resource_group_name" validate:"required"`
+	Name                 string   `json:"name" validate:"required"`
+	Region               string   `json:"region" validate:"required"`
+	Zones                []string `json:"zones" validate:"omitempty"`
+	IdleTimeoutInMinutes *int32   `json:"idle_timeout_in_minutes" validate:"omitempty,min=4,max=120"`
+	// CloudPublicIPIDs 关联的公共IP地址ID，即弹性公网IP的云上ID
+	CloudPublicIPIDs []string `json:"cloud_public_ip_ids" validate:"omitempty"`
+}
+
+// Validate AzureCreateOption.
+func (opt AzureCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AzureDeleteOption defines options to delete azure nat gateway.
+type AzureDeleteOption = core.AzureDeleteOption
@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+)
+
+// GcpNatGateway gcp nat gateway, corresponds to cloud nat configured in cloud router.
+type GcpNatGateway = NatGateway[corenat.GcpNatGatewayExtension]
+
+// GcpListOption defines options to list gcp cloud routers and their cloud nats.
+type GcpListOption struct {
+	Region string `json:"region" validate:"required"`
+	// CloudIDs NAT网关云上ID，由路由器ID与NAT名称组成
+	CloudIDs []string      `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.GcpPage `json:"page" validate:"omitempty"`
+}
+
+// Validate GcpListOption.
+func (opt GcpListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) > core.GcpQueryLimit {
+		return errf.Newf(errf.InvalidParameter, "gcp resource ids length should <= %d", core.GcpQueryLimit)
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GcpCreateOption defines options to create gcp cloud nat in an existing cloud router.
+type GcpCreateOption struct {
+	Region     string `json:"region" validate:"required"`
+	RouterName string `json:"router_name" validate:"required"`
+	Name       string `json:"name" validate:"required,max=63"`
+	// NatIPSelfLinks 手动分配的外部IP地址，为空时自动分配
+	NatIPSelfLinks []string `json:"nat_ip_self_links" validate:"omitempty"`
+	// CloudSubnetSelfLinks 需要进行地址转换的子网，为空时转换所有子网的全部IP范围
+	CloudSubnetSelfLinks []string `json:"cloud_subnet_self_links" validate:"omitempty"`
+	MinPortsPerVm        *int64   `json:"min_ports_per_vm" validate:"omitempty"`
+}
+
+// Validate GcpCreateOption.
+func (opt GcpCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// GcpDeleteOption defines options to delete gcp cloud nat from cloud router.
+type GcpDeleteOption struct {
+	Region     string `json:"region" validate:"required"`
+	RouterName string `json:"router_name" validate:"required"`
+	Name       string `json:"name" validate:"required"`
+}
+
+// Validate GcpDeleteOption.
+func (opt GcpDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
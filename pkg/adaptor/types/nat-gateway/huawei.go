@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/validator"
+)
+
+// HuaWeiNatGateway huawei nat gateway.
+type HuaWeiNatGateway = NatGateway[corenat.HuaWeiNatGatewayExtension]
+
+// HuaWeiCreateOption defines options to create huawei public nat gateway.
+type HuaWeiCreateOption struct {
+	Region string `json:"region" validate:"required"`
+	Name   string `json:"name" validate:"required,max=64"`
+	// CloudVpcID 华为云NAT网关对应的路由器ID即VPC ID
+	CloudVpcID string `json:"cloud_vpc_id" validate:"required"`
+	// CloudSubnetID 华为云NAT网关对应的下一跳所属网络ID，即子网的网络ID
+	CloudSubnetID string `json:"cloud_subnet_id" validate:"required"`
+	// Spec 网关规格，1：小型，2：中型，3：大型，4：超大型
+	Spec                string  `json:"spec" validate:"required,oneof=1 2 3 4"`
+	Memo                *string `json:"memo" validate:"omitempty,max=255"`
+	EnterpriseProjectID *string `json:"enterprise_project_id" validate:"omitempty"`
+}
+
+// Validate HuaWeiCreateOption.
+func (opt HuaWeiCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway defines nat gateway adaptor types.
+package natgateway
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+)
+
+// NatGateway define nat gateway returned by cloud.
+type NatGateway[Ext corenat.Extension] struct {
+	CloudID            string                `json:"cloud_id"`
+	Name               string                `json:"name"`
+	Region             string                `json:"region"`
+	Zone               string                `json:"zone"`
+	NatType            enumor.NatGatewayType `json:"nat_type"`
+	Status             string                `json:"status"`
+	CloudVpcID         string                `json:"cloud_vpc_id"`
+	CloudSubnetID      string                `json:"cloud_subnet_id"`
+	PublicIPAddresses  []string              `json:"public_ip_addresses"`
+	PrivateIPAddresses []string              `json:"private_ip_addresses"`
+	CloudEipIDs        []string              `json:"cloud_eip_ids"`
+	Memo               *string               `json:"memo"`
+	CloudCreatedTime   string                `json:"cloud_created_time"`
+	Extension          *Ext                  `json:"extension"`
+}
+
+// GetCloudID ...
+func (nat NatGateway[Ext]) GetCloudID() string {
+	return nat.CloudID
+}
+
+// DeleteOption defines options to delete nat gateway.
+type DeleteOption = core.BaseRegionalDeleteOption
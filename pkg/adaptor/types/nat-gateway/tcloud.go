@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudNatGateway tcloud nat gateway.
+type TCloudNatGateway = NatGateway[corenat.TCloudNatGatewayExtension]
+
+// TCloudCreateOption defines options to create tcloud nat gateway.
+type TCloudCreateOption struct {
+	Region                  string  `json:"region" validate:"required"`
+	Name                    string  `json:"name" validate:"required,max=60"`
+	CloudVpcID              string  `json:"cloud_vpc_id" validate:"required"`
+	CloudSubnetID           *string `json:"cloud_subnet_id" validate:"omitempty"`
+	Zone                    *string `json:"zone" validate:"omitempty"`
+	InternetMaxBandwidthOut *uint64 `json:"internet_max_bandwidth_out" validate:"omitempty"`
+	MaxConcurrentConnection *uint64 `json:"max_concurrent_connection" validate:"omitempty"`
+	// AddressCount 自动申请的弹性公网IP个数，与 PublicIPAddresses 至少指定一个
+	AddressCount *uint64 `json:"address_count" validate:"omitempty,min=1,max=10"`
+	// PublicIPAddresses 绑定已有的弹性公网IP地址
+	PublicIPAddresses []string `json:"public_ip_addresses" validate:"omitempty,max=10"`
+}
+
+// Validate TCloudCreateOption.
+func (opt TCloudCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
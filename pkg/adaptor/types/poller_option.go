@@ -55,3 +55,19 @@ func NewBatchOperateCvmPollerOpt() *poller.PollUntilDoneOption {
 		Retry:             retry.NewRetryPolicy(10, [2]uint{1000, 5000}),
 	}
 }
+
+// NewModifyCvmPollerOpt 超时时间15分钟，10次之内重试间隔时间2s，10次之后重试间隔时间2-10s之间
+func NewModifyCvmPollerOpt() *poller.PollUntilDoneOption {
+	return &poller.PollUntilDoneOption{
+		TimeoutTimeSecond: 15 * 60,
+		Retry:             retry.NewRetryPolicy(10, [2]uint{2000, 10000}),
+	}
+}
+
+// NewCreateImagePollerOpt 超时时间30分钟，10次之内重试间隔时间5s，10次之后重试间隔时间5-30s之间
+func NewCreateImagePollerOpt() *poller.PollUntilDoneOption {
+	return &poller.PollUntilDoneOption{
+		TimeoutTimeSecond: 30 * 60,
+		Retry:             retry.NewRetryPolicy(10, [2]uint{5000, 30000}),
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines cloud resource tag adaptor types.
+package resourcetag
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// Tag define cloud resource tag, it's called label in gcp and metadata in openstack.
+type Tag struct {
+	Key   string `json:"key" validate:"required"`
+	Value string `json:"value"`
+}
+
+// ResourceTags define all tags of one cloud resource.
+type ResourceTags struct {
+	CloudID string `json:"cloud_id"`
+	Tags    []Tag  `json:"tags"`
+}
+
+// ListOption defines options to list tags of resources, region is required by regional vendors.
+type ListOption struct {
+	Region   string                   `json:"region"`
+	ResType  enumor.CloudResourceType `json:"res_type" validate:"required"`
+	CloudIDs []string                 `json:"cloud_ids" validate:"required,min=1,max=100"`
+}
+
+// Validate list option.
+func (opt ListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TagOption defines options to add or overwrite tags of resources.
+type TagOption struct {
+	Region   string                   `json:"region"`
+	ResType  enumor.CloudResourceType `json:"res_type" validate:"required"`
+	CloudIDs []string                 `json:"cloud_ids" validate:"required,min=1,max=100"`
+	Tags     []Tag                    `json:"tags" validate:"required,min=1,dive"`
+}
+
+// Validate tag option.
+func (opt TagOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// UntagOption defines options to remove tags of resources by tag keys.
+type UntagOption struct {
+	Region   string                   `json:"region"`
+	ResType  enumor.CloudResourceType `json:"res_type" validate:"required"`
+	CloudIDs []string                 `json:"cloud_ids" validate:"required,min=1,max=100"`
+	Keys     []string                 `json:"keys" validate:"required,min=1"`
+}
+
+// Validate untag option.
+func (opt UntagOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// GcpResource defines gcp resource to operate labels, gcp resource is located by zone or region and name.
+type GcpResource struct {
+	// Zone 主机、硬盘所在可用区
+	Zone string `json:"zone"`
+	// Region 弹性IP所在地域
+	Region  string `json:"region"`
+	Name    string `json:"name" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// GcpListOption defines options to list labels of gcp resources.
+type GcpListOption struct {
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Resources []GcpResource            `json:"resources" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate gcp list option.
+func (opt GcpListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// GcpTagOption defines options to add or overwrite labels of gcp resources.
+type GcpTagOption struct {
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Resources []GcpResource            `json:"resources" validate:"required,min=1,max=100,dive"`
+	Tags      []Tag                    `json:"tags" validate:"required,min=1,dive"`
+}
+
+// Validate gcp tag option.
+func (opt GcpTagOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// GcpUntagOption defines options to remove labels of gcp resources by label keys.
+type GcpUntagOption struct {
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Resources []GcpResource            `json:"resources" validate:"required,min=1,max=100,dive"`
+	Keys      []string                 `json:"keys" validate:"required,min=1"`
+}
+
+// Validate gcp untag option.
+func (opt GcpUntagOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
@@ -70,3 +70,20 @@ type AzureCredential struct {
 func (a *AzureCredential) Validate() error {
 	return validator.Validate.Struct(a)
 }
+
+// OpenStackCredential define openstack credential information, openstack uses keystone v3 application
+// credential to authenticate, application credential is scoped to the project which it is created in.
+type OpenStackCredential struct {
+	// CloudAuthUrl keystone v3 地址，如 https://keystone.example.com:5000/v3
+	CloudAuthUrl   string `json:"cloud_auth_url" validate:"required"`
+	CloudProjectID string `json:"cloud_project_id" validate:"required"`
+	// CloudSecretID application credential id
+	CloudSecretID string `json:"cloud_secret_id" validate:"required"`
+	// CloudSecretKey application credential secret
+	CloudSecretKey string `json:"cloud_secret_key" validate:"required"`
+}
+
+// Validate OpenStackCredential
+func (o *OpenStackCredential) Validate() error {
+	return validator.Validate.Struct(o)
+}
@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygrouprule
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- List --------------------------
+
+// OpenStackListOption define openstack security group rule list option.
+type OpenStackListOption struct {
+	Region               string `json:"region" validate:"required"`
+	CloudSecurityGroupID string `json:"cloud_security_group_id" validate:"required"`
+}
+
+// Validate openstack security group rule list option.
+func (opt OpenStackListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// OpenStackSGRule openstack neutron security group rule, neutron rule only allows traffic.
+type OpenStackSGRule struct {
+	CloudID              string `json:"cloud_id"`
+	CloudSecurityGroupID string `json:"cloud_security_group_id"`
+	// Direction ingress or egress.
+	Direction      string `json:"direction"`
+	Ethertype      string `json:"ethertype"`
+	Protocol       string `json:"protocol"`
+	RemoteIPPrefix string `json:"remote_ip_prefix"`
+	RemoteGroupID  string `json:"remote_group_id"`
+	// Port 端口范围，如 "22", "8000-8080"，为空时表示全部端口
+	Port           string `json:"port"`
+	Description    string `json:"description"`
+	CloudProjectID string `json:"cloud_project_id"`
+}
+
+// GetCloudID ...
+func (rule OpenStackSGRule) GetCloudID() string {
+	return rule.CloudID
+}
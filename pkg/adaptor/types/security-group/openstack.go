@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- List --------------------------
+
+// OpenStackListOption define openstack security group list option.
+type OpenStackListOption struct {
+	core.OpenStackListOption `json:",inline"`
+}
+
+// Validate openstack security group list option.
+func (opt OpenStackListOption) Validate() error {
+	return opt.OpenStackListOption.Validate()
+}
+
+// OpenStackSecurityGroup openstack neutron security group.
+type OpenStackSecurityGroup struct {
+	CloudID        string `json:"cloud_id"`
+	Name           string `json:"name"`
+	Region         string `json:"region"`
+	Description    string `json:"description"`
+	CloudProjectID string `json:"cloud_project_id"`
+	Stateful       bool   `json:"stateful"`
+}
+
+// GetCloudID ...
+func (sg OpenStackSecurityGroup) GetCloudID() string {
+	return sg.CloudID
+}
+
+// -------------------------- Associate --------------------------
+
+// OpenStackAssociateCvmOption define security group bind cvm option.
+type OpenStackAssociateCvmOption struct {
+	Region               string `json:"region" validate:"required"`
+	CloudSecurityGroupID string `json:"cloud_security_group_id" validate:"required"`
+	CloudCvmID           string `json:"cloud_cvm_id" validate:"required"`
+}
+
+// Validate security group cvm bind option.
+func (opt OpenStackAssociateCvmOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Delete --------------------------
+
+// OpenStackDeleteOption openstack security group delete option.
+type OpenStackDeleteOption struct {
+	CloudID string `json:"cloud_id" validate:"required"`
+	Region  string `json:"region" validate:"required"`
+}
+
+// Validate security group delete option.
+func (opt OpenStackDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
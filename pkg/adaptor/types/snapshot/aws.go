@@ -0,0 +1,42 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/validator"
+)
+
+// AwsSnapshot aws snapshot.
+type AwsSnapshot = Snapshot[coresnap.AwsSnapshotExtension]
+
+// AwsCreateOption defines options to create aws snapshot.
+type AwsCreateOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	// Name aws 快照没有名称属性，通过 Name 标签设置
+	Name string  `json:"name" validate:"omitempty,max=255"`
+	Memo *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate AwsCreateOption.
+func (opt AwsCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
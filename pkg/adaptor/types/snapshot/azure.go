@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/validator"
+)
+
+// AzureSnapshot azure snapshot.
+type AzureSnapshot = Snapshot[coresnap.AzureSnapshotExtension]
+
+// AzureCreateOption defines options to create azure snapshot.
+type AzureCreateOption struct {
+	ResourceGroupName string `json:"resource_group_name" validate:"required"`
+	Name              string `json:"name" validate:"required,max=80"`
+	Region            string `json:"region" validate:"required"`
+	CloudDiskID       string `json:"cloud_disk_id" validate:"required"`
+	// Incremental 是否创建增量快照，默认为全量快照
+	Incremental *bool `json:"incremental" validate:"omitempty"`
+}
+
+// Validate AzureCreateOption.
+func (opt AzureCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AzureDeleteOption defines options to delete azure snapshot.
+type AzureDeleteOption = core.AzureDeleteOption
@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/validator"
+)
+
+// GcpSnapshot gcp snapshot.
+type GcpSnapshot = Snapshot[coresnap.GcpSnapshotExtension]
+
+// GcpCreateOption defines options to create gcp snapshot.
+type GcpCreateOption struct {
+	Zone string `json:"zone" validate:"required"`
+	// CloudDiskName 源云盘名称，gcp 通过可用区与名称定位云盘
+	CloudDiskName string  `json:"cloud_disk_name" validate:"required"`
+	Name          string  `json:"name" validate:"required,max=63"`
+	Memo          *string `json:"memo" validate:"omitempty"`
+	// StorageLocations 快照存储位置，为空时使用云盘所在区域对应的多区域位置
+	StorageLocations []string `json:"storage_locations" validate:"omitempty"`
+}
+
+// Validate GcpCreateOption.
+func (opt GcpCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// GcpDeleteOption gcp 快照为全局资源，通过快照名称删除
+type GcpDeleteOption = core.BaseDeleteOption
@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/validator"
+)
+
+// HuaWeiSnapshot huawei snapshot.
+type HuaWeiSnapshot = Snapshot[coresnap.HuaWeiSnapshotExtension]
+
+// HuaWeiListOption defines options to list huawei snapshot, evs snapshot list api only supports offset paging.
+type HuaWeiListOption struct {
+	Region   string                    `json:"region" validate:"required"`
+	CloudIDs []string                  `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.HuaWeiCvmOffsetPage `json:"page" validate:"omitempty"`
+}
+
+// Validate HuaWeiListOption.
+func (opt HuaWeiListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HuaWeiCreateOption defines options to create huawei snapshot.
+type HuaWeiCreateOption struct {
+	Region      string  `json:"region" validate:"required"`
+	CloudDiskID string  `json:"cloud_disk_id" validate:"required"`
+	Name        string  `json:"name" validate:"omitempty,max=64"`
+	Memo        *string `json:"memo" validate:"omitempty,max=255"`
+	// Force 云盘已挂载时是否强制创建快照
+	Force *bool `json:"force" validate:"omitempty"`
+}
+
+// Validate HuaWeiCreateOption.
+func (opt HuaWeiCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiRollbackOption defines options to rollback huawei disk from snapshot.
+type HuaWeiRollbackOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudID     string `json:"cloud_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+}
+
+// Validate HuaWeiRollbackOption.
+func (opt HuaWeiRollbackOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
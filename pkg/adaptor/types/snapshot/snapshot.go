@@ -0,0 +1,50 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot defines disk snapshot adaptor types.
+package snapshot
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+)
+
+// Snapshot define disk snapshot returned by cloud.
+type Snapshot[Ext coresnap.Extension] struct {
+	CloudID     string `json:"cloud_id"`
+	Name        string `json:"name"`
+	Region      string `json:"region"`
+	Zone        string `json:"zone"`
+	Status      string `json:"status"`
+	CloudDiskID string `json:"cloud_disk_id"`
+	// DiskSize 源云盘大小，单位GB
+	DiskSize         uint64  `json:"disk_size"`
+	Encrypted        *bool   `json:"encrypted"`
+	Memo             *string `json:"memo"`
+	CloudCreatedTime string  `json:"cloud_created_time"`
+	Extension        *Ext    `json:"extension"`
+}
+
+// GetCloudID ...
+func (s Snapshot[Ext]) GetCloudID() string {
+	return s.CloudID
+}
+
+// DeleteOption defines options to delete snapshot.
+type DeleteOption = core.BaseRegionalDeleteOption
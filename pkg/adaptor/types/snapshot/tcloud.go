@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudSnapshot tcloud snapshot.
+type TCloudSnapshot = Snapshot[coresnap.TCloudSnapshotExtension]
+
+// TCloudCreateOption defines options to create tcloud snapshot.
+type TCloudCreateOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	Name        string `json:"name" validate:"omitempty,max=60"`
+	// Deadline 快照到期时间，格式 2006-01-02T15:04:05+00:00，不传时快照永久保留
+	Deadline *string `json:"deadline" validate:"omitempty"`
+}
+
+// Validate TCloudCreateOption.
+func (opt TCloudCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudRollbackOption defines options to rollback tcloud disk from snapshot.
+type TCloudRollbackOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudID     string `json:"cloud_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	// AutoStopInstance 回滚前是否自动关闭云盘挂载的实例
+	AutoStopInstance *bool `json:"auto_stop_instance" validate:"omitempty"`
+	// AutoStartInstance 回滚完成后是否自动开启实例，仅 AutoStopInstance 为 true 时有效
+	AutoStartInstance *bool `json:"auto_start_instance" validate:"omitempty"`
+}
+
+// Validate TCloudRollbackOption.
+func (opt TCloudRollbackOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package adtysubnet
+
+import "hcm/pkg/adaptor/types/core"
+
+// OpenStackSubnetListOption defines openstack list subnet options.
+type OpenStackSubnetListOption struct {
+	core.OpenStackListOption `json:",inline"`
+	CloudVpcID               string `json:"cloud_vpc_id,omitempty"`
+}
+
+// Validate openstack list subnet option.
+func (opt OpenStackSubnetListOption) Validate() error {
+	return opt.OpenStackListOption.Validate()
+}
+
+// OpenStackSubnetListResult defines openstack list subnet result.
+type OpenStackSubnetListResult struct {
+	Details []OpenStackSubnet `json:"details"`
+}
+
+// OpenStackSubnetExtension defines openstack subnet extensional info.
+type OpenStackSubnetExtension struct {
+	Region         string   `json:"region"`
+	IpVersion      int64    `json:"ip_version"`
+	GatewayIp      string   `json:"gateway_ip"`
+	EnableDhcp     bool     `json:"enable_dhcp"`
+	DnsNameservers []string `json:"dns_nameservers"`
+}
+
+// OpenStackSubnet defines openstack subnet.
+type OpenStackSubnet Subnet[OpenStackSubnetExtension]
+
+// GetCloudID ...
+func (subnet OpenStackSubnet) GetCloudID() string {
+	return subnet.CloudID
+}
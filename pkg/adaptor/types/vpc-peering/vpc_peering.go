@@ -0,0 +1,62 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package vpcpeering defines vpc peering adaptor types.
+package vpcpeering
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// VpcPeering define connection between vpcs returned by cloud. for hub attachments like transit gateway vpc
+// attachment and ccn vpc attachment, only local vpc is set, and vpcs attached to the same gateway can reach
+// each other.
+type VpcPeering struct {
+	CloudID            string                  `json:"cloud_id"`
+	Name               string                  `json:"name"`
+	Region             string                  `json:"region"`
+	Type               enumor.VpcPeeringType   `json:"type"`
+	Status             enumor.VpcPeeringStatus `json:"status"`
+	CloudStatus        string                  `json:"cloud_status"`
+	CloudGatewayID     string                  `json:"cloud_gateway_id"`
+	LocalCloudVpcID    string                  `json:"local_cloud_vpc_id"`
+	LocalCidrs         []string                `json:"local_cidrs"`
+	PeerCloudVpcID     string                  `json:"peer_cloud_vpc_id"`
+	PeerCloudAccountID string                  `json:"peer_cloud_account_id"`
+	PeerRegion         string                  `json:"peer_region"`
+	PeerCidrs          []string                `json:"peer_cidrs"`
+	Memo               *string                 `json:"memo"`
+	CloudCreatedTime   string                  `json:"cloud_created_time"`
+}
+
+// GetCloudID ...
+func (p VpcPeering) GetCloudID() string {
+	return p.CloudID
+}
+
+// ListOption defines options to list regional vpc peerings.
+type ListOption struct {
+	Region string `json:"region" validate:"required"`
+}
+
+// Validate list option.
+func (opt ListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
@@ -289,7 +289,7 @@ type AzureVpcExtension struct {
 // VpcExtension defines vpc extensional info.
 type VpcExtension interface {
 	cloud.TCloudVpcExtension | cloud.AwsVpcExtension | cloud.GcpVpcExtension | AzureVpcExtension |
-		cloud.HuaWeiVpcExtension
+		cloud.HuaWeiVpcExtension | cloud.OpenStackVpcExtension
 }
 
 // TCloudVpc defines tencent cloud vpc.
@@ -378,3 +378,16 @@ func (v AzureVpcListUsageOption) Validate() error {
 
 	return nil
 }
+
+// OpenStackVpcListResult defines openstack list vpc result.
+type OpenStackVpcListResult struct {
+	Details []OpenStackVpc `json:"details"`
+}
+
+// OpenStackVpc defines openstack vpc.
+type OpenStackVpc Vpc[cloud.OpenStackVpcExtension]
+
+// GetCloudID ...
+func (vpc OpenStackVpc) GetCloudID() string {
+	return vpc.CloudID
+}
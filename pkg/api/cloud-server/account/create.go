@@ -112,6 +112,34 @@ func (req *HuaWeiAccountExtensionCreateReq) IsFull() bool {
 	return req.CloudSecretID != "" && req.CloudSecretKey != ""
 }
 
+// OpenStackAccountExtensionCreateReq ...
+type OpenStackAccountExtensionCreateReq struct {
+	CloudAuthUrl     string `json:"cloud_auth_url" validate:"required,url"`
+	CloudProjectID   string `json:"cloud_project_id" validate:"required"`
+	CloudProjectName string `json:"cloud_project_name" validate:"omitempty"`
+	CloudSecretID    string `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey   string `json:"cloud_secret_key" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *OpenStackAccountExtensionCreateReq) Validate(accountType enumor.AccountType) error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	// 登记账号密钥可为空，其他类型则必填
+	if accountType != enumor.RegistrationAccount && !req.IsFull() {
+		return secretEmptyError
+	}
+
+	return nil
+}
+
+// IsFull 对于不同账号类型，有些字段是允许为空的，这里返回是否所有字段都有值
+func (req *OpenStackAccountExtensionCreateReq) IsFull() bool {
+	return req.CloudSecretID != "" && req.CloudSecretKey != ""
+}
+
 // GcpAccountExtensionCreateReq ...
 type GcpAccountExtensionCreateReq struct {
 	CloudProjectID          string `json:"cloud_project_id" validate:"required"`
@@ -37,3 +37,14 @@ type AuditListReq struct {
 func (req *AuditListReq) Validate() error {
 	return validator.Validate.Struct(req)
 }
+
+// ResDriftEventListReq define resource drift event list req.
+type ResDriftEventListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate resource drift event list req.
+func (req *ResDriftEventListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -73,8 +73,9 @@ type AzureCvmCreateReq struct {
 	// https://learn.microsoft.com/en-us/azure/virtual-machines/linux/faq
 	// https://learn.microsoft.com/en-us/azure/virtual-machines/windows/faq
 	Username          string `json:"username" validate:"required,min=1,max=32"`
-	Password          string `json:"password" validate:"required"`
+	Password          string `json:"password" validate:"required_without=KeyPairID"`
 	ConfirmedPassword string `json:"confirmed_password" validate:"eqfield=Password"`
+	KeyPairID         string `json:"key_pair_id" validate:"excluded_with=Password"`
 
 	RequiredCount int64 `json:"required_count" validate:"required,min=1,max=500"`
 
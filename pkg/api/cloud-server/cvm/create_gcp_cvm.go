@@ -56,7 +56,8 @@ type GcpCvmCreateReq struct {
 	} `json:"data_disk" validate:"omitempty"`
 
 	// 访问主机的ssh公钥
-	Password string `json:"password" validate:"required"`
+	Password  string `json:"password" validate:"required_without=KeyPairID"`
+	KeyPairID string `json:"key_pair_id" validate:"excluded_with=Password"`
 
 	RequiredCount int64 `json:"required_count" validate:"required,min=1,max=500"`
 
@@ -67,8 +67,9 @@ type TCloudCvmCreateReq struct {
 		DiskCount  int64                      `json:"disk_count" validate:"required,min=1"`
 	} `json:"data_disk" validate:"omitempty,max=20"`
 
-	Password          string `json:"password" validate:"required"`
+	Password          string `json:"password" validate:"required_without=KeyPairID"`
 	ConfirmedPassword string `json:"confirmed_password" validate:"eqfield=Password"`
+	KeyPairID         string `json:"key_pair_id" validate:"excluded_with=Password"`
 
 	InstanceChargeType typecvm.TCloudInstanceChargeType `json:"instance_charge_type" validate:"required"`
 
@@ -131,9 +132,11 @@ func (req *TCloudCvmCreateReq) Validate(bizRequired bool) error {
 		)
 	}
 
-	// 校验密码是否符合要求
-	if err := req.validatePassword(); err != nil {
-		return err
+	// 校验密码是否符合要求，使用密钥对登录时无需密码
+	if len(req.Password) != 0 {
+		if err := req.validatePassword(); err != nil {
+			return err
+		}
 	}
 
 	return nil
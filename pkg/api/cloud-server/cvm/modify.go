@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cscvm
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/validator"
+)
+
+// ModifyCvmAction 主机变更操作类型
+type ModifyCvmAction string
+
+const (
+	// ChangeInstanceType 变更机型，主机运行中时会先关机，变更完成后再开机
+	ChangeInstanceType ModifyCvmAction = "change_instance_type"
+	// ResizeDisk 扩容系统盘或数据盘
+	ResizeDisk ModifyCvmAction = "resize_disk"
+	// Rename 修改主机名称
+	Rename ModifyCvmAction = "rename"
+	// ChangeSecurityGroup 变更主机绑定的安全组
+	ChangeSecurityGroup ModifyCvmAction = "change_security_group"
+)
+
+// ModifyCvmReq define modify cvm req, only the fields of the action are used.
+type ModifyCvmReq struct {
+	Action           ModifyCvmAction `json:"action" validate:"required"`
+	InstanceType     string          `json:"instance_type,omitempty"`
+	DiskID           string          `json:"disk_id,omitempty"`
+	DiskSize         int64           `json:"disk_size,omitempty"`
+	Name             string          `json:"name,omitempty"`
+	SecurityGroupIDs []string        `json:"security_group_ids,omitempty"`
+}
+
+// Validate modify cvm request.
+func (req *ModifyCvmReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	switch req.Action {
+	case ChangeInstanceType:
+		if len(req.InstanceType) == 0 {
+			return errors.New("instance_type is required")
+		}
+
+	case ResizeDisk:
+		if len(req.DiskID) == 0 {
+			return errors.New("disk_id is required")
+		}
+
+		if req.DiskSize <= 0 {
+			return errors.New("disk_size should > 0")
+		}
+
+	case Rename:
+		if len(req.Name) == 0 {
+			return errors.New("name is required")
+		}
+
+	case ChangeSecurityGroup:
+		if len(req.SecurityGroupIDs) == 0 {
+			return errors.New("security_group_ids is required")
+		}
+
+	default:
+		return fmt.Errorf("unsupported modify cvm action: %s", req.Action)
+	}
+
+	return nil
+}
+
+// UpdateFields 返回用于更新审计的变更字段
+func (req *ModifyCvmReq) UpdateFields() map[string]interface{} {
+	switch req.Action {
+	case ChangeInstanceType:
+		return map[string]interface{}{"machine_type": req.InstanceType}
+	case ResizeDisk:
+		return map[string]interface{}{"disk_id": req.DiskID, "disk_size": req.DiskSize}
+	case Rename:
+		return map[string]interface{}{"name": req.Name}
+	case ChangeSecurityGroup:
+		return map[string]interface{}{"security_group_ids": req.SecurityGroupIDs}
+	default:
+		return map[string]interface{}{"action": req.Action}
+	}
+}
+
+// ModifyCvmApplicationReq define modify cvm application req.
+type ModifyCvmApplicationReq struct {
+	BkBizID       int64  `json:"bk_biz_id" validate:"required,min=1"`
+	CvmID         string `json:"cvm_id" validate:"required"`
+	*ModifyCvmReq `json:",inline" validate:"required"`
+}
+
+// Validate modify cvm application request.
+func (req *ModifyCvmApplicationReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.ModifyCvmReq.Validate()
+}
@@ -0,0 +1,118 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cscvm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestModifyCvmReqValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		req     *ModifyCvmReq
+		wantErr bool
+	}{
+		{"change instance type", &ModifyCvmReq{Action: ChangeInstanceType, InstanceType: "S5.MEDIUM4"}, false},
+		{"change instance type without type", &ModifyCvmReq{Action: ChangeInstanceType}, true},
+		{"resize disk", &ModifyCvmReq{Action: ResizeDisk, DiskID: "disk-1", DiskSize: 100}, false},
+		{"resize disk without disk id", &ModifyCvmReq{Action: ResizeDisk, DiskSize: 100}, true},
+		{"resize disk with invalid size", &ModifyCvmReq{Action: ResizeDisk, DiskID: "disk-1"}, true},
+		{"rename", &ModifyCvmReq{Action: Rename, Name: "cvm-1"}, false},
+		{"rename without name", &ModifyCvmReq{Action: Rename}, true},
+		{
+			"change security group",
+			&ModifyCvmReq{Action: ChangeSecurityGroup, SecurityGroupIDs: []string{"sg-1"}},
+			false,
+		},
+		{"change security group without ids", &ModifyCvmReq{Action: ChangeSecurityGroup}, true},
+		{"missing action", &ModifyCvmReq{Name: "cvm-1"}, true},
+		{"unsupported action", &ModifyCvmReq{Action: "reboot"}, true},
+	}
+
+	for _, c := range cases {
+		err := c.req.Validate()
+		if c.wantErr && err == nil {
+			t.Errorf("%s: expect validate failed, but got nil", c.name)
+		}
+
+		if !c.wantErr && err != nil {
+			t.Errorf("%s: expect validate success, but got err: %v", c.name, err)
+		}
+	}
+}
+
+func TestModifyCvmReqUpdateFields(t *testing.T) {
+	cases := []struct {
+		req    *ModifyCvmReq
+		expect map[string]interface{}
+	}{
+		{
+			req:    &ModifyCvmReq{Action: ChangeInstanceType, InstanceType: "S5.MEDIUM4", Name: "ignored"},
+			expect: map[string]interface{}{"machine_type": "S5.MEDIUM4"},
+		},
+		{
+			req:    &ModifyCvmReq{Action: ResizeDisk, DiskID: "disk-1", DiskSize: 100},
+			expect: map[string]interface{}{"disk_id": "disk-1", "disk_size": int64(100)},
+		},
+		{
+			req:    &ModifyCvmReq{Action: Rename, Name: "cvm-1"},
+			expect: map[string]interface{}{"name": "cvm-1"},
+		},
+		{
+			req:    &ModifyCvmReq{Action: ChangeSecurityGroup, SecurityGroupIDs: []string{"sg-1", "sg-2"}},
+			expect: map[string]interface{}{"security_group_ids": []string{"sg-1", "sg-2"}},
+		},
+	}
+
+	for _, c := range cases {
+		if got := c.req.UpdateFields(); !reflect.DeepEqual(got, c.expect) {
+			t.Errorf("action %s update fields, expect: %v, got: %v", c.req.Action, c.expect, got)
+		}
+	}
+}
+
+func TestModifyCvmApplicationReqValidate(t *testing.T) {
+	req := &ModifyCvmApplicationReq{
+		BkBizID:      100,
+		CvmID:        "00000001",
+		ModifyCvmReq: &ModifyCvmReq{Action: Rename, Name: "cvm-1"},
+	}
+	if err := req.Validate(); err != nil {
+		t.Errorf("validate application req failed, err: %v", err)
+		return
+	}
+
+	req.ModifyCvmReq = nil
+	if err := req.Validate(); err == nil {
+		t.Errorf("application req without modify info should validate failed")
+	}
+
+	req.ModifyCvmReq = &ModifyCvmReq{Action: Rename}
+	if err := req.Validate(); err == nil {
+		t.Errorf("application req with invalid modify info should validate failed")
+	}
+
+	req.ModifyCvmReq = &ModifyCvmReq{Action: Rename, Name: "cvm-1"}
+	req.BkBizID = 0
+	if err := req.Validate(); err == nil {
+		t.Errorf("application req without bk_biz_id should validate failed")
+	}
+}
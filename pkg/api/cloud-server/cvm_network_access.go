@@ -0,0 +1,130 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	"fmt"
+	"net"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/sgrule"
+)
+
+// maxAccessQueryCount is the max count of queries in one evaluation.
+const maxAccessQueryCount = 20
+
+// CvmNetworkAccessReq evaluate network access of cvm request, the effective inbound and outbound access is always
+// returned, and queries are answered additionally.
+type CvmNetworkAccessReq struct {
+	Queries []AccessQuery `json:"queries" validate:"omitempty,dive"`
+}
+
+// AccessQuery is the traffic to evaluate.
+type AccessQuery struct {
+	// Direction 流量方向，为空表示入站
+	Direction enumor.SecurityGroupRuleType `json:"direction" validate:"omitempty"`
+	// Protocol 协议，支持 all、tcp、udp、icmp
+	Protocol string `json:"protocol" validate:"required"`
+	// Port 目的端口，仅 tcp、udp 有效
+	Port int `json:"port" validate:"omitempty,min=0,max=65535"`
+	// Cidr 入站为源地址，出站为目的地址，为空表示公网所有地址 0.0.0.0/0
+	Cidr string `json:"cidr" validate:"omitempty,cidr"`
+}
+
+// Validate evaluate network access of cvm request.
+func (req *CvmNetworkAccessReq) Validate() error {
+	if len(req.Queries) > maxAccessQueryCount {
+		return fmt.Errorf("queries should <= %d", maxAccessQueryCount)
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for index, one := range req.Queries {
+		if len(one.Direction) != 0 && one.Direction != enumor.Ingress && one.Direction != enumor.Egress {
+			return fmt.Errorf("queries[%d] direction %s is invalid", index, one.Direction)
+		}
+
+		switch one.Protocol {
+		case sgrule.ProtocolTCP, sgrule.ProtocolUDP:
+			if one.Port == 0 {
+				return fmt.Errorf("queries[%d] port is required by protocol %s", index, one.Protocol)
+			}
+		case sgrule.ProtocolAll, sgrule.ProtocolICMP:
+		default:
+			return fmt.Errorf("queries[%d] protocol %s is invalid", index, one.Protocol)
+		}
+	}
+
+	return nil
+}
+
+// Query convert access query to rule query, with default direction and cidr.
+func (q AccessQuery) Query() sgrule.Query {
+	query := sgrule.Query{
+		Direction: q.Direction,
+		Protocol:  q.Protocol,
+		Port:      q.Port,
+		Cidr:      q.Cidr,
+	}
+	if len(query.Direction) == 0 {
+		query.Direction = enumor.Ingress
+	}
+
+	if len(query.Cidr) == 0 {
+		query.Cidr = "0.0.0.0/0"
+	}
+
+	if _, ipNet, err := net.ParseCIDR(query.Cidr); err == nil {
+		query.Cidr = ipNet.String()
+	}
+
+	return query
+}
+
+// CvmNetworkAccess is the effective network access of cvm.
+type CvmNetworkAccess struct {
+	CvmID  string        `json:"cvm_id"`
+	Vendor enumor.Vendor `json:"vendor"`
+	// PublicIPs 公网IP，包括绑定的弹性IP
+	PublicIPs  []string `json:"public_ips"`
+	PrivateIPs []string `json:"private_ips"`
+	// InternetRoutable 公网IP是否可以与公网互通，aws要求子网路由表存在指向internet网关的默认路由，其它云厂商公网IP即可路由
+	InternetRoutable bool `json:"internet_routable"`
+	// Layers 流量依次经过的过滤层，如网卡上的安全组、子网上的安全组、vpc的防火墙规则，流量需要被每一层放通
+	Layers []sgrule.Layer `json:"layers"`
+	// Ingress 可能生效的入站放通规则，即哪些源地址可以访问哪些端口
+	Ingress []sgrule.Rule `json:"ingress"`
+	// Egress 可能生效的出站放通规则
+	Egress  []sgrule.Rule       `json:"egress"`
+	Results []AccessQueryResult `json:"results"`
+	// Notes 未能纳入计算的网络配置，如未同步规则的网络ACL，结果可能比实际更宽松
+	Notes []string `json:"notes"`
+}
+
+// AccessQueryResult is the evaluation result of access query.
+type AccessQueryResult struct {
+	Query   sgrule.Query   `json:"query"`
+	Verdict sgrule.Verdict `json:"verdict"`
+	// Reachable 流量被放通，且对公网地址的访问还要求cvm有可路由的公网IP
+	Reachable bool `json:"reachable"`
+}
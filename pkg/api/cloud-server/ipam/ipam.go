@@ -0,0 +1,227 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ipam defines ipam cloud-server api.
+package ipam
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	coreipam "hcm/pkg/api/core/cloud/ipam"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// maxCheckCidrCount 一次最多检查的网段数量
+const maxCheckCidrCount = 100
+
+// CreateCidrPoolReq define create cidr pool req.
+type CreateCidrPoolReq struct {
+	Name string `json:"name" validate:"required,max=255"`
+	// BkBizID 地址池所属业务ID，-1表示不限业务
+	BkBizID int64 `json:"bk_biz_id" validate:"required"`
+	// Region 地址池所属地域，为空表示不限地域
+	Region string `json:"region" validate:"omitempty,max=64"`
+	// Environment 地址池所属环境，如prod、test，为空表示不限环境
+	Environment string  `json:"environment" validate:"omitempty,max=32"`
+	IPv4Cidr    string  `json:"ipv4_cidr" validate:"required,cidrv4"`
+	Memo        *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate create cidr pool request.
+func (req *CreateCidrPoolReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.BkBizID != constant.UnassignedBiz && req.BkBizID <= 0 {
+		return fmt.Errorf("bk_biz_id should > 0 or be %d", constant.UnassignedBiz)
+	}
+
+	// 地址池网段必须是网络号，避免同一网段的不同写法绕过重叠检查
+	_, ipNet, err := net.ParseCIDR(req.IPv4Cidr)
+	if err != nil {
+		return err
+	}
+
+	if ipNet.String() != req.IPv4Cidr {
+		return fmt.Errorf("ipv4_cidr should be network address, e.g. %s", ipNet.String())
+	}
+
+	return nil
+}
+
+// UpdateCidrPoolReq define update cidr pool req, the cidr of pool can not be updated.
+type UpdateCidrPoolReq struct {
+	Name        string  `json:"name" validate:"omitempty,max=255"`
+	BkBizID     int64   `json:"bk_biz_id" validate:"omitempty"`
+	Region      *string `json:"region" validate:"omitempty,max=64"`
+	Environment *string `json:"environment" validate:"omitempty,max=32"`
+	Memo        *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate update cidr pool request.
+func (req *UpdateCidrPoolReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.BkBizID != 0 && req.BkBizID != constant.UnassignedBiz && req.BkBizID < 0 {
+		return fmt.Errorf("bk_biz_id should > 0 or be %d", constant.UnassignedBiz)
+	}
+
+	return nil
+}
+
+// AllocateCidrReq define reserve a cidr from cidr pool req, the reserved cidr can be used to create vpc manually.
+type AllocateCidrReq struct {
+	// MaskLen 需要分配的网段掩码长度
+	MaskLen   int           `json:"mask_len" validate:"required,min=8,max=29"`
+	Vendor    enumor.Vendor `json:"vendor" validate:"omitempty"`
+	AccountID string        `json:"account_id" validate:"omitempty"`
+	Region    string        `json:"region" validate:"omitempty"`
+	Memo      *string       `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate allocate cidr request.
+func (req *AllocateCidrReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Vendor) != 0 {
+		if err := req.Vendor.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AllocateCidrResult define allocate cidr result.
+type AllocateCidrResult struct {
+	// ID 分配记录ID，释放网段时使用
+	ID   string `json:"id"`
+	Cidr string `json:"cidr"`
+}
+
+// CheckCidrConflictReq define check whether cidrs conflict with synced vpcs and cidr pools req.
+type CheckCidrConflictReq struct {
+	Cidrs []string `json:"cidrs" validate:"required,min=1,dive,cidr"`
+}
+
+// Validate check cidr conflict request.
+func (req *CheckCidrConflictReq) Validate() error {
+	if len(req.Cidrs) > maxCheckCidrCount {
+		return fmt.Errorf("cidrs count should <= %d", maxCheckCidrCount)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// CidrConflict define vpcs and cidr pools overlapped with the cidr.
+type CidrConflict struct {
+	Cidr      string              `json:"cidr"`
+	Vpcs      []VpcCidr           `json:"vpcs"`
+	CidrPools []coreipam.CidrPool `json:"cidr_pools"`
+}
+
+// CheckCidrConflictResult define check cidr conflict result.
+type CheckCidrConflictResult struct {
+	Details []CidrConflict `json:"details"`
+}
+
+// ListVpcCidrOverlapReq define list overlapped vpc cidrs of all vendors req, overlaps which any side of them
+// matches the scope are returned, all overlaps are returned when scope is not set.
+type ListVpcCidrOverlapReq struct {
+	Vendors    []enumor.Vendor `json:"vendors" validate:"omitempty"`
+	AccountIDs []string        `json:"account_ids" validate:"omitempty"`
+	BkBizIDs   []int64         `json:"bk_biz_ids" validate:"omitempty"`
+}
+
+// Validate list vpc cidr overlap request.
+func (req *ListVpcCidrOverlapReq) Validate() error {
+	if len(req.Vendors) > constant.BatchOperationMaxLimit || len(req.AccountIDs) > constant.BatchOperationMaxLimit ||
+		len(req.BkBizIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("scope count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, vendor := range req.Vendors {
+		if err := vendor.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// VpcCidr define a cidr of synced vpc, gcp vpc has no cidr, so the cidrs of its subnets are used.
+type VpcCidr struct {
+	VpcID      string        `json:"vpc_id"`
+	CloudVpcID string        `json:"cloud_vpc_id"`
+	Name       string        `json:"name"`
+	Vendor     enumor.Vendor `json:"vendor"`
+	AccountID  string        `json:"account_id"`
+	Region     string        `json:"region"`
+	BkBizID    int64         `json:"bk_biz_id"`
+	Cidr       string        `json:"cidr"`
+}
+
+// VpcCidrOverlap define two overlapped cidrs of different vpcs, vpcs with overlapped cidrs can not be peered.
+type VpcCidrOverlap struct {
+	Vpc     VpcCidr `json:"vpc"`
+	PeerVpc VpcCidr `json:"peer_vpc"`
+}
+
+// VpcCidrOverlapResult define list vpc cidr overlap result.
+type VpcCidrOverlapResult struct {
+	Details []VpcCidrOverlap `json:"details"`
+}
+
+// CidrPoolUtilization define utilization of cidr pool.
+type CidrPoolUtilization struct {
+	PoolID   string `json:"pool_id"`
+	Name     string `json:"name"`
+	IPv4Cidr string `json:"ipv4_cidr"`
+	// TotalIPCount 地址池包含的IP数量，包括网络号和广播地址
+	TotalIPCount uint64 `json:"total_ip_count"`
+	// AllocatedIPCount 从地址池分配出去的网段包含的IP数量
+	AllocatedIPCount uint64 `json:"allocated_ip_count"`
+	// UsedIPCount 已分配网段以及未经地址池分配但落在地址池内的VPC网段覆盖的IP数量，重叠部分只计算一次
+	UsedIPCount uint64 `json:"used_ip_count"`
+	FreeIPCount uint64 `json:"free_ip_count"`
+	// UsageRate 使用率，为UsedIPCount/TotalIPCount，保留4位小数
+	UsageRate       float64 `json:"usage_rate"`
+	AllocationCount int     `json:"allocation_count"`
+	// VpcCount 网段与地址池重叠的VPC数量
+	VpcCount int `json:"vpc_count"`
+	// MaxFreeMaskLen 当前可分配的最大网段的掩码长度，为0时表示地址池已无可分配网段
+	MaxFreeMaskLen int `json:"max_free_mask_len"`
+}
+
+// CidrPoolUtilizationResult define list cidr pool utilization result.
+type CidrPoolUtilizationResult struct {
+	Details []CidrPoolUtilization `json:"details"`
+}
+
+// ErrCidrPoolExhausted is returned when there is no available cidr with required mask length in cidr pool.
+var ErrCidrPoolExhausted = errors.New("cidr pool has no available cidr with required mask length")
@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair defines ssh key pair cloud-server api.
+package keypair
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+)
+
+var (
+	// 各云厂商密钥对名称限制的交集，腾讯云最为严格：只能包含字母、数字和下划线，不超过25个字符
+	validKeyPairNameRegex   = regexp.MustCompile("^[a-zA-Z0-9_]{1,25}$")
+	keyPairNameInvalidError = errors.New("invalid key pair name: name should only contains letters(a-z, A-Z), " +
+		"numbers(0-9) or underline(_), length should be 1 to 25 letters")
+)
+
+func validateKeyPairName(name string) error {
+	if name != "" && !validKeyPairNameRegex.MatchString(name) {
+		return keyPairNameInvalidError
+	}
+	return nil
+}
+
+// KeyPairCreateReq define key pair create req.
+type KeyPairCreateReq struct {
+	Name string `json:"name" validate:"required"`
+	// PublicKey OpenSSH authorized_keys 格式的公钥，仅支持不少于2048位的RSA公钥
+	PublicKey string  `json:"public_key" validate:"required"`
+	Memo      *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate key pair create request.
+func (req *KeyPairCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return validateKeyPairName(req.Name)
+}
+
+// KeyPairUpdateReq define key pair update req, public key can not be changed after imported.
+type KeyPairUpdateReq struct {
+	Name string  `json:"name" validate:"omitempty"`
+	Memo *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate key pair update request.
+func (req *KeyPairUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return validateKeyPairName(req.Name)
+}
+
+// KeyPairPushReq define push key pair to cloud account region req.
+type KeyPairPushReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	Region    string `json:"region" validate:"required"`
+}
+
+// Validate key pair push request.
+func (req *KeyPairPushReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AssignCloudKeyPairToBizReq define assign cloud key pair to biz req.
+type AssignCloudKeyPairToBizReq struct {
+	BkBizID         int64    `json:"bk_biz_id" validate:"required"`
+	CloudKeyPairIDs []string `json:"cloud_key_pair_ids" validate:"required"`
+}
+
+// Validate assign cloud key pair to biz request.
+func (req *AssignCloudKeyPairToBizReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.BkBizID <= 0 {
+		return errors.New("bk_biz_id should > 0")
+	}
+
+	if len(req.CloudKeyPairIDs) == 0 {
+		return errors.New("cloud_key_pair_ids is required")
+	}
+
+	if len(req.CloudKeyPairIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("cloud_key_pair_ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
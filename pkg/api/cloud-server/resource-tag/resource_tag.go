@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines resource tag cloud-server api.
+package resourcetag
+
+import (
+	"fmt"
+
+	corertag "hcm/pkg/api/core/cloud/resource-tag"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// ResourceTagListReq defines list tags of resources request.
+type ResourceTagListReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs     []string                 `json:"ids" validate:"required,min=1"`
+}
+
+// Validate ResourceTagListReq.
+func (req *ResourceTagListReq) Validate() error {
+	if len(req.IDs) > int(filter.DefaultMaxInLimit) {
+		return fmt.Errorf("ids count should <= %d", filter.DefaultMaxInLimit)
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return corertag.ValidateTagResType(req.ResType)
+}
+
+// ResourceTagListResult defines list tags of resources result.
+type ResourceTagListResult struct {
+	Details []ResourceTags `json:"details"`
+}
+
+// ResourceTags defines tags of one resource.
+type ResourceTags struct {
+	ResID string         `json:"res_id"`
+	Tags  []corertag.Tag `json:"tags"`
+}
+
+// TagResourceReq defines add or overwrite tags of resources request, resources can belong to different vendors
+// and accounts.
+type TagResourceReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs     []string                 `json:"ids" validate:"required,min=1"`
+	Tags    []corertag.Tag           `json:"tags" validate:"required,min=1,dive"`
+}
+
+// Validate TagResourceReq.
+func (req *TagResourceReq) Validate() error {
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return corertag.ValidateTagResType(req.ResType)
+}
+
+// UntagResourceReq defines remove tags of resources by keys request.
+type UntagResourceReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs     []string                 `json:"ids" validate:"required,min=1"`
+	Keys    []string                 `json:"keys" validate:"required,min=1,dive,required,max=255"`
+}
+
+// Validate UntagResourceReq.
+func (req *UntagResourceReq) Validate() error {
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return corertag.ValidateTagResType(req.ResType)
+}
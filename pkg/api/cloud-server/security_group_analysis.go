@@ -0,0 +1,107 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/sgrule"
+)
+
+// -------------------------- Analyze --------------------------
+
+// SecurityGroupAnalyzeReq security group rule analyze request.
+type SecurityGroupAnalyzeReq struct {
+	SecurityGroupIDs []string `json:"security_group_ids" validate:"required,min=1"`
+}
+
+// Validate security group rule analyze request.
+func (req *SecurityGroupAnalyzeReq) Validate() error {
+	if len(req.SecurityGroupIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("security group ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// CvmSecurityGroupAnalyzeReq analyze security group rules of cvms request.
+type CvmSecurityGroupAnalyzeReq struct {
+	CvmIDs []string `json:"cvm_ids" validate:"required,min=1"`
+}
+
+// Validate analyze security group rules of cvms request.
+func (req *CvmSecurityGroupAnalyzeReq) Validate() error {
+	if len(req.CvmIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("cvm ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// SecurityGroupAnalysis is the rule analysis result of security group, gcp has no security group, its firewall
+// rules are analyzed by vpc, and res_type is gcp_firewall_rule, id is the id of vpc.
+type SecurityGroupAnalysis struct {
+	ResType     enumor.CloudResourceType `json:"res_type"`
+	ID          string                   `json:"id"`
+	CloudID     string                   `json:"cloud_id"`
+	Name        string                   `json:"name"`
+	Vendor      enumor.Vendor            `json:"vendor"`
+	AccountID   string                   `json:"account_id"`
+	Region      string                   `json:"region"`
+	BkBizID     int64                    `json:"bk_biz_id"`
+	RuleCount   int                      `json:"rule_count"`
+	Score       int                      `json:"score"`
+	MaxSeverity sgrule.Severity          `json:"max_severity"`
+	Findings    []sgrule.Finding         `json:"findings"`
+}
+
+// SecurityGroupAnalyzeResult security group rule analyze result.
+type SecurityGroupAnalyzeResult struct {
+	Details []SecurityGroupAnalysis `json:"details"`
+}
+
+// CvmSecurityGroupAnalysis is the rule analysis result of security groups bound to cvm, score is calculated by
+// findings of all the security groups.
+type CvmSecurityGroupAnalysis struct {
+	CvmID          string                  `json:"cvm_id"`
+	Score          int                     `json:"score"`
+	MaxSeverity    sgrule.Severity         `json:"max_severity"`
+	SecurityGroups []SecurityGroupAnalysis `json:"security_groups"`
+}
+
+// CvmSecurityGroupAnalyzeResult analyze security group rules of cvms result.
+type CvmSecurityGroupAnalyzeResult struct {
+	Details []CvmSecurityGroupAnalysis `json:"details"`
+}
+
+// BizSecurityGroupAnalysis is the rule analysis result of security groups in business, score is the highest score
+// of security groups, security groups are sorted by score in descending order.
+type BizSecurityGroupAnalysis struct {
+	BkBizID       int64                   `json:"bk_biz_id"`
+	Score         int                     `json:"score"`
+	MaxSeverity   sgrule.Severity         `json:"max_severity"`
+	SeverityCount map[sgrule.Severity]int `json:"severity_count"`
+	// RiskySecurityGroupCount 存在风险的安全组数量
+	RiskySecurityGroupCount int                     `json:"risky_security_group_count"`
+	SecurityGroups          []SecurityGroupAnalysis `json:"security_groups"`
+}
@@ -0,0 +1,205 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	"errors"
+	"fmt"
+
+	coresgt "hcm/pkg/api/core/cloud/sg-template"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/sgrule"
+)
+
+// -------------------------- Create --------------------------
+
+// SGTemplateCreateReq security group template create request.
+type SGTemplateCreateReq struct {
+	Name  string                 `json:"name" validate:"required,max=255"`
+	Rules []coresgt.TemplateRule `json:"rules" validate:"required,min=1,dive"`
+	Memo  *string                `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate security group template create request.
+func (req *SGTemplateCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return validateTemplateRules(req.Rules)
+}
+
+// -------------------------- Update --------------------------
+
+// SGTemplateUpdateReq security group template update request, linked security groups are not changed until
+// the template is pushed.
+type SGTemplateUpdateReq struct {
+	Name  string                 `json:"name" validate:"omitempty,max=255"`
+	Rules []coresgt.TemplateRule `json:"rules" validate:"omitempty,dive"`
+	Memo  *string                `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate security group template update request.
+func (req *SGTemplateUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return validateTemplateRules(req.Rules)
+}
+
+func validateTemplateRules(rules []coresgt.TemplateRule) error {
+	for index, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rules[%d] is invalid, err: %v", index, err)
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- Instantiate --------------------------
+
+// SGTemplateInstantiateReq create a security group on the account and region, and apply the template to it.
+type SGTemplateInstantiateReq struct {
+	SecurityGroupCreateReq `json:",inline"`
+}
+
+// Validate security group template instantiate request.
+func (req *SGTemplateInstantiateReq) Validate() error {
+	switch req.Vendor {
+	case enumor.TCloud, enumor.Aws, enumor.HuaWei, enumor.Azure:
+	default:
+		return fmt.Errorf("vendor: %s can not instantiate security group template, gcp should bind vpc instead",
+			req.Vendor)
+	}
+
+	return req.SecurityGroupCreateReq.Validate()
+}
+
+// SGTemplateInstantiateResult security group template instantiate result.
+type SGTemplateInstantiateResult struct {
+	SecurityGroupID string               `json:"security_group_id"`
+	RelID           string               `json:"rel_id"`
+	Push            SGTemplatePushResult `json:"push"`
+}
+
+// -------------------------- Bind --------------------------
+
+// SGTemplateBindReq link existing security groups or gcp vpcs to the template, rules are not changed until
+// the template is pushed.
+type SGTemplateBindReq struct {
+	SecurityGroupIDs []string         `json:"security_group_ids" validate:"omitempty"`
+	GcpVpcs          []GcpVpcTemplate `json:"gcp_vpcs" validate:"omitempty,dive"`
+}
+
+// GcpVpcTemplate gcp has no security group, template is applied as firewall rules of the vpc.
+type GcpVpcTemplate struct {
+	VpcID string `json:"vpc_id" validate:"required"`
+	// TargetTag 防火墙规则的目标网络标记，为空表示应用到VPC下所有实例
+	TargetTag string `json:"target_tag" validate:"omitempty,max=63"`
+}
+
+// Validate security group template bind request.
+func (req *SGTemplateBindReq) Validate() error {
+	if len(req.SecurityGroupIDs)+len(req.GcpVpcs) == 0 {
+		return errors.New("security_group_ids or gcp_vpcs is required")
+	}
+
+	if len(req.SecurityGroupIDs)+len(req.GcpVpcs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("security groups and gcp vpcs should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Unbind --------------------------
+
+// SGTemplateUnbindReq unlink security groups or gcp vpcs from the template, rules created by the template are
+// kept on the cloud.
+type SGTemplateUnbindReq struct {
+	RelIDs []string `json:"rel_ids" validate:"required,min=1"`
+}
+
+// Validate security group template unbind request.
+func (req *SGTemplateUnbindReq) Validate() error {
+	if len(req.RelIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("rel ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Push --------------------------
+
+// SGTemplatePushReq preview or push the template to linked resources, empty rel ids means all linked resources.
+type SGTemplatePushReq struct {
+	RelIDs []string `json:"rel_ids" validate:"omitempty"`
+}
+
+// Validate security group template push request.
+func (req *SGTemplatePushReq) Validate() error {
+	if len(req.RelIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("rel ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// SGTemplateRelDiff is the difference between the template and the rules of a linked resource.
+type SGTemplateRelDiff struct {
+	RelID   string                   `json:"rel_id"`
+	Vendor  enumor.Vendor            `json:"vendor"`
+	ResType enumor.CloudResourceType `json:"res_type"`
+	ResID   string                   `json:"res_id"`
+	// Version 该资源已同步的模版版本
+	Version uint64 `json:"version"`
+	// Added 推送时需要新增的规则，为对应云厂商转换后的规则
+	Added []sgrule.Rule `json:"added"`
+	// Removed 推送时需要删除的规则
+	Removed   []sgrule.Rule `json:"removed"`
+	Unchanged int           `json:"unchanged"`
+	// Error 模版无法转换为该云厂商的规则或获取规则失败时的错误信息
+	Error string `json:"error,omitempty"`
+}
+
+// SGTemplatePreviewResult security group template push preview result.
+type SGTemplatePreviewResult struct {
+	TemplateVersion uint64              `json:"template_version"`
+	Details         []SGTemplateRelDiff `json:"details"`
+}
+
+// SGTemplateRelPushResult is the push result of a linked resource.
+type SGTemplateRelPushResult struct {
+	RelID   string                   `json:"rel_id"`
+	ResType enumor.CloudResourceType `json:"res_type"`
+	ResID   string                   `json:"res_id"`
+	Added   int                      `json:"added"`
+	Removed int                      `json:"removed"`
+	Error   string                   `json:"error,omitempty"`
+}
+
+// SGTemplatePushResult security group template push result.
+type SGTemplatePushResult struct {
+	TemplateVersion uint64                    `json:"template_version"`
+	Details         []SGTemplateRelPushResult `json:"details"`
+}
@@ -0,0 +1,149 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot defines disk snapshot cloud-server api.
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/cron"
+)
+
+// AssignSnapshotToBizReq define assign snapshot to biz req.
+type AssignSnapshotToBizReq struct {
+	BkBizID     int64    `json:"bk_biz_id" validate:"required"`
+	SnapshotIDs []string `json:"snapshot_ids" validate:"required"`
+}
+
+// Validate assign snapshot to biz request.
+func (req *AssignSnapshotToBizReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.BkBizID <= 0 {
+		return errors.New("bk_biz_id should > 0")
+	}
+
+	if len(req.SnapshotIDs) == 0 {
+		return errors.New("snapshot_ids is required")
+	}
+
+	if len(req.SnapshotIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("snapshot_ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// SnapshotPolicyMaxRetentionCount 快照策略每块云盘最多保留的快照个数
+const SnapshotPolicyMaxRetentionCount = 100
+
+// SnapshotPolicyCreateReq define snapshot policy create req.
+type SnapshotPolicyCreateReq struct {
+	Name  string                     `json:"name" validate:"required,max=255"`
+	Scope enumor.SnapshotPolicyScope `json:"scope" validate:"required"`
+	// DiskIDs 作用范围为云盘时，指定策略作用的云盘ID，作用范围为业务时不可指定
+	DiskIDs []string `json:"disk_ids" validate:"omitempty"`
+	// Schedule 标准5段式 cron 表达式：分 时 日 月 周
+	Schedule       string  `json:"schedule" validate:"required"`
+	RetentionCount uint64  `json:"retention_count" validate:"required,min=1"`
+	Enabled        bool    `json:"enabled" validate:"omitempty"`
+	Memo           *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate snapshot policy create request.
+func (req *SnapshotPolicyCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := ValidateSnapshotPolicyScope(req.Scope, req.DiskIDs); err != nil {
+		return err
+	}
+
+	if _, err := cron.Parse(req.Schedule); err != nil {
+		return fmt.Errorf("schedule is invalid, err: %v", err)
+	}
+
+	if req.RetentionCount > SnapshotPolicyMaxRetentionCount {
+		return fmt.Errorf("retention_count should <= %d", SnapshotPolicyMaxRetentionCount)
+	}
+
+	return nil
+}
+
+// SnapshotPolicyUpdateReq define snapshot policy update req, only the specified fields are updated.
+type SnapshotPolicyUpdateReq struct {
+	Name           string                     `json:"name" validate:"omitempty,max=255"`
+	Scope          enumor.SnapshotPolicyScope `json:"scope" validate:"omitempty"`
+	DiskIDs        []string                   `json:"disk_ids" validate:"omitempty"`
+	Schedule       string                     `json:"schedule" validate:"omitempty"`
+	RetentionCount uint64                     `json:"retention_count" validate:"omitempty"`
+	Enabled        *bool                      `json:"enabled" validate:"omitempty"`
+	Memo           *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate snapshot policy update request.
+func (req *SnapshotPolicyUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Schedule) != 0 {
+		if _, err := cron.Parse(req.Schedule); err != nil {
+			return fmt.Errorf("schedule is invalid, err: %v", err)
+		}
+	}
+
+	if req.RetentionCount > SnapshotPolicyMaxRetentionCount {
+		return fmt.Errorf("retention_count should <= %d", SnapshotPolicyMaxRetentionCount)
+	}
+
+	return nil
+}
+
+// ValidateSnapshotPolicyScope validate disk ids of snapshot policy matches its scope.
+func ValidateSnapshotPolicyScope(scope enumor.SnapshotPolicyScope, diskIDs []string) error {
+	if err := scope.Validate(); err != nil {
+		return err
+	}
+
+	switch scope {
+	case enumor.DiskSnapshotPolicyScope:
+		if len(diskIDs) == 0 {
+			return errors.New("disk_ids is required when scope is disk")
+		}
+
+		if len(diskIDs) > constant.BatchOperationMaxLimit {
+			return fmt.Errorf("disk_ids should <= %d", constant.BatchOperationMaxLimit)
+		}
+	case enumor.BizSnapshotPolicyScope:
+		if len(diskIDs) != 0 {
+			return errors.New("disk_ids is not allowed when scope is biz")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package vpcpeering defines vpc peering cloud-server api.
+package vpcpeering
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// AssignVpcPeeringToBizReq define assign vpc peering to biz req.
+type AssignVpcPeeringToBizReq struct {
+	BkBizID       int64    `json:"bk_biz_id" validate:"required"`
+	VpcPeeringIDs []string `json:"vpc_peering_ids" validate:"required"`
+}
+
+// Validate assign vpc peering to biz request.
+func (req *AssignVpcPeeringToBizReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.BkBizID <= 0 {
+		return errors.New("bk_biz_id should > 0")
+	}
+
+	if len(req.VpcPeeringIDs) == 0 {
+		return errors.New("vpc_peering_ids is required")
+	}
+
+	if len(req.VpcPeeringIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("vpc_peering_ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// SubnetConnectivityCheckReq define check whether source subnet can reach destination subnet req.
+type SubnetConnectivityCheckReq struct {
+	SrcSubnetID string `json:"src_subnet_id" validate:"required"`
+	DstSubnetID string `json:"dst_subnet_id" validate:"required"`
+}
+
+// Validate subnet connectivity check request.
+func (req *SubnetConnectivityCheckReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// SubnetConnectivityResult define subnet connectivity check result, it's calculated by synced vpc peerings and
+// routes, so it may be out of date before next sync.
+type SubnetConnectivityResult struct {
+	Reachable bool `json:"reachable"`
+	// Reason 无需经过连接路径即可得出结论时的说明，如两个子网属于同一VPC、不同云厂商或不存在任何连接路径
+	Reason string             `json:"reason,omitempty"`
+	Paths  []ConnectivityPath `json:"paths"`
+}
+
+// ConnectivityPath define a path connecting vpcs of source and destination subnet.
+type ConnectivityPath struct {
+	Type enumor.VpcPeeringType `json:"type"`
+	// CloudGatewayID 路径经过的对等连接/中转网关/云联网的云上ID
+	CloudGatewayID string `json:"cloud_gateway_id"`
+	// VpcPeeringIDs 构成路径的对等连接记录，对等连接为一条记录，中转网关/云联网为两端VPC各自的关联记录
+	VpcPeeringIDs []string `json:"vpc_peering_ids"`
+	Reachable     bool     `json:"reachable"`
+	// Reasons 路径各环节的检查结论，如路由缺失、下一跳不是该连接等
+	Reasons []string `json:"reasons"`
+}
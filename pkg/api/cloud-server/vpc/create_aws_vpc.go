@@ -31,12 +31,14 @@ type AwsVpcCreateReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
 	Name      string `json:"name" validate:"required,min=1,max=60"`
-	IPv4Cidr  string `json:"ipv4_cidr" validate:"required,cidrv4"`
+	IPv4Cidr  string `json:"ipv4_cidr" validate:"omitempty,cidrv4"`
 	BkCloudID int64  `json:"bk_cloud_id" validate:"required,min=1"`
 
 	InstanceTenancy string `json:"instance_tenancy" validate:"required,oneof=default dedicated"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
+
+	CidrPoolOption `json:",inline"`
 }
 
 // Validate ...
@@ -49,5 +51,9 @@ func (req *AwsVpcCreateReq) Validate(bizRequired bool) error {
 		return errors.New("bk_biz_id is required")
 	}
 
+	if err := req.CidrPoolOption.validate(req.IPv4Cidr, nil); err != nil {
+		return err
+	}
+
 	return nil
 }
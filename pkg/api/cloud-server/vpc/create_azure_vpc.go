@@ -34,15 +34,17 @@ type AzureVpcCreateReq struct {
 	ResourceGroupName string `json:"resource_group_name" validate:"required,lowercase"`
 	Region            string `json:"region" validate:"required,lowercase"`
 	Name              string `json:"name" validate:"required,min=1,max=60,lowercase"`
-	IPv4Cidr          string `json:"ipv4_cidr" validate:"required,cidrv4"`
+	IPv4Cidr          string `json:"ipv4_cidr" validate:"omitempty,cidrv4"`
 	BkCloudID         int64  `json:"bk_cloud_id" validate:"required,min=1"`
 
 	Subnet struct {
 		Name     string `json:"name" validate:"required,min=1,max=60,lowercase"`
-		IPv4Cidr string `json:"ipv4_cidr" validate:"required,cidrv4"`
+		IPv4Cidr string `json:"ipv4_cidr" validate:"omitempty,cidrv4"`
 	} `json:"subnet" validate:"required"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
+
+	CidrPoolOption `json:",inline"`
 }
 
 // Validate ...
@@ -55,6 +57,10 @@ func (req *AzureVpcCreateReq) Validate(bizRequired bool) error {
 		return errors.New("bk_biz_id is required")
 	}
 
+	if err := req.CidrPoolOption.validate(req.IPv4Cidr, &req.Subnet.IPv4Cidr); err != nil {
+		return err
+	}
+
 	// region can be no space lowercase
 	if !assert.IsSameCaseNoSpaceString(req.Region) {
 		return errf.New(errf.InvalidParameter, "region can only be lowercase")
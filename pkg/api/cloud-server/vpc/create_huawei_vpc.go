@@ -31,17 +31,19 @@ type HuaWeiVpcCreateReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
 	Name      string `json:"name" validate:"required,min=1,max=60"`
-	IPv4Cidr  string `json:"ipv4_cidr" validate:"required,cidrv4"`
+	IPv4Cidr  string `json:"ipv4_cidr" validate:"omitempty,cidrv4"`
 	BkCloudID int64  `json:"bk_cloud_id" validate:"required,min=1"`
 
 	Subnet struct {
 		Name       string `json:"name" validate:"required,min=1,max=60"`
-		IPv4Cidr   string `json:"ipv4_cidr" validate:"required,cidrv4"`
+		IPv4Cidr   string `json:"ipv4_cidr" validate:"omitempty,cidrv4"`
 		IPv6Enable *bool  `json:"ipv6_enable" validate:"required"`
-		GatewayIP  string `json:"gateway_ip" validate:"required"`
+		GatewayIP  string `json:"gateway_ip" validate:"omitempty"`
 	} `json:"subnet" validate:"required"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
+
+	CidrPoolOption `json:",inline"`
 }
 
 // Validate ...
@@ -54,5 +56,14 @@ func (req *HuaWeiVpcCreateReq) Validate(bizRequired bool) error {
 		return errors.New("bk_biz_id is required")
 	}
 
+	if err := req.CidrPoolOption.validate(req.IPv4Cidr, &req.Subnet.IPv4Cidr); err != nil {
+		return err
+	}
+
+	// 使用地址池时子网网关为分配的子网网段的第一个地址
+	if !req.UseCidrPool() && len(req.Subnet.GatewayIP) == 0 {
+		return errors.New("subnet.gateway_ip is required")
+	}
+
 	return nil
 }
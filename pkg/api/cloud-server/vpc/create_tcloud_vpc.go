@@ -31,16 +31,18 @@ type TCloudVpcCreateReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
 	Name      string `json:"name" validate:"required,min=1,max=60"`
-	IPv4Cidr  string `json:"ipv4_cidr" validate:"required,cidrv4"`
+	IPv4Cidr  string `json:"ipv4_cidr" validate:"omitempty,cidrv4"`
 	BkCloudID int64  `json:"bk_cloud_id" validate:"required,min=1"`
 
 	Subnet struct {
 		Name     string `json:"name" validate:"required,min=1,max=60"`
-		IPv4Cidr string `json:"ipv4_cidr" validate:"required,cidrv4"`
+		IPv4Cidr string `json:"ipv4_cidr" validate:"omitempty,cidrv4"`
 		Zone     string `json:"zone" validate:"required"`
 	} `json:"subnet" validate:"required"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
+
+	CidrPoolOption `json:",inline"`
 }
 
 // Validate ...
@@ -53,5 +55,9 @@ func (req *TCloudVpcCreateReq) Validate(bizRequired bool) error {
 		return errors.New("bk_biz_id is required")
 	}
 
+	if err := req.CidrPoolOption.validate(req.IPv4Cidr, &req.Subnet.IPv4Cidr); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -20,12 +20,58 @@
 package csvpc
 
 import (
+	"errors"
+
 	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/rest"
 )
 
+// -------------------------- Create --------------------------
+
+// CidrPoolOption defines the option to allocate vpc cidr from ipam cidr pool, ipv4 cidr of vpc and subnet
+// should not be set when cidr pool is used, the subnet takes the first cidr of subnet mask length in vpc cidr.
+type CidrPoolOption struct {
+	CidrPoolID    string `json:"cidr_pool_id" validate:"omitempty"`
+	VpcMaskLen    int    `json:"vpc_mask_len" validate:"omitempty,min=8,max=28"`
+	SubnetMaskLen int    `json:"subnet_mask_len" validate:"omitempty,min=8,max=29"`
+}
+
+// UseCidrPool returns whether vpc cidr is allocated from cidr pool.
+func (opt CidrPoolOption) UseCidrPool() bool {
+	return len(opt.CidrPoolID) != 0
+}
+
+// validate vpc cidr and subnet cidr with cidr pool option, subnetCidr is nil if vpc is created without subnet.
+func (opt CidrPoolOption) validate(vpcCidr string, subnetCidr *string) error {
+	if !opt.UseCidrPool() {
+		if len(vpcCidr) == 0 {
+			return errors.New("ipv4_cidr or cidr_pool_id is required")
+		}
+
+		if subnetCidr != nil && len(*subnetCidr) == 0 {
+			return errors.New("subnet.ipv4_cidr is required")
+		}
+
+		return nil
+	}
+
+	if len(vpcCidr) != 0 || (subnetCidr != nil && len(*subnetCidr) != 0) {
+		return errors.New("ipv4_cidr can not be set when cidr_pool_id is set")
+	}
+
+	if opt.VpcMaskLen == 0 {
+		return errors.New("vpc_mask_len is required when cidr_pool_id is set")
+	}
+
+	if subnetCidr != nil && opt.SubnetMaskLen < opt.VpcMaskLen {
+		return errors.New("subnet_mask_len is required and should >= vpc_mask_len when cidr_pool_id is set")
+	}
+
+	return nil
+}
+
 // -------------------------- Update --------------------------
 
 // VpcUpdateReq defines update vpc request.
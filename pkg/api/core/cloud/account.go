@@ -25,6 +25,16 @@ import (
 	"hcm/pkg/cryptography"
 )
 
+// AccountSecretKeyFieldMap 各云账号Extension中需要加密存储的密钥字段
+var AccountSecretKeyFieldMap = map[enumor.Vendor]string{
+	enumor.TCloud:    "cloud_secret_key",
+	enumor.Aws:       "cloud_secret_key",
+	enumor.HuaWei:    "cloud_secret_key",
+	enumor.Gcp:       "cloud_service_secret_key",
+	enumor.Azure:     "cloud_client_secret_key",
+	enumor.OpenStack: "cloud_secret_key",
+}
+
 // BaseAccount 云账号
 type BaseAccount struct {
 	ID            string                 `json:"id"`
@@ -152,3 +162,26 @@ func (e *AzureAccountExtension) DecryptSecretKey(cipher cryptography.Crypto) err
 
 	return nil
 }
+
+// OpenStackAccountExtension define openstack account extension, account is authenticated by keystone v3
+// application credential which is scoped to the project.
+type OpenStackAccountExtension struct {
+	CloudAuthUrl     string `json:"cloud_auth_url"`
+	CloudProjectID   string `json:"cloud_project_id"`
+	CloudProjectName string `json:"cloud_project_name"`
+	CloudSecretID    string `json:"cloud_secret_id"`
+	CloudSecretKey   string `json:"cloud_secret_key,omitempty"`
+}
+
+// DecryptSecretKey ...
+func (e *OpenStackAccountExtension) DecryptSecretKey(cipher cryptography.Crypto) error {
+	if e.CloudSecretKey != "" {
+		plainSecretKey, err := cipher.DecryptFromBase64(e.CloudSecretKey)
+		if err != nil {
+			return err
+		}
+		e.CloudSecretKey = plainSecretKey
+	}
+
+	return nil
+}
@@ -64,3 +64,71 @@ type AwsBillConfigExtension struct {
 // GcpBillConfigExtension define gcp bill config extension.
 type GcpBillConfigExtension struct {
 }
+
+// BillItem define bill item.
+type BillItem struct {
+	ID             string          `json:"id"`
+	Vendor         enumor.Vendor   `json:"vendor"`
+	AccountID      string          `json:"account_id"`
+	BillMonth      string          `json:"bill_month"`
+	ProductCode    string          `json:"product_code"`
+	ProductName    string          `json:"product_name"`
+	CloudResID     string          `json:"cloud_res_id"`
+	ResName        string          `json:"res_name"`
+	Region         string          `json:"region"`
+	Cost           string          `json:"cost"`
+	Currency       string          `json:"currency"`
+	Extension      types.JsonField `json:"extension"`
+	*core.Revision `json:",inline"`
+}
+
+// BillCostAllocation define bill cost summary allocated by biz, vendor and resource type.
+type BillCostAllocation struct {
+	// BkBizID 分摊到的业务ID，未分配业务或未关联到本地资源的费用为-1
+	BkBizID int64         `json:"bk_biz_id"`
+	Vendor  enumor.Vendor `json:"vendor"`
+	// ResType 资源类型，未关联到本地资源的费用为空
+	ResType   string `json:"res_type"`
+	Currency  string `json:"currency"`
+	Cost      string `json:"cost"`
+	ItemCount uint64 `json:"item_count"`
+}
+
+// ExchangeRate define exchange rate.
+type ExchangeRate struct {
+	ID             string `json:"id"`
+	FromCurrency   string `json:"from_currency"`
+	ToCurrency     string `json:"to_currency"`
+	Month          string `json:"month"`
+	Rate           string `json:"rate"`
+	*core.Revision `json:",inline"`
+}
+
+// Budget define budget.
+type Budget struct {
+	ID             string                 `json:"id"`
+	Name           string                 `json:"name"`
+	ScopeType      enumor.BudgetScopeType `json:"scope_type"`
+	ScopeValue     string                 `json:"scope_value"`
+	Amount         string                 `json:"amount"`
+	Currency       string                 `json:"currency"`
+	Thresholds     []int64                `json:"thresholds"`
+	Memo           *string                `json:"memo"`
+	*core.Revision `json:",inline"`
+}
+
+// BudgetAlert define budget alert event.
+type BudgetAlert struct {
+	ID             string                    `json:"id"`
+	BudgetID       string                    `json:"budget_id"`
+	ScopeType      enumor.BudgetScopeType    `json:"scope_type"`
+	ScopeValue     string                    `json:"scope_value"`
+	BillMonth      string                    `json:"bill_month"`
+	Threshold      uint64                    `json:"threshold"`
+	Amount         string                    `json:"amount"`
+	Spend          string                    `json:"spend"`
+	Currency       string                    `json:"currency"`
+	NotifyStatus   enumor.BudgetNotifyStatus `json:"notify_status"`
+	NotifyMsg      string                    `json:"notify_msg"`
+	*core.Revision `json:",inline"`
+}
@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bucket defines object storage bucket core types.
+package bucket
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Bucket define object storage bucket, cos/s3/blob container/gcs/obs are all treated as bucket.
+type Bucket struct {
+	ID                string        `json:"id"`
+	Vendor            enumor.Vendor `json:"vendor"`
+	AccountID         string        `json:"account_id"`
+	Region            string        `json:"region"`
+	CloudID           string        `json:"cloud_id"`
+	Name              string        `json:"name"`
+	BkBizID           int64         `json:"bk_biz_id"`
+	Encrypted         bool          `json:"encrypted"`
+	EncryptionType    string        `json:"encryption_type"`
+	VersioningEnabled bool          `json:"versioning_enabled"`
+	PublicRead        bool          `json:"public_read"`
+	PublicWrite       bool          `json:"public_write"`
+	// PublicAccessReasons 存储桶可被公开访问的原因，为空表示不可公开访问
+	PublicAccessReasons []string `json:"public_access_reasons"`
+	CloudCreatedTime    string   `json:"cloud_created_time"`
+	*core.Revision      `json:",inline"`
+}
+
+// GetID ...
+func (b Bucket) GetID() string {
+	return b.ID
+}
+
+// GetCloudID ...
+func (b Bucket) GetCloudID() string {
+	return b.CloudID
+}
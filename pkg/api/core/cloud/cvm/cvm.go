@@ -57,6 +57,7 @@ type BaseCvm struct {
 		gcp: PROVISIONING, STAGING, RUNNING, STOPPING, SUSPENDING, SUSPENDED, REPAIRING, and TERMINATED
 		aws: pending | running | shutting-down | terminated | stopping | stopped
 		azure：PowerState/running｜PowerState/stopped｜PowerState/deallocating｜PowerState/deallocated
+		openstack: ACTIVE、BUILD、SHUTOFF、PAUSED、SUSPENDED、SHELVED、REBOOT、HARD_REBOOT、ERROR、SOFT_DELETED 等
 	*/
 	Status string `json:"status"`
 
@@ -94,5 +95,6 @@ func (cvm Cvm[T]) GetCloudID() string {
 
 // Extension cvm extension.
 type Extension interface {
-	TCloudCvmExtension | AwsCvmExtension | HuaWeiCvmExtension | AzureCvmExtension | GcpCvmExtension
+	TCloudCvmExtension | AwsCvmExtension | HuaWeiCvmExtension | AzureCvmExtension | GcpCvmExtension |
+		OpenStackCvmExtension
 }
@@ -0,0 +1,35 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+// OpenStackCvmExtension cvm extension.
+type OpenStackCvmExtension struct {
+	// CloudFlavorID 云服务器规格ID，规格名称记录在 machine_type 中。
+	CloudFlavorID string `json:"cloud_flavor_id,omitempty"`
+	// KeyName 云服务器绑定的密钥对名称。
+	KeyName string `json:"key_name,omitempty"`
+	// CloudHostID 云服务器所在宿主机在项目内的唯一标识。
+	CloudHostID string `json:"cloud_host_id,omitempty"`
+	// BootFromVolume 是否从云硬盘启动，从云硬盘启动的云服务器没有镜像信息。
+	BootFromVolume        bool              `json:"boot_from_volume,omitempty"`
+	CloudSecurityGroupIDs []string          `json:"cloud_security_group_ids,omitempty"`
+	CloudVolumeIDs        []string          `json:"cloud_volume_ids,omitempty"`
+	Metadata              map[string]string `json:"metadata,omitempty"`
+}
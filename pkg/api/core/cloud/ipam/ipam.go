@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ipam defines ipam cidr pool and cidr allocation core types.
+package ipam
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// CidrPool define ipam cidr pool, which plans the address space used by vpcs of business, region and environment.
+type CidrPool struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// BkBizID 地址池所属业务ID，-1表示不限业务
+	BkBizID int64 `json:"bk_biz_id"`
+	// Region 地址池所属地域，为空表示不限地域
+	Region string `json:"region"`
+	// Environment 地址池所属环境，为空表示不限环境
+	Environment    string  `json:"environment"`
+	IPv4Cidr       string  `json:"ipv4_cidr"`
+	Memo           *string `json:"memo"`
+	*core.Revision `json:",inline"`
+}
+
+// CidrAllocation define cidr allocated from ipam cidr pool.
+type CidrAllocation struct {
+	ID        string                      `json:"id"`
+	PoolID    string                      `json:"pool_id"`
+	Cidr      string                      `json:"cidr"`
+	Status    enumor.CidrAllocationStatus `json:"status"`
+	Vendor    enumor.Vendor               `json:"vendor"`
+	AccountID string                      `json:"account_id"`
+	Region    string                      `json:"region"`
+	// VpcID 使用该网段的VPC的ID，预留状态时为空
+	VpcID          string  `json:"vpc_id"`
+	Memo           *string `json:"memo"`
+	*core.Revision `json:",inline"`
+}
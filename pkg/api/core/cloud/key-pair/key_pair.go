@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair defines hcm managed ssh key pair and cloud key pair core types.
+package keypair
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// KeyPair define hcm managed ssh key pair, which is imported once and pushed to cloud on demand.
+type KeyPair struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	BkBizID int64  `json:"bk_biz_id"`
+	// PublicKey OpenSSH authorized_keys 格式的公钥
+	PublicKey string `json:"public_key"`
+	// Fingerprint 公钥的 SHA256 指纹
+	Fingerprint    string  `json:"fingerprint"`
+	Memo           *string `json:"memo"`
+	*core.Revision `json:",inline"`
+}
+
+// CloudKeyPair define key pair on cloud.
+type CloudKeyPair struct {
+	ID          string        `json:"id"`
+	Vendor      enumor.Vendor `json:"vendor"`
+	AccountID   string        `json:"account_id"`
+	Region      string        `json:"region"`
+	CloudID     string        `json:"cloud_id"`
+	Name        string        `json:"name"`
+	Fingerprint string        `json:"fingerprint"`
+	// KeyPairID 由 HCM 密钥对推送到云上时关联的 HCM 密钥对ID，云上已存在的密钥对为空
+	KeyPairID        string `json:"key_pair_id"`
+	BkBizID          int64  `json:"bk_biz_id"`
+	CloudCreatedTime string `json:"cloud_created_time"`
+	*core.Revision   `json:",inline"`
+}
+
+// GetID ...
+func (kp CloudKeyPair) GetID() string {
+	return kp.ID
+}
+
+// GetCloudID ...
+func (kp CloudKeyPair) GetCloudID() string {
+	return kp.CloudID
+}
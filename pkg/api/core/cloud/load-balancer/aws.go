@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+// AwsLoadBalancerExtension aws load balancer extension.
+type AwsLoadBalancerExtension struct {
+	// Type application | network | gateway
+	Type *string `json:"type,omitempty"`
+	// Scheme internet-facing | internal
+	Scheme                *string  `json:"scheme,omitempty"`
+	IpAddressType         *string  `json:"ip_address_type,omitempty"`
+	CanonicalHostedZoneID *string  `json:"canonical_hosted_zone_id,omitempty"`
+	CloudSubnetIDs        []string `json:"cloud_subnet_ids,omitempty"`
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids,omitempty"`
+}
+
+// AwsListenerExtension aws load balancer listener extension.
+type AwsListenerExtension struct {
+	// CloudTargetGroupArns 默认转发动作关联的目标组
+	CloudTargetGroupArns []string `json:"cloud_target_group_arns,omitempty"`
+	SslPolicy            *string  `json:"ssl_policy,omitempty"`
+	CertificateArns      []string `json:"certificate_arns,omitempty"`
+}
+
+// AwsTargetExtension aws load balancer target extension.
+type AwsTargetExtension struct {
+	CloudTargetGroupArn *string `json:"cloud_target_group_arn,omitempty"`
+	AvailabilityZone    *string `json:"availability_zone,omitempty"`
+	// HealthState initial | healthy | unhealthy | unused | draining | unavailable
+	HealthState *string `json:"health_state,omitempty"`
+}
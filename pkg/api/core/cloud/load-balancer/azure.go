@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+// AzureLoadBalancerExtension azure load balancer extension.
+type AzureLoadBalancerExtension struct {
+	ResourceGroupName string `json:"resource_group_name,omitempty"`
+	// SkuName Basic | Standard | Gateway
+	SkuName *string `json:"sku_name,omitempty"`
+	// SkuTier Regional | Global
+	SkuTier                    *string                        `json:"sku_tier,omitempty"`
+	FrontendIPConfigurations   []AzureFrontendIPConfiguration `json:"frontend_ip_configurations,omitempty"`
+	CloudBackendAddressPoolIDs []string                       `json:"cloud_backend_address_pool_ids,omitempty"`
+}
+
+// AzureFrontendIPConfiguration azure load balancer frontend ip configuration.
+type AzureFrontendIPConfiguration struct {
+	CloudID          *string `json:"cloud_id,omitempty"`
+	Name             *string `json:"name,omitempty"`
+	PrivateIPAddress *string `json:"private_ip_address,omitempty"`
+	CloudPublicIPID  *string `json:"cloud_public_ip_id,omitempty"`
+	CloudSubnetID    *string `json:"cloud_subnet_id,omitempty"`
+}
+
+// AzureListenerExtension azure load balancer listener extension, listener corresponds to load balancing rule.
+type AzureListenerExtension struct {
+	CloudFrontendIPConfigurationID *string `json:"cloud_frontend_ip_configuration_id,omitempty"`
+	CloudBackendAddressPoolID      *string `json:"cloud_backend_address_pool_id,omitempty"`
+	CloudProbeID                   *string `json:"cloud_probe_id,omitempty"`
+	// LoadDistribution Default | SourceIP | SourceIPProtocol
+	LoadDistribution     *string `json:"load_distribution,omitempty"`
+	IdleTimeoutInMinutes *int32  `json:"idle_timeout_in_minutes,omitempty"`
+	EnableFloatingIP     *bool   `json:"enable_floating_ip,omitempty"`
+}
+
+// AzureTargetExtension azure load balancer target extension, target corresponds to backend address.
+type AzureTargetExtension struct {
+	CloudBackendAddressPoolID *string `json:"cloud_backend_address_pool_id,omitempty"`
+	// CloudIPConfigurationID 后端地址关联的网络接口IP配置ID
+	CloudIPConfigurationID *string `json:"cloud_ip_configuration_id,omitempty"`
+}
@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+// GcpLoadBalancerExtension gcp load balancer extension, load balancer corresponds to regional forwarding rule.
+type GcpLoadBalancerExtension struct {
+	SelfLink *string `json:"self_link,omitempty"`
+	// LoadBalancingScheme EXTERNAL | EXTERNAL_MANAGED | INTERNAL | INTERNAL_MANAGED
+	LoadBalancingScheme *string `json:"load_balancing_scheme,omitempty"`
+	IPProtocol          *string `json:"ip_protocol,omitempty"`
+	NetworkTier         *string `json:"network_tier,omitempty"`
+	// CloudTarget 转发目标，目标池或后端服务的 self link
+	CloudTarget         *string `json:"cloud_target,omitempty"`
+	CloudBackendService *string `json:"cloud_backend_service,omitempty"`
+	CloudSubnetwork     *string `json:"cloud_subnetwork,omitempty"`
+}
+
+// GcpListenerExtension gcp load balancer listener extension.
+type GcpListenerExtension struct {
+	PortRange *string  `json:"port_range,omitempty"`
+	Ports     []string `json:"ports,omitempty"`
+}
+
+// GcpTargetExtension gcp load balancer target extension.
+type GcpTargetExtension struct {
+	Zone *string `json:"zone,omitempty"`
+	// CloudTargetPool 所属目标池或实例组的 self link
+	CloudTargetPool *string `json:"cloud_target_pool,omitempty"`
+}
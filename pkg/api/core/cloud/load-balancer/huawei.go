@@ -0,0 +1,49 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+// HuaWeiLoadBalancerExtension huawei load balancer extension.
+type HuaWeiLoadBalancerExtension struct {
+	// Provider 负载均衡器的生产者名称，固定为 vlb
+	Provider *string `json:"provider,omitempty"`
+	// Guaranteed 是否独享型负载均衡
+	Guaranteed          *bool    `json:"guaranteed,omitempty"`
+	CloudVipSubnetID    *string  `json:"cloud_vip_subnet_id,omitempty"`
+	CloudVipPortID      *string  `json:"cloud_vip_port_id,omitempty"`
+	L4FlavorID          *string  `json:"l4_flavor_id,omitempty"`
+	L7FlavorID          *string  `json:"l7_flavor_id,omitempty"`
+	CloudEipIDs         []string `json:"cloud_eip_ids,omitempty"`
+	EnterpriseProjectID *string  `json:"enterprise_project_id,omitempty"`
+}
+
+// HuaWeiListenerExtension huawei load balancer listener extension.
+type HuaWeiListenerExtension struct {
+	CloudDefaultPoolID *string `json:"cloud_default_pool_id,omitempty"`
+	ConnectionLimit    *int32  `json:"connection_limit,omitempty"`
+	Http2Enable        *bool   `json:"http2_enable,omitempty"`
+}
+
+// HuaWeiTargetExtension huawei load balancer target extension.
+type HuaWeiTargetExtension struct {
+	CloudPoolID   *string `json:"cloud_pool_id,omitempty"`
+	CloudSubnetID *string `json:"cloud_subnet_id,omitempty"`
+	// OperatingStatus ONLINE | NO_MONITOR | OFFLINE
+	OperatingStatus *string `json:"operating_status,omitempty"`
+}
@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer ...
+package loadbalancer
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BaseLoadBalancer define base load balancer.
+type BaseLoadBalancer struct {
+	ID        string                  `json:"id"`
+	CloudID   string                  `json:"cloud_id"`
+	Name      string                  `json:"name"`
+	Vendor    enumor.Vendor           `json:"vendor"`
+	AccountID string                  `json:"account_id"`
+	BkBizID   int64                   `json:"bk_biz_id"`
+	Region    string                  `json:"region"`
+	Zones     []string                `json:"zones"`
+	LBType    enumor.LoadBalancerType `json:"lb_type"`
+	/*
+		tcloud: 0：创建中，1：正常运行
+		aws: provisioning | active | active_impaired | failed
+		huawei: ONLINE | FROZEN
+		azure: Succeeded | Updating | Deleting | Failed
+		gcp: 转发规则没有状态，固定为 READY
+	*/
+	Status     string `json:"status"`
+	CloudVpcID string `json:"cloud_vpc_id"`
+	VpcID      string `json:"vpc_id"`
+	// Domain 负载均衡域名，仅 tcloud、aws 支持
+	Domain               string   `json:"domain"`
+	PublicIPv4Addresses  []string `json:"public_ipv4_addresses"`
+	PrivateIPv4Addresses []string `json:"private_ipv4_addresses"`
+	Memo                 *string  `json:"memo"`
+	CloudCreatedTime     string   `json:"cloud_created_time"`
+	*core.Revision       `json:",inline"`
+}
+
+// LoadBalancer define load balancer.
+type LoadBalancer[Ext Extension] struct {
+	BaseLoadBalancer `json:",inline"`
+	Extension        *Ext `json:"extension"`
+}
+
+// GetID ...
+func (lb LoadBalancer[T]) GetID() string {
+	return lb.BaseLoadBalancer.ID
+}
+
+// GetCloudID ...
+func (lb LoadBalancer[T]) GetCloudID() string {
+	return lb.BaseLoadBalancer.CloudID
+}
+
+// Extension load balancer extension.
+type Extension interface {
+	TCloudLoadBalancerExtension | AwsLoadBalancerExtension | HuaWeiLoadBalancerExtension |
+		AzureLoadBalancerExtension | GcpLoadBalancerExtension
+}
+
+// BaseListener define base load balancer listener.
+type BaseListener struct {
+	ID        string        `json:"id"`
+	CloudID   string        `json:"cloud_id"`
+	Name      string        `json:"name"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	LbID      string        `json:"lb_id"`
+	CloudLbID string        `json:"cloud_lb_id"`
+	// Protocol 监听协议，如 TCP、UDP、HTTP、HTTPS
+	Protocol string `json:"protocol"`
+	Port     int64  `json:"port"`
+	// EndPort 端口段监听器的结束端口，非端口段监听器为0
+	EndPort        int64 `json:"end_port"`
+	*core.Revision `json:",inline"`
+}
+
+// Listener define load balancer listener.
+type Listener[Ext ListenerExtension] struct {
+	BaseListener `json:",inline"`
+	Extension    *Ext `json:"extension"`
+}
+
+// GetID ...
+func (l Listener[T]) GetID() string {
+	return l.BaseListener.ID
+}
+
+// GetCloudID ...
+func (l Listener[T]) GetCloudID() string {
+	return l.BaseListener.CloudID
+}
+
+// ListenerExtension load balancer listener extension.
+type ListenerExtension interface {
+	TCloudListenerExtension | AwsListenerExtension | HuaWeiListenerExtension |
+		AzureListenerExtension | GcpListenerExtension
+}
+
+// BaseTarget define base load balancer target.
+type BaseTarget struct {
+	ID string `json:"id"`
+	// CloudID 由后端目标ID(或IP)与端口组成，在所属监听器下唯一
+	CloudID         string                        `json:"cloud_id"`
+	Vendor          enumor.Vendor                 `json:"vendor"`
+	AccountID       string                        `json:"account_id"`
+	LbID            string                        `json:"lb_id"`
+	ListenerID      string                        `json:"listener_id"`
+	CloudListenerID string                        `json:"cloud_listener_id"`
+	TargetType      enumor.LoadBalancerTargetType `json:"target_type"`
+	CloudTargetID   string                        `json:"cloud_target_id"`
+	IP              string                        `json:"ip"`
+	Port            int64                         `json:"port"`
+	Weight          int64                         `json:"weight"`
+	*core.Revision  `json:",inline"`
+}
+
+// Target define load balancer target.
+type Target[Ext TargetExtension] struct {
+	BaseTarget `json:",inline"`
+	Extension  *Ext `json:"extension"`
+}
+
+// GetID ...
+func (t Target[T]) GetID() string {
+	return t.BaseTarget.ID
+}
+
+// GetCloudID ...
+func (t Target[T]) GetCloudID() string {
+	return t.BaseTarget.CloudID
+}
+
+// TargetExtension load balancer target extension.
+type TargetExtension interface {
+	TCloudTargetExtension | AwsTargetExtension | HuaWeiTargetExtension | AzureTargetExtension |
+		GcpTargetExtension
+}
+
+// GenTargetCloudID 生成后端目标的云上唯一标识，云上后端目标没有独立ID，使用目标ID(或IP)与端口组合。
+func GenTargetCloudID(cloudTargetID string, port int64) string {
+	return fmt.Sprintf("%s:%d", cloudTargetID, port)
+}
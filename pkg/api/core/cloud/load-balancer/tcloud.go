@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+// TCloudLoadBalancerExtension tcloud load balancer extension.
+type TCloudLoadBalancerExtension struct {
+	// Forward 负载均衡实例类型，1：通用的负载均衡实例，0：传统型负载均衡实例
+	Forward *uint64 `json:"forward,omitempty"`
+	// VipIsp 负载均衡IP地址所属的运营商，仅公网实例有效
+	VipIsp *string `json:"vip_isp,omitempty"`
+	// AddressIPVersion IP版本，IPV4 | IPV6FullChain | IPv6
+	AddressIPVersion *string `json:"address_ip_version,omitempty"`
+	CloudSubnetID    *string `json:"cloud_subnet_id,omitempty"`
+	// InternetChargeType 计费模式，TRAFFIC_POSTPAID_BY_HOUR | BANDWIDTH_POSTPAID_BY_HOUR | BANDWIDTH_PACKAGE
+	InternetChargeType      *string  `json:"internet_charge_type,omitempty"`
+	InternetMaxBandwidthOut *int64   `json:"internet_max_bandwidth_out,omitempty"`
+	CloudSecurityGroupIDs   []string `json:"cloud_security_group_ids,omitempty"`
+}
+
+// TCloudListenerExtension tcloud load balancer listener extension.
+type TCloudListenerExtension struct {
+	// Scheduler 转发方式，WRR | LEAST_CONN，七层监听器为空
+	Scheduler         *string `json:"scheduler,omitempty"`
+	SessionExpireTime *int64  `json:"session_expire_time,omitempty"`
+	HealthCheckSwitch *int64  `json:"health_check_switch,omitempty"`
+	// SniSwitch 是否开启SNI，仅 HTTPS 监听器有效
+	SniSwitch *int64 `json:"sni_switch,omitempty"`
+}
+
+// TCloudTargetExtension tcloud load balancer target extension.
+type TCloudTargetExtension struct {
+	// Type 后端服务的类型，CVM | ENI
+	Type         *string `json:"type,omitempty"`
+	InstanceName *string `json:"instance_name,omitempty"`
+	// CloudRuleID 七层监听器转发规则ID，四层监听器为空
+	CloudRuleID *string `json:"cloud_rule_id,omitempty"`
+	Domain      *string `json:"domain,omitempty"`
+	Url         *string `json:"url,omitempty"`
+}
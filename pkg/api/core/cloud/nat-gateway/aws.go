@@ -0,0 +1,36 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+// AwsNatGatewayExtension aws nat gateway extension.
+type AwsNatGatewayExtension struct {
+	Addresses      []AwsNatGatewayAddress `json:"addresses,omitempty"`
+	FailureCode    *string                `json:"failure_code,omitempty"`
+	FailureMessage *string                `json:"failure_message,omitempty"`
+}
+
+// AwsNatGatewayAddress aws nat gateway address, public nat gateway address is associated with eip.
+type AwsNatGatewayAddress struct {
+	// CloudAllocationID 关联的弹性IP分配ID，私网NAT网关为空
+	CloudAllocationID       *string `json:"cloud_allocation_id,omitempty"`
+	CloudNetworkInterfaceID *string `json:"cloud_network_interface_id,omitempty"`
+	PrivateIP               *string `json:"private_ip,omitempty"`
+	PublicIP                *string `json:"public_ip,omitempty"`
+}
@@ -0,0 +1,36 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+// GcpNatGatewayExtension gcp nat gateway extension, nat gateway corresponds to cloud nat of cloud router.
+type GcpNatGatewayExtension struct {
+	CloudRouterID  string `json:"cloud_router_id,omitempty"`
+	RouterName     string `json:"router_name,omitempty"`
+	RouterSelfLink string `json:"router_self_link,omitempty"`
+	// NatIPAllocateOption AUTO_ONLY | MANUAL_ONLY
+	NatIPAllocateOption *string `json:"nat_ip_allocate_option,omitempty"`
+	// NatIPSelfLinks 手动分配的外部IP地址
+	NatIPSelfLinks []string `json:"nat_ip_self_links,omitempty"`
+	// SourceSubnetworkIPRangesToNat ALL_SUBNETWORKS_ALL_IP_RANGES | ALL_SUBNETWORKS_ALL_PRIMARY_IP_RANGES |
+	// LIST_OF_SUBNETWORKS
+	SourceSubnetworkIPRangesToNat *string  `json:"source_subnetwork_ip_ranges_to_nat,omitempty"`
+	SubnetSelfLinks               []string `json:"subnet_self_links,omitempty"`
+	MinPortsPerVm                 *int64   `json:"min_ports_per_vm,omitempty"`
+}
@@ -0,0 +1,29 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+// HuaWeiNatGatewayExtension huawei nat gateway extension.
+type HuaWeiNatGatewayExtension struct {
+	// Spec 网关规格，1：小型，2：中型，3：大型，4：超大型
+	Spec                *string `json:"spec,omitempty"`
+	EnterpriseProjectID *string `json:"enterprise_project_id,omitempty"`
+	// CloudSnatRuleIDs SNAT规则ID，NAT网关通过SNAT规则关联弹性公网IP
+	CloudSnatRuleIDs []string `json:"cloud_snat_rule_ids,omitempty"`
+}
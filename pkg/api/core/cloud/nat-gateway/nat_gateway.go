@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway ...
+package natgateway
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BaseNatGateway define base nat gateway.
+type BaseNatGateway struct {
+	ID        string                `json:"id"`
+	CloudID   string                `json:"cloud_id"`
+	Name      string                `json:"name"`
+	Vendor    enumor.Vendor         `json:"vendor"`
+	AccountID string                `json:"account_id"`
+	BkBizID   int64                 `json:"bk_biz_id"`
+	Region    string                `json:"region"`
+	Zone      string                `json:"zone"`
+	NatType   enumor.NatGatewayType `json:"nat_type"`
+	/*
+		tcloud: PENDING | DELETING | AVAILABLE | UPDATING | FAILED
+		aws: pending | failed | available | deleting | deleted
+		huawei: ACTIVE | PENDING_CREATE | PENDING_UPDATE | PENDING_DELETE | INACTIVE
+		azure: Succeeded | Updating | Deleting | Failed
+		gcp: NAT网关是路由器的一项配置，没有状态，固定为 READY
+	*/
+	Status     string `json:"status"`
+	CloudVpcID string `json:"cloud_vpc_id"`
+	VpcID      string `json:"vpc_id"`
+	// CloudSubnetID NAT网关所在子网，azure、gcp 的NAT网关可以关联多个子网，保存在拓展字段中
+	CloudSubnetID      string   `json:"cloud_subnet_id"`
+	SubnetID           string   `json:"subnet_id"`
+	PublicIPAddresses  []string `json:"public_ip_addresses"`
+	PrivateIPAddresses []string `json:"private_ip_addresses"`
+	// CloudEipIDs NAT网关关联的弹性公网IP云上ID
+	CloudEipIDs      []string `json:"cloud_eip_ids"`
+	EipIDs           []string `json:"eip_ids"`
+	Memo             *string  `json:"memo"`
+	CloudCreatedTime string   `json:"cloud_created_time"`
+	*core.Revision   `json:",inline"`
+}
+
+// NatGateway define nat gateway.
+type NatGateway[Ext Extension] struct {
+	BaseNatGateway `json:",inline"`
+	Extension      *Ext `json:"extension"`
+}
+
+// GetID ...
+func (nat NatGateway[T]) GetID() string {
+	return nat.BaseNatGateway.ID
+}
+
+// GetCloudID ...
+func (nat NatGateway[T]) GetCloudID() string {
+	return nat.BaseNatGateway.CloudID
+}
+
+// Extension nat gateway extension.
+type Extension interface {
+	TCloudNatGatewayExtension | AwsNatGatewayExtension | HuaWeiNatGatewayExtension |
+		AzureNatGatewayExtension | GcpNatGatewayExtension
+}
+
+// GenGcpNatGatewayCloudID gcp 的NAT网关是云路由器的一项配置，没有独立ID，使用路由器ID与NAT名称组合作为云上ID。
+func GenGcpNatGatewayCloudID(cloudRouterID string, name string) string {
+	return fmt.Sprintf("%s/%s", cloudRouterID, name)
+}
+
+// ParseGcpNatGatewayCloudID parse gcp nat gateway cloud id to cloud router id and nat name.
+func ParseGcpNatGatewayCloudID(cloudID string) (string, string, error) {
+	idx := strings.Index(cloudID, "/")
+	if idx <= 0 || idx == len(cloudID)-1 {
+		return "", "", fmt.Errorf("gcp nat gateway cloud id %s is invalid", cloudID)
+	}
+
+	return cloudID[:idx], cloudID[idx+1:], nil
+}
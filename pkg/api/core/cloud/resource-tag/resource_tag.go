@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag ...
+package resourcetag
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// TagResTypes 支持统一标签管理的资源类型
+var TagResTypes = []enumor.CloudResourceType{
+	enumor.CvmCloudResType,
+	enumor.DiskCloudResType,
+	enumor.VpcCloudResType,
+	enumor.SubnetCloudResType,
+	enumor.EipCloudResType,
+	enumor.SecurityGroupCloudResType,
+}
+
+// ValidateTagResType validate resource type supports tag management.
+func ValidateTagResType(resType enumor.CloudResourceType) error {
+	for _, one := range TagResTypes {
+		if one == resType {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("resource type: %s not support tag", resType)
+}
+
+// Tag define cloud resource tag.
+type Tag struct {
+	Key   string `json:"key" validate:"required,max=255"`
+	Value string `json:"value" validate:"max=255"`
+}
+
+// ResourceTag define a tag of the cloud resource.
+type ResourceTag struct {
+	ID             string                   `json:"id"`
+	Vendor         enumor.Vendor            `json:"vendor"`
+	AccountID      string                   `json:"account_id"`
+	ResType        enumor.CloudResourceType `json:"res_type"`
+	ResID          string                   `json:"res_id"`
+	ResCloudID     string                   `json:"res_cloud_id"`
+	Key            string                   `json:"key"`
+	Value          string                   `json:"value"`
+	*core.Revision `json:",inline"`
+}
@@ -58,7 +58,7 @@ func (sg SecurityGroup[T]) GetCloudID() string {
 // SecurityGroupExtension define security group extension.
 type SecurityGroupExtension interface {
 	TCloudSecurityGroupExtension | AwsSecurityGroupExtension | HuaWeiSecurityGroupExtension |
-		AzureSecurityGroupExtension
+		AzureSecurityGroupExtension | OpenStackSecurityGroupExtension
 }
 
 // TCloudSecurityGroupExtension define tcloud security group extension.
@@ -96,3 +96,11 @@ type AzureSecurityGroupExtension struct {
 	// ResourceGUID 网络安全组资源的资源GUID。
 	ResourceGUID *string `json:"resource_guid"`
 }
+
+// OpenStackSecurityGroupExtension define openstack security group extension.
+type OpenStackSecurityGroupExtension struct {
+	// CloudProjectID 安全组所属的项目ID。
+	CloudProjectID string `json:"cloud_project_id"`
+	// Stateful 是否为有状态安全组，无状态安全组的出入方向规则需要成对配置。
+	Stateful bool `json:"stateful"`
+}
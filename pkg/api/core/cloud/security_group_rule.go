@@ -25,7 +25,8 @@ import (
 
 // SecurityGroupRule define security group rule.
 type SecurityGroupRule interface {
-	TCloudSecurityGroupRule | AwsSecurityGroupRule | HuaWeiSecurityGroupRule | AzureSecurityGroupRule
+	TCloudSecurityGroupRule | AwsSecurityGroupRule | HuaWeiSecurityGroupRule | AzureSecurityGroupRule |
+		OpenStackSecurityGroupRule
 }
 
 // TCloudSecurityGroupRule define tcloud security group rule.
@@ -165,3 +166,35 @@ func (sgr AzureSecurityGroupRule) GetID() string {
 func (sgr AzureSecurityGroupRule) GetCloudID() string {
 	return sgr.CloudID
 }
+
+// OpenStackSecurityGroupRule define openstack security group rule.
+type OpenStackSecurityGroupRule struct {
+	ID                   string                       `json:"id"`
+	CloudID              string                       `json:"cloud_id"`
+	Memo                 *string                      `json:"memo"`
+	Protocol             string                       `json:"protocol"`
+	Ethertype            string                       `json:"ethertype"`
+	CloudRemoteGroupID   string                       `json:"cloud_remote_group_id"`
+	RemoteIPPrefix       string                       `json:"remote_ip_prefix"`
+	Port                 string                       `json:"port"`
+	Type                 enumor.SecurityGroupRuleType `json:"type"`
+	CloudSecurityGroupID string                       `json:"cloud_security_group_id"`
+	CloudProjectID       string                       `json:"cloud_project_id"`
+	AccountID            string                       `json:"account_id"`
+	Region               string                       `json:"region"`
+	SecurityGroupID      string                       `json:"security_group_id"`
+	Creator              string                       `json:"creator"`
+	Reviser              string                       `json:"reviser"`
+	CreatedAt            string                       `json:"created_at"`
+	UpdatedAt            string                       `json:"updated_at"`
+}
+
+// GetID ...
+func (sgr OpenStackSecurityGroupRule) GetID() string {
+	return sgr.ID
+}
+
+// GetCloudID ...
+func (sgr OpenStackSecurityGroupRule) GetCloudID() string {
+	return sgr.CloudID
+}
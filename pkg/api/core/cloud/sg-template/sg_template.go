@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package sgtemplate defines vendor neutral security group template core types.
+package sgtemplate
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/sgrule"
+)
+
+// SecurityGroupTemplate define vendor neutral security group template.
+type SecurityGroupTemplate struct {
+	ID    string         `json:"id"`
+	Name  string         `json:"name"`
+	Rules []TemplateRule `json:"rules"`
+	// Version 模版版本，每次修改规则时加一，用于判断关联的安全组是否已同步最新的规则
+	Version        uint64  `json:"version"`
+	Memo           *string `json:"memo"`
+	*core.Revision `json:",inline"`
+}
+
+// TemplateRule define vendor neutral security group template rule.
+type TemplateRule struct {
+	Type   enumor.SecurityGroupRuleType `json:"type" validate:"required"`
+	Action sgrule.Action                `json:"action" validate:"required"`
+	// Priority 优先级，取值1-100，值越小越先匹配，不支持优先级的云厂商按优先级顺序创建
+	Priority int64 `json:"priority" validate:"min=1,max=100"`
+	// Protocol 协议，支持 all、tcp、udp、icmp
+	Protocol string `json:"protocol" validate:"required"`
+	// Port 端口，仅 tcp、udp 有效，支持 22、80,443、8000-8080 及其组合，为空或all表示所有端口
+	Port string `json:"port" validate:"omitempty,max=255"`
+	// Cidr 对端网段，入站为源地址，出站为目的地址，支持IPv4、IPv6
+	Cidr string  `json:"cidr" validate:"required,cidr"`
+	Memo *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate TemplateRule.
+func (r TemplateRule) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if r.Type != enumor.Ingress && r.Type != enumor.Egress {
+		return fmt.Errorf("unsupported rule type: %s", r.Type)
+	}
+
+	if r.Action != sgrule.Allow && r.Action != sgrule.Deny {
+		return fmt.Errorf("unsupported rule action: %s", r.Action)
+	}
+
+	ports, err := sgrule.ParsePorts(r.Port)
+	if err != nil {
+		return err
+	}
+
+	switch r.Protocol {
+	case sgrule.ProtocolTCP, sgrule.ProtocolUDP:
+	case sgrule.ProtocolAll, sgrule.ProtocolICMP:
+		if len(ports) != 0 {
+			return fmt.Errorf("port is not supported by protocol %s", r.Protocol)
+		}
+	default:
+		return fmt.Errorf("unsupported protocol: %s", r.Protocol)
+	}
+
+	return nil
+}
+
+// ToRule convert template rule to vendor neutral rule.
+func (r TemplateRule) ToRule() sgrule.Rule {
+	ports, _ := sgrule.ParsePorts(r.Port)
+	if r.Protocol != sgrule.ProtocolTCP && r.Protocol != sgrule.ProtocolUDP {
+		ports = nil
+	}
+
+	return sgrule.Rule{
+		Direction: r.Type,
+		Action:    r.Action,
+		Priority:  r.Priority,
+		Protocol:  r.Protocol,
+		Ports:     ports,
+		Cidrs:     []string{r.Cidr},
+	}
+}
+
+// SecurityGroupTemplateRel define the resource the template is applied to, it is security group for vendors
+// which support security group, and vpc for gcp whose firewall rules are created in vpc.
+type SecurityGroupTemplateRel struct {
+	ID         string                   `json:"id"`
+	TemplateID string                   `json:"template_id"`
+	Vendor     enumor.Vendor            `json:"vendor"`
+	AccountID  string                   `json:"account_id"`
+	Region     string                   `json:"region"`
+	ResType    enumor.CloudResourceType `json:"res_type"`
+	ResID      string                   `json:"res_id"`
+	// TargetTag gcp防火墙规则的目标网络标记，为空表示应用到VPC下所有实例
+	TargetTag string `json:"target_tag"`
+	// Version 已同步到该资源的模版版本，0表示还未同步过
+	Version        uint64 `json:"version"`
+	*core.Revision `json:",inline"`
+}
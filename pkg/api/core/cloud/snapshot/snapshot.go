@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot ...
+package snapshot
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BaseSnapshot define base disk snapshot.
+type BaseSnapshot struct {
+	ID        string        `json:"id"`
+	CloudID   string        `json:"cloud_id"`
+	Name      string        `json:"name"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	BkBizID   int64         `json:"bk_biz_id"`
+	Region    string        `json:"region"`
+	Zone      string        `json:"zone"`
+	/*
+		tcloud: NORMAL | CREATING | ROLLBACKING | COPYING_FROM_REMOTE | CHECKING_COPIED | TORECYCLE
+		aws: pending | completed | error | recoverable | recovering
+		huawei: creating | available | error | deleting | error_deleting | rollbacking | backing-up
+		azure: Succeeded | Creating | Updating | Deleting | Failed
+		gcp: CREATING | DELETING | FAILED | READY | UPLOADING
+	*/
+	Status      string `json:"status"`
+	CloudDiskID string `json:"cloud_disk_id"`
+	DiskID      string `json:"disk_id"`
+	// DiskSize 快照源云盘大小，单位GB
+	DiskSize  uint64 `json:"disk_size"`
+	Encrypted *bool  `json:"encrypted"`
+	// SnapshotPolicyID 创建该快照的快照策略ID，手动创建或云上同步的快照为空
+	SnapshotPolicyID string  `json:"snapshot_policy_id"`
+	Memo             *string `json:"memo"`
+	CloudCreatedTime string  `json:"cloud_created_time"`
+	*core.Revision   `json:",inline"`
+}
+
+// Snapshot define disk snapshot.
+type Snapshot[Ext Extension] struct {
+	BaseSnapshot `json:",inline"`
+	Extension    *Ext `json:"extension"`
+}
+
+// GetID ...
+func (s Snapshot[T]) GetID() string {
+	return s.BaseSnapshot.ID
+}
+
+// GetCloudID ...
+func (s Snapshot[T]) GetCloudID() string {
+	return s.BaseSnapshot.CloudID
+}
+
+// Extension snapshot extension.
+type Extension interface {
+	TCloudSnapshotExtension | AwsSnapshotExtension | HuaWeiSnapshotExtension |
+		AzureSnapshotExtension | GcpSnapshotExtension
+}
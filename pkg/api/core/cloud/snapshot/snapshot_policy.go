@@ -0,0 +1,42 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// SnapshotPolicy define hcm snapshot policy, which creates disk snapshots periodically.
+type SnapshotPolicy struct {
+	ID      string                     `json:"id"`
+	Name    string                     `json:"name"`
+	BkBizID int64                      `json:"bk_biz_id"`
+	Scope   enumor.SnapshotPolicyScope `json:"scope"`
+	DiskIDs []string                   `json:"disk_ids"`
+	// Schedule 标准5段式 cron 表达式：分 时 日 月 周
+	Schedule string `json:"schedule"`
+	// RetentionCount 每块云盘保留的由该策略创建的快照个数，超出部分按创建时间从旧到新删除
+	RetentionCount uint64  `json:"retention_count"`
+	Enabled        bool    `json:"enabled"`
+	LastRunAt      string  `json:"last_run_at"`
+	Memo           *string `json:"memo"`
+	*core.Revision `json:",inline"`
+}
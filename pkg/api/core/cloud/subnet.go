@@ -62,7 +62,8 @@ type BaseSubnet struct {
 
 // SubnetExtension defines subnet extensional info.
 type SubnetExtension interface {
-	TCloudSubnetExtension | AwsSubnetExtension | GcpSubnetExtension | AzureSubnetExtension | HuaWeiSubnetExtension
+	TCloudSubnetExtension | AwsSubnetExtension | GcpSubnetExtension | AzureSubnetExtension | HuaWeiSubnetExtension |
+		OpenStackSubnetExtension
 }
 
 // TCloudSubnetExtension defines tencent cloud subnet extensional info.
@@ -109,6 +110,14 @@ type HuaWeiSubnetExtension struct {
 	NtpAddresses []string `json:"ntp_addresses"`
 }
 
+// OpenStackSubnetExtension defines openstack subnet extensional info.
+type OpenStackSubnetExtension struct {
+	IpVersion      int64    `json:"ip_version"`
+	GatewayIp      string   `json:"gateway_ip"`
+	EnableDhcp     bool     `json:"enable_dhcp"`
+	DnsNameservers []string `json:"dns_nameservers"`
+}
+
 // TCloudSubnet defines tencent cloud subnet.
 type TCloudSubnet Subnet[TCloudSubnetExtension]
 
@@ -123,3 +132,6 @@ type AzureSubnet Subnet[AzureSubnetExtension]
 
 // HuaWeiSubnet defines huawei subnet.
 type HuaWeiSubnet Subnet[HuaWeiSubnetExtension]
+
+// OpenStackSubnet defines openstack subnet.
+type OpenStackSubnet Subnet[OpenStackSubnetExtension]
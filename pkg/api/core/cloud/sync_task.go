@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// SyncTask define account resource sync task.
+type SyncTask struct {
+	ID             string                 `json:"id"`
+	Vendor         enumor.Vendor          `json:"vendor"`
+	AccountID      string                 `json:"account_id"`
+	TriggerType    enumor.SyncTaskTrigger `json:"trigger_type"`
+	Status         enumor.SyncTaskStatus  `json:"status"`
+	ChangeCount    int64                  `json:"change_count"`
+	Reason         string                 `json:"reason"`
+	StartAt        string                 `json:"start_at"`
+	EndAt          string                 `json:"end_at"`
+	*core.Revision `json:",inline"`
+}
+
+// SyncDetail define resource sync detail of one resource type in sync task.
+type SyncDetail struct {
+	ID             string                   `json:"id"`
+	TaskID         string                   `json:"task_id"`
+	Vendor         enumor.Vendor            `json:"vendor"`
+	AccountID      string                   `json:"account_id"`
+	ResType        enumor.CloudResourceType `json:"res_type"`
+	Status         enumor.SyncTaskStatus    `json:"status"`
+	ChangeCount    int64                    `json:"change_count"`
+	Reason         string                   `json:"reason"`
+	StartAt        string                   `json:"start_at"`
+	EndAt          string                   `json:"end_at"`
+	*core.Revision `json:",inline"`
+}
+
+// SyncWatermark define account incremental sync watermark.
+type SyncWatermark struct {
+	ID             string        `json:"id"`
+	Vendor         enumor.Vendor `json:"vendor"`
+	AccountID      string        `json:"account_id"`
+	Watermark      string        `json:"watermark"`
+	*core.Revision `json:",inline"`
+}
@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package vpcpeering defines vpc peering core types.
+package vpcpeering
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// VpcPeering define connection between two vpcs, vpc peering connection, transit gateway vpc attachment
+// and ccn vpc attachment are all treated as vpc peering.
+type VpcPeering struct {
+	ID          string                  `json:"id"`
+	Vendor      enumor.Vendor           `json:"vendor"`
+	AccountID   string                  `json:"account_id"`
+	Region      string                  `json:"region"`
+	CloudID     string                  `json:"cloud_id"`
+	Name        string                  `json:"name"`
+	Type        enumor.VpcPeeringType   `json:"type"`
+	Status      enumor.VpcPeeringStatus `json:"status"`
+	CloudStatus string                  `json:"cloud_status"`
+	// CloudGatewayID 中转网关/云联网关联时为中转网关/云联网ID，关联到同一网关的VPC之间可以互通
+	CloudGatewayID     string   `json:"cloud_gateway_id"`
+	LocalVpcID         string   `json:"local_vpc_id"`
+	LocalCloudVpcID    string   `json:"local_cloud_vpc_id"`
+	LocalCidrs         []string `json:"local_cidrs"`
+	PeerVpcID          string   `json:"peer_vpc_id"`
+	PeerCloudVpcID     string   `json:"peer_cloud_vpc_id"`
+	PeerCloudAccountID string   `json:"peer_cloud_account_id"`
+	PeerRegion         string   `json:"peer_region"`
+	PeerCidrs          []string `json:"peer_cidrs"`
+	BkBizID            int64    `json:"bk_biz_id"`
+	Memo               *string  `json:"memo"`
+	CloudCreatedTime   string   `json:"cloud_created_time"`
+	*core.Revision     `json:",inline"`
+}
+
+// GetID ...
+func (p VpcPeering) GetID() string {
+	return p.ID
+}
+
+// GetCloudID ...
+func (p VpcPeering) GetCloudID() string {
+	return p.CloudID
+}
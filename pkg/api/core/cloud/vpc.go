@@ -57,7 +57,8 @@ type BaseVpc struct {
 
 // VpcExtension defines vpc extensional info.
 type VpcExtension interface {
-	TCloudVpcExtension | AwsVpcExtension | GcpVpcExtension | AzureVpcExtension | HuaWeiVpcExtension
+	TCloudVpcExtension | AwsVpcExtension | GcpVpcExtension | AzureVpcExtension | HuaWeiVpcExtension |
+		OpenStackVpcExtension
 }
 
 // TCloudVpcExtension defines tencent cloud vpc extensional info.
@@ -130,6 +131,23 @@ type HuaWeiCidr struct {
 	Cidr string               `json:"cidr"`
 }
 
+// OpenStackVpcExtension defines openstack vpc extensional info, openstack vpc is neutron network,
+// cidr is the cidr of subnets in the network.
+type OpenStackVpcExtension struct {
+	Cidr         []OpenStackCidr `json:"cidr"`
+	Status       string          `json:"status"`
+	Shared       bool            `json:"shared"`
+	External     bool            `json:"external"`
+	AdminStateUp bool            `json:"admin_state_up"`
+	Mtu          int64           `json:"mtu"`
+}
+
+// OpenStackCidr openstack cidr
+type OpenStackCidr struct {
+	Type enumor.IPAddressType `json:"type"`
+	Cidr string               `json:"cidr"`
+}
+
 // TCloudVpc defines tencent cloud vpc.
 type TCloudVpc Vpc[TCloudVpcExtension]
 
@@ -144,3 +162,6 @@ type AzureVpc Vpc[AzureVpcExtension]
 
 // HuaWeiVpc defines huawei vpc.
 type HuaWeiVpc Vpc[HuaWeiVpcExtension]
+
+// OpenStackVpc defines openstack vpc.
+type OpenStackVpc Vpc[OpenStackVpcExtension]
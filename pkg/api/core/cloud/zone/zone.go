@@ -56,7 +56,7 @@ type Zone[Extension ZoneExtension] struct {
 
 // ZoneExtension define zone extension.
 type ZoneExtension interface {
-	TCloudZoneExtension | AwsZoneExtension | HuaWeiZoneExtension | GcpZoneExtension
+	TCloudZoneExtension | AwsZoneExtension | HuaWeiZoneExtension | GcpZoneExtension | OpenStackZoneExtension
 }
 
 // TCloudZoneExtension define tcloud zone extension.
@@ -76,3 +76,8 @@ type GcpZoneExtension struct {
 // AwsZoneExtension define aws zone extension.
 type AwsZoneExtension struct {
 }
+
+// OpenStackZoneExtension define openstack zone extension.
+type OpenStackZoneExtension struct {
+	AccountID string `json:"account_id"`
+}
@@ -140,6 +140,12 @@ func (o *OperationAction) ConvAuditAction() (enumor.AuditAction, error) {
 		return enumor.Associate, nil
 	case Disassociate:
 		return enumor.Disassociate, nil
+	case Rollback:
+		return enumor.Rollback, nil
+	case Copy:
+		return enumor.Copy, nil
+	case Share:
+		return enumor.Share, nil
 
 	default:
 		return "", fmt.Errorf("action is not corresponding audit action")
@@ -155,6 +161,12 @@ const (
 	Associate OperationAction = "associate"
 	// Disassociate 解绑、解挂载等操作
 	Disassociate OperationAction = "disassociate"
+	// Rollback 快照回滚等操作
+	Rollback OperationAction = "rollback"
+	// Copy 镜像跨地域复制等操作
+	Copy OperationAction = "copy"
+	// Share 镜像共享等操作
+	Share OperationAction = "share"
 )
 
 // CloudResourceOperationAuditReq define cloud resource operation audit req.
@@ -35,7 +35,7 @@ import (
 // AccountExtensionCreateReq account extension create req.
 type AccountExtensionCreateReq interface {
 	TCloudAccountExtensionCreateReq | AwsAccountExtensionCreateReq | HuaWeiAccountExtensionCreateReq |
-		GcpAccountExtensionCreateReq | AzureAccountExtensionCreateReq
+		GcpAccountExtensionCreateReq | AzureAccountExtensionCreateReq | OpenStackAccountExtensionCreateReq
 }
 
 // TCloudAccountExtensionCreateReq ...
@@ -111,6 +111,20 @@ func (req *AzureAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.
 	req.CloudClientSecretKey = cipher.EncryptToBase64(req.CloudClientSecretKey)
 }
 
+// OpenStackAccountExtensionCreateReq ...
+type OpenStackAccountExtensionCreateReq struct {
+	CloudAuthUrl     string `json:"cloud_auth_url" validate:"required"`
+	CloudProjectID   string `json:"cloud_project_id" validate:"required"`
+	CloudProjectName string `json:"cloud_project_name" validate:"omitempty"`
+	CloudSecretID    string `json:"cloud_secret_id" validate:"omitempty"`
+	CloudSecretKey   string `json:"cloud_secret_key" validate:"omitempty"`
+}
+
+// EncryptSecretKey ...
+func (req *OpenStackAccountExtensionCreateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+	req.CloudSecretKey = cipher.EncryptToBase64(req.CloudSecretKey)
+}
+
 // AccountCreateReq ...
 type AccountCreateReq[T AccountExtensionCreateReq] struct {
 	Name      string                 `json:"name" validate:"required"`
@@ -132,7 +146,8 @@ func (c *AccountCreateReq[T]) Validate() error {
 // AccountExtensionUpdateReq Note: DataService的更新是与业务无关的，所以必须支持调用方根据场景需求来更新部分字段
 // Note: 对于允许为空字符串的字段，则其类型需要定义为指针，正常情况下，Json合并时空值会被忽略
 type AccountExtensionUpdateReq interface {
-	TCloudAccountExtensionUpdateReq | AwsAccountExtensionUpdateReq | HuaWeiAccountExtensionUpdateReq | GcpAccountExtensionUpdateReq | AzureAccountExtensionUpdateReq
+	TCloudAccountExtensionUpdateReq | AwsAccountExtensionUpdateReq | HuaWeiAccountExtensionUpdateReq | GcpAccountExtensionUpdateReq | AzureAccountExtensionUpdateReq |
+		OpenStackAccountExtensionUpdateReq
 }
 
 type TCloudAccountExtensionUpdateReq struct {
@@ -218,6 +233,23 @@ func (req *AzureAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.
 	}
 }
 
+// OpenStackAccountExtensionUpdateReq ...
+type OpenStackAccountExtensionUpdateReq struct {
+	CloudAuthUrl     string  `json:"cloud_auth_url,omitempty" validate:"omitempty"`
+	CloudProjectID   string  `json:"cloud_project_id,omitempty" validate:"omitempty"`
+	CloudProjectName string  `json:"cloud_project_name,omitempty" validate:"omitempty"`
+	CloudSecretID    *string `json:"cloud_secret_id,omitempty" validate:"omitempty"`
+	CloudSecretKey   *string `json:"cloud_secret_key,omitempty" validate:"omitempty"`
+}
+
+// EncryptSecretKey ...
+func (req *OpenStackAccountExtensionUpdateReq) EncryptSecretKey(cipher cryptography.Crypto) {
+	if req.CloudSecretKey != nil {
+		encryptedCloudSecretKey := cipher.EncryptToBase64(*req.CloudSecretKey)
+		req.CloudSecretKey = &encryptedCloudSecretKey
+	}
+}
+
 // AccountUpdateReq ...
 type AccountUpdateReq[T AccountExtensionUpdateReq] struct {
 	Name      string   `json:"name" validate:"omitempty"`
@@ -236,7 +268,8 @@ func (u *AccountUpdateReq[T]) Validate() error {
 // -------------------------- Get --------------------------
 
 type AccountExtensionGetResp interface {
-	cloud.TCloudAccountExtension | cloud.AwsAccountExtension | cloud.HuaWeiAccountExtension | cloud.GcpAccountExtension | cloud.AzureAccountExtension
+	cloud.TCloudAccountExtension | cloud.AwsAccountExtension | cloud.HuaWeiAccountExtension | cloud.GcpAccountExtension | cloud.AzureAccountExtension |
+		cloud.OpenStackAccountExtension
 }
 
 type AccountGetResult[T AccountExtensionGetResp] struct {
@@ -342,3 +375,28 @@ type SecretDecryptor[T AccountExtensionGetResp] interface {
 	DecryptSecretKey(cryptography.Crypto) error
 	*T
 }
+
+// -------------------------- Secret Re-Encrypt --------------------------
+
+// AccountSecretReEncryptReq 使用当前主密钥重新加密账号密钥的请求，不指定账号时处理全部账号
+type AccountSecretReEncryptReq struct {
+	AccountIDs []string `json:"account_ids" validate:"omitempty,max=500"`
+}
+
+// Validate ...
+func (req *AccountSecretReEncryptReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AccountSecretReEncryptResult ...
+type AccountSecretReEncryptResult struct {
+	Total       uint64 `json:"total"`
+	ReEncrypted uint64 `json:"re_encrypted"`
+	Skipped     uint64 `json:"skipped"`
+}
+
+// AccountSecretReEncryptResp ...
+type AccountSecretReEncryptResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *AccountSecretReEncryptResult `json:"data"`
+}
@@ -0,0 +1,140 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/rest"
+)
+
+// -------------------------- Create --------------------------
+
+// BillItemBatchCreateReq defines batch create bill item request.
+type BillItemBatchCreateReq struct {
+	Items []BillItemCreateReq `json:"items" validate:"required,min=1"`
+}
+
+// BillItemCreateReq defines create bill item request.
+type BillItemCreateReq struct {
+	Vendor      enumor.Vendor   `json:"vendor" validate:"required"`
+	AccountID   string          `json:"account_id" validate:"required"`
+	BillMonth   string          `json:"bill_month" validate:"required,len=7"`
+	ProductCode string          `json:"product_code" validate:"omitempty"`
+	ProductName string          `json:"product_name" validate:"omitempty"`
+	CloudResID  string          `json:"cloud_res_id" validate:"omitempty"`
+	ResName     string          `json:"res_name" validate:"omitempty"`
+	Region      string          `json:"region" validate:"omitempty"`
+	Cost        string          `json:"cost" validate:"required"`
+	Currency    string          `json:"currency" validate:"required,max=16"`
+	Extension   types.JsonField `json:"extension" validate:"omitempty"`
+}
+
+// Validate BillItemBatchCreateReq.
+func (c *BillItemBatchCreateReq) Validate() error {
+	if len(c.Items) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("items count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(c)
+}
+
+// -------------------------- Replace --------------------------
+
+// BillItemReplaceReq defines replace all bill items of the account in the bill month request, existing items are
+// deleted and the new items are created in one transaction.
+type BillItemReplaceReq struct {
+	Vendor    enumor.Vendor       `json:"vendor" validate:"required"`
+	AccountID string              `json:"account_id" validate:"required"`
+	BillMonth string              `json:"bill_month" validate:"required,len=7"`
+	Items     []BillItemCreateReq `json:"items" validate:"omitempty"`
+}
+
+// Validate BillItemReplaceReq.
+func (c *BillItemReplaceReq) Validate() error {
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	for _, one := range c.Items {
+		if one.Vendor != c.Vendor || one.AccountID != c.AccountID || one.BillMonth != c.BillMonth {
+			return fmt.Errorf("bill item(vendor: %s, account: %s, month: %s) not belongs to the replaced bill",
+				one.Vendor, one.AccountID, one.BillMonth)
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// BillItemListResult defines list bill item result.
+type BillItemListResult struct {
+	Count   uint64           `json:"count"`
+	Details []cloud.BillItem `json:"details"`
+}
+
+// BillItemListResp defines list bill item response.
+type BillItemListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *BillItemListResult `json:"data"`
+}
+
+// -------------------------- Cost Allocation --------------------------
+
+// BillCostAllocationReq defines list bill cost allocation request.
+type BillCostAllocationReq struct {
+	// BillMonth 账单月份，格式为yyyy-mm
+	BillMonth  string          `json:"bill_month" validate:"required,len=7"`
+	Vendors    []enumor.Vendor `json:"vendors" validate:"omitempty"`
+	AccountIDs []string        `json:"account_ids" validate:"omitempty"`
+	BkBizIDs   []int64         `json:"bk_biz_ids" validate:"omitempty"`
+	// ReportCurrency 报表币种，不为空时各币种费用按当月汇率统一换算为该币种
+	ReportCurrency string `json:"report_currency" validate:"omitempty,max=16"`
+}
+
+// Validate BillCostAllocationReq.
+func (c *BillCostAllocationReq) Validate() error {
+	if len(c.AccountIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("account_ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if len(c.BkBizIDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("bk_biz_ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(c)
+}
+
+// BillCostAllocationResult defines list bill cost allocation result.
+type BillCostAllocationResult struct {
+	Details []cloud.BillCostAllocation `json:"details"`
+}
+
+// BillCostAllocationResp defines list bill cost allocation response.
+type BillCostAllocationResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *BillCostAllocationResult `json:"data"`
+}
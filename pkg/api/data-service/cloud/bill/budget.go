@@ -0,0 +1,256 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// maxBudgetThresholdCount 预算告警阈值最大数量
+const maxBudgetThresholdCount = 10
+
+// maxBudgetThreshold 预算告警阈值最大百分比
+const maxBudgetThreshold = 1000
+
+// -------------------------- Create --------------------------
+
+// BudgetBatchCreateReq defines batch create budget request.
+type BudgetBatchCreateReq struct {
+	Budgets []BudgetCreateReq `json:"budgets" validate:"required,min=1"`
+}
+
+// BudgetCreateReq defines create budget request.
+type BudgetCreateReq struct {
+	Name       string                 `json:"name" validate:"required,max=255"`
+	ScopeType  enumor.BudgetScopeType `json:"scope_type" validate:"required"`
+	ScopeValue string                 `json:"scope_value" validate:"required,max=64"`
+	// Amount 每月预算金额
+	Amount   string `json:"amount" validate:"required"`
+	Currency string `json:"currency" validate:"required,max=16"`
+	// Thresholds 告警阈值百分比列表，如[50,80,100]
+	Thresholds []int64 `json:"thresholds" validate:"required,min=1"`
+	Memo       *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BudgetBatchCreateReq.
+func (c *BudgetBatchCreateReq) Validate() error {
+	if len(c.Budgets) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("budgets count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	for _, one := range c.Budgets {
+		if err := ValidateBudgetScope(one.ScopeType, one.ScopeValue); err != nil {
+			return err
+		}
+
+		if err := validatePositiveDecimal("amount", one.Amount); err != nil {
+			return err
+		}
+
+		if err := ValidateBudgetThresholds(one.Thresholds); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateBudgetScope validate budget scope type and scope value.
+func ValidateBudgetScope(scopeType enumor.BudgetScopeType, scopeValue string) error {
+	if err := scopeType.Validate(); err != nil {
+		return err
+	}
+
+	switch scopeType {
+	case enumor.BizBudgetScope:
+		if _, err := strconv.ParseInt(scopeValue, 10, 64); err != nil {
+			return fmt.Errorf("biz budget scope_value should be bk_biz_id, err: %v", err)
+		}
+	case enumor.VendorBudgetScope:
+		if err := enumor.Vendor(scopeValue).Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateBudgetThresholds validate budget threshold percentages.
+func ValidateBudgetThresholds(thresholds []int64) error {
+	if len(thresholds) > maxBudgetThresholdCount {
+		return fmt.Errorf("thresholds count should <= %d", maxBudgetThresholdCount)
+	}
+
+	exists := make(map[int64]struct{}, len(thresholds))
+	for _, one := range thresholds {
+		if one <= 0 || one > maxBudgetThreshold {
+			return fmt.Errorf("threshold should in range (0, %d], threshold: %d", maxBudgetThreshold, one)
+		}
+
+		if _, exist := exists[one]; exist {
+			return fmt.Errorf("threshold %d is duplicated", one)
+		}
+		exists[one] = struct{}{}
+	}
+
+	return nil
+}
+
+// -------------------------- Update --------------------------
+
+// BudgetBatchUpdateReq defines batch update budget request.
+type BudgetBatchUpdateReq struct {
+	Budgets []BudgetUpdateReq `json:"budgets" validate:"required,min=1"`
+}
+
+// BudgetUpdateReq defines update budget request, the scope of budget can not be updated.
+type BudgetUpdateReq struct {
+	ID         string  `json:"id" validate:"required"`
+	Name       string  `json:"name" validate:"omitempty,max=255"`
+	Amount     string  `json:"amount" validate:"omitempty"`
+	Currency   string  `json:"currency" validate:"omitempty,max=16"`
+	Thresholds []int64 `json:"thresholds" validate:"omitempty"`
+	Memo       *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BudgetBatchUpdateReq.
+func (c *BudgetBatchUpdateReq) Validate() error {
+	if len(c.Budgets) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("budgets count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	for _, one := range c.Budgets {
+		if len(one.Amount) != 0 {
+			if err := validatePositiveDecimal("amount", one.Amount); err != nil {
+				return err
+			}
+		}
+
+		if err := ValidateBudgetThresholds(one.Thresholds); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// BudgetListResult defines list budget result.
+type BudgetListResult struct {
+	Count   uint64         `json:"count"`
+	Details []cloud.Budget `json:"details"`
+}
+
+// BudgetListResp defines list budget response.
+type BudgetListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *BudgetListResult `json:"data"`
+}
+
+// -------------------------- Alert --------------------------
+
+// BudgetAlertBatchCreateReq defines batch create budget alert request.
+type BudgetAlertBatchCreateReq struct {
+	Alerts []BudgetAlertCreateReq `json:"alerts" validate:"required,min=1"`
+}
+
+// BudgetAlertCreateReq defines create budget alert request.
+type BudgetAlertCreateReq struct {
+	BudgetID     string                    `json:"budget_id" validate:"required"`
+	ScopeType    enumor.BudgetScopeType    `json:"scope_type" validate:"required"`
+	ScopeValue   string                    `json:"scope_value" validate:"required"`
+	BillMonth    string                    `json:"bill_month" validate:"required"`
+	Threshold    uint64                    `json:"threshold" validate:"required"`
+	Amount       string                    `json:"amount" validate:"required"`
+	Spend        string                    `json:"spend" validate:"required"`
+	Currency     string                    `json:"currency" validate:"required"`
+	NotifyStatus enumor.BudgetNotifyStatus `json:"notify_status" validate:"omitempty"`
+	NotifyMsg    string                    `json:"notify_msg" validate:"omitempty"`
+}
+
+// Validate BudgetAlertBatchCreateReq.
+func (c *BudgetAlertBatchCreateReq) Validate() error {
+	if len(c.Alerts) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("alerts count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	for _, one := range c.Alerts {
+		if _, err := time.Parse(constant.MonthLayout, one.BillMonth); err != nil {
+			return fmt.Errorf("bill_month should be yyyy-mm, err: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// BudgetAlertBatchUpdateReq defines batch update budget alert request.
+type BudgetAlertBatchUpdateReq struct {
+	Alerts []BudgetAlertUpdateReq `json:"alerts" validate:"required,min=1"`
+}
+
+// BudgetAlertUpdateReq defines update budget alert request, only the spend and notify result can be updated.
+type BudgetAlertUpdateReq struct {
+	ID           string                    `json:"id" validate:"required"`
+	Spend        string                    `json:"spend" validate:"omitempty"`
+	NotifyStatus enumor.BudgetNotifyStatus `json:"notify_status" validate:"omitempty"`
+	NotifyMsg    string                    `json:"notify_msg" validate:"omitempty"`
+}
+
+// Validate BudgetAlertBatchUpdateReq.
+func (c *BudgetAlertBatchUpdateReq) Validate() error {
+	if len(c.Alerts) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("alerts count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(c)
+}
+
+// BudgetAlertListResult defines list budget alert result.
+type BudgetAlertListResult struct {
+	Count   uint64              `json:"count"`
+	Details []cloud.BudgetAlert `json:"details"`
+}
+
+// BudgetAlertListResp defines list budget alert response.
+type BudgetAlertListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *BudgetAlertListResult `json:"data"`
+}
@@ -0,0 +1,140 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/math"
+)
+
+// -------------------------- Create --------------------------
+
+// ExchangeRateBatchCreateReq defines batch create exchange rate request.
+type ExchangeRateBatchCreateReq struct {
+	Rates []ExchangeRateCreateReq `json:"rates" validate:"required,min=1"`
+}
+
+// ExchangeRateCreateReq defines create exchange rate request.
+type ExchangeRateCreateReq struct {
+	FromCurrency string `json:"from_currency" validate:"required,max=16"`
+	ToCurrency   string `json:"to_currency" validate:"required,max=16"`
+	// Month 汇率生效月份，格式为yyyy-mm
+	Month string `json:"month" validate:"required"`
+	// Rate 汇率，1单位源币种可兑换的目标币种数量
+	Rate string `json:"rate" validate:"required"`
+}
+
+// Validate ExchangeRateBatchCreateReq.
+func (c *ExchangeRateBatchCreateReq) Validate() error {
+	if len(c.Rates) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("rates count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	for _, one := range c.Rates {
+		if one.FromCurrency == one.ToCurrency {
+			return fmt.Errorf("from_currency and to_currency can not be the same: %s", one.FromCurrency)
+		}
+
+		if _, err := time.Parse(constant.MonthLayout, one.Month); err != nil {
+			return fmt.Errorf("month should be yyyy-mm, err: %v", err)
+		}
+
+		if err := ValidateExchangeRate(one.Rate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateExchangeRate validate exchange rate is a positive decimal.
+func ValidateExchangeRate(rate string) error {
+	return validatePositiveDecimal("rate", rate)
+}
+
+// validatePositiveDecimal validate the field value is a positive decimal.
+func validatePositiveDecimal(field, value string) error {
+	decimal, err := math.NewDecimalFromString(value)
+	if err != nil {
+		return fmt.Errorf("%s is invalid, err: %v", field, err)
+	}
+
+	if decimal.Sign() <= 0 {
+		return fmt.Errorf("%s should > 0, %s: %s", field, field, value)
+	}
+
+	return nil
+}
+
+// -------------------------- Update --------------------------
+
+// ExchangeRateBatchUpdateReq defines batch update exchange rate request.
+type ExchangeRateBatchUpdateReq struct {
+	Rates []ExchangeRateUpdateReq `json:"rates" validate:"required,min=1"`
+}
+
+// ExchangeRateUpdateReq defines update exchange rate request.
+type ExchangeRateUpdateReq struct {
+	ID   string `json:"id" validate:"required"`
+	Rate string `json:"rate" validate:"required"`
+}
+
+// Validate ExchangeRateBatchUpdateReq.
+func (c *ExchangeRateBatchUpdateReq) Validate() error {
+	if len(c.Rates) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("rates count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	for _, one := range c.Rates {
+		if err := ValidateExchangeRate(one.Rate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// ExchangeRateListResult defines list exchange rate result.
+type ExchangeRateListResult struct {
+	Count   uint64               `json:"count"`
+	Details []cloud.ExchangeRate `json:"details"`
+}
+
+// ExchangeRateListResp defines list exchange rate response.
+type ExchangeRateListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ExchangeRateListResult `json:"data"`
+}
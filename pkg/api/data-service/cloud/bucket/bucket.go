@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bucket defines bucket data-service api.
+package bucket
+
+import (
+	"errors"
+	"fmt"
+
+	corebucket "hcm/pkg/api/core/cloud/bucket"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// BucketBatchCreateReq bucket batch create req.
+type BucketBatchCreateReq struct {
+	Buckets []BucketBatchCreate `json:"buckets" validate:"required,min=1"`
+}
+
+// BucketBatchCreate define bucket batch create.
+type BucketBatchCreate struct {
+	Vendor              enumor.Vendor `json:"vendor" validate:"required"`
+	AccountID           string        `json:"account_id" validate:"required"`
+	Region              string        `json:"region"`
+	CloudID             string        `json:"cloud_id" validate:"required"`
+	Name                string        `json:"name"`
+	BkBizID             int64         `json:"bk_biz_id" validate:"required"`
+	Encrypted           bool          `json:"encrypted"`
+	EncryptionType      string        `json:"encryption_type"`
+	VersioningEnabled   bool          `json:"versioning_enabled"`
+	PublicRead          bool          `json:"public_read"`
+	PublicWrite         bool          `json:"public_write"`
+	PublicAccessReasons []string      `json:"public_access_reasons"`
+	CloudCreatedTime    string        `json:"cloud_created_time"`
+}
+
+// Validate bucket batch create request.
+func (req *BucketBatchCreateReq) Validate() error {
+	if len(req.Buckets) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("buckets count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// BucketBatchUpdateReq bucket batch update req.
+type BucketBatchUpdateReq struct {
+	Buckets []BucketBatchUpdate `json:"buckets" validate:"required,min=1"`
+}
+
+// BucketBatchUpdate bucket batch update, attributes synced from cloud are all updated.
+type BucketBatchUpdate struct {
+	ID                  string   `json:"id" validate:"required"`
+	Region              string   `json:"region"`
+	Name                string   `json:"name"`
+	Encrypted           *bool    `json:"encrypted"`
+	EncryptionType      string   `json:"encryption_type"`
+	VersioningEnabled   *bool    `json:"versioning_enabled"`
+	PublicRead          *bool    `json:"public_read"`
+	PublicWrite         *bool    `json:"public_write"`
+	PublicAccessReasons []string `json:"public_access_reasons"`
+}
+
+// Validate bucket batch update request.
+func (req *BucketBatchUpdateReq) Validate() error {
+	if len(req.Buckets) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("buckets count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// BucketCommonInfoBatchUpdateReq define bucket common info batch update req.
+type BucketCommonInfoBatchUpdateReq struct {
+	IDs     []string `json:"ids" validate:"required"`
+	BkBizID int64    `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate bucket common info batch update req.
+func (req *BucketCommonInfoBatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.IDs) == 0 {
+		return errors.New("ids required")
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// BucketListResult define bucket list result.
+type BucketListResult struct {
+	Count   uint64              `json:"count"`
+	Details []corebucket.Bucket `json:"details"`
+}
+
+// BucketListResp define list resp.
+type BucketListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *BucketListResult `json:"data"`
+}
+
+// -------------------------- Delete --------------------------
+
+// BucketBatchDeleteReq bucket delete request.
+type BucketBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate bucket delete request.
+func (req *BucketBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+// OpenStackDiskExtensionCreateReq ...
+type OpenStackDiskExtensionCreateReq struct {
+	Bootable    bool                   `json:"bootable"`
+	Encrypted   bool                   `json:"encrypted"`
+	Multiattach bool                   `json:"multiattach"`
+	Attachment  []*OpenStackAttachment `json:"attachment,omitempty"`
+}
+
+// OpenStackDiskExtensionResult ...
+type OpenStackDiskExtensionResult struct {
+	Bootable    bool                   `json:"bootable"`
+	Encrypted   bool                   `json:"encrypted"`
+	Multiattach bool                   `json:"multiattach"`
+	Attachment  []*OpenStackAttachment `json:"attachment,omitempty"`
+}
+
+// OpenStackDiskExtensionUpdateReq ...
+type OpenStackDiskExtensionUpdateReq struct {
+	Bootable   *bool                  `json:"bootable,omitempty"`
+	Attachment []*OpenStackAttachment `json:"attachment,omitempty"`
+}
+
+// OpenStackAttachment ...
+type OpenStackAttachment struct {
+	// AttachmentID 挂载信息对应的ID
+	AttachmentID string `json:"attachment_id"`
+	// CloudInstanceID 挂载的云服务器ID
+	CloudInstanceID string `json:"cloud_instance_id"`
+	// DeviceName 挂载点
+	DeviceName string `json:"device_name"`
+	// AttachedAt 挂载时间
+	AttachedAt string `json:"attached_at"`
+}
@@ -72,7 +72,8 @@ func (req *DiskExtBatchCreateReq[T]) Validate() error {
 
 // DiskExtensionCreateReq ...
 type DiskExtensionCreateReq interface {
-	TCloudDiskExtensionCreateReq | AwsDiskExtensionCreateReq | AzureDiskExtensionCreateReq | GcpDiskExtensionCreateReq | HuaWeiDiskExtensionCreateReq
+	TCloudDiskExtensionCreateReq | AwsDiskExtensionCreateReq | AzureDiskExtensionCreateReq | GcpDiskExtensionCreateReq | HuaWeiDiskExtensionCreateReq |
+		OpenStackDiskExtensionCreateReq
 }
 
 // DiskExtUpdateReq ...
@@ -94,7 +95,8 @@ func (req *DiskExtUpdateReq[T]) Validate() error {
 
 // DiskExtensionUpdateReq ...
 type DiskExtensionUpdateReq interface {
-	TCloudDiskExtensionUpdateReq | HuaWeiDiskExtensionUpdateReq | AwsDiskExtensionUpdateReq | AzureDiskExtensionUpdateReq | GcpDiskExtensionUpdateReq
+	TCloudDiskExtensionUpdateReq | HuaWeiDiskExtensionUpdateReq | AwsDiskExtensionUpdateReq | AzureDiskExtensionUpdateReq | GcpDiskExtensionUpdateReq |
+		OpenStackDiskExtensionUpdateReq
 }
 
 // DiskExtBatchUpdateReq ...
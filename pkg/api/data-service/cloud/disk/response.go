@@ -120,5 +120,6 @@ type DiskCountResult struct {
 
 // DiskExtensionResult ...
 type DiskExtensionResult interface {
-	TCloudDiskExtensionResult | AwsDiskExtensionResult | AzureDiskExtensionResult | GcpDiskExtensionResult | HuaWeiDiskExtensionResult
+	TCloudDiskExtensionResult | AwsDiskExtensionResult | AzureDiskExtensionResult | GcpDiskExtensionResult | HuaWeiDiskExtensionResult |
+		OpenStackDiskExtensionResult
 }
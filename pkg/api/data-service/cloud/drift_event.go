@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"errors"
+	"fmt"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/rest"
+)
+
+// -------------------------- Create --------------------------
+
+// ResDriftEventBatchCreateReq resource drift event batch create request.
+type ResDriftEventBatchCreateReq struct {
+	Events []ResDriftEventCreate `json:"events" validate:"required,min=1,dive,required"`
+}
+
+// ResDriftEventCreate define resource drift event create.
+type ResDriftEventCreate struct {
+	Vendor     enumor.Vendor            `json:"vendor" validate:"required"`
+	AccountID  string                   `json:"account_id" validate:"required"`
+	ResType    enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ResID      string                   `json:"res_id" validate:"omitempty"`
+	CloudResID string                   `json:"cloud_res_id" validate:"required"`
+	Action     enumor.DriftEventAction  `json:"action" validate:"required"`
+	BeforeData types.JsonField          `json:"before_data" validate:"required"`
+	AfterData  types.JsonField          `json:"after_data" validate:"required"`
+}
+
+// Validate resource drift event batch create request.
+func (req *ResDriftEventBatchCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Events) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("events count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, one := range req.Events {
+		if err := one.Action.Validate(); err != nil {
+			return err
+		}
+
+		if one.Action != enumor.AddDriftEvent && len(one.ResID) == 0 {
+			return errors.New("res_id is required when action is not add")
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// ResDriftEventListResult define resource drift event list result.
+type ResDriftEventListResult struct {
+	Count   uint64                    `json:"count"`
+	Details []corecloud.ResDriftEvent `json:"details"`
+}
+
+// ResDriftEventListResp define resource drift event list resp.
+type ResDriftEventListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ResDriftEventListResult `json:"data"`
+}
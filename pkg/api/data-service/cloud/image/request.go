@@ -27,6 +27,8 @@ import (
 
 // ImageExtCreateReq ...
 type ImageExtCreateReq[T ImageExtensionCreateReq] struct {
+	// AccountID 自定义镜像所属账号，公共镜像为空
+	AccountID    string `json:"account_id"`
 	CloudID      string `json:"cloud_id"`
 	Name         string `json:"name"`
 	Architecture string `json:"architecture"`
@@ -74,6 +76,7 @@ func (req *ImageListReq) Validate() error {
 // ImageExtUpdateReq ...
 type ImageExtUpdateReq[T ImageExtensionUpdateReq] struct {
 	ID        string `json:"id" validate:"required"`
+	Name      string `json:"name"`
 	State     string `json:"state"`
 	Extension *T     `json:"extension"`
 }
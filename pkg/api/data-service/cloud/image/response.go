@@ -39,6 +39,7 @@ type ImageExtListResult[T ImageExtensionResult] struct {
 type ImageExtResult[T ImageExtensionResult] struct {
 	ID           string `json:"id,omitempty"`
 	Vendor       string `json:"vendor,omitempty"`
+	AccountID    string `json:"account_id,omitempty"`
 	CloudID      string `json:"cloud_id,omitempty"`
 	Name         string `json:"name,omitempty"`
 	Architecture string `json:"architecture,omitempty"`
@@ -77,6 +78,7 @@ type ImageListResp struct {
 type ImageResult struct {
 	ID           string `json:"id,omitempty"`
 	Vendor       string `json:"vendor,omitempty"`
+	AccountID    string `json:"account_id,omitempty"`
 	CloudID      string `json:"cloud_id,omitempty"`
 	Name         string `json:"name,omitempty"`
 	Architecture string `json:"architecture,omitempty"`
@@ -0,0 +1,184 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ipam defines ipam cidr pool and cidr allocation data-service api.
+package ipam
+
+import (
+	"errors"
+	"fmt"
+
+	coreipam "hcm/pkg/api/core/cloud/ipam"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// -------------------------- Cidr Pool --------------------------
+
+// CidrPoolBatchCreateReq defines batch create cidr pool request.
+type CidrPoolBatchCreateReq struct {
+	CidrPools []CidrPoolCreateReq `json:"cidr_pools" validate:"required,min=1"`
+}
+
+// CidrPoolCreateReq defines create cidr pool request.
+type CidrPoolCreateReq struct {
+	Name        string  `json:"name" validate:"required,max=255"`
+	BkBizID     int64   `json:"bk_biz_id" validate:"required"`
+	Region      string  `json:"region" validate:"omitempty,max=64"`
+	Environment string  `json:"environment" validate:"omitempty,max=32"`
+	IPv4Cidr    string  `json:"ipv4_cidr" validate:"required,cidrv4"`
+	Memo        *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate CidrPoolBatchCreateReq.
+func (c *CidrPoolBatchCreateReq) Validate() error {
+	if len(c.CidrPools) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("cidr_pools count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(c)
+}
+
+// CidrPoolBatchUpdateReq defines batch update cidr pool request.
+type CidrPoolBatchUpdateReq struct {
+	CidrPools []CidrPoolUpdateReq `json:"cidr_pools" validate:"required,min=1"`
+}
+
+// CidrPoolUpdateReq defines update cidr pool request, the cidr of pool can not be updated.
+type CidrPoolUpdateReq struct {
+	ID          string  `json:"id" validate:"required"`
+	Name        string  `json:"name" validate:"omitempty,max=255"`
+	BkBizID     int64   `json:"bk_biz_id" validate:"omitempty"`
+	Region      *string `json:"region" validate:"omitempty,max=64"`
+	Environment *string `json:"environment" validate:"omitempty,max=32"`
+	Memo        *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate CidrPoolBatchUpdateReq.
+func (c *CidrPoolBatchUpdateReq) Validate() error {
+	if len(c.CidrPools) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("cidr_pools count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(c)
+}
+
+// CidrPoolListResult defines list cidr pool result.
+type CidrPoolListResult struct {
+	Count   uint64              `json:"count"`
+	Details []coreipam.CidrPool `json:"details"`
+}
+
+// CidrPoolListResp defines list cidr pool response.
+type CidrPoolListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *CidrPoolListResult `json:"data"`
+}
+
+// -------------------------- Cidr Allocation --------------------------
+
+// CidrAllocationBatchCreateReq defines batch create cidr allocation request.
+type CidrAllocationBatchCreateReq struct {
+	Allocations []CidrAllocationCreateReq `json:"allocations" validate:"required,min=1"`
+}
+
+// CidrAllocationCreateReq defines create cidr allocation request.
+type CidrAllocationCreateReq struct {
+	PoolID    string                      `json:"pool_id" validate:"required"`
+	Cidr      string                      `json:"cidr" validate:"required,cidrv4"`
+	Status    enumor.CidrAllocationStatus `json:"status" validate:"required"`
+	Vendor    enumor.Vendor               `json:"vendor" validate:"omitempty"`
+	AccountID string                      `json:"account_id" validate:"omitempty"`
+	Region    string                      `json:"region" validate:"omitempty"`
+	VpcID     string                      `json:"vpc_id" validate:"omitempty"`
+	Memo      *string                     `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate CidrAllocationBatchCreateReq.
+func (c *CidrAllocationBatchCreateReq) Validate() error {
+	if len(c.Allocations) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("allocations count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	for _, one := range c.Allocations {
+		if err := one.Status.Validate(); err != nil {
+			return err
+		}
+
+		if one.Status == enumor.CidrAllocated && len(one.VpcID) == 0 {
+			return errors.New("vpc_id is required when cidr is allocated")
+		}
+	}
+
+	return nil
+}
+
+// CidrAllocationBatchUpdateReq defines batch update cidr allocation request.
+type CidrAllocationBatchUpdateReq struct {
+	Allocations []CidrAllocationUpdateReq `json:"allocations" validate:"required,min=1"`
+}
+
+// CidrAllocationUpdateReq defines update cidr allocation request, the pool and cidr can not be updated.
+type CidrAllocationUpdateReq struct {
+	ID     string                      `json:"id" validate:"required"`
+	Status enumor.CidrAllocationStatus `json:"status" validate:"omitempty"`
+	VpcID  string                      `json:"vpc_id" validate:"omitempty"`
+	Memo   *string                     `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate CidrAllocationBatchUpdateReq.
+func (c *CidrAllocationBatchUpdateReq) Validate() error {
+	if len(c.Allocations) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("allocations count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	for _, one := range c.Allocations {
+		if len(one.Status) == 0 {
+			continue
+		}
+
+		if err := one.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CidrAllocationListResult defines list cidr allocation result.
+type CidrAllocationListResult struct {
+	Count   uint64                    `json:"count"`
+	Details []coreipam.CidrAllocation `json:"details"`
+}
+
+// CidrAllocationListResp defines list cidr allocation response.
+type CidrAllocationListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *CidrAllocationListResult `json:"data"`
+}
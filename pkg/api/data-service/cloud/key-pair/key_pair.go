@@ -0,0 +1,190 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair defines key pair data-service api.
+package keypair
+
+import (
+	"errors"
+	"fmt"
+
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// KeyPairBatchCreateReq key pair batch create req.
+type KeyPairBatchCreateReq struct {
+	KeyPairs []KeyPairBatchCreate `json:"key_pairs" validate:"required,min=1"`
+}
+
+// KeyPairBatchCreate define key pair batch create.
+type KeyPairBatchCreate struct {
+	Name        string  `json:"name" validate:"required"`
+	BkBizID     int64   `json:"bk_biz_id" validate:"required"`
+	PublicKey   string  `json:"public_key" validate:"required"`
+	Fingerprint string  `json:"fingerprint" validate:"required"`
+	Memo        *string `json:"memo"`
+}
+
+// Validate key pair batch create request.
+func (req *KeyPairBatchCreateReq) Validate() error {
+	if len(req.KeyPairs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("key_pairs count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// CloudKeyPairBatchCreateReq cloud key pair batch create req.
+type CloudKeyPairBatchCreateReq struct {
+	KeyPairs []CloudKeyPairBatchCreate `json:"key_pairs" validate:"required,min=1"`
+}
+
+// CloudKeyPairBatchCreate define cloud key pair batch create.
+type CloudKeyPairBatchCreate struct {
+	Vendor           enumor.Vendor `json:"vendor" validate:"required"`
+	AccountID        string        `json:"account_id" validate:"required"`
+	Region           string        `json:"region"`
+	CloudID          string        `json:"cloud_id" validate:"required"`
+	Name             string        `json:"name"`
+	Fingerprint      string        `json:"fingerprint"`
+	KeyPairID        string        `json:"key_pair_id"`
+	BkBizID          int64         `json:"bk_biz_id" validate:"required"`
+	CloudCreatedTime string        `json:"cloud_created_time"`
+}
+
+// Validate cloud key pair batch create request.
+func (req *CloudKeyPairBatchCreateReq) Validate() error {
+	if len(req.KeyPairs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("key_pairs count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// KeyPairBatchUpdateReq key pair batch update req, public key can not be updated once imported.
+type KeyPairBatchUpdateReq struct {
+	KeyPairs []KeyPairBatchUpdate `json:"key_pairs" validate:"required,min=1"`
+}
+
+// KeyPairBatchUpdate key pair batch update.
+type KeyPairBatchUpdate struct {
+	ID   string  `json:"id" validate:"required"`
+	Name string  `json:"name"`
+	Memo *string `json:"memo"`
+}
+
+// Validate key pair batch update request.
+func (req *KeyPairBatchUpdateReq) Validate() error {
+	if len(req.KeyPairs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("key_pairs count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// CloudKeyPairBatchUpdateReq cloud key pair batch update req.
+type CloudKeyPairBatchUpdateReq struct {
+	KeyPairs []CloudKeyPairBatchUpdate `json:"key_pairs" validate:"required,min=1"`
+}
+
+// CloudKeyPairBatchUpdate cloud key pair batch update.
+type CloudKeyPairBatchUpdate struct {
+	ID          string `json:"id" validate:"required"`
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Validate cloud key pair batch update request.
+func (req *CloudKeyPairBatchUpdateReq) Validate() error {
+	if len(req.KeyPairs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("key_pairs count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// CloudKeyPairCommonInfoBatchUpdateReq define cloud key pair common info batch update req.
+type CloudKeyPairCommonInfoBatchUpdateReq struct {
+	IDs     []string `json:"ids" validate:"required"`
+	BkBizID int64    `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate cloud key pair common info batch update req.
+func (req *CloudKeyPairCommonInfoBatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.IDs) == 0 {
+		return errors.New("ids required")
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// KeyPairListResult define key pair list result.
+type KeyPairListResult struct {
+	Count   uint64           `json:"count"`
+	Details []corekp.KeyPair `json:"details"`
+}
+
+// KeyPairListResp define list resp.
+type KeyPairListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *KeyPairListResult `json:"data"`
+}
+
+// CloudKeyPairListResult define cloud key pair list result.
+type CloudKeyPairListResult struct {
+	Count   uint64                `json:"count"`
+	Details []corekp.CloudKeyPair `json:"details"`
+}
+
+// CloudKeyPairListResp define list resp.
+type CloudKeyPairListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *CloudKeyPairListResult `json:"data"`
+}
+
+// -------------------------- Delete --------------------------
+
+// KeyPairBatchDeleteReq key pair delete request.
+type KeyPairBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate key pair delete request.
+func (req *KeyPairBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
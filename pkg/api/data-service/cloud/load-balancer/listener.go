@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// ListenerBatchCreateReq load balancer listener batch create req.
+type ListenerBatchCreateReq[Extension corelb.ListenerExtension] struct {
+	Listeners []ListenerBatchCreate[Extension] `json:"listeners" validate:"required,min=1"`
+}
+
+// ListenerBatchCreate define load balancer listener batch create.
+type ListenerBatchCreate[Extension corelb.ListenerExtension] struct {
+	CloudID   string     `json:"cloud_id" validate:"required"`
+	Name      string     `json:"name"`
+	AccountID string     `json:"account_id" validate:"required"`
+	LbID      string     `json:"lb_id" validate:"required"`
+	CloudLbID string     `json:"cloud_lb_id" validate:"required"`
+	Protocol  string     `json:"protocol"`
+	Port      int64      `json:"port"`
+	EndPort   int64      `json:"end_port"`
+	Extension *Extension `json:"extension" validate:"required"`
+}
+
+// Validate load balancer listener batch create request.
+func (req *ListenerBatchCreateReq[T]) Validate() error {
+	if len(req.Listeners) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("listeners count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// ListenerBatchUpdateReq load balancer listener batch update req.
+type ListenerBatchUpdateReq[Extension corelb.ListenerExtension] struct {
+	Listeners []ListenerBatchUpdate[Extension] `json:"listeners" validate:"required,min=1"`
+}
+
+// ListenerBatchUpdate load balancer listener batch update.
+type ListenerBatchUpdate[Extension corelb.ListenerExtension] struct {
+	ID        string     `json:"id" validate:"required"`
+	Name      string     `json:"name"`
+	Protocol  string     `json:"protocol"`
+	Port      int64      `json:"port"`
+	EndPort   int64      `json:"end_port"`
+	Extension *Extension `json:"extension,omitempty"`
+}
+
+// Validate load balancer listener batch update request.
+func (req *ListenerBatchUpdateReq[T]) Validate() error {
+	if len(req.Listeners) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("listeners count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// ListenerListResult define load balancer listener list result.
+type ListenerListResult struct {
+	Count   uint64                `json:"count"`
+	Details []corelb.BaseListener `json:"details"`
+}
+
+// ListenerListResp define list resp.
+type ListenerListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ListenerListResult `json:"data"`
+}
+
+// ListenerExtListResult define load balancer listener with extension list result.
+type ListenerExtListResult[T corelb.ListenerExtension] struct {
+	Count   uint64               `json:"count,omitempty"`
+	Details []corelb.Listener[T] `json:"details,omitempty"`
+}
+
+// ListenerExtListResp define list resp.
+type ListenerExtListResp[T corelb.ListenerExtension] struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ListenerExtListResult[T] `json:"data"`
+}
+
+// ListenerBatchDeleteReq load balancer listener delete request, targets of
+// the deleted listeners will be deleted together.
+type ListenerBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate load balancer listener delete request.
+func (req *ListenerBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,169 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer ...
+package loadbalancer
+
+import (
+	"errors"
+	"fmt"
+
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// LoadBalancerBatchCreateReq load balancer batch create req.
+type LoadBalancerBatchCreateReq[Extension corelb.Extension] struct {
+	LoadBalancers []LoadBalancerBatchCreate[Extension] `json:"load_balancers" validate:"required,min=1"`
+}
+
+// LoadBalancerBatchCreate define load balancer batch create.
+type LoadBalancerBatchCreate[Extension corelb.Extension] struct {
+	CloudID              string                  `json:"cloud_id" validate:"required"`
+	Name                 string                  `json:"name"`
+	AccountID            string                  `json:"account_id" validate:"required"`
+	BkBizID              int64                   `json:"bk_biz_id" validate:"required"`
+	Region               string                  `json:"region"`
+	Zones                []string                `json:"zones"`
+	LBType               enumor.LoadBalancerType `json:"lb_type"`
+	Status               string                  `json:"status"`
+	CloudVpcID           string                  `json:"cloud_vpc_id"`
+	VpcID                string                  `json:"vpc_id"`
+	Domain               string                  `json:"domain"`
+	PublicIPv4Addresses  []string                `json:"public_ipv4_addresses"`
+	PrivateIPv4Addresses []string                `json:"private_ipv4_addresses"`
+	Memo                 *string                 `json:"memo"`
+	CloudCreatedTime     string                  `json:"cloud_created_time"`
+	Extension            *Extension              `json:"extension" validate:"required"`
+}
+
+// Validate load balancer batch create request.
+func (req *LoadBalancerBatchCreateReq[T]) Validate() error {
+	if len(req.LoadBalancers) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("load_balancers count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// LoadBalancerBatchUpdateReq load balancer batch update req.
+type LoadBalancerBatchUpdateReq[Extension corelb.Extension] struct {
+	LoadBalancers []LoadBalancerBatchUpdate[Extension] `json:"load_balancers" validate:"required,min=1"`
+}
+
+// LoadBalancerBatchUpdate load balancer batch update.
+type LoadBalancerBatchUpdate[Extension corelb.Extension] struct {
+	ID                   string                  `json:"id" validate:"required"`
+	Name                 string                  `json:"name"`
+	Zones                []string                `json:"zones"`
+	LBType               enumor.LoadBalancerType `json:"lb_type"`
+	Status               string                  `json:"status"`
+	CloudVpcID           string                  `json:"cloud_vpc_id"`
+	VpcID                string                  `json:"vpc_id"`
+	Domain               string                  `json:"domain"`
+	PublicIPv4Addresses  []string                `json:"public_ipv4_addresses"`
+	PrivateIPv4Addresses []string                `json:"private_ipv4_addresses"`
+	Memo                 *string                 `json:"memo"`
+	Extension            *Extension              `json:"extension,omitempty"`
+}
+
+// Validate load balancer batch update request.
+func (req *LoadBalancerBatchUpdateReq[T]) Validate() error {
+	if len(req.LoadBalancers) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("load_balancers count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// LoadBalancerCommonInfoBatchUpdateReq define load balancer common info batch update req.
+type LoadBalancerCommonInfoBatchUpdateReq struct {
+	IDs     []string `json:"ids" validate:"required"`
+	BkBizID int64    `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate load balancer common info batch update req.
+func (req *LoadBalancerCommonInfoBatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.IDs) == 0 {
+		return errors.New("ids required")
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// LoadBalancerListResult define load balancer list result.
+type LoadBalancerListResult struct {
+	Count   uint64                    `json:"count"`
+	Details []corelb.BaseLoadBalancer `json:"details"`
+}
+
+// LoadBalancerListResp define list resp.
+type LoadBalancerListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *LoadBalancerListResult `json:"data"`
+}
+
+// LoadBalancerExtListResult define load balancer with extension list result.
+type LoadBalancerExtListResult[T corelb.Extension] struct {
+	Count   uint64                   `json:"count,omitempty"`
+	Details []corelb.LoadBalancer[T] `json:"details,omitempty"`
+}
+
+// LoadBalancerExtListResp define list resp.
+type LoadBalancerExtListResp[T corelb.Extension] struct {
+	rest.BaseResp `json:",inline"`
+	Data          *LoadBalancerExtListResult[T] `json:"data"`
+}
+
+// LoadBalancerGetResp define load balancer get resp.
+type LoadBalancerGetResp[T corelb.Extension] struct {
+	rest.BaseResp `json:",inline"`
+	Data          *corelb.LoadBalancer[T] `json:"data"`
+}
+
+// -------------------------- Delete --------------------------
+
+// LoadBalancerBatchDeleteReq load balancer delete request, listeners and targets of
+// the deleted load balancers will be deleted together.
+type LoadBalancerBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate load balancer delete request.
+func (req *LoadBalancerBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// TargetBatchCreateReq load balancer target batch create req.
+type TargetBatchCreateReq[Extension corelb.TargetExtension] struct {
+	Targets []TargetBatchCreate[Extension] `json:"targets" validate:"required,min=1"`
+}
+
+// TargetBatchCreate define load balancer target batch create.
+type TargetBatchCreate[Extension corelb.TargetExtension] struct {
+	CloudID         string                        `json:"cloud_id" validate:"required"`
+	AccountID       string                        `json:"account_id" validate:"required"`
+	LbID            string                        `json:"lb_id" validate:"required"`
+	ListenerID      string                        `json:"listener_id" validate:"required"`
+	CloudListenerID string                        `json:"cloud_listener_id" validate:"required"`
+	TargetType      enumor.LoadBalancerTargetType `json:"target_type" validate:"required"`
+	CloudTargetID   string                        `json:"cloud_target_id"`
+	IP              string                        `json:"ip"`
+	Port            int64                         `json:"port"`
+	Weight          int64                         `json:"weight"`
+	Extension       *Extension                    `json:"extension" validate:"required"`
+}
+
+// Validate load balancer target batch create request.
+func (req *TargetBatchCreateReq[T]) Validate() error {
+	if len(req.Targets) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("targets count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// TargetBatchUpdateReq load balancer target batch update req.
+type TargetBatchUpdateReq[Extension corelb.TargetExtension] struct {
+	Targets []TargetBatchUpdate[Extension] `json:"targets" validate:"required,min=1"`
+}
+
+// TargetBatchUpdate load balancer target batch update.
+type TargetBatchUpdate[Extension corelb.TargetExtension] struct {
+	ID        string     `json:"id" validate:"required"`
+	IP        string     `json:"ip"`
+	Weight    int64      `json:"weight"`
+	Extension *Extension `json:"extension,omitempty"`
+}
+
+// Validate load balancer target batch update request.
+func (req *TargetBatchUpdateReq[T]) Validate() error {
+	if len(req.Targets) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("targets count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// TargetListResult define load balancer target list result.
+type TargetListResult struct {
+	Count   uint64              `json:"count"`
+	Details []corelb.BaseTarget `json:"details"`
+}
+
+// TargetListResp define list resp.
+type TargetListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *TargetListResult `json:"data"`
+}
+
+// TargetExtListResult define load balancer target with extension list result.
+type TargetExtListResult[T corelb.TargetExtension] struct {
+	Count   uint64             `json:"count,omitempty"`
+	Details []corelb.Target[T] `json:"details,omitempty"`
+}
+
+// TargetExtListResp define list resp.
+type TargetExtListResp[T corelb.TargetExtension] struct {
+	rest.BaseResp `json:",inline"`
+	Data          *TargetExtListResult[T] `json:"data"`
+}
+
+// TargetBatchDeleteReq load balancer target delete request.
+type TargetBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate load balancer target delete request.
+func (req *TargetBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
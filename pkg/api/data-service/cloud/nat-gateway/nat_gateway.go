@@ -0,0 +1,174 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway ...
+package natgateway
+
+import (
+	"errors"
+	"fmt"
+
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// NatGatewayBatchCreateReq nat gateway batch create req.
+type NatGatewayBatchCreateReq[Extension corenat.Extension] struct {
+	NatGateways []NatGatewayBatchCreate[Extension] `json:"nat_gateways" validate:"required,min=1"`
+}
+
+// NatGatewayBatchCreate define nat gateway batch create.
+type NatGatewayBatchCreate[Extension corenat.Extension] struct {
+	CloudID            string                `json:"cloud_id" validate:"required"`
+	Name               string                `json:"name"`
+	AccountID          string                `json:"account_id" validate:"required"`
+	BkBizID            int64                 `json:"bk_biz_id" validate:"required"`
+	Region             string                `json:"region"`
+	Zone               string                `json:"zone"`
+	NatType            enumor.NatGatewayType `json:"nat_type"`
+	Status             string                `json:"status"`
+	CloudVpcID         string                `json:"cloud_vpc_id"`
+	VpcID              string                `json:"vpc_id"`
+	CloudSubnetID      string                `json:"cloud_subnet_id"`
+	SubnetID           string                `json:"subnet_id"`
+	PublicIPAddresses  []string              `json:"public_ip_addresses"`
+	PrivateIPAddresses []string              `json:"private_ip_addresses"`
+	CloudEipIDs        []string              `json:"cloud_eip_ids"`
+	EipIDs             []string              `json:"eip_ids"`
+	Memo               *string               `json:"memo"`
+	CloudCreatedTime   string                `json:"cloud_created_time"`
+	Extension          *Extension            `json:"extension" validate:"required"`
+}
+
+// Validate nat gateway batch create request.
+func (req *NatGatewayBatchCreateReq[T]) Validate() error {
+	if len(req.NatGateways) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("nat_gateways count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// NatGatewayBatchUpdateReq nat gateway batch update req.
+type NatGatewayBatchUpdateReq[Extension corenat.Extension] struct {
+	NatGateways []NatGatewayBatchUpdate[Extension] `json:"nat_gateways" validate:"required,min=1"`
+}
+
+// NatGatewayBatchUpdate nat gateway batch update.
+type NatGatewayBatchUpdate[Extension corenat.Extension] struct {
+	ID                 string                `json:"id" validate:"required"`
+	Name               string                `json:"name"`
+	Zone               string                `json:"zone"`
+	NatType            enumor.NatGatewayType `json:"nat_type"`
+	Status             string                `json:"status"`
+	CloudVpcID         string                `json:"cloud_vpc_id"`
+	VpcID              string                `json:"vpc_id"`
+	CloudSubnetID      string                `json:"cloud_subnet_id"`
+	SubnetID           string                `json:"subnet_id"`
+	PublicIPAddresses  []string              `json:"public_ip_addresses"`
+	PrivateIPAddresses []string              `json:"private_ip_addresses"`
+	CloudEipIDs        []string              `json:"cloud_eip_ids"`
+	EipIDs             []string              `json:"eip_ids"`
+	Memo               *string               `json:"memo"`
+	Extension          *Extension            `json:"extension,omitempty"`
+}
+
+// Validate nat gateway batch update request.
+func (req *NatGatewayBatchUpdateReq[T]) Validate() error {
+	if len(req.NatGateways) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("nat_gateways count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// NatGatewayCommonInfoBatchUpdateReq define nat gateway common info batch update req.
+type NatGatewayCommonInfoBatchUpdateReq struct {
+	IDs     []string `json:"ids" validate:"required"`
+	BkBizID int64    `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate nat gateway common info batch update req.
+func (req *NatGatewayCommonInfoBatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.IDs) == 0 {
+		return errors.New("ids required")
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// NatGatewayListResult define nat gateway list result.
+type NatGatewayListResult struct {
+	Count   uint64                   `json:"count"`
+	Details []corenat.BaseNatGateway `json:"details"`
+}
+
+// NatGatewayListResp define list resp.
+type NatGatewayListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *NatGatewayListResult `json:"data"`
+}
+
+// NatGatewayExtListResult define nat gateway with extension list result.
+type NatGatewayExtListResult[T corenat.Extension] struct {
+	Count   uint64                  `json:"count,omitempty"`
+	Details []corenat.NatGateway[T] `json:"details,omitempty"`
+}
+
+// NatGatewayExtListResp define list resp.
+type NatGatewayExtListResp[T corenat.Extension] struct {
+	rest.BaseResp `json:",inline"`
+	Data          *NatGatewayExtListResult[T] `json:"data"`
+}
+
+// NatGatewayGetResp define nat gateway get resp.
+type NatGatewayGetResp[T corenat.Extension] struct {
+	rest.BaseResp `json:",inline"`
+	Data          *corenat.NatGateway[T] `json:"data"`
+}
+
+// -------------------------- Delete --------------------------
+
+// NatGatewayBatchDeleteReq nat gateway delete request.
+type NatGatewayBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate nat gateway delete request.
+func (req *NatGatewayBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
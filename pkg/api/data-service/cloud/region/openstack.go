@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package region
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreregion "hcm/pkg/api/core/cloud/region"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// OpenStackRegionBatchCreateReq define openstack region batch create request.
+type OpenStackRegionBatchCreateReq struct {
+	Regions []OpenStackRegionBatchCreate `json:"regions" validate:"required,dive,required"`
+}
+
+// OpenStackRegionBatchCreate define openstack region when create.
+type OpenStackRegionBatchCreate struct {
+	AccountID string `json:"account_id" validate:"required"`
+	RegionID  string `json:"region_id" validate:"required"`
+}
+
+// Validate openstack region batch create request.
+func (req *OpenStackRegionBatchCreateReq) Validate() error {
+	if len(req.Regions) == 0 {
+		return errors.New("regions is required")
+	}
+
+	if len(req.Regions) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("regions count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Delete --------------------------
+
+// OpenStackRegionBatchDeleteReq openstack region batch delete request.
+type OpenStackRegionBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate openstack region batch delete request.
+func (req *OpenStackRegionBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- List --------------------------
+
+// OpenStackRegionListReq openstack region list request.
+type OpenStackRegionListReq struct {
+	Field  []string           `json:"field" validate:"omitempty"`
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate openstack region list request.
+func (req *OpenStackRegionListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// OpenStackRegionListResult define openstack region list result.
+type OpenStackRegionListResult struct {
+	Count   uint64                       `json:"count"`
+	Details []coreregion.OpenStackRegion `json:"details"`
+}
+
+// OpenStackRegionListResp define openstack region list resp.
+type OpenStackRegionListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *OpenStackRegionListResult `json:"data"`
+}
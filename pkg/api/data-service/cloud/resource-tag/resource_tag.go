@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag defines resource tag data-service api.
+package resourcetag
+
+import (
+	"fmt"
+
+	corertag "hcm/pkg/api/core/cloud/resource-tag"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// ResourceTagBatchUpsertReq defines batch upsert resource tag request.
+type ResourceTagBatchUpsertReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	// ReplaceAll 为true时使用传入的标签覆盖资源的全部标签(用于同步)，否则只覆盖传入的标签键对应的标签
+	ReplaceAll bool                `json:"replace_all"`
+	Resources  []ResourceTagUpsert `json:"resources" validate:"required,min=1"`
+}
+
+// ResourceTagUpsert defines the tags of one resource to upsert.
+type ResourceTagUpsert struct {
+	Vendor     enumor.Vendor  `json:"vendor" validate:"required"`
+	AccountID  string         `json:"account_id" validate:"required"`
+	ResID      string         `json:"res_id" validate:"required"`
+	ResCloudID string         `json:"res_cloud_id" validate:"required"`
+	Tags       []corertag.Tag `json:"tags" validate:"omitempty,dive"`
+}
+
+// Validate ResourceTagBatchUpsertReq.
+func (c *ResourceTagBatchUpsertReq) Validate() error {
+	if len(c.Resources) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("resources count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := corertag.ValidateTagResType(c.ResType); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(c)
+}
+
+// ResourceTagListResult defines list resource tag result.
+type ResourceTagListResult struct {
+	Count   uint64                 `json:"count"`
+	Details []corertag.ResourceTag `json:"details"`
+}
+
+// ResourceTagListResp defines list resource tag response.
+type ResourceTagListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ResourceTagListResult `json:"data"`
+}
@@ -0,0 +1,178 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package sgtemplate defines security group template and security group template rel data-service api.
+package sgtemplate
+
+import (
+	"fmt"
+
+	coresgt "hcm/pkg/api/core/cloud/sg-template"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// -------------------------- Security Group Template --------------------------
+
+// SGTemplateBatchCreateReq defines batch create security group template request.
+type SGTemplateBatchCreateReq struct {
+	Templates []SGTemplateCreateReq `json:"templates" validate:"required,min=1"`
+}
+
+// SGTemplateCreateReq defines create security group template request.
+type SGTemplateCreateReq struct {
+	Name  string                 `json:"name" validate:"required,max=255"`
+	Rules []coresgt.TemplateRule `json:"rules" validate:"required,min=1,dive"`
+	Memo  *string                `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SGTemplateBatchCreateReq.
+func (c *SGTemplateBatchCreateReq) Validate() error {
+	if len(c.Templates) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("templates count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	for _, one := range c.Templates {
+		if err := validateRules(one.Rules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SGTemplateBatchUpdateReq defines batch update security group template request.
+type SGTemplateBatchUpdateReq struct {
+	Templates []SGTemplateUpdateReq `json:"templates" validate:"required,min=1"`
+}
+
+// SGTemplateUpdateReq defines update security group template request, version of template is increased when
+// rules is updated.
+type SGTemplateUpdateReq struct {
+	ID    string                 `json:"id" validate:"required"`
+	Name  string                 `json:"name" validate:"omitempty,max=255"`
+	Rules []coresgt.TemplateRule `json:"rules" validate:"omitempty,dive"`
+	Memo  *string                `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SGTemplateBatchUpdateReq.
+func (c *SGTemplateBatchUpdateReq) Validate() error {
+	if len(c.Templates) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("templates count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	for _, one := range c.Templates {
+		if err := validateRules(one.Rules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateRules(rules []coresgt.TemplateRule) error {
+	for index, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rules[%d] is invalid, err: %v", index, err)
+		}
+	}
+
+	return nil
+}
+
+// SGTemplateListResult defines list security group template result.
+type SGTemplateListResult struct {
+	Count   uint64                          `json:"count"`
+	Details []coresgt.SecurityGroupTemplate `json:"details"`
+}
+
+// SGTemplateListResp defines list security group template response.
+type SGTemplateListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *SGTemplateListResult `json:"data"`
+}
+
+// -------------------------- Security Group Template Rel --------------------------
+
+// SGTemplateRelBatchCreateReq defines batch create security group template rel request.
+type SGTemplateRelBatchCreateReq struct {
+	Rels []SGTemplateRelCreateReq `json:"rels" validate:"required,min=1"`
+}
+
+// SGTemplateRelCreateReq defines create security group template rel request.
+type SGTemplateRelCreateReq struct {
+	TemplateID string                   `json:"template_id" validate:"required"`
+	Vendor     enumor.Vendor            `json:"vendor" validate:"required"`
+	AccountID  string                   `json:"account_id" validate:"required"`
+	Region     string                   `json:"region" validate:"omitempty"`
+	ResType    enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ResID      string                   `json:"res_id" validate:"required"`
+	TargetTag  string                   `json:"target_tag" validate:"omitempty,max=64"`
+}
+
+// Validate SGTemplateRelBatchCreateReq.
+func (c *SGTemplateRelBatchCreateReq) Validate() error {
+	if len(c.Rels) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("rels count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(c)
+}
+
+// SGTemplateRelBatchUpdateReq defines batch update security group template rel request.
+type SGTemplateRelBatchUpdateReq struct {
+	Rels []SGTemplateRelUpdateReq `json:"rels" validate:"required,min=1"`
+}
+
+// SGTemplateRelUpdateReq defines update security group template rel request, only the synced version can be updated.
+type SGTemplateRelUpdateReq struct {
+	ID      string `json:"id" validate:"required"`
+	Version uint64 `json:"version" validate:"required"`
+}
+
+// Validate SGTemplateRelBatchUpdateReq.
+func (c *SGTemplateRelBatchUpdateReq) Validate() error {
+	if len(c.Rels) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("rels count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(c)
+}
+
+// SGTemplateRelListResult defines list security group template rel result.
+type SGTemplateRelListResult struct {
+	Count   uint64                             `json:"count"`
+	Details []coresgt.SecurityGroupTemplateRel `json:"details"`
+}
+
+// SGTemplateRelListResp defines list security group template rel response.
+type SGTemplateRelListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *SGTemplateRelListResult `json:"data"`
+}
@@ -0,0 +1,163 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot ...
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// SnapshotBatchCreateReq snapshot batch create req.
+type SnapshotBatchCreateReq[Extension coresnap.Extension] struct {
+	Snapshots []SnapshotBatchCreate[Extension] `json:"snapshots" validate:"required,min=1"`
+}
+
+// SnapshotBatchCreate define snapshot batch create.
+type SnapshotBatchCreate[Extension coresnap.Extension] struct {
+	CloudID          string     `json:"cloud_id" validate:"required"`
+	Name             string     `json:"name"`
+	AccountID        string     `json:"account_id" validate:"required"`
+	BkBizID          int64      `json:"bk_biz_id" validate:"required"`
+	Region           string     `json:"region"`
+	Zone             string     `json:"zone"`
+	Status           string     `json:"status"`
+	CloudDiskID      string     `json:"cloud_disk_id"`
+	DiskID           string     `json:"disk_id"`
+	DiskSize         uint64     `json:"disk_size"`
+	Encrypted        *bool      `json:"encrypted"`
+	SnapshotPolicyID string     `json:"snapshot_policy_id"`
+	Memo             *string    `json:"memo"`
+	CloudCreatedTime string     `json:"cloud_created_time"`
+	Extension        *Extension `json:"extension" validate:"required"`
+}
+
+// Validate snapshot batch create request.
+func (req *SnapshotBatchCreateReq[T]) Validate() error {
+	if len(req.Snapshots) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("snapshots count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// SnapshotBatchUpdateReq snapshot batch update req.
+type SnapshotBatchUpdateReq[Extension coresnap.Extension] struct {
+	Snapshots []SnapshotBatchUpdate[Extension] `json:"snapshots" validate:"required,min=1"`
+}
+
+// SnapshotBatchUpdate snapshot batch update.
+type SnapshotBatchUpdate[Extension coresnap.Extension] struct {
+	ID          string     `json:"id" validate:"required"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	CloudDiskID string     `json:"cloud_disk_id"`
+	DiskID      string     `json:"disk_id"`
+	DiskSize    uint64     `json:"disk_size"`
+	Encrypted   *bool      `json:"encrypted"`
+	Memo        *string    `json:"memo"`
+	Extension   *Extension `json:"extension,omitempty"`
+}
+
+// Validate snapshot batch update request.
+func (req *SnapshotBatchUpdateReq[T]) Validate() error {
+	if len(req.Snapshots) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("snapshots count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// SnapshotCommonInfoBatchUpdateReq define snapshot common info batch update req.
+type SnapshotCommonInfoBatchUpdateReq struct {
+	IDs     []string `json:"ids" validate:"required"`
+	BkBizID int64    `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate snapshot common info batch update req.
+func (req *SnapshotCommonInfoBatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.IDs) == 0 {
+		return errors.New("ids required")
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// SnapshotListResult define snapshot list result.
+type SnapshotListResult struct {
+	Count   uint64                  `json:"count"`
+	Details []coresnap.BaseSnapshot `json:"details"`
+}
+
+// SnapshotListResp define list resp.
+type SnapshotListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *SnapshotListResult `json:"data"`
+}
+
+// SnapshotExtListResult define snapshot with extension list result.
+type SnapshotExtListResult[T coresnap.Extension] struct {
+	Count   uint64                 `json:"count,omitempty"`
+	Details []coresnap.Snapshot[T] `json:"details,omitempty"`
+}
+
+// SnapshotExtListResp define list resp.
+type SnapshotExtListResp[T coresnap.Extension] struct {
+	rest.BaseResp `json:",inline"`
+	Data          *SnapshotExtListResult[T] `json:"data"`
+}
+
+// SnapshotGetResp define snapshot get resp.
+type SnapshotGetResp[T coresnap.Extension] struct {
+	rest.BaseResp `json:",inline"`
+	Data          *coresnap.Snapshot[T] `json:"data"`
+}
+
+// -------------------------- Delete --------------------------
+
+// SnapshotBatchDeleteReq snapshot delete request.
+type SnapshotBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate snapshot delete request.
+func (req *SnapshotBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
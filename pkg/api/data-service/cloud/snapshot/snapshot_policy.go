@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"fmt"
+
+	coresnap "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// SnapshotPolicyBatchCreateReq snapshot policy batch create req.
+type SnapshotPolicyBatchCreateReq struct {
+	Policies []SnapshotPolicyBatchCreate `json:"policies" validate:"required,min=1"`
+}
+
+// SnapshotPolicyBatchCreate define snapshot policy batch create.
+type SnapshotPolicyBatchCreate struct {
+	Name           string                     `json:"name" validate:"required"`
+	BkBizID        int64                      `json:"bk_biz_id" validate:"required"`
+	Scope          enumor.SnapshotPolicyScope `json:"scope" validate:"required"`
+	DiskIDs        []string                   `json:"disk_ids"`
+	Schedule       string                     `json:"schedule" validate:"required"`
+	RetentionCount uint64                     `json:"retention_count" validate:"required,min=1"`
+	Enabled        bool                       `json:"enabled"`
+	Memo           *string                    `json:"memo"`
+}
+
+// Validate snapshot policy batch create request.
+func (req *SnapshotPolicyBatchCreateReq) Validate() error {
+	if len(req.Policies) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("policies count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// SnapshotPolicyBatchUpdateReq snapshot policy batch update req.
+type SnapshotPolicyBatchUpdateReq struct {
+	Policies []SnapshotPolicyBatchUpdate `json:"policies" validate:"required,min=1"`
+}
+
+// SnapshotPolicyBatchUpdate snapshot policy batch update.
+type SnapshotPolicyBatchUpdate struct {
+	ID             string                     `json:"id" validate:"required"`
+	Name           string                     `json:"name"`
+	Scope          enumor.SnapshotPolicyScope `json:"scope"`
+	DiskIDs        []string                   `json:"disk_ids"`
+	Schedule       string                     `json:"schedule"`
+	RetentionCount uint64                     `json:"retention_count"`
+	Enabled        *bool                      `json:"enabled"`
+	LastRunAt      string                     `json:"last_run_at"`
+	Memo           *string                    `json:"memo"`
+}
+
+// Validate snapshot policy batch update request.
+func (req *SnapshotPolicyBatchUpdateReq) Validate() error {
+	if len(req.Policies) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("policies count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- List --------------------------
+
+// SnapshotPolicyListResult define snapshot policy list result.
+type SnapshotPolicyListResult struct {
+	Count   uint64                    `json:"count"`
+	Details []coresnap.SnapshotPolicy `json:"details"`
+}
+
+// SnapshotPolicyListResp define list resp.
+type SnapshotPolicyListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *SnapshotPolicyListResult `json:"data"`
+}
+
+// -------------------------- Delete --------------------------
+
+// SnapshotPolicyBatchDeleteReq snapshot policy delete request.
+type SnapshotPolicyBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate snapshot policy delete request.
+func (req *SnapshotPolicyBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
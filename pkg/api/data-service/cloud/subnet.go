@@ -57,7 +57,8 @@ func (c *SubnetBatchCreateReq[T]) Validate() error {
 
 // SubnetCreateExtension defines create subnet extensional info.
 type SubnetCreateExtension interface {
-	TCloudSubnetCreateExt | AwsSubnetCreateExt | GcpSubnetCreateExt | AzureSubnetCreateExt | HuaWeiSubnetCreateExt
+	TCloudSubnetCreateExt | AwsSubnetCreateExt | GcpSubnetCreateExt | AzureSubnetCreateExt | HuaWeiSubnetCreateExt |
+		OpenStackSubnetCreateExt
 }
 
 // TCloudSubnetCreateExt defines create tencent cloud subnet extensional info.
@@ -103,6 +104,14 @@ type HuaWeiSubnetCreateExt struct {
 	NtpAddresses []string `json:"ntp_addresses" validate:"required"`
 }
 
+// OpenStackSubnetCreateExt defines create openstack subnet extensional info.
+type OpenStackSubnetCreateExt struct {
+	IpVersion      int64    `json:"ip_version" validate:"required"`
+	GatewayIp      string   `json:"gateway_ip" validate:"omitempty"`
+	EnableDhcp     bool     `json:"enable_dhcp" validate:"omitempty"`
+	DnsNameservers []string `json:"dns_nameservers" validate:"omitempty"`
+}
+
 // -------------------------- Update --------------------------
 
 // SubnetBatchUpdateReq defines batch update subnet request.
@@ -136,7 +145,8 @@ type SubnetUpdateBaseInfo struct {
 
 // SubnetUpdateExtension defines subnet update request extensional info.
 type SubnetUpdateExtension interface {
-	TCloudSubnetUpdateExt | AwsSubnetUpdateExt | GcpSubnetUpdateExt | AzureSubnetUpdateExt | HuaWeiSubnetUpdateExt
+	TCloudSubnetUpdateExt | AwsSubnetUpdateExt | GcpSubnetUpdateExt | AzureSubnetUpdateExt | HuaWeiSubnetUpdateExt |
+		OpenStackSubnetUpdateExt
 }
 
 // TCloudSubnetUpdateExt defines update tencent cloud subnet extensional info.
@@ -183,6 +193,13 @@ type HuaWeiSubnetUpdateExt struct {
 	NtpAddresses []string `json:"ntp_addresses,omitempty" validate:"omitempty"`
 }
 
+// OpenStackSubnetUpdateExt defines update openstack subnet extensional info.
+type OpenStackSubnetUpdateExt struct {
+	GatewayIp      string   `json:"gateway_ip,omitempty" validate:"omitempty"`
+	EnableDhcp     *bool    `json:"enable_dhcp,omitempty" validate:"omitempty"`
+	DnsNameservers []string `json:"dns_nameservers,omitempty" validate:"omitempty"`
+}
+
 // SubnetBaseInfoBatchUpdateReq defines batch update subnet base info request.
 type SubnetBaseInfoBatchUpdateReq struct {
 	Subnets []SubnetBaseInfoUpdateReq `json:"subnets" validate:"required"`
@@ -0,0 +1,159 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// -------------------------- Create --------------------------
+
+// SyncTaskCreateReq define sync task create request.
+type SyncTaskCreateReq struct {
+	Vendor      enumor.Vendor          `json:"vendor" validate:"required"`
+	AccountID   string                 `json:"account_id" validate:"required"`
+	TriggerType enumor.SyncTaskTrigger `json:"trigger_type" validate:"required"`
+	StartAt     string                 `json:"start_at" validate:"required"`
+}
+
+// Validate sync task create request.
+func (req *SyncTaskCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	return req.TriggerType.Validate()
+}
+
+// SyncDetailBatchCreateReq define sync detail batch create request.
+type SyncDetailBatchCreateReq struct {
+	Details []SyncDetailCreate `json:"details" validate:"required,min=1,dive,required"`
+}
+
+// SyncDetailCreate define sync detail create.
+type SyncDetailCreate struct {
+	TaskID    string                   `json:"task_id" validate:"required"`
+	Vendor    enumor.Vendor            `json:"vendor" validate:"required"`
+	AccountID string                   `json:"account_id" validate:"required"`
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	StartAt   string                   `json:"start_at" validate:"required"`
+}
+
+// Validate sync detail batch create request.
+func (req *SyncDetailBatchCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Details) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("details count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, one := range req.Details {
+		if err := one.Vendor.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- Update --------------------------
+
+// SyncTaskUpdateReq define sync task or sync detail update request, used to record sync finish result.
+type SyncTaskUpdateReq struct {
+	Status      enumor.SyncTaskStatus `json:"status" validate:"required"`
+	ChangeCount int64                 `json:"change_count" validate:"min=0"`
+	Reason      string                `json:"reason" validate:"omitempty,max=1024"`
+	EndAt       string                `json:"end_at" validate:"omitempty"`
+}
+
+// Validate sync task update request.
+func (req *SyncTaskUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Status.Validate()
+}
+
+// SyncWatermarkSetReq define sync watermark set request, create watermark when account has no watermark.
+type SyncWatermarkSetReq struct {
+	Vendor    enumor.Vendor `json:"vendor" validate:"required"`
+	AccountID string        `json:"account_id" validate:"required"`
+	Watermark string        `json:"watermark" validate:"required,max=64"`
+}
+
+// Validate sync watermark set request.
+func (req *SyncWatermarkSetReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Vendor.Validate()
+}
+
+// -------------------------- List --------------------------
+
+// SyncTaskListResult define sync task list result.
+type SyncTaskListResult struct {
+	Count   uint64               `json:"count"`
+	Details []corecloud.SyncTask `json:"details"`
+}
+
+// SyncTaskListResp define sync task list resp.
+type SyncTaskListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *SyncTaskListResult `json:"data"`
+}
+
+// SyncDetailListResult define sync detail list result.
+type SyncDetailListResult struct {
+	Count   uint64                 `json:"count"`
+	Details []corecloud.SyncDetail `json:"details"`
+}
+
+// SyncDetailListResp define sync detail list resp.
+type SyncDetailListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *SyncDetailListResult `json:"data"`
+}
+
+// SyncWatermarkListResult define sync watermark list result.
+type SyncWatermarkListResult struct {
+	Count   uint64                    `json:"count"`
+	Details []corecloud.SyncWatermark `json:"details"`
+}
+
+// SyncWatermarkListResp define sync watermark list resp.
+type SyncWatermarkListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *SyncWatermarkListResult `json:"data"`
+}
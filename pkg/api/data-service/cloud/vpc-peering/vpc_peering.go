@@ -0,0 +1,156 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package vpcpeering defines vpc peering data-service api.
+package vpcpeering
+
+import (
+	"errors"
+	"fmt"
+
+	corevpcpeering "hcm/pkg/api/core/cloud/vpc-peering"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// VpcPeeringBatchCreateReq vpc peering batch create req.
+type VpcPeeringBatchCreateReq struct {
+	VpcPeerings []VpcPeeringBatchCreate `json:"vpc_peerings" validate:"required,min=1"`
+}
+
+// VpcPeeringBatchCreate define vpc peering batch create.
+type VpcPeeringBatchCreate struct {
+	Vendor             enumor.Vendor           `json:"vendor" validate:"required"`
+	AccountID          string                  `json:"account_id" validate:"required"`
+	Region             string                  `json:"region"`
+	CloudID            string                  `json:"cloud_id" validate:"required"`
+	Name               string                  `json:"name"`
+	Type               enumor.VpcPeeringType   `json:"type" validate:"required"`
+	Status             enumor.VpcPeeringStatus `json:"status"`
+	CloudStatus        string                  `json:"cloud_status"`
+	CloudGatewayID     string                  `json:"cloud_gateway_id"`
+	LocalVpcID         string                  `json:"local_vpc_id"`
+	LocalCloudVpcID    string                  `json:"local_cloud_vpc_id"`
+	LocalCidrs         []string                `json:"local_cidrs"`
+	PeerVpcID          string                  `json:"peer_vpc_id"`
+	PeerCloudVpcID     string                  `json:"peer_cloud_vpc_id"`
+	PeerCloudAccountID string                  `json:"peer_cloud_account_id"`
+	PeerRegion         string                  `json:"peer_region"`
+	PeerCidrs          []string                `json:"peer_cidrs"`
+	BkBizID            int64                   `json:"bk_biz_id" validate:"required"`
+	Memo               *string                 `json:"memo"`
+	CloudCreatedTime   string                  `json:"cloud_created_time"`
+}
+
+// Validate vpc peering batch create request.
+func (req *VpcPeeringBatchCreateReq) Validate() error {
+	if len(req.VpcPeerings) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("vpc_peerings count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// VpcPeeringBatchUpdateReq vpc peering batch update req.
+type VpcPeeringBatchUpdateReq struct {
+	VpcPeerings []VpcPeeringBatchUpdate `json:"vpc_peerings" validate:"required,min=1"`
+}
+
+// VpcPeeringBatchUpdate vpc peering batch update, attributes synced from cloud are all updated.
+type VpcPeeringBatchUpdate struct {
+	ID                 string                  `json:"id" validate:"required"`
+	Name               string                  `json:"name"`
+	Status             enumor.VpcPeeringStatus `json:"status"`
+	CloudStatus        string                  `json:"cloud_status"`
+	CloudGatewayID     string                  `json:"cloud_gateway_id"`
+	LocalVpcID         string                  `json:"local_vpc_id"`
+	LocalCloudVpcID    string                  `json:"local_cloud_vpc_id"`
+	LocalCidrs         []string                `json:"local_cidrs"`
+	PeerVpcID          string                  `json:"peer_vpc_id"`
+	PeerCloudVpcID     string                  `json:"peer_cloud_vpc_id"`
+	PeerCloudAccountID string                  `json:"peer_cloud_account_id"`
+	PeerRegion         string                  `json:"peer_region"`
+	PeerCidrs          []string                `json:"peer_cidrs"`
+	Memo               *string                 `json:"memo"`
+}
+
+// Validate vpc peering batch update request.
+func (req *VpcPeeringBatchUpdateReq) Validate() error {
+	if len(req.VpcPeerings) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("vpc_peerings count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// VpcPeeringCommonInfoBatchUpdateReq define vpc peering common info batch update req.
+type VpcPeeringCommonInfoBatchUpdateReq struct {
+	IDs     []string `json:"ids" validate:"required"`
+	BkBizID int64    `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate vpc peering common info batch update req.
+func (req *VpcPeeringCommonInfoBatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.IDs) == 0 {
+		return errors.New("ids required")
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// VpcPeeringListResult define vpc peering list result.
+type VpcPeeringListResult struct {
+	Count   uint64                      `json:"count"`
+	Details []corevpcpeering.VpcPeering `json:"details"`
+}
+
+// VpcPeeringListResp define list resp.
+type VpcPeeringListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *VpcPeeringListResult `json:"data"`
+}
+
+// -------------------------- Delete --------------------------
+
+// VpcPeeringBatchDeleteReq vpc peering delete request.
+type VpcPeeringBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate vpc peering delete request.
+func (req *VpcPeeringBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
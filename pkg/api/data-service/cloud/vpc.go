@@ -48,7 +48,8 @@ type VpcCreateReq[T VpcCreateExtension] struct {
 
 // VpcCreateExtension defines create vpc extensional info.
 type VpcCreateExtension interface {
-	TCloudVpcCreateExt | AwsVpcCreateExt | GcpVpcCreateExt | AzureVpcCreateExt | HuaWeiVpcCreateExt
+	TCloudVpcCreateExt | AwsVpcCreateExt | GcpVpcCreateExt | AzureVpcCreateExt | HuaWeiVpcCreateExt |
+		OpenStackVpcCreateExt
 }
 
 // TCloudVpcCreateExt defines create tencent cloud vpc extensional info.
@@ -94,6 +95,16 @@ type HuaWeiVpcCreateExt struct {
 	EnterpriseProjectID string       `json:"enterprise_project_id" validate:"omitempty"`
 }
 
+// OpenStackVpcCreateExt defines openstack vpc extensional info.
+type OpenStackVpcCreateExt struct {
+	Cidr         []OpenStackCidr `json:"cidr" validate:"omitempty"`
+	Status       string          `json:"status" validate:"required"`
+	Shared       bool            `json:"shared" validate:"omitempty"`
+	External     bool            `json:"external" validate:"omitempty"`
+	AdminStateUp bool            `json:"admin_state_up" validate:"omitempty"`
+	Mtu          int64           `json:"mtu" validate:"omitempty"`
+}
+
 // Validate VpcBatchCreateReq.
 func (c *VpcBatchCreateReq[T]) Validate() error {
 	return validator.Validate.Struct(c)
@@ -129,7 +140,8 @@ type VpcUpdateBaseInfo struct {
 
 // VpcUpdateExtension defines vpc update request extensional info.
 type VpcUpdateExtension interface {
-	TCloudVpcUpdateExt | AwsVpcUpdateExt | GcpVpcUpdateExt | AzureVpcUpdateExt | HuaWeiVpcUpdateExt
+	TCloudVpcUpdateExt | AwsVpcUpdateExt | GcpVpcUpdateExt | AzureVpcUpdateExt | HuaWeiVpcUpdateExt |
+		OpenStackVpcUpdateExt
 }
 
 // TCloudVpcUpdateExt defines tencent cloud vpc extensional info.
@@ -172,6 +184,16 @@ type HuaWeiVpcUpdateExt struct {
 	EnterpriseProjectId *string      `json:"enterprise_project_id,omitempty" validate:"omitempty"`
 }
 
+// OpenStackVpcUpdateExt defines openstack vpc extensional info.
+type OpenStackVpcUpdateExt struct {
+	Cidr         []OpenStackCidr `json:"cidr,omitempty" validate:"omitempty"`
+	Status       string          `json:"status,omitempty" validate:"omitempty"`
+	Shared       *bool           `json:"shared,omitempty" validate:"omitempty"`
+	External     *bool           `json:"external,omitempty" validate:"omitempty"`
+	AdminStateUp *bool           `json:"admin_state_up,omitempty" validate:"omitempty"`
+	Mtu          int64           `json:"mtu,omitempty" validate:"omitempty"`
+}
+
 // VpcBaseInfoBatchUpdateReq defines batch update vpc base info request.
 type VpcBaseInfoBatchUpdateReq struct {
 	Vpcs []VpcBaseInfoUpdateReq `json:"vpcs" validate:"required"`
@@ -250,3 +272,9 @@ type HuaWeiCidr struct {
 	Type enumor.IPAddressType `json:"type" validate:"required"`
 	Cidr string               `json:"cidr" validate:"required"`
 }
+
+// OpenStackCidr openstack cidr
+type OpenStackCidr struct {
+	Type enumor.IPAddressType `json:"type" validate:"required"`
+	Cidr string               `json:"cidr" validate:"required"`
+}
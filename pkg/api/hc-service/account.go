@@ -66,6 +66,19 @@ func (r *HuaWeiAccountCheckReq) Validate() error {
 	return validator.Validate.Struct(r)
 }
 
+// OpenStackAccountCheckReq ...
+type OpenStackAccountCheckReq struct {
+	CloudAuthUrl   string `json:"cloud_auth_url" validate:"required"`
+	CloudProjectID string `json:"cloud_project_id" validate:"required"`
+	CloudSecretID  string `json:"cloud_secret_id" validate:"required"`
+	CloudSecretKey string `json:"cloud_secret_key" validate:"required"`
+}
+
+// Validate ...
+func (r *OpenStackAccountCheckReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
 // GcpAccountCheckReq ...
 type GcpAccountCheckReq struct {
 	CloudProjectID        string `json:"cloud_project_id" validate:"required"`
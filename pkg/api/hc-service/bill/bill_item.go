@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// -------------------------- Sync --------------------------
+
+// BillItemSyncReq define bill item sync request.
+type BillItemSyncReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// BillMonth 账单月份，格式为yyyy-mm
+	BillMonth string `json:"bill_month" validate:"required"`
+	// BillAccountID 账单账号ID，仅gcp使用，为空时使用已配置的gcp账单账号
+	BillAccountID string `json:"bill_account_id" validate:"omitempty"`
+}
+
+// Validate bill item sync request.
+func (opt BillItemSyncReq) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse(constant.MonthLayout, opt.BillMonth); err != nil {
+		return errf.Newf(errf.InvalidParameter, "bill_month should be yyyy-mm, err: %v", err)
+	}
+
+	return nil
+}
+
+// BillItemSyncResult define bill item sync result.
+type BillItemSyncResult struct {
+	// Count 同步入库的账单明细数量
+	Count int `json:"count"`
+}
+
+// BillItemSyncResp define bill item sync response.
+type BillItemSyncResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *BillItemSyncResult `json:"data"`
+}
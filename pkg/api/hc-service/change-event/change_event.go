@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package changeevent ...
+package changeevent
+
+import (
+	"errors"
+	"time"
+
+	typeschangeevent "hcm/pkg/adaptor/types/change-event"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// ListReq list cloud resource change event request.
+type ListReq struct {
+	AccountID string    `json:"account_id" validate:"required"`
+	Region    string    `json:"region" validate:"omitempty"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required"`
+}
+
+// Validate list cloud resource change event request.
+func (req *ListReq) Validate(regionRequired bool) error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if regionRequired && len(req.Region) == 0 {
+		return errors.New("region is required")
+	}
+
+	return nil
+}
+
+// ListResp list cloud resource change event response.
+type ListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          []typeschangeevent.ChangeEvent `json:"data"`
+}
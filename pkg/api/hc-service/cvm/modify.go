@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// ChangeInstanceTypeReq define change cvm instance type req.
+type ChangeInstanceTypeReq struct {
+	InstanceType string `json:"instance_type" validate:"required"`
+}
+
+// Validate request.
+func (req *ChangeInstanceTypeReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ResizeDiskReq define resize disk attached to cvm req.
+type ResizeDiskReq struct {
+	DiskID   string `json:"disk_id" validate:"required"`
+	DiskSize int64  `json:"disk_size" validate:"required,min=1"`
+}
+
+// Validate request.
+func (req *ResizeDiskReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RenameReq define rename cvm req.
+type RenameReq struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// Validate request.
+func (req *RenameReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ChangeSecurityGroupReq define replace security groups bound to cvm req.
+type ChangeSecurityGroupReq struct {
+	SecurityGroupIDs []string `json:"security_group_ids" validate:"required,min=1"`
+}
+
+// Validate request.
+func (req *ChangeSecurityGroupReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
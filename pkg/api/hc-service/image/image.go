@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package image
+
+import (
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// ImageCreateReq create private image from cvm request, cvm must be stopped.
+type ImageCreateReq struct {
+	CvmID       string  `json:"cvm_id" validate:"required"`
+	Name        string  `json:"name" validate:"required,min=1,max=60"`
+	Description *string `json:"description" validate:"omitempty,max=255"`
+}
+
+// Validate ImageCreateReq.
+func (req *ImageCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ImageCopyReq copy private image to other regions request.
+type ImageCopyReq struct {
+	DestinationRegions []string `json:"destination_regions" validate:"required,min=1,max=10"`
+	// Name 目标地域镜像名称，为空时使用源镜像名称，腾讯云复制后的镜像与源镜像同名，不支持指定
+	Name string `json:"name" validate:"omitempty,max=60"`
+	// AgencyName 华为云跨区域复制镜像需要的IAM委托名称，仅华为云需要
+	AgencyName string `json:"agency_name" validate:"omitempty"`
+}
+
+// Validate ImageCopyReq.
+func (req *ImageCopyReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ImageCopyResult copy private image result.
+type ImageCopyResult struct {
+	// CloudIDs key为目标地域，value为目标地域的镜像云ID，异步复制的云厂商(华为云)value为空，需等待同步
+	CloudIDs map[string]string `json:"cloud_ids"`
+}
+
+// ImageCopyResp copy private image response.
+type ImageCopyResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ImageCopyResult `json:"data"`
+}
+
+// ImageShareReq share private image with other accounts of the same vendor request.
+type ImageShareReq struct {
+	AccountIDs []string `json:"account_ids" validate:"required,min=1,max=20"`
+}
+
+// Validate ImageShareReq.
+func (req *ImageShareReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
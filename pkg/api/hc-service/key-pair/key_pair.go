@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair defines ssh key pair hc-service api.
+package keypair
+
+import (
+	corekp "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// KeyPairPushReq push hcm key pair to cloud request.
+type KeyPairPushReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	Region    string `json:"region" validate:"required"`
+	KeyPairID string `json:"key_pair_id" validate:"required"`
+}
+
+// Validate KeyPairPushReq.
+func (req *KeyPairPushReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// KeyPairPushResp push hcm key pair to cloud response, returns the cloud key pair.
+type KeyPairPushResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *corekp.CloudKeyPair `json:"data"`
+}
@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer defines load balancer hc-service api.
+package loadbalancer
+
+import (
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudLoadBalancerCreateReq tcloud load balancer create request.
+type TCloudLoadBalancerCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	BkBizID   int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typelb.TCloudCreateOption
+}
+
+// Validate TCloudLoadBalancerCreateReq.
+func (req *TCloudLoadBalancerCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AwsLoadBalancerCreateReq aws load balancer create request.
+type AwsLoadBalancerCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	BkBizID   int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typelb.AwsCreateOption
+}
+
+// Validate AwsLoadBalancerCreateReq.
+func (req *AwsLoadBalancerCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// HuaWeiLoadBalancerCreateReq huawei load balancer create request.
+type HuaWeiLoadBalancerCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	BkBizID   int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typelb.HuaWeiCreateOption
+}
+
+// Validate HuaWeiLoadBalancerCreateReq.
+func (req *HuaWeiLoadBalancerCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AzureLoadBalancerCreateReq azure load balancer create request.
+type AzureLoadBalancerCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	BkBizID   int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typelb.AzureCreateOption
+}
+
+// Validate AzureLoadBalancerCreateReq.
+func (req *AzureLoadBalancerCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// GcpLoadBalancerCreateReq gcp load balancer create request.
+type GcpLoadBalancerCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	BkBizID   int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typelb.GcpCreateOption
+}
+
+// Validate GcpLoadBalancerCreateReq.
+func (req *GcpLoadBalancerCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
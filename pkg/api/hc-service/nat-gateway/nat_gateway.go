@@ -0,0 +1,86 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway defines nat gateway hc-service api.
+package natgateway
+
+import (
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudNatGatewayCreateReq tcloud nat gateway create request.
+type TCloudNatGatewayCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	BkBizID   int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typenat.TCloudCreateOption
+}
+
+// Validate TCloudNatGatewayCreateReq.
+func (req *TCloudNatGatewayCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AwsNatGatewayCreateReq aws nat gateway create request.
+type AwsNatGatewayCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	BkBizID   int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typenat.AwsCreateOption
+}
+
+// Validate AwsNatGatewayCreateReq.
+func (req *AwsNatGatewayCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// HuaWeiNatGatewayCreateReq huawei nat gateway create request.
+type HuaWeiNatGatewayCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	BkBizID   int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typenat.HuaWeiCreateOption
+}
+
+// Validate HuaWeiNatGatewayCreateReq.
+func (req *HuaWeiNatGatewayCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AzureNatGatewayCreateReq azure nat gateway create request.
+type AzureNatGatewayCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	BkBizID   int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typenat.AzureCreateOption
+}
+
+// Validate AzureNatGatewayCreateReq.
+func (req *AzureNatGatewayCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// GcpNatGatewayCreateReq gcp nat gateway create request.
+type GcpNatGatewayCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	BkBizID   int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typenat.GcpCreateOption
+}
+
+// Validate GcpNatGatewayCreateReq.
+func (req *GcpNatGatewayCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot defines disk snapshot hc-service api.
+package snapshot
+
+import (
+	typesnap "hcm/pkg/adaptor/types/snapshot"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudSnapshotCreateReq tcloud snapshot create request.
+type TCloudSnapshotCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	BkBizID   int64  `json:"bk_biz_id" validate:"omitempty"`
+	// SnapshotPolicyID 由快照策略触发创建时传入，手动创建时为空
+	SnapshotPolicyID string `json:"snapshot_policy_id" validate:"omitempty"`
+	*typesnap.TCloudCreateOption
+}
+
+// Validate TCloudSnapshotCreateReq.
+func (req *TCloudSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AwsSnapshotCreateReq aws snapshot create request.
+type AwsSnapshotCreateReq struct {
+	AccountID        string `json:"account_id" validate:"required"`
+	BkBizID          int64  `json:"bk_biz_id" validate:"omitempty"`
+	SnapshotPolicyID string `json:"snapshot_policy_id" validate:"omitempty"`
+	*typesnap.AwsCreateOption
+}
+
+// Validate AwsSnapshotCreateReq.
+func (req *AwsSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// HuaWeiSnapshotCreateReq huawei snapshot create request.
+type HuaWeiSnapshotCreateReq struct {
+	AccountID        string `json:"account_id" validate:"required"`
+	BkBizID          int64  `json:"bk_biz_id" validate:"omitempty"`
+	SnapshotPolicyID string `json:"snapshot_policy_id" validate:"omitempty"`
+	*typesnap.HuaWeiCreateOption
+}
+
+// Validate HuaWeiSnapshotCreateReq.
+func (req *HuaWeiSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AzureSnapshotCreateReq azure snapshot create request.
+type AzureSnapshotCreateReq struct {
+	AccountID        string `json:"account_id" validate:"required"`
+	BkBizID          int64  `json:"bk_biz_id" validate:"omitempty"`
+	SnapshotPolicyID string `json:"snapshot_policy_id" validate:"omitempty"`
+	*typesnap.AzureCreateOption
+}
+
+// Validate AzureSnapshotCreateReq.
+func (req *AzureSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// GcpSnapshotCreateReq gcp snapshot create request.
+type GcpSnapshotCreateReq struct {
+	AccountID        string `json:"account_id" validate:"required"`
+	BkBizID          int64  `json:"bk_biz_id" validate:"omitempty"`
+	SnapshotPolicyID string `json:"snapshot_policy_id" validate:"omitempty"`
+	*typesnap.GcpCreateOption
+}
+
+// Validate GcpSnapshotCreateReq.
+func (req *GcpSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TCloudSnapshotRollbackReq tcloud snapshot rollback request, snapshot is rolled back to its source disk.
+type TCloudSnapshotRollbackReq struct {
+	// AutoStopInstance 回滚前是否自动关闭云盘挂载的实例
+	AutoStopInstance *bool `json:"auto_stop_instance" validate:"omitempty"`
+	// AutoStartInstance 回滚完成后是否自动开启实例，仅 AutoStopInstance 为 true 时有效
+	AutoStartInstance *bool `json:"auto_start_instance" validate:"omitempty"`
+}
+
+// Validate TCloudSnapshotRollbackReq.
+func (req *TCloudSnapshotRollbackReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
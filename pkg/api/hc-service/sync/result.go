@@ -0,0 +1,118 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sync
+
+import (
+	"sort"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/rest"
+)
+
+// SyncResult 资源同步结果，记录各资源类型同步时新增、更新、删除的资源，演练模式下为将会新增、更新、删除的资源
+type SyncResult struct {
+	Details []SyncResultDetail `json:"details"`
+}
+
+// SyncResultDetail 单个资源类型的同步结果
+type SyncResultDetail struct {
+	ResType        enumor.CloudResourceType `json:"res_type"`
+	AddCount       int                      `json:"add_count"`
+	UpdateCount    int                      `json:"update_count"`
+	DeleteCount    int                      `json:"delete_count"`
+	AddCloudIDs    []string                 `json:"add_cloud_ids"`
+	UpdateCloudIDs []string                 `json:"update_cloud_ids"`
+	DeleteCloudIDs []string                 `json:"delete_cloud_ids"`
+}
+
+// Merge 合并其他同步结果，相同资源类型的云ID去重后合并。
+func (r *SyncResult) Merge(other *SyncResult) {
+	if r == nil || other == nil || len(other.Details) == 0 {
+		return
+	}
+
+	index := make(map[enumor.CloudResourceType]int, len(r.Details))
+	for i, detail := range r.Details {
+		index[detail.ResType] = i
+	}
+
+	for _, detail := range other.Details {
+		i, exist := index[detail.ResType]
+		if !exist {
+			r.Details = append(r.Details, SyncResultDetail{ResType: detail.ResType})
+			i = len(r.Details) - 1
+			index[detail.ResType] = i
+		}
+
+		one := &r.Details[i]
+		one.AddCloudIDs = mergeCloudIDs(one.AddCloudIDs, detail.AddCloudIDs)
+		one.UpdateCloudIDs = mergeCloudIDs(one.UpdateCloudIDs, detail.UpdateCloudIDs)
+		one.DeleteCloudIDs = mergeCloudIDs(one.DeleteCloudIDs, detail.DeleteCloudIDs)
+		one.AddCount = len(one.AddCloudIDs)
+		one.UpdateCount = len(one.UpdateCloudIDs)
+		one.DeleteCount = len(one.DeleteCloudIDs)
+	}
+
+	sort.Slice(r.Details, func(i, j int) bool {
+		return r.Details[i].ResType < r.Details[j].ResType
+	})
+}
+
+// ChangeCount 返回新增、更新、删除的资源总数。
+func (r *SyncResult) ChangeCount() int {
+	if r == nil {
+		return 0
+	}
+
+	count := 0
+	for _, detail := range r.Details {
+		count += detail.AddCount + detail.UpdateCount + detail.DeleteCount
+	}
+
+	return count
+}
+
+func mergeCloudIDs(origin []string, ids []string) []string {
+	if len(ids) == 0 {
+		if origin == nil {
+			return make([]string, 0)
+		}
+		return origin
+	}
+
+	exist := make(map[string]struct{}, len(origin)+len(ids))
+	result := make([]string, 0, len(origin)+len(ids))
+	for _, one := range append(origin, ids...) {
+		if _, ok := exist[one]; ok {
+			continue
+		}
+		exist[one] = struct{}{}
+		result = append(result, one)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// SyncResultResp 资源同步返回
+type SyncResultResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *SyncResult `json:"data"`
+}
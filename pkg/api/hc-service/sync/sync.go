@@ -22,6 +22,9 @@ package sync
 
 import "hcm/pkg/criteria/validator"
 
+// SyncCloudIDsMaxLimit 指定云ID同步时单次请求的最大云ID数量
+const SyncCloudIDsMaxLimit = 500
+
 // TCloudRegionSyncReq tcloud sync request
 type TCloudRegionSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
@@ -36,6 +39,9 @@ func (req *TCloudRegionSyncReq) Validate() error {
 type TCloudSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
+	DryRun    bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate tcloud sync request.
@@ -57,6 +63,9 @@ func (req *AwsRegionSyncReq) Validate() error {
 type AwsSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
+	DryRun    bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate aws sync request.
@@ -78,6 +87,9 @@ func (req *HuaWeiRegionSyncReq) Validate() error {
 type HuaWeiSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
+	DryRun    bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate huawei sync request.
@@ -85,11 +97,28 @@ func (req *HuaWeiSyncReq) Validate() error {
 	return validator.Validate.Struct(req)
 }
 
+// OpenStackSyncReq openstack sync request
+type OpenStackSyncReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	Region    string `json:"region" validate:"required"`
+	DryRun    bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
+}
+
+// Validate openstack sync request.
+func (req *OpenStackSyncReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
 // HuaWeiSubnetSyncReq huawei subnet sync request
 type HuaWeiSubnetSyncReq struct {
 	AccountID  string `json:"account_id" validate:"required"`
 	CloudVpcID string `json:"cloud_vpc_id" validate:"required"`
 	Region     string `json:"region" validate:"required"`
+	DryRun     bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate huawei sync request.
@@ -102,6 +131,9 @@ type GcpCvmSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
 	Zone      string `json:"zone" validate:"required"`
+	DryRun    bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate gcp sync request.
@@ -113,6 +145,9 @@ func (req *GcpCvmSyncReq) Validate() error {
 type GcpSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
+	DryRun    bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate gcp sync request.
@@ -123,6 +158,9 @@ func (req *GcpSyncReq) Validate() error {
 // GcpGlobalRegionResSyncReq gcp sync request
 type GcpGlobalRegionResSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
+	DryRun    bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate gcp sync request.
@@ -134,6 +172,9 @@ func (req *GcpGlobalRegionResSyncReq) Validate() error {
 type GcpDiskSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Zone      string `json:"zone" validate:"required"`
+	DryRun    bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate gcp disk sync request.
@@ -145,6 +186,9 @@ func (req *GcpDiskSyncReq) Validate() error {
 type GcpRouteSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Zone      string `json:"zone" validate:"required"`
+	DryRun    bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate gcp route sync request.
@@ -155,6 +199,9 @@ func (req *GcpRouteSyncReq) Validate() error {
 // GcpFireWallSyncReq gcp firewall sync request
 type GcpFireWallSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
+	DryRun    bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate gcp firewall sync request.
@@ -176,6 +223,9 @@ func (req *AzureResourceGroupSyncReq) Validate() error {
 type AzureSyncReq struct {
 	AccountID         string `json:"account_id" validate:"required"`
 	ResourceGroupName string `json:"resource_group_name" validate:"required"`
+	DryRun            bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate azure sync request.
@@ -188,6 +238,9 @@ type AzureSubnetSyncReq struct {
 	AccountID         string `json:"account_id" validate:"required"`
 	ResourceGroupName string `json:"resource_group_name" validate:"required"`
 	CloudVpcID        string `json:"cloud_vpc_id" validate:"required"`
+	DryRun            bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的资源云ID，为空时全量同步，不为空时只同步这些资源，用于增量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
 }
 
 // Validate azure sync request.
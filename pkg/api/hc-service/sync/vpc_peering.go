@@ -0,0 +1,37 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sync
+
+import "hcm/pkg/criteria/validator"
+
+// VpcPeeringSyncReq define sync vpc peering req.
+type VpcPeeringSyncReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// Region 按地域同步的云厂商（aws、huawei）必传，其余云厂商对等连接为全局资源，忽略该参数
+	Region string `json:"region" validate:"omitempty"`
+	DryRun bool   `json:"dry_run" validate:"omitempty"`
+	// CloudIDs 指定同步的对等连接云ID，为空时全量同步
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty,max=500"`
+}
+
+// Validate VpcPeeringSyncReq.
+func (req *VpcPeeringSyncReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -117,6 +117,7 @@ type CloudServerSetting struct {
 	CloudResource CloudResource `yaml:"cloudResource"`
 	Recycle       Recycle       `yaml:"recycle"`
 	BillConfig    BillConfig    `yaml:"billConfig"`
+	Budget        Budget        `yaml:"budget"`
 	Itsm          ApiGateway    `yaml:"itsm"`
 }
 
@@ -130,6 +131,7 @@ func (s *CloudServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.CloudResource.Sync.Scheduler.trySetDefault()
 
 	return
 }
@@ -164,6 +166,10 @@ func (s CloudServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Budget.validate(); err != nil {
+		return err
+	}
+
 	if err := s.Itsm.validate(); err != nil {
 		return err
 	}
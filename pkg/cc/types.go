@@ -27,6 +27,7 @@ import (
 	"os"
 	"time"
 
+	"hcm/pkg/cryptography"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/ssl"
 	"hcm/pkg/version"
@@ -529,22 +530,85 @@ func (s Esb) validate() error {
 
 // AesGcm Aes Gcm加密
 type AesGcm struct {
+	// Key 和 Nonce 为历史的固定Nonce加密配置，配置了密钥环后仅用于解密历史密文
 	Key   string `yaml:"key"`
 	Nonce string `yaml:"nonce"`
+	// PrimaryKeyID 密钥环中用于加密的主密钥ID
+	PrimaryKeyID string `yaml:"primaryKeyID"`
+	// Keys 密钥环，轮转密钥时新增密钥并切换主密钥，旧密钥需保留到所有数据重新加密完成
+	Keys []AesGcmKey `yaml:"keys"`
+}
+
+// AesGcmKey 密钥环中的Aes Gcm密钥
+type AesGcmKey struct {
+	ID  string `yaml:"id"`
+	Key string `yaml:"key"`
 }
 
 func (a AesGcm) validate() error {
-	if len(a.Key) != 16 && len(a.Key) != 32 {
-		return errors.New("invalid key, should be 16 or 32 bytes")
+	if len(a.Keys) == 0 && len(a.Key) == 0 {
+		return errors.New("aes gcm key or keys should be set")
+	}
+
+	if len(a.Key) != 0 || len(a.Nonce) != 0 {
+		if len(a.Key) != 16 && len(a.Key) != 32 {
+			return errors.New("invalid key, should be 16 or 32 bytes")
+		}
+
+		if len(a.Nonce) != 12 {
+			return errors.New("invalid nonce, should be 12 bytes")
+		}
+	}
+
+	if len(a.Keys) == 0 {
+		return nil
+	}
+
+	ids := make(map[string]struct{}, len(a.Keys))
+	for _, one := range a.Keys {
+		if err := cryptography.ValidateKeyID(one.ID); err != nil {
+			return err
+		}
+
+		if one.ID == cryptography.LegacyKeyID {
+			return fmt.Errorf("aes gcm key id %s is reserved", one.ID)
+		}
+
+		if _, exists := ids[one.ID]; exists {
+			return fmt.Errorf("aes gcm key id %s is duplicated", one.ID)
+		}
+		ids[one.ID] = struct{}{}
+
+		if len(one.Key) != 16 && len(one.Key) != 32 {
+			return fmt.Errorf("invalid key %s, should be 16 or 32 bytes", one.ID)
+		}
 	}
 
-	if len(a.Nonce) != 12 {
-		return errors.New("invalid nonce, should be 12 bytes")
+	if _, exists := ids[a.PrimaryKeyID]; !exists {
+		return fmt.Errorf("primary key id %s is not in keys", a.PrimaryKeyID)
 	}
 
 	return nil
 }
 
+// KeyringKeys 返回密钥环的主密钥ID及全部密钥，历史密钥以保留ID加入密钥环，未配置密钥环时作为主密钥
+func (a AesGcm) KeyringKeys() (string, map[string][]byte) {
+	keys := make(map[string][]byte, len(a.Keys)+1)
+	primaryKeyID := a.PrimaryKeyID
+	if len(a.Key) != 0 {
+		keys[cryptography.LegacyKeyID] = []byte(a.Key)
+		if len(a.Keys) == 0 {
+			primaryKeyID = cryptography.LegacyKeyID
+		}
+	}
+
+	for _, one := range a.Keys {
+		keys[one.ID] = []byte(one.Key)
+	}
+
+	return primaryKeyID, keys
+}
+
 // Crypto 定义项目里需要用到的加密，包括选择的算法等
 // TODO: 这里默认只支持AES Gcm算法，后续需要支持国密等的选择，可能还需要支持根据不同场景配置不同（比如不同场景，加密的密钥等都不一样）
 type Crypto struct {
@@ -577,6 +641,12 @@ type CloudResourceSync struct {
 	Enable                       bool   `yaml:"enable"`
 	SyncIntervalMin              uint64 `yaml:"syncIntervalMin"`
 	SyncFrequencyLimitingTimeMin uint64 `yaml:"syncFrequencyLimitingTimeMin"`
+	// IncrSyncEnable 是否开启基于云审计变更事件的增量同步
+	IncrSyncEnable bool `yaml:"incrSyncEnable"`
+	// IncrSyncIntervalMin 增量同步间隔，单位：分钟
+	IncrSyncIntervalMin uint64 `yaml:"incrSyncIntervalMin"`
+	// Scheduler 账号同步调度配置
+	Scheduler SyncScheduler `yaml:"scheduler"`
 }
 
 func (c CloudResourceSync) validate() error {
@@ -584,11 +654,65 @@ func (c CloudResourceSync) validate() error {
 		if c.SyncFrequencyLimitingTimeMin < 10 {
 			return errors.New("syncFrequencyLimitingTimeMin must > 10")
 		}
+
+		if c.IncrSyncEnable && c.IncrSyncIntervalMin < 1 {
+			return errors.New("incrSyncIntervalMin must >= 1")
+		}
+
+		if err := c.Scheduler.validate(); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// SyncScheduler 账号同步调度配置
+type SyncScheduler struct {
+	// WorkerNum 每个云厂商并发同步的账号数
+	WorkerNum uint `yaml:"workerNum"`
+	// RegionLimiter 每个云厂商每个地域调用同步接口的限流配置
+	RegionLimiter Limiter `yaml:"regionLimiter"`
+	// FailureBackoffMin 账号同步失败后暂停定时同步的时间，连续失败时翻倍，单位：分钟
+	FailureBackoffMin uint64 `yaml:"failureBackoffMin"`
+	// MaxFailureBackoffMin 账号同步失败后暂停定时同步的最大时间，单位：分钟
+	MaxFailureBackoffMin uint64 `yaml:"maxFailureBackoffMin"`
+}
+
+func (s SyncScheduler) validate() error {
+	if err := s.RegionLimiter.validate(); err != nil {
+		return fmt.Errorf("scheduler.regionLimiter %v", err)
+	}
+
+	if s.MaxFailureBackoffMin < s.FailureBackoffMin {
+		return errors.New("scheduler.maxFailureBackoffMin must >= scheduler.failureBackoffMin")
+	}
+
+	return nil
+}
+
+func (s *SyncScheduler) trySetDefault() {
+	if s.WorkerNum == 0 {
+		s.WorkerNum = 5
+	}
+
+	if s.RegionLimiter.QPS == 0 {
+		s.RegionLimiter.QPS = 5
+	}
+
+	if s.RegionLimiter.Burst == 0 {
+		s.RegionLimiter.Burst = 10
+	}
+
+	if s.FailureBackoffMin == 0 {
+		s.FailureBackoffMin = 60
+	}
+
+	if s.MaxFailureBackoffMin == 0 {
+		s.MaxFailureBackoffMin = 1440
+	}
+}
+
 // Recycle configuration.
 type Recycle struct {
 	AutoDeleteTime uint `yaml:"autoDeleteTimeHour"`
@@ -616,6 +740,31 @@ func (c BillConfig) validate() error {
 	return nil
 }
 
+// Budget 预算告警配置
+type Budget struct {
+	Enable bool `yaml:"enable"`
+	// CheckIntervalMin 预算检查间隔，单位：分钟
+	CheckIntervalMin uint64 `yaml:"checkIntervalMin"`
+	// Webhook 预算告警通知webhook配置，url为空时不发送通知
+	Webhook Webhook `yaml:"webhook"`
+}
+
+func (b Budget) validate() error {
+	if b.Enable && b.CheckIntervalMin < 1 {
+		return errors.New("budget.checkIntervalMin must >= 1")
+	}
+
+	return nil
+}
+
+// Webhook defines webhook notify config.
+type Webhook struct {
+	// URL is the address to post notify message.
+	URL string `yaml:"url"`
+	// TimeoutSec is the timeout of post notify message, unit: second.
+	TimeoutSec uint `yaml:"timeoutSec"`
+}
+
 // ApiGateway defines the api gateway config.
 type ApiGateway struct {
 	// Endpoints is a seed list of host:port addresses of api gateway.
@@ -35,6 +35,9 @@ type Client struct {
 	Cvm           *CvmClient
 	RouteTable    *RouteTableClient
 	Bill          *BillClient
+	LoadBalancer  *LoadBalancerClient
+	NatGateway    *NatGatewayClient
+	Snapshot      *SnapshotClient
 }
 
 type restClient struct {
@@ -54,5 +57,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		Cvm:           NewCloudCvmClient(client),
 		RouteTable:    NewRouteTableClient(client),
 		Bill:          NewBillClient(client),
+		LoadBalancer:  NewLoadBalancerClient(client),
+		NatGateway:    NewNatGatewayClient(client),
+		Snapshot:      NewSnapshotClient(client),
 	}
 }
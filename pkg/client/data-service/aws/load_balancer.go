@@ -0,0 +1,285 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	protolb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// NewLoadBalancerClient create a new load balancer api client.
+func NewLoadBalancerClient(client rest.ClientInterface) *LoadBalancerClient {
+	return &LoadBalancerClient{
+		client: client,
+	}
+}
+
+// LoadBalancerClient is data service load balancer api client.
+type LoadBalancerClient struct {
+	client rest.ClientInterface
+}
+
+// BatchCreateLoadBalancer batch create load balancer.
+func (cli *LoadBalancerClient) BatchCreateLoadBalancer(ctx context.Context, h http.Header,
+	request *protolb.LoadBalancerBatchCreateReq[corelb.AwsLoadBalancerExtension]) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/load_balancers/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateLoadBalancer batch update load balancer.
+func (cli *LoadBalancerClient) BatchUpdateLoadBalancer(ctx context.Context, h http.Header,
+	request *protolb.LoadBalancerBatchUpdateReq[corelb.AwsLoadBalancerExtension]) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/load_balancers/batch/update").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListLoadBalancerExt list load balancer with extension.
+func (cli *LoadBalancerClient) ListLoadBalancerExt(ctx context.Context, h http.Header,
+	request *core.ListReq) (
+	*protolb.LoadBalancerExtListResult[corelb.AwsLoadBalancerExtension], error) {
+
+	resp := new(protolb.LoadBalancerExtListResp[corelb.AwsLoadBalancerExtension])
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/load_balancers/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// GetLoadBalancer get load balancer.
+func (cli *LoadBalancerClient) GetLoadBalancer(ctx context.Context, h http.Header, id string) (
+	*corelb.LoadBalancer[corelb.AwsLoadBalancerExtension], error) {
+
+	resp := new(protolb.LoadBalancerGetResp[corelb.AwsLoadBalancerExtension])
+
+	err := cli.client.Get().
+		WithContext(ctx).
+		SubResourcef("/load_balancers/%s", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchCreateListener batch create load balancer listener.
+func (cli *LoadBalancerClient) BatchCreateListener(ctx context.Context, h http.Header,
+	request *protolb.ListenerBatchCreateReq[corelb.AwsListenerExtension]) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/load_balancers/listeners/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateListener batch update load balancer listener.
+func (cli *LoadBalancerClient) BatchUpdateListener(ctx context.Context, h http.Header,
+	request *protolb.ListenerBatchUpdateReq[corelb.AwsListenerExtension]) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/load_balancers/listeners/batch/update").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListListenerExt list load balancer listener with extension.
+func (cli *LoadBalancerClient) ListListenerExt(ctx context.Context, h http.Header,
+	request *core.ListReq) (
+	*protolb.ListenerExtListResult[corelb.AwsListenerExtension], error) {
+
+	resp := new(protolb.ListenerExtListResp[corelb.AwsListenerExtension])
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/load_balancers/listeners/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchCreateTarget batch create load balancer target.
+func (cli *LoadBalancerClient) BatchCreateTarget(ctx context.Context, h http.Header,
+	request *protolb.TargetBatchCreateReq[corelb.AwsTargetExtension]) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/load_balancers/targets/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateTarget batch update load balancer target.
+func (cli *LoadBalancerClient) BatchUpdateTarget(ctx context.Context, h http.Header,
+	request *protolb.TargetBatchUpdateReq[corelb.AwsTargetExtension]) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/load_balancers/targets/batch/update").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListTargetExt list load balancer target with extension.
+func (cli *LoadBalancerClient) ListTargetExt(ctx context.Context, h http.Header,
+	request *core.ListReq) (
+	*protolb.TargetExtListResult[corelb.AwsTargetExtension], error) {
+
+	resp := new(protolb.TargetExtListResp[corelb.AwsTargetExtension])
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/load_balancers/targets/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
@@ -27,6 +27,7 @@ import (
 	"hcm/pkg/client/data-service/gcp"
 	"hcm/pkg/client/data-service/global"
 	"hcm/pkg/client/data-service/huawei"
+	"hcm/pkg/client/data-service/openstack"
 	"hcm/pkg/client/data-service/tcloud"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/rest"
@@ -35,12 +36,13 @@ import (
 
 // Client is data-service api client.
 type Client struct {
-	Global *global.Client
-	TCloud *tcloud.Client
-	Aws    *aws.Client
-	HuaWei *huawei.Client
-	Gcp    *gcp.Client
-	Azure  *azure.Client
+	Global    *global.Client
+	TCloud    *tcloud.Client
+	Aws       *aws.Client
+	HuaWei    *huawei.Client
+	Gcp       *gcp.Client
+	Azure     *azure.Client
+	OpenStack *openstack.Client
 }
 
 // NewClient create a new data-service api client.
@@ -66,5 +68,8 @@ func NewClient(c *client.Capability, version string) *Client {
 		Azure: azure.NewClient(
 			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.Azure)),
 		),
+		OpenStack: openstack.NewClient(
+			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.OpenStack)),
+		),
 	}
 }
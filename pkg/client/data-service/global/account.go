@@ -207,3 +207,27 @@ func (a *AccountClient) ListAccountBizRel(ctx context.Context, h http.Header, re
 
 	return resp.Data, nil
 }
+
+// ReEncryptSecret re-encrypt account secret with current primary key.
+func (a *AccountClient) ReEncryptSecret(ctx context.Context, h http.Header,
+	request *protocloud.AccountSecretReEncryptReq) (*protocloud.AccountSecretReEncryptResult, error) {
+
+	resp := new(protocloud.AccountSecretReEncryptResp)
+
+	err := a.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/accounts/secrets/re_encrypt").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
@@ -87,3 +87,217 @@ func (b *BillClient) BatchDelete(ctx context.Context, h http.Header, req *datase
 
 	return nil
 }
+
+// ListBillItem list bill item.
+func (b *BillClient) ListBillItem(ctx context.Context, h http.Header, req *core.ListReq) (
+	*datacloudbillproto.BillItemListResult, error) {
+
+	resp := new(datacloudbillproto.BillItemListResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/bills/items/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchCreateBillItem batch create bill item.
+func (b *BillClient) BatchCreateBillItem(ctx context.Context, h http.Header,
+	req *datacloudbillproto.BillItemBatchCreateReq) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/bills/items/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchDeleteBillItem batch delete bill item.
+func (b *BillClient) BatchDeleteBillItem(ctx context.Context, h http.Header, req *dataservice.BatchDeleteReq) error {
+	resp := new(rest.BaseResp)
+
+	err := b.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/bills/items/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ReplaceBillItem replace all bill items of the account in the bill month.
+func (b *BillClient) ReplaceBillItem(ctx context.Context, h http.Header,
+	req *datacloudbillproto.BillItemReplaceReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/bills/items/replace").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListBillCostAllocation list bill cost allocation by biz, vendor and resource type.
+func (b *BillClient) ListBillCostAllocation(ctx context.Context, h http.Header,
+	req *datacloudbillproto.BillCostAllocationReq) (*datacloudbillproto.BillCostAllocationResult, error) {
+
+	resp := new(datacloudbillproto.BillCostAllocationResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/bills/items/allocations/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// ListExchangeRate list exchange rate.
+func (b *BillClient) ListExchangeRate(ctx context.Context, h http.Header, req *core.ListReq) (
+	*datacloudbillproto.ExchangeRateListResult, error) {
+
+	resp := new(datacloudbillproto.ExchangeRateListResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/exchange_rates/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchCreateExchangeRate batch create exchange rate.
+func (b *BillClient) BatchCreateExchangeRate(ctx context.Context, h http.Header,
+	req *datacloudbillproto.ExchangeRateBatchCreateReq) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/exchange_rates/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateExchangeRate batch update exchange rate.
+func (b *BillClient) BatchUpdateExchangeRate(ctx context.Context, h http.Header,
+	req *datacloudbillproto.ExchangeRateBatchUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := b.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/exchange_rates/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// BatchDeleteExchangeRate batch delete exchange rate.
+func (b *BillClient) BatchDeleteExchangeRate(ctx context.Context, h http.Header,
+	req *dataservice.BatchDeleteReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := b.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/exchange_rates/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	datacloudbillproto "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// ListBudget list budget.
+func (b *BillClient) ListBudget(ctx context.Context, h http.Header, req *core.ListReq) (
+	*datacloudbillproto.BudgetListResult, error) {
+
+	resp := new(datacloudbillproto.BudgetListResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/budgets/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchCreateBudget batch create budget.
+func (b *BillClient) BatchCreateBudget(ctx context.Context, h http.Header, req *datacloudbillproto.BudgetBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/budgets/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateBudget batch update budget.
+func (b *BillClient) BatchUpdateBudget(ctx context.Context, h http.Header, req *datacloudbillproto.BudgetBatchUpdateReq) error {
+	resp := new(rest.BaseResp)
+
+	err := b.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/budgets/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// BatchDeleteBudget batch delete budget.
+func (b *BillClient) BatchDeleteBudget(ctx context.Context, h http.Header, req *dataservice.BatchDeleteReq) error {
+	resp := new(rest.BaseResp)
+
+	err := b.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/budgets/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListBudgetAlert list budget alert.
+func (b *BillClient) ListBudgetAlert(ctx context.Context, h http.Header, req *core.ListReq) (
+	*datacloudbillproto.BudgetAlertListResult, error) {
+
+	resp := new(datacloudbillproto.BudgetAlertListResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/budgets/alerts/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchCreateBudgetAlert batch create budget alert.
+func (b *BillClient) BatchCreateBudgetAlert(ctx context.Context, h http.Header, req *datacloudbillproto.BudgetAlertBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := b.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/budgets/alerts/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateBudgetAlert batch update budget alert.
+func (b *BillClient) BatchUpdateBudgetAlert(ctx context.Context, h http.Header,
+	req *datacloudbillproto.BudgetAlertBatchUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := b.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/budgets/alerts/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
@@ -39,11 +39,22 @@ type Client struct {
 	SGCvmRel               *SGCvmRelClient
 	NetworkInterface       *NetworkInterfaceClient
 	NetworkInterfaceCvmRel *NetworkInterfaceCvmRelClient
+	LoadBalancer           *LoadBalancerClient
+	NatGateway             *NatGatewayClient
+	Snapshot               *SnapshotClient
+	KeyPair                *KeyPairClient
+	Bucket                 *BucketClient
+	VpcPeering             *VpcPeeringClient
+	Ipam                   *IpamClient
+	SGTemplate             *SGTemplateClient
+	ResourceTag            *ResourceTagClient
 
 	Auth          *AuthClient
 	Account       *AccountClient
 	RecycleRecord *RecycleRecordClient
 	Audit         *AuditClient
+	DriftEvent    *ResDriftEventClient
+	SyncTask      *SyncTaskClient
 
 	Application     *ApplicationClient
 	ApprovalProcess *ApprovalProcessClient
@@ -70,11 +81,22 @@ func NewClient(client rest.ClientInterface) *Client {
 		SGCvmRel:               NewCloudSGCvmRelClient(client),
 		NetworkInterface:       NewNetworkInterfaceClient(client),
 		NetworkInterfaceCvmRel: NewNetworkInterfaceCvmRelClient(client),
+		LoadBalancer:           NewLoadBalancerClient(client),
+		NatGateway:             NewNatGatewayClient(client),
+		Snapshot:               NewSnapshotClient(client),
+		KeyPair:                NewKeyPairClient(client),
+		Bucket:                 NewBucketClient(client),
+		VpcPeering:             NewVpcPeeringClient(client),
+		Ipam:                   NewIpamClient(client),
+		SGTemplate:             NewSGTemplateClient(client),
+		ResourceTag:            NewResourceTagClient(client),
 
 		Auth:          NewAuthClient(client),
 		Account:       NewAccountClient(client),
 		RecycleRecord: NewRecycleRecordClient(client),
 		Audit:         NewAuditClient(client),
+		DriftEvent:    NewResDriftEventClient(client),
+		SyncTask:      NewSyncTaskClient(client),
 
 		Application:     NewApplicationClient(client),
 		ApprovalProcess: NewApprovalProcessClient(client),
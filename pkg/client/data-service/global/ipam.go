@@ -0,0 +1,233 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	protoipam "hcm/pkg/api/data-service/cloud/ipam"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// NewIpamClient create a new ipam api client.
+func NewIpamClient(client rest.ClientInterface) *IpamClient {
+	return &IpamClient{
+		client: client,
+	}
+}
+
+// IpamClient is data service ipam cidr pool and cidr allocation api client.
+type IpamClient struct {
+	client rest.ClientInterface
+}
+
+// BatchCreateCidrPool batch create cidr pool.
+func (cli *IpamClient) BatchCreateCidrPool(ctx context.Context, h http.Header,
+	req *protoipam.CidrPoolBatchCreateReq) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/cidr_pools/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateCidrPool batch update cidr pool.
+func (cli *IpamClient) BatchUpdateCidrPool(ctx context.Context, h http.Header,
+	req *protoipam.CidrPoolBatchUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/cidr_pools/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListCidrPool list cidr pool.
+func (cli *IpamClient) ListCidrPool(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protoipam.CidrPoolListResult, error) {
+
+	resp := new(protoipam.CidrPoolListResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/cidr_pools/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchDeleteCidrPool batch delete cidr pool.
+func (cli *IpamClient) BatchDeleteCidrPool(ctx context.Context, h http.Header, req *dataservice.BatchDeleteReq) error {
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/cidr_pools/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// BatchCreateCidrAllocation batch create cidr allocation.
+func (cli *IpamClient) BatchCreateCidrAllocation(ctx context.Context, h http.Header,
+	req *protoipam.CidrAllocationBatchCreateReq) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/cidr_allocations/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateCidrAllocation batch update cidr allocation.
+func (cli *IpamClient) BatchUpdateCidrAllocation(ctx context.Context, h http.Header,
+	req *protoipam.CidrAllocationBatchUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/cidr_allocations/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListCidrAllocation list cidr allocation.
+func (cli *IpamClient) ListCidrAllocation(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protoipam.CidrAllocationListResult, error) {
+
+	resp := new(protoipam.CidrAllocationListResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/cidr_allocations/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchDeleteCidrAllocation batch delete cidr allocation.
+func (cli *IpamClient) BatchDeleteCidrAllocation(ctx context.Context, h http.Header,
+	req *dataservice.BatchDeleteReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/cidr_allocations/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	protokp "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// NewKeyPairClient create a new key pair api client.
+func NewKeyPairClient(client rest.ClientInterface) *KeyPairClient {
+	return &KeyPairClient{
+		client: client,
+	}
+}
+
+// KeyPairClient is data service key pair api client.
+type KeyPairClient struct {
+	client rest.ClientInterface
+}
+
+// BatchCreateKeyPair batch create key pair.
+func (cli *KeyPairClient) BatchCreateKeyPair(ctx context.Context, h http.Header,
+	request *protokp.KeyPairBatchCreateReq) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/key_pairs/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateKeyPair batch update key pair.
+func (cli *KeyPairClient) BatchUpdateKeyPair(ctx context.Context, h http.Header,
+	request *protokp.KeyPairBatchUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/key_pairs/batch/update").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListKeyPair list key pair.
+func (cli *KeyPairClient) ListKeyPair(ctx context.Context, h http.Header, request *core.ListReq) (
+	*protokp.KeyPairListResult, error) {
+
+	resp := new(protokp.KeyPairListResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/key_pairs/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchDeleteKeyPair batch delete key pair.
+func (cli *KeyPairClient) BatchDeleteKeyPair(ctx context.Context, h http.Header,
+	request *protokp.KeyPairBatchDeleteReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Delete().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/key_pairs/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// BatchCreateCloudKeyPair batch create cloud key pair.
+func (cli *KeyPairClient) BatchCreateCloudKeyPair(ctx context.Context, h http.Header,
+	request *protokp.CloudKeyPairBatchCreateReq) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/cloud_key_pairs/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateCloudKeyPair batch update cloud key pair.
+func (cli *KeyPairClient) BatchUpdateCloudKeyPair(ctx context.Context, h http.Header,
+	request *protokp.CloudKeyPairBatchUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/cloud_key_pairs/batch/update").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// BatchUpdateCloudKeyPairCommonInfo batch update cloud key pair common info.
+func (cli *KeyPairClient) BatchUpdateCloudKeyPairCommonInfo(ctx context.Context, h http.Header,
+	request *protokp.CloudKeyPairCommonInfoBatchUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/cloud_key_pairs/common/info/batch/update").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListCloudKeyPair list cloud key pair.
+func (cli *KeyPairClient) ListCloudKeyPair(ctx context.Context, h http.Header, request *core.ListReq) (
+	*protokp.CloudKeyPairListResult, error) {
+
+	resp := new(protokp.CloudKeyPairListResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/cloud_key_pairs/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchDeleteCloudKeyPair batch delete cloud key pair.
+func (cli *KeyPairClient) BatchDeleteCloudKeyPair(ctx context.Context, h http.Header,
+	request *protokp.KeyPairBatchDeleteReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Delete().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/cloud_key_pairs/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
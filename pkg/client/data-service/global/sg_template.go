@@ -0,0 +1,235 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	protosgt "hcm/pkg/api/data-service/cloud/sg-template"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// NewSGTemplateClient create a new security group template api client.
+func NewSGTemplateClient(client rest.ClientInterface) *SGTemplateClient {
+	return &SGTemplateClient{
+		client: client,
+	}
+}
+
+// SGTemplateClient is data service security group template and security group template rel api client.
+type SGTemplateClient struct {
+	client rest.ClientInterface
+}
+
+// BatchCreateSGTemplate batch create security group template.
+func (cli *SGTemplateClient) BatchCreateSGTemplate(ctx context.Context, h http.Header,
+	req *protosgt.SGTemplateBatchCreateReq) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/security_group_templates/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateSGTemplate batch update security group template.
+func (cli *SGTemplateClient) BatchUpdateSGTemplate(ctx context.Context, h http.Header,
+	req *protosgt.SGTemplateBatchUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/security_group_templates/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListSGTemplate list security group template.
+func (cli *SGTemplateClient) ListSGTemplate(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protosgt.SGTemplateListResult, error) {
+
+	resp := new(protosgt.SGTemplateListResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/security_group_templates/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchDeleteSGTemplate batch delete security group template.
+func (cli *SGTemplateClient) BatchDeleteSGTemplate(ctx context.Context, h http.Header,
+	req *dataservice.BatchDeleteReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/security_group_templates/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// BatchCreateSGTemplateRel batch create security group template rel.
+func (cli *SGTemplateClient) BatchCreateSGTemplateRel(ctx context.Context, h http.Header,
+	req *protosgt.SGTemplateRelBatchCreateReq) (*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/security_group_template_rels/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchUpdateSGTemplateRel batch update security group template rel.
+func (cli *SGTemplateClient) BatchUpdateSGTemplateRel(ctx context.Context, h http.Header,
+	req *protosgt.SGTemplateRelBatchUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/security_group_template_rels/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListSGTemplateRel list security group template rel.
+func (cli *SGTemplateClient) ListSGTemplateRel(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protosgt.SGTemplateRelListResult, error) {
+
+	resp := new(protosgt.SGTemplateRelListResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/security_group_template_rels/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchDeleteSGTemplateRel batch delete security group template rel.
+func (cli *SGTemplateClient) BatchDeleteSGTemplateRel(ctx context.Context, h http.Header,
+	req *dataservice.BatchDeleteReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Delete().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/security_group_template_rels/batch").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"context"
+	"net/http"
+
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// SyncTaskClient is data service sync task api client.
+type SyncTaskClient struct {
+	client rest.ClientInterface
+}
+
+// NewSyncTaskClient create a new sync task api client.
+func NewSyncTaskClient(client rest.ClientInterface) *SyncTaskClient {
+	return &SyncTaskClient{
+		client: client,
+	}
+}
+
+// CreateSyncTask create sync task.
+func (cli *SyncTaskClient) CreateSyncTask(ctx context.Context, h http.Header, req *protocloud.SyncTaskCreateReq) (
+	*core.CreateResult, error) {
+
+	resp := new(core.CreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/sync_tasks/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateSyncTask update sync task result.
+func (cli *SyncTaskClient) UpdateSyncTask(ctx context.Context, h http.Header, id string,
+	req *protocloud.SyncTaskUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/sync_tasks/%s", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListSyncTask list sync task.
+func (cli *SyncTaskClient) ListSyncTask(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protocloud.SyncTaskListResult, error) {
+
+	resp := new(protocloud.SyncTaskListResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/sync_tasks/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// BatchCreateSyncDetail batch create sync detail.
+func (cli *SyncTaskClient) BatchCreateSyncDetail(ctx context.Context, h http.Header, req *protocloud.SyncDetailBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	resp := new(core.BatchCreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/sync_details/batch/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateSyncDetail update sync detail result.
+func (cli *SyncTaskClient) UpdateSyncDetail(ctx context.Context, h http.Header, id string,
+	req *protocloud.SyncTaskUpdateReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Patch().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/sync_details/%s", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListSyncDetail list sync detail.
+func (cli *SyncTaskClient) ListSyncDetail(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protocloud.SyncDetailListResult, error) {
+
+	resp := new(protocloud.SyncDetailListResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/sync_details/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// SetSyncWatermark set account incremental sync watermark.
+func (cli *SyncTaskClient) SetSyncWatermark(ctx context.Context, h http.Header,
+	req *protocloud.SyncWatermarkSetReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Put().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/sync_watermarks/set").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ListSyncWatermark list sync watermark.
+func (cli *SyncTaskClient) ListSyncWatermark(ctx context.Context, h http.Header, req *core.ListReq) (
+	*protocloud.SyncWatermarkListResult, error) {
+
+	resp := new(protocloud.SyncWatermarkListResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/sync_watermarks/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
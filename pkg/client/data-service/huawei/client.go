@@ -35,6 +35,9 @@ type Client struct {
 	Cvm              *CvmClient
 	RouteTable       *RouteTableClient
 	NetworkInterface *NetworkInterfaceClient
+	LoadBalancer     *LoadBalancerClient
+	NatGateway       *NatGatewayClient
+	Snapshot         *SnapshotClient
 }
 
 type restClient struct {
@@ -54,5 +57,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		Cvm:              NewCloudCvmClient(client),
 		RouteTable:       NewRouteTableClient(client),
 		NetworkInterface: NewNetworkInterfaceClient(client),
+		LoadBalancer:     NewLoadBalancerClient(client),
+		NatGateway:       NewNatGatewayClient(client),
+		Snapshot:         NewSnapshotClient(client),
 	}
 }
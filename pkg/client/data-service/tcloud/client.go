@@ -34,6 +34,9 @@ type Client struct {
 	Zone          *ZoneClient
 	Cvm           *CvmClient
 	RouteTable    *RouteTableClient
+	LoadBalancer  *LoadBalancerClient
+	NatGateway    *NatGatewayClient
+	Snapshot      *SnapshotClient
 }
 
 type restClient struct {
@@ -52,5 +55,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		Zone:          NewZoneClient(client),
 		Cvm:           NewCloudCvmClient(client),
 		RouteTable:    NewRouteTableClient(client),
+		LoadBalancer:  NewLoadBalancerClient(client),
+		NatGateway:    NewNatGatewayClient(client),
+		Snapshot:      NewSnapshotClient(client),
 	}
 }
@@ -20,6 +20,7 @@
 package aws
 
 import (
+	resourcetag "hcm/pkg/client/hc-service/resource-tag"
 	"hcm/pkg/rest"
 )
 
@@ -38,6 +39,14 @@ type Client struct {
 	RouteTable    *RouteTableClient
 	InstanceType  *InstanceTypeClient
 	Bill          *BillClient
+	ChangeEvent   *ChangeEventClient
+	LoadBalancer  *LoadBalancerClient
+	NatGateway    *NatGatewayClient
+	Snapshot      *SnapshotClient
+	KeyPair       *KeyPairClient
+	Bucket        *BucketClient
+	VpcPeering    *VpcPeeringClient
+	ResourceTag   *resourcetag.Client
 }
 
 // NewClient create a new aws api client.
@@ -56,5 +65,13 @@ func NewClient(client rest.ClientInterface) *Client {
 		RouteTable:    NewRouteTableClient(client),
 		InstanceType:  NewInstanceTypeClient(client),
 		Bill:          NewBillClient(client),
+		ChangeEvent:   NewChangeEventClient(client),
+		LoadBalancer:  NewLoadBalancerClient(client),
+		NatGateway:    NewNatGatewayClient(client),
+		Snapshot:      NewSnapshotClient(client),
+		KeyPair:       NewKeyPairClient(client),
+		Bucket:        NewBucketClient(client),
+		VpcPeering:    NewVpcPeeringClient(client),
+		ResourceTag:   resourcetag.NewClient(client),
 	}
 }
@@ -25,6 +25,7 @@ import (
 
 	"hcm/pkg/api/core"
 	"hcm/pkg/api/hc-service/image"
+	"hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/rest"
 )
@@ -64,3 +65,119 @@ func (cli *ImageClient) SyncImage(ctx context.Context, h http.Header,
 
 	return nil
 }
+
+// CreateImage create private image from stopped cvm.
+func (cli *ImageClient) CreateImage(ctx context.Context, h http.Header, req *image.ImageCreateReq) (
+	*core.CreateResult, error) {
+
+	resp := new(core.CreateResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/images/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// CopyImage copy private image to other regions.
+func (cli *ImageClient) CopyImage(ctx context.Context, h http.Header, id string, req *image.ImageCopyReq) (
+	*image.ImageCopyResult, error) {
+
+	resp := new(image.ImageCopyResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/images/%s/copy", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// ShareImage share private image with other accounts of the same vendor.
+func (cli *ImageClient) ShareImage(ctx context.Context, h http.Header, id string, req *image.ImageShareReq) error {
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/images/%s/share", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// DeleteImage delete private image.
+func (cli *ImageClient) DeleteImage(ctx context.Context, h http.Header, id string) error {
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Delete().
+		WithContext(ctx).
+		Body(nil).
+		SubResourcef("/images/%s", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// SyncPrivateImage sync private image of account.
+func (cli *ImageClient) SyncPrivateImage(ctx context.Context, h http.Header, req *sync.AwsSyncReq) (
+	*sync.SyncResult, error) {
+
+	resp := new(sync.SyncResultResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/private_images/sync").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
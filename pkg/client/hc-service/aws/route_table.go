@@ -134,9 +134,10 @@ func (r *RouteTableClient) DeleteRoute(ctx context.Context, h http.Header, id st
 }
 
 // SyncRouteTable route table.
-func (r *RouteTableClient) SyncRouteTable(ctx context.Context, h http.Header, req *sync.AwsSyncReq) error {
+func (r *RouteTableClient) SyncRouteTable(ctx context.Context, h http.Header,
+	req *sync.AwsSyncReq) (*sync.SyncResult, error) {
 
-	resp := new(rest.BaseResp)
+	resp := new(sync.SyncResultResp)
 
 	err := r.client.Post().
 		WithContext(ctx).
@@ -146,12 +147,12 @@ func (r *RouteTableClient) SyncRouteTable(ctx context.Context, h http.Header, re
 		Do().
 		Into(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.Code != errf.OK {
-		return errf.New(resp.Code, resp.Message)
+		return nil, errf.New(resp.Code, resp.Message)
 	}
 
-	return nil
+	return resp.Data, nil
 }
@@ -111,8 +111,8 @@ func (v *VpcClient) Delete(ctx context.Context, h http.Header, id string) error
 }
 
 // SyncVpc aws vpc.
-func (v *VpcClient) SyncVpc(ctx context.Context, h http.Header, req *sync.AwsSyncReq) error {
-	resp := new(rest.BaseResp)
+func (v *VpcClient) SyncVpc(ctx context.Context, h http.Header, req *sync.AwsSyncReq) (*sync.SyncResult, error) {
+	resp := new(sync.SyncResultResp)
 
 	err := v.client.Post().
 		WithContext(ctx).
@@ -122,12 +122,12 @@ func (v *VpcClient) SyncVpc(ctx context.Context, h http.Header, req *sync.AwsSyn
 		Do().
 		Into(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.Code != errf.OK {
-		return errf.New(resp.Code, resp.Message)
+		return nil, errf.New(resp.Code, resp.Message)
 	}
 
-	return nil
+	return resp.Data, nil
 }
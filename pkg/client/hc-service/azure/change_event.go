@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"context"
+	"net/http"
+
+	typeschangeevent "hcm/pkg/adaptor/types/change-event"
+	changeevent "hcm/pkg/api/hc-service/change-event"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+)
+
+// ChangeEventClient is hc service change event api client.
+type ChangeEventClient struct {
+	client rest.ClientInterface
+}
+
+// NewChangeEventClient create a new change event api client.
+func NewChangeEventClient(client rest.ClientInterface) *ChangeEventClient {
+	return &ChangeEventClient{
+		client: client,
+	}
+}
+
+// List azure resource change event from cloud audit trail.
+func (cli *ChangeEventClient) List(ctx context.Context, h http.Header, req *changeevent.ListReq) (
+	[]typeschangeevent.ChangeEvent, error) {
+
+	resp := new(changeevent.ListResp)
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/change_events/list").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
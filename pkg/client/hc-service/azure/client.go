@@ -20,6 +20,7 @@
 package azure
 
 import (
+	resourcetag "hcm/pkg/client/hc-service/resource-tag"
 	"hcm/pkg/rest"
 )
 
@@ -39,6 +40,13 @@ type Client struct {
 	InstanceType     *InstanceTypeClient
 	NetworkInterface *NetworkInterfaceClient
 	Bill             *BillClient
+	ChangeEvent      *ChangeEventClient
+	LoadBalancer     *LoadBalancerClient
+	NatGateway       *NatGatewayClient
+	Snapshot         *SnapshotClient
+	Bucket           *BucketClient
+	VpcPeering       *VpcPeeringClient
+	ResourceTag      *resourcetag.Client
 }
 
 // NewClient create a new azure api client.
@@ -58,5 +66,12 @@ func NewClient(client rest.ClientInterface) *Client {
 		InstanceType:     NewInstanceTypeClient(client),
 		NetworkInterface: NewNetworkInterfaceClient(client),
 		Bill:             NewBillClient(client),
+		ChangeEvent:      NewChangeEventClient(client),
+		LoadBalancer:     NewLoadBalancerClient(client),
+		NatGateway:       NewNatGatewayClient(client),
+		Snapshot:         NewSnapshotClient(client),
+		Bucket:           NewBucketClient(client),
+		VpcPeering:       NewVpcPeeringClient(client),
+		ResourceTag:      resourcetag.NewClient(client),
 	}
 }
@@ -23,7 +23,6 @@ import (
 	"context"
 	"net/http"
 
-	"hcm/pkg/api/core"
 	"hcm/pkg/api/hc-service/disk"
 	"hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/criteria/errf"
@@ -43,8 +42,10 @@ type DiskClient struct {
 }
 
 // SyncDisk disk.
-func (cli *DiskClient) SyncDisk(ctx context.Context, h http.Header, request *sync.AzureSyncReq) error {
-	resp := new(core.SyncResp)
+func (cli *DiskClient) SyncDisk(ctx context.Context, h http.Header,
+	request *sync.AzureSyncReq) (*sync.SyncResult, error) {
+
+	resp := new(sync.SyncResultResp)
 
 	err := cli.client.Post().
 		WithContext(ctx).
@@ -54,14 +55,14 @@ func (cli *DiskClient) SyncDisk(ctx context.Context, h http.Header, request *syn
 		Do().
 		Into(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.Code != errf.OK {
-		return errf.New(resp.Code, resp.Message)
+		return nil, errf.New(resp.Code, resp.Message)
 	}
 
-	return nil
+	return resp.Data, nil
 }
 
 // AttachDisk ...
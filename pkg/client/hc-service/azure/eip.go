@@ -43,8 +43,10 @@ type EipClient struct {
 }
 
 // SyncEip sync eip.
-func (cli *EipClient) SyncEip(ctx context.Context, h http.Header, request *sync.AzureSyncReq) error {
-	resp := new(core.SyncResp)
+func (cli *EipClient) SyncEip(ctx context.Context, h http.Header,
+	request *sync.AzureSyncReq) (*sync.SyncResult, error) {
+
+	resp := new(sync.SyncResultResp)
 
 	err := cli.client.Post().
 		WithContext(ctx).
@@ -54,14 +56,14 @@ func (cli *EipClient) SyncEip(ctx context.Context, h http.Header, request *sync.
 		Do().
 		Into(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.Code != errf.OK {
-		return errf.New(resp.Code, resp.Message)
+		return nil, errf.New(resp.Code, resp.Message)
 	}
 
-	return nil
+	return resp.Data, nil
 }
 
 // DeleteEip ...
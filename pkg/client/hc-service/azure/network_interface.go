@@ -42,9 +42,9 @@ func NewNetworkInterfaceClient(client rest.ClientInterface) *NetworkInterfaceCli
 
 // SyncNetworkInterface huawei network interface.
 func (v *NetworkInterfaceClient) SyncNetworkInterface(ctx context.Context, h http.Header,
-	req *sync.AzureSyncReq) error {
+	req *sync.AzureSyncReq) (*sync.SyncResult, error) {
 
-	resp := new(rest.BaseResp)
+	resp := new(sync.SyncResultResp)
 
 	err := v.client.Post().
 		WithContext(ctx).
@@ -54,12 +54,12 @@ func (v *NetworkInterfaceClient) SyncNetworkInterface(ctx context.Context, h htt
 		Do().
 		Into(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.Code != errf.OK {
-		return errf.New(resp.Code, resp.Message)
+		return nil, errf.New(resp.Code, resp.Message)
 	}
 
-	return nil
+	return resp.Data, nil
 }
@@ -111,8 +111,10 @@ func (s *SubnetClient) Delete(ctx context.Context, h http.Header, id string) err
 }
 
 // SyncSubnet sync azure subnet.
-func (s *SubnetClient) SyncSubnet(ctx context.Context, h http.Header, req *sync.AzureSubnetSyncReq) error {
-	resp := new(rest.BaseResp)
+func (s *SubnetClient) SyncSubnet(ctx context.Context, h http.Header,
+	req *sync.AzureSubnetSyncReq) (*sync.SyncResult, error) {
+
+	resp := new(sync.SyncResultResp)
 
 	err := s.client.Post().
 		WithContext(ctx).
@@ -122,14 +124,14 @@ func (s *SubnetClient) SyncSubnet(ctx context.Context, h http.Header, req *sync.
 		Do().
 		Into(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.Code != errf.OK {
-		return errf.New(resp.Code, resp.Message)
+		return nil, errf.New(resp.Code, resp.Message)
 	}
 
-	return nil
+	return resp.Data, nil
 }
 
 // ListCountIP count tcloud subnet available ips.
@@ -111,8 +111,10 @@ func (v *VpcClient) Delete(ctx context.Context, h http.Header, id string) error
 }
 
 // SyncVpc sync azure vpc.
-func (v *VpcClient) SyncVpc(ctx context.Context, h http.Header, req *sync.AzureSyncReq) error {
-	resp := new(rest.BaseResp)
+func (v *VpcClient) SyncVpc(ctx context.Context, h http.Header,
+	req *sync.AzureSyncReq) (*sync.SyncResult, error) {
+
+	resp := new(sync.SyncResultResp)
 
 	err := v.client.Post().
 		WithContext(ctx).
@@ -122,12 +124,12 @@ func (v *VpcClient) SyncVpc(ctx context.Context, h http.Header, req *sync.AzureS
 		Do().
 		Into(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.Code != errf.OK {
-		return errf.New(resp.Code, resp.Message)
+		return nil, errf.New(resp.Code, resp.Message)
 	}
 
-	return nil
+	return resp.Data, nil
 }
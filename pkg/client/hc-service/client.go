@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/client/hc-service/azure"
 	"hcm/pkg/client/hc-service/gcp"
 	"hcm/pkg/client/hc-service/huawei"
+	"hcm/pkg/client/hc-service/openstack"
 	"hcm/pkg/client/hc-service/tcloud"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/rest"
@@ -39,6 +40,8 @@ type Client struct {
 	HuaWei *huawei.Client
 	Gcp    *gcp.Client
 	Azure  *azure.Client
+	// OpenStack 私有云
+	OpenStack *openstack.Client
 }
 
 // NewClient create a new hc-service api client.
@@ -61,5 +64,8 @@ func NewClient(c *client.Capability, version string) *Client {
 		Azure: azure.NewClient(
 			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.Azure)),
 		),
+		OpenStack: openstack.NewClient(
+			rest.NewClient(c, fmt.Sprintf("%s/%s", prefixPath, enumor.OpenStack)),
+		),
 	}
 }
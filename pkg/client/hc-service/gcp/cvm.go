@@ -23,7 +23,6 @@ import (
 	"context"
 	"net/http"
 
-	"hcm/pkg/api/core"
 	protocvm "hcm/pkg/api/hc-service/cvm"
 	"hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/criteria/errf"
@@ -43,9 +42,10 @@ type CvmClient struct {
 }
 
 // SyncCvmWithRelResource sync cvm with rel resource.
-func (cli *CvmClient) SyncCvmWithRelResource(ctx context.Context, h http.Header, request *sync.GcpCvmSyncReq) error {
+func (cli *CvmClient) SyncCvmWithRelResource(ctx context.Context, h http.Header,
+	request *sync.GcpCvmSyncReq) (*sync.SyncResult, error) {
 
-	resp := new(core.SyncResp)
+	resp := new(sync.SyncResultResp)
 
 	err := cli.client.Post().
 		WithContext(ctx).
@@ -55,14 +55,14 @@ func (cli *CvmClient) SyncCvmWithRelResource(ctx context.Context, h http.Header,
 		Do().
 		Into(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.Code != errf.OK {
-		return errf.New(resp.Code, resp.Message)
+		return nil, errf.New(resp.Code, resp.Message)
 	}
 
-	return nil
+	return resp.Data, nil
 }
 
 // StartCvm ....
@@ -44,9 +44,9 @@ type FirewallClient struct {
 
 // SyncFirewall sync fire wall.
 func (cli *FirewallClient) SyncFirewall(ctx context.Context, h http.Header,
-	request *sync.GcpGlobalRegionResSyncReq) error {
+	request *sync.GcpGlobalRegionResSyncReq) (*sync.SyncResult, error) {
 
-	resp := new(core.SyncResp)
+	resp := new(sync.SyncResultResp)
 
 	err := cli.client.Post().
 		WithContext(ctx).
@@ -56,14 +56,14 @@ func (cli *FirewallClient) SyncFirewall(ctx context.Context, h http.Header,
 		Do().
 		Into(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.Code != errf.OK {
-		return errf.New(resp.Code, resp.Message)
+		return nil, errf.New(resp.Code, resp.Message)
 	}
 
-	return nil
+	return resp.Data, nil
 }
 
 // CreateFirewallRule create firewall rule.
@@ -23,7 +23,6 @@ import (
 	"context"
 	"net/http"
 
-	"hcm/pkg/api/core"
 	protocvm "hcm/pkg/api/hc-service/cvm"
 	"hcm/pkg/api/hc-service/sync"
 	"hcm/pkg/criteria/errf"
@@ -43,9 +42,10 @@ type CvmClient struct {
 }
 
 // SyncCvmWithRelResource sync cvm with rel resource.
-func (cli *CvmClient) SyncCvmWithRelResource(ctx context.Context, h http.Header, request *sync.HuaWeiSyncReq) error {
+func (cli *CvmClient) SyncCvmWithRelResource(ctx context.Context, h http.Header,
+	request *sync.HuaWeiSyncReq) (*sync.SyncResult, error) {
 
-	resp := new(core.SyncResp)
+	resp := new(sync.SyncResultResp)
 
 	err := cli.client.Post().
 		WithContext(ctx).
@@ -55,14 +55,14 @@ func (cli *CvmClient) SyncCvmWithRelResource(ctx context.Context, h http.Header,
 		Do().
 		Into(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.Code != errf.OK {
-		return errf.New(resp.Code, resp.Message)
+		return nil, errf.New(resp.Code, resp.Message)
 	}
 
-	return nil
+	return resp.Data, nil
 }
 
 // BatchStartCvm ....
@@ -205,3 +205,99 @@ func (cli *CvmClient) BatchCreateCvm(ctx context.Context, h http.Header, request
 
 	return resp.Data, nil
 }
+
+// ChangeInstanceType ....
+func (cli *CvmClient) ChangeInstanceType(ctx context.Context, h http.Header, id string,
+	request *protocvm.ChangeInstanceTypeReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/cvms/%s/instance_type/change", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ResizeDisk ....
+func (cli *CvmClient) ResizeDisk(ctx context.Context, h http.Header, id string,
+	request *protocvm.ResizeDiskReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/cvms/%s/disks/resize", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// Rename ....
+func (cli *CvmClient) Rename(ctx context.Context, h http.Header, id string,
+	request *protocvm.RenameReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/cvms/%s/rename", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ChangeSecurityGroup ....
+func (cli *CvmClient) ChangeSecurityGroup(ctx context.Context, h http.Header, id string,
+	request *protocvm.ChangeSecurityGroupReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/cvms/%s/security_groups/change", id).
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package openstack ...
+package openstack
+
+import (
+	resourcetag "hcm/pkg/client/hc-service/resource-tag"
+	"hcm/pkg/rest"
+)
+
+// Client is a openstack api client
+type Client struct {
+	Account       *AccountClient
+	Vpc           *VpcClient
+	Subnet        *SubnetClient
+	SecurityGroup *SecurityGroupClient
+	Cvm           *CvmClient
+	Disk          *DiskClient
+	ResourceTag   *resourcetag.Client
+	Region        *RegionClient
+	Zone          *ZoneClient
+}
+
+// NewClient create a new openstack api client.
+func NewClient(client rest.ClientInterface) *Client {
+	return &Client{
+		Account:       NewAccountClient(client),
+		Vpc:           NewVpcClient(client),
+		Subnet:        NewSubnetClient(client),
+		SecurityGroup: NewSecurityGroupClient(client),
+		Cvm:           NewCvmClient(client),
+		Disk:          NewDiskClient(client),
+		ResourceTag:   resourcetag.NewClient(client),
+		Region:        NewRegionClient(client),
+		Zone:          NewZoneClient(client),
+	}
+}
@@ -64,3 +64,28 @@ func (v *BillClient) List(ctx context.Context, h http.Header, req *hcbillservice
 
 	return resp.Data, nil
 }
+
+// SyncBillItem sync bill items of the month.
+func (v *BillClient) SyncBillItem(ctx context.Context, h http.Header, req *hcbillservice.BillItemSyncReq) (
+	*hcbillservice.BillItemSyncResult, error) {
+
+	resp := new(hcbillservice.BillItemSyncResp)
+
+	err := v.client.Post().
+		WithContext(ctx).
+		Body(req).
+		SubResourcef("/bills/items/sync").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
@@ -68,9 +68,9 @@ func (cli *SecurityGroupClient) CreateSecurityGroup(ctx context.Context, h http.
 
 // SyncSecurityGroup security group.
 func (cli *SecurityGroupClient) SyncSecurityGroup(ctx context.Context, h http.Header,
-	request *sync.TCloudSyncReq) error {
+	request *sync.TCloudSyncReq) (*sync.SyncResult, error) {
 
-	resp := new(core.SyncResp)
+	resp := new(sync.SyncResultResp)
 
 	err := cli.client.Post().
 		WithContext(ctx).
@@ -80,14 +80,14 @@ func (cli *SecurityGroupClient) SyncSecurityGroup(ctx context.Context, h http.He
 		Do().
 		Into(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.Code != errf.OK {
-		return errf.New(resp.Code, resp.Message)
+		return nil, errf.New(resp.Code, resp.Message)
 	}
 
-	return nil
+	return resp.Data, nil
 }
 
 // UpdateSecurityGroup update security group rule.
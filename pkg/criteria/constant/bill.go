@@ -35,3 +35,10 @@ const (
 	StatusCreateCloudFormation = 5
 	StatusSuccess              = 100
 )
+
+const (
+	// CurrencyCNY 人民币
+	CurrencyCNY = "CNY"
+	// CurrencyUSD 美元
+	CurrencyUSD = "USD"
+)
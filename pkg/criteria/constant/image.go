@@ -26,3 +26,14 @@ const (
 	// cpu architecture is arm64
 	Arm64 = "arm64"
 )
+
+const (
+	// PublicImageType 公共镜像
+	PublicImageType = "public"
+
+	// PrivateImageType 自定义镜像，由主机创建或从其他地域复制得到，归属于具体账号
+	PrivateImageType = "private"
+
+	// SharedImageType 共享镜像，由其他账号共享给当前账号的自定义镜像，归属于被共享的账号，仅能用于创建主机
+	SharedImageType = "shared"
+)
@@ -26,6 +26,8 @@ const (
 	TimeStdFormat = "2006-01-02T15:04:05Z07:00"
 	// DateLayout is the date layout with '%Y-%m-%d
 	DateLayout = "2006-01-02"
+	// MonthLayout is the month layout with '%Y-%m
+	MonthLayout = "2006-01"
 )
 
 // TimeStdRegexp is a regular expression to match the TimeStdFormat
@@ -33,6 +33,7 @@ func (a ApplicationType) Validate() error {
 	case CreateCvm:
 	case CreateVpc:
 	case CreateDisk:
+	case ModifyCvm:
 	default:
 		return fmt.Errorf("unsupported application type: %s", a)
 	}
@@ -49,6 +50,8 @@ const (
 	CreateVpc ApplicationType = "create_vpc"
 	// CreateDisk 创建云盘
 	CreateDisk ApplicationType = "create_disk"
+	// ModifyCvm 主机变更，包括变更机型、扩容云盘、重命名、变更安全组
+	ModifyCvm ApplicationType = "modify_cvm"
 )
 
 type ApplicationStatus string
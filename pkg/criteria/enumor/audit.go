@@ -39,6 +39,15 @@ const (
 	EipAuditResType               AuditResourceType = "eip"
 	GcpFirewallRuleAuditResType   AuditResourceType = "gcp_firewall_rule"
 	NetworkInterfaceAuditResType  AuditResourceType = "network_interface"
+	LoadBalancerAuditResType      AuditResourceType = "load_balancer"
+	NatGatewayAuditResType        AuditResourceType = "nat_gateway"
+	SnapshotAuditResType          AuditResourceType = "snapshot"
+	SnapshotPolicyAuditResType    AuditResourceType = "snapshot_policy"
+	KeyPairAuditResType           AuditResourceType = "key_pair"
+	CloudKeyPairAuditResType      AuditResourceType = "cloud_key_pair"
+	BucketAuditResType            AuditResourceType = "bucket"
+	VpcPeeringAuditResType        AuditResourceType = "vpc_peering"
+	ImageAuditResType             AuditResourceType = "image"
 )
 
 // AuditResourceTypeEnums resource type map.
@@ -54,6 +63,15 @@ var AuditResourceTypeEnums = map[AuditResourceType]struct{}{
 	EipAuditResType:               {},
 	GcpFirewallRuleAuditResType:   {},
 	NetworkInterfaceAuditResType:  {},
+	LoadBalancerAuditResType:      {},
+	NatGatewayAuditResType:        {},
+	SnapshotAuditResType:          {},
+	SnapshotPolicyAuditResType:    {},
+	KeyPairAuditResType:           {},
+	CloudKeyPairAuditResType:      {},
+	BucketAuditResType:            {},
+	VpcPeeringAuditResType:        {},
+	ImageAuditResType:             {},
 }
 
 // Exist judge enum value exist.
@@ -94,6 +112,12 @@ const (
 	Bind AuditAction = "bind"
 	// Deliver 交付
 	Deliver AuditAction = "deliver"
+	// Rollback 回滚
+	Rollback AuditAction = "rollback"
+	// Copy 复制
+	Copy AuditAction = "copy"
+	// Share 共享
+	Share AuditAction = "share"
 )
 
 // AuditActionEnums op type map.
@@ -112,6 +136,9 @@ var AuditActionEnums = map[AuditAction]struct{}{
 	Disassociate: {},
 	Bind:         {},
 	Deliver:      {},
+	Rollback:     {},
+	Copy:         {},
+	Share:        {},
 }
 
 // Exist judge enum value exist.
@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// BudgetScopeType is budget scope type.
+type BudgetScopeType string
+
+// Validate BudgetScopeType.
+func (v BudgetScopeType) Validate() error {
+	switch v {
+	case AccountBudgetScope:
+	case BizBudgetScope:
+	case VendorBudgetScope:
+	default:
+		return fmt.Errorf("unsupported budget scope type: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// AccountBudgetScope budget scoped to an account, scope value is account id.
+	AccountBudgetScope BudgetScopeType = "account"
+	// BizBudgetScope budget scoped to a biz, scope value is bk_biz_id.
+	BizBudgetScope BudgetScopeType = "biz"
+	// VendorBudgetScope budget scoped to a vendor, scope value is vendor.
+	VendorBudgetScope BudgetScopeType = "vendor"
+)
+
+// BudgetNotifyStatus is budget alert notify status.
+type BudgetNotifyStatus string
+
+const (
+	// SuccessBudgetNotifyStatus budget alert is notified successfully.
+	SuccessBudgetNotifyStatus BudgetNotifyStatus = "success"
+	// FailedBudgetNotifyStatus budget alert notify failed.
+	FailedBudgetNotifyStatus BudgetNotifyStatus = "failed"
+	// SkippedBudgetNotifyStatus budget alert is not notified because no notifier is configured.
+	SkippedBudgetNotifyStatus BudgetNotifyStatus = "skipped"
+)
@@ -56,6 +56,20 @@ func (rt CloudResourceType) ConvTableName() (table.Name, error) {
 		return table.RouteTableTable, nil
 	case NetworkInterfaceCloudResType:
 		return table.NetworkInterfaceTable, nil
+	case LoadBalancerCloudResType:
+		return table.LoadBalancerTable, nil
+	case NatGatewayCloudResType:
+		return table.NatGatewayTable, nil
+	case SnapshotCloudResType:
+		return table.SnapshotTable, nil
+	case KeyPairCloudResType:
+		return table.CloudKeyPairTable, nil
+	case BucketCloudResType:
+		return table.BucketTable, nil
+	case VpcPeeringCloudResType:
+		return table.VpcPeeringTable, nil
+	case ImageCloudResType:
+		return table.ImageTable, nil
 	default:
 		return "", fmt.Errorf("%s does not have a corresponding table name", rt)
 	}
@@ -63,17 +77,28 @@ func (rt CloudResourceType) ConvTableName() (table.Name, error) {
 
 // CloudResourceType define all cloud resource type.
 const (
-	AccountCloudResType          CloudResourceType = "account"
-	SecurityGroupCloudResType    CloudResourceType = "security_group"
-	GcpFirewallRuleCloudResType  CloudResourceType = "gcp_firewall_rule"
-	VpcCloudResType              CloudResourceType = "vpc"
-	SubnetCloudResType           CloudResourceType = "subnet"
-	EipCloudResType              CloudResourceType = "eip"
-	CvmCloudResType              CloudResourceType = "cvm"
-	DiskCloudResType             CloudResourceType = "disk"
-	RouteTableCloudResType       CloudResourceType = "route_table"
-	RouteCloudResType            CloudResourceType = "route"
-	NetworkInterfaceCloudResType CloudResourceType = "network_interface"
-	RegionCloudResType           CloudResourceType = "region"
-	ImageCloudResType            CloudResourceType = "image"
+	AccountCloudResType           CloudResourceType = "account"
+	SecurityGroupCloudResType     CloudResourceType = "security_group"
+	GcpFirewallRuleCloudResType   CloudResourceType = "gcp_firewall_rule"
+	VpcCloudResType               CloudResourceType = "vpc"
+	SubnetCloudResType            CloudResourceType = "subnet"
+	EipCloudResType               CloudResourceType = "eip"
+	CvmCloudResType               CloudResourceType = "cvm"
+	DiskCloudResType              CloudResourceType = "disk"
+	RouteTableCloudResType        CloudResourceType = "route_table"
+	RouteCloudResType             CloudResourceType = "route"
+	NetworkInterfaceCloudResType  CloudResourceType = "network_interface"
+	RegionCloudResType            CloudResourceType = "region"
+	ZoneCloudResType              CloudResourceType = "zone"
+	ImageCloudResType             CloudResourceType = "image"
+	SecurityGroupRuleCloudResType CloudResourceType = "security_group_rule"
+	LoadBalancerCloudResType      CloudResourceType = "load_balancer"
+	LBListenerCloudResType        CloudResourceType = "load_balancer_listener"
+	LBTargetCloudResType          CloudResourceType = "load_balancer_target"
+	NatGatewayCloudResType        CloudResourceType = "nat_gateway"
+	SnapshotCloudResType          CloudResourceType = "snapshot"
+	KeyPairCloudResType           CloudResourceType = "cloud_key_pair"
+	BucketCloudResType            CloudResourceType = "bucket"
+	VpcPeeringCloudResType        CloudResourceType = "vpc_peering"
+	ResourceTagCloudResType       CloudResourceType = "resource_tag"
 )
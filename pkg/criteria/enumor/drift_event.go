@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// DriftEventAction is the resource change action detected by resource sync.
+type DriftEventAction string
+
+// Validate DriftEventAction.
+func (v DriftEventAction) Validate() error {
+	switch v {
+	case AddDriftEvent:
+	case UpdateDriftEvent:
+	case DeleteDriftEvent:
+	default:
+		return fmt.Errorf("unsupported drift event action: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// AddDriftEvent resource is found on cloud but not in db.
+	AddDriftEvent DriftEventAction = "add"
+	// UpdateDriftEvent resource on cloud is different from the one in db.
+	UpdateDriftEvent DriftEventAction = "update"
+	// DeleteDriftEvent resource is in db but not found on cloud.
+	DeleteDriftEvent DriftEventAction = "delete"
+)
@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// CidrAllocationStatus is the status of cidr allocated from ipam cidr pool.
+type CidrAllocationStatus string
+
+// Validate CidrAllocationStatus.
+func (s CidrAllocationStatus) Validate() error {
+	switch s {
+	case CidrReserved:
+	case CidrAllocated:
+	default:
+		return fmt.Errorf("unsupported cidr allocation status: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// CidrReserved 网段已从地址池中预留，还未关联到VPC，如VPC正在创建或手动预留的网段
+	CidrReserved CidrAllocationStatus = "reserved"
+	// CidrAllocated 网段已分配给VPC使用
+	CidrAllocated CidrAllocationStatus = "allocated"
+)
@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// LoadBalancerType is load balancer network type.
+type LoadBalancerType string
+
+// Validate LoadBalancerType.
+func (t LoadBalancerType) Validate() error {
+	switch t {
+	case InternetLoadBalancer:
+	case IntranetLoadBalancer:
+	default:
+		return fmt.Errorf("unsupported load balancer type: %s", t)
+	}
+
+	return nil
+}
+
+const (
+	// InternetLoadBalancer 公网负载均衡
+	InternetLoadBalancer LoadBalancerType = "internet"
+	// IntranetLoadBalancer 内网负载均衡
+	IntranetLoadBalancer LoadBalancerType = "intranet"
+)
+
+// LoadBalancerTargetType is load balancer backend target type.
+type LoadBalancerTargetType string
+
+const (
+	// InstanceLBTargetType 后端目标为主机
+	InstanceLBTargetType LoadBalancerTargetType = "instance"
+	// NetworkInterfaceLBTargetType 后端目标为网卡
+	NetworkInterfaceLBTargetType LoadBalancerTargetType = "network_interface"
+	// IPLBTargetType 后端目标为IP
+	IPLBTargetType LoadBalancerTargetType = "ip"
+	// InstanceGroupLBTargetType 后端目标为实例组，目前只有gcp
+	InstanceGroupLBTargetType LoadBalancerTargetType = "instance_group"
+)
@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// NatGatewayType is nat gateway network type.
+type NatGatewayType string
+
+// Validate NatGatewayType.
+func (t NatGatewayType) Validate() error {
+	switch t {
+	case PublicNatGateway:
+	case PrivateNatGateway:
+	default:
+		return fmt.Errorf("unsupported nat gateway type: %s", t)
+	}
+
+	return nil
+}
+
+const (
+	// PublicNatGateway 公网NAT网关，通过弹性公网IP访问公网
+	PublicNatGateway NatGatewayType = "public"
+	// PrivateNatGateway 私网NAT网关，仅用于私网地址转换
+	PrivateNatGateway NatGatewayType = "private"
+)
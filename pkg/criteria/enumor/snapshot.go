@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// SnapshotPolicyScope is the disk scope that snapshot policy applies to.
+type SnapshotPolicyScope string
+
+// Validate SnapshotPolicyScope.
+func (s SnapshotPolicyScope) Validate() error {
+	switch s {
+	case DiskSnapshotPolicyScope:
+	case BizSnapshotPolicyScope:
+	default:
+		return fmt.Errorf("unsupported snapshot policy scope: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// DiskSnapshotPolicyScope 快照策略作用于指定的云盘
+	DiskSnapshotPolicyScope SnapshotPolicyScope = "disk"
+	// BizSnapshotPolicyScope 快照策略作用于业务下的所有云盘
+	BizSnapshotPolicyScope SnapshotPolicyScope = "biz"
+)
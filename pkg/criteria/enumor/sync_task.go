@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// SyncTaskTrigger is account resource sync task trigger type.
+type SyncTaskTrigger string
+
+// Validate SyncTaskTrigger.
+func (v SyncTaskTrigger) Validate() error {
+	switch v {
+	case TimerSyncTaskTrigger:
+	case ManualSyncTaskTrigger:
+	case IncrementalSyncTaskTrigger:
+	default:
+		return fmt.Errorf("unsupported sync task trigger: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// TimerSyncTaskTrigger sync task is triggered by timing sync.
+	TimerSyncTaskTrigger SyncTaskTrigger = "timer"
+	// ManualSyncTaskTrigger sync task is triggered by user manually.
+	ManualSyncTaskTrigger SyncTaskTrigger = "manual"
+	// IncrementalSyncTaskTrigger sync task is triggered by incremental sync of cloud resource change events.
+	IncrementalSyncTaskTrigger SyncTaskTrigger = "incremental"
+)
+
+// SyncTaskStatus is account resource sync task and sync detail status.
+type SyncTaskStatus string
+
+// Validate SyncTaskStatus.
+func (v SyncTaskStatus) Validate() error {
+	switch v {
+	case RunningSyncTaskStatus:
+	case SuccessSyncTaskStatus:
+	case FailedSyncTaskStatus:
+	default:
+		return fmt.Errorf("unsupported sync task status: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// RunningSyncTaskStatus resource is syncing.
+	RunningSyncTaskStatus SyncTaskStatus = "running"
+	// SuccessSyncTaskStatus resource sync succeeded.
+	SuccessSyncTaskStatus SyncTaskStatus = "success"
+	// FailedSyncTaskStatus resource sync failed.
+	FailedSyncTaskStatus SyncTaskStatus = "failed"
+)
@@ -32,6 +32,7 @@ func (v Vendor) Validate() error {
 	case Gcp:
 	case Azure:
 	case HuaWei:
+	case OpenStack:
 	default:
 		return fmt.Errorf("unsupported cloud vendor: %s", v)
 	}
@@ -50,4 +51,6 @@ const (
 	Azure Vendor = "azure"
 	// HuaWei is hua wei cloud.
 	HuaWei Vendor = "huawei"
+	// OpenStack is openstack private cloud.
+	OpenStack Vendor = "openstack"
 )
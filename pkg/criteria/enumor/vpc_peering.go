@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// VpcPeeringType is the type of connection between vpcs.
+type VpcPeeringType string
+
+// Validate VpcPeeringType.
+func (t VpcPeeringType) Validate() error {
+	switch t {
+	case PeeringConnection:
+	case TransitGatewayAttachment:
+	case CcnAttachment:
+	default:
+		return fmt.Errorf("unsupported vpc peering type: %s", t)
+	}
+
+	return nil
+}
+
+// IsHubAttachment returns whether the vpc is attached to a hub which connects multiple vpcs.
+func (t VpcPeeringType) IsHubAttachment() bool {
+	return t == TransitGatewayAttachment || t == CcnAttachment
+}
+
+const (
+	// PeeringConnection 对等连接，直接连通本端和对端两个VPC
+	PeeringConnection VpcPeeringType = "peering"
+	// TransitGatewayAttachment 中转网关的VPC关联，关联到同一中转网关的VPC之间通过中转网关互通
+	TransitGatewayAttachment VpcPeeringType = "transit_gateway_attachment"
+	// CcnAttachment 云联网的VPC关联，关联到同一云联网的VPC之间通过云联网互通
+	CcnAttachment VpcPeeringType = "ccn_attachment"
+)
+
+// VpcPeeringStatus is the normalized status of vpc peering.
+type VpcPeeringStatus string
+
+const (
+	// VpcPeeringActive 连接已建立，可以转发流量
+	VpcPeeringActive VpcPeeringStatus = "active"
+	// VpcPeeringPending 连接正在创建或等待对端接受
+	VpcPeeringPending VpcPeeringStatus = "pending"
+	// VpcPeeringInactive 连接已拒绝、失败、过期或正在删除，不能转发流量
+	VpcPeeringInactive VpcPeeringStatus = "inactive"
+)
@@ -0,0 +1,221 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/TencentBlueKing/gopkg/conv"
+)
+
+const (
+	// EnvelopeVersion 当前信封加密密文的版本号
+	EnvelopeVersion = "v1"
+	// envelopeSep 信封加密密文各部分之间的分隔符，不在标准Base64字符集中，可用于区分历史密文
+	envelopeSep = ":"
+	// LegacyKeyID 历史固定Nonce配置中的密钥在密钥环中的保留ID
+	LegacyKeyID = "legacy"
+)
+
+var keyIDRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// ValidateKeyID 校验密钥ID，密钥ID会写入密文中，只允许字母、数字、下划线、中划线，最长32位
+func ValidateKeyID(id string) error {
+	if !keyIDRegexp.MatchString(id) {
+		return fmt.Errorf("invalid key id %s, should match %s", id, keyIDRegexp.String())
+	}
+	return nil
+}
+
+// AESGcmKeyring 基于AES Gcm的信封加密密钥环。
+// 加密时使用主密钥及每次随机生成的Nonce，密文格式为: v1:<keyID>:Base64(nonce+ciphertext)；
+// 解密时根据密文中的密钥ID选择密钥，对于不带版本前缀的历史密文，使用固定Nonce的历史加密器解密。
+type AESGcmKeyring struct {
+	primaryKeyID string
+	keys         map[string]cipher.AEAD
+	legacy       *AESGcm
+}
+
+// NewAESGcmKeyring new aes gcm keyring. legacy 为历史固定Nonce的加密器，仅用于解密历史密文，可为空。
+func NewAESGcmKeyring(primaryKeyID string, keys map[string][]byte, legacy *AESGcm) (*AESGcmKeyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keyring keys is required")
+	}
+
+	ring := &AESGcmKeyring{
+		primaryKeyID: primaryKeyID,
+		keys:         make(map[string]cipher.AEAD, len(keys)),
+		legacy:       legacy,
+	}
+	for id, key := range keys {
+		if err := ValidateKeyID(id); err != nil {
+			return nil, err
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("init key %s failed, err: %v", id, err)
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("init key %s gcm failed, err: %v", id, err)
+		}
+		ring.keys[id] = aead
+	}
+
+	if _, exists := ring.keys[primaryKeyID]; !exists {
+		return nil, fmt.Errorf("primary key %s not in keyring", primaryKeyID)
+	}
+
+	return ring, nil
+}
+
+// PrimaryKeyID 返回当前用于加密的主密钥ID
+func (r *AESGcmKeyring) PrimaryKeyID() string {
+	return r.primaryKeyID
+}
+
+// Encrypt 使用主密钥及随机Nonce加密，返回带版本及密钥ID的信封密文
+func (r *AESGcmKeyring) Encrypt(plaintext []byte) []byte {
+	aead := r.keys[r.primaryKeyID]
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		// 系统随机数不可用时无法保证加密安全，不能降级
+		panic(fmt.Sprintf("generate random nonce failed, err: %v", err))
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, nil)
+
+	envelope := EnvelopeVersion + envelopeSep + r.primaryKeyID + envelopeSep +
+		base64.StdEncoding.EncodeToString(sealed)
+
+	return conv.StringToBytes(envelope)
+}
+
+// Decrypt 解密信封密文，不带版本前缀的密文当作历史密文解密
+func (r *AESGcmKeyring) Decrypt(encryptedText []byte) ([]byte, error) {
+	keyID, payload, isEnvelope, err := parseEnvelope(conv.BytesToString(encryptedText))
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEnvelope {
+		if r.legacy == nil {
+			return nil, errors.New("legacy cipher is not configured, can not decrypt unversioned text")
+		}
+		return r.legacy.Decrypt(encryptedText)
+	}
+
+	return r.open(keyID, payload)
+}
+
+// EncryptToString 同 Encrypt，返回字符串格式的信封密文
+func (r *AESGcmKeyring) EncryptToString(plaintext []byte) string {
+	return conv.BytesToString(r.Encrypt(plaintext))
+}
+
+// DecryptString 同 Decrypt，解密字符串格式的信封密文
+func (r *AESGcmKeyring) DecryptString(encryptedText string) ([]byte, error) {
+	return r.Decrypt(conv.StringToBytes(encryptedText))
+}
+
+// EncryptToBase64 将字符串明文加密为信封密文，信封中的密文部分为Base64格式
+func (r *AESGcmKeyring) EncryptToBase64(plaintext string) string {
+	return r.EncryptToString(conv.StringToBytes(plaintext))
+}
+
+// DecryptFromBase64 解密信封密文，兼容历史Base64格式的AES Gcm密文
+func (r *AESGcmKeyring) DecryptFromBase64(encryptedTextB64 string) (string, error) {
+	keyID, payload, isEnvelope, err := parseEnvelope(encryptedTextB64)
+	if err != nil {
+		return "", err
+	}
+
+	if !isEnvelope {
+		if r.legacy == nil {
+			return "", errors.New("legacy cipher is not configured, can not decrypt unversioned text")
+		}
+		return r.legacy.DecryptFromBase64(encryptedTextB64)
+	}
+
+	plaintext, err := r.open(keyID, payload)
+	if err != nil {
+		return "", err
+	}
+
+	return conv.BytesToString(plaintext), nil
+}
+
+// IsPrimaryKeyEncrypted 判断密文是否已使用当前主密钥加密，用于密钥轮转时跳过无需重新加密的数据
+func (r *AESGcmKeyring) IsPrimaryKeyEncrypted(encryptedText string) bool {
+	keyID, _, isEnvelope, err := parseEnvelope(encryptedText)
+	if err != nil || !isEnvelope {
+		return false
+	}
+
+	return keyID == r.primaryKeyID
+}
+
+func (r *AESGcmKeyring) open(keyID string, payload string) ([]byte, error) {
+	aead, exists := r.keys[keyID]
+	if !exists {
+		return nil, fmt.Errorf("key %s not in keyring", keyID)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("decode envelope payload failed, err: %v", err)
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("envelope payload is too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+// parseEnvelope 解析信封密文，返回密钥ID及Base64格式的密文部分，历史密文不包含分隔符，isEnvelope为false
+func parseEnvelope(text string) (keyID string, payload string, isEnvelope bool, err error) {
+	if !strings.Contains(text, envelopeSep) {
+		return "", "", false, nil
+	}
+
+	parts := strings.SplitN(text, envelopeSep, 3)
+	if len(parts) != 3 {
+		return "", "", false, errors.New("invalid envelope encrypted text")
+	}
+
+	if parts[0] != EnvelopeVersion {
+		return "", "", false, fmt.Errorf("unsupported envelope version %s", parts[0])
+	}
+
+	return parts[1], parts[2], true, nil
+}
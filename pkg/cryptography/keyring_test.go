@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cryptography
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAESGcmKeyring(t *testing.T) {
+	legacy, err := NewAESGcm([]byte("0123456789abcdef"), []byte("0123456789ab"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyText := legacy.EncryptToBase64("secret")
+
+	oldRing, err := NewAESGcmKeyring("k1", map[string][]byte{"k1": []byte("abcdef0123456789")}, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, second := oldRing.EncryptToBase64("secret"), oldRing.EncryptToBase64("secret")
+	if first == second {
+		t.Errorf("same plaintext should be encrypted with different nonce, got %s", first)
+	}
+	if !strings.HasPrefix(first, "v1:k1:") {
+		t.Errorf("encrypted text should contain version and key id, got %s", first)
+	}
+
+	ring, err := NewAESGcmKeyring("k2", map[string][]byte{
+		"k1": []byte("abcdef0123456789"),
+		"k2": []byte("abcdef0123456789abcdef0123456789"),
+	}, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, text := range []string{legacyText, first} {
+		if ring.IsPrimaryKeyEncrypted(text) {
+			t.Errorf("%s should not be encrypted by primary key", text)
+		}
+
+		plaintext, err := ring.DecryptFromBase64(text)
+		if err != nil {
+			t.Errorf("decrypt %s failed, err: %v", text, err)
+			continue
+		}
+		if plaintext != "secret" {
+			t.Errorf("decrypt %s got %s, expect secret", text, plaintext)
+		}
+	}
+
+	rotated := ring.EncryptToBase64("secret")
+	if !ring.IsPrimaryKeyEncrypted(rotated) {
+		t.Errorf("%s should be encrypted by primary key", rotated)
+	}
+
+	if _, err := oldRing.DecryptFromBase64(rotated); err == nil {
+		t.Errorf("decrypt text of unknown key should failed")
+	}
+
+	tampered := rotated[:len(rotated)-4] + "AAA="
+	if _, err := ring.DecryptFromBase64(tampered); err == nil {
+		t.Errorf("decrypt tampered text should failed")
+	}
+
+	raw, err := ring.Decrypt(ring.Encrypt([]byte("secret")))
+	if err != nil || string(raw) != "secret" {
+		t.Errorf("decrypt bytes failed, got %s, err: %v", raw, err)
+	}
+}
@@ -30,3 +30,9 @@ type Crypto interface {
 	EncryptToBase64(plaintext string) string
 	DecryptFromBase64(encryptedTextB64 string) (string, error)
 }
+
+// KeyRotator 支持密钥轮转的加解密器，用于判断密文是否需要使用主密钥重新加密
+type KeyRotator interface {
+	Crypto
+	IsPrimaryKeyEncrypted(encryptedText string) bool
+}
@@ -68,12 +68,13 @@ type tableNames struct {
 // DeleteValidate ...
 func (a AccountDao) DeleteValidate(kt *kit.Kit, accountID string) (map[string]uint64, error) {
 	ingoreTable := map[table.Name]struct{}{
-		table.AuditTable:                   {},
-		table.AccountBizRelTable:           {},
-		table.AwsSecurityGroupRuleTable:    {},
-		table.AzureSecurityGroupRuleTable:  {},
-		table.TCloudSecurityGroupRuleTable: {},
-		table.HuaWeiSecurityGroupRuleTable: {},
+		table.AuditTable:                      {},
+		table.AccountBizRelTable:              {},
+		table.AwsSecurityGroupRuleTable:       {},
+		table.AzureSecurityGroupRuleTable:     {},
+		table.TCloudSecurityGroupRuleTable:    {},
+		table.HuaWeiSecurityGroupRuleTable:    {},
+		table.OpenStackSecurityGroupRuleTable: {},
 	}
 
 	expr := `select table_name as name from information_schema.columns where column_name = :column_name;`
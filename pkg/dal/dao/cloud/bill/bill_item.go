@@ -0,0 +1,236 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesbill "hcm/pkg/dal/dao/types/bill"
+	"hcm/pkg/dal/table"
+	tablebill "hcm/pkg/dal/table/cloud/bill"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BillItem only used for bill item.
+type BillItem interface {
+	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablebill.BillItemTable) ([]string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*typesbill.ListBillItemDetails, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+	ListCostAllocation(kt *kit.Kit, opt *typesbill.BillCostAllocationOption) ([]typesbill.BillCostAllocation, error)
+}
+
+var _ BillItem = new(BillItemDao)
+
+// BillItemDao bill item dao.
+type BillItemDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// CreateWithTx create bill item with tx.
+func (b BillItemDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablebill.BillItemTable) ([]string, error) {
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "models to create cannot be empty")
+	}
+
+	ids, err := b.IDGen.Batch(kt, models[0].TableName(), len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+
+		if err = models[index].InsertValidate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
+		tablebill.BillItemColumns.ColumnExpr(), tablebill.BillItemColumns.ColonNameExpr())
+
+	if err = b.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+	}
+
+	return ids, nil
+}
+
+// List get bill item list.
+func (b BillItemDao) List(kt *kit.Kit, opt *types.ListOption) (*typesbill.ListBillItemDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list bill item options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablebill.BillItemColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.BillItemTable, whereExpr)
+		count, err := b.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count bill item failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesbill.ListBillItemDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablebill.BillItemColumns.FieldsNamedExpr(opt.Fields),
+		table.BillItemTable, whereExpr, pageExpr)
+
+	details := make([]tablebill.BillItemTable, 0)
+	if err = b.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &typesbill.ListBillItemDetails{Details: details}, nil
+}
+
+// DeleteWithTx delete bill item with tx.
+func (b BillItemDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	// 账单明细量大，禁止无条件删除
+	if len(whereExpr) == 0 {
+		return errf.New(errf.InvalidParameter, "delete bill item filter rules can not be empty")
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.BillItemTable, whereExpr)
+
+	if _, err = b.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete bill item failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// costAllocResTables 参与成本分摊的资源表及其资源类型，资源表需包含id、vendor、account_id、cloud_id、bk_biz_id字段。
+// 云ID在多个资源表中同时存在时，按此顺序取第一个匹配的资源
+var costAllocResTables = []struct {
+	Table   table.Name
+	ResType enumor.CloudResourceType
+}{
+	{Table: table.CvmTable, ResType: enumor.CvmCloudResType},
+	{Table: table.DiskTable, ResType: enumor.DiskCloudResType},
+	{Table: table.EipTable, ResType: enumor.EipCloudResType},
+	{Table: table.VpcTable, ResType: enumor.VpcCloudResType},
+}
+
+// ListCostAllocation summary the bill items cost of the month by biz, vendor, resource type and currency.
+// bill items that can not match a local resource or whose resource is not assigned to biz are summarized
+// into unassigned biz.
+func (b BillItemDao) ListCostAllocation(kt *kit.Kit, opt *typesbill.BillCostAllocationOption) (
+	[]typesbill.BillCostAllocation, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	sql, whereValue := costAllocationSQL(opt)
+
+	details := make([]typesbill.BillCostAllocation, 0)
+	if err := b.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.Errorf("list bill cost allocation failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// costAllocationSQL build the cost allocation sql, each resource table is left joined separately so that a bill
+// item matches at most one row of each table, and the first matched table decides the biz and resource type, a
+// cloud id exists in several resource tables is never counted more than once.
+func costAllocationSQL(opt *typesbill.BillCostAllocationOption) (string, map[string]interface{}) {
+	joins := make([]string, 0, len(costAllocResTables))
+	bizFields := make([]string, 0, len(costAllocResTables)+1)
+	resTypeCases := make([]string, 0, len(costAllocResTables))
+	for index, one := range costAllocResTables {
+		alias := fmt.Sprintf("res%d", index)
+		joins = append(joins, fmt.Sprintf(`LEFT JOIN %s AS %s ON %s.vendor = item.vendor AND `+
+			`%s.account_id = item.account_id AND %s.cloud_id = item.cloud_res_id AND item.cloud_res_id != ''`,
+			one.Table, alias, alias, alias, alias))
+		bizFields = append(bizFields, alias+".bk_biz_id")
+		resTypeCases = append(resTypeCases, fmt.Sprintf("WHEN %s.id IS NOT NULL THEN '%s'", alias, one.ResType))
+	}
+	bizFields = append(bizFields, ":unassigned_id")
+
+	whereExpr := "WHERE item.bill_month = :bill_month"
+	whereValue := map[string]interface{}{
+		"bill_month":    opt.BillMonth,
+		"unassigned_id": constant.UnassignedBiz,
+	}
+	if len(opt.Vendors) != 0 {
+		whereExpr += " AND item.vendor IN (:vendors)"
+		whereValue["vendors"] = opt.Vendors
+	}
+	if len(opt.AccountIDs) != 0 {
+		whereExpr += " AND item.account_id IN (:account_ids)"
+		whereValue["account_ids"] = opt.AccountIDs
+	}
+
+	havingExpr := ""
+	if len(opt.BkBizIDs) != 0 {
+		havingExpr = "HAVING bk_biz_id IN (:bk_biz_ids)"
+		whereValue["bk_biz_ids"] = opt.BkBizIDs
+	}
+
+	sql := fmt.Sprintf(`SELECT COALESCE(%s) AS bk_biz_id, item.vendor AS vendor, CASE %s ELSE '' END AS res_type, `+
+		`item.currency AS currency, CAST(SUM(item.cost) AS CHAR) AS cost, COUNT(*) AS item_count FROM %s AS item `+
+		`%s %s GROUP BY bk_biz_id, vendor, res_type, currency %s ORDER BY bk_biz_id, vendor, res_type, currency`,
+		strings.Join(bizFields, ", "), strings.Join(resTypeCases, " "), table.BillItemTable,
+		strings.Join(joins, " "), whereExpr, havingExpr)
+
+	return sql, whereValue
+}
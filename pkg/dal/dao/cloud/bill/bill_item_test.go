@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/orm"
+	typesbill "hcm/pkg/dal/dao/types/bill"
+	"hcm/pkg/kit"
+)
+
+// fakeOrm records the select sql and args, other orm methods are not supported.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	sql  string
+	args map[string]interface{}
+	rows []typesbill.BillCostAllocation
+}
+
+func (f *fakeOrm) Do() orm.DoOrm {
+	return f
+}
+
+func (f *fakeOrm) Select(_ context.Context, dest interface{}, expr string, arg map[string]interface{}) error {
+	f.sql = expr
+	f.args = arg
+	*(dest.(*[]typesbill.BillCostAllocation)) = f.rows
+	return nil
+}
+
+func TestListCostAllocation(t *testing.T) {
+	fake := &fakeOrm{rows: []typesbill.BillCostAllocation{
+		{BkBizID: 100, Vendor: enumor.TCloud, ResType: string(enumor.CvmCloudResType), Currency: "CNY", Cost: "1.5",
+			ItemCount: 2},
+		{BkBizID: constant.UnassignedBiz, Vendor: enumor.TCloud, Currency: "CNY", Cost: "3", ItemCount: 1},
+	}}
+	dao := BillItemDao{Orm: fake}
+
+	details, err := dao.ListCostAllocation(kit.New(), &typesbill.BillCostAllocationOption{BillMonth: "2024-01"})
+	if err != nil {
+		t.Fatalf("list cost allocation failed, err: %v", err)
+	}
+	if len(details) != 2 || details[0].BkBizID != 100 || details[1].BkBizID != constant.UnassignedBiz {
+		t.Errorf("unexpected cost allocation details: %+v", details)
+	}
+	if fake.args["bill_month"] != "2024-01" {
+		t.Errorf("bill month arg is not set, args: %v", fake.args)
+	}
+
+	if _, err = dao.ListCostAllocation(kit.New(), &typesbill.BillCostAllocationOption{}); err == nil {
+		t.Errorf("list cost allocation without bill month should fail")
+	}
+}
+
+func TestCostAllocationSQLUnassigned(t *testing.T) {
+	sql, args := costAllocationSQL(&typesbill.BillCostAllocationOption{BillMonth: "2024-01"})
+
+	// bill items not matched by any resource fall back to unassigned biz and empty resource type.
+	if !strings.Contains(sql, "res3.bk_biz_id, :unassigned_id) AS bk_biz_id") {
+		t.Errorf("unassigned biz should be the last coalesce value, sql: %s", sql)
+	}
+	if !strings.Contains(sql, "ELSE '' END AS res_type") {
+		t.Errorf("unmatched bill item should have empty res type, sql: %s", sql)
+	}
+	if args["unassigned_id"] != constant.UnassignedBiz {
+		t.Errorf("unassigned id arg is %v, want %d", args["unassigned_id"], constant.UnassignedBiz)
+	}
+	for _, key := range []string{"vendors", "account_ids", "bk_biz_ids"} {
+		if _, exists := args[key]; exists {
+			t.Errorf("arg %s should not be set without filter", key)
+		}
+	}
+	if strings.Contains(sql, "HAVING") {
+		t.Errorf("sql should not have biz filter, sql: %s", sql)
+	}
+}
+
+func TestCostAllocationSQLAssigned(t *testing.T) {
+	sql, args := costAllocationSQL(&typesbill.BillCostAllocationOption{
+		BillMonth:  "2024-01",
+		Vendors:    []enumor.Vendor{enumor.TCloud},
+		AccountIDs: []string{"00000001"},
+		BkBizIDs:   []int64{100},
+	})
+
+	if !strings.Contains(sql, "COALESCE(res0.bk_biz_id, res1.bk_biz_id, res2.bk_biz_id, res3.bk_biz_id, ") {
+		t.Errorf("assigned biz should be taken from matched resource, sql: %s", sql)
+	}
+	for _, expr := range []string{"item.vendor IN (:vendors)", "item.account_id IN (:account_ids)",
+		"HAVING bk_biz_id IN (:bk_biz_ids)"} {
+		if !strings.Contains(sql, expr) {
+			t.Errorf("sql should contain %s, sql: %s", expr, sql)
+		}
+	}
+	if len(args["bk_biz_ids"].([]int64)) != 1 || len(args["account_ids"].([]string)) != 1 {
+		t.Errorf("filter args are not set, args: %v", args)
+	}
+}
+
+func TestCostAllocationSQLMultiResType(t *testing.T) {
+	sql, _ := costAllocationSQL(&typesbill.BillCostAllocationOption{BillMonth: "2024-01"})
+
+	// union the resource tables makes a bill item join several rows when its cloud id exists in more than one
+	// table, each table must be joined separately.
+	if strings.Contains(sql, "UNION") {
+		t.Errorf("resource tables should not be unioned, sql: %s", sql)
+	}
+
+	lastCase := -1
+	for index, one := range costAllocResTables {
+		join := "LEFT JOIN " + string(one.Table) + " AS res"
+		if strings.Count(sql, join) != 1 {
+			t.Errorf("table %s should be joined once, sql: %s", one.Table, sql)
+		}
+
+		caseExpr := "THEN '" + string(one.ResType) + "'"
+		pos := strings.Index(sql, caseExpr)
+		if pos < 0 || pos < lastCase {
+			t.Errorf("res type %s case at index %d is not in table priority order, sql: %s", one.ResType,
+				index, sql)
+		}
+		lastCase = pos
+	}
+}
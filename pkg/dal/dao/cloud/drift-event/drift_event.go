@@ -0,0 +1,117 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package driftevent ...
+package driftevent
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesdrift "hcm/pkg/dal/dao/types/drift-event"
+	"hcm/pkg/dal/table"
+	driftevent "hcm/pkg/dal/table/cloud/drift-event"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// ResDriftEvent only used for resource drift event.
+type ResDriftEvent interface {
+	BatchCreate(kt *kit.Kit, events []*driftevent.ResDriftEventTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*typesdrift.ListResDriftEventDetails, error)
+}
+
+var _ ResDriftEvent = new(ResDriftEventDao)
+
+// ResDriftEventDao resource drift event dao.
+type ResDriftEventDao struct {
+	Orm orm.Interface
+}
+
+// BatchCreate batch create resource drift event.
+func (r ResDriftEventDao) BatchCreate(kt *kit.Kit, events []*driftevent.ResDriftEventTable) error {
+	if len(events) == 0 {
+		return errf.New(errf.InvalidParameter, "drift events is required")
+	}
+
+	for _, one := range events {
+		if err := one.InsertValidate(); err != nil {
+			return err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.ResDriftEventTable,
+		driftevent.ResDriftEventColumns.ColumnExpr(), driftevent.ResDriftEventColumns.ColonNameExpr())
+
+	if err := r.Orm.Do().BulkInsert(kt.Ctx, sql, events); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.ResDriftEventTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.ResDriftEventTable, err)
+	}
+
+	return nil
+}
+
+// List resource drift event.
+func (r ResDriftEventDao) List(kt *kit.Kit, opt *types.ListOption) (*typesdrift.ListResDriftEventDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list drift event options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(driftevent.ResDriftEventColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResDriftEventTable, whereExpr)
+
+		count, err := r.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count drift event failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesdrift.ListResDriftEventDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, driftevent.ResDriftEventColumns.FieldsNamedExpr(opt.Fields),
+		table.ResDriftEventTable, whereExpr, pageExpr)
+
+	details := make([]driftevent.ResDriftEventTable, 0)
+	if err = r.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &typesdrift.ListResDriftEventDetails{Details: details}, nil
+}
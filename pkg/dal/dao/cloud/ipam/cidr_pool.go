@@ -0,0 +1,215 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ipam ...
+package ipam
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesipam "hcm/pkg/dal/dao/types/ipam"
+	"hcm/pkg/dal/table"
+	tableipam "hcm/pkg/dal/table/cloud/ipam"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CidrPool only used for cidr pool.
+type CidrPool interface {
+	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableipam.CidrPoolTable) ([]string, error)
+	UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression, model *tableipam.CidrPoolTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*typesipam.ListCidrPoolDetails, error)
+	LockWithTx(kt *kit.Kit, tx *sqlx.Tx, id string) (*tableipam.CidrPoolTable, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ CidrPool = new(CidrPoolDao)
+
+// CidrPoolDao cidr pool dao.
+type CidrPoolDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// CreateWithTx create cidr pool with tx.
+func (dao CidrPoolDao) CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableipam.CidrPoolTable) (
+	[]string, error) {
+
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "models to create cannot be empty")
+	}
+
+	ids, err := dao.IDGen.Batch(kt, models[0].TableName(), len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+
+		if err = models[index].InsertValidate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, models[0].TableName(),
+		tableipam.CidrPoolColumns.ColumnExpr(), tableipam.CidrPoolColumns.ColonNameExpr())
+
+	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", models[0].TableName(), err, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", models[0].TableName(), err)
+	}
+
+	return ids, nil
+}
+
+// UpdateWithTx update cidr pool with tx.
+func (dao CidrPoolDao) UpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression,
+	model *tableipam.CidrPoolTable) error {
+
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is nil")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
+
+	effected, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, tools.MapMerge(toUpdate, whereValue))
+	if err != nil {
+		logs.ErrorJson("update cidr pool failed, filter: %s, err: %v, rid: %v", expr, err, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		logs.ErrorJson("update cidr pool, but record not found, filter: %v, rid: %v", expr, kt.Rid)
+		return errf.New(errf.RecordNotFound, "cidr pool not found")
+	}
+
+	return nil
+}
+
+// List get cidr pool list.
+func (dao CidrPoolDao) List(kt *kit.Kit, opt *types.ListOption) (*typesipam.ListCidrPoolDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list cidr pool options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tableipam.CidrPoolColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.CidrPoolTable, whereExpr)
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count cidr pool failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesipam.ListCidrPoolDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableipam.CidrPoolColumns.FieldsNamedExpr(opt.Fields),
+		table.CidrPoolTable, whereExpr, pageExpr)
+
+	details := make([]tableipam.CidrPoolTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &typesipam.ListCidrPoolDetails{Details: details}, nil
+}
+
+// LockWithTx get cidr pool and lock it until the tx is finished, used to serialize the allocations from the pool.
+func (dao CidrPoolDao) LockWithTx(kt *kit.Kit, tx *sqlx.Tx, id string) (*tableipam.CidrPoolTable, error) {
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "cidr pool id is required")
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s WHERE id = :id FOR UPDATE`, tableipam.CidrPoolColumns.NamedExpr(),
+		table.CidrPoolTable)
+
+	details := make([]tableipam.CidrPoolTable, 0)
+	if err := dao.Orm.Txn(tx).Select(kt.Ctx, &details, sql, map[string]interface{}{"id": id}); err != nil {
+		logs.Errorf("lock cidr pool failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "cidr pool %s not found", id)
+	}
+
+	return &details[0], nil
+}
+
+// DeleteWithTx delete cidr pool with tx.
+func (dao CidrPoolDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.CidrPoolTable, whereExpr)
+
+	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete cidr pool failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+)
+
+// fakeOrm records the sql and args, other orm methods are not supported.
+type fakeOrm struct {
+	orm.Interface
+	orm.DoOrm
+	sql   string
+	args  map[string]interface{}
+	count uint64
+	rows  []tablelb.LoadBalancerTable
+}
+
+func (f *fakeOrm) Do() orm.DoOrm {
+	return f
+}
+
+func (f *fakeOrm) Select(_ context.Context, dest interface{}, expr string, arg map[string]interface{}) error {
+	f.sql, f.args = expr, arg
+	*(dest.(*[]tablelb.LoadBalancerTable)) = f.rows
+	return nil
+}
+
+func (f *fakeOrm) Count(_ context.Context, expr string, arg map[string]interface{}) (uint64, error) {
+	f.sql, f.args = expr, arg
+	return f.count, nil
+}
+
+func TestLoadBalancerList(t *testing.T) {
+	fake := &fakeOrm{count: 3, rows: []tablelb.LoadBalancerTable{{ID: "00000001", CloudID: "lb-1"}}}
+	dao := LoadBalancerDao{Orm: fake}
+	expr := tools.EqualWithOpExpression(filter.And, map[string]interface{}{
+		"vendor":                        enumor.Azure,
+		"extension.resource_group_name": "rg-1",
+	})
+
+	result, err := dao.List(kit.New(), &types.ListOption{Filter: expr, Page: &core.BasePage{Count: true}})
+	if err != nil {
+		t.Fatalf("count load balancer failed, err: %v", err)
+	}
+	if result.Count != 3 || !strings.HasPrefix(fake.sql, "SELECT COUNT(*) FROM load_balancer") {
+		t.Errorf("unexpected count result: %d, sql: %s", result.Count, fake.sql)
+	}
+
+	result, err = dao.List(kit.New(), &types.ListOption{Filter: expr, Page: core.NewDefaultBasePage()})
+	if err != nil {
+		t.Fatalf("list load balancer failed, err: %v", err)
+	}
+	if len(result.Details) != 1 || result.Details[0].CloudID != "lb-1" {
+		t.Errorf("unexpected list result: %+v", result.Details)
+	}
+	// extension field is filtered by json path.
+	if !strings.Contains(fake.sql, "resource_group_name") {
+		t.Errorf("list sql should filter by extension resource group name, sql: %s", fake.sql)
+	}
+}
+
+func TestLoadBalancerListInvalid(t *testing.T) {
+	dao := LoadBalancerDao{Orm: new(fakeOrm)}
+
+	if _, err := dao.List(kit.New(), nil); err == nil {
+		t.Errorf("list with nil option should fail")
+	}
+
+	expr := tools.EqualExpression("not_exist_field", "value")
+	if _, err := dao.List(kit.New(), &types.ListOption{Filter: expr, Page: core.NewDefaultBasePage()}); err == nil {
+		t.Errorf("list with unknown field should fail")
+	}
+
+	if err := dao.Update(kit.New(), nil, new(tablelb.LoadBalancerTable)); err == nil {
+		t.Errorf("update without filter should fail")
+	}
+	if err := dao.UpdateByIDWithTx(kit.New(), nil, "", new(tablelb.LoadBalancerTable)); err == nil {
+		t.Errorf("update without id should fail")
+	}
+}
@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package region
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesRegion "hcm/pkg/dal/dao/types/region"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/cloud/region"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// OpenStackRegion defines openstack region dao operations.
+type OpenStackRegion interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []region.OpenStackRegionTable) ([]string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*typesRegion.ListOpenStackRegionDetails, error)
+	BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression) error
+}
+
+var _ OpenStackRegion = new(openStackRegionDao)
+
+// openStackRegionDao openstack region dao.
+type openStackRegionDao struct {
+	orm   orm.Interface
+	idGen idgenerator.IDGenInterface
+}
+
+// NewOpenStackRegionDao create a openstack region dao.
+func NewOpenStackRegionDao(orm orm.Interface, idGen idgenerator.IDGenInterface) OpenStackRegion {
+	return &openStackRegionDao{
+		orm:   orm,
+		idGen: idGen,
+	}
+}
+
+// BatchCreateWithTx create openstack region with transaction.
+func (o *openStackRegionDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []region.OpenStackRegionTable) (
+	[]string, error) {
+
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "models to create cannot be empty")
+	}
+
+	// generate region id
+	ids, err := o.idGen.Batch(kt, table.OpenStackRegionTable, len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range models {
+		models[idx].ID = ids[idx]
+	}
+
+	for _, model := range models {
+		if err = model.InsertValidate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.OpenStackRegionTable,
+		region.OpenStackRegionColumns.ColumnExpr(), region.OpenStackRegionColumns.ColonNameExpr())
+
+	if err = o.orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.OpenStackRegionTable, err, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", table.OpenStackRegionTable, err)
+	}
+
+	return ids, nil
+}
+
+// List get openstack region list.
+func (o *openStackRegionDao) List(kt *kit.Kit, opt *types.ListOption) (*typesRegion.ListOpenStackRegionDetails,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list openstack region options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(region.OpenStackRegionColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is a count request, do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.OpenStackRegionTable, whereExpr)
+
+		count, err := o.orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count openstack region failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesRegion.ListOpenStackRegionDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, region.OpenStackRegionColumns.FieldsNamedExpr(opt.Fields),
+		table.OpenStackRegionTable, whereExpr, pageExpr)
+
+	details := make([]*region.OpenStackRegionTable, 0)
+	if err = o.orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &typesRegion.ListOpenStackRegionDetails{Details: details}, nil
+}
+
+// BatchDeleteWithTx batch delete openstack region with transaction.
+func (o *openStackRegionDao) BatchDeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, filterExpr *filter.Expression) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.OpenStackRegionTable, whereExpr)
+	if _, err = o.orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete openstack region failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
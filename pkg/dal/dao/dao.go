@@ -31,19 +31,30 @@ import (
 	"hcm/pkg/dal/dao/auth"
 	"hcm/pkg/dal/dao/cloud"
 	"hcm/pkg/dal/dao/cloud/bill"
+	"hcm/pkg/dal/dao/cloud/bucket"
 	"hcm/pkg/dal/dao/cloud/cvm"
 	"hcm/pkg/dal/dao/cloud/disk"
 	diskcvmrel "hcm/pkg/dal/dao/cloud/disk-cvm-rel"
+	driftevent "hcm/pkg/dal/dao/cloud/drift-event"
 	"hcm/pkg/dal/dao/cloud/eip"
 	eipcvmrel "hcm/pkg/dal/dao/cloud/eip-cvm-rel"
 	cimage "hcm/pkg/dal/dao/cloud/image"
+	"hcm/pkg/dal/dao/cloud/ipam"
+	keypair "hcm/pkg/dal/dao/cloud/key-pair"
+	loadbalancer "hcm/pkg/dal/dao/cloud/load-balancer"
+	natgateway "hcm/pkg/dal/dao/cloud/nat-gateway"
 	networkinterface "hcm/pkg/dal/dao/cloud/network-interface"
 	nicvmrel "hcm/pkg/dal/dao/cloud/network-interface-cvm-rel"
 	"hcm/pkg/dal/dao/cloud/region"
 	resourcegroup "hcm/pkg/dal/dao/cloud/resource-group"
+	resourcetag "hcm/pkg/dal/dao/cloud/resource-tag"
 	routetable "hcm/pkg/dal/dao/cloud/route-table"
 	securitygroup "hcm/pkg/dal/dao/cloud/security-group"
 	sgcvmrel "hcm/pkg/dal/dao/cloud/security-group-cvm-rel"
+	sgtemplate "hcm/pkg/dal/dao/cloud/sg-template"
+	"hcm/pkg/dal/dao/cloud/snapshot"
+	synctask "hcm/pkg/dal/dao/cloud/sync-task"
+	vpcpeering "hcm/pkg/dal/dao/cloud/vpc-peering"
 	"hcm/pkg/dal/dao/cloud/zone"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
@@ -66,6 +77,7 @@ type Set interface {
 	AwsSGRule() securitygroup.AwsSGRule
 	HuaWeiSGRule() securitygroup.HuaWeiSGRule
 	AzureSGRule() securitygroup.AzureSGRule
+	OpenStackSGRule() securitygroup.OpenStackSGRule
 	GcpFirewallRule() cloud.GcpFirewallRule
 	Cloud() cloud.Cloud
 	AccountBizRel() cloud.AccountBizRel
@@ -78,6 +90,7 @@ type Set interface {
 	TCloudRegion() region.TCloudRegion
 	AwsRegion() region.AwsRegion
 	GcpRegion() region.GcpRegion
+	OpenStackRegion() region.OpenStackRegion
 	Cvm() cvm.Interface
 	RouteTable() routetable.RouteTable
 	Route() routetable.Route
@@ -92,6 +105,29 @@ type Set interface {
 	DiskCvmRel() diskcvmrel.DiskCvmRel
 	EipCvmRel() eipcvmrel.EipCvmRel
 	AccountBillConfig() bill.Interface
+	BillItem() bill.BillItem
+	ExchangeRate() bill.ExchangeRate
+	Budget() bill.Budget
+	BudgetAlert() bill.BudgetAlert
+	ResDriftEvent() driftevent.ResDriftEvent
+	SyncTask() synctask.SyncTask
+	SyncDetail() synctask.SyncDetail
+	SyncWatermark() synctask.SyncWatermark
+	LoadBalancer() loadbalancer.LoadBalancer
+	LBListener() loadbalancer.LBListener
+	LBTarget() loadbalancer.LBTarget
+	NatGateway() natgateway.NatGateway
+	Snapshot() snapshot.Snapshot
+	SnapshotPolicy() snapshot.SnapshotPolicy
+	KeyPair() keypair.KeyPair
+	CloudKeyPair() keypair.CloudKeyPair
+	Bucket() bucket.Bucket
+	VpcPeering() vpcpeering.VpcPeering
+	CidrPool() ipam.CidrPool
+	CidrAllocation() ipam.CidrAllocation
+	SGTemplate() sgtemplate.SGTemplate
+	SGTemplateRel() sgtemplate.SGTemplateRel
+	ResourceTag() resourcetag.ResourceTag
 
 	Txn() *Txn
 }
@@ -341,6 +377,15 @@ func (s *set) AzureSGRule() securitygroup.AzureSGRule {
 	}
 }
 
+// OpenStackSGRule return openstack security group rule dao.
+func (s *set) OpenStackSGRule() securitygroup.OpenStackSGRule {
+	return &securitygroup.OpenStackSGRuleDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
 // Cvm return cvm dao.
 func (s *set) Cvm() cvm.Interface {
 	return &cvm.Dao{
@@ -365,6 +410,11 @@ func (s *set) GcpRegion() region.GcpRegion {
 	return region.NewGcpRegionDao(s.orm, s.idGen)
 }
 
+// OpenStackRegion returns openstack region dao.
+func (s *set) OpenStackRegion() region.OpenStackRegion {
+	return region.NewOpenStackRegionDao(s.orm, s.idGen)
+}
+
 // RouteTable returns route table dao.
 func (s *set) RouteTable() routetable.RouteTable {
 	return routetable.NewRouteTableDao(s.orm, s.idGen, s.audit)
@@ -435,3 +485,194 @@ func (s *set) AccountBillConfig() bill.Interface {
 		Audit: s.audit,
 	}
 }
+
+// BillItem returns bill item dao.
+func (s *set) BillItem() bill.BillItem {
+	return &bill.BillItemDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ExchangeRate returns exchange rate dao.
+func (s *set) ExchangeRate() bill.ExchangeRate {
+	return &bill.ExchangeRateDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// Budget returns budget dao.
+func (s *set) Budget() bill.Budget {
+	return &bill.BudgetDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// BudgetAlert returns budget alert dao.
+func (s *set) BudgetAlert() bill.BudgetAlert {
+	return &bill.BudgetAlertDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ResDriftEvent returns resource drift event dao.
+func (s *set) ResDriftEvent() driftevent.ResDriftEvent {
+	return &driftevent.ResDriftEventDao{
+		Orm: s.orm,
+	}
+}
+
+// SyncTask returns sync task dao.
+func (s *set) SyncTask() synctask.SyncTask {
+	return &synctask.SyncTaskDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// SyncDetail returns sync detail dao.
+func (s *set) SyncDetail() synctask.SyncDetail {
+	return &synctask.SyncDetailDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// SyncWatermark returns sync watermark dao.
+func (s *set) SyncWatermark() synctask.SyncWatermark {
+	return &synctask.SyncWatermarkDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// LoadBalancer returns load balancer dao.
+func (s *set) LoadBalancer() loadbalancer.LoadBalancer {
+	return &loadbalancer.LoadBalancerDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// LBListener returns load balancer listener dao.
+func (s *set) LBListener() loadbalancer.LBListener {
+	return &loadbalancer.LBListenerDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// LBTarget returns load balancer target dao.
+func (s *set) LBTarget() loadbalancer.LBTarget {
+	return &loadbalancer.LBTargetDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// NatGateway returns nat gateway dao.
+func (s *set) NatGateway() natgateway.NatGateway {
+	return &natgateway.NatGatewayDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// Snapshot returns snapshot dao.
+func (s *set) Snapshot() snapshot.Snapshot {
+	return &snapshot.SnapshotDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// SnapshotPolicy returns snapshot policy dao.
+func (s *set) SnapshotPolicy() snapshot.SnapshotPolicy {
+	return &snapshot.SnapshotPolicyDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// KeyPair returns key pair dao.
+func (s *set) KeyPair() keypair.KeyPair {
+	return &keypair.KeyPairDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// CloudKeyPair returns cloud key pair dao.
+func (s *set) CloudKeyPair() keypair.CloudKeyPair {
+	return &keypair.CloudKeyPairDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// Bucket returns bucket dao.
+func (s *set) Bucket() bucket.Bucket {
+	return &bucket.BucketDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// VpcPeering returns vpc peering dao.
+func (s *set) VpcPeering() vpcpeering.VpcPeering {
+	return &vpcpeering.VpcPeeringDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// CidrPool returns ipam cidr pool dao.
+func (s *set) CidrPool() ipam.CidrPool {
+	return &ipam.CidrPoolDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// CidrAllocation returns ipam cidr allocation dao.
+func (s *set) CidrAllocation() ipam.CidrAllocation {
+	return &ipam.CidrAllocationDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// SGTemplate returns security group template dao.
+func (s *set) SGTemplate() sgtemplate.SGTemplate {
+	return &sgtemplate.SGTemplateDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// SGTemplateRel returns security group template rel dao.
+func (s *set) SGTemplateRel() sgtemplate.SGTemplateRel {
+	return &sgtemplate.SGTemplateRelDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ResourceTag returns resource tag dao.
+func (s *set) ResourceTag() resourcetag.ResourceTag {
+	return &resourcetag.ResourceTagDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	tablebill "hcm/pkg/dal/table/cloud/bill"
+)
+
+// ListBillItemDetails list bill item details.
+type ListBillItemDetails struct {
+	Count   uint64                    `json:"count,omitempty"`
+	Details []tablebill.BillItemTable `json:"details,omitempty"`
+}
+
+// BillCostAllocationOption defines bill cost allocation summary option.
+type BillCostAllocationOption struct {
+	// BillMonth 账单月份，格式为yyyy-mm
+	BillMonth  string
+	Vendors    []enumor.Vendor
+	AccountIDs []string
+	BkBizIDs   []int64
+}
+
+// Validate BillCostAllocationOption.
+func (opt *BillCostAllocationOption) Validate() error {
+	if opt == nil {
+		return errors.New("bill cost allocation option is required")
+	}
+
+	if _, err := time.Parse(constant.MonthLayout, opt.BillMonth); err != nil {
+		return fmt.Errorf("bill_month should be yyyy-mm, err: %v", err)
+	}
+
+	return nil
+}
+
+// BillCostAllocation defines bill cost summary allocated by biz, vendor and resource type.
+type BillCostAllocation struct {
+	BkBizID   int64         `db:"bk_biz_id" json:"bk_biz_id"`
+	Vendor    enumor.Vendor `db:"vendor" json:"vendor"`
+	ResType   string        `db:"res_type" json:"res_type"`
+	Currency  string        `db:"currency" json:"currency"`
+	Cost      string        `db:"cost" json:"cost"`
+	ItemCount uint64        `db:"item_count" json:"item_count"`
+}
@@ -56,6 +56,12 @@ type ListAzureSGRuleDetails struct {
 	Details []cloud.AzureSecurityGroupRuleTable `json:"details,omitempty"`
 }
 
+// ListOpenStackSGRuleDetails list openstack security group rule details.
+type ListOpenStackSGRuleDetails struct {
+	Count   uint64                                  `json:"count,omitempty"`
+	Details []cloud.OpenStackSecurityGroupRuleTable `json:"details,omitempty"`
+}
+
 // SGRuleListOption defines options to list security group rule.
 type SGRuleListOption struct {
 	SecurityGroupID string
@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// BillItemColumns defines all the bill item table's columns.
+var BillItemColumns = utils.MergeColumns(nil, BillItemColumnDescriptor)
+
+// BillItemColumnDescriptor is BillItem's column descriptors.
+var BillItemColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bill_month", NamedC: "bill_month", Type: enumor.String},
+	{Column: "product_code", NamedC: "product_code", Type: enumor.String},
+	{Column: "product_name", NamedC: "product_name", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
+	{Column: "res_name", NamedC: "res_name", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "cost", NamedC: "cost", Type: enumor.Numeric},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BillItemTable bill_item表
+type BillItemTable struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"max=64" json:"id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" validate:"-" json:"vendor"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" validate:"max=64" json:"account_id"`
+	// BillMonth 账单月份，格式为yyyy-mm
+	BillMonth string `db:"bill_month" validate:"max=7" json:"bill_month"`
+	// ProductCode 云产品编码
+	ProductCode string `db:"product_code" validate:"max=128" json:"product_code"`
+	// ProductName 云产品名称
+	ProductName string `db:"product_name" validate:"max=255" json:"product_name"`
+	// CloudResID 云资源ID
+	CloudResID string `db:"cloud_res_id" validate:"max=255" json:"cloud_res_id"`
+	// ResName 云资源名称
+	ResName string `db:"res_name" validate:"max=255" json:"res_name"`
+	// Region 地域
+	Region string `db:"region" validate:"max=64" json:"region"`
+	// Cost 费用
+	Cost string `db:"cost" json:"cost"`
+	// Currency 币种
+	Currency string `db:"currency" validate:"max=16" json:"currency"`
+	// Extension 云厂商差异扩展字段
+	Extension types.JsonField `db:"extension" json:"extension"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return bill item table name.
+func (b BillItemTable) TableName() table.Name {
+	return table.BillItemTable
+}
+
+// InsertValidate validate bill item table on insert.
+func (b BillItemTable) InsertValidate() error {
+	if err := validator.Validate.Struct(b); err != nil {
+		return err
+	}
+
+	if len(b.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(b.AccountID) == 0 {
+		return errors.New("account_id can not be empty")
+	}
+
+	if len(b.BillMonth) == 0 {
+		return errors.New("bill_month can not be empty")
+	}
+
+	if len(b.Cost) == 0 {
+		return errors.New("cost can not be empty")
+	}
+
+	if len(b.Currency) == 0 {
+		return errors.New("currency can not be empty")
+	}
+
+	if len(b.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
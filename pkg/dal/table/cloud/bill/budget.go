@@ -0,0 +1,247 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// BudgetColumns defines all the budget table's columns.
+var BudgetColumns = utils.MergeColumns(nil, BudgetColumnDescriptor)
+
+// BudgetColumnDescriptor is Budget's column descriptors.
+var BudgetColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "scope_type", NamedC: "scope_type", Type: enumor.String},
+	{Column: "scope_value", NamedC: "scope_value", Type: enumor.String},
+	{Column: "amount", NamedC: "amount", Type: enumor.Numeric},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "thresholds", NamedC: "thresholds", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BudgetTable budget表
+type BudgetTable struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"max=64" json:"id"`
+	// Name 预算名称
+	Name string `db:"name" validate:"max=255" json:"name"`
+	// ScopeType 预算范围类型(account:账号、biz:业务、vendor:云厂商)
+	ScopeType enumor.BudgetScopeType `db:"scope_type" validate:"max=16" json:"scope_type"`
+	// ScopeValue 预算范围值，分别为账号ID、业务ID、云厂商
+	ScopeValue string `db:"scope_value" validate:"max=64" json:"scope_value"`
+	// Amount 每月预算金额
+	Amount string `db:"amount" json:"amount"`
+	// Currency 预算币种
+	Currency string `db:"currency" validate:"max=16" json:"currency"`
+	// Thresholds 告警阈值百分比列表
+	Thresholds types.Int64Array `db:"thresholds" json:"thresholds"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,max=255" json:"memo"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return budget table name.
+func (b BudgetTable) TableName() table.Name {
+	return table.BudgetTable
+}
+
+// InsertValidate validate budget table on insert.
+func (b BudgetTable) InsertValidate() error {
+	if err := validator.Validate.Struct(b); err != nil {
+		return err
+	}
+
+	if len(b.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if err := b.ScopeType.Validate(); err != nil {
+		return err
+	}
+
+	if len(b.ScopeValue) == 0 {
+		return errors.New("scope_value can not be empty")
+	}
+
+	if len(b.Amount) == 0 {
+		return errors.New("amount can not be empty")
+	}
+
+	if len(b.Currency) == 0 {
+		return errors.New("currency can not be empty")
+	}
+
+	if len(b.Thresholds) == 0 {
+		return errors.New("thresholds can not be empty")
+	}
+
+	if len(b.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate budget table on update.
+func (b BudgetTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(b); err != nil {
+		return err
+	}
+
+	if len(b.ScopeType) != 0 || len(b.ScopeValue) != 0 {
+		return errors.New("budget scope can not update")
+	}
+
+	if len(b.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(b.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
+
+// BudgetAlertColumns defines all the budget alert table's columns.
+var BudgetAlertColumns = utils.MergeColumns(nil, BudgetAlertColumnDescriptor)
+
+// BudgetAlertColumnDescriptor is BudgetAlert's column descriptors.
+var BudgetAlertColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "budget_id", NamedC: "budget_id", Type: enumor.String},
+	{Column: "scope_type", NamedC: "scope_type", Type: enumor.String},
+	{Column: "scope_value", NamedC: "scope_value", Type: enumor.String},
+	{Column: "bill_month", NamedC: "bill_month", Type: enumor.String},
+	{Column: "threshold", NamedC: "threshold", Type: enumor.Numeric},
+	{Column: "amount", NamedC: "amount", Type: enumor.Numeric},
+	{Column: "spend", NamedC: "spend", Type: enumor.Numeric},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "notify_status", NamedC: "notify_status", Type: enumor.String},
+	{Column: "notify_msg", NamedC: "notify_msg", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BudgetAlertTable budget_alert表
+type BudgetAlertTable struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"max=64" json:"id"`
+	// BudgetID 预算ID
+	BudgetID string `db:"budget_id" validate:"max=64" json:"budget_id"`
+	// ScopeType 预算范围类型
+	ScopeType enumor.BudgetScopeType `db:"scope_type" validate:"max=16" json:"scope_type"`
+	// ScopeValue 预算范围值
+	ScopeValue string `db:"scope_value" validate:"max=64" json:"scope_value"`
+	// BillMonth 账单月份，格式为yyyy-mm
+	BillMonth string `db:"bill_month" validate:"max=7" json:"bill_month"`
+	// Threshold 触发的告警阈值百分比
+	Threshold uint64 `db:"threshold" json:"threshold"`
+	// Amount 告警时的预算金额
+	Amount string `db:"amount" json:"amount"`
+	// Spend 告警时的当月累计费用
+	Spend string `db:"spend" json:"spend"`
+	// Currency 币种
+	Currency string `db:"currency" validate:"max=16" json:"currency"`
+	// NotifyStatus 通知状态
+	NotifyStatus enumor.BudgetNotifyStatus `db:"notify_status" validate:"max=16" json:"notify_status"`
+	// NotifyMsg 通知结果信息
+	NotifyMsg string `db:"notify_msg" validate:"max=1024" json:"notify_msg"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return budget alert table name.
+func (b BudgetAlertTable) TableName() table.Name {
+	return table.BudgetAlertTable
+}
+
+// InsertValidate validate budget alert table on insert.
+func (b BudgetAlertTable) InsertValidate() error {
+	if err := validator.Validate.Struct(b); err != nil {
+		return err
+	}
+
+	if len(b.BudgetID) == 0 {
+		return errors.New("budget_id can not be empty")
+	}
+
+	if len(b.BillMonth) == 0 {
+		return errors.New("bill_month can not be empty")
+	}
+
+	if b.Threshold == 0 {
+		return errors.New("threshold can not be zero")
+	}
+
+	if len(b.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate budget alert table on update, only the spend and notify result can be updated.
+func (b BudgetAlertTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(b); err != nil {
+		return err
+	}
+
+	if len(b.BudgetID) != 0 || len(b.BillMonth) != 0 || b.Threshold != 0 {
+		return errors.New("budget alert's budget, month and threshold can not update")
+	}
+
+	if len(b.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(b.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
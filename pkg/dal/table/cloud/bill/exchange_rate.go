@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ExchangeRateColumns defines all the exchange rate table's columns.
+var ExchangeRateColumns = utils.MergeColumns(nil, ExchangeRateColumnDescriptor)
+
+// ExchangeRateColumnDescriptor is ExchangeRate's column descriptors.
+var ExchangeRateColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "from_currency", NamedC: "from_currency", Type: enumor.String},
+	{Column: "to_currency", NamedC: "to_currency", Type: enumor.String},
+	{Column: "month", NamedC: "month", Type: enumor.String},
+	{Column: "rate", NamedC: "rate", Type: enumor.Numeric},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ExchangeRateTable exchange_rate表
+type ExchangeRateTable struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"max=64" json:"id"`
+	// FromCurrency 源币种
+	FromCurrency string `db:"from_currency" validate:"max=16" json:"from_currency"`
+	// ToCurrency 目标币种
+	ToCurrency string `db:"to_currency" validate:"max=16" json:"to_currency"`
+	// Month 汇率生效月份，格式为yyyy-mm
+	Month string `db:"month" validate:"max=7" json:"month"`
+	// Rate 汇率，1单位源币种可兑换的目标币种数量
+	Rate string `db:"rate" json:"rate"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return exchange rate table name.
+func (e ExchangeRateTable) TableName() table.Name {
+	return table.ExchangeRateTable
+}
+
+// InsertValidate validate exchange rate table on insert.
+func (e ExchangeRateTable) InsertValidate() error {
+	if err := validator.Validate.Struct(e); err != nil {
+		return err
+	}
+
+	if len(e.FromCurrency) == 0 {
+		return errors.New("from_currency can not be empty")
+	}
+
+	if len(e.ToCurrency) == 0 {
+		return errors.New("to_currency can not be empty")
+	}
+
+	if len(e.Month) == 0 {
+		return errors.New("month can not be empty")
+	}
+
+	if len(e.Rate) == 0 {
+		return errors.New("rate can not be empty")
+	}
+
+	if len(e.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate exchange rate table on update.
+func (e ExchangeRateTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(e); err != nil {
+		return err
+	}
+
+	if len(e.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(e.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
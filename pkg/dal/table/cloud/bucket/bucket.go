@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package bucket defines object storage bucket table.
+package bucket
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// BucketColumns defines all the bucket table's columns.
+var BucketColumns = utils.MergeColumns(nil, BucketColumnDescriptor)
+
+// BucketColumnDescriptor is bucket table column descriptors.
+var BucketColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "encrypted", NamedC: "encrypted", Type: enumor.Boolean},
+	{Column: "encryption_type", NamedC: "encryption_type", Type: enumor.String},
+	{Column: "versioning_enabled", NamedC: "versioning_enabled", Type: enumor.Boolean},
+	{Column: "public_read", NamedC: "public_read", Type: enumor.Boolean},
+	{Column: "public_write", NamedC: "public_write", Type: enumor.Boolean},
+	{Column: "public_access_reasons", NamedC: "public_access_reasons", Type: enumor.Json},
+	{Column: "cloud_created_time", NamedC: "cloud_created_time", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BucketTable define bucket table.
+type BucketTable struct {
+	ID        string        `db:"id" validate:"lte=64" json:"id"`
+	Vendor    enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	Region    string        `db:"region" validate:"lte=64" json:"region"`
+	// CloudID 云上存储桶ID，除 Azure 使用 Blob 容器的资源ID外，其余云厂商的存储桶名称全局唯一，使用名称作为云ID
+	CloudID           string `db:"cloud_id" validate:"lte=255" json:"cloud_id"`
+	Name              string `db:"name" validate:"lte=255" json:"name"`
+	BkBizID           int64  `db:"bk_biz_id" json:"bk_biz_id"`
+	Encrypted         *bool  `db:"encrypted" json:"encrypted"`
+	EncryptionType    string `db:"encryption_type" validate:"lte=64" json:"encryption_type"`
+	VersioningEnabled *bool  `db:"versioning_enabled" json:"versioning_enabled"`
+	PublicRead        *bool  `db:"public_read" json:"public_read"`
+	PublicWrite       *bool  `db:"public_write" json:"public_write"`
+	// PublicAccessReasons 存储桶可被公开访问的原因，如 ACL 授权所有用户、存储桶策略允许匿名访问等
+	PublicAccessReasons types.StringArray `db:"public_access_reasons" json:"public_access_reasons"`
+	CloudCreatedTime    string            `db:"cloud_created_time" validate:"lte=64" json:"cloud_created_time"`
+	Creator             string            `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser             string            `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt           types.Time        `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt           types.Time        `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return bucket table name.
+func (t BucketTable) TableName() table.Name {
+	return table.BucketTable
+}
+
+// InsertValidate bucket table when insert.
+func (t BucketTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate bucket table when update.
+func (t BucketTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package driftevent ...
+package driftevent
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ResDriftEventColumns defines all the resource drift event table's columns.
+var ResDriftEventColumns = utils.MergeColumns(utils.InsertWithoutPrimaryID, ResDriftEventColumnDescriptor)
+
+// ResDriftEventColumnDescriptor is ResDriftEventTable's column descriptors.
+var ResDriftEventColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.Numeric},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
+	{Column: "action", NamedC: "action", Type: enumor.String},
+	{Column: "before_data", NamedC: "before_data", Type: enumor.Json},
+	{Column: "after_data", NamedC: "after_data", Type: enumor.Json},
+	{Column: "rid", NamedC: "rid", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// ResDriftEventTable 资源漂移事件表，记录资源同步时发现的云上资源变更。
+type ResDriftEventTable struct {
+	// ID 自增ID
+	ID uint64 `db:"id" json:"id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" validate:"max=16" json:"vendor"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" validate:"max=64" json:"account_id"`
+	// ResType 资源类型
+	ResType enumor.CloudResourceType `db:"res_type" validate:"max=50" json:"res_type"`
+	// ResID 资源ID，云上新增的资源在同步前没有资源ID，为空
+	ResID string `db:"res_id" validate:"max=64" json:"res_id"`
+	// CloudResID 云资源ID
+	CloudResID string `db:"cloud_res_id" validate:"max=255" json:"cloud_res_id"`
+	// Action 变更动作(add:新增、update:变更、delete:删除)
+	Action enumor.DriftEventAction `db:"action" validate:"max=20" json:"action"`
+	// BeforeData 变更前的db资源快照，包含云厂商扩展字段，新增时为null
+	BeforeData types.JsonField `db:"before_data" json:"before_data"`
+	// AfterData 变更后的云上资源快照，删除时为null
+	AfterData types.JsonField `db:"after_data" json:"after_data"`
+	// Rid 发现该变更的同步请求ID
+	Rid string `db:"rid" validate:"max=64" json:"rid"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+}
+
+// TableName return resource drift event table name.
+func (r ResDriftEventTable) TableName() table.Name {
+	return table.ResDriftEventTable
+}
+
+// InsertValidate validate resource drift event table on insert.
+func (r ResDriftEventTable) InsertValidate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if len(r.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(r.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(r.ResType) == 0 {
+		return errors.New("res_type is required")
+	}
+
+	if len(r.CloudResID) == 0 {
+		return errors.New("cloud_res_id is required")
+	}
+
+	if err := r.Action.Validate(); err != nil {
+		return err
+	}
+
+	if len(r.BeforeData) == 0 || len(r.AfterData) == 0 {
+		return errors.New("before_data and after_data is required")
+	}
+
+	return nil
+}
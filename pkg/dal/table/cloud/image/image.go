@@ -34,6 +34,7 @@ var ImageColumns = utils.MergeColumns(nil, ImageColumnDescriptor)
 var ImageColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "id", NamedC: "id", Type: enumor.String},
 	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
 	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
 	{Column: "name", NamedC: "name", Type: enumor.String},
 	{Column: "architecture", NamedC: "architecture", Type: enumor.String},
@@ -51,6 +52,7 @@ var ImageColumnDescriptor = utils.ColumnDescriptors{
 type ImageModel struct {
 	ID           string          `db:"id"`
 	Vendor       string          `db:"vendor"`
+	AccountID    string          `db:"account_id"`
 	CloudID      string          `db:"cloud_id" validate:"max=512"`
 	Name         string          `db:"name"`
 	Architecture string          `db:"architecture"`
@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// CidrAllocationColumns defines all the cidr allocation table's columns.
+var CidrAllocationColumns = utils.MergeColumns(nil, CidrAllocationColumnDescriptor)
+
+// CidrAllocationColumnDescriptor is cidr allocation table column descriptors.
+var CidrAllocationColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "pool_id", NamedC: "pool_id", Type: enumor.String},
+	{Column: "cidr", NamedC: "cidr", Type: enumor.String},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "vpc_id", NamedC: "vpc_id", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// CidrAllocationTable ipam网段分配表，记录从地址池中分配出去的网段
+type CidrAllocationTable struct {
+	// ID 分配记录ID
+	ID string `db:"id" validate:"max=64" json:"id"`
+	// PoolID 地址池ID
+	PoolID string `db:"pool_id" validate:"max=64" json:"pool_id"`
+	// Cidr 分配的网段
+	Cidr string `db:"cidr" validate:"max=64" json:"cidr"`
+	// Status 分配状态
+	Status enumor.CidrAllocationStatus `db:"status" validate:"max=16" json:"status"`
+	// Vendor 使用该网段的VPC所属云厂商
+	Vendor enumor.Vendor `db:"vendor" validate:"max=16" json:"vendor"`
+	// AccountID 使用该网段的VPC所属账号ID
+	AccountID string `db:"account_id" validate:"max=64" json:"account_id"`
+	// Region 使用该网段的VPC所属地域
+	Region string `db:"region" validate:"max=64" json:"region"`
+	// VpcID 使用该网段的VPC的ID，预留状态时为空
+	VpcID string `db:"vpc_id" validate:"max=64" json:"vpc_id"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,max=255" json:"memo"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return cidr allocation table name.
+func (t CidrAllocationTable) TableName() table.Name {
+	return table.CidrAllocationTable
+}
+
+// InsertValidate validate cidr allocation table on insert.
+func (t CidrAllocationTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.PoolID) == 0 {
+		return errors.New("pool_id can not be empty")
+	}
+
+	if len(t.Cidr) == 0 {
+		return errors.New("cidr can not be empty")
+	}
+
+	if err := t.Status.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate cidr allocation table on update.
+func (t CidrAllocationTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.PoolID) != 0 || len(t.Cidr) != 0 {
+		return errors.New("pool_id and cidr can not update")
+	}
+
+	if len(t.Status) != 0 {
+		if err := t.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ipam defines ipam cidr pool and cidr allocation table.
+package ipam
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// CidrPoolColumns defines all the cidr pool table's columns.
+var CidrPoolColumns = utils.MergeColumns(nil, CidrPoolColumnDescriptor)
+
+// CidrPoolColumnDescriptor is cidr pool table column descriptors.
+var CidrPoolColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "environment", NamedC: "environment", Type: enumor.String},
+	{Column: "ipv4_cidr", NamedC: "ipv4_cidr", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// CidrPoolTable ipam地址池表，规划给业务、地域、环境使用的地址空间
+type CidrPoolTable struct {
+	// ID 地址池ID
+	ID string `db:"id" validate:"max=64" json:"id"`
+	// Name 地址池名称
+	Name string `db:"name" validate:"max=255" json:"name"`
+	// BkBizID 地址池所属业务ID，-1表示不限业务
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// Region 地址池所属地域，为空表示不限地域
+	Region string `db:"region" validate:"max=64" json:"region"`
+	// Environment 地址池所属环境，如prod、test，为空表示不限环境
+	Environment string `db:"environment" validate:"max=32" json:"environment"`
+	// IPv4Cidr 地址池的IPv4网段
+	IPv4Cidr string `db:"ipv4_cidr" validate:"max=64" json:"ipv4_cidr"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,max=255" json:"memo"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return cidr pool table name.
+func (t CidrPoolTable) TableName() table.Name {
+	return table.CidrPoolTable
+}
+
+// InsertValidate validate cidr pool table on insert.
+func (t CidrPoolTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if len(t.IPv4Cidr) == 0 {
+		return errors.New("ipv4_cidr can not be empty")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate cidr pool table on update.
+func (t CidrPoolTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.IPv4Cidr) != 0 {
+		return errors.New("ipv4_cidr can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// CloudKeyPairColumns defines all the cloud key pair table's columns.
+var CloudKeyPairColumns = utils.MergeColumns(nil, CloudKeyPairColumnDescriptor)
+
+// CloudKeyPairColumnDescriptor is cloud key pair table column descriptors.
+var CloudKeyPairColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "fingerprint", NamedC: "fingerprint", Type: enumor.String},
+	{Column: "key_pair_id", NamedC: "key_pair_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "cloud_created_time", NamedC: "cloud_created_time", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// CloudKeyPairTable define cloud key pair table.
+type CloudKeyPairTable struct {
+	ID        string        `db:"id" validate:"lte=64" json:"id"`
+	Vendor    enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	Region    string        `db:"region" validate:"lte=64" json:"region"`
+	// CloudID 云上密钥对ID，华为云密钥对没有ID，使用名称作为云ID
+	CloudID     string `db:"cloud_id" validate:"lte=255" json:"cloud_id"`
+	Name        string `db:"name" validate:"lte=255" json:"name"`
+	Fingerprint string `db:"fingerprint" validate:"lte=255" json:"fingerprint"`
+	// KeyPairID 由 HCM 密钥对推送到云上时关联的 HCM 密钥对ID，云上已存在的密钥对为空
+	KeyPairID        string     `db:"key_pair_id" validate:"lte=64" json:"key_pair_id"`
+	BkBizID          int64      `db:"bk_biz_id" json:"bk_biz_id"`
+	CloudCreatedTime string     `db:"cloud_created_time" validate:"lte=64" json:"cloud_created_time"`
+	Creator          string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser          string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt        types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt        types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return cloud key pair table name.
+func (t CloudKeyPairTable) TableName() table.Name {
+	return table.CloudKeyPairTable
+}
+
+// InsertValidate cloud key pair table when insert.
+func (t CloudKeyPairTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate cloud key pair table when update.
+func (t CloudKeyPairTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
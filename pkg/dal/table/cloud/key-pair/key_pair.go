@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair ...
+package keypair
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// KeyPairColumns defines all the key pair table's columns.
+var KeyPairColumns = utils.MergeColumns(nil, KeyPairColumnDescriptor)
+
+// KeyPairColumnDescriptor is key pair table column descriptors.
+var KeyPairColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "public_key", NamedC: "public_key", Type: enumor.String},
+	{Column: "fingerprint", NamedC: "fingerprint", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// KeyPairTable define hcm managed ssh key pair table, only public key is stored.
+type KeyPairTable struct {
+	ID      string `db:"id" validate:"lte=64" json:"id"`
+	Name    string `db:"name" validate:"lte=255" json:"name"`
+	BkBizID int64  `db:"bk_biz_id" json:"bk_biz_id"`
+	// PublicKey OpenSSH authorized_keys 格式的公钥
+	PublicKey string `db:"public_key" json:"public_key"`
+	// Fingerprint 公钥的 SHA256 指纹
+	Fingerprint string     `db:"fingerprint" validate:"lte=255" json:"fingerprint"`
+	Memo        *string    `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	Creator     string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser     string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt   types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt   types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return key pair table name.
+func (t KeyPairTable) TableName() table.Name {
+	return table.KeyPairTable
+}
+
+// InsertValidate key pair table when insert.
+func (t KeyPairTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(t.PublicKey) == 0 {
+		return errors.New("public_key is required")
+	}
+
+	if len(t.Fingerprint) == 0 {
+		return errors.New("fingerprint is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate key pair table when update.
+func (t KeyPairTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	// 公钥导入后不允许修改，否则已推送到云上的密钥对会与之不一致
+	if len(t.PublicKey) != 0 || len(t.Fingerprint) != 0 {
+		return errors.New("public key can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ListenerColumns defines all the load balancer listener table's columns.
+var ListenerColumns = utils.MergeColumns(nil, ListenerColumnDescriptor)
+
+// ListenerColumnDescriptor is load balancer listener table column descriptors.
+var ListenerColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "lb_id", NamedC: "lb_id", Type: enumor.String},
+	{Column: "cloud_lb_id", NamedC: "cloud_lb_id", Type: enumor.String},
+	{Column: "protocol", NamedC: "protocol", Type: enumor.String},
+	{Column: "port", NamedC: "port", Type: enumor.Numeric},
+	{Column: "end_port", NamedC: "end_port", Type: enumor.Numeric},
+	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ListenerTable define load balancer listener table.
+type ListenerTable struct {
+	ID        string          `db:"id" validate:"lte=64" json:"id"`
+	Vendor    enumor.Vendor   `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string          `db:"account_id" validate:"lte=64" json:"account_id"`
+	CloudID   string          `db:"cloud_id" validate:"lte=255" json:"cloud_id"`
+	Name      string          `db:"name" validate:"lte=255" json:"name"`
+	LbID      string          `db:"lb_id" validate:"lte=64" json:"lb_id"`
+	CloudLbID string          `db:"cloud_lb_id" validate:"lte=255" json:"cloud_lb_id"`
+	Protocol  string          `db:"protocol" validate:"lte=32" json:"protocol"`
+	Port      int64           `db:"port" json:"port"`
+	EndPort   int64           `db:"end_port" json:"end_port"`
+	Extension types.JsonField `db:"extension" json:"extension"`
+	Creator   string          `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string          `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time      `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time      `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return load balancer listener table name.
+func (t ListenerTable) TableName() table.Name {
+	return table.LoadBalancerListenerTable
+}
+
+// InsertValidate load balancer listener table when insert.
+func (t ListenerTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.LbID) == 0 {
+		return errors.New("lb_id is required")
+	}
+
+	if len(t.Extension) == 0 {
+		return errors.New("extension is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate load balancer listener table when update.
+func (t ListenerTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
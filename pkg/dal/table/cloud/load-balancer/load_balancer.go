@@ -0,0 +1,141 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer ...
+package loadbalancer
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// LoadBalancerColumns defines all the load balancer table's columns.
+var LoadBalancerColumns = utils.MergeColumns(nil, LoadBalancerColumnDescriptor)
+
+// LoadBalancerColumnDescriptor is load balancer table column descriptors.
+var LoadBalancerColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "zones", NamedC: "zones", Type: enumor.Json},
+	{Column: "lb_type", NamedC: "lb_type", Type: enumor.String},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "cloud_vpc_id", NamedC: "cloud_vpc_id", Type: enumor.String},
+	{Column: "vpc_id", NamedC: "vpc_id", Type: enumor.String},
+	{Column: "domain", NamedC: "domain", Type: enumor.String},
+	{Column: "public_ipv4_addresses", NamedC: "public_ipv4_addresses", Type: enumor.Json},
+	{Column: "private_ipv4_addresses", NamedC: "private_ipv4_addresses", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "cloud_created_time", NamedC: "cloud_created_time", Type: enumor.String},
+	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// LoadBalancerTable define load balancer table.
+type LoadBalancerTable struct {
+	ID                   string                  `db:"id" validate:"lte=64" json:"id"`
+	Vendor               enumor.Vendor           `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID            string                  `db:"account_id" validate:"lte=64" json:"account_id"`
+	CloudID              string                  `db:"cloud_id" validate:"lte=255" json:"cloud_id"`
+	Name                 string                  `db:"name" validate:"lte=255" json:"name"`
+	BkBizID              int64                   `db:"bk_biz_id" json:"bk_biz_id"`
+	Region               string                  `db:"region" validate:"lte=64" json:"region"`
+	Zones                types.StringArray       `db:"zones" json:"zones"`
+	LBType               enumor.LoadBalancerType `db:"lb_type" validate:"lte=32" json:"lb_type"`
+	Status               string                  `db:"status" validate:"lte=64" json:"status"`
+	CloudVpcID           string                  `db:"cloud_vpc_id" validate:"lte=255" json:"cloud_vpc_id"`
+	VpcID                string                  `db:"vpc_id" validate:"lte=64" json:"vpc_id"`
+	Domain               string                  `db:"domain" validate:"lte=255" json:"domain"`
+	PublicIPv4Addresses  types.StringArray       `db:"public_ipv4_addresses" json:"public_ipv4_addresses"`
+	PrivateIPv4Addresses types.StringArray       `db:"private_ipv4_addresses" json:"private_ipv4_addresses"`
+	Memo                 *string                 `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	CloudCreatedTime     string                  `db:"cloud_created_time" validate:"lte=64" json:"cloud_created_time"`
+	Extension            types.JsonField         `db:"extension" json:"extension"`
+	Creator              string                  `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser              string                  `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt            types.Time              `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt            types.Time              `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return load balancer table name.
+func (t LoadBalancerTable) TableName() table.Name {
+	return table.LoadBalancerTable
+}
+
+// InsertValidate load balancer table when insert.
+func (t LoadBalancerTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.Extension) == 0 {
+		return errors.New("extension is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate load balancer table when update.
+func (t LoadBalancerTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
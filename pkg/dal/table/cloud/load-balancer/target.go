@@ -0,0 +1,135 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// TargetColumns defines all the load balancer target table's columns.
+var TargetColumns = utils.MergeColumns(nil, TargetColumnDescriptor)
+
+// TargetColumnDescriptor is load balancer target table column descriptors.
+var TargetColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "lb_id", NamedC: "lb_id", Type: enumor.String},
+	{Column: "listener_id", NamedC: "listener_id", Type: enumor.String},
+	{Column: "cloud_listener_id", NamedC: "cloud_listener_id", Type: enumor.String},
+	{Column: "target_type", NamedC: "target_type", Type: enumor.String},
+	{Column: "cloud_target_id", NamedC: "cloud_target_id", Type: enumor.String},
+	{Column: "ip", NamedC: "ip", Type: enumor.String},
+	{Column: "port", NamedC: "port", Type: enumor.Numeric},
+	{Column: "weight", NamedC: "weight", Type: enumor.Numeric},
+	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// TargetTable define load balancer target table.
+type TargetTable struct {
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// CloudID 后端目标在云上没有独立的ID，由后端目标ID(或IP)与端口组成，在所属监听器下唯一
+	CloudID         string                        `db:"cloud_id" validate:"lte=512" json:"cloud_id"`
+	Vendor          enumor.Vendor                 `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID       string                        `db:"account_id" validate:"lte=64" json:"account_id"`
+	LbID            string                        `db:"lb_id" validate:"lte=64" json:"lb_id"`
+	ListenerID      string                        `db:"listener_id" validate:"lte=64" json:"listener_id"`
+	CloudListenerID string                        `db:"cloud_listener_id" validate:"lte=255" json:"cloud_listener_id"`
+	TargetType      enumor.LoadBalancerTargetType `db:"target_type" validate:"lte=32" json:"target_type"`
+	CloudTargetID   string                        `db:"cloud_target_id" validate:"lte=512" json:"cloud_target_id"`
+	IP              string                        `db:"ip" validate:"lte=64" json:"ip"`
+	Port            int64                         `db:"port" json:"port"`
+	Weight          int64                         `db:"weight" json:"weight"`
+	Extension       types.JsonField               `db:"extension" json:"extension"`
+	Creator         string                        `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser         string                        `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt       types.Time                    `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt       types.Time                    `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return load balancer target table name.
+func (t TargetTable) TableName() table.Name {
+	return table.LoadBalancerTargetTable
+}
+
+// InsertValidate load balancer target table when insert.
+func (t TargetTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.ListenerID) == 0 {
+		return errors.New("listener_id is required")
+	}
+
+	if len(t.TargetType) == 0 {
+		return errors.New("target_type is required")
+	}
+
+	if len(t.Extension) == 0 {
+		return errors.New("extension is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate load balancer target table when update.
+func (t TargetTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package region
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// OpenStackRegionColumns defines all the openstack region table's columns.
+var OpenStackRegionColumns = utils.MergeColumns(nil, OpenStackRegionTableColumnDescriptor)
+
+// OpenStackRegionTableColumnDescriptor is OpenStackRegion's column descriptors.
+var OpenStackRegionTableColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "region_id", NamedC: "region_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// OpenStackRegionTable openstack地域表，openstack地域由各部署自定义，来自账号keystone服务目录，因此按账号保存
+type OpenStackRegionTable struct {
+	// ID 地域ID
+	ID string `db:"id" validate:"lte=64"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" validate:"lte=64"`
+	// RegionID 云上地域ID
+	RegionID string `db:"region_id" validate:"lte=64"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"lte=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"lte=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return openstack region table name.
+func (o OpenStackRegionTable) TableName() table.Name {
+	return table.OpenStackRegionTable
+}
+
+// InsertValidate openstack region table when insert.
+func (o OpenStackRegionTable) InsertValidate() error {
+	// length validate.
+	if err := validator.Validate.Struct(o); err != nil {
+		return err
+	}
+
+	if len(o.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(o.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(o.RegionID) == 0 {
+		return errors.New("region_id is required")
+	}
+
+	if len(o.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(o.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package sgtemplate defines security group template and security group template rel table.
+package sgtemplate
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SGTemplateColumns defines all the security group template table's columns.
+var SGTemplateColumns = utils.MergeColumns(nil, SGTemplateColumnDescriptor)
+
+// SGTemplateColumnDescriptor is security group template table column descriptors.
+var SGTemplateColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "rules", NamedC: "rules", Type: enumor.Json},
+	{Column: "version", NamedC: "version", Type: enumor.Numeric},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SGTemplateTable 安全组模版表，保存与云厂商无关的安全组规则
+type SGTemplateTable struct {
+	// ID 模版ID
+	ID string `db:"id" validate:"max=64" json:"id"`
+	// Name 模版名称
+	Name string `db:"name" validate:"max=255" json:"name"`
+	// Rules 模版规则
+	Rules types.JsonField `db:"rules" json:"rules"`
+	// Version 模版版本，每次修改规则时加一
+	Version uint64 `db:"version" json:"version"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,max=255" json:"memo"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return security group template table name.
+func (t SGTemplateTable) TableName() table.Name {
+	return table.SGTemplateTable
+}
+
+// InsertValidate validate security group template table on insert.
+func (t SGTemplateTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if len(t.Rules) == 0 {
+		return errors.New("rules can not be empty")
+	}
+
+	if t.Version == 0 {
+		return errors.New("version can not be 0")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate security group template table on update.
+func (t SGTemplateTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Rules) != 0 && t.Version == 0 {
+		return errors.New("version must be updated with rules")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
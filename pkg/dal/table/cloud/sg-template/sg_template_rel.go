@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgtemplate
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SGTemplateRelColumns defines all the security group template rel table's columns.
+var SGTemplateRelColumns = utils.MergeColumns(nil, SGTemplateRelColumnDescriptor)
+
+// SGTemplateRelColumnDescriptor is security group template rel table column descriptors.
+var SGTemplateRelColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "template_id", NamedC: "template_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "target_tag", NamedC: "target_tag", Type: enumor.String},
+	{Column: "version", NamedC: "version", Type: enumor.Numeric},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SGTemplateRelTable 安全组模版关联表，记录模版应用到的安全组，gcp没有安全组，记录防火墙规则所在的VPC
+type SGTemplateRelTable struct {
+	// ID 关联ID
+	ID string `db:"id" validate:"max=64" json:"id"`
+	// TemplateID 模版ID
+	TemplateID string `db:"template_id" validate:"max=64" json:"template_id"`
+	// Vendor 关联资源所属云厂商
+	Vendor enumor.Vendor `db:"vendor" validate:"max=16" json:"vendor"`
+	// AccountID 关联资源所属账号ID
+	AccountID string `db:"account_id" validate:"max=64" json:"account_id"`
+	// Region 关联资源所属地域
+	Region string `db:"region" validate:"max=64" json:"region"`
+	// ResType 关联资源类型，security_group 或 vpc
+	ResType enumor.CloudResourceType `db:"res_type" validate:"max=64" json:"res_type"`
+	// ResID 关联资源ID
+	ResID string `db:"res_id" validate:"max=64" json:"res_id"`
+	// TargetTag gcp防火墙规则的目标网络标记
+	TargetTag string `db:"target_tag" validate:"max=64" json:"target_tag"`
+	// Version 已同步到关联资源的模版版本
+	Version uint64 `db:"version" json:"version"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return security group template rel table name.
+func (t SGTemplateRelTable) TableName() table.Name {
+	return table.SGTemplateRelTable
+}
+
+// InsertValidate validate security group template rel table on insert.
+func (t SGTemplateRelTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.TemplateID) == 0 {
+		return errors.New("template_id can not be empty")
+	}
+
+	if err := t.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.ResType) == 0 || len(t.ResID) == 0 {
+		return errors.New("res_type and res_id can not be empty")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate security group template rel table on update, only the synced version can be updated.
+func (t SGTemplateRelTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.TemplateID) != 0 || len(t.Vendor) != 0 || len(t.AccountID) != 0 || len(t.Region) != 0 ||
+		len(t.ResType) != 0 || len(t.ResID) != 0 || len(t.TargetTag) != 0 {
+		return errors.New("only version can update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
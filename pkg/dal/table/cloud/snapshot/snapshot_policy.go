@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SnapshotPolicyColumns defines all the snapshot policy table's columns.
+var SnapshotPolicyColumns = utils.MergeColumns(nil, SnapshotPolicyColumnDescriptor)
+
+// SnapshotPolicyColumnDescriptor is snapshot policy table column descriptors.
+var SnapshotPolicyColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "scope", NamedC: "scope", Type: enumor.String},
+	{Column: "disk_ids", NamedC: "disk_ids", Type: enumor.Json},
+	{Column: "schedule", NamedC: "schedule", Type: enumor.String},
+	{Column: "retention_count", NamedC: "retention_count", Type: enumor.Numeric},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "last_run_at", NamedC: "last_run_at", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SnapshotPolicyTable define snapshot policy table.
+type SnapshotPolicyTable struct {
+	ID      string                     `db:"id" validate:"lte=64" json:"id"`
+	Name    string                     `db:"name" validate:"lte=255" json:"name"`
+	BkBizID int64                      `db:"bk_biz_id" json:"bk_biz_id"`
+	Scope   enumor.SnapshotPolicyScope `db:"scope" validate:"lte=16" json:"scope"`
+	// DiskIDs 策略作用的云盘ID，仅 scope 为 disk 时有效
+	DiskIDs types.StringArray `db:"disk_ids" json:"disk_ids"`
+	// Schedule 标准5段式 cron 表达式：分 时 日 月 周
+	Schedule       string `db:"schedule" validate:"lte=64" json:"schedule"`
+	RetentionCount uint64 `db:"retention_count" json:"retention_count"`
+	Enabled        *bool  `db:"enabled" json:"enabled"`
+	// LastRunAt 策略最近一次执行时间，RFC3339 格式
+	LastRunAt string     `db:"last_run_at" validate:"lte=64" json:"last_run_at"`
+	Memo      *string    `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return snapshot policy table name.
+func (t SnapshotPolicyTable) TableName() table.Name {
+	return table.SnapshotPolicyTable
+}
+
+// InsertValidate snapshot policy table when insert.
+func (t SnapshotPolicyTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if err := t.Scope.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Schedule) == 0 {
+		return errors.New("schedule is required")
+	}
+
+	if t.RetentionCount == 0 {
+		return errors.New("retention_count is required")
+	}
+
+	if t.Enabled == nil {
+		return errors.New("enabled is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate snapshot policy table when update.
+func (t SnapshotPolicyTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Scope) != 0 {
+		if err := t.Scope.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
@@ -0,0 +1,268 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package synctask ...
+package synctask
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SyncTaskColumns defines all the sync task table's columns.
+var SyncTaskColumns = utils.MergeColumns(nil, SyncTaskColumnDescriptor)
+
+// SyncTaskColumnDescriptor is SyncTask's column descriptors.
+var SyncTaskColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "trigger_type", NamedC: "trigger_type", Type: enumor.String},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "change_count", NamedC: "change_count", Type: enumor.Numeric},
+	{Column: "reason", NamedC: "reason", Type: enumor.String},
+	{Column: "start_at", NamedC: "start_at", Type: enumor.String},
+	{Column: "end_at", NamedC: "end_at", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SyncTaskTable 账号资源同步任务表
+type SyncTaskTable struct {
+	// ID 同步任务ID
+	ID string `db:"id" validate:"max=64" json:"id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" validate:"max=16" json:"vendor"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" validate:"max=64" json:"account_id"`
+	// TriggerType 触发方式(timer:定时同步、manual:手动同步、incremental:增量同步)
+	TriggerType enumor.SyncTaskTrigger `db:"trigger_type" validate:"max=16" json:"trigger_type"`
+	// Status 同步状态(running:同步中、success:同步成功、failed:同步失败)
+	Status enumor.SyncTaskStatus `db:"status" validate:"max=16" json:"status"`
+	// ChangeCount 同步时新增、更新、删除的资源总数
+	ChangeCount int64 `db:"change_count" json:"change_count"`
+	// Reason 同步失败原因
+	Reason string `db:"reason" validate:"max=1024" json:"reason"`
+	// StartAt 同步开始时间
+	StartAt string `db:"start_at" validate:"max=64" json:"start_at"`
+	// EndAt 同步结束时间
+	EndAt string `db:"end_at" validate:"max=64" json:"end_at"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return sync task table name.
+func (t SyncTaskTable) TableName() table.Name {
+	return table.SyncTaskTable
+}
+
+// InsertValidate validate sync task table on insert.
+func (t SyncTaskTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if err := t.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id can not be empty")
+	}
+
+	if err := t.TriggerType.Validate(); err != nil {
+		return err
+	}
+
+	if err := t.Status.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.StartAt) == 0 {
+		return errors.New("start_at can not be empty")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate sync task table on update.
+func (t SyncTaskTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Vendor) != 0 || len(t.AccountID) != 0 || len(t.TriggerType) != 0 {
+		return errors.New("vendor, account_id and trigger_type can not update")
+	}
+
+	if len(t.Status) != 0 {
+		if err := t.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
+
+// SyncDetailColumns defines all the sync detail table's columns.
+var SyncDetailColumns = utils.MergeColumns(nil, SyncDetailColumnDescriptor)
+
+// SyncDetailColumnDescriptor is SyncDetail's column descriptors.
+var SyncDetailColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "task_id", NamedC: "task_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "change_count", NamedC: "change_count", Type: enumor.Numeric},
+	{Column: "reason", NamedC: "reason", Type: enumor.String},
+	{Column: "start_at", NamedC: "start_at", Type: enumor.String},
+	{Column: "end_at", NamedC: "end_at", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SyncDetailTable 账号资源同步详情表，记录同步任务中各资源类型的同步情况
+type SyncDetailTable struct {
+	// ID 同步详情ID
+	ID string `db:"id" validate:"max=64" json:"id"`
+	// TaskID 同步任务ID
+	TaskID string `db:"task_id" validate:"max=64" json:"task_id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" validate:"max=16" json:"vendor"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" validate:"max=64" json:"account_id"`
+	// ResType 资源类型
+	ResType enumor.CloudResourceType `db:"res_type" validate:"max=64" json:"res_type"`
+	// Status 同步状态(running:同步中、success:同步成功、failed:同步失败)
+	Status enumor.SyncTaskStatus `db:"status" validate:"max=16" json:"status"`
+	// ChangeCount 同步时新增、更新、删除的资源总数
+	ChangeCount int64 `db:"change_count" json:"change_count"`
+	// Reason 同步失败原因
+	Reason string `db:"reason" validate:"max=1024" json:"reason"`
+	// StartAt 同步开始时间
+	StartAt string `db:"start_at" validate:"max=64" json:"start_at"`
+	// EndAt 同步结束时间
+	EndAt string `db:"end_at" validate:"max=64" json:"end_at"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return sync detail table name.
+func (d SyncDetailTable) TableName() table.Name {
+	return table.SyncDetailTable
+}
+
+// InsertValidate validate sync detail table on insert.
+func (d SyncDetailTable) InsertValidate() error {
+	if err := validator.Validate.Struct(d); err != nil {
+		return err
+	}
+
+	if len(d.TaskID) == 0 {
+		return errors.New("task_id can not be empty")
+	}
+
+	if err := d.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if len(d.AccountID) == 0 {
+		return errors.New("account_id can not be empty")
+	}
+
+	if len(d.ResType) == 0 {
+		return errors.New("res_type can not be empty")
+	}
+
+	if err := d.Status.Validate(); err != nil {
+		return err
+	}
+
+	if len(d.StartAt) == 0 {
+		return errors.New("start_at can not be empty")
+	}
+
+	if len(d.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate sync detail table on update.
+func (d SyncDetailTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(d); err != nil {
+		return err
+	}
+
+	if len(d.TaskID) != 0 || len(d.Vendor) != 0 || len(d.AccountID) != 0 || len(d.ResType) != 0 {
+		return errors.New("task_id, vendor, account_id and res_type can not update")
+	}
+
+	if len(d.Status) != 0 {
+		if err := d.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(d.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(d.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
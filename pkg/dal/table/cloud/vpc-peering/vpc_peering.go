@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package vpcpeering defines vpc peering table.
+package vpcpeering
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// VpcPeeringColumns defines all the vpc peering table's columns.
+var VpcPeeringColumns = utils.MergeColumns(nil, VpcPeeringColumnDescriptor)
+
+// VpcPeeringColumnDescriptor is vpc peering table column descriptors.
+var VpcPeeringColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "type", NamedC: "type", Type: enumor.String},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "cloud_status", NamedC: "cloud_status", Type: enumor.String},
+	{Column: "cloud_gateway_id", NamedC: "cloud_gateway_id", Type: enumor.String},
+	{Column: "local_vpc_id", NamedC: "local_vpc_id", Type: enumor.String},
+	{Column: "local_cloud_vpc_id", NamedC: "local_cloud_vpc_id", Type: enumor.String},
+	{Column: "local_cidrs", NamedC: "local_cidrs", Type: enumor.Json},
+	{Column: "peer_vpc_id", NamedC: "peer_vpc_id", Type: enumor.String},
+	{Column: "peer_cloud_vpc_id", NamedC: "peer_cloud_vpc_id", Type: enumor.String},
+	{Column: "peer_cloud_account_id", NamedC: "peer_cloud_account_id", Type: enumor.String},
+	{Column: "peer_region", NamedC: "peer_region", Type: enumor.String},
+	{Column: "peer_cidrs", NamedC: "peer_cidrs", Type: enumor.Json},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "cloud_created_time", NamedC: "cloud_created_time", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// VpcPeeringTable define vpc peering table.
+type VpcPeeringTable struct {
+	ID        string        `db:"id" validate:"lte=64" json:"id"`
+	Vendor    enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	// Region 对等连接所属地域，腾讯云云联网、Azure、GCP 的对等连接为全局资源，地域为空
+	Region  string                  `db:"region" validate:"lte=64" json:"region"`
+	CloudID string                  `db:"cloud_id" validate:"lte=255" json:"cloud_id"`
+	Name    string                  `db:"name" validate:"lte=255" json:"name"`
+	Type    enumor.VpcPeeringType   `db:"type" validate:"lte=64" json:"type"`
+	Status  enumor.VpcPeeringStatus `db:"status" validate:"lte=32" json:"status"`
+	// CloudStatus 云上原始状态
+	CloudStatus string `db:"cloud_status" validate:"lte=64" json:"cloud_status"`
+	// CloudGatewayID 流量经过的云上网关ID，对等连接为对等连接ID，中转网关关联为中转网关ID，云联网关联为云联网ID
+	CloudGatewayID     string            `db:"cloud_gateway_id" validate:"lte=255" json:"cloud_gateway_id"`
+	LocalVpcID         string            `db:"local_vpc_id" validate:"lte=64" json:"local_vpc_id"`
+	LocalCloudVpcID    string            `db:"local_cloud_vpc_id" validate:"lte=255" json:"local_cloud_vpc_id"`
+	LocalCidrs         types.StringArray `db:"local_cidrs" json:"local_cidrs"`
+	PeerVpcID          string            `db:"peer_vpc_id" validate:"lte=64" json:"peer_vpc_id"`
+	PeerCloudVpcID     string            `db:"peer_cloud_vpc_id" validate:"lte=255" json:"peer_cloud_vpc_id"`
+	PeerCloudAccountID string            `db:"peer_cloud_account_id" validate:"lte=255" json:"peer_cloud_account_id"`
+	PeerRegion         string            `db:"peer_region" validate:"lte=64" json:"peer_region"`
+	PeerCidrs          types.StringArray `db:"peer_cidrs" json:"peer_cidrs"`
+	BkBizID            int64             `db:"bk_biz_id" json:"bk_biz_id"`
+	Memo               *string           `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	CloudCreatedTime   string            `db:"cloud_created_time" validate:"lte=64" json:"cloud_created_time"`
+	Creator            string            `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser            string            `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt          types.Time        `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt          types.Time        `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return vpc peering table name.
+func (t VpcPeeringTable) TableName() table.Name {
+	return table.VpcPeeringTable
+}
+
+// InsertValidate vpc peering table when insert.
+func (t VpcPeeringTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if err := t.Type.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate vpc peering table when update.
+func (t VpcPeeringTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
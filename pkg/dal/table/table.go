@@ -48,8 +48,6 @@ const (
 	SecurityGroupTable Name = "security_group"
 	// VpcSecurityGroupRelTable is vpc and security group table's name.
 	VpcSecurityGroupRelTable Name = "vpc_security_group_rel"
-	// SecurityGroupTagTable is security group tag table's name.
-	SecurityGroupTagTable Name = "security_group_tag"
 	// SecurityGroupSubnetTable is security group subnet table's name.
 	SecurityGroupSubnetTable Name = "security_group_subnet_rel"
 	// SecurityGroupCvmTable is security group cvm table's name.
@@ -64,6 +62,8 @@ const (
 	HuaWeiSecurityGroupRuleTable = "huawei_security_group_rule"
 	// AzureSecurityGroupRuleTable is azure security group rule table's name.
 	AzureSecurityGroupRuleTable = "azure_security_group_rule"
+	// OpenStackSecurityGroupRuleTable is openstack security group rule table's name.
+	OpenStackSecurityGroupRuleTable = "openstack_security_group_rule"
 	// SGNetworkInterfaceRelTable is security group and network interface rel table's name.
 	SGNetworkInterfaceRelTable = "security_group_network_interface_rel"
 	// GcpFirewallRuleTable is gcp firewall rule table's name.
@@ -86,6 +86,8 @@ const (
 	AwsRegionTable Name = "aws_region"
 	// GcpRegionTable is gcp region table's name.
 	GcpRegionTable Name = "gcp_region"
+	// OpenStackRegionTable is openstack region table's name.
+	OpenStackRegionTable Name = "openstack_region"
 	// EipTable is eip table's name
 	EipTable Name = "eip"
 	// ImageTable is image table's name
@@ -121,6 +123,52 @@ const (
 	NetworkInterfaceCvmRelTable Name = "network_interface_cvm_rel"
 	// AccountBillConfigTable is account bill config table's name.
 	AccountBillConfigTable Name = "account_bill_config"
+	// BillItemTable is bill item table's name.
+	BillItemTable Name = "bill_item"
+	// ExchangeRateTable is exchange rate table's name.
+	ExchangeRateTable Name = "exchange_rate"
+	// BudgetTable is budget table's name.
+	BudgetTable Name = "budget"
+	// BudgetAlertTable is budget alert table's name.
+	BudgetAlertTable Name = "budget_alert"
+	// ResDriftEventTable is resource drift event table's name.
+	ResDriftEventTable Name = "res_drift_event"
+	// SyncTaskTable is sync task table's name.
+	SyncTaskTable Name = "sync_task"
+	// SyncDetailTable is sync detail table's name.
+	SyncDetailTable Name = "sync_detail"
+	// SyncWatermarkTable is sync watermark table's name.
+	SyncWatermarkTable Name = "sync_watermark"
+	// LoadBalancerTable is load balancer table's name.
+	LoadBalancerTable Name = "load_balancer"
+	// LoadBalancerListenerTable is load balancer listener table's name.
+	LoadBalancerListenerTable Name = "load_balancer_listener"
+	// LoadBalancerTargetTable is load balancer target table's name.
+	LoadBalancerTargetTable Name = "load_balancer_target"
+	// NatGatewayTable is nat gateway table's name.
+	NatGatewayTable Name = "nat_gateway"
+	// SnapshotTable is disk snapshot table's name.
+	SnapshotTable Name = "snapshot"
+	// SnapshotPolicyTable is snapshot policy table's name.
+	SnapshotPolicyTable Name = "snapshot_policy"
+	// KeyPairTable is hcm managed ssh key pair table's name.
+	KeyPairTable Name = "key_pair"
+	// CloudKeyPairTable is cloud key pair table's name.
+	CloudKeyPairTable Name = "cloud_key_pair"
+	// BucketTable is object storage bucket table's name.
+	BucketTable Name = "bucket"
+	// VpcPeeringTable is vpc peering table's name.
+	VpcPeeringTable Name = "vpc_peering"
+	// CidrPoolTable is ipam cidr pool table's name.
+	CidrPoolTable Name = "cidr_pool"
+	// CidrAllocationTable is ipam cidr allocation table's name.
+	CidrAllocationTable Name = "cidr_allocation"
+	// SGTemplateTable is security group template table's name.
+	SGTemplateTable Name = "security_group_template"
+	// SGTemplateRelTable is security group template rel table's name.
+	SGTemplateRelTable Name = "security_group_template_rel"
+	// ResourceTagTable is cloud resource tag table's name.
+	ResourceTagTable Name = "resource_tag"
 
 	// RecycleRecordTableTaskID is recycle record table's task id.
 	// TODO: 之后考虑非表id的id_generator如何更优雅的使用
@@ -143,48 +191,72 @@ func (n Name) Validate() error {
 
 // TableMap table map config
 var TableMap = map[Name]struct{}{
-	AuditTable:                   {},
-	AccountTable:                 {},
-	AccountBizRelTable:           {},
-	VpcTable:                     {},
-	SubnetTable:                  {},
-	IDGenerator:                  {},
-	SecurityGroupTable:           {},
-	VpcSecurityGroupRelTable:     {},
-	SecurityGroupTagTable:        {},
-	SecurityGroupSubnetTable:     {},
-	SGSecurityGroupRuleTable:     {},
-	TCloudSecurityGroupRuleTable: {},
-	AwsSecurityGroupRuleTable:    {},
-	HuaWeiSecurityGroupRuleTable: {},
-	AzureSecurityGroupRuleTable:  {},
-	SGNetworkInterfaceRelTable:   {},
-	GcpFirewallRuleTable:         {},
-	HuaWeiRegionTable:            {},
-	AzureRGTable:                 {},
-	AzureRegionTable:             {},
-	GcpRegionTable:               {},
-	AwsRegionTable:               {},
-	TCloudRegionTable:            {},
-	RouteTableTable:              {},
-	TCloudRouteTable:             {},
-	AwsRouteTable:                {},
-	AzureRouteTable:              {},
-	HuaWeiRouteTable:             {},
-	GcpRouteTable:                {},
-	ZoneTable:                    {},
-	CvmTable:                     {},
-	ApplicationTable:             {},
-	ApprovalProcessTable:         {},
-	NetworkInterfaceTable:        {},
-	NetworkInterfaceCvmRelTable:  {},
-	RecycleRecordTable:           {},
-	EipTable:                     {},
-	DiskTable:                    {},
-	ImageTable:                   {},
-	DiskCvmRelTableName:          {},
-	EipCvmRelTableName:           {},
-	AccountBillConfigTable:       {},
+	AuditTable:                      {},
+	AccountTable:                    {},
+	AccountBizRelTable:              {},
+	VpcTable:                        {},
+	SubnetTable:                     {},
+	IDGenerator:                     {},
+	SecurityGroupTable:              {},
+	VpcSecurityGroupRelTable:        {},
+	SecurityGroupSubnetTable:        {},
+	SGSecurityGroupRuleTable:        {},
+	TCloudSecurityGroupRuleTable:    {},
+	AwsSecurityGroupRuleTable:       {},
+	HuaWeiSecurityGroupRuleTable:    {},
+	AzureSecurityGroupRuleTable:     {},
+	OpenStackSecurityGroupRuleTable: {},
+	SGNetworkInterfaceRelTable:      {},
+	GcpFirewallRuleTable:            {},
+	HuaWeiRegionTable:               {},
+	AzureRGTable:                    {},
+	AzureRegionTable:                {},
+	GcpRegionTable:                  {},
+	AwsRegionTable:                  {},
+	TCloudRegionTable:               {},
+	OpenStackRegionTable:            {},
+	RouteTableTable:                 {},
+	TCloudRouteTable:                {},
+	AwsRouteTable:                   {},
+	AzureRouteTable:                 {},
+	HuaWeiRouteTable:                {},
+	GcpRouteTable:                   {},
+	ZoneTable:                       {},
+	CvmTable:                        {},
+	ApplicationTable:                {},
+	ApprovalProcessTable:            {},
+	NetworkInterfaceTable:           {},
+	NetworkInterfaceCvmRelTable:     {},
+	RecycleRecordTable:              {},
+	EipTable:                        {},
+	DiskTable:                       {},
+	ImageTable:                      {},
+	DiskCvmRelTableName:             {},
+	EipCvmRelTableName:              {},
+	AccountBillConfigTable:          {},
+	BillItemTable:                   {},
+	ExchangeRateTable:               {},
+	BudgetTable:                     {},
+	BudgetAlertTable:                {},
+	ResDriftEventTable:              {},
+	SyncTaskTable:                   {},
+	SyncDetailTable:                 {},
+	SyncWatermarkTable:              {},
+	LoadBalancerTable:               {},
+	LoadBalancerListenerTable:       {},
+	LoadBalancerTargetTable:         {},
+	NatGatewayTable:                 {},
+	SnapshotTable:                   {},
+	SnapshotPolicyTable:             {},
+	KeyPairTable:                    {},
+	CloudKeyPairTable:               {},
+	BucketTable:                     {},
+	VpcPeeringTable:                 {},
+	CidrPoolTable:                   {},
+	CidrAllocationTable:             {},
+	SGTemplateTable:                 {},
+	SGTemplateRelTable:              {},
+	ResourceTagTable:                {},
 
 	// TODO: 临时方案
 	RecycleRecordTableTaskID: {},
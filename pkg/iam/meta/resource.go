@@ -55,6 +55,28 @@ const (
 	RecycleBin ResourceType = "recycle_bin"
 	// NetworkInterface defines eip hcm network_interface resource type
 	NetworkInterface ResourceType = "network_interface"
+	// LoadBalancer defines load balancer's hcm auth resource type
+	LoadBalancer ResourceType = "load_balancer"
+	// NatGateway defines nat gateway's hcm auth resource type
+	NatGateway ResourceType = "nat_gateway"
+	// Snapshot defines disk snapshot's hcm auth resource type
+	Snapshot ResourceType = "snapshot"
+	// SnapshotPolicy defines snapshot policy's hcm auth resource type
+	SnapshotPolicy ResourceType = "snapshot_policy"
+	// KeyPair defines hcm managed ssh key pair's hcm auth resource type
+	KeyPair ResourceType = "key_pair"
+	// CloudKeyPair defines cloud key pair's hcm auth resource type
+	CloudKeyPair ResourceType = "cloud_key_pair"
+	// Bucket defines object storage bucket's hcm auth resource type
+	Bucket ResourceType = "bucket"
+	// VpcPeering defines vpc peering's hcm auth resource type
+	VpcPeering ResourceType = "vpc_peering"
+	// CidrPool defines ipam cidr pool's hcm auth resource type
+	CidrPool ResourceType = "cidr_pool"
+	// SecurityGroupTemplate defines security group template's hcm auth resource type
+	SecurityGroupTemplate ResourceType = "security_group_template"
+	// Image defines private image's hcm auth resource type
+	Image ResourceType = "image"
 	// Audit defines audit log's hcm auth resource type
 	Audit ResourceType = "biz_audit"
 	// Biz defines biz's hcm auth resource type
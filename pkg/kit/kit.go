@@ -78,6 +78,19 @@ func (kt *Kit) ContextWithRid() context.Context {
 	return context.WithValue(kt.Ctx, constant.RidKey, kt.Rid)
 }
 
+// Detach returns a copy of kit with a new context which will not be canceled when the request is finished,
+// it is used by the task that still runs asynchronously after the request is returned.
+func (kt *Kit) Detach() *Kit {
+	return &Kit{
+		Ctx:           context.WithValue(context.TODO(), constant.RidKey, kt.Rid),
+		User:          kt.User,
+		Rid:           kt.Rid,
+		AppCode:       kt.AppCode,
+		TenantID:      kt.TenantID,
+		RequestSource: kt.RequestSource,
+	}
+}
+
 // CtxWithTimeoutMS create a new context with basic info and timout configuration.
 func (kt *Kit) CtxWithTimeoutMS(timeoutMS int) context.CancelFunc {
 	ctx := context.WithValue(context.TODO(), constant.RidKey, kt.Rid)
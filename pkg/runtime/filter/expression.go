@@ -453,8 +453,11 @@ func (ar AtomRule) Validate(opt *ExprOption) error {
 			return fmt.Errorf("rule field: %s is not exist in the expr option", ar.Field)
 		}
 
-		if err := validateFieldValue(ar.Value, typ); err != nil {
-			return fmt.Errorf("invalid %s's value, %v", ar.Field, err)
+		// tag operator's value is tag key or tag key-value pair, instead of the value of the field.
+		if !isTagOp(ar.Op) {
+			if err := validateFieldValue(ar.Value, typ); err != nil {
+				return fmt.Errorf("invalid %s's value, %v", ar.Field, err)
+			}
 		}
 	}
 
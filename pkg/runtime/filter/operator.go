@@ -63,6 +63,11 @@ func init() {
 	opFactory[JSONContainsPath.Factory()] = JSONContainsPathOp(JSONContainsPath)
 	opFactory[JSONNotContainsPath.Factory()] = JSONNotContainsPathOp(JSONNotContainsPath)
 	opFactory[JSONLength.Factory()] = JSONLengthOp(JSONLength)
+
+	opFactory[TagEqual.Factory()] = TagEqualOp(TagEqual)
+	opFactory[TagIn.Factory()] = TagInOp(TagIn)
+	opFactory[TagExists.Factory()] = TagExistsOp(TagExists)
+	opFactory[TagNotExists.Factory()] = TagNotExistsOp(TagNotExists)
 }
 
 const (
@@ -169,6 +174,8 @@ func (op OpType) Validate() error {
 	case JSONEqual, JSONIn, JSONContains, JSONOverlaps,
 		JSONContainsPath, JSONNotContainsPath, JSONLength:
 
+	case TagEqual, TagIn, TagExists, TagNotExists:
+
 	default:
 		return fmt.Errorf("unsupported operator: %s", op)
 	}
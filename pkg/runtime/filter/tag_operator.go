@@ -0,0 +1,259 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// resourceTagTable is the table which stores tags of cloud resources, tag operators match resources by a sub
+// query on this table, so the rule's field should be the id column of the resource, e.g. id.
+const resourceTagTable = "resource_tag"
+
+const (
+	// TagEqual 资源的标签中存在指定的标签键，且标签值等于指定值，
+	// 值格式：{"res_type": "cvm", "key": "owner", "value": "tom"}
+	TagEqual OpType = "tag_eq"
+	// TagIn 资源的标签中存在指定的标签键，且标签值在指定值中，
+	// 值格式：{"res_type": "cvm", "key": "owner", "values": ["tom", "jerry"]}
+	TagIn OpType = "tag_in"
+	// TagExists 资源的标签中存在指定的标签键，值格式：{"res_type": "cvm", "key": "owner"}
+	TagExists OpType = "tag_exists"
+	// TagNotExists 资源的标签中不存在指定的标签键，值格式：{"res_type": "cvm", "key": "owner"}
+	TagNotExists OpType = "tag_not_exists"
+)
+
+// isTagOp returns if the operator is a tag operator.
+func isTagOp(of OpFactory) bool {
+	switch OpType(of) {
+	case TagEqual, TagIn, TagExists, TagNotExists:
+		return true
+	default:
+		return false
+	}
+}
+
+// TagValue is the value of tag operators, resource type is required because the ids of different types of
+// resources may be the same.
+type TagValue struct {
+	ResType enumor.CloudResourceType `json:"res_type"`
+	Key     string                   `json:"key"`
+	Value   string                   `json:"value,omitempty"`
+	Values  []string                 `json:"values,omitempty"`
+}
+
+// parseTagValue parse tag value from the rule's value, the value is a map when it is decoded from json.
+func parseTagValue(v interface{}) (*TagValue, error) {
+	var tag *TagValue
+	switch val := v.(type) {
+	case TagValue:
+		tag = &val
+	case *TagValue:
+		tag = val
+	default:
+		if v == nil || reflect.ValueOf(v).Kind() != reflect.Map {
+			return nil, errors.New("tag operator's value should be an object with res_type and key")
+		}
+
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		tag = new(TagValue)
+		if err = json.Unmarshal(raw, tag); err != nil {
+			return nil, fmt.Errorf("invalid tag operator's value, err: %v", err)
+		}
+	}
+
+	if tag == nil || len(tag.ResType) == 0 {
+		return nil, errors.New("tag operator's res_type is required")
+	}
+
+	if len(tag.Key) == 0 {
+		return nil, errors.New("tag operator's key is required")
+	}
+
+	return tag, nil
+}
+
+// tagSubQuery returns the sub query which selects the ids of the resources of the resource type with the tag key.
+func tagSubQuery(field string, tag *TagValue) (string, map[string]interface{}) {
+	resTypePlaceholder := fieldPlaceholderName(field + "_tag_res_type")
+	keyPlaceholder := fieldPlaceholderName(field + "_tag_key")
+	subQuery := fmt.Sprintf(`SELECT res_id FROM %s WHERE res_type = %s%s AND tag_key = %s%s`, resourceTagTable,
+		SqlPlaceholder, resTypePlaceholder, SqlPlaceholder, keyPlaceholder)
+
+	return subQuery, map[string]interface{}{resTypePlaceholder: string(tag.ResType), keyPlaceholder: tag.Key}
+}
+
+// TagEqualOp is tag equal operator
+type TagEqualOp OpType
+
+// Name is tag equal operator
+func (op TagEqualOp) Name() OpType {
+	return TagEqual
+}
+
+// ValidateValue validate tag equal's value
+func (op TagEqualOp) ValidateValue(v interface{}, _ *ExprOption) error {
+	_, err := parseTagValue(v)
+	return err
+}
+
+// SQLExprAndValue convert this operator's field and value to a mysql's sub query expression.
+func (op TagEqualOp) SQLExprAndValue(field string, value interface{}) (string, map[string]interface{}, error) {
+	if len(field) == 0 {
+		return "", nil, errors.New("field is empty")
+	}
+
+	tag, err := parseTagValue(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	subQuery, valueMap := tagSubQuery(field, tag)
+	placeholder := fieldPlaceholderName(field + "_tag_value")
+	valueMap[placeholder] = tag.Value
+
+	return fmt.Sprintf(`%s IN (%s AND tag_value = %s%s)`, field, subQuery, SqlPlaceholder, placeholder), valueMap,
+		nil
+}
+
+// TagInOp is tag in operator
+type TagInOp OpType
+
+// Name is tag in operator
+func (op TagInOp) Name() OpType {
+	return TagIn
+}
+
+// ValidateValue validate tag in's value
+func (op TagInOp) ValidateValue(v interface{}, opt *ExprOption) error {
+	tag, err := parseTagValue(v)
+	if err != nil {
+		return err
+	}
+
+	if len(tag.Values) == 0 {
+		return errors.New("invalid tag in operator's values, at least have one element")
+	}
+
+	maxInV := DefaultMaxInLimit
+	if opt != nil && opt.MaxInLimit > 0 {
+		maxInV = opt.MaxInLimit
+	}
+
+	if len(tag.Values) > int(maxInV) {
+		return fmt.Errorf("invalid tag in operator's values, at most have %d elements", maxInV)
+	}
+
+	return nil
+}
+
+// SQLExprAndValue convert this operator's field and value to a mysql's sub query expression.
+func (op TagInOp) SQLExprAndValue(field string, value interface{}) (string, map[string]interface{}, error) {
+	if len(field) == 0 {
+		return "", nil, errors.New("field is empty")
+	}
+
+	tag, err := parseTagValue(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(tag.Values) == 0 {
+		return "", nil, errors.New("tag in operator's values is empty")
+	}
+
+	subQuery, valueMap := tagSubQuery(field, tag)
+	placeholder := fieldPlaceholderName(field + "_tag_value")
+	valueMap[placeholder] = tag.Values
+
+	return fmt.Sprintf(`%s IN (%s AND tag_value IN (%s%s))`, field, subQuery, SqlPlaceholder, placeholder),
+		valueMap, nil
+}
+
+// TagExistsOp is tag exists operator
+type TagExistsOp OpType
+
+// Name is tag exists operator
+func (op TagExistsOp) Name() OpType {
+	return TagExists
+}
+
+// ValidateValue validate tag exists's value
+func (op TagExistsOp) ValidateValue(v interface{}, _ *ExprOption) error {
+	_, err := parseTagValue(v)
+	return err
+}
+
+// SQLExprAndValue convert this operator's field and value to a mysql's sub query expression.
+func (op TagExistsOp) SQLExprAndValue(field string, value interface{}) (string, map[string]interface{}, error) {
+	if len(field) == 0 {
+		return "", nil, errors.New("field is empty")
+	}
+
+	tag, err := parseTagValue(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	subQuery, valueMap := tagSubQuery(field, tag)
+
+	return fmt.Sprintf(`%s IN (%s)`, field, subQuery), valueMap, nil
+}
+
+// TagNotExistsOp is tag not exists operator
+type TagNotExistsOp OpType
+
+// Name is tag not exists operator
+func (op TagNotExistsOp) Name() OpType {
+	return TagNotExists
+}
+
+// ValidateValue validate tag not exists's value
+func (op TagNotExistsOp) ValidateValue(v interface{}, _ *ExprOption) error {
+	_, err := parseTagValue(v)
+	return err
+}
+
+// SQLExprAndValue convert this operator's field and value to a mysql's sub query expression.
+func (op TagNotExistsOp) SQLExprAndValue(field string, value interface{}) (string, map[string]interface{},
+	error) {
+
+	if len(field) == 0 {
+		return "", nil, errors.New("field is empty")
+	}
+
+	tag, err := parseTagValue(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	subQuery, valueMap := tagSubQuery(field, tag)
+
+	return fmt.Sprintf(`%s NOT IN (%s)`, field, subQuery), valueMap, nil
+}
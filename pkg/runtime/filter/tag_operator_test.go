@@ -0,0 +1,152 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package filter
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// placeholderRegexp matches the random suffix of the placeholders.
+var placeholderRegexp = regexp.MustCompile(`(:[a-z_]+)_[a-zA-Z0-9]{4}`)
+
+func trimPlaceholderSuffix(expr string) string {
+	return placeholderRegexp.ReplaceAllString(expr, "$1")
+}
+
+func TestTagEqualSQLExpr(t *testing.T) {
+	op := TagEqualOp(TagEqual)
+	expr, valueMap, err := op.SQLExprAndValue("id", map[string]interface{}{"res_type": "cvm", "key": "owner",
+		"value": "tom"})
+	if err != nil {
+		t.Errorf("test tag eq operator failed, err: %v", err)
+		return
+	}
+
+	want := `id IN (SELECT res_id FROM resource_tag WHERE res_type = :id_tag_res_type AND tag_key = :id_tag_key ` +
+		`AND tag_value = :id_tag_value)`
+	if trimPlaceholderSuffix(expr) != want {
+		t.Errorf("test tag eq operator got wrong expr: %s", expr)
+		return
+	}
+
+	if len(valueMap) != 3 {
+		t.Errorf("test tag eq operator got wrong value: %v", valueMap)
+		return
+	}
+
+	expectValues := map[string]interface{}{":id_tag_res_type": "cvm", ":id_tag_key": "owner", ":id_tag_value": "tom"}
+	for key, val := range valueMap {
+		if expectValues[trimPlaceholderSuffix(":"+key)] != val {
+			t.Errorf("test tag eq operator got wrong value map: %v", valueMap)
+			return
+		}
+	}
+}
+
+func TestTagInSQLExpr(t *testing.T) {
+	op := TagInOp(TagIn)
+	expr, valueMap, err := op.SQLExprAndValue("id", TagValue{ResType: enumor.CvmCloudResType, Key: "owner",
+		Values: []string{"tom", "jerry"}})
+	if err != nil {
+		t.Errorf("test tag in operator failed, err: %v", err)
+		return
+	}
+
+	want := `id IN (SELECT res_id FROM resource_tag WHERE res_type = :id_tag_res_type AND tag_key = :id_tag_key ` +
+		`AND tag_value IN (:id_tag_value))`
+	if trimPlaceholderSuffix(expr) != want {
+		t.Errorf("test tag in operator got wrong expr: %s", expr)
+		return
+	}
+
+	if len(valueMap) != 3 {
+		t.Errorf("test tag in operator got wrong value: %v", valueMap)
+		return
+	}
+
+	if err = op.ValidateValue(TagValue{ResType: enumor.CvmCloudResType, Key: "owner"}, nil); err == nil {
+		t.Errorf("test tag in operator without values expect error, but not")
+		return
+	}
+}
+
+func TestTagExistsSQLExpr(t *testing.T) {
+	exists := TagExistsOp(TagExists)
+	tag := TagValue{ResType: enumor.DiskCloudResType, Key: "owner"}
+	expr, valueMap, err := exists.SQLExprAndValue("id", tag)
+	if err != nil {
+		t.Errorf("test tag exists operator failed, err: %v", err)
+		return
+	}
+
+	if trimPlaceholderSuffix(expr) != `id IN (SELECT res_id FROM resource_tag WHERE `+
+		`res_type = :id_tag_res_type AND tag_key = :id_tag_key)` {
+		t.Errorf("test tag exists operator got wrong expr: %s", expr)
+		return
+	}
+
+	if len(valueMap) != 2 {
+		t.Errorf("test tag exists operator got wrong value: %v", valueMap)
+		return
+	}
+
+	notExists := TagNotExistsOp(TagNotExists)
+	expr, _, err = notExists.SQLExprAndValue("id", tag)
+	if err != nil {
+		t.Errorf("test tag not exists operator failed, err: %v", err)
+		return
+	}
+
+	if trimPlaceholderSuffix(expr) != `id NOT IN (SELECT res_id FROM resource_tag WHERE `+
+		`res_type = :id_tag_res_type AND tag_key = :id_tag_key)` {
+		t.Errorf("test tag not exists operator got wrong expr: %s", expr)
+		return
+	}
+
+	if err = notExists.ValidateValue(map[string]interface{}{"key": "owner"}, nil); err == nil {
+		t.Errorf("test tag not exists operator without res_type expect error, but not")
+		return
+	}
+}
+
+func TestTagRuleValidate(t *testing.T) {
+	raw := `{"op":"and","rules":[{"field":"id","op":"tag_eq",` +
+		`"value":{"res_type":"cvm","key":"owner","value":"tom"}}]}`
+	expr := new(Expression)
+	if err := json.Unmarshal([]byte(raw), expr); err != nil {
+		t.Errorf("unmarshal tag expression failed, err: %v", err)
+		return
+	}
+
+	opt := &ExprOption{RuleFields: map[string]enumor.ColumnType{"id": enumor.String}}
+	if err := expr.Validate(opt); err != nil {
+		t.Errorf("validate tag expression failed, err: %v", err)
+		return
+	}
+
+	if _, _, err := expr.SQLWhereExpr(&SQLWhereOption{Priority: Priority{"id"}}); err != nil {
+		t.Errorf("gen tag expression sql failed, err: %v", err)
+		return
+	}
+}
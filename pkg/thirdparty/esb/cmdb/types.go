@@ -247,20 +247,22 @@ type Host struct {
 type CloudVendor string
 
 const (
-	AwsCloudVendor    CloudVendor = "1"
-	TCloudCloudVendor CloudVendor = "2"
-	GcpCloudVendor    CloudVendor = "3"
-	AzureCloudVendor  CloudVendor = "4"
-	HuaWeiCloudVendor CloudVendor = "15"
+	AwsCloudVendor     CloudVendor = "1"
+	TCloudCloudVendor  CloudVendor = "2"
+	GcpCloudVendor     CloudVendor = "3"
+	AzureCloudVendor   CloudVendor = "4"
+	PrivateCloudVendor CloudVendor = "5"
+	HuaWeiCloudVendor  CloudVendor = "15"
 )
 
 // HcmCmdbVendorMap is hcm vendor to cmdb cloud vendor map.
 var HcmCmdbVendorMap = map[enumor.Vendor]CloudVendor{
-	enumor.Aws:    AwsCloudVendor,
-	enumor.TCloud: TCloudCloudVendor,
-	enumor.Gcp:    GcpCloudVendor,
-	enumor.Azure:  AzureCloudVendor,
-	enumor.HuaWei: HuaWeiCloudVendor,
+	enumor.Aws:       AwsCloudVendor,
+	enumor.TCloud:    TCloudCloudVendor,
+	enumor.Gcp:       GcpCloudVendor,
+	enumor.Azure:     AzureCloudVendor,
+	enumor.HuaWei:    HuaWeiCloudVendor,
+	enumor.OpenStack: PrivateCloudVendor,
 }
 
 // CloudHostStatus defines cmdb cloud host status type.
@@ -277,11 +279,12 @@ const (
 
 // HcmCmdbHostStatusMap is hcm vendor to cmdb cloud host status map.
 var HcmCmdbHostStatusMap = map[enumor.Vendor]map[string]CloudHostStatus{
-	enumor.TCloud: TCloudCmdbStatusMap,
-	enumor.Aws:    AwsCmdbStatusMap,
-	enumor.Gcp:    GcpCmdbStatusMap,
-	enumor.Azure:  AzureCmdbStatusMap,
-	enumor.HuaWei: HuaWeiCmdbStatusMap,
+	enumor.TCloud:    TCloudCmdbStatusMap,
+	enumor.Aws:       AwsCmdbStatusMap,
+	enumor.Gcp:       GcpCmdbStatusMap,
+	enumor.Azure:     AzureCmdbStatusMap,
+	enumor.HuaWei:    HuaWeiCmdbStatusMap,
+	enumor.OpenStack: OpenStackCmdbStatusMap,
 }
 
 // TCloudCmdbStatusMap is tcloud status to cmdb cloud host status map.
@@ -345,3 +348,25 @@ var HuaWeiCmdbStatusMap = map[string]CloudHostStatus{
 	"SHELVED_OFFLOADED": UnknownCloudHostStatus,
 	"UNKNOWN":           UnknownCloudHostStatus,
 }
+
+// OpenStackCmdbStatusMap is openstack nova server status to cmdb cloud host status map.
+var OpenStackCmdbStatusMap = map[string]CloudHostStatus{
+	"BUILD":             StartingCloudHostStatus,
+	"ACTIVE":            RunningCloudHostStatus,
+	"REBOOT":            UnknownCloudHostStatus,
+	"HARD_REBOOT":       UnknownCloudHostStatus,
+	"REBUILD":           UnknownCloudHostStatus,
+	"MIGRATING":         UnknownCloudHostStatus,
+	"RESIZE":            UnknownCloudHostStatus,
+	"VERIFY_RESIZE":     UnknownCloudHostStatus,
+	"REVERT_RESIZE":     UnknownCloudHostStatus,
+	"PAUSED":            StoppedCloudHostStatus,
+	"SUSPENDED":         StoppedCloudHostStatus,
+	"SHUTOFF":           StoppedCloudHostStatus,
+	"SHELVED":           StoppedCloudHostStatus,
+	"SHELVED_OFFLOADED": StoppedCloudHostStatus,
+	"SOFT_DELETED":      TerminatedCloudHostStatus,
+	"DELETED":           TerminatedCloudHostStatus,
+	"ERROR":             UnknownCloudHostStatus,
+	"UNKNOWN":           UnknownCloudHostStatus,
+}
@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package notifier defines the pluggable notifier to send notify message to outside systems.
+package notifier
+
+import (
+	"hcm/pkg/cc"
+	"hcm/pkg/kit"
+)
+
+// Notifier send notify message to outside system.
+type Notifier interface {
+	// Name return the notifier name.
+	Name() string
+	// Notify send the notify message.
+	Notify(kt *kit.Kit, msg *Message) error
+}
+
+// Message is the notify message.
+type Message struct {
+	// Type 消息类型，如：budget_alert
+	Type string `json:"type"`
+	// Title 消息标题
+	Title string `json:"title"`
+	// Content 消息内容
+	Content string `json:"content"`
+	// Data 消息携带的结构化数据
+	Data interface{} `json:"data,omitempty"`
+}
+
+// NewNotifiers create notifiers by webhook config, returns empty notifiers if nothing configured.
+func NewNotifiers(webhook cc.Webhook) []Notifier {
+	notifiers := make([]Notifier, 0)
+	if len(webhook.URL) != 0 {
+		notifiers = append(notifiers, NewWebhook(webhook))
+	}
+
+	return notifiers
+}
@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package notifier
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/json"
+)
+
+// defaultWebhookTimeout default webhook post timeout.
+const defaultWebhookTimeout = 10 * time.Second
+
+// NewWebhook create a notifier which post notify message as json to the webhook url.
+func NewWebhook(cfg cc.Webhook) Notifier {
+	timeout := defaultWebhookTimeout
+	if cfg.TimeoutSec != 0 {
+		timeout = time.Duration(cfg.TimeoutSec) * time.Second
+	}
+
+	return &webhook{
+		url:    cfg.URL,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+// Name return webhook notifier name.
+func (w *webhook) Name() string {
+	return "webhook"
+}
+
+// Notify post notify message to webhook url.
+func (w *webhook) Notify(kt *kit.Kit, msg *Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal notify message failed, err: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("new webhook request failed, err: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.RidKey, kt.Rid)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook failed, err: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("post webhook failed, status code: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
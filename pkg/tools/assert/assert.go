@@ -281,3 +281,18 @@ func IsStringMapEqual(a map[string]string, b map[string]string) bool {
 
 	return true
 }
+
+// IsJsonEqual test if two values are equal after json marshal, used to compare struct which has omitempty fields.
+func IsJsonEqual(a, b interface{}) bool {
+	aJson, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+
+	bJson, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return string(aJson) == string(bJson)
+}
@@ -64,6 +64,39 @@ func CidrIPCounts(cidr string) (int, error) {
 	return totalIPs - 2, nil
 }
 
+// CidrContains check if inner cidr is entirely contained in outer cidr, cidrs of different ip address type are
+// never contained in each other.
+func CidrContains(outer, inner string) (bool, error) {
+	_, outerNet, err := net.ParseCIDR(outer)
+	if err != nil {
+		return false, err
+	}
+
+	_, innerNet, err := net.ParseCIDR(inner)
+	if err != nil {
+		return false, err
+	}
+
+	outerOnes, outerBits := outerNet.Mask.Size()
+	innerOnes, innerBits := innerNet.Mask.Size()
+	if outerBits != innerBits || outerOnes > innerOnes {
+		return false, nil
+	}
+
+	return outerNet.Contains(innerNet.IP), nil
+}
+
+// CidrMaskLen get mask length of cidr, e.g. 24 for 10.0.0.0/24.
+func CidrMaskLen(cidr string) (int, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return 0, err
+	}
+
+	ones, _ := ipNet.Mask.Size()
+	return ones, nil
+}
+
 // IpNumToMasklen calculate the netmask len by number of ip, if number of ip less then 4 treat as 4.
 func IpNumToMasklen(ipnum int) int {
 	// calculat ceil(log2(x)), if x < 4 , treat x as 4
@@ -143,3 +176,204 @@ func NextAvailableNet(outer net.IPNet, used []net.IPNet, masklen int) (net.IPNet
 	return nextAvailable, nil
 
 }
+
+// CidrOverlap check if two cidrs have any ip address in common, cidrs of different ip address type never overlap.
+func CidrOverlap(a, b string) (bool, error) {
+	_, aNet, err := net.ParseCIDR(a)
+	if err != nil {
+		return false, err
+	}
+
+	_, bNet, err := net.ParseCIDR(b)
+	if err != nil {
+		return false, err
+	}
+
+	return NetOverlap(*aNet, *bNet), nil
+}
+
+// NetOverlap check if two nets have any ip address in common.
+func NetOverlap(a, b net.IPNet) bool {
+	_, aBits := a.Mask.Size()
+	_, bBits := b.Mask.Size()
+	if aBits != bBits {
+		return false
+	}
+
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// FindOverlapPairs find all pairs of overlapped nets, returns index pairs of nets, the smaller index comes first.
+func FindOverlapPairs(nets []net.IPNet) [][2]int {
+	type netRange struct {
+		index int
+		start []byte
+		end   []byte
+	}
+
+	// 统一转换为16字节的地址范围，按起始地址排序后扫描，只需要和范围尚未结束的网段比较
+	ranges := make([]netRange, 0, len(nets))
+	for index, one := range nets {
+		start, end, ok := netRange16(one)
+		if !ok {
+			continue
+		}
+		ranges = append(ranges, netRange{index: index, start: start, end: end})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return bytes.Compare(ranges[i].start, ranges[j].start) < 0 })
+
+	pairs := make([][2]int, 0)
+	active := make([]netRange, 0)
+	for _, cur := range ranges {
+		remain := active[:0]
+		for _, one := range active {
+			if bytes.Compare(one.end, cur.start) < 0 {
+				continue
+			}
+			remain = append(remain, one)
+
+			if one.index < cur.index {
+				pairs = append(pairs, [2]int{one.index, cur.index})
+			} else {
+				pairs = append(pairs, [2]int{cur.index, one.index})
+			}
+		}
+		active = append(remain, cur)
+	}
+
+	return pairs
+}
+
+// netRange16 get the first and last ip address of net in 16 bytes form, ipv4 net is mapped to ipv6 address.
+func netRange16(n net.IPNet) ([]byte, []byte, bool) {
+	ones, bits := n.Mask.Size()
+	if bits != 8*net.IPv4len && bits != 8*net.IPv6len {
+		return nil, nil, false
+	}
+
+	ip := n.IP.To16()
+	if ip == nil {
+		return nil, nil, false
+	}
+
+	mask := n.Mask
+	if bits == 8*net.IPv4len {
+		mask = net.CIDRMask(ones+8*(net.IPv6len-net.IPv4len), 8*net.IPv6len)
+	}
+
+	start := make([]byte, net.IPv6len)
+	end := make([]byte, net.IPv6len)
+	for i := 0; i < net.IPv6len; i++ {
+		start[i] = ip[i] & mask[i]
+		end[i] = ip[i] | ^mask[i]
+	}
+
+	return start, end, true
+}
+
+// FirstAvailableNet find the first available net in outer from low address to high address, only support ipv4.
+// Params:
+// 1. outer: 待分配的网段
+// 2. used: 已经分配出去的网段，与 NextAvailableNet 不同，used 中的网段可以互相相交，也可以超出 outer 的范围
+// 3. masklen: 待分配的网段掩码长度
+// 已释放网段留下的空洞会被优先分配。
+func FirstAvailableNet(outer net.IPNet, used []net.IPNet, masklen int) (net.IPNet, error) {
+	outerStart, outerEnd, ok := ipv4Range(outer)
+	if !ok {
+		return net.IPNet{}, errors.New("outer net is not ipv4")
+	}
+
+	outerMasklen, _ := outer.Mask.Size()
+	if masklen < outerMasklen {
+		return net.IPNet{}, errors.New("new net mask length is shorter than outer net")
+	}
+
+	if masklen > 32 {
+		return net.IPNet{}, errors.New("new net mask length is longer than 32")
+	}
+
+	size := uint64(1) << uint(32-masklen)
+	candidate := outerStart
+	for _, one := range mergeIPv4Ranges(outer, used) {
+		if candidate+size-1 < one[0] {
+			break
+		}
+
+		if one[1] >= candidate {
+			// 跳过已使用的范围，并按新网段大小对齐
+			candidate = (one[1] + size) / size * size
+		}
+	}
+
+	if candidate+size-1 > outerEnd {
+		return net.IPNet{}, errors.New("out of range")
+	}
+
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, uint32(candidate))
+	return net.IPNet{IP: ip, Mask: net.CIDRMask(masklen, 32)}, nil
+}
+
+// CoveredIPCount count ip addresses of outer which are covered by used nets, ip addresses covered by multiple
+// used nets are only counted once, only support ipv4.
+func CoveredIPCount(outer net.IPNet, used []net.IPNet) uint64 {
+	var count uint64
+	for _, one := range mergeIPv4Ranges(outer, used) {
+		count += one[1] - one[0] + 1
+	}
+
+	return count
+}
+
+// mergeIPv4Ranges clip used nets to outer, then merge them into sorted non-overlapped [start, end] ranges.
+func mergeIPv4Ranges(outer net.IPNet, used []net.IPNet) [][2]uint64 {
+	outerStart, outerEnd, ok := ipv4Range(outer)
+	if !ok {
+		return nil
+	}
+
+	ranges := make([][2]uint64, 0, len(used))
+	for _, one := range used {
+		start, end, ok := ipv4Range(one)
+		if !ok || end < outerStart || start > outerEnd {
+			continue
+		}
+
+		if start < outerStart {
+			start = outerStart
+		}
+		if end > outerEnd {
+			end = outerEnd
+		}
+		ranges = append(ranges, [2]uint64{start, end})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+
+	merged := make([][2]uint64, 0, len(ranges))
+	for _, one := range ranges {
+		last := len(merged) - 1
+		if last >= 0 && one[0] <= merged[last][1]+1 {
+			if one[1] > merged[last][1] {
+				merged[last][1] = one[1]
+			}
+			continue
+		}
+		merged = append(merged, one)
+	}
+
+	return merged
+}
+
+// ipv4Range get the first and last ip address of ipv4 net as integer.
+func ipv4Range(n net.IPNet) (uint64, uint64, bool) {
+	ones, bits := n.Mask.Size()
+	ip := n.IP.To4()
+	if bits != 32 || ip == nil {
+		return 0, 0, false
+	}
+
+	start := uint64(binary.BigEndian.Uint32(ip.Mask(n.Mask)))
+	return start, start + (uint64(1) << uint(32-ones)) - 1, true
+}
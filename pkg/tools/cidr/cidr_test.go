@@ -32,16 +32,16 @@ type NextAvailableNetResult struct {
 
 func TestNextAvailableNet(t *testing.T) {
 
-	_, testNet, _ := net.ParseCIDR("*.*.*.0/24")
+	_, testNet, _ := net.ParseCIDR("192.168.1.0/24")
 	usedNetStr := []string{
-		// "*.*.*.0/24",
-		"*.*.*.0/28",
-		"*.*.*.144/28",
-		"*.*.*.96/27",
-		"*.*.*.64/29",
-		"*.*.*.32/27",
-		"*.*.*.80/28",
-		"*.*.*.128/29",
+		// "192.168.1.0/24",
+		"192.168.1.0/28",
+		"192.168.1.16/28",
+		"192.168.1.96/27",
+		"192.168.1.64/29",
+		"192.168.1.32/27",
+		"192.168.1.80/28",
+		"192.168.1.128/29",
 	}
 	usedNetList := make([]net.IPNet, len(usedNetStr))
 
@@ -52,12 +52,12 @@ func TestNextAvailableNet(t *testing.T) {
 		{"", fmt.Errorf("out of range")},
 		// 25
 		{"", fmt.Errorf("out of range")},
-		{"*.*.*.192/26", nil},
-		{"*.*.*.160/27", nil},
-		{"*.*.*.144/28", nil},
-		{"*.*.*.136/29", nil},
-		{"*.*.*.136/30", nil},
-		{"*.*.*.136/31", nil},
+		{"192.168.1.192/26", nil},
+		{"192.168.1.160/27", nil},
+		{"192.168.1.144/28", nil},
+		{"192.168.1.136/29", nil},
+		{"192.168.1.136/30", nil},
+		{"192.168.1.136/31", nil},
 	}
 	for idx, netStr := range usedNetStr {
 		_, _net, _ := net.ParseCIDR(netStr)
@@ -83,18 +83,18 @@ func TestNextAvailableNet(t *testing.T) {
 
 func TestNextAvailableNet2(t *testing.T) {
 
-	_, testNet, _ := net.ParseCIDR("*.*.*.0/24")
+	_, testNet, _ := net.ParseCIDR("192.168.1.0/24")
 	result := []NextAvailableNetResult{
 		// 23
 		{"", fmt.Errorf("new net mask length is shorter than outer net")},
-		{"*.*.*.0/24", nil},
-		{"*.*.*.0/25", nil},
-		{"*.*.*.0/26", nil},
-		{"*.*.*.0/27", nil},
-		{"*.*.*.0/28", nil},
-		{"*.*.*.0/29", nil},
-		{"*.*.*.0/30", nil},
-		{"*.*.*.0/31", nil},
+		{"192.168.1.0/24", nil},
+		{"192.168.1.0/25", nil},
+		{"192.168.1.0/26", nil},
+		{"192.168.1.0/27", nil},
+		{"192.168.1.0/28", nil},
+		{"192.168.1.0/29", nil},
+		{"192.168.1.0/30", nil},
+		{"192.168.1.0/31", nil},
 	}
 	for i := range result {
 		t.Run(fmt.Sprint("unused", i+23), func(t *testing.T) {
@@ -133,16 +133,16 @@ func TestIpNumToMasklen(t *testing.T) {
 }
 
 func TestNextAvailableNetByIPNum(t *testing.T) {
-	_, testNet, _ := net.ParseCIDR("*.*.*.0/24")
+	_, testNet, _ := net.ParseCIDR("192.168.1.0/24")
 	usedNetStr := []string{
-		// "*.*.*.0/24",
-		"*.*.*.0/28",
-		"*.*.*.144/28",
-		"*.*.*.96/27",
-		"*.*.*.64/29",
-		"*.*.*.32/27",
-		"*.*.*.80/28",
-		"*.*.*.128/29",
+		// "192.168.1.0/24",
+		"192.168.1.0/28",
+		"192.168.1.16/28",
+		"192.168.1.96/27",
+		"192.168.1.64/29",
+		"192.168.1.32/27",
+		"192.168.1.80/28",
+		"192.168.1.128/29",
 	}
 	usedNetList := make([]net.IPNet, len(usedNetStr))
 
@@ -153,14 +153,14 @@ func TestNextAvailableNetByIPNum(t *testing.T) {
 		{"", fmt.Errorf("out of range")},
 		// 25
 		{"", fmt.Errorf("out of range")},
-		{"*.*.*.192/26", nil},
-		{"*.*.*.160/27", nil},
-		{"*.*.*.144/28", nil},
-		{"*.*.*.136/29", nil},
-		{"*.*.*.136/30", nil},
-		{"*.*.*.136/30", nil},
-		{"*.*.*.136/30", nil},
-		{"*.*.*.136/30", nil},
+		{"192.168.1.192/26", nil},
+		{"192.168.1.160/27", nil},
+		{"192.168.1.144/28", nil},
+		{"192.168.1.136/29", nil},
+		{"192.168.1.136/30", nil},
+		{"192.168.1.136/30", nil},
+		{"192.168.1.136/30", nil},
+		{"192.168.1.136/30", nil},
 	}
 	for idx, netStr := range usedNetStr {
 		_, _net, _ := net.ParseCIDR(netStr)
@@ -182,3 +182,175 @@ func TestNextAvailableNetByIPNum(t *testing.T) {
 
 	}
 }
+
+func TestCidrContains(t *testing.T) {
+	cases := []struct {
+		outer  string
+		inner  string
+		expect bool
+	}{
+		{"10.0.0.0/16", "10.0.1.0/24", true},
+		{"10.0.0.0/16", "10.0.0.0/16", true},
+		{"10.0.1.0/24", "10.0.0.0/16", false},
+		{"10.0.0.0/16", "10.1.0.0/24", false},
+		{"0.0.0.0/0", "192.168.1.0/24", true},
+		{"10.0.0.0/8", "fd00::/64", false},
+		{"fd00::/56", "fd00:0:0:1::/64", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.outer+" contains "+c.inner, func(t *testing.T) {
+			got, err := CidrContains(c.outer, c.inner)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.expect {
+				t.Errorf("except %v got %v", c.expect, got)
+			}
+		})
+	}
+
+	if _, err := CidrContains("10.0.0.0/16", "invalid"); err == nil {
+		t.Errorf("except error for invalid cidr")
+	}
+}
+
+func TestCidrMaskLen(t *testing.T) {
+	cases := map[string]int{
+		"10.0.0.0/16": 16,
+		"0.0.0.0/0":   0,
+		"fd00::/64":   64,
+	}
+
+	for cidr, expect := range cases {
+		got, err := CidrMaskLen(cidr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != expect {
+			t.Errorf("cidr %s except %d got %d", cidr, expect, got)
+		}
+	}
+}
+
+func TestCidrOverlap(t *testing.T) {
+	cases := []struct {
+		a      string
+		b      string
+		expect bool
+	}{
+		{"10.0.0.0/16", "10.0.1.0/24", true},
+		{"10.0.1.0/24", "10.0.0.0/16", true},
+		{"10.0.0.0/24", "10.0.1.0/24", false},
+		{"10.0.0.0/8", "fd00::/64", false},
+		{"fd00::/56", "fd00:0:0:1::/64", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.a+" overlap "+c.b, func(t *testing.T) {
+			got, err := CidrOverlap(c.a, c.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.expect {
+				t.Errorf("except %v got %v", c.expect, got)
+			}
+		})
+	}
+}
+
+func TestFindOverlapPairs(t *testing.T) {
+	cidrs := []string{
+		"10.0.0.0/16",
+		"192.168.0.0/24",
+		"10.0.8.0/24",
+		"172.16.0.0/12",
+		"10.1.0.0/16",
+		"10.0.0.0/8",
+		"fd00::/64",
+		"fd00::/56",
+	}
+	nets := make([]net.IPNet, len(cidrs))
+	for i, one := range cidrs {
+		_, n, _ := net.ParseCIDR(one)
+		nets[i] = *n
+	}
+
+	expect := map[[2]int]struct{}{
+		{0, 2}: {}, {0, 5}: {}, {2, 5}: {}, {4, 5}: {}, {6, 7}: {},
+	}
+
+	got := FindOverlapPairs(nets)
+	if len(got) != len(expect) {
+		t.Fatalf("except %d pairs got %v", len(expect), got)
+	}
+	for _, pair := range got {
+		if _, ok := expect[pair]; !ok {
+			t.Errorf("unexpected pair: %v", pair)
+		}
+	}
+}
+
+func TestFirstAvailableNet(t *testing.T) {
+	_, outer, _ := net.ParseCIDR("10.0.0.0/16")
+	usedStr := []string{"10.0.0.0/24", "10.0.2.0/23", "9.0.0.0/8", "10.0.1.128/25", "192.168.0.0/16"}
+	used := make([]net.IPNet, len(usedStr))
+	for i, one := range usedStr {
+		_, n, _ := net.ParseCIDR(one)
+		used[i] = *n
+	}
+
+	cases := []struct {
+		masklen int
+		expect  string
+		isErr   bool
+	}{
+		{15, "", true},
+		{16, "", true},
+		{25, "10.0.1.0/25", false},
+		{24, "10.0.4.0/24", false},
+		{22, "10.0.4.0/22", false},
+		{17, "10.0.128.0/17", false},
+	}
+
+	for _, c := range cases {
+		got, err := FirstAvailableNet(*outer, used, c.masklen)
+		if c.isErr {
+			if err == nil {
+				t.Errorf("masklen %d except error got %s", c.masklen, got.String())
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("masklen %d unexpected error: %v", c.masklen, err)
+		}
+		if got.String() != c.expect {
+			t.Errorf("masklen %d except %s got %s", c.masklen, c.expect, got.String())
+		}
+	}
+
+	_, cover, _ := net.ParseCIDR("10.0.0.0/8")
+	if _, err := FirstAvailableNet(*outer, []net.IPNet{*cover}, 24); err == nil {
+		t.Errorf("except error when outer is covered by used net")
+	}
+}
+
+func TestCoveredIPCount(t *testing.T) {
+	_, outer, _ := net.ParseCIDR("10.0.0.0/16")
+	usedStr := []string{"10.0.0.0/24", "10.0.0.128/25", "10.0.1.0/24", "9.0.0.0/8", "10.0.255.0/24", "10.0.0.0/8"}
+
+	used := make([]net.IPNet, 0)
+	for _, one := range usedStr[:5] {
+		_, n, _ := net.ParseCIDR(one)
+		used = append(used, *n)
+	}
+	if got := CoveredIPCount(*outer, used); got != 768 {
+		t.Errorf("except 768 got %d", got)
+	}
+
+	_, all, _ := net.ParseCIDR(usedStr[5])
+	if got := CoveredIPCount(*outer, append(used, *all)); got != 65536 {
+		t.Errorf("except 65536 got %d", got)
+	}
+}
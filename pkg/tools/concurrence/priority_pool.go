@@ -0,0 +1,148 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package concurrence
+
+import (
+	"container/heap"
+	"errors"
+	gosync "sync"
+)
+
+// ErrPoolClosed 协程池已关闭，不再接收任务
+var ErrPoolClosed = errors.New("priority pool is closed")
+
+// PriorityPool 按优先级执行任务的协程池，优先级数值越大越先执行，同优先级的任务按提交顺序执行。
+type PriorityPool struct {
+	lock   gosync.Mutex
+	cond   *gosync.Cond
+	queue  priorityQueue
+	seq    uint64
+	closed bool
+	wg     gosync.WaitGroup
+}
+
+// NewPriorityPool 创建并启动 workerNum 个执行协程的优先级协程池。
+func NewPriorityPool(workerNum int) *PriorityPool {
+	if workerNum <= 0 {
+		workerNum = 1
+	}
+
+	pool := &PriorityPool{queue: make(priorityQueue, 0)}
+	pool.cond = gosync.NewCond(&pool.lock)
+
+	pool.wg.Add(workerNum)
+	for i := 0; i < workerNum; i++ {
+		go pool.work()
+	}
+
+	return pool
+}
+
+// Submit 提交任务，任务在有空闲协程时按优先级执行，协程池关闭后返回 ErrPoolClosed。
+func (p *PriorityPool) Submit(priority int, task func()) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	p.seq++
+	heap.Push(&p.queue, &priorityTask{priority: priority, seq: p.seq, run: task})
+	p.cond.Signal()
+
+	return nil
+}
+
+// Len 返回等待执行的任务数。
+func (p *PriorityPool) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.queue.Len()
+}
+
+// Close 关闭协程池，不再接收新任务，等待已提交的任务执行完成后返回。
+func (p *PriorityPool) Close() {
+	p.lock.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.lock.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *PriorityPool) work() {
+	defer p.wg.Done()
+
+	for {
+		p.lock.Lock()
+		for p.queue.Len() == 0 && !p.closed {
+			p.cond.Wait()
+		}
+
+		if p.queue.Len() == 0 {
+			p.lock.Unlock()
+			return
+		}
+
+		task := heap.Pop(&p.queue).(*priorityTask)
+		p.lock.Unlock()
+
+		task.run()
+	}
+}
+
+type priorityTask struct {
+	priority int
+	seq      uint64
+	run      func()
+}
+
+// priorityQueue 实现 heap.Interface，优先级高的在前，同优先级先提交的在前。
+type priorityQueue []*priorityTask
+
+// Len ...
+func (q priorityQueue) Len() int { return len(q) }
+
+// Less ...
+func (q priorityQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+
+	return q[i].seq < q[j].seq
+}
+
+// Swap ...
+func (q priorityQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+// Push ...
+func (q *priorityQueue) Push(x any) { *q = append(*q, x.(*priorityTask)) }
+
+// Pop ...
+func (q *priorityQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
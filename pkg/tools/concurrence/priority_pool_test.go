@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package concurrence
+
+import (
+	gosync "sync"
+	"testing"
+)
+
+func TestPriorityPool(t *testing.T) {
+	pool := NewPriorityPool(1)
+
+	// 阻塞唯一的执行协程，保证后续任务都在队列中等待
+	block := make(chan struct{})
+	started := make(chan struct{})
+	if err := pool.Submit(0, func() {
+		close(started)
+		<-block
+	}); err != nil {
+		t.Fatalf("submit block task failed, err: %v", err)
+	}
+	<-started
+
+	lock := gosync.Mutex{}
+	order := make([]string, 0)
+	submit := func(priority int, name string) {
+		if err := pool.Submit(priority, func() {
+			lock.Lock()
+			order = append(order, name)
+			lock.Unlock()
+		}); err != nil {
+			t.Fatalf("submit task %s failed, err: %v", name, err)
+		}
+	}
+
+	submit(0, "timer-1")
+	submit(10, "manual-1")
+	submit(0, "timer-2")
+	submit(10, "manual-2")
+
+	if pool.Len() != 4 {
+		t.Errorf("pool len should be 4, but got %d", pool.Len())
+	}
+
+	close(block)
+	pool.Close()
+
+	expect := []string{"manual-1", "manual-2", "timer-1", "timer-2"}
+	if len(order) != len(expect) {
+		t.Fatalf("executed task count should be %d, but got %d", len(expect), len(order))
+	}
+
+	for i := range expect {
+		if order[i] != expect[i] {
+			t.Errorf("task %d should be %s, but got %s", i, expect[i], order[i])
+		}
+	}
+
+	if err := pool.Submit(0, func() {}); err != ErrPoolClosed {
+		t.Errorf("submit to closed pool should return ErrPoolClosed, but got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cron provides a minimal parser for standard 5 fields cron expression, which is used to schedule periodic
+// jobs inside hcm without relying on a third-party cron library.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// field defines the value range of a cron field.
+type field struct {
+	name string
+	min  int
+	max  int
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 6},
+}
+
+// Schedule is a parsed cron expression, each field is stored as a bit set of allowed values.
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// domStar, dowStar record whether day of month, day of week is "*", which decides how these two fields are
+	// combined, same as standard cron: if both are restricted, matching either one is enough.
+	domStar bool
+	dowStar bool
+}
+
+// Parse parse standard 5 fields cron expression: minute hour day-of-month month day-of-week. each field supports
+// "*", single value, range "a-b", step "*/n" or "a-b/n" and list separated by ",". day of week 7 is treated as sunday.
+func Parse(spec string) (*Schedule, error) {
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("cron expression should have %d fields, but got %d", len(fields), len(parts))
+	}
+
+	bits := make([]uint64, len(fields))
+	for idx, part := range parts {
+		f := fields[idx]
+		// day of week allows 7 as sunday
+		if idx == 4 {
+			f.max = 7
+		}
+
+		val, err := parseField(part, f)
+		if err != nil {
+			return nil, err
+		}
+		bits[idx] = val
+	}
+
+	// fold sunday 7 to 0
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return &Schedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: parts[2] == "*",
+		dowStar: parts[4] == "*",
+	}, nil
+}
+
+func parseField(expr string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(expr, ",") {
+		if len(item) == 0 {
+			return 0, fmt.Errorf("%s field has empty item", f.name)
+		}
+
+		rangeExpr, step := item, 1
+		if idx := strings.Index(item, "/"); idx >= 0 {
+			var err error
+			rangeExpr = item[:idx]
+			step, err = strconv.Atoi(item[idx+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%s field step %s is invalid", f.name, item[idx+1:])
+			}
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if start, err = parseValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(bounds[1], f); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("%s field range %s is invalid", f.name, rangeExpr)
+			}
+		default:
+			val, err := parseValue(rangeExpr, f)
+			if err != nil {
+				return 0, err
+			}
+			start = val
+			// "a/n" means from a to max with step n
+			if !strings.Contains(item, "/") {
+				end = val
+			}
+		}
+
+		for val := start; val <= end; val += step {
+			bits |= 1 << uint(val)
+		}
+	}
+
+	return bits, nil
+}
+
+func parseValue(val string, f field) (int, error) {
+	num, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("%s field value %s is not a number", f.name, val)
+	}
+
+	if num < f.min || num > f.max {
+		return 0, fmt.Errorf("%s field value %d is out of range [%d, %d]", f.name, num, f.min, f.max)
+	}
+
+	return num, nil
+}
+
+// maxSearchYears limits the search of next activation time, in case of expressions which never match, e.g. 0 0 31 2 *.
+const maxSearchYears = 5
+
+// Next returns the first activation time which is strictly after t, precision is minute. zero time is returned if
+// no activation time can be found in the next few years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.dayMatch(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatch(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
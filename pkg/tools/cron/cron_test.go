@@ -0,0 +1,93 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	cases := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1,,2 * * * *",
+	}
+
+	for _, c := range cases {
+		if _, err := Parse(c); err == nil {
+			t.Errorf("parse %q expect error, but got nil", c)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	base := time.Date(2023, 11, 29, 10, 30, 15, 0, time.UTC)
+
+	cases := []struct {
+		spec   string
+		expect time.Time
+	}{
+		{"* * * * *", time.Date(2023, 11, 29, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2023, 11, 29, 10, 45, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2023, 11, 30, 10, 30, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2023, 11, 30, 2, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 3 * * 0", time.Date(2023, 12, 3, 3, 0, 0, 0, time.UTC)},
+		{"0 3 * * 7", time.Date(2023, 12, 3, 3, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 8-18/4 * * 1-5", time.Date(2023, 11, 29, 12, 0, 0, 0, time.UTC)},
+		// day of month and day of week are both restricted, matching either one is enough
+		{"0 0 15 * 5", time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Errorf("parse %q failed, err: %v", c.spec, err)
+			continue
+		}
+
+		if got := sched.Next(base); !got.Equal(c.expect) {
+			t.Errorf("next of %q, expect: %v, got: %v", c.spec, c.expect, got)
+		}
+	}
+}
+
+func TestNextNeverMatch(t *testing.T) {
+	sched, err := Parse("0 0 31 2 *")
+	if err != nil {
+		t.Errorf("parse failed, err: %v", err)
+		return
+	}
+
+	if got := sched.Next(time.Now()); !got.IsZero() {
+		t.Errorf("expect zero time, got: %v", got)
+	}
+}
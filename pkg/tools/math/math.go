@@ -106,6 +106,84 @@ func NewDecimalFromString(value string) (Decimal, error) {
 	}, nil
 }
 
+// NewDecimalFromFloat returns a new Decimal from a float64.
+func NewDecimalFromFloat(value float64) (Decimal, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}, fmt.Errorf("can't convert %v to decimal", value)
+	}
+
+	return NewDecimalFromString(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// Add returns d + d2.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	exp := d.exp
+	if d2.exp < exp {
+		exp = d2.exp
+	}
+
+	left := d.rescale(exp)
+	right := d2.rescale(exp)
+
+	return Decimal{
+		value: new(big.Int).Add(left.value, right.value),
+		exp:   exp,
+	}
+}
+
+// Mul returns d * d2.
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	left := d.rescale(d.exp)
+	right := d2.rescale(d2.exp)
+
+	return Decimal{
+		value: new(big.Int).Mul(left.value, right.value),
+		exp:   d.exp + d2.exp,
+	}
+}
+
+// Round returns d rounded to the given number of decimal places, half away from zero.
+func (d Decimal) Round(places int32) Decimal {
+	if d.exp >= -places {
+		return d.rescale(d.exp)
+	}
+
+	expScale := new(big.Int).Exp(tenInt, big.NewInt(int64(-places-d.exp)), nil)
+	value := new(big.Int)
+	if d.value != nil {
+		value.Set(d.value)
+	}
+
+	quo, rem := new(big.Int).QuoRem(value, expScale, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(expScale) >= 0 {
+		quo.Add(quo, big.NewInt(int64(value.Sign())))
+	}
+
+	return Decimal{
+		value: quo,
+		exp:   -places,
+	}
+}
+
+// Cmp compares d and d2, returns -1 if d < d2, 0 if d == d2, +1 if d > d2.
+func (d Decimal) Cmp(d2 Decimal) int {
+	exp := d.exp
+	if d2.exp < exp {
+		exp = d2.exp
+	}
+
+	return d.rescale(exp).value.Cmp(d2.rescale(exp).value)
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0, +1 if d > 0.
+func (d Decimal) Sign() int {
+	if d.value == nil {
+		return 0
+	}
+
+	return d.value.Sign()
+}
+
 // ToString returns the string representation of the decimal with the fixed point.
 func (d Decimal) ToString() string {
 	if d.exp >= 0 {
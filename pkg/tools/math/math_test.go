@@ -0,0 +1,172 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package math
+
+import (
+	"testing"
+)
+
+func TestDecimalAdd(t *testing.T) {
+	cases := []struct {
+		left   string
+		right  string
+		expect string
+	}{
+		{"1.5", "2.25", "3.75"},
+		{"0.1", "0.2", "0.3"},
+		{"10", "-0.001", "9.999"},
+		{"1.2E-3", "1", "1.0012"},
+	}
+
+	for _, c := range cases {
+		left, err := NewDecimalFromString(c.left)
+		if err != nil {
+			t.Errorf("parse %s failed, err: %v", c.left, err)
+			return
+		}
+
+		right, err := NewDecimalFromString(c.right)
+		if err != nil {
+			t.Errorf("parse %s failed, err: %v", c.right, err)
+			return
+		}
+
+		if got := left.Add(right).ToString(); got != c.expect {
+			t.Errorf("%s + %s, expect: %s, got: %s", c.left, c.right, c.expect, got)
+		}
+	}
+
+	if got := new(Decimal).Add(Decimal{}).ToString(); got != "0" {
+		t.Errorf("zero decimal add, expect: 0, got: %s", got)
+	}
+}
+
+func TestNewDecimalFromFloat(t *testing.T) {
+	d, err := NewDecimalFromFloat(12.345)
+	if err != nil {
+		t.Errorf("new decimal from float failed, err: %v", err)
+		return
+	}
+
+	if d.ToString() != "12.345" {
+		t.Errorf("new decimal from float, expect: 12.345, got: %s", d.ToString())
+	}
+}
+
+func TestDecimalMul(t *testing.T) {
+	cases := []struct {
+		left   string
+		right  string
+		expect string
+	}{
+		{"1.5", "2", "3"},
+		{"100.25", "7.1234", "714.12085"},
+		{"-0.5", "0.5", "-0.25"},
+		{"0", "6.8", "0"},
+	}
+
+	for _, c := range cases {
+		left, err := NewDecimalFromString(c.left)
+		if err != nil {
+			t.Errorf("parse %s failed, err: %v", c.left, err)
+			return
+		}
+
+		right, err := NewDecimalFromString(c.right)
+		if err != nil {
+			t.Errorf("parse %s failed, err: %v", c.right, err)
+			return
+		}
+
+		if got := left.Mul(right).ToString(); got != c.expect {
+			t.Errorf("%s * %s, expect: %s, got: %s", c.left, c.right, c.expect, got)
+		}
+	}
+
+	if got := new(Decimal).Mul(Decimal{}).Sign(); got != 0 {
+		t.Errorf("zero decimal mul, expect sign: 0, got: %d", got)
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	cases := []struct {
+		left   string
+		right  string
+		expect int
+	}{
+		{"1.5", "1.50", 0},
+		{"0.1", "0.2", -1},
+		{"10", "9.999", 1},
+		{"-1", "0", -1},
+	}
+
+	for _, c := range cases {
+		left, err := NewDecimalFromString(c.left)
+		if err != nil {
+			t.Errorf("parse %s failed, err: %v", c.left, err)
+			return
+		}
+
+		right, err := NewDecimalFromString(c.right)
+		if err != nil {
+			t.Errorf("parse %s failed, err: %v", c.right, err)
+			return
+		}
+
+		if got := left.Cmp(right); got != c.expect {
+			t.Errorf("cmp %s and %s, expect: %d, got: %d", c.left, c.right, c.expect, got)
+		}
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	cases := []struct {
+		value  string
+		places int32
+		expect string
+	}{
+		{"1.23456", 2, "1.23"},
+		{"1.235", 2, "1.24"},
+		{"-1.235", 2, "-1.24"},
+		{"0.00000000005", 10, "0.0000000001"},
+		{"0.00000000004", 10, "0"},
+		{"12.5", 10, "12.5"},
+		{"99.995", 2, "100"},
+	}
+
+	for _, c := range cases {
+		d, err := NewDecimalFromString(c.value)
+		if err != nil {
+			t.Errorf("parse %s failed, err: %v", c.value, err)
+			return
+		}
+
+		if got := d.Round(c.places).ToString(); got != c.expect {
+			t.Errorf("round %s to %d places, expect: %s, got: %s", c.value, c.places, c.expect, got)
+		}
+	}
+
+	// rounding after each multiply keeps the scale from growing.
+	cost, _ := NewDecimalFromString("1.1234567891")
+	rate, _ := NewDecimalFromString("7.1234567891")
+	if got := cost.Mul(rate).Round(10).Mul(rate).Round(10).exp; got != -10 {
+		t.Errorf("rounded multiply exp, expect: -10, got: %d", got)
+	}
+}
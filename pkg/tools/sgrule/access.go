@@ -0,0 +1,266 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgrule
+
+import (
+	"fmt"
+	"net"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// DefaultRuleID is the id of rule which represents the default allow action of group in exposure result.
+const DefaultRuleID = "default"
+
+// Group is a security group, a network security group or the firewall rules of a vpc, its rules are matched by
+// priority and the first matched rule decides, traffic matched by no rule takes the default action.
+type Group struct {
+	ID    string `json:"id"`
+	Rules []Rule `json:"-"`
+	// DefaultIngress 没有规则匹配时入站流量的动作，为空表示拒绝
+	DefaultIngress Action `json:"default_ingress,omitempty"`
+	// DefaultEgress 没有规则匹配时出站流量的动作，为空表示拒绝
+	DefaultEgress Action `json:"default_egress,omitempty"`
+}
+
+func (g Group) defaultAction(direction enumor.SecurityGroupRuleType) Action {
+	action := g.DefaultIngress
+	if direction == enumor.Egress {
+		action = g.DefaultEgress
+	}
+
+	if action != Allow {
+		return Deny
+	}
+	return action
+}
+
+// match returns the action and the rule id which decide the traffic of query.
+func (g Group) match(q Query, queryNet *net.IPNet) (Action, string) {
+	for _, rule := range orderByPriority(g.Rules) {
+		if rule.Direction != q.Direction || !rule.matchPort(q.Protocol, q.Port) || !rule.containsNet(queryNet) {
+			continue
+		}
+
+		return rule.Action, rule.ID
+	}
+
+	return g.defaultAction(q.Direction), ""
+}
+
+// effectiveAllows returns allow rules of direction which are not covered by rules matched before them, the
+// default allow action is returned as a rule with DefaultRuleID.
+func (g Group) effectiveAllows(direction enumor.SecurityGroupRuleType) []Rule {
+	ordered := make([]Rule, 0, len(g.Rules))
+	for _, rule := range orderByPriority(g.Rules) {
+		if rule.Direction == direction {
+			ordered = append(ordered, rule)
+		}
+	}
+
+	allows := make([]Rule, 0)
+	for i, rule := range ordered {
+		if rule.Action != Allow {
+			continue
+		}
+
+		if _, covered := coveredFinding(ordered[:i], rule); !covered {
+			allows = append(allows, rule)
+		}
+	}
+
+	if g.defaultAction(direction) == Allow {
+		allows = append(allows, Rule{ID: DefaultRuleID, Direction: direction, Action: Allow, Protocol: ProtocolAll,
+			Cidrs: []string{"0.0.0.0/0", "::/0"}})
+	}
+
+	return allows
+}
+
+// Layer is a filtering point of traffic, such as the security groups of an instance or the network security group
+// of a subnet. traffic passes a layer when any group of the layer allows it, and traffic is allowed only when it
+// passes all layers.
+type Layer struct {
+	Name   string  `json:"name"`
+	Groups []Group `json:"groups"`
+}
+
+// mayAllow returns whether the layer allows part of the traffic matched by rule.
+func (l Layer) mayAllow(rule Rule) bool {
+	for _, group := range l.Groups {
+		for _, allow := range group.effectiveAllows(rule.Direction) {
+			if allow.overlaps(rule) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Query is the traffic to evaluate, ingress traffic comes from the cidr, and egress traffic goes to the cidr.
+type Query struct {
+	Direction enumor.SecurityGroupRuleType `json:"direction"`
+	// Protocol 协议，支持 all、tcp、udp、icmp
+	Protocol string `json:"protocol"`
+	// Port 目的端口，仅 tcp、udp 有效
+	Port int    `json:"port"`
+	Cidr string `json:"cidr"`
+}
+
+// Verdict is the evaluation result of query.
+type Verdict struct {
+	Allowed bool `json:"allowed"`
+	// Layer 拒绝流量的层，放通时为空
+	Layer string `json:"layer,omitempty"`
+	// RuleIDs 放通时为每一层放通流量的规则，拒绝时为拒绝流量的规则，由默认动作决定的层没有规则
+	RuleIDs []string `json:"rule_ids"`
+}
+
+// Evaluate evaluate the traffic of query through layers. a rule matches the query only when its cidrs contain the
+// whole query cidr, so querying 0.0.0.0/0 answers whether the traffic from or to any address is allowed. rules
+// whose peers are references such as security groups never match, because their addresses are unknown.
+func Evaluate(layers []Layer, q Query) (Verdict, error) {
+	_, queryNet, err := net.ParseCIDR(q.Cidr)
+	if err != nil {
+		return Verdict{}, fmt.Errorf("invalid cidr: %s", q.Cidr)
+	}
+
+	verdict := Verdict{Allowed: true, RuleIDs: make([]string, 0)}
+	for _, layer := range layers {
+		allowed, denyIDs := false, make([]string, 0)
+		for _, group := range layer.Groups {
+			action, ruleID := group.match(q, queryNet)
+			if action == Allow {
+				allowed = true
+				if len(ruleID) != 0 {
+					verdict.RuleIDs = append(verdict.RuleIDs, ruleID)
+				}
+				break
+			}
+
+			if len(ruleID) != 0 {
+				denyIDs = append(denyIDs, ruleID)
+			}
+		}
+
+		if !allowed {
+			return Verdict{Layer: layer.Name, RuleIDs: denyIDs}, nil
+		}
+	}
+
+	return verdict, nil
+}
+
+// Exposure returns the allow rules of the first layer which may take effect in the direction. a rule may take
+// effect when it is not covered by the rules matched before it in its group, and each of the other layers allows
+// part of its traffic, so the traffic actually allowed may be narrower than the rule.
+func Exposure(layers []Layer, direction enumor.SecurityGroupRuleType) []Rule {
+	result := make([]Rule, 0)
+	if len(layers) == 0 {
+		return result
+	}
+
+	for _, group := range layers[0].Groups {
+		for _, rule := range group.effectiveAllows(direction) {
+			passed := true
+			for _, layer := range layers[1:] {
+				if !layer.mayAllow(rule) {
+					passed = false
+					break
+				}
+			}
+
+			if passed {
+				result = append(result, rule)
+			}
+		}
+	}
+
+	return result
+}
+
+// containsNet returns whether one of the cidrs of rule contains the whole net.
+func (r Rule) containsNet(ipNet *net.IPNet) bool {
+	for _, cidr := range r.Cidrs {
+		if _, ruleNet, err := net.ParseCIDR(cidr); err == nil && netContains(ruleNet, ipNet) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// overlaps returns whether the traffic matched by the two rules may intersect, peers referenced by id are
+// treated as overlapping with any peer, because their addresses are unknown.
+func (r Rule) overlaps(other Rule) bool {
+	if r.Direction != other.Direction {
+		return false
+	}
+
+	if r.Protocol != ProtocolAll && other.Protocol != ProtocolAll && r.Protocol != other.Protocol {
+		return false
+	}
+
+	return portsOverlap(r.Ports, other.Ports) && peersOverlap(r, other)
+}
+
+// portsOverlap returns whether two port ranges intersect, nil ranges means all ports.
+func portsOverlap(a, b []PortRange) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+
+	for _, x := range a {
+		for _, y := range b {
+			if x.From <= y.To && y.From <= x.To {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func peersOverlap(a, b Rule) bool {
+	if len(a.Refs) != 0 || len(b.Refs) != 0 {
+		return true
+	}
+
+	for _, x := range a.Cidrs {
+		_, xNet, err := net.ParseCIDR(x)
+		if err != nil {
+			continue
+		}
+
+		for _, y := range b.Cidrs {
+			_, yNet, err := net.ParseCIDR(y)
+			if err != nil {
+				continue
+			}
+
+			if netContains(xNet, yNet) || netContains(yNet, xNet) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgrule
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func testAccessLayers() []Layer {
+	sg := Group{
+		ID: "sg",
+		Rules: []Rule{
+			{ID: "deny-ssh", Direction: enumor.Ingress, Action: Deny, Protocol: ProtocolTCP,
+				Ports: []PortRange{{22, 22}}, Cidrs: []string{"0.0.0.0/0"}, Priority: 1},
+			{ID: "web", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP,
+				Ports: []PortRange{{80, 80}, {443, 443}}, Cidrs: []string{"0.0.0.0/0"}, Priority: 2},
+			{ID: "office", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolAll,
+				Cidrs: []string{"10.0.0.0/8"}, Priority: 3},
+			{ID: "redundant", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP,
+				Ports: []PortRange{{80, 80}}, Cidrs: []string{"1.1.1.1/32"}, Priority: 4},
+			{ID: "egress", Direction: enumor.Egress, Action: Allow, Protocol: ProtocolAll,
+				Cidrs: []string{"0.0.0.0/0"}, Priority: 1},
+		},
+	}
+	subnet := Group{
+		ID: "subnet",
+		Rules: []Rule{
+			{ID: "subnet-https", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP,
+				Ports: []PortRange{{443, 443}}, Cidrs: []string{"0.0.0.0/0"}, Priority: 100},
+			{ID: "subnet-vnet", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolAll,
+				Cidrs: []string{"10.0.0.0/16"}, Priority: 200},
+		},
+		DefaultEgress: Allow,
+	}
+
+	return []Layer{{Name: "nic", Groups: []Group{sg}}, {Name: "subnet", Groups: []Group{subnet}}}
+}
+
+func TestEvaluate(t *testing.T) {
+	layers := testAccessLayers()
+	cases := []struct {
+		query   Query
+		allowed bool
+		layer   string
+	}{
+		{query: Query{Direction: enumor.Ingress, Protocol: ProtocolTCP, Port: 443, Cidr: "0.0.0.0/0"}, allowed: true},
+		{query: Query{Direction: enumor.Ingress, Protocol: ProtocolTCP, Port: 80, Cidr: "0.0.0.0/0"},
+			layer: "subnet"},
+		{query: Query{Direction: enumor.Ingress, Protocol: ProtocolTCP, Port: 22, Cidr: "10.0.0.0/24"},
+			layer: "nic"},
+		{query: Query{Direction: enumor.Ingress, Protocol: ProtocolUDP, Port: 53, Cidr: "10.0.1.0/24"},
+			allowed: true},
+		{query: Query{Direction: enumor.Ingress, Protocol: ProtocolUDP, Port: 53, Cidr: "10.2.0.0/16"},
+			layer: "subnet"},
+		{query: Query{Direction: enumor.Egress, Protocol: ProtocolTCP, Port: 443, Cidr: "8.8.8.8/32"},
+			allowed: true},
+	}
+
+	for _, c := range cases {
+		verdict, err := Evaluate(layers, c.query)
+		if err != nil {
+			t.Fatalf("query %+v unexpected error: %v", c.query, err)
+		}
+
+		if verdict.Allowed != c.allowed || verdict.Layer != c.layer {
+			t.Errorf("query %+v except allowed: %v, layer: %s, got %+v", c.query, c.allowed, c.layer, verdict)
+		}
+	}
+
+	if _, err := Evaluate(layers, Query{Direction: enumor.Ingress, Cidr: "any"}); err == nil {
+		t.Errorf("invalid cidr except error")
+	}
+}
+
+func TestExposure(t *testing.T) {
+	ingress := Exposure(testAccessLayers(), enumor.Ingress)
+	ids := make(map[string]bool)
+	for _, one := range ingress {
+		ids[one.ID] = true
+	}
+
+	if len(ingress) != 2 || !ids["web"] || !ids["office"] {
+		t.Errorf("ingress exposure except web and office, got %+v", ingress)
+	}
+
+	egress := Exposure(testAccessLayers(), enumor.Egress)
+	if len(egress) != 1 || egress[0].ID != "egress" {
+		t.Errorf("egress exposure except egress, got %+v", egress)
+	}
+}
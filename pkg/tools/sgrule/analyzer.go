@@ -0,0 +1,234 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgrule
+
+import (
+	"fmt"
+	"sort"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// FindingType is the type of risky rule.
+type FindingType string
+
+const (
+	// AnyAny 对公网开放所有协议和端口的入站规则
+	AnyAny FindingType = "any_any"
+	// PublicAdminPort 对公网开放远程管理端口的入站规则
+	PublicAdminPort FindingType = "public_admin_port"
+	// PublicDBPort 对公网开放数据库端口的入站规则
+	PublicDBPort FindingType = "public_db_port"
+	// Shadowed 被优先级更高、动作相反的规则完全覆盖，永远不会生效的规则
+	Shadowed FindingType = "shadowed"
+	// Redundant 被优先级更高、动作相同的规则完全覆盖，删除后不影响效果的规则
+	Redundant FindingType = "redundant"
+)
+
+// Severity is the severity of finding.
+type Severity string
+
+const (
+	// Critical severity.
+	Critical Severity = "critical"
+	// High severity.
+	High Severity = "high"
+	// Medium severity.
+	Medium Severity = "medium"
+	// Low severity.
+	Low Severity = "low"
+)
+
+// Weight returns the score weight of severity.
+func (s Severity) Weight() int {
+	switch s {
+	case Critical:
+		return 40
+	case High:
+		return 20
+	case Medium:
+		return 5
+	case Low:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// maxScore is the max risk score.
+const maxScore = 100
+
+var (
+	// AdminPorts 远程管理端口，ssh和rdp
+	AdminPorts = []int{22, 3389}
+	// DBPorts 常见数据库端口，sqlserver、oracle、mysql、postgresql、redis、elasticsearch、memcached、mongodb
+	DBPorts = []int{1433, 1521, 3306, 5432, 6379, 9200, 11211, 27017}
+)
+
+// Finding is a risky rule found by analyzer.
+type Finding struct {
+	RuleID   string      `json:"rule_id"`
+	Type     FindingType `json:"type"`
+	Severity Severity    `json:"severity"`
+	// Port 暴露的端口，只有端口暴露类的风险有值
+	Port int `json:"port,omitempty"`
+	// RelatedRuleID 覆盖该规则的规则ID，只有遮蔽和冗余类的风险有值
+	RelatedRuleID string `json:"related_rule_id,omitempty"`
+	Message       string `json:"message"`
+}
+
+// Analyze analyze rules of one security group, rules are matched by priority, deny rules take precedence over
+// allow rules of the same priority, and rules with the same priority and action keep the input order.
+func Analyze(rules []Rule) []Finding {
+	ordered := orderByPriority(rules)
+	findings := make([]Finding, 0)
+	for i, rule := range ordered {
+		if finding, covered := coveredFinding(ordered[:i], rule); covered {
+			findings = append(findings, finding)
+			continue
+		}
+
+		if rule.Direction != enumor.Ingress || rule.Action != Allow || !rule.hasPublicCidr() {
+			continue
+		}
+
+		if rule.Protocol == ProtocolAll && rule.allPorts() {
+			findings = append(findings, Finding{
+				RuleID:   rule.ID,
+				Type:     AnyAny,
+				Severity: Critical,
+				Message:  "all protocols and ports are open to the internet",
+			})
+			continue
+		}
+
+		findings = append(findings, exposedPortFindings(ordered[:i], rule, AdminPorts, PublicAdminPort)...)
+		findings = append(findings, exposedPortFindings(ordered[:i], rule, DBPorts, PublicDBPort)...)
+	}
+
+	return findings
+}
+
+// orderByPriority returns rules in match order, deny rules take precedence over allow rules of the same priority,
+// and rules with the same priority and action keep the input order.
+func orderByPriority(rules []Rule) []Rule {
+	ordered := append(make([]Rule, 0, len(rules)), rules...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority < ordered[j].Priority
+		}
+		return ordered[i].Action == Deny && ordered[j].Action != Deny
+	})
+
+	return ordered
+}
+
+// coveredFinding check whether the rule is covered by a rule matched before it.
+func coveredFinding(before []Rule, rule Rule) (Finding, bool) {
+	for _, prev := range before {
+		// 同一条云上规则拆分出的多条规则之间不比较
+		if prev.ID == rule.ID || !prev.covers(rule) {
+			continue
+		}
+
+		if prev.Action == rule.Action {
+			return Finding{
+				RuleID:        rule.ID,
+				Type:          Redundant,
+				Severity:      Low,
+				RelatedRuleID: prev.ID,
+				Message:       fmt.Sprintf("rule is redundant, it is covered by rule %s", prev.ID),
+			}, true
+		}
+
+		// 被放通规则遮蔽的拒绝规则意味着本应拒绝的流量被放通了，比永远不生效的放通规则风险更高
+		severity := Medium
+		if rule.Action == Deny {
+			severity = High
+		}
+		return Finding{
+			RuleID:        rule.ID,
+			Type:          Shadowed,
+			Severity:      severity,
+			RelatedRuleID: prev.ID,
+			Message:       fmt.Sprintf("rule never takes effect, it is shadowed by %s rule %s", prev.Action, prev.ID),
+		}, true
+	}
+
+	return Finding{}, false
+}
+
+// exposedPortFindings returns findings of tcp ports exposed to the internet by rule, ports denied by the rules
+// matched before it are excluded.
+func exposedPortFindings(before []Rule, rule Rule, ports []int, typ FindingType) []Finding {
+	findings := make([]Finding, 0)
+	for _, port := range ports {
+		if !rule.matchPort(ProtocolTCP, port) || publicDenied(before, port) {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			RuleID:   rule.ID,
+			Type:     typ,
+			Severity: High,
+			Port:     port,
+			Message:  fmt.Sprintf("tcp port %d is open to the internet", port),
+		})
+	}
+
+	return findings
+}
+
+// publicDenied returns whether tcp port from the internet is denied by one of the rules.
+func publicDenied(rules []Rule, port int) bool {
+	for _, rule := range rules {
+		if rule.Direction == enumor.Ingress && rule.Action == Deny && rule.hasPublicCidr() &&
+			rule.matchPort(ProtocolTCP, port) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Score returns the risk score of findings, which is the sum of severity weights and no more than 100.
+func Score(findings []Finding) int {
+	score := 0
+	for _, one := range findings {
+		score += one.Severity.Weight()
+		if score >= maxScore {
+			return maxScore
+		}
+	}
+
+	return score
+}
+
+// MaxSeverity returns the highest severity of findings, returns empty if there is no finding.
+func MaxSeverity(findings []Finding) Severity {
+	var highest Severity
+	for _, one := range findings {
+		if one.Severity.Weight() > highest.Weight() {
+			highest = one.Severity
+		}
+	}
+
+	return highest
+}
@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgrule
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestParsePorts(t *testing.T) {
+	cases := []struct {
+		expr   string
+		expect []PortRange
+		isErr  bool
+	}{
+		{expr: "ALL", expect: nil},
+		{expr: "", expect: nil},
+		{expr: "0-65535", expect: nil},
+		{expr: "22", expect: []PortRange{{22, 22}}},
+		{expr: "80, 443,8000-8080", expect: []PortRange{{80, 80}, {443, 443}, {8000, 8080}}},
+		{expr: "8080-80", isErr: true},
+		{expr: "65536", isErr: true},
+		{expr: "ssh", isErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := ParsePorts(c.expr)
+		if c.isErr {
+			if err == nil {
+				t.Errorf("port %s except error got %v", c.expr, got)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("port %s unexpected error: %v", c.expr, err)
+		}
+		if len(got) != len(c.expect) {
+			t.Fatalf("port %s except %v got %v", c.expr, c.expect, got)
+		}
+		for i := range got {
+			if got[i] != c.expect[i] {
+				t.Errorf("port %s except %v got %v", c.expr, c.expect, got)
+			}
+		}
+	}
+}
+
+func TestAnalyzeExposure(t *testing.T) {
+	rules := []Rule{
+		{ID: "any", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolAll, Cidrs: []string{"0.0.0.0/0"},
+			Priority: 10},
+		{ID: "ssh", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP,
+			Ports: []PortRange{{22, 22}}, Cidrs: []string{"::/0"}, Priority: 1},
+		{ID: "db", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP,
+			Ports: []PortRange{{3306, 3310}}, Cidrs: []string{"0.0.0.0/0"}, Priority: 2},
+		{ID: "private", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP,
+			Ports: []PortRange{{22, 22}}, Cidrs: []string{"10.0.0.0/8"}, Priority: 3},
+		{ID: "egress", Direction: enumor.Egress, Action: Allow, Protocol: ProtocolAll, Cidrs: []string{"0.0.0.0/0"}},
+	}
+
+	findings := Analyze(rules)
+	expect := map[string]FindingType{"any": AnyAny, "ssh": PublicAdminPort, "db": PublicDBPort}
+	if len(findings) != len(expect) {
+		t.Fatalf("except %d findings got %+v", len(expect), findings)
+	}
+
+	for _, one := range findings {
+		if expect[one.RuleID] != one.Type {
+			t.Errorf("rule %s except %s got %s", one.RuleID, expect[one.RuleID], one.Type)
+		}
+	}
+
+	if findings[1].Port != 3306 {
+		t.Errorf("except db port 3306 got %d", findings[1].Port)
+	}
+
+	if got := Score(findings); got != 80 {
+		t.Errorf("except score 80 got %d", got)
+	}
+
+	if got := MaxSeverity(findings); got != Critical {
+		t.Errorf("except critical got %s", got)
+	}
+}
+
+func TestAnalyzeDeniedExposure(t *testing.T) {
+	rules := []Rule{
+		{ID: "allow", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP, Priority: 2,
+			Ports: []PortRange{{1, 10000}}, Cidrs: []string{"0.0.0.0/0"}},
+		{ID: "deny", Direction: enumor.Ingress, Action: Deny, Protocol: ProtocolTCP, Priority: 1,
+			Ports: []PortRange{{22, 22}, {3389, 3389}}, Cidrs: []string{"0.0.0.0/0"}},
+	}
+
+	for _, one := range Analyze(rules) {
+		if one.Type == PublicAdminPort {
+			t.Errorf("denied port %d should not be exposed", one.Port)
+		}
+	}
+}
+
+func TestAnalyzeShadowedAndRedundant(t *testing.T) {
+	rules := []Rule{
+		{ID: "deny-all", Direction: enumor.Egress, Action: Deny, Protocol: ProtocolAll, Priority: 1,
+			Cidrs: []string{"10.0.0.0/8"}},
+		{ID: "allow-web", Direction: enumor.Egress, Action: Allow, Protocol: ProtocolTCP, Priority: 2,
+			Ports: []PortRange{{80, 80}}, Cidrs: []string{"10.1.0.0/16"}},
+		{ID: "allow-sg", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP, Priority: 1,
+			Ports: []PortRange{{8000, 9000}}, Refs: []string{"sg-1"}},
+		{ID: "allow-sg-dup", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP, Priority: 2,
+			Ports: []PortRange{{8080, 8080}}, Refs: []string{"sg-1"}},
+		{ID: "allow-sg-other", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP, Priority: 2,
+			Ports: []PortRange{{8080, 8080}}, Refs: []string{"sg-2"}},
+		{ID: "deny-after", Direction: enumor.Ingress, Action: Deny, Protocol: ProtocolTCP, Priority: 3,
+			Ports: []PortRange{{8080, 8080}}, Refs: []string{"sg-1"}},
+	}
+
+	findings := Analyze(rules)
+	expect := map[string]Finding{
+		"allow-web":    {Type: Shadowed, Severity: Medium, RelatedRuleID: "deny-all"},
+		"allow-sg-dup": {Type: Redundant, Severity: Low, RelatedRuleID: "allow-sg"},
+		"deny-after":   {Type: Shadowed, Severity: High, RelatedRuleID: "allow-sg"},
+	}
+	if len(findings) != len(expect) {
+		t.Fatalf("except %d findings got %+v", len(expect), findings)
+	}
+
+	for _, one := range findings {
+		e, exists := expect[one.RuleID]
+		if !exists || e.Type != one.Type || e.Severity != one.Severity || e.RelatedRuleID != one.RelatedRuleID {
+			t.Errorf("rule %s except %+v got %+v", one.RuleID, e, one)
+		}
+	}
+}
+
+func TestScoreLimit(t *testing.T) {
+	findings := make([]Finding, 0)
+	for i := 0; i < 10; i++ {
+		findings = append(findings, Finding{Severity: High})
+	}
+
+	if got := Score(findings); got != maxScore {
+		t.Errorf("except score %d got %d", maxScore, got)
+	}
+
+	if got := Score(nil); got != 0 {
+		t.Errorf("except score 0 got %d", got)
+	}
+}
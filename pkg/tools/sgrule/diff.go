@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgrule
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FormatPorts format port ranges to port expression which can be parsed by ParsePorts, nil ranges returns "all".
+func FormatPorts(ports []PortRange) string {
+	if len(ports) == 0 {
+		return ProtocolAll
+	}
+
+	exprs := make([]string, 0, len(ports))
+	for _, one := range mergePortRanges(ports) {
+		if one.From == one.To {
+			exprs = append(exprs, strconv.Itoa(one.From))
+			continue
+		}
+		exprs = append(exprs, fmt.Sprintf("%d-%d", one.From, one.To))
+	}
+
+	return strings.Join(exprs, ",")
+}
+
+// Key returns the identity of rule, equivalent rules have the same key no matter how their ports, cidrs and refs
+// are ordered or written. priority is a part of the key only when withPriority is true, because vendors like
+// tcloud and aws do not have a configurable priority.
+func Key(r Rule, withPriority bool) string {
+	cidrs := make([]string, 0, len(r.Cidrs))
+	for _, one := range r.Cidrs {
+		if _, ipNet, err := net.ParseCIDR(one); err == nil {
+			one = ipNet.String()
+		}
+		cidrs = append(cidrs, one)
+	}
+	sort.Strings(cidrs)
+
+	refs := append(make([]string, 0, len(r.Refs)), r.Refs...)
+	sort.Strings(refs)
+
+	key := fmt.Sprintf("%s|%s|%s|%s|%s|%s", r.Direction, r.Action, r.Protocol, FormatPorts(r.Ports),
+		strings.Join(cidrs, ","), strings.Join(refs, ","))
+	if withPriority {
+		key = fmt.Sprintf("%s|%d", key, r.Priority)
+	}
+
+	return key
+}
+
+// DiffResult is the difference between the desired rules and the actual rules.
+type DiffResult struct {
+	// Added 期望存在但实际不存在，需要新增的规则
+	Added []Rule `json:"added"`
+	// Removed 实际存在但不期望存在，需要删除的规则
+	Removed []Rule `json:"removed"`
+	// Unchanged 期望与实际一致的规则数量
+	Unchanged int `json:"unchanged"`
+}
+
+// Changed returns whether there is any rule to add or remove.
+func (d DiffResult) Changed() bool {
+	return len(d.Added) != 0 || len(d.Removed) != 0
+}
+
+// Diff compares the desired rules with the actual rules by Key, duplicated actual rules are removed.
+func Diff(desired, actual []Rule, withPriority bool) DiffResult {
+	expected := make(map[string]int, len(desired))
+	for _, one := range desired {
+		expected[Key(one, withPriority)]++
+	}
+
+	result := DiffResult{Added: make([]Rule, 0), Removed: make([]Rule, 0)}
+	for _, one := range actual {
+		key := Key(one, withPriority)
+		if expected[key] == 0 {
+			result.Removed = append(result.Removed, one)
+			continue
+		}
+		expected[key]--
+		result.Unchanged++
+	}
+
+	for _, one := range desired {
+		key := Key(one, withPriority)
+		if expected[key] == 0 {
+			continue
+		}
+		expected[key]--
+		result.Added = append(result.Added, one)
+	}
+
+	return result
+}
@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgrule
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestFormatPorts(t *testing.T) {
+	cases := map[string]string{
+		"":               "all",
+		"22":             "22",
+		"443, 80":        "80,443",
+		"8000-8080,8081": "8000-8081",
+		"0-65535":        "all",
+	}
+
+	for expr, expect := range cases {
+		ports, err := ParsePorts(expr)
+		if err != nil {
+			t.Fatalf("parse %s failed, err: %v", expr, err)
+		}
+
+		if got := FormatPorts(ports); got != expect {
+			t.Errorf("format %s, expect: %s, got: %s", expr, expect, got)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ssh := Rule{ID: "1", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP,
+		Ports: []PortRange{{From: 22, To: 22}}, Cidrs: []string{"10.0.0.0/8"}, Priority: 1}
+	web := Rule{ID: "2", Direction: enumor.Ingress, Action: Allow, Protocol: ProtocolTCP,
+		Ports: []PortRange{{From: 443, To: 443}, {From: 80, To: 80}}, Cidrs: []string{"0.0.0.0/0"}, Priority: 2}
+	dns := Rule{ID: "3", Direction: enumor.Egress, Action: Allow, Protocol: ProtocolUDP,
+		Ports: []PortRange{{From: 53, To: 53}}, Cidrs: []string{"0.0.0.0/0"}, Priority: 1}
+
+	// the same rule written in another way, and a duplicated rule.
+	actualWeb := web
+	actualWeb.ID = "4"
+	actualWeb.Ports = []PortRange{{From: 80, To: 80}, {From: 443, To: 443}}
+	actualWeb.Cidrs = []string{"0.0.0.0/0"}
+	dupSSH := ssh
+	dupSSH.ID = "5"
+
+	result := Diff([]Rule{ssh, web, dns}, []Rule{ssh, actualWeb, dupSSH}, false)
+	if result.Unchanged != 2 {
+		t.Errorf("expect 2 unchanged rules, got: %d", result.Unchanged)
+	}
+
+	if len(result.Added) != 1 || result.Added[0].ID != "3" {
+		t.Errorf("expect dns rule to be added, got: %+v", result.Added)
+	}
+
+	if len(result.Removed) != 1 || result.Removed[0].ID != "5" {
+		t.Errorf("expect duplicated ssh rule to be removed, got: %+v", result.Removed)
+	}
+
+	// priority changed rule is replaced only when priority is compared.
+	moved := web
+	moved.Priority = 10
+	if Diff([]Rule{web}, []Rule{moved}, false).Changed() {
+		t.Errorf("expect no change when priority is ignored")
+	}
+
+	result = Diff([]Rule{web}, []Rule{moved}, true)
+	if len(result.Added) != 1 || len(result.Removed) != 1 {
+		t.Errorf("expect priority changed rule to be replaced, got: %+v", result)
+	}
+}
@@ -0,0 +1,304 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package sgrule defines the vendor neutral security group rule model and analyzes risky rules.
+package sgrule
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// Action is the action of rule when traffic matches it.
+type Action string
+
+const (
+	// Allow traffic matched the rule is allowed.
+	Allow Action = "allow"
+	// Deny traffic matched the rule is denied.
+	Deny Action = "deny"
+)
+
+const (
+	// ProtocolAll matches all protocols.
+	ProtocolAll = "all"
+	// ProtocolTCP is tcp protocol.
+	ProtocolTCP = "tcp"
+	// ProtocolUDP is udp protocol.
+	ProtocolUDP = "udp"
+	// ProtocolICMP is icmp protocol.
+	ProtocolICMP = "icmp"
+
+	maxPort = 65535
+)
+
+// PortRange is a closed port range.
+type PortRange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// Rule is the vendor neutral security group rule.
+type Rule struct {
+	ID        string                       `json:"id"`
+	Direction enumor.SecurityGroupRuleType `json:"direction"`
+	Action    Action                       `json:"action"`
+	// Priority 优先级，值越小越先匹配，没有优先级的厂商为0
+	Priority int64 `json:"priority"`
+	// Protocol 小写的协议名，所有协议为all
+	Protocol string `json:"protocol"`
+	// Ports 目的端口，为空表示所有端口
+	Ports []PortRange `json:"ports"`
+	// Cidrs 对端网段，入站为源地址，出站为目的地址
+	Cidrs []string `json:"cidrs"`
+	// Refs 对端的非网段引用，如安全组、地址组、网络标签，无法确定其包含的地址，只能按引用是否相同比较
+	Refs []string `json:"refs"`
+}
+
+// ParsePorts parse port expression to port ranges, supported expressions are "22", "80,443", "8000-8080" and
+// their combination, empty, "all", "*" and "-1" means all ports and returns nil.
+func ParsePorts(expr string) ([]PortRange, error) {
+	expr = strings.TrimSpace(expr)
+	switch strings.ToLower(expr) {
+	case "", "all", "*", "-1":
+		return nil, nil
+	}
+
+	ranges := make([]PortRange, 0)
+	for _, one := range strings.Split(expr, ",") {
+		one = strings.TrimSpace(one)
+		if len(one) == 0 {
+			continue
+		}
+
+		from, to := one, one
+		if idx := strings.Index(one, "-"); idx > 0 {
+			from, to = one[:idx], one[idx+1:]
+		}
+
+		fromPort, err := parsePort(from)
+		if err != nil {
+			return nil, err
+		}
+
+		toPort, err := parsePort(to)
+		if err != nil {
+			return nil, err
+		}
+
+		if fromPort > toPort {
+			return nil, fmt.Errorf("invalid port range %s", one)
+		}
+
+		if fromPort == 0 && toPort == maxPort {
+			return nil, nil
+		}
+		ranges = append(ranges, PortRange{From: fromPort, To: toPort})
+	}
+
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("invalid port expression %s", expr)
+	}
+
+	return ranges, nil
+}
+
+func parsePort(port string) (int, error) {
+	p, err := strconv.Atoi(strings.TrimSpace(port))
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %s", port)
+	}
+
+	if p < 0 || p > maxPort {
+		return 0, fmt.Errorf("port %d is out of range", p)
+	}
+
+	return p, nil
+}
+
+// IsPublicCidr returns whether cidr contains all addresses, such as 0.0.0.0/0 and ::/0.
+func IsPublicCidr(cidr string) bool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+
+	ones, _ := ipNet.Mask.Size()
+	return ones == 0
+}
+
+// allPorts returns whether the rule matches all ports.
+func (r Rule) allPorts() bool {
+	return len(r.Ports) == 0
+}
+
+// hasPublicCidr returns whether the peer of rule contains all addresses.
+func (r Rule) hasPublicCidr() bool {
+	for _, cidr := range r.Cidrs {
+		if IsPublicCidr(cidr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchPort returns whether the rule matches the port of protocol.
+func (r Rule) matchPort(protocol string, port int) bool {
+	if r.Protocol != ProtocolAll && r.Protocol != protocol {
+		return false
+	}
+
+	if r.allPorts() {
+		return true
+	}
+
+	for _, one := range r.Ports {
+		if port >= one.From && port <= one.To {
+			return true
+		}
+	}
+
+	return false
+}
+
+// covers returns whether all traffic matched by other rule is matched by this rule too.
+func (r Rule) covers(other Rule) bool {
+	if r.Direction != other.Direction {
+		return false
+	}
+
+	if r.Protocol != ProtocolAll && r.Protocol != other.Protocol {
+		return false
+	}
+
+	return portsCover(r.Ports, other.Ports) && peersCover(r, other)
+}
+
+// portsCover returns whether outer port ranges cover inner port ranges, nil ranges means all ports.
+func portsCover(outer, inner []PortRange) bool {
+	if len(outer) == 0 {
+		return true
+	}
+
+	if len(inner) == 0 {
+		return false
+	}
+
+	merged := mergePortRanges(outer)
+	for _, one := range inner {
+		covered := false
+		for _, m := range merged {
+			if one.From >= m.From && one.To <= m.To {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mergePortRanges(ranges []PortRange) []PortRange {
+	sorted := append(make([]PortRange, 0, len(ranges)), ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].From < sorted[j].From })
+
+	merged := make([]PortRange, 0, len(sorted))
+	for _, one := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && one.From <= merged[last].To+1 {
+			if one.To > merged[last].To {
+				merged[last].To = one.To
+			}
+			continue
+		}
+		merged = append(merged, one)
+	}
+
+	return merged
+}
+
+// peersCover returns whether peers of outer rule cover peers of inner rule.
+func peersCover(outer, inner Rule) bool {
+	if len(inner.Cidrs) == 0 && len(inner.Refs) == 0 {
+		return false
+	}
+
+	outerNets := make([]*net.IPNet, 0, len(outer.Cidrs))
+	for _, cidr := range outer.Cidrs {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			outerNets = append(outerNets, ipNet)
+		}
+	}
+
+	for _, cidr := range inner.Cidrs {
+		_, innerNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return false
+		}
+
+		covered := false
+		for _, outerNet := range outerNets {
+			if netContains(outerNet, innerNet) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			return false
+		}
+	}
+
+	for _, ref := range inner.Refs {
+		covered := false
+		for _, outerRef := range outer.Refs {
+			if ref == outerRef {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			return false
+		}
+	}
+
+	return true
+}
+
+// netContains returns whether outer net contains the whole inner net.
+func netContains(outer, inner *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+	if outerBits != innerBits || outerOnes > innerOnes {
+		return false
+	}
+
+	return outer.Contains(inner.IP)
+}
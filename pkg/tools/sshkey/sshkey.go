@@ -0,0 +1,95 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package sshkey provides helpers to parse and validate ssh public key which is imported by user.
+package sshkey
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// minRSABits 各云厂商导入密钥对时要求 RSA 公钥长度至少为 2048 位
+const minRSABits = 2048
+
+// PublicKey is parsed ssh public key.
+type PublicKey struct {
+	// Type public key algorithm, only ssh-rsa is supported, because tcloud and azure only support rsa key.
+	Type string
+	// Normalized public key in authorized_keys format without comment, which is pushed to cloud.
+	Normalized string
+	// Fingerprint SHA256 fingerprint of public key, same as `ssh-keygen -lf` output.
+	Fingerprint string
+}
+
+// Parse parse public key in OpenSSH authorized_keys format, e.g. "ssh-rsa AAAA... user@host".
+func Parse(publicKey string) (*PublicKey, error) {
+	publicKey = strings.TrimSpace(publicKey)
+	if len(publicKey) == 0 {
+		return nil, errors.New("public key is required")
+	}
+
+	key, _, options, rest, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse public key failed, err: %v", err)
+	}
+
+	if len(options) != 0 || len(strings.TrimSpace(string(rest))) != 0 {
+		return nil, errors.New("only one public key without options is allowed")
+	}
+
+	if key.Type() != ssh.KeyAlgoRSA {
+		return nil, fmt.Errorf("public key type %s is not supported, only %s is allowed", key.Type(), ssh.KeyAlgoRSA)
+	}
+
+	cryptoKey, ok := key.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, errors.New("public key can not convert to crypto public key")
+	}
+
+	rsaKey, ok := cryptoKey.CryptoPublicKey().(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not a valid rsa public key")
+	}
+
+	if rsaKey.N.BitLen() < minRSABits {
+		return nil, fmt.Errorf("rsa public key should be at least %d bits, but got %d", minRSABits, rsaKey.N.BitLen())
+	}
+
+	return &PublicKey{
+		Type:        key.Type(),
+		Normalized:  strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+		Fingerprint: ssh.FingerprintSHA256(key),
+	}, nil
+}
+
+// Fingerprint returns SHA256 fingerprint of any type public key in authorized_keys format, it's used to calculate
+// fingerprint of key pair returned by cloud which does not return fingerprint.
+func Fingerprint(publicKey string) (string, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(publicKey)))
+	if err != nil {
+		return "", fmt.Errorf("parse public key failed, err: %v", err)
+	}
+
+	return ssh.FingerprintSHA256(key), nil
+}
@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sshkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func authorizedKey(t *testing.T, pub interface{}) string {
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("new ssh public key failed, err: %v", err)
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+}
+
+func TestParse(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key failed, err: %v", err)
+	}
+	normalized := authorizedKey(t, &priv.PublicKey)
+
+	key, err := Parse("  " + normalized + " user@host\n")
+	if err != nil {
+		t.Fatalf("parse public key failed, err: %v", err)
+	}
+
+	if key.Type != ssh.KeyAlgoRSA {
+		t.Errorf("key type expect %s, but got %s", ssh.KeyAlgoRSA, key.Type)
+	}
+
+	if key.Normalized != normalized {
+		t.Errorf("normalized key expect %s, but got %s", normalized, key.Normalized)
+	}
+
+	if !strings.HasPrefix(key.Fingerprint, "SHA256:") {
+		t.Errorf("fingerprint expect SHA256 format, but got %s", key.Fingerprint)
+	}
+
+	fingerprint, err := Fingerprint(normalized)
+	if err != nil {
+		t.Fatalf("calculate fingerprint failed, err: %v", err)
+	}
+
+	if fingerprint != key.Fingerprint {
+		t.Errorf("fingerprint expect %s, but got %s", key.Fingerprint, fingerprint)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("generate rsa key failed, err: %v", err)
+	}
+
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ed25519 key failed, err: %v", err)
+	}
+
+	strong, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key failed, err: %v", err)
+	}
+	strongKey := authorizedKey(t, &strong.PublicKey)
+
+	cases := map[string]string{
+		"empty":        "  ",
+		"invalid":      "ssh-rsa not-base64",
+		"weak rsa":     authorizedKey(t, &weak.PublicKey),
+		"ed25519":      authorizedKey(t, edPub),
+		"with options": `command="ls" ` + strongKey,
+		"multiple":     strongKey + "\n" + strongKey,
+	}
+
+	for name, c := range cases {
+		if _, err := Parse(c); err == nil {
+			t.Errorf("case %s expect error, but got nil", name)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+/*
+    SQLVER=0012,HCMVER=v1.1.28
+
+    Notes:
+        1. 添加云账单明细表bill_item，按月存储各账号的账单明细，账单查询不再实时调用云厂商接口，同一资源不同币种的费用分别存储。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('bill_item', '0');
+
+create table if not exists `bill_item`
+(
+    `id`           varchar(64)    not null,
+    `vendor`       varchar(16)    not null,
+    `account_id`   varchar(64)    not null,
+    `bill_month`   char(7)        not null,
+    `product_code` varchar(128)   not null default '',
+    `product_name` varchar(255)   not null default '',
+    `cloud_res_id` varchar(255)   not null default '',
+    `res_name`     varchar(255)   not null default '',
+    `region`       varchar(64)    not null default '',
+    `cost`         decimal(38, 10) not null default 0,
+    `currency`     varchar(16)    not null default '',
+    `extension`    json                    default null,
+    `creator`      varchar(64)    not null default '',
+    `reviser`      varchar(64)    not null default '',
+    `created_at`   timestamp      not null default current_timestamp,
+    `updated_at`   timestamp      not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_vendor_account_id_bill_month_product_code_cloud_res_id_currency`
+        (`vendor`, `account_id`, `bill_month`, `product_code`, `cloud_res_id`, `currency`),
+    key `idx_cloud_res_id` (`cloud_res_id`),
+    key `idx_bill_month` (`bill_month`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.28' as `hcm_ver`, '0012' as `sql_ver`;
+
+commit;
@@ -0,0 +1,33 @@
+/*
+    SQLVER=0013,HCMVER=v1.1.29
+
+    Notes:
+        1. 添加汇率表exchange_rate，按月存储币种之间的汇率，用于将账单费用统一换算为报表币种。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('exchange_rate', '0');
+
+create table if not exists `exchange_rate`
+(
+    `id`            varchar(64)     not null,
+    `from_currency` varchar(16)     not null,
+    `to_currency`   varchar(16)     not null,
+    `month`         char(7)         not null,
+    `rate`          decimal(38, 10) not null,
+    `creator`       varchar(64)     not null default '',
+    `reviser`       varchar(64)     not null default '',
+    `created_at`    timestamp       not null default current_timestamp,
+    `updated_at`    timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_from_currency_to_currency_month` (`from_currency`, `to_currency`, `month`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.29' as `hcm_ver`, '0013' as `sql_ver`;
+
+commit;
@@ -0,0 +1,63 @@
+/*
+    SQLVER=0014,HCMVER=v1.1.30
+
+    Notes:
+        1. 添加预算表budget，支持按账号、业务、云厂商设置每月预算及告警阈值。
+        2. 添加预算告警表budget_alert，记录当月费用超过预算阈值的告警事件。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('budget', '0'),
+       ('budget_alert', '0');
+
+create table if not exists `budget`
+(
+    `id`          varchar(64)     not null,
+    `name`        varchar(255)    not null,
+    `scope_type`  varchar(16)     not null,
+    `scope_value` varchar(64)     not null,
+    `amount`      decimal(38, 10) not null,
+    `currency`    varchar(16)     not null,
+    `thresholds`  json            not null,
+    `memo`        varchar(255)             default '',
+    `creator`     varchar(64)     not null default '',
+    `reviser`     varchar(64)     not null default '',
+    `created_at`  timestamp       not null default current_timestamp,
+    `updated_at`  timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`),
+    key `idx_scope_type_scope_value` (`scope_type`, `scope_value`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin;
+
+create table if not exists `budget_alert`
+(
+    `id`            varchar(64)     not null,
+    `budget_id`     varchar(64)     not null,
+    `scope_type`    varchar(16)     not null,
+    `scope_value`   varchar(64)     not null,
+    `bill_month`    char(7)         not null,
+    `threshold`     int unsigned    not null,
+    `amount`        decimal(38, 10) not null,
+    `spend`         decimal(38, 10) not null,
+    `currency`      varchar(16)     not null,
+    `notify_status` varchar(16)     not null default '',
+    `notify_msg`    varchar(1024)   not null default '',
+    `creator`       varchar(64)     not null default '',
+    `reviser`       varchar(64)     not null default '',
+    `created_at`    timestamp       not null default current_timestamp,
+    `updated_at`    timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_budget_id_bill_month_threshold` (`budget_id`, `bill_month`, `threshold`),
+    key `idx_bill_month` (`bill_month`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.30' as `hcm_ver`, '0014' as `sql_ver`;
+
+commit;
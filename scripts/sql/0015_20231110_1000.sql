@@ -0,0 +1,34 @@
+/*
+    SQLVER=0015,HCMVER=v1.1.31
+
+    Notes:
+        1. 添加资源漂移事件表res_drift_event，记录资源同步时发现的云上资源新增、变更、删除及变更前后快照。
+*/
+
+start transaction;
+
+create table if not exists `res_drift_event`
+(
+    `id`           bigint(1) unsigned not null auto_increment,
+    `vendor`       varchar(16)        not null,
+    `account_id`   varchar(64)        not null,
+    `res_type`     varchar(50)        not null,
+    `res_id`       varchar(64)                 default '',
+    `cloud_res_id` varchar(255)       not null,
+    `action`       varchar(20)        not null,
+    `before_data`  json               not null,
+    `after_data`   json               not null,
+    `rid`          varchar(64)        not null default '',
+    `created_at`   timestamp          not null default current_timestamp,
+    primary key (`id`),
+    key `idx_account_id_res_type` (`account_id`, `res_type`),
+    key `idx_res_type_cloud_res_id` (`res_type`, `cloud_res_id`),
+    key `idx_res_id` (`res_id`),
+    key `idx_created_at` (`created_at`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.31' as `hcm_ver`, '0015' as `sql_ver`;
+
+commit;
@@ -0,0 +1,63 @@
+/*
+    SQLVER=0016,HCMVER=v1.1.32
+
+    Notes:
+        1. 添加同步任务表sync_task，记录每一次账号资源同步的触发方式、起止时间、状态及失败原因。
+        2. 添加同步详情表sync_detail，记录同步任务中各资源类型的同步状态、变更数量及失败原因。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('sync_task', '0'),
+       ('sync_detail', '0');
+
+create table if not exists `sync_task`
+(
+    `id`           varchar(64)   not null,
+    `vendor`       varchar(16)   not null,
+    `account_id`   varchar(64)   not null,
+    `trigger_type` varchar(16)   not null,
+    `status`       varchar(16)   not null,
+    `change_count` bigint        not null default 0,
+    `reason`       varchar(1024) not null default '',
+    `start_at`     varchar(64)   not null default '',
+    `end_at`       varchar(64)   not null default '',
+    `creator`      varchar(64)   not null default '',
+    `reviser`      varchar(64)   not null default '',
+    `created_at`   timestamp     not null default current_timestamp,
+    `updated_at`   timestamp     not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    key `idx_account_id_created_at` (`account_id`, `created_at`),
+    key `idx_status` (`status`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin;
+
+create table if not exists `sync_detail`
+(
+    `id`           varchar(64)   not null,
+    `task_id`      varchar(64)   not null,
+    `vendor`       varchar(16)   not null,
+    `account_id`   varchar(64)   not null,
+    `res_type`     varchar(64)   not null,
+    `status`       varchar(16)   not null,
+    `change_count` bigint        not null default 0,
+    `reason`       varchar(1024) not null default '',
+    `start_at`     varchar(64)   not null default '',
+    `end_at`       varchar(64)   not null default '',
+    `creator`      varchar(64)   not null default '',
+    `reviser`      varchar(64)   not null default '',
+    `created_at`   timestamp     not null default current_timestamp,
+    `updated_at`   timestamp     not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    key `idx_task_id` (`task_id`),
+    key `idx_account_id_res_type` (`account_id`, `res_type`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.32' as `hcm_ver`, '0016' as `sql_ver`;
+
+commit;
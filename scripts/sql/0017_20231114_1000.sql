@@ -0,0 +1,32 @@
+/*
+    SQLVER=0017,HCMVER=v1.1.33
+
+    Notes:
+        1. 添加同步水位表sync_watermark，记录每个账号增量同步已处理到的云审计事件时间。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('sync_watermark', '0');
+
+create table if not exists `sync_watermark`
+(
+    `id`         varchar(64) not null,
+    `vendor`     varchar(16) not null,
+    `account_id` varchar(64) not null,
+    `watermark`  varchar(64) not null,
+    `creator`    varchar(64) not null default '',
+    `reviser`    varchar(64) not null default '',
+    `created_at` timestamp   not null default current_timestamp,
+    `updated_at` timestamp   not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_account_id` (`account_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.33' as `hcm_ver`, '0017' as `sql_ver`;
+
+commit;
@@ -0,0 +1,95 @@
+/*
+    SQLVER=0018,HCMVER=v1.1.34
+
+    Notes:
+        1. 添加负载均衡表load_balancer、负载均衡监听器表load_balancer_listener、负载均衡后端目标表load_balancer_target。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('load_balancer', '0'),
+       ('load_balancer_listener', '0'),
+       ('load_balancer_target', '0');
+
+create table if not exists `load_balancer`
+(
+    `id`                     varchar(64)  not null,
+    `vendor`                 varchar(16)  not null,
+    `account_id`             varchar(64)  not null,
+    `cloud_id`               varchar(255) not null,
+    `name`                   varchar(255) not null default '',
+    `bk_biz_id`              bigint(1)    not null default -1,
+    `region`                 varchar(64)  not null default '',
+    `zones`                  json         not null,
+    `lb_type`                varchar(32)  not null default '',
+    `status`                 varchar(64)  not null default '',
+    `cloud_vpc_id`           varchar(255) not null default '',
+    `vpc_id`                 varchar(64)  not null default '',
+    `domain`                 varchar(255) not null default '',
+    `public_ipv4_addresses`  json                  default null,
+    `private_ipv4_addresses` json                  default null,
+    `memo`                   varchar(255)          default '',
+    `cloud_created_time`     varchar(64)           default '',
+    `extension`              json         not null,
+    `creator`                varchar(64)  not null,
+    `reviser`                varchar(64)  not null,
+    `created_at`             timestamp    not null default current_timestamp,
+    `updated_at`             timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_cloud_id_vendor` (`cloud_id`, `vendor`)
+) engine = innodb
+  default charset = utf8mb4;
+
+create table if not exists `load_balancer_listener`
+(
+    `id`          varchar(64)  not null,
+    `vendor`      varchar(16)  not null,
+    `account_id`  varchar(64)  not null,
+    `cloud_id`    varchar(255) not null,
+    `name`        varchar(255) not null default '',
+    `lb_id`       varchar(64)  not null,
+    `cloud_lb_id` varchar(255) not null,
+    `protocol`    varchar(32)  not null default '',
+    `port`        bigint(1)    not null default 0,
+    `end_port`    bigint(1)    not null default 0,
+    `extension`   json         not null,
+    `creator`     varchar(64)  not null,
+    `reviser`     varchar(64)  not null,
+    `created_at`  timestamp    not null default current_timestamp,
+    `updated_at`  timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_lb_id_cloud_id` (`lb_id`, `cloud_id`),
+    key `idx_cloud_lb_id` (`cloud_lb_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+create table if not exists `load_balancer_target`
+(
+    `id`                varchar(64)  not null,
+    `vendor`            varchar(16)  not null,
+    `account_id`        varchar(64)  not null,
+    `cloud_id`          varchar(512) not null,
+    `lb_id`             varchar(64)  not null,
+    `listener_id`       varchar(64)  not null,
+    `cloud_listener_id` varchar(255) not null,
+    `target_type`       varchar(32)  not null,
+    `cloud_target_id`   varchar(512) not null default '',
+    `ip`                varchar(64)  not null default '',
+    `port`              bigint(1)    not null default 0,
+    `weight`            bigint(1)    not null default 0,
+    `extension`         json         not null,
+    `creator`           varchar(64)  not null,
+    `reviser`           varchar(64)  not null,
+    `created_at`        timestamp    not null default current_timestamp,
+    `updated_at`        timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_listener_id_cloud_id` (`listener_id`, `cloud_id`),
+    key `idx_lb_id` (`lb_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.34' as `hcm_ver`, '0018' as `sql_ver`;
+
+commit;
@@ -0,0 +1,49 @@
+/*
+    SQLVER=0019,HCMVER=v1.1.35
+
+    Notes:
+        1. 添加NAT网关表nat_gateway。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('nat_gateway', '0');
+
+create table if not exists `nat_gateway`
+(
+    `id`                   varchar(64)  not null,
+    `vendor`               varchar(16)  not null,
+    `account_id`           varchar(64)  not null,
+    `cloud_id`             varchar(255) not null,
+    `name`                 varchar(255) not null default '',
+    `bk_biz_id`            bigint(1)    not null default -1,
+    `region`               varchar(64)  not null default '',
+    `zone`                 varchar(64)  not null default '',
+    `nat_type`             varchar(32)  not null default '',
+    `status`               varchar(64)  not null default '',
+    `cloud_vpc_id`         varchar(255) not null default '',
+    `vpc_id`               varchar(64)  not null default '',
+    `cloud_subnet_id`      varchar(255) not null default '',
+    `subnet_id`            varchar(64)  not null default '',
+    `public_ip_addresses`  json                  default null,
+    `private_ip_addresses` json                  default null,
+    `cloud_eip_ids`        json                  default null,
+    `eip_ids`              json                  default null,
+    `memo`                 varchar(255)          default '',
+    `cloud_created_time`   varchar(64)           default '',
+    `extension`            json         not null,
+    `creator`              varchar(64)  not null,
+    `reviser`              varchar(64)  not null,
+    `created_at`           timestamp    not null default current_timestamp,
+    `updated_at`           timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_cloud_id_vendor` (`cloud_id`, `vendor`),
+    key `idx_vpc_id` (`vpc_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.35' as `hcm_ver`, '0019' as `sql_ver`;
+
+commit;
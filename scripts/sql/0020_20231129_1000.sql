@@ -0,0 +1,69 @@
+/*
+    SQLVER=0020,HCMVER=v1.1.36
+
+    Notes:
+        1. 添加云盘快照表snapshot。
+        2. 添加快照策略表snapshot_policy。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('snapshot', '0'),
+       ('snapshot_policy', '0');
+
+create table if not exists `snapshot`
+(
+    `id`                 varchar(64)     not null,
+    `vendor`             varchar(16)     not null,
+    `account_id`         varchar(64)     not null,
+    `cloud_id`           varchar(255)    not null,
+    `name`               varchar(255)    not null default '',
+    `bk_biz_id`          bigint(1)       not null default -1,
+    `region`             varchar(64)     not null default '',
+    `zone`               varchar(64)     not null default '',
+    `status`             varchar(64)     not null default '',
+    `cloud_disk_id`      varchar(255)    not null default '',
+    `disk_id`            varchar(64)     not null default '',
+    `disk_size`          bigint unsigned not null default 0,
+    `encrypted`          boolean         not null default false,
+    `snapshot_policy_id` varchar(64)     not null default '',
+    `memo`               varchar(255)             default '',
+    `cloud_created_time` varchar(64)              default '',
+    `extension`          json            not null,
+    `creator`            varchar(64)     not null,
+    `reviser`            varchar(64)     not null,
+    `created_at`         timestamp       not null default current_timestamp,
+    `updated_at`         timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_cloud_id_vendor` (`cloud_id`, `vendor`),
+    key `idx_disk_id` (`disk_id`),
+    key `idx_snapshot_policy_id` (`snapshot_policy_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+create table if not exists `snapshot_policy`
+(
+    `id`              varchar(64)     not null,
+    `name`            varchar(255)    not null,
+    `bk_biz_id`       bigint(1)       not null default -1,
+    `scope`           varchar(16)     not null,
+    `disk_ids`        json                     default null,
+    `schedule`        varchar(64)     not null,
+    `retention_count` bigint unsigned not null,
+    `enabled`         boolean         not null default true,
+    `last_run_at`     varchar(64)     not null default '',
+    `memo`            varchar(255)             default '',
+    `creator`         varchar(64)     not null,
+    `reviser`         varchar(64)     not null,
+    `created_at`      timestamp       not null default current_timestamp,
+    `updated_at`      timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_bk_biz_id_name` (`bk_biz_id`, `name`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.36' as `hcm_ver`, '0020' as `sql_ver`;
+
+commit;
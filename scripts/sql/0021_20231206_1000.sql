@@ -0,0 +1,57 @@
+/*
+    SQLVER=0021,HCMVER=v1.1.37
+
+    Notes:
+        1. 添加 HCM 侧管理的 SSH 密钥对表key_pair。
+        2. 添加云上密钥对表cloud_key_pair。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('key_pair', '0'),
+       ('cloud_key_pair', '0');
+
+create table if not exists `key_pair`
+(
+    `id`          varchar(64)  not null,
+    `name`        varchar(255) not null,
+    `bk_biz_id`   bigint(1)    not null default -1,
+    `public_key`  text         not null,
+    `fingerprint` varchar(255) not null,
+    `memo`        varchar(255)          default '',
+    `creator`     varchar(64)  not null,
+    `reviser`     varchar(64)  not null,
+    `created_at`  timestamp    not null default current_timestamp,
+    `updated_at`  timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_bk_biz_id_name` (`bk_biz_id`, `name`)
+) engine = innodb
+  default charset = utf8mb4;
+
+create table if not exists `cloud_key_pair`
+(
+    `id`                 varchar(64)  not null,
+    `vendor`             varchar(16)  not null,
+    `account_id`         varchar(64)  not null,
+    `region`             varchar(64)  not null default '',
+    `cloud_id`           varchar(255) not null,
+    `name`               varchar(255) not null default '',
+    `fingerprint`        varchar(255) not null default '',
+    `key_pair_id`        varchar(64)  not null default '',
+    `bk_biz_id`          bigint(1)    not null default -1,
+    `cloud_created_time` varchar(64)           default '',
+    `creator`            varchar(64)  not null,
+    `reviser`            varchar(64)  not null,
+    `created_at`         timestamp    not null default current_timestamp,
+    `updated_at`         timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_vendor_account_id_region_cloud_id` (`vendor`, `account_id`, `region`, `cloud_id`),
+    key `idx_key_pair_id` (`key_pair_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.37' as `hcm_ver`, '0021' as `sql_ver`;
+
+commit;
@@ -0,0 +1,42 @@
+/*
+    SQLVER=0022,HCMVER=v1.1.38
+
+    Notes:
+        1. 添加对象存储桶表bucket。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('bucket', '0');
+
+create table if not exists `bucket`
+(
+    `id`                    varchar(64)  not null,
+    `vendor`                varchar(16)  not null,
+    `account_id`            varchar(64)  not null,
+    `region`                varchar(64)  not null default '',
+    `cloud_id`              varchar(255) not null,
+    `name`                  varchar(255) not null default '',
+    `bk_biz_id`             bigint(1)    not null default -1,
+    `encrypted`             boolean      not null default false,
+    `encryption_type`       varchar(64)  not null default '',
+    `versioning_enabled`    boolean      not null default false,
+    `public_read`           boolean      not null default false,
+    `public_write`          boolean      not null default false,
+    `public_access_reasons` json         not null,
+    `cloud_created_time`    varchar(64)           default '',
+    `creator`               varchar(64)  not null,
+    `reviser`               varchar(64)  not null,
+    `created_at`            timestamp    not null default current_timestamp,
+    `updated_at`            timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_vendor_account_id_cloud_id` (`vendor`, `account_id`, `cloud_id`),
+    key `idx_public_read` (`public_read`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.38' as `hcm_ver`, '0022' as `sql_ver`;
+
+commit;
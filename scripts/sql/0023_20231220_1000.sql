@@ -0,0 +1,50 @@
+/*
+    SQLVER=0023,HCMVER=v1.1.39
+
+    Notes:
+        1. 添加VPC对等连接表vpc_peering，记录对等连接、云联网及中转网关的VPC关联实例。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('vpc_peering', '0');
+
+create table if not exists `vpc_peering`
+(
+    `id`                    varchar(64)  not null,
+    `vendor`                varchar(16)  not null,
+    `account_id`            varchar(64)  not null,
+    `region`                varchar(64)  not null default '',
+    `cloud_id`              varchar(255) not null,
+    `name`                  varchar(255) not null default '',
+    `type`                  varchar(64)  not null,
+    `status`                varchar(32)  not null default '',
+    `cloud_status`          varchar(64)  not null default '',
+    `cloud_gateway_id`      varchar(255) not null default '',
+    `local_vpc_id`          varchar(64)  not null default '',
+    `local_cloud_vpc_id`    varchar(255) not null default '',
+    `local_cidrs`           json         not null,
+    `peer_vpc_id`           varchar(64)  not null default '',
+    `peer_cloud_vpc_id`     varchar(255) not null default '',
+    `peer_cloud_account_id` varchar(255) not null default '',
+    `peer_region`           varchar(64)  not null default '',
+    `peer_cidrs`            json         not null,
+    `bk_biz_id`             bigint(1)    not null default -1,
+    `memo`                  varchar(255)          default '',
+    `cloud_created_time`    varchar(64)           default '',
+    `creator`               varchar(64)  not null,
+    `reviser`               varchar(64)  not null,
+    `created_at`            timestamp    not null default current_timestamp,
+    `updated_at`            timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_vendor_account_id_cloud_id` (`vendor`, `account_id`, `cloud_id`),
+    key `idx_local_cloud_vpc_id` (`local_cloud_vpc_id`),
+    key `idx_peer_cloud_vpc_id` (`peer_cloud_vpc_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.39' as `hcm_ver`, '0023' as `sql_ver`;
+
+commit;
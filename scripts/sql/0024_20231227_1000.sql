@@ -0,0 +1,19 @@
+/*
+    SQLVER=0024,HCMVER=v1.1.40
+
+    Notes:
+        1. 镜像表image添加账号ID字段account_id，用于记录自定义镜像所属账号或共享镜像被共享的账号，公共镜像该字段为空。
+*/
+
+start transaction;
+
+alter table image
+    add column `account_id` varchar(64) not null default '' after `vendor`;
+
+alter table image
+    add index `idx_vendor_account_id` (`vendor`, `account_id`);
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.40' as `hcm_ver`, '0024' as `sql_ver`;
+
+commit;
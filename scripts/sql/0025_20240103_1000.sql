@@ -0,0 +1,58 @@
+/*
+    SQLVER=0025,HCMVER=v1.1.41
+
+    Notes:
+        1. 添加IPAM地址池表cidr_pool，按业务、地域、环境规划VPC可使用的地址空间。
+        2. 添加IPAM网段分配表cidr_allocation，记录从地址池中分配给VPC的网段。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('cidr_pool', '0'),
+       ('cidr_allocation', '0');
+
+create table if not exists `cidr_pool`
+(
+    `id`          varchar(64)  not null,
+    `name`        varchar(255) not null,
+    `bk_biz_id`   bigint(1)    not null default -1,
+    `region`      varchar(64)  not null default '',
+    `environment` varchar(32)  not null default '',
+    `ipv4_cidr`   varchar(64)  not null,
+    `memo`        varchar(255)          default '',
+    `creator`     varchar(64)  not null,
+    `reviser`     varchar(64)  not null,
+    `created_at`  timestamp    not null default current_timestamp,
+    `updated_at`  timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_ipv4_cidr` (`ipv4_cidr`),
+    key `idx_bk_biz_id_region_environment` (`bk_biz_id`, `region`, `environment`)
+) engine = innodb
+  default charset = utf8mb4;
+
+create table if not exists `cidr_allocation`
+(
+    `id`         varchar(64)  not null,
+    `pool_id`    varchar(64)  not null,
+    `cidr`       varchar(64)  not null,
+    `status`     varchar(16)  not null,
+    `vendor`     varchar(16)  not null default '',
+    `account_id` varchar(64)  not null default '',
+    `region`     varchar(64)  not null default '',
+    `vpc_id`     varchar(64)  not null default '',
+    `memo`       varchar(255)          default '',
+    `creator`    varchar(64)  not null,
+    `reviser`    varchar(64)  not null,
+    `created_at` timestamp    not null default current_timestamp,
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_pool_id_cidr` (`pool_id`, `cidr`),
+    key `idx_vpc_id` (`vpc_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.41' as `hcm_ver`, '0025' as `sql_ver`;
+
+commit;
@@ -0,0 +1,55 @@
+/*
+    SQLVER=0026,HCMVER=v1.1.42
+
+    Notes:
+        1. 添加安全组模版表security_group_template，保存与云厂商无关的安全组规则。
+        2. 添加安全组模版关联表security_group_template_rel，记录模版应用到的安全组或gcp防火墙规则所在的VPC。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('security_group_template', '0'),
+       ('security_group_template_rel', '0');
+
+create table if not exists `security_group_template`
+(
+    `id`         varchar(64)     not null,
+    `name`       varchar(255)    not null,
+    `rules`      json            not null,
+    `version`    bigint unsigned not null default 1,
+    `memo`       varchar(255)             default '',
+    `creator`    varchar(64)     not null,
+    `reviser`    varchar(64)     not null,
+    `created_at` timestamp       not null default current_timestamp,
+    `updated_at` timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`)
+) engine = innodb
+  default charset = utf8mb4;
+
+create table if not exists `security_group_template_rel`
+(
+    `id`          varchar(64)     not null,
+    `template_id` varchar(64)     not null,
+    `vendor`      varchar(16)     not null,
+    `account_id`  varchar(64)     not null,
+    `region`      varchar(64)     not null default '',
+    `res_type`    varchar(64)     not null,
+    `res_id`      varchar(64)     not null,
+    `target_tag`  varchar(64)     not null default '',
+    `version`     bigint unsigned not null default 0,
+    `creator`     varchar(64)     not null,
+    `reviser`     varchar(64)     not null,
+    `created_at`  timestamp       not null default current_timestamp,
+    `updated_at`  timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_res_type_res_id_target_tag` (`res_type`, `res_id`, `target_tag`),
+    key `idx_template_id` (`template_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.42' as `hcm_ver`, '0026' as `sql_ver`;
+
+commit;
@@ -0,0 +1,40 @@
+/*
+    SQLVER=0027,HCMVER=v1.1.43
+
+    Notes:
+        1. 添加云资源标签表resource_tag，统一保存主机、硬盘、VPC、子网、弹性IP、安全组的标签。
+        2. 删除未使用的安全组标签表security_group_tag，安全组的标签由resource_tag保存，通过同步从云上重新获取。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('resource_tag', '0');
+
+create table if not exists `resource_tag`
+(
+    `id`           varchar(64)  not null,
+    `vendor`       varchar(16)  not null,
+    `account_id`   varchar(64)  not null,
+    `res_type`     varchar(64)  not null,
+    `res_id`       varchar(64)  not null,
+    `res_cloud_id` varchar(255) not null,
+    `tag_key`      varchar(255) not null,
+    `tag_value`    varchar(255) not null default '',
+    `creator`      varchar(64)  not null,
+    `reviser`      varchar(64)  not null,
+    `created_at`   timestamp    not null default current_timestamp,
+    `updated_at`   timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_res_type_res_id_tag_key` (`res_type`, `res_id`, `tag_key`),
+    key `idx_res_cloud_id` (`res_cloud_id`),
+    key `idx_res_type_tag_key_tag_value` (`res_type`, `tag_key`, `tag_value`)
+) engine = innodb
+  default charset = utf8mb4;
+
+drop table if exists `security_group_tag`;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.43' as `hcm_ver`, '0027' as `sql_ver`;
+
+commit;
@@ -0,0 +1,57 @@
+/*
+    SQLVER=0028,HCMVER=v1.1.44
+
+    Notes:
+        1. 添加openstack地域表openstack_region，openstack地域由各自的部署定义，按账号保存。
+        2. 添加openstack安全组规则表openstack_security_group_rule。
+*/
+
+start transaction;
+
+insert into id_generator(`resource`, `max_id`)
+values ('openstack_region', '0'),
+       ('openstack_security_group_rule', '0');
+
+create table if not exists `openstack_region`
+(
+    `id`         varchar(64) not null,
+    `account_id` varchar(64) not null,
+    `region_id`  varchar(64) not null,
+    `creator`    varchar(64) not null,
+    `reviser`    varchar(64) not null,
+    `created_at` timestamp   not null default current_timestamp,
+    `updated_at` timestamp   not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_account_id_region_id` (`account_id`, `region_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+create table if not exists `openstack_security_group_rule`
+(
+    `id`                      varchar(64)  not null,
+    `cloud_id`                varchar(255) not null,
+    `type`                    varchar(20)  not null,
+    `cloud_security_group_id` varchar(255) not null,
+    `security_group_id`       varchar(64)  not null,
+    `account_id`              varchar(64)  not null,
+    `region`                  varchar(64)  not null,
+    `cloud_project_id`        varchar(255)          default '',
+    `memo`                    varchar(255)          default '',
+    `protocol`                varchar(32)           default '',
+    `ethertype`               varchar(10)           default '',
+    `cloud_remote_group_id`   varchar(255)          default '',
+    `remote_ip_prefix`        varchar(255)          default '',
+    `port`                    varchar(255)          default '',
+    `creator`                 varchar(64)  not null,
+    `reviser`                 varchar(64)  not null,
+    `created_at`              timestamp    not null default current_timestamp,
+    `updated_at`              timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_cloud_id` (`cloud_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.1.44' as `hcm_ver`, '0028' as `sql_ver`;
+
+commit;